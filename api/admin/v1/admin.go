@@ -57,6 +57,9 @@ func AdminMethodScopes() map[string]AdminScope {
 		svc + "FirewallRotateCA":        ScopeAdmin,
 		svc + "FirewallSyncRoutes":      ScopeAdmin,
 		svc + "FirewallResolveHostname": ScopeAdmin,
+		svc + "FirewallAudit":           ScopeAdmin,
+		svc + "SetLogLevel":             ScopeAdmin,
+		svc + "NotifyAgent":             ScopeAdmin,
 		svc + "ListAgents":              ScopeAdmin,
 	}
 }
@@ -1560,15 +1560,220 @@ func (x *FirewallResolveHostnameResult) GetAddresses() []string {
 	return nil
 }
 
+type FirewallAuditRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// project is the agent's clawker project slug (empty for 2-segment
+	// naming); agent_name is the short agent name. Together they match the
+	// dev.clawker.project / dev.clawker.agent container labels.
+	Project       string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	AgentName     string `protobuf:"bytes,2,opt,name=agent_name,json=agentName,proto3" json:"agent_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FirewallAuditRequest) Reset() {
+	*x = FirewallAuditRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirewallAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirewallAuditRequest) ProtoMessage() {}
+
+func (x *FirewallAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirewallAuditRequest.ProtoReflect.Descriptor instead.
+func (*FirewallAuditRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FirewallAuditRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *FirewallAuditRequest) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+type FirewallAuditEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// domain is the queried name, lowercased, without the trailing dot.
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// action is the firewall verdict for these lookups: "allowed", or
+	// "denied" when CoreDNS answered NXDOMAIN (no allow rule covers the
+	// name, or a deny rule does).
+	Action        string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Count         uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	FirstSeenUnix int64  `protobuf:"varint,4,opt,name=first_seen_unix,json=firstSeenUnix,proto3" json:"first_seen_unix,omitempty"`
+	LastSeenUnix  int64  `protobuf:"varint,5,opt,name=last_seen_unix,json=lastSeenUnix,proto3" json:"last_seen_unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FirewallAuditEntry) Reset() {
+	*x = FirewallAuditEntry{}
+	mi := &file_admin_v1_admin_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirewallAuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirewallAuditEntry) ProtoMessage() {}
+
+func (x *FirewallAuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirewallAuditEntry.ProtoReflect.Descriptor instead.
+func (*FirewallAuditEntry) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FirewallAuditEntry) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *FirewallAuditEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *FirewallAuditEntry) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *FirewallAuditEntry) GetFirstSeenUnix() int64 {
+	if x != nil {
+		return x.FirstSeenUnix
+	}
+	return 0
+}
+
+func (x *FirewallAuditEntry) GetLastSeenUnix() int64 {
+	if x != nil {
+		return x.LastSeenUnix
+	}
+	return 0
+}
+
+type FirewallAuditResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// entries are sorted denied-first, then by count (descending), then by
+	// domain.
+	Entries []*FirewallAuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// dropped counts lookups of domains that were not recorded because the
+	// agent's log reached its per-agent entry cap.
+	Dropped uint64 `protobuf:"varint,2,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	// since_unix is when the CP started recording.
+	SinceUnix     int64 `protobuf:"varint,3,opt,name=since_unix,json=sinceUnix,proto3" json:"since_unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FirewallAuditResult) Reset() {
+	*x = FirewallAuditResult{}
+	mi := &file_admin_v1_admin_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FirewallAuditResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirewallAuditResult) ProtoMessage() {}
+
+func (x *FirewallAuditResult) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirewallAuditResult.ProtoReflect.Descriptor instead.
+func (*FirewallAuditResult) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *FirewallAuditResult) GetEntries() []*FirewallAuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *FirewallAuditResult) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *FirewallAuditResult) GetSinceUnix() int64 {
+	if x != nil {
+		return x.SinceUnix
+	}
+	return 0
+}
+
 type ListAgentsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// project selects the agents registered under this clawker project slug
+	// (empty selects global-scope agents, 2-segment naming).
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// all_projects ignores project and returns every registered agent.
+	AllProjects   bool `protobuf:"varint,2,opt,name=all_projects,json=allProjects,proto3" json:"all_projects,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListAgentsRequest) Reset() {
 	*x = ListAgentsRequest{}
-	mi := &file_admin_v1_admin_proto_msgTypes[29]
+	mi := &file_admin_v1_admin_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1580,7 +1785,7 @@ func (x *ListAgentsRequest) String() string {
 func (*ListAgentsRequest) ProtoMessage() {}
 
 func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_v1_admin_proto_msgTypes[29]
+	mi := &file_admin_v1_admin_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1593,7 +1798,21 @@ func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
 func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
-	return file_admin_v1_admin_proto_rawDescGZIP(), []int{29}
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListAgentsRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *ListAgentsRequest) GetAllProjects() bool {
+	if x != nil {
+		return x.AllProjects
+	}
+	return false
 }
 
 type ListAgentsResult struct {
@@ -1605,7 +1824,7 @@ type ListAgentsResult struct {
 
 func (x *ListAgentsResult) Reset() {
 	*x = ListAgentsResult{}
-	mi := &file_admin_v1_admin_proto_msgTypes[30]
+	mi := &file_admin_v1_admin_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1617,7 +1836,7 @@ func (x *ListAgentsResult) String() string {
 func (*ListAgentsResult) ProtoMessage() {}
 
 func (x *ListAgentsResult) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_v1_admin_proto_msgTypes[30]
+	mi := &file_admin_v1_admin_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1630,7 +1849,7 @@ func (x *ListAgentsResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAgentsResult.ProtoReflect.Descriptor instead.
 func (*ListAgentsResult) Descriptor() ([]byte, []int) {
-	return file_admin_v1_admin_proto_rawDescGZIP(), []int{30}
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ListAgentsResult) GetAgents() []*Agent {
@@ -1640,6 +1859,221 @@ func (x *ListAgentsResult) GetAgents() []*Agent {
 	return nil
 }
 
+type SetLogLevelRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// level is one of "debug", "info", "warn", "error".
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// container_id selects an agent's clawkerd. Empty targets the control
+	// plane.
+	ContainerId string `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	// project is the clawker project slug the caller expects the agent to be
+	// registered under (empty for 2-segment naming). CP refuses the change
+	// with PERMISSION_DENIED when the agent belongs to another project, so a
+	// command run in one project never drives another project's agents.
+	// Ignored when container_id is empty.
+	Project       string `protobuf:"bytes,3,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SetLogLevelRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *SetLogLevelRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+type SetLogLevelResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// previous_level is the control plane's level before the change. Empty
+	// when the change went to an agent — clawkerd does not report it.
+	PreviousLevel string `protobuf:"bytes,1,opt,name=previous_level,json=previousLevel,proto3" json:"previous_level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelResult) Reset() {
+	*x = SetLogLevelResult{}
+	mi := &file_admin_v1_admin_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelResult) ProtoMessage() {}
+
+func (x *SetLogLevelResult) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelResult.ProtoReflect.Descriptor instead.
+func (*SetLogLevelResult) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetLogLevelResult) GetPreviousLevel() string {
+	if x != nil {
+		return x.PreviousLevel
+	}
+	return ""
+}
+
+type NotifyAgentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// container_id selects the agent whose console shows the message.
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	// project is the clawker project slug the caller expects the agent to be
+	// registered under; CP refuses with PERMISSION_DENIED otherwise, as for
+	// SetLogLevelRequest.project.
+	Project string `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	// message is the text clawkerd writes to the agent's console.
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyAgentRequest) Reset() {
+	*x = NotifyAgentRequest{}
+	mi := &file_admin_v1_admin_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyAgentRequest) ProtoMessage() {}
+
+func (x *NotifyAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyAgentRequest.ProtoReflect.Descriptor instead.
+func (*NotifyAgentRequest) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *NotifyAgentRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *NotifyAgentRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *NotifyAgentRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type NotifyAgentResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyAgentResult) Reset() {
+	*x = NotifyAgentResult{}
+	mi := &file_admin_v1_admin_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyAgentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyAgentResult) ProtoMessage() {}
+
+func (x *NotifyAgentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyAgentResult.ProtoReflect.Descriptor instead.
+func (*NotifyAgentResult) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{37}
+}
+
 type GetSystemTimeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -1648,7 +2082,7 @@ type GetSystemTimeRequest struct {
 
 func (x *GetSystemTimeRequest) Reset() {
 	*x = GetSystemTimeRequest{}
-	mi := &file_admin_v1_admin_proto_msgTypes[31]
+	mi := &file_admin_v1_admin_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1660,7 +2094,7 @@ func (x *GetSystemTimeRequest) String() string {
 func (*GetSystemTimeRequest) ProtoMessage() {}
 
 func (x *GetSystemTimeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_v1_admin_proto_msgTypes[31]
+	mi := &file_admin_v1_admin_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1673,7 +2107,7 @@ func (x *GetSystemTimeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSystemTimeRequest.ProtoReflect.Descriptor instead.
 func (*GetSystemTimeRequest) Descriptor() ([]byte, []int) {
-	return file_admin_v1_admin_proto_rawDescGZIP(), []int{31}
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{38}
 }
 
 type GetSystemTimeResult struct {
@@ -1692,7 +2126,7 @@ type GetSystemTimeResult struct {
 
 func (x *GetSystemTimeResult) Reset() {
 	*x = GetSystemTimeResult{}
-	mi := &file_admin_v1_admin_proto_msgTypes[32]
+	mi := &file_admin_v1_admin_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1704,7 +2138,7 @@ func (x *GetSystemTimeResult) String() string {
 func (*GetSystemTimeResult) ProtoMessage() {}
 
 func (x *GetSystemTimeResult) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_v1_admin_proto_msgTypes[32]
+	mi := &file_admin_v1_admin_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1717,7 +2151,7 @@ func (x *GetSystemTimeResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSystemTimeResult.ProtoReflect.Descriptor instead.
 func (*GetSystemTimeResult) Descriptor() ([]byte, []int) {
-	return file_admin_v1_admin_proto_rawDescGZIP(), []int{32}
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *GetSystemTimeResult) GetUnixNanos() int64 {
@@ -1748,14 +2182,18 @@ type Agent struct {
 	// project is the clawker project slug the agent registered under (empty
 	// for 2-segment naming). Composite with agent_name when callers need a
 	// unique key across projects.
-	Project       string `protobuf:"bytes,6,opt,name=project,proto3" json:"project,omitempty"`
+	Project string `protobuf:"bytes,6,opt,name=project,proto3" json:"project,omitempty"`
+	// status is the latest in-container health sample clawkerd reported
+	// (AgentService.ReportStatus). Unset until the agent's first report, and
+	// after CP restarts until the next one.
+	Status        *AgentStatus `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Agent) Reset() {
 	*x = Agent{}
-	mi := &file_admin_v1_admin_proto_msgTypes[33]
+	mi := &file_admin_v1_admin_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1767,7 +2205,7 @@ func (x *Agent) String() string {
 func (*Agent) ProtoMessage() {}
 
 func (x *Agent) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_v1_admin_proto_msgTypes[33]
+	mi := &file_admin_v1_admin_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1780,7 +2218,7 @@ func (x *Agent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Agent.ProtoReflect.Descriptor instead.
 func (*Agent) Descriptor() ([]byte, []int) {
-	return file_admin_v1_admin_proto_rawDescGZIP(), []int{33}
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *Agent) GetAgentName() string {
@@ -1825,6 +2263,223 @@ func (x *Agent) GetProject() string {
 	return ""
 }
 
+func (x *Agent) GetStatus() *AgentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// AgentStatus is an agent's self-reported resource usage, as clawkerd
+// sampled it inside the container.
+type AgentStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// processes is every process in the container except clawkerd, ordered
+	// by pid.
+	Processes []*AgentProcess `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes,omitempty"`
+	// memory_rss_bytes is the summed resident set size of processes.
+	MemoryRssBytes uint64 `protobuf:"varint,2,opt,name=memory_rss_bytes,json=memoryRssBytes,proto3" json:"memory_rss_bytes,omitempty"`
+	// cpu_percent is the summed CPU usage of processes, in percent of one
+	// core.
+	CpuPercent float64 `protobuf:"fixed64,3,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	// workspace_path is the container path of the workspace;
+	// workspace_used_bytes and workspace_total_bytes describe the filesystem
+	// holding it (both zero when clawkerd could not stat it).
+	WorkspacePath       string `protobuf:"bytes,4,opt,name=workspace_path,json=workspacePath,proto3" json:"workspace_path,omitempty"`
+	WorkspaceUsedBytes  uint64 `protobuf:"varint,5,opt,name=workspace_used_bytes,json=workspaceUsedBytes,proto3" json:"workspace_used_bytes,omitempty"`
+	WorkspaceTotalBytes uint64 `protobuf:"varint,6,opt,name=workspace_total_bytes,json=workspaceTotalBytes,proto3" json:"workspace_total_bytes,omitempty"`
+	// claude_pid is the pid of the running Claude Code process, 0 when none
+	// is running.
+	ClaudePid int32 `protobuf:"varint,7,opt,name=claude_pid,json=claudePid,proto3" json:"claude_pid,omitempty"`
+	// collected_at_unix is when clawkerd took the sample, in Unix seconds.
+	CollectedAtUnix int64 `protobuf:"varint,8,opt,name=collected_at_unix,json=collectedAtUnix,proto3" json:"collected_at_unix,omitempty"`
+	// received_at_unix is when CP received it, in Unix seconds (CP clock).
+	ReceivedAtUnix int64 `protobuf:"varint,9,opt,name=received_at_unix,json=receivedAtUnix,proto3" json:"received_at_unix,omitempty"`
+	// last_activity_unix is the last TTY or docker exec activity clawkerd
+	// saw in the container, in Unix seconds (agent clock). Zero from a
+	// clawkerd that predates activity tracking.
+	LastActivityUnix int64 `protobuf:"varint,10,opt,name=last_activity_unix,json=lastActivityUnix,proto3" json:"last_activity_unix,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AgentStatus) Reset() {
+	*x = AgentStatus{}
+	mi := &file_admin_v1_admin_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentStatus) ProtoMessage() {}
+
+func (x *AgentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentStatus.ProtoReflect.Descriptor instead.
+func (*AgentStatus) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AgentStatus) GetProcesses() []*AgentProcess {
+	if x != nil {
+		return x.Processes
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetMemoryRssBytes() uint64 {
+	if x != nil {
+		return x.MemoryRssBytes
+	}
+	return 0
+}
+
+func (x *AgentStatus) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *AgentStatus) GetWorkspacePath() string {
+	if x != nil {
+		return x.WorkspacePath
+	}
+	return ""
+}
+
+func (x *AgentStatus) GetWorkspaceUsedBytes() uint64 {
+	if x != nil {
+		return x.WorkspaceUsedBytes
+	}
+	return 0
+}
+
+func (x *AgentStatus) GetWorkspaceTotalBytes() uint64 {
+	if x != nil {
+		return x.WorkspaceTotalBytes
+	}
+	return 0
+}
+
+func (x *AgentStatus) GetClaudePid() int32 {
+	if x != nil {
+		return x.ClaudePid
+	}
+	return 0
+}
+
+func (x *AgentStatus) GetCollectedAtUnix() int64 {
+	if x != nil {
+		return x.CollectedAtUnix
+	}
+	return 0
+}
+
+func (x *AgentStatus) GetReceivedAtUnix() int64 {
+	if x != nil {
+		return x.ReceivedAtUnix
+	}
+	return 0
+}
+
+func (x *AgentStatus) GetLastActivityUnix() int64 {
+	if x != nil {
+		return x.LastActivityUnix
+	}
+	return 0
+}
+
+// AgentProcess is one process of an agent container.
+type AgentProcess struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pid           int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Ppid          int32                  `protobuf:"varint,2,opt,name=ppid,proto3" json:"ppid,omitempty"`
+	Command       string                 `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	RssBytes      uint64                 `protobuf:"varint,4,opt,name=rss_bytes,json=rssBytes,proto3" json:"rss_bytes,omitempty"`
+	CpuPercent    float64                `protobuf:"fixed64,5,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentProcess) Reset() {
+	*x = AgentProcess{}
+	mi := &file_admin_v1_admin_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentProcess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentProcess) ProtoMessage() {}
+
+func (x *AgentProcess) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_v1_admin_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentProcess.ProtoReflect.Descriptor instead.
+func (*AgentProcess) Descriptor() ([]byte, []int) {
+	return file_admin_v1_admin_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AgentProcess) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *AgentProcess) GetPpid() int32 {
+	if x != nil {
+		return x.Ppid
+	}
+	return 0
+}
+
+func (x *AgentProcess) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *AgentProcess) GetRssBytes() uint64 {
+	if x != nil {
+		return x.RssBytes
+	}
+	return 0
+}
+
+func (x *AgentProcess) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
 var File_admin_v1_admin_proto protoreflect.FileDescriptor
 
 const file_admin_v1_admin_proto_rawDesc = "" +
@@ -1910,14 +2565,42 @@ const file_admin_v1_admin_proto_rawDesc = "" +
 	"\x1eFirewallResolveHostnameRequest\x12\x1a\n" +
 	"\bhostname\x18\x01 \x01(\tR\bhostname\"=\n" +
 	"\x1dFirewallResolveHostnameResult\x12\x1c\n" +
-	"\taddresses\x18\x01 \x03(\tR\taddresses\"\x13\n" +
-	"\x11ListAgentsRequest\"C\n" +
+	"\taddresses\x18\x01 \x03(\tR\taddresses\"O\n" +
+	"\x14FirewallAuditRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\x1d\n" +
+	"\n" +
+	"agent_name\x18\x02 \x01(\tR\tagentName\"\xa8\x01\n" +
+	"\x12FirewallAuditEntry\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x04R\x05count\x12&\n" +
+	"\x0ffirst_seen_unix\x18\x04 \x01(\x03R\rfirstSeenUnix\x12$\n" +
+	"\x0elast_seen_unix\x18\x05 \x01(\x03R\flastSeenUnix\"\x8e\x01\n" +
+	"\x13FirewallAuditResult\x12>\n" +
+	"\aentries\x18\x01 \x03(\v2$.clawker.admin.v1.FirewallAuditEntryR\aentries\x12\x18\n" +
+	"\adropped\x18\x02 \x01(\x04R\adropped\x12\x1d\n" +
+	"\n" +
+	"since_unix\x18\x03 \x01(\x03R\tsinceUnix\"P\n" +
+	"\x11ListAgentsRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12!\n" +
+	"\fall_projects\x18\x02 \x01(\bR\vallProjects\"C\n" +
 	"\x10ListAgentsResult\x12/\n" +
-	"\x06agents\x18\x01 \x03(\v2\x17.clawker.admin.v1.AgentR\x06agents\"\x16\n" +
+	"\x06agents\x18\x01 \x03(\v2\x17.clawker.admin.v1.AgentR\x06agents\"g\n" +
+	"\x12SetLogLevelRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12!\n" +
+	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12\x18\n" +
+	"\aproject\x18\x03 \x01(\tR\aproject\":\n" +
+	"\x11SetLogLevelResult\x12%\n" +
+	"\x0eprevious_level\x18\x01 \x01(\tR\rpreviousLevel\"k\n" +
+	"\x12NotifyAgentRequest\x12!\n" +
+	"\fcontainer_id\x18\x01 \x01(\tR\vcontainerId\x12\x18\n" +
+	"\aproject\x18\x02 \x01(\tR\aproject\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x13\n" +
+	"\x11NotifyAgentResult\"\x16\n" +
 	"\x14GetSystemTimeRequest\"4\n" +
 	"\x13GetSystemTimeResult\x12\x1d\n" +
 	"\n" +
-	"unix_nanos\x18\x01 \x01(\x03R\tunixNanos\"\xe0\x01\n" +
+	"unix_nanos\x18\x01 \x01(\x03R\tunixNanos\"\x97\x02\n" +
 	"\x05Agent\x12\x1d\n" +
 	"\n" +
 	"agent_name\x18\x01 \x01(\tR\tagentName\x12!\n" +
@@ -1925,7 +2608,29 @@ const file_admin_v1_admin_proto_rawDesc = "" +
 	"\x0fcert_thumbprint\x18\x03 \x01(\tR\x0ecertThumbprint\x12,\n" +
 	"\x12registered_at_unix\x18\x04 \x01(\x03R\x10registeredAtUnix\x12$\n" +
 	"\x0elast_seen_unix\x18\x05 \x01(\x03R\flastSeenUnix\x12\x18\n" +
-	"\aproject\x18\x06 \x01(\tR\aproject*\x88\x01\n" +
+	"\aproject\x18\x06 \x01(\tR\aproject\x125\n" +
+	"\x06status\x18\a \x01(\v2\x1d.clawker.admin.v1.AgentStatusR\x06status\"\xc6\x03\n" +
+	"\vAgentStatus\x12<\n" +
+	"\tprocesses\x18\x01 \x03(\v2\x1e.clawker.admin.v1.AgentProcessR\tprocesses\x12(\n" +
+	"\x10memory_rss_bytes\x18\x02 \x01(\x04R\x0ememoryRssBytes\x12\x1f\n" +
+	"\vcpu_percent\x18\x03 \x01(\x01R\n" +
+	"cpuPercent\x12%\n" +
+	"\x0eworkspace_path\x18\x04 \x01(\tR\rworkspacePath\x120\n" +
+	"\x14workspace_used_bytes\x18\x05 \x01(\x04R\x12workspaceUsedBytes\x122\n" +
+	"\x15workspace_total_bytes\x18\x06 \x01(\x04R\x13workspaceTotalBytes\x12\x1d\n" +
+	"\n" +
+	"claude_pid\x18\a \x01(\x05R\tclaudePid\x12*\n" +
+	"\x11collected_at_unix\x18\b \x01(\x03R\x0fcollectedAtUnix\x12(\n" +
+	"\x10received_at_unix\x18\t \x01(\x03R\x0ereceivedAtUnix\x12,\n" +
+	"\x12last_activity_unix\x18\n" +
+	" \x01(\x03R\x10lastActivityUnix\"\x8c\x01\n" +
+	"\fAgentProcess\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x12\n" +
+	"\x04ppid\x18\x02 \x01(\x05R\x04ppid\x12\x18\n" +
+	"\acommand\x18\x03 \x01(\tR\acommand\x12\x1b\n" +
+	"\trss_bytes\x18\x04 \x01(\x04R\brssBytes\x12\x1f\n" +
+	"\vcpu_percent\x18\x05 \x01(\x01R\n" +
+	"cpuPercent*\x88\x01\n" +
 	"\rAddRuleStatus\x12\x1f\n" +
 	"\x1bADD_RULE_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15ADD_RULE_STATUS_ADDED\x10\x01\x12\x1c\n" +
@@ -1935,7 +2640,7 @@ const file_admin_v1_admin_proto_rawDesc = "" +
 	"\x1eREMOVE_RULE_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aREMOVE_RULE_STATUS_REMOVED\x10\x01\x12#\n" +
 	"\x1fREMOVE_RULE_STATUS_PATH_REMOVED\x10\x02\x12 \n" +
-	"\x1cREMOVE_RULE_STATUS_NOT_FOUND\x10\x032\x91\f\n" +
+	"\x1cREMOVE_RULE_STATUS_NOT_FOUND\x10\x032\xa5\x0e\n" +
 	"\fAdminService\x12[\n" +
 	"\fFirewallInit\x12%.clawker.admin.v1.FirewallInitRequest\x1a$.clawker.admin.v1.FirewallInitResult\x12a\n" +
 	"\x0eFirewallRemove\x12'.clawker.admin.v1.FirewallRemoveRequest\x1a&.clawker.admin.v1.FirewallRemoveResult\x12a\n" +
@@ -1949,9 +2654,12 @@ const file_admin_v1_admin_proto_rawDesc = "" +
 	"\x0eFirewallStatus\x12'.clawker.admin.v1.FirewallStatusRequest\x1a&.clawker.admin.v1.FirewallStatusResult\x12g\n" +
 	"\x10FirewallRotateCA\x12).clawker.admin.v1.FirewallRotateCARequest\x1a(.clawker.admin.v1.FirewallRotateCAResult\x12m\n" +
 	"\x12FirewallSyncRoutes\x12+.clawker.admin.v1.FirewallSyncRoutesRequest\x1a*.clawker.admin.v1.FirewallSyncRoutesResult\x12|\n" +
-	"\x17FirewallResolveHostname\x120.clawker.admin.v1.FirewallResolveHostnameRequest\x1a/.clawker.admin.v1.FirewallResolveHostnameResult\x12U\n" +
+	"\x17FirewallResolveHostname\x120.clawker.admin.v1.FirewallResolveHostnameRequest\x1a/.clawker.admin.v1.FirewallResolveHostnameResult\x12^\n" +
+	"\rFirewallAudit\x12&.clawker.admin.v1.FirewallAuditRequest\x1a%.clawker.admin.v1.FirewallAuditResult\x12U\n" +
 	"\n" +
-	"ListAgents\x12#.clawker.admin.v1.ListAgentsRequest\x1a\".clawker.admin.v1.ListAgentsResult\x12^\n" +
+	"ListAgents\x12#.clawker.admin.v1.ListAgentsRequest\x1a\".clawker.admin.v1.ListAgentsResult\x12X\n" +
+	"\vSetLogLevel\x12$.clawker.admin.v1.SetLogLevelRequest\x1a#.clawker.admin.v1.SetLogLevelResult\x12X\n" +
+	"\vNotifyAgent\x12$.clawker.admin.v1.NotifyAgentRequest\x1a#.clawker.admin.v1.NotifyAgentResult\x12^\n" +
 	"\rGetSystemTime\x12&.clawker.admin.v1.GetSystemTimeRequest\x1a%.clawker.admin.v1.GetSystemTimeResultB,Z*github.com/schmitthub/clawker/api/admin/v1b\x06proto3"
 
 var (
@@ -1967,7 +2675,7 @@ func file_admin_v1_admin_proto_rawDescGZIP() []byte {
 }
 
 var file_admin_v1_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_admin_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_admin_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_admin_v1_admin_proto_goTypes = []any{
 	(AddRuleStatus)(0),                     // 0: clawker.admin.v1.AddRuleStatus
 	(RemoveRuleStatus)(0),                  // 1: clawker.admin.v1.RemoveRuleStatus
@@ -2000,11 +2708,20 @@ var file_admin_v1_admin_proto_goTypes = []any{
 	(*FirewallSyncRoutesResult)(nil),       // 28: clawker.admin.v1.FirewallSyncRoutesResult
 	(*FirewallResolveHostnameRequest)(nil), // 29: clawker.admin.v1.FirewallResolveHostnameRequest
 	(*FirewallResolveHostnameResult)(nil),  // 30: clawker.admin.v1.FirewallResolveHostnameResult
-	(*ListAgentsRequest)(nil),              // 31: clawker.admin.v1.ListAgentsRequest
-	(*ListAgentsResult)(nil),               // 32: clawker.admin.v1.ListAgentsResult
-	(*GetSystemTimeRequest)(nil),           // 33: clawker.admin.v1.GetSystemTimeRequest
-	(*GetSystemTimeResult)(nil),            // 34: clawker.admin.v1.GetSystemTimeResult
-	(*Agent)(nil),                          // 35: clawker.admin.v1.Agent
+	(*FirewallAuditRequest)(nil),           // 31: clawker.admin.v1.FirewallAuditRequest
+	(*FirewallAuditEntry)(nil),             // 32: clawker.admin.v1.FirewallAuditEntry
+	(*FirewallAuditResult)(nil),            // 33: clawker.admin.v1.FirewallAuditResult
+	(*ListAgentsRequest)(nil),              // 34: clawker.admin.v1.ListAgentsRequest
+	(*ListAgentsResult)(nil),               // 35: clawker.admin.v1.ListAgentsResult
+	(*SetLogLevelRequest)(nil),             // 36: clawker.admin.v1.SetLogLevelRequest
+	(*SetLogLevelResult)(nil),              // 37: clawker.admin.v1.SetLogLevelResult
+	(*NotifyAgentRequest)(nil),             // 38: clawker.admin.v1.NotifyAgentRequest
+	(*NotifyAgentResult)(nil),              // 39: clawker.admin.v1.NotifyAgentResult
+	(*GetSystemTimeRequest)(nil),           // 40: clawker.admin.v1.GetSystemTimeRequest
+	(*GetSystemTimeResult)(nil),            // 41: clawker.admin.v1.GetSystemTimeResult
+	(*Agent)(nil),                          // 42: clawker.admin.v1.Agent
+	(*AgentStatus)(nil),                    // 43: clawker.admin.v1.AgentStatus
+	(*AgentProcess)(nil),                   // 44: clawker.admin.v1.AgentProcess
 }
 var file_admin_v1_admin_proto_depIdxs = []int32{
 	4,  // 0: clawker.admin.v1.EgressRule.path_rules:type_name -> clawker.admin.v1.PathRule
@@ -2013,42 +2730,51 @@ var file_admin_v1_admin_proto_depIdxs = []int32{
 	1,  // 3: clawker.admin.v1.FirewallRemoveRuleResult.status:type_name -> clawker.admin.v1.RemoveRuleStatus
 	3,  // 4: clawker.admin.v1.FirewallListRulesResult.rules:type_name -> clawker.admin.v1.EgressRule
 	2,  // 5: clawker.admin.v1.FirewallSyncRoutesRequest.routes:type_name -> clawker.admin.v1.Route
-	35, // 6: clawker.admin.v1.ListAgentsResult.agents:type_name -> clawker.admin.v1.Agent
-	5,  // 7: clawker.admin.v1.AdminService.FirewallInit:input_type -> clawker.admin.v1.FirewallInitRequest
-	7,  // 8: clawker.admin.v1.AdminService.FirewallRemove:input_type -> clawker.admin.v1.FirewallRemoveRequest
-	9,  // 9: clawker.admin.v1.AdminService.FirewallEnable:input_type -> clawker.admin.v1.FirewallEnableRequest
-	11, // 10: clawker.admin.v1.AdminService.FirewallDisable:input_type -> clawker.admin.v1.FirewallDisableRequest
-	13, // 11: clawker.admin.v1.AdminService.FirewallBypass:input_type -> clawker.admin.v1.FirewallBypassRequest
-	15, // 12: clawker.admin.v1.AdminService.FirewallAddRules:input_type -> clawker.admin.v1.FirewallAddRulesRequest
-	17, // 13: clawker.admin.v1.AdminService.FirewallRemoveRule:input_type -> clawker.admin.v1.FirewallRemoveRuleRequest
-	19, // 14: clawker.admin.v1.AdminService.FirewallListRules:input_type -> clawker.admin.v1.FirewallListRulesRequest
-	21, // 15: clawker.admin.v1.AdminService.FirewallReload:input_type -> clawker.admin.v1.FirewallReloadRequest
-	23, // 16: clawker.admin.v1.AdminService.FirewallStatus:input_type -> clawker.admin.v1.FirewallStatusRequest
-	25, // 17: clawker.admin.v1.AdminService.FirewallRotateCA:input_type -> clawker.admin.v1.FirewallRotateCARequest
-	27, // 18: clawker.admin.v1.AdminService.FirewallSyncRoutes:input_type -> clawker.admin.v1.FirewallSyncRoutesRequest
-	29, // 19: clawker.admin.v1.AdminService.FirewallResolveHostname:input_type -> clawker.admin.v1.FirewallResolveHostnameRequest
-	31, // 20: clawker.admin.v1.AdminService.ListAgents:input_type -> clawker.admin.v1.ListAgentsRequest
-	33, // 21: clawker.admin.v1.AdminService.GetSystemTime:input_type -> clawker.admin.v1.GetSystemTimeRequest
-	6,  // 22: clawker.admin.v1.AdminService.FirewallInit:output_type -> clawker.admin.v1.FirewallInitResult
-	8,  // 23: clawker.admin.v1.AdminService.FirewallRemove:output_type -> clawker.admin.v1.FirewallRemoveResult
-	10, // 24: clawker.admin.v1.AdminService.FirewallEnable:output_type -> clawker.admin.v1.FirewallEnableResult
-	12, // 25: clawker.admin.v1.AdminService.FirewallDisable:output_type -> clawker.admin.v1.FirewallDisableResult
-	14, // 26: clawker.admin.v1.AdminService.FirewallBypass:output_type -> clawker.admin.v1.FirewallBypassResult
-	16, // 27: clawker.admin.v1.AdminService.FirewallAddRules:output_type -> clawker.admin.v1.FirewallAddRulesResult
-	18, // 28: clawker.admin.v1.AdminService.FirewallRemoveRule:output_type -> clawker.admin.v1.FirewallRemoveRuleResult
-	20, // 29: clawker.admin.v1.AdminService.FirewallListRules:output_type -> clawker.admin.v1.FirewallListRulesResult
-	22, // 30: clawker.admin.v1.AdminService.FirewallReload:output_type -> clawker.admin.v1.FirewallReloadResult
-	24, // 31: clawker.admin.v1.AdminService.FirewallStatus:output_type -> clawker.admin.v1.FirewallStatusResult
-	26, // 32: clawker.admin.v1.AdminService.FirewallRotateCA:output_type -> clawker.admin.v1.FirewallRotateCAResult
-	28, // 33: clawker.admin.v1.AdminService.FirewallSyncRoutes:output_type -> clawker.admin.v1.FirewallSyncRoutesResult
-	30, // 34: clawker.admin.v1.AdminService.FirewallResolveHostname:output_type -> clawker.admin.v1.FirewallResolveHostnameResult
-	32, // 35: clawker.admin.v1.AdminService.ListAgents:output_type -> clawker.admin.v1.ListAgentsResult
-	34, // 36: clawker.admin.v1.AdminService.GetSystemTime:output_type -> clawker.admin.v1.GetSystemTimeResult
-	22, // [22:37] is the sub-list for method output_type
-	7,  // [7:22] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	32, // 6: clawker.admin.v1.FirewallAuditResult.entries:type_name -> clawker.admin.v1.FirewallAuditEntry
+	42, // 7: clawker.admin.v1.ListAgentsResult.agents:type_name -> clawker.admin.v1.Agent
+	43, // 8: clawker.admin.v1.Agent.status:type_name -> clawker.admin.v1.AgentStatus
+	44, // 9: clawker.admin.v1.AgentStatus.processes:type_name -> clawker.admin.v1.AgentProcess
+	5,  // 10: clawker.admin.v1.AdminService.FirewallInit:input_type -> clawker.admin.v1.FirewallInitRequest
+	7,  // 11: clawker.admin.v1.AdminService.FirewallRemove:input_type -> clawker.admin.v1.FirewallRemoveRequest
+	9,  // 12: clawker.admin.v1.AdminService.FirewallEnable:input_type -> clawker.admin.v1.FirewallEnableRequest
+	11, // 13: clawker.admin.v1.AdminService.FirewallDisable:input_type -> clawker.admin.v1.FirewallDisableRequest
+	13, // 14: clawker.admin.v1.AdminService.FirewallBypass:input_type -> clawker.admin.v1.FirewallBypassRequest
+	15, // 15: clawker.admin.v1.AdminService.FirewallAddRules:input_type -> clawker.admin.v1.FirewallAddRulesRequest
+	17, // 16: clawker.admin.v1.AdminService.FirewallRemoveRule:input_type -> clawker.admin.v1.FirewallRemoveRuleRequest
+	19, // 17: clawker.admin.v1.AdminService.FirewallListRules:input_type -> clawker.admin.v1.FirewallListRulesRequest
+	21, // 18: clawker.admin.v1.AdminService.FirewallReload:input_type -> clawker.admin.v1.FirewallReloadRequest
+	23, // 19: clawker.admin.v1.AdminService.FirewallStatus:input_type -> clawker.admin.v1.FirewallStatusRequest
+	25, // 20: clawker.admin.v1.AdminService.FirewallRotateCA:input_type -> clawker.admin.v1.FirewallRotateCARequest
+	27, // 21: clawker.admin.v1.AdminService.FirewallSyncRoutes:input_type -> clawker.admin.v1.FirewallSyncRoutesRequest
+	29, // 22: clawker.admin.v1.AdminService.FirewallResolveHostname:input_type -> clawker.admin.v1.FirewallResolveHostnameRequest
+	31, // 23: clawker.admin.v1.AdminService.FirewallAudit:input_type -> clawker.admin.v1.FirewallAuditRequest
+	34, // 24: clawker.admin.v1.AdminService.ListAgents:input_type -> clawker.admin.v1.ListAgentsRequest
+	36, // 25: clawker.admin.v1.AdminService.SetLogLevel:input_type -> clawker.admin.v1.SetLogLevelRequest
+	38, // 26: clawker.admin.v1.AdminService.NotifyAgent:input_type -> clawker.admin.v1.NotifyAgentRequest
+	40, // 27: clawker.admin.v1.AdminService.GetSystemTime:input_type -> clawker.admin.v1.GetSystemTimeRequest
+	6,  // 28: clawker.admin.v1.AdminService.FirewallInit:output_type -> clawker.admin.v1.FirewallInitResult
+	8,  // 29: clawker.admin.v1.AdminService.FirewallRemove:output_type -> clawker.admin.v1.FirewallRemoveResult
+	10, // 30: clawker.admin.v1.AdminService.FirewallEnable:output_type -> clawker.admin.v1.FirewallEnableResult
+	12, // 31: clawker.admin.v1.AdminService.FirewallDisable:output_type -> clawker.admin.v1.FirewallDisableResult
+	14, // 32: clawker.admin.v1.AdminService.FirewallBypass:output_type -> clawker.admin.v1.FirewallBypassResult
+	16, // 33: clawker.admin.v1.AdminService.FirewallAddRules:output_type -> clawker.admin.v1.FirewallAddRulesResult
+	18, // 34: clawker.admin.v1.AdminService.FirewallRemoveRule:output_type -> clawker.admin.v1.FirewallRemoveRuleResult
+	20, // 35: clawker.admin.v1.AdminService.FirewallListRules:output_type -> clawker.admin.v1.FirewallListRulesResult
+	22, // 36: clawker.admin.v1.AdminService.FirewallReload:output_type -> clawker.admin.v1.FirewallReloadResult
+	24, // 37: clawker.admin.v1.AdminService.FirewallStatus:output_type -> clawker.admin.v1.FirewallStatusResult
+	26, // 38: clawker.admin.v1.AdminService.FirewallRotateCA:output_type -> clawker.admin.v1.FirewallRotateCAResult
+	28, // 39: clawker.admin.v1.AdminService.FirewallSyncRoutes:output_type -> clawker.admin.v1.FirewallSyncRoutesResult
+	30, // 40: clawker.admin.v1.AdminService.FirewallResolveHostname:output_type -> clawker.admin.v1.FirewallResolveHostnameResult
+	33, // 41: clawker.admin.v1.AdminService.FirewallAudit:output_type -> clawker.admin.v1.FirewallAuditResult
+	35, // 42: clawker.admin.v1.AdminService.ListAgents:output_type -> clawker.admin.v1.ListAgentsResult
+	37, // 43: clawker.admin.v1.AdminService.SetLogLevel:output_type -> clawker.admin.v1.SetLogLevelResult
+	39, // 44: clawker.admin.v1.AdminService.NotifyAgent:output_type -> clawker.admin.v1.NotifyAgentResult
+	41, // 45: clawker.admin.v1.AdminService.GetSystemTime:output_type -> clawker.admin.v1.GetSystemTimeResult
+	28, // [28:46] is the sub-list for method output_type
+	10, // [10:28] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_admin_v1_admin_proto_init() }
@@ -2062,7 +2788,7 @@ func file_admin_v1_admin_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_v1_admin_proto_rawDesc), len(file_admin_v1_admin_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   34,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
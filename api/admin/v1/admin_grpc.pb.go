@@ -32,7 +32,10 @@ const (
 	AdminService_FirewallRotateCA_FullMethodName        = "/clawker.admin.v1.AdminService/FirewallRotateCA"
 	AdminService_FirewallSyncRoutes_FullMethodName      = "/clawker.admin.v1.AdminService/FirewallSyncRoutes"
 	AdminService_FirewallResolveHostname_FullMethodName = "/clawker.admin.v1.AdminService/FirewallResolveHostname"
+	AdminService_FirewallAudit_FullMethodName           = "/clawker.admin.v1.AdminService/FirewallAudit"
 	AdminService_ListAgents_FullMethodName              = "/clawker.admin.v1.AdminService/ListAgents"
+	AdminService_SetLogLevel_FullMethodName             = "/clawker.admin.v1.AdminService/SetLogLevel"
+	AdminService_NotifyAgent_FullMethodName             = "/clawker.admin.v1.AdminService/NotifyAgent"
 	AdminService_GetSystemTime_FullMethodName           = "/clawker.admin.v1.AdminService/GetSystemTime"
 )
 
@@ -111,10 +114,30 @@ type AdminServiceClient interface {
 	// network namespace — used to resolve host.docker.internal during
 	// per-container enroll.
 	FirewallResolveHostname(ctx context.Context, in *FirewallResolveHostnameRequest, opts ...grpc.CallOption) (*FirewallResolveHostnameResult, error)
-	// ListAgents returns a snapshot of every agent currently registered
-	// with the control plane. Used by `clawker controlplane agents` and
+	// FirewallAudit returns the egress audit log for one agent: the DNS
+	// lookups the agent made through CoreDNS since the CP started,
+	// aggregated per domain and split by verdict (allowed / denied). Lets
+	// users discover which domains an agent actually needs before tightening
+	// rules. Read-only; global (keyed by project + agent name, not
+	// container_id, so the log survives container recreation).
+	FirewallAudit(ctx context.Context, in *FirewallAuditRequest, opts ...grpc.CallOption) (*FirewallAuditResult, error)
+	// ListAgents returns a snapshot of the agents registered with the
+	// control plane under one project, or under every project when
+	// all_projects is set, each with its latest self-reported status. Used
+	// by `clawker controlplane agents`, `clawker monitor status` and
 	// diagnostic tooling. Read-only; uniform admin scope.
 	ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResult, error)
+	// SetLogLevel changes the minimum log level of a running daemon without
+	// a restart. An empty container_id targets the control plane itself;
+	// otherwise CP forwards the change over that agent's live clawkerd
+	// Session, provided the agent registered under the request's project.
+	// Uniform admin scope.
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResult, error)
+	// NotifyAgent shows a message on a running agent's console, forwarded
+	// over its live clawkerd Session, provided the agent registered under
+	// the request's project. The host proxy's idle reaper warns an agent
+	// this way before stopping it. Uniform admin scope.
+	NotifyAgent(ctx context.Context, in *NotifyAgentRequest, opts ...grpc.CallOption) (*NotifyAgentResult, error)
 	// GetSystemTime returns the control plane's current wall-clock time.
 	// This RPC is intentionally PUBLIC (the public scope in AdminMethodScopes —
 	// no bearer token required), because it bootstraps the very auth flow.
@@ -274,6 +297,16 @@ func (c *adminServiceClient) FirewallResolveHostname(ctx context.Context, in *Fi
 	return out, nil
 }
 
+func (c *adminServiceClient) FirewallAudit(ctx context.Context, in *FirewallAuditRequest, opts ...grpc.CallOption) (*FirewallAuditResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FirewallAuditResult)
+	err := c.cc.Invoke(ctx, AdminService_FirewallAudit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminServiceClient) ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResult, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListAgentsResult)
@@ -284,6 +317,26 @@ func (c *adminServiceClient) ListAgents(ctx context.Context, in *ListAgentsReque
 	return out, nil
 }
 
+func (c *adminServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLogLevelResult)
+	err := c.cc.Invoke(ctx, AdminService_SetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) NotifyAgent(ctx context.Context, in *NotifyAgentRequest, opts ...grpc.CallOption) (*NotifyAgentResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotifyAgentResult)
+	err := c.cc.Invoke(ctx, AdminService_NotifyAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminServiceClient) GetSystemTime(ctx context.Context, in *GetSystemTimeRequest, opts ...grpc.CallOption) (*GetSystemTimeResult, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetSystemTimeResult)
@@ -369,10 +422,30 @@ type AdminServiceServer interface {
 	// network namespace — used to resolve host.docker.internal during
 	// per-container enroll.
 	FirewallResolveHostname(context.Context, *FirewallResolveHostnameRequest) (*FirewallResolveHostnameResult, error)
-	// ListAgents returns a snapshot of every agent currently registered
-	// with the control plane. Used by `clawker controlplane agents` and
+	// FirewallAudit returns the egress audit log for one agent: the DNS
+	// lookups the agent made through CoreDNS since the CP started,
+	// aggregated per domain and split by verdict (allowed / denied). Lets
+	// users discover which domains an agent actually needs before tightening
+	// rules. Read-only; global (keyed by project + agent name, not
+	// container_id, so the log survives container recreation).
+	FirewallAudit(context.Context, *FirewallAuditRequest) (*FirewallAuditResult, error)
+	// ListAgents returns a snapshot of the agents registered with the
+	// control plane under one project, or under every project when
+	// all_projects is set, each with its latest self-reported status. Used
+	// by `clawker controlplane agents`, `clawker monitor status` and
 	// diagnostic tooling. Read-only; uniform admin scope.
 	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResult, error)
+	// SetLogLevel changes the minimum log level of a running daemon without
+	// a restart. An empty container_id targets the control plane itself;
+	// otherwise CP forwards the change over that agent's live clawkerd
+	// Session, provided the agent registered under the request's project.
+	// Uniform admin scope.
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResult, error)
+	// NotifyAgent shows a message on a running agent's console, forwarded
+	// over its live clawkerd Session, provided the agent registered under
+	// the request's project. The host proxy's idle reaper warns an agent
+	// this way before stopping it. Uniform admin scope.
+	NotifyAgent(context.Context, *NotifyAgentRequest) (*NotifyAgentResult, error)
 	// GetSystemTime returns the control plane's current wall-clock time.
 	// This RPC is intentionally PUBLIC (the public scope in AdminMethodScopes —
 	// no bearer token required), because it bootstraps the very auth flow.
@@ -441,9 +514,18 @@ func (UnimplementedAdminServiceServer) FirewallSyncRoutes(context.Context, *Fire
 func (UnimplementedAdminServiceServer) FirewallResolveHostname(context.Context, *FirewallResolveHostnameRequest) (*FirewallResolveHostnameResult, error) {
 	return nil, status.Error(codes.Unimplemented, "method FirewallResolveHostname not implemented")
 }
+func (UnimplementedAdminServiceServer) FirewallAudit(context.Context, *FirewallAuditRequest) (*FirewallAuditResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method FirewallAudit not implemented")
+}
 func (UnimplementedAdminServiceServer) ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResult, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListAgents not implemented")
 }
+func (UnimplementedAdminServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedAdminServiceServer) NotifyAgent(context.Context, *NotifyAgentRequest) (*NotifyAgentResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method NotifyAgent not implemented")
+}
 func (UnimplementedAdminServiceServer) GetSystemTime(context.Context, *GetSystemTimeRequest) (*GetSystemTimeResult, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetSystemTime not implemented")
 }
@@ -702,6 +784,24 @@ func _AdminService_FirewallResolveHostname_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_FirewallAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FirewallAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).FirewallAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_FirewallAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).FirewallAudit(ctx, req.(*FirewallAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AdminService_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListAgentsRequest)
 	if err := dec(in); err != nil {
@@ -720,6 +820,42 @@ func _AdminService_ListAgents_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_NotifyAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotifyAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).NotifyAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_NotifyAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).NotifyAgent(ctx, req.(*NotifyAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AdminService_GetSystemTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetSystemTimeRequest)
 	if err := dec(in); err != nil {
@@ -797,10 +933,22 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FirewallResolveHostname",
 			Handler:    _AdminService_FirewallResolveHostname_Handler,
 		},
+		{
+			MethodName: "FirewallAudit",
+			Handler:    _AdminService_FirewallAudit_Handler,
+		},
 		{
 			MethodName: "ListAgents",
 			Handler:    _AdminService_ListAgents_Handler,
 		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _AdminService_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "NotifyAgent",
+			Handler:    _AdminService_NotifyAgent_Handler,
+		},
 		{
 			MethodName: "GetSystemTime",
 			Handler:    _AdminService_GetSystemTime_Handler,
@@ -23,6 +23,9 @@ var _ v1.AdminServiceClient = &AdminServiceClientMock{}
 //			FirewallAddRulesFunc: func(ctx context.Context, in *v1.FirewallAddRulesRequest, opts ...grpc.CallOption) (*v1.FirewallAddRulesResult, error) {
 //				panic("mock out the FirewallAddRules method")
 //			},
+//			FirewallAuditFunc: func(ctx context.Context, in *v1.FirewallAuditRequest, opts ...grpc.CallOption) (*v1.FirewallAuditResult, error) {
+//				panic("mock out the FirewallAudit method")
+//			},
 //			FirewallBypassFunc: func(ctx context.Context, in *v1.FirewallBypassRequest, opts ...grpc.CallOption) (*v1.FirewallBypassResult, error) {
 //				panic("mock out the FirewallBypass method")
 //			},
@@ -65,6 +68,12 @@ var _ v1.AdminServiceClient = &AdminServiceClientMock{}
 //			ListAgentsFunc: func(ctx context.Context, in *v1.ListAgentsRequest, opts ...grpc.CallOption) (*v1.ListAgentsResult, error) {
 //				panic("mock out the ListAgents method")
 //			},
+//			NotifyAgentFunc: func(ctx context.Context, in *v1.NotifyAgentRequest, opts ...grpc.CallOption) (*v1.NotifyAgentResult, error) {
+//				panic("mock out the NotifyAgent method")
+//			},
+//			SetLogLevelFunc: func(ctx context.Context, in *v1.SetLogLevelRequest, opts ...grpc.CallOption) (*v1.SetLogLevelResult, error) {
+//				panic("mock out the SetLogLevel method")
+//			},
 //		}
 //
 //		// use mockedAdminServiceClient in code that requires v1.AdminServiceClient
@@ -75,6 +84,9 @@ type AdminServiceClientMock struct {
 	// FirewallAddRulesFunc mocks the FirewallAddRules method.
 	FirewallAddRulesFunc func(ctx context.Context, in *v1.FirewallAddRulesRequest, opts ...grpc.CallOption) (*v1.FirewallAddRulesResult, error)
 
+	// FirewallAuditFunc mocks the FirewallAudit method.
+	FirewallAuditFunc func(ctx context.Context, in *v1.FirewallAuditRequest, opts ...grpc.CallOption) (*v1.FirewallAuditResult, error)
+
 	// FirewallBypassFunc mocks the FirewallBypass method.
 	FirewallBypassFunc func(ctx context.Context, in *v1.FirewallBypassRequest, opts ...grpc.CallOption) (*v1.FirewallBypassResult, error)
 
@@ -117,6 +129,12 @@ type AdminServiceClientMock struct {
 	// ListAgentsFunc mocks the ListAgents method.
 	ListAgentsFunc func(ctx context.Context, in *v1.ListAgentsRequest, opts ...grpc.CallOption) (*v1.ListAgentsResult, error)
 
+	// NotifyAgentFunc mocks the NotifyAgent method.
+	NotifyAgentFunc func(ctx context.Context, in *v1.NotifyAgentRequest, opts ...grpc.CallOption) (*v1.NotifyAgentResult, error)
+
+	// SetLogLevelFunc mocks the SetLogLevel method.
+	SetLogLevelFunc func(ctx context.Context, in *v1.SetLogLevelRequest, opts ...grpc.CallOption) (*v1.SetLogLevelResult, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// FirewallAddRules holds details about calls to the FirewallAddRules method.
@@ -128,6 +146,15 @@ type AdminServiceClientMock struct {
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
+		// FirewallAudit holds details about calls to the FirewallAudit method.
+		FirewallAudit []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *v1.FirewallAuditRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
 		// FirewallBypass holds details about calls to the FirewallBypass method.
 		FirewallBypass []struct {
 			// Ctx is the ctx argument value.
@@ -254,8 +281,27 @@ type AdminServiceClientMock struct {
 			// Opts is the opts argument value.
 			Opts []grpc.CallOption
 		}
+		// NotifyAgent holds details about calls to the NotifyAgent method.
+		NotifyAgent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *v1.NotifyAgentRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
+		// SetLogLevel holds details about calls to the SetLogLevel method.
+		SetLogLevel []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// In is the in argument value.
+			In *v1.SetLogLevelRequest
+			// Opts is the opts argument value.
+			Opts []grpc.CallOption
+		}
 	}
 	lockFirewallAddRules        sync.RWMutex
+	lockFirewallAudit           sync.RWMutex
 	lockFirewallBypass          sync.RWMutex
 	lockFirewallDisable         sync.RWMutex
 	lockFirewallEnable          sync.RWMutex
@@ -270,6 +316,8 @@ type AdminServiceClientMock struct {
 	lockFirewallSyncRoutes      sync.RWMutex
 	lockGetSystemTime           sync.RWMutex
 	lockListAgents              sync.RWMutex
+	lockNotifyAgent             sync.RWMutex
+	lockSetLogLevel             sync.RWMutex
 }
 
 // FirewallAddRules calls FirewallAddRulesFunc.
@@ -312,6 +360,46 @@ func (mock *AdminServiceClientMock) FirewallAddRulesCalls() []struct {
 	return calls
 }
 
+// FirewallAudit calls FirewallAuditFunc.
+func (mock *AdminServiceClientMock) FirewallAudit(ctx context.Context, in *v1.FirewallAuditRequest, opts ...grpc.CallOption) (*v1.FirewallAuditResult, error) {
+	if mock.FirewallAuditFunc == nil {
+		panic("AdminServiceClientMock.FirewallAuditFunc: method is nil but AdminServiceClient.FirewallAudit was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *v1.FirewallAuditRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockFirewallAudit.Lock()
+	mock.calls.FirewallAudit = append(mock.calls.FirewallAudit, callInfo)
+	mock.lockFirewallAudit.Unlock()
+	return mock.FirewallAuditFunc(ctx, in, opts...)
+}
+
+// FirewallAuditCalls gets all the calls that were made to FirewallAudit.
+// Check the length with:
+//
+//	len(mockedAdminServiceClient.FirewallAuditCalls())
+func (mock *AdminServiceClientMock) FirewallAuditCalls() []struct {
+	Ctx  context.Context
+	In   *v1.FirewallAuditRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *v1.FirewallAuditRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockFirewallAudit.RLock()
+	calls = mock.calls.FirewallAudit
+	mock.lockFirewallAudit.RUnlock()
+	return calls
+}
+
 // FirewallBypass calls FirewallBypassFunc.
 func (mock *AdminServiceClientMock) FirewallBypass(ctx context.Context, in *v1.FirewallBypassRequest, opts ...grpc.CallOption) (*v1.FirewallBypassResult, error) {
 	if mock.FirewallBypassFunc == nil {
@@ -871,3 +959,83 @@ func (mock *AdminServiceClientMock) ListAgentsCalls() []struct {
 	mock.lockListAgents.RUnlock()
 	return calls
 }
+
+// NotifyAgent calls NotifyAgentFunc.
+func (mock *AdminServiceClientMock) NotifyAgent(ctx context.Context, in *v1.NotifyAgentRequest, opts ...grpc.CallOption) (*v1.NotifyAgentResult, error) {
+	if mock.NotifyAgentFunc == nil {
+		panic("AdminServiceClientMock.NotifyAgentFunc: method is nil but AdminServiceClient.NotifyAgent was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *v1.NotifyAgentRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockNotifyAgent.Lock()
+	mock.calls.NotifyAgent = append(mock.calls.NotifyAgent, callInfo)
+	mock.lockNotifyAgent.Unlock()
+	return mock.NotifyAgentFunc(ctx, in, opts...)
+}
+
+// NotifyAgentCalls gets all the calls that were made to NotifyAgent.
+// Check the length with:
+//
+//	len(mockedAdminServiceClient.NotifyAgentCalls())
+func (mock *AdminServiceClientMock) NotifyAgentCalls() []struct {
+	Ctx  context.Context
+	In   *v1.NotifyAgentRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *v1.NotifyAgentRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockNotifyAgent.RLock()
+	calls = mock.calls.NotifyAgent
+	mock.lockNotifyAgent.RUnlock()
+	return calls
+}
+
+// SetLogLevel calls SetLogLevelFunc.
+func (mock *AdminServiceClientMock) SetLogLevel(ctx context.Context, in *v1.SetLogLevelRequest, opts ...grpc.CallOption) (*v1.SetLogLevelResult, error) {
+	if mock.SetLogLevelFunc == nil {
+		panic("AdminServiceClientMock.SetLogLevelFunc: method is nil but AdminServiceClient.SetLogLevel was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		In   *v1.SetLogLevelRequest
+		Opts []grpc.CallOption
+	}{
+		Ctx:  ctx,
+		In:   in,
+		Opts: opts,
+	}
+	mock.lockSetLogLevel.Lock()
+	mock.calls.SetLogLevel = append(mock.calls.SetLogLevel, callInfo)
+	mock.lockSetLogLevel.Unlock()
+	return mock.SetLogLevelFunc(ctx, in, opts...)
+}
+
+// SetLogLevelCalls gets all the calls that were made to SetLogLevel.
+// Check the length with:
+//
+//	len(mockedAdminServiceClient.SetLogLevelCalls())
+func (mock *AdminServiceClientMock) SetLogLevelCalls() []struct {
+	Ctx  context.Context
+	In   *v1.SetLogLevelRequest
+	Opts []grpc.CallOption
+} {
+	var calls []struct {
+		Ctx  context.Context
+		In   *v1.SetLogLevelRequest
+		Opts []grpc.CallOption
+	}
+	mock.lockSetLogLevel.RLock()
+	calls = mock.calls.SetLogLevel
+	mock.lockSetLogLevel.RUnlock()
+	return calls
+}
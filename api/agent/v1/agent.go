@@ -1,11 +1,13 @@
 // Package v1 defines the gRPC AgentService for clawkerd-to-CP communication.
 //
 // AgentService is the agent-side surface clawkerd dials on the CP's
-// agent listener on the clawker network. Today the only RPC is Register — the
-// one-time CP-driven handshake binding the agent's cert thumbprint to its
-// container identity.
+// agent listener on the clawker network: Register — the one-time CP-driven
+// handshake binding the agent's cert thumbprint to its container identity —
+// and ReportStatus, clawkerd's periodic in-container health report.
 package v1
 
+import "github.com/schmitthub/clawker/internal/consts"
+
 // ServiceName is the fully-qualified gRPC service name for AgentService.
 const ServiceName = "clawker.agent.v1.AgentService"
 
@@ -29,5 +31,11 @@ const ScopeSelfRegister AgentScope = "agent:self:register"
 func AgentMethodScopes() map[string]AgentScope {
 	return map[string]AgentScope{
 		"/" + ServiceName + "/Register": ScopeSelfRegister,
+		// ReportStatus is PUBLIC: Register consumes the single-use
+		// client_assertion, so clawkerd holds no token it could renew for
+		// a call it repeats for the container's lifetime. mTLS and the
+		// universal IdentityInterceptor still gate it, and the report
+		// grants nothing — CP only stores it for display.
+		"/" + ServiceName + "/ReportStatus": consts.ScopePublic,
 	}
 }
@@ -121,6 +121,249 @@ func (*Welcome) Descriptor() ([]byte, []int) {
 	return file_agent_v1_agent_proto_rawDescGZIP(), []int{1}
 }
 
+// StatusReport is one resource-usage sample of the agent container, taken
+// by clawkerd (PID 1) from /proc and statfs.
+type StatusReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// processes is every process in the container except clawkerd itself,
+	// ordered by pid.
+	Processes []*ProcessUsage `protobuf:"bytes,1,rep,name=processes,proto3" json:"processes,omitempty"`
+	// memory_rss_bytes is the summed resident set size of processes.
+	MemoryRssBytes uint64 `protobuf:"varint,2,opt,name=memory_rss_bytes,json=memoryRssBytes,proto3" json:"memory_rss_bytes,omitempty"`
+	// cpu_percent is the summed CPU usage of processes over the previous
+	// sample interval, in percent of one core. Zero on the first sample.
+	CpuPercent float64 `protobuf:"fixed64,3,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	// workspace_path is the container path of the workspace — clawkerd's
+	// working directory, which the image's WORKDIR sets.
+	WorkspacePath string `protobuf:"bytes,4,opt,name=workspace_path,json=workspacePath,proto3" json:"workspace_path,omitempty"`
+	// workspace_used_bytes and workspace_total_bytes describe the filesystem
+	// holding workspace_path. Both zero when statfs failed.
+	WorkspaceUsedBytes  uint64 `protobuf:"varint,5,opt,name=workspace_used_bytes,json=workspaceUsedBytes,proto3" json:"workspace_used_bytes,omitempty"`
+	WorkspaceTotalBytes uint64 `protobuf:"varint,6,opt,name=workspace_total_bytes,json=workspaceTotalBytes,proto3" json:"workspace_total_bytes,omitempty"`
+	// claude_pid is the pid of the running Claude Code process, 0 when none
+	// is running.
+	ClaudePid int32 `protobuf:"varint,7,opt,name=claude_pid,json=claudePid,proto3" json:"claude_pid,omitempty"`
+	// collected_at_unix is the sample's wall-clock time in Unix seconds.
+	CollectedAtUnix int64 `protobuf:"varint,8,opt,name=collected_at_unix,json=collectedAtUnix,proto3" json:"collected_at_unix,omitempty"`
+	// last_activity_unix is the latest TTY or docker exec activity in the
+	// container, in Unix seconds: the newest access or modification time
+	// of a /dev/pts terminal, or the sample time while a docker exec
+	// process runs. Starts at clawkerd's boot time.
+	LastActivityUnix int64 `protobuf:"varint,9,opt,name=last_activity_unix,json=lastActivityUnix,proto3" json:"last_activity_unix,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StatusReport) Reset() {
+	*x = StatusReport{}
+	mi := &file_agent_v1_agent_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusReport) ProtoMessage() {}
+
+func (x *StatusReport) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusReport.ProtoReflect.Descriptor instead.
+func (*StatusReport) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StatusReport) GetProcesses() []*ProcessUsage {
+	if x != nil {
+		return x.Processes
+	}
+	return nil
+}
+
+func (x *StatusReport) GetMemoryRssBytes() uint64 {
+	if x != nil {
+		return x.MemoryRssBytes
+	}
+	return 0
+}
+
+func (x *StatusReport) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *StatusReport) GetWorkspacePath() string {
+	if x != nil {
+		return x.WorkspacePath
+	}
+	return ""
+}
+
+func (x *StatusReport) GetWorkspaceUsedBytes() uint64 {
+	if x != nil {
+		return x.WorkspaceUsedBytes
+	}
+	return 0
+}
+
+func (x *StatusReport) GetWorkspaceTotalBytes() uint64 {
+	if x != nil {
+		return x.WorkspaceTotalBytes
+	}
+	return 0
+}
+
+func (x *StatusReport) GetClaudePid() int32 {
+	if x != nil {
+		return x.ClaudePid
+	}
+	return 0
+}
+
+func (x *StatusReport) GetCollectedAtUnix() int64 {
+	if x != nil {
+		return x.CollectedAtUnix
+	}
+	return 0
+}
+
+func (x *StatusReport) GetLastActivityUnix() int64 {
+	if x != nil {
+		return x.LastActivityUnix
+	}
+	return 0
+}
+
+// ProcessUsage is one process of the agent container.
+type ProcessUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Pid   int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Ppid  int32                  `protobuf:"varint,2,opt,name=ppid,proto3" json:"ppid,omitempty"`
+	// command is the process name (/proc/<pid>/comm).
+	Command  string `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	RssBytes uint64 `protobuf:"varint,4,opt,name=rss_bytes,json=rssBytes,proto3" json:"rss_bytes,omitempty"`
+	// cpu_percent is the process's CPU usage over the previous sample
+	// interval, in percent of one core.
+	CpuPercent    float64 `protobuf:"fixed64,5,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessUsage) Reset() {
+	*x = ProcessUsage{}
+	mi := &file_agent_v1_agent_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessUsage) ProtoMessage() {}
+
+func (x *ProcessUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessUsage.ProtoReflect.Descriptor instead.
+func (*ProcessUsage) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProcessUsage) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ProcessUsage) GetPpid() int32 {
+	if x != nil {
+		return x.Ppid
+	}
+	return 0
+}
+
+func (x *ProcessUsage) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ProcessUsage) GetRssBytes() uint64 {
+	if x != nil {
+		return x.RssBytes
+	}
+	return 0
+}
+
+func (x *ProcessUsage) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+// StatusAck is the empty ReportStatus response.
+type StatusAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusAck) Reset() {
+	*x = StatusAck{}
+	mi := &file_agent_v1_agent_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusAck) ProtoMessage() {}
+
+func (x *StatusAck) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_v1_agent_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusAck.ProtoReflect.Descriptor instead.
+func (*StatusAck) Descriptor() ([]byte, []int) {
+	return file_agent_v1_agent_proto_rawDescGZIP(), []int{4}
+}
+
 var File_agent_v1_agent_proto protoreflect.FileDescriptor
 
 const file_agent_v1_agent_proto_rawDesc = "" +
@@ -130,9 +373,30 @@ const file_agent_v1_agent_proto_rawDesc = "" +
 	"\n" +
 	"agent_name\x18\x01 \x01(\tR\tagentName\x12\x18\n" +
 	"\aproject\x18\x02 \x01(\tR\aproject\"\t\n" +
-	"\aWelcome2X\n" +
+	"\aWelcome\"\x9d\x03\n" +
+	"\fStatusReport\x12<\n" +
+	"\tprocesses\x18\x01 \x03(\v2\x1e.clawker.agent.v1.ProcessUsageR\tprocesses\x12(\n" +
+	"\x10memory_rss_bytes\x18\x02 \x01(\x04R\x0ememoryRssBytes\x12\x1f\n" +
+	"\vcpu_percent\x18\x03 \x01(\x01R\n" +
+	"cpuPercent\x12%\n" +
+	"\x0eworkspace_path\x18\x04 \x01(\tR\rworkspacePath\x120\n" +
+	"\x14workspace_used_bytes\x18\x05 \x01(\x04R\x12workspaceUsedBytes\x122\n" +
+	"\x15workspace_total_bytes\x18\x06 \x01(\x04R\x13workspaceTotalBytes\x12\x1d\n" +
+	"\n" +
+	"claude_pid\x18\a \x01(\x05R\tclaudePid\x12*\n" +
+	"\x11collected_at_unix\x18\b \x01(\x03R\x0fcollectedAtUnix\x12,\n" +
+	"\x12last_activity_unix\x18\t \x01(\x03R\x10lastActivityUnix\"\x8c\x01\n" +
+	"\fProcessUsage\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x12\n" +
+	"\x04ppid\x18\x02 \x01(\x05R\x04ppid\x12\x18\n" +
+	"\acommand\x18\x03 \x01(\tR\acommand\x12\x1b\n" +
+	"\trss_bytes\x18\x04 \x01(\x04R\brssBytes\x12\x1f\n" +
+	"\vcpu_percent\x18\x05 \x01(\x01R\n" +
+	"cpuPercent\"\v\n" +
+	"\tStatusAck2\xa5\x01\n" +
 	"\fAgentService\x12H\n" +
-	"\bRegister\x12!.clawker.agent.v1.RegisterRequest\x1a\x19.clawker.agent.v1.WelcomeB,Z*github.com/schmitthub/clawker/api/agent/v1b\x06proto3"
+	"\bRegister\x12!.clawker.agent.v1.RegisterRequest\x1a\x19.clawker.agent.v1.Welcome\x12K\n" +
+	"\fReportStatus\x12\x1e.clawker.agent.v1.StatusReport\x1a\x1b.clawker.agent.v1.StatusAckB,Z*github.com/schmitthub/clawker/api/agent/v1b\x06proto3"
 
 var (
 	file_agent_v1_agent_proto_rawDescOnce sync.Once
@@ -146,19 +410,25 @@ func file_agent_v1_agent_proto_rawDescGZIP() []byte {
 	return file_agent_v1_agent_proto_rawDescData
 }
 
-var file_agent_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_agent_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_agent_v1_agent_proto_goTypes = []any{
 	(*RegisterRequest)(nil), // 0: clawker.agent.v1.RegisterRequest
 	(*Welcome)(nil),         // 1: clawker.agent.v1.Welcome
+	(*StatusReport)(nil),    // 2: clawker.agent.v1.StatusReport
+	(*ProcessUsage)(nil),    // 3: clawker.agent.v1.ProcessUsage
+	(*StatusAck)(nil),       // 4: clawker.agent.v1.StatusAck
 }
 var file_agent_v1_agent_proto_depIdxs = []int32{
-	0, // 0: clawker.agent.v1.AgentService.Register:input_type -> clawker.agent.v1.RegisterRequest
-	1, // 1: clawker.agent.v1.AgentService.Register:output_type -> clawker.agent.v1.Welcome
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3, // 0: clawker.agent.v1.StatusReport.processes:type_name -> clawker.agent.v1.ProcessUsage
+	0, // 1: clawker.agent.v1.AgentService.Register:input_type -> clawker.agent.v1.RegisterRequest
+	2, // 2: clawker.agent.v1.AgentService.ReportStatus:input_type -> clawker.agent.v1.StatusReport
+	1, // 3: clawker.agent.v1.AgentService.Register:output_type -> clawker.agent.v1.Welcome
+	4, // 4: clawker.agent.v1.AgentService.ReportStatus:output_type -> clawker.agent.v1.StatusAck
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_agent_v1_agent_proto_init() }
@@ -172,7 +442,7 @@ func file_agent_v1_agent_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_agent_v1_agent_proto_rawDesc), len(file_agent_v1_agent_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AgentService_Register_FullMethodName = "/clawker.agent.v1.AgentService/Register"
+	AgentService_Register_FullMethodName     = "/clawker.agent.v1.AgentService/Register"
+	AgentService_ReportStatus_FullMethodName = "/clawker.agent.v1.AgentService/ReportStatus"
 )
 
 // AgentServiceClient is the client API for AgentService service.
@@ -27,9 +28,10 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
 // AgentService is the inbound gRPC surface clawkerd calls on the CP's
-// agent listener on the clawker network. Today the only RPC is Register, the
+// agent listener on the clawker network: Register, the
 // one-time-per-container provenance handshake CP triggers via a
-// RegisterRequired Command on the existing CP→clawkerd Session stream.
+// RegisterRequired Command on the existing CP→clawkerd Session stream, and
+// ReportStatus, clawkerd's periodic in-container health report.
 //
 // Trust model:
 //   - The CLI mints the agent's leaf cert at container-create time,
@@ -50,7 +52,11 @@ const (
 // Transport: mTLS over TCP on the CP's clawker network agent listener.
 // Server requires a client cert chained to the CLI CA; authorization
 // via Hydra-issued bearer tokens scoped to the `clawker-agent` OAuth2
-// client (scope `agent:self:register`).
+// client (scope `agent:self:register`). ReportStatus is the exception: it
+// is public (no bearer token), because the single-use assertion leaves
+// clawkerd no token to renew for a call it repeats for the container's
+// lifetime. The mTLS chain and the identity gate (cert CN, peer IP →
+// container labels → cert SAN) still apply to it.
 type AgentServiceClient interface {
 	// Register binds (peer cert thumbprint, container_id) into the CP's
 	// agentregistry. Container_id is read from the peer cert's URI SAN;
@@ -63,6 +69,14 @@ type AgentServiceClient interface {
 	// mismatch, peer-IP mismatch, label mismatch, thumbprint replay) or
 	// InvalidArgument (malformed identity fields).
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*Welcome, error)
+	// ReportStatus records clawkerd's latest resource-usage sample for the
+	// calling container: the agent process tree, the workspace filesystem
+	// and Claude Code liveness — the in-container view docker stats can't
+	// give. clawkerd sends one per consts.ClawkerdStatusInterval; CP keeps
+	// only the latest, in memory, and surfaces it through
+	// AdminService.ListAgents. The container is identified by the identity
+	// gate, never by the request.
+	ReportStatus(ctx context.Context, in *StatusReport, opts ...grpc.CallOption) (*StatusAck, error)
 }
 
 type agentServiceClient struct {
@@ -83,14 +97,25 @@ func (c *agentServiceClient) Register(ctx context.Context, in *RegisterRequest,
 	return out, nil
 }
 
+func (c *agentServiceClient) ReportStatus(ctx context.Context, in *StatusReport, opts ...grpc.CallOption) (*StatusAck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusAck)
+	err := c.cc.Invoke(ctx, AgentService_ReportStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServiceServer is the server API for AgentService service.
 // All implementations must embed UnimplementedAgentServiceServer
 // for forward compatibility.
 //
 // AgentService is the inbound gRPC surface clawkerd calls on the CP's
-// agent listener on the clawker network. Today the only RPC is Register, the
+// agent listener on the clawker network: Register, the
 // one-time-per-container provenance handshake CP triggers via a
-// RegisterRequired Command on the existing CP→clawkerd Session stream.
+// RegisterRequired Command on the existing CP→clawkerd Session stream, and
+// ReportStatus, clawkerd's periodic in-container health report.
 //
 // Trust model:
 //   - The CLI mints the agent's leaf cert at container-create time,
@@ -111,7 +136,11 @@ func (c *agentServiceClient) Register(ctx context.Context, in *RegisterRequest,
 // Transport: mTLS over TCP on the CP's clawker network agent listener.
 // Server requires a client cert chained to the CLI CA; authorization
 // via Hydra-issued bearer tokens scoped to the `clawker-agent` OAuth2
-// client (scope `agent:self:register`).
+// client (scope `agent:self:register`). ReportStatus is the exception: it
+// is public (no bearer token), because the single-use assertion leaves
+// clawkerd no token to renew for a call it repeats for the container's
+// lifetime. The mTLS chain and the identity gate (cert CN, peer IP →
+// container labels → cert SAN) still apply to it.
 type AgentServiceServer interface {
 	// Register binds (peer cert thumbprint, container_id) into the CP's
 	// agentregistry. Container_id is read from the peer cert's URI SAN;
@@ -124,6 +153,14 @@ type AgentServiceServer interface {
 	// mismatch, peer-IP mismatch, label mismatch, thumbprint replay) or
 	// InvalidArgument (malformed identity fields).
 	Register(context.Context, *RegisterRequest) (*Welcome, error)
+	// ReportStatus records clawkerd's latest resource-usage sample for the
+	// calling container: the agent process tree, the workspace filesystem
+	// and Claude Code liveness — the in-container view docker stats can't
+	// give. clawkerd sends one per consts.ClawkerdStatusInterval; CP keeps
+	// only the latest, in memory, and surfaces it through
+	// AdminService.ListAgents. The container is identified by the identity
+	// gate, never by the request.
+	ReportStatus(context.Context, *StatusReport) (*StatusAck, error)
 	mustEmbedUnimplementedAgentServiceServer()
 }
 
@@ -137,6 +174,9 @@ type UnimplementedAgentServiceServer struct{}
 func (UnimplementedAgentServiceServer) Register(context.Context, *RegisterRequest) (*Welcome, error) {
 	return nil, status.Error(codes.Unimplemented, "method Register not implemented")
 }
+func (UnimplementedAgentServiceServer) ReportStatus(context.Context, *StatusReport) (*StatusAck, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportStatus not implemented")
+}
 func (UnimplementedAgentServiceServer) mustEmbedUnimplementedAgentServiceServer() {}
 func (UnimplementedAgentServiceServer) testEmbeddedByValue()                      {}
 
@@ -176,6 +216,24 @@ func _AgentService_Register_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AgentService_ReportStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).ReportStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_ReportStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).ReportStatus(ctx, req.(*StatusReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AgentService_ServiceDesc is the grpc.ServiceDesc for AgentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -187,6 +245,10 @@ var AgentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Register",
 			Handler:    _AgentService_Register_Handler,
 		},
+		{
+			MethodName: "ReportStatus",
+			Handler:    _AgentService_ReportStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "agent/v1/agent.proto",
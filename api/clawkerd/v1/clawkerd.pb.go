@@ -108,6 +108,8 @@ type Command struct {
 	//	*Command_RegisterRequired
 	//	*Command_AgentReady
 	//	*Command_AgentInitialized
+	//	*Command_SetLogLevel
+	//	*Command_Notice
 	Payload       isCommand_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -229,6 +231,24 @@ func (x *Command) GetAgentInitialized() *AgentInitialized {
 	return nil
 }
 
+func (x *Command) GetSetLogLevel() *SetLogLevel {
+	if x != nil {
+		if x, ok := x.Payload.(*Command_SetLogLevel); ok {
+			return x.SetLogLevel
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetNotice() *Notice {
+	if x != nil {
+		if x, ok := x.Payload.(*Command_Notice); ok {
+			return x.Notice
+		}
+	}
+	return nil
+}
+
 type isCommand_Payload interface {
 	isCommand_Payload()
 }
@@ -265,6 +285,14 @@ type Command_AgentInitialized struct {
 	AgentInitialized *AgentInitialized `protobuf:"bytes,9,opt,name=agent_initialized,json=agentInitialized,proto3,oneof"`
 }
 
+type Command_SetLogLevel struct {
+	SetLogLevel *SetLogLevel `protobuf:"bytes,10,opt,name=set_log_level,json=setLogLevel,proto3,oneof"`
+}
+
+type Command_Notice struct {
+	Notice *Notice `protobuf:"bytes,11,opt,name=notice,proto3,oneof"`
+}
+
 func (*Command_Hello) isCommand_Payload() {}
 
 func (*Command_Shell) isCommand_Payload() {}
@@ -281,6 +309,10 @@ func (*Command_AgentReady) isCommand_Payload() {}
 
 func (*Command_AgentInitialized) isCommand_Payload() {}
 
+func (*Command_SetLogLevel) isCommand_Payload() {}
+
+func (*Command_Notice) isCommand_Payload() {}
+
 // Hello is the first Command CP sends after the Session stream
 // opens. clawkerd replies with HelloAck. Liveness is otherwise
 // maintained via gRPC keepalive.
@@ -456,6 +488,101 @@ func (*AgentInitialized) Descriptor() ([]byte, []int) {
 	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{4}
 }
 
+// SetLogLevel changes clawkerd's minimum log level at runtime. Reply:
+// Done{exit_code:0} on success; Error{INVALID_REQUEST} for an unknown
+// level.
+type SetLogLevel struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// level is one of "debug", "info", "warn", "error".
+	Level         string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevel) Reset() {
+	*x = SetLogLevel{}
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevel) ProtoMessage() {}
+
+func (x *SetLogLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevel.ProtoReflect.Descriptor instead.
+func (*SetLogLevel) Descriptor() ([]byte, []int) {
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SetLogLevel) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+// Notice asks clawkerd to show a message on the agent's console, e.g.
+// the idle reaper's stop warning. Reply: Done{exit_code:0} once written.
+type Notice struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// message is one line of text, shown as-is.
+	Message       string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notice) Reset() {
+	*x = Notice{}
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notice) ProtoMessage() {}
+
+func (x *Notice) ProtoReflect() protoreflect.Message {
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notice.ProtoReflect.Descriptor instead.
+func (*Notice) Descriptor() ([]byte, []int) {
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Notice) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 // ShellCommand starts a shell pipeline. stages.len == 1 runs a
 // single command; stages.len > 1 chains stage[i].stdout into
 // stage[i+1].stdin (a | b | c). stage[0].stdin is fed by
@@ -489,7 +616,7 @@ type ShellCommand struct {
 
 func (x *ShellCommand) Reset() {
 	*x = ShellCommand{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[5]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -501,7 +628,7 @@ func (x *ShellCommand) String() string {
 func (*ShellCommand) ProtoMessage() {}
 
 func (x *ShellCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[5]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,7 +641,7 @@ func (x *ShellCommand) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShellCommand.ProtoReflect.Descriptor instead.
 func (*ShellCommand) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{5}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ShellCommand) GetStages() []*PipeStage {
@@ -567,7 +694,7 @@ type PipeStage struct {
 
 func (x *PipeStage) Reset() {
 	*x = PipeStage{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[6]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -579,7 +706,7 @@ func (x *PipeStage) String() string {
 func (*PipeStage) ProtoMessage() {}
 
 func (x *PipeStage) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[6]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -592,7 +719,7 @@ func (x *PipeStage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PipeStage.ProtoReflect.Descriptor instead.
 func (*PipeStage) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{6}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PipeStage) GetArgv() []string {
@@ -641,7 +768,7 @@ type Stdin struct {
 
 func (x *Stdin) Reset() {
 	*x = Stdin{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[7]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -653,7 +780,7 @@ func (x *Stdin) String() string {
 func (*Stdin) ProtoMessage() {}
 
 func (x *Stdin) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[7]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -666,7 +793,7 @@ func (x *Stdin) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Stdin.ProtoReflect.Descriptor instead.
 func (*Stdin) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{7}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Stdin) GetData() []byte {
@@ -685,7 +812,7 @@ type CloseStdin struct {
 
 func (x *CloseStdin) Reset() {
 	*x = CloseStdin{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[8]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -697,7 +824,7 @@ func (x *CloseStdin) String() string {
 func (*CloseStdin) ProtoMessage() {}
 
 func (x *CloseStdin) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[8]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -710,7 +837,7 @@ func (x *CloseStdin) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseStdin.ProtoReflect.Descriptor instead.
 func (*CloseStdin) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{8}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{10}
 }
 
 // Signal sends a POSIX signal to every stage in the pipeline (or to
@@ -725,7 +852,7 @@ type Signal struct {
 
 func (x *Signal) Reset() {
 	*x = Signal{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[9]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -737,7 +864,7 @@ func (x *Signal) String() string {
 func (*Signal) ProtoMessage() {}
 
 func (x *Signal) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[9]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,7 +877,7 @@ func (x *Signal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Signal.ProtoReflect.Descriptor instead.
 func (*Signal) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{9}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Signal) GetSigno() int32 {
@@ -785,7 +912,7 @@ type Response struct {
 
 func (x *Response) Reset() {
 	*x = Response{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[10]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -797,7 +924,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[10]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -810,7 +937,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{10}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Response) GetCommandId() string {
@@ -946,7 +1073,7 @@ type HelloAck struct {
 
 func (x *HelloAck) Reset() {
 	*x = HelloAck{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[11]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -958,7 +1085,7 @@ func (x *HelloAck) String() string {
 func (*HelloAck) ProtoMessage() {}
 
 func (x *HelloAck) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[11]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -971,7 +1098,7 @@ func (x *HelloAck) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HelloAck.ProtoReflect.Descriptor instead.
 func (*HelloAck) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{11}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *HelloAck) GetInitialized() bool {
@@ -1002,7 +1129,7 @@ type RegisterDone struct {
 
 func (x *RegisterDone) Reset() {
 	*x = RegisterDone{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[12]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1014,7 +1141,7 @@ func (x *RegisterDone) String() string {
 func (*RegisterDone) ProtoMessage() {}
 
 func (x *RegisterDone) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[12]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1027,7 +1154,7 @@ func (x *RegisterDone) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterDone.ProtoReflect.Descriptor instead.
 func (*RegisterDone) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{12}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *RegisterDone) GetOk() bool {
@@ -1053,7 +1180,7 @@ type Started struct {
 
 func (x *Started) Reset() {
 	*x = Started{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[13]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1065,7 +1192,7 @@ func (x *Started) String() string {
 func (*Started) ProtoMessage() {}
 
 func (x *Started) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[13]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1078,7 +1205,7 @@ func (x *Started) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Started.ProtoReflect.Descriptor instead.
 func (*Started) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{13}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{15}
 }
 
 // OutputChunk carries the command's combined output: every stage's
@@ -1095,7 +1222,7 @@ type OutputChunk struct {
 
 func (x *OutputChunk) Reset() {
 	*x = OutputChunk{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[14]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1107,7 +1234,7 @@ func (x *OutputChunk) String() string {
 func (*OutputChunk) ProtoMessage() {}
 
 func (x *OutputChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[14]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1120,7 +1247,7 @@ func (x *OutputChunk) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OutputChunk.ProtoReflect.Descriptor instead.
 func (*OutputChunk) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{14}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *OutputChunk) GetData() []byte {
@@ -1144,7 +1271,7 @@ type StageExit struct {
 
 func (x *StageExit) Reset() {
 	*x = StageExit{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[15]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1156,7 +1283,7 @@ func (x *StageExit) String() string {
 func (*StageExit) ProtoMessage() {}
 
 func (x *StageExit) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[15]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1169,7 +1296,7 @@ func (x *StageExit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StageExit.ProtoReflect.Descriptor instead.
 func (*StageExit) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{15}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *StageExit) GetStageIndex() uint32 {
@@ -1204,7 +1331,7 @@ type Done struct {
 
 func (x *Done) Reset() {
 	*x = Done{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[16]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1216,7 +1343,7 @@ func (x *Done) String() string {
 func (*Done) ProtoMessage() {}
 
 func (x *Done) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[16]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1229,7 +1356,7 @@ func (x *Done) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Done.ProtoReflect.Descriptor instead.
 func (*Done) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{16}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Done) GetFinalExitCode() int32 {
@@ -1250,7 +1377,7 @@ type Error struct {
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[17]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1262,7 +1389,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[17]
+	mi := &file_clawkerd_v1_clawkerd_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1275,7 +1402,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{17}
+	return file_clawkerd_v1_clawkerd_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *Error) GetCode() ErrorCode {
@@ -1296,7 +1423,7 @@ var File_clawkerd_v1_clawkerd_proto protoreflect.FileDescriptor
 
 const file_clawkerd_v1_clawkerd_proto_rawDesc = "" +
 	"\n" +
-	"\x1aclawkerd/v1/clawkerd.proto\x12\x13clawker.clawkerd.v1\"\xc1\x04\n" +
+	"\x1aclawkerd/v1/clawkerd.proto\x12\x13clawker.clawkerd.v1\"\xc0\x05\n" +
 	"\aCommand\x12\x1d\n" +
 	"\n" +
 	"command_id\x18\x01 \x01(\tR\tcommandId\x122\n" +
@@ -1309,7 +1436,10 @@ const file_clawkerd_v1_clawkerd_proto_rawDesc = "" +
 	"\x11register_required\x18\a \x01(\v2%.clawker.clawkerd.v1.RegisterRequiredH\x00R\x10registerRequired\x12B\n" +
 	"\vagent_ready\x18\b \x01(\v2\x1f.clawker.clawkerd.v1.AgentReadyH\x00R\n" +
 	"agentReady\x12T\n" +
-	"\x11agent_initialized\x18\t \x01(\v2%.clawker.clawkerd.v1.AgentInitializedH\x00R\x10agentInitializedB\t\n" +
+	"\x11agent_initialized\x18\t \x01(\v2%.clawker.clawkerd.v1.AgentInitializedH\x00R\x10agentInitialized\x12F\n" +
+	"\rset_log_level\x18\n" +
+	" \x01(\v2 .clawker.clawkerd.v1.SetLogLevelH\x00R\vsetLogLevel\x125\n" +
+	"\x06notice\x18\v \x01(\v2\x1b.clawker.clawkerd.v1.NoticeH\x00R\x06noticeB\t\n" +
 	"\apayload\"\a\n" +
 	"\x05Hello\"\x12\n" +
 	"\x10RegisterRequired\"-\n" +
@@ -1317,7 +1447,11 @@ const file_clawkerd_v1_clawkerd_proto_rawDesc = "" +
 	"AgentReady\x12\x1f\n" +
 	"\vdefault_cmd\x18\x01 \x01(\tR\n" +
 	"defaultCmd\"\x12\n" +
-	"\x10AgentInitialized\"\xe0\x01\n" +
+	"\x10AgentInitialized\"#\n" +
+	"\vSetLogLevel\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\"\"\n" +
+	"\x06Notice\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xe0\x01\n" +
 	"\fShellCommand\x126\n" +
 	"\x06stages\x18\x01 \x03(\v2\x1e.clawker.clawkerd.v1.PipeStageR\x06stages\x12'\n" +
 	"\x0ftimeout_seconds\x18\x02 \x01(\rR\x0etimeoutSeconds\x12#\n" +
@@ -1395,7 +1529,7 @@ func file_clawkerd_v1_clawkerd_proto_rawDescGZIP() []byte {
 }
 
 var file_clawkerd_v1_clawkerd_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_clawkerd_v1_clawkerd_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_clawkerd_v1_clawkerd_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_clawkerd_v1_clawkerd_proto_goTypes = []any{
 	(ErrorCode)(0),           // 0: clawker.clawkerd.v1.ErrorCode
 	(*Command)(nil),          // 1: clawker.clawkerd.v1.Command
@@ -1403,47 +1537,51 @@ var file_clawkerd_v1_clawkerd_proto_goTypes = []any{
 	(*RegisterRequired)(nil), // 3: clawker.clawkerd.v1.RegisterRequired
 	(*AgentReady)(nil),       // 4: clawker.clawkerd.v1.AgentReady
 	(*AgentInitialized)(nil), // 5: clawker.clawkerd.v1.AgentInitialized
-	(*ShellCommand)(nil),     // 6: clawker.clawkerd.v1.ShellCommand
-	(*PipeStage)(nil),        // 7: clawker.clawkerd.v1.PipeStage
-	(*Stdin)(nil),            // 8: clawker.clawkerd.v1.Stdin
-	(*CloseStdin)(nil),       // 9: clawker.clawkerd.v1.CloseStdin
-	(*Signal)(nil),           // 10: clawker.clawkerd.v1.Signal
-	(*Response)(nil),         // 11: clawker.clawkerd.v1.Response
-	(*HelloAck)(nil),         // 12: clawker.clawkerd.v1.HelloAck
-	(*RegisterDone)(nil),     // 13: clawker.clawkerd.v1.RegisterDone
-	(*Started)(nil),          // 14: clawker.clawkerd.v1.Started
-	(*OutputChunk)(nil),      // 15: clawker.clawkerd.v1.OutputChunk
-	(*StageExit)(nil),        // 16: clawker.clawkerd.v1.StageExit
-	(*Done)(nil),             // 17: clawker.clawkerd.v1.Done
-	(*Error)(nil),            // 18: clawker.clawkerd.v1.Error
-	nil,                      // 19: clawker.clawkerd.v1.PipeStage.EnvEntry
+	(*SetLogLevel)(nil),      // 6: clawker.clawkerd.v1.SetLogLevel
+	(*Notice)(nil),           // 7: clawker.clawkerd.v1.Notice
+	(*ShellCommand)(nil),     // 8: clawker.clawkerd.v1.ShellCommand
+	(*PipeStage)(nil),        // 9: clawker.clawkerd.v1.PipeStage
+	(*Stdin)(nil),            // 10: clawker.clawkerd.v1.Stdin
+	(*CloseStdin)(nil),       // 11: clawker.clawkerd.v1.CloseStdin
+	(*Signal)(nil),           // 12: clawker.clawkerd.v1.Signal
+	(*Response)(nil),         // 13: clawker.clawkerd.v1.Response
+	(*HelloAck)(nil),         // 14: clawker.clawkerd.v1.HelloAck
+	(*RegisterDone)(nil),     // 15: clawker.clawkerd.v1.RegisterDone
+	(*Started)(nil),          // 16: clawker.clawkerd.v1.Started
+	(*OutputChunk)(nil),      // 17: clawker.clawkerd.v1.OutputChunk
+	(*StageExit)(nil),        // 18: clawker.clawkerd.v1.StageExit
+	(*Done)(nil),             // 19: clawker.clawkerd.v1.Done
+	(*Error)(nil),            // 20: clawker.clawkerd.v1.Error
+	nil,                      // 21: clawker.clawkerd.v1.PipeStage.EnvEntry
 }
 var file_clawkerd_v1_clawkerd_proto_depIdxs = []int32{
 	2,  // 0: clawker.clawkerd.v1.Command.hello:type_name -> clawker.clawkerd.v1.Hello
-	6,  // 1: clawker.clawkerd.v1.Command.shell:type_name -> clawker.clawkerd.v1.ShellCommand
-	8,  // 2: clawker.clawkerd.v1.Command.stdin:type_name -> clawker.clawkerd.v1.Stdin
-	9,  // 3: clawker.clawkerd.v1.Command.close_stdin:type_name -> clawker.clawkerd.v1.CloseStdin
-	10, // 4: clawker.clawkerd.v1.Command.signal:type_name -> clawker.clawkerd.v1.Signal
+	8,  // 1: clawker.clawkerd.v1.Command.shell:type_name -> clawker.clawkerd.v1.ShellCommand
+	10, // 2: clawker.clawkerd.v1.Command.stdin:type_name -> clawker.clawkerd.v1.Stdin
+	11, // 3: clawker.clawkerd.v1.Command.close_stdin:type_name -> clawker.clawkerd.v1.CloseStdin
+	12, // 4: clawker.clawkerd.v1.Command.signal:type_name -> clawker.clawkerd.v1.Signal
 	3,  // 5: clawker.clawkerd.v1.Command.register_required:type_name -> clawker.clawkerd.v1.RegisterRequired
 	4,  // 6: clawker.clawkerd.v1.Command.agent_ready:type_name -> clawker.clawkerd.v1.AgentReady
 	5,  // 7: clawker.clawkerd.v1.Command.agent_initialized:type_name -> clawker.clawkerd.v1.AgentInitialized
-	7,  // 8: clawker.clawkerd.v1.ShellCommand.stages:type_name -> clawker.clawkerd.v1.PipeStage
-	19, // 9: clawker.clawkerd.v1.PipeStage.env:type_name -> clawker.clawkerd.v1.PipeStage.EnvEntry
-	12, // 10: clawker.clawkerd.v1.Response.hello_ack:type_name -> clawker.clawkerd.v1.HelloAck
-	14, // 11: clawker.clawkerd.v1.Response.started:type_name -> clawker.clawkerd.v1.Started
-	15, // 12: clawker.clawkerd.v1.Response.output:type_name -> clawker.clawkerd.v1.OutputChunk
-	16, // 13: clawker.clawkerd.v1.Response.stage_exit:type_name -> clawker.clawkerd.v1.StageExit
-	17, // 14: clawker.clawkerd.v1.Response.done:type_name -> clawker.clawkerd.v1.Done
-	18, // 15: clawker.clawkerd.v1.Response.error:type_name -> clawker.clawkerd.v1.Error
-	13, // 16: clawker.clawkerd.v1.Response.register_done:type_name -> clawker.clawkerd.v1.RegisterDone
-	0,  // 17: clawker.clawkerd.v1.Error.code:type_name -> clawker.clawkerd.v1.ErrorCode
-	1,  // 18: clawker.clawkerd.v1.ClawkerdService.Session:input_type -> clawker.clawkerd.v1.Command
-	11, // 19: clawker.clawkerd.v1.ClawkerdService.Session:output_type -> clawker.clawkerd.v1.Response
-	19, // [19:20] is the sub-list for method output_type
-	18, // [18:19] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	6,  // 8: clawker.clawkerd.v1.Command.set_log_level:type_name -> clawker.clawkerd.v1.SetLogLevel
+	7,  // 9: clawker.clawkerd.v1.Command.notice:type_name -> clawker.clawkerd.v1.Notice
+	9,  // 10: clawker.clawkerd.v1.ShellCommand.stages:type_name -> clawker.clawkerd.v1.PipeStage
+	21, // 11: clawker.clawkerd.v1.PipeStage.env:type_name -> clawker.clawkerd.v1.PipeStage.EnvEntry
+	14, // 12: clawker.clawkerd.v1.Response.hello_ack:type_name -> clawker.clawkerd.v1.HelloAck
+	16, // 13: clawker.clawkerd.v1.Response.started:type_name -> clawker.clawkerd.v1.Started
+	17, // 14: clawker.clawkerd.v1.Response.output:type_name -> clawker.clawkerd.v1.OutputChunk
+	18, // 15: clawker.clawkerd.v1.Response.stage_exit:type_name -> clawker.clawkerd.v1.StageExit
+	19, // 16: clawker.clawkerd.v1.Response.done:type_name -> clawker.clawkerd.v1.Done
+	20, // 17: clawker.clawkerd.v1.Response.error:type_name -> clawker.clawkerd.v1.Error
+	15, // 18: clawker.clawkerd.v1.Response.register_done:type_name -> clawker.clawkerd.v1.RegisterDone
+	0,  // 19: clawker.clawkerd.v1.Error.code:type_name -> clawker.clawkerd.v1.ErrorCode
+	1,  // 20: clawker.clawkerd.v1.ClawkerdService.Session:input_type -> clawker.clawkerd.v1.Command
+	13, // 21: clawker.clawkerd.v1.ClawkerdService.Session:output_type -> clawker.clawkerd.v1.Response
+	21, // [21:22] is the sub-list for method output_type
+	20, // [20:21] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_clawkerd_v1_clawkerd_proto_init() }
@@ -1460,8 +1598,10 @@ func file_clawkerd_v1_clawkerd_proto_init() {
 		(*Command_RegisterRequired)(nil),
 		(*Command_AgentReady)(nil),
 		(*Command_AgentInitialized)(nil),
+		(*Command_SetLogLevel)(nil),
+		(*Command_Notice)(nil),
 	}
-	file_clawkerd_v1_clawkerd_proto_msgTypes[10].OneofWrappers = []any{
+	file_clawkerd_v1_clawkerd_proto_msgTypes[12].OneofWrappers = []any{
 		(*Response_HelloAck)(nil),
 		(*Response_Started)(nil),
 		(*Response_Output)(nil),
@@ -1476,7 +1616,7 @@ func file_clawkerd_v1_clawkerd_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_clawkerd_v1_clawkerd_proto_rawDesc), len(file_clawkerd_v1_clawkerd_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   19,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -129,6 +129,20 @@ func (p *progressReporter) WriteOutput(b []byte) {
 	fmt.Fprintf(p.out, "%s", b)
 }
 
+// Notice writes a CP-requested one-line message (the idle reaper's stop
+// warning) to the console. Unlike the step lines it is not muted after
+// Final or Stop: a Notice is an explicit interruption the user must see
+// while the user CMD owns the TTY. The line is framed with CRLF so it
+// reads cleanly over a raw-mode terminal. Nil-tolerant.
+func (p *progressReporter) Notice(msg string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprintf(p.out, "\r\n%s %s\r\n", p.warn(), msg)
+}
+
 // finalLabel is the fixed closing-banner text. Hard-coded (not a
 // parameter) so a caller can't accidentally burn the once-only Final
 // slot with an empty string.
@@ -175,6 +189,15 @@ func (p *progressReporter) info() string {
 	return "\033[36mℹ\033[0m"
 }
 
+// warn returns the standard warning icon — yellow ! on a TTY, [warn]
+// as ASCII fallback, mirroring iostreams.ColorScheme.WarningIcon.
+func (p *progressReporter) warn() string {
+	if !p.isTTY {
+		return "[warn]"
+	}
+	return "\033[33m!\033[0m"
+}
+
 func (p *progressReporter) green(s string) string {
 	if !p.isTTY {
 		return s
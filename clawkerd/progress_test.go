@@ -163,4 +163,5 @@ func TestProgressReporter_NilReceiverSafe(t *testing.T) {
 	p.EndStep(label, false)
 	p.Final()
 	p.Stop()
+	p.Notice("x")
 }
@@ -0,0 +1,140 @@
+package clawkerd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// Process supervisors named in consts.ServicesSupervisorFile by the image's
+// build-time services install step (internal/bundler).
+const (
+	supervisorNone    = "none"
+	supervisorS6      = "s6"
+	supervisorRunit   = "runit"
+	supervisorSystemd = "systemd"
+)
+
+// Service restart policies as written to the services manifest. Mirrors
+// config.ServiceRestart*; clawkerd does not import the config package.
+const (
+	restartAlways    = "always"
+	restartOnFailure = "on-failure"
+	restartNo        = "no"
+)
+
+// serviceStableUptime is how long a service must stay up before its restart
+// backoff resets. A service that crash-loops faster than this backs off up
+// to serviceMaxBackoff instead of hot-spinning.
+const serviceStableUptime = 10 * time.Second
+
+// serviceMaxBackoff caps the delay between restarts of a failing service.
+const serviceMaxBackoff = 30 * time.Second
+
+// ServiceSpec is one entry of the services manifest the bundler bakes into
+// the image (consts.ServicesManifestFile). The command, env, and workdir
+// live in the per-service launch script under bin/<name>.
+type ServiceSpec struct {
+	Name    string `json:"name"`
+	Restart string `json:"restart"`
+}
+
+// serviceManifest is the services block as baked into the image.
+type serviceManifest struct {
+	supervisor string
+	services   []ServiceSpec
+}
+
+// loadServiceManifest reads the services block under dir. A missing dir
+// means the project declares no services and yields (nil, nil). A missing
+// supervisor marker is treated as supervisorNone so an image whose install
+// step never ran still gets its services supervised.
+func loadServiceManifest(dir string) (*serviceManifest, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, consts.ServicesManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read services manifest: %w", err)
+	}
+	var services []ServiceSpec
+	if err := json.Unmarshal(raw, &services); err != nil {
+		return nil, fmt.Errorf("parse services manifest: %w", err)
+	}
+	for _, svc := range services {
+		if svc.Name == "" || strings.ContainsAny(svc.Name, `/\`) {
+			return nil, fmt.Errorf("services manifest: invalid service name %q", svc.Name)
+		}
+		switch svc.Restart {
+		case restartAlways, restartOnFailure, restartNo:
+		default:
+			return nil, fmt.Errorf("services manifest: service %s: unknown restart policy %q", svc.Name, svc.Restart)
+		}
+	}
+
+	supervisor := supervisorNone
+	marker, err := os.ReadFile(filepath.Join(dir, consts.ServicesSupervisorFile))
+	switch {
+	case err == nil:
+		supervisor = strings.TrimSpace(string(marker))
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("read services supervisor marker: %w", err)
+	}
+	switch supervisor {
+	case supervisorNone, supervisorS6, supervisorRunit, supervisorSystemd:
+	default:
+		return nil, fmt.Errorf("services supervisor marker: unknown supervisor %q", supervisor)
+	}
+	return &serviceManifest{supervisor: supervisor, services: services}, nil
+}
+
+// shouldRestart reports whether a service that exited with exitCode
+// (bash convention, see mapExitCode) is restarted under policy.
+func shouldRestart(policy string, exitCode int) bool {
+	switch policy {
+	case restartAlways:
+		return true
+	case restartOnFailure:
+		return exitCode != 0
+	default:
+		return false
+	}
+}
+
+// restartBackoff returns the delay before the n-th consecutive restart
+// (0-based): 1s doubling up to serviceMaxBackoff.
+func restartBackoff(n int) time.Duration {
+	d := time.Second
+	for range n {
+		d *= 2
+		if d >= serviceMaxBackoff {
+			return serviceMaxBackoff
+		}
+	}
+	return d
+}
+
+// supervisorArgv returns the command that runs supervisor's root process
+// over the services installed at build time.
+func supervisorArgv(supervisor, scanDir string) []string {
+	switch supervisor {
+	case supervisorS6:
+		return []string{"s6-svscan", scanDir}
+	case supervisorRunit:
+		return []string{"runsvdir", "-P", scanDir}
+	case supervisorSystemd:
+		for _, p := range []string{"/usr/lib/systemd/systemd", "/lib/systemd/systemd"} {
+			if _, err := os.Stat(p); err == nil {
+				return []string{p, "--user"}
+			}
+		}
+		return []string{"/usr/lib/systemd/systemd", "--user"}
+	}
+	return nil
+}
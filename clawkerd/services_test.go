@@ -0,0 +1,87 @@
+package clawkerd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// writeServicesDir lays out a baked services dir: the manifest, the
+// supervisor marker (skipped when ""), and a launch script per entry.
+func writeServicesDir(t *testing.T, manifest, supervisor string, scripts map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, consts.ServicesManifestFile), []byte(manifest), 0o644))
+	if supervisor != "" {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, consts.ServicesSupervisorFile), []byte(supervisor+"\n"), 0o644))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "bin"), 0o755))
+	for name, body := range scripts {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "bin", name), []byte(body), 0o755))
+	}
+	return dir
+}
+
+func TestLoadServiceManifest(t *testing.T) {
+	t.Run("missing dir means no services", func(t *testing.T) {
+		m, err := loadServiceManifest(filepath.Join(t.TempDir(), "absent"))
+		require.NoError(t, err)
+		assert.Nil(t, m)
+	})
+
+	t.Run("marker selects supervisor", func(t *testing.T) {
+		dir := writeServicesDir(t, `[{"name":"redis","restart":"on-failure"}]`, "runit", nil)
+		m, err := loadServiceManifest(dir)
+		require.NoError(t, err)
+		assert.Equal(t, supervisorRunit, m.supervisor)
+		assert.Equal(t, []ServiceSpec{{Name: "redis", Restart: restartOnFailure}}, m.services)
+	})
+
+	t.Run("missing marker falls back to clawkerd", func(t *testing.T) {
+		dir := writeServicesDir(t, `[{"name":"redis","restart":"always"}]`, "", nil)
+		m, err := loadServiceManifest(dir)
+		require.NoError(t, err)
+		assert.Equal(t, supervisorNone, m.supervisor)
+	})
+
+	for name, tc := range map[string]struct{ manifest, supervisor string }{
+		"malformed json":     {`{`, "none"},
+		"path in name":       {`[{"name":"../x","restart":"always"}]`, "none"},
+		"unknown restart":    {`[{"name":"x","restart":"sometimes"}]`, "none"},
+		"unknown supervisor": {`[{"name":"x","restart":"always"}]`, "openrc"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := loadServiceManifest(writeServicesDir(t, tc.manifest, tc.supervisor, nil))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestShouldRestart(t *testing.T) {
+	assert.True(t, shouldRestart(restartAlways, 0))
+	assert.True(t, shouldRestart(restartAlways, 1))
+	assert.False(t, shouldRestart(restartOnFailure, 0))
+	assert.True(t, shouldRestart(restartOnFailure, 143))
+	assert.False(t, shouldRestart(restartNo, 1))
+}
+
+func TestRestartBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, restartBackoff(0))
+	assert.Equal(t, 4*time.Second, restartBackoff(2))
+	assert.Equal(t, serviceMaxBackoff, restartBackoff(5))
+	assert.Equal(t, serviceMaxBackoff, restartBackoff(100))
+}
+
+func TestSupervisorArgv(t *testing.T) {
+	assert.Equal(t, []string{"s6-svscan", "/scan"}, supervisorArgv(supervisorS6, "/scan"))
+	assert.Equal(t, []string{"runsvdir", "-P", "/scan"}, supervisorArgv(supervisorRunit, "/scan"))
+	argv := supervisorArgv(supervisorSystemd, "/scan")
+	require.Len(t, argv, 2)
+	assert.Equal(t, "--user", argv[1])
+}
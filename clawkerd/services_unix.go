@@ -0,0 +1,341 @@
+//go:build unix
+
+package clawkerd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// ServiceSupervisor runs the project's services: block inside the agent
+// container. When the image ships a process supervisor (s6, runit, systemd
+// user units) the bundler installed the services for it at build time and
+// clawkerd only runs that supervisor's root process; otherwise clawkerd
+// supervises each service itself. Either way every process runs as the
+// container user in its own process group, with stdout/stderr appended to
+// a per-unit log file under the clawker log dir.
+//
+// Lifecycle: Start (idempotent, via WrapEntry when the user CMD spawns) →
+// Stop(grace). Stop MUST complete before the spawn reaper's
+// BeginOrphanDrain: each unit is reaped by its own exec.Cmd.Wait, which a
+// phase-2 Wait4(-1) would otherwise steal. A nil *ServiceSupervisor (no
+// services declared) is valid and every method is a no-op.
+type ServiceSupervisor struct {
+	log   *logger.Logger
+	units []*serviceUnit
+
+	mu        sync.Mutex
+	started   bool
+	stopped   bool
+	wg        sync.WaitGroup
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+	stopOnce  sync.Once
+	startOnce sync.Once
+	doneOnce  sync.Once
+}
+
+// serviceUnit is one supervised process: a service's launch script, or
+// the root process of the image's own supervisor.
+type serviceUnit struct {
+	name    string
+	argv    []string
+	env     []string
+	dir     string
+	user    *ExecUser
+	restart string
+	logPath string
+	// runtimeDir is created (owned by user) before the first start; "" = skip.
+	runtimeDir string
+
+	mu   sync.Mutex
+	pgid int // 0 while not running
+}
+
+// NewServiceSupervisor loads the services block baked under servicesDir
+// (consts.ServicesDir in production) and prepares one unit per service, or
+// a single unit for the image's supervisor. Returns (nil, nil) when the
+// image has no services. Unit logs go to logsDir as service-<name>.log.
+func NewServiceSupervisor(log *logger.Logger, servicesDir, logsDir string, user *ExecUser) (*ServiceSupervisor, error) {
+	manifest, err := loadServiceManifest(servicesDir)
+	if err != nil || manifest == nil || len(manifest.services) == 0 {
+		return nil, err
+	}
+
+	env := envForUser(os.Environ(), user)
+	dir := ""
+	if user != nil {
+		dir = user.Home()
+	}
+
+	s := &ServiceSupervisor{
+		log:    log,
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+	if manifest.supervisor == supervisorNone {
+		for _, svc := range manifest.services {
+			s.units = append(s.units, &serviceUnit{
+				name:    svc.Name,
+				argv:    []string{filepath.Join(servicesDir, "bin", svc.Name)},
+				env:     env,
+				dir:     dir,
+				user:    user,
+				restart: svc.Restart,
+				logPath: filepath.Join(logsDir, "service-"+svc.Name+".log"),
+			})
+		}
+		return s, nil
+	}
+
+	// The image's supervisor owns restart policy for the services; clawkerd
+	// only keeps the supervisor itself alive.
+	unit := &serviceUnit{
+		name:    manifest.supervisor,
+		argv:    supervisorArgv(manifest.supervisor, consts.ServicesScanDir),
+		env:     env,
+		dir:     dir,
+		user:    user,
+		restart: restartOnFailure,
+		logPath: filepath.Join(logsDir, "service-"+manifest.supervisor+".log"),
+	}
+	if manifest.supervisor == supervisorSystemd && user != nil {
+		// systemd --user needs its runtime dir; there is no logind to make it.
+		unit.runtimeDir = "/run/user/" + strconv.FormatUint(uint64(user.UID()), 10)
+		unit.env = append(unit.env, "XDG_RUNTIME_DIR="+unit.runtimeDir)
+	}
+	s.units = []*serviceUnit{unit}
+	return s, nil
+}
+
+// WrapEntry returns a spawn entry that starts the services before running
+// entry, so they are up by the time the user CMD execs. Services start at
+// most once; a Session reconnect re-dispatching AgentReady is harmless.
+func (s *ServiceSupervisor) WrapEntry(entry func(string) error) func(string) error {
+	if s == nil {
+		return entry
+	}
+	return func(defaultCmd string) error {
+		s.Start()
+		return entry(defaultCmd)
+	}
+}
+
+// Start launches a supervising goroutine per unit. Idempotent; a no-op
+// after Stop.
+func (s *ServiceSupervisor) Start() {
+	if s == nil {
+		return
+	}
+	s.startOnce.Do(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.stopped {
+			return
+		}
+		s.started = true
+		for _, u := range s.units {
+			s.wg.Add(1)
+			go s.supervise(u)
+		}
+		s.log.Info().
+			Str("event", "services_started").
+			Int("units", len(s.units)).
+			Msg("clawkerd: project services started")
+	})
+}
+
+// Stop terminates every unit: SIGTERM to each process group, SIGKILL after
+// grace, then waits until all are reaped. Idempotent and safe to call
+// concurrently — every caller returns once the units are gone.
+func (s *ServiceSupervisor) Stop(grace time.Duration) {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		s.mu.Lock()
+		s.stopped = true
+		started := s.started
+		s.mu.Unlock()
+		close(s.stopCh)
+		if !started {
+			s.closeDone()
+			return
+		}
+		s.signalAll(unix.SIGTERM)
+		go func() {
+			// A panic must not strand Stop's callers: kill and release them.
+			defer recoverGoroutine(s.log, "services_stop", func() {
+				s.signalAll(unix.SIGKILL)
+				s.closeDone()
+			})
+			allExited := make(chan struct{})
+			go func() {
+				s.wg.Wait()
+				close(allExited)
+			}()
+			select {
+			case <-allExited:
+			case <-time.After(grace):
+				s.log.Warn().
+					Str("event", "services_stop_escalated").
+					Dur("grace", grace).
+					Msg("clawkerd: services did not exit within grace; sending SIGKILL")
+				s.signalAll(unix.SIGKILL)
+				<-allExited
+			}
+			s.closeDone()
+		}()
+	})
+	<-s.doneCh
+}
+
+func (s *ServiceSupervisor) closeDone() {
+	s.doneOnce.Do(func() { close(s.doneCh) })
+}
+
+func (s *ServiceSupervisor) signalAll(sig unix.Signal) {
+	for _, u := range s.units {
+		u.mu.Lock()
+		pgid := u.pgid
+		u.mu.Unlock()
+		if pgid == 0 {
+			continue
+		}
+		if err := unix.Kill(-pgid, sig); err != nil && !errors.Is(err, unix.ESRCH) {
+			s.log.Warn().Err(err).
+				Str("event", "service_signal_failed").
+				Str("service", u.name).
+				Int("pgid", pgid).
+				Msg("clawkerd: signal to service pgroup failed")
+		}
+	}
+}
+
+// supervise runs u until Stop, restarting it per its policy with backoff.
+func (s *ServiceSupervisor) supervise(u *serviceUnit) {
+	defer s.wg.Done()
+	defer recoverGoroutine(s.log, "service_"+u.name, nil)
+
+	failures := 0
+	for {
+		started := time.Now()
+		code, err := u.runOnce(s.stopCh)
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+		if err != nil {
+			s.log.Error().Err(err).
+				Str("event", "service_start_failed").
+				Str("service", u.name).
+				Msg("clawkerd: service failed to start")
+			code = 127
+		}
+		if !shouldRestart(u.restart, code) {
+			s.log.Info().
+				Str("event", "service_exited").
+				Str("service", u.name).
+				Int("exit_code", code).
+				Msg("clawkerd: service exited; not restarting")
+			return
+		}
+		if time.Since(started) >= serviceStableUptime {
+			failures = 0
+		}
+		delay := restartBackoff(failures)
+		failures++
+		s.log.Warn().
+			Str("event", "service_restarting").
+			Str("service", u.name).
+			Int("exit_code", code).
+			Dur("delay", delay).
+			Msg("clawkerd: service exited; restarting")
+		select {
+		case <-s.stopCh:
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// errServiceStopping is returned by runOnce when Stop won the race with a
+// (re)start; supervise exits on the closed stopCh before inspecting it.
+var errServiceStopping = errors.New("clawkerd: services stopping")
+
+// runOnce starts u and waits for it to exit, returning its bash-convention
+// exit code. The start happens under u.mu after a stopCh check, so Stop
+// either sees the pgid and signals it or the start never happens.
+func (u *serviceUnit) runOnce(stopCh <-chan struct{}) (int, error) {
+	if u.runtimeDir != "" {
+		if err := ensureUserDir(u.runtimeDir, u.user); err != nil {
+			return 0, err
+		}
+	}
+	//nolint:gosec // log path is clawkerd-owned, built from the validated service name
+	logFile, err := os.OpenFile(u.logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("open service log: %w", err)
+	}
+	defer logFile.Close()
+
+	//nolint:gosec // argv is the baked launch script or the detected supervisor
+	cmd := exec.Command(u.argv[0], u.argv[1:]...)
+	cmd.Env = u.env
+	cmd.Dir = u.dir
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = buildSysProcAttr(u.user, -1)
+
+	u.mu.Lock()
+	select {
+	case <-stopCh:
+		u.mu.Unlock()
+		return 0, errServiceStopping
+	default:
+	}
+	if err := cmd.Start(); err != nil {
+		u.mu.Unlock()
+		return 0, fmt.Errorf("start %s: %w", u.argv[0], err)
+	}
+	// Setpgid: the child leads its own process group.
+	u.pgid = cmd.Process.Pid
+	u.mu.Unlock()
+
+	waitErr := cmd.Wait()
+	u.mu.Lock()
+	u.pgid = 0
+	u.mu.Unlock()
+
+	var exitErr *exec.ExitError
+	if waitErr != nil && !errors.As(waitErr, &exitErr) {
+		return 0, fmt.Errorf("wait %s: %w", u.argv[0], waitErr)
+	}
+	return mapExitCode(cmd.ProcessState), nil
+}
+
+// ensureUserDir creates dir (mode 0700) owned by user.
+func ensureUserDir(dir string, user *ExecUser) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create %s: %w", dir, err)
+	}
+	if user == nil {
+		return nil
+	}
+	if err := os.Chown(dir, int(user.UID()), int(user.GID())); err != nil {
+		return fmt.Errorf("chown %s: %w", dir, err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+//go:build unix
+
+package clawkerd
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+func TestNewServiceSupervisor_NoServices(t *testing.T) {
+	s, err := NewServiceSupervisor(logger.Nop(), filepath.Join(t.TempDir(), "absent"), t.TempDir(), nil)
+	require.NoError(t, err)
+	assert.Nil(t, s)
+
+	// A nil supervisor passes the entry through and stops instantly.
+	called := false
+	entry := s.WrapEntry(func(string) error { called = true; return nil })
+	require.NoError(t, entry("claude"))
+	assert.True(t, called)
+	s.Stop(time.Second)
+}
+
+func TestNewServiceSupervisor_ImageSupervisor(t *testing.T) {
+	dir := writeServicesDir(t, `[{"name":"a","restart":"always"},{"name":"b","restart":"no"}]`, "s6", nil)
+	s, err := NewServiceSupervisor(logger.Nop(), dir, t.TempDir(), nil)
+	require.NoError(t, err)
+	require.Len(t, s.units, 1, "an image supervisor runs as a single unit owning every service")
+	assert.Equal(t, "s6-svscan", s.units[0].argv[0])
+}
+
+// countingScript appends a line to a counter file per run, then exits
+// with code.
+func countingScript(counter string, code int) string {
+	return "#!/bin/sh\necho run >> '" + counter + "'\nexit " + strconv.Itoa(code) + "\n"
+}
+
+func TestServiceSupervisor_RestartPolicies(t *testing.T) {
+	tmp := t.TempDir()
+	scripts := map[string]string{
+		"ok":     countingScript(filepath.Join(tmp, "ok"), 0),
+		"failer": countingScript(filepath.Join(tmp, "failer"), 1),
+		"once":   countingScript(filepath.Join(tmp, "once"), 1),
+	}
+	dir := writeServicesDir(t,
+		`[{"name":"ok","restart":"on-failure"},{"name":"failer","restart":"on-failure"},{"name":"once","restart":"no"}]`,
+		"none", scripts)
+	logs := t.TempDir()
+	s, err := NewServiceSupervisor(logger.Nop(), dir, logs, nil)
+	require.NoError(t, err)
+	require.Len(t, s.units, 3)
+
+	s.Start()
+	s.Start() // idempotent
+
+	// failer restarts after the 1s initial backoff.
+	require.Eventually(t, func() bool { return runs(t, filepath.Join(tmp, "failer")) >= 2 },
+		5*time.Second, 50*time.Millisecond)
+	s.Stop(time.Second)
+
+	assert.Equal(t, 1, runs(t, filepath.Join(tmp, "ok")), "clean exit is not restarted under on-failure")
+	assert.Equal(t, 1, runs(t, filepath.Join(tmp, "once")), "restart: no never restarts")
+	_, err = os.Stat(filepath.Join(logs, "service-failer.log"))
+	assert.NoError(t, err, "each service logs to its own file")
+}
+
+func TestServiceSupervisor_StopTerminatesRunning(t *testing.T) {
+	tmp := t.TempDir()
+	started := filepath.Join(tmp, "started")
+	dir := writeServicesDir(t, `[{"name":"sleeper","restart":"always"}]`, "none", map[string]string{
+		// Ignores SIGTERM so Stop has to escalate.
+		"sleeper": "#!/bin/sh\ntrap '' TERM\ntouch '" + started + "'\nwhile :; do sleep 1; done\n",
+	})
+	s, err := NewServiceSupervisor(logger.Nop(), dir, t.TempDir(), nil)
+	require.NoError(t, err)
+
+	s.Start()
+	require.Eventually(t, func() bool { _, err := os.Stat(started); return err == nil },
+		5*time.Second, 20*time.Millisecond)
+
+	begin := time.Now()
+	s.Stop(200 * time.Millisecond)
+	assert.Less(t, time.Since(begin), 5*time.Second, "SIGKILL escalation bounds Stop")
+
+	// Concurrent or repeated callers return once the units are gone.
+	s.Stop(time.Second)
+	s.units[0].mu.Lock()
+	defer s.units[0].mu.Unlock()
+	assert.Zero(t, s.units[0].pgid)
+}
+
+func TestServiceSupervisor_StopBeforeStart(t *testing.T) {
+	dir := writeServicesDir(t, `[{"name":"x","restart":"always"}]`, "none", map[string]string{"x": "#!/bin/sh\nexit 0\n"})
+	s, err := NewServiceSupervisor(logger.Nop(), dir, t.TempDir(), nil)
+	require.NoError(t, err)
+	s.Stop(time.Second)
+	s.Start()
+	assert.False(t, s.started, "Start after Stop is a no-op")
+}
+
+func runs(t *testing.T, counter string) int {
+	t.Helper()
+	data, err := os.ReadFile(counter)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	require.NoError(t, err)
+	return strings.Count(string(data), "run")
+}
@@ -432,6 +432,47 @@ func (s *session) handleAgentInitialized(ctx context.Context, commandID string)
 	})
 }
 
+// handleSetLogLevel applies a CP-requested log level change. clawkerd
+// forwards SIGUSR1 to the agent's process group, so this command is its
+// only runtime level control. Runs synchronously on the receive loop —
+// SetLevel is an atomic store.
+func (s *session) handleSetLogLevel(ctx context.Context, commandID, level string) {
+	prev := logger.Level()
+	if err := logger.SetLevel(level); err != nil {
+		s.send(ctx, errResponse(commandID, clawkerdv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, err.Error()))
+		return
+	}
+	s.log.Info().
+		Str("event", "log_level_changed").
+		Str("command_id", commandID).
+		Str("previous_level", prev).
+		Str("level", level).
+		Msg("clawkerd: log level changed")
+	s.send(ctx, &clawkerdv1.Response{
+		CommandId: commandID,
+		Payload:   &clawkerdv1.Response_Done{Done: &clawkerdv1.Done{FinalExitCode: 0}},
+	})
+}
+
+// handleNotice shows a CP-requested message on the container console
+// through the progress reporter, which owns clawkerd's console writes.
+// Runs synchronously on the receive loop — one short write.
+func (s *session) handleNotice(ctx context.Context, commandID, message string) {
+	if message == "" {
+		s.send(ctx, errResponse(commandID, clawkerdv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, "empty notice"))
+		return
+	}
+	s.progress.Notice(message)
+	s.log.Info().
+		Str("event", "notice_shown").
+		Str("command_id", commandID).
+		Msg("clawkerd: CP notice shown on console")
+	s.send(ctx, &clawkerdv1.Response{
+		CommandId: commandID,
+		Payload:   &clawkerdv1.Response_Done{Done: &clawkerdv1.Done{FinalExitCode: 0}},
+	})
+}
+
 // runningCommand tracks one in-flight ShellCommand for routing
 // follow-up Stdin / CloseStdin / Signal frames. The per-command ctx
 // (derived from the Session ctx by startShellCommand) is plumbed as a
@@ -585,7 +626,7 @@ func (s *session) runSender(ctx context.Context, cancel context.CancelFunc) {
 			// Settle the user-facing init-step line only after the
 			// terminal Response has shipped on the wire. Mirrors the
 			// "starting" line emitted in dispatch. EndStep on a dropped
-			// or unsent Response would leave the user with a "✓ done"
+			// or unsent Response would leave the user with an "[ok]"
 			// line for a step CP never saw the outcome of.
 			s.settleInitStep(resp)
 		}
@@ -826,7 +867,7 @@ func (s *session) dispatch(ctx context.Context, cmd *clawkerdv1.Command) {
 		// CP-driven init steps carry an `init-` prefixed CommandID;
 		// emit the "in progress" status line off the boundary. Done/Error
 		// completion is fired in runSender via settleInitStep, only after
-		// stream.Send succeeds — so a step's "✓ done" line is never emitted
+		// stream.Send succeeds — so a step's "[ok]" line is never emitted
 		// for a Response CP didn't actually receive.
 		if label, ok := parseInitStep(cmd.CommandId); ok {
 			s.progress.StartStep(label)
@@ -880,6 +921,22 @@ func (s *session) dispatch(ctx context.Context, cmd *clawkerdv1.Command) {
 			return
 		}
 		s.handleAgentInitialized(ctx, cmd.CommandId)
+	case *clawkerdv1.Command_SetLogLevel:
+		if cmd.CommandId == "" {
+			s.send(ctx, errResponse("",
+				clawkerdv1.ErrorCode_ERROR_CODE_INVALID_REQUEST,
+				"command_id required"))
+			return
+		}
+		s.handleSetLogLevel(ctx, cmd.CommandId, p.SetLogLevel.GetLevel())
+	case *clawkerdv1.Command_Notice:
+		if cmd.CommandId == "" {
+			s.send(ctx, errResponse("",
+				clawkerdv1.ErrorCode_ERROR_CODE_INVALID_REQUEST,
+				"command_id required"))
+			return
+		}
+		s.handleNotice(ctx, cmd.CommandId, p.Notice.GetMessage())
 	default:
 		// Unknown payload is the canonical CP/clawkerd version-mismatch
 		// signal — the proto added a Command variant that this clawkerd
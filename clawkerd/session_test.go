@@ -172,6 +172,63 @@ func TestDispatch_AgentInitialized(t *testing.T) {
 	})
 }
 
+// TestDispatch_SetLogLevel pins the CP-driven runtime level change:
+// a known level is applied process-wide and acked Done{0}; an unknown
+// one is INVALID_REQUEST and leaves the level untouched.
+func TestDispatch_SetLogLevel(t *testing.T) {
+	t.Cleanup(func() { _ = logger.SetLevel("debug") })
+	setLevel := func(s *session, id, level string) *clawkerdv1.Response {
+		s.dispatch(context.Background(), &clawkerdv1.Command{
+			CommandId: id,
+			Payload:   &clawkerdv1.Command_SetLogLevel{SetLogLevel: &clawkerdv1.SetLogLevel{Level: level}},
+		})
+		resps := drainAll(s)
+		require.Len(t, resps, 1)
+		return resps[0]
+	}
+
+	s, _ := newTestSession()
+	resp := setLevel(s, "loglevel-1", "info")
+	require.Equal(t, "loglevel-1", resp.CommandId)
+	require.NotNil(t, resp.GetDone(), "SetLogLevel must ack Done, not Error")
+	require.Equal(t, "info", logger.Level())
+
+	resp = setLevel(s, "loglevel-2", "loud")
+	require.NotNil(t, resp.GetError())
+	require.Equal(t, clawkerdv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, resp.GetError().GetCode())
+	require.Equal(t, "info", logger.Level())
+
+	resp = setLevel(s, "", "debug")
+	require.NotNil(t, resp.GetError(), "empty command_id must be rejected")
+	require.Equal(t, "info", logger.Level())
+}
+
+// TestDispatch_Notice pins that a CP notice reaches the console even
+// after the boot display is muted, and is acked Done{0}.
+func TestDispatch_Notice(t *testing.T) {
+	s, _ := newTestSession()
+	var console bytes.Buffer
+	s.progress = NewProgressReporter(&console)
+	s.progress.Final()
+
+	s.dispatch(context.Background(), &clawkerdv1.Command{
+		CommandId: "notice-1",
+		Payload:   &clawkerdv1.Command_Notice{Notice: &clawkerdv1.Notice{Message: "stopping in 5m"}},
+	})
+	resps := drainAll(s)
+	require.Len(t, resps, 1)
+	require.NotNil(t, resps[0].GetDone(), "Notice must ack Done, not Error")
+	require.Contains(t, console.String(), "stopping in 5m")
+
+	s.dispatch(context.Background(), &clawkerdv1.Command{
+		CommandId: "notice-2",
+		Payload:   &clawkerdv1.Command_Notice{Notice: &clawkerdv1.Notice{}},
+	})
+	resps = drainAll(s)
+	require.Len(t, resps, 1)
+	require.Equal(t, clawkerdv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, resps[0].GetError().GetCode())
+}
+
 // TestDispatch_HelloAck_ReflectsState pins the fix for the re-run
 // regression: HelloAck MUST carry the agent's init/cmd-running state so
 // CP makes init/boot one-shot. Before the fix Hello returned an empty
@@ -0,0 +1,303 @@
+package clawkerd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	agentv1 "github.com/schmitthub/clawker/api/agent/v1"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// clockTicks is USER_HZ, the unit of /proc/<pid>/stat utime and stime.
+// Fixed at 100 on every Linux architecture clawker images target; Go has
+// no cgo-free sysconf(_SC_CLK_TCK).
+const clockTicks = 100
+
+// claudeCommand is the process name Claude Code runs under — the native
+// binary's name, and the title the npm build sets on its node process.
+const claudeCommand = "claude"
+
+// statusReporter periodically samples the container — every process but
+// clawkerd itself, the workspace filesystem, Claude Code liveness, the
+// last TTY or docker exec activity — and
+// sends the sample to CP's AgentService.ReportStatus. This is the
+// in-container view docker stats can't give: a per-process breakdown of
+// the agent's tree.
+//
+// The dial is mTLS with the agent leaf and no bearer token (ReportStatus
+// is public; the identity gate still applies). A failed report is logged
+// at Debug and dropped — the next tick sends a fresh sample, and CP
+// shows the last one it received.
+type statusReporter struct {
+	boot      *bootstrap
+	agentAddr string
+	workspace string
+	procRoot  string
+	devPts    string
+	selfPID   int
+	pageSize  uint64
+	interval  time.Duration
+	now       func() time.Time
+
+	// send delivers one report; tests replace it to skip the CP dial.
+	// nil means dial agentAddr on the first report.
+	send func(ctx context.Context, report *agentv1.StatusReport) error
+
+	// prevTicks and prevAt are the previous sample's per-pid CPU ticks,
+	// the baseline for the next sample's cpu_percent.
+	prevTicks map[int32]uint64
+	prevAt    time.Time
+
+	// lastActivity is the newest activity seen so far; it only moves
+	// forward. Starts at construction, so a fresh container is not idle.
+	lastActivity time.Time
+}
+
+// NewStatusReporter returns a reporter for the container's workspace —
+// clawkerd's working directory, which the image's WORKDIR sets. An empty
+// agentAddr (CLAWKER_CP_AGENT_ADDR unset) makes Run a no-op.
+func NewStatusReporter(boot *bootstrap, agentAddr string) *statusReporter {
+	workspace, err := os.Getwd()
+	if err != nil {
+		workspace = ""
+	}
+	return &statusReporter{
+		boot:      boot,
+		agentAddr: agentAddr,
+		workspace: workspace,
+		procRoot:  "/proc",
+		devPts:    "/dev/pts",
+		selfPID:   os.Getpid(),
+		pageSize:  uint64(os.Getpagesize()),
+		interval:  consts.ClawkerdStatusInterval,
+		now:       time.Now,
+
+		lastActivity: time.Now(),
+	}
+}
+
+// Run reports every interval until ctx is done. Long-lived goroutine
+// entry point — recovers per the resilience contract.
+func (r *statusReporter) Run(ctx context.Context, log *logger.Logger) {
+	defer recoverGoroutine(log, "status_reporter", nil)
+	if r.agentAddr == "" && r.send == nil {
+		log.Warn().Str("event", "status_reporter_disabled").Msg("CLAWKER_CP_AGENT_ADDR unset; not reporting status")
+		return
+	}
+	if r.send == nil {
+		conn, err := r.dial()
+		if err != nil {
+			log.Error().Err(err).Str("event", "status_reporter_dial_failed").Msg("status reporter disabled")
+			return
+		}
+		defer func() {
+			if cerr := conn.Close(); cerr != nil {
+				log.Debug().Err(cerr).Str("event", "status_reporter_conn_close_failed").Msg("close")
+			}
+		}()
+		client := agentv1.NewAgentServiceClient(conn)
+		r.send = func(ctx context.Context, report *agentv1.StatusReport) error {
+			_, err := client.ReportStatus(ctx, report)
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		r.report(ctx, log)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dial builds the lazily-connecting mTLS client for the CP agent
+// listener. grpc.NewClient does not connect, so a CP that isn't up yet
+// only fails individual reports.
+func (r *statusReporter) dial() (*grpc.ClientConn, error) {
+	tlsCfg, err := buildDialTLSConfig(r.boot.CertPEM, r.boot.KeyPEM, r.boot.CACertPEM)
+	if err != nil {
+		return nil, fmt.Errorf("dial TLS config: %w", err)
+	}
+	return grpc.NewClient(r.agentAddr, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+}
+
+// report samples once and sends the result.
+func (r *statusReporter) report(ctx context.Context, log *logger.Logger) {
+	report := r.collect()
+	ctx, cancel := context.WithTimeout(ctx, consts.ClawkerdStatusTimeout)
+	defer cancel()
+	if err := r.send(ctx, report); err != nil && ctx.Err() == nil {
+		log.Debug().Err(err).Str("event", "status_report_failed").Msg("AgentService.ReportStatus")
+	}
+}
+
+// collect takes one sample. Unreadable processes (exited mid-scan) are
+// skipped; an unreadable /proc or workspace leaves those fields empty.
+func (r *statusReporter) collect() *agentv1.StatusReport {
+	now := r.now()
+	report := &agentv1.StatusReport{
+		WorkspacePath:   r.workspace,
+		CollectedAtUnix: now.Unix(),
+	}
+
+	elapsed := now.Sub(r.prevAt).Seconds()
+	ticks := make(map[int32]uint64)
+	execRunning := false
+	entries, _ := os.ReadDir(r.procRoot)
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || pid == r.selfPID {
+			continue
+		}
+		st, err := readProcStat(filepath.Join(r.procRoot, e.Name()))
+		if err != nil {
+			continue
+		}
+		p := &agentv1.ProcessUsage{
+			Pid:      st.pid,
+			Ppid:     st.ppid,
+			Command:  st.comm,
+			RssBytes: st.rssPages * r.pageSize,
+		}
+		ticks[st.pid] = st.ticks
+		// clawkerd is PID 1; the only other processes without a parent in
+		// the container's pid namespace are the ones docker exec started.
+		if st.ppid == 0 {
+			execRunning = true
+		}
+		if prev, ok := r.prevTicks[st.pid]; ok && elapsed > 0 && st.ticks >= prev {
+			p.CpuPercent = float64(st.ticks-prev) / clockTicks / elapsed * 100
+		}
+		report.Processes = append(report.Processes, p)
+		report.MemoryRssBytes += p.RssBytes
+		report.CpuPercent += p.CpuPercent
+		if report.ClaudePid == 0 && isClaude(st.comm, readArgv0(filepath.Join(r.procRoot, e.Name()))) {
+			report.ClaudePid = st.pid
+		}
+	}
+	sort.Slice(report.Processes, func(i, j int) bool { return report.Processes[i].Pid < report.Processes[j].Pid })
+	r.prevTicks, r.prevAt = ticks, now
+	report.LastActivityUnix = r.activity(now, execRunning).Unix()
+
+	if r.workspace != "" {
+		var fs unix.Statfs_t
+		if err := unix.Statfs(r.workspace, &fs); err == nil {
+			total := uint64(fs.Blocks) * uint64(fs.Bsize)
+			report.WorkspaceTotalBytes = total
+			report.WorkspaceUsedBytes = total - uint64(fs.Bfree)*uint64(fs.Bsize)
+		}
+	}
+	return report
+}
+
+// activity advances lastActivity to now while a docker exec process runs,
+// and to the newest access or modification time of any /dev/pts terminal
+// — the kernel stamps those on terminal reads and writes. ptmx is the
+// multiplexer, not a session, and is skipped.
+func (r *statusReporter) activity(now time.Time, execRunning bool) time.Time {
+	if execRunning && now.After(r.lastActivity) {
+		r.lastActivity = now
+	}
+	if r.devPts == "" {
+		return r.lastActivity
+	}
+	entries, _ := os.ReadDir(r.devPts)
+	for _, e := range entries {
+		if e.Name() == "ptmx" {
+			continue
+		}
+		var st unix.Stat_t
+		if err := unix.Stat(filepath.Join(r.devPts, e.Name()), &st); err != nil {
+			continue
+		}
+		for _, ts := range []unix.Timespec{st.Atim, st.Mtim} {
+			if t := time.Unix(ts.Unix()); t.After(r.lastActivity) {
+				r.lastActivity = t
+			}
+		}
+	}
+	return r.lastActivity
+}
+
+// procStat is the subset of /proc/<pid>/stat the reporter uses.
+type procStat struct {
+	pid      int32
+	ppid     int32
+	comm     string
+	ticks    uint64 // utime + stime
+	rssPages uint64
+}
+
+// readProcStat parses <dir>/stat. The comm field is parenthesized and
+// may itself contain spaces and parentheses, so the fixed fields are
+// located from the last ')'.
+func readProcStat(dir string) (procStat, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return procStat{}, err
+	}
+	s := string(data)
+	open, closing := strings.IndexByte(s, '('), strings.LastIndexByte(s, ')')
+	if open < 0 || closing < open {
+		return procStat{}, errors.New("malformed stat: no comm")
+	}
+	pid, err := strconv.ParseInt(strings.TrimSpace(s[:open]), 10, 32)
+	if err != nil {
+		return procStat{}, fmt.Errorf("malformed stat pid: %w", err)
+	}
+	// fields[0] is state (field 3); field N is fields[N-3].
+	fields := strings.Fields(s[closing+1:])
+	if len(fields) < 22 {
+		return procStat{}, errors.New("malformed stat: too few fields")
+	}
+	ppid, err := strconv.ParseInt(fields[1], 10, 32)
+	if err != nil {
+		return procStat{}, fmt.Errorf("malformed stat ppid: %w", err)
+	}
+	var nums [3]uint64
+	for i, idx := range []int{11, 12, 21} { // utime (14), stime (15), rss (24)
+		if nums[i], err = strconv.ParseUint(fields[idx], 10, 64); err != nil {
+			return procStat{}, fmt.Errorf("malformed stat field %d: %w", idx+3, err)
+		}
+	}
+	return procStat{
+		pid:      int32(pid),
+		ppid:     int32(ppid),
+		comm:     s[open+1 : closing],
+		ticks:    nums[0] + nums[1],
+		rssPages: nums[2],
+	}, nil
+}
+
+// readArgv0 returns the first argument of <dir>/cmdline, empty when
+// unreadable (kernel threads, exited processes).
+func readArgv0(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "cmdline"))
+	if err != nil {
+		return ""
+	}
+	argv0, _, _ := strings.Cut(string(data), "\x00")
+	return argv0
+}
+
+// isClaude reports whether a process is Claude Code: named claude, or
+// exec'd from a file named claude.
+func isClaude(comm, argv0 string) bool {
+	return comm == claudeCommand || (argv0 != "" && filepath.Base(argv0) == claudeCommand)
+}
@@ -0,0 +1,159 @@
+package clawkerd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/schmitthub/clawker/api/agent/v1"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// writeProc lays out <root>/<pid>/{stat,cmdline} with the given comm,
+// ppid, utime+stime split evenly, and rss pages.
+func writeProc(t *testing.T, root string, pid, ppid int, comm, argv0 string, ticks, rss uint64) {
+	t.Helper()
+	dir := filepath.Join(root, fmt.Sprint(pid))
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	stat := fmt.Sprintf("%d (%s) S %d 1 1 0 -1 4194560 100 0 0 0 %d %d 0 0 20 0 1 0 100 1000000 %d 18446744073709551615\n",
+		pid, comm, ppid, ticks/2, ticks-ticks/2, rss)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cmdline"), []byte(argv0+"\x00--flag\x00"), 0o644))
+}
+
+func testReporter(t *testing.T, procRoot string, now *time.Time) *statusReporter {
+	t.Helper()
+	return &statusReporter{
+		workspace: t.TempDir(),
+		procRoot:  procRoot,
+		selfPID:   1,
+		pageSize:  4096,
+		interval:  time.Hour,
+		now:       func() time.Time { return *now },
+	}
+}
+
+func TestReadProcStat_CommWithParens(t *testing.T) {
+	root := t.TempDir()
+	writeProc(t, root, 42, 7, "weird) (name", "/bin/weird", 30, 5)
+
+	st, err := readProcStat(filepath.Join(root, "42"))
+	require.NoError(t, err)
+	assert.Equal(t, procStat{pid: 42, ppid: 7, comm: "weird) (name", ticks: 30, rssPages: 5}, st)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "42", "stat"), []byte("42 (short) S 1"), 0o644))
+	_, err = readProcStat(filepath.Join(root, "42"))
+	assert.Error(t, err)
+}
+
+func TestStatusReporter_Collect(t *testing.T) {
+	root := t.TempDir()
+	writeProc(t, root, 1, 0, "clawkerd", "/usr/local/bin/clawkerd", 500, 100)
+	writeProc(t, root, 10, 1, "node", "/usr/local/bin/claude", 100, 1000)
+	writeProc(t, root, 20, 10, "bash", "/bin/bash", 0, 200)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "self"), 0o755))
+
+	now := time.Unix(1000, 0)
+	r := testReporter(t, root, &now)
+
+	first := r.collect()
+	require.Len(t, first.GetProcesses(), 2, "clawkerd itself is excluded")
+	assert.Equal(t, int32(10), first.GetProcesses()[0].GetPid())
+	assert.Equal(t, int32(20), first.GetProcesses()[1].GetPid())
+	assert.Equal(t, uint64(1200*4096), first.GetMemoryRssBytes())
+	assert.Zero(t, first.GetCpuPercent(), "no baseline on the first sample")
+	assert.Equal(t, int32(10), first.GetClaudePid(), "detected by argv0")
+	assert.Equal(t, int64(1000), first.GetCollectedAtUnix())
+	assert.NotZero(t, first.GetWorkspaceTotalBytes())
+
+	// 10s later pid 10 used 500 more ticks: 5s of CPU over 10s = 50%.
+	writeProc(t, root, 10, 1, "node", "/usr/local/bin/claude", 600, 1000)
+	now = now.Add(10 * time.Second)
+	second := r.collect()
+	assert.InDelta(t, 50.0, second.GetProcesses()[0].GetCpuPercent(), 0.001)
+	assert.InDelta(t, 50.0, second.GetCpuPercent(), 0.001)
+}
+
+func TestStatusReporter_NoClaude(t *testing.T) {
+	root := t.TempDir()
+	writeProc(t, root, 20, 1, "bash", "/bin/bash", 0, 1)
+	now := time.Unix(1000, 0)
+
+	report := testReporter(t, root, &now).collect()
+
+	assert.Zero(t, report.GetClaudePid())
+	assert.True(t, isClaude("claude", ""))
+	assert.False(t, isClaude("node", "/usr/bin/node"))
+}
+
+func TestStatusReporter_LastActivity(t *testing.T) {
+	root := t.TempDir()
+	writeProc(t, root, 20, 1, "bash", "/bin/bash", 0, 1)
+	pts := t.TempDir()
+	for _, name := range []string{"0", "ptmx"} {
+		require.NoError(t, os.WriteFile(filepath.Join(pts, name), nil, 0o600))
+	}
+	require.NoError(t, os.Chtimes(filepath.Join(pts, "0"), time.Unix(1100, 0), time.Unix(1200, 0)))
+	require.NoError(t, os.Chtimes(filepath.Join(pts, "ptmx"), time.Unix(9000, 0), time.Unix(9000, 0)))
+
+	now := time.Unix(2000, 0)
+	r := testReporter(t, root, &now)
+	r.devPts = pts
+	r.lastActivity = time.Unix(1000, 0)
+
+	assert.Equal(t, int64(1200), r.collect().GetLastActivityUnix(), "newest terminal time, ptmx skipped")
+
+	// A docker exec process (no parent in the pid namespace) is activity
+	// for as long as it runs.
+	writeProc(t, root, 30, 0, "sh", "/bin/sh", 0, 1)
+	assert.Equal(t, int64(2000), r.collect().GetLastActivityUnix())
+
+	require.NoError(t, os.RemoveAll(filepath.Join(root, "30")))
+	now = now.Add(time.Hour)
+	assert.Equal(t, int64(2000), r.collect().GetLastActivityUnix(), "activity never moves backwards")
+}
+
+func TestStatusReporter_RunReportsUntilCancelled(t *testing.T) {
+	now := time.Unix(1000, 0)
+	r := testReporter(t, t.TempDir(), &now)
+	r.interval = time.Millisecond
+	reports := make(chan *agentv1.StatusReport, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	r.send = func(_ context.Context, report *agentv1.StatusReport) error {
+		select {
+		case reports <- report:
+		default:
+		}
+		return fmt.Errorf("cp down")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx, logger.Nop())
+		close(done)
+	}()
+	for range 2 {
+		select {
+		case <-reports:
+		case <-time.After(5 * time.Second):
+			t.Fatal("no report sent")
+		}
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
+
+func TestStatusReporter_RunWithoutAddrIsNoop(t *testing.T) {
+	r := NewStatusReporter(&bootstrap{}, "")
+	r.Run(context.Background(), logger.Nop())
+}
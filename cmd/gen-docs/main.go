@@ -1,7 +1,9 @@
 // gen-docs is a standalone binary for generating CLI and configuration documentation.
 // It provides documentation generation for clawker CLI in multiple formats
-// (Markdown, man pages, YAML, reStructuredText) and auto-generates configuration
-// reference docs from schema struct tags.
+// (Markdown, man pages, YAML, reStructuredText), shell completion scripts, and
+// auto-generates configuration reference docs and a flat config key reference
+// from schema struct tags. A single JSON manifest of the command tree serves
+// tooling that should not scrape Markdown.
 package main
 
 import (
@@ -9,6 +11,7 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -44,6 +47,9 @@ func run(args []string) error {
 		flagRST      bool
 		flagWebsite  bool
 		flagSchemas  bool
+		flagComplete bool
+		flagCfgRef   bool
+		flagManifest bool
 	)
 
 	flags.StringVar(&flagDocPath, "doc-path", "", "Output directory for generated docs (required)")
@@ -64,6 +70,27 @@ func run(args []string) error {
 		"Generate config JSON Schemas from struct tags (written to <doc-path>/schemas/)",
 	)
 
+	flags.BoolVar(
+		&flagComplete,
+		"completions",
+		false,
+		"Generate bash, zsh, fish and PowerShell completion scripts (written to <doc-path>/completions/)",
+	)
+
+	flags.BoolVar(
+		&flagCfgRef,
+		"config-reference",
+		false,
+		"Generate the config key reference (<doc-path>/config-reference.md and .yaml)",
+	)
+
+	flags.BoolVar(
+		&flagManifest,
+		"manifest",
+		false,
+		"Generate a JSON manifest of every command, flag, default and example (<doc-path>/cli-manifest.json)",
+	)
+
 	flags.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage of %s:\n\n%s", filepath.Base(args[0]), flags.FlagUsages())
 	}
@@ -77,8 +104,12 @@ func run(args []string) error {
 		return fmt.Errorf("--doc-path is required")
 	}
 
-	if !flagMarkdown && !flagManPage && !flagYAML && !flagRST && !flagSchemas {
-		return errors.New("at least one output must be specified (--markdown, --man-page, --yaml, --rst, --schemas)")
+	if !flagMarkdown && !flagManPage && !flagYAML && !flagRST && !flagSchemas && !flagComplete && !flagCfgRef &&
+		!flagManifest {
+		return errors.New(
+			"at least one output must be specified " +
+				"(--markdown, --man-page, --yaml, --rst, --schemas, --completions, --config-reference, --manifest)",
+		)
 	}
 
 	if flagWebsite && !flagMarkdown {
@@ -147,6 +178,29 @@ func run(args []string) error {
 		fmt.Fprintf(os.Stderr, "Generated config JSON schemas in %s\n", schemaDir)
 	}
 
+	if flagCfgRef {
+		if err := genConfigReference(flagDocPath); err != nil {
+			return fmt.Errorf("failed to generate config key reference: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Generated config key reference in %s\n", filepath.Join(flagDocPath, "config-reference.{md,yaml}"))
+	}
+
+	if flagManifest {
+		var buf bytes.Buffer
+		if err := docs.GenCommandManifest(rootCmd, &buf); err != nil {
+			return fmt.Errorf("failed to generate command manifest: %w", err)
+		}
+		outPath := filepath.Join(flagDocPath, "cli-manifest.json")
+		if err := os.WriteFile( //nolint:gosec // non-secret generated docs; conventional world-readable perms
+			outPath,
+			buf.Bytes(),
+			0o644,
+		); err != nil {
+			return fmt.Errorf("writing %s: %w", outPath, err)
+		}
+		fmt.Fprintf(os.Stderr, "Generated command manifest in %s\n", outPath)
+	}
+
 	if flagManPage {
 		dir := filepath.Join(flagDocPath, "man")
 		if err = os.MkdirAll( //nolint:gosec // non-secret generated docs; conventional world-readable perms
@@ -192,6 +246,21 @@ func run(args []string) error {
 		fmt.Fprintf(os.Stderr, "Generated reStructuredText documentation in %s\n", dir)
 	}
 
+	if flagComplete {
+		dir := filepath.Join(flagDocPath, "completions")
+		if err = os.MkdirAll( //nolint:gosec // non-secret generated docs; conventional world-readable perms
+			dir,
+			0o755,
+		); err != nil {
+			return fmt.Errorf("failed to create completions directory: %w", err)
+		}
+
+		if err := docs.GenCompletions(rootCmd, dir); err != nil {
+			return fmt.Errorf("failed to generate shell completions: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Generated shell completions in %s\n", dir)
+	}
+
 	return nil
 }
 
@@ -214,6 +283,32 @@ func genConfigDoc(docPath string) error {
 	return nil
 }
 
+// genConfigReference writes the flat config key reference as Markdown and
+// YAML, both generated from the schema field sets.
+func genConfigReference(docPath string) error {
+	for _, out := range []struct {
+		file string
+		gen  func(io.Writer) error
+	}{
+		{"config-reference.md", docs.GenConfigReferenceMarkdown},
+		{"config-reference.yaml", docs.GenConfigReferenceYAML},
+	} {
+		var buf bytes.Buffer
+		if err := out.gen(&buf); err != nil {
+			return err
+		}
+		outPath := filepath.Join(docPath, out.file)
+		if err := os.WriteFile( //nolint:gosec // non-secret generated docs; conventional world-readable perms
+			outPath,
+			buf.Bytes(),
+			0o644,
+		); err != nil {
+			return fmt.Errorf("writing %s: %w", outPath, err)
+		}
+	}
+	return nil
+}
+
 // configSchemaSpec describes one generated config JSON Schema file.
 type configSchemaSpec struct {
 	typ   reflect.Type
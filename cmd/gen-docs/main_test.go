@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -278,3 +279,56 @@ func TestRunSchemasOnly(t *testing.T) {
 	_, err := os.Stat(filepath.Join(dir, "cli-reference"))
 	require.True(t, os.IsNotExist(err), "--schemas must not generate CLI reference docs")
 }
+
+func TestRunCompletionsOnly(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, run([]string{"gen-docs", "--doc-path", dir, "--completions"}))
+
+	for _, shell := range docs.CompletionShells {
+		_, err := os.Stat(filepath.Join(dir, "completions", docs.CompletionFilename("clawker", shell)))
+		require.NoErrorf(t, err, "%s completion should be generated by --completions alone", shell)
+	}
+
+	_, err := os.Stat(filepath.Join(dir, "cli-reference"))
+	require.True(t, os.IsNotExist(err), "--completions must not generate CLI reference docs")
+}
+
+func TestRunConfigReferenceOnly(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, run([]string{"gen-docs", "--doc-path", dir, "--config-reference"}))
+
+	for _, file := range []string{"config-reference.md", "config-reference.yaml"} {
+		_, err := os.Stat(filepath.Join(dir, file))
+		require.NoErrorf(t, err, "%s should be generated by --config-reference alone", file)
+	}
+
+	_, err := os.Stat(filepath.Join(dir, "cli-reference"))
+	require.True(t, os.IsNotExist(err), "--config-reference must not generate CLI reference docs")
+}
+
+func TestRunManifestOnly(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, run([]string{"gen-docs", "--doc-path", dir, "--manifest"}))
+
+	data, err := os.ReadFile(filepath.Join(dir, "cli-manifest.json"))
+	require.NoError(t, err)
+	var m docs.CommandManifest
+	require.NoError(t, json.Unmarshal(data, &m))
+	require.Equal(t, "clawker", m.Root)
+
+	var runCmd *docs.ManifestCommand
+	for i := range m.Commands {
+		if m.Commands[i].Path == "clawker container run" {
+			runCmd = &m.Commands[i]
+		}
+	}
+	require.NotNil(t, runCmd, "manifest should describe clawker container run")
+	require.NotEmpty(t, runCmd.Flags)
+	require.NotEmpty(t, runCmd.Example)
+
+	_, err = os.Stat(filepath.Join(dir, "cli-reference"))
+	require.True(t, os.IsNotExist(err), "--manifest must not generate CLI reference docs")
+}
@@ -140,6 +140,12 @@ type Dialer struct {
 	// construction; immutable after Start.
 	Executor *Executor
 
+	// Sessions, when set, receives each Session once the init/boot
+	// plans have run so AdminService can send one-off commands (e.g.
+	// SetLogLevel) to a running agent. nil disables that path. Set
+	// before Start; immutable after.
+	Sessions *Sessions
+
 	CpClientCert tls.Certificate
 	CaPool       *x509.CertPool
 
@@ -454,7 +460,14 @@ func (d *Dialer) runDial(cpCtx context.Context, dialCtx context.Context, contain
 			))
 		}
 
-		drain := d.drainStream(dialCtx, res.Stream, cycleLog)
+		var live *liveSession
+		if d.Sessions != nil {
+			live = d.Sessions.attach(containerID, res.Stream)
+		}
+		drain := d.drainStream(dialCtx, res.Stream, live, cycleLog)
+		if live != nil {
+			d.Sessions.detach(containerID, live)
+		}
 		// Cancel the stream-scoped ctx so any goroutine still parked on
 		// stream.Recv (e.g. a leftover from a driveRegister timeout that
 		// preceded drainStream) is guaranteed to unblock before the next
@@ -1180,9 +1193,11 @@ type DrainResult struct {
 	Reason  string
 }
 
-// drainStream holds the Session open. Reads each Response and
-// discards. Exits on EOF (peer close), ctx cancel (CP shutdown), or error.
-func (d *Dialer) drainStream(ctx context.Context, stream clawkerdv1.ClawkerdService_SessionClient, log *logger.Logger) DrainResult {
+// drainStream holds the Session open. Reads each Response, routing
+// replies to one-off commands sent through live (nil when Sessions is
+// unset) and discarding the rest. Exits on EOF (peer close), ctx cancel
+// (CP shutdown), or error.
+func (d *Dialer) drainStream(ctx context.Context, stream clawkerdv1.ClawkerdService_SessionClient, live *liveSession, log *logger.Logger) DrainResult {
 	for {
 		resp, err := stream.Recv()
 		if errors.Is(err, io.EOF) {
@@ -1197,6 +1212,9 @@ func (d *Dialer) drainStream(ctx context.Context, stream clawkerdv1.ClawkerdServ
 			log.Error().Err(err).Str("event", "agentdial_session_recv_failed").Msg("Session.Recv")
 			return DrainResult{Outcome: DrainStreamErr, Reason: err.Error()}
 		}
+		if live != nil && live.deliver(resp) {
+			continue
+		}
 		log.Debug().
 			Str("event", "agentdial_unexpected_response").
 			Str("type", fmt.Sprintf("%T", resp.Payload)).
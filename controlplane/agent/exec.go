@@ -119,9 +119,14 @@ else
 fi
 `
 
+	// GitCredentialsScript makes the host-proxy bridge the only HTTPS
+	// credential helper. The empty value first clears any helper inherited
+	// from the image's system gitconfig (e.g. "store"), which would otherwise
+	// also receive — and write to disk — every credential git approves.
 	GitCredentialsScript = `[ -n "$` + consts.EnvHostProxy + `" ] || exit 0
 [ "$` + consts.EnvGitHTTPS + `" = "true" ] || exit 0
-git config --global credential.helper clawker
+git config --global --replace-all credential.helper ""
+git config --global --add credential.helper clawker
 `
 
 	// SshKnownHostsScript first heals the existing file — dropping entries
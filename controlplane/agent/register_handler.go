@@ -14,16 +14,16 @@ import (
 	"github.com/schmitthub/clawker/internal/logger"
 )
 
-// Handler serves the Register RPC on the CP's clawker network agent
-// listener and is the SOLE writer of the agentregistry sqlite DB.
-// IdentityInterceptor has already grounded the peer in the
-// daemon-attested container identity (peer IP → purpose=agent
-// container → labels → cross-checked cert SAN AgentFullName) and
-// attached the resolved (containerID, project, agentName) to ctx. The
-// handler reads that, captures the cert thumbprint at the gate that
-// persists it, cross-checks the cert's container_id SAN + request
-// fields against the resolved truth, and writes the registry row
-// using label-derived (authoritative) values.
+// Handler serves the AgentService RPCs on the CP's clawker network
+// agent listener — Register here, ReportStatus in status.go — and is
+// the SOLE writer of the agentregistry sqlite DB. IdentityInterceptor
+// has already grounded the peer in the daemon-attested container
+// identity (peer IP → purpose=agent container → labels → cross-checked
+// cert SAN AgentFullName) and attached the resolved (containerID,
+// project, agentName) to ctx. The handler reads that, captures the cert
+// thumbprint at the gate that persists it, cross-checks the cert's
+// container_id SAN + request fields against the resolved truth, and
+// writes the registry row using label-derived (authoritative) values.
 //
 // Trust ordering: daemon labels > cert claim > request claim.
 // Persisting `resolved.*` keeps the registry aligned with the daemon
@@ -40,6 +40,10 @@ type Handler struct {
 	Registry Registry
 	Log      *logger.Logger
 	Clock    func() time.Time
+
+	// Status receives ReportStatus samples. nil answers ReportStatus with
+	// codes.Unavailable; Register is unaffected.
+	Status *StatusStore
 }
 
 // NewHandler constructs a Register handler. registry MUST be non-nil
@@ -90,6 +90,30 @@ func TestSQLiteRegistry_EvictByContainerID_DeletesRow(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestSQLiteRegistry_SurvivesReopen pins the CP-restart contract: rows
+// written by one writer are read back by the next one opened on the same
+// file, so a restarted CP redials (DialAllRunning) against the registry
+// instead of re-registering every agent.
+func TestSQLiteRegistry_SurvivesReopen(t *testing.T) {
+	path := dbPath(t, "agents.db")
+	r, err := NewSQLiteWriter(path, logger.Nop())
+	require.NoError(t, err)
+	want := validEntry("p", "a", "ctr-1", "cert-1")
+	require.NoError(t, r.Add(want))
+	closeRegistry(t, r)
+
+	r, err = NewSQLiteWriter(path, logger.Nop())
+	require.NoError(t, err)
+	t.Cleanup(func() { closeRegistry(t, r) })
+
+	got, err := r.LookupByContainerID("ctr-1")
+	require.NoError(t, err)
+	assert.Equal(t, want.Thumbprint, got.Thumbprint)
+	assert.Equal(t, want.Project, got.Project)
+	assert.Equal(t, want.AgentName, got.AgentName)
+	assert.True(t, want.RegisteredAt.Equal(got.RegisteredAt), "registered_at round-trips")
+}
+
 func TestSQLiteRegistry_EvictByContainerID_ReturnsErrOnDBFailure(t *testing.T) {
 	// Closing the DB handle then calling Evict surfaces a real sqlite
 	// error — the new return signature exists so reapers and the
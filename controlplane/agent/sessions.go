@@ -0,0 +1,183 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	clawkerdv1 "github.com/schmitthub/clawker/api/clawkerd/v1"
+)
+
+// SessionCommandTimeout caps how long a one-off Session command (e.g.
+// SetLogLevel) waits for clawkerd's reply. The handlers run synchronously
+// on clawkerd's receive loop, so a healthy agent answers in milliseconds.
+const SessionCommandTimeout = 10 * time.Second
+
+// ErrSessionNotConnected is returned when no live Session exists for the
+// container — it isn't running, CP hasn't dialed it yet, or its init/boot
+// plan is still in flight.
+var ErrSessionNotConnected = errors.New("agent: no live clawkerd Session for container")
+
+// Sessions is the table of live CP→clawkerd Session streams, keyed by
+// container ID. The Dialer attaches a Session once the init/boot plans
+// have run and drainStream owns Recv; AdminService handlers use it to send
+// one-off commands to a running agent. Safe for concurrent use; the zero
+// value is not usable — construct with NewSessions.
+type Sessions struct {
+	mu   sync.Mutex
+	live map[string]*liveSession
+	seq  atomic.Uint64
+}
+
+// NewSessions returns an empty Sessions table.
+func NewSessions() *Sessions {
+	return &Sessions{live: make(map[string]*liveSession)}
+}
+
+// liveSession is one attached Session. sendMu serializes Send (gRPC
+// streams are not safe for concurrent Send); pending routes Responses
+// that drainStream receives back to the waiting caller by command_id.
+type liveSession struct {
+	stream clawkerdv1.ClawkerdService_SessionClient
+	sendMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[string]chan *clawkerdv1.Response
+	closed  bool
+}
+
+// attach registers stream as the live Session for containerID, replacing
+// any previous one.
+func (s *Sessions) attach(containerID string, stream clawkerdv1.ClawkerdService_SessionClient) *liveSession {
+	ls := &liveSession{stream: stream, pending: make(map[string]chan *clawkerdv1.Response)}
+	s.mu.Lock()
+	s.live[containerID] = ls
+	s.mu.Unlock()
+	return ls
+}
+
+// detach removes ls if it is still the live Session for containerID and
+// fails every command still waiting on it.
+func (s *Sessions) detach(containerID string, ls *liveSession) {
+	s.mu.Lock()
+	if s.live[containerID] == ls {
+		delete(s.live, containerID)
+	}
+	s.mu.Unlock()
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.closed = true
+	for id, ch := range ls.pending {
+		close(ch)
+		delete(ls.pending, id)
+	}
+}
+
+func (s *Sessions) lookup(containerID string) *liveSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.live[containerID]
+}
+
+// deliver hands resp to the command waiting on its command_id. Returns
+// false when nothing is waiting, so drainStream logs it as unsolicited.
+func (ls *liveSession) deliver(resp *clawkerdv1.Response) bool {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ch, ok := ls.pending[resp.GetCommandId()]
+	if !ok {
+		return false
+	}
+	delete(ls.pending, resp.GetCommandId())
+	ch <- resp
+	return true
+}
+
+// SetLogLevel sends SetLogLevel on containerID's live Session and waits
+// for clawkerd's reply. Returns ErrSessionNotConnected when there is no
+// live Session, and clawkerd's error message when it rejects the level.
+func (s *Sessions) SetLogLevel(ctx context.Context, containerID, level string) error {
+	resp, err := s.call(ctx, containerID, "loglevel", &clawkerdv1.Command{
+		Payload: &clawkerdv1.Command_SetLogLevel{SetLogLevel: &clawkerdv1.SetLogLevel{Level: level}},
+	})
+	if err != nil {
+		return err
+	}
+	switch p := resp.Payload.(type) {
+	case *clawkerdv1.Response_Done:
+		return nil
+	case *clawkerdv1.Response_Error:
+		return fmt.Errorf("clawkerd: %s", p.Error.GetMessage())
+	default:
+		return fmt.Errorf("clawkerd: unexpected %T reply to SetLogLevel", resp.Payload)
+	}
+}
+
+// Notify sends Notice on containerID's live Session and waits for
+// clawkerd to confirm it wrote the message to the agent's console.
+// Returns ErrSessionNotConnected when there is no live Session.
+func (s *Sessions) Notify(ctx context.Context, containerID, message string) error {
+	resp, err := s.call(ctx, containerID, "notice", &clawkerdv1.Command{
+		Payload: &clawkerdv1.Command_Notice{Notice: &clawkerdv1.Notice{Message: message}},
+	})
+	if err != nil {
+		return err
+	}
+	switch p := resp.Payload.(type) {
+	case *clawkerdv1.Response_Done:
+		return nil
+	case *clawkerdv1.Response_Error:
+		return fmt.Errorf("clawkerd: %s", p.Error.GetMessage())
+	default:
+		return fmt.Errorf("clawkerd: unexpected %T reply to Notice", resp.Payload)
+	}
+}
+
+// call stamps cmd with a fresh command_id, sends it, and waits up to
+// SessionCommandTimeout for the Response carrying the same id.
+func (s *Sessions) call(ctx context.Context, containerID, prefix string, cmd *clawkerdv1.Command) (*clawkerdv1.Response, error) {
+	ls := s.lookup(containerID)
+	if ls == nil {
+		return nil, ErrSessionNotConnected
+	}
+
+	commandID := prefix + "-" + strconv.FormatUint(s.seq.Add(1), 10)
+	ch := make(chan *clawkerdv1.Response, 1)
+	ls.mu.Lock()
+	if ls.closed {
+		ls.mu.Unlock()
+		return nil, ErrSessionNotConnected
+	}
+	ls.pending[commandID] = ch
+	ls.mu.Unlock()
+	defer func() {
+		ls.mu.Lock()
+		delete(ls.pending, commandID)
+		ls.mu.Unlock()
+	}()
+
+	cmd.CommandId = commandID
+	ls.sendMu.Lock()
+	err := ls.stream.Send(cmd)
+	ls.sendMu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("send %s: %w", commandID, err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, SessionCommandTimeout)
+	defer cancel()
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return nil, fmt.Errorf("%s: Session closed before clawkerd replied", commandID)
+		}
+		return resp, nil
+	case <-waitCtx.Done():
+		return nil, fmt.Errorf("%s: waiting for clawkerd: %w", commandID, waitCtx.Err())
+	}
+}
@@ -0,0 +1,100 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clawkerdv1 "github.com/schmitthub/clawker/api/clawkerd/v1"
+	clawkerdv1mocks "github.com/schmitthub/clawker/api/clawkerd/v1/mocks"
+)
+
+// drainInto stands in for Dialer.drainStream: it routes every Response on
+// stream to live until the stream ends.
+func drainInto(stream *clawkerdv1mocks.FakeSessionStream, live *liveSession) {
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			live.deliver(resp)
+		}
+	}()
+}
+
+func TestSessions_SetLogLevel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := clawkerdv1mocks.NewFakeSessionStream(ctx)
+	stream.FeedResponses(func(_ int, cmd *clawkerdv1.Command) []*clawkerdv1.Response {
+		if cmd.GetSetLogLevel().GetLevel() != "debug" {
+			return []*clawkerdv1.Response{{
+				CommandId: cmd.CommandId,
+				Payload: &clawkerdv1.Response_Error{Error: &clawkerdv1.Error{
+					Code:    clawkerdv1.ErrorCode_ERROR_CODE_INVALID_REQUEST,
+					Message: "unknown level",
+				}},
+			}}
+		}
+		return []*clawkerdv1.Response{{
+			CommandId: cmd.CommandId,
+			Payload:   &clawkerdv1.Response_Done{Done: &clawkerdv1.Done{}},
+		}}
+	})
+
+	sessions := NewSessions()
+	live := sessions.attach("c1", stream)
+	drainInto(stream, live)
+
+	require.NoError(t, sessions.SetLogLevel(ctx, "c1", "debug"))
+
+	err := sessions.SetLogLevel(ctx, "c1", "loud")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown level")
+
+	assert.ErrorIs(t, sessions.SetLogLevel(ctx, "other", "debug"), ErrSessionNotConnected)
+}
+
+func TestSessions_Notify(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := clawkerdv1mocks.NewFakeSessionStream(ctx)
+	var got string
+	stream.FeedResponses(func(_ int, cmd *clawkerdv1.Command) []*clawkerdv1.Response {
+		got = cmd.GetNotice().GetMessage()
+		return []*clawkerdv1.Response{{
+			CommandId: cmd.CommandId,
+			Payload:   &clawkerdv1.Response_Done{Done: &clawkerdv1.Done{}},
+		}}
+	})
+
+	sessions := NewSessions()
+	live := sessions.attach("c1", stream)
+	drainInto(stream, live)
+
+	require.NoError(t, sessions.Notify(ctx, "c1", "stopping soon"))
+	assert.Equal(t, "stopping soon", got)
+	assert.ErrorIs(t, sessions.Notify(ctx, "other", "x"), ErrSessionNotConnected)
+}
+
+func TestSessions_DetachFailsPending(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := clawkerdv1mocks.NewFakeSessionStream(ctx)
+
+	sessions := NewSessions()
+	live := sessions.attach("c1", stream)
+	// Detach as soon as the command goes out, before any reply.
+	go func() {
+		<-stream.Sent()
+		sessions.detach("c1", live)
+	}()
+
+	err := sessions.SetLogLevel(ctx, "c1", "debug")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Session closed")
+	assert.ErrorIs(t, sessions.SetLogLevel(ctx, "c1", "debug"), ErrSessionNotConnected)
+}
@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	agentv1 "github.com/schmitthub/clawker/api/agent/v1"
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// StatusSample is the latest StatusReport an agent sent, stamped with
+// CP's receive time.
+type StatusSample struct {
+	Report     *agentv1.StatusReport
+	ReceivedAt time.Time
+}
+
+// StatusStore holds the latest ReportStatus sample per container ID. It
+// is in memory only: a report is a point-in-time sample that the next one
+// replaces, so losing them on a CP restart costs one interval. Samples
+// not refreshed within the retention window are dropped on the next Put,
+// so removed containers don't accumulate. Safe for concurrent use; the
+// zero value is not usable — construct with NewStatusStore.
+type StatusStore struct {
+	mu        sync.Mutex
+	samples   map[string]StatusSample
+	retention time.Duration
+}
+
+// NewStatusStore returns an empty StatusStore keeping samples for
+// consts.CPAgentStatusRetention.
+func NewStatusStore() *StatusStore {
+	return &StatusStore{
+		samples:   make(map[string]StatusSample),
+		retention: consts.CPAgentStatusRetention,
+	}
+}
+
+// Put records report as containerID's latest sample, received at now.
+func (s *StatusStore) Put(containerID string, report *agentv1.StatusReport, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, sample := range s.samples {
+		if now.Sub(sample.ReceivedAt) > s.retention {
+			delete(s.samples, id)
+		}
+	}
+	s.samples[containerID] = StatusSample{Report: report, ReceivedAt: now}
+}
+
+// Get returns containerID's latest sample.
+func (s *StatusStore) Get(containerID string) (StatusSample, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sample, ok := s.samples[containerID]
+	return sample, ok
+}
+
+// ReportStatus stores the calling agent's resource-usage sample.
+// IdentityInterceptor has resolved the peer to its container; the
+// request carries no identity, so an agent can only report for itself.
+// A handler without a StatusStore answers Unavailable — clawkerd logs
+// and retries on its next tick.
+func (h *Handler) ReportStatus(ctx context.Context, req *agentv1.StatusReport) (*agentv1.StatusAck, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "report status: nil request")
+	}
+	resolved, ok := ResolvedContainerFromContext(ctx)
+	if !ok {
+		h.Log.Error().
+			Str("event", "agent_report_status_no_resolved_container").
+			Msg("middleware did not attach ResolvedContainer to ctx — wiring bug")
+		return nil, status.Error(codes.Internal, "report status: identity not resolved")
+	}
+	if h.Status == nil {
+		return nil, status.Error(codes.Unavailable, "report status: status store unavailable")
+	}
+	h.Status.Put(resolved.ContainerID, req, h.Clock())
+	return &agentv1.StatusAck{}, nil
+}
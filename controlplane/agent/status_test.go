@@ -0,0 +1,55 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	agentv1 "github.com/schmitthub/clawker/api/agent/v1"
+	"github.com/schmitthub/clawker/controlplane/agent"
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+func TestHandler_ReportStatus(t *testing.T) {
+	h, err := agent.NewHandler(agent.NewRegistry(nil), nil)
+	require.NoError(t, err)
+	now := time.Unix(5000, 0)
+	h.Clock = func() time.Time { return now }
+	report := &agentv1.StatusReport{ClaudePid: 42, MemoryRssBytes: 1 << 20}
+	ctx := agent.WithResolvedContainer(context.Background(), resolvedFor(t, "myapp", "ctr-1"))
+
+	_, err = h.ReportStatus(ctx, report)
+	assert.Equal(t, codes.Unavailable, status.Code(err), "no store wired")
+
+	h.Status = agent.NewStatusStore()
+	_, err = h.ReportStatus(context.Background(), report)
+	assert.Equal(t, codes.Internal, status.Code(err), "identity not resolved")
+
+	_, err = h.ReportStatus(ctx, report)
+	require.NoError(t, err)
+	sample, ok := h.Status.Get("ctr-1")
+	require.True(t, ok)
+	assert.Equal(t, int32(42), sample.Report.GetClaudePid())
+	assert.Equal(t, now, sample.ReceivedAt)
+}
+
+func TestStatusStore_DropsStaleSamples(t *testing.T) {
+	s := agent.NewStatusStore()
+	start := time.Unix(5000, 0)
+	s.Put("old", &agentv1.StatusReport{}, start)
+	s.Put("fresh", &agentv1.StatusReport{}, start.Add(consts.CPAgentStatusRetention))
+
+	_, ok := s.Get("old")
+	assert.True(t, ok, "retained up to the window")
+
+	s.Put("fresh", &agentv1.StatusReport{}, start.Add(consts.CPAgentStatusRetention+time.Second))
+	_, ok = s.Get("old")
+	assert.False(t, ok)
+	_, ok = s.Get("fresh")
+	assert.True(t, ok)
+}
@@ -0,0 +1,125 @@
+package firewall
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// auditMaxEntriesPerAgent caps the distinct (domain, verdict) rows kept per
+// agent. An agent that walks a large namespace (a crawler, a DNS-tunnel
+// probe) can otherwise grow the CP's memory without bound; lookups of new
+// domains past the cap are counted in AuditSnapshot.Dropped instead.
+const auditMaxEntriesPerAgent = 1024
+
+// AuditEntry is one aggregated row of an agent's egress audit log: every
+// lookup of Domain that got the same verdict.
+type AuditEntry struct {
+	Domain    string
+	Denied    bool
+	Count     uint64
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// AuditSnapshot is a point-in-time copy of one agent's audit log.
+type AuditSnapshot struct {
+	// Entries are sorted denied-first, then by Count (descending), then
+	// by Domain.
+	Entries []AuditEntry
+	Dropped uint64
+	Since   time.Time
+}
+
+// AuditLog aggregates the egress DNS lookups of every agent, keyed by
+// (project, agent) — the container labels — rather than container ID, so a
+// recreated container keeps its history. In-memory only: the log covers
+// the CP's lifetime and starts empty on every CP start.
+//
+// Safe for concurrent use: the DNS audit follower records while the
+// FirewallAudit RPC snapshots.
+type AuditLog struct {
+	mu     sync.Mutex
+	since  time.Time
+	agents map[auditAgentKey]*agentAudit
+}
+
+type auditAgentKey struct {
+	project string
+	agent   string
+}
+
+type auditRowKey struct {
+	domain string
+	denied bool
+}
+
+type agentAudit struct {
+	rows    map[auditRowKey]*AuditEntry
+	dropped uint64
+}
+
+// NewAuditLog returns an empty AuditLog recording from now.
+func NewAuditLog() *AuditLog {
+	return &AuditLog{since: time.Now(), agents: make(map[auditAgentKey]*agentAudit)}
+}
+
+// Record counts one lookup of domain by the agent. domain is normalized
+// (lowercased, trailing dot stripped); an empty domain or agent is ignored.
+func (a *AuditLog) Record(project, agent, domain string, denied bool, at time.Time) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if agent == "" || domain == "" {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := auditAgentKey{project: project, agent: agent}
+	aa, ok := a.agents[key]
+	if !ok {
+		aa = &agentAudit{rows: make(map[auditRowKey]*AuditEntry)}
+		a.agents[key] = aa
+	}
+	rk := auditRowKey{domain: domain, denied: denied}
+	if e, ok := aa.rows[rk]; ok {
+		e.Count++
+		e.LastSeen = at
+		return
+	}
+	if len(aa.rows) >= auditMaxEntriesPerAgent {
+		aa.dropped++
+		return
+	}
+	aa.rows[rk] = &AuditEntry{Domain: domain, Denied: denied, Count: 1, FirstSeen: at, LastSeen: at}
+}
+
+// Snapshot returns a copy of the agent's log. An agent with no recorded
+// lookups yields an empty snapshot, not an error — the CP cannot tell "no
+// traffic yet" from "unknown agent".
+func (a *AuditLog) Snapshot(project, agent string) AuditSnapshot {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	snap := AuditSnapshot{Since: a.since}
+	aa, ok := a.agents[auditAgentKey{project: project, agent: agent}]
+	if !ok {
+		return snap
+	}
+	snap.Dropped = aa.dropped
+	snap.Entries = make([]AuditEntry, 0, len(aa.rows))
+	for _, e := range aa.rows {
+		snap.Entries = append(snap.Entries, *e)
+	}
+	sort.Slice(snap.Entries, func(i, j int) bool {
+		ei, ej := snap.Entries[i], snap.Entries[j]
+		if ei.Denied != ej.Denied {
+			return ei.Denied
+		}
+		if ei.Count != ej.Count {
+			return ei.Count > ej.Count
+		}
+		return ei.Domain < ej.Domain
+	})
+	return snap
+}
@@ -0,0 +1,219 @@
+package firewall
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moby/moby/api/pkg/stdcopy"
+
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// Defaults for the DNS audit follower.
+const (
+	// defaultDNSAuditRetry is the wait before reopening the CoreDNS log
+	// stream after it ends — the stack is down, or CoreDNS was restarted
+	// by a reload.
+	defaultDNSAuditRetry = 2 * time.Second
+
+	// dnsAuditIdentityTTL bounds how long a client-IP → agent binding is
+	// trusted. Docker reuses IPs across container recreation, so bindings
+	// must expire; a lookup per query would cost a ContainerList plus an
+	// inspect per agent.
+	dnsAuditIdentityTTL = 30 * time.Second
+
+	// dnsAuditNegativeTTL bounds how long an IP that matched no agent
+	// (the CP itself, Envoy, monitoring containers) is skipped.
+	dnsAuditNegativeTTL = 10 * time.Second
+)
+
+// DNSAuditDeps carries the collaborators of RunDNSAudit.
+type DNSAuditDeps struct {
+	// Audit receives the attributed lookups. Required.
+	Audit *AuditLog
+
+	// FollowLogs opens a follow-mode stream of CoreDNS stdout starting at
+	// since, in Docker's multiplexed log framing. Production wiring:
+	// Stack.FollowCoreDNSLogs. Required.
+	FollowLogs func(ctx context.Context, since time.Time) (io.ReadCloser, error)
+
+	// Identify resolves a DNS client IP to the agent container's
+	// (project, agent) labels. An error means "not an agent" — the lookup
+	// is skipped. Required.
+	Identify func(ctx context.Context, ip netip.Addr) (project, agent string, err error)
+
+	// Log defaults to a Nop logger.
+	Log *logger.Logger
+
+	// RetryInterval overrides defaultDNSAuditRetry. 0 means default.
+	RetryInterval time.Duration
+}
+
+// RunDNSAudit feeds the egress audit log from CoreDNS's query log until ctx
+// is cancelled. CoreDNS logs one corefileLogFormat line per query in every
+// zone; the client IP attributes the query to an agent and the rcode gives
+// the verdict — NXDOMAIN is what the deny zones, the catch-all zone and the
+// exact-host subdomain templates answer for a blocked name.
+//
+// The log stream is reopened whenever it ends (stack down, CoreDNS
+// restarted on reload), resuming from where the last stream stopped so no
+// query is counted twice. Failures never touch enforcement; they only
+// leave gaps in the audit log.
+func RunDNSAudit(ctx context.Context, deps DNSAuditDeps) {
+	if deps.Log == nil {
+		deps.Log = logger.Nop()
+	}
+	if deps.RetryInterval == 0 {
+		deps.RetryInterval = defaultDNSAuditRetry
+	}
+	a := &dnsAuditor{deps: deps, idents: make(map[netip.Addr]auditIdentity)}
+	defer func() {
+		if rec := recover(); rec != nil {
+			deps.Log.Error().
+				Interface("panic", rec).
+				Str("event", "dns_audit_panic").
+				Msg("DNS audit follower panicked — egress audit log stops recording")
+		}
+	}()
+
+	since := time.Now()
+	for {
+		rc, err := deps.FollowLogs(ctx, since)
+		if err != nil {
+			deps.Log.Debug().Err(err).Str("event", "dns_audit_follow_failed").Msg("CoreDNS log stream unavailable; retrying")
+		} else {
+			a.consume(ctx, rc)
+			rc.Close()
+			since = time.Now()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(deps.RetryInterval):
+		}
+	}
+}
+
+// auditIdentity is a cached client-IP → agent binding. ok=false caches a
+// non-agent IP.
+type auditIdentity struct {
+	project, agent string
+	ok             bool
+	expires        time.Time
+}
+
+type dnsAuditor struct {
+	deps   DNSAuditDeps
+	mu     sync.Mutex
+	idents map[netip.Addr]auditIdentity
+}
+
+// consume demultiplexes one log stream and records every query line.
+// Returns when the stream ends or ctx is cancelled.
+func (a *dnsAuditor) consume(ctx context.Context, rc io.Reader) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(pw, io.Discard, rc)
+		pw.CloseWithError(err)
+	}()
+	defer pr.Close()
+
+	scanner := bufio.NewScanner(pr)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return
+		}
+		q, ok := parseDNSLogLine(scanner.Text())
+		if !ok || isInternalDNSName(q.domain) {
+			continue
+		}
+		project, agent, ok := a.identify(ctx, q.clientIP)
+		if !ok {
+			continue
+		}
+		a.deps.Audit.Record(project, agent, q.domain, q.rcode == "NXDOMAIN", time.Now())
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, io.EOF) && ctx.Err() == nil {
+		a.deps.Log.Debug().Err(err).Str("event", "dns_audit_stream_ended").Msg("CoreDNS log stream ended")
+	}
+}
+
+// identify resolves ip through the TTL cache.
+func (a *dnsAuditor) identify(ctx context.Context, ip netip.Addr) (project, agent string, ok bool) {
+	now := time.Now()
+	a.mu.Lock()
+	id, hit := a.idents[ip]
+	a.mu.Unlock()
+	if hit && now.Before(id.expires) {
+		return id.project, id.agent, id.ok
+	}
+
+	project, agent, err := a.deps.Identify(ctx, ip)
+	id = auditIdentity{project: project, agent: agent, ok: err == nil, expires: now.Add(dnsAuditIdentityTTL)}
+	if err != nil {
+		id.expires = now.Add(dnsAuditNegativeTTL)
+	}
+	a.mu.Lock()
+	a.idents[ip] = id
+	a.mu.Unlock()
+	return id.project, id.agent, id.ok
+}
+
+// dnsQuery is one parsed CoreDNS query log line.
+type dnsQuery struct {
+	clientIP netip.Addr
+	domain   string
+	rcode    string
+}
+
+// parseDNSLogLine extracts the fields of a corefileLogFormat line. CoreDNS
+// prefixes its log lines ("[INFO] "), so parsing starts at the
+// source=coredns marker. Lines without a client IP or name are rejected.
+func parseDNSLogLine(line string) (dnsQuery, bool) {
+	i := strings.Index(line, "source=coredns ")
+	if i < 0 {
+		return dnsQuery{}, false
+	}
+	var q dnsQuery
+	for _, field := range strings.Fields(line[i:]) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "client_ip":
+			ip, err := netip.ParseAddr(value)
+			if err != nil {
+				return dnsQuery{}, false
+			}
+			q.clientIP = ip.Unmap()
+		case "domain":
+			q.domain = strings.ToLower(strings.TrimSuffix(value, "."))
+		case "rcode":
+			q.rcode = value
+		}
+	}
+	if !q.clientIP.IsValid() || q.domain == "" {
+		return dnsQuery{}, false
+	}
+	return q, true
+}
+
+// isInternalDNSName reports whether name is served by a reserved internal
+// zone (Docker's magic hostnames, the monitoring services) — lookups that
+// never leave the host and have no place in an egress audit.
+func isInternalDNSName(name string) bool {
+	for _, host := range append([]string{"docker.internal"}, consts.MonitoringServiceHostnames...) {
+		if name == host || strings.HasSuffix(name, "."+host) {
+			return true
+		}
+	}
+	return false
+}
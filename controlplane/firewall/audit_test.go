@@ -0,0 +1,183 @@
+package firewall
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+func TestAuditLog_RecordAggregatesAndSorts(t *testing.T) {
+	a := NewAuditLog()
+	t0 := time.Unix(1000, 0)
+	t1 := time.Unix(2000, 0)
+
+	a.Record("proj", "dev", "api.example.com", false, t0)
+	a.Record("proj", "dev", "API.Example.com.", false, t1)
+	a.Record("proj", "dev", "b.example.com", false, t0)
+	a.Record("proj", "dev", "tracker.example.com", true, t0)
+	a.Record("proj", "other", "api.example.com", false, t0)
+	a.Record("proj", "", "ignored.example.com", false, t0)
+
+	snap := a.Snapshot("proj", "dev")
+	require.Len(t, snap.Entries, 3)
+	assert.Equal(t, AuditEntry{Domain: "tracker.example.com", Denied: true, Count: 1, FirstSeen: t0, LastSeen: t0}, snap.Entries[0])
+	assert.Equal(t, AuditEntry{Domain: "api.example.com", Count: 2, FirstSeen: t0, LastSeen: t1}, snap.Entries[1])
+	assert.Equal(t, "b.example.com", snap.Entries[2].Domain)
+	assert.Zero(t, snap.Dropped)
+
+	assert.Len(t, a.Snapshot("proj", "other").Entries, 1)
+	assert.Empty(t, a.Snapshot("proj", "missing").Entries)
+}
+
+func TestAuditLog_CapCountsDropped(t *testing.T) {
+	a := NewAuditLog()
+	now := time.Now()
+	for i := range auditMaxEntriesPerAgent + 5 {
+		a.Record("proj", "dev", fmt.Sprintf("d%d.example.com", i), false, now)
+	}
+	// Known domains keep counting past the cap.
+	a.Record("proj", "dev", "d0.example.com", false, now)
+
+	snap := a.Snapshot("proj", "dev")
+	assert.Len(t, snap.Entries, auditMaxEntriesPerAgent)
+	assert.Equal(t, uint64(5), snap.Dropped)
+	assert.Equal(t, "d0.example.com", snap.Entries[0].Domain)
+	assert.Equal(t, uint64(2), snap.Entries[0].Count)
+}
+
+func TestParseDNSLogLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want dnsQuery
+		ok   bool
+	}{
+		{
+			name: "allowed",
+			line: `[INFO] source=coredns client_ip=172.18.0.5 domain=api.github.com. qtype=A rcode=NOERROR duration=0.001s`,
+			want: dnsQuery{clientIP: netip.MustParseAddr("172.18.0.5"), domain: "api.github.com", rcode: "NOERROR"},
+			ok:   true,
+		},
+		{
+			name: "denied",
+			line: `[INFO] source=coredns client_ip=172.18.0.5 domain=Tracker.Example.com. qtype=AAAA rcode=NXDOMAIN duration=0s`,
+			want: dnsQuery{clientIP: netip.MustParseAddr("172.18.0.5"), domain: "tracker.example.com", rcode: "NXDOMAIN"},
+			ok:   true,
+		},
+		{name: "not a query line", line: `[INFO] plugin/reload: Running configuration SHA512 = abc`},
+		{name: "bad client ip", line: `source=coredns client_ip=nope domain=a.com. rcode=NOERROR`},
+		{name: "missing domain", line: `source=coredns client_ip=172.18.0.5 rcode=NOERROR`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseDNSLogLine(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+// muxedLog frames lines the way Docker's log endpoint does for a non-TTY
+// container, so the follower's stdcopy demux is exercised.
+func muxedLog(t *testing.T, lines ...string) io.ReadCloser {
+	t.Helper()
+	var buf bytes.Buffer
+	for _, l := range lines {
+		payload := []byte(l + "\n")
+		header := [8]byte{0: byte(stdcopy.Stdout)}
+		binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+		buf.Write(header[:])
+		buf.Write(payload)
+	}
+	return io.NopCloser(&buf)
+}
+
+func TestRunDNSAudit_AttributesQueries(t *testing.T) {
+	audit := NewAuditLog()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var follows, lookups atomic.Int32
+	agentIP := netip.MustParseAddr("172.18.0.5")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		RunDNSAudit(ctx, DNSAuditDeps{
+			Audit: audit,
+			FollowLogs: func(_ context.Context, _ time.Time) (io.ReadCloser, error) {
+				if follows.Add(1) > 1 {
+					cancel()
+					return nil, errors.New("stream closed")
+				}
+				return muxedLog(t,
+					`[INFO] source=coredns client_ip=172.18.0.5 domain=api.github.com. qtype=A rcode=NOERROR duration=0s`,
+					`[INFO] source=coredns client_ip=172.18.0.5 domain=api.github.com. qtype=AAAA rcode=NOERROR duration=0s`,
+					`[INFO] source=coredns client_ip=172.18.0.5 domain=tracker.example.com. qtype=A rcode=NXDOMAIN duration=0s`,
+					`[INFO] source=coredns client_ip=172.18.0.5 domain=host.docker.internal. qtype=A rcode=NOERROR duration=0s`,
+					`[INFO] source=coredns client_ip=172.18.0.9 domain=api.github.com. qtype=A rcode=NOERROR duration=0s`,
+				), nil
+			},
+			Identify: func(_ context.Context, ip netip.Addr) (string, string, error) {
+				lookups.Add(1)
+				if ip != agentIP {
+					return "", "", errors.New("not an agent")
+				}
+				return "proj", "dev", nil
+			},
+			Log:           logger.Nop(),
+			RetryInterval: time.Millisecond,
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunDNSAudit did not return after cancel")
+	}
+
+	snap := audit.Snapshot("proj", "dev")
+	require.Len(t, snap.Entries, 2)
+	assert.Equal(t, "tracker.example.com", snap.Entries[0].Domain)
+	assert.True(t, snap.Entries[0].Denied)
+	assert.Equal(t, "api.github.com", snap.Entries[1].Domain)
+	assert.Equal(t, uint64(2), snap.Entries[1].Count)
+	// One lookup per distinct client IP; the rest hit the cache.
+	assert.Equal(t, int32(2), lookups.Load())
+}
+
+func TestFirewallAudit(t *testing.T) {
+	h := newTestHandler(t, noopMock(), nil)
+	seen := time.Unix(1700000000, 0)
+	h.AuditLog().Record("proj", "dev", "api.github.com", false, seen)
+	h.AuditLog().Record("proj", "dev", "tracker.example.com", true, seen)
+
+	res, err := h.FirewallAudit(context.Background(), &adminv1.FirewallAuditRequest{Project: "proj", AgentName: "dev"})
+	require.NoError(t, err)
+	require.Len(t, res.GetEntries(), 2)
+	assert.Equal(t, consts.VerdictDenied, res.GetEntries()[0].GetAction())
+	assert.Equal(t, consts.VerdictAllowed, res.GetEntries()[1].GetAction())
+	assert.Equal(t, seen.Unix(), res.GetEntries()[1].GetLastSeenUnix())
+	assert.NotZero(t, res.GetSinceUnix())
+
+	_, err = h.FirewallAudit(context.Background(), &adminv1.FirewallAuditRequest{Project: "proj"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -89,7 +89,13 @@ struct container_config {
 	__u32 host_proxy_ip;   // Host proxy IP (network byte order)
 	__u16 host_proxy_port; // Host proxy port (host byte order)
 	__u16 egress_port;     // Envoy egress listener port (host byte order)
+	__u32 proj_net_addr;   // project network address, 0 if none (network byte order)
+	__u32 proj_net_mask;   // project network subnet mask (network byte order)
+	__u32 proj_gateway_ip; // project network gateway IP (network byte order)
 };
+// The proj_* fields were appended after the ports so they pack without
+// padding; Manager.Load replaces a pinned container_map of the old size.
+_Static_assert(sizeof(struct container_config) == 40, "container_config must be 40 bytes");
 
 // DNS cache entry: resolved IP → domain identity.
 // Written by the CoreDNS dnsbpf plugin on every resolution; read by userspace
@@ -419,6 +425,16 @@ static __always_inline bool is_in_subnet(__u32 ip, __u32 net_addr, __u32 net_mas
 	return (ip & net_mask) == net_addr;
 }
 
+// is_project_peer: IPv4 address is a container on the agent's project
+// network (its sidecars). The project gateway is the host, not a peer, so
+// it falls through to the egress rules like any other address.
+static __always_inline bool is_project_peer(struct container_config *cfg, __u32 ip)
+{
+	return cfg->proj_net_mask != 0 &&
+	       is_in_subnet(ip, cfg->proj_net_addr, cfg->proj_net_mask) &&
+	       ip != cfg->proj_gateway_ip;
+}
+
 // is_ipv6_loopback: ::1
 static __always_inline bool is_ipv6_loopback(const struct bpf_sock_addr *ctx)
 {
@@ -848,6 +864,9 @@ decide_connect(struct bpf_sock_addr *ctx, struct container_config *cfg,
 	if (is_in_subnet(dst_ip, cfg->net_addr, cfg->net_mask))
 		return r;
 
+	if (is_project_peer(cfg, dst_ip))
+		return r;
+
 	if (cfg->host_proxy_ip != 0 &&
 	    dst_ip == cfg->host_proxy_ip && dst_port == cfg->host_proxy_port)
 		return r;
@@ -927,7 +946,7 @@ decide_connect(struct bpf_sock_addr *ctx, struct container_config *cfg,
 
 // decide_sendmsg is the UDP-only counterpart to decide_connect for sendmsg4 and
 // the IPv4-mapped branch of sendmsg6 — i.e. UNCONNECTED UDP. DNS redirect,
-// loopback/subnet pass-through, per-domain route, else deny.
+// loopback/subnet/project-peer pass-through, per-domain route, else deny.
 //
 // Per-domain routing mirrors decide_connect's SOCK_DGRAM branch (dns_cache →
 // route_map(SOCK_DGRAM) → dedicated/QUIC listener). The difference vs a
@@ -959,6 +978,9 @@ decide_sendmsg(struct container_config *cfg, __u64 cgroup_id,
 	if (is_in_subnet(dst_ip, cfg->net_addr, cfg->net_mask))
 		return r;
 
+	if (is_project_peer(cfg, dst_ip))
+		return r;
+
 	// Non-DNS UDP: per-domain routing. On a hit, record the original dst for
 	// the recvmsg4 reverse-rewrite, then redirect; on a miss, fail closed.
 	__u32 udp_hash = 0;
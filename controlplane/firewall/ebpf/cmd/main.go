@@ -41,6 +41,9 @@ type enableArgs struct {
 	HostProxyIP   string `json:"host_proxy_ip"`
 	HostProxyPort uint16 `json:"host_proxy_port"`
 	EgressPort    uint16 `json:"egress_port"`
+	// Optional: the agent's project network, for sidecar passthrough.
+	ProjectCIDR      string `json:"project_cidr,omitempty"`
+	ProjectGatewayIP string `json:"project_gateway_ip,omitempty"`
 }
 
 func main() {
@@ -118,6 +121,11 @@ func runEnable(log *logger.Logger, cgroupPath, configJSON string) {
 	if err != nil {
 		fatal("enable", err)
 	}
+	if args.ProjectCIDR != "" {
+		if err := cfg.SetProjectNetwork(args.ProjectCIDR, args.ProjectGatewayIP); err != nil {
+			fatal("enable", err)
+		}
+	}
 
 	cgroupID, err := clawkerebpf.CgroupID(cgroupPath)
 	if err != nil {
@@ -276,6 +284,10 @@ func runDump(log *logger.Logger, cgroupPath string) {
 		fmt.Printf("  host_proxy_ip=%s host_proxy_port=%d egress_port=%d\n",
 			clawkerebpf.Uint32ToIP(cfg.HostProxyIp),
 			cfg.HostProxyPort, cfg.EgressPort)
+		fmt.Printf("  proj_net_addr=%s proj_net_mask=%s proj_gateway_ip=%s\n",
+			clawkerebpf.Uint32ToIP(cfg.ProjNetAddr),
+			clawkerebpf.Uint32ToIP(cfg.ProjNetMask),
+			clawkerebpf.Uint32ToIP(cfg.ProjGatewayIp))
 	}
 }
 
@@ -363,6 +375,10 @@ func runDumpContainers(log *logger.Logger, asJSON bool) {
 		fmt.Printf("    host_proxy_ip=%s host_proxy_port=%d egress_port=%d\n",
 			clawkerebpf.Uint32ToIP(c.HostProxyIP),
 			c.HostProxyPort, c.EgressPort)
+		fmt.Printf("    proj_net_addr=%s proj_net_mask=%s proj_gateway_ip=%s\n",
+			clawkerebpf.Uint32ToIP(c.ProjNetAddr),
+			clawkerebpf.Uint32ToIP(c.ProjNetMask),
+			clawkerebpf.Uint32ToIP(c.ProjGatewayIP))
 	}
 }
 
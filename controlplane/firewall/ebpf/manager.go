@@ -1080,6 +1080,9 @@ func (m *Manager) DumpContainers() ([]ContainerEntry, error) {
 				HostProxyIP:   cfg.HostProxyIp,
 				HostProxyPort: cfg.HostProxyPort,
 				EgressPort:    cfg.EgressPort,
+				ProjNetAddr:   cfg.ProjNetAddr,
+				ProjNetMask:   cfg.ProjNetMask,
+				ProjGatewayIP: cfg.ProjGatewayIp,
 			},
 		})
 	}
@@ -1194,6 +1197,25 @@ func NewContainerConfig(envoyIP, corednsIP, gatewayIP, cidr string,
 	return cfg, nil
 }
 
+// SetProjectNetwork records the agent's project network (see
+// docker.ProjectNetworkName) so connections to its sidecars pass through
+// like clawker-net traffic. The project gateway is the host and stays
+// subject to the egress rules.
+func (c *clawkerContainerConfig) SetProjectNetwork(cidr, gatewayIP string) error {
+	netAddr, netMask, err := CIDRToAddrMask(cidr)
+	if err != nil {
+		return fmt.Errorf("parsing project CIDR %s: %w", cidr, err)
+	}
+	gateway, err := parseIP(gatewayIP)
+	if err != nil {
+		return fmt.Errorf("project gatewayIP: %w", err)
+	}
+	c.ProjNetAddr = netAddr
+	c.ProjNetMask = netMask
+	c.ProjGatewayIp = IPToUint32(gateway)
+	return nil
+}
+
 // CgroupPath returns the cgroup v2 path for a Docker container.
 func CgroupPath(containerID string) string {
 	return filepath.Join("/sys/fs/cgroup/system.slice", "docker-"+containerID+".scope")
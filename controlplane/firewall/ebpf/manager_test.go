@@ -54,6 +54,17 @@ func TestUDPFlowKey_SizeMatchesABI(t *testing.T) {
 	}
 }
 
+// TestContainerConfig_SizeMatchesABI mirrors the C-side
+// _Static_assert(sizeof(struct container_config) == 40). The project network
+// fields sit after the two u16 ports; a reorder that introduces padding would
+// shift them and the BPF side would read the wrong subnet.
+func TestContainerConfig_SizeMatchesABI(t *testing.T) {
+	t.Parallel()
+	if got := binary.Size(ContainerConfig{}); got != 40 {
+		t.Fatalf("ContainerConfig on-wire size = %d; want 40 — C struct container_config has drifted from the Go side", got)
+	}
+}
+
 // TestDiffSeededIPs covers the IP-literal seed lifecycle that keeps a valid UDP
 // route from failing closed: GarbageCollectDNS protects every IP in the seed set,
 // and SyncRoutes drops the protection (and removes the orphan dns_cache entry)
@@ -50,6 +50,9 @@ type ContainerConfig struct {
 	HostProxyIP   uint32 // Host proxy resolved IP (network byte order)
 	HostProxyPort uint16 // Host proxy port (host byte order)
 	EgressPort    uint16 // Envoy egress listener port (host byte order)
+	ProjNetAddr   uint32 // project network address, 0 if none (network byte order)
+	ProjNetMask   uint32 // project network subnet mask (network byte order)
+	ProjGatewayIP uint32 // project network gateway IP (network byte order)
 }
 
 // DNSEntry mirrors struct dns_entry in bpf/common.h.
@@ -20,6 +20,7 @@ import (
 	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
 	ebpf "github.com/schmitthub/clawker/controlplane/firewall/ebpf"
 	"github.com/schmitthub/clawker/controlplane/pubsub"
+	"github.com/schmitthub/clawker/internal/audit"
 	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/logger"
@@ -118,6 +119,15 @@ type Handler struct {
 	enrolled   *pubsub.Topic[ebpf.EBPFContainerEnrolled]
 	certDirF   func() (string, error)
 	listAgents func(ctx context.Context) ([]string, error)
+	projectNet ProjectNetworkResolver
+
+	// audit is the egress audit log RunDNSAudit feeds and FirewallAudit
+	// reads. Always non-nil.
+	audit *AuditLog
+
+	// journal records applied firewall changes (HandlerDeps.Journal); nil
+	// when not wired.
+	journal func(audit.Entry)
 
 	// resolveHostFn is injectable for tests. nil defaults to
 	// net.DefaultResolver.LookupHost.
@@ -179,6 +189,17 @@ type HandlerDeps struct {
 	// restarted. Nil means "no re-enrollment" (test wiring and flows
 	// that never want Init to touch agent state).
 	ListAgents func(ctx context.Context) ([]string, error)
+
+	// ProjectNetworks looks up the project network an agent shares with
+	// its sidecars, so the BPF passthrough covers that subnet too. Nil
+	// means clawker-net only: sidecars on a project network are then
+	// unreachable from a firewalled agent.
+	ProjectNetworks ProjectNetworkResolver
+
+	// Journal receives an audit journal entry for each firewall change the
+	// handler applies: enable, disable, bypass and its expiry, rule add and
+	// remove, CA rotation, and teardown. Nil disables journaling.
+	Journal func(audit.Entry)
 }
 
 // maxBypassTimeout caps how long a single FirewallBypass call can
@@ -251,12 +272,30 @@ func NewHandler(deps HandlerDeps) (*Handler, error) {
 		enrolled:       deps.EnrolledTopic,
 		certDirF:       certDirFn,
 		listAgents:     deps.ListAgents,
+		projectNet:     deps.ProjectNetworks,
+		audit:          NewAuditLog(),
+		journal:        deps.Journal,
 		cgroupIDFn:     ebpf.CgroupID,
 		bypassTimers:   make(map[string]*bypassEntry),
 		storedCgroupID: make(map[string]uint64),
 	}, nil
 }
 
+// record journals a firewall change the handler applied.
+func (h *Handler) record(action, target string, params map[string]string) {
+	if h.journal == nil {
+		return
+	}
+	h.journal(audit.Entry{
+		Event:    audit.EventOperation,
+		Actor:    audit.ActorControlPlane,
+		Action:   action,
+		Resource: "firewall",
+		Target:   target,
+		Params:   params,
+	})
+}
+
 // submit routes a closure through the queue and type-asserts the
 // Result. Centralizes the "queue rejected" branch so every RPC
 // uniformly surfaces ErrQueueClosed when the CP is draining.
@@ -430,7 +469,16 @@ func (h *Handler) reenrollAgents(ctx context.Context) {
 			failed++
 			continue
 		}
-		if err := h.ebpf.Install(cgroupID, cgroupPath, bpfCfg); err != nil {
+		cfg, err := h.withProjectNetwork(ctx, rid, bpfCfg)
+		if err != nil {
+			h.log.Warn().
+				Err(err).
+				Str("container_id", rid).
+				Msg("firewall ebpf: re-enroll: project network failed, skipping container")
+			failed++
+			continue
+		}
+		if err := h.ebpf.Install(cgroupID, cgroupPath, cfg); err != nil {
 			h.log.Warn().
 				Err(err).
 				Str("container_id", rid).
@@ -461,6 +509,25 @@ func (h *Handler) reenrollAgents(ctx context.Context) {
 		Msg("firewall ebpf: re-enroll complete")
 }
 
+// withProjectNetwork returns base with the container's project network
+// recorded, when it has one, so the agent can reach its sidecars.
+func (h *Handler) withProjectNetwork(ctx context.Context, cid string, base ebpf.BPFContainerConfig) (ebpf.BPFContainerConfig, error) {
+	if h.projectNet == nil {
+		return base, nil
+	}
+	subnet, gateway, ok, err := h.projectNet(ctx, cid)
+	if err != nil {
+		return base, fmt.Errorf("resolve project network: %w", err)
+	}
+	if !ok {
+		return base, nil
+	}
+	if err := base.SetProjectNetwork(subnet.String(), gateway.String()); err != nil {
+		return base, err
+	}
+	return base, nil
+}
+
 // FirewallRemove is global teardown. Pre-Submit: cancel pending bypass
 // timers so they can't fire against maps that are about to be flushed.
 // Queued closure: stop stack, flush eBPF state, delete generated config
@@ -502,6 +569,7 @@ func (h *Handler) FirewallRemove(
 	if err != nil {
 		return nil, toStatus(fmt.Errorf("firewall remove: %w", err))
 	}
+	h.record("teardown", "", nil)
 	return &adminv1.FirewallRemoveResult{}, nil
 }
 
@@ -573,6 +641,10 @@ func (h *Handler) FirewallEnable(
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "firewall enable: build container config: %v", err)
 	}
+	bpfCfg, err = h.withProjectNetwork(ctx, cid, bpfCfg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "firewall enable: %v", err)
+	}
 
 	_, err = h.submit(ActionEnable, func(_ context.Context) (any, error) {
 		if err := h.ebpf.Install(cgroupID, cgroupPath, bpfCfg); err != nil {
@@ -588,6 +660,7 @@ func (h *Handler) FirewallEnable(
 	}
 
 	h.publishEnrolled(cid, cgroupID)
+	h.record("enable", cid, nil)
 
 	h.log.Info().
 		Str("container_id", cid).
@@ -646,6 +719,7 @@ func (h *Handler) FirewallDisable(
 		Str("container_id", cid).
 		Uint64("cgroup_id", cgroupID).
 		Msg("firewall disabled")
+	h.record("disable", cid, nil)
 	return &adminv1.FirewallDisableResult{}, nil
 }
 
@@ -712,6 +786,7 @@ func (h *Handler) FirewallBypass(
 		Uint64("cgroup_id", cgroupID).
 		Dur("timeout", timeout).
 		Msg("bypass started with server-side failsafe")
+	h.record("bypass", cid, map[string]string{"timeout": timeout.String()})
 	return &adminv1.FirewallBypassResult{}, nil
 }
 
@@ -749,6 +824,7 @@ func (h *Handler) FirewallAddRules(
 	if !anyAddChange(statuses) {
 		return &adminv1.FirewallAddRulesResult{Statuses: toProtoAddStatuses(statuses)}, nil
 	}
+	h.record("add-rules", "", map[string]string{"rules": changedRuleDsts(rules, statuses)})
 
 	val, err := h.submit(ActionReconcile, h.reconcileStackClosure)
 	if err != nil {
@@ -799,6 +875,7 @@ func (h *Handler) FirewallRemoveRule(
 			Status: toProtoRemoveStatus(removeStatusNotFound),
 		}, nil
 	}
+	h.record("remove-rule", rule.Dst, ruleParams(rule, req.GetPath()))
 
 	val, err := h.submit(ActionReconcile, h.reconcileStackClosure)
 	if err != nil {
@@ -990,6 +1067,7 @@ func (h *Handler) FirewallRotateCA(
 	if err := RotateCA(certDir, rules); err != nil {
 		return nil, toStatus(fmt.Errorf("%w: %v", ErrCertRegen, err))
 	}
+	h.record("rotate-ca", "", nil)
 
 	val, err := h.submit(ActionReconcile, h.reconcileStackClosure)
 	if err != nil {
@@ -1057,6 +1135,42 @@ func (h *Handler) FirewallResolveHostname(
 	return &adminv1.FirewallResolveHostnameResult{Addresses: r.Addresses}, nil
 }
 
+// AuditLog returns the handler's egress audit log so the CP can wire the
+// DNS audit follower (RunDNSAudit) into it.
+func (h *Handler) AuditLog() *AuditLog { return h.audit }
+
+// FirewallAudit returns one agent's aggregated egress lookups. It reads the
+// in-memory audit log directly rather than through the queue — nothing in
+// it touches the stack or eBPF state.
+func (h *Handler) FirewallAudit(
+	_ context.Context,
+	req *adminv1.FirewallAuditRequest,
+) (*adminv1.FirewallAuditResult, error) {
+	if req.GetAgentName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "agent_name is required")
+	}
+	snap := h.audit.Snapshot(req.GetProject(), req.GetAgentName())
+	entries := make([]*adminv1.FirewallAuditEntry, 0, len(snap.Entries))
+	for _, e := range snap.Entries {
+		action := consts.VerdictAllowed
+		if e.Denied {
+			action = consts.VerdictDenied
+		}
+		entries = append(entries, &adminv1.FirewallAuditEntry{
+			Domain:        e.Domain,
+			Action:        action,
+			Count:         e.Count,
+			FirstSeenUnix: e.FirstSeen.Unix(),
+			LastSeenUnix:  e.LastSeen.Unix(),
+		})
+	}
+	return &adminv1.FirewallAuditResult{
+		Entries:   entries,
+		Dropped:   snap.Dropped,
+		SinceUnix: snap.Since.Unix(),
+	}, nil
+}
+
 // --- Closures shared across RPCs ---
 
 // reconcileStackClosure is the queued closure every rule-CRUD, reload,
@@ -1268,6 +1382,7 @@ func (h *Handler) bypassTimerFired(cid string, entry *bypassEntry) {
 			Uint64("cgroup_id", enableID).
 			Str("container_id", entry.containerID).
 			Msg("bypass timer expired, enforcement restored")
+		h.record("enable", entry.containerID, map[string]string{"reason": "bypass expired"})
 	case errors.Is(res.Err, ErrClosed):
 		h.log.Info().
 			Uint64("cgroup_id", enableID).
@@ -12,6 +12,7 @@ import (
 	ebpf "github.com/schmitthub/clawker/controlplane/firewall/ebpf"
 	ebpfmocks "github.com/schmitthub/clawker/controlplane/firewall/ebpf/mocks"
 	"github.com/schmitthub/clawker/controlplane/pubsub"
+	"github.com/schmitthub/clawker/internal/audit"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/consts"
@@ -240,6 +241,52 @@ func TestHandler_FirewallEnable_Success(t *testing.T) {
 	}
 }
 
+// TestHandler_FirewallEnable_ProjectNetwork pins the sidecar passthrough:
+// an agent on a project network gets that subnet and gateway recorded in
+// its container_config, and one without keeps the proj_* fields zero.
+func TestHandler_FirewallEnable_ProjectNetwork(t *testing.T) {
+	t.Run("attached", func(t *testing.T) {
+		mock := noopMock()
+		h := newTestHandler(t, mock, nil)
+		h.projectNet = func(_ context.Context, _ string) (netip.Prefix, netip.Addr, bool, error) {
+			return netip.MustParsePrefix("172.21.0.0/16"), netip.MustParseAddr("172.21.0.1"), true, nil
+		}
+
+		_, err := h.FirewallEnable(context.Background(), &adminv1.FirewallEnableRequest{ContainerId: "ctr-1"})
+		require.NoError(t, err)
+		require.Len(t, mock.InstallCalls(), 1)
+		got := mock.InstallCalls()[0].Cfg
+		assert.Equal(t, "172.21.0.0", ebpf.Uint32ToIP(got.ProjNetAddr).String())
+		assert.Equal(t, "255.255.0.0", ebpf.Uint32ToIP(got.ProjNetMask).String())
+		assert.Equal(t, "172.21.0.1", ebpf.Uint32ToIP(got.ProjGatewayIp).String())
+	})
+
+	t.Run("none", func(t *testing.T) {
+		mock := noopMock()
+		h := newTestHandler(t, mock, nil)
+		h.projectNet = func(_ context.Context, _ string) (netip.Prefix, netip.Addr, bool, error) {
+			return netip.Prefix{}, netip.Addr{}, false, nil
+		}
+
+		_, err := h.FirewallEnable(context.Background(), &adminv1.FirewallEnableRequest{ContainerId: "ctr-1"})
+		require.NoError(t, err)
+		require.Len(t, mock.InstallCalls(), 1)
+		assert.Zero(t, mock.InstallCalls()[0].Cfg.ProjNetMask)
+	})
+
+	t.Run("resolve error", func(t *testing.T) {
+		mock := noopMock()
+		h := newTestHandler(t, mock, nil)
+		h.projectNet = func(_ context.Context, _ string) (netip.Prefix, netip.Addr, bool, error) {
+			return netip.Prefix{}, netip.Addr{}, false, errors.New("docker down")
+		}
+
+		_, err := h.FirewallEnable(context.Background(), &adminv1.FirewallEnableRequest{ContainerId: "ctr-1"})
+		assertCode(t, err, codes.Internal)
+		assert.Empty(t, mock.InstallCalls())
+	})
+}
+
 func TestHandler_FirewallEnable_EmptyContainerID(t *testing.T) {
 	h := newTestHandler(t, noopMock(), nil)
 	_, err := h.FirewallEnable(context.Background(), &adminv1.FirewallEnableRequest{})
@@ -426,6 +473,30 @@ func TestHandler_FirewallDisable_ContainerUnknown_NoOp(t *testing.T) {
 	}
 }
 
+// TestHandler_Journal_RecordsAppliedChanges pins that a firewall change the
+// handler applies is journaled with the container it touched, and that a
+// no-op Disable on a container never enrolled is not.
+func TestHandler_Journal_RecordsAppliedChanges(t *testing.T) {
+	resolver := func(_ context.Context, ref string) (string, string, bool, error) {
+		return ref, "", ref == "ctr-1", nil
+	}
+	h := newTestHandler(t, noopMock(), resolver)
+	var got []audit.Entry
+	h.journal = func(e audit.Entry) { got = append(got, e) }
+
+	_, err := h.FirewallDisable(context.Background(), &adminv1.FirewallDisableRequest{ContainerId: "ctr-1"})
+	require.NoError(t, err)
+	_, err = h.FirewallDisable(context.Background(), &adminv1.FirewallDisableRequest{ContainerId: "never-seen"})
+	require.NoError(t, err)
+
+	require.Len(t, got, 1)
+	assert.Equal(t, audit.EventOperation, got[0].Event)
+	assert.Equal(t, audit.ActorControlPlane, got[0].Actor)
+	assert.Equal(t, "disable", got[0].Action)
+	assert.Equal(t, "firewall", got[0].Resource)
+	assert.Equal(t, "ctr-1", got[0].Target)
+}
+
 // ---------------------------------------------------------------------------
 // FirewallBypass
 // ---------------------------------------------------------------------------
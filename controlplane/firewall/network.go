@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"net/netip"
 
+	"github.com/moby/moby/client"
+
 	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
 )
 
@@ -92,3 +95,43 @@ func ComputeStaticIP(gateway netip.Addr, lastOctet byte) (netip.Addr, error) {
 	octets[3] = lastOctet
 	return netip.AddrFrom4(octets), nil
 }
+
+// ProjectNetworkResolver returns the subnet and gateway of the project
+// network a container is attached to (docker.ProjectNetworkName). ok=false
+// means the container has none — a global-scope agent, a project with
+// network.shared set, or a container created before per-project networks.
+type ProjectNetworkResolver func(ctx context.Context, containerID string) (subnet netip.Prefix, gateway netip.Addr, ok bool, err error)
+
+// NewProjectNetworkResolver builds a ProjectNetworkResolver backed by a live
+// Docker client. The network is found by name from the container's project
+// label, and its subnet read off the container's own endpoint, so no
+// network inspect is needed.
+func NewProjectNetworkResolver(dc *docker.Client) ProjectNetworkResolver {
+	return func(ctx context.Context, containerID string) (netip.Prefix, netip.Addr, bool, error) {
+		info, err := dc.ContainerInspect(ctx, containerID, client.ContainerInspectOptions{})
+		if err != nil {
+			return netip.Prefix{}, netip.Addr{}, false, fmt.Errorf("inspecting container %s: %w", containerID, err)
+		}
+		c := info.Container
+		if c.Config == nil || c.NetworkSettings == nil {
+			return netip.Prefix{}, netip.Addr{}, false, nil
+		}
+		project := c.Config.Labels[consts.LabelProject]
+		if project == "" {
+			return netip.Prefix{}, netip.Addr{}, false, nil
+		}
+		name, err := docker.ProjectNetworkName(project)
+		if err != nil {
+			return netip.Prefix{}, netip.Addr{}, false, nil
+		}
+		ep, attached := c.NetworkSettings.Networks[name]
+		if !attached || ep == nil || !ep.IPAddress.Is4() || !ep.Gateway.Is4() {
+			return netip.Prefix{}, netip.Addr{}, false, nil
+		}
+		subnet, err := ep.IPAddress.Prefix(ep.IPPrefixLen)
+		if err != nil {
+			return netip.Prefix{}, netip.Addr{}, false, fmt.Errorf("project network %s: %w", name, err)
+		}
+		return subnet, ep.Gateway, true, nil
+	}
+}
@@ -1,13 +1,20 @@
 package firewall_test
 
 import (
+	"context"
 	"net/netip"
 	"testing"
 
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+	mobyclient "github.com/moby/moby/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	fwcp "github.com/schmitthub/clawker/controlplane/firewall"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	dockermocks "github.com/schmitthub/clawker/internal/docker/mocks"
 )
 
 func TestComputeStaticIP(t *testing.T) {
@@ -46,3 +53,61 @@ func TestComputeStaticIP(t *testing.T) {
 		})
 	}
 }
+
+func TestNewProjectNetworkResolver(t *testing.T) {
+	inspect := func(fake *dockermocks.FakeClient, project string, networks map[string]*network.EndpointSettings) {
+		fake.FakeAPI.ContainerInspectFn = func(_ context.Context, id string, _ mobyclient.ContainerInspectOptions) (mobyclient.ContainerInspectResult, error) {
+			return mobyclient.ContainerInspectResult{
+				Container: container.InspectResponse{
+					ID: id,
+					Config: &container.Config{Labels: map[string]string{
+						fake.Cfg.LabelManaged(): fake.Cfg.ManagedLabelValue(),
+						consts.LabelProject:     project,
+					}},
+					NetworkSettings: &container.NetworkSettings{Networks: networks},
+				},
+			}, nil
+		}
+	}
+	clawkerNet := &network.EndpointSettings{
+		IPAddress:   netip.MustParseAddr("172.20.0.5"),
+		IPPrefixLen: 16,
+		Gateway:     netip.MustParseAddr("172.20.0.1"),
+	}
+
+	t.Run("attached", func(t *testing.T) {
+		fake := dockermocks.NewFakeClient(configmocks.NewBlankConfig())
+		inspect(fake, "myapp", map[string]*network.EndpointSettings{
+			consts.Network: clawkerNet,
+			"clawker-myapp": {
+				IPAddress:   netip.MustParseAddr("172.21.0.3"),
+				IPPrefixLen: 16,
+				Gateway:     netip.MustParseAddr("172.21.0.1"),
+			},
+		})
+
+		subnet, gateway, ok, err := fwcp.NewProjectNetworkResolver(fake.Client)(t.Context(), "ctr-1")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "172.21.0.0/16", subnet.String())
+		assert.Equal(t, "172.21.0.1", gateway.String())
+	})
+
+	t.Run("clawker network only", func(t *testing.T) {
+		fake := dockermocks.NewFakeClient(configmocks.NewBlankConfig())
+		inspect(fake, "myapp", map[string]*network.EndpointSettings{consts.Network: clawkerNet})
+
+		_, _, ok, err := fwcp.NewProjectNetworkResolver(fake.Client)(t.Context(), "ctr-1")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("global scope", func(t *testing.T) {
+		fake := dockermocks.NewFakeClient(configmocks.NewBlankConfig())
+		inspect(fake, "", map[string]*network.EndpointSettings{consts.Network: clawkerNet})
+
+		_, _, ok, err := fwcp.NewProjectNetworkResolver(fake.Client)(t.Context(), "ctr-1")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
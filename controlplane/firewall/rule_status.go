@@ -1,6 +1,11 @@
 package firewall
 
-import adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+import (
+	"strings"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/internal/config"
+)
 
 // addStatus is the package-internal per-rule outcome of addRulesToStore.
 // Maps 1:1 to adminv1.AddRuleStatus via toProtoAddStatus so handler logic
@@ -69,3 +74,32 @@ func anyAddChange(statuses []addStatus) bool {
 	}
 	return false
 }
+
+// changedRuleDsts lists the destinations of rules whose addStatus is a store
+// mutation, comma-joined for the audit journal. statuses is index-aligned
+// with rules.
+func changedRuleDsts(rules []config.EgressRule, statuses []addStatus) string {
+	var dsts []string
+	for i, s := range statuses {
+		if i < len(rules) && (s == addStatusAdded || s == addStatusModified) {
+			dsts = append(dsts, rules[i].Dst)
+		}
+	}
+	return strings.Join(dsts, ",")
+}
+
+// ruleParams renders a removed rule's match key as audit journal params,
+// omitting empty fields.
+func ruleParams(rule config.EgressRule, path string) map[string]string {
+	params := map[string]string{}
+	if rule.Proto != "" {
+		params["proto"] = rule.Proto
+	}
+	if rule.Port != "" {
+		params["port"] = rule.Port
+	}
+	if path != "" {
+		params["path"] = path
+	}
+	return params
+}
@@ -371,6 +371,29 @@ func (s *Stack) Status(ctx context.Context) (*Status, error) {
 	return st, nil
 }
 
+// FollowCoreDNSLogs opens a follow-mode stream of the CoreDNS container's
+// stdout (the per-query log) from since. The stream uses Docker's
+// multiplexed framing — demux with stdcopy. Errors when the container is
+// absent or stopped; the caller retries.
+func (s *Stack) FollowCoreDNSLogs(ctx context.Context, since time.Time) (io.ReadCloser, error) {
+	summary, err := s.findByName(ctx, corednsContainerName)
+	if err != nil {
+		return nil, err
+	}
+	if summary == nil || summary.State != container.StateRunning {
+		return nil, fmt.Errorf("following coredns logs: %w", ErrCoreDNSUnhealthy)
+	}
+	logs, err := s.docker.ContainerLogs(ctx, summary.ID, whail.ContainerLogsOptions{
+		ShowStdout: true,
+		Follow:     true,
+		Since:      fmt.Sprintf("%d.%09d", since.Unix(), since.Nanosecond()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("following coredns logs: %w", err)
+	}
+	return logs, nil
+}
+
 // EnvoyIP, CoreDNSIP, NetworkID, and CIDR return the current network
 // topology. They re-discover on every call; they are intended for
 // display paths where the cost is negligible. On failure they return ""
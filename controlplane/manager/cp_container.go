@@ -137,6 +137,10 @@ func BuildCPContainerConfig(cfg config.Config, opts CPContainerOpts) (*CPContain
 	if err != nil {
 		return nil, fmt.Errorf("resolve logs subdir: %w", err)
 	}
+	hostAuditDir, err := consts.AuditSubdir()
+	if err != nil {
+		return nil, fmt.Errorf("resolve audit subdir: %w", err)
+	}
 
 	caCertPath, err := consts.AuthCACertPath()
 	if err != nil {
@@ -267,6 +271,12 @@ func BuildCPContainerConfig(cfg config.Config, opts CPContainerOpts) (*CPContain
 			Source: hostLogsDir,
 			Target: consts.CPLogsPath,
 		},
+		// Audit journal — the CP appends its own file beside the CLI's.
+		{
+			Type:   mount.TypeBind,
+			Source: hostAuditDir,
+			Target: consts.CPAuditPath,
+		},
 		// cgroup filesystem for eBPF program attachment.
 		{
 			Type:     mount.TypeBind,
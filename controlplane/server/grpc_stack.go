@@ -45,6 +45,12 @@ type GRPCDeps struct {
 	// AdminService.ListAgents RPC and the AgentService.Register handler.
 	Registry agent.Registry
 
+	// Sessions is the live clawkerd Session table the Dialer fills;
+	// AdminService.SetLogLevel and NotifyAgent forward agent-targeted
+	// commands through it.
+	// nil disables that path.
+	Sessions *agent.Sessions
+
 	// PeerLookup resolves a live mTLS peer IP to the purpose=agent
 	// container owning that endpoint, grounding the IdentityInterceptor's
 	// trust check on a kernel-attested source instead of cert claims. A
@@ -165,7 +171,12 @@ func NewGRPCStack(deps GRPCDeps) (*GRPCStack, error) {
 		grpc.ChainStreamInterceptor(authInterceptor.StreamInterceptor()),
 	)
 
-	adminServer, err := NewAdminServer(deps.Handler, deps.Registry, log)
+	// Agents' ReportStatus samples: written by the agent listener's
+	// handler, read by AdminService.ListAgents. In memory — a sample is
+	// superseded within one report interval, so none survive a restart.
+	statuses := agent.NewStatusStore()
+
+	adminServer, err := NewAdminServer(deps.Handler, deps.Registry, deps.Sessions, statuses, log)
 	if err != nil {
 		return nil, fmt.Errorf("admin server: %w", err)
 	}
@@ -229,11 +240,12 @@ func NewGRPCStack(deps GRPCDeps) (*GRPCStack, error) {
 		return nil, fmt.Errorf("agent grpc listen: %w", err)
 	}
 
-	// Register the AgentService.Register handler. IdentityInterceptor
-	// has already grounded the peer in a daemon-resolved container
-	// identity and attached it to ctx; the handler captures the cert
-	// thumbprint, cross-checks the cert's container_id SAN + request
-	// fields against the resolved truth, and writes the registry row.
+	// Register the AgentService handler. IdentityInterceptor has
+	// already grounded the peer in a daemon-resolved container identity
+	// and attached it to ctx; Register captures the cert thumbprint,
+	// cross-checks the cert's container_id SAN + request fields against
+	// the resolved truth, and writes the registry row; ReportStatus
+	// stores the sample under the resolved container.
 	registerHandler, herr := agent.NewHandler(
 		deps.Registry,
 		log.With("component", "agent-register"),
@@ -241,6 +253,7 @@ func NewGRPCStack(deps GRPCDeps) (*GRPCStack, error) {
 	if herr != nil {
 		return nil, fmt.Errorf("agent register handler: %w", herr)
 	}
+	registerHandler.Status = statuses
 	agentv1.RegisterAgentServiceServer(agentServer, registerHandler)
 
 	stack.agentServer = agentServer
@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -31,8 +32,10 @@ type adminServer struct {
 	// rather than blocking the whole CP on a partial domain rewrite.
 	*fwhandler.Handler
 
-	agents agent.Registry
-	log    *logger.Logger
+	agents   agent.Registry
+	sessions *agent.Sessions
+	statuses *agent.StatusStore
+	log      *logger.Logger
 }
 
 // ErrNilRegistry is returned by NewAdminServer when no agent registry is
@@ -54,26 +57,33 @@ var _ adminv1.AdminServiceServer = (*adminServer)(nil)
 // pinned eBPF programs with no supervisor), so the caller logs a
 // structured event=<subsystem>_unavailable line and degrades.
 //
+//   - sessions is the live clawkerd Session table SetLogLevel and
+//     NotifyAgent forward agent-targeted commands through. nil answers
+//     those with codes.Unavailable; control-plane changes still work.
+//   - statuses holds the agents' ReportStatus samples ListAgents attaches.
+//     nil lists agents without status.
 //   - log defaults to logger.Nop() when nil. Production wiring passes
 //     the CP's structured logger.
-func NewAdminServer(fw *fwhandler.Handler, agents agent.Registry, log *logger.Logger) (adminv1.AdminServiceServer, error) {
+func NewAdminServer(fw *fwhandler.Handler, agents agent.Registry, sessions *agent.Sessions, statuses *agent.StatusStore, log *logger.Logger) (adminv1.AdminServiceServer, error) {
 	if agents == nil {
 		return nil, ErrNilRegistry
 	}
 	if log == nil {
 		log = logger.Nop()
 	}
-	return &adminServer{Handler: fw, agents: agents, log: log}, nil
+	return &adminServer{Handler: fw, agents: agents, sessions: sessions, statuses: statuses, log: log}, nil
 }
 
-// ListAgents returns a deterministic snapshot of every agent currently
-// registered with the control plane. The thumbprint is exported as
+// ListAgents returns a deterministic snapshot of the agents registered
+// under the request's project — or every agent with all_projects — so a
+// caller scoped to one project never sees another's. The thumbprint is exported as
 // lowercase hex so a debugger can match `dev.clawker.cert-thumbprint`
 // labels (or the bootstrap material on disk) against the entry the CP
 // holds. RegisteredAt and LastSeen are emitted as Unix seconds (UTC) to
 // avoid pulling google.protobuf.Timestamp into the AdminService surface
-// for one read-only RPC.
-func (s *adminServer) ListAgents(_ context.Context, _ *adminv1.ListAgentsRequest) (*adminv1.ListAgentsResult, error) {
+// for one read-only RPC. Each agent carries its latest ReportStatus
+// sample when CP holds one.
+func (s *adminServer) ListAgents(_ context.Context, req *adminv1.ListAgentsRequest) (*adminv1.ListAgentsResult, error) {
 	// Snapshot's interface contract guarantees (Project, AgentName)
 	// ordering — trust it on the wire rather than re-sorting (avoids
 	// duplicating the comparator across in-memory + sqlite impls and
@@ -88,9 +98,12 @@ func (s *adminServer) ListAgents(_ context.Context, _ *adminv1.ListAgentsRequest
 		return nil, status.Error(codes.Internal, "list agents: snapshot unavailable")
 	}
 
-	out := make([]*adminv1.Agent, len(snap))
-	for i, e := range snap {
-		out[i] = &adminv1.Agent{
+	out := make([]*adminv1.Agent, 0, len(snap))
+	for _, e := range snap {
+		if !req.GetAllProjects() && e.Project.String() != req.GetProject() {
+			continue
+		}
+		a := &adminv1.Agent{
 			AgentName:        e.AgentName.String(),
 			Project:          e.Project.String(),
 			ContainerId:      e.ContainerID,
@@ -98,10 +111,45 @@ func (s *adminServer) ListAgents(_ context.Context, _ *adminv1.ListAgentsRequest
 			RegisteredAtUnix: e.RegisteredAt.Unix(),
 			LastSeenUnix:     e.LastSeen.Unix(),
 		}
+		if s.statuses != nil {
+			if sample, ok := s.statuses.Get(e.ContainerID); ok {
+				a.Status = agentStatusToProto(sample)
+			}
+		}
+		out = append(out, a)
 	}
 	return &adminv1.ListAgentsResult{Agents: out}, nil
 }
 
+// agentStatusToProto converts a clawkerd ReportStatus sample to the
+// AdminService shape. The two services keep separate message types so
+// neither proto package imports the other across the trust boundary.
+func agentStatusToProto(sample agent.StatusSample) *adminv1.AgentStatus {
+	r := sample.Report
+	procs := make([]*adminv1.AgentProcess, len(r.GetProcesses()))
+	for i, p := range r.GetProcesses() {
+		procs[i] = &adminv1.AgentProcess{
+			Pid:        p.GetPid(),
+			Ppid:       p.GetPpid(),
+			Command:    p.GetCommand(),
+			RssBytes:   p.GetRssBytes(),
+			CpuPercent: p.GetCpuPercent(),
+		}
+	}
+	return &adminv1.AgentStatus{
+		Processes:           procs,
+		MemoryRssBytes:      r.GetMemoryRssBytes(),
+		CpuPercent:          r.GetCpuPercent(),
+		WorkspacePath:       r.GetWorkspacePath(),
+		WorkspaceUsedBytes:  r.GetWorkspaceUsedBytes(),
+		WorkspaceTotalBytes: r.GetWorkspaceTotalBytes(),
+		ClaudePid:           r.GetClaudePid(),
+		CollectedAtUnix:     r.GetCollectedAtUnix(),
+		ReceivedAtUnix:      sample.ReceivedAt.Unix(),
+		LastActivityUnix:    r.GetLastActivityUnix(),
+	}
+}
+
 // GetSystemTime returns the CP container's current wall-clock time as Unix
 // nanoseconds. UnixNano counts from the Unix epoch and is inherently a
 // TZ-independent absolute instant, so no UTC conversion is needed here — the
@@ -116,3 +164,119 @@ func (s *adminServer) ListAgents(_ context.Context, _ *adminv1.ListAgentsRequest
 func (s *adminServer) GetSystemTime(_ context.Context, _ *adminv1.GetSystemTimeRequest) (*adminv1.GetSystemTimeResult, error) {
 	return &adminv1.GetSystemTimeResult{UnixNanos: time.Now().UnixNano()}, nil
 }
+
+// SetLogLevel changes the minimum log level of the control plane (empty
+// container_id) or of one agent's clawkerd, forwarded over its live Session.
+// The level is validated here so both targets reject the same inputs with
+// codes.InvalidArgument. An agent-targeted change is scoped to the request's
+// project: an agent registered under another project is
+// codes.PermissionDenied, an unregistered one codes.FailedPrecondition. An
+// agent without a live Session — stopped, not yet dialed, or still running
+// its init/boot plan — is codes.FailedPrecondition too.
+func (s *adminServer) SetLogLevel(ctx context.Context, req *adminv1.SetLogLevelRequest) (*adminv1.SetLogLevelResult, error) {
+	level := req.GetLevel()
+	if !logger.ValidLevel(level) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown log level %q (want debug, info, warn, or error)", level)
+	}
+
+	containerID := req.GetContainerId()
+	if containerID == "" {
+		prev := logger.Level()
+		if err := logger.SetLevel(level); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.log.Info().
+			Str("event", "log_level_changed").
+			Str("previous_level", prev).
+			Str("level", level).
+			Str("trigger", "admin_rpc").
+			Msg("log level changed")
+		return &adminv1.SetLogLevelResult{PreviousLevel: prev}, nil
+	}
+
+	if err := s.checkAgentProject(containerID, req.GetProject()); err != nil {
+		return nil, err
+	}
+	if s.sessions == nil {
+		return nil, status.Error(codes.Unavailable, "set log level: agent dispatch is disabled on this control plane")
+	}
+	if err := s.sessions.SetLogLevel(ctx, containerID, level); err != nil {
+		if errors.Is(err, agent.ErrSessionNotConnected) {
+			return nil, status.Error(codes.FailedPrecondition, "set log level: agent is not connected to the control plane")
+		}
+		s.log.Warn().Err(err).
+			Str("event", "set_log_level_forward_failed").
+			Str("container_id", containerID).
+			Msg("controlplane: SetLogLevel forward to clawkerd failed")
+		return nil, status.Error(codes.Unavailable, fmt.Sprintf("set log level: %v", err))
+	}
+	s.log.Info().
+		Str("event", "agent_log_level_changed").
+		Str("container_id", containerID).
+		Str("level", level).
+		Msg("agent log level changed")
+	return &adminv1.SetLogLevelResult{}, nil
+}
+
+// NotifyAgent shows a message on one agent's console, forwarded over its
+// live Session. Scoped like SetLogLevel: an agent registered under another
+// project is codes.PermissionDenied, an unregistered or disconnected one
+// codes.FailedPrecondition.
+func (s *adminServer) NotifyAgent(ctx context.Context, req *adminv1.NotifyAgentRequest) (*adminv1.NotifyAgentResult, error) {
+	containerID := req.GetContainerId()
+	if containerID == "" {
+		return nil, status.Error(codes.InvalidArgument, "notify agent: container_id is required")
+	}
+	if req.GetMessage() == "" {
+		return nil, status.Error(codes.InvalidArgument, "notify agent: message is required")
+	}
+	if err := s.checkAgentProject(containerID, req.GetProject()); err != nil {
+		return nil, err
+	}
+	if s.sessions == nil {
+		return nil, status.Error(codes.Unavailable, "notify agent: agent dispatch is disabled on this control plane")
+	}
+	if err := s.sessions.Notify(ctx, containerID, req.GetMessage()); err != nil {
+		if errors.Is(err, agent.ErrSessionNotConnected) {
+			return nil, status.Error(codes.FailedPrecondition, "notify agent: agent is not connected to the control plane")
+		}
+		s.log.Warn().Err(err).
+			Str("event", "notify_agent_forward_failed").
+			Str("container_id", containerID).
+			Msg("controlplane: Notice forward to clawkerd failed")
+		return nil, status.Error(codes.Unavailable, fmt.Sprintf("notify agent: %v", err))
+	}
+	s.log.Info().
+		Str("event", "agent_notified").
+		Str("container_id", containerID).
+		Msg("agent notified")
+	return &adminv1.NotifyAgentResult{}, nil
+}
+
+// checkAgentProject enforces per-project isolation for agent-targeted RPCs:
+// the container must be registered, under project. The registry row is the
+// authority — its project came from the container's labels via the
+// peer-IP-grounded IdentityInterceptor, not from the caller.
+func (s *adminServer) checkAgentProject(containerID, project string) error {
+	entry, err := s.agents.LookupByContainerID(containerID)
+	if errors.Is(err, agent.ErrUnknownAgent) {
+		return status.Error(codes.FailedPrecondition, "agent is not registered with the control plane")
+	}
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("event", "agent_project_lookup_failed").
+			Str("container_id", containerID).
+			Msg("controlplane: registry lookup for project scope failed")
+		return status.Error(codes.Internal, "agent registry unavailable")
+	}
+	if got := entry.Project.String(); got != project {
+		s.log.Warn().
+			Str("event", "agent_project_mismatch").
+			Str("container_id", containerID).
+			Str("agent_project", got).
+			Str("requested_project", project).
+			Msg("controlplane: refused cross-project agent RPC")
+		return status.Errorf(codes.PermissionDenied, "agent belongs to project %q, not %q", got, project)
+	}
+	return nil
+}
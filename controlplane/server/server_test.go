@@ -14,8 +14,10 @@ import (
 	"google.golang.org/grpc/status"
 
 	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	agentv1 "github.com/schmitthub/clawker/api/agent/v1"
 	"github.com/schmitthub/clawker/controlplane/agent"
 	"github.com/schmitthub/clawker/internal/auth"
+	"github.com/schmitthub/clawker/internal/logger"
 )
 
 // TestAdminServer_NewAdminServer_NilAgentsErrors pins that the
@@ -24,7 +26,7 @@ import (
 // programming bug. It surfaces as ErrNilRegistry (not a panic) so the
 // daemon degrades rather than crashing and stranding pinned eBPF.
 func TestAdminServer_NewAdminServer_NilAgentsErrors(t *testing.T) {
-	srv, err := NewAdminServer(nil, nil, nil)
+	srv, err := NewAdminServer(nil, nil, nil, nil, nil)
 	require.ErrorIs(t, err, ErrNilRegistry)
 	assert.Nil(t, srv)
 }
@@ -67,7 +69,7 @@ func TestAdminServer_ListAgents_Snapshot(t *testing.T) {
 	}))
 
 	srv := &adminServer{agents: reg}
-	resp, err := srv.ListAgents(context.Background(), &adminv1.ListAgentsRequest{})
+	resp, err := srv.ListAgents(context.Background(), &adminv1.ListAgentsRequest{Project: "p"})
 	require.NoError(t, err)
 	require.Len(t, resp.Agents, 2)
 
@@ -115,7 +117,7 @@ func (f *fakeSnapshotRegistry) Snapshot() ([]agent.Entry, error) { return f.snap
 // intact but unreadable.
 func TestAdminServer_ListAgents_SnapshotError_ReturnsCodesInternal(t *testing.T) {
 	reg := &fakeSnapshotRegistry{snapErr: errors.New("sqlite query failed")}
-	srvIface, err := NewAdminServer(nil, reg, nil)
+	srvIface, err := NewAdminServer(nil, reg, nil, nil, nil)
 	require.NoError(t, err)
 	srv := srvIface.(*adminServer)
 
@@ -126,3 +128,133 @@ func TestAdminServer_ListAgents_SnapshotError_ReturnsCodesInternal(t *testing.T)
 	require.True(t, ok, "must be a gRPC status error")
 	assert.Equal(t, codes.Internal, st.Code())
 }
+
+func TestAdminServer_SetLogLevel(t *testing.T) {
+	t.Cleanup(func() { _ = logger.SetLevel("debug") })
+	srvIface, err := NewAdminServer(nil, agent.NewRegistry(nil), agent.NewSessions(), nil, nil)
+	require.NoError(t, err)
+	srv := srvIface.(*adminServer)
+	ctx := context.Background()
+
+	resp, err := srv.SetLogLevel(ctx, &adminv1.SetLogLevelRequest{Level: "warn"})
+	require.NoError(t, err)
+	assert.Equal(t, "debug", resp.GetPreviousLevel())
+	assert.Equal(t, "warn", logger.Level())
+
+	_, err = srv.SetLogLevel(ctx, &adminv1.SetLogLevelRequest{Level: "loud"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "warn", logger.Level())
+
+	_, err = srv.SetLogLevel(ctx, &adminv1.SetLogLevelRequest{Level: "debug", ContainerId: "ctr-a"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "warn", logger.Level(), "an agent-targeted change must not touch the CP level")
+}
+
+func TestAdminServer_SetLogLevel_NoSessions(t *testing.T) {
+	reg := agent.NewRegistry(nil)
+	require.NoError(t, reg.Add(testEntry("p", "a", "ctr-a")))
+	srvIface, err := NewAdminServer(nil, reg, nil, nil, nil)
+	require.NoError(t, err)
+	srv := srvIface.(*adminServer)
+
+	_, err = srv.SetLogLevel(context.Background(), &adminv1.SetLogLevelRequest{Level: "debug", ContainerId: "ctr-a", Project: "p"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestAdminServer_NotifyAgent(t *testing.T) {
+	reg := agent.NewRegistry(nil)
+	require.NoError(t, reg.Add(testEntry("alpha", "dev", "ctr-dev")))
+	srvIface, err := NewAdminServer(nil, reg, agent.NewSessions(), nil, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = srvIface.NotifyAgent(ctx, &adminv1.NotifyAgentRequest{ContainerId: "ctr-dev", Project: "alpha"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "empty message")
+	_, err = srvIface.NotifyAgent(ctx, &adminv1.NotifyAgentRequest{ContainerId: "ctr-dev", Project: "beta", Message: "hi"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = srvIface.NotifyAgent(ctx, &adminv1.NotifyAgentRequest{ContainerId: "ctr-dev", Project: "alpha", Message: "hi"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "no live Session")
+}
+
+// testEntry returns a valid registry entry for container id under project.
+func testEntry(project, name, containerID string) agent.Entry {
+	now := time.Unix(1000, 0)
+	return agent.Entry{
+		AgentName:    auth.MustAgentName(name),
+		Project:      auth.MustProjectSlug(project),
+		ContainerID:  containerID,
+		Thumbprint:   sha256.Sum256([]byte(containerID)),
+		RegisteredAt: now,
+		LastSeen:     now,
+	}
+}
+
+// TestAdminServer_ProjectScoping pins per-project isolation: ListAgents
+// returns only the selected project's agents unless all_projects is set,
+// and SetLogLevel refuses to drive an agent registered under another
+// project.
+func TestAdminServer_ProjectScoping(t *testing.T) {
+	reg := agent.NewRegistry(nil)
+	require.NoError(t, reg.Add(testEntry("alpha", "dev", "ctr-alpha")))
+	require.NoError(t, reg.Add(testEntry("beta", "dev", "ctr-beta")))
+	require.NoError(t, reg.Add(testEntry("", "solo", "ctr-global")))
+	srvIface, err := NewAdminServer(nil, reg, nil, nil, nil)
+	require.NoError(t, err)
+	srv := srvIface.(*adminServer)
+	ctx := context.Background()
+
+	containers := func(req *adminv1.ListAgentsRequest) []string {
+		t.Helper()
+		resp, err := srv.ListAgents(ctx, req)
+		require.NoError(t, err)
+		var ids []string
+		for _, a := range resp.GetAgents() {
+			ids = append(ids, a.GetContainerId())
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"ctr-alpha"}, containers(&adminv1.ListAgentsRequest{Project: "alpha"}))
+	assert.Equal(t, []string{"ctr-global"}, containers(&adminv1.ListAgentsRequest{}))
+	assert.Len(t, containers(&adminv1.ListAgentsRequest{Project: "alpha", AllProjects: true}), 3)
+
+	_, err = srv.SetLogLevel(ctx, &adminv1.SetLogLevelRequest{Level: "debug", ContainerId: "ctr-beta", Project: "alpha"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = srv.SetLogLevel(ctx, &adminv1.SetLogLevelRequest{Level: "debug", ContainerId: "ctr-global"})
+	assert.Equal(t, codes.Unavailable, status.Code(err), "matching project passes the scope check")
+	_, err = srv.SetLogLevel(ctx, &adminv1.SetLogLevelRequest{Level: "debug", ContainerId: "ctr-unknown", Project: "alpha"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+// TestAdminServer_ListAgentsAttachesStatus pins that ListAgents carries an
+// agent's latest ReportStatus sample, and leaves status unset for agents
+// that haven't reported.
+func TestAdminServer_ListAgentsAttachesStatus(t *testing.T) {
+	reg := agent.NewRegistry(nil)
+	require.NoError(t, reg.Add(testEntry("alpha", "dev", "ctr-dev")))
+	require.NoError(t, reg.Add(testEntry("alpha", "quiet", "ctr-quiet")))
+	statuses := agent.NewStatusStore()
+	statuses.Put("ctr-dev", &agentv1.StatusReport{
+		Processes:        []*agentv1.ProcessUsage{{Pid: 7, Ppid: 1, Command: "claude", RssBytes: 2048, CpuPercent: 12.5}},
+		MemoryRssBytes:   2048,
+		CpuPercent:       12.5,
+		WorkspacePath:    "/workspace",
+		ClaudePid:        7,
+		LastActivityUnix: 1900,
+	}, time.Unix(2000, 0))
+	srvIface, err := NewAdminServer(nil, reg, nil, statuses, nil)
+	require.NoError(t, err)
+
+	resp, err := srvIface.ListAgents(context.Background(), &adminv1.ListAgentsRequest{Project: "alpha"})
+	require.NoError(t, err)
+	require.Len(t, resp.GetAgents(), 2)
+
+	st := resp.GetAgents()[0].GetStatus()
+	require.NotNil(t, st)
+	assert.Equal(t, int32(7), st.GetClaudePid())
+	assert.Equal(t, "/workspace", st.GetWorkspacePath())
+	assert.Equal(t, int64(2000), st.GetReceivedAtUnix())
+	assert.Equal(t, int64(1900), st.GetLastActivityUnix())
+	require.Len(t, st.GetProcesses(), 1)
+	assert.Equal(t, "claude", st.GetProcesses()[0].GetCommand())
+	assert.Nil(t, resp.GetAgents()[1].GetStatus())
+}
@@ -0,0 +1,233 @@
+// Package attachhub fans one interactive container session's TTY output out
+// to read-only observers over a host-side Unix socket.
+//
+// The process that owns the interactive attach runs a Hub and tees the
+// container's output into it. A second `clawker container attach --observe`
+// dials the socket and receives the recent-output replay followed by the
+// live stream. Observers never write to the container: the hub does not
+// read anything from their connections except EOF.
+package attachhub
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrNoSession is returned by Dial when no interactive session is hosting a
+// hub at the given path.
+var ErrNoSession = errors.New("no interactive session to observe")
+
+// replaySize is how much recent output a newly connected observer receives
+// before the live stream, so it sees the current screen rather than a blank
+// terminal. The replay is raw bytes and may begin mid-escape-sequence; a TUI's
+// next redraw repairs any damage.
+const replaySize = 64 << 10
+
+// observerQueue is the number of pending output chunks buffered per observer.
+// An observer that falls this far behind is disconnected rather than allowed
+// to stall the interactive session.
+const observerQueue = 256
+
+// Hub accepts observer connections on a Unix socket and broadcasts everything
+// written to it. Write never blocks on observers and never fails, so it is
+// safe to tee the interactive session's output through it.
+type Hub struct {
+	path     string
+	listener net.Listener
+
+	mu        sync.Mutex
+	replay    []byte
+	observers map[*observer]struct{}
+	closed    bool
+
+	wg sync.WaitGroup
+}
+
+type observer struct {
+	conn net.Conn
+	out  chan []byte
+	once sync.Once
+}
+
+func (o *observer) close() {
+	o.once.Do(func() {
+		close(o.out)
+		o.conn.Close()
+	})
+}
+
+// Listen creates the hub socket at path and starts accepting observers.
+// A stale socket left by a crashed session is replaced; a live one means
+// another session already hosts the container and Listen fails.
+func Listen(path string) (*Hub, error) {
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("attach hub %s is already in use", path)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("removing stale attach hub socket: %w", err)
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listening on attach hub socket: %w", err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("restricting attach hub socket: %w", err)
+	}
+
+	h := &Hub{
+		path:      path,
+		listener:  ln,
+		observers: make(map[*observer]struct{}),
+	}
+	h.wg.Add(1)
+	go h.accept()
+	return h, nil
+}
+
+// Path returns the hub's socket path.
+func (h *Hub) Path() string { return h.path }
+
+// Observers returns the number of connected observers.
+func (h *Hub) Observers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.observers)
+}
+
+// Write records p in the replay buffer and queues it for every observer.
+// It always reports len(p), nil.
+func (h *Hub) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	chunk := make([]byte, len(p))
+	copy(chunk, p)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return len(p), nil
+	}
+
+	h.replay = append(h.replay, chunk...)
+	if over := len(h.replay) - replaySize; over > 0 {
+		h.replay = append(h.replay[:0], h.replay[over:]...)
+	}
+
+	for o := range h.observers {
+		select {
+		case o.out <- chunk:
+		default:
+			delete(h.observers, o)
+			o.close()
+		}
+	}
+	return len(p), nil
+}
+
+// Close stops accepting observers, disconnects the connected ones and
+// removes the socket.
+func (h *Hub) Close() error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil
+	}
+	h.closed = true
+	for o := range h.observers {
+		delete(h.observers, o)
+		o.close()
+	}
+	h.mu.Unlock()
+
+	err := h.listener.Close()
+	h.wg.Wait()
+	if rmErr := os.Remove(h.path); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+func (h *Hub) accept() {
+	defer h.wg.Done()
+	for {
+		conn, err := h.listener.Accept()
+		if err != nil {
+			return
+		}
+		h.add(conn)
+	}
+}
+
+// add registers conn and queues the replay as its first chunk, under the same
+// lock Write takes so no output falls between the replay and the live stream.
+func (h *Hub) add(conn net.Conn) {
+	o := &observer{conn: conn, out: make(chan []byte, observerQueue)}
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		conn.Close()
+		return
+	}
+	if len(h.replay) > 0 {
+		o.out <- append([]byte(nil), h.replay...)
+	}
+	h.observers[o] = struct{}{}
+	h.mu.Unlock()
+
+	h.wg.Add(2)
+	go h.send(o)
+	go h.watch(o)
+}
+
+// send drains o's queue onto its connection.
+func (h *Hub) send(o *observer) {
+	defer h.wg.Done()
+	for chunk := range o.out {
+		if _, err := o.conn.Write(chunk); err != nil {
+			h.drop(o)
+			// Keep draining so Write never blocks on a dead queue.
+			for range o.out {
+			}
+			return
+		}
+	}
+}
+
+// watch discards anything an observer sends and drops it on disconnect.
+// Observer input is never forwarded; this is what makes observers read-only.
+func (h *Hub) watch(o *observer) {
+	defer h.wg.Done()
+	_, _ = io.Copy(io.Discard, o.conn)
+	h.drop(o)
+}
+
+func (h *Hub) drop(o *observer) {
+	h.mu.Lock()
+	delete(h.observers, o)
+	h.mu.Unlock()
+	o.close()
+}
+
+// Dial connects to the hub at path as an observer. It returns ErrNoSession
+// when nothing is listening there.
+func Dial(path string) (net.Conn, error) {
+	conn, err := net.DialTimeout("unix", path, 5*time.Second)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, syscall.ECONNREFUSED) {
+			return nil, ErrNoSession
+		}
+		return nil, fmt.Errorf("connecting to attach hub: %w", err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,214 @@
+package attachhub
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func socketPath(t *testing.T) string {
+	t.Helper()
+	return filepath.Join(t.TempDir(), "hub.sock")
+}
+
+// readN reads exactly n bytes from conn or fails the test.
+func readN(t *testing.T, conn net.Conn, n int) string {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		t.Fatalf("reading from hub: %v", err)
+	}
+	return string(buf)
+}
+
+func waitObservers(t *testing.T, h *Hub, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for h.Observers() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d observers, got %d", want, h.Observers())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestHub_ReplayThenLive(t *testing.T) {
+	h, err := Listen(socketPath(t))
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer h.Close()
+
+	h.Write([]byte("before"))
+
+	conn, err := Dial(h.Path())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	waitObservers(t, h, 1)
+
+	h.Write([]byte("after"))
+
+	if got := readN(t, conn, len("beforeafter")); got != "beforeafter" {
+		t.Errorf("observer read %q, want %q", got, "beforeafter")
+	}
+}
+
+func TestHub_FansOutToEveryObserver(t *testing.T) {
+	h, err := Listen(socketPath(t))
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer h.Close()
+
+	a, err := Dial(h.Path())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer a.Close()
+	b, err := Dial(h.Path())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer b.Close()
+	waitObservers(t, h, 2)
+
+	h.Write([]byte("live"))
+
+	for _, conn := range []net.Conn{a, b} {
+		if got := readN(t, conn, 4); got != "live" {
+			t.Errorf("observer read %q, want %q", got, "live")
+		}
+	}
+}
+
+func TestHub_ObserverInputIsDiscarded(t *testing.T) {
+	h, err := Listen(socketPath(t))
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer h.Close()
+
+	conn, err := Dial(h.Path())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	waitObservers(t, h, 1)
+
+	if _, err := conn.Write([]byte("rm -rf /\n")); err != nil {
+		t.Fatalf("observer write: %v", err)
+	}
+	h.Write([]byte("ok"))
+
+	// Only the session's own output comes back; the observer's bytes went
+	// nowhere.
+	if got := readN(t, conn, 2); got != "ok" {
+		t.Errorf("observer read %q, want %q", got, "ok")
+	}
+}
+
+func TestHub_DisconnectRemovesObserver(t *testing.T) {
+	h, err := Listen(socketPath(t))
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer h.Close()
+
+	conn, err := Dial(h.Path())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	waitObservers(t, h, 1)
+
+	conn.Close()
+	waitObservers(t, h, 0)
+	h.Write([]byte("still fine"))
+}
+
+func TestHub_ReplayIsBounded(t *testing.T) {
+	h, err := Listen(socketPath(t))
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer h.Close()
+
+	h.Write(bytes.Repeat([]byte("a"), replaySize))
+	h.Write([]byte("tail"))
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.replay) != replaySize {
+		t.Fatalf("replay holds %d bytes, want %d", len(h.replay), replaySize)
+	}
+	if !bytes.HasSuffix(h.replay, []byte("tail")) {
+		t.Error("replay should keep the most recent output")
+	}
+}
+
+func TestHub_CloseEndsObserversAndRemovesSocket(t *testing.T) {
+	h, err := Listen(socketPath(t))
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+
+	conn, err := Dial(h.Path())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	waitObservers(t, h, 1)
+
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, err := conn.Read(make([]byte, 1)); !errors.Is(err, io.EOF) {
+		t.Errorf("observer read after Close = %v, want EOF", err)
+	}
+	if _, err := os.Stat(h.Path()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("socket should be removed, stat err = %v", err)
+	}
+	if err := h.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+}
+
+func TestListen_SocketInUse(t *testing.T) {
+	path := socketPath(t)
+	h, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer h.Close()
+
+	if _, err := Listen(path); err == nil {
+		t.Fatal("second Listen on a live socket should fail")
+	}
+}
+
+func TestListen_ReplacesStaleSocket(t *testing.T) {
+	path := socketPath(t)
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("writing stale socket: %v", err)
+	}
+
+	h, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen over stale socket: %v", err)
+	}
+	defer h.Close()
+}
+
+func TestDial_NoSession(t *testing.T) {
+	if _, err := Dial(socketPath(t)); !errors.Is(err, ErrNoSession) {
+		t.Fatalf("Dial error = %v, want ErrNoSession", err)
+	}
+}
@@ -0,0 +1,187 @@
+// Package audit appends security-relevant user decisions and mutating
+// operations to the host-side audit journal — one JSON object per line in
+// the file at consts.AuditLogPath, plus the control plane's own file beside
+// it. The journal is append-only: entries are never rewritten or pruned by
+// clawker, so it can be handed to an operator as-is.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// Event identifies what kind of decision an Entry records.
+type Event string
+
+// EventSecurityOverride records a CLI flag weakening a security setting the
+// project config declares.
+const EventSecurityOverride Event = "security_override"
+
+// EventOperation records a mutating operation: a Docker call on a managed
+// resource, a config file write, or a firewall change.
+const EventOperation Event = "operation"
+
+// Actor is the process that performed an operation.
+type Actor string
+
+const (
+	// ActorCLI is a clawker command run on the host.
+	ActorCLI Actor = "cli"
+	// ActorControlPlane is the control plane daemon, acting on an RPC or
+	// on its own (e.g. starting the firewall stack).
+	ActorControlPlane Actor = "controlplane"
+)
+
+// Decision is how a security prompt was resolved.
+type Decision string
+
+const (
+	// DecisionConfirmed means the user accepted at the interactive prompt.
+	DecisionConfirmed Decision = "confirmed"
+	// DecisionDeclined means the user rejected at the interactive prompt.
+	DecisionDeclined Decision = "declined"
+	// DecisionOverrideFlag means the user pre-accepted with --override-security.
+	DecisionOverrideFlag Decision = "override_flag"
+	// DecisionRefused means clawker refused without asking: no TTY to prompt
+	// on and no --override-security.
+	DecisionRefused Decision = "refused"
+)
+
+// journalFileMode keeps the journal owner-only; it records project names,
+// agent names, and the flags a user ran with.
+const journalFileMode = 0o600
+
+// Change is one setting whose effective value differs from the config.
+type Change struct {
+	Setting   string `json:"setting"`
+	Config    string `json:"config"`
+	Effective string `json:"effective"`
+	Flag      string `json:"flag"`
+}
+
+// Entry is one journal line. Security decisions set Decision and Changes;
+// operations set Actor, Action, Resource, Target, and Params.
+type Entry struct {
+	Time     time.Time         `json:"time"`
+	Event    Event             `json:"event"`
+	Decision Decision          `json:"decision,omitempty"`
+	Actor    Actor             `json:"actor,omitempty"`
+	Action   string            `json:"action,omitempty"`
+	Resource string            `json:"resource,omitempty"`
+	Target   string            `json:"target,omitempty"`
+	Params   map[string]string `json:"params,omitempty"`
+	Command  string            `json:"command,omitempty"`
+	Project  string            `json:"project,omitempty"`
+	Agent    string            `json:"agent,omitempty"`
+	Changes  []Change          `json:"changes,omitempty"`
+}
+
+// Append writes e to the journal at consts.AuditLogPath, creating the audit
+// directory and file on first use.
+func Append(e Entry) error {
+	path, err := consts.AuditLogPath()
+	if err != nil {
+		return fmt.Errorf("resolving audit journal path: %w", err)
+	}
+	return AppendTo(path, e)
+}
+
+// AppendTo writes e to the journal at path. A zero Time is stamped with the
+// current time. The line is emitted in a single O_APPEND write so concurrent
+// clawker processes never interleave partial entries.
+func AppendTo(path string, e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encoding audit entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, journalFileMode)
+	if err != nil {
+		return fmt.Errorf("opening audit journal: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return fmt.Errorf("writing audit journal: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing audit journal: %w", err)
+	}
+	return nil
+}
+
+// maxLineSize bounds one journal line when reading. Entries are small; the
+// bound only guards against a corrupted file.
+const maxLineSize = 1 << 20
+
+// Paths returns the journal files clawker writes: the CLI's at
+// consts.AuditLogPath and the control plane's beside it. The control plane
+// appends to its own file through a bind mount, so the two processes never
+// append to the same one.
+func Paths() ([]string, error) {
+	cli, err := consts.AuditLogPath()
+	if err != nil {
+		return nil, fmt.Errorf("resolving audit journal path: %w", err)
+	}
+	cp, err := consts.ControlPlaneAuditLogPath()
+	if err != nil {
+		return nil, fmt.Errorf("resolving control plane audit journal path: %w", err)
+	}
+	return []string{cli, cp}, nil
+}
+
+// Read returns the entries of the journals at paths recorded at or after
+// since (zero = all), merged oldest first. A missing file has no entries. A
+// line that does not decode is skipped, so one damaged line never hides the
+// rest of the journal.
+func Read(paths []string, since time.Time) ([]Entry, error) {
+	var entries []Entry
+	for _, path := range paths {
+		fileEntries, err := readFile(path, since)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+	slices.SortStableFunc(entries, func(a, b Entry) int { return a.Time.Compare(b.Time) })
+	return entries, nil
+}
+
+func readFile(path string, since time.Time) ([]Entry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening audit journal: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for sc.Scan() {
+		var e Entry
+		if json.Unmarshal(sc.Bytes(), &e) != nil {
+			continue
+		}
+		if e.Time.Before(since) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading audit journal %s: %w", path, err)
+	}
+	return entries, nil
+}
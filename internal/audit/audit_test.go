@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readEntries(t *testing.T, path string) []Entry {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var entries []Entry
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e Entry
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	require.NoError(t, sc.Err())
+	return entries
+}
+
+func TestAppendTo_AppendsOneLinePerEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	require.NoError(t, AppendTo(path, Entry{
+		Event:    EventSecurityOverride,
+		Decision: DecisionConfirmed,
+		Project:  "myapp",
+		Agent:    "dev",
+		Changes:  []Change{{Setting: "privileged", Config: "false", Effective: "true", Flag: "--privileged"}},
+	}))
+	require.NoError(t, AppendTo(path, Entry{Event: EventSecurityOverride, Decision: DecisionDeclined}))
+
+	entries := readEntries(t, path)
+	require.Len(t, entries, 2)
+	assert.Equal(t, DecisionConfirmed, entries[0].Decision)
+	assert.Equal(t, "myapp", entries[0].Project)
+	require.Len(t, entries[0].Changes, 1)
+	assert.Equal(t, "--privileged", entries[0].Changes[0].Flag)
+	assert.Equal(t, DecisionDeclined, entries[1].Decision)
+	assert.False(t, entries[1].Time.IsZero(), "zero Time should be stamped")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(journalFileMode), info.Mode().Perm())
+}
+
+func TestAppendTo_KeepsExplicitTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	require.NoError(t, AppendTo(path, Entry{Time: at, Event: EventSecurityOverride, Decision: DecisionRefused}))
+
+	entries := readEntries(t, path)
+	require.Len(t, entries, 1)
+	assert.True(t, at.Equal(entries[0].Time))
+}
+
+func TestRead_MergesFilesOldestFirst(t *testing.T) {
+	dir := t.TempDir()
+	cli := filepath.Join(dir, "audit.log")
+	cp := filepath.Join(dir, "controlplane-audit.log")
+	base := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, AppendTo(cli, Entry{Time: base, Event: EventOperation, Actor: ActorCLI, Action: "create", Resource: "container"}))
+	require.NoError(t, AppendTo(cli, Entry{Time: base.Add(2 * time.Hour), Event: EventOperation, Actor: ActorCLI, Action: "stop", Resource: "container"}))
+	require.NoError(t, AppendTo(cp, Entry{Time: base.Add(time.Hour), Event: EventOperation, Actor: ActorControlPlane, Action: "enable", Resource: "firewall"}))
+
+	entries, err := Read([]string{cli, cp}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, []string{"create", "enable", "stop"}, []string{entries[0].Action, entries[1].Action, entries[2].Action})
+
+	entries, err = Read([]string{cli, cp}, base.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "enable", entries[0].Action)
+}
+
+func TestRead_SkipsDamagedLinesAndMissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+	require.NoError(t, AppendTo(path, Entry{Event: EventOperation, Action: "remove"}))
+
+	entries, err := Read([]string{path, filepath.Join(dir, "missing.log")}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "remove", entries[0].Action)
+}
@@ -0,0 +1,48 @@
+#!/bin/sh
+# Installs the project's services: block for the process supervisor the
+# image ships, and records which one clawkerd should launch at runtime.
+# Runs once, as root, at harness image build time.
+#
+# Usage: clawker-services-install.sh <user> <services-dir> <scan-dir>
+#
+# Detection order is s6, runit, systemd. With none of them installed the
+# marker says "none" and clawkerd supervises each service itself, so a
+# service is never run by two supervisors at once.
+set -eu
+
+user="$1"
+dir="$2"
+scan="$3"
+
+if command -v s6-svscan >/dev/null 2>&1; then
+	supervisor=s6
+elif command -v runsvdir >/dev/null 2>&1; then
+	supervisor=runit
+elif [ -x /usr/lib/systemd/systemd ] || [ -x /lib/systemd/systemd ]; then
+	supervisor=systemd
+else
+	supervisor=none
+fi
+
+chmod 0755 "$dir"/bin/*
+find "$dir/s6" "$dir/runit" -type f -exec chmod 0755 {} +
+
+case "$supervisor" in
+s6 | runit)
+	# The supervisors run as the container user and keep their
+	# supervise/ state inside each service directory.
+	mkdir -p "$scan"
+	cp -R "$dir/$supervisor/." "$scan/"
+	chown -R "$user:$user" "$scan"
+	;;
+systemd)
+	mkdir -p /etc/systemd/user
+	for unit in "$dir"/systemd/*.service; do
+		cp "$unit" /etc/systemd/user/
+		systemctl --global enable "$(basename "$unit")"
+	done
+	;;
+esac
+
+printf '%s\n' "$supervisor" >"$dir/supervisor"
+echo "clawker services: installed for supervisor '$supervisor'"
@@ -48,6 +48,7 @@ func (g *ProjectGenerator) BaseContentHash(baseDockerfile []byte, buildArgs map[
 	}
 
 	hashBaseBuildArgs(h, baseDockerfile, buildArgs)
+	hashPlatforms(h, g.Platforms)
 
 	return hex.EncodeToString(h.Sum(nil)), nil
 }
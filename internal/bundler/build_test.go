@@ -63,8 +63,9 @@ security:
 		"assets/claude-config.json",
 		"clawker-agent-prompt.md",
 		"host-open.sh",
+		"clawker-clip.sh",
 		"callback-forwarder.go",
-		"git-credential-clawker.sh",
+		"clawker-credential-helper.go",
 		"clawker-socket-server.go",
 	}
 	for _, name := range expectedFiles {
@@ -73,7 +74,7 @@ security:
 	}
 
 	// Verify scripts are executable
-	for _, name := range []string{"clawkerd", "host-open.sh"} {
+	for _, name := range []string{"clawkerd", "host-open.sh", "clawker-clip.sh"} {
 		info, err := os.Stat(filepath.Join(dir, name))
 		require.NoError(t, err)
 		assert.NotZero(t, info.Mode()&0o111, "%s should be executable", name)
@@ -91,6 +91,11 @@ func LoadBundle(name string, fsys fs.FS) (*Bundle, error) {
 	if mpErr := validateManagedPrompt(name, m.Volumes, m.ManagedPrompt); mpErr != nil {
 		return nil, mpErr
 	}
+	for _, s := range m.Sessions {
+		if sessErr := validateStagingDest(name, "session dir", s, s, m.Volumes); sessErr != nil {
+			return nil, sessErr
+		}
+	}
 
 	rawTmpl, readErr := fs.ReadFile(fsys, HarnessTemplateFile)
 	if readErr != nil {
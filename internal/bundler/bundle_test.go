@@ -322,6 +322,18 @@ seeds:
 	}
 }
 
+// TestLoadBundle_SessionDirsUnderVolumes: session dirs are restored into the
+// harness volumes, so each must fall under a declared one.
+func TestLoadBundle_SessionDirsUnderVolumes(t *testing.T) {
+	b, err := bundler.LoadBundle("t", seedBundleFS("volumes: [{ name: config, path: .test }]\nsessions: [.test/sessions]\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{".test/sessions"}, b.Manifest.Sessions)
+
+	_, err = bundler.LoadBundle("t", seedBundleFS("volumes: [{ name: config, path: .test }]\nsessions: [.elsewhere/sessions]\n"))
+	require.ErrorContains(t, err, `session dir ".elsewhere/sessions"`)
+	require.ErrorContains(t, err, "not under any declared volume")
+}
+
 // TestLoadBundle_StagingValidationErrors pins the load front door: volumes are
 // explicit and well-formed, every directive names src and dest
 // deliberately, dests fall under a declared volume, and filter verbs match
@@ -60,15 +60,16 @@ var AgentPromptContent string
 // instructions in the harness-image template.
 const (
 	ctxFileHostOpen = "host-open.sh"
+	ctxFileClip     = "clawker-clip.sh"
 	// BaseDockerfileName is the reserved name the rendered base Dockerfile
 	// is injected under in the legacy tar build context, so a user's own
 	// Dockerfile in the project build context is never clobbered.
-	BaseDockerfileName   = "Dockerfile.clawker-base"
-	ctxFileCallbackFwd   = "callback-forwarder.go"
-	ctxFileGitCredential = "git-credential-clawker.sh" //nolint:gosec // filename, not a credential
-	ctxFileSocketServer  = "clawker-socket-server.go"
-	ctxFileClawkerd      = "clawkerd"
-	ctxFileAgentPrompt   = "clawker-agent-prompt.md"
+	BaseDockerfileName  = "Dockerfile.clawker-base"
+	ctxFileCallbackFwd  = "callback-forwarder.go"
+	ctxFileCredHelper   = "clawker-credential-helper.go"
+	ctxFileSocketServer = "clawker-socket-server.go"
+	ctxFileClawkerd     = "clawkerd"
+	ctxFileAgentPrompt  = "clawker-agent-prompt.md"
 )
 
 // tarFileMode is the mode recorded for files streamed into the build-context
@@ -79,8 +80,9 @@ const tarFileMode = 0o644
 // them with its own assets.
 var (
 	HostOpenScript          = internals.HostOpenScript
+	ClipboardScript         = internals.ClipboardScript
 	CallbackForwarderSource = internals.CallbackForwarderSource
-	GitCredentialScript     = internals.GitCredentialScript
+	CredentialHelperSource  = internals.CredentialHelperSource
 	SocketForwarderSource   = internals.SocketForwarderSource
 )
 
@@ -156,6 +158,10 @@ type DockerfileContext struct {
 	// deduped against Packages (apt install is idempotent). Empty for the
 	// base render — the field only carries overlay content in GenerateHarness.
 	HarnessPackages []string
+	// Services drives the harness-image template's services block: the
+	// rendered services: configs are copied in and installed for the
+	// image's process supervisor. Nil when the project declares none.
+	Services        *ServicesContext
 	BuildKitEnabled bool
 	Instructions    *DockerfileInstructions
 	Inject          *DockerfileInject
@@ -199,22 +205,28 @@ type ctxFile struct {
 }
 
 // clawkerContextFiles returns the clawker-owned scripts and binaries staged
-// into every harness build context — host-open, the two Go sources compiled
-// by the builder stages, the git credential helper, and the pre-compiled
+// into every harness build context — host-open, clawker-clip, the three Go sources compiled
+// by the builder stages (including the git credential helper), and the pre-compiled
 // clawkerd binary — plus the managed agent prompt when (and only when) the
-// harness manifest declares a managed_prompt dest for it.
-func clawkerContextFiles(b *Bundle) []ctxFile {
+// harness manifest declares a managed_prompt dest for it, and the rendered
+// services: block when the project declares services.
+func clawkerContextFiles(b *Bundle, services map[string]config.ServiceConfig) ([]ctxFile, error) {
 	files := []ctxFile{
 		{ctxFileHostOpen, []byte(HostOpenScript), 0o755},
+		{ctxFileClip, []byte(ClipboardScript), 0o755},
 		{ctxFileCallbackFwd, []byte(CallbackForwarderSource), 0o644},
-		{ctxFileGitCredential, []byte(GitCredentialScript), 0o755},
+		{ctxFileCredHelper, []byte(CredentialHelperSource), 0o644},
 		{ctxFileSocketServer, []byte(SocketForwarderSource), 0o644},
 		{ctxFileClawkerd, clawkerdembed.Binary, 0o755},
 	}
 	if b.Manifest.ManagedPrompt != nil {
 		files = append(files, ctxFile{ctxFileAgentPrompt, []byte(AgentPromptContent), 0o644})
 	}
-	return files
+	serviceFiles, err := serviceContextFiles(services)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, serviceFiles...), nil
 }
 
 // managedPromptContext resolves a manifest managed_prompt spec into render
@@ -290,6 +302,10 @@ type ProjectGenerator struct {
 	// docker Builder — bundler never derives project names itself.
 	// Required by GenerateHarness.
 	BaseImageRef string
+	// Platforms are the build's target platforms, folded into both content
+	// hashes so an image built for one platform set is never reused for
+	// another. Empty (the daemon's platform) leaves the hashes unchanged.
+	Platforms []string
 
 	bundle *Bundle // lazily loaded via harnessBundle()
 
@@ -530,7 +546,11 @@ func (g *ProjectGenerator) GenerateHarnessBuildContext(dockerfile []byte) (io.Re
 		return nil, err
 	}
 
-	for _, f := range clawkerContextFiles(bundle) {
+	ctxFiles, err := clawkerContextFiles(bundle, g.cfg.Project().Services)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range ctxFiles {
 		if err := addFileToTar(tw, f.name, f.content); err != nil {
 			return nil, err
 		}
@@ -579,7 +599,14 @@ func (g *ProjectGenerator) WriteHarnessBuildContextToDir(dir string, dockerfile
 	}
 
 	// Write all supporting scripts (mirrors GenerateHarnessBuildContext).
-	for _, s := range clawkerContextFiles(bundle) {
+	ctxFiles, err := clawkerContextFiles(bundle, g.cfg.Project().Services)
+	if err != nil {
+		return err
+	}
+	for _, s := range ctxFiles {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, s.name)), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", s.name, err)
+		}
 		if err := os.WriteFile(filepath.Join(dir, s.name), s.content, s.mode); err != nil {
 			return fmt.Errorf("failed to write %s: %w", s.name, err)
 		}
@@ -658,6 +685,11 @@ func (g *ProjectGenerator) buildContext() (*DockerfileContext, error) {
 		return nil, err
 	}
 
+	services, err := servicesContext(p.Services)
+	if err != nil {
+		return nil, err
+	}
+
 	tctx := &DockerfileContext{
 		BaseImage:                SubstrateImage,
 		Packages:                 filterBasePackages(p.Build.Packages),
@@ -669,6 +701,7 @@ func (g *ProjectGenerator) buildContext() (*DockerfileContext, error) {
 		HarnessVersion:           harnessVersion,
 		HarnessVolumeDirs:        harnessVolumeDirs(bundle),
 		HarnessSeeds:             bundle.Manifest.Seeds,
+		Services:                 services,
 		ManagedPrompt:            managedPromptContext(bundle.Manifest.ManagedPrompt),
 		BuildKitEnabled:          g.BuildKitEnabled,
 		HasFirewallCA:            hasFirewallCA,
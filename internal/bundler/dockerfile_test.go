@@ -445,7 +445,8 @@ func TestBuildContext_LateClawkerBlock(t *testing.T) {
 	for _, asset := range []string{
 		"clawker-agent-prompt.md",
 		"host-open.sh",
-		"git-credential-clawker.sh",
+		"clawker-clip.sh",
+		"/build/clawker-credential-helper",
 		"/build/callback-forwarder",
 		"/build/clawker-socket-server",
 		"/usr/local/bin/clawkerd",
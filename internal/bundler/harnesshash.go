@@ -0,0 +1,107 @@
+package bundler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"maps"
+	"os"
+	"slices"
+)
+
+// HarnessBuildInputs are the build parameters, beyond the generator's own
+// rendering, that change what a harness image build produces.
+type HarnessBuildInputs struct {
+	// BaseImageID is the ID of the base image the harness builds FROM. The
+	// base tag is local and mutable; its ID pins the parent, so a rebuilt
+	// base (new packages, a pulled upstream image) flips the hash.
+	BaseImageID string
+	// BuildArgs are the user --build-arg entries, all of them: the harness
+	// Dockerfile and its stacks are too open-ended to filter by declaration
+	// the way BaseContentHash does, and a spurious harness rebuild is cheap.
+	BuildArgs map[string]*string
+	// Target is the multi-stage build target.
+	Target string
+	// Labels are the labels stamped on the image, minus any that vary per
+	// build (the created timestamp).
+	Labels map[string]string
+}
+
+// HarnessContentHash computes the SHA-256 freshness key for a harness
+// image: the rendered harness Dockerfile; every file the harness build
+// context stages (the bundle's assets/ tree, clawker's scripts and
+// binaries, the firewall CA certificate); and the build inputs. The
+// builder skips a harness build whose key matches the existing image's
+// content label.
+//
+// Like BaseContentHash this is a "may skip" key, not a cache of layers:
+// anything it misses can only leave a stale image that `--force-rebuild`
+// replaces, and anything extra only costs a rebuild Docker's layer cache
+// mostly absorbs.
+func (g *ProjectGenerator) HarnessContentHash(harnessDockerfile []byte, in HarnessBuildInputs) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "dockerfile:%d\x00", len(harnessDockerfile))
+	h.Write(harnessDockerfile)
+	fmt.Fprintf(h, "base:%s\x00target:%s\x00", in.BaseImageID, in.Target)
+
+	bundle, err := g.harnessBundle()
+	if err != nil {
+		return "", err
+	}
+	if walkErr := bundle.WalkAssets(func(relPath string, content []byte) error {
+		hashContextFile(h, relPath, 0, content)
+		return nil
+	}); walkErr != nil {
+		return "", fmt.Errorf("hash harness assets: %w", walkErr)
+	}
+
+	ctxFiles, err := clawkerContextFiles(bundle, g.cfg.Project().Services)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range ctxFiles {
+		hashContextFile(h, f.name, f.mode, f.content)
+	}
+
+	if caCertPath, caErr := g.firewallCACertPath(); caErr == nil && caCertPath != "" {
+		content, readErr := os.ReadFile(caCertPath)
+		if readErr != nil {
+			return "", fmt.Errorf("failed to read firewall CA cert: %w", readErr)
+		}
+		hashContextFile(h, "clawker-ca.crt", 0, content)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(in.BuildArgs)) {
+		var effective string
+		if value := in.BuildArgs[name]; value != nil {
+			effective = *value
+		} else {
+			// `--build-arg NAME` takes its value from the client environment.
+			effective = os.Getenv(name)
+		}
+		fmt.Fprintf(h, "arg:%s=%s\x00", name, effective)
+	}
+	for _, key := range slices.Sorted(maps.Keys(in.Labels)) {
+		fmt.Fprintf(h, "label:%s=%s\x00", key, in.Labels[key])
+	}
+	hashPlatforms(h, g.Platforms)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashPlatforms writes the target platform list, in order. Nothing is
+// written for an empty list, so native builds keep the hashes they had
+// before platforms were an input.
+func hashPlatforms(h hash.Hash, platforms []string) {
+	for _, p := range platforms {
+		fmt.Fprintf(h, "platform:%s\x00", p)
+	}
+}
+
+// hashContextFile writes one length-prefixed context file record, so no
+// two distinct file sets can produce the same byte stream.
+func hashContextFile(h hash.Hash, name string, mode os.FileMode, content []byte) {
+	fmt.Fprintf(h, "file:%s:%o:%d\x00", name, mode, len(content))
+	h.Write(content)
+}
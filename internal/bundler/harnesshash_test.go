@@ -0,0 +1,72 @@
+package bundler //nolint:testpackage // shares in-package test helpers (testConfig, newTestProjectGenerator)
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHarnessContentHash(t *testing.T) {
+	gen := newTestProjectGenerator(testConfig(t, minimalProjectYAML()), t.TempDir())
+	df := []byte("FROM clawker-proj:base\n")
+	in := HarnessBuildInputs{
+		BaseImageID: "sha256:base",
+		BuildArgs:   map[string]*string{"A": strptr("1")},
+		Labels:      map[string]string{"k": "v"},
+	}
+
+	base, err := gen.HarnessContentHash(df, in)
+	require.NoError(t, err)
+	again, err := gen.HarnessContentHash(df, in)
+	require.NoError(t, err)
+	assert.Equal(t, base, again, "deterministic")
+	assert.Len(t, base, 64, "hex-encoded sha256")
+
+	changed := map[string]func(HarnessBuildInputs) ([]byte, HarnessBuildInputs){
+		"dockerfile": func(in HarnessBuildInputs) ([]byte, HarnessBuildInputs) { return []byte("FROM other\n"), in },
+		"base id": func(in HarnessBuildInputs) ([]byte, HarnessBuildInputs) {
+			in.BaseImageID = "sha256:rebuilt"
+			return df, in
+		},
+		"build arg": func(in HarnessBuildInputs) ([]byte, HarnessBuildInputs) {
+			in.BuildArgs = map[string]*string{"A": strptr("2")}
+			return df, in
+		},
+		"target": func(in HarnessBuildInputs) ([]byte, HarnessBuildInputs) {
+			in.Target = "dev"
+			return df, in
+		},
+		"label": func(in HarnessBuildInputs) ([]byte, HarnessBuildInputs) {
+			in.Labels = map[string]string{"k": "w"}
+			return df, in
+		},
+	}
+	for name, mutate := range changed {
+		t.Run(name, func(t *testing.T) {
+			mdf, mIn := mutate(in)
+			h, err := gen.HarnessContentHash(mdf, mIn)
+			require.NoError(t, err)
+			assert.NotEqual(t, base, h)
+		})
+	}
+}
+
+func TestContentHashes_Platforms(t *testing.T) {
+	gen := newTestProjectGenerator(testConfig(t, minimalProjectYAML()), t.TempDir())
+	df := []byte("FROM x\n")
+
+	native, err := gen.BaseContentHash(df, nil)
+	require.NoError(t, err)
+	nativeHarness, err := gen.HarnessContentHash(df, HarnessBuildInputs{})
+	require.NoError(t, err)
+
+	gen.Platforms = []string{"linux/amd64", "linux/arm64"}
+	multi, err := gen.BaseContentHash(df, nil)
+	require.NoError(t, err)
+	multiHarness, err := gen.HarnessContentHash(df, HarnessBuildInputs{})
+	require.NoError(t, err)
+
+	assert.NotEqual(t, native, multi, "base hash folds in platforms")
+	assert.NotEqual(t, nativeHarness, multiHarness, "harness hash folds in platforms")
+}
@@ -0,0 +1,179 @@
+package bundler
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// ServicesInstallScript detects the image's process supervisor at build time
+// and installs the rendered service configs for it (see the script header).
+//
+//go:embed assets/clawker-services-install.sh
+var ServicesInstallScript string
+
+// Build-context layout of the rendered services: block. The harness
+// template copies ctxDirServices to consts.ServicesDir.
+const (
+	ctxDirServices         = "clawker-services"
+	ctxFileServicesInstall = "install.sh"
+	// serviceUnitPrefix namespaces the generated systemd user units so they
+	// never collide with units the image already ships.
+	serviceUnitPrefix = "clawker-"
+)
+
+// ServicesContext drives the harness template's services block. Nil when
+// the project declares no services.
+type ServicesContext struct {
+	// Dir is the in-image directory the rendered services are copied to.
+	Dir string
+	// ScanDir is the scan directory the install step populates for s6/runit.
+	ScanDir string
+}
+
+// serviceManifestEntry is one services.json entry. clawkerd decodes the same
+// shape (clawkerd.ServiceSpec) to supervise services itself when the image
+// has no supervisor.
+type serviceManifestEntry struct {
+	Name    string `json:"name"`
+	Restart string `json:"restart"`
+}
+
+// servicesContext checks the merged services: block and returns the template
+// data for it. Layers merge per field, so an empty command is only caught
+// here, once the final entry is known.
+func servicesContext(services map[string]config.ServiceConfig) (*ServicesContext, error) {
+	if len(services) == 0 {
+		return nil, nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(services)) {
+		if strings.TrimSpace(services[name].Command) == "" {
+			return nil, fmt.Errorf("services.%s: command is required", name)
+		}
+	}
+	return &ServicesContext{Dir: consts.ServicesDir, ScanDir: consts.ServicesScanDir}, nil
+}
+
+// serviceContextFiles renders the services: block into build-context files
+// under ctxDirServices: a launch script per service (bin/<name>) that every
+// supervisor execs, s6 and runit service directories, systemd user units,
+// the services.json manifest, and the install script. Configs for every
+// supervisor are staged; the install step keeps only the one it finds.
+func serviceContextFiles(services map[string]config.ServiceConfig) ([]ctxFile, error) {
+	if len(services) == 0 {
+		return nil, nil
+	}
+	names := slices.Sorted(maps.Keys(services))
+
+	manifest := make([]serviceManifestEntry, 0, len(names))
+	var files []ctxFile
+	add := func(name, content string, mode os.FileMode) {
+		files = append(files, ctxFile{path.Join(ctxDirServices, name), []byte(content), mode})
+	}
+	for _, name := range names {
+		svc := services[name]
+		launcher := path.Join(consts.ServicesDir, "bin", name)
+
+		add(path.Join("bin", name), serviceLaunchScript(name, svc), 0o755)
+		add(path.Join("s6", name, "run"), superviseRunScript(launcher), 0o755)
+		add(path.Join("runit", name, "run"), superviseRunScript(launcher), 0o755)
+		if finish := s6FinishScript(svc.RestartPolicy()); finish != "" {
+			add(path.Join("s6", name, "finish"), finish, 0o755)
+		}
+		if finish := runitFinishScript(svc.RestartPolicy()); finish != "" {
+			add(path.Join("runit", name, "finish"), finish, 0o755)
+		}
+		add(path.Join("systemd", serviceUnitPrefix+name+".service"), systemdUnit(name, launcher, svc.RestartPolicy()), 0o644)
+
+		manifest = append(manifest, serviceManifestEntry{Name: name, Restart: svc.RestartPolicy()})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode services manifest: %w", err)
+	}
+	add(consts.ServicesManifestFile, string(data)+"\n", 0o644)
+	add(ctxFileServicesInstall, ServicesInstallScript, 0o755)
+	return files, nil
+}
+
+// serviceLaunchScript is the single place a service's command, env, and
+// workdir are rendered; each supervisor just execs it. The command runs
+// under exec so the supervisor's signals reach the service directly.
+func serviceLaunchScript(name string, svc config.ServiceConfig) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	fmt.Fprintf(&b, "# clawker service %q, generated from clawker.yaml services.%s.\n", name, name)
+	if svc.Workdir != "" {
+		fmt.Fprintf(&b, "cd %s || exit 1\n", shellQuote(svc.Workdir))
+	} else {
+		b.WriteString("cd \"$HOME\" || exit 1\n")
+	}
+	for _, k := range slices.Sorted(maps.Keys(svc.Env)) {
+		fmt.Fprintf(&b, "export %s=%s\n", k, shellQuote(svc.Env[k]))
+	}
+	fmt.Fprintf(&b, "exec /bin/sh -c %s\n", shellQuote(svc.Command))
+	return b.String()
+}
+
+// superviseRunScript is the s6/runit run file: merge stderr into stdout
+// (which the supervisor inherits from clawkerd's per-service log) and exec
+// the launcher.
+func superviseRunScript(launcher string) string {
+	return "#!/bin/sh\nexec 2>&1\nexec " + launcher + "\n"
+}
+
+// s6FinishScript maps a restart policy onto s6's finish protocol: exit 125
+// marks the service permanently down. Empty for "always" — s6 restarts by
+// default.
+func s6FinishScript(policy string) string {
+	switch policy {
+	case config.ServiceRestartNo:
+		return "#!/bin/sh\nexit 125\n"
+	case config.ServiceRestartOnFailure:
+		return "#!/bin/sh\n# $1 is the exit code (256 when killed by a signal).\n[ \"$1\" = 0 ] && exit 125\nexit 0\n"
+	}
+	return ""
+}
+
+// runitFinishScript maps a restart policy onto runit, which has no finish
+// exit-code protocol: the service is taken down explicitly instead.
+func runitFinishScript(policy string) string {
+	switch policy {
+	case config.ServiceRestartNo:
+		return "#!/bin/sh\nexec sv down .\n"
+	case config.ServiceRestartOnFailure:
+		return "#!/bin/sh\n# $1 is the exit code (-1 when killed by a signal).\n[ \"$1\" = 0 ] && exec sv down .\nexit 0\n"
+	}
+	return ""
+}
+
+// systemdUnit renders a systemd user unit enabled for default.target.
+// The restart policy names are systemd's own Restart= values.
+func systemdUnit(name, launcher, policy string) string {
+	return fmt.Sprintf(`[Unit]
+Description=clawker service %s
+
+[Service]
+Type=simple
+ExecStart=%s
+Restart=%s
+RestartSec=1
+
+[Install]
+WantedBy=default.target
+`, name, launcher, policy)
+}
+
+// shellQuote single-quotes s for POSIX sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
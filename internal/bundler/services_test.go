@@ -0,0 +1,125 @@
+package bundler
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+)
+
+const servicesProjectYAML = `
+build:
+services:
+  redis:
+    command: redis-server --save ''
+    restart: on-failure
+  worker:
+    command: ./bin/worker --port "$PORT"
+    workdir: /workspace
+    env:
+      PORT: "8080"
+`
+
+func TestGenerateHarness_Services(t *testing.T) {
+	cfg := testConfig(t, servicesProjectYAML)
+	gen := newTestProjectGenerator(cfg, t.TempDir())
+	dockerfile, err := gen.GenerateHarness()
+	require.NoError(t, err)
+	content := string(dockerfile)
+
+	assert.Contains(t, content, "COPY clawker-services/ /etc/clawker/services/")
+	assert.Contains(t, content,
+		"RUN sh /etc/clawker/services/install.sh ${USERNAME} /etc/clawker/services /var/lib/clawker/services")
+	assert.Greater(t, strings.Index(content, "clawker-services/"), strings.LastIndex(content, "USER root"),
+		"services are installed inside the clawker-managed root block")
+}
+
+func TestGenerateHarness_NoServicesNoBlock(t *testing.T) {
+	cfg := testConfig(t, minimalProjectYAML())
+	gen := newTestProjectGenerator(cfg, t.TempDir())
+	dockerfile, err := gen.GenerateHarness()
+	require.NoError(t, err)
+	assert.NotContains(t, string(dockerfile), "clawker-services")
+}
+
+func TestGenerateHarness_ServiceWithoutCommand(t *testing.T) {
+	cfg := testConfig(t, "build:\nservices:\n  redis:\n    restart: \"no\"\n")
+	gen := newTestProjectGenerator(cfg, t.TempDir())
+	_, err := gen.GenerateHarness()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "services.redis: command is required")
+}
+
+func TestServiceContextFiles(t *testing.T) {
+	files, err := serviceContextFiles(map[string]config.ServiceConfig{
+		"redis":  {Command: "redis-server --save ''", Restart: config.ServiceRestartOnFailure},
+		"worker": {Command: "./bin/worker", Workdir: "/workspace", Env: map[string]string{"PORT": "8080"}},
+		"once":   {Command: "./migrate", Restart: config.ServiceRestartNo},
+	})
+	require.NoError(t, err)
+	byName := map[string]string{}
+	for _, f := range files {
+		byName[f.name] = string(f.content)
+	}
+
+	assert.Equal(t, `#!/bin/sh
+# clawker service "redis", generated from clawker.yaml services.redis.
+cd "$HOME" || exit 1
+exec /bin/sh -c 'redis-server --save '\'''\'''
+`, byName["clawker-services/bin/redis"])
+	assert.Equal(t, `#!/bin/sh
+# clawker service "worker", generated from clawker.yaml services.worker.
+cd '/workspace' || exit 1
+export PORT='8080'
+exec /bin/sh -c './bin/worker'
+`, byName["clawker-services/bin/worker"])
+
+	assert.Equal(t, "#!/bin/sh\nexec 2>&1\nexec /etc/clawker/services/bin/redis\n", byName["clawker-services/s6/redis/run"])
+	assert.Equal(t, byName["clawker-services/s6/redis/run"], byName["clawker-services/runit/redis/run"])
+
+	// "always" is every supervisor's default — no finish script.
+	assert.NotContains(t, byName, "clawker-services/s6/worker/finish")
+	assert.NotContains(t, byName, "clawker-services/runit/worker/finish")
+	assert.Contains(t, byName["clawker-services/s6/once/finish"], "exit 125")
+	assert.Contains(t, byName["clawker-services/runit/once/finish"], "sv down .")
+	assert.Contains(t, byName["clawker-services/s6/redis/finish"], `[ "$1" = 0 ] && exit 125`)
+
+	assert.Contains(t, byName["clawker-services/systemd/clawker-redis.service"], "Restart=on-failure\n")
+	assert.Contains(t, byName["clawker-services/systemd/clawker-once.service"], "Restart=no\n")
+	assert.Contains(t, byName["clawker-services/systemd/clawker-worker.service"], "ExecStart=/etc/clawker/services/bin/worker\n")
+
+	var manifest []serviceManifestEntry
+	require.NoError(t, json.Unmarshal([]byte(byName["clawker-services/services.json"]), &manifest))
+	assert.Equal(t, []serviceManifestEntry{
+		{Name: "once", Restart: config.ServiceRestartNo},
+		{Name: "redis", Restart: config.ServiceRestartOnFailure},
+		{Name: "worker", Restart: config.ServiceRestartAlways},
+	}, manifest)
+
+	assert.Equal(t, ServicesInstallScript, byName["clawker-services/install.sh"])
+}
+
+func TestWriteHarnessBuildContextToDir_Services(t *testing.T) {
+	cfg := testConfig(t, servicesProjectYAML)
+	gen := NewProjectGenerator(cfg, t.TempDir())
+	dir := t.TempDir()
+
+	require.NoError(t, gen.WriteHarnessBuildContextToDir(dir, []byte("FROM scratch\n")))
+
+	for _, name := range []string{
+		"clawker-services/services.json",
+		"clawker-services/install.sh",
+		"clawker-services/bin/redis",
+		"clawker-services/s6/worker/run",
+		"clawker-services/systemd/clawker-worker.service",
+	} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		assert.NoError(t, err, "expected file %s to exist", name)
+	}
+}
@@ -9,8 +9,11 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 
 	"github.com/schmitthub/clawker/internal/build"
 	"github.com/schmitthub/clawker/internal/changelog"
@@ -35,6 +38,18 @@ func Main() int {
 	// Create factory with version info
 	f := factory.New(buildVersion)
 
+	// An unhandled panic writes a crash report — the stack plus the recent
+	// log records, debug included — next to clawker.log, then re-panics so
+	// the exit status and Go's own trace are unchanged.
+	defer func() {
+		if r := recover(); r != nil {
+			if log, logErr := f.Logger(); logErr == nil {
+				log.ReportPanic(f.IOStreams.ErrOut, r)
+			}
+			panic(r)
+		}
+	}()
+
 	// Fail fast if XDG directories collide (e.g. CLAWKER_DATA_DIR == CLAWKER_CONFIG_DIR).
 	// Checked before any file I/O to prevent data corruption.
 	if err := storage.ValidateDirectories(); err != nil {
@@ -42,6 +57,19 @@ func Main() int {
 		return 1
 	}
 
+	// --context runs the command against a registered project's root. It is
+	// applied before anything reads config: the root command loads it while
+	// building the command tree.
+	if name, ok := root.ContextFlagValue(f, os.Args[1:]); ok {
+		// Finding the flag loaded config from the current directory; start
+		// over with a factory that resolves everything from the project root.
+		f = factory.New(buildVersion)
+		if err := root.EnterProjectContext(f, name); err != nil {
+			fmt.Fprintf(f.IOStreams.ErrOut, "%s %v\n", f.IOStreams.ColorScheme().FailureIcon(), err)
+			return 1
+		}
+	}
+
 	// CLI runtime state (the update-check cache + changelog cursor) is resolved
 	// lazily inside checkForUpdate/checkForChanges via f.CLIState(). A state-store
 	// error there aborts that one background check and is logged to the file log,
@@ -151,7 +179,8 @@ func Main() int {
 	// cmd.Context() to every caller (WaitForHealthy, etc.) instead of hanging.
 	signalCtx, signalStop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer signalStop()
-	rootCmd.SetContext(signalCtx)
+	cmdCtx, endCommandSpan := startCommandSpan(signalCtx, f)
+	rootCmd.SetContext(cmdCtx)
 
 	// Flush buffered logs + the OTEL provider on exit. loggerCtx is a child of the
 	// signal context, and loggerCancel() is called below (before draining the
@@ -170,6 +199,7 @@ func Main() int {
 	}()
 
 	cmd, err := rootCmd.ExecuteC()
+	endCommandSpan(cmd, err)
 
 	// gh CLI pattern: cancel the background checks now, before draining their
 	// channels. Cancelling aborts any in-flight HTTP so the drain returns promptly
@@ -204,8 +234,8 @@ func Main() int {
 	if err != nil {
 		if errors.Is(err, cmdutil.SilentError) {
 			// Already displayed — no-op
-		} else if errors.Is(err, whail.ErrDockerNotAvailable) {
-			printDockerInstallHelper(f.IOStreams.ErrOut, f.IOStreams.ColorScheme(), err)
+		} else if whail.IsDockerUnavailable(err) {
+			printDockerUnavailable(f.IOStreams.ErrOut, f.IOStreams.ColorScheme(), err, os.Getenv("DOCKER_HOST"))
 		} else {
 			printError(f.IOStreams.ErrOut, f.IOStreams.ColorScheme(), err, cmd)
 		}
@@ -224,6 +254,45 @@ func Main() int {
 	return 0
 }
 
+// traceFlushTimeout bounds the span export at the end of a traced command.
+// A local collector takes milliseconds; the bound keeps an unreachable one
+// from holding up exit for the exporter's full retry window.
+const traceFlushTimeout = time.Second
+
+// startCommandSpan opens the invocation's root span when the logger exports
+// spans (monitoring on, see factory newLogger) and returns the context
+// carrying it — Docker API spans from whail nest under it. The returned
+// func renames the span to the executed command path, records err, ends the
+// span, and flushes the batch so short commands are not lost. With tracing
+// off it returns ctx unchanged and a no-op.
+func startCommandSpan(ctx context.Context, f *cmdutil.Factory) (context.Context, func(*cobra.Command, error)) {
+	log, err := f.Logger()
+	if err != nil {
+		return ctx, func(*cobra.Command, error) {}
+	}
+	tp := log.TracerProvider()
+	if tp == nil {
+		return ctx, func(*cobra.Command, error) {}
+	}
+	spanCtx, span := tp.Tracer("github.com/schmitthub/clawker").Start(ctx, "clawker")
+	return spanCtx, func(cmd *cobra.Command, err error) {
+		if cmd != nil {
+			span.SetName(cmd.CommandPath())
+			span.SetAttributes(attribute.String("clawker.command", cmd.CommandPath()))
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+		flushCtx, cancel := context.WithTimeout(ctx, traceFlushTimeout)
+		defer cancel()
+		if err := log.FlushTraces(flushCtx); err != nil {
+			log.Debug().Err(err).Msg("flushing command span")
+		}
+	}
+}
+
 // checkForChanges resolves the HttpClient and CLIState nouns from the Factory and
 // hands them to changelog.CheckForChanges. It is the changelog teaser's single
 // entry from Main; a noun-resolution error aborts just this one background check
@@ -322,9 +391,14 @@ func printChangelogTeaser(ios *iostreams.IOStreams, entries []changelog.Entry) {
 	}
 }
 
-// printDockerInstallHelper renders a user-friendly message when the Docker
-// daemon cannot be reached, showing the actual error and troubleshooting steps.
-func printDockerInstallHelper(out io.Writer, cs *iostreams.ColorScheme, err error) {
+// printDockerUnavailable renders the one standard message for a command that
+// needs the Docker daemon and cannot reach it — whether the connect health
+// check failed or the daemon went away mid-command. It shows the underlying
+// cause and troubleshooting steps; dockerHost is the DOCKER_HOST value, named
+// in the steps when set since a stale remote host is a common cause. Commands
+// that need no daemon (help, completion, config, settings, project) never get
+// here: the Docker client is resolved lazily by the commands that use it.
+func printDockerUnavailable(out io.Writer, cs *iostreams.ColorScheme, err error, dockerHost string) {
 	// Extract the actual cause from the DockerError chain
 	detail := err.Error()
 	var dockerErr *whail.DockerError
@@ -332,12 +406,25 @@ func printDockerInstallHelper(out io.Writer, cs *iostreams.ColorScheme, err erro
 		detail = dockerErr.Unwrap().Error()
 	}
 
-	fmt.Fprintf(out, "%s Failed to connect to Docker: %s\n\n", cs.FailureIcon(), cs.Muted(cs.Italic(detail)))
+	steps := []string{
+		fmt.Sprintf("Install Docker: %s", cs.Cyan("https://docs.docker.com/get-docker/")),
+		fmt.Sprintf("Start Docker Desktop or run %s", cs.Bold("sudo systemctl start docker")),
+	}
+	if dockerHost != "" {
+		steps = append(steps, fmt.Sprintf("DOCKER_HOST is set to %s; check that it points at a running daemon", cs.Bold(dockerHost)))
+	} else {
+		steps = append(steps, fmt.Sprintf("On Linux, check that your user can access the socket: %s", cs.Bold("ls -l /var/run/docker.sock")))
+	}
+	steps = append(steps,
+		fmt.Sprintf("Verify the daemon is reachable: %s", cs.Bold("docker info")),
+		"Re-run your command",
+	)
+
+	fmt.Fprintf(out, "%s Docker is unavailable: %s\n\n", cs.FailureIcon(), cs.Muted(cs.Italic(detail)))
 	fmt.Fprintf(out, "%s\n", cs.Bold("Troubleshooting:"))
-	fmt.Fprintf(out, "  1. Install Docker Desktop: %s\n", cs.Cyan("https://docs.docker.com/get-docker/"))
-	fmt.Fprintf(out, "  2. Start Docker Desktop or run %s\n", cs.Bold("sudo systemctl start docker"))
-	fmt.Fprintf(out, "  3. Verify the daemon is reachable: %s\n", cs.Bold("docker info"))
-	fmt.Fprintf(out, "  4. Re-run your command\n")
+	for i, step := range steps {
+		fmt.Fprintf(out, "  %d. %s\n", i+1, step)
+	}
 }
 
 // userFormattedError is a duck-typed interface for errors that provide
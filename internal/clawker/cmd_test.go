@@ -104,28 +104,43 @@ func TestPrintChangelogTeaser_EmptySelfGuard(t *testing.T) {
 	}
 }
 
-func TestPrintDockerInstallHelper(t *testing.T) {
-	var buf bytes.Buffer
+func TestPrintDockerUnavailable(t *testing.T) {
 	cs := iostreams.NewColorScheme(false, "") // no color for test assertions
 	pingErr := errors.New("dial unix /var/run/docker.sock: connect: connection refused")
 	dockerErr := whail.ErrDockerHealthCheckFailed(pingErr)
 	wrapped := fmt.Errorf("connecting to Docker: %w", dockerErr)
 
-	printDockerInstallHelper(&buf, cs, wrapped)
+	t.Run("default host", func(t *testing.T) {
+		var buf bytes.Buffer
+		printDockerUnavailable(&buf, cs, wrapped, "")
+
+		output := buf.String()
+		wantParts := []string{
+			"Docker is unavailable",
+			"dial unix /var/run/docker.sock: connect: connection refused",
+			"https://docs.docker.com/get-docker/",
+			"ls -l /var/run/docker.sock",
+			"docker info",
+			"Re-run your command",
+		}
+		for _, part := range wantParts {
+			if !strings.Contains(output, part) {
+				t.Errorf("output missing %q, got:\n%s", part, output)
+			}
+		}
+		if strings.Contains(output, "DOCKER_HOST") {
+			t.Errorf("output must not mention an unset DOCKER_HOST, got:\n%s", output)
+		}
+	})
 
-	output := buf.String()
-	wantParts := []string{
-		"Failed to connect to Docker",
-		"dial unix /var/run/docker.sock: connect: connection refused",
-		"https://docs.docker.com/get-docker/",
-		"docker info",
-		"Re-run your command",
-	}
-	for _, part := range wantParts {
-		if !strings.Contains(output, part) {
-			t.Errorf("output missing %q, got:\n%s", part, output)
+	t.Run("DOCKER_HOST set", func(t *testing.T) {
+		var buf bytes.Buffer
+		printDockerUnavailable(&buf, cs, wrapped, "tcp://10.0.0.5:2375")
+
+		if output := buf.String(); !strings.Contains(output, "DOCKER_HOST is set to tcp://10.0.0.5:2375") {
+			t.Errorf("output must name DOCKER_HOST, got:\n%s", output)
 		}
-	}
+	})
 }
 
 func TestPrintUpdateNotification_NilInfo(t *testing.T) {
@@ -12,8 +12,9 @@
 //  2. Start the ClawkerdService mTLS listener (CP-dialed) which pins
 //     the peer CN to consts.ContainerCP.
 //  3. Resolve the unprivileged container user; build the spawn state
-//     but do NOT spawn — handleAgentReady triggers the spawn when
-//     CP-driven init completes.
+//     and the project-services supervisor but do NOT spawn —
+//     handleAgentReady starts the services and spawns the user CMD when
+//     CP-driven init completes. Start the periodic status reporter.
 //  4. Wait for ctx.Done (SIGTERM/SIGINT), main child exit, listener
 //     fatal, or a command-requested exit; tear the listener down before
 //     releasing the reaper's orphan drain; exit with the child's
@@ -99,6 +100,16 @@ func Main() int {
 		return 1
 	}
 
+	// A panic in the daemon itself writes a crash report — stack plus the
+	// recent log records, debug included — next to the log file before
+	// re-panicking; the stderr line is best-effort like Go's own trace.
+	defer func() {
+		if r := recover(); r != nil {
+			log.ReportPanic(os.Stderr, r)
+			panic(r)
+		}
+	}()
+
 	exitCode, runErr := run(ctx, log)
 	if runErr != nil {
 		log.Error().Err(runErr).Str("event", "shutdown").Msg("clawkerd exiting with error")
@@ -153,7 +164,7 @@ func run(ctx context.Context, log *logger.Logger) (int, error) {
 	// boundaries observed in dispatch/send/handleAgentReady can drive
 	// the spinner. nil-safe, so a wiring oversight is benign.
 	progress := daemon.NewProgressReporter(os.Stdout)
-	progress.Banner("Starting Clawker agent...")
+	progress.Banner("Starting Clawker agent")
 	defer progress.Stop()
 
 	// Resolve the unprivileged user the spawn child will run as.
@@ -206,7 +217,17 @@ func run(ctx context.Context, log *logger.Logger) (int, error) {
 	// c.Wait for stage children. DefaultEntry captures argv/env/std
 	// streams + the resolved user (see clawkerd/spawn_unix.go).
 	spawn := daemon.NewSpawnState(log)
-	spawnEntry := spawn.DefaultEntry(execUser)
+
+	// Project services (clawker.yaml services:) start alongside the user
+	// CMD, under the image's own supervisor when the build found one. nil
+	// when the image declares none. A malformed baked manifest is a broken
+	// image — deterministic, so exit 2 like the other config failures.
+	services, err := daemon.NewServiceSupervisor(log, consts.ServicesDir, logsDir, execUser)
+	if err != nil {
+		log.Error().Err(err).Str("event", "services_load_failed").Msg("load project services")
+		return exitCodeConfig, fmt.Errorf("load services: %w", err)
+	}
+	spawnEntry := services.WrapEntry(spawn.DefaultEntry(execUser))
 
 	// listenerFatalCh fires once if the Serve goroutine dies on a
 	// non-stop error or panics. Without this signal, run() sits on
@@ -253,6 +274,14 @@ func run(ctx context.Context, log *logger.Logger) (int, error) {
 		return code, fmt.Errorf("start clawkerd listener: %w", err)
 	}
 
+	// Periodic in-container health report to CP (AgentService.ReportStatus):
+	// process tree usage, workspace filesystem, Claude Code liveness.
+	// Stopped before the listener teardown below; a no-op when
+	// CLAWKER_CP_AGENT_ADDR is unset.
+	statusCtx, stopStatus := context.WithCancel(ctx)
+	defer stopStatus()
+	go daemon.NewStatusReporter(boot, os.Getenv(consts.EnvClawkerdAgentAddr)).Run(statusCtx, log)
+
 	log.Info().Str("event", "daemon_idle").Msg("entering daemon idle loop; CP may dial Session at any time")
 
 	// Wait for either signal-driven shutdown, listener fatal, OR main
@@ -272,6 +301,9 @@ func run(ctx context.Context, log *logger.Logger) (int, error) {
 		// it would never fire — and proceed straight to listener
 		// teardown.
 		if spawn.Spawned() {
+			// Services share the user CMD's grace window rather than
+			// queueing behind it; the Stop below waits for them.
+			go services.Stop(shutdownGrace)
 			spawn.Stop(shutdownGrace)
 			<-spawn.MainExited()
 		} else {
@@ -311,6 +343,13 @@ func run(ctx context.Context, log *logger.Logger) (int, error) {
 		}
 	}
 
+	// Stop project services before the listener teardown and phase 2:
+	// each is reaped by its own exec.Cmd.Wait, which the reaper's
+	// Wait4(-1) would otherwise steal. No-op when none were declared or
+	// started; returns immediately if the SIGTERM path already stopped them.
+	services.Stop(shutdownGrace)
+	stopStatus()
+
 	// Tear down the gRPC listener BEFORE phase 2 begins so
 	// session.go's exec.Cmd.Wait calls complete (their stage children
 	// remain reapable to those calls; the reaper's Wait4(mainPID)
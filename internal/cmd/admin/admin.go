@@ -0,0 +1,29 @@
+// Package admin provides the `clawker admin` command group: maintenance
+// operations on clawker's own Docker resources.
+package admin
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmd/admin/migratelabels"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+)
+
+// NewCmdAdmin creates the admin parent command and registers its
+// subcommands.
+func NewCmdAdmin(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Maintain clawker's Docker resources",
+		Long: `Maintenance commands for the containers and images clawker manages.
+
+These are occasional, one-off operations — typically run after upgrading
+clawker when its release notes say so.`,
+		Example: `  # Upgrade existing containers and images to the current label scheme
+  clawker admin migrate-labels`,
+	}
+
+	cmd.AddCommand(migratelabels.NewCmdMigrateLabels(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,189 @@
+// Package migratelabels provides the admin migrate-labels command.
+package migratelabels
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/prompter"
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+// MigrateLabelsOptions holds options for the migrate-labels command.
+type MigrateLabelsOptions struct {
+	IOStreams *iostreams.IOStreams
+	Client    func(context.Context) (*docker.Client, error)
+	Prompter  func() *prompter.Prompter
+
+	Force bool
+}
+
+// NewCmdMigrateLabels creates the admin migrate-labels command.
+func NewCmdMigrateLabels(f *cmdutil.Factory, runF func(context.Context, *MigrateLabelsOptions) error) *cobra.Command {
+	opts := &MigrateLabelsOptions{
+		IOStreams: f.IOStreams,
+		Client:    f.Client,
+		Prompter:  f.Prompter,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "migrate-labels [OPTIONS]",
+		Short: "Upgrade existing resources to the current label scheme",
+		Long: `Relabels clawker-managed containers and images created under an older
+label scheme so they carry the current one.
+
+Every resource clawker creates records the label schema version it was
+labeled under. Docker cannot change the labels of an existing resource, so
+migrate-labels replaces each outdated one:
+
+  - Images are rebuilt as a single FROM of themselves with the new labels.
+    No layer is added, and every tag moves to the rebuilt image.
+  - Stopped containers are recreated with the same name, config, mounts,
+    and networks, from the rebuilt image. As with any recreate, changes to
+    the container's own filesystem outside its volumes are lost.
+
+Running containers are skipped; stop them and run the command again.
+Untagged images are skipped; remove them with 'clawker image prune'.
+Containers created by the monitoring stack are left to docker compose.`,
+		Example: `  # Relabel outdated containers and images
+  clawker admin migrate-labels
+
+  # Relabel without a confirmation prompt
+  clawker admin migrate-labels --force
+
+  # List what would be relabeled, changing nothing
+  clawker admin migrate-labels --dry-run --force`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return migrateLabelsRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Do not prompt for confirmation")
+
+	cmdutil.EnableDryRun(cmd)
+	return cmd
+}
+
+func migrateLabelsRun(ctx context.Context, opts *MigrateLabelsOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+
+	stale, err := client.StaleLabelSchemas(ctx)
+	if err != nil {
+		return fmt.Errorf("listing resources: %w", err)
+	}
+	if len(stale) == 0 {
+		fmt.Fprintf(ios.ErrOut, "All clawker resources use label schema %d.\n", client.LabelSchemaVersion())
+		return nil
+	}
+
+	var images, containers []whail.StaleResource
+	var stopped int
+	for _, r := range stale {
+		if r.Kind == whail.StaleImage {
+			images = append(images, r)
+			continue
+		}
+		containers = append(containers, r)
+		if !r.Running {
+			stopped++
+		}
+	}
+
+	fmt.Fprintf(ios.ErrOut, "Labeled under an older schema than %d:\n", client.LabelSchemaVersion())
+	for _, r := range stale {
+		state := ""
+		if r.Running {
+			state = ", running"
+		}
+		fmt.Fprintf(ios.ErrOut, "  %s %s (schema %d%s)\n", r.Kind, resourceName(r), r.Schema, state)
+	}
+
+	if !opts.Force {
+		msg := fmt.Sprintf("%s This will rebuild %d %s and recreate %d stopped %s.",
+			cs.WarningIcon(), len(images), plural(len(images), "image"), stopped, plural(stopped, "container"))
+		confirmed, err := opts.Prompter().Confirm(msg, false)
+		if err != nil {
+			return fmt.Errorf("confirm migration: %w", err)
+		}
+		if !confirmed {
+			fmt.Fprintln(ios.ErrOut, "Aborted.")
+			return nil
+		}
+	}
+
+	var migrated, failed int
+
+	// Images first, so recreated containers can run the relabeled image.
+	replaced := make(map[string]string, len(images))
+	for _, img := range images {
+		if img.Name == "" {
+			fmt.Fprintf(ios.ErrOut, "%s Skipped untagged image %s: remove it with 'clawker image prune'\n", cs.WarningIcon(), resourceName(img))
+			continue
+		}
+		newID, err := client.ImageRelabel(ctx, img.ID)
+		if err != nil {
+			fmt.Fprintf(ios.ErrOut, "%s %v\n", cs.FailureIcon(), err)
+			failed++
+			continue
+		}
+		replaced[img.ID] = newID
+		migrated++
+		fmt.Fprintf(ios.ErrOut, "%s Relabeled image %s\n", cs.SuccessIcon(), img.Name)
+	}
+
+	for _, c := range containers {
+		if c.Running {
+			fmt.Fprintf(ios.ErrOut, "%s Skipped running container %s: stop it with 'clawker container stop %s' and run this again\n",
+				cs.WarningIcon(), c.Name, c.Name)
+			continue
+		}
+		if _, err := client.ContainerRelabel(ctx, c.ID, replaced[c.ImageID]); err != nil {
+			fmt.Fprintf(ios.ErrOut, "%s %v\n", cs.FailureIcon(), err)
+			failed++
+			continue
+		}
+		migrated++
+		fmt.Fprintf(ios.ErrOut, "%s Relabeled container %s\n", cs.SuccessIcon(), c.Name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("relabeling failed for %d of %d resources", failed, failed+migrated)
+	}
+	return nil
+}
+
+// resourceName names a stale resource by its name, or its short ID when it
+// has none.
+func resourceName(r whail.StaleResource) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	id := strings.TrimPrefix(r.ID, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return noun
+	}
+	return noun + "s"
+}
@@ -0,0 +1,212 @@
+package migratelabels
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/prompter"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+func TestNewCmdMigrateLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantForce bool
+		wantErr   bool
+	}{
+		{name: "no flags"},
+		{name: "force flag", input: "--force", wantForce: true},
+		{name: "force flag short", input: "-f", wantForce: true},
+		{name: "positional argument", input: "web", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf := cmdutiltest.NewFactory(t)
+
+			var gotOpts *MigrateLabelsOptions
+			cmd := NewCmdMigrateLabels(tf.Factory, func(_ context.Context, opts *MigrateLabelsOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			cmd.Flags().BoolP("help", "x", false, "")
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, gotOpts)
+			require.Equal(t, tt.wantForce, gotOpts.Force)
+		})
+	}
+}
+
+func TestCmdMigrateLabels_Properties(t *testing.T) {
+	cmd := NewCmdMigrateLabels(cmdutiltest.NewFactory(t).Factory, nil)
+
+	require.Equal(t, "migrate-labels [OPTIONS]", cmd.Use)
+	require.NotEmpty(t, cmd.Short)
+	require.NotEmpty(t, cmd.Long)
+	require.NotEmpty(t, cmd.Example)
+	require.True(t, cmdutil.SupportsDryRun(cmd))
+	require.NotNil(t, cmd.Flags().ShorthandLookup("f"))
+}
+
+func runMigrateLabels(t *testing.T, fake *mocks.FakeClient, args ...string) (string, error) {
+	t.Helper()
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	ios := tf.Factory.IOStreams
+	tf.Factory.Prompter = func() *prompter.Prompter { return prompter.NewPrompter(ios) }
+
+	cmd := NewCmdMigrateLabels(tf.Factory, nil)
+	cmd.SetArgs(args)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(tf.Out)
+	cmd.SetErr(tf.ErrOut)
+	err := cmd.Execute()
+	return tf.ErrOut.String(), err
+}
+
+func TestMigrateLabelsRun_NothingStale(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	current := mocks.ContainerFixture("myapp", "dev", "clawker-myapp:latest")
+	current.Labels[consts.LabelSchema] = "1"
+	fake.SetupContainerList(current)
+	fake.SetupImageList()
+
+	errOut, err := runMigrateLabels(t, fake)
+	require.NoError(t, err)
+	require.Contains(t, errOut, "All clawker resources use label schema 1.")
+	fake.AssertNotCalled(t, "ContainerCreate")
+}
+
+func TestMigrateLabelsRun_UnlabeledIsCurrent(t *testing.T) {
+	// Resources created before labels were versioned carry the version 1
+	// layout; they must not be recreated to gain the schema label.
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList(mocks.ContainerFixture("myapp", "dev", "clawker-myapp:latest"))
+	fake.SetupImageList(mocks.ImageSummaryFixture("clawker-myapp:latest"))
+
+	errOut, err := runMigrateLabels(t, fake, "--force")
+	require.NoError(t, err)
+	require.Contains(t, errOut, "All clawker resources use label schema 1.")
+	fake.AssertNotCalled(t, "ImageBuild")
+	fake.AssertNotCalled(t, "ContainerCreate")
+}
+
+// staleFake serves a stale tagged image, a stale untagged image, a stopped
+// agent container created from the tagged image, and a running agent. The
+// fixtures record no schema label (version 1); the client is at version 2.
+func staleFake(t *testing.T) (*mocks.FakeClient, *client.ContainerCreateOptions) {
+	t.Helper()
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig(), mocks.WithLabelSchemaVersion(2))
+
+	tagged := mocks.ImageSummaryFixture("clawker-myapp:latest")
+	untagged := mocks.ImageSummaryFixture("")
+	untagged.ID = "sha256:0000dangling0000"
+	untagged.RepoTags = nil
+	fake.SetupImageList(tagged, untagged)
+
+	stopped := mocks.ContainerFixture("myapp", "dev", "clawker-myapp:latest")
+	stopped.ImageID = tagged.ID
+	running := mocks.RunningContainerFixture("myapp", "web")
+	fake.SetupContainerList(stopped, running)
+
+	fake.FakeAPI.ImageInspectFn = func(_ context.Context, ref string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+		res := whailtest.ManagedImageInspect(ref)
+		res.ID = tagged.ID
+		res.RepoTags = tagged.RepoTags
+		res.Config.Labels = map[string]string{consts.LabelManaged: consts.ManagedLabelValue}
+		return res, nil
+	}
+	fake.FakeAPI.ImageBuildFn = func(_ context.Context, _ io.Reader, _ client.ImageBuildOptions) (client.ImageBuildResult, error) {
+		body := `{"aux":{"ID":"sha256:relabeled"}}` + "\n"
+		return client.ImageBuildResult{Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+
+	fake.FakeAPI.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		return client.ContainerInspectResult{Container: container.InspectResponse{
+			ID:     id,
+			Name:   stopped.Names[0],
+			Image:  tagged.ID,
+			State:  &container.State{Status: container.StateExited},
+			Config: &container.Config{Image: "clawker-myapp:latest", Labels: stopped.Labels},
+		}}, nil
+	}
+	created := &client.ContainerCreateOptions{}
+	fake.FakeAPI.ContainerCreateFn = func(_ context.Context, opts client.ContainerCreateOptions) (client.ContainerCreateResult, error) {
+		*created = opts
+		return client.ContainerCreateResult{ID: mocks.FakeContainerID}, nil
+	}
+	fake.SetupContainerRename()
+	fake.SetupContainerRemove()
+	return fake, created
+}
+
+func TestMigrateLabelsRun_Relabels(t *testing.T) {
+	fake, created := staleFake(t)
+
+	errOut, err := runMigrateLabels(t, fake, "--force")
+	require.NoError(t, err)
+
+	require.Contains(t, errOut, "Relabeled image clawker-myapp:latest")
+	require.Contains(t, errOut, "Skipped untagged image 0000dangling")
+	require.Contains(t, errOut, "Relabeled container clawker.myapp.dev")
+	require.Contains(t, errOut, "Skipped running container clawker.myapp.web")
+	fake.AssertCalledN(t, "ImageBuild", 1)
+	fake.AssertCalledN(t, "ContainerCreate", 1)
+
+	require.Equal(t, "clawker.myapp.dev", created.Name)
+	require.Equal(t, "sha256:relabeled", created.Config.Image, "the container runs the relabeled image")
+	require.Equal(t, "2", created.Config.Labels[consts.LabelSchema])
+	require.Equal(t, "dev", created.Config.Labels[consts.LabelAgent])
+}
+
+func TestMigrateLabelsRun_ReportsFailures(t *testing.T) {
+	fake, _ := staleFake(t)
+	fake.FakeAPI.ImageBuildFn = func(_ context.Context, _ io.Reader, _ client.ImageBuildOptions) (client.ImageBuildResult, error) {
+		body := `{"errorDetail":{"message":"no space left on device"},"error":"no space left on device"}` + "\n"
+		return client.ImageBuildResult{Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+
+	errOut, err := runMigrateLabels(t, fake, "--force")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "relabeling failed for 1 of 2 resources")
+	require.Contains(t, errOut, "no space left on device")
+	require.Contains(t, errOut, "Relabeled container clawker.myapp.dev", "a failed image does not stop the containers")
+}
+
+func TestMigrateLabelsRun_NonInteractiveAborts(t *testing.T) {
+	fake, _ := staleFake(t)
+
+	errOut, err := runMigrateLabels(t, fake)
+	require.NoError(t, err)
+	require.Contains(t, errOut, "container clawker.myapp.web (schema 1, running)")
+	require.Contains(t, errOut, "Aborted.")
+	fake.AssertNotCalled(t, "ImageBuild")
+	fake.AssertNotCalled(t, "ContainerCreate")
+}
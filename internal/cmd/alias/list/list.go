@@ -91,7 +91,7 @@ func listRun(_ context.Context, opts *ListOptions) error {
 		return nil
 
 	case opts.Format.IsJSON():
-		return cmdutil.WriteJSON(ios.Out, rows)
+		return opts.Format.WriteJSON(ios, "alias.list", rows)
 
 	case opts.Format.IsTemplate():
 		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(rows))
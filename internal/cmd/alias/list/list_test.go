@@ -59,7 +59,7 @@ func executeList(t *testing.T, cfg config.Config, args ...string) (stdout, stder
 
 func TestListRun_JSON(t *testing.T) {
 	cfg, path := newListEnv(t, "aliases:\n  v: version\n")
-	stdout, _, err := executeList(t, cfg, "--json")
+	stdout, _, err := executeList(t, cfg, "--format", "json")
 	require.NoError(t, err)
 
 	var rows []aliasRow
@@ -90,7 +90,7 @@ func TestListRun_JSON(t *testing.T) {
 
 func TestListRun_OverriddenDefaultReportsFile(t *testing.T) {
 	cfg, path := newListEnv(t, "aliases:\n  go: version\n")
-	stdout, _, err := executeList(t, cfg, "--json")
+	stdout, _, err := executeList(t, cfg, "--format", "json")
 	require.NoError(t, err)
 
 	var rows []aliasRow
@@ -101,7 +101,7 @@ func TestListRun_OverriddenDefaultReportsFile(t *testing.T) {
 
 func TestListRun_DisabledDefaultReportsDisablingFile(t *testing.T) {
 	cfg, path := newListEnv(t, "aliases:\n  go: \"\"\n")
-	stdout, _, err := executeList(t, cfg, "--json")
+	stdout, _, err := executeList(t, cfg, "--format", "json")
 	require.NoError(t, err)
 
 	var rows []aliasRow
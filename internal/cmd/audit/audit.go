@@ -0,0 +1,38 @@
+// Package audit provides the `clawker audit` command group: the host-side
+// journal of security decisions and mutating operations.
+package audit
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmd/audit/log"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+)
+
+// NewCmdAudit creates the audit parent command and registers its
+// subcommands.
+func NewCmdAudit(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Inspect the audit journal",
+		Long: `Commands for the audit journal clawker keeps under its state directory.
+
+Every mutating operation is appended to the journal with a timestamp, the
+actor that performed it (the CLI or the control plane), and its parameters:
+container create, start, stop, and remove, exec, image, volume, and network
+changes, config and settings writes, and firewall changes. Security overrides
+confirmed or refused at a prompt are journaled too. Config writes record the
+keys that changed, never their values.
+
+The journal is append-only; clawker never rewrites or prunes it.`,
+		Example: `  # Operations from the last day
+  clawker audit log
+
+  # Everything the control plane did this week
+  clawker audit log --since 7d --actor controlplane`,
+	}
+
+	cmd.AddCommand(log.NewCmdLog(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,182 @@
+// Package log provides the audit log command.
+package log
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/audit"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// LogOptions holds options for the audit log command.
+type LogOptions struct {
+	IOStreams *iostreams.IOStreams
+	TUI       *tui.TUI
+	Paths     func() ([]string, error)
+
+	Format *cmdutil.FormatFlags
+	Since  string
+	Actor  string
+
+	// now is the window's end time; nil means time.Now (tests pin it).
+	now func() time.Time
+}
+
+// NewCmdLog creates the audit log command.
+func NewCmdLog(f *cmdutil.Factory, runF func(context.Context, *LogOptions) error) *cobra.Command {
+	opts := &LogOptions{
+		IOStreams: f.IOStreams,
+		TUI:       f.TUI,
+		Paths:     audit.Paths,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "log",
+		Short: "Show journaled operations",
+		Long: `Shows the audit journal entries recorded in the --since window, oldest
+first. The CLI's and the control plane's journals are merged.
+
+--since accepts a duration (90m, 24h, 7d, 2w) or an RFC3339 timestamp.
+--actor limits the output to operations performed by the CLI (cli) or the
+control plane (controlplane).`,
+		Example: `  # Operations from the last day
+  clawker audit log
+
+  # Firewall changes made by the control plane since a point in time
+  clawker audit log --since 2026-03-01T00:00:00Z --actor controlplane
+
+  # Machine-readable output
+  clawker audit log --since 7d --json`,
+		Args: cmdutil.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if _, err := cmdutil.ParseSince(opts.Since, time.Now()); err != nil {
+				return cmdutil.FlagErrorf("invalid --since: %v", err)
+			}
+			switch audit.Actor(opts.Actor) {
+			case "", audit.ActorCLI, audit.ActorControlPlane:
+			default:
+				return cmdutil.FlagErrorf("invalid --actor %q: must be %s or %s", opts.Actor, audit.ActorCLI, audit.ActorControlPlane)
+			}
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return logRun(cmd.Context(), opts)
+		},
+	}
+
+	opts.Format = cmdutil.AddFormatFlags(cmd)
+	cmd.Flags().StringVar(&opts.Since, "since", "24h", "Show entries since: a duration (e.g. 24h, 7d, 2w) or an RFC3339 timestamp")
+	cmd.Flags().StringVar(&opts.Actor, "actor", "", "Only show operations by this actor (cli or controlplane)")
+
+	cmd.RegisterFlagCompletionFunc("actor", cobra.FixedCompletions( //nolint:errcheck,gosec // only errors on a programmer mistake (flag must exist); flags are defined above
+		[]string{string(audit.ActorCLI), string(audit.ActorControlPlane)}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+func logRun(_ context.Context, opts *LogOptions) error {
+	ios := opts.IOStreams
+
+	now := time.Now
+	if opts.now != nil {
+		now = opts.now
+	}
+	end := now()
+	window, err := cmdutil.ParseSince(opts.Since, end)
+	if err != nil {
+		return cmdutil.FlagErrorf("invalid --since: %v", err)
+	}
+	since := end.Add(-window)
+
+	paths, err := opts.Paths()
+	if err != nil {
+		return err
+	}
+	entries, err := audit.Read(paths, since)
+	if err != nil {
+		return err
+	}
+	if opts.Actor != "" {
+		entries = slices.DeleteFunc(entries, func(e audit.Entry) bool { return e.Actor != audit.Actor(opts.Actor) })
+	}
+	if entries == nil {
+		entries = []audit.Entry{}
+	}
+
+	switch {
+	case opts.Format.Quiet:
+		for _, e := range entries {
+			fmt.Fprintln(ios.Out, operation(e))
+		}
+		return nil
+	case opts.Format.IsJSON():
+		return opts.Format.WriteJSON(ios, "audit.log", entries)
+	case opts.Format.IsTemplate():
+		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(entries))
+	}
+
+	if len(entries) == 0 {
+		cs := ios.ColorScheme()
+		fmt.Fprintf(ios.ErrOut, "%s No audit entries since %s\n", cs.InfoIcon(), since.Format(time.RFC3339))
+		return nil
+	}
+
+	tp := opts.TUI.NewTable("TIME", "ACTOR", "OPERATION", "TARGET", "DETAILS")
+	for _, e := range entries {
+		tp.AddRow(e.Time.Local().Format(time.DateTime), actor(e), operation(e), target(e), details(e))
+	}
+	return tp.Render()
+}
+
+// actor renders who acted. Security decisions are always made in the CLI and
+// predate the Actor field.
+func actor(e audit.Entry) string {
+	if e.Actor == "" {
+		return string(audit.ActorCLI)
+	}
+	return string(e.Actor)
+}
+
+// operation renders what happened: "<resource> <action>" for operations,
+// "security override <decision>" for security decisions.
+func operation(e audit.Entry) string {
+	if e.Event == audit.EventSecurityOverride {
+		return "security override " + string(e.Decision)
+	}
+	return strings.TrimSpace(e.Resource + " " + e.Action)
+}
+
+// target renders what was acted on, falling back to the agent a security
+// decision was made for.
+func target(e audit.Entry) string {
+	switch {
+	case e.Target != "":
+		return e.Target
+	case e.Agent != "":
+		return e.Project + "." + e.Agent
+	default:
+		return e.Project
+	}
+}
+
+// details renders an operation's parameters as sorted key=value pairs, or the
+// settings a security decision covered.
+func details(e audit.Entry) string {
+	var parts []string
+	for _, k := range slices.Sorted(maps.Keys(e.Params)) {
+		parts = append(parts, k+"="+e.Params[k])
+	}
+	for _, c := range e.Changes {
+		parts = append(parts, c.Setting+"="+c.Effective)
+	}
+	return strings.Join(parts, " ")
+}
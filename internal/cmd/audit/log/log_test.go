@@ -0,0 +1,121 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/audit"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+var testNow = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func newOpts(t *testing.T, format *cmdutil.FormatFlags) (*LogOptions, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	dir := t.TempDir()
+	cli := filepath.Join(dir, "audit.log")
+	cp := filepath.Join(dir, "controlplane-audit.log")
+	require.NoError(t, audit.AppendTo(cli, audit.Entry{
+		Time: testNow.Add(-48 * time.Hour), Event: audit.EventOperation, Actor: audit.ActorCLI,
+		Action: "remove", Resource: "volume", Target: "clawker.app.old-config",
+	}))
+	require.NoError(t, audit.AppendTo(cli, audit.Entry{
+		Time: testNow.Add(-2 * time.Hour), Event: audit.EventOperation, Actor: audit.ActorCLI,
+		Action: "create", Resource: "container", Target: "clawker.app.dev",
+		Params: map[string]string{"image": "clawker-app:latest"},
+	}))
+	require.NoError(t, audit.AppendTo(cli, audit.Entry{
+		Time: testNow.Add(-90 * time.Minute), Event: audit.EventSecurityOverride, Decision: audit.DecisionConfirmed,
+		Project: "app", Agent: "dev",
+		Changes: []audit.Change{{Setting: "privileged", Config: "false", Effective: "true", Flag: "--privileged"}},
+	}))
+	require.NoError(t, audit.AppendTo(cp, audit.Entry{
+		Time: testNow.Add(-time.Hour), Event: audit.EventOperation, Actor: audit.ActorControlPlane,
+		Action: "bypass", Resource: "firewall", Target: "abc123",
+		Params: map[string]string{"timeout": "5m0s"},
+	}))
+
+	ios, _, out, errOut := iostreams.Test()
+	return &LogOptions{
+		IOStreams: ios,
+		TUI:       tui.NewTUI(ios),
+		Paths:     func() ([]string, error) { return []string{cli, cp}, nil },
+		Format:    format,
+		Since:     "24h",
+		now:       func() time.Time { return testNow },
+	}, out, errOut
+}
+
+func TestLogRun_Table(t *testing.T) {
+	opts, out, _ := newOpts(t, &cmdutil.FormatFlags{})
+	require.NoError(t, logRun(context.Background(), opts))
+	assert.Contains(t, out.String(), "OPERATION")
+	assert.Contains(t, out.String(), "container create")
+	assert.Contains(t, out.String(), "image=clawker-app:latest")
+	assert.Contains(t, out.String(), "security override confirmed")
+	assert.Contains(t, out.String(), "app.dev")
+	assert.Contains(t, out.String(), "firewall bypass")
+	assert.NotContains(t, out.String(), "old-config")
+}
+
+func TestLogRun_Actor(t *testing.T) {
+	opts, out, _ := newOpts(t, &cmdutil.FormatFlags{Quiet: true})
+	opts.Actor = "controlplane"
+	require.NoError(t, logRun(context.Background(), opts))
+	assert.Equal(t, "firewall bypass\n", out.String())
+}
+
+func TestLogRun_JSON(t *testing.T) {
+	format, err := cmdutil.ParseFormat("json")
+	require.NoError(t, err)
+	opts, out, _ := newOpts(t, &cmdutil.FormatFlags{Format: format})
+	opts.Since = "7d"
+	require.NoError(t, logRun(context.Background(), opts))
+
+	var got []audit.Entry
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	require.Len(t, got, 4)
+	assert.Equal(t, "clawker.app.old-config", got[0].Target)
+	assert.Equal(t, audit.ActorControlPlane, got[3].Actor)
+}
+
+func TestLogRun_Empty(t *testing.T) {
+	opts, out, errOut := newOpts(t, &cmdutil.FormatFlags{})
+	opts.Since = "30m"
+	require.NoError(t, logRun(context.Background(), opts))
+	assert.Empty(t, out.String())
+	assert.Contains(t, errOut.String(), "No audit entries since 2026-03-10T11:30:00Z")
+}
+
+func TestNewCmdLog_FlagErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "bad since", args: []string{"--since", "yesterday"}, wantErr: "invalid --since"},
+		{name: "bad actor", args: []string{"--actor", "daemon"}, wantErr: `invalid --actor "daemon"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf := cmdutiltest.NewFactory(t)
+			cmd := NewCmdLog(tf.Factory, func(context.Context, *LogOptions) error { return nil })
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
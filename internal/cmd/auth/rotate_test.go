@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/testenv"
 )
@@ -55,8 +56,7 @@ func TestRotateCommand_RunF(t *testing.T) {
 		return nil
 	}
 
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	cmd := NewCmdRotate(f, runF)
 	cmd.SetArgs([]string{"--force"})
@@ -18,6 +18,7 @@ import (
 
 	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/hostproxy"
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/socketbridge"
 )
@@ -65,7 +66,8 @@ func NewCmdBridgeServe() *cobra.Command {
 			// on an O_APPEND descriptor, so concurrent daemons never shear each
 			// other's lines; rotation is owned by the host-side Manager.
 			log := logger.Nop()
-			if cfg, cfgErr := config.NewConfig(); cfgErr == nil {
+			cfg, cfgErr := config.NewConfig()
+			if cfgErr == nil {
 				if logsDir, dirErr := cfg.LogsSubdir(); dirErr == nil {
 					logPath := filepath.Join(logsDir, consts.SocketBridgeLogFile)
 					if f, fErr := logger.OpenAppend(logPath); fErr == nil {
@@ -100,6 +102,34 @@ func NewCmdBridgeServe() *cobra.Command {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
+			// Meter forwarded traffic: the cap is enforced here, where the
+			// bytes flow; the deltas are flushed to the host proxy for
+			// /metrics and `clawker host-proxy stats`. Without config there
+			// is no cap and no known proxy port, so accounting is skipped.
+			if cfgErr == nil {
+				hpCfg := cfg.HostProxyConfig()
+				meter := hostproxy.NewTrafficMeter(hpCfg.ForwardCapBytesPerSec())
+				bridge.SetTrafficMeter(meter)
+				reporterDone := make(chan struct{})
+				go func() {
+					defer close(reporterDone)
+					// Reports are signed with the host-only report key; without
+					// it the cap still applies but nothing is reported.
+					identity, err := bridgeIdentity(cfg, containerID)
+					if err != nil {
+						log.Warn().Err(err).Msg("traffic reporting disabled")
+						return
+					}
+					hostproxy.RunTrafficReporter(ctx, meter, hpCfg.Manager.Port, identity, consts.HostProxyTrafficReportInterval, log)
+				}()
+				// Deferred after cancel so it runs first: stop the reporter
+				// and wait for its final flush before the daemon exits.
+				defer func() {
+					cancel()
+					<-reporterDone
+				}()
+			}
+
 			sigCh := make(chan os.Signal, 1)
 			signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
 			go func() {
@@ -161,6 +191,20 @@ type dockerEventsClient interface {
 	Close() error
 }
 
+// bridgeIdentity loads the host-only traffic report key from the bridges
+// directory and returns this bridge's signed identity.
+func bridgeIdentity(cfg config.Config, containerID string) (hostproxy.BridgeIdentity, error) {
+	dir, err := cfg.BridgesSubdir()
+	if err != nil {
+		return hostproxy.BridgeIdentity{}, fmt.Errorf("resolving bridges directory: %w", err)
+	}
+	key, err := hostproxy.LoadOrCreateReportKey(filepath.Join(dir, consts.HostProxyReportKeyFile))
+	if err != nil {
+		return hostproxy.BridgeIdentity{}, err
+	}
+	return hostproxy.NewBridgeIdentity(key, containerID), nil
+}
+
 // watchContainerEvents subscribes to Docker events for the given container and
 // calls onDeath when a "die" event is received. It blocks until one of:
 //   - a "die" event fires (calls onDeath, returns nil)
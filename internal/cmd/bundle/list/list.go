@@ -127,7 +127,7 @@ func renderRows(opts *ListOptions, statuses []bundle.Status) error {
 		}
 		return nil
 	case opts.Format.IsJSON():
-		if err := cmdutil.WriteJSON(ios.Out, rows); err != nil {
+		if err := opts.Format.WriteJSON(ios, "bundle.list", rows); err != nil {
 			return fmt.Errorf("writing json: %w", err)
 		}
 		return nil
@@ -134,7 +134,7 @@ func TestBundleList_JSON(t *testing.T) {
 	f, out, _ := newFactory(t, cfg)
 
 	cmd := listcmd.NewCmdList(f, nil)
-	cmd.SetArgs([]string{"--json"})
+	cmd.SetArgs([]string{"--format", "json"})
 	require.NoError(t, cmd.Execute())
 
 	s := strings.TrimSpace(out.String())
@@ -0,0 +1,45 @@
+package config
+
+import (
+	configedit "github.com/schmitthub/clawker/internal/cmd/config/edit"
+	configget "github.com/schmitthub/clawker/internal/cmd/config/get"
+	configlist "github.com/schmitthub/clawker/internal/cmd/config/list"
+	configprofile "github.com/schmitthub/clawker/internal/cmd/config/profile"
+	configset "github.com/schmitthub/clawker/internal/cmd/config/set"
+	configtheme "github.com/schmitthub/clawker/internal/cmd/config/theme"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdConfig creates the `clawker config` parent command.
+func NewCmdConfig(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Read and write clawker configuration",
+		Long: `Read and write clawker configuration from the command line.
+
+Keys are dotted paths into one of three scopes: project (clawker.yaml),
+settings (settings.yaml), or registry (the project registry, read-only).
+Unqualified keys resolve to their scope automatically.`,
+		Example: `  # Print a value
+  clawker config get build.packages
+
+  # Set a value
+  clawker config set logging.max_size_mb 100
+
+  # List everything with its source file
+  clawker config list
+
+  # Open the interactive editor
+  clawker config edit --scope settings`,
+	}
+
+	cmd.AddCommand(configget.NewCmdGet(f, nil))
+	cmd.AddCommand(configset.NewCmdSet(f, nil))
+	cmd.AddCommand(configlist.NewCmdList(f, nil))
+	cmd.AddCommand(configedit.NewCmdEdit(f, nil))
+	cmd.AddCommand(configprofile.NewCmdProfile(f))
+	cmd.AddCommand(configtheme.NewCmdTheme(f))
+
+	return cmd
+}
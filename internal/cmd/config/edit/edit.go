@@ -0,0 +1,91 @@
+package edit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/schmitthub/clawker/internal/cmd/config/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	projectui "github.com/schmitthub/clawker/internal/config/storeui/project"
+	settingsui "github.com/schmitthub/clawker/internal/config/storeui/settings"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/storeui"
+	"github.com/spf13/cobra"
+)
+
+// EditOptions holds dependencies for the config edit command.
+type EditOptions struct {
+	IOStreams *iostreams.IOStreams
+	Config    func() (config.Config, error)
+
+	Scope string
+}
+
+// NewCmdEdit creates the `clawker config edit` command.
+func NewCmdEdit(f *cmdutil.Factory, runF func(context.Context, *EditOptions) error) *cobra.Command {
+	opts := &EditOptions{
+		IOStreams: f.IOStreams,
+		Config:    f.Config,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Interactively edit configuration",
+		Long: `Opens the interactive configuration editor for one scope.
+
+--scope project (the default) edits clawker.yaml, the same editor as
+'clawker project edit'; --scope settings edits settings.yaml, the same
+editor as 'clawker settings edit'. The registry is not editable here.`,
+		Example: `  # Edit project configuration
+  clawker config edit
+
+  # Edit user settings
+  clawker config edit --scope settings`,
+		Args: cmdutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return editRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Scope, "scope", string(shared.ScopeProject), "Scope to edit: project or settings")
+
+	return cmd
+}
+
+func editRun(_ context.Context, opts *EditOptions) error {
+	scope, err := shared.ParseScope(opts.Scope)
+	if err != nil {
+		return cmdutil.FlagErrorWrap(err)
+	}
+	if scope == shared.ScopeRegistry {
+		return cmdutil.FlagErrorf("the registry is not editable here: use 'clawker project' commands")
+	}
+
+	cfg, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	var result storeui.Result
+	noun := "Project configuration"
+	if scope == shared.ScopeSettings {
+		noun = "Settings"
+		result, err = settingsui.Edit(opts.IOStreams, cfg.SettingsStore())
+	} else {
+		result, err = projectui.Edit(opts.IOStreams, cfg, cfg.ProjectStore())
+	}
+	if err != nil {
+		return err
+	}
+
+	if result.Saved {
+		cs := opts.IOStreams.ColorScheme()
+		fmt.Fprintf(opts.IOStreams.Out, "%s %s saved (%d fields modified)\n",
+			cs.SuccessIcon(), noun, result.SavedCount)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package edit
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdEdit_RejectsArgs(t *testing.T) {
+	cmd := NewCmdEdit(cmdutiltest.NewFactory(t).Factory, nil)
+	cmd.SetArgs([]string{"extra"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+}
+
+func TestNewCmdEdit_RunFInjection(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantScope string
+	}{
+		{name: "default scope", args: []string{}, wantScope: "project"},
+		{name: "settings scope", args: []string{"--scope", "settings"}, wantScope: "settings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var captured *EditOptions
+
+			tf := cmdutiltest.NewFactory(t)
+			cmd := NewCmdEdit(tf.Factory, func(_ context.Context, opts *EditOptions) error {
+				captured = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			require.NoError(t, err)
+			require.NotNil(t, captured)
+			assert.Equal(t, tf.IOStreams, captured.IOStreams)
+			assert.Equal(t, tt.wantScope, captured.Scope)
+		})
+	}
+}
+
+func TestEditRun_RejectsBadScopes(t *testing.T) {
+	tests := []struct {
+		scope   string
+		wantErr string
+	}{
+		{scope: "registry", wantErr: "not editable"},
+		{scope: "global", wantErr: "invalid scope"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			tio, _, _, _ := iostreams.Test()
+			err := editRun(context.Background(), &EditOptions{IOStreams: tio, Scope: tt.scope})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package get
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/schmitthub/clawker/internal/cmd/config/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// GetOptions holds dependencies for the config get command.
+type GetOptions struct {
+	IOStreams      *iostreams.IOStreams
+	Config         func() (config.Config, error)
+	ProjectManager func() (project.ProjectManager, error)
+
+	Key  string
+	JSON bool
+}
+
+// NewCmdGet creates the `clawker config get` command.
+func NewCmdGet(f *cmdutil.Factory, runF func(context.Context, *GetOptions) error) *cobra.Command {
+	opts := &GetOptions{
+		IOStreams:      f.IOStreams,
+		Config:         f.Config,
+		ProjectManager: f.ProjectManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the effective value of a configuration key",
+		Long: `Prints the effective (merged) value of a configuration key.
+
+Keys are dotted paths. An unqualified key is resolved against the project
+(clawker.yaml) and settings (settings.yaml) schemas automatically; prefix it
+with project., settings., or registry. to pick a scope explicitly.
+
+Scalars print as-is; lists and mappings print as YAML.`,
+		Example: `  # Print the project's apt packages
+  clawker config get build.packages
+
+  # Print a settings value
+  clawker config get logging.max_size_mb
+
+  # Print a registered project's root
+  clawker config get registry.projects.my-app.root
+
+  # Machine-readable output
+  clawker config get security.firewall.add_domains --json`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Key = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return getRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output the key and value as versioned JSON envelope")
+	cmdutil.EnableJSONOutput(cmd)
+
+	return cmd
+}
+
+func getRun(ctx context.Context, opts *GetOptions) error {
+	key, err := shared.ParseNamespacedKey(opts.Key)
+	if err != nil {
+		return cmdutil.FlagErrorWrap(err)
+	}
+
+	value, found, err := resolve(ctx, opts, key)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%s is not set", key)
+	}
+
+	ios := opts.IOStreams
+	if opts.JSON {
+		return ios.JSONPrinter().Print("config.value", valueDoc{Key: key.String(), Value: value})
+	}
+	out, err := shared.FormatBlock(value)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(ios.Out, out)
+	return nil
+}
+
+// valueDoc is the --json document: the resolved key and its value.
+type valueDoc struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+func resolve(ctx context.Context, opts *GetOptions, key shared.NamespacedKey) (any, bool, error) {
+	var v any
+	switch key.Scope {
+	case shared.ScopeRegistry:
+		pm, err := opts.ProjectManager()
+		if err != nil {
+			return nil, false, fmt.Errorf("loading project manager: %w", err)
+		}
+		entries, err := pm.List(ctx)
+		if err != nil {
+			return nil, false, fmt.Errorf("listing registered projects: %w", err)
+		}
+		v, found := shared.Lookup(shared.RegistryView(entries), key.Path)
+		return v, found, nil
+	case shared.ScopeSettings:
+		cfg, err := opts.Config()
+		if err != nil {
+			return nil, false, err
+		}
+		found, err := cfg.SettingsStore().Get(key.Path, &v)
+		return v, found, err
+	default:
+		cfg, err := opts.Config()
+		if err != nil {
+			return nil, false, err
+		}
+		found, err := cfg.ProjectStore().Get(key.Path, &v)
+		return v, found, err
+	}
+}
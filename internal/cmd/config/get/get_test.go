@@ -0,0 +1,124 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tier 1: Flag parsing tests ---
+
+func TestNewCmdGet(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantKey  string
+		wantJSON bool
+		wantErr  bool
+	}{
+		{name: "key", input: "build.packages", wantKey: "build.packages"},
+		{name: "json", input: "agent.env --json", wantKey: "agent.env", wantJSON: true},
+		{name: "no args", input: "", wantErr: true},
+		{name: "too many args", input: "a b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *GetOptions
+			cmd := NewCmdGet(f, func(_ context.Context, opts *GetOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, gotOpts)
+			assert.Equal(t, tt.wantKey, gotOpts.Key)
+			assert.Equal(t, tt.wantJSON, gotOpts.JSON)
+		})
+	}
+}
+
+// --- Tier 2: Run function tests ---
+
+func newOpts(t *testing.T, key string) (*GetOptions, *bytes.Buffer) {
+	t.Helper()
+	ios, _, outBuf, _ := iostreams.Test()
+	cfg := configmocks.NewFromString(`
+build:
+  packages: [git, ripgrep]
+agent:
+  editor: vim
+`, `
+logging:
+  max_size_mb: 42
+`)
+	mgr := projectmocks.NewMockProjectManager()
+	mgr.ListFunc = func(_ context.Context) ([]project.ProjectEntry, error) {
+		return []project.ProjectEntry{{Name: "app", Root: "/src/app"}}, nil
+	}
+	return &GetOptions{
+		IOStreams:      ios,
+		Config:         func() (config.Config, error) { return cfg, nil },
+		ProjectManager: func() (project.ProjectManager, error) { return mgr, nil },
+		Key:            key,
+	}, outBuf
+}
+
+func TestGetRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		json    bool
+		want    string
+		wantErr string
+	}{
+		{name: "project scalar", key: "agent.editor", want: "vim\n"},
+		{name: "project list as yaml", key: "build.packages", want: "- git\n- ripgrep\n"},
+		{name: "project list as json", key: "build.packages", json: true, want: `{"schema_version":1,"kind":"config.value","data":{"key":"project.build.packages","value":["git","ripgrep"]}}` + "\n"},
+		{name: "settings int", key: "logging.max_size_mb", want: "42\n"},
+		{name: "qualified settings", key: "settings.logging.max_size_mb", want: "42\n"},
+		{name: "registry root", key: "registry.projects.app.root", want: "/src/app\n"},
+		{name: "registry missing project", key: "registry.projects.other.root", wantErr: "registry.projects.other.root is not set"},
+		{name: "unknown key", key: "nope", wantErr: "unknown config key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, outBuf := newOpts(t, tt.key)
+			opts.JSON = tt.json
+
+			err := getRun(context.Background(), opts)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, outBuf.String())
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/schmitthub/clawker/internal/cmd/config/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// ListOptions holds dependencies for the config list command.
+type ListOptions struct {
+	IOStreams      *iostreams.IOStreams
+	TUI            *tui.TUI
+	Config         func() (config.Config, error)
+	ProjectManager func() (project.ProjectManager, error)
+	Format         *cmdutil.FormatFlags
+
+	Scope string
+}
+
+// NewCmdList creates the `clawker config list` command.
+func NewCmdList(f *cmdutil.Factory, runF func(context.Context, *ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IOStreams:      f.IOStreams,
+		TUI:            f.TUI,
+		Config:         f.Config,
+		ProjectManager: f.ProjectManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List effective configuration values",
+		Long: `Lists every configuration key that has a value, with the file that
+provides it.
+
+Project keys come from the merged clawker.yaml layers, settings keys from
+settings.yaml, and registry keys from the project registry. Values no file
+sets are shown with source "default".`,
+		Example: `  # List everything
+  clawker config list
+
+  # Only project configuration
+  clawker config list --scope project
+
+  # Keys only
+  clawker config list -q
+
+  # Output as JSON
+  clawker config list --scope settings --json`,
+		Args: cmdutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Scope, "scope", "", "Limit to one scope: project, settings, or registry")
+	opts.Format = cmdutil.AddFormatFlags(cmd)
+	cmd.Flags().Lookup("quiet").Usage = "Only display keys"
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	scope, err := shared.ParseScope(opts.Scope)
+	if err != nil {
+		return cmdutil.FlagErrorWrap(err)
+	}
+
+	entries, err := collect(ctx, opts, scope)
+	if err != nil {
+		return err
+	}
+
+	ios := opts.IOStreams
+	switch {
+	case opts.Format.Quiet:
+		for _, e := range entries {
+			fmt.Fprintln(ios.Out, e.Key)
+		}
+		return nil
+
+	case opts.Format.IsJSON():
+		if entries == nil {
+			entries = []shared.Entry{}
+		}
+		return opts.Format.WriteJSON(ios, "config.list", entries)
+
+	case opts.Format.IsTemplate():
+		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(entries))
+
+	default:
+		if len(entries) == 0 {
+			fmt.Fprintln(ios.ErrOut, "No configuration values found.")
+			return nil
+		}
+		tp := opts.TUI.NewTable("KEY", "VALUE", "SOURCE")
+		cs := ios.ColorScheme()
+		for _, e := range entries {
+			val, err := shared.FormatInline(e.Value)
+			if err != nil {
+				return err
+			}
+			source := e.Source
+			switch source {
+			case "":
+				source = cs.Muted("merged")
+			case shared.SourceDefault:
+				source = cs.Muted(source)
+			}
+			tp.AddRow(e.Key, val, source)
+		}
+		return tp.Render()
+	}
+}
+
+func collect(ctx context.Context, opts *ListOptions, scope shared.Scope) ([]shared.Entry, error) {
+	var entries []shared.Entry
+	if scope == "" || scope == shared.ScopeProject || scope == shared.ScopeSettings {
+		cfg, err := opts.Config()
+		if err != nil {
+			return nil, err
+		}
+		if scope == "" || scope == shared.ScopeProject {
+			projectEntries, err := shared.StoreEntries(shared.ScopeProject, cfg.ProjectStore())
+			if err != nil {
+				return nil, fmt.Errorf("reading project config: %w", err)
+			}
+			entries = append(entries, projectEntries...)
+		}
+		if scope == "" || scope == shared.ScopeSettings {
+			settingsEntries, err := shared.StoreEntries(shared.ScopeSettings, cfg.SettingsStore())
+			if err != nil {
+				return nil, fmt.Errorf("reading settings: %w", err)
+			}
+			entries = append(entries, settingsEntries...)
+		}
+	}
+	if scope == "" || scope == shared.ScopeRegistry {
+		pm, err := opts.ProjectManager()
+		if err != nil {
+			return nil, fmt.Errorf("loading project manager: %w", err)
+		}
+		registered, err := pm.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing registered projects: %w", err)
+		}
+		entries = append(entries, shared.ViewEntries(shared.ScopeRegistry, shared.RegistryView(registered))...)
+	}
+	return entries, nil
+}
@@ -0,0 +1,155 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/schmitthub/clawker/internal/cmd/config/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tier 1: Flag parsing tests ---
+
+func TestNewCmdList(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantScope string
+		wantQuiet bool
+		wantErr   string
+	}{
+		{name: "no flags", input: ""},
+		{name: "scope", input: "--scope settings", wantScope: "settings"},
+		{name: "quiet", input: "-q", wantQuiet: true},
+		{name: "json", input: "--json"},
+		{name: "quiet and json mutually exclusive", input: "-q --json", wantErr: "--quiet and --format/--json are mutually exclusive"},
+		{name: "positional arg rejected", input: "extra", wantErr: "accepts no arguments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *ListOptions
+			cmd := NewCmdList(f, func(_ context.Context, opts *ListOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, gotOpts)
+			assert.Equal(t, tt.wantScope, gotOpts.Scope)
+			assert.Equal(t, tt.wantQuiet, gotOpts.Format.Quiet)
+		})
+	}
+}
+
+// --- Tier 2: Run function tests ---
+
+func newOpts(format *cmdutil.FormatFlags, scope string) (*ListOptions, *bytes.Buffer, *bytes.Buffer) {
+	ios, _, outBuf, errBuf := iostreams.Test()
+	cfg := configmocks.NewFromString(`
+build:
+  packages: [git]
+agent:
+  editor: vim
+`, `
+firewall:
+  enable: false
+`)
+	mgr := projectmocks.NewMockProjectManager()
+	mgr.ListFunc = func(_ context.Context) ([]project.ProjectEntry, error) {
+		return []project.ProjectEntry{{Name: "app", Root: "/src/app"}}, nil
+	}
+	return &ListOptions{
+		IOStreams:      ios,
+		TUI:            tui.NewTUI(ios),
+		Config:         func() (config.Config, error) { return cfg, nil },
+		ProjectManager: func() (project.ProjectManager, error) { return mgr, nil },
+		Format:         format,
+		Scope:          scope,
+	}, outBuf, errBuf
+}
+
+func TestListRun_Table(t *testing.T) {
+	opts, outBuf, _ := newOpts(&cmdutil.FormatFlags{}, "")
+
+	err := listRun(context.Background(), opts)
+	require.NoError(t, err)
+
+	output := outBuf.String()
+	assert.Contains(t, output, "KEY")
+	assert.Contains(t, output, "SOURCE")
+	assert.Contains(t, output, "project.build.packages")
+	assert.Contains(t, output, `["git"]`)
+	assert.Contains(t, output, "settings.firewall.enable")
+	assert.Contains(t, output, "registry.projects.app.root")
+}
+
+func TestListRun_Scope(t *testing.T) {
+	opts, outBuf, _ := newOpts(&cmdutil.FormatFlags{Quiet: true}, "project")
+
+	err := listRun(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "project.build.packages\nproject.agent.editor\n", outBuf.String())
+}
+
+func TestListRun_InvalidScope(t *testing.T) {
+	opts, _, _ := newOpts(&cmdutil.FormatFlags{}, "global")
+
+	err := listRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid scope")
+}
+
+func TestListRun_JSON(t *testing.T) {
+	format, err := cmdutil.ParseFormat("json")
+	require.NoError(t, err)
+	opts, outBuf, _ := newOpts(&cmdutil.FormatFlags{Format: format}, "registry")
+
+	err = listRun(context.Background(), opts)
+	require.NoError(t, err)
+
+	var got []shared.Entry
+	require.NoError(t, json.Unmarshal(outBuf.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "registry.projects.app.root", got[0].Key)
+	assert.Equal(t, "/src/app", got[0].Value)
+	assert.Equal(t, shared.ScopeRegistry, got[0].Scope)
+}
+
+func TestListRun_Empty(t *testing.T) {
+	opts, outBuf, errBuf := newOpts(&cmdutil.FormatFlags{}, "registry")
+	mgr := projectmocks.NewMockProjectManager()
+	opts.ProjectManager = func() (project.ProjectManager, error) { return mgr, nil }
+
+	err := listRun(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Empty(t, outBuf.String())
+	assert.Contains(t, errBuf.String(), "No configuration values found")
+}
@@ -0,0 +1,126 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// ListOptions holds dependencies for the config profile list command.
+type ListOptions struct {
+	IOStreams *iostreams.IOStreams
+	TUI       *tui.TUI
+	Config    func() (config.Config, error)
+	// Active returns the profile selected with --profile, read at run time
+	// because the flag is parsed after the command is built.
+	Active func() string
+	Format *cmdutil.FormatFlags
+}
+
+// profileEntry is one row of the listing.
+type profileEntry struct {
+	Name   string   `json:"name"`
+	Blocks []string `json:"blocks"`
+	Active bool     `json:"active"`
+}
+
+// NewCmdList creates the `clawker config profile list` command.
+func NewCmdList(f *cmdutil.Factory, runF func(context.Context, *ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IOStreams: f.IOStreams,
+		TUI:       f.TUI,
+		Config:    f.Config,
+		Active:    func() string { return f.Profile },
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List config profiles",
+		Long: `Lists the profiles declared under profiles: in the merged clawker.yaml
+layers, with the config blocks each one overrides. The profile selected
+with --profile is marked active.`,
+		Example: `  # List profiles
+  clawker config profile list
+
+  # Names only
+  clawker config profile list -q
+
+  # Output as JSON
+  clawker config profile list --json`,
+		Args: cmdutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	opts.Format = cmdutil.AddFormatFlags(cmd)
+	cmd.Flags().Lookup("quiet").Usage = "Only display profile names"
+
+	return cmd
+}
+
+func listRun(_ context.Context, opts *ListOptions) error {
+	cfg, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	active := opts.Active()
+	entries := []profileEntry{}
+	for _, name := range config.ProfileNames(cfg) {
+		var block map[string]any
+		if _, err := cfg.ProjectStore().Get(config.ProfilePath(name), &block); err != nil {
+			return fmt.Errorf("reading profile %s: %w", name, err)
+		}
+		// Non-nil so an empty profile encodes as [] rather than null.
+		blocks := append([]string{}, slices.Sorted(maps.Keys(block))...)
+		entries = append(entries, profileEntry{Name: name, Blocks: blocks, Active: name == active})
+	}
+
+	ios := opts.IOStreams
+	switch {
+	case opts.Format.Quiet:
+		for _, e := range entries {
+			fmt.Fprintln(ios.Out, e.Name)
+		}
+		return nil
+
+	case opts.Format.IsJSON():
+		return opts.Format.WriteJSON(ios, "config.profile.list", entries)
+
+	case opts.Format.IsTemplate():
+		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(entries))
+
+	default:
+		if len(entries) == 0 {
+			fmt.Fprintln(ios.ErrOut, "No profiles defined. Add a profiles: block to clawker.yaml.")
+			return nil
+		}
+		tp := opts.TUI.NewTable("NAME", "OVERRIDES", "ACTIVE")
+		cs := ios.ColorScheme()
+		for _, e := range entries {
+			blocks := strings.Join(e.Blocks, ", ")
+			if blocks == "" {
+				blocks = cs.Muted("(none)")
+			}
+			mark := ""
+			if e.Active {
+				mark = cs.SuccessIcon()
+			}
+			tp.AddRow(e.Name, blocks, mark)
+		}
+		return tp.Render()
+	}
+}
@@ -0,0 +1,138 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tier 1: Flag parsing tests ---
+
+func TestNewCmdList(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantQuiet bool
+		wantErr   string
+	}{
+		{name: "no flags", input: ""},
+		{name: "quiet", input: "-q", wantQuiet: true},
+		{name: "json", input: "--json"},
+		{name: "positional arg rejected", input: "ci", wantErr: "accepts no arguments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+			f.Profile = "ci"
+
+			var gotOpts *ListOptions
+			cmd := NewCmdList(f, func(_ context.Context, opts *ListOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, gotOpts)
+			assert.Equal(t, tt.wantQuiet, gotOpts.Format.Quiet)
+			assert.Equal(t, "ci", gotOpts.Active())
+		})
+	}
+}
+
+// --- Tier 2: Run function tests ---
+
+const profilesYAML = `
+build:
+  packages: [git]
+profiles:
+  ci:
+    workspace:
+      default_mode: snapshot
+    security:
+      firewall:
+        add_domains: [ci.example.com]
+  local: {}
+`
+
+func newOpts(projectYAML string, format *cmdutil.FormatFlags, active string) (*ListOptions, *bytes.Buffer, *bytes.Buffer) {
+	ios, _, outBuf, errBuf := iostreams.Test()
+	cfg := configmocks.NewFromString(projectYAML, "")
+	return &ListOptions{
+		IOStreams: ios,
+		TUI:       tui.NewTUI(ios),
+		Config:    func() (config.Config, error) { return cfg, nil },
+		Active:    func() string { return active },
+		Format:    format,
+	}, outBuf, errBuf
+}
+
+func TestListRun_Table(t *testing.T) {
+	opts, outBuf, _ := newOpts(profilesYAML, &cmdutil.FormatFlags{}, "ci")
+
+	err := listRun(context.Background(), opts)
+	require.NoError(t, err)
+
+	output := outBuf.String()
+	assert.Contains(t, output, "NAME")
+	assert.Contains(t, output, "OVERRIDES")
+	assert.Contains(t, output, "security, workspace")
+	assert.Contains(t, output, "(none)")
+}
+
+func TestListRun_Quiet(t *testing.T) {
+	opts, outBuf, _ := newOpts(profilesYAML, &cmdutil.FormatFlags{Quiet: true}, "")
+
+	err := listRun(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "ci\nlocal\n", outBuf.String())
+}
+
+func TestListRun_JSON(t *testing.T) {
+	format, err := cmdutil.ParseFormat("json")
+	require.NoError(t, err)
+	opts, outBuf, _ := newOpts(profilesYAML, &cmdutil.FormatFlags{Format: format}, "local")
+
+	err = listRun(context.Background(), opts)
+	require.NoError(t, err)
+
+	var got []profileEntry
+	require.NoError(t, json.Unmarshal(outBuf.Bytes(), &got))
+	assert.Equal(t, []profileEntry{
+		{Name: "ci", Blocks: []string{"security", "workspace"}},
+		{Name: "local", Blocks: []string{}, Active: true},
+	}, got)
+}
+
+func TestListRun_Empty(t *testing.T) {
+	opts, outBuf, errBuf := newOpts("build:\n  packages: [git]\n", &cmdutil.FormatFlags{}, "")
+
+	err := listRun(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Empty(t, outBuf.String())
+	assert.Contains(t, errBuf.String(), "No profiles defined")
+}
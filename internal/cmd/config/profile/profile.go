@@ -0,0 +1,29 @@
+package profile
+
+import (
+	"github.com/schmitthub/clawker/internal/cmd/config/profile/list"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdProfile creates the `clawker config profile` parent command.
+func NewCmdProfile(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Inspect project config profiles",
+		Long: `Inspect the named config profiles declared under profiles: in clawker.yaml.
+
+A profile is a partial project config. Selecting one with the global
+--profile flag deep-merges it over the base config for that invocation,
+before any per-agent override.`,
+		Example: `  # List the project's profiles
+  clawker config profile list
+
+  # Run with the ci profile applied
+  clawker --profile ci container run --agent dev @`,
+	}
+
+	cmd.AddCommand(list.NewCmdList(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,157 @@
+package set
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/schmitthub/clawker/internal/cmd/config/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+// SetOptions holds dependencies for the config set command.
+type SetOptions struct {
+	IOStreams *iostreams.IOStreams
+	Config    func() (config.Config, error)
+
+	Key     string
+	Value   string
+	Encrypt bool
+}
+
+// NewCmdSet creates the `clawker config set` command.
+func NewCmdSet(f *cmdutil.Factory, runF func(context.Context, *SetOptions) error) *cobra.Command {
+	opts := &SetOptions{
+		IOStreams: f.IOStreams,
+		Config:    f.Config,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a configuration key",
+		Long: `Sets a configuration key and persists it.
+
+The key's scope is resolved the same way as 'clawker config get'. The value
+is coerced to the field's type: booleans and integers parse strictly, lists
+accept "a,b,c" or a JSON/YAML sequence, and mappings take a JSON/YAML object.
+
+The write lands in the file that currently provides the key (so a value set
+in clawker.local.yaml stays there); a key no file sets yet is written to the
+highest-priority file in scope. Registry keys are read-only here — use the
+'clawker project' commands to change the registry.
+
+--encrypt stores a text value as ENC[age:...] ciphertext, encrypted to the
+identity in the settings encryption.identity_file and the project's
+encryption.recipients, so the file can be committed. Later writes to the key
+keep it encrypted.`,
+		Example: `  # Replace the project's apt packages
+  clawker config set build.packages git,ripgrep
+
+  # Flip a boolean
+  clawker config set security.docker_socket true
+
+  # Set one env var entry
+  clawker config set agent.env.LOG_LEVEL debug
+
+  # Set a settings value explicitly
+  clawker config set settings.logging.max_size_mb 100
+
+  # Store an API key encrypted
+  clawker config set --encrypt agent.env.API_KEY sk-...`,
+		Args: cmdutil.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Key = args[0]
+			opts.Value = args[1]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return setRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Encrypt, "encrypt", false, "Store the value encrypted with age")
+
+	return cmd
+}
+
+func setRun(_ context.Context, opts *SetOptions) error {
+	key, err := shared.ParseNamespacedKey(opts.Key)
+	if err != nil {
+		return cmdutil.FlagErrorWrap(err)
+	}
+	if key.Scope == shared.ScopeRegistry {
+		return fmt.Errorf("%s is read-only: use 'clawker project' commands to change the project registry", key)
+	}
+
+	value, err := shared.ParseValue(shared.LookupField(key.Scope, key.Path), opts.Value)
+	if err != nil {
+		return cmdutil.FlagErrorWrap(err)
+	}
+	if _, isText := value.(string); opts.Encrypt && !isText {
+		return cmdutil.FlagErrorf("--encrypt only applies to text values; %s is not one", key)
+	}
+
+	cfg, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	var source string
+	switch key.Scope {
+	case shared.ScopeSettings:
+		store := cfg.SettingsStore()
+		if value, err = sealValue(opts, store.Options().Cipher, value); err != nil {
+			return err
+		}
+		if err := store.Set(key.Path, value); err != nil {
+			return fmt.Errorf("setting %s: %w", key, err)
+		}
+		if err := store.Write(); err != nil {
+			return fmt.Errorf("writing %s: %w", key, err)
+		}
+		source = shared.StoreSource(store, key.Path)
+	default:
+		if err := config.ValidateProjectSet(key.Path, value); err != nil {
+			return fmt.Errorf("setting %s: %w", key, err)
+		}
+		store := cfg.ProjectStore()
+		if value, err = sealValue(opts, store.Options().Cipher, value); err != nil {
+			return err
+		}
+		if err := store.Set(key.Path, value); err != nil {
+			return fmt.Errorf("setting %s: %w", key, err)
+		}
+		if err := store.Write(); err != nil {
+			return fmt.Errorf("writing %s: %w", key, err)
+		}
+		source = shared.StoreSource(store, key.Path)
+	}
+
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+	if source == "" || source == shared.SourceDefault {
+		fmt.Fprintf(ios.Out, "%s Set %s\n", cs.SuccessIcon(), key)
+		return nil
+	}
+	fmt.Fprintf(ios.Out, "%s Set %s in %s\n", cs.SuccessIcon(), key, source)
+	return nil
+}
+
+// sealValue encrypts value with the store's cipher when --encrypt is set.
+// Project values are validated in plaintext before this runs.
+func sealValue(opts *SetOptions, cipher storage.Cipher, value any) (any, error) {
+	if !opts.Encrypt {
+		return value, nil
+	}
+	if cipher == nil {
+		return nil, fmt.Errorf("encrypting %s: this configuration does not support encrypted values", opts.Key)
+	}
+	sealed, err := cipher.Encrypt(value.(string))
+	if err != nil {
+		return nil, fmt.Errorf("encrypting %s: %w", opts.Key, err)
+	}
+	return sealed, nil
+}
@@ -0,0 +1,160 @@
+package set
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/google/shlex"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/testenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Tier 1: Flag parsing tests ---
+
+func TestNewCmdSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantKey   string
+		wantValue string
+		wantErr   bool
+	}{
+		{name: "key and value", input: "agent.editor vim", wantKey: "agent.editor", wantValue: "vim"},
+		{name: "quoted list", input: `build.packages "git, jq"`, wantKey: "build.packages", wantValue: "git, jq"},
+		{name: "missing value", input: "agent.editor", wantErr: true},
+		{name: "no args", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *SetOptions
+			cmd := NewCmdSet(f, func(_ context.Context, opts *SetOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, gotOpts)
+			assert.Equal(t, tt.wantKey, gotOpts.Key)
+			assert.Equal(t, tt.wantValue, gotOpts.Value)
+		})
+	}
+}
+
+// --- Tier 2: Run function tests ---
+
+func TestSetRun_Project(t *testing.T) {
+	cfg := configmocks.NewIsolatedTestConfig(t)
+	ios, _, outBuf, _ := iostreams.Test()
+
+	err := setRun(context.Background(), &SetOptions{
+		IOStreams: ios,
+		Config:    func() (config.Config, error) { return cfg, nil },
+		Key:       "build.packages",
+		Value:     "git,ripgrep",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, outBuf.String(), "Set project.build.packages")
+	assert.Equal(t, []string{"git", "ripgrep"}, cfg.Project().Build.Packages)
+}
+
+func TestSetRun_Settings(t *testing.T) {
+	cfg := configmocks.NewIsolatedTestConfig(t)
+	ios, _, outBuf, _ := iostreams.Test()
+
+	err := setRun(context.Background(), &SetOptions{
+		IOStreams: ios,
+		Config:    func() (config.Config, error) { return cfg, nil },
+		Key:       "logging.max_size_mb",
+		Value:     "75",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, outBuf.String(), "Set settings.logging.max_size_mb")
+	assert.Equal(t, 75, cfg.Settings().Logging.MaxSizeMB)
+}
+
+func TestSetRun_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		encrypt bool
+		wantErr string
+	}{
+		{name: "registry is read-only", key: "registry.projects.app.root", value: "/x", wantErr: "read-only"},
+		{name: "unknown key", key: "nope", value: "x", wantErr: "unknown config key"},
+		{name: "bad bool", key: "security.docker_socket", value: "maybe", wantErr: "expects a boolean"},
+		{name: "invalid harness entry", key: "harnesses.claude.kind", value: "bogus", wantErr: "harnesses.claude"},
+		{name: "encrypt non-text", key: "security.docker_socket", value: "true", encrypt: true, wantErr: "only applies to text values"},
+		{name: "encrypt without identity", key: "agent.env.API_KEY", value: "x", encrypt: true, wantErr: "no age recipients"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := configmocks.NewIsolatedTestConfig(t)
+			ios, _, _, _ := iostreams.Test()
+
+			err := setRun(context.Background(), &SetOptions{
+				IOStreams: ios,
+				Config:    func() (config.Config, error) { return cfg, nil },
+				Key:       tt.key,
+				Value:     tt.value,
+				Encrypt:   tt.encrypt,
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSetRun_Encrypt(t *testing.T) {
+	env := testenv.New(t)
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	keyPath := filepath.Join(env.Dirs.Base, "age.key")
+	require.NoError(t, os.WriteFile(keyPath, []byte(id.String()+"\n"), 0o600))
+	env.WriteYAML(t, testenv.Settings, "", "encryption:\n  identity_file: "+keyPath+"\n")
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+	ios, _, outBuf, _ := iostreams.Test()
+
+	err = setRun(context.Background(), &SetOptions{
+		IOStreams: ios,
+		Config:    func() (config.Config, error) { return cfg, nil },
+		Key:       "agent.env.API_KEY",
+		Value:     "sk-live-123",
+		Encrypt:   true,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, outBuf.String(), "Set project.agent.env.API_KEY")
+	assert.Equal(t, "sk-live-123", cfg.Project().Agent.Env["API_KEY"], "decrypted in the snapshot")
+
+	data, err := os.ReadFile(filepath.Join(consts.ConfigDir(), consts.ProjectConfigFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "API_KEY: ENC[age:")
+	assert.NotContains(t, string(data), "sk-live-123")
+}
@@ -0,0 +1,222 @@
+// Package shared holds domain logic used by multiple config subcommands:
+// namespaced key resolution, CLI value coercion against the schema, and the
+// flattened key/value views get and list render.
+package shared
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/storage"
+)
+
+// Scope names the configuration file family a key belongs to.
+type Scope string
+
+const (
+	// ScopeProject is clawker.yaml (walk-up + config-dir layers).
+	ScopeProject Scope = "project"
+	// ScopeSettings is the user settings.yaml in the config dir.
+	ScopeSettings Scope = "settings"
+	// ScopeRegistry is the project registry owned by internal/project.
+	// Read-only here: registry mutations go through the project commands.
+	ScopeRegistry Scope = "registry"
+)
+
+// Scopes lists every scope in display order.
+func Scopes() []Scope {
+	return []Scope{ScopeProject, ScopeSettings, ScopeRegistry}
+}
+
+// ParseScope validates a --scope flag value. Empty means "all scopes".
+func ParseScope(raw string) (Scope, error) {
+	if raw == "" {
+		return "", nil
+	}
+	s := Scope(raw)
+	if !slices.Contains(Scopes(), s) {
+		return "", fmt.Errorf("invalid scope %q: must be one of project, settings, registry", raw)
+	}
+	return s, nil
+}
+
+// NamespacedKey is a dotted config key bound to the scope that owns it.
+type NamespacedKey struct {
+	Scope Scope
+	Path  string
+}
+
+// String returns the fully-qualified key (scope.path).
+func (k NamespacedKey) String() string {
+	return string(k.Scope) + "." + k.Path
+}
+
+// ParseNamespacedKey resolves a user-supplied key to its scope. A key with an
+// explicit scope prefix (project.build.packages, settings.logging.max_size_mb,
+// registry.projects) is taken verbatim. An unqualified key is matched against
+// the Project and Settings schemas: it resolves to whichever schema has a
+// field at (or under) that path, and is rejected as ambiguous when both do.
+// Registry keys must always be qualified.
+func ParseNamespacedKey(raw string) (NamespacedKey, error) {
+	if raw == "" {
+		return NamespacedKey{}, fmt.Errorf("key must not be empty")
+	}
+	if slices.Contains(strings.Split(raw, "."), "") {
+		return NamespacedKey{}, fmt.Errorf("key %q has an empty segment", raw)
+	}
+	head, rest, hasRest := strings.Cut(raw, ".")
+	for _, s := range Scopes() {
+		if head != string(s) {
+			continue
+		}
+		if !hasRest {
+			return NamespacedKey{}, fmt.Errorf("key %q names a scope, not a key", raw)
+		}
+		return NamespacedKey{Scope: s, Path: rest}, nil
+	}
+
+	inProject := schemaHasPath(config.Project{}.Fields(), raw)
+	inSettings := schemaHasPath(config.Settings{}.Fields(), raw)
+	switch {
+	case inProject && inSettings:
+		return NamespacedKey{}, fmt.Errorf("key %q is ambiguous: qualify it as project.%s or settings.%s", raw, raw, raw)
+	case inProject:
+		return NamespacedKey{Scope: ScopeProject, Path: raw}, nil
+	case inSettings:
+		return NamespacedKey{Scope: ScopeSettings, Path: raw}, nil
+	}
+	return NamespacedKey{}, fmt.Errorf("unknown config key %q", raw)
+}
+
+// schemaHasPath reports whether path addresses a schema field, a struct group
+// above fields, or an entry inside a map-valued field (agent.env.FOO).
+func schemaHasPath(fields storage.FieldSet, path string) bool {
+	if fields.Get(path) != nil || len(fields.Group(path)) > 0 {
+		return true
+	}
+	for _, f := range fields.All() {
+		if isMapKind(f.Kind()) && strings.HasPrefix(path, f.Path()+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// structMapElems maps each struct-map field, by its path within the schema
+// block that declares it, to the field set of one entry. LookupField walks it
+// so a key inside a named entry (services.db.restart,
+// profiles.ci.build.packages) coerces like any other schema leaf.
+var structMapElems = map[string]func() storage.FieldSet{
+	config.AgentsKey:   func() storage.FieldSet { return storage.NormalizeFields(config.AgentOverride{}) },
+	config.ProfilesKey: func() storage.FieldSet { return storage.NormalizeFields(config.ProjectProfile{}) },
+	"services":         func() storage.FieldSet { return storage.NormalizeFields(config.ServiceConfig{}) },
+	"secrets":          func() storage.FieldSet { return storage.NormalizeFields(config.SecretConfig{}) },
+	"sidecars":         func() storage.FieldSet { return storage.NormalizeFields(config.SidecarConfig{}) },
+	"harnesses":        func() storage.FieldSet { return storage.NormalizeFields(config.HarnessConfig{}) },
+	"build.harnesses":  func() storage.FieldSet { return storage.NormalizeFields(config.HarnessBuildOverlay{}) },
+}
+
+// LookupField returns the schema field at path, or nil for paths that are not
+// schema leaves (struct groups, map entries, unknown keys). Paths inside a
+// named entry of a struct map — a per-agent override block
+// (agents.<name>.security.cap_add), a profile (profiles.<name>.build.packages),
+// a service or sidecar — resolve against the entry's own fields, so their
+// values coerce like the base keys.
+func LookupField(scope Scope, path string) storage.Field {
+	switch scope {
+	case ScopeProject:
+		return lookupIn(config.Project{}.Fields(), path)
+	case ScopeSettings:
+		return lookupIn(config.Settings{}.Fields(), path)
+	}
+	return nil
+}
+
+// lookupIn resolves path against fields, descending into struct-map entries.
+func lookupIn(fields storage.FieldSet, path string) storage.Field {
+	if f := fields.Get(path); f != nil {
+		return f
+	}
+	for _, f := range fields.All() {
+		rest, ok := strings.CutPrefix(path, f.Path()+".")
+		if !ok || f.Kind() != storage.KindStructMap {
+			continue
+		}
+		elem, known := structMapElems[f.Path()]
+		if _, sub, hasSub := strings.Cut(rest, "."); known && hasSub {
+			return lookupIn(elem(), sub)
+		}
+		return nil
+	}
+	return nil
+}
+
+// ParseValue coerces a CLI string into the Go value the store expects for the
+// field's kind: bools and ints parse strictly, string slices accept either a
+// comma-separated list or a YAML/JSON flow sequence, and map/struct kinds take
+// a YAML/JSON document. Text fields and paths without a schema field (entries
+// of a map[string]string such as agent.env.FOO) keep the raw string, so
+// "007", "0x10" and "false" are stored as typed. The store re-validates the
+// result against the typed schema on Set.
+func ParseValue(field storage.Field, raw string) (any, error) {
+	if field == nil {
+		return raw, nil
+	}
+	switch field.Kind() {
+	case storage.KindBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects a boolean, got %q", field.Path(), raw)
+		}
+		return b, nil
+	case storage.KindInt:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects an integer, got %q", field.Path(), raw)
+		}
+		return n, nil
+	case storage.KindStringSlice:
+		if strings.HasPrefix(strings.TrimSpace(raw), "[") {
+			var list []string
+			if err := yaml.Unmarshal([]byte(raw), &list); err != nil {
+				return nil, fmt.Errorf("%s expects a list of strings: %w", field.Path(), err)
+			}
+			return list, nil
+		}
+		list := []string{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		return list, nil
+	case storage.KindMap:
+		var m map[string]string
+		if err := yaml.Unmarshal([]byte(raw), &m); err != nil {
+			return nil, fmt.Errorf("%s expects a mapping (e.g. '{KEY: value}'): %w", field.Path(), err)
+		}
+		return m, nil
+	case storage.KindStructSlice:
+		var list []any
+		if err := yaml.Unmarshal([]byte(raw), &list); err != nil {
+			return nil, fmt.Errorf("%s expects a list: %w", field.Path(), err)
+		}
+		return list, nil
+	case storage.KindStructMap:
+		var m map[string]any
+		if err := yaml.Unmarshal([]byte(raw), &m); err != nil {
+			return nil, fmt.Errorf("%s expects a mapping: %w", field.Path(), err)
+		}
+		return m, nil
+	}
+	return raw, nil
+}
+
+func isMapKind(k storage.FieldKind) bool {
+	return k == storage.KindMap || k == storage.KindStructMap
+}
@@ -0,0 +1,128 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNamespacedKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    NamespacedKey
+		wantErr string
+	}{
+		{name: "unqualified project key", input: "build.packages", want: NamespacedKey{Scope: ScopeProject, Path: "build.packages"}},
+		{name: "unqualified settings key", input: "logging.max_size_mb", want: NamespacedKey{Scope: ScopeSettings, Path: "logging.max_size_mb"}},
+		{name: "project struct group", input: "security.firewall", want: NamespacedKey{Scope: ScopeProject, Path: "security.firewall"}},
+		{name: "map entry", input: "agent.env.FOO", want: NamespacedKey{Scope: ScopeProject, Path: "agent.env.FOO"}},
+		{name: "agent override", input: "project.agents.reviewer.security.cap_add", want: NamespacedKey{Scope: ScopeProject, Path: "agents.reviewer.security.cap_add"}},
+		{name: "profile", input: "project.profiles.ci.workspace.default_mode", want: NamespacedKey{Scope: ScopeProject, Path: "profiles.ci.workspace.default_mode"}},
+		{name: "qualified project", input: "project.agent.editor", want: NamespacedKey{Scope: ScopeProject, Path: "agent.editor"}},
+		{name: "qualified settings", input: "settings.firewall.enable", want: NamespacedKey{Scope: ScopeSettings, Path: "firewall.enable"}},
+		{name: "qualified registry", input: "registry.projects.app.root", want: NamespacedKey{Scope: ScopeRegistry, Path: "projects.app.root"}},
+		{name: "bare scope", input: "settings", wantErr: "names a scope"},
+		{name: "empty", input: "", wantErr: "must not be empty"},
+		{name: "empty segment", input: "build..packages", wantErr: "empty segment"},
+		{name: "unknown", input: "nope.nothing", wantErr: "unknown config key"},
+		{name: "unqualified registry", input: "projects.app.root", wantErr: "unknown config key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNamespacedKey(tt.input)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   Scope
+		path    string
+		raw     string
+		want    any
+		wantErr string
+	}{
+		{name: "bool", scope: ScopeProject, path: "security.docker_socket", raw: "true", want: true},
+		{name: "bad bool", scope: ScopeProject, path: "security.docker_socket", raw: "yes please", wantErr: "expects a boolean"},
+		{name: "int", scope: ScopeSettings, path: "logging.max_size_mb", raw: "100", want: 100},
+		{name: "bad int", scope: ScopeSettings, path: "logging.max_size_mb", raw: "lots", wantErr: "expects an integer"},
+		{name: "text", scope: ScopeProject, path: "agent.editor", raw: "vim", want: "vim"},
+		{name: "comma list", scope: ScopeProject, path: "build.packages", raw: "git, ripgrep,,jq", want: []string{"git", "ripgrep", "jq"}},
+		{name: "flow list", scope: ScopeProject, path: "build.packages", raw: `["git", "a,b"]`, want: []string{"git", "a,b"}},
+		{name: "empty list", scope: ScopeProject, path: "build.packages", raw: "", want: []string{}},
+		{name: "map", scope: ScopeProject, path: "agent.env", raw: "{FOO: bar}", want: map[string]string{"FOO": "bar"}},
+		{name: "bad map", scope: ScopeProject, path: "agent.env", raw: "[1, 2]", wantErr: "expects a mapping"},
+		{name: "map entry keeps bool-like string", scope: ScopeProject, path: "agent.env.DEBUG", raw: "false", want: "false"},
+		{name: "map entry keeps leading zeros", scope: ScopeProject, path: "agent.env.UMASK", raw: "007", want: "007"},
+		{name: "map entry keeps hex", scope: ScopeProject, path: "agent.env.MASK", raw: "0x10", want: "0x10"},
+		{name: "map entry keeps exponent", scope: ScopeProject, path: "agent.env.N", raw: "1e3", want: "1e3"},
+		{name: "map entry keeps mapping-like string", scope: ScopeProject, path: "agent.env.X", raw: "a: b", want: "a: b"},
+		{name: "text keeps number-like string", scope: ScopeProject, path: "agent.editor", raw: "007", want: "007"},
+		{name: "service member text", scope: ScopeProject, path: "services.db.command", raw: "1e3", want: "1e3"},
+		{name: "service env entry", scope: ScopeProject, path: "services.db.env.PORT", raw: "5432", want: "5432"},
+		{name: "sidecar member list", scope: ScopeProject, path: "sidecars.pg.ports", raw: "5432:5432", want: []string{"5432:5432"}},
+		{name: "sidecar member int", scope: ScopeProject, path: "sidecars.pg.healthcheck.retries", raw: "5", want: 5},
+		{name: "profile service member", scope: ScopeProject, path: "profiles.ci.services.db.restart", raw: "no", want: "no"},
+		{name: "agent override list", scope: ScopeProject, path: "agents.reviewer.security.firewall.add_domains", raw: "api.example.com", want: []string{"api.example.com"}},
+		{name: "agent override bool", scope: ScopeProject, path: "agents.reviewer.security.docker_socket", raw: "true", want: true},
+		{name: "profile list", scope: ScopeProject, path: "profiles.ci.build.packages", raw: "jq,make", want: []string{"jq", "make"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseValue(LookupField(tt.scope, tt.path), tt.raw)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStructMapElemsCoverSchema(t *testing.T) {
+	var walk func(fields storage.FieldSet)
+	walk = func(fields storage.FieldSet) {
+		for _, f := range fields.All() {
+			if f.Kind() != storage.KindStructMap {
+				continue
+			}
+			elem, ok := structMapElems[f.Path()]
+			if assert.True(t, ok, "structMapElems has no entry for %s", f.Path()) {
+				walk(elem())
+			}
+		}
+	}
+	walk(config.Project{}.Fields())
+	walk(config.Settings{}.Fields())
+}
+
+func TestParseScope(t *testing.T) {
+	s, err := ParseScope("")
+	require.NoError(t, err)
+	assert.Equal(t, Scope(""), s)
+
+	s, err = ParseScope("registry")
+	require.NoError(t, err)
+	assert.Equal(t, ScopeRegistry, s)
+
+	_, err = ParseScope("global")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid scope")
+}
@@ -0,0 +1,157 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/storage"
+)
+
+// SourceDefault marks a value that no file layer set — it comes from the
+// schema defaults.
+const SourceDefault = "default"
+
+// Entry is one resolved key in a flattened config view.
+type Entry struct {
+	Key    string `json:"key"`
+	Value  any    `json:"value"`
+	Scope  Scope  `json:"scope"`
+	Source string `json:"source,omitempty"`
+}
+
+// StoreEntries flattens every schema leaf field present in store into
+// entries keyed by their fully-qualified name, in schema order. Source is
+// the winning layer's file path, SourceDefault for the defaults layer, or
+// empty when the store tracks no single winner (union-merged fields).
+func StoreEntries[T storage.Schema](scope Scope, store *storage.Store[T]) ([]Entry, error) {
+	var zero T
+	var entries []Entry
+	for _, f := range zero.Fields().All() {
+		var v any
+		found, err := store.Get(f.Path(), &v)
+		if err != nil {
+			return nil, err
+		}
+		if !found || v == nil {
+			continue
+		}
+		entries = append(entries, Entry{
+			Key:    NamespacedKey{Scope: scope, Path: f.Path()}.String(),
+			Value:  v,
+			Scope:  scope,
+			Source: StoreSource(store, f.Path()),
+		})
+	}
+	return entries, nil
+}
+
+// StoreSource names the layer that won path: its file path, SourceDefault
+// for the virtual defaults layer, or "" when unknown.
+func StoreSource[T storage.Schema](store *storage.Store[T], path string) string {
+	layer, ok := store.Provenance(path)
+	if !ok {
+		return ""
+	}
+	if layer.Path == "" {
+		return SourceDefault
+	}
+	return layer.Path
+}
+
+// RegistryView projects registry entries into a nested map keyed by project
+// name, so registry keys read naturally (projects.<name>.root,
+// projects.<name>.worktrees.<branch>.path) instead of by slice index.
+func RegistryView(entries []project.ProjectEntry) map[string]any {
+	projects := make(map[string]any, len(entries))
+	for _, e := range entries {
+		p := map[string]any{"root": e.Root}
+		if len(e.Worktrees) > 0 {
+			wts := make(map[string]any, len(e.Worktrees))
+			for branch, wt := range e.Worktrees {
+				w := map[string]any{"path": wt.Path}
+				if wt.Branch != "" {
+					w["branch"] = wt.Branch
+				}
+				wts[branch] = w
+			}
+			p["worktrees"] = wts
+		}
+		projects[e.Name] = p
+	}
+	return map[string]any{"projects": projects}
+}
+
+// Lookup walks a dotted path through a nested map view.
+func Lookup(view map[string]any, path string) (any, bool) {
+	var cur any = view
+	for _, seg := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[seg]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// ViewEntries flattens a nested map view into sorted leaf entries.
+func ViewEntries(scope Scope, view map[string]any) []Entry {
+	var entries []Entry
+	var walk func(prefix string, v any)
+	walk = func(prefix string, v any) {
+		m, ok := v.(map[string]any)
+		if !ok {
+			entries = append(entries, Entry{Key: NamespacedKey{Scope: scope, Path: prefix}.String(), Value: v, Scope: scope})
+			return
+		}
+		for k, child := range m {
+			p := k
+			if prefix != "" {
+				p = prefix + "." + k
+			}
+			walk(p, child)
+		}
+	}
+	walk("", view)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+// FormatInline renders a value on one line: scalars as-is, collections as
+// compact JSON (the table cell form).
+func FormatInline(v any) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	case map[string]any, []any:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return "", fmt.Errorf("encoding value: %w", err)
+		}
+		return string(b), nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// FormatBlock renders a value for `config get`: scalars as-is, collections as
+// a YAML block so they can be pasted back into a config file.
+func FormatBlock(v any) (string, error) {
+	switch v.(type) {
+	case map[string]any, []any:
+		b, err := yaml.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("encoding value: %w", err)
+		}
+		return strings.TrimSuffix(string(b), "\n"), nil
+	}
+	return FormatInline(v)
+}
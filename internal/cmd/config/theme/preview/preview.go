@@ -0,0 +1,151 @@
+package preview
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// customTheme names the preview of the configured theme with the
+// settings.yaml palette overrides applied.
+const customTheme = "custom"
+
+// PreviewOptions holds dependencies for the config theme preview command.
+type PreviewOptions struct {
+	IOStreams *iostreams.IOStreams
+	Config    func() (config.Config, error)
+
+	Theme string
+}
+
+// NewCmdPreview creates the `clawker config theme preview` command.
+func NewCmdPreview(f *cmdutil.Factory, runF func(context.Context, *PreviewOptions) error) *cobra.Command {
+	opts := &PreviewOptions{
+		IOStreams: f.IOStreams,
+		Config:    f.Config,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "preview [theme]",
+		Short: "Render sample output in each color theme",
+		Long: `Renders sample output in each built-in color theme so you can pick one
+for ui.theme in settings.yaml.
+
+When ui.palette overrides are set, a "custom" preview shows the configured
+theme with those overrides applied. Pass a theme name to preview only that
+theme.
+
+Color follows the usual rules: NO_COLOR disables it, CLICOLOR_FORCE=1
+enables it even when output is not a terminal.`,
+		Example: `  # Preview every theme
+  clawker config theme preview
+
+  # Preview only the high-contrast theme
+  clawker config theme preview high-contrast
+
+  # Preview through a pager or file
+  CLICOLOR_FORCE=1 clawker config theme preview | less -R`,
+		Args:      cmdutil.RequiresMaxArgs(1),
+		ValidArgs: iostreams.Themes(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				opts.Theme = args[0]
+			}
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return previewRun(cmd.Context(), opts)
+		},
+	}
+
+	return cmd
+}
+
+type themePreview struct {
+	name    string
+	palette iostreams.Palette
+}
+
+func previewRun(_ context.Context, opts *PreviewOptions) error {
+	ios := opts.IOStreams
+
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	ui := cfg.Settings().UI
+	overrides := ui.Palette.Colors()
+
+	names := iostreams.Themes()
+	if len(overrides) > 0 {
+		names = append(names, customTheme)
+	}
+	if opts.Theme != "" {
+		if !slices.Contains(names, opts.Theme) {
+			return cmdutil.FlagErrorf("unknown theme %q (valid: %s)", opts.Theme, strings.Join(names, ", "))
+		}
+		names = []string{opts.Theme}
+	}
+
+	previews := make([]themePreview, 0, len(names))
+	for _, name := range names {
+		var p iostreams.Palette
+		if name == customTheme {
+			p, err = iostreams.ResolvePalette(ui.Theme, ios.TerminalTheme(), overrides)
+		} else {
+			p, err = iostreams.ResolvePalette(name, ios.TerminalTheme(), nil)
+		}
+		if err != nil {
+			return fmt.Errorf("theme %s: %w", name, err)
+		}
+		previews = append(previews, themePreview{name: name, palette: p})
+	}
+
+	if !ios.ColorEnabled() {
+		fmt.Fprintln(ios.ErrOut, "Color output is disabled (NO_COLOR, CLICOLOR=0, or not a terminal); set CLICOLOR_FORCE=1 to preview colors.")
+	}
+
+	// Styles are package-wide; render each preview with its palette installed
+	// and put the active one back afterwards.
+	active := iostreams.ActivePalette()
+	defer iostreams.ApplyPalette(active)
+
+	current := ios.ColorTheme()
+	if len(overrides) > 0 {
+		current = customTheme
+	}
+	for i, tp := range previews {
+		if i > 0 {
+			fmt.Fprintln(ios.Out)
+		}
+		iostreams.ApplyPalette(tp.palette)
+		renderSample(ios, tp.name, tp.name == current)
+	}
+	return nil
+}
+
+// renderSample writes representative clawker output in the installed palette.
+func renderSample(ios *iostreams.IOStreams, name string, active bool) {
+	cs := ios.ColorScheme()
+	out := ios.Out
+
+	title := cs.Primary(name)
+	if active {
+		title += " " + cs.Muted("(active)")
+	}
+	fmt.Fprintln(out, title)
+	fmt.Fprintf(out, "  %s  %s  %s  %s  %s\n",
+		cs.Primary("primary"), cs.Secondary("secondary"), cs.Accent("accent"),
+		cs.Highlight("highlight"), cs.Muted("muted"))
+	fmt.Fprintf(out, "  %s\n", cs.SuccessIconWithColor("Container clawker.myapp.dev started"))
+	fmt.Fprintf(out, "  %s\n", cs.WarningIconWithColor("Image is 14 days old; run clawker build"))
+	fmt.Fprintf(out, "  %s\n", cs.FailureIconWithColor("Firewall rule rejected: example.com"))
+	fmt.Fprintf(out, "  %s\n", cs.InfoIconWithColor("Workspace mounted in bind mode"))
+	fmt.Fprintf(out, "  %s %s\n", cs.Muted("hint:"), cs.Disabled("disabled option"))
+}
@@ -0,0 +1,107 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdPreview(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantTheme string
+		wantErr   bool
+	}{
+		{name: "all themes", input: ""},
+		{name: "one theme", input: "light", wantTheme: "light"},
+		{name: "too many args", input: "light dark", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *PreviewOptions
+			cmd := NewCmdPreview(f, func(_ context.Context, opts *PreviewOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, gotOpts)
+			assert.Equal(t, tt.wantTheme, gotOpts.Theme)
+		})
+	}
+}
+
+func newOpts(ios *iostreams.IOStreams, settingsYAML, theme string) *PreviewOptions {
+	cfg := configmocks.NewFromString("", settingsYAML)
+	return &PreviewOptions{
+		IOStreams: ios,
+		Config:    func() (config.Config, error) { return cfg, nil },
+		Theme:     theme,
+	}
+}
+
+func TestPreviewRun(t *testing.T) {
+	t.Run("renders every built-in theme", func(t *testing.T) {
+		ios, _, out, errOut := iostreams.Test()
+
+		require.NoError(t, previewRun(context.Background(), newOpts(ios, "", "")))
+		for _, name := range iostreams.Themes() {
+			assert.Contains(t, out.String(), name)
+		}
+		assert.Contains(t, out.String(), "default (active)")
+		assert.NotContains(t, out.String(), "custom")
+		assert.Contains(t, out.String(), "[ok] Container")
+		assert.Contains(t, errOut.String(), "Color output is disabled", "non-TTY output explains the missing color")
+	})
+
+	t.Run("palette overrides add a custom preview", func(t *testing.T) {
+		ios, _, out, _ := iostreams.Test()
+
+		require.NoError(t, previewRun(context.Background(), newOpts(ios, "ui:\n  palette:\n    primary: \"#123456\"\n", "")))
+		assert.Contains(t, out.String(), "custom (active)")
+	})
+
+	t.Run("single theme restores the active palette", func(t *testing.T) {
+		ios, _, out, _ := iostreams.Test()
+		ios.SetColorEnabled(true)
+		before := iostreams.ActivePalette()
+
+		require.NoError(t, previewRun(context.Background(), newOpts(ios, "ui:\n  palette:\n    primary: \"#123456\"\n", "custom")))
+		assert.NotContains(t, out.String(), "high-contrast")
+		assert.Equal(t, before, iostreams.ActivePalette())
+	})
+
+	t.Run("unknown theme", func(t *testing.T) {
+		ios, _, _, _ := iostreams.Test()
+
+		err := previewRun(context.Background(), newOpts(ios, "", "neon"))
+		var flagErr *cmdutil.FlagError
+		require.ErrorAs(t, err, &flagErr)
+		assert.Contains(t, err.Error(), "high-contrast")
+	})
+}
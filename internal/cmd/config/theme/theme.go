@@ -0,0 +1,29 @@
+package theme
+
+import (
+	"github.com/schmitthub/clawker/internal/cmd/config/theme/preview"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdTheme creates the `clawker config theme` parent command.
+func NewCmdTheme(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "theme",
+		Short: "Inspect terminal color themes",
+		Long: `Inspect the color themes clawker uses for terminal output.
+
+The active theme is set with ui.theme in settings.yaml; individual colors
+can be overridden with ui.palette. NO_COLOR, CLICOLOR and CLICOLOR_FORCE
+decide whether color is used at all.`,
+		Example: `  # Render sample output in every theme
+  clawker config theme preview
+
+  # Switch to the light theme
+  clawker config set ui.theme light`,
+	}
+
+	cmd.AddCommand(preview.NewCmdPreview(f, nil))
+
+	return cmd
+}
@@ -3,17 +3,23 @@ package attach
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
 	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/schmitthub/clawker/internal/attachhub"
+	"github.com/schmitthub/clawker/internal/cmd/container/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/hostproxy"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/project"
 	"github.com/schmitthub/clawker/internal/signals"
+	"github.com/schmitthub/clawker/internal/tui"
 	"github.com/spf13/cobra"
 )
 
@@ -21,15 +27,21 @@ import (
 type AttachOptions struct {
 	IOStreams      *iostreams.IOStreams
 	Client         func(context.Context) (*docker.Client, error)
+	Config         func() (config.Config, error)
 	ProjectManager func() (project.ProjectManager, error)
 	HostProxy      func() hostproxy.Service
 	Logger         func() (*logger.Logger, error)
+	TUI            *tui.TUI
 
 	Agent      bool // treat argument as agent name (resolves to clawker.<project>.<agent>)
 	NoStdin    bool
+	Observe    bool
 	SigProxy   bool
 	DetachKeys string
 	container  string
+
+	// pick chooses the agent when none is named; TUI.RunPicker when nil.
+	pick shared.AgentPicker
 }
 
 // NewCmdAttach creates a new attach command.
@@ -37,26 +49,43 @@ func NewCmdAttach(f *cmdutil.Factory, runF func(context.Context, *AttachOptions)
 	opts := &AttachOptions{
 		IOStreams:      f.IOStreams,
 		Client:         f.Client,
+		Config:         f.Config,
 		ProjectManager: f.ProjectManager,
 		HostProxy:      f.HostProxy,
 		Logger:         f.Logger,
+		TUI:            f.TUI,
 	}
 
 	cmd := &cobra.Command{
-		Use:   "attach [OPTIONS] CONTAINER",
+		Use:   "attach [OPTIONS] [CONTAINER]",
 		Short: "Attach local standard input, output, and error streams to a running container",
 		Long: `Attach local standard input, output, and error streams to a running container.
 
 Use ctrl-p, ctrl-q to detach from the container and leave it running.
+Override the sequence with --detach-keys or settings.terminal.detach_keys.
 To stop a container, use clawker container stop.
 
+Use --observe to watch a session someone else is attached to, read-only.
+The observer sees recent output and the live stream of the interactive
+run, start or attach session hosting the container; its keystrokes never
+reach the container. The observer's terminal is not resized, so it should
+match the size of the interactive terminal.
+
 When --agent is provided, the container name is resolved as clawker.<project>.<agent>
 using the project resolved from the current directory.
 
+With no container, the running agents of the current project are
+candidates: a single one is attached to, several open a picker. Without
+a terminal several candidates are an error; with --no-input a container
+is required.
+
 Container name can be:
   - Full name: clawker.myproject.myagent
   - Container ID: abc123...`,
-		Example: `  # Attach to a container using agent name
+		Example: `  # Pick one of the project's running agents to attach to
+  clawker container attach
+
+  # Attach to a container using agent name
   clawker container attach --agent dev
 
   # Attach to a container by full name
@@ -64,10 +93,18 @@ Container name can be:
 
   # Attach without stdin (output only)
   clawker container attach --no-stdin --agent dev
+
+  # Watch a teammate's live agent session without taking input
+  clawker container attach --observe --agent dev
+
+  # Detach with ctrl-a, d instead of ctrl-p, ctrl-q
+  clawker container attach --detach-keys ctrl-a,d --agent dev
 `,
-		Args: cmdutil.ExactArgs(1),
+		Args: shared.AgentArgs(f.IOStreams, cmdutil.ExactArgs(1), false),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts.container = args[0]
+			if len(args) == 1 {
+				opts.container = args[0]
+			}
 			if runF != nil {
 				return runF(cmd.Context(), opts)
 			}
@@ -77,8 +114,11 @@ Container name can be:
 
 	cmd.Flags().BoolVar(&opts.Agent, "agent", false, "Treat argument as agent name (resolves to clawker.<project>.<agent>)")
 	cmd.Flags().BoolVar(&opts.NoStdin, "no-stdin", false, "Do not attach STDIN")
+	cmd.Flags().BoolVar(&opts.Observe, "observe", false, "Watch another attached session read-only")
 	cmd.Flags().BoolVar(&opts.SigProxy, "sig-proxy", true, "Proxy all received signals to the process")
-	cmd.Flags().StringVar(&opts.DetachKeys, "detach-keys", "", "Override the key sequence for detaching a container")
+	cmd.Flags().StringVar(&opts.DetachKeys, "detach-keys", "", "Override the key sequence for detaching a container (e.g. ctrl-a,d)")
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.ContainerCompletions(f.Client, f.ProjectManager))
 
 	return cmd
 }
@@ -91,8 +131,50 @@ func attachRun(ctx context.Context, opts *AttachOptions) error {
 		return fmt.Errorf("initializing logger: %w", err)
 	}
 
+	// Settings only supply the default; an unloadable config must not block
+	// attaching.
+	var cfg config.Config
+	if opts.Config != nil {
+		if c, cfgErr := opts.Config(); cfgErr == nil {
+			cfg = c
+		} else {
+			log.Debug().Err(cfgErr).Msg("config unavailable; using default detach keys")
+		}
+	}
+	detachSpec, detachKeys, err := shared.ResolveDetachKeys(opts.DetachKeys, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Connect to Docker
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+
 	container := opts.container
-	if opts.Agent {
+	if container == "" {
+		pick := opts.pick
+		if pick == nil {
+			pick = opts.TUI.RunPicker
+		}
+		container, err = shared.PickAgentContainer(ctx, shared.PickAgentOptions{
+			IOStreams:      ios,
+			Client:         client,
+			Pick:           pick,
+			ProjectManager: opts.ProjectManager,
+			States:         []string{"running"},
+			Verb:           "attach to",
+			Command:        "clawker container attach",
+		})
+		if err != nil {
+			return err
+		}
+		if container == "" {
+			fmt.Fprintln(ios.ErrOut, "Aborted.")
+			return nil
+		}
+	} else if opts.Agent {
 		var projectName string
 		if pm, err := opts.ProjectManager(); err == nil {
 			if p, err := pm.CurrentProject(ctx); err == nil {
@@ -105,11 +187,6 @@ func attachRun(ctx context.Context, opts *AttachOptions) error {
 			return nameErr
 		}
 	}
-	// Connect to Docker
-	client, err := opts.Client(ctx)
-	if err != nil {
-		return fmt.Errorf("connecting to Docker: %w", err)
-	}
 
 	// Find container by name
 	c, err := client.FindContainerByName(ctx, container)
@@ -125,6 +202,10 @@ func attachRun(ctx context.Context, opts *AttachOptions) error {
 		return fmt.Errorf("container %q is not running", container)
 	}
 
+	if opts.Observe {
+		return observeRun(ctx, opts, log, c.ID, container, detachSpec, detachKeys)
+	}
+
 	// Get container info to determine if it has a TTY
 	info, err := client.ContainerInspect(ctx, c.ID, docker.ContainerInspectOptions{})
 	if err != nil {
@@ -135,16 +216,18 @@ func attachRun(ctx context.Context, opts *AttachOptions) error {
 
 	// Create attach options
 	attachOpts := docker.ContainerAttachOptions{
-		Stream: true,
-		Stdin:  !opts.NoStdin,
-		Stdout: true,
-		Stderr: true,
+		Stream:     true,
+		Stdin:      !opts.NoStdin,
+		Stdout:     true,
+		Stderr:     true,
+		DetachKeys: detachSpec,
 	}
 
 	// Set up TTY if container has one
 	var pty *docker.PTYHandler
 	if hasTTY && !opts.NoStdin {
 		pty = docker.NewPTYHandler(log)
+		pty.SetDetachKeys(detachKeys)
 		if err := pty.Setup(); err != nil {
 			return fmt.Errorf("failed to set up terminal: %w", err)
 		}
@@ -160,6 +243,8 @@ func attachRun(ctx context.Context, opts *AttachOptions) error {
 
 	// Handle I/O
 	if hasTTY && pty != nil {
+		defer shared.HostAttachHub(pty, c.ID, log)()
+
 		// TTY mode: Stream for I/O, separate resize handling
 		resizeFunc := func(height, width uint) error {
 			_, err := client.ContainerResize(ctx, c.ID, height, width)
@@ -188,11 +273,17 @@ func attachRun(ctx context.Context, opts *AttachOptions) error {
 
 			// Monitor for window resize events (SIGWINCH)
 			resizeHandler := signals.NewResizeHandler(resizeFunc, pty.GetSize)
+			resizeHandler.SetErrorHandler(func(err error) {
+				log.Debug().Err(err).Msg("failed to resize container TTY")
+			})
 			resizeHandler.Start()
 			defer resizeHandler.Stop()
 		}
 
-		return <-streamDone
+		if err := <-streamDone; !errors.Is(err, docker.ErrDetached) {
+			return err
+		}
+		return nil
 	}
 
 	// Non-TTY mode: demux the multiplexed stream
@@ -227,3 +318,37 @@ func attachRun(ctx context.Context, opts *AttachOptions) error {
 		return err
 	}
 }
+
+// observeRun streams another session's output read-only through the
+// container's attach hub. Only the session hosting the hub talks to Docker;
+// the observer never attaches, so it cannot send input or resize the TTY.
+func observeRun(ctx context.Context, opts *AttachOptions, log *logger.Logger, containerID, container, detachSpec string, detachKeys []byte) error {
+	ios := opts.IOStreams
+
+	path, err := consts.AttachSocketPath(containerID)
+	if err != nil {
+		return fmt.Errorf("resolving attach hub socket: %w", err)
+	}
+	conn, err := attachhub.Dial(path)
+	if errors.Is(err, attachhub.ErrNoSession) {
+		return fmt.Errorf("container %q has no interactive session to observe; attach without --observe to start one", container)
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	fmt.Fprintf(ios.ErrOut, "Observing %s read-only. Detach with %s.\n", container, detachSpec)
+
+	pty := docker.NewPTYHandler(log)
+	pty.SetDetachKeys(detachKeys)
+	if err := pty.Setup(); err != nil {
+		return fmt.Errorf("failed to set up terminal: %w", err)
+	}
+	defer pty.Restore()
+
+	if err := pty.Observe(ctx, conn); !errors.Is(err, docker.ErrDetached) {
+		return err
+	}
+	return nil
+}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/google/shlex"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/docker/mocks"
 	"github.com/schmitthub/clawker/internal/iostreams"
@@ -36,6 +38,11 @@ func TestNewCmdAttach(t *testing.T) {
 			input:    "--no-stdin mycontainer",
 			wantOpts: AttachOptions{NoStdin: true, SigProxy: true, container: "mycontainer"},
 		},
+		{
+			name:     "observe flag",
+			input:    "--observe mycontainer",
+			wantOpts: AttachOptions{Observe: true, SigProxy: true, container: "mycontainer"},
+		},
 		{
 			name:     "sig-proxy false",
 			input:    "--sig-proxy=false mycontainer",
@@ -47,8 +54,13 @@ func TestNewCmdAttach(t *testing.T) {
 			wantOpts: AttachOptions{SigProxy: true, DetachKeys: "ctrl-c", container: "mycontainer"},
 		},
 		{
-			name:       "no arguments",
-			input:      "",
+			name:     "no arguments leaves the agent to the picker",
+			input:    "",
+			wantOpts: AttachOptions{SigProxy: true},
+		},
+		{
+			name:       "no arguments with no-input",
+			input:      "--no-input",
 			wantErr:    true,
 			wantErrMsg: "attach: 'attach' requires 1 argument",
 		},
@@ -62,7 +74,7 @@ func TestNewCmdAttach(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f := &cmdutil.Factory{}
+			f := cmdutiltest.NewFactory(t).Factory
 
 			var gotOpts *AttachOptions
 			cmd := NewCmdAttach(f, func(_ context.Context, opts *AttachOptions) error {
@@ -71,6 +83,8 @@ func TestNewCmdAttach(t *testing.T) {
 			})
 
 			cmd.Flags().BoolP("help", "x", false, "")
+			// Root persistent flag, read by the Args check.
+			cmd.Flags().Bool("no-input", false, "")
 
 			argv, err := shlex.Split(tt.input)
 			require.NoError(t, err)
@@ -89,6 +103,7 @@ func TestNewCmdAttach(t *testing.T) {
 			require.NoError(t, err)
 			require.NotNil(t, gotOpts)
 			require.Equal(t, tt.wantOpts.NoStdin, gotOpts.NoStdin)
+			require.Equal(t, tt.wantOpts.Observe, gotOpts.Observe)
 			require.Equal(t, tt.wantOpts.SigProxy, gotOpts.SigProxy)
 			require.Equal(t, tt.wantOpts.DetachKeys, gotOpts.DetachKeys)
 			require.Equal(t, tt.wantOpts.container, gotOpts.container)
@@ -97,10 +112,10 @@ func TestNewCmdAttach(t *testing.T) {
 }
 
 func TestCmdAttach_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdAttach(f, nil)
 
-	require.Equal(t, "attach [OPTIONS] CONTAINER", cmd.Use)
+	require.Equal(t, "attach [OPTIONS] [CONTAINER]", cmd.Use)
 	require.NotEmpty(t, cmd.Short)
 	require.NotEmpty(t, cmd.Long)
 	require.NotEmpty(t, cmd.Example)
@@ -134,7 +149,7 @@ func TestCmdAttach_ArgsParsing(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f := &cmdutil.Factory{}
+			f := cmdutiltest.NewFactory(t).Factory
 
 			var gotOpts *AttachOptions
 			cmd := NewCmdAttach(f, func(_ context.Context, opts *AttachOptions) error {
@@ -159,17 +174,8 @@ func TestCmdAttach_ArgsParsing(t *testing.T) {
 
 func testFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return configmocks.NewBlankConfig(), nil
-		},
-	}, in, out, errOut
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 func TestAttachRun_DockerConnectionError(t *testing.T) {
@@ -252,3 +258,24 @@ func TestAttachRun_NonTTYHappyPath(t *testing.T) {
 	require.NoError(t, err)
 	fake.AssertCalled(t, "ContainerAttach")
 }
+
+func TestAttachRun_ObserveWithoutSession(t *testing.T) {
+	t.Setenv(consts.EnvStateDir, t.TempDir())
+	fixture := mocks.RunningContainerFixture("myapp", "dev")
+
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList(fixture)
+	fake.SetupContainerInspect("clawker.myapp.dev", fixture)
+	f, _, out, errOut := testFactory(t, fake)
+
+	cmd := NewCmdAttach(f, nil)
+	cmd.SetArgs([]string{"--observe", "clawker.myapp.dev"})
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no interactive session to observe")
+	fake.AssertNotCalled(t, "ContainerAttach")
+}
@@ -0,0 +1,158 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mobyClient "github.com/moby/moby/client"
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+)
+
+// CheckpointOptions holds options for the checkpoint command.
+type CheckpointOptions struct {
+	IOStreams      *iostreams.IOStreams
+	Client         func(context.Context) (*docker.Client, error)
+	ProjectManager func() (project.ProjectManager, error)
+
+	Agent         bool
+	Name          string
+	LeaveRunning  bool
+	CheckpointDir string
+
+	Container string
+
+	// now stamps the default checkpoint name; nil means time.Now.
+	now func() time.Time
+}
+
+// NewCmdCheckpoint creates the container checkpoint command.
+func NewCmdCheckpoint(f *cmdutil.Factory, runF func(context.Context, *CheckpointOptions) error) *cobra.Command {
+	opts := &CheckpointOptions{
+		IOStreams:      f.IOStreams,
+		Client:         f.Client,
+		ProjectManager: f.ProjectManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "checkpoint [OPTIONS] CONTAINER",
+		Short: "Checkpoint a running container's processes (experimental)",
+		Long: `Checkpoints a running clawker container with CRIU, saving its processes and
+their in-memory state to disk so the session can be resumed later with
+'clawker container restore' — after a host reboot, or on another machine
+when --checkpoint-dir points at storage you move there.
+
+Experimental: needs a rootful Linux Docker daemon with experimental features
+on and CRIU installed on the daemon host. Podman, rootless daemons, and
+daemons without experimental features are refused before anything is
+touched. CRIU cannot capture every process: open TCP connections (model API
+calls, the control plane link) make the checkpoint fail, so checkpoint an
+agent while it is idle.
+
+The container stops once the checkpoint is written; --leave-running keeps it
+running. The checkpoint name defaults to clawker-<UTC timestamp> and is
+printed on success.
+
+When --agent is provided, the container name is resolved as clawker.<project>.<agent>
+using the project resolved from the current directory.`,
+		Example: `  # Checkpoint an agent and stop it
+  clawker container checkpoint --agent dev
+
+  # Checkpoint under a chosen name and keep the agent running
+  clawker container checkpoint --agent dev --name before-refactor --leave-running
+
+  # Write the checkpoint where it can be copied to another machine
+  clawker container checkpoint --agent dev --checkpoint-dir /mnt/share/checkpoints`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Container = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return checkpointRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Agent, "agent", false, "Treat the argument as an agent name (resolves to clawker.<project>.<agent>)")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Checkpoint name (default clawker-<UTC timestamp>)")
+	cmd.Flags().BoolVar(&opts.LeaveRunning, "leave-running", false, "Keep the container running after the checkpoint")
+	cmd.Flags().StringVar(&opts.CheckpointDir, "checkpoint-dir", "", "Directory to write the checkpoint to (default: the daemon's own)")
+
+	cmd.ValidArgsFunction = cmdutil.ContainerCompletions(f.Client, f.ProjectManager)
+
+	return cmd
+}
+
+func checkpointRun(ctx context.Context, opts *CheckpointOptions) error {
+	ios := opts.IOStreams
+
+	name := opts.Container
+	if opts.Agent {
+		var projectName string
+		if opts.ProjectManager != nil {
+			if pm, pmErr := opts.ProjectManager(); pmErr == nil {
+				if p, pErr := pm.CurrentProject(ctx); pErr == nil {
+					projectName = p.Name()
+				}
+			}
+		}
+		resolved, err := docker.ContainerNamesFromAgents(projectName, []string{name})
+		if err != nil {
+			return err
+		}
+		name = resolved[0]
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+	if err := client.CheckpointSupport(); err != nil {
+		return err
+	}
+
+	c, err := client.FindContainerByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to find container %q: %w", name, err)
+	}
+	if c == nil {
+		return fmt.Errorf("container %q not found", name)
+	}
+
+	checkpointID := opts.Name
+	if checkpointID == "" {
+		now := time.Now
+		if opts.now != nil {
+			now = opts.now
+		}
+		checkpointID = defaultName(now())
+	}
+
+	err = ios.RunWithSpinner("Checkpointing "+name, func() error {
+		return client.CheckpointCreate(ctx, c.ID, mobyClient.CheckpointCreateOptions{
+			CheckpointID:  checkpointID,
+			CheckpointDir: opts.CheckpointDir,
+			Exit:          !opts.LeaveRunning,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(ios.Out, checkpointID)
+	cs := ios.ColorScheme()
+	fmt.Fprintf(ios.ErrOut, "%s Restore with: clawker container restore --checkpoint %s %s\n",
+		cs.InfoIcon(), checkpointID, name)
+	return nil
+}
+
+// defaultName is the checkpoint name used when --name is not given. The
+// UTC timestamp sorts names in creation order.
+func defaultName(t time.Time) string {
+	return "clawker-" + t.UTC().Format("20060102T150405Z")
+}
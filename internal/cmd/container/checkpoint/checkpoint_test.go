@@ -0,0 +1,100 @@
+package checkpoint
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	mobyclient "github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+)
+
+func TestNewCmdCheckpoint(t *testing.T) {
+	f := cmdutiltest.NewFactory(t).Factory
+	var gotOpts *CheckpointOptions
+	cmd := NewCmdCheckpoint(f, func(_ context.Context, opts *CheckpointOptions) error {
+		gotOpts = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"--agent", "dev", "--name", "cp1", "--leave-running", "--checkpoint-dir", "/tmp/cp"})
+	require.NoError(t, cmd.Execute())
+	require.NotNil(t, gotOpts)
+	assert.Equal(t, "dev", gotOpts.Container)
+	assert.True(t, gotOpts.Agent)
+	assert.Equal(t, "cp1", gotOpts.Name)
+	assert.True(t, gotOpts.LeaveRunning)
+	assert.Equal(t, "/tmp/cp", gotOpts.CheckpointDir)
+
+	cmd = NewCmdCheckpoint(f, func(context.Context, *CheckpointOptions) error { return nil })
+	cmd.SetArgs([]string{})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	require.Error(t, cmd.Execute())
+}
+
+func TestCheckpointRun_DefaultName(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fixture := mocks.RunningContainerFixture("myapp", "dev")
+	fake.SetupFindContainer("clawker.myapp.dev", fixture)
+	var got mobyclient.CheckpointCreateOptions
+	var gotID string
+	fake.FakeAPI.CheckpointCreateFn = func(_ context.Context, id string, opts mobyclient.CheckpointCreateOptions) (mobyclient.CheckpointCreateResult, error) {
+		gotID, got = id, opts
+		return mobyclient.CheckpointCreateResult{}, nil
+	}
+
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	opts := &CheckpointOptions{
+		IOStreams: tf.IOStreams,
+		Client:    tf.Client,
+		Container: "clawker.myapp.dev",
+		now:       func() time.Time { return time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC) },
+	}
+	require.NoError(t, checkpointRun(context.Background(), opts))
+
+	assert.Equal(t, fixture.ID, gotID)
+	assert.Equal(t, "clawker-20260304T050607Z", got.CheckpointID)
+	assert.True(t, got.Exit, "the container stops unless --leave-running")
+	assert.Equal(t, "clawker-20260304T050607Z\n", tf.Out.String())
+	assert.Contains(t, tf.ErrOut.String(), "clawker container restore --checkpoint clawker-20260304T050607Z clawker.myapp.dev")
+}
+
+func TestCheckpointRun_LeaveRunning(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupFindContainer("clawker.myapp.dev", mocks.RunningContainerFixture("myapp", "dev"))
+	var got mobyclient.CheckpointCreateOptions
+	fake.FakeAPI.CheckpointCreateFn = func(_ context.Context, _ string, opts mobyclient.CheckpointCreateOptions) (mobyclient.CheckpointCreateResult, error) {
+		got = opts
+		return mobyclient.CheckpointCreateResult{}, nil
+	}
+
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	cmd := NewCmdCheckpoint(tf.Factory, nil)
+	cmd.SetArgs([]string{"--name", "cp1", "--leave-running", "--checkpoint-dir", "/srv/cp", "clawker.myapp.dev"})
+	cmd.SetOut(tf.Out)
+	cmd.SetErr(tf.ErrOut)
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, mobyclient.CheckpointCreateOptions{CheckpointID: "cp1", CheckpointDir: "/srv/cp"}, got)
+}
+
+func TestCheckpointRun_ContainerNotFound(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList()
+
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	cmd := NewCmdCheckpoint(tf.Factory, nil)
+	cmd.SetArgs([]string{"clawker.myapp.dev"})
+	cmd.SetOut(tf.Out)
+	cmd.SetErr(tf.ErrOut)
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	fake.AssertNotCalled(t, "CheckpointCreate")
+}
@@ -0,0 +1,138 @@
+// Package commit provides the container commit command.
+package commit
+
+import (
+	"context"
+	"fmt"
+
+	moby "github.com/moby/moby/client"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// CommitOptions holds options for the commit command.
+type CommitOptions struct {
+	IOStreams      *iostreams.IOStreams
+	Client         func(context.Context) (*docker.Client, error)
+	ProjectManager func() (project.ProjectManager, error)
+
+	Agent   bool
+	Tag     string
+	Message string
+	Author  string
+	Pause   bool
+
+	container string
+}
+
+// NewCmdCommit creates a new commit command.
+func NewCmdCommit(f *cmdutil.Factory, runF func(context.Context, *CommitOptions) error) *cobra.Command {
+	opts := &CommitOptions{
+		IOStreams:      f.IOStreams,
+		Client:         f.Client,
+		ProjectManager: f.ProjectManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "commit [OPTIONS] CONTAINER",
+		Short: "Create a new image from a container's changes",
+		Long: `Create a new image from the changes in a clawker container's writable layer.
+
+The image is tagged with --tag and carries clawker's managed labels, so it shows
+up in 'clawker image ls' and can be passed to 'clawker run' or 'clawker create'.
+The project and version labels are copied from the container.
+
+Only the container layer is captured. Volume and bind mounts — including the
+workspace volume of a snapshot-mode agent — are not part of the image; use
+'clawker container diff' to see exactly what will be committed.
+
+By default the container is paused while the image is written so the
+filesystem is consistent. Use --pause=false to commit without pausing.
+
+When --agent is provided, the container name is resolved as clawker.<project>.<agent>
+using the project resolved from the current directory.
+
+Container name can be:
+  - Full name: clawker.myproject.myagent
+  - Container ID: abc123...`,
+		Example: `  # Save an agent's installed tooling as a new image
+  clawker container commit --agent dev --tag myapp:with-tools
+
+  # Commit with a message and author
+  clawker container commit --agent dev -t myapp:snap -m "add ripgrep" -a "Jane <jane@example.com>"
+
+  # Commit by full container name without pausing it
+  clawker container commit --pause=false -t myapp:snap clawker.myapp.dev`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return commitRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Agent, "agent", false, "Treat argument as agent name (resolves to clawker.<project>.<agent>)")
+	cmd.Flags().StringVarP(&opts.Tag, "tag", "t", "", "Name and optionally a tag for the image (name:tag)")
+	cmd.Flags().StringVarP(&opts.Message, "message", "m", "", "Commit message")
+	cmd.Flags().StringVarP(&opts.Author, "author", "a", "", "Author (e.g., \"John Hannibal Smith <hannibal@a-team.com>\")")
+	cmd.Flags().BoolVarP(&opts.Pause, "pause", "p", true, "Pause container during commit")
+	_ = cmd.MarkFlagRequired("tag")
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.ContainerCompletions(f.Client, f.ProjectManager))
+
+	return cmd
+}
+
+func commitRun(ctx context.Context, opts *CommitOptions) error {
+	ios := opts.IOStreams
+	containerName := opts.container
+
+	if opts.Agent {
+		var projectName string
+		if opts.ProjectManager != nil {
+			if pm, pmErr := opts.ProjectManager(); pmErr == nil {
+				if p, pErr := pm.CurrentProject(ctx); pErr == nil {
+					projectName = p.Name()
+				}
+			}
+		}
+		containers, err := docker.ContainerNamesFromAgents(projectName, []string{containerName})
+		if err != nil {
+			return err
+		}
+		containerName = containers[0]
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+
+	c, err := client.FindContainerByName(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("failed to find container %q: %w", containerName, err)
+	}
+	if c == nil {
+		return fmt.Errorf("container %q not found", containerName)
+	}
+
+	labels := client.ImageLabels(c.Labels[consts.LabelProject], c.Labels[consts.LabelVersion])
+	result, err := client.ContainerCommit(ctx, c.ID, moby.ContainerCommitOptions{
+		Reference: opts.Tag,
+		Comment:   opts.Message,
+		Author:    opts.Author,
+		NoPause:   !opts.Pause,
+	}, labels)
+	if err != nil {
+		return fmt.Errorf("committing container %q: %w", containerName, err)
+	}
+
+	fmt.Fprintln(ios.Out, result.ID)
+	return nil
+}
@@ -0,0 +1,134 @@
+package commit
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/shlex"
+	moby "github.com/moby/moby/client"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdCommit(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantAgent bool
+		wantTag   string
+		wantMsg   string
+		wantAuth  string
+		wantPause bool
+		wantErr   string
+	}{
+		{name: "tag and container", input: "-t myapp:snap clawker.myapp.dev", wantTag: "myapp:snap", wantPause: true},
+		{
+			name:      "all flags",
+			input:     `--agent --tag myapp:snap -m "add rg" -a "Jane <jane@example.com>" --pause=false dev`,
+			wantAgent: true,
+			wantTag:   "myapp:snap",
+			wantMsg:   "add rg",
+			wantAuth:  "Jane <jane@example.com>",
+		},
+		{name: "tag is required", input: "clawker.myapp.dev", wantErr: `required flag(s) "tag" not set`},
+		{name: "no arguments", input: "-t myapp:snap", wantErr: "requires 1 argument"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *CommitOptions
+			cmd := NewCmdCommit(f, func(_ context.Context, opts *CommitOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAgent, gotOpts.Agent)
+			assert.Equal(t, tt.wantTag, gotOpts.Tag)
+			assert.Equal(t, tt.wantMsg, gotOpts.Message)
+			assert.Equal(t, tt.wantAuth, gotOpts.Author)
+			assert.Equal(t, tt.wantPause, gotOpts.Pause)
+		})
+	}
+}
+
+// --- Tier 2 tests (Cobra+Factory, real run function) ---
+
+func testFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer) {
+	t.Helper()
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	return tf.Factory, tf.Out
+}
+
+func TestCommitRun_AppliesManagedLabels(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	c := mocks.RunningContainerFixture("myapp", "dev")
+	c.Labels[consts.LabelVersion] = "1.2.3"
+	fake.SetupFindContainer("clawker.myapp.dev", c)
+
+	var gotID string
+	var gotOpts moby.ContainerCommitOptions
+	fake.FakeAPI.ContainerCommitFn = func(_ context.Context, id string, opts moby.ContainerCommitOptions) (moby.ContainerCommitResult, error) {
+		gotID, gotOpts = id, opts
+		return moby.ContainerCommitResult{ID: "sha256:abc123"}, nil
+	}
+
+	f, out := testFactory(t, fake)
+	cmd := NewCmdCommit(f, nil)
+	cmd.SetArgs([]string{"-t", "myapp:snap", "-m", "add rg", "--pause=false", "clawker.myapp.dev"})
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "sha256:abc123\n", out.String())
+
+	assert.Equal(t, c.ID, gotID)
+	assert.Equal(t, "myapp:snap", gotOpts.Reference)
+	assert.Equal(t, "add rg", gotOpts.Comment)
+	assert.True(t, gotOpts.NoPause)
+	require.NotNil(t, gotOpts.Config)
+	labels := gotOpts.Config.Labels
+	assert.Equal(t, consts.ManagedLabelValue, labels[consts.LabelManaged])
+	assert.Equal(t, "myapp", labels[consts.LabelProject])
+	assert.Equal(t, "1.2.3", labels[consts.LabelVersion])
+	assert.NotEmpty(t, labels[consts.LabelCreated])
+}
+
+func TestCommitRun_ContainerNotFound(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList()
+
+	f, _ := testFactory(t, fake)
+	cmd := NewCmdCommit(f, nil)
+	cmd.SetArgs([]string{"-t", "myapp:snap", "clawker.myapp.missing"})
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "clawker.myapp.missing")
+	fake.AssertNotCalled(t, "ContainerCommit")
+}
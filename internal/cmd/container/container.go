@@ -3,17 +3,23 @@ package container
 
 import (
 	"github.com/schmitthub/clawker/internal/cmd/container/attach"
+	"github.com/schmitthub/clawker/internal/cmd/container/checkpoint"
+	"github.com/schmitthub/clawker/internal/cmd/container/commit"
 	"github.com/schmitthub/clawker/internal/cmd/container/cp"
 	"github.com/schmitthub/clawker/internal/cmd/container/create"
+	"github.com/schmitthub/clawker/internal/cmd/container/diff"
 	"github.com/schmitthub/clawker/internal/cmd/container/exec"
 	"github.com/schmitthub/clawker/internal/cmd/container/inspect"
 	"github.com/schmitthub/clawker/internal/cmd/container/kill"
 	"github.com/schmitthub/clawker/internal/cmd/container/list"
 	"github.com/schmitthub/clawker/internal/cmd/container/logs"
 	"github.com/schmitthub/clawker/internal/cmd/container/pause"
+	"github.com/schmitthub/clawker/internal/cmd/container/port"
+	"github.com/schmitthub/clawker/internal/cmd/container/prune"
 	"github.com/schmitthub/clawker/internal/cmd/container/remove"
 	"github.com/schmitthub/clawker/internal/cmd/container/rename"
 	"github.com/schmitthub/clawker/internal/cmd/container/restart"
+	"github.com/schmitthub/clawker/internal/cmd/container/restore"
 	"github.com/schmitthub/clawker/internal/cmd/container/run"
 	"github.com/schmitthub/clawker/internal/cmd/container/start"
 	"github.com/schmitthub/clawker/internal/cmd/container/stats"
@@ -52,17 +58,23 @@ container management commands.`,
 
 	// Add subcommands
 	cmd.AddCommand(attach.NewCmdAttach(f, nil))
+	cmd.AddCommand(checkpoint.NewCmdCheckpoint(f, nil))
+	cmd.AddCommand(commit.NewCmdCommit(f, nil))
 	cmd.AddCommand(cp.NewCmdCp(f, nil))
 	cmd.AddCommand(create.NewCmdCreate(f, nil))
+	cmd.AddCommand(diff.NewCmdDiff(f, nil))
 	cmd.AddCommand(exec.NewCmdExec(f, nil))
 	cmd.AddCommand(inspect.NewCmdInspect(f, nil))
 	cmd.AddCommand(kill.NewCmdKill(f, nil))
 	cmd.AddCommand(list.NewCmdList(f, nil))
 	cmd.AddCommand(logs.NewCmdLogs(f, nil))
 	cmd.AddCommand(pause.NewCmdPause(f, nil))
+	cmd.AddCommand(port.NewCmdPort(f, nil))
+	cmd.AddCommand(prune.NewCmdPrune(f, nil))
 	cmd.AddCommand(remove.NewCmdRemove(f, nil))
 	cmd.AddCommand(rename.NewCmdRename(f, nil))
 	cmd.AddCommand(restart.NewCmdRestart(f, nil))
+	cmd.AddCommand(restore.NewCmdRestore(f, nil))
 	cmd.AddCommand(run.NewCmdRun(f, nil))
 	cmd.AddCommand(start.NewCmdStart(f, nil))
 	cmd.AddCommand(stats.NewCmdStats(f, nil))
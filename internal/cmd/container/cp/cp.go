@@ -9,12 +9,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/text"
 	"github.com/spf13/cobra"
 )
 
@@ -28,6 +30,7 @@ type CpOptions struct {
 	Archive    bool
 	FollowLink bool
 	CopyUIDGID bool
+	Quiet      bool
 
 	Src string
 	Dst string
@@ -50,6 +53,10 @@ Use '-' as the destination to write a tar archive of the container source
 to stdout. Use '-' as the source to read a tar archive from stdin and
 extract it to a directory destination in a container.
 
+Directories are streamed as a tar archive, so large trees copy without
+staging on disk. When stderr is a terminal, the running total of bytes
+copied is shown; use --quiet to suppress it.
+
 When --agent is provided, container names in CONTAINER:PATH are resolved
 as agent names (clawker.<project>.<agent>).
 
@@ -70,6 +77,9 @@ Local path format: PATH`,
   # Copy directory from container to local
   clawker container cp --agent dev:/app/logs ./logs
 
+  # Copy a directory into the container, preserving ownership
+  clawker container cp -a --agent ./data dev:/app/data
+
   # Stream tar from container to stdout
   clawker container cp --agent dev:/app - > backup.tar`,
 		Args: cobra.ExactArgs(2),
@@ -87,6 +97,7 @@ Local path format: PATH`,
 	cmd.Flags().BoolVarP(&opts.Archive, "archive", "a", false, "Archive mode (copy all uid/gid information)")
 	cmd.Flags().BoolVarP(&opts.FollowLink, "follow-link", "L", false, "Always follow symbol link in SRC_PATH")
 	cmd.Flags().BoolVar(&opts.CopyUIDGID, "copy-uidgid", false, "Copy UID/GID from source to destination (same as -a)")
+	cmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Suppress progress output during copy (always suppressed without a terminal)")
 
 	return cmd
 }
@@ -180,7 +191,7 @@ func copyFromContainer(ctx context.Context, client *docker.Client, containerName
 	}
 	defer copyResult.Content.Close()
 
-	// If destination is stdout, just copy the tar
+	// If destination is stdout, just copy the tar (no progress: stdout is the data)
 	if dstPath == "-" {
 		if _, err := io.Copy(opts.IOStreams.Out, copyResult.Content); err != nil {
 			return fmt.Errorf("streaming from container %q to stdout: %w", containerName, err)
@@ -189,7 +200,13 @@ func copyFromContainer(ctx context.Context, client *docker.Client, containerName
 	}
 
 	// Extract tar to destination
-	return extractTar(copyResult.Content, dstPath, copyResult.Stat.Name, opts)
+	progress := newCopyProgress(opts, "Copying from container")
+	if err := extractTar(progress.reader(copyResult.Content), dstPath, copyResult.Stat.Name, opts); err != nil {
+		progress.clear()
+		return err
+	}
+	progress.done(dstPath)
+	return nil
 }
 
 func copyToContainer(ctx context.Context, client *docker.Client, containerName, srcPath, dstPath string, opts *CpOptions) error {
@@ -202,17 +219,22 @@ func copyToContainer(ctx context.Context, client *docker.Client, containerName,
 		return fmt.Errorf("container %q not found", containerName)
 	}
 
+	progress := newCopyProgress(opts, "Copying to container")
+	dest := containerName + ":" + dstPath
+
 	// If source is stdin, read tar directly
 	if srcPath == "-" {
 		copyOpts := docker.CopyToContainerOptions{
 			DestinationPath:           dstPath,
-			Content:                   opts.IOStreams.In,
+			Content:                   progress.reader(opts.IOStreams.In),
 			AllowOverwriteDirWithFile: true,
 			CopyUIDGID:                opts.Archive || opts.CopyUIDGID,
 		}
 		if _, err := client.CopyToContainer(ctx, c.ID, copyOpts); err != nil {
+			progress.clear()
 			return fmt.Errorf("copying to container %q: %w", containerName, err)
 		}
+		progress.done(dest)
 		return nil
 	}
 
@@ -225,16 +247,89 @@ func copyToContainer(ctx context.Context, client *docker.Client, containerName,
 	// Copy to container
 	copyOpts := docker.CopyToContainerOptions{
 		DestinationPath:           dstPath,
-		Content:                   tarReader,
+		Content:                   progress.reader(tarReader),
 		AllowOverwriteDirWithFile: true,
 		CopyUIDGID:                opts.Archive || opts.CopyUIDGID,
 	}
 	if _, err = client.CopyToContainer(ctx, c.ID, copyOpts); err != nil {
+		progress.clear()
 		return fmt.Errorf("copying to container %q: %w", containerName, err)
 	}
+	progress.done(dest)
 	return nil
 }
 
+// progressInterval throttles progress redraws so large trees don't flood the
+// terminal with one write per tar block.
+const progressInterval = 100 * time.Millisecond
+
+// copyProgress counts the archive bytes streamed through a copy and, when
+// stderr is a terminal and --quiet is unset, renders a running total there
+// (the same "Copying ... - <size>" / "Successfully copied" lines as docker cp).
+// The count is always kept so callers can report it; only rendering is gated.
+type copyProgress struct {
+	out      io.Writer
+	enabled  bool
+	label    string
+	total    int64
+	lastDraw time.Time
+}
+
+func newCopyProgress(opts *CpOptions, label string) *copyProgress {
+	ios := opts.IOStreams
+	p := &copyProgress{
+		out:     ios.ErrOut,
+		enabled: !opts.Quiet && ios.IsStderrTTY(),
+		label:   label,
+	}
+	if p.enabled {
+		fmt.Fprint(p.out, "Preparing to copy...")
+	}
+	return p
+}
+
+// reader wraps r so every byte read through it is counted. The copy reads
+// from a single goroutine, so the counter needs no locking.
+func (p *copyProgress) reader(r io.Reader) io.Reader {
+	return &progressReader{r: r, p: p}
+}
+
+func (p *copyProgress) add(n int) {
+	p.total += int64(n)
+	if !p.enabled || time.Since(p.lastDraw) < progressInterval {
+		return
+	}
+	p.lastDraw = time.Now()
+	fmt.Fprintf(p.out, "\r\033[K%s - %s", p.label, text.FormatBytes(p.total))
+}
+
+// done replaces the running line with the final total.
+func (p *copyProgress) done(dest string) {
+	if !p.enabled {
+		return
+	}
+	fmt.Fprintf(p.out, "\r\033[KSuccessfully copied %s to %s\n", text.FormatBytes(p.total), dest)
+}
+
+// clear erases the running line so an error message starts on a clean line.
+func (p *copyProgress) clear() {
+	if !p.enabled {
+		return
+	}
+	fmt.Fprint(p.out, "\r\033[K")
+}
+
+type progressReader struct {
+	r io.Reader
+	p *copyProgress
+}
+
+func (pr *progressReader) Read(b []byte) (int, error) {
+	n, err := pr.r.Read(b)
+	pr.p.add(n)
+	return n, err
+}
+
 // extractTar extracts a tar archive to a local path.
 func extractTar(reader io.Reader, dstPath, _ string, _ *CpOptions) error {
 	tr := tar.NewReader(reader)
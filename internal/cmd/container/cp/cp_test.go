@@ -5,19 +5,23 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/google/shlex"
+	moby "github.com/moby/moby/client"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/docker/mocks"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/text"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -67,6 +71,11 @@ func TestNewCmdCp(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "accepts 2 arg(s), received 1",
 		},
+		{
+			name:     "quiet flag",
+			input:    "-q ./dir mycontainer:/app",
+			wantOpts: CpOptions{Quiet: true, Src: "./dir", Dst: "mycontainer:/app"},
+		},
 		{
 			name:     "agent flag with container path",
 			input:    "--agent dev:/app/file.txt ./file.txt",
@@ -81,7 +90,7 @@ func TestNewCmdCp(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f := &cmdutil.Factory{}
+			f := cmdutiltest.NewFactory(t).Factory
 
 			var gotOpts *CpOptions
 			cmd := NewCmdCp(f, func(_ context.Context, opts *CpOptions) error {
@@ -114,6 +123,7 @@ func TestNewCmdCp(t *testing.T) {
 			require.Equal(t, tt.wantOpts.Archive, gotOpts.Archive)
 			require.Equal(t, tt.wantOpts.FollowLink, gotOpts.FollowLink)
 			require.Equal(t, tt.wantOpts.CopyUIDGID, gotOpts.CopyUIDGID)
+			require.Equal(t, tt.wantOpts.Quiet, gotOpts.Quiet)
 			require.Equal(t, tt.wantOpts.Src, gotOpts.Src)
 			require.Equal(t, tt.wantOpts.Dst, gotOpts.Dst)
 		})
@@ -121,7 +131,7 @@ func TestNewCmdCp(t *testing.T) {
 }
 
 func TestCmdCp_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdCp(f, nil)
 
 	// Test command basics
@@ -247,7 +257,7 @@ func TestCmdCp_ArgsParsing(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f := &cmdutil.Factory{}
+			f := cmdutiltest.NewFactory(t).Factory
 
 			var gotOpts *CpOptions
 			cmd := NewCmdCp(f, func(_ context.Context, opts *CpOptions) error {
@@ -273,18 +283,8 @@ func TestCmdCp_ArgsParsing(t *testing.T) {
 
 func testCpFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return configmocks.NewBlankConfig(), nil
-		},
-	}, in, out, errOut
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 func TestCpRun_CopyFromContainer_Stdout(t *testing.T) {
@@ -529,3 +529,53 @@ func TestExtractTar_SafeEntries(t *testing.T) {
 	_, err = os.Lstat(filepath.Join(dst, "subdir", "link"))
 	assert.NoError(t, err)
 }
+
+func TestCpRun_CopyToContainer_Progress(t *testing.T) {
+	srcDir := filepath.Join(t.TempDir(), "data")
+	require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "nested", "big.bin"), bytes.Repeat([]byte("x"), 64*1024), 0o644))
+
+	tests := []struct {
+		name     string
+		args     []string
+		tty      bool
+		wantLine bool
+	}{
+		{name: "tty shows total", args: []string{srcDir, "clawker.myapp.dev:/app"}, tty: true, wantLine: true},
+		{name: "quiet suppresses", args: []string{"-q", srcDir, "clawker.myapp.dev:/app"}, tty: true},
+		{name: "non-tty suppresses", args: []string{srcDir, "clawker.myapp.dev:/app"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+			fake.SetupFindContainer("clawker.myapp.dev", mocks.RunningContainerFixture("myapp", "dev"))
+
+			var streamed int64
+			fake.FakeAPI.CopyToContainerFn = func(_ context.Context, _ string, opts moby.CopyToContainerOptions) (moby.CopyToContainerResult, error) {
+				n, err := io.Copy(io.Discard, opts.Content)
+				streamed = n
+				return moby.CopyToContainerResult{}, err
+			}
+
+			f, _, out, errOut := testCpFactory(t, fake)
+			f.IOStreams.SetStderrTTY(tt.tty)
+
+			cmd := NewCmdCp(f, nil)
+			cmd.SetArgs(tt.args)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(out)
+			cmd.SetErr(errOut)
+
+			require.NoError(t, cmd.Execute())
+			require.Greater(t, streamed, int64(64*1024), "directory should be streamed as a tar archive")
+
+			if !tt.wantLine {
+				assert.Empty(t, errOut.String())
+				return
+			}
+			assert.Contains(t, errOut.String(), "Preparing to copy...")
+			assert.Contains(t, errOut.String(), "Successfully copied "+text.FormatBytes(streamed)+" to clawker.myapp.dev:/app\n")
+		})
+	}
+}
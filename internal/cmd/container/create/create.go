@@ -4,6 +4,7 @@ package create
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/schmitthub/clawker/internal/bundle"
 	"github.com/schmitthub/clawker/internal/cmd/container/shared"
@@ -129,6 +130,11 @@ func createRun(ctx context.Context, opts *CreateOptions) error {
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
+	// Fold in the project's agents.<name> override block, if any
+	cfg, err = config.ForAgent(cfg, containerOpts.Agent)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
 
 	// --- Phase A: Pre-progress (synchronous) ---
 
@@ -175,6 +181,18 @@ func createRun(ctx context.Context, opts *CreateOptions) error {
 		}
 	}
 
+	// Gate on flags that weaken the project's declared security posture
+	if err := shared.ConfirmSecurityOverrides(shared.SecurityGateOptions{
+		IOStreams: ios,
+		Prompter:  opts.Prompter,
+		Config:    cfg,
+		Options:   containerOpts,
+		Command:   "container create",
+		Project:   projectName,
+	}); err != nil {
+		return err
+	}
+
 	type outcome struct {
 		result *shared.CreateContainerResult
 		err    error
@@ -200,6 +218,7 @@ func createRun(ctx context.Context, opts *CreateOptions) error {
 			Log:             log,
 			Is256Color:      ios.Is256ColorSupported(),
 			IsTrueColor:     ios.IsTrueColorSupported(),
+			HookOut:         ios.ErrOut,
 		})
 		done <- outcome{r, err}
 	}()
@@ -211,6 +230,10 @@ func createRun(ctx context.Context, opts *CreateOptions) error {
 		return o.err
 	}
 
+	if r := o.result.RestoredSession; r != nil {
+		fmt.Fprintf(ios.ErrOut, "%s Restored the saved %s session from %s\n",
+			ios.ColorScheme().InfoIcon(), r.Harness, r.SavedAt.Local().Format(time.DateTime))
+	}
 	fmt.Fprintln(ios.Out, o.result.ContainerID[:12])
 	return nil
 }
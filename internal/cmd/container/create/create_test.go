@@ -18,6 +18,7 @@ import (
 	"github.com/schmitthub/clawker/internal/auth"
 	"github.com/schmitthub/clawker/internal/cmd/container/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -242,7 +243,7 @@ func TestNewCmdCreate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f := &cmdutil.Factory{}
+			f := cmdutiltest.NewFactory(t).Factory
 
 			var gotOpts *CreateOptions
 			cmd := NewCmdCreate(f, func(_ context.Context, opts *CreateOptions) error {
@@ -294,7 +295,7 @@ func TestNewCmdCreate(t *testing.T) {
 }
 
 func TestCmdCreate_MutuallyExclusiveFlags(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdCreate(f, func(_ context.Context, _ *CreateOptions) error {
 		return nil
 	})
@@ -426,33 +427,25 @@ func testFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes
 	// Ensure CWD is inside $HOME so IsOutsideHome returns false (matters in containers).
 	cwd, _ := os.Getwd()
 	t.Setenv("HOME", filepath.Dir(cwd))
-	tio, in, out, errOut := iostreams.Test()
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		TUI:       tui.NewTUI(tio),
-		// Isolated data dir (testenv.New above) → empty registry →
-		// not-in-project cwd fallback.
-		ProjectRegistry: func() (*project.Registry, error) { return project.NewRegistry() },
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			mock := configmocks.NewFromString(`
+	tf := cmdutiltest.NewFactory(t,
+		cmdutiltest.WithFakeClient(fake),
+		cmdutiltest.WithConfig(configmocks.NewFromString(`
 version: "1"
 workspace: { default_mode: "bind" }
 security: { enable_host_proxy: false }
 agent:
   claude_code:
     mount_projects: false
-`, "")
-			return mock, nil
-		},
-		HostProxy: func() hostproxy.Service {
-			return hostproxytest.NewMockManager()
-		},
-		Prompter: func() *prompter.Prompter { return prompter.NewPrompter(tio) },
-	}, in, out, errOut
+`, "")),
+	)
+	// Isolated data dir (testenv.New above) → empty registry →
+	// not-in-project cwd fallback.
+	tf.ProjectRegistry = func() (*project.Registry, error) { return project.NewRegistry() }
+	tf.HostProxy = func() hostproxy.Service {
+		return hostproxytest.NewMockManager()
+	}
+	tf.Prompter = func() *prompter.Prompter { return prompter.NewPrompter(tf.IOStreams) }
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 func TestCreateRun(t *testing.T) {
@@ -539,6 +532,43 @@ func TestCreateRun(t *testing.T) {
 		fake.AssertCalled(t, "ContainerCreate")
 	})
 
+	t.Run("security-weakening flag is refused without override", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fake.SetupContainerCreate()
+		fake.SetupCopyToContainer()
+
+		f, _, out, errOut := testFactory(t, fake)
+		cmd := NewCmdCreate(f, nil)
+
+		cmd.SetArgs([]string{"--privileged", "alpine"})
+		cmd.SetIn(&bytes.Buffer{})
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "--override-security")
+		fake.AssertNotCalled(t, "ContainerCreate")
+	})
+
+	t.Run("security-weakening flag proceeds with --override-security", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fake.SetupContainerCreate()
+		fake.SetupCopyToContainer()
+
+		f, _, out, errOut := testFactory(t, fake)
+		cmd := NewCmdCreate(f, nil)
+
+		cmd.SetArgs([]string{"--privileged", "--override-security", "alpine"})
+		cmd.SetIn(&bytes.Buffer{})
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+		fake.AssertCalled(t, "ContainerCreate")
+	})
+
 	// NOTE: Onboarding bypass tests removed — onboarding is now handled at image level
 	// (CP's seed-apply step places the harness's .config.json seed from ~/.clawker/seed/).
 	// CopyToContainer is no longer called for onboarding injection.
@@ -0,0 +1,255 @@
+// Package diff provides the container diff command.
+package diff
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
+	wsshared "github.com/schmitthub/clawker/internal/cmd/workspace/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// DiffOptions holds options for the diff command.
+type DiffOptions struct {
+	IOStreams       *iostreams.IOStreams
+	Client          func(context.Context) (*docker.Client, error)
+	Config          func() (config.Config, error)
+	ProjectManager  func() (project.ProjectManager, error)
+	ProjectRegistry func() (*project.Registry, error)
+
+	Agent         bool
+	Paths         []string
+	IncludeMounts bool
+
+	container string
+}
+
+// NewCmdDiff creates a new diff command.
+func NewCmdDiff(f *cmdutil.Factory, runF func(context.Context, *DiffOptions) error) *cobra.Command {
+	opts := &DiffOptions{
+		IOStreams:       f.IOStreams,
+		Client:          f.Client,
+		Config:          f.Config,
+		ProjectManager:  f.ProjectManager,
+		ProjectRegistry: f.ProjectRegistry,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff [OPTIONS] CONTAINER",
+		Short: "Inspect changes to files or directories on a container's filesystem",
+		Long: `List the files and directories changed in a clawker container's writable
+layer since it was created from its image, and for a snapshot-mode agent,
+the files the agent changed in its workspace.
+
+Each line is prefixed with the kind of change:
+  A  the file or directory was added
+  C  the file or directory was changed
+  D  the file or directory was deleted
+
+The workspace volume of a snapshot-mode agent is compared against what the
+container last received — the snapshot taken on create, or the last
+"clawker workspace sync" — the way "clawker workspace pull --dry-run" does,
+and its changes are listed under the workspace path. Other volume and bind
+mounts, such as a bind-mode workspace or the agent's config and history
+volumes, are not tracked. Layer entries at or below a mount point are omitted
+by default because they only record the daemon creating the mount target;
+use --include-mounts to show them.
+
+Use --path to limit output to changes at or below one or more paths.
+
+When --agent is provided, the container name is resolved as clawker.<project>.<agent>
+using the project resolved from the current directory.
+
+Container name can be:
+  - Full name: clawker.myproject.myagent
+  - Container ID: abc123...`,
+		Example: `  # Show what changed in an agent's container
+  clawker container diff --agent dev
+
+  # Only show changes under /etc and /usr/local
+  clawker container diff --agent dev --path /etc --path /usr/local
+
+  # Show changes by full container name
+  clawker container diff clawker.myapp.dev`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			for _, p := range opts.Paths {
+				if !path.IsAbs(p) {
+					return cmdutil.FlagErrorf("--path must be an absolute path: %q", p)
+				}
+			}
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return diffRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Agent, "agent", false, "Treat argument as agent name (resolves to clawker.<project>.<agent>)")
+	cmd.Flags().StringArrayVar(&opts.Paths, "path", nil, "Only show changes at or below this absolute path (repeatable)")
+	cmd.Flags().BoolVar(&opts.IncludeMounts, "include-mounts", false, "Show entries at or below mount points")
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.ContainerCompletions(f.Client, f.ProjectManager))
+
+	return cmd
+}
+
+func diffRun(ctx context.Context, opts *DiffOptions) error {
+	ios := opts.IOStreams
+	containerName := opts.container
+
+	if opts.Agent {
+		var projectName string
+		if opts.ProjectManager != nil {
+			if pm, pmErr := opts.ProjectManager(); pmErr == nil {
+				if p, pErr := pm.CurrentProject(ctx); pErr == nil {
+					projectName = p.Name()
+				}
+			}
+		}
+		containers, err := docker.ContainerNamesFromAgents(projectName, []string{containerName})
+		if err != nil {
+			return err
+		}
+		containerName = containers[0]
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+
+	c, err := client.FindContainerByName(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("failed to find container %q: %w", containerName, err)
+	}
+	if c == nil {
+		return fmt.Errorf("container %q not found", containerName)
+	}
+
+	result, err := client.ContainerDiff(ctx, c.ID)
+	if err != nil {
+		return fmt.Errorf("getting changes for container %q: %w", containerName, err)
+	}
+
+	var mounts []string
+	if !opts.IncludeMounts {
+		for _, m := range c.Mounts {
+			mounts = append(mounts, m.Destination)
+		}
+	}
+
+	changes := filterChanges(result.Changes, opts.Paths, mounts)
+
+	var workspaceListed bool
+	if hasWorkspaceVolume(c) {
+		ws, err := workspaceChanges(ctx, client, opts, containerName)
+		if err != nil {
+			cs := ios.ColorScheme()
+			fmt.Fprintf(ios.ErrOut, "%s workspace changes not listed: %v\n", cs.WarningIcon(), err)
+		} else {
+			workspaceListed = true
+			changes = append(changes, filterChanges(ws, opts.Paths, nil)...)
+		}
+	}
+
+	if len(changes) == 0 {
+		if workspaceListed {
+			fmt.Fprintln(ios.ErrOut, "No changes in the container layer or workspace.")
+		} else {
+			fmt.Fprintln(ios.ErrOut, "No changes in the container layer.")
+		}
+		return nil
+	}
+
+	for _, ch := range changes {
+		fmt.Fprintf(ios.Out, "%s %s\n", ch.Kind, ch.Path)
+	}
+	return nil
+}
+
+// hasWorkspaceVolume reports whether c mounts its agent's workspace volume,
+// i.e. is a snapshot-mode agent.
+func hasWorkspaceVolume(c *container.Summary) bool {
+	volume, err := docker.VolumeName(c.Labels[consts.LabelProject], c.Labels[consts.LabelAgent], docker.VolumePurposeWorkspace)
+	if err != nil {
+		return false
+	}
+	for _, m := range c.Mounts {
+		if m.Type == mount.TypeVolume && m.Name == volume {
+			return true
+		}
+	}
+	return false
+}
+
+// pullKinds maps workspace change kinds onto the container layer's.
+var pullKinds = map[docker.PullKind]container.ChangeType{
+	docker.PullAdded:    container.ChangeAdd,
+	docker.PullModified: container.ChangeModify,
+	docker.PullDeleted:  container.ChangeDelete,
+}
+
+// workspaceChanges lists what the agent changed in its workspace volume,
+// as absolute container paths.
+func workspaceChanges(ctx context.Context, client *docker.Client, opts *DiffOptions, name string) ([]container.FilesystemChange, error) {
+	cfg, err := opts.Config()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	target, err := wsshared.FindTarget(ctx, client, cfg, opts.ProjectRegistry, name)
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.PullWorkspace(ctx, target.ID, target.Src, target.Dest, target.Ignore, target.Created)
+	if err != nil {
+		return nil, fmt.Errorf("reading workspace: %w", err)
+	}
+	changes := make([]container.FilesystemChange, 0, len(result.Changes))
+	for _, ch := range result.Changes {
+		changes = append(changes, container.FilesystemChange{
+			Kind: pullKinds[ch.Kind],
+			Path: path.Join(target.Dest, ch.Path),
+		})
+	}
+	return changes, nil
+}
+
+// filterChanges keeps changes at or below any of include (all changes when
+// include is empty) and drops changes at or below any of exclude.
+func filterChanges(changes []container.FilesystemChange, include, exclude []string) []container.FilesystemChange {
+	var out []container.FilesystemChange
+	for _, ch := range changes {
+		if len(include) > 0 && !underAny(ch.Path, include) {
+			continue
+		}
+		if underAny(ch.Path, exclude) {
+			continue
+		}
+		out = append(out, ch)
+	}
+	return out
+}
+
+// underAny reports whether p equals or is nested below any of roots.
+func underAny(p string, roots []string) bool {
+	p = path.Clean(p)
+	for _, root := range roots {
+		root = path.Clean(root)
+		if root == "/" || p == root || strings.HasPrefix(p, root+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package diff
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/moby/moby/api/types/container"
+	"github.com/schmitthub/clawker/internal/cmd/workspace/workspacetest"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdDiff(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantAgent  bool
+		wantPaths  []string
+		wantMounts bool
+		wantErr    string
+	}{
+		{name: "container name", input: "clawker.myapp.dev"},
+		{name: "agent flag", input: "--agent dev", wantAgent: true},
+		{
+			name:      "repeated path filter",
+			input:     "--path /etc --path /usr/local dev",
+			wantPaths: []string{"/etc", "/usr/local"},
+		},
+		{name: "include mounts", input: "--include-mounts dev", wantMounts: true},
+		{name: "relative path rejected", input: "--path etc dev", wantErr: "--path must be an absolute path"},
+		{name: "no arguments", input: "", wantErr: "requires 1 argument"},
+		{name: "too many arguments", input: "a b", wantErr: "requires 1 argument"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *DiffOptions
+			cmd := NewCmdDiff(f, func(_ context.Context, opts *DiffOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAgent, gotOpts.Agent)
+			assert.Equal(t, tt.wantPaths, gotOpts.Paths)
+			assert.Equal(t, tt.wantMounts, gotOpts.IncludeMounts)
+		})
+	}
+}
+
+// --- Tier 2 tests (Cobra+Factory, real run function) ---
+
+func testFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	return tf.Factory, tf.Out, tf.ErrOut
+}
+
+func snapshotContainerFixture() container.Summary {
+	c := mocks.RunningContainerFixture("myapp", "dev")
+	c.Mounts = []container.MountPoint{
+		{Type: "volume", Name: "clawker.myapp.dev-workspace", Destination: "/workspace"},
+		{Type: "volume", Name: "clawker.myapp.dev-config", Destination: "/home/claude/.claude"},
+	}
+	return c
+}
+
+func runDiff(t *testing.T, fake *mocks.FakeClient, args ...string) (string, string, error) {
+	t.Helper()
+	f, out, errOut := testFactory(t, fake)
+	cmd := NewCmdDiff(f, nil)
+	cmd.SetArgs(args)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	return out.String(), errOut.String(), err
+}
+
+func TestDiffRun_OmitsMountPoints(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupFindContainer("clawker.myapp.dev", snapshotContainerFixture())
+	fake.SetupContainerDiff(
+		container.FilesystemChange{Kind: container.ChangeModify, Path: "/home/claude"},
+		container.FilesystemChange{Kind: container.ChangeAdd, Path: "/home/claude/.claude"},
+		container.FilesystemChange{Kind: container.ChangeAdd, Path: "/workspace"},
+		container.FilesystemChange{Kind: container.ChangeAdd, Path: "/workspaces"},
+		container.FilesystemChange{Kind: container.ChangeAdd, Path: "/usr/local/bin/rg"},
+		container.FilesystemChange{Kind: container.ChangeDelete, Path: "/etc/motd"},
+	)
+
+	out, _, err := runDiff(t, fake, "clawker.myapp.dev")
+	require.NoError(t, err)
+	assert.Equal(t, "C /home/claude\nA /workspaces\nA /usr/local/bin/rg\nD /etc/motd\n", out)
+	fake.AssertCalled(t, "ContainerDiff")
+}
+
+func TestDiffRun_IncludeMountsAndPathFilter(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupFindContainer("clawker.myapp.dev", snapshotContainerFixture())
+	fake.SetupContainerDiff(
+		container.FilesystemChange{Kind: container.ChangeAdd, Path: "/workspace"},
+		container.FilesystemChange{Kind: container.ChangeAdd, Path: "/usr/local/bin/rg"},
+		container.FilesystemChange{Kind: container.ChangeModify, Path: "/usr/local"},
+		container.FilesystemChange{Kind: container.ChangeDelete, Path: "/etc/motd"},
+	)
+
+	out, _, err := runDiff(t, fake, "--include-mounts", "--path", "/workspace", "--path", "/usr/local/", "clawker.myapp.dev")
+	require.NoError(t, err)
+	assert.Equal(t, "A /workspace\nA /usr/local/bin/rg\nC /usr/local\n", out)
+}
+
+func TestDiffRun_NoChanges(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	c := mocks.RunningContainerFixture("myapp", "dev")
+	c.Mounts = []container.MountPoint{{Type: "bind", Source: "/src/myapp", Destination: "/workspace"}}
+	fake.SetupFindContainer("clawker.myapp.dev", c)
+	fake.SetupContainerDiff(container.FilesystemChange{Kind: container.ChangeAdd, Path: "/workspace"})
+
+	out, errOut, err := runDiff(t, fake, "clawker.myapp.dev")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+	assert.Equal(t, "No changes in the container layer.\n", errOut)
+}
+
+func TestDiffRun_SnapshotWorkspace(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{Stopped: true})
+	fx.Fake.SetupContainerDiff(
+		container.FilesystemChange{Kind: container.ChangeAdd, Path: "/workspace"},
+		container.FilesystemChange{Kind: container.ChangeAdd, Path: "/usr/local/bin/rg"},
+	)
+	run := func(args ...string) (string, string) {
+		t.Helper()
+		tf := cmdutiltest.NewFactory(t, cmdutiltest.WithConfig(fx.Fake.Cfg), cmdutiltest.WithFakeClient(fx.Fake))
+		cmd := NewCmdDiff(tf.Factory, nil)
+		cmd.SetArgs(args)
+		cmd.SetIn(&bytes.Buffer{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		require.NoError(t, cmd.Execute())
+		return tf.Out.String(), tf.ErrOut.String()
+	}
+
+	// Untouched since the snapshot: only the layer change shows.
+	out, errOut := run(workspacetest.ContainerName)
+	assert.Equal(t, "A /usr/local/bin/rg\n", out)
+	assert.Empty(t, errOut)
+
+	// The agent's edits to the workspace volume show under its mount path.
+	workspacetest.WriteFiles(t, fx.Workspace("."), map[string]string{
+		"main.go":  "package main\n\nfunc main() {}\n",
+		"notes.md": "agent notes\n",
+	})
+	require.NoError(t, os.Remove(fx.Workspace("README.md")))
+
+	out, _ = run(workspacetest.ContainerName)
+	assert.Equal(t, "A /usr/local/bin/rg\nD /workspace/README.md\nC /workspace/main.go\nA /workspace/notes.md\n", out)
+
+	out, _ = run("--path", "/workspace/main.go", workspacetest.ContainerName)
+	assert.Equal(t, "C /workspace/main.go\n", out)
+}
+
+func TestDiffRun_ContainerNotFound(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList()
+
+	_, _, err := runDiff(t, fake, "clawker.myapp.missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "clawker.myapp.missing")
+	fake.AssertNotCalled(t, "ContainerDiff")
+}
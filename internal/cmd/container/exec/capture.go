@@ -0,0 +1,120 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+)
+
+const (
+	outputJSON = "json"
+
+	// execResultKind is the JSON envelope kind of an execResult.
+	execResultKind = "container.exec"
+
+	// defaultOutputLimit is the number of bytes kept per stream in batch mode.
+	defaultOutputLimit = 1 << 20
+)
+
+// execResult is the data of the envelope --output json prints.
+type execResult struct {
+	Container  string         `json:"container"`
+	Command    []string       `json:"command"`
+	ExitCode   int            `json:"exitCode"`
+	DurationMs int64          `json:"durationMs"`
+	Stdout     capturedStream `json:"stdout"`
+	Stderr     capturedStream `json:"stderr"`
+}
+
+// capturedStream is one captured output stream. Bytes counts everything the
+// command wrote; Data holds the first --output-limit bytes of it.
+type capturedStream struct {
+	Data      string `json:"data"`
+	Bytes     int64  `json:"bytes"`
+	Truncated bool   `json:"truncated"`
+}
+
+// limitedBuffer keeps the first limit bytes written to it and counts the
+// rest. It never returns a short write, so the demuxer keeps draining the
+// stream past the limit.
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+	total int64
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.total += int64(len(p))
+	if room := b.limit - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(room, len(p))])
+	}
+	return len(p), nil
+}
+
+func (b *limitedBuffer) stream() capturedStream {
+	return capturedStream{
+		Data:      b.buf.String(),
+		Bytes:     b.total,
+		Truncated: b.total > int64(b.buf.Len()),
+	}
+}
+
+// execCapture runs the exec in batch mode: it attaches without a TTY,
+// captures stdout and stderr separately, and prints an execResult in a
+// versioned JSON envelope once the command finishes. A non-zero exit is
+// returned as a cmdutil.ExitError so clawker exits with the command's code.
+func execCapture(ctx context.Context, client *docker.Client, execID string, opts *ExecOptions) error {
+	ios := opts.IOStreams
+	start := time.Now()
+
+	hijacked, err := client.ExecAttach(ctx, execID, docker.ExecAttachOptions{})
+	if err != nil {
+		return fmt.Errorf("attaching to exec: %w", err)
+	}
+	defer hijacked.Close()
+
+	if opts.Interactive {
+		go func() {
+			io.Copy(hijacked.Conn, ios.In)
+			hijacked.CloseWrite()
+		}()
+	}
+
+	stdout := &limitedBuffer{limit: opts.OutputLimit}
+	stderr := &limitedBuffer{limit: opts.OutputLimit}
+	if _, err := stdcopy.StdCopy(stdout, stderr, hijacked.Reader); err != nil && err != io.EOF {
+		return fmt.Errorf("reading exec output: %w", err)
+	}
+	duration := time.Since(start)
+
+	inspect, err := client.ExecInspect(ctx, execID, docker.ExecInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("reading exec exit code: %w", err)
+	}
+
+	result := execResult{
+		Container:  opts.containerName,
+		Command:    opts.command,
+		ExitCode:   inspect.ExitCode,
+		DurationMs: duration.Milliseconds(),
+		Stdout:     stdout.stream(),
+		Stderr:     stderr.stream(),
+	}
+	if result.Command == nil {
+		result.Command = []string{}
+	}
+	if err := ios.JSONPrinter().Print(execResultKind, result); err != nil {
+		return fmt.Errorf("writing json: %w", err)
+	}
+
+	if result.ExitCode != 0 {
+		return &cmdutil.ExitError{Code: result.ExitCode}
+	}
+	return nil
+}
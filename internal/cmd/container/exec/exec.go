@@ -3,10 +3,12 @@ package exec
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
 	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/schmitthub/clawker/internal/cmd/container/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -35,6 +37,9 @@ type ExecOptions struct {
 	Workdir     string
 	User        string
 	Privileged  bool
+	DetachKeys  string
+	Output      string
+	OutputLimit int
 
 	containerName string
 	command       []string
@@ -57,7 +62,16 @@ func NewCmdExec(f *cmdutil.Factory, runF func(context.Context, *ExecOptions) err
 		Long: `Execute a command in a running clawker container.
 
 This creates a new process inside the container and connects to it.
-Use -it flags for an interactive shell session.
+Use -it flags for an interactive shell session. Use ctrl-p, ctrl-q to
+detach from an interactive session and leave the command running;
+override the sequence with --detach-keys or settings.terminal.detach_keys.
+
+With --output json the command runs in batch mode for scripts and CI: stdout
+and stderr are captured separately and a single versioned JSON envelope of
+kind "container.exec" is printed once the command finishes. Its data holds
+the exit code, duration, and each stream with its total byte count and
+whether it was truncated at --output-limit bytes. clawker exits with the
+command's exit code. --output json cannot be combined with --tty or --detach.
 
 When --agent is provided, the container name is resolved as clawker.<project>.<agent>
 using the project resolved from the current directory.
@@ -84,9 +98,15 @@ Container name can be:
   clawker container exec -u root clawker.myapp.dev whoami
 
   # Run in a specific directory
-  clawker container exec -w /tmp clawker.myapp.dev pwd`,
+  clawker container exec -w /tmp clawker.myapp.dev pwd
+
+  # Capture output and exit code as JSON (for CI)
+  clawker container exec --output json --agent dev go test ./...`,
 		Args: cmdutil.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputFlags(cmd, opts); err != nil {
+				return err
+			}
 			opts.containerName = args[0]
 			if opts.Agent {
 				var projectName string
@@ -122,11 +142,16 @@ Container name can be:
 	cmd.Flags().StringVarP(&opts.Workdir, "workdir", "w", "", "Working directory inside the container")
 	cmd.Flags().StringVarP(&opts.User, "user", "u", "", "Username or UID (format: <name|uid>[:<group|gid>])")
 	cmd.Flags().BoolVar(&opts.Privileged, "privileged", false, "Give extended privileges to the command")
+	cmd.Flags().StringVar(&opts.DetachKeys, "detach-keys", "", "Override the key sequence for detaching a container (e.g. ctrl-a,d)")
+	cmd.Flags().StringVar(&opts.Output, "output", "", `Capture the command's output and report it as "json"`)
+	cmd.Flags().IntVar(&opts.OutputLimit, "output-limit", defaultOutputLimit, "Bytes kept per stream with --output json")
 
 	// Stop parsing flags after the first positional argument (CONTAINER)
 	// so that command flags like "sh -c" are passed to the command, not Cobra
 	cmd.Flags().SetInterspersed(false)
 
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.ContainerCompletions(f.Client, f.ProjectManager))
+
 	return cmd
 }
 
@@ -140,6 +165,21 @@ func execRun(ctx context.Context, opts *ExecOptions) error {
 		return fmt.Errorf("initializing logger: %w", err)
 	}
 
+	// Settings only supply the default; an unloadable config must not block
+	// the exec.
+	var cfg config.Config
+	if opts.Config != nil {
+		if c, cfgErr := opts.Config(); cfgErr == nil {
+			cfg = c
+		} else {
+			log.Debug().Err(cfgErr).Msg("config unavailable; using default detach keys")
+		}
+	}
+	detachSpec, detachKeys, err := shared.ResolveDetachKeys(opts.DetachKeys, cfg)
+	if err != nil {
+		return err
+	}
+
 	// Connect to Docker
 	client, err := opts.Client(ctx)
 	if err != nil {
@@ -168,6 +208,7 @@ func execRun(ctx context.Context, opts *ExecOptions) error {
 		WorkingDir:   opts.Workdir,
 		User:         opts.User,
 		Privileged:   opts.Privileged,
+		DetachKeys:   detachSpec,
 	}
 
 	// Create exec instance
@@ -194,10 +235,15 @@ func execRun(ctx context.Context, opts *ExecOptions) error {
 		return nil
 	}
 
+	if opts.Output == outputJSON {
+		return execCapture(ctx, client, execID, opts)
+	}
+
 	// Set up TTY if needed
 	var pty *docker.PTYHandler
 	if opts.TTY {
 		pty = docker.NewPTYHandler(log)
+		pty.SetDetachKeys(detachKeys)
 		if err := pty.Setup(); err != nil {
 			return fmt.Errorf("failed to set up terminal: %w", err)
 		}
@@ -248,11 +294,18 @@ func execRun(ctx context.Context, opts *ExecOptions) error {
 
 			// Monitor for window resize events (SIGWINCH)
 			resizeHandler := signals.NewResizeHandler(resizeFunc, pty.GetSize)
+			resizeHandler.SetErrorHandler(func(err error) {
+				log.Debug().Err(err).Msg("failed to resize exec TTY")
+			})
 			resizeHandler.Start()
 			defer resizeHandler.Stop()
 		}
 
 		if err := <-streamDone; err != nil {
+			if errors.Is(err, docker.ErrDetached) {
+				// The command keeps running; there is no exit code yet.
+				return nil
+			}
 			return err
 		}
 		// Check exit code after TTY mode completes
@@ -286,6 +339,31 @@ func execRun(ctx context.Context, opts *ExecOptions) error {
 	return checkExecExitCode(ctx, client, execID, log)
 }
 
+// validateOutputFlags rejects --output values and flag combinations batch
+// mode cannot honor.
+func validateOutputFlags(cmd *cobra.Command, opts *ExecOptions) error {
+	switch opts.Output {
+	case "":
+		if cmd.Flags().Changed("output-limit") {
+			return cmdutil.FlagErrorf("--output-limit requires --output json")
+		}
+		return nil
+	case outputJSON:
+	default:
+		return cmdutil.FlagErrorf("invalid --output %q: only \"json\" is supported", opts.Output)
+	}
+	if opts.TTY {
+		return cmdutil.FlagErrorf("--output json cannot be used with --tty")
+	}
+	if opts.Detach {
+		return cmdutil.FlagErrorf("--output json cannot be used with --detach")
+	}
+	if opts.OutputLimit < 0 {
+		return cmdutil.FlagErrorf("--output-limit must not be negative")
+	}
+	return nil
+}
+
 // checkExecExitCode inspects the exec and returns an error if exit code is non-zero.
 func checkExecExitCode(ctx context.Context, client *docker.Client, execID string, log *logger.Logger) error {
 	inspect, err := client.ExecInspect(ctx, execID, docker.ExecInspectOptions{})
@@ -3,11 +3,15 @@ package exec
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/google/shlex"
+	"github.com/moby/moby/client"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -51,6 +55,11 @@ func TestNewCmdExec(t *testing.T) {
 			input:    "--detach mycontainer sleep 100",
 			wantOpts: ExecOptions{Detach: true, containerName: "mycontainer", command: []string{"sleep", "100"}},
 		},
+		{
+			name:     "detach-keys flag",
+			input:    "-it --detach-keys ctrl-a,d mycontainer /bin/sh",
+			wantOpts: ExecOptions{Interactive: true, TTY: true, DetachKeys: "ctrl-a,d", containerName: "mycontainer", command: []string{"/bin/sh"}},
+		},
 		{
 			name:     "env flag",
 			input:    "-e FOO=bar mycontainer env",
@@ -92,6 +101,41 @@ func TestNewCmdExec(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "exec: 'exec' requires at least 1 argument\n\nUsage:  exec [OPTIONS] [CONTAINER] COMMAND [ARG...] [flags]\n\nSee 'exec --help' for more information",
 		},
+		{
+			name:     "output json",
+			input:    "--output json -i mycontainer go test ./...",
+			wantOpts: ExecOptions{Interactive: true, Output: "json", OutputLimit: defaultOutputLimit, containerName: "mycontainer", command: []string{"go", "test", "./..."}},
+		},
+		{
+			name:       "output rejects unknown format",
+			input:      "--output yaml mycontainer ls",
+			wantErr:    true,
+			wantErrMsg: `invalid --output "yaml": only "json" is supported`,
+		},
+		{
+			name:       "output json rejects tty",
+			input:      "--output json -t mycontainer ls",
+			wantErr:    true,
+			wantErrMsg: "--output json cannot be used with --tty",
+		},
+		{
+			name:       "output json rejects detach",
+			input:      "--output json --detach mycontainer ls",
+			wantErr:    true,
+			wantErrMsg: "--output json cannot be used with --detach",
+		},
+		{
+			name:       "output-limit requires output json",
+			input:      "--output-limit 10 mycontainer ls",
+			wantErr:    true,
+			wantErrMsg: "--output-limit requires --output json",
+		},
+		{
+			name:       "negative output-limit",
+			input:      "--output json --output-limit -1 mycontainer ls",
+			wantErr:    true,
+			wantErrMsg: "--output-limit must not be negative",
+		},
 		{
 			name:     "container only (now valid - container is arg, no command)",
 			input:    "mycontainer",
@@ -138,6 +182,7 @@ func TestNewCmdExec(t *testing.T) {
 			require.Equal(t, tt.wantOpts.Interactive, gotOpts.Interactive)
 			require.Equal(t, tt.wantOpts.TTY, gotOpts.TTY)
 			require.Equal(t, tt.wantOpts.Detach, gotOpts.Detach)
+			require.Equal(t, tt.wantOpts.DetachKeys, gotOpts.DetachKeys)
 			// Compare env slices - handle nil vs empty slice
 			if len(tt.wantOpts.Env) == 0 {
 				require.Empty(t, gotOpts.Env)
@@ -147,6 +192,7 @@ func TestNewCmdExec(t *testing.T) {
 			require.Equal(t, tt.wantOpts.Workdir, gotOpts.Workdir)
 			require.Equal(t, tt.wantOpts.User, gotOpts.User)
 			require.Equal(t, tt.wantOpts.Privileged, gotOpts.Privileged)
+			require.Equal(t, tt.wantOpts.Output, gotOpts.Output)
 			// Verify container name and command are populated (when not using --agent which needs Resolution)
 			if !tt.wantOpts.Agent {
 				require.Equal(t, tt.wantOpts.containerName, gotOpts.containerName)
@@ -159,7 +205,7 @@ func TestNewCmdExec(t *testing.T) {
 }
 
 func TestCmdExec_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdExec(f, nil)
 
 	// Test command basics
@@ -216,7 +262,7 @@ func TestCmdExec_ArgsParsing(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f := &cmdutil.Factory{}
+			f := cmdutiltest.NewFactory(t).Factory
 
 			var gotOpts *ExecOptions
 			cmd := NewCmdExec(f, func(_ context.Context, opts *ExecOptions) error {
@@ -260,17 +306,8 @@ func ptrBool(b bool) *bool { return &b }
 
 func testFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return testConfig(), nil
-		},
-	}, in, out, errOut
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake), cmdutiltest.WithConfig(testConfig()))
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 func TestExecRun_DockerConnectionError(t *testing.T) {
@@ -398,3 +435,113 @@ func TestExecRun_NonZeroExitCode(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "exited with code 42")
 }
+
+func runCapture(t *testing.T, fake *mocks.FakeClient, args ...string) (string, error) {
+	t.Helper()
+	f, _, out, _ := testFactory(t, fake)
+	cmd := NewCmdExec(f, nil)
+	cmd.SetArgs(args)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestExecRun_OutputJSON(t *testing.T) {
+	fixture := mocks.RunningContainerFixture("myapp", "dev")
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdout   string
+		stderr   string
+		exitCode int
+		want     execResult
+	}{
+		{
+			name:   "captures streams separately",
+			args:   []string{"--output", "json", "clawker.myapp.dev", "make", "test"},
+			stdout: "ok\n",
+			stderr: "warning: cache miss\n",
+			want: execResult{
+				Container: "clawker.myapp.dev",
+				Command:   []string{"make", "test"},
+				Stdout:    capturedStream{Data: "ok\n", Bytes: 3},
+				Stderr:    capturedStream{Data: "warning: cache miss\n", Bytes: 20},
+			},
+		},
+		{
+			name:     "truncates at the limit and reports the exit code",
+			args:     []string{"--output", "json", "--output-limit", "4", "clawker.myapp.dev", "false"},
+			stdout:   "0123456789",
+			exitCode: 3,
+			want: execResult{
+				Container: "clawker.myapp.dev",
+				Command:   []string{"false"},
+				ExitCode:  3,
+				Stdout:    capturedStream{Data: "0123", Bytes: 10, Truncated: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+			fake.SetupContainerList(fixture)
+			fake.SetupExecCreate("exec-json")
+			fake.SetupExecAttachWithStreams(tt.stdout, tt.stderr)
+			fake.SetupExecInspect(tt.exitCode)
+
+			out, err := runCapture(t, fake, tt.args...)
+			if tt.exitCode != 0 {
+				var exitErr *cmdutil.ExitError
+				require.ErrorAs(t, err, &exitErr)
+				assert.Equal(t, tt.exitCode, exitErr.Code)
+			} else {
+				require.NoError(t, err)
+			}
+
+			var env struct {
+				iostreams.JSONEnvelope
+				Data execResult `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(out), &env))
+			assert.Equal(t, iostreams.JSONSchemaVersion, env.SchemaVersion)
+			assert.Equal(t, "container.exec", env.Kind)
+			got := env.Data
+			assert.GreaterOrEqual(t, got.DurationMs, int64(0))
+			got.DurationMs = 0
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExecRun_OutputJSONInspectError(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList(mocks.RunningContainerFixture("myapp", "dev"))
+	fake.SetupExecCreate("exec-json")
+	fake.SetupExecAttachWithStreams("partial", "")
+	fake.FakeAPI.ExecInspectFn = func(_ context.Context, _ string, _ client.ExecInspectOptions) (client.ExecInspectResult, error) {
+		return client.ExecInspectResult{}, errors.New("daemon went away")
+	}
+
+	out, err := runCapture(t, fake, "--output", "json", "clawker.myapp.dev", "ls")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reading exec exit code")
+	assert.Empty(t, out, "no document without an exit code")
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	b := &limitedBuffer{limit: 5}
+	for _, chunk := range []string{"abc", "defg", "hi"} {
+		n, err := b.Write([]byte(chunk))
+		require.NoError(t, err)
+		assert.Equal(t, len(chunk), n, "writes are never short")
+	}
+	assert.Equal(t, capturedStream{Data: "abcde", Bytes: 9, Truncated: true}, b.stream())
+
+	empty := &limitedBuffer{limit: 0}
+	_, _ = empty.Write([]byte("x"))
+	assert.Equal(t, capturedStream{Bytes: 1, Truncated: true}, empty.stream())
+}
@@ -76,6 +76,8 @@ Container names can be:
 	cmd.Flags().StringVarP(&opts.Format, "format", "f", "", "Format output using a Go template")
 	cmd.Flags().BoolVarP(&opts.Size, "size", "s", false, "Display total file sizes")
 
+	cmd.ValidArgsFunction = cmdutil.ContainerCompletions(f.Client, f.ProjectManager)
+
 	return cmd
 }
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/shlex"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -129,7 +130,7 @@ func TestNewCmdInspect(t *testing.T) {
 }
 
 func TestCmdInspect_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdInspect(f, nil)
 
 	require.Equal(t, "inspect [OPTIONS] CONTAINER [CONTAINER...]", cmd.Use)
@@ -149,17 +150,8 @@ func TestCmdInspect_Properties(t *testing.T) {
 
 func testFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return configmocks.NewBlankConfig(), nil
-		},
-	}, in, out, errOut
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 func TestInspectRun_HappyPath(t *testing.T) {
@@ -70,6 +70,8 @@ Container names can be:
 	cmd.Flags().BoolVar(&opts.Agent, "agent", false, "Treat arguments as agent names (resolves to clawker.<project>.<agent>)")
 	cmd.Flags().StringVarP(&opts.Signal, "signal", "s", "SIGKILL", "Signal to send to the container")
 
+	cmd.ValidArgsFunction = cmdutil.ContainerCompletions(f.Client, f.ProjectManager)
+
 	return cmd
 }
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/shlex"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -111,8 +112,7 @@ func TestNewCmdKill(t *testing.T) {
 }
 
 func TestNewCmdKill_ErrorPropagation(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 	expectedErr := fmt.Errorf("simulated failure")
 	cmd := NewCmdKill(f, func(_ context.Context, _ *KillOptions) error {
 		return expectedErr
@@ -125,7 +125,7 @@ func TestNewCmdKill_ErrorPropagation(t *testing.T) {
 }
 
 func TestCmdKill_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdKill(f, nil)
 
 	// Test command basics
@@ -210,16 +210,6 @@ func TestKillRun_ContainerNotFound(t *testing.T) {
 
 func testKillFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return configmocks.NewBlankConfig(), nil
-		},
-	}, in, out, errOut
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
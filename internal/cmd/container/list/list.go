@@ -3,6 +3,7 @@ package list
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -10,10 +11,34 @@ import (
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/schmitthub/clawker/pkg/whail"
 	"github.com/spf13/cobra"
 )
 
-var containerListValidFilterKeys = []string{"name", "status", "agent"}
+var containerListValidFilterKeys = []string{"name", "status", "agent", "project", "label"}
+
+// containerHeader titles the columns of a "table" --format template.
+var containerHeader = containerHeaderRow{
+	ID:      "CONTAINER ID",
+	Name:    "NAME",
+	Names:   "NAMES",
+	Status:  "STATUS",
+	Project: "PROJECT",
+	Agent:   "AGENT",
+	Image:   "IMAGE",
+	Created: "CREATED",
+	Labels:  "LABELS",
+}
+
+// containerHeaderRow mirrors containerRow with column titles as values.
+type containerHeaderRow struct {
+	ID, Name, Names, Status, Project, Agent, Image, Created, Labels string
+}
+
+// Label titles a {{.Label "key"}} column with the upper-cased key.
+func (containerHeaderRow) Label(key string) string {
+	return strings.ToUpper(key)
+}
 
 // ListOptions holds options for the list command.
 type ListOptions struct {
@@ -29,13 +54,20 @@ type ListOptions struct {
 
 // containerRow is the display/serialization type for format dispatch.
 type containerRow struct {
-	Name    string `json:"name"`
-	Names   string `json:"names"` // Docker CLI compatibility alias
-	Status  string `json:"status"`
-	Project string `json:"project"`
-	Agent   string `json:"agent"`
-	Image   string `json:"image"`
-	Created string `json:"created"`
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	Names   string            `json:"names"` // Docker CLI compatibility alias
+	Status  string            `json:"status"`
+	Project string            `json:"project"`
+	Agent   string            `json:"agent"`
+	Image   string            `json:"image"`
+	Created string            `json:"created"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// Label returns the value of label key, for templates ({{.Label "key"}}).
+func (r containerRow) Label(key string) string {
+	return r.Labels[key]
 }
 
 // NewCmdList creates the container list command.
@@ -54,6 +86,21 @@ func NewCmdList(f *cmdutil.Factory, runF func(context.Context, *ListOptions) err
 
 By default, shows only running containers. Use -a to show all containers.
 
+Filter with --filter key=value (repeatable). Filters on different keys must
+all match; repeated values of the same key match any of them. project, agent,
+status, and label are evaluated by the Docker daemon:
+  project=NAME      containers of a project (same as --project)
+  agent=NAME        containers of an agent
+  status=STATE      created, restarting, running, removing, paused, exited,
+                    or dead; selects stopped containers without -a
+  label=KEY[=VALUE] containers carrying a label, optionally with a value
+  name=NAME         container name
+name, and project or agent values ending in "*", match by prefix instead.
+
+--format accepts "json", "table", a Go template, or "table" followed by a
+template for aligned columns with headers. Template fields: .ID, .Name,
+.Status, .Project, .Agent, .Image, .Created, .Labels, and .Label "key".
+
 Note: Use 'clawker monitor status' for monitoring stack containers.`,
 		Example: `  # List running containers
   clawker container list
@@ -77,7 +124,16 @@ Note: Use 'clawker monitor status' for monitoring stack containers.`,
   clawker container ls -a --filter status=running
 
   # Filter by agent name
-  clawker container ls --filter agent=dev`,
+  clawker container ls --filter agent=dev
+
+  # Stopped containers of one project
+  clawker container ls --filter project=myapp --filter status=exited
+
+  # Containers carrying a label
+  clawker container ls -a --filter label=team=platform
+
+  # Aligned columns of your choosing
+  clawker container ls --format 'table {{.Name}}\t{{.Status}}\t{{.Label "team"}}'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if runF != nil {
 				return runF(cmd.Context(), opts)
@@ -89,7 +145,7 @@ Note: Use 'clawker monitor status' for monitoring stack containers.`,
 	opts.Format = cmdutil.AddFormatFlags(cmd)
 	opts.Filter = cmdutil.AddFilterFlags(cmd)
 	cmd.Flags().BoolVarP(&opts.All, "all", "a", false, "Show all containers (including stopped)")
-	cmd.Flags().StringVarP(&opts.Project, "project", "p", "", "Filter by project name")
+	cmd.Flags().StringVarP(&opts.Project, "project", "p", "", "Filter by project name (same as --filter project=NAME)")
 
 	return cmd
 }
@@ -112,19 +168,21 @@ func listRun(ctx context.Context, opts *ListOptions) error {
 		return fmt.Errorf("connecting to Docker: %w", err)
 	}
 
-	// Fetch containers — project flag is a server-side filter via Docker API.
-	var containers []docker.Container
 	if opts.Project != "" {
-		containers, err = client.ListContainersByProject(ctx, opts.Project, opts.All)
-	} else {
-		containers, err = client.ListContainers(ctx, opts.All)
+		filters = append(filters, cmdutil.Filter{Key: "project", Value: opts.Project})
 	}
+	query, local, err := buildContainerQuery(filters)
+	if err != nil {
+		return err
+	}
+
+	containers, err := client.ListContainersQuery(ctx, query, opts.All)
 	if err != nil {
 		return fmt.Errorf("listing containers: %w", err)
 	}
 
-	// Apply local filters.
-	containers = applyContainerFilters(containers, filters)
+	// Apply the filters the daemon cannot express.
+	containers = applyContainerFilters(containers, local)
 
 	// Handle empty results.
 	if len(containers) == 0 {
@@ -148,10 +206,10 @@ func listRun(ctx context.Context, opts *ListOptions) error {
 		return nil
 
 	case opts.Format.IsJSON():
-		return cmdutil.WriteJSON(ios.Out, rows)
+		return opts.Format.WriteJSON(ios, "container.list", rows)
 
 	case opts.Format.IsTemplate():
-		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(rows))
+		return cmdutil.ExecuteTemplateWithHeader(ios.Out, opts.Format.Template(), containerHeader, cmdutil.ToAny(rows))
 
 	default:
 		tp := opts.TUI.NewTable("NAME", "STATUS", "PROJECT", "AGENT", "IMAGE", "CREATED")
@@ -165,7 +223,12 @@ func listRun(ctx context.Context, opts *ListOptions) error {
 func buildContainerRows(containers []docker.Container) []containerRow {
 	rows := make([]containerRow, 0, len(containers))
 	for _, c := range containers {
+		id := c.ID
+		if len(id) > 12 {
+			id = id[:12]
+		}
 		rows = append(rows, containerRow{
+			ID:      id,
 			Name:    c.Name,
 			Names:   c.Name, // Docker CLI compatibility
 			Status:  c.Status,
@@ -173,39 +236,98 @@ func buildContainerRows(containers []docker.Container) []containerRow {
 			Agent:   c.Agent,
 			Image:   truncateImage(c.Image),
 			Created: formatCreatedTime(c.Created),
+			Labels:  c.Labels,
 		})
 	}
 	return rows
 }
 
+// buildContainerQuery compiles the filters the daemon can evaluate into a
+// whail label query and returns the rest for local matching. project and
+// agent go to the daemon when they carry a single exact value; repeated
+// values (ORed) and prefix globs stay local because distinct label terms are
+// ANDed by the daemon. Every status value and every label goes to the
+// daemon, which ORs statuses and ANDs labels as docker ps does.
+func buildContainerQuery(filters []cmdutil.Filter) (whail.LabelQuery, []cmdutil.Filter, error) {
+	byKey := map[string][]string{}
+	for _, f := range filters {
+		byKey[f.Key] = append(byKey[f.Key], f.Value)
+	}
+
+	q := docker.Query()
+	var local []cmdutil.Filter
+	for _, key := range []string{"project", "agent"} {
+		values := byKey[key]
+		if len(values) == 1 && !strings.HasSuffix(values[0], "*") {
+			if key == "project" {
+				q = q.Project(values[0])
+			} else {
+				q = q.Agent(values[0])
+			}
+			continue
+		}
+		for _, v := range values {
+			local = append(local, cmdutil.Filter{Key: key, Value: v})
+		}
+	}
+	if states := byKey["status"]; len(states) > 0 {
+		lowered := make([]string, len(states))
+		for i, s := range states {
+			lowered[i] = strings.ToLower(s)
+		}
+		q = q.Status(lowered...)
+	}
+	for _, l := range byKey["label"] {
+		if key, value, ok := strings.Cut(l, "="); ok {
+			q = q.Label(key, value)
+		} else {
+			q = q.HasLabel(l)
+		}
+	}
+	for _, v := range byKey["name"] {
+		local = append(local, cmdutil.Filter{Key: "name", Value: v})
+	}
+
+	if _, err := q.Filters(); err != nil {
+		return whail.LabelQuery{}, nil, cmdutil.FlagErrorWrap(err)
+	}
+	return q, local, nil
+}
+
+// applyContainerFilters keeps the containers matching the local filters:
+// every key must match, any value of a key suffices.
 func applyContainerFilters(containers []docker.Container, filters []cmdutil.Filter) []docker.Container {
 	if len(filters) == 0 {
 		return containers
 	}
+	byKey := map[string][]string{}
+	for _, f := range filters {
+		byKey[f.Key] = append(byKey[f.Key], f.Value)
+	}
 	var result []docker.Container
 	for _, c := range containers {
-		if matchesContainerFilters(c, filters) {
+		if matchesContainerFilters(c, byKey) {
 			result = append(result, c)
 		}
 	}
 	return result
 }
 
-func matchesContainerFilters(c docker.Container, filters []cmdutil.Filter) bool {
-	for _, f := range filters {
-		switch f.Key {
+func matchesContainerFilters(c docker.Container, byKey map[string][]string) bool {
+	for key, patterns := range byKey {
+		var field string
+		switch key {
 		case "name":
-			if !matchGlob(c.Name, f.Value) {
-				return false
-			}
-		case "status":
-			if !strings.EqualFold(c.Status, f.Value) {
-				return false
-			}
+			field = c.Name
+		case "project":
+			field = c.Project
 		case "agent":
-			if !matchGlob(c.Agent, f.Value) {
-				return false
-			}
+			field = c.Agent
+		default:
+			continue
+		}
+		if !slices.ContainsFunc(patterns, func(p string) bool { return matchGlob(field, p) }) {
+			return false
 		}
 	}
 	return true
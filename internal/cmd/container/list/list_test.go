@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/shlex"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -16,24 +19,15 @@ import (
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func testFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		TUI:       tui.NewTUI(tio),
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return configmocks.NewBlankConfig(), nil
-		},
-	}, in, out, errOut
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 // --- Tier 1: Flag parsing tests ---
@@ -79,8 +73,7 @@ func TestNewCmdList(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tio, _, _, _ := iostreams.Test()
-			f := &cmdutil.Factory{IOStreams: tio}
+			f := cmdutiltest.NewFactory(t).Factory
 
 			var gotOpts *ListOptions
 			cmd := NewCmdList(f, func(_ context.Context, opts *ListOptions) error {
@@ -143,8 +136,7 @@ func TestNewCmdList_FormatFlags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tio, _, _, _ := iostreams.Test()
-			f := &cmdutil.Factory{IOStreams: tio}
+			f := cmdutiltest.NewFactory(t).Factory
 
 			cmd := NewCmdList(f, func(_ context.Context, _ *ListOptions) error {
 				return nil
@@ -169,8 +161,7 @@ func TestNewCmdList_FormatFlags(t *testing.T) {
 }
 
 func TestCmdList_Properties(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdList(f, nil)
 
 	require.Equal(t, "list", cmd.Use)
@@ -235,6 +226,7 @@ func TestListRun_JSONOutput(t *testing.T) {
 	require.NoError(t, err)
 
 	outStr := out.String()
+	assert.True(t, strings.HasPrefix(outStr, `{"schema_version":1,"kind":"container.list","data":[`), outStr)
 	assert.Contains(t, outStr, `"name":"clawker.myapp.dev"`)
 	assert.Contains(t, outStr, `"status":"running"`)
 	assert.Contains(t, outStr, `"project":"myapp"`)
@@ -286,48 +278,135 @@ func TestListRun_TemplateOutput(t *testing.T) {
 	assert.Contains(t, outStr, "clawker.myapp.dev dev")
 }
 
-func TestListRun_FilterByStatus(t *testing.T) {
-	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
-	fake.SetupContainerList(
-		mocks.RunningContainerFixture("myapp", "dev"),
-		mocks.ContainerFixture("myapp", "worker", "alpine:latest"),
-	)
+// seededContainer describes a clawker container for seedContainers.
+type seededContainer struct {
+	project, agent string
+	running        bool
+	labels         map[string]string
+}
+
+// seedContainers switches fake to stateful mode and adds the containers, so
+// the daemon-side label and status filters are honoured. Containers that are
+// not running are started and exited, leaving them in the exited state.
+func seedContainers(t *testing.T, fake *mocks.FakeClient, containers ...seededContainer) {
+	t.Helper()
+	cfg := fake.Cfg
+	state := fake.EnableState()
+	state.AddImage("node:20-slim", nil)
+	for _, c := range containers {
+		name, err := docker.ContainerName(c.project, c.agent)
+		require.NoError(t, err)
+		labels := map[string]string{
+			cfg.LabelProject(): c.project,
+			cfg.LabelAgent():   c.agent,
+			cfg.LabelImage():   "node:20-slim",
+		}
+		for k, v := range c.labels {
+			labels[k] = v
+		}
+		id, err := state.AddContainer(whailtest.ContainerSpec{
+			Name:    name,
+			Image:   "node:20-slim",
+			Labels:  labels,
+			Running: true,
+		})
+		require.NoError(t, err)
+		if !c.running {
+			require.NoError(t, state.Exit(id, 0))
+		}
+	}
+}
 
+// runList executes container ls with args against fake.
+func runList(t *testing.T, fake *mocks.FakeClient, args ...string) (string, string, error) {
+	t.Helper()
 	f, _, out, errOut := testFactory(t, fake)
 	cmd := NewCmdList(f, nil)
-	cmd.SetArgs([]string{"-a", "--filter", "status=running"})
+	cmd.SetArgs(args)
 	cmd.SetIn(&bytes.Buffer{})
-	cmd.SetOut(out)
-	cmd.SetErr(errOut)
-
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
 	err := cmd.Execute()
-	require.NoError(t, err)
+	return out.String(), errOut.String(), err
+}
 
-	outStr := out.String()
-	assert.Contains(t, outStr, "clawker.myapp.dev")
-	assert.NotContains(t, outStr, "clawker.myapp.worker")
+func TestListRun_Filters(t *testing.T) {
+	containers := []seededContainer{
+		{project: "myapp", agent: "dev", running: true, labels: map[string]string{"team": "platform"}},
+		{project: "myapp", agent: "worker", labels: map[string]string{"team": "data"}},
+		{project: "other", agent: "dev", running: true},
+		{project: "other", agent: "devbox"},
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "status", args: []string{"-a", "--filter", "status=running"}, want: []string{"clawker.myapp.dev", "clawker.other.dev"}},
+		{name: "status selects stopped without -a", args: []string{"--filter", "status=exited"}, want: []string{"clawker.myapp.worker", "clawker.other.devbox"}},
+		{name: "status values are ORed", args: []string{"--filter", "status=exited", "--filter", "status=RUNNING"}, want: []string{"clawker.myapp.dev", "clawker.myapp.worker", "clawker.other.dev", "clawker.other.devbox"}},
+		{name: "agent", args: []string{"-a", "--filter", "agent=dev"}, want: []string{"clawker.myapp.dev", "clawker.other.dev"}},
+		{name: "agent prefix", args: []string{"-a", "--filter", "agent=dev*"}, want: []string{"clawker.myapp.dev", "clawker.other.dev", "clawker.other.devbox"}},
+		{name: "project", args: []string{"-a", "--filter", "project=myapp"}, want: []string{"clawker.myapp.dev", "clawker.myapp.worker"}},
+		{name: "project values are ORed", args: []string{"-a", "--filter", "project=myapp", "--filter", "project=other"}, want: []string{"clawker.myapp.dev", "clawker.myapp.worker", "clawker.other.dev", "clawker.other.devbox"}},
+		{name: "project flag and agent filter", args: []string{"-a", "-p", "other", "--filter", "agent=dev"}, want: []string{"clawker.other.dev"}},
+		{name: "label with value", args: []string{"-a", "--filter", "label=team=data"}, want: []string{"clawker.myapp.worker"}},
+		{name: "label presence", args: []string{"-a", "--filter", "label=team"}, want: []string{"clawker.myapp.dev", "clawker.myapp.worker"}},
+		{name: "keys are ANDed", args: []string{"--filter", "label=team", "--filter", "status=exited"}, want: []string{"clawker.myapp.worker"}},
+		{name: "name", args: []string{"-a", "--filter", "name=clawker.other.*"}, want: []string{"clawker.other.dev", "clawker.other.devbox"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+			seedContainers(t, fake, containers...)
+
+			out, _, err := runList(t, fake, append(tt.args, "--format", "{{.Name}}")...)
+			require.NoError(t, err)
+			got := strings.Fields(out)
+			sort.Strings(got)
+			assert.Equal(t, tt.want, got)
+		})
+	}
 }
 
-func TestListRun_FilterByAgent(t *testing.T) {
+func TestListRun_FilterInvalidStatus(t *testing.T) {
 	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
-	fake.SetupContainerList(
-		mocks.RunningContainerFixture("myapp", "dev"),
-		mocks.RunningContainerFixture("myapp", "worker"),
-	)
+	seedContainers(t, fake)
 
-	f, _, out, errOut := testFactory(t, fake)
-	cmd := NewCmdList(f, nil)
-	cmd.SetArgs([]string{"-a", "--filter", "agent=dev"})
-	cmd.SetIn(&bytes.Buffer{})
-	cmd.SetOut(out)
-	cmd.SetErr(errOut)
+	_, _, err := runList(t, fake, "--filter", "status=sleeping")
+	require.Error(t, err)
+	var flagErr *cmdutil.FlagError
+	assert.ErrorAs(t, err, &flagErr)
+	assert.Contains(t, err.Error(), "sleeping")
+	fake.AssertNotCalled(t, "ContainerList")
+}
 
-	err := cmd.Execute()
+func TestListRun_TableTemplate(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	seedContainers(t, fake,
+		seededContainer{project: "myapp", agent: "dev", running: true, labels: map[string]string{"team": "platform"}},
+	)
+
+	out, _, err := runList(t, fake, "--format", `table {{.Name}}\t{{.Status}}\t{{.Label "team"}}`)
 	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"NAME", "STATUS", "TEAM"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"clawker.myapp.dev", "running", "platform"}, strings.Fields(lines[1]))
+}
 
-	outStr := out.String()
-	assert.Contains(t, outStr, "clawker.myapp.dev")
-	assert.NotContains(t, outStr, "clawker.myapp.worker")
+func TestListRun_JSONIncludesLabels(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	seedContainers(t, fake,
+		seededContainer{project: "myapp", agent: "dev", running: true, labels: map[string]string{"team": "platform"}},
+	)
+
+	out, _, err := runList(t, fake, "--json")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"team":"platform"`)
+	assert.Regexp(t, `"id":"[0-9a-f]{12}"`, out)
 }
 
 func TestListRun_FilterInvalidKey(t *testing.T) {
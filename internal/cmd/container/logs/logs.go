@@ -1,11 +1,17 @@
 package logs
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
+	"sync"
 
+	"github.com/moby/moby/api/pkg/stdcopy"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/project"
@@ -19,6 +25,7 @@ type LogsOptions struct {
 	ProjectManager func() (project.ProjectManager, error)
 
 	Agent      bool
+	Project    string
 	Follow     bool
 	Timestamps bool
 	Details    bool
@@ -42,6 +49,11 @@ func NewCmdLogs(f *cmdutil.Factory, runF func(context.Context, *LogsOptions) err
 		Short: "Fetch the logs of a container",
 		Long: `Fetches the logs of a clawker container.
 
+With --project, fetches the logs of every agent container in the project at
+once. Lines are interleaved as they arrive and prefixed with the agent name.
+--follow, --since, --until, --tail and --timestamps apply to each container as
+they do for a single one, so --tail 10 shows the last 10 lines of every agent.
+
 When --agent is provided, the container name is resolved as clawker.<project>.<agent>
 using the project resolved from the current directory.
 
@@ -64,24 +76,43 @@ Container name can be:
   clawker container logs --since 2024-01-01T00:00:00Z --agent dev
 
   # Show logs with timestamps
-  clawker container logs --timestamps --agent dev`,
-		Args: cobra.ExactArgs(1),
+  clawker container logs --timestamps --agent dev
+
+  # Follow every agent in a project
+  clawker container logs --project myapp --follow`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.Project != "" {
+				if len(args) > 0 {
+					return cmdutil.FlagErrorf("--project cannot be combined with a container argument")
+				}
+				return nil
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Containers = args
 			if runF != nil {
 				return runF(cmd.Context(), opts)
 			}
+			if opts.Project != "" {
+				return projectLogsRun(cmd.Context(), opts)
+			}
 			return logsRun(cmd.Context(), opts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&opts.Agent, "agent", false, "Treat argument as agent name (resolves to clawker.<project>.<agent>)")
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Fetch the logs of every agent container in this project")
 	cmd.Flags().BoolVarP(&opts.Follow, "follow", "f", false, "Follow log output")
 	cmd.Flags().BoolVarP(&opts.Timestamps, "timestamps", "t", false, "Show timestamps")
 	cmd.Flags().BoolVar(&opts.Details, "details", false, "Show extra details provided to logs")
 	cmd.Flags().StringVar(&opts.Since, "since", "", "Show logs since timestamp (e.g., 2024-01-01T00:00:00Z) or relative (e.g., 42m)")
 	cmd.Flags().StringVar(&opts.Until, "until", "", "Show logs before timestamp (e.g., 2024-01-01T00:00:00Z) or relative (e.g., 42m)")
 	cmd.Flags().StringVar(&opts.Tail, "tail", "all", "Number of lines to show from the end (default: all)")
+	cmd.MarkFlagsMutuallyExclusive("agent", "project")
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.ContainerCompletions(f.Client, f.ProjectManager))
+	cmd.RegisterFlagCompletionFunc("project", cmdutil.ProjectCompletions(f.ProjectManager)) //nolint:errcheck,gosec // only errors on a programmer mistake (flag must exist); flags are defined above
 
 	return cmd
 }
@@ -122,8 +153,23 @@ func logsRun(ctx context.Context, opts *LogsOptions) error {
 		return fmt.Errorf("container %q not found", containerName)
 	}
 
-	// Build log options
-	logOpts := docker.ContainerLogsOptions{
+	// Get logs
+	reader, err := client.ContainerLogs(ctx, c.ID, opts.logOptions())
+	if err != nil {
+		return fmt.Errorf("failed to get logs: %w", err)
+	}
+	defer reader.Close()
+
+	// Stream logs to stdout
+	if _, err = io.Copy(ios.Out, reader); err != nil {
+		return fmt.Errorf("error streaming logs: %w", err)
+	}
+	return nil
+}
+
+// logOptions builds the Docker log options shared by every container.
+func (opts *LogsOptions) logOptions() docker.ContainerLogsOptions {
+	return docker.ContainerLogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
 		Follow:     opts.Follow,
@@ -133,17 +179,133 @@ func logsRun(ctx context.Context, opts *LogsOptions) error {
 		Until:      opts.Until,
 		Tail:       opts.Tail,
 	}
+}
 
-	// Get logs
-	reader, err := client.ContainerLogs(ctx, c.ID, logOpts)
+// projectLogsRun streams the logs of every agent container in opts.Project
+// concurrently, compose-style: each line is prefixed with its agent's name
+// in a per-agent color.
+func projectLogsRun(ctx context.Context, opts *LogsOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+
+	// Sidecars carry no agent label, so HasLabel keeps this to agents.
+	containers, err := client.ListContainersQuery(ctx,
+		docker.Query().Project(opts.Project).HasLabel(consts.LabelAgent), true)
+	if err != nil {
+		return fmt.Errorf("listing containers: %w", err)
+	}
+	if len(containers) == 0 {
+		return fmt.Errorf("no agent containers found for project %q", opts.Project)
+	}
+	sort.Slice(containers, func(i, j int) bool { return containers[i].Agent < containers[j].Agent })
+
+	width := 0
+	for _, c := range containers {
+		width = max(width, len(c.Agent))
+	}
+	palette := []func(string) string{cs.Cyan, cs.Yellow, cs.Green, cs.Magenta, cs.Blue, cs.BrandOrange}
+
+	var (
+		mu   sync.Mutex // one line at a time across every container and stream
+		wg   sync.WaitGroup
+		errs = make([]error, len(containers))
+	)
+	for i, c := range containers {
+		prefix := palette[i%len(palette)](fmt.Sprintf("%-*s |", width, c.Agent)) + " "
+		stdout := &prefixWriter{mu: &mu, out: ios.Out, prefix: prefix}
+		stderr := &prefixWriter{mu: &mu, out: ios.ErrOut, prefix: prefix}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer stdout.Flush()
+			defer stderr.Flush()
+			if err := streamContainerLogs(ctx, client, c.ID, opts.logOptions(), stdout, stderr); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", c.Agent, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// streamContainerLogs copies one container's logs to stdout and stderr.
+// Logs of a container without a TTY are multiplexed and are demultiplexed
+// here; a TTY container has a single raw stream.
+func streamContainerLogs(ctx context.Context, client *docker.Client, id string, logOpts docker.ContainerLogsOptions, stdout, stderr io.Writer) error {
+	info, err := client.ContainerInspect(ctx, id, docker.ContainerInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("inspecting container: %w", err)
+	}
+
+	reader, err := client.ContainerLogs(ctx, id, logOpts)
 	if err != nil {
 		return fmt.Errorf("failed to get logs: %w", err)
 	}
 	defer reader.Close()
 
-	// Stream logs to stdout
-	if _, err = io.Copy(ios.Out, reader); err != nil {
+	if info.Container.Config != nil && info.Container.Config.Tty {
+		_, err = io.Copy(stdout, reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, reader)
+	}
+	if err != nil && ctx.Err() == nil {
 		return fmt.Errorf("error streaming logs: %w", err)
 	}
 	return nil
 }
+
+// prefixWriter writes complete lines to out, each preceded by prefix. A
+// partial line is held until its newline arrives or Flush is called. mu is
+// shared by every prefixWriter on the terminal so lines never interleave.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	end := bytes.LastIndexByte(w.buf, '\n')
+	if end < 0 {
+		return len(p), nil
+	}
+
+	var lines bytes.Buffer
+	for _, line := range bytes.SplitAfter(w.buf[:end+1], []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		lines.WriteString(w.prefix)
+		lines.Write(line)
+	}
+	w.buf = append(w.buf[:0], w.buf[end+1:]...)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.out.Write(lines.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush writes a trailing partial line, terminated with a newline.
+func (w *prefixWriter) Flush() {
+	if len(w.buf) == 0 {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fmt.Fprintf(w.out, "%s%s\n", w.prefix, w.buf)
+	w.buf = nil
+}
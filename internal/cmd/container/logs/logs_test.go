@@ -3,11 +3,19 @@ package logs
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
+	"io"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/shlex"
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -101,6 +109,24 @@ func TestNewCmdLogs(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "accepts 1 arg(s), received 2",
 		},
+		{
+			name:   "project flag without container",
+			input:  "--project myapp --follow",
+			output: LogsOptions{Project: "myapp", Follow: true, Tail: "all"},
+		},
+		{
+			name:       "project flag with container",
+			input:      "--project myapp",
+			args:       []string{"clawker.myapp.dev"},
+			wantErr:    true,
+			wantErrMsg: "--project cannot be combined with a container argument",
+		},
+		{
+			name:       "project and agent together",
+			input:      "--project myapp --agent",
+			wantErr:    true,
+			wantErrMsg: "none of the others can be",
+		},
 	}
 
 	for _, tt := range tests {
@@ -143,6 +169,7 @@ func TestNewCmdLogs(t *testing.T) {
 			require.NoError(t, err)
 			require.NotNil(t, gotOpts)
 			require.Equal(t, tt.output.Agent, gotOpts.Agent)
+			require.Equal(t, tt.output.Project, gotOpts.Project)
 			require.Equal(t, tt.output.Follow, gotOpts.Follow)
 			require.Equal(t, tt.output.Timestamps, gotOpts.Timestamps)
 			require.Equal(t, tt.output.Details, gotOpts.Details)
@@ -154,7 +181,7 @@ func TestNewCmdLogs(t *testing.T) {
 }
 
 func TestCmdLogs_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdLogs(f, nil)
 
 	// Test command basics
@@ -185,17 +212,8 @@ func TestCmdLogs_Properties(t *testing.T) {
 
 func testFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return configmocks.NewBlankConfig(), nil
-		},
-	}, in, out, errOut
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 func TestLogsRun_HappyPath(t *testing.T) {
@@ -273,3 +291,96 @@ func TestLogsRun_WithTailFlag(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "last line\n", out.String())
 }
+
+// writeFrame appends one frame of Docker's multiplexed log stream to buf.
+func writeFrame(buf *bytes.Buffer, stream stdcopy.StdType, payload string) {
+	header := make([]byte, 8)
+	header[0] = byte(stream)
+	binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+	buf.Write(header)
+	buf.WriteString(payload)
+}
+
+func TestLogsRun_Project(t *testing.T) {
+	dev := mocks.RunningContainerFixture("myapp", "dev")
+	reviewer := mocks.RunningContainerFixture("myapp", "reviewer")
+
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList(reviewer, dev)
+	fake.FakeAPI.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		return client.ContainerInspectResult{Container: container.InspectResponse{
+			ID: id,
+			Config: &container.Config{
+				// dev has a TTY; reviewer's logs are multiplexed.
+				Tty:    id == dev.ID,
+				Labels: dev.Labels,
+			},
+		}}, nil
+	}
+	var gotOpts []client.ContainerLogsOptions
+	var mu sync.Mutex
+	fake.FakeAPI.ContainerLogsFn = func(_ context.Context, id string, opts client.ContainerLogsOptions) (client.ContainerLogsResult, error) {
+		mu.Lock()
+		gotOpts = append(gotOpts, opts)
+		mu.Unlock()
+		if id == dev.ID {
+			return io.NopCloser(strings.NewReader("dev one\ndev two")), nil
+		}
+		var buf bytes.Buffer
+		writeFrame(&buf, stdcopy.Stdout, "review out\n")
+		writeFrame(&buf, stdcopy.Stderr, "review err\n")
+		return io.NopCloser(&buf), nil
+	}
+
+	f, in, out, errOut := testFactory(t, fake)
+	cmd := NewCmdLogs(f, nil)
+	cmd.SetArgs([]string{"--project", "myapp", "--tail", "5", "--timestamps"})
+	cmd.SetIn(in)
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+
+	require.NoError(t, cmd.Execute())
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.ElementsMatch(t, []string{
+		"dev      | dev one",
+		"dev      | dev two",
+		"reviewer | review out",
+	}, lines)
+	assert.Equal(t, "reviewer | review err\n", errOut.String())
+
+	require.Len(t, gotOpts, 2)
+	for _, o := range gotOpts {
+		assert.Equal(t, "5", o.Tail)
+		assert.True(t, o.Timestamps)
+	}
+}
+
+func TestLogsRun_ProjectWithoutAgents(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList()
+
+	f, in, out, errOut := testFactory(t, fake)
+	cmd := NewCmdLogs(f, nil)
+	cmd.SetArgs([]string{"--project", "myapp"})
+	cmd.SetIn(in)
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no agent containers found for project "myapp"`)
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var mu sync.Mutex
+	var out bytes.Buffer
+	w := &prefixWriter{mu: &mu, out: &out, prefix: "dev | "}
+
+	w.Write([]byte("one\ntw"))
+	assert.Equal(t, "dev | one\n", out.String(), "partial line is held back")
+
+	w.Write([]byte("o\nthree"))
+	w.Flush()
+	assert.Equal(t, "dev | one\ndev | two\ndev | three\n", out.String())
+}
@@ -63,6 +63,8 @@ Container names can be:
 
 	cmd.Flags().BoolVar(&opts.Agent, "agent", false, "Treat arguments as agent names (resolves to clawker.<project>.<agent>)")
 
+	cmd.ValidArgsFunction = cmdutil.ContainerCompletions(f.Client, f.ProjectManager)
+
 	return cmd
 }
 
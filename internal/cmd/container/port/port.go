@@ -0,0 +1,181 @@
+// Package port provides the container port command.
+package port
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moby/moby/api/types/network"
+	"github.com/schmitthub/clawker/internal/cmd/container/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// PortOptions holds options for the port command.
+type PortOptions struct {
+	IOStreams      *iostreams.IOStreams
+	Client         func(context.Context) (*docker.Client, error)
+	ProjectManager func() (project.ProjectManager, error)
+
+	Agent  bool
+	Format *cmdutil.FormatFlags
+
+	container   string
+	privatePort network.Port
+}
+
+// NewCmdPort creates a new port command.
+func NewCmdPort(f *cmdutil.Factory, runF func(context.Context, *PortOptions) error) *cobra.Command {
+	opts := &PortOptions{
+		IOStreams:      f.IOStreams,
+		Client:         f.Client,
+		ProjectManager: f.ProjectManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "port [OPTIONS] CONTAINER [PRIVATE_PORT[/PROTO]]",
+		Short: "List port mappings or a specific mapping for the container",
+		Long: `List the published port mappings of a clawker container, or the host
+addresses a single container port is published on.
+
+Ports are published with --publish (-p) or --publish-all (-P) on
+'clawker container run' and 'clawker container create'. A stopped container
+has no published ports.
+
+When --agent is provided, the container name is resolved as clawker.<project>.<agent>
+using the project resolved from the current directory.
+
+Container name can be:
+  - Full name: clawker.myproject.myagent
+  - Container ID: abc123...`,
+		Example: `  # List all published ports of an agent's container
+  clawker container port --agent dev
+
+  # Show where container port 3000 is published
+  clawker container port --agent dev 3000
+
+  # Show a udp mapping by full container name
+  clawker container port clawker.myapp.dev 5353/udp
+
+  # Machine-readable output
+  clawker container port --agent dev --json`,
+		Args: cmdutil.RequiresRangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			if len(args) > 1 {
+				p, err := shared.ParseContainerPort(args[1])
+				if err != nil {
+					return cmdutil.FlagErrorWrap(err)
+				}
+				opts.privatePort = p
+			}
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return portRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Agent, "agent", false, "Treat argument as agent name (resolves to clawker.<project>.<agent>)")
+	opts.Format = cmdutil.AddFormatFlags(cmd)
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.ContainerCompletions(f.Client, f.ProjectManager))
+
+	return cmd
+}
+
+func portRun(ctx context.Context, opts *PortOptions) error {
+	containerName := opts.container
+
+	if opts.Agent {
+		var projectName string
+		if opts.ProjectManager != nil {
+			if pm, pmErr := opts.ProjectManager(); pmErr == nil {
+				if p, pErr := pm.CurrentProject(ctx); pErr == nil {
+					projectName = p.Name()
+				}
+			}
+		}
+		containers, err := docker.ContainerNamesFromAgents(projectName, []string{containerName})
+		if err != nil {
+			return err
+		}
+		containerName = containers[0]
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+
+	c, err := client.FindContainerByName(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("failed to find container %q: %w", containerName, err)
+	}
+	if c == nil {
+		return fmt.Errorf("container %q not found", containerName)
+	}
+
+	inspect, err := client.ContainerInspect(ctx, c.ID, docker.ContainerInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("inspecting container %q: %w", containerName, err)
+	}
+
+	var mappings []shared.PortMapping
+	if ns := inspect.Container.NetworkSettings; ns != nil {
+		mappings = shared.PortMappings(ns.Ports)
+	}
+
+	if !opts.privatePort.IsZero() {
+		p := opts.privatePort
+		var matched []shared.PortMapping
+		for _, m := range mappings {
+			if m.ContainerPort == p.String() {
+				matched = append(matched, m)
+			}
+		}
+		if len(matched) == 0 {
+			return fmt.Errorf("no public port %q published for %s", p.String(), containerName)
+		}
+		mappings = matched
+	}
+
+	return renderMappings(opts, mappings)
+}
+
+// renderMappings writes the mappings in the format the flags select. A
+// single-port query prints host addresses only, matching docker port.
+func renderMappings(opts *PortOptions, mappings []shared.PortMapping) error {
+	ios := opts.IOStreams
+	switch {
+	case opts.Format.IsJSON():
+		if mappings == nil {
+			mappings = []shared.PortMapping{}
+		}
+		if err := opts.Format.WriteJSON(ios, "container.port", mappings); err != nil {
+			return fmt.Errorf("writing json: %w", err)
+		}
+		return nil
+	case opts.Format.IsTemplate():
+		if err := cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(mappings)); err != nil {
+			return fmt.Errorf("executing template: %w", err)
+		}
+		return nil
+	}
+
+	if len(mappings) == 0 {
+		fmt.Fprintln(ios.ErrOut, "No published ports.")
+		return nil
+	}
+	for _, m := range mappings {
+		if opts.Format.Quiet || !opts.privatePort.IsZero() {
+			fmt.Fprintln(ios.Out, m.HostAddress())
+			continue
+		}
+		fmt.Fprintf(ios.Out, "%s -> %s\n", m.ContainerPort, m.HostAddress())
+	}
+	return nil
+}
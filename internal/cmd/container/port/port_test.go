@@ -0,0 +1,179 @@
+package port
+
+import (
+	"bytes"
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/moby/moby/api/types/network"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdPort(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantAgent bool
+		wantPort  string
+		wantErr   string
+	}{
+		{name: "container name", input: "clawker.myapp.dev"},
+		{name: "agent flag", input: "--agent dev", wantAgent: true},
+		{name: "private port defaults to tcp", input: "dev 3000", wantPort: "3000/tcp"},
+		{name: "private port with protocol", input: "dev 5353/udp", wantPort: "5353/udp"},
+		{name: "invalid private port", input: "dev http", wantErr: `invalid port "http"`},
+		{name: "no arguments", input: "", wantErr: "requires at least 1 and at most 2 arguments"},
+		{name: "too many arguments", input: "a 1 2", wantErr: "requires at least 1 and at most 2 arguments"},
+		{name: "quiet and json exclusive", input: "-q --json dev", wantErr: "mutually exclusive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *PortOptions
+			cmd := NewCmdPort(f, func(_ context.Context, opts *PortOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAgent, gotOpts.Agent)
+			if tt.wantPort != "" {
+				assert.Equal(t, tt.wantPort, gotOpts.privatePort.String())
+			} else {
+				assert.True(t, gotOpts.privatePort.IsZero())
+			}
+		})
+	}
+}
+
+// --- Tier 2 tests (Cobra+Factory, real run function) ---
+
+func publishedPorts() network.PortMap {
+	return network.PortMap{
+		network.MustParsePort("3000/tcp"): {
+			{HostIP: netip.MustParseAddr("0.0.0.0"), HostPort: "32768"},
+			{HostIP: netip.MustParseAddr("::"), HostPort: "32768"},
+		},
+		network.MustParsePort("5353/udp"): {{HostIP: netip.MustParseAddr("127.0.0.1"), HostPort: "5353"}},
+		network.MustParsePort("8080/tcp"): nil,
+	}
+}
+
+func runPort(t *testing.T, fake *mocks.FakeClient, args ...string) (string, string, error) {
+	t.Helper()
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	cmd := NewCmdPort(tf.Factory, nil)
+	cmd.SetArgs(args)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	return tf.Out.String(), tf.ErrOut.String(), err
+}
+
+func TestPortRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		ports   network.PortMap
+		wantOut string
+		wantErr string
+		errOut  string
+	}{
+		{
+			name:    "all mappings",
+			args:    []string{"clawker.myapp.dev"},
+			ports:   publishedPorts(),
+			wantOut: "3000/tcp -> 0.0.0.0:32768\n3000/tcp -> [::]:32768\n5353/udp -> 127.0.0.1:5353\n",
+		},
+		{
+			name:    "single port prints host addresses",
+			args:    []string{"clawker.myapp.dev", "3000"},
+			ports:   publishedPorts(),
+			wantOut: "0.0.0.0:32768\n[::]:32768\n",
+		},
+		{
+			name:    "quiet",
+			args:    []string{"-q", "clawker.myapp.dev"},
+			ports:   publishedPorts(),
+			wantOut: "0.0.0.0:32768\n[::]:32768\n127.0.0.1:5353\n",
+		},
+		{
+			name:    "json",
+			args:    []string{"--format", "json", "clawker.myapp.dev", "5353/udp"},
+			ports:   publishedPorts(),
+			wantOut: `[{"containerPort":"5353/udp","hostIp":"127.0.0.1","hostPort":"5353"}]` + "\n",
+		},
+		{
+			name:    "json with nothing published",
+			args:    []string{"--format", "json", "clawker.myapp.dev"},
+			wantOut: "[]\n",
+		},
+		{
+			name:    "template",
+			args:    []string{"--format", "{{.HostPort}}", "clawker.myapp.dev", "3000/tcp"},
+			ports:   publishedPorts(),
+			wantOut: "32768\n32768\n",
+		},
+		{
+			name:   "nothing published",
+			args:   []string{"clawker.myapp.dev"},
+			errOut: "No published ports.",
+		},
+		{
+			name:    "exposed but unpublished port",
+			args:    []string{"clawker.myapp.dev", "8080"},
+			ports:   publishedPorts(),
+			wantErr: `no public port "8080/tcp" published for clawker.myapp.dev`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+			fake.SetupContainerPorts("clawker.myapp.dev", mocks.RunningContainerFixture("myapp", "dev"), tt.ports)
+
+			out, errOut, err := runPort(t, fake, tt.args...)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOut, out)
+			if tt.errOut != "" {
+				assert.Contains(t, errOut, tt.errOut)
+			}
+		})
+	}
+}
+
+func TestPortRun_ContainerNotFound(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList()
+
+	_, _, err := runPort(t, fake, "clawker.myapp.missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "clawker.myapp.missing")
+}
@@ -0,0 +1,262 @@
+package prune
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/shlex"
+	"github.com/moby/moby/client"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/prompter"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdPrune(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantOpts  PruneOptions
+		wantUntil time.Duration
+		wantErr   string
+	}{
+		{name: "no flags", input: ""},
+		{name: "force", input: "-f", wantOpts: PruneOptions{Force: true}},
+		{name: "volumes", input: "-v", wantOpts: PruneOptions{Volumes: true}},
+		{name: "project", input: "-p myapp", wantOpts: PruneOptions{Project: "myapp"}},
+		{name: "until", input: "--until 72h", wantOpts: PruneOptions{Until: "72h"}, wantUntil: 72 * time.Hour},
+		{name: "invalid until", input: "--until 3d", wantErr: `invalid --until "3d"`},
+		{name: "negative until", input: "--until -1h", wantErr: "must not be negative"},
+		{name: "no arguments allowed", input: "extra", wantErr: "unknown command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *PruneOptions
+			cmd := NewCmdPrune(f, func(_ context.Context, opts *PruneOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOpts.Force, gotOpts.Force)
+			assert.Equal(t, tt.wantOpts.Volumes, gotOpts.Volumes)
+			assert.Equal(t, tt.wantOpts.Project, gotOpts.Project)
+			assert.Equal(t, tt.wantOpts.Until, gotOpts.Until)
+			assert.Equal(t, tt.wantUntil, gotOpts.until)
+		})
+	}
+}
+
+// --- Tier 2 tests (Cobra+Factory, real run function) ---
+
+// testNow is the pinned clock: 100h after the FakeState epoch.
+var testNow = time.Date(2025, 1, 5, 4, 0, 0, 0, time.UTC)
+
+type seeded struct {
+	project, agent string
+	running        bool
+	age            time.Duration
+	size           int64
+}
+
+// pruneFixture is a stateful fake daemon seeded with clawker containers of
+// the given ages and writable-layer sizes, recording removals.
+type pruneFixture struct {
+	fake    *mocks.FakeClient
+	removed map[string]client.ContainerRemoveOptions
+}
+
+func newPruneFixture(t *testing.T, containers ...seeded) *pruneFixture {
+	t.Helper()
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	cfg := fake.Cfg
+	state := fake.EnableState()
+	state.AddImage("node:20-slim", nil)
+
+	sizes := map[string]int64{}
+	created := map[string]time.Time{}
+	for _, c := range containers {
+		name, err := docker.ContainerName(c.project, c.agent)
+		require.NoError(t, err)
+		id, err := state.AddContainer(whailtest.ContainerSpec{
+			Name:  name,
+			Image: "node:20-slim",
+			Labels: map[string]string{
+				cfg.LabelProject(): c.project,
+				cfg.LabelAgent():   c.agent,
+			},
+			Running: true,
+		})
+		require.NoError(t, err)
+		if !c.running {
+			require.NoError(t, state.Exit(id, 0))
+		}
+		sizes[id] = c.size
+		created[id] = testNow.Add(-c.age)
+	}
+
+	// Backdate creation times; the state stamps them from its own epoch.
+	snap := state.Snapshot()
+	for i := range snap.Containers {
+		snap.Containers[i].Created = created[snap.Containers[i].ID].Format(time.RFC3339Nano)
+	}
+	state.Restore(snap)
+
+	inspect := fake.FakeAPI.ContainerInspectFn
+	fake.FakeAPI.ContainerInspectFn = func(ctx context.Context, id string, opts client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		res, err := inspect(ctx, id, opts)
+		if err == nil && opts.Size {
+			size := sizes[res.Container.ID]
+			res.Container.SizeRw = &size
+		}
+		return res, err
+	}
+
+	fx := &pruneFixture{fake: fake, removed: map[string]client.ContainerRemoveOptions{}}
+	remove := fake.FakeAPI.ContainerRemoveFn
+	fake.FakeAPI.ContainerRemoveFn = func(ctx context.Context, id string, opts client.ContainerRemoveOptions) (client.ContainerRemoveResult, error) {
+		res, err := inspect(ctx, id, client.ContainerInspectOptions{})
+		if err == nil {
+			fx.removed[strings.TrimPrefix(res.Container.Name, "/")] = opts
+		}
+		return remove(ctx, id, opts)
+	}
+	return fx
+}
+
+func (fx *pruneFixture) run(t *testing.T, ios *iostreams.IOStreams, args ...string) error {
+	t.Helper()
+	f := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fx.fake)).Factory
+	f.IOStreams = ios
+	f.Prompter = func() *prompter.Prompter { return prompter.NewPrompter(ios) }
+	cmd := NewCmdPrune(f, func(ctx context.Context, opts *PruneOptions) error {
+		opts.now = func() time.Time { return testNow }
+		return pruneRun(ctx, opts)
+	})
+	cmd.SetArgs(args)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	return cmd.Execute()
+}
+
+func (fx *pruneFixture) removedNames() []string {
+	var names []string
+	for name := range fx.removed {
+		names = append(names, name)
+	}
+	return names
+}
+
+func defaultContainers() []seeded {
+	return []seeded{
+		{project: "myapp", agent: "old", age: 96 * time.Hour, size: 2 * 1024 * 1024},
+		{project: "myapp", agent: "fresh", age: time.Hour, size: 512},
+		{project: "myapp", agent: "live", running: true, age: 96 * time.Hour},
+		{project: "other", agent: "old", age: 80 * time.Hour, size: 1024},
+	}
+}
+
+func TestPruneRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantRemoved []string
+		wantErrOut  string
+	}{
+		{
+			name:        "all stopped containers",
+			args:        []string{"--force"},
+			wantRemoved: []string{"clawker.myapp.fresh", "clawker.myapp.old", "clawker.other.old"},
+			wantErrOut:  "Total reclaimed space: 2.00MB",
+		},
+		{
+			name:        "until keeps recent containers",
+			args:        []string{"--force", "--until", "72h"},
+			wantRemoved: []string{"clawker.myapp.old", "clawker.other.old"},
+		},
+		{
+			name:        "project and until",
+			args:        []string{"--force", "--project", "myapp", "--until", "72h"},
+			wantRemoved: []string{"clawker.myapp.old"},
+			wantErrOut:  "Total reclaimed space: 2.00MB",
+		},
+		{
+			name:       "nothing matches",
+			args:       []string{"--force", "--project", "myapp", "--until", "200h"},
+			wantErrOut: "No stopped containers to remove.",
+		},
+		{
+			name:       "declined without a terminal",
+			args:       []string{},
+			wantErrOut: "Aborted.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := newPruneFixture(t, defaultContainers()...)
+			ios, _, _, errOut := iostreams.Test()
+
+			require.NoError(t, fx.run(t, ios, tt.args...))
+			assert.ElementsMatch(t, tt.wantRemoved, fx.removedNames())
+			if tt.wantErrOut != "" {
+				assert.Contains(t, errOut.String(), tt.wantErrOut)
+			}
+		})
+	}
+}
+
+func TestPruneRun_DryRun(t *testing.T) {
+	fx := newPruneFixture(t, defaultContainers()...)
+	mocks.WithDryRun()(fx.fake)
+	ios, _, out, errOut := iostreams.Test()
+
+	require.NoError(t, fx.run(t, ios, "--until", "72h"))
+	assert.Empty(t, fx.removed)
+	var ops []string
+	for _, op := range fx.fake.Client.DryRunReport() {
+		ops = append(ops, op.Action+" "+op.Resource)
+	}
+	assert.Equal(t, []string{"remove container", "remove container"}, ops)
+	assert.Contains(t, out.String(), "clawker.myapp.old  2.00MB\n")
+	assert.Contains(t, out.String(), "clawker.other.old  1.00KB\n")
+	assert.NotContains(t, out.String(), "fresh")
+	assert.NotContains(t, out.String(), "live")
+	assert.Contains(t, errOut.String(), "Would remove 2 containers, reclaiming 2.00MB.")
+	assert.NotContains(t, errOut.String(), "Aborted.")
+}
+
+func TestPruneRun_Volumes(t *testing.T) {
+	fx := newPruneFixture(t, defaultContainers()...)
+	ios, _, _, _ := iostreams.Test()
+
+	require.NoError(t, fx.run(t, ios, "--force", "--volumes", "--project", "other"))
+	require.Contains(t, fx.removed, "clawker.other.old")
+	assert.True(t, fx.removed["clawker.other.old"].RemoveVolumes)
+	assert.False(t, fx.removed["clawker.other.old"].Force)
+}
@@ -5,9 +5,9 @@ import (
 	"fmt"
 
 	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
-	"github.com/schmitthub/clawker/controlplane/agent"
+	"github.com/schmitthub/clawker/internal/cmd/container/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
-	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
@@ -20,6 +20,7 @@ import (
 type RemoveOptions struct {
 	IOStreams      *iostreams.IOStreams
 	Client         func(context.Context) (*docker.Client, error)
+	Config         func() (config.Config, error)
 	ProjectManager func() (project.ProjectManager, error)
 	AdminClient    func(context.Context) (adminv1.AdminServiceClient, error)
 	SocketBridge   func() socketbridge.SocketBridgeManager
@@ -37,6 +38,7 @@ func NewCmdRemove(f *cmdutil.Factory, runF func(context.Context, *RemoveOptions)
 	opts := &RemoveOptions{
 		IOStreams:      f.IOStreams,
 		Client:         f.Client,
+		Config:         f.Config,
 		ProjectManager: f.ProjectManager,
 		AdminClient:    f.AdminClient,
 		SocketBridge:   f.SocketBridge,
@@ -52,6 +54,13 @@ func NewCmdRemove(f *cmdutil.Factory, runF func(context.Context, *RemoveOptions)
 By default, only stopped containers can be removed. Use --force to remove
 running containers.
 
+When the project config sets hooks.pre_remove, that host command runs before
+each container is removed; a non-zero exit skips that container.
+
+With the global --dry-run, nothing is removed: the Docker calls are
+reported instead, and the hook, firewall and socket bridge teardown is
+skipped.
+
 When --agent is provided, the container names are resolved as clawker.<project>.<agent>
 using the project resolved from the current directory.
 
@@ -71,7 +80,10 @@ Container names can be:
   clawker container remove --force --agent dev
 
   # Remove container and its volumes
-  clawker container remove --volumes --agent dev`,
+  clawker container remove --volumes --agent dev
+
+  # Show what removing a container and its volumes would do
+  clawker container remove --dry-run --volumes --agent dev`,
 		Args: cmdutil.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Containers = args
@@ -86,6 +98,9 @@ Container names can be:
 	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Force remove running containers")
 	cmd.Flags().BoolVarP(&opts.Volumes, "volumes", "v", false, "Remove associated volumes")
 
+	cmd.ValidArgsFunction = cmdutil.ContainerCompletions(f.Client, f.ProjectManager)
+
+	cmdutil.EnableDryRun(cmd)
 	return cmd
 }
 
@@ -121,9 +136,20 @@ func removeRun(ctx context.Context, opts *RemoveOptions) error {
 		return fmt.Errorf("connecting to Docker: %w", err)
 	}
 
+	var preRemoveHook string
+	if opts.Config != nil {
+		cfg, err := opts.Config()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+		if preRemoveHook, err = shared.HostHookCommand(cfg, shared.HostHookPreRemove); err != nil {
+			return err
+		}
+	}
+
 	var errs []error
 	for _, name := range containers {
-		if err := removeContainer(ctx, client, name, opts, log, ios, cs); err != nil {
+		if err := removeContainer(ctx, client, name, preRemoveHook, opts, log, ios); err != nil {
 			errs = append(errs, err)
 			fmt.Fprintf(ios.ErrOut, "%s %s: %v\n", cs.FailureIcon(), name, err)
 		} else {
@@ -137,7 +163,7 @@ func removeRun(ctx context.Context, opts *RemoveOptions) error {
 	return nil
 }
 
-func removeContainer(ctx context.Context, client *docker.Client, name string, opts *RemoveOptions, log *logger.Logger, ios *iostreams.IOStreams, cs *iostreams.ColorScheme) error {
+func removeContainer(ctx context.Context, client *docker.Client, name, preRemoveHook string, opts *RemoveOptions, log *logger.Logger, ios *iostreams.IOStreams) error {
 	// Find container by name
 	container, err := client.FindContainerByName(ctx, name)
 	if err != nil {
@@ -147,71 +173,13 @@ func removeContainer(ctx context.Context, client *docker.Client, name string, op
 		return fmt.Errorf("container %q not found", name)
 	}
 
-	// Disable firewall enforcement for this container (best-effort). Runs
-	// before container removal so the CP can resolve the cgroup via Docker.
-	// If it fails we still proceed, but surface a user-visible warning
-	// because orphaned BPF state leaks until the next firewall restart.
-	if opts.AdminClient != nil {
-		client, cErr := opts.AdminClient(ctx)
-		if cErr != nil {
-			log.Warn().Err(cErr).Str("container", container.ID).Msg("failed to reach control plane for firewall disable")
-			fmt.Fprintf(ios.ErrOut, "%s firewall disable skipped for %s: could not reach control plane: %v (BPF resources may leak until next firewall restart)\n",
-				cs.WarningIcon(), name, cErr)
-		} else if _, disableErr := client.FirewallDisable(ctx, &adminv1.FirewallDisableRequest{ContainerId: container.ID}); disableErr != nil {
-			log.Warn().Err(disableErr).Str("container", container.ID).Msg("failed to disable firewall")
-			fmt.Fprintf(ios.ErrOut, "%s firewall disable failed for %s: %v (BPF resources may leak until next firewall restart)\n",
-				cs.WarningIcon(), name, disableErr)
-		}
-	}
-
-	// Stop socket bridge before removing the container (best-effort)
-	if opts.SocketBridge != nil {
-		if mgr := opts.SocketBridge(); mgr != nil {
-			if err := mgr.StopBridge(container.ID); err != nil {
-				log.Warn().Err(err).Str("container", container.ID).Msg("failed to stop socket bridge")
-			}
-		}
-	}
-
-	// Use RemoveContainerWithVolumes if volumes flag is set
-	if opts.Volumes {
-		if err := client.RemoveContainerWithVolumes(ctx, container.ID, opts.Force); err != nil {
-			return err
-		}
-	} else {
-		// Otherwise just remove the container
-		if _, err = client.ContainerRemove(ctx, container.ID, opts.Force); err != nil {
-			return err
-		}
-	}
-
-	// Drop the agent row keyed by container_id. Best-effort:
-	// if the DB doesn't yet exist (fresh install with no managed
-	// container) or the eviction fails, the start path's evict-on-die
-	// dockerevents subscription cleans up later. Container removal is
-	// the user-facing success of this command, so registry hiccups
-	// must not surface as remove failures.
-	registryDBPath, pathErr := consts.ControlPlaneDBPath()
-	if pathErr != nil {
-		log.Debug().Err(pathErr).Msg("agent: skipping evict on remove (db path unresolved)")
-		return nil
-	}
-	reg, openErr := agent.NewSQLiteWriter(registryDBPath, log)
-	if openErr != nil {
-		log.Debug().Err(openErr).Msg("agent: skipping evict on remove (db open failed)")
-		return nil
-	}
-	defer func() {
-		if closer, ok := reg.(interface{ Close() error }); ok {
-			_ = closer.Close()
-		}
-	}()
-	if err := reg.EvictByContainerID(container.ID); err != nil {
-		// Best-effort: container removal already succeeded above so a
-		// registry hiccup must not surface as a remove failure. Log at
-		// debug; the dockerevents subscription / startup reap heals
-		// the orphan row later.
-		log.Debug().Err(err).Str("container_id", container.ID).Msg("agent: evict on remove failed")
-	}
-	return nil
+	return shared.RemoveContainer(ctx, client, name, *container, shared.RemoveContainerOptions{
+		IOStreams:     ios,
+		AdminClient:   opts.AdminClient,
+		SocketBridge:  opts.SocketBridge,
+		Log:           log,
+		PreRemoveHook: preRemoveHook,
+		Force:         opts.Force,
+		Volumes:       opts.Volumes,
+	})
 }
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/shlex"
@@ -12,6 +14,7 @@ import (
 	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
 	adminv1mocks "github.com/schmitthub/clawker/api/admin/v1/mocks"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -133,7 +136,7 @@ func TestNewCmdRemove(t *testing.T) {
 }
 
 func TestCmdRemove_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdRemove(f, nil)
 
 	require.Equal(t, "remove [OPTIONS] CONTAINER [CONTAINER...]", cmd.Use)
@@ -380,6 +383,83 @@ func TestRemoveRun_NilFirewall(t *testing.T) {
 	fake.AssertCalled(t, "ContainerRemove")
 }
 
+func TestRemoveRun_PreRemoveHook(t *testing.T) {
+	setup := func(t *testing.T, hook string) (*mocks.FakeClient, *cmdutil.Factory, *bytes.Buffer) {
+		t.Helper()
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fixture := mocks.ContainerFixture("myapp", "dev", "node:20-slim")
+		fake.SetupFindContainer("clawker.myapp.dev", fixture)
+		fake.FakeAPI.ContainerRemoveFn = func(_ context.Context, _ string, _ mobyclient.ContainerRemoveOptions) (mobyclient.ContainerRemoveResult, error) {
+			return mobyclient.ContainerRemoveResult{}, nil
+		}
+		f, _, _, errOut := testFactory(t, fake, nil, nil)
+		f.Config = func() (config.Config, error) {
+			return configmocks.NewFromString("hooks:\n  pre_remove: '"+hook+"'\n", ""), nil
+		}
+		return fake, f, errOut
+	}
+
+	t.Run("hook receives the container context", func(t *testing.T) {
+		ctxFile := filepath.Join(t.TempDir(), "ctx.json")
+		fake, f, _ := setup(t, "cat > "+ctxFile)
+
+		cmd := NewCmdRemove(f, nil)
+		cmd.SetArgs([]string{"clawker.myapp.dev"})
+		require.NoError(t, cmd.Execute())
+
+		fake.AssertCalled(t, "ContainerRemove")
+		data, err := os.ReadFile(ctxFile)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"event":"pre_remove"`)
+		require.Contains(t, string(data), `"container_name":"clawker.myapp.dev"`)
+	})
+
+	t.Run("failing hook skips the removal", func(t *testing.T) {
+		fake, f, errOut := setup(t, "exit 3")
+
+		cmd := NewCmdRemove(f, nil)
+		cmd.SetArgs([]string{"clawker.myapp.dev"})
+		err := cmd.Execute()
+		require.ErrorIs(t, err, cmdutil.SilentError)
+
+		fake.AssertNotCalled(t, "ContainerRemove")
+		require.Contains(t, errOut.String(), "pre_remove hook failed")
+	})
+}
+
+func TestRemoveRun_DryRun(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig(), mocks.WithDryRun())
+	fixture := mocks.ContainerFixture("myapp", "dev", "node:20-slim")
+	fake.SetupFindContainer("clawker.myapp.dev", fixture)
+
+	bridge := sockebridgemocks.NewMockManager()
+	fwMock := &adminv1mocks.AdminServiceClientMock{}
+	f, in, out, errOut := testFactory(t, fake, bridge, fwMock)
+	hookRan := filepath.Join(t.TempDir(), "hook-ran")
+	f.Config = func() (config.Config, error) {
+		return configmocks.NewFromString("hooks:\n  pre_remove: 'touch "+hookRan+"'\n", ""), nil
+	}
+
+	cmd := NewCmdRemove(f, nil)
+	require.True(t, cmdutil.SupportsDryRun(cmd))
+	cmd.SetArgs([]string{"clawker.myapp.dev"})
+	cmd.SetIn(in)
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+	require.NoError(t, cmd.Execute())
+
+	// Only the Docker call happens, and only in the journal.
+	fake.AssertNotCalled(t, "ContainerRemove")
+	report := fake.Client.DryRunReport()
+	require.NotEmpty(t, report)
+	require.Equal(t, "remove container "+fixture.ID, report[0].String())
+
+	require.NoFileExists(t, hookRan)
+	require.Contains(t, errOut.String(), "would run the pre_remove hook")
+	require.Empty(t, fwMock.FirewallDisableCalls())
+	require.False(t, sockebridgemocks.CalledWith(bridge, "StopBridge", fixture.ID))
+}
+
 func TestRemoveRun_DockerConnectionError(t *testing.T) {
 	tio, in, out, errOut := iostreams.Test()
 	f := &cmdutil.Factory{
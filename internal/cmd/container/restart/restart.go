@@ -92,6 +92,8 @@ Container names can be:
 	cmd.Flags().IntVarP(&opts.Timeout, "time", "t", 10, "Seconds to wait before killing the container")
 	cmd.Flags().StringVarP(&opts.Signal, "signal", "s", "", "Signal to send (default: SIGTERM)")
 
+	cmd.ValidArgsFunction = cmdutil.ContainerCompletions(f.Client, f.ProjectManager)
+
 	return cmd
 }
 
@@ -171,6 +173,7 @@ func restartContainer(
 		Logger:       opts.Logger,
 		AgentName:    "",
 		Project:      "",
+		HookOut:      opts.IOStreams.ErrOut,
 	}
 
 	// If signal specified, kill with that signal first, then start
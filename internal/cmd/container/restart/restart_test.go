@@ -12,6 +12,7 @@ import (
 	cpbootmocks "github.com/schmitthub/clawker/controlplane/manager/mocks"
 	"github.com/schmitthub/clawker/internal/cmd/container/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -116,7 +117,7 @@ func TestNewCmdRestart(t *testing.T) {
 }
 
 func TestCmdRestart_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdRestart(f, nil)
 
 	// Test command basics
@@ -143,7 +144,7 @@ func TestCmdRestart_Properties(t *testing.T) {
 }
 
 func TestCmdRestart_MultipleContainers(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	var gotOpts *RestartOptions
 	cmd := NewCmdRestart(f, func(_ context.Context, opts *RestartOptions) error {
@@ -166,23 +167,12 @@ func TestCmdRestart_MultipleContainers(t *testing.T) {
 
 func testRestartFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return configmocks.NewFromString("", `firewall: { enable: false }`), nil
-		},
-		ControlPlane: func() manager.Manager {
-			return &cpbootmocks.ManagerMock{
-				EnsureRunningFunc: func(context.Context) error { return nil },
-			}
-		},
-	}, in, out, errOut
+	tf := cmdutiltest.NewFactory(t,
+		cmdutiltest.WithFakeClient(fake),
+		cmdutiltest.WithConfig(configmocks.NewFromString("", `firewall: { enable: false }`)),
+	)
+	tf.ControlPlane = cmdutiltest.RunningControlPlane()
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 // TestRestartRun_PreStartFailureReapsAutoRemove pins the plain-restart
@@ -254,6 +244,7 @@ func TestRestartRun_Success(t *testing.T) {
 	fake.SetupFindContainer("clawker.myapp.dev", fixture)
 	fake.SetupContainerRestart()
 	fake.SetupCopyToContainer() // BootstrapServicesPreStart always delivers the pre_run hook
+	fake.SetupNetworkConnect()  // the fixture predates its project network
 
 	f, in, out, errOut := testRestartFactory(t, fake)
 
@@ -317,6 +308,7 @@ func TestRestartRun_PartialFailure(t *testing.T) {
 	fake.SetupFindContainer("clawker.myapp.dev", fixture1)
 	fake.SetupContainerRestart()
 	fake.SetupCopyToContainer() // BootstrapServicesPreStart always delivers the pre_run hook
+	fake.SetupNetworkConnect()  // the fixture predates its project network
 
 	f, in, out, errOut := testRestartFactory(t, fake)
 
@@ -0,0 +1,197 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mobyClient "github.com/moby/moby/client"
+	"github.com/spf13/cobra"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/controlplane/manager"
+	"github.com/schmitthub/clawker/internal/cmd/container/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/hostproxy"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/socketbridge"
+)
+
+// RestoreOptions holds options for the restore command.
+type RestoreOptions struct {
+	IOStreams      *iostreams.IOStreams
+	Client         func(context.Context) (*docker.Client, error)
+	Config         func() (config.Config, error)
+	ProjectManager func() (project.ProjectManager, error)
+	HostProxy      func() hostproxy.Service
+	ControlPlane   func() manager.Manager
+	AdminClient    func(context.Context) (adminv1.AdminServiceClient, error)
+	SocketBridge   func() socketbridge.SocketBridgeManager
+	Logger         func() (*logger.Logger, error)
+
+	Agent         bool
+	Checkpoint    string
+	CheckpointDir string
+
+	Container string
+}
+
+// NewCmdRestore creates the container restore command.
+func NewCmdRestore(f *cmdutil.Factory, runF func(context.Context, *RestoreOptions) error) *cobra.Command {
+	opts := &RestoreOptions{
+		IOStreams:      f.IOStreams,
+		Client:         f.Client,
+		Config:         f.Config,
+		ProjectManager: f.ProjectManager,
+		HostProxy:      f.HostProxy,
+		ControlPlane:   f.ControlPlane,
+		AdminClient:    f.AdminClient,
+		SocketBridge:   f.SocketBridge,
+		Logger:         f.Logger,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "restore [OPTIONS] CONTAINER",
+		Short: "Start a stopped container from a checkpoint (experimental)",
+		Long: `Starts a stopped clawker container from a checkpoint taken with
+'clawker container checkpoint', resuming its processes with the in-memory
+state they had when the checkpoint was written.
+
+The start runs the same host-service bootstrap as 'clawker container start'
+(control plane, host proxy, firewall). Without --checkpoint the container's
+only checkpoint is used; when it has several, name one.
+
+Experimental: needs the same daemon support as checkpoint — a rootful Linux
+Docker daemon with experimental features on and CRIU installed. To restore on
+another machine, create the container there from the same image and
+configuration, copy the checkpoint directory over, and pass --checkpoint-dir.
+
+When --agent is provided, the container name is resolved as clawker.<project>.<agent>
+using the project resolved from the current directory.`,
+		Example: `  # Restore an agent from its only checkpoint
+  clawker container restore --agent dev
+
+  # Restore a named checkpoint
+  clawker container restore --agent dev --checkpoint before-refactor
+
+  # Restore from a checkpoint copied from another machine
+  clawker container restore --agent dev --checkpoint before-refactor --checkpoint-dir /mnt/share/checkpoints`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Container = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return restoreRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Agent, "agent", false, "Treat the argument as an agent name (resolves to clawker.<project>.<agent>)")
+	cmd.Flags().StringVar(&opts.Checkpoint, "checkpoint", "", "Checkpoint to restore (default: the container's only checkpoint)")
+	cmd.Flags().StringVar(&opts.CheckpointDir, "checkpoint-dir", "", "Directory the checkpoint was written to (default: the daemon's own)")
+
+	cmd.ValidArgsFunction = cmdutil.ContainerCompletions(f.Client, f.ProjectManager)
+
+	return cmd
+}
+
+func restoreRun(ctx context.Context, opts *RestoreOptions) error {
+	ios := opts.IOStreams
+
+	name := opts.Container
+	if opts.Agent {
+		var projectName string
+		if opts.ProjectManager != nil {
+			if pm, pmErr := opts.ProjectManager(); pmErr == nil {
+				if p, pErr := pm.CurrentProject(ctx); pErr == nil {
+					projectName = p.Name()
+				}
+			}
+		}
+		resolved, err := docker.ContainerNamesFromAgents(projectName, []string{name})
+		if err != nil {
+			return err
+		}
+		name = resolved[0]
+	}
+
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+	if err := client.CheckpointSupport(); err != nil {
+		return err
+	}
+
+	c, err := client.FindContainerByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to find container %q: %w", name, err)
+	}
+	if c == nil {
+		return fmt.Errorf("container %q not found", name)
+	}
+
+	checkpointID := opts.Checkpoint
+	if checkpointID == "" {
+		checkpointID, err = onlyCheckpoint(ctx, client, c.ID, name, opts.CheckpointDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = shared.ContainerStart(ctx,
+		shared.CommandOpts{
+			Client:       opts.Client,
+			Config:       opts.Config,
+			HostProxy:    opts.HostProxy,
+			ControlPlane: opts.ControlPlane,
+			AdminClient:  opts.AdminClient,
+			SocketBridge: opts.SocketBridge,
+			Logger:       opts.Logger,
+			HookOut:      ios.ErrOut,
+		},
+		docker.ContainerStartOptions{
+			ContainerStartOptions: mobyClient.ContainerStartOptions{
+				CheckpointID:  checkpointID,
+				CheckpointDir: opts.CheckpointDir,
+			},
+			ContainerID: c.ID,
+			EnsureNetwork: &docker.EnsureNetworkOptions{
+				Name: cfg.ClawkerNetwork(),
+			},
+		})
+	if err != nil {
+		return fmt.Errorf("restoring %s from checkpoint %s: %w", name, checkpointID, err)
+	}
+
+	fmt.Fprintln(ios.Out, name)
+	return nil
+}
+
+// onlyCheckpoint returns the container's checkpoint when it has exactly one.
+func onlyCheckpoint(ctx context.Context, client *docker.Client, containerID, name, dir string) (string, error) {
+	items, err := client.CheckpointList(ctx, containerID, mobyClient.CheckpointListOptions{CheckpointDir: dir})
+	if err != nil {
+		return "", err
+	}
+	switch len(items) {
+	case 0:
+		return "", fmt.Errorf("container %s has no checkpoints; create one with: clawker container checkpoint %s", name, name)
+	case 1:
+		return items[0].Name, nil
+	}
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+	return "", cmdutil.FlagErrorf("container %s has %d checkpoints (%s); choose one with --checkpoint",
+		name, len(items), strings.Join(names, ", "))
+}
@@ -0,0 +1,114 @@
+package restore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/moby/api/types/checkpoint"
+	mobyclient "github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/hostproxy"
+	"github.com/schmitthub/clawker/internal/hostproxy/hostproxytest"
+)
+
+var _blankCfg = configmocks.NewBlankConfig()
+
+func TestNewCmdRestore(t *testing.T) {
+	f := cmdutiltest.NewFactory(t).Factory
+	var gotOpts *RestoreOptions
+	cmd := NewCmdRestore(f, func(_ context.Context, opts *RestoreOptions) error {
+		gotOpts = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"--agent", "dev", "--checkpoint", "cp1", "--checkpoint-dir", "/tmp/cp"})
+	require.NoError(t, cmd.Execute())
+	require.NotNil(t, gotOpts)
+	assert.Equal(t, "dev", gotOpts.Container)
+	assert.True(t, gotOpts.Agent)
+	assert.Equal(t, "cp1", gotOpts.Checkpoint)
+	assert.Equal(t, "/tmp/cp", gotOpts.CheckpointDir)
+}
+
+// restoreFake is a fake with one stopped container, clawker.myapp.dev,
+// holding the given checkpoints; started records the start options.
+func restoreFake(t *testing.T, checkpoints ...string) (*mocks.FakeClient, *mobyclient.ContainerStartOptions) {
+	t.Helper()
+	fake := mocks.NewFakeClient(_blankCfg)
+	fake.SetupFindContainer("clawker.myapp.dev", mocks.ContainerFixture("myapp", "dev", "img:latest"))
+	fake.SetupNetworkExists("", true) // the clawker network and the fixture's project network
+	fake.FakeAPI.NetworkConnectFn = func(_ context.Context, _ string, _ mobyclient.NetworkConnectOptions) (mobyclient.NetworkConnectResult, error) {
+		return mobyclient.NetworkConnectResult{}, nil
+	}
+	fake.SetupCopyToContainer() // BootstrapServicesPreStart always delivers the pre_run hook
+	fake.FakeAPI.CheckpointListFn = func(context.Context, string, mobyclient.CheckpointListOptions) (mobyclient.CheckpointListResult, error) {
+		var res mobyclient.CheckpointListResult
+		for _, name := range checkpoints {
+			res.Items = append(res.Items, checkpoint.Summary{Name: name})
+		}
+		return res, nil
+	}
+	started := &mobyclient.ContainerStartOptions{}
+	fake.FakeAPI.ContainerStartFn = func(_ context.Context, _ string, opts mobyclient.ContainerStartOptions) (mobyclient.ContainerStartResult, error) {
+		*started = opts
+		return mobyclient.ContainerStartResult{}, nil
+	}
+	return fake, started
+}
+
+func runRestore(t *testing.T, fake *mocks.FakeClient, args ...string) (*cmdutiltest.Factory, error) {
+	t.Helper()
+	tf := cmdutiltest.NewFactory(t,
+		cmdutiltest.WithFakeClient(fake),
+		cmdutiltest.WithConfig(configmocks.NewFromString(`security: { enable_host_proxy: false }`, `firewall: { enable: false }`)),
+	)
+	tf.HostProxy = func() hostproxy.Service { return hostproxytest.NewMockManager() }
+	tf.ControlPlane = cmdutiltest.RunningControlPlane()
+
+	cmd := NewCmdRestore(tf.Factory, nil)
+	cmd.SetArgs(args)
+	cmd.SetIn(tf.In)
+	cmd.SetOut(tf.Out)
+	cmd.SetErr(tf.ErrOut)
+	return tf, cmd.Execute()
+}
+
+func TestRestoreRun_OnlyCheckpoint(t *testing.T) {
+	fake, started := restoreFake(t, "clawker-20260304T050607Z")
+
+	tf, err := runRestore(t, fake, "clawker.myapp.dev")
+	require.NoError(t, err)
+	assert.Equal(t, "clawker-20260304T050607Z", started.CheckpointID)
+	assert.Contains(t, tf.Out.String(), "clawker.myapp.dev")
+}
+
+func TestRestoreRun_NamedCheckpoint(t *testing.T) {
+	fake, started := restoreFake(t, "a", "b")
+
+	_, err := runRestore(t, fake, "--checkpoint", "b", "--checkpoint-dir", "/srv/cp", "clawker.myapp.dev")
+	require.NoError(t, err)
+	assert.Equal(t, mobyclient.ContainerStartOptions{CheckpointID: "b", CheckpointDir: "/srv/cp"}, *started)
+	fake.AssertNotCalled(t, "CheckpointList")
+}
+
+func TestRestoreRun_AmbiguousCheckpoint(t *testing.T) {
+	fake, _ := restoreFake(t, "a", "b")
+
+	_, err := runRestore(t, fake, "clawker.myapp.dev")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has 2 checkpoints (a, b); choose one with --checkpoint")
+	fake.AssertNotCalled(t, "ContainerStart")
+}
+
+func TestRestoreRun_NoCheckpoints(t *testing.T) {
+	fake, _ := restoreFake(t)
+
+	_, err := runRestore(t, fake, "clawker.myapp.dev")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no checkpoints")
+	fake.AssertNotCalled(t, "ContainerStart")
+}
@@ -0,0 +1,160 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/schmitthub/clawker/internal/cmd/container/shared"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// printPlan resolves the container create request with shared.PlanContainer
+// and prints it: YAML by default, a "container.plan" envelope with --json.
+// With --print-env only the env is printed (see printEnv).
+func printPlan(ctx context.Context, client *docker.Client, cfg config.Config, projectName string, opts *RunOptions) error {
+	log, err := opts.Logger()
+	if err != nil {
+		return fmt.Errorf("initializing logger: %w", err)
+	}
+	if log == nil {
+		log = logger.Nop()
+	}
+	ios := opts.IOStreams
+
+	plan, err := shared.PlanContainer(ctx, &shared.CreateContainerOptions{
+		Client:          client,
+		Config:          cfg,
+		ProjectName:     projectName,
+		Options:         opts.ContainerCreateOptions,
+		Flags:           opts.flags,
+		Version:         opts.Version,
+		ProjectManager:  opts.ProjectManager,
+		ProjectRegistry: opts.ProjectRegistry,
+		HostProxy:       opts.HostProxy,
+		Log:             log,
+		Is256Color:      ios.Is256ColorSupported(),
+		IsTrueColor:     ios.IsTrueColorSupported(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.PrintEnv {
+		return printEnv(opts, plan.Env)
+	}
+	if opts.JSON {
+		return ios.JSONPrinter().Print("container.plan", plan)
+	}
+	out, err := planYAML(plan)
+	if err != nil {
+		return err
+	}
+	_, err = ios.Out.Write(out)
+	return err
+}
+
+// printEnv prints the merged container env as a KEY/VALUE/SOURCE table, or a
+// "container.env" envelope with --json. SOURCE names the winning layer and,
+// in parentheses, the layers it overrode.
+func printEnv(opts *RunOptions, env []shared.EnvVar) error {
+	ios := opts.IOStreams
+	if opts.JSON {
+		if env == nil {
+			env = []shared.EnvVar{}
+		}
+		return ios.JSONPrinter().Print("container.env", env)
+	}
+
+	cs := ios.ColorScheme()
+	tp := opts.TUI.NewTable("KEY", "VALUE", "SOURCE")
+	for _, v := range env {
+		value := v.Value
+		if v.Unset {
+			value = cs.Muted("(unset)")
+		}
+		source := string(v.Source)
+		if len(v.Overrides) > 0 {
+			overrides := make([]string, len(v.Overrides))
+			for i, o := range v.Overrides {
+				overrides[i] = string(o)
+			}
+			source += cs.Muted(" (over " + strings.Join(overrides, ", ") + ")")
+		}
+		tp.AddRow(v.Key, value, source)
+	}
+	return tp.Render()
+}
+
+// planYAML renders the plan as block YAML keyed by the Docker API field
+// names. It goes through JSON so the moby types' json tags apply; decoding
+// the JSON as a yaml.Node keeps the field order. Unset API fields are
+// dropped to keep the output readable (--json keeps them).
+func planYAML(plan *shared.ContainerPlan) ([]byte, error) {
+	data, err := json.Marshal(plan)
+	if err != nil {
+		return nil, fmt.Errorf("encoding plan: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("encoding plan: %w", err)
+	}
+	tidyPlanNode(&doc)
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("encoding plan: %w", err)
+	}
+	return out, nil
+}
+
+// tidyPlanNode clears the flow style the JSON source gave every node and
+// drops the mapping entries that hold a zero value. Only Docker API fields
+// (PascalCase keys) are dropped: map keys that are user data — label names,
+// tmpfs paths, sysctls — keep an empty value, which is meaningful there.
+func tidyPlanNode(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		tidyPlanNode(c)
+	}
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	kept := n.Content[:0]
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if isAPIField(key.Value) && isZeroNode(value) {
+			continue
+		}
+		kept = append(kept, key, value)
+	}
+	n.Content = kept
+}
+
+func isAPIField(key string) bool {
+	return key != "" && unicode.IsUpper(rune(key[0]))
+}
+
+func isZeroNode(n *yaml.Node) bool {
+	switch n.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(n.Content) == 0
+	case yaml.ScalarNode:
+		switch n.Tag {
+		case "!!null":
+			return true
+		case "!!str":
+			return n.Value == ""
+		case "!!bool":
+			return n.Value == "false"
+		case "!!int", "!!float":
+			return n.Value == "0"
+		}
+	}
+	return false
+}
@@ -3,12 +3,15 @@ package run
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/moby/moby/api/pkg/stdcopy"
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
@@ -19,10 +22,12 @@ import (
 	wtshared "github.com/schmitthub/clawker/internal/cmd/worktree/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/hostproxy"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/notify"
 	"github.com/schmitthub/clawker/internal/project"
 	"github.com/schmitthub/clawker/internal/prompter"
 	"github.com/schmitthub/clawker/internal/signals"
@@ -47,15 +52,40 @@ type RunOptions struct {
 	Prompter        func() *prompter.Prompter
 	Logger          func() (*logger.Logger, error)
 	BundleManager   func() (*bundle.Manager, error)
+	Notifier        func() *notify.Notifier
 	Version         string
 
 	// Run-specific options
-	Detach bool
+	Detach      bool
+	DetachKeys  string
+	WaitForPort string
+	WaitHealthy bool
+	WaitTimeout time.Duration
+	Reuse       bool
+	// KeepSidecars leaves the project's sidecars running after the agent
+	// exits.
+	KeepSidecars bool
+	// KeepAlive labels the container so the idle reaper never stops it.
+	KeepAlive bool
+	// Plan prints the resolved create request instead of running it;
+	// PrintEnv prints only its env, with each var's source. JSON prints
+	// either as a versioned JSON envelope.
+	Plan     bool
+	PrintEnv bool
+	JSON     bool
 
 	// Computed fields (set during execution)
 	AgentName string
 	Project   string
 
+	// detachSpec and detachKeys are the resolved detach key sequence: the spec
+	// handed to the daemon and its parsed bytes for the PTY handler.
+	detachSpec string
+	detachKeys []byte
+
+	// waitPort is the parsed --wait-for-port value; zero when not waiting.
+	waitPort network.Port
+
 	// Internal (set by RunE before calling runRun)
 	flags *pflag.FlagSet
 }
@@ -78,6 +108,7 @@ func NewCmdRun(f *cmdutil.Factory, runF func(context.Context, *RunOptions) error
 		Prompter:               f.Prompter,
 		Logger:                 f.Logger,
 		BundleManager:          f.BundleManager,
+		Notifier:               f.Notifier,
 		Version:                f.Version,
 	}
 
@@ -95,7 +126,50 @@ If IMAGE is "@", clawker resolves the built image for the current scope: the
 project image inside a registered project, or the global image (built with
 "clawker build" outside any project) elsewhere. "@" selects the default
 harness image; "@:<harness>" (e.g. "@:codex") selects a specific harness
-image built with "clawker build -t <harness>".`,
+image built with "clawker build -t <harness>".
+
+In an interactive session, ctrl-p, ctrl-q detaches and leaves the container
+running. Override the sequence with --detach-keys or
+settings.terminal.detach_keys.
+
+With --detach, --wait-for-port PORT[/PROTO] holds the command until the
+container port, published with -p or -P, accepts connections on the host. It
+fails if the container exits first or --wait-timeout (default 60s) elapses;
+on timeout the container is left running. Only tcp ports can be waited on.
+--wait-healthy likewise holds the command until the container's healthcheck
+reports healthy — for clawker images, until the agent command has started.
+It fails with the last probe output if the container turns unhealthy.
+
+--reuse makes run idempotent for an --agent: an existing container for the
+agent is reused instead of created. A stopped container is started; a running
+one is stopped and started again so its command begins a fresh session. Only
+when the agent has no container is a new one created. A reused container
+keeps the command, mounts, environment and TTY settings it was created with;
+create-time flags and COMMAND apply only to a newly created container.
+
+When the project config declares sidecars: (a database, a local service),
+run starts each as its own container on the clawker network before the agent
+and waits for it to report healthy. The agent reaches a sidecar at its name.
+When the agent exits, its sidecars are removed along with their data, unless
+--keep-sidecars is set; a detached agent keeps them until it is removed with
+"clawker container rm".
+
+When settings idle_timeout is set, the host proxy stops agent containers that
+go that long without terminal or exec activity, warning on the agent's
+console a few minutes before. --keep-alive exempts this container.
+
+--plan prints the container config, host config (mounts included), env,
+labels and networks the container would be created with, then exits without
+creating anything. Use it to see why a flag or config field is not taking
+effect. Secret values are redacted, sidecars are not started, and --worktree
+is not supported.
+
+The container env is merged from four sources, each overriding the one
+before: settings (clawker's runtime env), project (agent.env_file, from_env
+and env, the harness's env, build.instructions.env), secret (env secrets)
+and cli (--env-file, then -e). --env-unset KEY removes KEY whichever source
+set it, including the image. --print-env shows the merged env and the
+source of each variable, resolved the same way as --plan.`,
 		Example: `  # Run an interactive shell
   clawker container run -it --agent ralph @ 
 
@@ -109,6 +183,12 @@ image built with "clawker build -t <harness>".`,
   # Run in detached mode (background)
   clawker container run --detach --agent web @ -p "build entire app, don't make mistakes" --dangerously-skip-permissions
 
+  # Start a dev server in the background and return once port 3000 answers
+  clawker container run --detach --agent web -p 3000:3000 --wait-for-port 3000 @ npm run dev
+
+  # Get the agent's container, creating it only if it does not exist yet
+  clawker container run -it --reuse --agent dev @
+
   # Bypass the harness and run system commands on the container directly
   clawker container run --agent worker @ echo "Hello" 
   clawker container run --agent worker @ zsh 
@@ -121,7 +201,16 @@ image built with "clawker build -t <harness>".`,
   clawker container run -it --agent dev -v /host/path:/container/path @
 
   # Run and automatically remove on exit
-  clawker container run --rm -it @`,
+  clawker container run --rm -it @
+
+  # Show the resolved container config without creating it
+  clawker container run --plan --agent dev -e DEBUG=1 @
+
+  # The same plan as JSON
+  clawker container run --plan --json --agent dev @
+
+  # Show where each env var comes from, dropping one set by the project
+  clawker container run --print-env --env-unset HTTP_PROXY --agent dev @`,
 		Args: cmdutil.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			containerOpts.Image = args[0]
@@ -129,6 +218,28 @@ image built with "clawker build -t <harness>".`,
 				containerOpts.Command = args[1:]
 			}
 			opts.flags = cmd.Flags()
+			if opts.WaitForPort != "" {
+				if !opts.Detach {
+					return cmdutil.FlagErrorf("--wait-for-port requires --detach")
+				}
+				p, err := shared.ParseContainerPort(opts.WaitForPort)
+				if err != nil {
+					return cmdutil.FlagErrorWrap(err)
+				}
+				opts.waitPort = p
+			}
+			if opts.WaitHealthy && !opts.Detach {
+				return cmdutil.FlagErrorf("--wait-healthy requires --detach")
+			}
+			if cmd.Flags().Changed("wait-timeout") && opts.WaitForPort == "" && !opts.WaitHealthy {
+				return cmdutil.FlagErrorf("--wait-timeout requires --wait-for-port or --wait-healthy")
+			}
+			if opts.Reuse && containerOpts.Agent == "" {
+				return cmdutil.FlagErrorf("--reuse requires --agent")
+			}
+			if opts.JSON && !opts.Plan && !opts.PrintEnv {
+				return cmdutil.FlagErrorf("--json requires --plan or --print-env")
+			}
 			if runF != nil {
 				return runF(cmd.Context(), opts)
 			}
@@ -145,6 +256,23 @@ image built with "clawker build -t <harness>".`,
 	// Run-specific flags
 	// Note: NOT using -d shorthand as it conflicts with global --debug flag
 	cmd.Flags().BoolVar(&opts.Detach, "detach", false, "Run container in background and print container ID")
+	cmd.Flags().StringVar(&opts.DetachKeys, "detach-keys", "", "Override the key sequence for detaching a container (e.g. ctrl-a,d)")
+	cmd.Flags().StringVar(&opts.WaitForPort, "wait-for-port", "", "With --detach, wait until the published container PORT[/PROTO] accepts connections")
+	cmd.Flags().BoolVar(&opts.WaitHealthy, "wait-healthy", false, "With --detach, wait until the container's healthcheck reports healthy")
+	cmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", 60*time.Second, "Maximum time to wait for --wait-for-port or --wait-healthy")
+	cmd.Flags().BoolVar(&opts.Reuse, "reuse", false, "Reuse the agent's existing container, creating one only if none exists")
+	cmd.MarkFlagsMutuallyExclusive("reuse", "rm")
+	cmd.Flags().BoolVar(&opts.KeepSidecars, "keep-sidecars", false, "Leave the project's sidecars running after the agent exits")
+	cmd.Flags().BoolVar(&opts.KeepAlive, "keep-alive", false, "Never stop this container for inactivity (settings idle_timeout)")
+	cmd.Flags().BoolVar(&opts.Plan, "plan", false, "Print the resolved container configuration and exit without creating it")
+	cmd.Flags().BoolVar(&opts.PrintEnv, "print-env", false, "Print the resolved container env with the source of each variable and exit")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "With --plan or --print-env, output as versioned JSON envelope")
+	cmd.MarkFlagsMutuallyExclusive("plan", "print-env")
+	cmd.MarkFlagsMutuallyExclusive("plan", "worktree")
+	cmd.MarkFlagsMutuallyExclusive("plan", "reuse")
+	cmd.MarkFlagsMutuallyExclusive("print-env", "worktree")
+	cmd.MarkFlagsMutuallyExclusive("print-env", "reuse")
+	cmdutil.EnableJSONOutput(cmd)
 
 	// Stop parsing flags after the first positional argument (IMAGE).
 	// This allows flags after IMAGE to be passed to the container command.
@@ -163,12 +291,24 @@ func runRun(ctx context.Context, opts *RunOptions) error {
 
 	// Opt-in bundle auto-update before the container resolves its harness/egress
 	// floor against the cached bundle set. Warn and proceed.
-	cmdutil.RunBundleAutoUpdate(ctx, opts.BundleManager, ios)
+	if !opts.Plan && !opts.PrintEnv {
+		cmdutil.RunBundleAutoUpdate(ctx, opts.BundleManager, ios)
+	}
 
 	cfg, err := opts.Config()
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
+	// Fold in the project's agents.<name> override block, if any
+	cfg, err = config.ForAgent(cfg, containerOpts.Agent)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	// Resolved up front so a bad sequence fails before anything is created.
+	opts.detachSpec, opts.detachKeys, err = shared.ResolveDetachKeys(opts.DetachKeys, cfg)
+	if err != nil {
+		return err
+	}
 
 	// --- Phase A: Pre-progress (synchronous) ---
 	// Config + Docker connect + image resolution — may trigger interactive prompts.
@@ -203,6 +343,28 @@ func runRun(ctx context.Context, opts *RunOptions) error {
 		}
 	}
 
+	if opts.Reuse {
+		existing, err := client.FindAgentContainer(ctx, projectName, containerOpts.Agent)
+		if err != nil {
+			return fmt.Errorf("looking up container for agent %q: %w", containerOpts.Agent, err)
+		}
+		if existing != nil {
+			// The reused container keeps the sidecar addresses it was created with.
+			if len(cfg.Project().Sidecars) > 0 {
+				_, release, err := startSidecars(ctx, client, opts, cfg.Project(), projectName, containerOpts.Agent)
+				if err != nil {
+					return err
+				}
+				defer release(existing.ID)
+			}
+			return reuseContainer(ctx, client, existing, opts)
+		}
+	}
+
+	if opts.KeepAlive {
+		containerOpts.Labels = append(containerOpts.Labels, consts.LabelKeepAlive+"=true")
+	}
+
 	if harnessTag, isPlaceholder := shared.ParseImagePlaceholder(containerOpts.Image); isPlaceholder {
 		ref, resolveErr := shared.ResolvePlaceholderImage(
 			ctx, client, cfg, ios, projectName, harnessTag, "run")
@@ -212,6 +374,10 @@ func runRun(ctx context.Context, opts *RunOptions) error {
 		containerOpts.Image = ref
 	}
 
+	if opts.Plan || opts.PrintEnv {
+		return printPlan(ctx, client, cfg, projectName, opts)
+	}
+
 	// Warn if workspace mount would include the home directory or higher
 	if shared.IsOutsideHome(".") {
 		confirmed, promptErr := opts.Prompter().Confirm(
@@ -226,6 +392,34 @@ func runRun(ctx context.Context, opts *RunOptions) error {
 		}
 	}
 
+	// Gate on flags that weaken the project's declared security posture
+	if err := shared.ConfirmSecurityOverrides(shared.SecurityGateOptions{
+		IOStreams: ios,
+		Prompter:  opts.Prompter,
+		Config:    cfg,
+		Options:   containerOpts,
+		Command:   "container run",
+		Project:   projectName,
+	}); err != nil {
+		return err
+	}
+
+	// Sidecars come up before the agent is created: their addresses go into
+	// the agent's hosts file. The agent name is fixed now so they can be
+	// labeled with it.
+	var agentID string
+	if len(cfg.Project().Sidecars) > 0 {
+		if containerOpts.GetAgentName() == "" {
+			containerOpts.Agent = docker.GenerateRandomName()
+		}
+		hosts, release, err := startSidecars(ctx, client, opts, cfg.Project(), projectName, containerOpts.GetAgentName())
+		if err != nil {
+			return err
+		}
+		defer func() { release(agentID) }()
+		containerOpts.ExtraHosts = append(containerOpts.ExtraHosts, hosts...)
+	}
+
 	type outcome struct {
 		result *shared.CreateContainerResult
 		err    error
@@ -251,6 +445,7 @@ func runRun(ctx context.Context, opts *RunOptions) error {
 			Log:             log,
 			Is256Color:      ios.Is256ColorSupported(),
 			IsTrueColor:     ios.IsTrueColorSupported(),
+			HookOut:         ios.ErrOut,
 		})
 		done <- outcome{r, err}
 	}()
@@ -261,17 +456,15 @@ func runRun(ctx context.Context, opts *RunOptions) error {
 	if o.err != nil {
 		return o.err
 	}
+	agentID = o.result.ContainerID
+	if r := o.result.RestoredSession; r != nil {
+		fmt.Fprintf(ios.ErrOut, "%s Restored the saved %s session from %s\n",
+			ios.ColorScheme().InfoIcon(), r.Harness, r.SavedAt.Local().Format(time.DateTime))
+	}
 
 	opts.AgentName = o.result.AgentName
 	opts.Project = projectName
 
-	// Bootstrap host services (CP ensure, host proxy, firewall init/rules)
-	// under a spinner BEFORE attach. Doing it here — in cooked mode, before
-	// pty.Setup hijacks the terminal — keeps the spinner clear of the raw-tty
-	// stream and guarantees the host stops writing to ios.ErrOut before
-	// clawkerd starts writing to the attached TTY (pty.Stream copies hijacked
-	// container output to os.Stdout). Both detach and attach paths share the
-	// same pre-start, so the bootstrap effort isn't repeated downstream.
 	cmdOpts := shared.CommandOpts{
 		Client:       opts.Client,
 		Config:       opts.Config,
@@ -283,39 +476,241 @@ func runRun(ctx context.Context, opts *RunOptions) error {
 		AgentName:    opts.AgentName,
 		Project:      opts.Project,
 	}
+	return startContainer(ctx, client, o.result.ContainerID, cmdOpts, opts, log)
+}
+
+// startContainer takes a created (or reused, stopped) container through
+// pre-start bootstrap, then either starts it detached and prints its ID or
+// hands off to attachThenStart. A detached start raises the agent_ready
+// desktop notification, or failure.
+func startContainer(
+	ctx context.Context,
+	client *docker.Client,
+	containerID string,
+	cmdOpts shared.CommandOpts,
+	opts *RunOptions,
+	log *logger.Logger,
+) (err error) {
+	ios := opts.IOStreams
+	if opts.Detach {
+		started := time.Now()
+		defer func() { notifyDetached(ctx, opts, time.Since(started), err) }()
+	}
+
+	// Bootstrap host services (CP ensure, host proxy, firewall init/rules)
+	// under a spinner BEFORE attach. Doing it here — in cooked mode, before
+	// pty.Setup hijacks the terminal — keeps the spinner clear of the raw-tty
+	// stream and guarantees the host stops writing to ios.ErrOut before
+	// clawkerd starts writing to the attached TTY (pty.Stream copies hijacked
+	// container output to os.Stdout). Both detach and attach paths share the
+	// same pre-start, so the bootstrap effort isn't repeated downstream.
 	if err := ios.RunWithSpinner("Bootstrapping host services", func() error {
-		return shared.BootstrapServicesPreStart(ctx, o.result.ContainerID, cmdOpts)
+		return shared.BootstrapServicesPreStart(ctx, containerID, cmdOpts)
 	}); err != nil {
-		// Reap-on-failed-start: this invocation just created the container —
-		// free its name so the same command can simply be re-run.
+		// Reap-on-failed-start: a never-started --rm container is removed so
+		// the same command can simply be re-run.
 		//nolint:contextcheck,wrapcheck // reap runs on context.Background (Ctrl+C must not abort it) and returns the already-wrapped caller error
 		return shared.ReapFailedStart(
 			client,
-			o.result.ContainerID,
+			containerID,
 			fmt.Errorf("pre-start bootstrapping failed: %w", err),
 		)
 	}
 
 	if opts.Detach {
 		// Pre-start already ran; just docker start + post-start (eBPF attach +
-		// socket bridge). No spinner — detach output is the container ID.
+		// socket bridge). No spinner — detach output is the container ID; the
+		// post_ready hook reports on stderr.
+		cmdOpts.HookOut = ios.ErrOut
 		//nolint:exhaustruct // start options: unset fields are intentional defaults; the moby embed is unnameable outside whail
 		if _, startErr := client.ContainerStart(
 			ctx,
-			docker.ContainerStartOptions{ContainerID: o.result.ContainerID},
+			docker.ContainerStartOptions{ContainerID: containerID},
 		); startErr != nil {
 			//nolint:contextcheck,wrapcheck // reap runs on context.Background (Ctrl+C must not abort it) and returns the already-wrapped caller error
-			return shared.ReapFailedStart(client, o.result.ContainerID, fmt.Errorf("starting container: %w", startErr))
+			return shared.ReapFailedStart(client, containerID, fmt.Errorf("starting container: %w", startErr))
 		}
-		if err := shared.BootstrapServicesPostStart(ctx, o.result.ContainerID, cmdOpts); err != nil {
+		if err := shared.BootstrapServicesPostStart(ctx, containerID, cmdOpts); err != nil {
 			return fmt.Errorf("starting container: %w", err)
 		}
 
-		fmt.Fprintln(ios.Out, o.result.ContainerID[:12])
+		// Not ContainerStartOptions.WaitHealthy: the clawker image's
+		// healthcheck waits on the ready marker clawkerd writes once the
+		// agent command is spawned, which needs the post-start bootstrap
+		// above — waiting inside the start would only time out.
+		if opts.WaitHealthy {
+			if err := ios.RunWithSpinner("Waiting for container to become healthy", func() error {
+				return client.ContainerWaitHealthy(ctx, containerID, opts.WaitTimeout)
+			}); err != nil {
+				return err
+			}
+		}
+
+		if !opts.waitPort.IsZero() {
+			label := fmt.Sprintf("Waiting for port %s", opts.waitPort)
+			if err := ios.RunWithSpinner(label, func() error {
+				return shared.WaitForPort(ctx, client, containerID, opts.waitPort, opts.WaitTimeout)
+			}); err != nil {
+				return err
+			}
+		}
+
+		fmt.Fprintln(ios.Out, containerID[:12])
 		return nil
 	}
 
-	return attachThenStart(ctx, client, o.result.ContainerID, cmdOpts, opts, log)
+	return attachThenStart(ctx, client, containerID, cmdOpts, opts, log)
+}
+
+// notifyDetached raises the desktop notification for a detached start that
+// finished: agent_ready once the agent is up (and healthy or listening, when
+// waited on), failure otherwise. An interrupted start raises nothing.
+func notifyDetached(ctx context.Context, opts *RunOptions, elapsed time.Duration, err error) {
+	if opts.Notifier == nil || ctx.Err() != nil {
+		return
+	}
+	note := notify.Notification{
+		Event:   notify.EventAgentReady,
+		Title:   strings.TrimSpace("clawker " + opts.Project),
+		Message: fmt.Sprintf("Agent %s is ready", opts.AgentName),
+		Elapsed: elapsed,
+	}
+	if err != nil {
+		note.Event = notify.EventFailure
+		note.Message = fmt.Sprintf("Agent %s failed to start", opts.AgentName)
+	}
+	opts.Notifier().Notify(ctx, note)
+}
+
+// sidecarTeardownTimeout bounds removing an agent's sidecars after the run.
+const sidecarTeardownTimeout = 30 * time.Second
+
+// startSidecars brings up the agent's sidecars under a spinner and returns
+// their --add-host entries plus the teardown to defer. The teardown takes
+// the agent's container ID (empty if it was never created) and removes the
+// sidecars unless --keep-sidecars is set or the agent is still running —
+// started with --detach, or detached with the detach keys — in which case
+// they stay up with it until 'clawker container rm' removes the agent. A
+// failed startup is torn down at once, again unless --keep-sidecars.
+func startSidecars(
+	ctx context.Context,
+	client *docker.Client,
+	opts *RunOptions,
+	projectCfg *config.Project,
+	project, agent string,
+) ([]string, func(agentID string), error) {
+	ios := opts.IOStreams
+	network, err := shared.ProjectNetwork(projectCfg, project)
+	if err != nil {
+		return nil, nil, err
+	}
+	release := func(agentID string) {
+		if opts.KeepSidecars || agentRunning(client, agentID) {
+			return
+		}
+		// Background context: Ctrl+C must not abort the teardown.
+		rmCtx, cancel := context.WithTimeout(context.Background(), sidecarTeardownTimeout)
+		defer cancel()
+		if err := client.RemoveSidecars(rmCtx, project, agent); err != nil {
+			fmt.Fprintf(ios.ErrOut, "%s removing sidecars: %v\n", ios.ColorScheme().WarningIcon(), err)
+		}
+	}
+
+	var started []shared.Sidecar
+	if err := ios.RunWithSpinner("Starting sidecars", func() error {
+		var err error
+		started, err = shared.StartSidecars(ctx, shared.StartSidecarsOptions{
+			Client:   client,
+			Project:  project,
+			Agent:    agent,
+			Network:  network,
+			Sidecars: projectCfg.Sidecars,
+		})
+		return err
+	}); err != nil {
+		release("")
+		return nil, nil, fmt.Errorf("starting sidecars: %w", err)
+	}
+	return shared.SidecarHosts(started), release, nil
+}
+
+// agentRunning reports whether the agent container is still running. A
+// container that is gone (--rm) or can't be inspected counts as stopped.
+func agentRunning(client *docker.Client, containerID string) bool {
+	if containerID == "" {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sidecarTeardownTimeout)
+	defer cancel()
+	info, err := client.ContainerInspect(ctx, containerID, docker.ContainerInspectOptions{})
+	if err != nil {
+		return false
+	}
+	return info.Container.State != nil && info.Container.State.Running
+}
+
+// reuseStopTimeout is how long a running container gets to stop before
+// --reuse restarts its session.
+const reuseStopTimeout = 10
+
+// reuseContainer starts an agent's existing container in place of creating a
+// new one (--reuse). A running container is stopped first so its entrypoint,
+// and with it the session, starts afresh. The container's own TTY, stdin and
+// auto-remove settings decide how it is attached.
+func reuseContainer(ctx context.Context, client *docker.Client, existing *docker.Container, opts *RunOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+	containerOpts := opts.ContainerCreateOptions
+
+	log, err := opts.Logger()
+	if err != nil {
+		return fmt.Errorf("initializing logger: %w", err)
+	}
+
+	info, err := client.ContainerInspect(ctx, existing.ID, docker.ContainerInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("inspecting container %q: %w", existing.Name, err)
+	}
+	c := info.Container
+
+	if len(containerOpts.Command) > 0 {
+		fmt.Fprintf(ios.ErrOut, "%s %s already exists; ignoring COMMAND and running its own command\n", cs.WarningIcon(), existing.Name)
+	}
+
+	if c.State != nil && c.State.Running {
+		timeout := reuseStopTimeout
+		if err := ios.RunWithSpinner("Stopping "+existing.Name, func() error {
+			_, err := client.ContainerStop(ctx, c.ID, &timeout)
+			return err
+		}); err != nil {
+			return fmt.Errorf("stopping container %q: %w", existing.Name, err)
+		}
+	}
+	fmt.Fprintf(ios.ErrOut, "Reusing container %s\n", existing.Name)
+
+	if c.Config != nil {
+		containerOpts.TTY = c.Config.Tty
+		containerOpts.Stdin = c.Config.OpenStdin
+	}
+	if c.HostConfig != nil {
+		containerOpts.AutoRemove = c.HostConfig.AutoRemove
+	}
+
+	opts.AgentName = existing.Agent
+	opts.Project = existing.Project
+
+	// An existing container's registry row is already in place, so the
+	// agent identity stays off CommandOpts — as for start and restart.
+	cmdOpts := shared.CommandOpts{
+		Client:       opts.Client,
+		Config:       opts.Config,
+		HostProxy:    opts.HostProxy,
+		ControlPlane: opts.ControlPlane,
+		AdminClient:  opts.AdminClient,
+		SocketBridge: opts.SocketBridge,
+		Logger:       opts.Logger,
+	}
+	return startContainer(ctx, client, c.ID, cmdOpts, opts, log)
 }
 
 // attachThenStart attaches to a container BEFORE starting it, then waits for it to exit.
@@ -341,16 +736,18 @@ func attachThenStart(
 
 	// Create attach options
 	attachOpts := docker.ContainerAttachOptions{
-		Stream: true,
-		Stdin:  containerOpts.Stdin,
-		Stdout: true,
-		Stderr: true,
+		Stream:     true,
+		Stdin:      containerOpts.Stdin,
+		Stdout:     true,
+		Stderr:     true,
+		DetachKeys: opts.detachSpec,
 	}
 
 	// Set up TTY if enabled
 	var pty *docker.PTYHandler
 	if containerOpts.TTY && containerOpts.Stdin {
 		pty = docker.NewPTYHandler(log)
+		pty.SetDetachKeys(opts.detachKeys)
 		if err := pty.Setup(); err != nil {
 			return fmt.Errorf("failed to set up terminal: %w", err)
 		}
@@ -368,6 +765,9 @@ func attachThenStart(
 	}
 	defer hijacked.Close()
 	log.Debug().Msg("container attach succeeded")
+	if pty != nil {
+		defer shared.HostAttachHub(pty, containerID, log)()
+	}
 
 	// Set up wait channel for container exit following Docker CLI's waitExitOrRemoved pattern.
 	// This wraps the dual-channel ContainerWait into a single status channel.
@@ -440,6 +840,9 @@ func attachThenStart(
 
 			// Monitor for window resize events (SIGWINCH)
 			resizeHandler := signals.NewResizeHandler(resizeFunc, pty.GetSize)
+			resizeHandler.SetErrorHandler(func(err error) {
+				log.Debug().Err(err).Msg("failed to resize container TTY")
+			})
 			resizeHandler.Start()
 			defer resizeHandler.Stop()
 		}
@@ -451,15 +854,18 @@ func attachThenStart(
 	select {
 	case err := <-streamDone:
 		log.Debug().Err(err).Msg("stream completed")
+		if errors.Is(err, docker.ErrDetached) {
+			// The user typed the detach keys; the container keeps running.
+			return nil
+		}
 		if err != nil {
 			return err
 		}
 		// Stream done — check for container exit status.
 		// For normal container exits, the status is available almost immediately.
-		// For detach (Ctrl+P Ctrl+Q), the container is still running so no status
-		// arrives. We use a timeout to distinguish the two cases without blocking
-		// forever. This is necessary because we don't do client-side detach key
-		// detection (Docker CLI uses term.EscapeError for this).
+		// A detach the PTY handler didn't see (the daemon honours the same
+		// sequence) leaves the container running with no status to report,
+		// so a timeout distinguishes the two cases without blocking forever.
 		select {
 		case status := <-statusCh:
 			log.Debug().Int("exitCode", status).Msg("container exited")
@@ -3,6 +3,7 @@ package run
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,8 +23,10 @@ import (
 	"github.com/schmitthub/clawker/internal/auth"
 	"github.com/schmitthub/clawker/internal/cmd/container/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/docker/mocks"
 	"github.com/schmitthub/clawker/internal/hostproxy"
@@ -39,6 +42,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
 )
 
 func TestNewCmdRun(t *testing.T) {
@@ -52,6 +56,7 @@ func TestNewCmdRun(t *testing.T) {
 		wantAgent      string
 		wantName       string
 		wantDetach     bool
+		wantDetachKeys string
 		wantMode       string
 		wantImage      string
 		wantCommand    []string
@@ -65,6 +70,9 @@ func TestNewCmdRun(t *testing.T) {
 		wantNetwork    string
 		wantLabels     []string
 		wantAutoRemove bool
+		wantWaitPort   string
+		wantWaitHealth bool
+		wantReuse      bool
 	}{
 		{
 			name:    "no image specified",
@@ -109,6 +117,81 @@ func TestNewCmdRun(t *testing.T) {
 			wantDetach: true,
 			wantImage:  "alpine",
 		},
+		{
+			name:           "with detach-keys flag",
+			input:          "-it --detach-keys ctrl-a,d",
+			args:           []string{"alpine"},
+			wantTTY:        true,
+			wantStdin:      true,
+			wantDetachKeys: "ctrl-a,d",
+			wantImage:      "alpine",
+		},
+		{
+			name:         "with wait-for-port",
+			input:        "--detach --wait-for-port 3000",
+			args:         []string{"alpine"},
+			wantDetach:   true,
+			wantWaitPort: "3000/tcp",
+			wantImage:    "alpine",
+		},
+		{
+			name:       "wait-for-port requires detach",
+			input:      "--wait-for-port 3000",
+			args:       []string{"alpine"},
+			wantErr:    true,
+			wantErrMsg: "--wait-for-port requires --detach",
+		},
+		{
+			name:       "wait-for-port rejects invalid port",
+			input:      "--detach --wait-for-port http",
+			args:       []string{"alpine"},
+			wantErr:    true,
+			wantErrMsg: `invalid port "http"`,
+		},
+		{
+			name:       "wait-timeout requires wait-for-port",
+			input:      "--detach --wait-timeout 5s",
+			args:       []string{"alpine"},
+			wantErr:    true,
+			wantErrMsg: "--wait-timeout requires --wait-for-port or --wait-healthy",
+		},
+		{
+			name:           "with wait-healthy",
+			input:          "--detach --wait-healthy --wait-timeout 5s",
+			args:           []string{"alpine"},
+			wantDetach:     true,
+			wantWaitHealth: true,
+			wantImage:      "alpine",
+		},
+		{
+			name:       "wait-healthy requires detach",
+			input:      "--wait-healthy",
+			args:       []string{"alpine"},
+			wantErr:    true,
+			wantErrMsg: "--wait-healthy requires --detach",
+		},
+		{
+			name:      "with reuse",
+			input:     "--reuse --agent dev",
+			args:      []string{"@"},
+			wantAgent: "dev",
+			wantImage: "@",
+			wantReuse: true,
+		},
+		{
+			name:       "reuse requires agent",
+			input:      "--reuse",
+			args:       []string{"@"},
+			wantErr:    true,
+			wantErrMsg: "--reuse requires --agent",
+		},
+		{
+			name:       "reuse conflicts with rm",
+			input:      "--reuse --rm --agent dev",
+			args:       []string{"@"},
+			wantErr:    true,
+			wantErrMsg: "[reuse rm] were all set",
+		},
 		{
 			name:      "with environment variable",
 			input:     "-e FOO=bar",
@@ -342,7 +425,7 @@ func TestNewCmdRun(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f := &cmdutil.Factory{}
+			f := cmdutiltest.NewFactory(t).Factory
 
 			var gotOpts *RunOptions
 			cmd := NewCmdRun(f, func(_ context.Context, opts *RunOptions) error {
@@ -385,6 +468,7 @@ func TestNewCmdRun(t *testing.T) {
 			require.Equal(t, tt.wantAgent, gotOpts.ContainerCreateOptions.Agent)
 			require.Equal(t, tt.wantName, gotOpts.ContainerCreateOptions.Name)
 			require.Equal(t, tt.wantDetach, gotOpts.Detach)
+			require.Equal(t, tt.wantDetachKeys, gotOpts.DetachKeys)
 			require.Equal(t, tt.wantMode, gotOpts.ContainerCreateOptions.Mode)
 			require.Equal(t, tt.wantImage, gotOpts.ContainerCreateOptions.Image)
 			require.Equal(t, tt.wantCommand, gotOpts.ContainerCreateOptions.Command)
@@ -398,13 +482,20 @@ func TestNewCmdRun(t *testing.T) {
 			require.Equal(t, tt.wantNetwork, gotOpts.ContainerCreateOptions.NetMode.NetworkMode())
 			requireSliceEqual(t, tt.wantLabels, gotOpts.ContainerCreateOptions.Labels)
 			require.Equal(t, tt.wantAutoRemove, gotOpts.ContainerCreateOptions.AutoRemove)
+			require.Equal(t, tt.wantReuse, gotOpts.Reuse)
+			require.Equal(t, tt.wantWaitHealth, gotOpts.WaitHealthy)
+			if tt.wantWaitPort != "" {
+				require.Equal(t, tt.wantWaitPort, gotOpts.waitPort.String())
+			} else {
+				require.True(t, gotOpts.waitPort.IsZero())
+			}
 		})
 	}
 }
 
 // TestCmdRun_NoDetachShorthand verifies --detach does NOT have -d shorthand (conflicts with --debug)
 func TestCmdRun_NoDetachShorthand(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdRun(f, nil)
 
 	// Verify --detach does NOT have -d shorthand (conflicts with --debug)
@@ -498,7 +589,7 @@ func TestBuildConfigs(t *testing.T) {
 			name: "with volumes/binds",
 			opts: &shared.ContainerCreateOptions{
 				Image:   "alpine",
-				Volumes: []string{"/host/path:/container/path", "/another:/mount"},
+				Volumes: []string{os.TempDir() + ":/container/path", "/:/mount"},
 				Publish: shared.NewPortOpts(),
 			},
 		},
@@ -680,7 +771,7 @@ func TestImageArg(t *testing.T) {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				f := &cmdutil.Factory{}
+				f := cmdutiltest.NewFactory(t).Factory
 
 				var gotOpts *RunOptions
 				cmd := NewCmdRun(f, func(_ context.Context, opts *RunOptions) error {
@@ -711,7 +802,7 @@ func TestImageArg(t *testing.T) {
 
 	// Test for empty image (no image argument provided)
 	t.Run("empty image shows error", func(t *testing.T) {
-		f := &cmdutil.Factory{}
+		f := cmdutiltest.NewFactory(t).Factory
 		cmd := NewCmdRun(f, nil)
 
 		// Cobra hack-around for help flag
@@ -758,41 +849,29 @@ func testFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes
 	// Ensure CWD is inside $HOME so IsOutsideHome returns false (matters in containers).
 	cwd, _ := os.Getwd()
 	t.Setenv("HOME", filepath.Dir(cwd))
-	tio, in, out, errOut := iostreams.Test()
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		TUI:       tui.NewTUI(tio),
-		// Isolated data dir (testenv.New above) → empty registry →
-		// not-in-project cwd fallback.
-		ProjectRegistry: func() (*project.Registry, error) { return project.NewRegistry() },
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			mock := configmocks.NewFromString(`
+	tf := cmdutiltest.NewFactory(t,
+		cmdutiltest.WithFakeClient(fake),
+		cmdutiltest.WithConfig(configmocks.NewFromString(`
 version: "1"
 workspace: { default_mode: "bind" }
 security: { enable_host_proxy: false }
 agent:
   claude_code:
     mount_projects: false
-`, `firewall: { enable: false }`)
-			return mock, nil
-		},
-		HostProxy: func() hostproxy.Service {
-			return hostproxytest.NewMockManager()
-		},
-		ControlPlane: func() manager.Manager {
-			return &cpbootmocks.ManagerMock{
-				EnsureRunningFunc: func(context.Context) error { return nil },
-			}
-		},
-		AdminClient: func(_ context.Context) (adminv1.AdminServiceClient, error) {
-			return &adminv1mocks.AdminServiceClientMock{}, nil
-		},
-		Prompter: func() *prompter.Prompter { return prompter.NewPrompter(tio) },
-	}, in, out, errOut
+`, `firewall: { enable: false }`)),
+	)
+	// Isolated data dir (testenv.New above) → empty registry →
+	// not-in-project cwd fallback.
+	tf.ProjectRegistry = func() (*project.Registry, error) { return project.NewRegistry() }
+	tf.HostProxy = func() hostproxy.Service {
+		return hostproxytest.NewMockManager()
+	}
+	tf.ControlPlane = cmdutiltest.RunningControlPlane()
+	tf.AdminClient = func(_ context.Context) (adminv1.AdminServiceClient, error) {
+		return &adminv1mocks.AdminServiceClientMock{}, nil
+	}
+	tf.Prompter = func() *prompter.Prompter { return prompter.NewPrompter(tf.IOStreams) }
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 func TestRunRun(t *testing.T) {
@@ -822,6 +901,27 @@ func TestRunRun(t *testing.T) {
 		fake.AssertCalled(t, "ContainerStart")
 	})
 
+	t.Run("wait-healthy fails on an unhealthy container", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fake.SetupContainerCreate()
+		fake.SetupCopyToContainer()
+		fake.SetupContainerStart()
+		fake.SetupContainerHealth(container.Unhealthy, "no ready marker")
+
+		f, in, out, errOut := testFactory(t, fake)
+		cmd := NewCmdRun(f, nil)
+
+		cmd.SetArgs([]string{"--detach", "--wait-healthy", "alpine"})
+		cmd.SetIn(in)
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+
+		err := cmd.Execute()
+		require.ErrorIs(t, err, whail.ErrContainerUnhealthy)
+		require.ErrorContains(t, err, "no ready marker")
+		require.NotContains(t, out.String(), mocks.FakeContainerID[:12], "no container ID is printed when the wait fails")
+	})
+
 	t.Run("container create failure returns error", func(t *testing.T) {
 		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
 		fake.FakeAPI.ContainerCreateFn = func(_ context.Context, _ moby.ContainerCreateOptions) (moby.ContainerCreateResult, error) {
@@ -962,6 +1062,204 @@ agent:
 	})
 }
 
+func TestRunRun_Reuse(t *testing.T) {
+	// seed adds clawker.myapp.dev to a stateful fake daemon, running or
+	// exited, and returns its ID.
+	seed := func(t *testing.T, fake *mocks.FakeClient, running bool) string {
+		t.Helper()
+		state := fake.EnableState()
+		state.AddImage("node:20-slim", nil)
+		id, err := state.AddContainer(whailtest.ContainerSpec{
+			Name:  "clawker.myapp.dev",
+			Image: "node:20-slim",
+			Labels: map[string]string{
+				fake.Cfg.LabelProject(): "myapp",
+				fake.Cfg.LabelAgent():   "dev",
+			},
+			Running: true,
+		})
+		require.NoError(t, err)
+		if !running {
+			require.NoError(t, state.Exit(id, 0))
+		}
+		fake.SetupCopyToContainer()
+		return id
+	}
+
+	runReuse := func(t *testing.T, fake *mocks.FakeClient, args ...string) (string, string, error) {
+		t.Helper()
+		f, in, out, errOut := testFactory(t, fake)
+		mgr := projectmocks.NewMockProjectManager()
+		mgr.CurrentProjectFunc = func(ctx context.Context) (project.Project, error) {
+			return projectmocks.NewMockProject("myapp", "/repo"), nil
+		}
+		f.ProjectManager = func() (project.ProjectManager, error) { return mgr, nil }
+		cmd := NewCmdRun(f, nil)
+		cmd.SetArgs(append([]string{"--detach", "--reuse", "--agent", "dev"}, args...))
+		cmd.SetIn(in)
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+		err := cmd.Execute()
+		return out.String(), errOut.String(), err
+	}
+
+	t.Run("stopped container is started", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		id := seed(t, fake, false)
+
+		out, errOut, err := runReuse(t, fake, "@", "echo", "hi")
+		require.NoError(t, err)
+		require.Equal(t, id[:12]+"\n", out)
+		require.Contains(t, errOut, "Reusing container clawker.myapp.dev")
+		require.Contains(t, errOut, "ignoring COMMAND")
+		fake.AssertNotCalled(t, "ContainerCreate")
+		fake.AssertNotCalled(t, "ContainerStop")
+		fake.AssertCalled(t, "ContainerStart")
+	})
+
+	t.Run("running container is restarted", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		id := seed(t, fake, true)
+
+		out, _, err := runReuse(t, fake, "@")
+		require.NoError(t, err)
+		require.Equal(t, id[:12]+"\n", out)
+		fake.AssertNotCalled(t, "ContainerCreate")
+		fake.AssertCalled(t, "ContainerStop")
+		fake.AssertCalled(t, "ContainerStart")
+	})
+
+	t.Run("missing container is created", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fake.SetupContainerList()
+		fake.SetupContainerCreate()
+		fake.SetupCopyToContainer()
+		fake.SetupContainerStart()
+
+		out, errOut, err := runReuse(t, fake, "alpine")
+		require.NoError(t, err)
+		require.Contains(t, out, "abcdef123456")
+		require.NotContains(t, errOut, "Reusing")
+		fake.AssertCalled(t, "ContainerCreate")
+	})
+}
+
+func TestRunRun_Plan(t *testing.T) {
+	planCfg := configmocks.NewFromString(`
+version: "1"
+workspace: { default_mode: "bind" }
+security: { enable_host_proxy: false }
+agent:
+  claude_code:
+    mount_projects: false
+secrets:
+  api:
+    provider: env
+    ref: HOST_API_KEY
+    env: API_KEY
+`, `firewall: { enable: false }`)
+
+	runPlan := func(t *testing.T, fake *mocks.FakeClient, args ...string) (string, error) {
+		t.Helper()
+		f, in, out, errOut := testFactory(t, fake)
+		f.Config = func() (config.Config, error) { return planCfg, nil }
+		cmd := NewCmdRun(f, nil)
+		cmd.SetArgs(args)
+		cmd.SetIn(in)
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	t.Run("prints YAML without creating anything", func(t *testing.T) {
+		t.Setenv("HOST_API_KEY", "sk-live-secret")
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+
+		out, err := runPlan(t, fake, "--plan", "--agent", "dev", "-e", "DEBUG=1", "alpine", "echo", "hi")
+		require.NoError(t, err)
+
+		require.Contains(t, out, "name: clawker.dev\n")
+		require.Contains(t, out, "- DEBUG=1\n")
+		require.Contains(t, out, "- API_KEY=<redacted>\n")
+		require.NotContains(t, out, "sk-live-secret")
+		require.Contains(t, out, "host_config:\n")
+		fake.AssertNotCalled(t, "ContainerCreate")
+		fake.AssertNotCalled(t, "VolumeCreate")
+		fake.AssertNotCalled(t, "NetworkCreate")
+	})
+
+	t.Run("json envelope", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+
+		out, err := runPlan(t, fake, "--plan", "--json", "--agent", "dev", "alpine")
+		require.NoError(t, err)
+
+		var env struct {
+			Kind string               `json:"kind"`
+			Data shared.ContainerPlan `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &env))
+		require.Equal(t, "container.plan", env.Kind)
+		require.Equal(t, "clawker.dev", env.Data.Name)
+		require.Equal(t, "alpine", env.Data.Config.Image)
+		require.NotEmpty(t, env.Data.HostConfig.Mounts)
+		fake.AssertNotCalled(t, "ContainerCreate")
+	})
+
+	t.Run("keep-alive labels the container", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+
+		out, err := runPlan(t, fake, "--plan", "--json", "--keep-alive", "--agent", "dev", "alpine")
+		require.NoError(t, err)
+
+		var env struct {
+			Data shared.ContainerPlan `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &env))
+		require.Equal(t, "true", env.Data.Config.Labels[consts.LabelKeepAlive])
+	})
+
+	t.Run("json requires plan", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+
+		_, err := runPlan(t, fake, "--json", "alpine")
+		require.ErrorContains(t, err, "--json requires --plan or --print-env")
+	})
+
+	t.Run("print-env shows sources", func(t *testing.T) {
+		t.Setenv("HOST_API_KEY", "sk-live-secret")
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+
+		out, err := runPlan(t, fake, "--print-env", "--agent", "dev",
+			"-e", "EDITOR=emacs", "--env-unset", "TERM", "alpine")
+		require.NoError(t, err)
+
+		require.Regexp(t, `(?m)^KEY\s+VALUE\s+SOURCE$`, out)
+		require.Regexp(t, `(?m)^EDITOR\s+emacs\s+cli \(over settings\)$`, out)
+		require.Regexp(t, `(?m)^API_KEY\s+<redacted>\s+secret$`, out)
+		require.Regexp(t, `(?m)^CLAWKER_AGENT\s+dev\s+settings$`, out)
+		require.Regexp(t, `(?m)^TERM\s+\(unset\)\s+cli`, out)
+		require.NotContains(t, out, "sk-live-secret")
+		fake.AssertNotCalled(t, "ContainerCreate")
+	})
+
+	t.Run("print-env json envelope", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+
+		out, err := runPlan(t, fake, "--print-env", "--json", "--agent", "dev", "-e", "DEBUG=1", "alpine")
+		require.NoError(t, err)
+
+		var env struct {
+			Kind string          `json:"kind"`
+			Data []shared.EnvVar `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &env))
+		require.Equal(t, "container.env", env.Kind)
+		require.Contains(t, env.Data, shared.EnvVar{Key: "DEBUG", Value: "1", Source: shared.EnvSourceCLI})
+	})
+}
+
 func TestNewCmdRun_WiresWorktreeFlagCompletion(t *testing.T) {
 	proj := projectmocks.NewMockProject("demo", "/repo")
 	proj.ListWorktreesFunc = func(ctx context.Context) ([]project.WorktreeState, error) {
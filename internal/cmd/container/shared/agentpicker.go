@@ -0,0 +1,131 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// AgentPicker chooses among candidate agents; TUI.RunPicker in production,
+// replaced in tests.
+type AgentPicker func(cfg tui.PickerConfig, items []tui.PickerItem) tui.PickerResult
+
+// PickAgentOptions configures PickAgentContainer.
+type PickAgentOptions struct {
+	IOStreams *iostreams.IOStreams
+	Client    *docker.Client
+	Pick      AgentPicker
+	// ProjectManager resolves the current project, which scopes the
+	// candidates; with none (nil, or outside a registered project) agents of
+	// every project are listed.
+	ProjectManager func() (project.ProjectManager, error)
+	// States selects the candidates by container state (e.g. "running").
+	States []string
+	// Verb completes the picker title and errors: "start", "stop", "attach to".
+	Verb string
+	// Command is the command path shown in the "name an agent" hint, e.g.
+	// "clawker container stop".
+	Command string
+	// Confirm is set for commands that disrupt the agent (stop): a lone
+	// candidate is not used silently but offered in the picker, so acting
+	// on it takes a choice, and it is an error when prompts are off.
+	Confirm bool
+}
+
+// AgentArgs is the Args check of a command that picks an agent when it is
+// given none. Zero arguments are accepted unless --no-input is set or, with
+// needTerminal, prompts are unavailable; then, and whenever arguments are
+// given, named — the check from before the picker existed — applies.
+// --no-input is read from the command's flags: cobra validates arguments
+// before the root PersistentPreRunE applies it to IOStreams.
+func AgentArgs(ios *iostreams.IOStreams, named cobra.PositionalArgs, needTerminal bool) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			return named(cmd, args)
+		}
+		if noInput, _ := cmd.Flags().GetBool("no-input"); noInput {
+			return named(cmd, args)
+		}
+		if needTerminal && (ios == nil || !ios.CanPrompt()) {
+			return named(cmd, args)
+		}
+		return nil
+	}
+}
+
+// PickAgentContainer resolves the agent container a command acts on when it
+// was given none. A single candidate is used directly unless opts.Confirm
+// is set; otherwise the candidates open the picker, or fail with the
+// candidates listed when prompts are off (no TTY or --no-input). Returns the
+// container name, or "" with a nil error when the user cancels the picker.
+func PickAgentContainer(ctx context.Context, opts PickAgentOptions) (string, error) {
+	var projectName string
+	if opts.ProjectManager != nil {
+		if pm, err := opts.ProjectManager(); err == nil {
+			if p, err := pm.CurrentProject(ctx); err == nil {
+				projectName = p.Name()
+			}
+		}
+	}
+	q := docker.Query().Purpose(consts.PurposeAgent).Status(opts.States...)
+	if projectName != "" {
+		q = q.Project(projectName)
+	}
+	candidates, err := opts.Client.ListContainersQuery(ctx, q, true)
+	if err != nil {
+		return "", fmt.Errorf("listing agents: %w", err)
+	}
+
+	scope := "any project"
+	if projectName != "" {
+		scope = fmt.Sprintf("project %q", projectName)
+	}
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("no agent to %s in %s", opts.Verb, scope)
+	case 1:
+		if !opts.Confirm {
+			fmt.Fprintf(opts.IOStreams.ErrOut, "%s Using %s\n", opts.IOStreams.ColorScheme().InfoIcon(), candidates[0].Name)
+			return candidates[0].Name, nil
+		}
+	}
+
+	items := make([]tui.PickerItem, len(candidates))
+	labels := make([]string, len(candidates))
+	for i, c := range candidates {
+		labels[i] = c.Name
+		if projectName != "" && c.Agent != "" {
+			labels[i] = c.Agent
+		}
+		items[i] = tui.PickerItem{Label: labels[i], Columns: []string{c.Status, c.StatusText, c.Image}}
+	}
+
+	if !opts.IOStreams.CanPrompt() {
+		hint := opts.Command + " " + candidates[0].Name
+		if projectName != "" && candidates[0].Agent != "" {
+			hint = opts.Command + " --agent " + candidates[0].Agent
+		}
+		if len(candidates) == 1 {
+			return "", fmt.Errorf("no agent named; to %s the only candidate in %s, run %s", opts.Verb, scope, hint)
+		}
+		return "", fmt.Errorf("several agents to %s in %s (%s); name one, e.g. %s",
+			opts.Verb, scope, strings.Join(labels, ", "), hint)
+	}
+
+	res := opts.Pick(tui.PickerConfig{Title: "Select an agent to " + opts.Verb}, items)
+	if res.Err != nil {
+		return "", fmt.Errorf("agent picker: %w", res.Err)
+	}
+	if res.Canceled || res.Index < 0 || res.Index >= len(candidates) {
+		return "", nil
+	}
+	return candidates[res.Index].Name, nil
+}
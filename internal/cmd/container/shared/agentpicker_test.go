@@ -0,0 +1,190 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// pickAgentOpts builds PickAgentOptions over a fake listing containers, in
+// project "myapp", with a terminal attached.
+func pickAgentOpts(t *testing.T, pick AgentPicker, containers ...container.Summary) (PickAgentOptions, *bytes.Buffer) {
+	t.Helper()
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList(containers...)
+
+	ios, _, _, errOut := iostreams.Test()
+	ios.SetStdinTTY(true)
+	ios.SetStdoutTTY(true)
+
+	pm := projectmocks.NewMockProjectManager()
+	pm.CurrentProjectFunc = func(context.Context) (project.Project, error) {
+		return projectmocks.NewMockProject("myapp", t.TempDir()), nil
+	}
+	return PickAgentOptions{
+		IOStreams:      ios,
+		Client:         fake.Client,
+		Pick:           pick,
+		ProjectManager: func() (project.ProjectManager, error) { return pm, nil },
+		States:         []string{"running"},
+		Verb:           "stop",
+		Command:        "clawker container stop",
+	}, errOut
+}
+
+func noPick(t *testing.T) AgentPicker {
+	return func(tui.PickerConfig, []tui.PickerItem) tui.PickerResult {
+		t.Fatal("picker opened")
+		return tui.PickerResult{}
+	}
+}
+
+func TestPickAgentContainer_NoCandidates(t *testing.T) {
+	opts, _ := pickAgentOpts(t, noPick(t))
+
+	_, err := PickAgentContainer(context.Background(), opts)
+	require.EqualError(t, err, `no agent to stop in project "myapp"`)
+}
+
+func TestPickAgentContainer_SingleCandidate(t *testing.T) {
+	opts, errOut := pickAgentOpts(t, noPick(t), mocks.RunningContainerFixture("myapp", "dev"))
+
+	name, err := PickAgentContainer(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "clawker.myapp.dev", name)
+	assert.Contains(t, errOut.String(), "Using clawker.myapp.dev")
+}
+
+func TestPickAgentContainer_ConfirmSingleCandidate(t *testing.T) {
+	var gotItems []tui.PickerItem
+	pick := func(_ tui.PickerConfig, items []tui.PickerItem) tui.PickerResult {
+		gotItems = items
+		return tui.PickerResult{Index: 0}
+	}
+	opts, errOut := pickAgentOpts(t, pick, mocks.RunningContainerFixture("myapp", "dev"))
+	opts.Confirm = true
+
+	name, err := PickAgentContainer(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "clawker.myapp.dev", name)
+	require.Len(t, gotItems, 1, "a lone candidate is offered, not used")
+	assert.NotContains(t, errOut.String(), "Using")
+}
+
+func TestPickAgentContainer_ConfirmSingleCandidateNoInput(t *testing.T) {
+	opts, _ := pickAgentOpts(t, noPick(t), mocks.RunningContainerFixture("myapp", "dev"))
+	opts.Confirm = true
+	opts.IOStreams.SetNeverPrompt(true)
+
+	_, err := PickAgentContainer(context.Background(), opts)
+	require.EqualError(t, err, `no agent named; to stop the only candidate in project "myapp", run clawker container stop --agent dev`)
+}
+
+func TestPickAgentContainer_Picks(t *testing.T) {
+	var gotCfg tui.PickerConfig
+	var gotItems []tui.PickerItem
+	pick := func(cfg tui.PickerConfig, items []tui.PickerItem) tui.PickerResult {
+		gotCfg, gotItems = cfg, items
+		return tui.PickerResult{Index: 1}
+	}
+	opts, _ := pickAgentOpts(t, pick,
+		mocks.RunningContainerFixture("myapp", "dev"),
+		mocks.RunningContainerFixture("myapp", "web"))
+
+	name, err := PickAgentContainer(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "clawker.myapp.web", name)
+	assert.Equal(t, "Select an agent to stop", gotCfg.Title)
+	require.Len(t, gotItems, 2)
+	assert.Equal(t, "dev", gotItems[0].Label, "agents of the current project are labeled by agent name")
+	assert.Equal(t, []string{"running", "", "node:20-slim"}, gotItems[0].Columns)
+}
+
+func TestPickAgentContainer_Canceled(t *testing.T) {
+	pick := func(tui.PickerConfig, []tui.PickerItem) tui.PickerResult {
+		return tui.PickerResult{Index: -1, Canceled: true}
+	}
+	opts, _ := pickAgentOpts(t, pick,
+		mocks.RunningContainerFixture("myapp", "dev"),
+		mocks.RunningContainerFixture("myapp", "web"))
+
+	name, err := PickAgentContainer(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Empty(t, name)
+}
+
+func TestPickAgentContainer_NoInput(t *testing.T) {
+	opts, _ := pickAgentOpts(t, noPick(t),
+		mocks.RunningContainerFixture("myapp", "dev"),
+		mocks.RunningContainerFixture("myapp", "web"))
+	opts.IOStreams.SetNeverPrompt(true)
+
+	_, err := PickAgentContainer(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `several agents to stop in project "myapp" (dev, web)`)
+	assert.Contains(t, err.Error(), "clawker container stop --agent dev")
+}
+
+func TestPickAgentContainer_NoProject(t *testing.T) {
+	opts, _ := pickAgentOpts(t, noPick(t),
+		mocks.RunningContainerFixture("myapp", "dev"),
+		mocks.RunningContainerFixture("other", "dev"))
+	opts.ProjectManager = nil
+	opts.IOStreams.SetStdinTTY(false)
+
+	_, err := PickAgentContainer(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "in any project (clawker.myapp.dev, clawker.other.dev)")
+	assert.Contains(t, err.Error(), "clawker container stop clawker.myapp.dev")
+}
+
+func TestAgentArgs(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		noInput      bool
+		terminal     bool
+		needTerminal bool
+		wantErr      bool
+	}{
+		{name: "named", args: []string{"dev"}},
+		{name: "none with a terminal", terminal: true, needTerminal: true},
+		{name: "none without a terminal", needTerminal: false},
+		{name: "none without a terminal when one is needed", needTerminal: true, wantErr: true},
+		{name: "none with no-input", terminal: true, noInput: true, wantErr: true},
+		{name: "named checked with no-input", args: []string{"dev", "writer"}, noInput: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ios, _, _, _ := iostreams.Test()
+			ios.SetStdinTTY(tt.terminal)
+			ios.SetStdoutTTY(tt.terminal)
+
+			cmd := &cobra.Command{Use: "attach"}
+			cmd.Flags().Bool("no-input", false, "")
+			if tt.noInput {
+				require.NoError(t, cmd.Flags().Set("no-input", "true"))
+			}
+
+			err := AgentArgs(ios, cmdutil.ExactArgs(1), tt.needTerminal)(cmd, tt.args)
+			if tt.wantErr {
+				require.ErrorContains(t, err, "requires 1 argument")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
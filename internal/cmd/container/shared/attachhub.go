@@ -0,0 +1,33 @@
+package shared
+
+import (
+	"github.com/schmitthub/clawker/internal/attachhub"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// HostAttachHub lets `clawker container attach --observe` watch this
+// interactive session: it serves containerID's attach hub socket and tees
+// pty's output into it. Best-effort — when another session already hosts the
+// container, or the socket cannot be created, the session runs without one.
+// The returned func closes the hub and must be deferred by the caller.
+func HostAttachHub(pty *docker.PTYHandler, containerID string, log *logger.Logger) func() {
+	path, err := consts.AttachSocketPath(containerID)
+	if err != nil {
+		log.Debug().Err(err).Msg("attach hub unavailable")
+		return func() {}
+	}
+	hub, err := attachhub.Listen(path)
+	if err != nil {
+		log.Debug().Err(err).Msg("attach hub unavailable")
+		return func() {}
+	}
+	pty.SetOutputTee(hub)
+	log.Debug().Str("socket", path).Msg("attach hub listening")
+	return func() {
+		if err := hub.Close(); err != nil {
+			log.Debug().Err(err).Msg("closing attach hub")
+		}
+	}
+}
@@ -11,12 +11,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"net"
 	"net/netip"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -36,6 +39,7 @@ import (
 	"github.com/schmitthub/clawker/internal/hostproxy"
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/session"
 	"github.com/schmitthub/clawker/internal/workspace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -60,6 +64,7 @@ type ContainerCreateOptions struct {
 
 	// Container configuration
 	Env             []string // Environment variables
+	EnvUnset        []string // Env vars to leave unset, whatever layer sets them
 	EnvFile         []string // Read env vars from file(s)
 	Volumes         []string // Bind mounts
 	Publish         *PortOpts
@@ -121,12 +126,13 @@ type ContainerCreateOptions struct {
 	LinkLocalIPs []string // Link-local addresses
 
 	// Storage
-	Tmpfs        []string         // Tmpfs mounts (path or path:options)
-	ReadOnly     bool             // Mount root filesystem as read-only
-	VolumesFrom  []string         // Mount volumes from another container
-	VolumeDriver string           // Volume driver
-	StorageOpt   []string         // Storage driver options
-	Mounts       *docker.MountOpt // Advanced mount specifications
+	Tmpfs          []string         // Tmpfs mounts (path or path:options)
+	ReadOnly       bool             // Mount root filesystem as read-only
+	VolumesFrom    []string         // Mount volumes from another container
+	VolumeDriver   string           // Volume driver
+	StorageOpt     []string         // Storage driver options
+	Mounts         *docker.MountOpt // Advanced mount specifications
+	CreateHostDirs bool             // Create missing bind-mount host directories
 
 	// Devices
 	Devices           *docker.DeviceOpt // Host devices to add
@@ -140,6 +146,10 @@ type ContainerCreateOptions struct {
 	SecurityOpt     []string // Security options (e.g., seccomp, apparmor, label)
 	DisableFirewall bool     // DEPRECATED: no-op, use "clawker firewall bypass" instead
 
+	// OverrideSecurity accepts flags that weaken the project's declared
+	// security posture without prompting (see ConfirmSecurityOverrides).
+	OverrideSecurity bool
+
 	// Health check
 	HealthCmd           string        // Command to run to check health
 	HealthInterval      time.Duration // Time between health checks
@@ -216,6 +226,7 @@ func AddFlags(flags *pflag.FlagSet, opts *ContainerCreateOptions) {
 	flags.VarP(opts.Attach, "attach", "a", "Attach to STDIN, STDOUT or STDERR")
 	flags.StringArrayVarP(&opts.Env, "env", "e", nil, "Set environment variables")
 	flags.StringArrayVar(&opts.EnvFile, "env-file", nil, "Read in a file of environment variables")
+	flags.StringArrayVar(&opts.EnvUnset, "env-unset", nil, "Unset an environment variable set by clawker, the project config or the image")
 	flags.StringArrayVarP(&opts.Volumes, "volume", "v", nil, "Bind mount a volume")
 	flags.VarP(opts.Publish, "publish", "p", "Publish container port(s) to host")
 	flags.StringVar(&opts.Workdir, "workdir", "", "Override container working directory")
@@ -282,11 +293,12 @@ func AddFlags(flags *pflag.FlagSet, opts *ContainerCreateOptions) {
 
 	// Storage flags
 	flags.StringArrayVar(&opts.Tmpfs, "tmpfs", nil, "Mount a tmpfs directory (e.g., /tmp:rw,size=64m)")
-	flags.BoolVar(&opts.ReadOnly, "read-only", false, "Mount the container's root filesystem as read only")
+	flags.BoolVar(&opts.ReadOnly, "read-only", false, "Mount the container's root filesystem as read only, with writable mounts for agent runtime paths (see security.read_only_root)")
 	flags.StringArrayVar(&opts.VolumesFrom, "volumes-from", nil, "Mount volumes from the specified container(s)")
 	flags.StringVar(&opts.VolumeDriver, "volume-driver", "", "Optional volume driver for the container")
 	flags.StringArrayVar(&opts.StorageOpt, "storage-opt", nil, "Storage driver options for the container")
 	flags.Var(opts.Mounts, "mount", "Attach a filesystem mount to the container")
+	flags.BoolVar(&opts.CreateHostDirs, "create-host-dirs", false, "Create missing host directories for bind mounts instead of failing")
 
 	// Device flags
 	flags.Var(opts.Devices, "device", "Add a host device to the container")
@@ -300,6 +312,7 @@ func AddFlags(flags *pflag.FlagSet, opts *ContainerCreateOptions) {
 	flags.StringArrayVar(&opts.SecurityOpt, "security-opt", nil, "Security options")
 	flags.BoolVar(&opts.DisableFirewall, "disable-firewall", false, "")
 	_ = flags.MarkDeprecated("disable-firewall", "use 'clawker firewall bypass' instead")
+	flags.BoolVar(&opts.OverrideSecurity, "override-security", false, "Accept flags that weaken the project's security settings without prompting")
 
 	// Health check flags
 	flags.StringVar(&opts.HealthCmd, "health-cmd", "", "Command to run to check health")
@@ -501,6 +514,11 @@ func (opts *ContainerCreateOptions) BuildConfigs(flags *pflag.FlagSet, mounts []
 		}
 	}
 
+	// Command-line mounts must fit around the workspace layout in mounts.
+	if err := validateMounts(mounts, opts.userMounts(), opts.CreateHostDirs); err != nil {
+		return nil, nil, nil, err
+	}
+
 	// On macOS Docker Desktop, socket files don't work correctly with HostConfig.Mounts
 	// (the SDK's mount.Mount API). They fail with "/socket_mnt" path errors.
 	// However, they work correctly with HostConfig.Binds (the CLI -v syntax).
@@ -679,6 +697,9 @@ func (opts *ContainerCreateOptions) BuildConfigs(flags *pflag.FlagSet, mounts []
 	if opts.OOMKillDisable {
 		hostCfg.OomKillDisable = &opts.OOMKillDisable
 	}
+	if err := applyResourceDefaults(&hostCfg.Resources, projectCfg.Resources); err != nil {
+		return nil, nil, nil, err
+	}
 
 	// Windows-only CPU/IO resources
 	if opts.CPUCount > 0 {
@@ -868,6 +889,12 @@ func (opts *ContainerCreateOptions) ValidateFlags() error {
 		return fmt.Errorf("--oom-score-adj must be between -1000 and 1000")
 	}
 
+	for _, key := range opts.EnvUnset {
+		if key == "" || strings.ContainsAny(key, "= ") {
+			return fmt.Errorf("--env-unset takes a variable name, got %q", key)
+		}
+	}
+
 	return nil
 }
 
@@ -1002,6 +1029,53 @@ func ParseLabelsToMap(labels []string) map[string]string {
 	return result
 }
 
+// openURLLabels stamps the project's security.open_url allowlist onto the
+// container, where the host proxy reads it back for the container's URL
+// opens. An unset list gets no label.
+func openURLLabels(cfg *config.Project) map[string]string {
+	labels := map[string]string{}
+	if cfg == nil {
+		return labels
+	}
+	if s := cfg.Security.OpenURL.Schemes; len(s) > 0 {
+		labels[consts.LabelOpenURLSchemes] = strings.Join(s, ",")
+	}
+	if h := cfg.Security.OpenURL.Hosts; len(h) > 0 {
+		labels[consts.LabelOpenURLHosts] = strings.Join(h, ",")
+	}
+	return labels
+}
+
+// gitCredentialHostLabels stamps the hosts of the workspace repository's git
+// remotes onto the container. While host_proxy.git_credential_hosts is empty
+// they are the only hosts the host proxy fetches git credentials for on the
+// container's behalf. A workspace outside a repository, or one without
+// remotes, gets no label, and the proxy then refuses every host.
+func gitCredentialHostLabels(ws *workspaceSetup, log *logger.Logger) map[string]string {
+	labels := map[string]string{}
+	dir := ws.projectRootDir
+	if dir == "" {
+		dir = ws.wd
+	}
+	if dir == "" {
+		return labels
+	}
+	mgr, err := git.NewGitManager(dir)
+	if err != nil {
+		log.Debug().Err(err).Str("dir", dir).Msg("no git repository for credential hosts")
+		return labels
+	}
+	hosts, err := mgr.RemoteHosts()
+	if err != nil {
+		log.Debug().Err(err).Str("dir", dir).Msg("reading git remote hosts")
+		return labels
+	}
+	if len(hosts) > 0 {
+		labels[consts.LabelGitCredentialHosts] = strings.Join(hosts, ",")
+	}
+	return labels
+}
+
 // MergeLabels merges user-provided labels with base labels.
 // Base labels take precedence (clawker labels should not be overwritten).
 func MergeLabels(baseLabels, userLabels map[string]string) map[string]string {
@@ -1341,8 +1415,13 @@ func parseRestartPolicy(policy string) (container.RestartPolicy, error) {
 	return p, nil
 }
 
-// readEnvFile reads an environment file and returns lines as KEY=VALUE pairs.
-// Lines starting with # are comments. Blank lines are skipped.
+// readEnvFile reads an --env-file and returns its entries as KEY=VALUE pairs
+// in file order, with docker run --env-file semantics: values are literal —
+// no $VAR interpolation, escapes, or inline comments — and a bare `KEY` line
+// inherits the host value (dropped when unset). On top of that, an `export `
+// prefix is dropped and one pair of matching quotes around a value is
+// stripped. Blank lines and lines starting with # are skipped. Syntax errors
+// name the offending line.
 func readEnvFile(filename string) ([]string, error) {
 	f, err := os.Open(filename)
 	if err != nil {
@@ -1352,12 +1431,35 @@ func readEnvFile(filename string) ([]string, error) {
 
 	var lines []string
 	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimLeft(scanner.Text(), " \t")
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
-		lines = append(lines, line)
+		line = strings.TrimPrefix(line, "export ")
+		key, value, hasValue := strings.Cut(line, "=")
+		if !hasValue {
+			key = strings.TrimRight(key, " \t")
+		}
+		if key == "" {
+			return nil, fmt.Errorf("line %d: missing variable name", n)
+		}
+		if strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: variable name %q contains whitespace", n, key)
+		}
+		if !hasValue {
+			if v, ok := os.LookupEnv(key); ok {
+				lines = append(lines, key+"="+v)
+			}
+			continue
+		}
+		if q := value[:min(1, len(value))]; q == `"` || q == "'" {
+			if len(value) < 2 || !strings.HasSuffix(value, q) {
+				return nil, fmt.Errorf("line %d: unterminated quoted value %s", n, value)
+			}
+			value = value[1 : len(value)-1]
+		}
+		lines = append(lines, key+"="+value)
 	}
 	return lines, scanner.Err()
 }
@@ -1445,6 +1547,9 @@ type CreateContainerOptions struct {
 	Log             *logger.Logger
 	Is256Color      bool
 	IsTrueColor     bool
+	// HookOut receives the output of the project's pre_create host hook
+	// (hooks.pre_create). Nil discards it.
+	HookOut io.Writer
 
 	// harnessBundle is the container's harness identity, resolved once at
 	// the top of CreateContainer from the image's harness label (registry
@@ -1452,6 +1557,10 @@ type CreateContainerOptions struct {
 	// CreateContainer, read by the create-path steps so staging, hooks,
 	// env, and the container label all agree.
 	harnessBundle *bundler.Bundle
+
+	// plan is set by PlanContainer: the create-path steps resolve what they
+	// would do without creating volumes or resolving secrets.
+	plan bool
 }
 
 // CreateContainerResult holds the outputs of CreateContainer.
@@ -1461,6 +1570,9 @@ type CreateContainerResult struct {
 	ContainerName    string
 	WorkDir          string
 	HostProxyRunning bool
+	// RestoredSession is the saved harness session restored into the
+	// container's fresh volumes, or nil.
+	RestoredSession *session.Meta
 }
 
 // CreateContainer is the single entry point for container creation, shared by
@@ -1525,16 +1637,26 @@ func CreateContainer(ctx context.Context, opts *CreateContainerOptions) (*Create
 		failed = true
 		return nil, err
 	}
+	restored, err := restoreSession(ctx, opts, agentName, containerName, ws)
+	if err != nil {
+		failed = true
+		return nil, err
+	}
 
 	// --- Step 3: Setup environment + build Docker configs ---
-	hostProxyRunning := setupHostProxy(opts.Config.Project(), containerOpts, opts.HostProxy, log)
+	hostProxyURL := setupHostProxy(opts.Config.Project(), opts.Client, opts.HostProxy, log)
 
-	cfgs, err := buildContainerConfigs(ctx, opts, agentName, ws, hostProxyRunning)
+	cfgs, err := buildContainerConfigs(ctx, opts, agentName, ws, hostProxyURL)
 	if err != nil {
 		failed = true
 		return nil, err
 	}
 
+	if err = provisionReadOnlyRoot(ctx, opts, agentName, cfgs, scope); err != nil {
+		failed = true
+		return nil, err
+	}
+
 	// --- Step 4: Create the container and install per-agent bootstrap ---
 	containerID, err := createAndBootstrapContainer(ctx, opts, agentName, containerName, ws, cfgs, scope)
 	if err != nil {
@@ -1547,7 +1669,8 @@ func CreateContainer(ctx context.Context, opts *CreateContainerOptions) (*Create
 		AgentName:        agentName,
 		ContainerName:    containerName,
 		WorkDir:          ws.wd,
-		HostProxyRunning: hostProxyRunning,
+		HostProxyRunning: hostProxyURL != "",
+		RestoredSession:  restored,
 	}, nil
 }
 
@@ -1678,34 +1801,39 @@ func resolveWorkDir(ctx context.Context, containerOpts *ContainerCreateOptions,
 	return wd, "", nil
 }
 
-// setupHostProxy starts the host proxy if enabled. Non-fatal — failures produce warnings.
-func setupHostProxy(cfg *config.Project, containerOpts *ContainerCreateOptions, hostProxyFn func() hostproxy.Service, log *logger.Logger) bool {
+// setupHostProxy starts the host proxy if enabled and returns its URL for
+// the container env; empty when it is not running. Non-fatal — failures
+// produce warnings. A container on a remote daemon cannot reach the proxy
+// on this host, so it gets no URL there.
+func setupHostProxy(cfg *config.Project, client *docker.Client, hostProxyFn func() hostproxy.Service, log *logger.Logger) string {
 	if !cfg.Security.HostProxyEnabled() {
 		log.Debug().Msg("host proxy disabled by config")
-		return false
+		return ""
+	}
+	if client != nil && client.Endpoint().Remote() {
+		log.Warn().Str("endpoint", client.Endpoint().String()).Msg("host proxy unreachable from a remote docker daemon; not wiring it into the container")
+		return ""
 	}
 
 	if hostProxyFn == nil {
-		return false
+		return ""
 	}
 
 	hp := hostProxyFn()
 	if hp == nil {
-		return false
+		return ""
 	}
 
-	envVar := consts.EnvHostProxy + "=" + hp.ProxyURL()
-	containerOpts.Env = append(containerOpts.Env, envVar)
-	log.Debug().Str("env", envVar).Msg("appended host proxy env var")
-
-	return true
+	url := hp.ProxyURL()
+	log.Debug().Str("url", url).Msg("host proxy running")
+	return url
 }
 
-// guardWorktreeSnapshot fails fast on the worktree + snapshot combination
-// before resolveWorkDir creates a git worktree we'd only reject later.
-// workspace.SetupMounts enforces the same invariant as the load-bearing guard;
-// this is the fail-fast UX layer.
-func guardWorktreeSnapshot(containerOpts *ContainerCreateOptions, projectCfg *config.Project) error {
+// guardWorktreeMode fails fast on a worktree with snapshot mode, or with
+// bind mode against a remote daemon, before resolveWorkDir creates a git
+// worktree we'd only reject later. workspace.SetupMounts enforces the same
+// invariants as the load-bearing guard; this is the fail-fast UX layer.
+func guardWorktreeMode(client *docker.Client, containerOpts *ContainerCreateOptions, projectCfg *config.Project) error {
 	if containerOpts.Worktree == "" {
 		return nil
 	}
@@ -1716,7 +1844,7 @@ func guardWorktreeSnapshot(containerOpts *ContainerCreateOptions, projectCfg *co
 	if mode == config.ModeSnapshot {
 		return workspace.ErrWorktreeSnapshot
 	}
-	return nil
+	return workspace.CheckRemoteBind(client, mode)
 }
 
 // trackCreatedVolumes registers the volumes newly created by SetupMounts on the
@@ -1846,8 +1974,8 @@ type workspaceSetup struct {
 	projectRootDir string
 }
 
-// prepareWorkspace resolves the project root, fails fast on the
-// worktree + snapshot combination, resolves the host work directory, and sets
+// prepareWorkspace resolves the project root, fails fast on a worktree the
+// workspace mode cannot serve, resolves the host work directory, and sets
 // up the workspace mounts (volumes, bind, config volume).
 func prepareWorkspace(ctx context.Context, opts *CreateContainerOptions, agentName string) (*workspaceSetup, error) {
 	containerOpts := opts.Options
@@ -1859,7 +1987,7 @@ func prepareWorkspace(ctx context.Context, opts *CreateContainerOptions, agentNa
 		return nil, err
 	}
 
-	if err = guardWorktreeSnapshot(containerOpts, projectCfg); err != nil {
+	if err = guardWorktreeMode(opts.Client, containerOpts, projectCfg); err != nil {
 		return nil, err
 	}
 
@@ -1892,6 +2020,7 @@ func prepareWorkspace(ctx context.Context, opts *CreateContainerOptions, agentNa
 		HarnessName:    bundle.Name,
 		HarnessVolumes: bundle.Manifest.Volumes,
 		HarnessConfig:  opts.Config.Project().HarnessConfigFor(bundle.Name),
+		Plan:           opts.plan,
 		Log:            log,
 	})
 	if err != nil {
@@ -1924,6 +2053,27 @@ func initConfigVolume(ctx context.Context, opts *CreateContainerOptions, agentNa
 	return nil
 }
 
+// restoreSession restores the agent's saved harness session (clawker
+// session) into the harness volumes this create made, unless the harness
+// has persist_sessions off.
+func restoreSession(ctx context.Context, opts *CreateContainerOptions, agentName, containerName string, ws *workspaceSetup) (*session.Meta, error) {
+	bundle := opts.harnessBundle
+	if !opts.Config.Project().HarnessConfigFor(bundle.Name).PersistSessionsEnabled() {
+		return nil, nil
+	}
+	return RestoreSession(ctx, RestoreSessionOpts{
+		Store:         session.DefaultStore,
+		ContainerName: containerName,
+		ProjectName:   opts.ProjectName,
+		AgentName:     agentName,
+		HarnessName:   bundle.Name,
+		Volumes:       bundle.Manifest.Volumes,
+		FreshVolumes:  ws.result.ConfigVolumeResult.CreatedByName,
+		CopyToVolume:  opts.Client.CopyToVolume,
+		Log:           opts.Log,
+	})
+}
+
 // harnessForImage derives the container's harness identity from the image's
 // harness label — the image IS the built harness, so create composes against
 // what the image contains, not whatever build.harness says today (that
@@ -1979,28 +2129,46 @@ type containerConfigs struct {
 	container *container.Config
 	host      *container.HostConfig
 	network   *network.NetworkingConfig
+	// env is container.Env with the layer each var came from.
+	env []EnvVar
+	// hostProxyTokenDigest labels the container with the digest of the
+	// host proxy caller token in its env; empty without a host proxy.
+	hostProxyTokenDigest string
 }
 
 // buildContainerConfigs assembles the git-credential mounts and create-time
 // env, validates flags, and builds the Docker container/host/networking
-// configs (including the DNS and working-directory defaults). It mutates
-// opts.Options.Env with the resolved git + runtime env.
-func buildContainerConfigs(ctx context.Context, opts *CreateContainerOptions, agentName string, ws *workspaceSetup, hostProxyRunning bool) (*containerConfigs, error) {
+// configs (including the DNS and working-directory defaults). The env is
+// merged with MergeEnv: settings < project < secret < cli, then --env-unset.
+// hostProxyURL is empty when the host proxy is not running.
+func buildContainerConfigs(ctx context.Context, opts *CreateContainerOptions, agentName string, ws *workspaceSetup, hostProxyURL string) (*containerConfigs, error) {
 	containerOpts := opts.Options
 	projectCfg := opts.Config.Project()
 	log := opts.Log
 
 	workspaceMounts := ws.result.Mounts
 
-	gitSetup := workspace.SetupGitCredentials(projectCfg.Security.GitCredentials, hostProxyRunning, log)
+	gitSetup := workspace.SetupGitCredentials(projectCfg.Security.GitCredentials, hostProxyURL != "", log)
 	workspaceMounts = append(workspaceMounts, gitSetup.Mounts...)
-	containerOpts.Env = append(containerOpts.Env, gitSetup.Env...)
 
-	runtimeEnv, envWarnings, err := buildCreateTimeEnv(ctx, opts, containerOpts, agentName, ws.wd, ws.projectRootDir, log)
+	settingsEnv, projectEnv, envWarnings, err := buildCreateTimeEnv(ctx, opts, containerOpts, agentName, ws.wd, ws.projectRootDir, log)
 	if err != nil {
 		return nil, err
 	}
-	containerOpts.Env = append(containerOpts.Env, runtimeEnv...)
+	settingsEnv = append(settingsEnv, gitSetup.Env...)
+	var tokenDigest string
+	if hostProxyURL != "" {
+		settingsEnv = append(settingsEnv, consts.EnvHostProxy+"="+hostProxyURL)
+		// The token is how the host proxy tells this container from the
+		// others; nothing the container reports about itself is trusted.
+		token := planRedacted
+		if !opts.plan {
+			if token, tokenDigest, err = hostproxy.NewCallerToken(); err != nil {
+				return nil, err
+			}
+		}
+		settingsEnv = append(settingsEnv, consts.EnvHostProxyToken+"="+token)
+	}
 	for _, w := range envWarnings {
 		log.Warn().Msg(w)
 	}
@@ -2014,6 +2182,32 @@ func buildContainerConfigs(ctx context.Context, opts *CreateContainerOptions, ag
 	if err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
+	if err = EnforceResourceCap(&hostConfig.Resources, opts.Config.Settings().Resources, true); err != nil {
+		return nil, err
+	}
+	if containerOpts.CreateHostDirs && !opts.plan {
+		if err = createHostDirs(containerOpts.userMounts()); err != nil {
+			return nil, err
+		}
+	}
+
+	var secretEnv []string
+	if opts.plan {
+		secretEnv, err = planSecrets(opts.Config, hostConfig)
+	} else {
+		secretEnv, err = applySecrets(ctx, opts.Config, hostConfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// BuildConfigs leaves only --env-file and -e in Env: the CLI layer.
+	env := MergeEnv([]EnvLayer{
+		{Source: EnvSourceSettings, Env: settingsEnv},
+		{Source: EnvSourceProject, Env: projectEnv},
+		{Source: EnvSourceSecret, Env: secretEnv},
+		{Source: EnvSourceCLI, Env: containerConfig.Env},
+	}, containerOpts.EnvUnset)
+	containerConfig.Env = EnvList(env)
 
 	// Set Cloudflare malware-blocking DNS as Docker's external forwarders.
 	// Docker's internal DNS (127.0.0.11) remains the container's nameserver and
@@ -2027,13 +2221,114 @@ func buildContainerConfigs(ctx context.Context, opts *CreateContainerOptions, ag
 		}
 	}
 
+	// Point host.docker.internal at this machine where the provider does
+	// not (Lima VMs, native Docker Engine), unless the user mapped it.
+	if hostProxyURL != "" && opts.Client != nil {
+		hostConfig.ExtraHosts = addHostProxyHosts(hostConfig.ExtraHosts, opts.Client.HostRuntime().ExtraHosts())
+	}
+
 	// Set container WorkingDir to match the workspace mount target unless
 	// the user explicitly provided --workdir.
 	if containerConfig.WorkingDir == "" {
 		containerConfig.WorkingDir = ws.result.ContainerPath
 	}
 
-	return &containerConfigs{container: containerConfig, host: hostConfig, network: networkConfig}, nil
+	return &containerConfigs{container: containerConfig, host: hostConfig, network: networkConfig, env: env, hostProxyTokenDigest: tokenDigest}, nil
+}
+
+// addHostProxyHosts appends the runtime's host entries to extraHosts,
+// skipping any name the user already mapped with --add-host.
+func addHostProxyHosts(extraHosts, runtimeHosts []string) []string {
+	for _, entry := range runtimeHosts {
+		name, _, _ := strings.Cut(entry, ":")
+		mapped := slices.ContainsFunc(extraHosts, func(h string) bool {
+			return strings.HasPrefix(h, name+":") || strings.HasPrefix(h, name+"=")
+		})
+		if !mapped {
+			extraHosts = append(extraHosts, entry)
+		}
+	}
+	return extraHosts
+}
+
+// provisionReadOnlyRoot mounts the root filesystem read-only when
+// security.read_only_root or --read-only is set, adding the tmpfs and
+// ephemeral volume mounts from workspace.SetupReadOnlyRoot for the paths the
+// agent legitimately writes. Paths already covered by a mount (workspace,
+// harness volumes, user -v/--mount/--tmpfs) are left as configured. The
+// provisioned volumes are registered on the reclaim scope.
+func provisionReadOnlyRoot(ctx context.Context, opts *CreateContainerOptions, agentName string, cfgs *containerConfigs, scope *createScope) error {
+	security := opts.Config.Project().Security
+	if !opts.Options.ReadOnly && !security.ReadOnlyRoot {
+		return nil
+	}
+
+	homeDir, err := imageHomeDir(ctx, opts.Client, opts.Options.Image)
+	if err != nil {
+		return err
+	}
+
+	var targets []string
+	for _, m := range cfgs.host.Mounts {
+		targets = append(targets, m.Target)
+	}
+	for _, b := range cfgs.host.Binds {
+		// "dst", "src:dst", or "src:dst:opts"
+		parts := strings.Split(b, ":")
+		if len(parts) == 1 {
+			targets = append(targets, parts[0])
+		} else {
+			targets = append(targets, parts[1])
+		}
+	}
+	for p := range cfgs.host.Tmpfs {
+		targets = append(targets, p)
+	}
+
+	result, err := workspace.SetupReadOnlyRoot(ctx, opts.Client, workspace.ReadOnlyRootConfig{
+		Log:           opts.Log,
+		ProjectName:   opts.ProjectName,
+		AgentName:     agentName,
+		HomeDir:       homeDir,
+		WritablePaths: security.WritablePaths,
+		Targets:       targets,
+		Plan:          opts.plan,
+	})
+	if err != nil {
+		return fmt.Errorf("setting up read-only root filesystem: %w", err)
+	}
+	scope.volumes = append(scope.volumes, result.CreatedVolumes...)
+
+	cfgs.host.ReadonlyRootfs = true
+	cfgs.host.Mounts = append(cfgs.host.Mounts, result.Mounts...)
+	if len(result.Tmpfs) > 0 {
+		if cfgs.host.Tmpfs == nil {
+			cfgs.host.Tmpfs = make(map[string]string, len(result.Tmpfs))
+		}
+		maps.Copy(cfgs.host.Tmpfs, result.Tmpfs)
+	}
+	return nil
+}
+
+// imageHomeDir returns the HOME set in the image config, falling back to
+// consts.ContainerHomeDir when the image sets none or is not visible to
+// clawker (not found or not managed). Any other inspect failure surfaces.
+func imageHomeDir(ctx context.Context, client *docker.Client, imageRef string) (string, error) {
+	inspect, err := client.ImageInspect(ctx, imageRef)
+	switch {
+	case err == nil:
+		if inspect.Config != nil {
+			for _, kv := range inspect.Config.Env {
+				if home, ok := strings.CutPrefix(kv, "HOME="); ok && home != "" {
+					return home, nil
+				}
+			}
+		}
+	case docker.IsNotFound(err):
+	default:
+		return "", fmt.Errorf("resolving home directory: inspect image %s: %w", imageRef, err)
+	}
+	return consts.ContainerHomeDir, nil
 }
 
 // finalizeCreatedContainer performs the post-create steps that depend on the
@@ -2085,6 +2380,34 @@ func createAndBootstrapContainer(ctx context.Context, opts *CreateContainerOptio
 	// start-time consumers (pre_run composition, egress refresh) read back
 	// instead of re-resolving the configured default.
 	extraLabels[consts.LabelHarness] = opts.harnessBundle.Name
+	maps.Copy(extraLabels, openURLLabels(opts.Config.Project()))
+	maps.Copy(extraLabels, gitCredentialHostLabels(ws, opts.Log))
+	if cfgs.hostProxyTokenDigest != "" {
+		extraLabels[consts.LabelHostProxyToken] = cfgs.hostProxyTokenDigest
+	}
+
+	// Last stop before the container exists: a failing pre_create host hook
+	// aborts the create (the caller's reclaim removes any new volumes).
+	hook, err := HostHookCommand(opts.Config, HostHookPreCreate)
+	if err != nil {
+		return "", err
+	}
+	if hook != "" {
+		hc := HostHookContext{
+			Event:         HostHookPreCreate,
+			ContainerName: containerName,
+			Image:         opts.Options.Image,
+			Labels:        MergeLabels(extraLabels, cfgs.container.Labels),
+			Ports:         hostHookPortsFromMap(cfgs.host.PortBindings),
+		}
+		if err := RunHostHook(ctx, opts.HookOut, hook, hc); err != nil {
+			return "", err
+		}
+	}
+
+	if err := addProjectNetwork(ctx, client, opts.Config.Project(), opts.ProjectName, cfgs.host, cfgs.network); err != nil {
+		return "", err
+	}
 
 	resp, err := client.ContainerCreate(ctx, docker.ContainerCreateOptions{
 		Config:           cfgs.container,
@@ -2113,8 +2436,10 @@ func createAndBootstrapContainer(ctx context.Context, opts *CreateContainerOptio
 // buildCreateTimeEnv constructs container runtime environment variables.
 // projectRootDir is the main repo root when the workspace is a git worktree
 // (empty otherwise) — the same signal workspace.SetupMounts keys the .git
-// mount on. Returns env vars, warnings (e.g. unset from_env vars), and error.
-func buildCreateTimeEnv(ctx context.Context, opts *CreateContainerOptions, containerOpts *ContainerCreateOptions, agentName, wd, projectRootDir string, log *logger.Logger) (env []string, warnings []string, retErr error) {
+// mount on. Returns the settings-layer env (clawker's runtime env), the
+// project-layer env (agent/harness env specs, then build.instructions.env),
+// warnings (e.g. unset from_env vars), and error.
+func buildCreateTimeEnv(ctx context.Context, opts *CreateContainerOptions, containerOpts *ContainerCreateOptions, agentName, wd, projectRootDir string, log *logger.Logger) (settingsEnv, projectEnv, warnings []string, retErr error) {
 	projectCfg := opts.Config.Project()
 	workspaceMode := containerOpts.Mode
 	if workspaceMode == "" {
@@ -2125,7 +2450,7 @@ func buildCreateTimeEnv(ctx context.Context, opts *CreateContainerOptions, conta
 	agentEnv, warnings, err := ResolveAgentEnv(
 		projectCfg.Agent, projectCfg.HarnessConfigFor(harnessName), harnessName, wd, log)
 	if err != nil {
-		return nil, nil, fmt.Errorf("resolving agent environment: %w", err)
+		return nil, nil, nil, fmt.Errorf("resolving agent environment: %w", err)
 	}
 
 	monitoringActive := opts.Client.IsMonitoringActive(ctx)
@@ -2148,7 +2473,6 @@ func buildCreateTimeEnv(ctx context.Context, opts *CreateContainerOptions, conta
 		Visual:            projectCfg.Agent.Visual,
 		Is256Color:        opts.Is256Color,
 		TrueColor:         opts.IsTrueColor,
-		AgentEnv:          agentEnv,
 		MonitoringActive:  monitoringActive,
 		ClawkerdAgentAddr: net.JoinHostPort(consts.ContainerCP, strconv.Itoa(cpSettings.AgentPort)),
 		ClawkerdHydraURL: "https://" + net.JoinHostPort(
@@ -2170,13 +2494,22 @@ func buildCreateTimeEnv(ctx context.Context, opts *CreateContainerOptions, conta
 		envOpts.GPGForwardingEnabled = projectCfg.Security.GitCredentials.GPGEnabled()
 		envOpts.SSHForwardingEnabled = projectCfg.Security.GitCredentials.GitSSHEnabled()
 	}
-	if projectCfg.Build.Instructions != nil {
-		envOpts.InstructionEnv = projectCfg.Build.Instructions.Env
+	settingsEnv, err = docker.RuntimeEnv(envOpts)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	result, err := docker.RuntimeEnv(envOpts)
-	if err != nil {
-		return nil, nil, err
+	// Project layer: agent + harness env specs, then build.instructions.env
+	// (the same order RuntimeEnv applies AgentEnv and InstructionEnv in).
+	project := maps.Clone(agentEnv)
+	if project == nil {
+		project = make(map[string]string)
+	}
+	if projectCfg.Build.Instructions != nil {
+		maps.Copy(project, projectCfg.Build.Instructions.Env)
+	}
+	for _, k := range slices.Sorted(maps.Keys(project)) {
+		projectEnv = append(projectEnv, k+"="+project[k])
 	}
-	return result, warnings, nil
+	return settingsEnv, projectEnv, warnings, nil
 }
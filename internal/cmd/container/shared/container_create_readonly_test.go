@@ -0,0 +1,102 @@
+package shared
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
+	moby "github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+func readOnlyCreateOpts(t *testing.T, projectYAML string, fake *mocks.FakeClient) *CreateContainerOptions {
+	t.Helper()
+	containerOpts := NewContainerOptions()
+	containerOpts.Image = "clawker-myapp:latest"
+	return &CreateContainerOptions{
+		Client:      fake.Client,
+		Config:      configmocks.NewFromString(projectYAML, ""),
+		ProjectName: "myapp",
+		Options:     containerOpts,
+		Log:         logger.Nop(),
+	}
+}
+
+func TestProvisionReadOnlyRoot_Disabled(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	opts := readOnlyCreateOpts(t, "", fake)
+	cfgs := &containerConfigs{host: &container.HostConfig{}}
+	scope := &createScope{client: fake.Client, log: logger.Nop()}
+
+	require.NoError(t, provisionReadOnlyRoot(t.Context(), opts, "dev", cfgs, scope))
+	assert.False(t, cfgs.host.ReadonlyRootfs)
+	assert.Empty(t, cfgs.host.Mounts)
+	assert.Empty(t, fake.FakeAPI.Calls, "no Docker calls when the read-only root is off")
+}
+
+// TestProvisionReadOnlyRoot_FromConfig pins the hardened create path: the
+// root goes read-only, the home dir comes from the image's HOME, paths the
+// user already mounts are left alone, and every provisioned volume lands on
+// the reclaim scope.
+func TestProvisionReadOnlyRoot_FromConfig(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupVolumeExists("", false)
+	fake.SetupVolumeCreate()
+	fake.SetupImageExists("clawker-myapp:latest", true)
+	inspect := fake.FakeAPI.ImageInspectFn
+	fake.FakeAPI.ImageInspectFn = func(ctx context.Context, image string, o ...moby.ImageInspectOption) (moby.ImageInspectResult, error) {
+		result, err := inspect(ctx, image, o...)
+		if err == nil {
+			result.Config.Env = []string{"PATH=/usr/bin", "HOME=/home/agent"}
+		}
+		return result, err
+	}
+
+	opts := readOnlyCreateOpts(t, `
+security:
+  read_only_root: true
+  writable_paths: [/opt/cache]
+`, fake)
+	cfgs := &containerConfigs{host: &container.HostConfig{
+		Mounts: []mount.Mount{{Type: mount.TypeVolume, Source: "ws", Target: "/workspace"}},
+		Binds:  []string{"/host/logs:/var/log/clawker:ro"},
+		Tmpfs:  map[string]string{"/var/tmp": "size=64m"},
+	}}
+	scope := &createScope{client: fake.Client, log: logger.Nop()}
+
+	require.NoError(t, provisionReadOnlyRoot(t.Context(), opts, "dev", cfgs, scope))
+	assert.True(t, cfgs.host.ReadonlyRootfs)
+
+	assert.Equal(t, map[string]string{
+		"/tmp":     "rw,nosuid,nodev,mode=1777",
+		"/var/tmp": "size=64m",
+	}, cfgs.host.Tmpfs, "the user's --tmpfs must win over the provisioned scratch mount")
+
+	var targets []string
+	for _, m := range cfgs.host.Mounts {
+		targets = append(targets, m.Target)
+	}
+	assert.Equal(t, []string{"/workspace", "/home/agent", "/run/clawker", "/var/lib/clawker", "/opt/cache"}, targets)
+	assert.Equal(t, []string{
+		"clawker.myapp.dev-rootfs-home-agent",
+		"clawker.myapp.dev-rootfs-run-clawker",
+		"clawker.myapp.dev-rootfs-var-lib-clawker",
+		"clawker.myapp.dev-rootfs-opt-cache",
+	}, scope.volumes)
+}
+
+func TestImageHomeDir_FallsBackForUnmanagedImage(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupImageExists("other:latest", false)
+
+	home, err := imageHomeDir(t.Context(), fake.Client, "other:latest")
+	require.NoError(t, err)
+	assert.Equal(t, consts.ContainerHomeDir, home)
+}
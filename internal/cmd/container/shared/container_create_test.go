@@ -1,10 +1,13 @@
 package shared
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
 	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -405,6 +408,20 @@ func TestContainerOptions_BuildConfigs_ResourceLimits(t *testing.T) {
 		assert.Equal(t, int64(0), hostCfg.NanoCPUs)
 		assert.Equal(t, int64(0), hostCfg.CPUShares)
 	})
+
+	t.Run("project resources fill unset limits", func(t *testing.T) {
+		opts := NewContainerOptions()
+		opts.Image = "alpine"
+		require.NoError(t, opts.Memory.Set("1g"))
+		project := &config.Project{Resources: config.ResourcesConfig{CPUs: "2", Memory: "4g", Pids: 256}}
+
+		_, hostCfg, _, err := opts.BuildConfigs(nil, nil, project)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2e9), hostCfg.NanoCPUs)
+		assert.Equal(t, int64(1<<30), hostCfg.Memory, "--memory overrides resources.memory")
+		require.NotNil(t, hostCfg.PidsLimit)
+		assert.Equal(t, int64(256), *hostCfg.PidsLimit)
+	})
 }
 
 // Verify docker types implement pflag.Value interface
@@ -1910,7 +1927,7 @@ func TestContainerOptions_BuildConfigs_NewStorage(t *testing.T) {
 	t.Run("mount is set in host config", func(t *testing.T) {
 		opts := NewContainerOptions()
 		opts.Image = "alpine"
-		require.NoError(t, opts.Mounts.Set("type=bind,source=/src,target=/dst"))
+		require.NoError(t, opts.Mounts.Set("type=bind,source="+t.TempDir()+",target=/dst"))
 
 		_, hostCfg, _, err := opts.BuildConfigs(nil, nil, &config.Project{})
 		require.NoError(t, err)
@@ -1920,6 +1937,63 @@ func TestContainerOptions_BuildConfigs_NewStorage(t *testing.T) {
 	})
 }
 
+func TestContainerOptions_BuildConfigs_MountValidation(t *testing.T) {
+	workspace := []mount.Mount{
+		{Type: mount.TypeBind, Source: "/home/dev/app", Target: "/home/dev/app"},
+		{Type: mount.TypeVolume, Source: "clawker.app.dev-config", Target: "/home/claude/.claude"},
+	}
+
+	t.Run("mount over the workspace is rejected", func(t *testing.T) {
+		opts := NewContainerOptions()
+		opts.Image = "alpine"
+		opts.Volumes = []string{t.TempDir() + ":/home/dev/app/"}
+
+		_, _, _, err := opts.BuildConfigs(nil, workspace, &config.Project{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "/home/dev/app is already mounted by clawker (bind of /home/dev/app)")
+	})
+
+	t.Run("problems are reported together", func(t *testing.T) {
+		opts := NewContainerOptions()
+		opts.Image = "alpine"
+		missing := filepath.Join(t.TempDir(), "missing")
+		opts.Volumes = []string{missing + ":/data", "cache:/home/claude/.claude"}
+		opts.Tmpfs = []string{"/data:size=64m"}
+
+		_, _, _, err := opts.BuildConfigs(nil, workspace, &config.Project{})
+		require.Error(t, err)
+		msg := err.Error()
+		assert.Contains(t, msg, "host path "+missing+" does not exist")
+		assert.Contains(t, msg, "-v cache:/home/claude/.claude: /home/claude/.claude is already mounted by clawker (volume clawker.app.dev-config)")
+		assert.Contains(t, msg, "--tmpfs /data:size=64m: /data is already the target of -v "+missing+":/data")
+	})
+
+	t.Run("named volumes and nested targets are allowed", func(t *testing.T) {
+		opts := NewContainerOptions()
+		opts.Image = "alpine"
+		opts.Volumes = []string{"node-modules:/home/dev/app/node_modules", "/scratch"}
+
+		_, hostCfg, _, err := opts.BuildConfigs(nil, workspace, &config.Project{})
+		require.NoError(t, err)
+		assert.Contains(t, hostCfg.Binds, "node-modules:/home/dev/app/node_modules")
+	})
+
+	t.Run("create-host-dirs skips the existence check", func(t *testing.T) {
+		opts := NewContainerOptions()
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddFlags(flags, opts)
+		missing := filepath.Join(t.TempDir(), "a", "b")
+		require.NoError(t, flags.Parse([]string{"--create-host-dirs", "-v", missing + ":/data"}))
+		opts.Image = "alpine"
+
+		_, _, _, err := opts.BuildConfigs(flags, workspace, &config.Project{})
+		require.NoError(t, err)
+
+		require.NoError(t, createHostDirs(opts.userMounts()))
+		assert.DirExists(t, missing)
+	})
+}
+
 func TestContainerOptions_DeviceFlags(t *testing.T) {
 	t.Run("device flag parsing", func(t *testing.T) {
 		opts := NewContainerOptions()
@@ -2817,3 +2891,86 @@ func TestContainerOptions_NewFlags(t *testing.T) {
 		assert.Empty(t, opts.Workdir)
 	})
 }
+
+func TestReadEnvFile(t *testing.T) {
+	t.Setenv("CLAWKER_TEST_INHERITED", "from-host")
+
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "plain entries keep file order",
+			content: "B=2\nA=1\n",
+			want:    []string{"B=2", "A=1"},
+		},
+		{
+			name:    "export prefix, quotes, and comments",
+			content: "# header\n  # indented comment\nexport KEY=value\nDQ=\"a # b\"\nSQ='x'\nEMPTY=\n",
+			want:    []string{"KEY=value", "DQ=a # b", "SQ=x", "EMPTY="},
+		},
+		{
+			name:    "values are literal",
+			content: "REF=$CLAWKER_TEST_INHERITED\nBRACED=\"${HOME}/bin\"\nINLINE=x # not a comment\nESC=a\\nb\n",
+			want: []string{
+				"REF=$CLAWKER_TEST_INHERITED", "BRACED=${HOME}/bin", "INLINE=x # not a comment",
+				"ESC=a\\nb",
+			},
+		},
+		{
+			name:    "bare key inherits from host",
+			content: "CLAWKER_TEST_INHERITED\n",
+			want:    []string{"CLAWKER_TEST_INHERITED=from-host"},
+		},
+		{
+			name:    "bare key unset on host is dropped",
+			content: "CLAWKER_TEST_NOT_SET_ANYWHERE\nKEY=v\n",
+			want:    []string{"KEY=v"},
+		},
+		{
+			name:    "unterminated quote reports line",
+			content: "A=1\nB=\"open\n",
+			wantErr: "line 2: unterminated quoted value",
+		},
+		{
+			name:    "whitespace in key reports line",
+			content: "A=1\n\nBAD KEY=x\n",
+			wantErr: `line 3: variable name "BAD KEY" contains whitespace`,
+		},
+		{
+			name:    "missing key reports line",
+			content: "A=1\n=value\n",
+			wantErr: "line 2: missing variable name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.env")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			got, err := readEnvFile(path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAddHostProxyHosts(t *testing.T) {
+	runtimeHosts := []string{"host.docker.internal:192.168.5.2"}
+
+	got := addHostProxyHosts([]string{"db:10.0.0.2"}, runtimeHosts)
+	assert.Equal(t, []string{"db:10.0.0.2", "host.docker.internal:192.168.5.2"}, got)
+
+	got = addHostProxyHosts([]string{"host.docker.internal:10.1.1.1"}, runtimeHosts)
+	assert.Equal(t, []string{"host.docker.internal:10.1.1.1"}, got, "a user --add-host mapping wins")
+
+	assert.Nil(t, addHostProxyHosts(nil, nil))
+}
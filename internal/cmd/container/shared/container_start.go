@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 
 	cerrdefs "github.com/containerd/errdefs"
 	mobyClient "github.com/moby/moby/client"
@@ -50,6 +51,13 @@ type CommandOpts struct {
 	// new-container start path so MintAgentCert composes the right
 	// AgentFullName URI SAN.
 	Project string
+
+	// HookOut receives the output of the project's post_ready host hook
+	// (hooks.post_ready) and its failure warning, plus start-time warnings
+	// such as an unavailable gpg-agent. Nil — the attached-session case,
+	// where the container owns the terminal — runs the hook in the
+	// background with its output routed to the log.
+	HookOut io.Writer
 }
 
 // NeedsSocketBridge returns true if the project config enables GPG or SSH
@@ -62,12 +70,18 @@ func NeedsSocketBridge(cfg *config.Project) bool {
 }
 
 // ensureHostProxyRunning starts the host proxy when the project enables it.
-// A nil provider or a nil proxy instance is a no-op (debug-logged); only a
-// failure from EnsureRunning aborts the start. log may be nil.
+// A nil provider or a nil proxy instance is a no-op (debug-logged). When the
+// project forwards credentials through the host proxy, the start also waits
+// for its /readyz checks so a missing dependency fails here rather than
+// mid-session. GPG forwarding is on by default, so an unreachable gpg-agent
+// (or no GnuPG on the host) is only a warning to warnOut, probed once rather
+// than waited on. log and warnOut may be nil.
 func ensureHostProxyRunning(
+	ctx context.Context,
 	projectCfg *config.Project,
 	hostProxyFn func() hostproxy.Service,
 	log *logger.Logger,
+	warnOut io.Writer,
 ) error {
 	if projectCfg == nil || !projectCfg.Security.HostProxyEnabled() {
 		if log != nil {
@@ -97,6 +111,29 @@ func ensureHostProxyRunning(
 	if log != nil {
 		log.Debug().Msg("host proxy started successfully")
 	}
+
+	gc := projectCfg.Security.GitCredentials
+	if !NeedsSocketBridge(projectCfg) && !gc.GitHTTPSEnabled(true) {
+		return nil
+	}
+	readyCtx, cancel := context.WithTimeout(ctx, consts.HostProxyReadyzTimeout)
+	defer cancel()
+	if err := hp.WaitReady(readyCtx); err != nil {
+		return fmt.Errorf("bootstrapping services: host proxy not ready for credential forwarding: %w", err)
+	}
+
+	if gc != nil && gc.GPGEnabled() {
+		probeCtx, cancelProbe := context.WithTimeout(ctx, consts.HostProxyReadyzCheckTimeout)
+		defer cancelProbe()
+		if err := hostproxy.CheckGPGAgent(probeCtx); err != nil {
+			if log != nil {
+				log.Warn().Err(err).Msg("gpg-agent unavailable; GPG forwarding will not work")
+			}
+			if warnOut != nil {
+				fmt.Fprintf(warnOut, "Warning: GPG forwarding unavailable: %v (set security.git_credentials.forward_gpg: false to silence)\n", err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -155,6 +192,12 @@ func BootstrapServicesPreStart(ctx context.Context, container string, cmdOpts Co
 		return errors.New("bootstrapping services: docker client is nil")
 	}
 
+	// Containers created before per-project networks join theirs here, so
+	// the agent reaches sidecars and the firewall passes project traffic.
+	if err := connectProjectNetwork(ctx, client, projectCfg, container); err != nil {
+		return fmt.Errorf("bootstrapping services: %w", err)
+	}
+
 	// The container's harness label (stamped at create from the image) is
 	// the runtime identity — egress floor and pre_run compose against it,
 	// not against whatever the configured default happens to be today.
@@ -202,7 +245,14 @@ func BootstrapServicesPreStart(ctx context.Context, container string, cmdOpts Co
 		}
 	}
 
-	if err = ensureHostProxyRunning(projectCfg, cmdOpts.HostProxy, log); err != nil {
+	// The host proxy listens on this machine; a container on a remote
+	// daemon cannot reach it, so browser auth and credential forwarding
+	// through it are unavailable there.
+	if ep := client.Endpoint(); ep.Remote() {
+		if projectCfg != nil && projectCfg.Security.HostProxyEnabled() && cmdOpts.HookOut != nil {
+			fmt.Fprintf(cmdOpts.HookOut, "Warning: host proxy unavailable with a remote Docker daemon (%s): browser auth and git credential forwarding will not work (set security.enable_host_proxy: false to silence)\n", ep)
+		}
+	} else if err = ensureHostProxyRunning(ctx, projectCfg, cmdOpts.HostProxy, log, cmdOpts.HookOut); err != nil {
 		return err
 	}
 
@@ -369,6 +419,34 @@ func BootstrapServicesPostStart(ctx context.Context, container string, cmdOpts C
 			}
 		}
 	}
+
+	// File secrets live on a tmpfs that every start empties.
+	if len(projectCfg.Secrets) > 0 && cmdOpts.Client != nil {
+		client, err := cmdOpts.Client(ctx)
+		if err != nil {
+			return fmt.Errorf("bootstrapping services: connecting to Docker: %w", err)
+		}
+		if err := injectSecretFiles(ctx, client, container, cfg, log); err != nil {
+			return fmt.Errorf("bootstrapping services: injecting secrets: %w", err)
+		}
+	}
+
+	// The container is ready: hand it to the project's post_ready host hook.
+	hook, err := HostHookCommand(cfg, HostHookPostReady)
+	if err != nil {
+		log.Warn().Err(err).Msg("post_ready hook skipped")
+		if cmdOpts.HookOut != nil {
+			fmt.Fprintf(cmdOpts.HookOut, "Warning: post_ready hook skipped: %v\n", err)
+		}
+	}
+	if hook != "" && cmdOpts.Client != nil {
+		client, err := cmdOpts.Client(ctx)
+		if err != nil {
+			log.Warn().Err(err).Msg("post_ready hook skipped: connecting to Docker failed")
+			return nil
+		}
+		runPostReadyHook(ctx, client, container, hook, cmdOpts.HookOut, log)
+	}
 	return nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	cerrdefs "github.com/containerd/errdefs"
 	"github.com/moby/moby/api/types/container"
@@ -19,8 +20,11 @@ import (
 	"github.com/schmitthub/clawker/internal/bundle/bundletest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
 	mocks "github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/hostproxy"
+	"github.com/schmitthub/clawker/internal/hostproxy/hostproxytest"
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/testenv"
 )
@@ -496,3 +500,22 @@ func TestAssertHarnessResolvable_DeclarationGated(t *testing.T) {
 	require.NoError(t, assertHarnessResolvable(undeclared, "claude"),
 		"a bare floor harness resolves regardless of bundle declarations")
 }
+
+func TestEnsureHostProxyRunning_DefaultConfigWithoutGPG(t *testing.T) {
+	// No gpgconf on PATH: the default config forwards GPG, but a host
+	// without GnuPG must start promptly with a warning, not wait out the
+	// readiness timeout and fail.
+	t.Setenv("PATH", t.TempDir())
+	projectCfg := configmocks.NewBlankConfig().Project()
+	require.True(t, projectCfg.Security.GitCredentials.GPGEnabled())
+
+	hp := hostproxytest.NewMockManager()
+	var warn strings.Builder
+	start := time.Now()
+	err := ensureHostProxyRunning(context.Background(), projectCfg, func() hostproxy.Service { return hp }, logger.Nop(), &warn)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), consts.HostProxyReadyzTimeout)
+	assert.Empty(t, hp.ReadyChecks, "gpg-agent is not part of the readiness gate")
+	assert.Contains(t, warn.String(), "GPG forwarding unavailable")
+	assert.Contains(t, warn.String(), "forward_gpg: false")
+}
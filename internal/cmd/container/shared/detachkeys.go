@@ -0,0 +1,32 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/term"
+)
+
+// ResolveDetachKeys picks the detach key sequence for an attached session:
+// the --detach-keys flag value, else settings.terminal.detach_keys, else
+// term.DefaultDetachKeys. It returns the spec, passed on to the daemon, and
+// its parsed bytes for the client-side check in docker.PTYHandler. cfg may
+// be nil when settings are unavailable.
+func ResolveDetachKeys(flagValue string, cfg config.Config) (string, []byte, error) {
+	spec, source := flagValue, "--detach-keys"
+	if spec == "" && cfg != nil {
+		spec, source = cfg.Settings().Terminal.DetachKeys, "settings.terminal.detach_keys"
+	}
+	if spec == "" {
+		spec = term.DefaultDetachKeys
+	}
+	keys, err := term.ParseDetachKeys(spec)
+	if err != nil {
+		if source == "--detach-keys" {
+			return "", nil, cmdutil.FlagErrorf("invalid --detach-keys: %v", err)
+		}
+		return "", nil, fmt.Errorf("invalid %s: %w", source, err)
+	}
+	return spec, keys, nil
+}
@@ -0,0 +1,54 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/term"
+)
+
+func TestResolveDetachKeys(t *testing.T) {
+	settings := configmocks.NewFromString("", "terminal:\n  detach_keys: ctrl-a,d\n")
+
+	tests := []struct {
+		name     string
+		flag     string
+		wantSpec string
+		wantKeys []byte
+	}{
+		{name: "flag wins over settings", flag: "ctrl-x", wantSpec: "ctrl-x", wantKeys: []byte{0x18}},
+		{name: "settings default", wantSpec: "ctrl-a,d", wantKeys: []byte{0x01, 'd'}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, keys, err := ResolveDetachKeys(tt.flag, settings)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSpec, spec)
+			assert.Equal(t, tt.wantKeys, keys)
+		})
+	}
+
+	t.Run("built-in default without config", func(t *testing.T) {
+		spec, keys, err := ResolveDetachKeys("", nil)
+		require.NoError(t, err)
+		assert.Equal(t, term.DefaultDetachKeys, spec)
+		assert.Equal(t, []byte{0x10, 0x11}, keys)
+	})
+
+	t.Run("invalid flag is a flag error", func(t *testing.T) {
+		_, _, err := ResolveDetachKeys("alt-x", settings)
+		var flagErr *cmdutil.FlagError
+		assert.ErrorAs(t, err, &flagErr)
+	})
+
+	t.Run("invalid setting names the key", func(t *testing.T) {
+		bad := configmocks.NewFromString("", "terminal:\n  detach_keys: ctrl-1\n")
+		_, _, err := ResolveDetachKeys("", bad)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "settings.terminal.detach_keys")
+	})
+}
@@ -0,0 +1,105 @@
+package shared
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+// EnvSource names the layer a container env var came from. Layers apply in
+// the order below; a later layer overrides an earlier one on key collision.
+type EnvSource string
+
+const (
+	// EnvSourceSettings is clawker's own runtime env: identity, editor and
+	// terminal defaults, telemetry, the firewall and control-plane endpoints
+	// derived from settings, host proxy and git credential forwarding.
+	EnvSourceSettings EnvSource = "settings"
+	// EnvSourceProject is the project config: agent.env_file/from_env/env,
+	// the selected harness's env spec, and build.instructions.env.
+	EnvSourceProject EnvSource = "project"
+	// EnvSourceSecret is the env-targeted entries of the secrets: block.
+	EnvSourceSecret EnvSource = "secret"
+	// EnvSourceCLI is --env-file and -e, in that order.
+	EnvSourceCLI EnvSource = "cli"
+)
+
+// EnvLayer is one source's KEY=value entries. A bare KEY entry takes the
+// host value; unset on the host, it unsets KEY like --env-unset (docker -e
+// passthrough semantics).
+type EnvLayer struct {
+	Source EnvSource
+	Env    []string
+}
+
+// EnvVar is one merged container env var.
+type EnvVar struct {
+	Key    string    `json:"key"`
+	Value  string    `json:"value"`
+	Source EnvSource `json:"source"`
+	// Overrides lists the lower layers whose value for Key was replaced.
+	Overrides []EnvSource `json:"overrides,omitempty"`
+	// Unset marks a key removed by --env-unset (or an unresolvable bare
+	// -e KEY). It is sent to the daemon as a bare KEY, which also drops the
+	// image's ENV for it.
+	Unset bool `json:"unset,omitempty"`
+}
+
+// MergeEnv merges the layers, lowest precedence first, then applies unset:
+// each key listed there is removed whichever layer set it. The result is
+// sorted by key.
+func MergeEnv(layers []EnvLayer, unset []string) []EnvVar {
+	merged := make(map[string]*EnvVar)
+	for _, layer := range layers {
+		for _, entry := range layer.Env {
+			key, value, ok := strings.Cut(entry, "=")
+			if key == "" {
+				continue
+			}
+			removed := false
+			if !ok {
+				value, ok = os.LookupEnv(key)
+				removed = !ok
+			}
+			v := merged[key]
+			if v == nil {
+				v = &EnvVar{Key: key}
+				merged[key] = v
+			} else if v.Source != layer.Source && !slices.Contains(v.Overrides, v.Source) {
+				v.Overrides = append(v.Overrides, v.Source)
+			}
+			v.Value, v.Source, v.Unset = value, layer.Source, removed
+		}
+	}
+	for _, key := range unset {
+		v := merged[key]
+		if v == nil {
+			v = &EnvVar{Key: key, Source: EnvSourceCLI}
+			merged[key] = v
+		} else if v.Source != EnvSourceCLI && !slices.Contains(v.Overrides, v.Source) {
+			v.Overrides = append(v.Overrides, v.Source)
+		}
+		v.Value, v.Source, v.Unset = "", EnvSourceCLI, true
+	}
+
+	out := make([]EnvVar, 0, len(merged))
+	for _, key := range slices.Sorted(maps.Keys(merged)) {
+		out = append(out, *merged[key])
+	}
+	return out
+}
+
+// EnvList renders merged vars as the container's Env: KEY=value, or a bare
+// KEY for an unset var.
+func EnvList(vars []EnvVar) []string {
+	env := make([]string, 0, len(vars))
+	for _, v := range vars {
+		if v.Unset {
+			env = append(env, v.Key)
+			continue
+		}
+		env = append(env, v.Key+"="+v.Value)
+	}
+	return env
+}
@@ -0,0 +1,49 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeEnv(t *testing.T) {
+	t.Setenv("HOST_ONLY", "from-host")
+
+	env := MergeEnv([]EnvLayer{
+		{Source: EnvSourceSettings, Env: []string{"EDITOR=nano", "TERM=xterm-256color", "GOFLAGS=-buildvcs=false"}},
+		{Source: EnvSourceProject, Env: []string{"EDITOR=vim", "HTTP_PROXY=http://proxy:3128"}},
+		{Source: EnvSourceSecret, Env: []string{"API_KEY=s3cret"}},
+		{Source: EnvSourceCLI, Env: []string{"EDITOR=emacs", "HOST_ONLY", "NOT_ON_HOST_XYZ", "A=b=c"}},
+	}, []string{"HTTP_PROXY", "IMAGE_ONLY"})
+
+	require.Equal(t, []EnvVar{
+		{Key: "A", Value: "b=c", Source: EnvSourceCLI},
+		{Key: "API_KEY", Value: "s3cret", Source: EnvSourceSecret},
+		{Key: "EDITOR", Value: "emacs", Source: EnvSourceCLI, Overrides: []EnvSource{EnvSourceSettings, EnvSourceProject}},
+		{Key: "GOFLAGS", Value: "-buildvcs=false", Source: EnvSourceSettings},
+		{Key: "HOST_ONLY", Value: "from-host", Source: EnvSourceCLI},
+		{Key: "HTTP_PROXY", Source: EnvSourceCLI, Overrides: []EnvSource{EnvSourceProject}, Unset: true},
+		{Key: "IMAGE_ONLY", Source: EnvSourceCLI, Unset: true},
+		{Key: "NOT_ON_HOST_XYZ", Source: EnvSourceCLI, Unset: true},
+		{Key: "TERM", Value: "xterm-256color", Source: EnvSourceSettings},
+	}, env)
+
+	require.Equal(t, []string{
+		"A=b=c",
+		"API_KEY=s3cret",
+		"EDITOR=emacs",
+		"GOFLAGS=-buildvcs=false",
+		"HOST_ONLY=from-host",
+		"HTTP_PROXY",
+		"IMAGE_ONLY",
+		"NOT_ON_HOST_XYZ",
+		"TERM=xterm-256color",
+	}, EnvList(env))
+}
+
+func TestMergeEnv_SameLayerLastWins(t *testing.T) {
+	env := MergeEnv([]EnvLayer{
+		{Source: EnvSourceCLI, Env: []string{"X=file", "X=flag"}},
+	}, nil)
+	require.Equal(t, []EnvVar{{Key: "X", Value: "flag", Source: EnvSourceCLI}}, env)
+}
@@ -0,0 +1,32 @@
+package shared
+
+import (
+	"testing"
+
+	gogit "github.com/go-git/go-git/v6"
+	gogitconfig "github.com/go-git/go-git/v6/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+func TestGitCredentialHostLabels(t *testing.T) {
+	log := logger.Nop()
+	assert.Empty(t, gitCredentialHostLabels(&workspaceSetup{wd: t.TempDir()}, log), "not a repository, no label")
+
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	require.NoError(t, err)
+	assert.Empty(t, gitCredentialHostLabels(&workspaceSetup{wd: dir}, log), "no remotes, no label")
+
+	_, err = repo.CreateRemote(&gogitconfig.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/me/repo.git"}})
+	require.NoError(t, err)
+	_, err = repo.CreateRemote(&gogitconfig.RemoteConfig{Name: "fork", URLs: []string{"git@gitlab.example.com:me/repo.git"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		consts.LabelGitCredentialHosts: "github.com,gitlab.example.com",
+	}, gitCredentialHostLabels(&workspaceSetup{wd: t.TempDir(), projectRootDir: dir}, log),
+		"a worktree's hosts come from the main repo")
+}
@@ -0,0 +1,224 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+	mobyClient "github.com/moby/moby/client"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// Host hook events, matching the keys of the project `hooks:` block.
+const (
+	HostHookPreCreate = "pre_create"
+	HostHookPostReady = "post_ready"
+	HostHookPreRemove = "pre_remove"
+)
+
+// HostHookContext is the JSON document a host hook receives on stdin.
+// ContainerID is empty for pre_create (the container does not exist yet).
+type HostHookContext struct {
+	Event         string            `json:"event"`
+	ContainerID   string            `json:"container_id,omitempty"`
+	ContainerName string            `json:"container_name"`
+	Image         string            `json:"image,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Ports         []HostHookPort    `json:"ports,omitempty"`
+}
+
+// HostHookPort is one port mapping in a HostHookContext. For pre_create the
+// host side is what was requested (HostPort may be empty for an ephemeral
+// port); for post_ready and pre_remove it is what Docker published.
+type HostHookPort struct {
+	ContainerPort string `json:"container_port"` // e.g. "8080/tcp"
+	HostIP        string `json:"host_ip,omitempty"`
+	HostPort      string `json:"host_port,omitempty"`
+}
+
+// HostHookCommand returns the configured command for event, or "" when the
+// project sets none. A hook declared in a project config file the user has
+// not approved (see config.HostCommands) is an error wrapping
+// config.ErrUntrustedHostCommand; it never runs.
+func HostHookCommand(cfg config.Config, event string) (string, error) {
+	if cfg == nil || cfg.Project() == nil {
+		return "", nil
+	}
+	hooks := cfg.Project().Hooks
+	var command string
+	switch event {
+	case HostHookPreCreate:
+		command = hooks.PreCreate
+	case HostHookPostReady:
+		command = hooks.PostReady
+	case HostHookPreRemove:
+		command = hooks.PreRemove
+	}
+	if command == "" {
+		return "", nil
+	}
+	if err := cfg.CheckHostCommand("hooks." + event); err != nil {
+		return "", fmt.Errorf("%s hook: %w", event, err)
+	}
+	return command, nil
+}
+
+// RunHostHook runs command on the host via /bin/sh -c with hc encoded as JSON
+// on stdin. The event, container name, and container ID are also exported as
+// CLAWKER_HOOK_EVENT, CLAWKER_CONTAINER_NAME, and CLAWKER_CONTAINER_ID for
+// one-liners that don't parse JSON. The hook's stdout and stderr go to out
+// (nil discards them). An empty command is a no-op. A non-zero exit is
+// returned as an error naming the event.
+func RunHostHook(ctx context.Context, out io.Writer, command string, hc HostHookContext) error {
+	if command == "" {
+		return nil
+	}
+	payload, err := json.Marshal(hc)
+	if err != nil {
+		return fmt.Errorf("%s hook: encoding context: %w", hc.Event, err)
+	}
+	if out == nil {
+		out = io.Discard
+	}
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.Env = append(os.Environ(),
+		"CLAWKER_HOOK_EVENT="+hc.Event,
+		"CLAWKER_CONTAINER_NAME="+hc.ContainerName,
+		"CLAWKER_CONTAINER_ID="+hc.ContainerID,
+	)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s hook failed: %w", hc.Event, err)
+	}
+	return nil
+}
+
+// HostHookContextFromInspect builds the hook context for an existing container
+// from its inspect response (post_ready: published ports are known).
+func HostHookContextFromInspect(event string, info container.InspectResponse) HostHookContext {
+	hc := HostHookContext{
+		Event:         event,
+		ContainerID:   info.ID,
+		ContainerName: trimContainerName(info.Name),
+	}
+	if info.Config != nil {
+		hc.Image = info.Config.Image
+		hc.Labels = info.Config.Labels
+	}
+	if info.NetworkSettings != nil {
+		hc.Ports = hostHookPortsFromMap(info.NetworkSettings.Ports)
+	}
+	return hc
+}
+
+// HostHookContextFromSummary builds the hook context for an existing container
+// from a container list entry (pre_remove).
+func HostHookContextFromSummary(event string, c container.Summary) HostHookContext {
+	hc := HostHookContext{
+		Event:       event,
+		ContainerID: c.ID,
+		Image:       c.Image,
+		Labels:      c.Labels,
+	}
+	if len(c.Names) > 0 {
+		hc.ContainerName = trimContainerName(c.Names[0])
+	}
+	for _, p := range c.Ports {
+		hp := HostHookPort{ContainerPort: strconv.Itoa(int(p.PrivatePort)) + "/" + p.Type}
+		if p.PublicPort != 0 {
+			hp.HostPort = strconv.Itoa(int(p.PublicPort))
+		}
+		if p.IP.IsValid() {
+			hp.HostIP = p.IP.String()
+		}
+		hc.Ports = append(hc.Ports, hp)
+	}
+	return hc
+}
+
+// hostHookPortsFromMap flattens a port map into hook ports, ordered by
+// container port for a stable document.
+func hostHookPortsFromMap(ports network.PortMap) []HostHookPort {
+	var out []HostHookPort
+	for port, bindings := range ports {
+		if len(bindings) == 0 {
+			out = append(out, HostHookPort{ContainerPort: port.String()})
+			continue
+		}
+		for _, b := range bindings {
+			hp := HostHookPort{ContainerPort: port.String(), HostPort: b.HostPort}
+			if b.HostIP.IsValid() {
+				hp.HostIP = b.HostIP.String()
+			}
+			out = append(out, hp)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].ContainerPort != out[j].ContainerPort {
+			return out[i].ContainerPort < out[j].ContainerPort
+		}
+		return out[i].HostIP < out[j].HostIP
+	})
+	return out
+}
+
+// trimContainerName drops the leading "/" Docker prefixes container names with.
+func trimContainerName(name string) string {
+	if len(name) > 0 && name[0] == '/' {
+		return name[1:]
+	}
+	return name
+}
+
+// runPostReadyHook runs the project's post_ready hook for a started container.
+// A failure never fails the start — the container is already running — so it
+// is logged and, when out is set, reported there. With out nil (attached
+// sessions, where the container owns the terminal) the hook runs in the
+// background and its output goes to the log only.
+func runPostReadyHook(ctx context.Context, client *docker.Client, containerID, command string, out io.Writer, log *logger.Logger) {
+	if command == "" {
+		return
+	}
+	run := func(w io.Writer) {
+		res, err := client.ContainerInspect(ctx, containerID, mobyClient.ContainerInspectOptions{})
+		if err != nil {
+			log.Warn().Err(err).Str("container", containerID).Msg("post_ready hook skipped: inspecting container failed")
+			if out != nil {
+				fmt.Fprintf(out, "post_ready hook skipped: inspecting %s: %v\n", containerID, err)
+			}
+			return
+		}
+		hc := HostHookContextFromInspect(HostHookPostReady, res.Container)
+		if err := RunHostHook(ctx, w, command, hc); err != nil {
+			log.Warn().Err(err).Str("container", containerID).Msg("post_ready hook failed")
+			if out != nil {
+				fmt.Fprintf(out, "Warning: %v\n", err)
+			}
+		}
+	}
+	if out != nil {
+		run(out)
+		return
+	}
+	go func() {
+		var buf bytes.Buffer
+		run(&buf)
+		if buf.Len() > 0 {
+			log.Info().Str("container", containerID).Str("output", buf.String()).Msg("post_ready hook output")
+		}
+	}()
+}
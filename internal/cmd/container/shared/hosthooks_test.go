@@ -0,0 +1,97 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+)
+
+func TestHostHookCommand(t *testing.T) {
+	cfg := configmocks.NewFromString("hooks:\n  pre_create: ./check.sh\n", "")
+
+	hook, err := HostHookCommand(cfg, HostHookPreCreate)
+	require.NoError(t, err)
+	assert.Equal(t, "./check.sh", hook)
+
+	hook, err = HostHookCommand(cfg, HostHookPreRemove)
+	require.NoError(t, err)
+	assert.Empty(t, hook)
+
+	cfg.CheckHostCommandFunc = func(key string) error {
+		return fmt.Errorf("%w: %s in /proj/.clawker.yaml", config.ErrUntrustedHostCommand, key)
+	}
+	hook, err = HostHookCommand(cfg, HostHookPreCreate)
+	require.ErrorIs(t, err, config.ErrUntrustedHostCommand)
+	assert.Contains(t, err.Error(), "pre_create hook")
+	assert.Empty(t, hook, "an unapproved hook is never returned")
+}
+
+func TestRunHostHook(t *testing.T) {
+	hc := HostHookContext{
+		Event:         HostHookPostReady,
+		ContainerID:   "abc123",
+		ContainerName: "clawker.myapp.dev",
+		Labels:        map[string]string{"dev.clawker.agent": "dev"},
+		Ports:         []HostHookPort{{ContainerPort: "8080/tcp", HostIP: "127.0.0.1", HostPort: "49153"}},
+	}
+
+	t.Run("context on stdin and in the environment", func(t *testing.T) {
+		var out bytes.Buffer
+		err := RunHostHook(context.Background(), &out, `cat; echo; echo "$CLAWKER_HOOK_EVENT $CLAWKER_CONTAINER_NAME $CLAWKER_CONTAINER_ID"`, hc)
+		require.NoError(t, err)
+
+		lines := bytes.SplitN(out.Bytes(), []byte("\n"), 2)
+		require.Len(t, lines, 2)
+		var got HostHookContext
+		require.NoError(t, json.Unmarshal(lines[0], &got))
+		assert.Equal(t, hc, got)
+		assert.Equal(t, "post_ready clawker.myapp.dev abc123\n", string(lines[1]))
+	})
+
+	t.Run("empty command is a no-op", func(t *testing.T) {
+		require.NoError(t, RunHostHook(context.Background(), nil, "", hc))
+	})
+
+	t.Run("non-zero exit names the event", func(t *testing.T) {
+		var out bytes.Buffer
+		err := RunHostHook(context.Background(), &out, "echo nope >&2; exit 2", hc)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "post_ready hook failed")
+		assert.Equal(t, "nope\n", out.String())
+	})
+}
+
+func TestHostHookContextFromInspect(t *testing.T) {
+	info := container.InspectResponse{
+		ID:     "abc123",
+		Name:   "/clawker.myapp.dev",
+		Config: &container.Config{Image: "clawker-myapp:latest", Labels: map[string]string{"a": "b"}},
+		NetworkSettings: &container.NetworkSettings{
+			Ports: network.PortMap{
+				network.MustParsePort("9000/tcp"): nil,
+				network.MustParsePort("8080/tcp"): {
+					{HostIP: netip.MustParseAddr("127.0.0.1"), HostPort: "49153"},
+				},
+			},
+		},
+	}
+
+	hc := HostHookContextFromInspect(HostHookPostReady, info)
+	assert.Equal(t, "clawker.myapp.dev", hc.ContainerName)
+	assert.Equal(t, "clawker-myapp:latest", hc.Image)
+	assert.Equal(t, []HostHookPort{
+		{ContainerPort: "8080/tcp", HostIP: "127.0.0.1", HostPort: "49153"},
+		{ContainerPort: "9000/tcp"},
+	}, hc.Ports)
+}
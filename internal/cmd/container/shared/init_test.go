@@ -143,6 +143,36 @@ func TestCreateContainer_HappyPath(t *testing.T) {
 	fake.AssertCalled(t, "ContainerCreate")
 }
 
+func TestCreateContainer_HostProxyCallerToken(t *testing.T) {
+	setupAuthEnv(t)
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupCopyToContainer()
+	var created moby.ContainerCreateOptions
+	fake.FakeAPI.ContainerCreateFn = func(_ context.Context, opts moby.ContainerCreateOptions) (moby.ContainerCreateResult, error) {
+		created = opts
+		return moby.ContainerCreateResult{ID: mocks.FakeContainerID}, nil
+	}
+
+	cmd := testFlags()
+	containerOpts := NewContainerOptions()
+	containerOpts.Image = "alpine"
+	containerOpts.Agent = "test-agent"
+	project, err := config.NewFromString("security:\n  enable_host_proxy: true\n", "")
+	require.NoError(t, err)
+	_, err = CreateContainer(context.Background(), testCreateConfig(fake, project.Project(), containerOpts, cmd))
+	require.NoError(t, err)
+
+	var token string
+	for _, e := range created.Config.Env {
+		if v, ok := strings.CutPrefix(e, consts.EnvHostProxyToken+"="); ok {
+			token = v
+		}
+	}
+	require.NotEmpty(t, token, "the container gets a host proxy caller token")
+	require.Equal(t, hostproxy.CallerTokenDigest(token), created.Config.Labels[consts.LabelHostProxyToken],
+		"the label carries the token's digest, not the token")
+}
+
 // snapshotModeProject builds a *config.Project whose workspace.default_mode is
 // snapshot, for exercising the config-default branch of the worktree guard.
 func snapshotModeProject(t *testing.T) *config.Project {
@@ -0,0 +1,125 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/moby/moby/api/types/mount"
+)
+
+// userMount is one -v, --mount or --tmpfs request, reduced to what mount
+// validation needs.
+type userMount struct {
+	spec   string // flag and value as given, e.g. "-v ./data:/data"
+	target string // cleaned container path
+	source string // host path for bind mounts; empty for volumes and tmpfs
+}
+
+// userMounts collects the mounts requested on the command line.
+func (opts *ContainerCreateOptions) userMounts() []userMount {
+	var out []userMount
+	for _, v := range opts.Volumes {
+		um := userMount{spec: "-v " + v}
+		parts := strings.Split(resolveVolumePath(v), ":")
+		if len(parts) == 1 {
+			// Anonymous volume: the value is the container path.
+			um.target = parts[0]
+		} else {
+			um.target = parts[1]
+			// Anything that is not an absolute path is a named volume.
+			if filepath.IsAbs(parts[0]) {
+				um.source = parts[0]
+			}
+		}
+		out = append(out, um)
+	}
+	if opts.Mounts != nil {
+		for _, m := range opts.Mounts.GetAll() {
+			um := userMount{spec: fmt.Sprintf("--mount type=%s,target=%s", m.Type, m.Target), target: m.Target}
+			if m.Type == mount.TypeBind {
+				um.source = m.Source
+			}
+			out = append(out, um)
+		}
+	}
+	for _, t := range opts.Tmpfs {
+		target, _, _ := strings.Cut(t, ":")
+		out = append(out, userMount{spec: "--tmpfs " + t, target: target})
+	}
+	for i := range out {
+		if out[i].target != "" {
+			out[i].target = path.Clean(out[i].target)
+		}
+	}
+	return out
+}
+
+// validateMounts checks the command-line mounts against the mounts clawker
+// sets up (workspace, harness volumes, credentials) and against each other,
+// so layout mistakes surface as one readable error instead of a daemon
+// failure at create. Reported: a mount whose target is a clawker-managed
+// mount target (it would shadow the workspace or harness state), two mounts
+// with the same target, and bind sources that don't exist on the host —
+// unless createHostDirs is set, in which case the caller creates them.
+// All problems are returned together.
+func validateMounts(managed []mount.Mount, user []userMount, createHostDirs bool) error {
+	managedByTarget := make(map[string]mount.Mount, len(managed))
+	for _, m := range managed {
+		managedByTarget[path.Clean(m.Target)] = m
+	}
+	seen := make(map[string]string, len(user))
+
+	var errs []error
+	for _, um := range user {
+		if um.target == "" || !path.IsAbs(um.target) {
+			errs = append(errs, fmt.Errorf("%s: container path must be absolute", um.spec))
+			continue
+		}
+		if m, ok := managedByTarget[um.target]; ok {
+			errs = append(errs, fmt.Errorf("%s: %s is already mounted by clawker (%s); pick another container path", um.spec, um.target, describeMount(m)))
+		} else if prev, ok := seen[um.target]; ok {
+			errs = append(errs, fmt.Errorf("%s: %s is already the target of %s", um.spec, um.target, prev))
+		}
+		seen[um.target] = um.spec
+
+		if um.source != "" && !createHostDirs {
+			if _, err := os.Stat(um.source); errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("%s: host path %s does not exist (create it, or pass --create-host-dirs)", um.spec, um.source))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// createHostDirs creates the missing bind-mount sources of the given mounts
+// as directories (--create-host-dirs).
+func createHostDirs(user []userMount) error {
+	for _, um := range user {
+		if um.source == "" {
+			continue
+		}
+		if _, err := os.Stat(um.source); !errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err := os.MkdirAll(um.source, 0o755); err != nil {
+			return fmt.Errorf("creating host directory for %s: %w", um.spec, err)
+		}
+	}
+	return nil
+}
+
+// describeMount names a clawker-managed mount for error messages.
+func describeMount(m mount.Mount) string {
+	switch m.Type {
+	case mount.TypeVolume:
+		return "volume " + m.Source
+	case mount.TypeBind:
+		return "bind of " + m.Source
+	default:
+		return string(m.Type)
+	}
+}
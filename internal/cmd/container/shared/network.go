@@ -1,6 +1,7 @@
 package shared
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/netip"
@@ -8,6 +9,10 @@ import (
 
 	"github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/network"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
 )
 
 // NetworkAttachmentOpts holds options for a single network attachment.
@@ -331,3 +336,86 @@ func isEndpointSettingsZero(ep *network.EndpointSettings) bool {
 		ep.IPAMConfig == nil &&
 		len(ep.MacAddress) == 0
 }
+
+// projectNetworkGwPriority ranks the project network below the clawker
+// network for the container's default route. Egress must leave through
+// the clawker network, where the firewall's gateway lockdown applies.
+const projectNetworkGwPriority = -1
+
+// ProjectNetwork returns the isolated network a project's agents and
+// sidecars share, or "" when they use the clawker network alone: a
+// global-scope agent (no project), or a project with network.shared set.
+func ProjectNetwork(cfg *config.Project, project string) (string, error) {
+	if project == "" || (cfg != nil && cfg.Network.Shared) {
+		return "", nil
+	}
+	return docker.ProjectNetworkName(project)
+}
+
+// addProjectNetwork ensures the project network and adds an endpoint for
+// it to a create's networking config. The clawker network itself comes
+// from ContainerCreateOptions.EnsureNetwork; the agent stays on both,
+// since the control plane and firewall live on the clawker network.
+func addProjectNetwork(ctx context.Context, client *docker.Client, cfg *config.Project, project string, hc *container.HostConfig, nc *network.NetworkingConfig) error {
+	// --network host/none/container:<id> shares or drops networking
+	// altogether; no second network can be attached.
+	if mode := hc.NetworkMode; mode.IsHost() || mode.IsNone() || mode.IsContainer() {
+		return nil
+	}
+	name, err := ProjectNetwork(cfg, project)
+	if err != nil || name == "" {
+		return err
+	}
+	id, err := client.EnsureProjectNetwork(ctx, name, project)
+	if err != nil {
+		return fmt.Errorf("ensuring project network: %w", err)
+	}
+	if nc.EndpointsConfig == nil {
+		nc.EndpointsConfig = make(map[string]*network.EndpointSettings)
+	}
+	if _, ok := nc.EndpointsConfig[name]; !ok {
+		nc.EndpointsConfig[name] = &network.EndpointSettings{
+			NetworkID:  id,
+			GwPriority: projectNetworkGwPriority,
+		}
+	}
+	return nil
+}
+
+// connectProjectNetwork attaches an existing agent container to its
+// project network when it is not on it yet — a container created before
+// per-project networks, or before network.shared was turned off.
+// Containers without a project label are global-scope and left alone.
+func connectProjectNetwork(ctx context.Context, client *docker.Client, cfg *config.Project, containerID string) error {
+	info, err := client.ContainerInspect(ctx, containerID, docker.ContainerInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("inspecting container: %w", err)
+	}
+	c := info.Container
+	if c.Config == nil {
+		return nil
+	}
+	if hc := c.HostConfig; hc != nil && (hc.NetworkMode.IsHost() || hc.NetworkMode.IsNone() || hc.NetworkMode.IsContainer()) {
+		return nil
+	}
+	name, err := ProjectNetwork(cfg, c.Config.Labels[consts.LabelProject])
+	if err != nil || name == "" {
+		return err
+	}
+	if c.NetworkSettings != nil {
+		if _, ok := c.NetworkSettings.Networks[name]; ok {
+			return nil
+		}
+	}
+	id, err := client.EnsureProjectNetwork(ctx, name, c.Config.Labels[consts.LabelProject])
+	if err != nil {
+		return fmt.Errorf("ensuring project network: %w", err)
+	}
+	if _, err := client.NetworkConnect(ctx, name, containerID, &network.EndpointSettings{
+		NetworkID:  id,
+		GwPriority: projectNetworkGwPriority,
+	}); err != nil {
+		return fmt.Errorf("connecting to project network: %w", err)
+	}
+	return nil
+}
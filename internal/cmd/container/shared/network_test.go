@@ -0,0 +1,102 @@
+package shared
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+	mobyClient "github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+)
+
+func TestProjectNetwork(t *testing.T) {
+	name, err := ProjectNetwork(&config.Project{}, "myapp")
+	require.NoError(t, err)
+	assert.Equal(t, "clawker-myapp", name)
+
+	name, err = ProjectNetwork(&config.Project{}, "")
+	require.NoError(t, err)
+	assert.Empty(t, name, "global scope uses the clawker network")
+
+	name, err = ProjectNetwork(&config.Project{Network: config.ProjectNetworkConfig{Shared: true}}, "myapp")
+	require.NoError(t, err)
+	assert.Empty(t, name, "network.shared opts out")
+}
+
+func TestAddProjectNetwork(t *testing.T) {
+	t.Run("adds a low-priority endpoint", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fake.SetupNetworkExists("clawker-myapp", true)
+		nc := &network.NetworkingConfig{EndpointsConfig: map[string]*network.EndpointSettings{consts.Network: {}}}
+
+		err := addProjectNetwork(context.Background(), fake.Client, &config.Project{}, "myapp", &container.HostConfig{}, nc)
+		require.NoError(t, err)
+
+		require.Contains(t, nc.EndpointsConfig, "clawker-myapp")
+		assert.Contains(t, nc.EndpointsConfig, consts.Network)
+		ep := nc.EndpointsConfig["clawker-myapp"]
+		assert.Equal(t, "net-clawker-myapp", ep.NetworkID)
+		assert.Equal(t, projectNetworkGwPriority, ep.GwPriority)
+	})
+
+	t.Run("host network mode is left alone", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		nc := &network.NetworkingConfig{}
+
+		err := addProjectNetwork(context.Background(), fake.Client, &config.Project{}, "myapp",
+			&container.HostConfig{NetworkMode: "host"}, nc)
+		require.NoError(t, err)
+		assert.Empty(t, nc.EndpointsConfig)
+	})
+}
+
+func TestConnectProjectNetwork(t *testing.T) {
+	inspect := func(fake *mocks.FakeClient, networks ...string) {
+		fake.FakeAPI.ContainerInspectFn = func(_ context.Context, id string, _ mobyClient.ContainerInspectOptions) (mobyClient.ContainerInspectResult, error) {
+			eps := make(map[string]*network.EndpointSettings, len(networks))
+			for _, n := range networks {
+				eps[n] = &network.EndpointSettings{}
+			}
+			return mobyClient.ContainerInspectResult{
+				Container: container.InspectResponse{
+					ID: id,
+					Config: &container.Config{Labels: map[string]string{
+						fake.Cfg.LabelManaged(): fake.Cfg.ManagedLabelValue(),
+						consts.LabelProject:     "myapp",
+					}},
+					HostConfig:      &container.HostConfig{},
+					NetworkSettings: &container.NetworkSettings{Networks: eps},
+				},
+			}, nil
+		}
+	}
+
+	t.Run("connects a container missing its project network", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		inspect(fake, consts.Network)
+		fake.SetupNetworkExists("clawker-myapp", true)
+		var connected string
+		fake.FakeAPI.NetworkConnectFn = func(_ context.Context, name string, _ mobyClient.NetworkConnectOptions) (mobyClient.NetworkConnectResult, error) {
+			connected = name
+			return mobyClient.NetworkConnectResult{}, nil
+		}
+
+		require.NoError(t, connectProjectNetwork(context.Background(), fake.Client, &config.Project{}, mocks.FakeContainerID))
+		assert.Equal(t, "clawker-myapp", connected)
+	})
+
+	t.Run("already attached is a no-op", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		inspect(fake, consts.Network, "clawker-myapp")
+
+		require.NoError(t, connectProjectNetwork(context.Background(), fake.Client, &config.Project{}, mocks.FakeContainerID))
+		fake.AssertNotCalled(t, "NetworkConnect")
+	})
+}
@@ -0,0 +1,24 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+func TestOpenURLLabels(t *testing.T) {
+	assert.Empty(t, openURLLabels(nil))
+	assert.Empty(t, openURLLabels(&config.Project{}), "no allowlist, no labels")
+
+	cfg := &config.Project{Security: config.SecurityConfig{OpenURL: config.OpenURLConfig{
+		Schemes: []string{"https", "vscode"},
+		Hosts:   []string{"github.com", "*.example.com"},
+	}}}
+	assert.Equal(t, map[string]string{
+		consts.LabelOpenURLSchemes: "https,vscode",
+		consts.LabelOpenURLHosts:   "github.com,*.example.com",
+	}, openURLLabels(cfg))
+}
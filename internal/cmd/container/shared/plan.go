@@ -0,0 +1,113 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+
+	"github.com/schmitthub/clawker/internal/bundler"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+)
+
+// planRedacted stands in for secret values in a ContainerPlan.
+const planRedacted = "<redacted>"
+
+// ErrPlanWorktree is returned by PlanContainer for --worktree: resolving the
+// workspace would create the git worktree.
+var ErrPlanWorktree = errors.New("--plan cannot be combined with --worktree: resolving the workspace would create the worktree")
+
+// ContainerPlan is the create request CreateContainer would send the daemon,
+// resolved without creating anything.
+type ContainerPlan struct {
+	Name    string `json:"name"`
+	Agent   string `json:"agent"`
+	Project string `json:"project,omitempty"`
+	Harness string `json:"harness"`
+	// Networks are the networks the container joins at create: the clawker
+	// network and, unless network.shared, the project network.
+	Networks   []string                  `json:"networks,omitempty"`
+	Config     *container.Config         `json:"config"`
+	HostConfig *container.HostConfig     `json:"host_config"`
+	Networking *network.NetworkingConfig `json:"networking_config,omitempty"`
+	// Env is Config.Env with the layer each var came from; see MergeEnv.
+	Env []EnvVar `json:"-"`
+}
+
+// PlanContainer runs CreateContainer's resolution steps — harness identity,
+// workspace mounts, env, BuildConfigs, secrets, read-only root, labels —
+// and returns the configs it would create the container with. No volume,
+// network, worktree or container is created, no secret provider runs (env
+// secrets appear as KEY=<redacted>), and no host hook runs. Without an
+// agent name a random one is generated, as CreateContainer would.
+func PlanContainer(ctx context.Context, opts *CreateContainerOptions) (*ContainerPlan, error) {
+	containerOpts := opts.Options
+	if containerOpts.Worktree != "" {
+		return nil, ErrPlanWorktree
+	}
+	opts.plan = true
+
+	agentName := containerOpts.GetAgentName()
+	if agentName == "" {
+		agentName = docker.GenerateRandomName()
+	}
+	containerName, err := docker.ContainerName(opts.ProjectName, agentName)
+	if err != nil {
+		return nil, err
+	}
+
+	harnessName, err := harnessForImage(ctx, opts.Client, opts.Config, containerOpts.Image, opts.Log)
+	if err != nil {
+		return nil, fmt.Errorf("resolving container harness identity: %w", err)
+	}
+	opts.harnessBundle, err = bundler.LoadHarness(opts.Config, harnessName)
+	if err != nil {
+		return nil, fmt.Errorf("loading harness %q: %w", harnessName, err)
+	}
+
+	ws, err := prepareWorkspace(ctx, opts, agentName)
+	if err != nil {
+		return nil, err
+	}
+
+	hostProxyURL := setupHostProxy(opts.Config.Project(), opts.Client, opts.HostProxy, opts.Log)
+	cfgs, err := buildContainerConfigs(ctx, opts, agentName, ws, hostProxyURL)
+	if err != nil {
+		return nil, err
+	}
+	// Plan mode creates no volumes, so there is nothing to reclaim.
+	if err = provisionReadOnlyRoot(ctx, opts, agentName, cfgs, &createScope{client: opts.Client, log: opts.Log}); err != nil {
+		return nil, err
+	}
+
+	labels := opts.Client.ContainerLabels(opts.ProjectName, agentName, opts.Version, containerOpts.Image, ws.wd)
+	labels[consts.LabelHarness] = opts.harnessBundle.Name
+	cfgs.container.Labels = MergeLabels(labels, cfgs.container.Labels)
+
+	var networks []string
+	if mode := cfgs.host.NetworkMode; !mode.IsHost() && !mode.IsNone() && !mode.IsContainer() {
+		networks = append(networks, opts.Config.ClawkerNetwork())
+		projectNetwork, err := ProjectNetwork(opts.Config.Project(), opts.ProjectName)
+		if err != nil {
+			return nil, err
+		}
+		if projectNetwork != "" {
+			networks = append(networks, projectNetwork)
+		}
+	}
+
+	return &ContainerPlan{
+		Name:       containerName,
+		Agent:      agentName,
+		Project:    opts.ProjectName,
+		Harness:    opts.harnessBundle.Name,
+		Networks:   networks,
+		Config:     cfgs.container,
+		HostConfig: cfgs.host,
+		Networking: cfgs.network,
+		Env:        cfgs.env,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package shared
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+)
+
+func TestPlanContainer_CreatesNothing(t *testing.T) {
+	setupAuthEnv(t)
+	t.Setenv("HOST_API_KEY", "sk-live-secret")
+	cfg, err := config.NewFromString(`
+secrets:
+  api: { provider: env, ref: HOST_API_KEY, env: API_KEY }
+  cert: { provider: env, ref: HOST_API_KEY, file: cert.pem }
+`, "")
+	require.NoError(t, err)
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+
+	containerOpts := NewContainerOptions()
+	containerOpts.Image = "alpine"
+	containerOpts.Agent = "dev"
+	containerOpts.Env = []string{"DEBUG=1"}
+
+	plan, err := PlanContainer(context.Background(),
+		testCreateConfig(fake, cfg.Project(), containerOpts, testFlags()))
+	require.NoError(t, err)
+
+	require.Equal(t, "clawker.testproject.dev", plan.Name)
+	require.Equal(t, "dev", plan.Agent)
+	require.Contains(t, plan.Config.Env, "DEBUG=1")
+	require.Contains(t, plan.Config.Env, "API_KEY=<redacted>")
+	require.NotContains(t, plan.Config.Env, "API_KEY=sk-live-secret")
+	require.Contains(t, plan.HostConfig.Tmpfs, consts.SecretsDir)
+	require.Equal(t, "testproject", plan.Config.Labels[fake.Cfg.LabelProject()])
+	require.Equal(t, []string{fake.Cfg.ClawkerNetwork(), "clawker-testproject"}, plan.Networks)
+	require.NotEmpty(t, plan.HostConfig.Mounts)
+
+	fake.AssertNotCalled(t, "ContainerCreate")
+	fake.AssertNotCalled(t, "VolumeCreate")
+	fake.AssertNotCalled(t, "NetworkCreate")
+}
+
+func TestPlanContainer_EnvPrecedence(t *testing.T) {
+	setupAuthEnv(t)
+	cfg, err := config.NewFromString(`
+agent:
+  env: { EDITOR: vim, LOG_LEVEL: info, HTTP_PROXY: "http://proxy:3128" }
+`, "")
+	require.NoError(t, err)
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+
+	containerOpts := NewContainerOptions()
+	containerOpts.Image = "alpine"
+	containerOpts.Env = []string{"LOG_LEVEL=debug"}
+	containerOpts.EnvUnset = []string{"HTTP_PROXY"}
+
+	plan, err := PlanContainer(context.Background(),
+		testCreateConfig(fake, cfg.Project(), containerOpts, testFlags()))
+	require.NoError(t, err)
+
+	require.Contains(t, plan.Config.Env, "LOG_LEVEL=debug")
+	require.NotContains(t, plan.Config.Env, "LOG_LEVEL=info")
+	require.Contains(t, plan.Config.Env, "EDITOR=vim")
+	require.Contains(t, plan.Config.Env, "HTTP_PROXY")
+	require.NotContains(t, plan.Config.Env, "HTTP_PROXY=http://proxy:3128")
+
+	sources := make(map[string]EnvVar)
+	for _, v := range plan.Env {
+		sources[v.Key] = v
+	}
+	require.Equal(t, EnvSourceCLI, sources["LOG_LEVEL"].Source)
+	require.Equal(t, []EnvSource{EnvSourceProject}, sources["LOG_LEVEL"].Overrides)
+	require.Equal(t, EnvSourceProject, sources["EDITOR"].Source)
+	require.Equal(t, []EnvSource{EnvSourceSettings}, sources["EDITOR"].Overrides)
+	require.Equal(t, EnvSourceSettings, sources["CLAWKER_AGENT"].Source)
+	// The caller's -e list is not mutated with the resolved env.
+	require.Equal(t, []string{"LOG_LEVEL=debug"}, containerOpts.Env)
+}
+
+func TestPlanContainer_RejectsWorktree(t *testing.T) {
+	setupAuthEnv(t)
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+
+	containerOpts := NewContainerOptions()
+	containerOpts.Image = "alpine"
+	containerOpts.Worktree = "feature/x"
+
+	_, err := PlanContainer(context.Background(),
+		testCreateConfig(fake, testConfig(), containerOpts, testFlags()))
+	require.ErrorIs(t, err, ErrPlanWorktree)
+}
@@ -0,0 +1,173 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/moby/moby/api/types/network"
+	"github.com/schmitthub/clawker/internal/docker"
+)
+
+// Port readiness tuning. Package vars so tests can shorten them.
+var (
+	// portPollInterval is the pause between readiness probes.
+	portPollInterval = 250 * time.Millisecond
+	// portDialTimeout bounds a single connection attempt.
+	portDialTimeout = time.Second
+	// portSettleTimeout is how long an accepted connection must stay open
+	// before the port counts as ready (see probePort).
+	portSettleTimeout = 200 * time.Millisecond
+)
+
+// PortMapping is one published port: a container port bound to a host
+// address. It is the shape exposed to --json and --format templates.
+type PortMapping struct {
+	ContainerPort string `json:"containerPort"`
+	HostIP        string `json:"hostIp"`
+	HostPort      string `json:"hostPort"`
+}
+
+// HostAddress renders the mapping's host side as "ip:port".
+func (m PortMapping) HostAddress() string {
+	return net.JoinHostPort(m.HostIP, m.HostPort)
+}
+
+// PortMappings flattens a container's port map into published mappings
+// ordered by port number, protocol and host IP. Exposed ports without a host
+// binding are omitted.
+func PortMappings(ports network.PortMap) []PortMapping {
+	keys := make([]network.Port, 0, len(ports))
+	for p := range ports {
+		keys = append(keys, p)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Num() != keys[j].Num() {
+			return keys[i].Num() < keys[j].Num()
+		}
+		return keys[i].Proto() < keys[j].Proto()
+	})
+
+	var out []PortMapping
+	for _, p := range keys {
+		bindings := append([]network.PortBinding(nil), ports[p]...)
+		sort.SliceStable(bindings, func(i, j int) bool {
+			return bindings[i].HostIP.Less(bindings[j].HostIP)
+		})
+		for _, b := range bindings {
+			hostIP := "0.0.0.0"
+			if b.HostIP.IsValid() {
+				hostIP = b.HostIP.String()
+			}
+			out = append(out, PortMapping{
+				ContainerPort: p.String(),
+				HostIP:        hostIP,
+				HostPort:      b.HostPort,
+			})
+		}
+	}
+	return out
+}
+
+// ParseContainerPort parses a PORT[/PROTO] reference; the protocol
+// defaults to tcp.
+func ParseContainerPort(s string) (network.Port, error) {
+	p, err := network.ParsePort(s)
+	if err != nil {
+		return network.Port{}, fmt.Errorf("invalid port %q: %w", s, err)
+	}
+	return p, nil
+}
+
+// WaitForPort blocks until the host side of the container's published port
+// accepts connections, the container stops, or timeout elapses. Only tcp
+// ports can be probed. A port that is not published to the host fails
+// immediately since no amount of waiting will make it reachable.
+func WaitForPort(ctx context.Context, client *docker.Client, containerID string, port network.Port, timeout time.Duration) error {
+	if port.Proto() != network.TCP {
+		return fmt.Errorf("cannot wait for %s: only tcp ports can be probed", port)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		inspect, err := client.ContainerInspect(ctx, containerID, docker.ContainerInspectOptions{})
+		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return portTimeoutError(port, timeout)
+			}
+			return fmt.Errorf("inspecting container: %w", err)
+		}
+		c := inspect.Container
+		if c.State == nil || !c.State.Running {
+			return fmt.Errorf("container exited before port %s accepted connections", port)
+		}
+
+		var bindings []network.PortBinding
+		if c.NetworkSettings != nil {
+			bindings = c.NetworkSettings.Ports[port]
+		}
+		if len(bindings) == 0 {
+			return fmt.Errorf("port %s is not published to the host; publish it with --publish", port)
+		}
+
+		for _, b := range bindings {
+			if probePort(ctx, dialAddress(b)) {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return portTimeoutError(port, timeout)
+			}
+			return ctx.Err()
+		case <-time.After(portPollInterval):
+		}
+	}
+}
+
+func portTimeoutError(port network.Port, timeout time.Duration) error {
+	return fmt.Errorf("port %s did not accept connections within %s; the container is still running", port, timeout)
+}
+
+// dialAddress returns the host address to probe for a binding, replacing a
+// wildcard host IP with the matching loopback address.
+func dialAddress(b network.PortBinding) string {
+	ip := b.HostIP
+	switch {
+	case !ip.IsValid() || ip == netip.IPv4Unspecified():
+		ip = netip.AddrFrom4([4]byte{127, 0, 0, 1})
+	case ip == netip.IPv6Unspecified():
+		ip = netip.IPv6Loopback()
+	}
+	return net.JoinHostPort(ip.String(), b.HostPort)
+}
+
+// probePort reports whether addr accepts a connection that stays open.
+// Docker's userland proxy accepts on the host port even while nothing in the
+// container is listening and then drops the connection, so a connection
+// closed within portSettleTimeout does not count. Services that send a banner
+// or wait for the client both pass.
+func probePort(ctx context.Context, addr string) bool {
+	dialer := net.Dialer{Timeout: portDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	if err := conn.SetReadDeadline(time.Now().Add(portSettleTimeout)); err != nil {
+		return false
+	}
+	buf := make([]byte, 1)
+	n, err := conn.Read(buf)
+	return n > 0 || errors.Is(err, os.ErrDeadlineExceeded)
+}
@@ -0,0 +1,149 @@
+package shared
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/api/types/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+)
+
+func TestPortMappings(t *testing.T) {
+	ports := network.PortMap{
+		network.MustParsePort("8080/tcp"): {
+			{HostIP: netip.MustParseAddr("::"), HostPort: "32769"},
+			{HostIP: netip.MustParseAddr("0.0.0.0"), HostPort: "32768"},
+		},
+		network.MustParsePort("53/udp"):   {{HostPort: "5353"}},
+		network.MustParsePort("53/tcp"):   {{HostIP: netip.MustParseAddr("127.0.0.1"), HostPort: "5300"}},
+		network.MustParsePort("9000/tcp"): nil, // exposed, not published
+	}
+
+	assert.Equal(t, []PortMapping{
+		{ContainerPort: "53/tcp", HostIP: "127.0.0.1", HostPort: "5300"},
+		{ContainerPort: "53/udp", HostIP: "0.0.0.0", HostPort: "5353"},
+		{ContainerPort: "8080/tcp", HostIP: "0.0.0.0", HostPort: "32768"},
+		{ContainerPort: "8080/tcp", HostIP: "::", HostPort: "32769"},
+	}, PortMappings(ports))
+	assert.Equal(t, "[::]:32769", PortMapping{HostIP: "::", HostPort: "32769"}.HostAddress())
+}
+
+func TestParseContainerPort(t *testing.T) {
+	p, err := ParseContainerPort("3000")
+	require.NoError(t, err)
+	assert.Equal(t, "3000/tcp", p.String())
+
+	p, err = ParseContainerPort("5353/UDP")
+	require.NoError(t, err)
+	assert.Equal(t, "5353/udp", p.String())
+
+	_, err = ParseContainerPort("http")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid port "http"`)
+}
+
+// shortenPortTimings makes readiness probing fast for the test.
+func shortenPortTimings(t *testing.T) {
+	t.Helper()
+	interval, dial, settle := portPollInterval, portDialTimeout, portSettleTimeout
+	portPollInterval, portDialTimeout, portSettleTimeout = 10*time.Millisecond, 100*time.Millisecond, 50*time.Millisecond
+	t.Cleanup(func() {
+		portPollInterval, portDialTimeout, portSettleTimeout = interval, dial, settle
+	})
+}
+
+// listenLoopback starts a tcp listener on an ephemeral loopback port. When
+// hold is true accepted connections stay open like a real service; otherwise
+// they are closed at once, like Docker's userland proxy with no backend.
+func listenLoopback(t *testing.T, hold bool) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		var held []net.Conn
+		defer func() {
+			for _, c := range held {
+				c.Close()
+			}
+		}()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			if hold {
+				held = append(held, conn)
+			} else {
+				conn.Close()
+			}
+		}
+	}()
+	return strconv.Itoa(ln.Addr().(*net.TCPAddr).Port)
+}
+
+func TestWaitForPort(t *testing.T) {
+	shortenPortTimings(t)
+	port := network.MustParsePort("3000/tcp")
+	running := mocks.RunningContainerFixture("myapp", "web")
+	name := "clawker.myapp.web"
+
+	bind := func(hostPort string) network.PortMap {
+		// Wildcard host IP: the probe must dial loopback instead.
+		return network.PortMap{port: {{HostIP: netip.IPv4Unspecified(), HostPort: hostPort}}}
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fake.SetupContainerPorts(name, running, bind(listenLoopback(t, true)))
+
+		err := WaitForPort(context.Background(), fake.Client, running.ID, port, 2*time.Second)
+		require.NoError(t, err)
+	})
+
+	t.Run("connection dropped at once times out", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fake.SetupContainerPorts(name, running, bind(listenLoopback(t, false)))
+
+		err := WaitForPort(context.Background(), fake.Client, running.ID, port, 300*time.Millisecond)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "did not accept connections within 300ms")
+	})
+
+	t.Run("not published", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fake.SetupContainerPorts(name, running, nil)
+
+		err := WaitForPort(context.Background(), fake.Client, running.ID, port, time.Minute)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "port 3000/tcp is not published to the host")
+	})
+
+	t.Run("container exited", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		exited := mocks.ContainerFixture("myapp", "web", "node:20")
+		fake.SetupContainerPorts(name, exited, bind("1"))
+
+		err := WaitForPort(context.Background(), fake.Client, exited.ID, port, time.Minute)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "container exited before port 3000/tcp accepted connections")
+	})
+
+	t.Run("udp rejected", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+
+		err := WaitForPort(context.Background(), fake.Client, running.ID, network.MustParsePort("53/udp"), time.Minute)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only tcp ports can be probed")
+		fake.AssertNotCalled(t, "ContainerInspect")
+	})
+}
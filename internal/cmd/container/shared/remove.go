@@ -0,0 +1,141 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moby/moby/api/types/container"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/controlplane/agent"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/socketbridge"
+)
+
+// RemoveContainerOptions holds the dependencies and flags for RemoveContainer.
+type RemoveContainerOptions struct {
+	IOStreams    *iostreams.IOStreams
+	AdminClient  func(context.Context) (adminv1.AdminServiceClient, error)
+	SocketBridge func() socketbridge.SocketBridgeManager
+	Log          *logger.Logger
+
+	// PreRemoveHook is the trusted hooks.pre_remove command (see
+	// HostHookCommand); empty runs no hook.
+	PreRemoveHook string
+
+	Force   bool
+	Volumes bool
+}
+
+// RemoveContainer tears down one clawker container: runs the pre_remove
+// hook (a failure leaves the container in place), disables its firewall
+// enforcement, stops its socket bridge, removes it (with its volumes when
+// opts.Volumes is set), removes an agent's sidecars, and evicts its agent
+// registry row. Everything after the hook except the removal itself is
+// best-effort. name labels the container in warnings. Every command that
+// removes clawker containers goes through here so none leaks BPF state,
+// bridges, or registry rows.
+//
+// With a dry-run client (global --dry-run) only the Docker calls happen,
+// journaled; the hook, firewall, bridge and registry steps are skipped.
+func RemoveContainer(ctx context.Context, client *docker.Client, name string, c container.Summary, opts RemoveContainerOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+	log := opts.Log
+	if log == nil {
+		log = logger.Nop()
+	}
+
+	if client.DryRun() {
+		if opts.PreRemoveHook != "" {
+			fmt.Fprintf(ios.ErrOut, "%s %s: would run the pre_remove hook\n", cs.InfoIcon(), name)
+		}
+		return removeDockerResources(ctx, client, name, c, opts)
+	}
+
+	// The project's pre_remove host hook gets the last look at the container;
+	// a failing hook leaves it in place.
+	hc := HostHookContextFromSummary(HostHookPreRemove, c)
+	if err := RunHostHook(ctx, ios.ErrOut, opts.PreRemoveHook, hc); err != nil {
+		return err
+	}
+
+	// Disable firewall enforcement for this container (best-effort). Runs
+	// before container removal so the CP can resolve the cgroup via Docker.
+	// If it fails we still proceed, but surface a user-visible warning
+	// because orphaned BPF state leaks until the next firewall restart.
+	if opts.AdminClient != nil {
+		admin, cErr := opts.AdminClient(ctx)
+		if cErr != nil {
+			log.Warn().Err(cErr).Str("container", c.ID).Msg("failed to reach control plane for firewall disable")
+			fmt.Fprintf(ios.ErrOut, "%s firewall disable skipped for %s: could not reach control plane: %v (BPF resources may leak until next firewall restart)\n",
+				cs.WarningIcon(), name, cErr)
+		} else if _, disableErr := admin.FirewallDisable(ctx, &adminv1.FirewallDisableRequest{ContainerId: c.ID}); disableErr != nil {
+			log.Warn().Err(disableErr).Str("container", c.ID).Msg("failed to disable firewall")
+			fmt.Fprintf(ios.ErrOut, "%s firewall disable failed for %s: %v (BPF resources may leak until next firewall restart)\n",
+				cs.WarningIcon(), name, disableErr)
+		}
+	}
+
+	// Stop socket bridge before removing the container (best-effort)
+	if opts.SocketBridge != nil {
+		if mgr := opts.SocketBridge(); mgr != nil {
+			if err := mgr.StopBridge(c.ID); err != nil {
+				log.Warn().Err(err).Str("container", c.ID).Msg("failed to stop socket bridge")
+			}
+		}
+	}
+
+	if err := removeDockerResources(ctx, client, name, c, opts); err != nil {
+		return err
+	}
+
+	// Drop the agent row keyed by container_id. Best-effort:
+	// if the DB doesn't yet exist (fresh install with no managed
+	// container) or the eviction fails, the start path's evict-on-die
+	// dockerevents subscription cleans up later. Container removal is
+	// the user-facing success, so registry hiccups must not surface as
+	// remove failures.
+	registryDBPath, pathErr := consts.ControlPlaneDBPath()
+	if pathErr != nil {
+		log.Debug().Err(pathErr).Msg("agent: skipping evict on remove (db path unresolved)")
+		return nil
+	}
+	reg, openErr := agent.NewSQLiteWriter(registryDBPath, log)
+	if openErr != nil {
+		log.Debug().Err(openErr).Msg("agent: skipping evict on remove (db open failed)")
+		return nil
+	}
+	defer func() {
+		if closer, ok := reg.(interface{ Close() error }); ok {
+			_ = closer.Close()
+		}
+	}()
+	if err := reg.EvictByContainerID(c.ID); err != nil {
+		log.Debug().Err(err).Str("container_id", c.ID).Msg("agent: evict on remove failed")
+	}
+	return nil
+}
+
+// removeDockerResources removes the container (with its volumes when
+// opts.Volumes is set) and, best-effort, an agent's sidecars.
+func removeDockerResources(ctx context.Context, client *docker.Client, name string, c container.Summary, opts RemoveContainerOptions) error {
+	if opts.Volumes {
+		if err := client.RemoveContainerWithVolumes(ctx, c.ID, opts.Force); err != nil {
+			return err
+		}
+	} else if _, err := client.ContainerRemove(ctx, c.ID, opts.Force); err != nil {
+		return err
+	}
+
+	// An agent's sidecars go with it (best-effort).
+	if agentName := c.Labels[consts.LabelAgent]; agentName != "" {
+		if err := client.RemoveSidecars(ctx, c.Labels[consts.LabelProject], agentName); err != nil {
+			fmt.Fprintf(opts.IOStreams.ErrOut, "%s %s: sidecars not removed: %v\n", opts.IOStreams.ColorScheme().WarningIcon(), name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/docker/go-units"
+	"github.com/moby/moby/api/types/container"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/docker"
+)
+
+// defaultCPUPeriod is the CFS period the daemon uses when --cpu-quota is
+// given without --cpu-period.
+const defaultCPUPeriod = 100000
+
+// applyResourceDefaults fills the CPU, memory and PID limits the CLI flags
+// left unset from the project resources: block. A --cpu-quota or
+// --cpu-period flag counts as a CPU limit: the daemon rejects NanoCPUs
+// alongside either.
+func applyResourceDefaults(r *container.Resources, res config.ResourcesConfig) error {
+	if res.CPUs != "" && r.NanoCPUs == 0 && r.CPUQuota == 0 && r.CPUPeriod == 0 {
+		cpus, err := docker.ParseCPUs(res.CPUs)
+		if err != nil {
+			return fmt.Errorf("resources.cpus: %w", err)
+		}
+		r.NanoCPUs = cpus
+	}
+	if res.Memory != "" && r.Memory == 0 {
+		mem, err := units.RAMInBytes(res.Memory)
+		if err != nil {
+			return fmt.Errorf("resources.memory: %w", err)
+		}
+		r.Memory = mem
+	}
+	if res.Pids != 0 && r.PidsLimit == nil {
+		pids := int64(res.Pids)
+		r.PidsLimit = &pids
+	}
+	return nil
+}
+
+// EnforceResourceCap checks a container's CPU, memory and PID limits
+// against the settings resources: cap. A limit above its cap is an error
+// naming the setting. With fill (container create), an unset limit takes
+// the cap, since an unset limit is unlimited; container update leaves it
+// alone, keeping the limit the container was created with.
+func EnforceResourceCap(r *container.Resources, limits config.ResourceCapSettings, fill bool) error {
+	if limits.MaxCPUs != "" {
+		maxCPUs, err := docker.ParseCPUs(limits.MaxCPUs)
+		if err != nil {
+			return fmt.Errorf("settings resources.max_cpus: %w", err)
+		}
+		cpus := r.NanoCPUs
+		if cpus == 0 && r.CPUQuota > 0 {
+			period := r.CPUPeriod
+			if period == 0 {
+				period = defaultCPUPeriod
+			}
+			cpus = r.CPUQuota * 1e9 / period
+		}
+		switch {
+		case cpus == 0 && r.CPUQuota == 0 && r.CPUPeriod == 0:
+			if fill {
+				r.NanoCPUs = maxCPUs
+			}
+		case cpus > maxCPUs:
+			return fmt.Errorf("CPU limit %s exceeds settings resources.max_cpus (%s)", formatCPUs(cpus), limits.MaxCPUs)
+		}
+	}
+	if limits.MaxMemory != "" {
+		maxMemory, err := units.RAMInBytes(limits.MaxMemory)
+		if err != nil {
+			return fmt.Errorf("settings resources.max_memory: %w", err)
+		}
+		switch {
+		case r.Memory == 0:
+			if fill {
+				r.Memory = maxMemory
+			}
+		case r.Memory > maxMemory:
+			return fmt.Errorf("memory limit %s exceeds settings resources.max_memory (%s)",
+				units.BytesSize(float64(r.Memory)), limits.MaxMemory)
+		}
+	}
+	if limits.MaxPids > 0 {
+		maxPids := int64(limits.MaxPids)
+		switch {
+		case r.PidsLimit == nil || *r.PidsLimit == 0:
+			if fill {
+				r.PidsLimit = &maxPids
+			}
+		case *r.PidsLimit < 0:
+			return fmt.Errorf("an unlimited pids limit exceeds settings resources.max_pids (%d)", maxPids)
+		case *r.PidsLimit > maxPids:
+			return fmt.Errorf("pids limit %d exceeds settings resources.max_pids (%d)", *r.PidsLimit, maxPids)
+		}
+	}
+	return nil
+}
+
+func formatCPUs(nano int64) string {
+	c := docker.NanoCPUs(nano)
+	return c.String()
+}
@@ -0,0 +1,131 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+)
+
+func TestApplyResourceDefaults(t *testing.T) {
+	res := config.ResourcesConfig{CPUs: "2", Memory: "4g", Pids: 512}
+
+	t.Run("fills unset limits", func(t *testing.T) {
+		var r container.Resources
+		require.NoError(t, applyResourceDefaults(&r, res))
+		assert.Equal(t, int64(2e9), r.NanoCPUs)
+		assert.Equal(t, int64(4<<30), r.Memory)
+		require.NotNil(t, r.PidsLimit)
+		assert.Equal(t, int64(512), *r.PidsLimit)
+	})
+
+	t.Run("flags win", func(t *testing.T) {
+		pids := int64(-1)
+		r := container.Resources{NanoCPUs: 5e8, Memory: 1 << 30, PidsLimit: &pids}
+		require.NoError(t, applyResourceDefaults(&r, res))
+		assert.Equal(t, int64(5e8), r.NanoCPUs)
+		assert.Equal(t, int64(1<<30), r.Memory)
+		assert.Equal(t, int64(-1), *r.PidsLimit)
+	})
+
+	t.Run("cpu quota counts as a CPU limit", func(t *testing.T) {
+		r := container.Resources{CPUQuota: 50000}
+		require.NoError(t, applyResourceDefaults(&r, res))
+		assert.Zero(t, r.NanoCPUs)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		var r container.Resources
+		err := applyResourceDefaults(&r, config.ResourcesConfig{CPUs: "lots"})
+		assert.ErrorContains(t, err, "resources.cpus")
+		err = applyResourceDefaults(&r, config.ResourcesConfig{Memory: "huge"})
+		assert.ErrorContains(t, err, "resources.memory")
+	})
+}
+
+func TestEnforceResourceCap(t *testing.T) {
+	limits := config.ResourceCapSettings{MaxCPUs: "4", MaxMemory: "8g", MaxPids: 1024}
+	pids := func(n int64) *int64 { return &n }
+
+	tests := []struct {
+		name    string
+		r       container.Resources
+		fill    bool
+		want    container.Resources
+		wantErr string
+	}{
+		{
+			name: "unset limits take the cap on create",
+			fill: true,
+			want: container.Resources{NanoCPUs: 4e9, Memory: 8 << 30, PidsLimit: pids(1024)},
+		},
+		{
+			name: "unset limits stay unset on update",
+			want: container.Resources{},
+		},
+		{
+			name: "limits within the cap pass",
+			r:    container.Resources{NanoCPUs: 2e9, Memory: 1 << 30, PidsLimit: pids(100)},
+			fill: true,
+			want: container.Resources{NanoCPUs: 2e9, Memory: 1 << 30, PidsLimit: pids(100)},
+		},
+		{
+			name:    "cpus above the cap",
+			r:       container.Resources{NanoCPUs: 8e9},
+			wantErr: "CPU limit 8.000 exceeds settings resources.max_cpus (4)",
+		},
+		{
+			name:    "cpu quota above the cap",
+			r:       container.Resources{CPUQuota: 500000},
+			wantErr: "CPU limit 5.000 exceeds",
+		},
+		{
+			name: "cpu quota within the cap is not filled",
+			r:    container.Resources{CPUQuota: 200000},
+			fill: true,
+			want: container.Resources{CPUQuota: 200000, Memory: 8 << 30, PidsLimit: pids(1024)},
+		},
+		{
+			name:    "memory above the cap",
+			r:       container.Resources{Memory: 16 << 30},
+			wantErr: "memory limit 16GiB exceeds settings resources.max_memory (8g)",
+		},
+		{
+			name:    "unlimited pids",
+			r:       container.Resources{PidsLimit: pids(-1)},
+			wantErr: "unlimited pids limit exceeds settings resources.max_pids (1024)",
+		},
+		{
+			name:    "pids above the cap",
+			r:       container.Resources{PidsLimit: pids(4096)},
+			wantErr: "pids limit 4096 exceeds",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.r
+			err := EnforceResourceCap(&r, limits, tt.fill)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, r)
+		})
+	}
+
+	t.Run("no cap", func(t *testing.T) {
+		r := container.Resources{PidsLimit: pids(-1)}
+		require.NoError(t, EnforceResourceCap(&r, config.ResourceCapSettings{}, true))
+		assert.Equal(t, container.Resources{PidsLimit: pids(-1)}, r)
+	})
+
+	t.Run("invalid cap", func(t *testing.T) {
+		var r container.Resources
+		err := EnforceResourceCap(&r, config.ResourceCapSettings{MaxMemory: "lots"}, true)
+		assert.ErrorContains(t, err, "settings resources.max_memory")
+	})
+}
@@ -0,0 +1,157 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/moby/moby/api/types/container"
+	mobyClient "github.com/moby/moby/client"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/secrets"
+)
+
+// secretsTmpfsSize caps the secrets tmpfs. Secrets are small; the cap only
+// keeps a runaway exec provider from eating container memory.
+const secretsTmpfsSize = "16m"
+
+// newSecretsResolver builds the resolver for the project's secrets: block
+// from secretsOptions. Tests swap it to inject fake providers.
+var newSecretsResolver = func(opts secrets.Options) *secrets.Resolver {
+	return secrets.NewResolver(opts)
+}
+
+// secretsOptions gates the providers on the project's trust: a secret a
+// project config file declares resolves only once approved with clawker
+// project trust, whatever its provider, and such a file secret may only read
+// inside the project or a settings secrets.file_roots directory.
+func secretsOptions(cfg config.Config) secrets.Options {
+	declared := make(map[string]bool)
+	for _, hc := range config.HostCommands(cfg) {
+		if name, ok := strings.CutPrefix(hc.Key, config.SecretsKey+"."); ok {
+			declared[name] = true
+		}
+	}
+	return secrets.Options{
+		ProjectDir: cfg.ProjectRoot(),
+		Check: func(name string, _ config.SecretConfig) error {
+			return cfg.CheckHostCommand(config.SecretsKey + "." + name)
+		},
+		ConfineFile: func(name string) []string {
+			if !declared[name] {
+				return nil
+			}
+			roots := []string{}
+			if root := cfg.ProjectRoot(); root != "" {
+				roots = append(roots, root)
+			}
+			for _, dir := range cfg.Settings().Secrets.FileRoots {
+				if abs, err := config.ExpandHostPath(dir); err == nil {
+					roots = append(roots, abs)
+				}
+			}
+			return roots
+		},
+	}
+}
+
+// applySecrets resolves the project's secrets at create time. Env-targeted
+// values are returned as KEY=value entries; when any secret targets a file,
+// a tmpfs is added at consts.SecretsDir for injectSecretFiles to fill on
+// every start. File secrets are resolved here too, so a broken reference
+// fails the create instead of the first start.
+func applySecrets(ctx context.Context, cfg config.Config, hostConfig *container.HostConfig) ([]string, error) {
+	specs := cfg.Project().Secrets
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	resolved, err := newSecretsResolver(secretsOptions(cfg)).ResolveAll(ctx, specs)
+	if err != nil {
+		return nil, fmt.Errorf("resolving secrets: %w", err)
+	}
+	if len(secrets.FileSpecs(specs)) > 0 {
+		if _, err := secrets.FilesTar(resolved, 0, 0); err != nil {
+			return nil, err
+		}
+		if err := addSecretsTmpfs(cfg, hostConfig); err != nil {
+			return nil, err
+		}
+	}
+	return secrets.EnvVars(resolved), nil
+}
+
+// planSecrets is applySecrets for a plan: nothing is resolved, so no
+// provider runs. Env-targeted secrets are returned as KEY=<redacted>.
+func planSecrets(cfg config.Config, hostConfig *container.HostConfig) ([]string, error) {
+	specs := cfg.Project().Secrets
+	var env []string
+	for _, name := range slices.Sorted(maps.Keys(secrets.EnvSpecs(specs))) {
+		env = append(env, specs[name].Env+"="+planRedacted)
+	}
+	if len(secrets.FileSpecs(specs)) > 0 {
+		if err := addSecretsTmpfs(cfg, hostConfig); err != nil {
+			return nil, err
+		}
+	}
+	return env, nil
+}
+
+// addSecretsTmpfs adds the tmpfs file secrets are written to.
+func addSecretsTmpfs(cfg config.Config, hostConfig *container.HostConfig) error {
+	if _, dup := hostConfig.Tmpfs[consts.SecretsDir]; dup {
+		return fmt.Errorf("--tmpfs %s conflicts with the secrets mount", consts.SecretsDir)
+	}
+	if hostConfig.Tmpfs == nil {
+		hostConfig.Tmpfs = make(map[string]string, 1)
+	}
+	hostConfig.Tmpfs[consts.SecretsDir] = fmt.Sprintf("rw,noexec,nosuid,nodev,size=%s,mode=0700,uid=%d,gid=%d",
+		secretsTmpfsSize, cfg.ContainerUID(), cfg.ContainerGID())
+	return nil
+}
+
+// injectSecretFiles writes the project's file secrets into the container's
+// secrets tmpfs. The tmpfs starts empty on every start, so this runs from
+// BootstrapServicesPostStart each time. A container created before the
+// secrets were configured has no tmpfs; it is skipped with a warning rather
+// than written to the container's filesystem.
+func injectSecretFiles(ctx context.Context, client *docker.Client, containerID string, cfg config.Config, log *logger.Logger) error {
+	specs := secrets.FileSpecs(cfg.Project().Secrets)
+	if len(specs) == 0 {
+		return nil
+	}
+	res, err := client.ContainerInspect(ctx, containerID, mobyClient.ContainerInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("inspecting container: %w", err)
+	}
+	if hc := res.Container.HostConfig; hc == nil || !hasTmpfs(hc, consts.SecretsDir) {
+		log.Warn().Str("container", containerID).
+			Msgf("container has no secrets mount at %s; recreate it to receive file secrets", consts.SecretsDir)
+		return nil
+	}
+
+	resolved, err := newSecretsResolver(secretsOptions(cfg)).ResolveAll(ctx, specs)
+	if err != nil {
+		return fmt.Errorf("resolving secrets: %w", err)
+	}
+	uid, gid := cfg.ContainerUID(), cfg.ContainerGID()
+	archive, err := secrets.FilesTar(resolved, uid, gid)
+	if err != nil {
+		return err
+	}
+	if err := client.ExtractArchiveAs(ctx, containerID, consts.SecretsDir, uid, gid, archive); err != nil {
+		return fmt.Errorf("writing secret files: %w", err)
+	}
+	log.Debug().Str("container", containerID).Int("count", len(resolved)).Msg("secret files written")
+	return nil
+}
+
+func hasTmpfs(hc *container.HostConfig, target string) bool {
+	_, ok := hc.Tmpfs[target]
+	return ok
+}
@@ -0,0 +1,122 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/secrets"
+)
+
+// fakeSecretsResolver swaps newSecretsResolver for one whose env provider
+// reads from vars, restoring it when the test ends.
+func fakeSecretsResolver(t *testing.T, vars map[string]string) {
+	t.Helper()
+	orig := newSecretsResolver
+	newSecretsResolver = func(opts secrets.Options) *secrets.Resolver {
+		opts.LookupEnv = func(k string) (string, bool) { v, ok := vars[k]; return v, ok }
+		return secrets.NewResolver(opts)
+	}
+	t.Cleanup(func() { newSecretsResolver = orig })
+}
+
+func TestApplySecrets(t *testing.T) {
+	fakeSecretsResolver(t, map[string]string{"HOST_TOKEN": "tok", "HOST_KEY": "key"})
+	cfg := configmocks.NewFromString(`
+secrets:
+  token:
+    provider: env
+    ref: HOST_TOKEN
+    env: API_TOKEN
+  deploy_key:
+    provider: env
+    ref: HOST_KEY
+`, "")
+	hc := &container.HostConfig{}
+
+	env, err := applySecrets(context.Background(), cfg, hc)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"API_TOKEN=tok"}, env)
+	require.Contains(t, hc.Tmpfs, consts.SecretsDir)
+	assert.Contains(t, hc.Tmpfs[consts.SecretsDir], "mode=0700")
+	assert.Contains(t, hc.Tmpfs[consts.SecretsDir], "noexec")
+}
+
+func TestApplySecrets_EnvOnlyHasNoMount(t *testing.T) {
+	fakeSecretsResolver(t, map[string]string{"HOST_TOKEN": "tok"})
+	cfg := configmocks.NewFromString(`
+secrets:
+  token: { provider: env, ref: HOST_TOKEN, env: API_TOKEN }
+`, "")
+	hc := &container.HostConfig{}
+
+	_, err := applySecrets(context.Background(), cfg, hc)
+	require.NoError(t, err)
+	assert.Empty(t, hc.Tmpfs)
+}
+
+func TestApplySecrets_UnresolvedFailsWithoutValues(t *testing.T) {
+	fakeSecretsResolver(t, map[string]string{"HOST_TOKEN": "tok"})
+	cfg := configmocks.NewFromString(`
+secrets:
+  token: { provider: env, ref: HOST_TOKEN, env: API_TOKEN }
+  missing: { provider: env, ref: NOT_SET }
+`, "")
+
+	_, err := applySecrets(context.Background(), cfg, &container.HostConfig{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `secret "missing"`)
+	assert.False(t, strings.Contains(err.Error(), "tok"), "error must not carry secret values")
+}
+
+func TestApplySecrets_TmpfsFlagConflict(t *testing.T) {
+	fakeSecretsResolver(t, map[string]string{"HOST_KEY": "key"})
+	cfg := configmocks.NewFromString(`
+secrets:
+  deploy_key: { provider: env, ref: HOST_KEY }
+`, "")
+	hc := &container.HostConfig{Tmpfs: map[string]string{consts.SecretsDir: ""}}
+
+	_, err := applySecrets(context.Background(), cfg, hc)
+	require.ErrorContains(t, err, "conflicts with the secrets mount")
+}
+
+func TestApplySecrets_UnapprovedExecRefused(t *testing.T) {
+	fakeSecretsResolver(t, nil)
+	cfg := configmocks.NewFromString(`
+secrets:
+  token: { provider: exec, ref: "echo tok", env: API_TOKEN }
+`, "")
+	cfg.CheckHostCommandFunc = func(key string) error {
+		return fmt.Errorf("%w: %s in /proj/.clawker.yaml", config.ErrUntrustedHostCommand, key)
+	}
+
+	_, err := applySecrets(context.Background(), cfg, &container.HostConfig{})
+	require.ErrorIs(t, err, config.ErrUntrustedHostCommand)
+	assert.Contains(t, err.Error(), "secrets.token")
+}
+
+func TestApplySecrets_UnapprovedOpRefused(t *testing.T) {
+	fakeSecretsResolver(t, nil)
+	cfg := configmocks.NewFromString(`
+secrets:
+  aws: { provider: op, ref: "op://Private/aws/secret", env: AWS_SECRET_ACCESS_KEY }
+`, "")
+	var checked []string
+	cfg.CheckHostCommandFunc = func(key string) error {
+		checked = append(checked, key)
+		return fmt.Errorf("%w: %s in /proj/.clawker.yaml", config.ErrUntrustedHostCommand, key)
+	}
+
+	_, err := applySecrets(context.Background(), cfg, &container.HostConfig{})
+	require.ErrorIs(t, err, config.ErrUntrustedHostCommand)
+	assert.Equal(t, []string{"secrets.aws"}, checked, "vault secrets are gated like exec secrets")
+}
@@ -0,0 +1,240 @@
+package shared
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/schmitthub/clawker/internal/audit"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/prompter"
+	"github.com/schmitthub/clawker/internal/workspace"
+)
+
+// Posture values shown in the security diff.
+const (
+	postureEnabled    = "enabled"
+	postureDisabled   = "disabled"
+	postureIsolated   = "isolated"
+	postureHost       = "host"
+	postureDefault    = "default"
+	postureUnconfined = "unconfined"
+	postureNone       = "none"
+)
+
+// hostNamespace is the namespace-mode value that shares the host's namespace.
+const hostNamespace = "host"
+
+// unconfinedSecurityOpts maps --security-opt keys to the value that disables
+// the protection, paired with the posture the config implies.
+var unconfinedSecurityOpts = map[string]struct{ value, config string }{
+	"seccomp":           {postureUnconfined, postureDefault},
+	"apparmor":          {postureUnconfined, postureDefault},
+	"systempaths":       {postureUnconfined, postureDefault},
+	"label":             {"disable", postureEnabled},
+	"no-new-privileges": {"false", "true"},
+}
+
+// SecurityOverride is one CLI flag that weakens the security posture the
+// project config declares.
+type SecurityOverride struct {
+	Setting   string // what is weakened, e.g. "security.cap_add"
+	Config    string // posture the config declares
+	Effective string // posture after the flag is applied
+	Flag      string // the flag responsible, as the user would type it
+}
+
+// SecurityOverrides compares the security posture declared by cfg with the
+// one the CLI flags in opts produce, and returns every setting the flags
+// weaken. Flags that only tighten the posture (e.g. --cap-drop, --read-only)
+// are not reported. The result is ordered by flag family so the diff reads
+// the same on every run.
+func (opts *ContainerCreateOptions) SecurityOverrides(cfg config.Config) []SecurityOverride {
+	projectCfg := cfg.Project()
+	firewallEnabled := cfg.Settings().Firewall.FirewallEnabled()
+
+	var out []SecurityOverride
+
+	if opts.Privileged {
+		out = append(out, SecurityOverride{
+			Setting: "privileged", Config: "false", Effective: "true", Flag: "--privileged",
+		})
+	}
+
+	if extra := extraCapabilities(opts.CapAdd, projectCfg.Security.CapAdd); len(extra) > 0 {
+		declared := postureNone
+		if len(projectCfg.Security.CapAdd) > 0 {
+			declared = strings.Join(projectCfg.Security.CapAdd, ",")
+		}
+		out = append(out, SecurityOverride{
+			Setting:   "security.cap_add",
+			Config:    declared,
+			Effective: strings.Join(opts.CapAdd, ","),
+			Flag:      "--cap-add " + strings.Join(extra, ","),
+		})
+	}
+
+	for _, opt := range opts.SecurityOpt {
+		k, v, ok := strings.Cut(opt, "=")
+		if !ok {
+			k, v, _ = strings.Cut(opt, ":")
+		}
+		weak, known := unconfinedSecurityOpts[k]
+		if !known || v != weak.value {
+			continue
+		}
+		out = append(out, SecurityOverride{
+			Setting: k, Config: weak.config, Effective: v, Flag: "--security-opt " + opt,
+		})
+	}
+
+	if firewallEnabled && opts.NetMode.NetworkMode() == hostNamespace {
+		out = append(out, SecurityOverride{
+			Setting: "firewall", Config: postureEnabled, Effective: "bypassed (host network)", Flag: "--network host",
+		})
+	}
+
+	for _, ns := range []struct{ name, mode string }{
+		{"pid", opts.PidMode},
+		{"ipc", opts.IpcMode},
+		{"uts", opts.UtsMode},
+		{"userns", opts.UsernsMode},
+		{"cgroupns", opts.CgroupnsMode},
+	} {
+		if ns.mode == hostNamespace {
+			out = append(out, SecurityOverride{
+				Setting: ns.name + " namespace", Config: postureIsolated, Effective: postureHost, Flag: "--" + ns.name + " host",
+			})
+		}
+	}
+
+	if !projectCfg.Security.DockerSocket {
+		if bind := dockerSocketBind(opts.Volumes); bind != "" {
+			out = append(out, SecurityOverride{
+				Setting: "security.docker_socket", Config: postureDisabled, Effective: postureEnabled, Flag: "--volume " + bind,
+			})
+		}
+	}
+
+	return out
+}
+
+// extraCapabilities returns the capabilities in requested that declared does
+// not already grant. Names are compared case-insensitively with or without
+// the CAP_ prefix, matching how the Docker daemon normalizes them.
+func extraCapabilities(requested, declared []string) []string {
+	granted := make([]string, 0, len(declared))
+	for _, c := range declared {
+		granted = append(granted, normalizeCapability(c))
+	}
+	var extra []string
+	for _, c := range requested {
+		if !slices.Contains(granted, normalizeCapability(c)) {
+			extra = append(extra, c)
+		}
+	}
+	return extra
+}
+
+func normalizeCapability(c string) string {
+	return strings.TrimPrefix(strings.ToUpper(c), "CAP_")
+}
+
+// dockerSocketBind returns the first --volume spec that bind-mounts the host
+// Docker socket, or "" if none does.
+func dockerSocketBind(volumes []string) string {
+	socket := workspace.GetDockerSocketMount().Source
+	for _, v := range volumes {
+		src, _, _ := strings.Cut(v, ":")
+		if src == socket {
+			return v
+		}
+	}
+	return ""
+}
+
+// WriteSecurityDiff renders overrides as an aligned CONFIG → EFFECTIVE table.
+func WriteSecurityDiff(w io.Writer, overrides []SecurityOverride) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  SETTING\tCONFIG\tEFFECTIVE\tFLAG")
+	for _, o := range overrides {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", o.Setting, o.Config, o.Effective, o.Flag)
+	}
+	return tw.Flush()
+}
+
+// SecurityGateOptions holds what ConfirmSecurityOverrides needs.
+type SecurityGateOptions struct {
+	IOStreams *iostreams.IOStreams
+	Prompter  func() *prompter.Prompter
+	Config    config.Config
+	Options   *ContainerCreateOptions
+	Command   string // command path for the audit journal, e.g. "container run"
+	Project   string
+}
+
+// ConfirmSecurityOverrides gates container creation on CLI flags that weaken
+// the project's declared security posture. With no such flags it does
+// nothing. Otherwise it prints the posture diff and proceeds only if
+// --override-security was passed or the user confirms at the prompt; when
+// stdin is not a terminal and the flag is absent it refuses. Every outcome
+// is recorded in the audit journal, and a journal write failure aborts the
+// create — an override that cannot be recorded is not allowed through.
+func ConfirmSecurityOverrides(opts SecurityGateOptions) error {
+	overrides := opts.Options.SecurityOverrides(opts.Config)
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+	fmt.Fprintf(ios.ErrOut, "%s These flags weaken the security settings in your project config:\n\n", cs.WarningIcon())
+	if err := WriteSecurityDiff(ios.ErrOut, overrides); err != nil {
+		return err
+	}
+	fmt.Fprintln(ios.ErrOut)
+
+	var decision audit.Decision
+	switch {
+	case opts.Options.OverrideSecurity:
+		decision = audit.DecisionOverrideFlag
+	case !ios.CanPrompt():
+		decision = audit.DecisionRefused
+	default:
+		confirmed, err := opts.Prompter().Confirm("Create the container with this weakened security posture?", false)
+		if err != nil {
+			return err
+		}
+		decision = audit.DecisionDeclined
+		if confirmed {
+			decision = audit.DecisionConfirmed
+		}
+	}
+
+	changes := make([]audit.Change, 0, len(overrides))
+	for _, o := range overrides {
+		changes = append(changes, audit.Change(o))
+	}
+	if err := audit.Append(audit.Entry{
+		Event:    audit.EventSecurityOverride,
+		Decision: decision,
+		Command:  opts.Command,
+		Project:  opts.Project,
+		Agent:    opts.Options.GetAgentName(),
+		Changes:  changes,
+	}); err != nil {
+		return fmt.Errorf("recording security override: %w", err)
+	}
+
+	switch decision {
+	case audit.DecisionRefused:
+		return cmdutil.FlagErrorf("refusing to weaken the configured security posture without confirmation; re-run with --override-security to accept")
+	case audit.DecisionDeclined:
+		return cmdutil.SilentError
+	}
+	return nil
+}
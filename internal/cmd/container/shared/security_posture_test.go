@@ -0,0 +1,226 @@
+package shared
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/audit"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/prompter"
+	"github.com/schmitthub/clawker/internal/testenv"
+)
+
+func TestSecurityOverrides(t *testing.T) {
+	cfg := configmocks.NewFromString(`
+security:
+  cap_add: [SYS_PTRACE]
+  docker_socket: false
+`, "")
+
+	tests := []struct {
+		name  string
+		apply func(o *ContainerCreateOptions)
+		want  []SecurityOverride
+	}{
+		{
+			name:  "no flags",
+			apply: func(o *ContainerCreateOptions) {},
+		},
+		{
+			name:  "privileged",
+			apply: func(o *ContainerCreateOptions) { o.Privileged = true },
+			want:  []SecurityOverride{{Setting: "privileged", Config: "false", Effective: "true", Flag: "--privileged"}},
+		},
+		{
+			name:  "cap-add already declared in config",
+			apply: func(o *ContainerCreateOptions) { o.CapAdd = []string{"cap_sys_ptrace"} },
+		},
+		{
+			name:  "cap-add beyond config",
+			apply: func(o *ContainerCreateOptions) { o.CapAdd = []string{"SYS_PTRACE", "NET_ADMIN"} },
+			want: []SecurityOverride{{
+				Setting: "security.cap_add", Config: "SYS_PTRACE", Effective: "SYS_PTRACE,NET_ADMIN", Flag: "--cap-add NET_ADMIN",
+			}},
+		},
+		{
+			name:  "unconfined seccomp",
+			apply: func(o *ContainerCreateOptions) { o.SecurityOpt = []string{"seccomp=unconfined"} },
+			want: []SecurityOverride{{
+				Setting: "seccomp", Config: "default", Effective: "unconfined", Flag: "--security-opt seccomp=unconfined",
+			}},
+		},
+		{
+			name:  "tightening security-opt is not reported",
+			apply: func(o *ContainerCreateOptions) { o.SecurityOpt = []string{"no-new-privileges=true"} },
+		},
+		{
+			name:  "host network bypasses firewall",
+			apply: func(o *ContainerCreateOptions) { require.NoError(t, o.NetMode.Set("host")) },
+			want: []SecurityOverride{{
+				Setting: "firewall", Config: "enabled", Effective: "bypassed (host network)", Flag: "--network host",
+			}},
+		},
+		{
+			name:  "host pid namespace",
+			apply: func(o *ContainerCreateOptions) { o.PidMode = "host" },
+			want: []SecurityOverride{{
+				Setting: "pid namespace", Config: "isolated", Effective: "host", Flag: "--pid host",
+			}},
+		},
+		{
+			name:  "docker socket bind while disabled",
+			apply: func(o *ContainerCreateOptions) { o.Volumes = []string{"/var/run/docker.sock:/var/run/docker.sock"} },
+			want: []SecurityOverride{{
+				Setting: "security.docker_socket", Config: "disabled", Effective: "enabled", Flag: "--volume /var/run/docker.sock:/var/run/docker.sock",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewContainerOptions()
+			tt.apply(opts)
+			assert.Equal(t, tt.want, opts.SecurityOverrides(cfg))
+		})
+	}
+}
+
+func TestSecurityOverrides_DockerSocketAllowedByConfig(t *testing.T) {
+	cfg := configmocks.NewFromString(`
+security:
+  docker_socket: true
+`, "")
+	opts := NewContainerOptions()
+	opts.Volumes = []string{"/var/run/docker.sock:/var/run/docker.sock"}
+
+	assert.Empty(t, opts.SecurityOverrides(cfg))
+}
+
+func readAuditJournal(t *testing.T) []audit.Entry {
+	t.Helper()
+	path, err := consts.AuditLogPath()
+	require.NoError(t, err)
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+	defer f.Close()
+
+	var entries []audit.Entry
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e audit.Entry
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	require.NoError(t, sc.Err())
+	return entries
+}
+
+func TestConfirmSecurityOverrides(t *testing.T) {
+	cfg := configmocks.NewFromString(`
+security:
+  docker_socket: false
+`, "")
+
+	gate := func(ios *iostreams.IOStreams, opts *ContainerCreateOptions) error {
+		return ConfirmSecurityOverrides(SecurityGateOptions{
+			IOStreams: ios,
+			Prompter:  func() *prompter.Prompter { return prompter.NewPrompter(ios) },
+			Config:    cfg,
+			Options:   opts,
+			Command:   "container run",
+			Project:   "myapp",
+		})
+	}
+
+	t.Run("nothing weakened records nothing", func(t *testing.T) {
+		testenv.New(t)
+		ios, _, _, errOut := iostreams.Test()
+
+		require.NoError(t, gate(ios, NewContainerOptions()))
+		assert.Empty(t, errOut.String())
+		assert.Empty(t, readAuditJournal(t))
+	})
+
+	t.Run("non-interactive without override is refused", func(t *testing.T) {
+		testenv.New(t)
+		ios, _, _, errOut := iostreams.Test()
+		opts := NewContainerOptions()
+		opts.Agent = "dev"
+		opts.Privileged = true
+
+		err := gate(ios, opts)
+		require.Error(t, err)
+		var flagErr *cmdutil.FlagError
+		require.ErrorAs(t, err, &flagErr)
+		assert.Contains(t, err.Error(), "--override-security")
+		assert.Contains(t, errOut.String(), "--privileged")
+
+		entries := readAuditJournal(t)
+		require.Len(t, entries, 1)
+		assert.Equal(t, audit.EventSecurityOverride, entries[0].Event)
+		assert.Equal(t, audit.DecisionRefused, entries[0].Decision)
+		assert.Equal(t, "myapp", entries[0].Project)
+		assert.Equal(t, "dev", entries[0].Agent)
+		assert.Equal(t, "container run", entries[0].Command)
+		require.Len(t, entries[0].Changes, 1)
+		assert.Equal(t, "privileged", entries[0].Changes[0].Setting)
+	})
+
+	t.Run("override flag proceeds and is recorded", func(t *testing.T) {
+		testenv.New(t)
+		ios, _, _, _ := iostreams.Test()
+		opts := NewContainerOptions()
+		opts.Privileged = true
+		opts.OverrideSecurity = true
+
+		require.NoError(t, gate(ios, opts))
+
+		entries := readAuditJournal(t)
+		require.Len(t, entries, 1)
+		assert.Equal(t, audit.DecisionOverrideFlag, entries[0].Decision)
+	})
+
+	t.Run("interactive confirm proceeds", func(t *testing.T) {
+		testenv.New(t)
+		ios, in, _, _ := iostreams.Test()
+		ios.SetStdinTTY(true)
+		ios.SetStdoutTTY(true)
+		in.WriteString("y\n")
+		opts := NewContainerOptions()
+		opts.PidMode = "host"
+
+		require.NoError(t, gate(ios, opts))
+
+		entries := readAuditJournal(t)
+		require.Len(t, entries, 1)
+		assert.Equal(t, audit.DecisionConfirmed, entries[0].Decision)
+	})
+
+	t.Run("interactive decline aborts silently", func(t *testing.T) {
+		testenv.New(t)
+		ios, in, _, _ := iostreams.Test()
+		ios.SetStdinTTY(true)
+		ios.SetStdoutTTY(true)
+		in.WriteString("n\n")
+		opts := NewContainerOptions()
+		opts.PidMode = "host"
+
+		err := gate(ios, opts)
+		require.ErrorIs(t, err, cmdutil.SilentError)
+
+		entries := readAuditJournal(t)
+		require.Len(t, entries, 1)
+		assert.Equal(t, audit.DecisionDeclined, entries[0].Decision)
+	})
+}
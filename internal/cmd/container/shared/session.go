@@ -0,0 +1,195 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
+
+	"github.com/schmitthub/clawker/internal/bundler"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/session"
+)
+
+// ExportDirFn copies a container directory (running or stopped) into a host
+// directory. Matches *docker.Client.ExportWorkspace.
+type ExportDirFn func(ctx context.Context, containerID, srcPath, dir string) error
+
+// SaveSessionOpts holds options for SaveSession.
+type SaveSessionOpts struct {
+	// Store opens the host session store the session is saved to; it is
+	// called only when there is something to save.
+	Store func() (*session.Store, error)
+	// Config resolves the container's harness bundle and its
+	// persist_sessions setting.
+	Config config.Config
+	// Container is the agent container; its labels name the project, agent
+	// and harness, and its mounts show which session dirs are bind-mounted.
+	Container *container.Summary
+	// Export copies a session dir out of the container.
+	// In production, wire this to (*docker.Client).ExportWorkspace.
+	Export ExportDirFn
+	// Now stamps the saved session; nil means time.Now.
+	Now func() time.Time
+}
+
+// SaveSession saves the harness session dirs of an agent container to the
+// host session store, replacing what was saved before. It returns nil when
+// there is nothing to save: the container has no harness, the harness
+// declares no session dirs or has persist_sessions off, every session dir
+// is bind-mounted from the host, or the dirs are empty.
+func SaveSession(ctx context.Context, opts SaveSessionOpts) (*session.Meta, error) {
+	c := opts.Container
+	harness := c.Labels[consts.LabelHarness]
+	agent := c.Labels[consts.LabelAgent]
+	if harness == "" || agent == "" || len(c.Names) == 0 {
+		return nil, nil
+	}
+	if !opts.Config.Project().HarnessConfigFor(harness).PersistSessionsEnabled() {
+		return nil, nil
+	}
+	bundle, err := bundler.LoadHarness(opts.Config, harness)
+	if err != nil {
+		return nil, fmt.Errorf("loading harness %q: %w", harness, err)
+	}
+	paths := unmountedSessionDirs(bundle.Manifest.Sessions, c.Mounts)
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	store, err := opts.Store()
+	if err != nil {
+		return nil, err
+	}
+	meta := session.Meta{
+		Container: strings.TrimPrefix(c.Names[0], "/"),
+		Project:   c.Labels[consts.LabelProject],
+		Agent:     agent,
+		Harness:   harness,
+		SavedAt:   now().UTC(),
+		Paths:     paths,
+	}
+	return store.Save(meta, func(dir string) error {
+		for _, p := range paths {
+			dest := filepath.Join(dir, filepath.FromSlash(p))
+			if err := opts.Export(ctx, c.ID, containerHomeDir+"/"+p, dest); err != nil {
+				if docker.IsNotFound(err) {
+					continue // the harness has not created this dir yet
+				}
+				return fmt.Errorf("saving %s: %w", p, err)
+			}
+		}
+		return nil
+	})
+}
+
+// unmountedSessionDirs returns the declared session dirs that live in the
+// container's volumes. A dir at or under a bind mount is host state that
+// outlives the container on its own, and restoring into it would overwrite
+// the host's copy.
+func unmountedSessionDirs(dirs []string, mounts []container.MountPoint) []string {
+	var out []string
+	for _, d := range dirs {
+		target := containerHomeDir + "/" + config.NormalizeContainerPath(d)
+		bound := false
+		for _, m := range mounts {
+			if m.Type == mount.TypeBind && (target == m.Destination || strings.HasPrefix(target, m.Destination+"/")) {
+				bound = true
+				break
+			}
+		}
+		if !bound {
+			out = append(out, config.NormalizeContainerPath(d))
+		}
+	}
+	return out
+}
+
+// RestoreSessionOpts holds options for RestoreSession.
+type RestoreSessionOpts struct {
+	// Store opens the host session store; it is called only when the
+	// create made a harness volume.
+	Store func() (*session.Store, error)
+	// ContainerName keys the saved session.
+	ContainerName string
+	// ProjectName and AgentName name the harness volumes.
+	ProjectName string
+	AgentName   string
+	// HarnessName is the new container's harness; a session saved by
+	// another harness is not restored.
+	HarnessName string
+	// Volumes are the harness bundle's declared persisted dirs.
+	Volumes []config.VolumeSpec
+	// FreshVolumes maps a harness volume name to whether this create made
+	// it. Only fresh volumes are restored into — a pre-existing volume
+	// already holds the agent's sessions.
+	FreshVolumes map[string]bool
+	// CopyToVolume copies a directory to a Docker volume.
+	CopyToVolume CopyToVolumeFn
+	// Log is the logger for diagnostic file logging.
+	Log *logger.Logger
+}
+
+// RestoreSession copies the container's saved session into the harness
+// volumes this create made. It returns the restored session, or nil when
+// none applied.
+func RestoreSession(ctx context.Context, opts RestoreSessionOpts) (*session.Meta, error) {
+	if !anyFresh(opts.Volumes, opts.FreshVolumes) {
+		return nil, nil
+	}
+	store, err := opts.Store()
+	if err != nil {
+		return nil, err
+	}
+	meta, err := store.Get(opts.ContainerName)
+	if errors.Is(err, session.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if meta.Harness != opts.HarnessName {
+		opts.Log.Debug().Str("saved", meta.Harness).Str("harness", opts.HarnessName).
+			Msg("saved session belongs to another harness; not restoring")
+		return nil, nil
+	}
+
+	files := store.FilesDir(opts.ContainerName)
+	restored := false
+	for _, v := range opts.Volumes {
+		if !opts.FreshVolumes[v.Name] {
+			continue
+		}
+		rel := config.NormalizeContainerPath(v.Path)
+		srcDir := filepath.Join(files, filepath.FromSlash(rel))
+		if _, statErr := os.Stat(srcDir); statErr != nil {
+			continue // no saved session dir lives in this volume
+		}
+		volName, err := docker.HarnessVolumeName(opts.ProjectName, opts.AgentName, opts.HarnessName, v.Name)
+		if err != nil {
+			return nil, fmt.Errorf("volume name for %q: %w", v.Name, err)
+		}
+		if err := opts.CopyToVolume(ctx, volName, srcDir, containerHomeDir+"/"+rel, nil); err != nil {
+			return nil, fmt.Errorf("restoring saved session: %w", err)
+		}
+		restored = true
+		opts.Log.Debug().Str("volume", v.Name).Msg("restored saved session into volume")
+	}
+	if !restored {
+		return nil, nil
+	}
+	return meta, nil
+}
@@ -0,0 +1,195 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/session"
+)
+
+func sessionContainer(mounts ...container.MountPoint) *container.Summary {
+	return &container.Summary{
+		ID:    "abc123",
+		Names: []string{"/clawker.app.dev"},
+		Labels: map[string]string{
+			consts.LabelProject: "app",
+			consts.LabelAgent:   "dev",
+			consts.LabelHarness: "claude",
+		},
+		Mounts: mounts,
+	}
+}
+
+// fakeExport writes one transcript into each exported session dir, or
+// reports the dirs in missing as absent from the container.
+func fakeExport(exported *[]string, missing ...string) ExportDirFn {
+	return func(_ context.Context, id, src, dir string) error {
+		for _, m := range missing {
+			if src == containerHomeDir+"/"+m {
+				return fmt.Errorf("reading container workspace: could not find %s in container %s: %w", src, id, cerrdefs.ErrNotFound)
+			}
+		}
+		*exported = append(*exported, src)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dir, "session.jsonl"), []byte("{}\n"), 0o644)
+	}
+}
+
+func TestSaveSession_SkipsBindMountedDirs(t *testing.T) {
+	store := session.NewStore(t.TempDir())
+	var exported []string
+	saved := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	meta, err := SaveSession(context.Background(), SaveSessionOpts{
+		Store:  func() (*session.Store, error) { return store, nil },
+		Config: configmocks.NewBlankConfig(),
+		Container: sessionContainer(container.MountPoint{
+			Type: mount.TypeBind, Source: "/host/.claude/projects", Destination: containerHomeDir + "/.claude/projects",
+		}),
+		Export: fakeExport(&exported),
+		Now:    func() time.Time { return saved },
+	})
+	require.NoError(t, err)
+	require.NotNil(t, meta)
+	assert.Equal(t, []string{containerHomeDir + "/.claude/todos"}, exported)
+	assert.Equal(t, []string{".claude/todos"}, meta.Paths)
+	assert.Equal(t, "clawker.app.dev", meta.Container)
+	assert.Equal(t, "claude", meta.Harness)
+	assert.Equal(t, saved, meta.SavedAt)
+	_, err = os.Stat(filepath.Join(store.FilesDir("clawker.app.dev"), ".claude", "todos", "session.jsonl"))
+	require.NoError(t, err)
+}
+
+func TestSaveSession_NothingToSave(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    config.Config
+		container *container.Summary
+		missing   []string
+	}{
+		{
+			name:      "persist_sessions off",
+			config:    configmocks.NewFromString("harnesses:\n  claude:\n    persist_sessions: false\n", ""),
+			container: sessionContainer(),
+		},
+		{
+			name:   "no harness label",
+			config: configmocks.NewBlankConfig(),
+			container: &container.Summary{
+				Names:  []string{"/clawker.app.dev"},
+				Labels: map[string]string{consts.LabelAgent: "dev"},
+			},
+		},
+		{
+			name:      "session dirs not created yet",
+			config:    configmocks.NewBlankConfig(),
+			container: sessionContainer(),
+			missing:   []string{".claude/projects", ".claude/todos"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := session.NewStore(t.TempDir())
+			var exported []string
+			meta, err := SaveSession(context.Background(), SaveSessionOpts{
+				Store:     func() (*session.Store, error) { return store, nil },
+				Config:    tt.config,
+				Container: tt.container,
+				Export:    fakeExport(&exported, tt.missing...),
+			})
+			require.NoError(t, err)
+			assert.Nil(t, meta)
+			assert.Empty(t, exported)
+			_, err = store.Get("clawker.app.dev")
+			assert.ErrorIs(t, err, session.ErrNotFound)
+		})
+	}
+}
+
+func TestRestoreSession(t *testing.T) {
+	store := session.NewStore(t.TempDir())
+	_, err := store.Save(session.Meta{Container: "clawker.app.dev", Agent: "dev", Harness: "claude", Paths: []string{".claude/projects"}},
+		func(dir string) error {
+			p := filepath.Join(dir, ".claude", "projects", "-app", "a.jsonl")
+			require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+			return os.WriteFile(p, []byte("{}\n"), 0o644)
+		})
+	require.NoError(t, err)
+
+	volumes := []config.VolumeSpec{{Name: "config", Path: ".claude"}}
+	configVolume, err := docker.HarnessVolumeName("app", "dev", "claude", "config")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		harness string
+		fresh   map[string]bool
+		want    bool
+	}{
+		{name: "fresh volume", harness: "claude", fresh: map[string]bool{"config": true}, want: true},
+		{name: "existing volume", harness: "claude", fresh: map[string]bool{}},
+		{name: "other harness", harness: "codex", fresh: map[string]bool{"config": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			type copyCall struct{ volume, src, dest string }
+			var calls []copyCall
+			meta, err := RestoreSession(context.Background(), RestoreSessionOpts{
+				Store:         func() (*session.Store, error) { return store, nil },
+				ContainerName: "clawker.app.dev",
+				ProjectName:   "app",
+				AgentName:     "dev",
+				HarnessName:   tt.harness,
+				Volumes:       volumes,
+				FreshVolumes:  tt.fresh,
+				CopyToVolume: func(_ context.Context, volume, src, dest string, _ []string) error {
+					calls = append(calls, copyCall{volume, src, dest})
+					return nil
+				},
+				Log: logger.Nop(),
+			})
+			require.NoError(t, err)
+			if !tt.want {
+				assert.Nil(t, meta)
+				assert.Empty(t, calls)
+				return
+			}
+			require.NotNil(t, meta)
+			assert.Equal(t, []copyCall{{
+				volume: configVolume,
+				src:    filepath.Join(store.FilesDir("clawker.app.dev"), ".claude"),
+				dest:   containerHomeDir + "/.claude",
+			}}, calls)
+		})
+	}
+}
+
+func TestRestoreSession_NoSavedSession(t *testing.T) {
+	meta, err := RestoreSession(context.Background(), RestoreSessionOpts{
+		Store:         func() (*session.Store, error) { return session.NewStore(t.TempDir()), nil },
+		ContainerName: "clawker.app.dev",
+		HarnessName:   "claude",
+		Volumes:       []config.VolumeSpec{{Name: "config", Path: ".claude"}},
+		FreshVolumes:  map[string]bool{"config": true},
+		Log:           logger.Nop(),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, meta)
+}
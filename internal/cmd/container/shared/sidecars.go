@@ -0,0 +1,313 @@
+package shared
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+)
+
+// SidecarReadyTimeout bounds how long StartSidecars waits for every sidecar
+// to become healthy.
+const SidecarReadyTimeout = 2 * time.Minute
+
+// sidecarPollInterval is how often a starting sidecar is re-inspected.
+const sidecarPollInterval = 500 * time.Millisecond
+
+// StartSidecarsOptions configures StartSidecars.
+type StartSidecarsOptions struct {
+	Client   *docker.Client
+	Project  string
+	Agent    string
+	Sidecars map[string]config.SidecarConfig
+	// Network is the project network (ProjectNetwork) the sidecars join;
+	// empty means the shared clawker network.
+	Network string
+	// Timeout bounds the wait for health; zero means SidecarReadyTimeout.
+	Timeout time.Duration
+}
+
+// Sidecar is a started sidecar container and its address on the network
+// it shares with the agent.
+type Sidecar struct {
+	Name        string
+	ContainerID string
+	IP          netip.Addr
+}
+
+// Host returns the --add-host entry that lets the agent reach the sidecar
+// at its name. A hosts entry rather than Docker DNS: with the firewall on,
+// the agent's DNS goes through CoreDNS, which only answers allowed domains.
+func (s Sidecar) Host() string {
+	return s.Name + ":" + s.IP.String()
+}
+
+// SidecarHosts returns the --add-host entries for sidecars: each one at
+// its bare name and at its stable <name>.clawker.internal name.
+func SidecarHosts(sidecars []Sidecar) []string {
+	hosts := make([]string, 0, 2*len(sidecars))
+	for _, s := range sidecars {
+		hosts = append(hosts, s.Host(), SidecarFQDN(s.Name)+":"+s.IP.String())
+	}
+	return hosts
+}
+
+// SidecarFQDN returns a sidecar's stable DNS name on its network.
+func SidecarFQDN(name string) string {
+	return name + "." + consts.SidecarDomain
+}
+
+// sidecarAliases are the names other containers on the sidecar's network
+// resolve it by through Docker DNS.
+func sidecarAliases(name string) []string {
+	return []string{name, SidecarFQDN(name)}
+}
+
+// StartSidecars brings up an agent's sidecars on the project network and
+// waits until each reports healthy (or, without a healthcheck, is
+// running). An existing sidecar container is started in place while its
+// config is unchanged, and recreated when the sidecars: entry changed. On
+// error, sidecars already started are left running; the caller decides
+// whether to remove them (docker.Client.RemoveSidecars).
+func StartSidecars(ctx context.Context, opts StartSidecarsOptions) ([]Sidecar, error) {
+	client := opts.Client
+	existing, err := client.ListSidecars(ctx, opts.Project, opts.Agent)
+	if err != nil {
+		return nil, fmt.Errorf("listing sidecars: %w", err)
+	}
+	byName := make(map[string]docker.Container, len(existing))
+	for _, c := range existing {
+		byName[c.Labels[consts.LabelSidecar]] = c
+	}
+
+	started := make([]Sidecar, 0, len(opts.Sidecars))
+	for _, name := range slices.Sorted(maps.Keys(opts.Sidecars)) {
+		id, err := ensureSidecar(ctx, opts, name, opts.Sidecars[name], byName[name])
+		if err != nil {
+			return nil, fmt.Errorf("sidecar %s: %w", name, err)
+		}
+		started = append(started, Sidecar{Name: name, ContainerID: id})
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = SidecarReadyTimeout
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for i := range started {
+		ip, err := waitSidecarReady(waitCtx, client, started[i].ContainerID, sidecarNetwork(opts))
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = fmt.Errorf("not healthy within %s", timeout)
+			}
+			return nil, fmt.Errorf("sidecar %s: %w", started[i].Name, err)
+		}
+		started[i].IP = ip
+	}
+	return started, nil
+}
+
+// ensureSidecar starts the sidecar's existing container, or replaces it
+// when its config hash is stale, or creates it. Returns the container ID.
+func ensureSidecar(ctx context.Context, opts StartSidecarsOptions, name string, sc config.SidecarConfig, existing docker.Container) (string, error) {
+	client := opts.Client
+	if sc.Image == "" {
+		return "", fmt.Errorf("%s.%s.image is required", config.SidecarsKey, name)
+	}
+	hash, err := sidecarConfigHash(sc)
+	if err != nil {
+		return "", err
+	}
+
+	if existing.ID != "" {
+		if existing.Labels[consts.LabelContentHash] == hash {
+			if existing.Status != string(container.StateRunning) {
+				if _, err := client.ContainerStart(ctx, docker.ContainerStartOptions{ContainerID: existing.ID}); err != nil {
+					return "", fmt.Errorf("starting container: %w", err)
+				}
+			}
+			if err := ensureSidecarEndpoint(ctx, opts, name, existing.ID); err != nil {
+				return "", err
+			}
+			return existing.ID, nil
+		}
+		// The sidecars: entry changed since the container was created.
+		if _, err := client.ContainerRemoveWithOptions(ctx, existing.ID, docker.ContainerRemoveOptions{
+			Force:         true,
+			RemoveVolumes: true,
+		}); err != nil {
+			return "", fmt.Errorf("removing outdated container: %w", err)
+		}
+	}
+
+	cfg, hostCfg, err := sidecarContainerConfig(sc)
+	if err != nil {
+		return "", err
+	}
+	containerName, err := docker.SidecarContainerName(opts.Project, opts.Agent, name)
+	if err != nil {
+		return "", err
+	}
+	if err := client.EnsureExternalImage(ctx, sc.Image); err != nil {
+		return "", err
+	}
+
+	labels := client.SidecarLabels(opts.Project, opts.Agent, name)
+	labels[consts.LabelContentHash] = hash
+	resp, err := client.ContainerCreate(ctx, docker.ContainerCreateOptions{
+		Config:     cfg,
+		HostConfig: hostCfg,
+		NetworkingConfig: &network.NetworkingConfig{EndpointsConfig: map[string]*network.EndpointSettings{
+			sidecarNetwork(opts): {Aliases: sidecarAliases(name)},
+		}},
+		Name:          containerName,
+		ExtraLabels:   docker.Labels{labels},
+		EnsureNetwork: sidecarEnsureNetwork(client, opts),
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating container: %w", err)
+	}
+	if _, err := client.ContainerStart(ctx, docker.ContainerStartOptions{ContainerID: resp.ID}); err != nil {
+		return "", fmt.Errorf("starting container: %w", err)
+	}
+	return resp.ID, nil
+}
+
+// sidecarNetwork returns the network a sidecar is attached to.
+func sidecarNetwork(opts StartSidecarsOptions) string {
+	if opts.Network != "" {
+		return opts.Network
+	}
+	return consts.Network
+}
+
+// sidecarEnsureNetwork returns the create options for the sidecar's
+// network: the project network only, so sidecars are unreachable from
+// other projects' agents, or the shared clawker network.
+func sidecarEnsureNetwork(client *docker.Client, opts StartSidecarsOptions) *docker.EnsureNetworkOptions {
+	if opts.Network == "" {
+		return &docker.EnsureNetworkOptions{Name: consts.Network}
+	}
+	netOpts := client.ProjectNetworkOptions(opts.Network, opts.Project)
+	return &netOpts
+}
+
+// ensureSidecarEndpoint attaches a reused, running sidecar to its network
+// under its aliases when it is not on it — one kept from before per-project
+// networks, or whose network was removed while it was stopped.
+func ensureSidecarEndpoint(ctx context.Context, opts StartSidecarsOptions, name, containerID string) error {
+	client := opts.Client
+	netName := sidecarNetwork(opts)
+	if _, err := client.EnsureNetwork(ctx, *sidecarEnsureNetwork(client, opts)); err != nil {
+		return fmt.Errorf("ensuring network: %w", err)
+	}
+	info, err := client.NetworkInspectManaged(ctx, netName, docker.NetworkInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("inspecting network: %w", err)
+	}
+	if _, ok := info.Network.Containers[containerID]; ok {
+		return nil
+	}
+	if _, err := client.NetworkConnectWithAlias(ctx, netName, containerID, sidecarAliases(name)...); err != nil {
+		return fmt.Errorf("connecting to %s: %w", netName, err)
+	}
+	return nil
+}
+
+// sidecarContainerConfig builds the Docker configs for a sidecars: entry.
+func sidecarContainerConfig(sc config.SidecarConfig) (*container.Config, *container.HostConfig, error) {
+	ports := NewPortOpts()
+	for _, spec := range sc.Ports {
+		if err := ports.Set(spec); err != nil {
+			return nil, nil, fmt.Errorf("ports: %w", err)
+		}
+	}
+
+	env := make([]string, 0, len(sc.Env))
+	for _, k := range slices.Sorted(maps.Keys(sc.Env)) {
+		env = append(env, k+"="+sc.Env[k])
+	}
+
+	cfg := &container.Config{
+		Image:        sc.Image,
+		Env:          env,
+		ExposedPorts: ports.GetExposedPorts(),
+	}
+	if hc := sc.Healthcheck; hc != nil && hc.Command != "" {
+		cfg.Healthcheck = &container.HealthConfig{
+			Test:        []string{"CMD-SHELL", hc.Command},
+			Interval:    hc.Interval,
+			Timeout:     hc.Timeout,
+			Retries:     hc.Retries,
+			StartPeriod: hc.StartPeriod,
+		}
+	}
+	hostCfg := &container.HostConfig{PortBindings: ports.GetPortBindings()}
+	return cfg, hostCfg, nil
+}
+
+// sidecarConfigHash fingerprints a sidecars: entry so a changed entry
+// replaces the container instead of silently reusing it.
+func sidecarConfigHash(sc config.SidecarConfig) (string, error) {
+	data, err := json.Marshal(sc)
+	if err != nil {
+		return "", fmt.Errorf("hashing sidecar config: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// waitSidecarReady polls a sidecar until it is healthy — running, for a
+// sidecar without a healthcheck — and returns its address on network.
+func waitSidecarReady(ctx context.Context, client *docker.Client, containerID, network string) (netip.Addr, error) {
+	for {
+		info, err := client.ContainerInspect(ctx, containerID, docker.ContainerInspectOptions{})
+		if err != nil {
+			if ctx.Err() != nil {
+				return netip.Addr{}, ctx.Err()
+			}
+			return netip.Addr{}, fmt.Errorf("inspecting container: %w", err)
+		}
+		c := info.Container
+		if state := c.State; state != nil {
+			switch {
+			case !state.Running:
+				return netip.Addr{}, fmt.Errorf("exited with code %d before becoming healthy; see 'docker logs %s'",
+					state.ExitCode, containerID[:min(12, len(containerID))])
+			case state.Health == nil || state.Health.Status == container.NoHealthcheck || state.Health.Status == container.Healthy:
+				return sidecarAddr(c, network)
+			case state.Health.Status == container.Unhealthy:
+				return netip.Addr{}, errors.New("reported unhealthy")
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return netip.Addr{}, ctx.Err()
+		case <-time.After(sidecarPollInterval):
+		}
+	}
+}
+
+// sidecarAddr returns a sidecar's address on network.
+func sidecarAddr(c container.InspectResponse, network string) (netip.Addr, error) {
+	if c.NetworkSettings != nil {
+		if ep, ok := c.NetworkSettings.Networks[network]; ok && ep.IPAddress.IsValid() {
+			return ep.IPAddress, nil
+		}
+	}
+	return netip.Addr{}, fmt.Errorf("no %s address", network)
+}
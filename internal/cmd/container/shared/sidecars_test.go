@@ -0,0 +1,198 @@
+package shared
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+	mobyClient "github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+)
+
+func TestSidecarContainerConfig(t *testing.T) {
+	cfg, hostCfg, err := sidecarContainerConfig(config.SidecarConfig{
+		Image: "postgres:16",
+		Env:   map[string]string{"POSTGRES_USER": "dev", "POSTGRES_PASSWORD": "dev"},
+		Ports: []string{"127.0.0.1:5432:5432"},
+		Healthcheck: &config.SidecarHealthcheck{
+			Command:  "pg_isready",
+			Interval: 2 * time.Second,
+			Retries:  5,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "postgres:16", cfg.Image)
+	assert.Equal(t, []string{"POSTGRES_PASSWORD=dev", "POSTGRES_USER=dev"}, cfg.Env)
+	assert.Equal(t, []string{"CMD-SHELL", "pg_isready"}, cfg.Healthcheck.Test)
+	assert.Equal(t, 2*time.Second, cfg.Healthcheck.Interval)
+	assert.Equal(t, 5, cfg.Healthcheck.Retries)
+	port := network.MustParsePort("5432/tcp")
+	assert.Contains(t, cfg.ExposedPorts, port)
+	assert.Equal(t, "5432", hostCfg.PortBindings[port][0].HostPort)
+
+	_, _, err = sidecarContainerConfig(config.SidecarConfig{Image: "redis", Ports: []string{"nope"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ports")
+}
+
+// setupSidecarInspect makes every inspected container a running, managed
+// sidecar with the given health status and address on net.
+func setupSidecarInspect(fake *mocks.FakeClient, health container.HealthStatus, net, ip string) {
+	fake.FakeAPI.ContainerInspectFn = func(_ context.Context, id string, _ mobyClient.ContainerInspectOptions) (mobyClient.ContainerInspectResult, error) {
+		return mobyClient.ContainerInspectResult{
+			Container: container.InspectResponse{
+				ID:     id,
+				Config: &container.Config{Labels: map[string]string{fake.Cfg.LabelManaged(): fake.Cfg.ManagedLabelValue()}},
+				State: &container.State{
+					Running: true,
+					Health:  &container.Health{Status: health},
+				},
+				NetworkSettings: &container.NetworkSettings{
+					Networks: map[string]*network.EndpointSettings{
+						net: {IPAddress: netip.MustParseAddr(ip)},
+					},
+				},
+			},
+		}, nil
+	}
+}
+
+// setupSidecarNetwork makes net a managed network with endpoints for the
+// attached container IDs.
+func setupSidecarNetwork(fake *mocks.FakeClient, net string, attached ...string) {
+	fake.FakeAPI.NetworkInspectFn = func(_ context.Context, name string, _ mobyClient.NetworkInspectOptions) (mobyClient.NetworkInspectResult, error) {
+		eps := make(map[string]network.EndpointResource, len(attached))
+		for _, id := range attached {
+			eps[id] = network.EndpointResource{}
+		}
+		return mobyClient.NetworkInspectResult{Network: network.Inspect{
+			Network:    network.Network{Name: name, ID: "net-" + name, Labels: map[string]string{fake.Cfg.LabelManaged(): fake.Cfg.ManagedLabelValue()}},
+			Containers: eps,
+		}}, nil
+	}
+}
+
+func TestStartSidecars(t *testing.T) {
+	sidecars := map[string]config.SidecarConfig{"db": {Image: "postgres:16"}}
+
+	t.Run("creates on the project network and waits for health", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fake.SetupContainerList()
+		fake.SetupImageExists("postgres:16", true)
+		fake.SetupNetworkExists("clawker-myapp", true)
+		fake.SetupContainerStart()
+		setupSidecarInspect(fake, container.Healthy, "clawker-myapp", "172.19.0.9")
+		var created mobyClient.ContainerCreateOptions
+		fake.FakeAPI.ContainerCreateFn = func(_ context.Context, opts mobyClient.ContainerCreateOptions) (mobyClient.ContainerCreateResult, error) {
+			created = opts
+			return mobyClient.ContainerCreateResult{ID: mocks.FakeContainerID}, nil
+		}
+
+		started, err := StartSidecars(context.Background(), StartSidecarsOptions{
+			Client: fake.Client, Project: "myapp", Agent: "dev", Network: "clawker-myapp", Sidecars: sidecars,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"db:172.19.0.9", "db.clawker.internal:172.19.0.9"}, SidecarHosts(started))
+		assert.Equal(t, "clawker.myapp.dev.sidecar-db", created.Name)
+		assert.Equal(t, "db", created.Config.Labels[consts.LabelSidecar])
+		assert.Equal(t, "dev", created.Config.Labels[consts.LabelSidecarAgent])
+		assert.Empty(t, created.Config.Labels[consts.LabelAgent])
+		assert.Contains(t, created.NetworkingConfig.EndpointsConfig, "clawker-myapp")
+		assert.NotContains(t, created.NetworkingConfig.EndpointsConfig, consts.Network)
+		assert.Equal(t, []string{"db", "db.clawker.internal"}, created.NetworkingConfig.EndpointsConfig["clawker-myapp"].Aliases)
+	})
+
+	t.Run("unhealthy fails", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fake.SetupContainerList()
+		fake.SetupImageExists("postgres:16", true)
+		fake.SetupNetworkExists(consts.Network, true)
+		fake.SetupContainerCreate()
+		fake.SetupContainerStart()
+		setupSidecarInspect(fake, container.Unhealthy, consts.Network, "172.18.0.9")
+
+		_, err := StartSidecars(context.Background(), StartSidecarsOptions{
+			Client: fake.Client, Project: "myapp", Agent: "dev", Sidecars: sidecars,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "sidecar db: reported unhealthy")
+	})
+
+	existing := func(t *testing.T, fake *mocks.FakeClient) {
+		t.Helper()
+		hash, err := sidecarConfigHash(sidecars["db"])
+		require.NoError(t, err)
+		fake.SetupContainerList(container.Summary{
+			ID:    "existing0000",
+			State: container.StateExited,
+			Labels: map[string]string{
+				fake.Cfg.LabelManaged():  fake.Cfg.ManagedLabelValue(),
+				fake.Cfg.LabelProject():  "myapp",
+				consts.LabelSidecar:      "db",
+				consts.LabelSidecarAgent: "dev",
+				consts.LabelContentHash:  hash,
+			},
+		})
+	}
+
+	t.Run("unchanged sidecar is started in place", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		existing(t, fake)
+		fake.SetupContainerStart()
+		setupSidecarNetwork(fake, consts.Network, "existing0000")
+		setupSidecarInspect(fake, container.Healthy, consts.Network, "172.18.0.9")
+
+		started, err := StartSidecars(context.Background(), StartSidecarsOptions{
+			Client: fake.Client, Project: "myapp", Agent: "dev", Sidecars: sidecars,
+		})
+		require.NoError(t, err)
+		require.Len(t, started, 1)
+		assert.Equal(t, "existing0000", started[0].ContainerID)
+		fake.AssertCalled(t, "ContainerStart")
+		fake.AssertNotCalled(t, "ContainerCreate")
+		fake.AssertNotCalled(t, "NetworkConnect")
+	})
+
+	t.Run("reused sidecar off its network is connected with aliases", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		existing(t, fake)
+		fake.SetupContainerStart()
+		setupSidecarNetwork(fake, "clawker-myapp")
+		setupSidecarInspect(fake, container.Healthy, "clawker-myapp", "172.19.0.9")
+		var connected mobyClient.NetworkConnectOptions
+		fake.FakeAPI.NetworkConnectFn = func(_ context.Context, _ string, opts mobyClient.NetworkConnectOptions) (mobyClient.NetworkConnectResult, error) {
+			connected = opts
+			return mobyClient.NetworkConnectResult{}, nil
+		}
+
+		_, err := StartSidecars(context.Background(), StartSidecarsOptions{
+			Client: fake.Client, Project: "myapp", Agent: "dev", Network: "clawker-myapp", Sidecars: sidecars,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "existing0000", connected.Container)
+		assert.Equal(t, []string{"db", "db.clawker.internal"}, connected.EndpointConfig.Aliases)
+	})
+
+	t.Run("missing image is rejected", func(t *testing.T) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fake.SetupContainerList()
+
+		_, err := StartSidecars(context.Background(), StartSidecarsOptions{
+			Client: fake.Client, Project: "myapp", Agent: "dev",
+			Sidecars: map[string]config.SidecarConfig{"db": {}},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "sidecars.db.image is required")
+	})
+}
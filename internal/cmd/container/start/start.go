@@ -23,6 +23,7 @@ import (
 	"github.com/schmitthub/clawker/internal/project"
 	"github.com/schmitthub/clawker/internal/signals"
 	"github.com/schmitthub/clawker/internal/socketbridge"
+	"github.com/schmitthub/clawker/internal/tui"
 )
 
 // StartOptions holds options for the start command.
@@ -36,11 +37,15 @@ type StartOptions struct {
 	AdminClient    func(context.Context) (adminv1.AdminServiceClient, error)
 	SocketBridge   func() socketbridge.SocketBridgeManager
 	Logger         func() (*logger.Logger, error)
+	TUI            *tui.TUI
 
 	Agent       bool // Use agent name (resolves to clawker.<project>.<agent>)
 	Attach      bool
 	Containers  []string
 	Interactive bool
+
+	// pick chooses the agent when none is named; TUI.RunPicker when nil.
+	pick shared.AgentPicker
 }
 
 // NewCmdStart creates the container start command.
@@ -55,20 +60,29 @@ func NewCmdStart(f *cmdutil.Factory, runF func(context.Context, *StartOptions) e
 		AdminClient:    f.AdminClient,
 		SocketBridge:   f.SocketBridge,
 		Logger:         f.Logger,
+		TUI:            f.TUI,
 	}
 
 	cmd := &cobra.Command{
-		Use:   "start [OPTIONS] CONTAINER [CONTAINER...]",
+		Use:   "start [OPTIONS] [CONTAINER...]",
 		Short: "Start one or more stopped containers",
 		Long: `Starts one or more stopped clawker containers.
 
 When --agent is provided, the container name is resolved as clawker.<project>.<agent>
 if you are within a registered (clawker project init) project directory.
 
+With no container, the stopped agents of the current project are
+candidates: a single one is started, several open a picker. Without
+a terminal several candidates are an error; with --no-input a container
+is required.
+
 Container names can be:
   - Full name: clawker.myproject.myagent
   - Container ID: abc123...`,
-		Example: `  # Start a stopped container by full name
+		Example: `  # Pick one of the project's stopped agents to start
+  clawker container start
+
+  # Start a stopped container by full name
   clawker container start clawker.myapp.dev
 
   # Start a container using agent name (resolves via project config)
@@ -79,7 +93,7 @@ Container names can be:
 
   # Start and attach your terminal
   clawker container start -ia clawker.myapp.dev`,
-		Args: cmdutil.RequiresMinArgs(1),
+		Args: shared.AgentArgs(f.IOStreams, cmdutil.RequiresMinArgs(1), false),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Containers = args
 			if runF != nil {
@@ -93,6 +107,8 @@ Container names can be:
 	cmd.Flags().BoolVarP(&opts.Attach, "attach", "a", false, "Attach STDOUT/STDERR and forward signals")
 	cmd.Flags().BoolVarP(&opts.Interactive, "interactive", "i", false, "Attach container's STDIN")
 
+	cmd.ValidArgsFunction = cmdutil.ContainerCompletions(f.Client, f.ProjectManager)
+
 	return cmd
 }
 
@@ -116,9 +132,31 @@ func startRun(ctx context.Context, opts *StartOptions) error {
 		return fmt.Errorf("connecting to Docker: %w", err)
 	}
 
-	// Resolve container names if --agent provided
+	// Resolve container names if --agent provided; pick one if none given
 	containers := opts.Containers
-	if opts.Agent {
+	if len(containers) == 0 {
+		pick := opts.pick
+		if pick == nil {
+			pick = opts.TUI.RunPicker
+		}
+		name, err := shared.PickAgentContainer(ctx, shared.PickAgentOptions{
+			IOStreams:      ios,
+			Client:         client,
+			Pick:           pick,
+			ProjectManager: opts.ProjectManager,
+			States:         []string{"created", "exited"},
+			Verb:           "start",
+			Command:        "clawker container start",
+		})
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			fmt.Fprintln(ios.ErrOut, "Aborted.")
+			return nil
+		}
+		containers = []string{name}
+	} else if opts.Agent {
 		var projectName string
 		if pm, err := opts.ProjectManager(); err == nil {
 			if p, err := pm.CurrentProject(ctx); err == nil {
@@ -234,6 +272,9 @@ func attachAndStart(
 	}
 	defer hijacked.Close()
 	log.Debug().Msg("container attach succeeded")
+	if pty != nil {
+		defer shared.HostAttachHub(pty, containerID, log)()
+	}
 
 	// Set up wait channel for container exit following Docker CLI's waitExitOrRemoved pattern.
 	// Must use WaitConditionNextExit (not WaitConditionNotRunning) because this is called
@@ -309,6 +350,9 @@ func attachAndStart(
 
 			// Monitor for window resize events (SIGWINCH)
 			resizeHandler := signals.NewResizeHandler(resizeFunc, pty.GetSize)
+			resizeHandler.SetErrorHandler(func(err error) {
+				log.Debug().Err(err).Msg("failed to resize container TTY")
+			})
 			resizeHandler.Start()
 			defer resizeHandler.Stop()
 		}
@@ -417,6 +461,7 @@ func startContainersWithoutAttach(
 				AdminClient:  opts.AdminClient,
 				SocketBridge: opts.SocketBridge,
 				Logger:       opts.Logger,
+				HookOut:      ios.ErrOut,
 			},
 			docker.ContainerStartOptions{
 				ContainerID: name,
@@ -12,6 +12,7 @@ import (
 	cpbootmocks "github.com/schmitthub/clawker/controlplane/manager/mocks"
 	"github.com/schmitthub/clawker/internal/cmd/container/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -105,7 +106,13 @@ func TestNewCmdStart(t *testing.T) {
 			},
 		},
 		{
-			name:       "no container specified",
+			name:     "no container leaves the agent to the picker",
+			args:     []string{},
+			wantOpts: StartOptions{Containers: []string{}},
+		},
+		{
+			name:       "no container with no-input",
+			input:      "--no-input",
 			args:       []string{},
 			wantErr:    true,
 			wantErrMsg: "requires at least 1 argument",
@@ -146,6 +153,8 @@ func TestNewCmdStart(t *testing.T) {
 			})
 
 			cmd.Flags().BoolP("help", "x", false, "")
+			// Root persistent flag, read by the Args check.
+			cmd.Flags().Bool("no-input", false, "")
 
 			argv := tt.args
 			if tt.input != "" {
@@ -177,10 +186,10 @@ func TestNewCmdStart(t *testing.T) {
 }
 
 func TestCmdStart_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdStart(f, nil)
 
-	require.Equal(t, "start [OPTIONS] CONTAINER [CONTAINER...]", cmd.Use)
+	require.Equal(t, "start [OPTIONS] [CONTAINER...]", cmd.Use)
 	require.NotEmpty(t, cmd.Short)
 	require.NotEmpty(t, cmd.Long)
 	require.NotEmpty(t, cmd.Example)
@@ -198,26 +207,15 @@ func TestCmdStart_Properties(t *testing.T) {
 
 func testStartFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return configmocks.NewFromString(`security: { enable_host_proxy: false }`, `firewall: { enable: false }`), nil
-		},
-		HostProxy: func() hostproxy.Service {
-			return hostproxytest.NewMockManager()
-		},
-		ControlPlane: func() cpmanager.Manager {
-			return &cpbootmocks.ManagerMock{
-				EnsureRunningFunc: func(context.Context) error { return nil },
-			}
-		},
-	}, in, out, errOut
+	tf := cmdutiltest.NewFactory(t,
+		cmdutiltest.WithFakeClient(fake),
+		cmdutiltest.WithConfig(configmocks.NewFromString(`security: { enable_host_proxy: false }`, `firewall: { enable: false }`)),
+	)
+	tf.HostProxy = func() hostproxy.Service {
+		return hostproxytest.NewMockManager()
+	}
+	tf.ControlPlane = cmdutiltest.RunningControlPlane()
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 // setupContainerStart configures the fake for the non-attach container start path.
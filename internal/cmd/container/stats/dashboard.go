@@ -0,0 +1,217 @@
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// statsDiscoverInterval is how often the collector re-lists running
+// containers when watching every managed container. A var so tests can
+// shorten it.
+var statsDiscoverInterval = 2 * time.Second
+
+// ---------------------------------------------------------------------------
+// Events
+// ---------------------------------------------------------------------------
+
+// statsSampleEvent carries a fresh sample for one container.
+type statsSampleEvent struct {
+	entry statsEntry
+}
+
+// statsGoneEvent reports that a container's stream ended, usually because it
+// stopped.
+type statsGoneEvent struct {
+	id string
+}
+
+// ---------------------------------------------------------------------------
+// Collector
+// ---------------------------------------------------------------------------
+
+// statsCollector streams stats for a set of containers into events. With
+// discover set it re-lists running managed containers every
+// statsDiscoverInterval, so containers started later join the dashboard and
+// stopped ones leave it.
+type statsCollector struct {
+	client   *docker.Client
+	events   chan<- any
+	discover bool
+
+	mu     sync.Mutex
+	active map[string]bool
+}
+
+func newStatsCollector(client *docker.Client, events chan<- any, discover bool) *statsCollector {
+	return &statsCollector{
+		client:   client,
+		events:   events,
+		discover: discover,
+		active:   make(map[string]bool),
+	}
+}
+
+// run streams the given containers (id → name) until ctx is cancelled.
+func (c *statsCollector) run(ctx context.Context, containers map[string]string) {
+	for id, name := range containers {
+		c.watch(ctx, id, name)
+	}
+	if !c.discover {
+		return
+	}
+
+	ticker := time.NewTicker(statsDiscoverInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			running, err := c.client.ContainerListRunning(ctx)
+			if err != nil {
+				continue
+			}
+			for _, s := range running {
+				c.watch(ctx, s.ID, summaryName(s))
+			}
+		}
+	}
+}
+
+// watch starts streaming a container unless it is already streamed.
+func (c *statsCollector) watch(ctx context.Context, id, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.active[id] {
+		return
+	}
+	c.active[id] = true
+	go c.stream(ctx, id, name)
+}
+
+func (c *statsCollector) stream(ctx context.Context, id, name string) {
+	defer func() {
+		c.mu.Lock()
+		delete(c.active, id)
+		c.mu.Unlock()
+		c.send(ctx, statsGoneEvent{id: id})
+	}()
+
+	reader, err := c.client.ContainerStats(ctx, id, true)
+	if err != nil {
+		return
+	}
+	defer reader.Body.Close()
+
+	decoder := json.NewDecoder(reader.Body)
+	for {
+		var stats container.StatsResponse
+		if err := decoder.Decode(&stats); err != nil {
+			return
+		}
+		if !c.send(ctx, statsSampleEvent{entry: newStatsEntry(id, name, &stats)}) {
+			return
+		}
+	}
+}
+
+// send delivers ev unless ctx is cancelled first.
+func (c *statsCollector) send(ctx context.Context, ev any) bool {
+	select {
+	case c.events <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// summaryName returns a container's name without the leading slash.
+func summaryName(s container.Summary) string {
+	if len(s.Names) == 0 {
+		return s.ID
+	}
+	return strings.TrimPrefix(s.Names[0], "/")
+}
+
+// ---------------------------------------------------------------------------
+// Board state and dashboard renderer (implements tui.DashboardRenderer)
+// ---------------------------------------------------------------------------
+
+// statsBoard holds the latest sample per container.
+type statsBoard struct {
+	noTrunc bool
+	entries map[string]statsEntry
+}
+
+func newStatsBoard(noTrunc bool) *statsBoard {
+	return &statsBoard{noTrunc: noTrunc, entries: make(map[string]statsEntry)}
+}
+
+func (b *statsBoard) ProcessEvent(ev any) {
+	switch e := ev.(type) {
+	case statsSampleEvent:
+		b.entries[e.entry.ID] = e.entry
+	case statsGoneEvent:
+		delete(b.entries, e.id)
+	}
+}
+
+// rows returns the table rows ordered by container name.
+func (b *statsBoard) rows() [][]string {
+	entries := make([]statsEntry, 0, len(b.entries))
+	for _, e := range b.entries {
+		entries = append(entries, e)
+	}
+	slices.SortFunc(entries, func(a, b statsEntry) int { return strings.Compare(a.Name, b.Name) })
+
+	rows := make([][]string, len(entries))
+	for i, e := range entries {
+		rows[i] = e.cells(b.noTrunc)
+	}
+	return rows
+}
+
+func (b *statsBoard) View(cs *iostreams.ColorScheme, width int) string {
+	var buf strings.Builder
+
+	buf.WriteString(tui.RenderDashHeader(cs, tui.DashHeaderConfig{
+		Title:    "Container Stats",
+		Subtitle: fmt.Sprintf("%d running", len(b.entries)),
+		Width:    width,
+	}))
+	buf.WriteString("\n\n")
+
+	rows := b.rows()
+	if len(rows) == 0 {
+		buf.WriteString(cs.Muted("  Waiting for running containers...") + "\n\n")
+		return buf.String()
+	}
+
+	var table strings.Builder
+	tw := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(statsColumns, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+
+	for i, line := range strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n") {
+		if i == 0 {
+			line = cs.Bold(line)
+		}
+		buf.WriteString("  " + line + "\n")
+	}
+	buf.WriteByte('\n')
+	return buf.String()
+}
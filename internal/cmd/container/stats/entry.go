@@ -0,0 +1,119 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moby/moby/api/types/container"
+
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/text"
+)
+
+// statsEntry is one container's usage sample with docker stats' derived
+// figures. It is the shape exposed to --json and --format templates.
+type statsEntry struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	CPUPercent    float64 `json:"cpuPercent"`
+	MemoryUsage   uint64  `json:"memoryUsage"`
+	MemoryLimit   uint64  `json:"memoryLimit"`
+	MemoryPercent float64 `json:"memoryPercent"`
+	NetRx         uint64  `json:"netRx"`
+	NetTx         uint64  `json:"netTx"`
+	BlockRead     uint64  `json:"blockRead"`
+	BlockWrite    uint64  `json:"blockWrite"`
+	PIDs          uint64  `json:"pids"`
+}
+
+// statsHeader titles the columns of a "table" --format template.
+var statsHeader = statsHeaderRow{
+	ID:            "CONTAINER ID",
+	Name:          "NAME",
+	CPUPercent:    "CPU %",
+	MemoryUsage:   "MEM USAGE",
+	MemoryLimit:   "MEM LIMIT",
+	MemoryPercent: "MEM %",
+	NetRx:         "NET RX",
+	NetTx:         "NET TX",
+	BlockRead:     "BLOCK READ",
+	BlockWrite:    "BLOCK WRITE",
+	PIDs:          "PIDS",
+	CPUPerc:       "CPU %",
+	MemUsage:      "MEM USAGE / LIMIT",
+	MemPerc:       "MEM %",
+	NetIO:         "NET I/O",
+	BlockIO:       "BLOCK I/O",
+}
+
+// statsHeaderRow mirrors statsEntry's fields and formatting methods with
+// column titles as values.
+type statsHeaderRow struct {
+	ID, Name, CPUPercent, MemoryUsage, MemoryLimit, MemoryPercent string
+	NetRx, NetTx, BlockRead, BlockWrite, PIDs                     string
+	CPUPerc, MemUsage, MemPerc, NetIO, BlockIO                    string
+}
+
+// newStatsEntry derives the displayed figures from a raw stats sample.
+func newStatsEntry(id, name string, stats *container.StatsResponse) statsEntry {
+	e := statsEntry{
+		ID:          id,
+		Name:        name,
+		CPUPercent:  docker.CPUPercent(stats),
+		MemoryUsage: docker.MemoryUsageNoCache(stats.MemoryStats),
+		MemoryLimit: stats.MemoryStats.Limit,
+		PIDs:        stats.PidsStats.Current,
+	}
+	if e.MemoryLimit > 0 {
+		e.MemoryPercent = float64(e.MemoryUsage) / float64(e.MemoryLimit) * 100.0
+	}
+	for _, n := range stats.Networks {
+		e.NetRx += n.RxBytes
+		e.NetTx += n.TxBytes
+	}
+	for _, entry := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch {
+		case strings.EqualFold(entry.Op, "read"):
+			e.BlockRead += entry.Value
+		case strings.EqualFold(entry.Op, "write"):
+			e.BlockWrite += entry.Value
+		}
+	}
+	return e
+}
+
+// CPUPerc formats CPUPercent as docker stats does.
+func (e statsEntry) CPUPerc() string { return fmt.Sprintf("%.2f%%", e.CPUPercent) }
+
+// MemUsage formats memory usage against the limit.
+func (e statsEntry) MemUsage() string {
+	return fmt.Sprintf("%s / %s", text.FormatBytes(int64(e.MemoryUsage)), text.FormatBytes(int64(e.MemoryLimit)))
+}
+
+// MemPerc formats MemoryPercent as docker stats does.
+func (e statsEntry) MemPerc() string { return fmt.Sprintf("%.2f%%", e.MemoryPercent) }
+
+// NetIO formats received / transmitted bytes across all networks.
+func (e statsEntry) NetIO() string {
+	return fmt.Sprintf("%s / %s", text.FormatBytes(int64(e.NetRx)), text.FormatBytes(int64(e.NetTx)))
+}
+
+// BlockIO formats bytes read / written by block devices.
+func (e statsEntry) BlockIO() string {
+	return fmt.Sprintf("%s / %s", text.FormatBytes(int64(e.BlockRead)), text.FormatBytes(int64(e.BlockWrite)))
+}
+
+// cells renders the entry as a default table row.
+func (e statsEntry) cells(noTrunc bool) []string {
+	return []string{e.shortID(noTrunc), e.Name, e.CPUPerc(), e.MemUsage(), e.MemPerc(), e.NetIO(), e.BlockIO(), fmt.Sprintf("%d", e.PIDs)}
+}
+
+func (e statsEntry) shortID(noTrunc bool) string {
+	if !noTrunc && len(e.ID) > 12 {
+		return e.ID[:12]
+	}
+	return e.ID
+}
+
+// statsColumns are the default table's headers, matching docker stats.
+var statsColumns = []string{"CONTAINER ID", "NAME", "CPU %", "MEM USAGE / LIMIT", "MEM %", "NET I/O", "BLOCK I/O", "PIDS"}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"time"
 
 	"github.com/moby/moby/api/types/container"
@@ -27,6 +26,7 @@ type StatsOptions struct {
 	Agent      bool // if set to true, treat arguments as agent name
 	NoStream   bool
 	NoTrunc    bool
+	Format     *cmdutil.FormatFlags
 	Containers []string
 }
 
@@ -44,7 +44,18 @@ func NewCmdStats(f *cmdutil.Factory, runF func(context.Context, *StatsOptions) e
 		Short: "Display a live stream of container resource usage statistics",
 		Long: `Display a live stream of container resource usage statistics.
 
-When no containers are specified, shows stats for all running clawker containers.
+When no containers are specified, shows stats for all running clawker
+containers; on a terminal the dashboard refreshes every second, picks up
+containers as they start and drops them when they stop. Press q to quit.
+
+CPU % and MEM % are derived as docker stats derives them: CPU time against
+system time across online CPUs, and memory usage minus the reclaimable page
+cache against the limit.
+
+--json and --format take a single snapshot and require --no-stream. Template
+fields: .ID, .Name, .CPUPercent, .MemoryUsage, .MemoryLimit, .MemoryPercent,
+.NetRx, .NetTx, .BlockRead, .BlockWrite, .PIDs, and the formatted .CPUPerc,
+.MemUsage, .MemPerc, .NetIO, .BlockIO.
 
 When --agent is provided, the container name is resolved as clawker.<project>.<agent>
 using the project resolved from the current directory.
@@ -65,10 +76,19 @@ Container names can be:
   clawker container stats --no-stream
 
   # Show stats once for a specific container
-  clawker container stats --no-stream --agent dev`,
+  clawker container stats --no-stream --agent dev
+
+  # Snapshot of every container as JSON, for scripts
+  clawker stats --no-stream --json
+
+  # Custom columns
+  clawker stats --no-stream --format 'table {{.Name}}\t{{.CPUPerc}}\t{{.MemPerc}}'`,
 		Args: cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Containers = args
+			if !opts.NoStream && (!opts.Format.IsDefault() || opts.Format.Quiet) {
+				return cmdutil.FlagErrorf("--format, --json and --quiet require --no-stream")
+			}
 			if runF != nil {
 				return runF(cmd.Context(), opts)
 			}
@@ -79,6 +99,9 @@ Container names can be:
 	cmd.Flags().BoolVar(&opts.Agent, "agent", false, "Treat arguments as agent name (resolves to clawker.<project>.<agent>)")
 	cmd.Flags().BoolVar(&opts.NoStream, "no-stream", false, "Disable streaming stats and only pull the first result")
 	cmd.Flags().BoolVar(&opts.NoTrunc, "no-trunc", false, "Do not truncate output")
+	opts.Format = cmdutil.AddFormatFlags(cmd)
+
+	cmd.ValidArgsFunction = cmdutil.ContainerCompletions(f.Client, f.ProjectManager)
 
 	return cmd
 }
@@ -111,22 +134,19 @@ func statsRun(ctx context.Context, opts *StatsOptions) error {
 	}
 
 	// If no containers specified, get all running containers
-	if len(containers) == 0 {
+	discover := len(containers) == 0
+	if discover {
 		running, err := client.ContainerListRunning(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to list containers: %w", err)
 		}
 		for _, c := range running {
-			if len(c.Names) > 0 {
-				// Remove leading slash from container name
-				name := c.Names[0]
-				if len(name) > 0 && name[0] == '/' {
-					name = name[1:]
-				}
-				containers = append(containers, name)
-			}
+			containers = append(containers, summaryName(c))
 		}
 		if len(containers) == 0 {
+			if opts.Format.IsJSON() {
+				return opts.Format.WriteJSON(ios, "container.stats", []statsEntry{})
+			}
 			fmt.Fprintln(ios.ErrOut, "No running containers")
 			return nil
 		}
@@ -138,13 +158,13 @@ func statsRun(ctx context.Context, opts *StatsOptions) error {
 	}
 
 	// Streaming mode - continuously show stats
-	return streamStats(ctx, ios, client, containers, opts)
+	return streamStats(ctx, ios, client, containers, discover, opts)
 }
 
 func showStatsOnce(ctx context.Context, ios *iostreams.IOStreams, client *docker.Client, containers []string, opts *StatsOptions) error {
 	cs := ios.ColorScheme()
-	tp := opts.TUI.NewTable("CONTAINER ID", "NAME", "CPU %", "MEM USAGE / LIMIT", "MEM %", "NET I/O", "BLOCK I/O", "PIDS")
 
+	var entries []statsEntry
 	var errs []error
 	for _, name := range containers {
 		// Find container by name
@@ -177,12 +197,11 @@ func showStatsOnce(ctx context.Context, ios *iostreams.IOStreams, client *docker
 		}
 		statsReader.Body.Close()
 
-		// Format and add stats row
-		addStatsRow(tp, c.ID, name, &stats, opts)
+		entries = append(entries, newStatsEntry(c.ID, name, &stats))
 	}
 
-	if err := tp.Render(); err != nil {
-		return fmt.Errorf("failed to render output: %w", err)
+	if err := renderEntries(ios, opts, entries); err != nil {
+		return err
 	}
 
 	if len(errs) > 0 {
@@ -191,24 +210,46 @@ func showStatsOnce(ctx context.Context, ios *iostreams.IOStreams, client *docker
 	return nil
 }
 
-func streamStats(ctx context.Context, ios *iostreams.IOStreams, client *docker.Client, containers []string, opts *StatsOptions) error {
+// renderEntries writes a snapshot in the format the flags select.
+func renderEntries(ios *iostreams.IOStreams, opts *StatsOptions, entries []statsEntry) error {
+	switch {
+	case opts.Format.IsJSON():
+		if entries == nil {
+			entries = []statsEntry{}
+		}
+		if err := opts.Format.WriteJSON(ios, "container.stats", entries); err != nil {
+			return fmt.Errorf("writing json: %w", err)
+		}
+		return nil
+	case opts.Format.IsTemplate():
+		if err := cmdutil.ExecuteTemplateWithHeader(ios.Out, opts.Format.Template(), statsHeader, cmdutil.ToAny(entries)); err != nil {
+			return fmt.Errorf("executing template: %w", err)
+		}
+		return nil
+	case opts.Format.Quiet:
+		for _, e := range entries {
+			fmt.Fprintln(ios.Out, e.shortID(opts.NoTrunc))
+		}
+		return nil
+	}
+
+	tp := opts.TUI.NewTable(statsColumns...)
+	for _, e := range entries {
+		tp.AddRow(e.cells(opts.NoTrunc)...)
+	}
+	if err := tp.Render(); err != nil {
+		return fmt.Errorf("failed to render output: %w", err)
+	}
+	return nil
+}
+
+func streamStats(ctx context.Context, ios *iostreams.IOStreams, client *docker.Client, containers []string, discover bool, opts *StatsOptions) error {
 	cs := ios.ColorScheme()
 
 	// Create a cancellable context
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	// Channel to collect stats from all containers
-	type statResult struct {
-		ID    string
-		Name  string
-		Stats *container.StatsResponse
-		Err   error
-	}
-
-	// Use buffered channel to prevent goroutine blocking when context is cancelled
-	results := make(chan statResult, len(containers)*2)
-
 	// Pre-resolve container IDs to avoid repeated lookups in the display loop
 	containerIDs := make(map[string]string)
 	for _, name := range containers {
@@ -221,50 +262,24 @@ func streamStats(ctx context.Context, ios *iostreams.IOStreams, client *docker.C
 			fmt.Fprintf(ios.ErrOut, "%s %s: container not found\n", cs.WarningIcon(), name)
 			continue
 		}
-		containerIDs[name] = c.ID
+		containerIDs[c.ID] = name
 	}
 
 	if len(containerIDs) == 0 {
 		return fmt.Errorf("no valid containers found")
 	}
 
-	// Start goroutines for each container
-	for name, id := range containerIDs {
-		go func(containerName, containerID string) {
-			// Start streaming stats
-			reader, err := client.ContainerStats(ctx, containerID, true)
-			if err != nil {
-				select {
-				case results <- statResult{ID: containerID, Name: containerName, Err: err}:
-				case <-ctx.Done():
-				}
-				return
-			}
-			defer reader.Body.Close()
-
-			decoder := json.NewDecoder(reader.Body)
-			for {
-				var stats container.StatsResponse
-				if err := decoder.Decode(&stats); err != nil {
-					if err != io.EOF && ctx.Err() == nil {
-						select {
-						case results <- statResult{ID: containerID, Name: containerName, Err: err}:
-						case <-ctx.Done():
-						}
-					}
-					return
-				}
-				select {
-				case results <- statResult{ID: containerID, Name: containerName, Stats: &stats}:
-				case <-ctx.Done():
-					return
-				}
-			}
-		}(name, id)
+	events := make(chan any, 64)
+	go newStatsCollector(client, events, discover).run(ctx, containerIDs)
+
+	board := newStatsBoard(opts.NoTrunc)
+
+	// On a terminal, a refreshing dashboard; otherwise successive tables.
+	if ios.IsStdoutTTY() {
+		result := tui.RunDashboard(ios, board, tui.DashboardConfig{HelpText: "q quit"}, events)
+		return result.Err
 	}
 
-	// Track the last stats for each container
-	lastStats := make(map[string]*container.StatsResponse)
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 
@@ -272,104 +287,17 @@ func streamStats(ctx context.Context, ios *iostreams.IOStreams, client *docker.C
 		select {
 		case <-ctx.Done():
 			return nil
-		case result := <-results:
-			if result.Err != nil {
-				fmt.Fprintf(ios.ErrOut, "%s %s: %v\n", cs.FailureIcon(), result.Name, result.Err)
-				continue
-			}
-			lastStats[result.Name] = result.Stats
+		case ev := <-events:
+			board.ProcessEvent(ev)
 		case <-ticker.C:
-			// Clear screen and reprint
-			fmt.Fprint(ios.Out, "\033[H\033[2J")
-			tp := opts.TUI.NewTable("CONTAINER ID", "NAME", "CPU %", "MEM USAGE / LIMIT", "MEM %", "NET I/O", "BLOCK I/O", "PIDS")
-			for name, stats := range lastStats {
-				id := containerIDs[name]
-				addStatsRow(tp, id, name, stats, opts)
+			tp := opts.TUI.NewTable(statsColumns...)
+			for _, row := range board.rows() {
+				tp.AddRow(row...)
 			}
 			if err := tp.Render(); err != nil {
 				fmt.Fprintf(ios.ErrOut, "%s output render failed: %v\n", cs.WarningIcon(), err)
 			}
+			fmt.Fprintln(ios.Out)
 		}
 	}
 }
-
-func addStatsRow(tp *tui.TablePrinter, id, name string, stats *container.StatsResponse, opts *StatsOptions) {
-	// Format container ID
-	containerID := id
-	if !opts.NoTrunc && len(containerID) > 12 {
-		containerID = containerID[:12]
-	}
-
-	// Calculate CPU percentage
-	cpuPercent := calculateCPUPercent(stats)
-
-	// Calculate memory percentage and format usage
-	memUsage := stats.MemoryStats.Usage
-	memLimit := stats.MemoryStats.Limit
-	memPercent := 0.0
-	if memLimit > 0 {
-		memPercent = float64(memUsage) / float64(memLimit) * 100.0
-	}
-	memStr := fmt.Sprintf("%s / %s", formatBytes(memUsage), formatBytes(memLimit))
-
-	// Calculate network I/O
-	var rxBytes, txBytes uint64
-	for _, netStats := range stats.Networks {
-		rxBytes += netStats.RxBytes
-		txBytes += netStats.TxBytes
-	}
-	netStr := fmt.Sprintf("%s / %s", formatBytes(rxBytes), formatBytes(txBytes))
-
-	// Calculate block I/O
-	var blkRead, blkWrite uint64
-	for _, entry := range stats.BlkioStats.IoServiceBytesRecursive {
-		switch entry.Op {
-		case "read", "Read":
-			blkRead += entry.Value
-		case "write", "Write":
-			blkWrite += entry.Value
-		}
-	}
-	blkStr := fmt.Sprintf("%s / %s", formatBytes(blkRead), formatBytes(blkWrite))
-
-	// Get PIDs
-	pids := stats.PidsStats.Current
-
-	tp.AddRow(containerID, name, fmt.Sprintf("%.2f%%", cpuPercent), memStr, fmt.Sprintf("%.2f%%", memPercent), netStr, blkStr, fmt.Sprintf("%d", pids))
-}
-
-func calculateCPUPercent(stats *container.StatsResponse) float64 {
-	// Calculate the change for the CPU usage of the container between readings
-	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage - stats.PreCPUStats.CPUUsage.TotalUsage)
-
-	// Calculate the change for the entire system between readings
-	systemDelta := float64(stats.CPUStats.SystemUsage - stats.PreCPUStats.SystemUsage)
-
-	if systemDelta > 0.0 && cpuDelta > 0.0 {
-		cpuPercent := (cpuDelta / systemDelta) * float64(stats.CPUStats.OnlineCPUs) * 100.0
-		return cpuPercent
-	}
-	return 0.0
-}
-
-func formatBytes(bytes uint64) string {
-	const (
-		KB = 1024
-		MB = KB * 1024
-		GB = MB * 1024
-		TB = GB * 1024
-	)
-
-	switch {
-	case bytes >= TB:
-		return fmt.Sprintf("%.2fTB", float64(bytes)/TB)
-	case bytes >= GB:
-		return fmt.Sprintf("%.2fGB", float64(bytes)/GB)
-	case bytes >= MB:
-		return fmt.Sprintf("%.2fMB", float64(bytes)/MB)
-	case bytes >= KB:
-		return fmt.Sprintf("%.2fKB", float64(bytes)/KB)
-	default:
-		return fmt.Sprintf("%dB", bytes)
-	}
-}
@@ -3,12 +3,16 @@ package stats
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/shlex"
 	"github.com/moby/moby/api/types/container"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -82,11 +86,35 @@ func TestNewCmdStats(t *testing.T) {
 			wantArgs:   []string{"dev"},
 			needRes:    true,
 		},
+		{
+			name:       "json with no-stream",
+			input:      "--no-stream --json",
+			wantStream: true,
+			wantArgs:   []string{},
+		},
+		{
+			name:       "json requires no-stream",
+			input:      "--json",
+			wantErr:    true,
+			wantErrMsg: "--format, --json and --quiet require --no-stream",
+		},
+		{
+			name:       "template requires no-stream",
+			input:      "--format '{{.Name}}'",
+			wantErr:    true,
+			wantErrMsg: "--format, --json and --quiet require --no-stream",
+		},
+		{
+			name:       "quiet requires no-stream",
+			input:      "-q",
+			wantErr:    true,
+			wantErrMsg: "--format, --json and --quiet require --no-stream",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f := &cmdutil.Factory{}
+			f := cmdutiltest.NewFactory(t).Factory
 			if tt.needRes {
 				f.Config = func() (config.Config, error) {
 					return configmocks.NewBlankConfig(), nil
@@ -124,7 +152,7 @@ func TestNewCmdStats(t *testing.T) {
 }
 
 func TestCmdStats_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdStats(f, nil)
 
 	require.Equal(t, "stats [OPTIONS] [CONTAINER...]", cmd.Use)
@@ -144,7 +172,7 @@ func TestCmdStats_Properties(t *testing.T) {
 }
 
 func TestCmdStats_AllowsNoArgs(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	var gotOpts *StatsOptions
 	cmd := NewCmdStats(f, func(_ context.Context, opts *StatsOptions) error {
@@ -164,7 +192,7 @@ func TestCmdStats_AllowsNoArgs(t *testing.T) {
 }
 
 func TestCmdStats_MultipleContainers(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	var gotOpts *StatsOptions
 	cmd := NewCmdStats(f, func(_ context.Context, opts *StatsOptions) error {
@@ -183,134 +211,12 @@ func TestCmdStats_MultipleContainers(t *testing.T) {
 	require.Equal(t, []string{"container1", "container2", "container3"}, gotOpts.Containers)
 }
 
-func TestFormatBytes(t *testing.T) {
-	tests := []struct {
-		bytes    uint64
-		expected string
-	}{
-		{0, "0B"},
-		{512, "512B"},
-		{1024, "1.00KB"},
-		{1536, "1.50KB"},
-		{1048576, "1.00MB"},
-		{1073741824, "1.00GB"},
-		{1099511627776, "1.00TB"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.expected, func(t *testing.T) {
-			result := formatBytes(tt.bytes)
-			require.Equal(t, tt.expected, result)
-		})
-	}
-}
-
-func TestCalculateCPUPercent(t *testing.T) {
-	tests := []struct {
-		name           string
-		cpuUsage       uint64
-		preCPUUsage    uint64
-		systemUsage    uint64
-		preSystemUsage uint64
-		onlineCPUs     uint32
-		expected       float64
-	}{
-		{
-			name:           "zero delta - no usage",
-			cpuUsage:       1000,
-			preCPUUsage:    1000,
-			systemUsage:    2000,
-			preSystemUsage: 2000,
-			onlineCPUs:     4,
-			expected:       0.0,
-		},
-		{
-			name:           "zero system delta",
-			cpuUsage:       2000,
-			preCPUUsage:    1000,
-			systemUsage:    2000,
-			preSystemUsage: 2000,
-			onlineCPUs:     4,
-			expected:       0.0,
-		},
-		{
-			name:           "zero cpu delta",
-			cpuUsage:       1000,
-			preCPUUsage:    1000,
-			systemUsage:    3000,
-			preSystemUsage: 2000,
-			onlineCPUs:     4,
-			expected:       0.0,
-		},
-		{
-			name:           "normal usage single core",
-			cpuUsage:       2000000000,
-			preCPUUsage:    1000000000,
-			systemUsage:    20000000000,
-			preSystemUsage: 10000000000,
-			onlineCPUs:     1,
-			expected:       10.0,
-		},
-		{
-			name:           "normal usage multi core",
-			cpuUsage:       2000000000,
-			preCPUUsage:    1000000000,
-			systemUsage:    20000000000,
-			preSystemUsage: 10000000000,
-			onlineCPUs:     4,
-			expected:       40.0,
-		},
-		{
-			name:           "100% single core",
-			cpuUsage:       2000000000,
-			preCPUUsage:    1000000000,
-			systemUsage:    2000000000,
-			preSystemUsage: 1000000000,
-			onlineCPUs:     1,
-			expected:       100.0,
-		},
-		{
-			name:           "50% of 8 cores",
-			cpuUsage:       5000000000,
-			preCPUUsage:    1000000000,
-			systemUsage:    9000000000,
-			preSystemUsage: 1000000000,
-			onlineCPUs:     8,
-			expected:       400.0,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			stats := &container.StatsResponse{}
-			stats.CPUStats.CPUUsage.TotalUsage = tt.cpuUsage
-			stats.PreCPUStats.CPUUsage.TotalUsage = tt.preCPUUsage
-			stats.CPUStats.SystemUsage = tt.systemUsage
-			stats.PreCPUStats.SystemUsage = tt.preSystemUsage
-			stats.CPUStats.OnlineCPUs = tt.onlineCPUs
-
-			result := calculateCPUPercent(stats)
-			require.InDelta(t, tt.expected, result, 0.01)
-		})
-	}
-}
-
 // --- Tier 2 tests (Cobra+Factory, real run function) ---
 
 func testFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		TUI:       tui.NewTUI(tio),
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return configmocks.NewBlankConfig(), nil
-		},
-	}, in, out, errOut
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 // statsJSON is a realistic stats JSON for testing.
@@ -420,3 +326,145 @@ func TestStatsRun_NoRunningContainers(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, errOut.String(), "No running containers")
 }
+
+func TestNewStatsEntry(t *testing.T) {
+	var stats container.StatsResponse
+	require.NoError(t, json.Unmarshal([]byte(statsJSON), &stats))
+	stats.MemoryStats.Stats = map[string]uint64{"inactive_file": 10485760}
+	stats.BlkioStats.IoServiceBytesRecursive = []container.BlkioStatEntry{
+		{Op: "Read", Value: 100}, {Op: "write", Value: 50}, {Op: "Total", Value: 150},
+	}
+
+	e := newStatsEntry("abc", "clawker.myapp.dev", &stats)
+	assert.InDelta(t, 40.0, e.CPUPercent, 0.01)
+	assert.Equal(t, uint64(41943040), e.MemoryUsage)
+	assert.Equal(t, "40.00MB / 1.00GB", e.MemUsage())
+	assert.Equal(t, "3.91%", e.MemPerc())
+	assert.Equal(t, "1.00KB / 2.00KB", e.NetIO())
+	assert.Equal(t, "100B / 50B", e.BlockIO())
+	assert.Equal(t, uint64(5), e.PIDs)
+}
+
+func runStatsOnce(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	c := mocks.RunningContainerFixture("myapp", "dev")
+	fake.SetupFindContainer("clawker.myapp.dev", c)
+	fake.SetupContainerStats(statsJSON)
+
+	f, in, out, _ := testFactory(t, fake)
+	cmd := NewCmdStats(f, nil)
+	cmd.SetArgs(append([]string{"--no-stream"}, args...))
+	cmd.SetIn(in)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestStatsRun_NoStream_JSON(t *testing.T) {
+	out, err := runStatsOnce(t, "--format", "json")
+	require.NoError(t, err)
+
+	var entries []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "clawker.myapp.dev", entries[0]["name"])
+	assert.InDelta(t, 40.0, entries[0]["cpuPercent"], 0.01)
+	assert.EqualValues(t, 52428800, entries[0]["memoryUsage"])
+	assert.EqualValues(t, 5, entries[0]["pids"])
+}
+
+func TestStatsRun_NoStream_TableTemplate(t *testing.T) {
+	out, err := runStatsOnce(t, "--format", `table {{.Name}}\t{{.CPUPerc}}\t{{.PIDs}}`)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"NAME", "CPU", "%", "PIDS"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"clawker.myapp.dev", "40.00%", "5"}, strings.Fields(lines[1]))
+}
+
+func TestStatsRun_NoRunningContainers_JSON(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList()
+
+	f, in, out, _ := testFactory(t, fake)
+	cmd := NewCmdStats(f, nil)
+	cmd.SetArgs([]string{"--no-stream", "--format", "json"})
+	cmd.SetIn(in)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "[]\n", out.String())
+}
+
+func TestStatsBoard(t *testing.T) {
+	board := newStatsBoard(false)
+	board.ProcessEvent(statsSampleEvent{entry: statsEntry{ID: "bbbbbbbbbbbbbbbb", Name: "clawker.myapp.writer", CPUPercent: 1.5}})
+	board.ProcessEvent(statsSampleEvent{entry: statsEntry{ID: "aaaaaaaaaaaaaaaa", Name: "clawker.myapp.dev"}})
+	board.ProcessEvent(statsSampleEvent{entry: statsEntry{ID: "bbbbbbbbbbbbbbbb", Name: "clawker.myapp.writer", CPUPercent: 2.5}})
+
+	rows := board.rows()
+	require.Len(t, rows, 2)
+	assert.Equal(t, "aaaaaaaaaaaa", rows[0][0])
+	assert.Equal(t, "clawker.myapp.writer", rows[1][1])
+	assert.Equal(t, "2.50%", rows[1][2])
+
+	ios, _, _, _ := iostreams.Test()
+	view := board.View(ios.ColorScheme(), 120)
+	assert.Contains(t, view, "Container Stats")
+	assert.Contains(t, view, "2 running")
+	assert.Contains(t, view, "MEM USAGE / LIMIT")
+
+	board.ProcessEvent(statsGoneEvent{id: "bbbbbbbbbbbbbbbb"})
+	require.Len(t, board.rows(), 1)
+
+	board.ProcessEvent(statsGoneEvent{id: "aaaaaaaaaaaaaaaa"})
+	assert.Contains(t, board.View(ios.ColorScheme(), 120), "Waiting for running containers")
+}
+
+func TestStatsCollector_DiscoversContainers(t *testing.T) {
+	interval := statsDiscoverInterval
+	statsDiscoverInterval = 10 * time.Millisecond
+	t.Cleanup(func() { statsDiscoverInterval = interval })
+
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	c := mocks.RunningContainerFixture("myapp", "dev")
+	fake.SetupFindContainer("clawker.myapp.dev", c)
+	fake.SetupContainerStats(statsJSON)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := make(chan any, 16)
+	// Start with nothing: the container must be found by discovery.
+	go newStatsCollector(fake.Client, events, true).run(ctx, nil)
+
+	var sample statsSampleEvent
+	for sample.entry.ID == "" {
+		select {
+		case ev := <-events:
+			if s, ok := ev.(statsSampleEvent); ok {
+				sample = s
+			}
+		case <-ctx.Done():
+			t.Fatal("no sample received")
+		}
+	}
+	assert.Equal(t, c.ID, sample.entry.ID)
+	assert.Equal(t, "clawker.myapp.dev", sample.entry.Name)
+
+	// The fake's stream ends after one sample, as when a container stops.
+	for {
+		select {
+		case ev := <-events:
+			if g, ok := ev.(statsGoneEvent); ok {
+				assert.Equal(t, c.ID, g.id)
+				return
+			}
+		case <-ctx.Done():
+			t.Fatal("no gone event received")
+		}
+	}
+}
@@ -4,13 +4,18 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/moby/moby/api/types/container"
 	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/internal/cmd/container/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/session"
 	"github.com/schmitthub/clawker/internal/socketbridge"
+	"github.com/schmitthub/clawker/internal/tui"
 	"github.com/spf13/cobra"
 )
 
@@ -18,16 +23,21 @@ import (
 type StopOptions struct {
 	IOStreams      *iostreams.IOStreams
 	Client         func(context.Context) (*docker.Client, error)
+	Config         func() (config.Config, error)
 	ProjectManager func() (project.ProjectManager, error)
 	AdminClient    func(context.Context) (adminv1.AdminServiceClient, error)
 	SocketBridge   func() socketbridge.SocketBridgeManager
 	Logger         func() (*logger.Logger, error)
+	TUI            *tui.TUI
 
 	Agent   bool
 	Timeout int
 	Signal  string
 
 	Containers []string
+
+	// pick chooses the agent when none is named; TUI.RunPicker when nil.
+	pick shared.AgentPicker
 }
 
 // NewCmdStop creates the container stop command.
@@ -35,10 +45,12 @@ func NewCmdStop(f *cmdutil.Factory, runF func(context.Context, *StopOptions) err
 	opts := &StopOptions{
 		IOStreams:      f.IOStreams,
 		Client:         f.Client,
+		Config:         f.Config,
 		ProjectManager: f.ProjectManager,
 		AdminClient:    f.AdminClient,
 		SocketBridge:   f.SocketBridge,
 		Logger:         f.Logger,
+		TUI:            f.TUI,
 	}
 
 	cmd := &cobra.Command{
@@ -49,13 +61,25 @@ func NewCmdStop(f *cmdutil.Factory, runF func(context.Context, *StopOptions) err
 The container is sent a SIGTERM signal, then after a timeout period (default 10s),
 it is sent SIGKILL if still running.
 
+Once stopped, the harness's conversation state is saved to the host
+session store so a recreated container for the same agent can resume it
+(see 'clawker session'). Set persist_sessions: false under the harness in
+clawker.yaml to turn this off.
+
 When --agent is provided, the container names are resolved as clawker.<project>.<agent>
 using the project resolved from the current directory.
 
+With no container, the running agents of the current project open a
+picker — even a single one, so nothing is stopped without a choice. With
+--no-input, or without a terminal, a container is required.
+
 Container names can be:
   - Full name: clawker.myproject.myagent
   - Container ID: abc123...`,
-		Example: `  # Stop a container using agent name (resolves via project config)
+		Example: `  # Pick one of the project's running agents to stop
+  clawker container stop
+
+  # Stop a container using agent name (resolves via project config)
   clawker container stop --agent dev
 
   # Stop a container by full name (10s timeout)
@@ -66,7 +90,9 @@ Container names can be:
 
   # Stop with a custom timeout (20 seconds)
   clawker container stop --time 20 --agent dev`,
-		Args: cmdutil.RequiresMinArgs(1),
+		// Stopping a lone agent takes a choice, so without a terminal (or
+		// with --no-input) a container is required, as before the picker.
+		Args: shared.AgentArgs(f.IOStreams, cmdutil.RequiresMinArgs(1), true),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Containers = args
 			if runF != nil {
@@ -80,6 +106,8 @@ Container names can be:
 	cmd.Flags().IntVarP(&opts.Timeout, "time", "t", 10, "Seconds to wait before killing the container")
 	cmd.Flags().StringVarP(&opts.Signal, "signal", "s", "", "Signal to send (default: SIGTERM)")
 
+	cmd.ValidArgsFunction = cmdutil.ContainerCompletions(f.Client, f.ProjectManager)
+
 	return cmd
 }
 
@@ -91,9 +119,38 @@ func stopRun(ctx context.Context, opts *StopOptions) error {
 		return fmt.Errorf("initializing logger: %w", err)
 	}
 
-	// Resolve container names
+	// Connect to Docker
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+
+	// Resolve container names; pick one if none given
 	containers := opts.Containers
-	if opts.Agent {
+	if len(containers) == 0 {
+		pick := opts.pick
+		if pick == nil {
+			pick = opts.TUI.RunPicker
+		}
+		name, err := shared.PickAgentContainer(ctx, shared.PickAgentOptions{
+			IOStreams:      ios,
+			Client:         client,
+			Pick:           pick,
+			ProjectManager: opts.ProjectManager,
+			States:         []string{"running", "paused", "restarting"},
+			Verb:           "stop",
+			Command:        "clawker container stop",
+			Confirm:        true,
+		})
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			fmt.Fprintln(ios.ErrOut, "Aborted.")
+			return nil
+		}
+		containers = []string{name}
+	} else if opts.Agent {
 		var projectName string
 		if opts.ProjectManager != nil {
 			if pm, pmErr := opts.ProjectManager(); pmErr == nil {
@@ -108,11 +165,6 @@ func stopRun(ctx context.Context, opts *StopOptions) error {
 		}
 		containers = resolved
 	}
-	// Connect to Docker
-	client, err := opts.Client(ctx)
-	if err != nil {
-		return fmt.Errorf("connecting to Docker: %w", err)
-	}
 
 	var errs []error
 	for _, name := range containers {
@@ -174,6 +226,33 @@ func stopContainer(ctx context.Context, client *docker.Client, name string, opts
 
 	// Stop the container with timeout
 	timeout := opts.Timeout
-	_, err = client.ContainerStop(ctx, container.ID, &timeout)
+	if _, err = client.ContainerStop(ctx, container.ID, &timeout); err != nil {
+		return err
+	}
+
+	// Save the harness session (best-effort): the stop itself succeeded.
+	if err := saveSession(ctx, client, container, opts); err != nil {
+		log.Warn().Err(err).Str("container", container.ID).Msg("failed to save session")
+		fmt.Fprintf(ios.ErrOut, "%s session not saved for %s: %v\n", cs.WarningIcon(), name, err)
+	}
+	return nil
+}
+
+// saveSession saves the stopped container's harness session dirs to the
+// host session store.
+func saveSession(ctx context.Context, client *docker.Client, c *container.Summary, opts *StopOptions) error {
+	if opts.Config == nil {
+		return nil
+	}
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	_, err = shared.SaveSession(ctx, shared.SaveSessionOpts{
+		Store:     session.DefaultStore,
+		Config:    cfg,
+		Container: c,
+		Export:    client.ExportWorkspace,
+	})
 	return err
 }
@@ -11,6 +11,7 @@ import (
 	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
 	adminv1mocks "github.com/schmitthub/clawker/api/admin/v1/mocks"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -129,7 +130,7 @@ func TestNewCmdStop(t *testing.T) {
 }
 
 func TestCmdStop_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdStop(f, nil)
 
 	require.Equal(t, "stop [CONTAINER...]", cmd.Use)
@@ -148,6 +149,50 @@ func TestCmdStop_Properties(t *testing.T) {
 	require.Equal(t, 10, timeout)
 }
 
+func TestCmdStop_PickerNeedsPrompts(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "terminal leaves the agent to the picker"},
+		{name: "no-input requires a container", input: "--no-input", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+			f.IOStreams.SetStdinTTY(true)
+			f.IOStreams.SetStdoutTTY(true)
+
+			var called bool
+			cmd := NewCmdStop(f, func(_ context.Context, _ *StopOptions) error {
+				called = true
+				return nil
+			})
+			// Root persistent flag; cobra checks Args before the root
+			// PersistentPreRunE would apply it to IOStreams.
+			cmd.Flags().Bool("no-input", false, "")
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr {
+				require.ErrorContains(t, err, "stop: 'stop' requires at least 1 argument")
+				require.False(t, called)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, called)
+		})
+	}
+}
+
 // --- Tier 2: Cobra+Factory integration tests ---
 
 func TestStopRun_StopsBridge(t *testing.T) {
@@ -388,30 +433,19 @@ func TestStopRun_DockerConnectionError(t *testing.T) {
 
 func testFactory(t *testing.T, fake *mocks.FakeClient, mock *sockebridgemocks.SocketBridgeManagerMock, adminMock *adminv1mocks.AdminServiceClientMock) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-
-	f := &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return configmocks.NewBlankConfig(), nil
-		},
-	}
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
 
 	if mock != nil {
-		f.SocketBridge = func() socketbridge.SocketBridgeManager {
+		tf.SocketBridge = func() socketbridge.SocketBridgeManager {
 			return mock
 		}
 	}
 
 	if adminMock != nil {
-		f.AdminClient = func(_ context.Context) (adminv1.AdminServiceClient, error) {
+		tf.AdminClient = func(_ context.Context) (adminv1.AdminServiceClient, error) {
 			return adminMock, nil
 		}
 	}
 
-	return f, in, out, errOut
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/shlex"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -105,7 +106,7 @@ func TestNewCmdTop(t *testing.T) {
 }
 
 func TestCmdTop_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdTop(f, nil)
 
 	// Test command basics
@@ -117,7 +118,7 @@ func TestCmdTop_Properties(t *testing.T) {
 }
 
 func TestCmdTop_ArgsValidation(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	var gotOpts *TopOptions
 	cmd := NewCmdTop(f, func(_ context.Context, opts *TopOptions) error {
@@ -166,7 +167,7 @@ func TestCmdTop_ArgsParsing(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f := &cmdutil.Factory{}
+			f := cmdutiltest.NewFactory(t).Factory
 
 			var gotOpts *TopOptions
 			cmd := NewCmdTop(f, func(_ context.Context, opts *TopOptions) error {
@@ -192,18 +193,8 @@ func TestCmdTop_ArgsParsing(t *testing.T) {
 
 func testFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		TUI:       tui.NewTUI(tio),
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return configmocks.NewBlankConfig(), nil
-		},
-	}, in, out, errOut
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 func TestTopRun_HappyPath(t *testing.T) {
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/schmitthub/clawker/internal/cmd/container/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/project"
@@ -17,6 +19,7 @@ import (
 type UpdateOptions struct {
 	IOStreams      *iostreams.IOStreams
 	Client         func(context.Context) (*docker.Client, error)
+	Config         func() (config.Config, error)
 	ProjectManager func() (project.ProjectManager, error)
 
 	Agent              bool
@@ -45,6 +48,7 @@ func NewCmdUpdate(f *cmdutil.Factory, runF func(context.Context, *UpdateOptions)
 	opts := &UpdateOptions{
 		IOStreams:      f.IOStreams,
 		Client:         f.Client,
+		Config:         f.Config,
 		ProjectManager: f.ProjectManager,
 	}
 
@@ -54,7 +58,8 @@ func NewCmdUpdate(f *cmdutil.Factory, runF func(context.Context, *UpdateOptions)
 		Long: `Update configuration of one or more containers.
 
 This command updates the resource limits of containers that are already running
-or have been created but not yet started.
+or have been created but not yet started. Limits above the resources: cap in
+settings are refused.
 
 When --agent is provided, the container name is resolved as clawker.<project>.<agent>
 using the project resolved from the current directory.
@@ -111,6 +116,8 @@ Container names can be:
 	flags.Var(&opts.cpus, "cpus", "Number of CPUs")
 	_ = flags.SetAnnotation("cpus", "version", []string{"1.29"})
 
+	cmd.ValidArgsFunction = cmdutil.ContainerCompletions(f.Client, f.ProjectManager)
+
 	return cmd
 }
 
@@ -144,6 +151,15 @@ func updateRun(ctx context.Context, opts *UpdateOptions) error {
 
 	// Build update resources
 	resources, restartPolicy := buildUpdateResources(opts)
+	if opts.Config != nil {
+		cfg, err := opts.Config()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if err := shared.EnforceResourceCap(resources, cfg.Settings().Resources, false); err != nil {
+			return err
+		}
+	}
 
 	cs := ios.ColorScheme()
 	var errs []error
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/shlex"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -199,7 +200,7 @@ func TestNewCmdUpdate(t *testing.T) {
 }
 
 func TestCmdUpdate_Properties(t *testing.T) {
-	f := &cmdutil.Factory{}
+	f := cmdutiltest.NewFactory(t).Factory
 	cmd := NewCmdUpdate(f, nil)
 
 	require.Equal(t, "update [OPTIONS] [CONTAINER...]", cmd.Use)
@@ -289,18 +290,8 @@ func int64Ptr(v int64) *int64 {
 
 func testUpdateFactory(t *testing.T, fake *mocks.FakeClient) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer, *bytes.Buffer) {
 	t.Helper()
-	tio, in, out, errOut := iostreams.Test()
-
-	return &cmdutil.Factory{
-		IOStreams: tio,
-		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
-		Client: func(_ context.Context) (*docker.Client, error) {
-			return fake.Client, nil
-		},
-		Config: func() (config.Config, error) {
-			return configmocks.NewBlankConfig(), nil
-		},
-	}, in, out, errOut
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	return tf.Factory, tf.In, tf.Out, tf.ErrOut
 }
 
 func TestUpdateRun_Success(t *testing.T) {
@@ -387,3 +378,25 @@ func TestUpdateRun_PartialFailure(t *testing.T) {
 	// Second container had error
 	assert.Contains(t, errOut.String(), "clawker.myapp.missing")
 }
+
+func TestUpdateRun_ExceedsResourceCap(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupFindContainer("clawker.myapp.dev", mocks.RunningContainerFixture("myapp", "dev"))
+	fake.SetupContainerUpdate()
+
+	tf := cmdutiltest.NewFactory(t,
+		cmdutiltest.WithFakeClient(fake),
+		cmdutiltest.WithConfig(configmocks.NewFromString("", `resources: { max_memory: 2g }`)),
+	)
+
+	cmd := NewCmdUpdate(tf.Factory, nil)
+	cmd.SetArgs([]string{"--memory", "4g", "clawker.myapp.dev"})
+	cmd.SetIn(tf.In)
+	cmd.SetOut(tf.Out)
+	cmd.SetErr(tf.ErrOut)
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds settings resources.max_memory (2g)")
+	fake.AssertNotCalled(t, "ContainerUpdate")
+}
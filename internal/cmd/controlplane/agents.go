@@ -11,6 +11,7 @@ import (
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/project"
 	"github.com/schmitthub/clawker/internal/tui"
 )
 
@@ -19,11 +20,14 @@ import (
 // of the agent registry, so the host can no longer read sqlite
 // directly. `f.AdminClient(ctx).ListAgents` is the canonical access.
 type AgentsOptions struct {
-	IOStreams   *iostreams.IOStreams
-	TUI         *tui.TUI
-	Logger      func() (*logger.Logger, error)
-	AdminClient func(context.Context) (adminv1.AdminServiceClient, error)
-	Format      *cmdutil.FormatFlags
+	IOStreams      *iostreams.IOStreams
+	TUI            *tui.TUI
+	Logger         func() (*logger.Logger, error)
+	AdminClient    func(context.Context) (adminv1.AdminServiceClient, error)
+	ProjectManager func() (project.ProjectManager, error)
+	Format         *cmdutil.FormatFlags
+
+	All bool
 }
 
 // agentRow is the JSON/template-friendly representation of one agent.
@@ -41,23 +45,28 @@ type agentRow struct {
 // NewCmdAgents creates the `clawker controlplane agents` command.
 func NewCmdAgents(f *cmdutil.Factory, runF func(context.Context, *AgentsOptions) error) *cobra.Command {
 	opts := &AgentsOptions{
-		IOStreams:   f.IOStreams,
-		TUI:         f.TUI,
-		Logger:      f.Logger,
-		AdminClient: f.AdminClient,
+		IOStreams:      f.IOStreams,
+		TUI:            f.TUI,
+		Logger:         f.Logger,
+		AdminClient:    f.AdminClient,
+		ProjectManager: f.ProjectManager,
 	}
 
 	cmd := &cobra.Command{
 		Use:   "agents",
 		Short: "List agents currently registered with the control plane",
-		Long: `List every agent currently registered with the control plane.
+		Long: `List the agents of the current project registered with the control plane.
+Outside a project, lists global-scope agents. Use --all for every project.
 
 The thumbprint shown is the SHA-256 of the agent's certificate. Agents
 are uniquely identified by the (project, agent_name) pair — agents with
 the same name in different projects appear as separate rows.`,
-		Example: `  # Show all registered agents
+		Example: `  # Show the current project's registered agents
   clawker controlplane agents
 
+  # Show registered agents across all projects
+  clawker controlplane agents --all
+
   # Machine-readable output
   clawker controlplane agents --json`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -68,6 +77,7 @@ the same name in different projects appear as separate rows.`,
 		},
 	}
 
+	cmd.Flags().BoolVarP(&opts.All, "all", "a", false, "List agents across all projects")
 	opts.Format = cmdutil.AddFormatFlags(cmd)
 	return cmd
 }
@@ -78,7 +88,11 @@ func agentsRun(ctx context.Context, opts *AgentsOptions) error {
 		return fmt.Errorf("dialing control plane: %w", err)
 	}
 
-	resp, err := client.ListAgents(ctx, &adminv1.ListAgentsRequest{})
+	req := &adminv1.ListAgentsRequest{AllProjects: opts.All}
+	if !opts.All {
+		req.Project = currentProject(ctx, opts)
+	}
+	resp, err := client.ListAgents(ctx, req)
 	if err != nil {
 		return fmt.Errorf("ListAgents: %w", err)
 	}
@@ -97,12 +111,30 @@ func agentsRun(ctx context.Context, opts *AgentsOptions) error {
 	return renderAgents(opts, rows)
 }
 
+// currentProject returns the current project's name, the control plane's
+// scope selector. Outside a project it is empty, selecting global-scope
+// agents.
+func currentProject(ctx context.Context, opts *AgentsOptions) string {
+	if opts.ProjectManager == nil {
+		return ""
+	}
+	pm, err := opts.ProjectManager()
+	if err != nil {
+		return ""
+	}
+	p, err := pm.CurrentProject(ctx)
+	if err != nil {
+		return ""
+	}
+	return p.Name()
+}
+
 func renderAgents(opts *AgentsOptions, rows []agentRow) error {
 	ios := opts.IOStreams
 
 	switch {
 	case opts.Format.IsJSON():
-		return cmdutil.WriteJSON(ios.Out, rows)
+		return opts.Format.WriteJSON(ios, "controlplane.agents", rows)
 	case opts.Format.IsTemplate():
 		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(rows))
 	}
@@ -15,6 +15,8 @@ import (
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
 	"github.com/schmitthub/clawker/internal/tui"
 )
 
@@ -137,3 +139,27 @@ func TestAgentsRun_PropagatesAdminClientError(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "dial boom")
 }
+
+func TestAgentsRun_ProjectScope(t *testing.T) {
+	var got *adminv1.ListAgentsRequest
+	mock := &adminv1mocks.AdminServiceClientMock{
+		ListAgentsFunc: func(_ context.Context, in *adminv1.ListAgentsRequest, _ ...grpc.CallOption) (*adminv1.ListAgentsResult, error) {
+			got = in
+			return &adminv1.ListAgentsResult{}, nil
+		},
+	}
+	h := newAgentsHarness(t, mock)
+	pm := projectmocks.NewMockProjectManager()
+	pm.CurrentProjectFunc = func(context.Context) (project.Project, error) {
+		return projectmocks.NewMockProject("myapp", t.TempDir()), nil
+	}
+	h.opts.ProjectManager = func() (project.ProjectManager, error) { return pm, nil }
+
+	require.NoError(t, agentsRun(context.Background(), h.opts))
+	assert.Equal(t, "myapp", got.GetProject())
+	assert.False(t, got.GetAllProjects())
+
+	h.opts.All = true
+	require.NoError(t, agentsRun(context.Background(), h.opts))
+	assert.True(t, got.GetAllProjects())
+}
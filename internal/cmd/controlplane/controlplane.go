@@ -35,6 +35,7 @@ need to observe or manipulate the CP directly.`,
 		NewCmdDown(f, nil),
 		NewCmdStatus(f, nil),
 		NewCmdAgents(f, nil),
+		NewCmdGateway(f, nil),
 	)
 
 	return cmd
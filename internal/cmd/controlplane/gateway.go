@@ -0,0 +1,187 @@
+package controlplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/gateway"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/signals"
+)
+
+// GatewayOptions wires the gateway command's run function.
+type GatewayOptions struct {
+	IOStreams   *iostreams.IOStreams
+	Logger      func() (*logger.Logger, error)
+	Client      func(context.Context) (*docker.Client, error)
+	AdminClient func(context.Context) (adminv1.AdminServiceClient, error)
+
+	// Socket is the Unix socket path; empty selects the default under the
+	// state directory.
+	Socket string
+	// Port is the localhost TCP port; 0 serves the socket only.
+	Port int
+	// TokenFile is where the TCP listener's token is kept; empty selects
+	// the default next to the socket.
+	TokenFile string
+}
+
+// NewCmdGateway creates the `clawker controlplane gateway` command.
+func NewCmdGateway(f *cmdutil.Factory, runF func(context.Context, *GatewayOptions) error) *cobra.Command {
+	opts := &GatewayOptions{
+		IOStreams:   f.IOStreams,
+		Logger:      f.Logger,
+		Client:      f.Client,
+		AdminClient: f.AdminClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "gateway",
+		Short: "Serve a read-only agent API for editors and dashboards",
+		Long: `Serve a read-only local API over clawker's agents, so tools such as an
+editor extension or a web dashboard can integrate without running the CLI.
+
+The API lists agents with their container state, init progress (from the
+container healthcheck) and control plane registration, streams container
+logs, and pushes the agent list on every change. JSON responses and
+WebSocket streams:
+
+  GET /v1/agents               agent list
+  GET /v1/agents/NAME          one agent by container name
+  GET /v1/agents/NAME/logs     logs (?follow, ?tail, ?timestamps); WebSocket
+                               messages per line, or chunked text over HTTP
+  GET /v1/watch                WebSocket; the agent list on every change
+
+It always listens on a Unix socket readable only by you. --port adds a
+listener on 127.0.0.1 that requires the token stored in the token file,
+sent as "Authorization: Bearer TOKEN" or, for browser WebSockets, an
+access_token query parameter. Delete the token file to rotate the token.
+
+The gateway runs in the foreground until interrupted. Registration and
+resource fields are left empty while the control plane is down.`,
+		Example: `  # Serve on the default Unix socket
+  clawker controlplane gateway
+
+  # Also listen on localhost:7777 with token auth
+  clawker controlplane gateway --port 7777
+
+  # Query the socket
+  curl --unix-socket ~/.local/state/clawker/sockets/gateway.sock http://gateway/v1/agents`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.Port < 0 || opts.Port > 65535 {
+				return cmdutil.FlagErrorf("--port must be between 1 and 65535")
+			}
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return gatewayRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Socket, "socket", "", "Unix socket path (default: gateway.sock in the clawker state directory)")
+	cmd.Flags().IntVar(&opts.Port, "port", 0, "Also listen on 127.0.0.1:PORT, with token auth")
+	cmd.Flags().StringVar(&opts.TokenFile, "token-file", "", "Token file for the --port listener (default: gateway.token next to the default socket)")
+	return cmd
+}
+
+func gatewayRun(ctx context.Context, opts *GatewayOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	log := logger.Nop()
+	if opts.Logger != nil {
+		if l, err := opts.Logger(); err == nil {
+			log = l
+		}
+	}
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+
+	var token string
+	if opts.Port != 0 {
+		tokenFile := opts.TokenFile
+		if tokenFile == "" {
+			if tokenFile, err = consts.GatewayTokenPath(); err != nil {
+				return err
+			}
+		}
+		if token, err = gateway.LoadOrCreateToken(tokenFile); err != nil {
+			return err
+		}
+		opts.TokenFile = tokenFile
+	}
+	srv, err := gateway.New(gateway.Options{
+		Source: gateway.NewDockerSource(client, opts.AdminClient, log),
+		Token:  token,
+		Log:    log,
+	})
+	if err != nil {
+		return err
+	}
+
+	socket := opts.Socket
+	if socket == "" {
+		if socket, err = consts.GatewaySocketPath(); err != nil {
+			return err
+		}
+	}
+	unixLn, err := gateway.ListenUnix(socket)
+	if err != nil {
+		return err
+	}
+	var tcpLn net.Listener
+	if opts.Port != 0 {
+		addr := net.JoinHostPort(consts.Localhost, strconv.Itoa(opts.Port))
+		if tcpLn, err = net.Listen("tcp", addr); err != nil {
+			unixLn.Close()
+			return fmt.Errorf("listening on %s: %w", addr, err)
+		}
+	}
+
+	fmt.Fprintf(ios.ErrOut, "%s Gateway listening on unix://%s\n", cs.SuccessIcon(), socket)
+	if tcpLn != nil {
+		fmt.Fprintf(ios.ErrOut, "%s Gateway listening on http://%s (token in %s)\n", cs.SuccessIcon(), tcpLn.Addr(), opts.TokenFile)
+	}
+	fmt.Fprintf(ios.ErrOut, "%s Press Ctrl+C to stop\n", cs.InfoIcon())
+
+	ctx, cancel := signals.SetupSignalContext(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	serve := func(ln net.Listener, requireToken bool) {
+		defer wg.Done()
+		if err := srv.Serve(ctx, ln, requireToken); err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+			// One listener failing stops the gateway.
+			cancel()
+		}
+	}
+	wg.Add(1)
+	go serve(unixLn, false)
+	if tcpLn != nil {
+		wg.Add(1)
+		go serve(tcpLn, true)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,134 @@
+package controlplane
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	dockermocks "github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/gateway"
+)
+
+func TestNewCmdGateway_Flags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    GatewayOptions
+		wantErr string
+	}{
+		{name: "defaults", args: nil},
+		{
+			name: "port and paths",
+			args: []string{"--port", "7777", "--socket", "/tmp/gw.sock", "--token-file", "/tmp/gw.token"},
+			want: GatewayOptions{Port: 7777, Socket: "/tmp/gw.sock", TokenFile: "/tmp/gw.token"},
+		},
+		{name: "port out of range", args: []string{"--port", "70000"}, wantErr: "--port must be between 1 and 65535"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := newTestBed(t)
+			var got *GatewayOptions
+			cmd := NewCmdGateway(tb.F, func(_ context.Context, opts *GatewayOptions) error {
+				got = opts
+				return nil
+			})
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(tb.Stdout)
+			cmd.SetErr(tb.Stderr)
+
+			err := cmd.Execute()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				var flagErr *cmdutil.FlagError
+				require.ErrorAs(t, err, &flagErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want.Port, got.Port)
+			require.Equal(t, tt.want.Socket, got.Socket)
+			require.Equal(t, tt.want.TokenFile, got.TokenFile)
+		})
+	}
+}
+
+func TestGatewayRun_ServesSocketAndTokenPort(t *testing.T) {
+	tb := newTestBed(t)
+	withDockerFake(tb, dockermocks.RunningContainerFixture("app", "dev"))
+	tb.F.AdminClient = func(context.Context) (adminv1.AdminServiceClient, error) {
+		return nil, errors.New("control plane is not running")
+	}
+
+	dir, err := os.MkdirTemp("", "gw")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	opts := &GatewayOptions{
+		IOStreams:   tb.F.IOStreams,
+		Client:      tb.F.Client,
+		AdminClient: tb.F.AdminClient,
+		Socket:      filepath.Join(dir, "gateway.sock"),
+		TokenFile:   filepath.Join(dir, "gateway.token"),
+		Port:        port,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- gatewayRun(ctx, opts) }()
+
+	unixClient := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", opts.Socket)
+		},
+	}}
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = unixClient.Get("http://gateway/v1/agents")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	var list gateway.AgentList
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	resp.Body.Close()
+	require.Len(t, list.Agents, 1)
+	require.False(t, list.ControlPlane)
+
+	tokenURL := "http://127.0.0.1:" + strconv.Itoa(port) + "/v1/agents"
+	resp, err = http.Get(tokenURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode, "the TCP listener requires the token")
+
+	token, err := gateway.LoadOrCreateToken(opts.TokenFile)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, tokenURL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("gatewayRun did not return after cancel")
+	}
+	require.Contains(t, tb.Stderr.String(), "Gateway listening on unix://"+opts.Socket)
+	_, err = os.Stat(opts.Socket)
+	require.ErrorIs(t, err, os.ErrNotExist, "the socket is removed on shutdown")
+}
@@ -122,7 +122,7 @@ func renderStatus(opts *StatusOptions, row statusRow) error {
 
 	switch {
 	case opts.Format.IsJSON():
-		return cmdutil.WriteJSON(ios.Out, row)
+		return opts.Format.WriteJSON(ios, "controlplane.status", row)
 	case opts.Format.IsTemplate():
 		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny([]statusRow{row}))
 	}
@@ -122,12 +122,12 @@ func TestStatusRun_BestEffortTolerance(t *testing.T) {
 				h.adminMock.FirewallStatusFunc = tc.firewallStatus
 			}
 
-			// Route through the command with --json so row fields are
+			// Route through the command with --format json so row fields are
 			// asserted directly — text-mode Contains would pass
 			// spuriously if the error string happened to appear
 			// elsewhere in the output.
 			cmd := NewCmdStatus(h.tb.F, nil)
-			cmd.SetArgs([]string{"--json"})
+			cmd.SetArgs([]string{"--format", "json"})
 			require.NoError(t, cmd.Execute(),
 				"all three tolerance paths must keep the command exit zero")
 
@@ -144,7 +144,7 @@ func TestStatusRun_JSON_StoppedCP(t *testing.T) {
 	h.tb.Mock.IsRunningFunc = func(_ context.Context) (bool, error) { return false, nil }
 
 	cmd := NewCmdStatus(h.tb.F, nil)
-	cmd.SetArgs([]string{"--json"})
+	cmd.SetArgs([]string{"--format", "json"})
 	require.NoError(t, cmd.Execute())
 
 	var row statusRow
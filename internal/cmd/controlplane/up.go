@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/moby/moby/api/types/container"
 	moby "github.com/moby/moby/client"
 	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
 	"github.com/schmitthub/clawker/controlplane/manager"
@@ -106,9 +105,7 @@ func warnIfStackRunning(ctx context.Context, opts *UpOptions, ios *iostreams.IOS
 		fmt.Fprintf(ios.ErrOut, "%s could not check firewall stack state: %v\n", cs.WarningIcon(), err)
 		return
 	}
-	filter := moby.Filters{}.
-		Add("label", consts.LabelPurpose+"="+consts.PurposeFirewall).
-		Add("status", string(container.StateRunning))
+	filter := docker.Query().Purpose(consts.PurposeFirewall).Running().MustFilters()
 	result, err := dc.ContainerList(ctx, moby.ContainerListOptions{Filters: filter})
 	if err != nil {
 		fmt.Fprintf(ios.ErrOut, "%s could not check firewall stack state: %v\n", cs.WarningIcon(), err)
@@ -0,0 +1,294 @@
+package dash
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/moby/moby/api/types/events"
+
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// maxEvents is how many recent events the board keeps.
+const maxEvents = 8
+
+// ---------------------------------------------------------------------------
+// Board state and dashboard renderer (implements tui.DashboardRenderer and
+// tui.DashboardKeyHandler)
+// ---------------------------------------------------------------------------
+
+// board holds the latest listing, usage samples and events. Only an
+// interactive board keeps a selection.
+type board struct {
+	interactive bool
+
+	state     dashState
+	usage     map[string]usage // by container ID
+	events    []events.Message // oldest first
+	lastEvent int64            // TimeNano of the newest event seen
+	eventsErr error
+
+	selected string // container name of the selected agent
+	notice   string // outcome of the last action
+}
+
+func newBoard(interactive bool) *board {
+	return &board{interactive: interactive, usage: make(map[string]usage)}
+}
+
+func (b *board) ProcessEvent(ev any) {
+	switch e := ev.(type) {
+	case stateEvent:
+		b.state = e.state
+		slices.SortFunc(b.state.Agents, func(x, y docker.Container) int {
+			return cmp.Or(cmp.Compare(x.Project, y.Project), cmp.Compare(x.Agent, y.Agent))
+		})
+		slices.SortFunc(b.state.Monitoring, func(x, y docker.Container) int {
+			return cmp.Compare(x.Name, y.Name)
+		})
+		b.keepSelection()
+	case usageEvent:
+		b.usage[e.id] = e.usage
+	case usageGoneEvent:
+		delete(b.usage, e.id)
+	case dockerEvent:
+		// A restarted stream replays events from the last second seen.
+		if e.msg.TimeNano <= b.lastEvent {
+			return
+		}
+		b.lastEvent = e.msg.TimeNano
+		b.events = append(b.events, e.msg)
+		if len(b.events) > maxEvents {
+			b.events = b.events[len(b.events)-maxEvents:]
+		}
+	case eventsClosedEvent:
+		b.eventsErr = e.err
+	}
+}
+
+// keepSelection moves the selection to the first agent when the selected
+// one is gone.
+func (b *board) keepSelection() {
+	if !b.interactive {
+		return
+	}
+	if b.index(b.selected) >= 0 {
+		return
+	}
+	b.selected = ""
+	if len(b.state.Agents) > 0 {
+		b.selected = b.state.Agents[0].Name
+	}
+}
+
+// index returns the position of the agent named name, or -1.
+func (b *board) index(name string) int {
+	return slices.IndexFunc(b.state.Agents, func(c docker.Container) bool { return c.Name == name })
+}
+
+// HandleKey moves the selection and maps action keys to actions on the
+// selected agent.
+func (b *board) HandleKey(key string) string {
+	n := len(b.state.Agents)
+	if n == 0 || b.selected == "" {
+		return ""
+	}
+	switch key {
+	case "up", "k":
+		b.selected = b.state.Agents[(b.index(b.selected)+n-1)%n].Name
+	case "down", "j":
+		b.selected = b.state.Agents[(b.index(b.selected)+1)%n].Name
+	case "s":
+		return actionStart
+	case "x":
+		return actionStop
+	case "a":
+		return actionAttach
+	case "l":
+		return actionLogs
+	}
+	return ""
+}
+
+// eventsSince is where a new event stream picks up: the second of the
+// newest event seen, or eventsWindow ago.
+func (b *board) eventsSince() string {
+	if b.lastEvent == 0 {
+		return unixTime(time.Now().Add(-eventsWindow))
+	}
+	return unixTime(time.Unix(0, b.lastEvent))
+}
+
+func (b *board) View(cs *iostreams.ColorScheme, width int) string {
+	var buf strings.Builder
+
+	running := 0
+	for _, a := range b.state.Agents {
+		if a.Status == "running" {
+			running++
+		}
+	}
+	buf.WriteString(tui.RenderDashHeader(cs, tui.DashHeaderConfig{
+		Title:    "clawker",
+		Subtitle: fmt.Sprintf("%d agents, %d running", len(b.state.Agents), running),
+		Width:    width,
+	}))
+	buf.WriteString("\n\n")
+
+	if b.state.Err != nil {
+		buf.WriteString("  " + cs.FailureIcon() + " " + b.state.Err.Error() + "\n\n")
+		return buf.String()
+	}
+
+	b.writeProjects(&buf, cs)
+	b.writeAgents(&buf, cs)
+	b.writeMonitoring(&buf, cs)
+	b.writeEvents(&buf, cs)
+
+	if b.notice != "" {
+		buf.WriteString("  " + b.notice + "\n\n")
+	}
+	return buf.String()
+}
+
+func (b *board) writeProjects(buf *strings.Builder, cs *iostreams.ColorScheme) {
+	writeSection(buf, cs, "PROJECTS")
+	if len(b.state.Projects) == 0 {
+		buf.WriteString(cs.Muted("  No registered projects") + "\n\n")
+		return
+	}
+
+	agents := make(map[string]int)
+	running := make(map[string]int)
+	for _, a := range b.state.Agents {
+		agents[a.Project]++
+		if a.Status == "running" {
+			running[a.Project]++
+		}
+	}
+	rows := [][]string{{"NAME", "AGENTS", "RUNNING", "ROOT"}}
+	for _, p := range b.state.Projects {
+		rows = append(rows, []string{p.Name, fmt.Sprint(agents[p.Name]), fmt.Sprint(running[p.Name]), p.Root})
+	}
+	for i, line := range tabulate(rows) {
+		if i == 0 {
+			line = cs.Bold(line)
+		}
+		buf.WriteString("  " + line + "\n")
+	}
+	buf.WriteByte('\n')
+}
+
+func (b *board) writeAgents(buf *strings.Builder, cs *iostreams.ColorScheme) {
+	writeSection(buf, cs, "AGENTS")
+	if len(b.state.Agents) == 0 {
+		buf.WriteString(cs.Muted("  No agents; create one with clawker run") + "\n\n")
+		return
+	}
+
+	rows := [][]string{{"AGENT", "PROJECT", "STATE", "STATUS", "IMAGE", "CPU %", "MEM"}}
+	for _, a := range b.state.Agents {
+		project := a.Project
+		if project == "" {
+			project = "-"
+		}
+		state := a.Status
+		if a.Health != "" {
+			state += " (" + a.Health + ")"
+		}
+		cpu, mem := "-", "-"
+		if u, ok := b.usage[a.ID]; ok {
+			cpu = fmt.Sprintf("%.2f%%", u.CPUPercent)
+			mem = units.BytesSize(float64(u.MemoryUsage))
+			if u.MemoryLimit > 0 {
+				mem += " / " + units.BytesSize(float64(u.MemoryLimit))
+			}
+		}
+		rows = append(rows, []string{a.Agent, project, state, a.StatusText, a.Image, cpu, mem})
+	}
+
+	// Style after tabwriter has aligned the columns; escape codes would
+	// throw off its widths.
+	for i, line := range tabulate(rows) {
+		if i == 0 {
+			buf.WriteString("  " + cs.Bold(line) + "\n")
+			continue
+		}
+		a := b.state.Agents[i-1]
+		if a.Status != "running" {
+			line = cs.Muted(line)
+		}
+		prefix := "  "
+		if b.interactive && a.Name == b.selected {
+			prefix = cs.Primary("▸ ")
+		}
+		buf.WriteString(prefix + line + "\n")
+	}
+	buf.WriteByte('\n')
+}
+
+func (b *board) writeMonitoring(buf *strings.Builder, cs *iostreams.ColorScheme) {
+	writeSection(buf, cs, "MONITORING")
+	services := b.state.Monitoring
+	var stopped []string
+	for _, c := range services {
+		if c.Status != "running" {
+			stopped = append(stopped, c.Name)
+		}
+	}
+	switch {
+	case len(services) == 0:
+		buf.WriteString(cs.Muted("  not running; start it with clawker monitor up") + "\n\n")
+	case len(stopped) == 0:
+		buf.WriteString(fmt.Sprintf("  %s running (%d services)\n\n", cs.SuccessIcon(), len(services)))
+	default:
+		buf.WriteString(fmt.Sprintf("  %s %d/%d running; stopped: %s\n\n",
+			cs.WarningIcon(), len(services)-len(stopped), len(services), strings.Join(stopped, ", ")))
+	}
+}
+
+func (b *board) writeEvents(buf *strings.Builder, cs *iostreams.ColorScheme) {
+	writeSection(buf, cs, "RECENT EVENTS")
+	if b.eventsErr != nil {
+		buf.WriteString(cs.Muted("  events unavailable: "+b.eventsErr.Error()) + "\n")
+	}
+	if len(b.events) == 0 {
+		buf.WriteString(cs.Muted("  No recent events") + "\n\n")
+		return
+	}
+	rows := make([][]string, len(b.events))
+	for i, msg := range b.events {
+		rows[i] = []string{
+			time.Unix(0, msg.TimeNano).Format(time.TimeOnly),
+			string(msg.Action),
+			msg.Actor.Attributes["name"],
+		}
+	}
+	for _, line := range tabulate(rows) {
+		buf.WriteString("  " + line + "\n")
+	}
+	buf.WriteByte('\n')
+}
+
+// writeSection writes a section title.
+func writeSection(buf *strings.Builder, cs *iostreams.ColorScheme, title string) {
+	buf.WriteString(cs.Primary(title) + "\n")
+}
+
+// tabulate aligns rows into columns and returns the lines.
+func tabulate(rows [][]string) []string {
+	var table strings.Builder
+	tw := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+	return strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n")
+}
@@ -0,0 +1,319 @@
+package dash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/events"
+	"github.com/moby/moby/client"
+
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/project"
+)
+
+// refreshInterval is how often the collector re-lists containers and
+// projects. Container events trigger a refresh sooner. A var so tests can
+// shorten it.
+var refreshInterval = 2 * time.Second
+
+// eventsWindow is how far back the dashboard's event list starts.
+const eventsWindow = 15 * time.Minute
+
+// snapshotTimeout bounds the one-off reads of a snapshot: stats samples
+// and the event backlog.
+const snapshotTimeout = 5 * time.Second
+
+// eventActions are the container events shown; exec, attach and resize
+// events are noise here.
+var eventActions = []events.Action{
+	events.ActionCreate, events.ActionStart, events.ActionRestart, events.ActionStop,
+	events.ActionDie, events.ActionKill, events.ActionOOM, events.ActionPause,
+	events.ActionUnPause, events.ActionRename, events.ActionDestroy, events.ActionHealthStatus,
+}
+
+// ---------------------------------------------------------------------------
+// Events
+// ---------------------------------------------------------------------------
+
+// stateEvent carries a fresh listing of projects and containers.
+type stateEvent struct {
+	state dashState
+}
+
+// usageEvent carries a fresh resource sample for one container.
+type usageEvent struct {
+	id    string
+	usage usage
+}
+
+// usageGoneEvent reports that a container's stats stream ended, usually
+// because it stopped.
+type usageGoneEvent struct {
+	id string
+}
+
+// dockerEvent carries one container event from the daemon.
+type dockerEvent struct {
+	msg events.Message
+}
+
+// eventsClosedEvent reports that the event stream failed; recent events
+// stop updating.
+type eventsClosedEvent struct {
+	err error
+}
+
+// dashState is one listing of what the dashboard shows.
+type dashState struct {
+	Projects   []project.ProjectEntry
+	Agents     []docker.Container
+	Monitoring []docker.Container
+	Err        error // listing containers failed; the rest is empty
+}
+
+// usage is a container's latest CPU and memory figures.
+type usage struct {
+	CPUPercent  float64
+	MemoryUsage uint64
+	MemoryLimit uint64
+}
+
+func newUsage(stats *container.StatsResponse) usage {
+	return usage{
+		CPUPercent:  docker.CPUPercent(stats),
+		MemoryUsage: docker.MemoryUsageNoCache(stats.MemoryStats),
+		MemoryLimit: stats.MemoryStats.Limit,
+	}
+}
+
+// collectState lists registered projects, agent containers and monitoring
+// stack containers. A project registry that cannot be read leaves the
+// project list empty rather than failing the listing.
+func collectState(ctx context.Context, c *docker.Client, pm func() (project.ProjectManager, error)) dashState {
+	var st dashState
+	if pm != nil {
+		if m, err := pm(); err == nil {
+			if entries, err := m.List(ctx); err == nil {
+				st.Projects = entries
+			}
+		}
+	}
+	st.Agents, st.Err = c.ListContainersQuery(ctx, docker.Query().Purpose(consts.PurposeAgent), true)
+	if st.Err != nil {
+		return st
+	}
+	st.Monitoring, st.Err = c.ListContainersQuery(ctx, docker.Query().Purpose(consts.PurposeMonitoring), true)
+	return st
+}
+
+// eventsOptions selects the dashboard's container events since since.
+func eventsOptions(since string) client.EventsListOptions {
+	actions := make([]string, len(eventActions))
+	for i, a := range eventActions {
+		actions[i] = string(a)
+	}
+	return client.EventsListOptions{
+		Since: since,
+		Filters: make(client.Filters).
+			Add("type", string(events.ContainerEventType)).
+			Add("event", actions...),
+	}
+}
+
+// unixTime formats t as the daemon's events API takes it.
+func unixTime(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+// ---------------------------------------------------------------------------
+// Snapshot
+// ---------------------------------------------------------------------------
+
+// collectSnapshot fills b with one listing, a stats sample per running
+// agent, and the container events of the last eventsWindow.
+func collectSnapshot(ctx context.Context, c *docker.Client, pm func() (project.ProjectManager, error), b *board) {
+	st := collectState(ctx, c, pm)
+	b.ProcessEvent(stateEvent{state: st})
+	if st.Err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, snapshotTimeout)
+	defer cancel()
+
+	// A non-streamed sample carries the previous CPU reading too, so CPU
+	// is derived as in the live view; sample every agent at once.
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, a := range st.Agents {
+		if a.Status != "running" {
+			continue
+		}
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			resp, err := c.ContainerStats(ctx, id, false)
+			if err != nil {
+				return
+			}
+			defer resp.Body.Close()
+			var stats container.StatsResponse
+			if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+				return
+			}
+			mu.Lock()
+			b.ProcessEvent(usageEvent{id: id, usage: newUsage(&stats)})
+			mu.Unlock()
+		}(a.ID)
+	}
+	wg.Wait()
+
+	now := time.Now()
+	opts := eventsOptions(unixTime(now.Add(-eventsWindow)))
+	opts.Until = unixTime(now)
+	res := c.Events(ctx, opts)
+	for {
+		select {
+		case msg := <-res.Messages:
+			b.ProcessEvent(dockerEvent{msg: msg})
+		case <-res.Err:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Live collector
+// ---------------------------------------------------------------------------
+
+// collector feeds a running dashboard: a listing every refreshInterval or
+// after a container event, a stats stream per running agent, and the
+// container events themselves.
+type collector struct {
+	client   *docker.Client
+	projects func() (project.ProjectManager, error)
+	events   chan<- any
+	since    string
+	refresh  chan struct{}
+
+	mu     sync.Mutex
+	active map[string]bool
+}
+
+func newCollector(c *docker.Client, pm func() (project.ProjectManager, error), events chan<- any, since string) *collector {
+	return &collector{
+		client:   c,
+		projects: pm,
+		events:   events,
+		since:    since,
+		refresh:  make(chan struct{}, 1),
+		active:   make(map[string]bool),
+	}
+}
+
+// run feeds the dashboard until ctx is cancelled.
+func (c *collector) run(ctx context.Context) {
+	go c.watchEvents(ctx)
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		st := collectState(ctx, c.client, c.projects)
+		if !c.send(ctx, stateEvent{state: st}) {
+			return
+		}
+		for _, a := range st.Agents {
+			if a.Status == "running" {
+				c.watch(ctx, a.ID)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-c.refresh:
+		}
+	}
+}
+
+// watchEvents forwards container events and asks for a refresh after
+// each. The stream is not reopened after an error; the periodic listing
+// still keeps the dashboard current.
+func (c *collector) watchEvents(ctx context.Context) {
+	res := c.client.Events(ctx, eventsOptions(c.since))
+	for {
+		select {
+		case msg := <-res.Messages:
+			if !c.send(ctx, dockerEvent{msg: msg}) {
+				return
+			}
+			select {
+			case c.refresh <- struct{}{}:
+			default:
+			}
+		case err := <-res.Err:
+			if err != nil && !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				c.send(ctx, eventsClosedEvent{err: err})
+			}
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// watch starts streaming a container's stats unless it is already streamed.
+func (c *collector) watch(ctx context.Context, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.active[id] {
+		return
+	}
+	c.active[id] = true
+	go c.stream(ctx, id)
+}
+
+func (c *collector) stream(ctx context.Context, id string) {
+	defer func() {
+		c.mu.Lock()
+		delete(c.active, id)
+		c.mu.Unlock()
+		c.send(ctx, usageGoneEvent{id: id})
+	}()
+
+	resp, err := c.client.ContainerStats(ctx, id, true)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var stats container.StatsResponse
+		if err := decoder.Decode(&stats); err != nil {
+			return
+		}
+		if !c.send(ctx, usageEvent{id: id, usage: newUsage(&stats)}) {
+			return
+		}
+	}
+}
+
+// send delivers ev unless ctx is cancelled first.
+func (c *collector) send(ctx context.Context, ev any) bool {
+	select {
+	case c.events <- ev:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
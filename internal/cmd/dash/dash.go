@@ -0,0 +1,172 @@
+// Package dash provides the `clawker dash` command.
+package dash
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmd/container/attach"
+	"github.com/schmitthub/clawker/internal/cmd/container/logs"
+	"github.com/schmitthub/clawker/internal/cmd/container/start"
+	"github.com/schmitthub/clawker/internal/cmd/container/stop"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// Actions bound to the dashboard's keys, each run against the selected
+// agent by the container command of the same name.
+const (
+	actionStart  = "start"
+	actionStop   = "stop"
+	actionAttach = "attach"
+	actionLogs   = "logs"
+)
+
+// logsTail is how many log lines the logs key shows.
+const logsTail = "100"
+
+// DashOptions holds options for the dash command.
+type DashOptions struct {
+	IOStreams      *iostreams.IOStreams
+	Client         func(context.Context) (*docker.Client, error)
+	ProjectManager func() (project.ProjectManager, error)
+
+	// commands build the container command each action runs; replaced in
+	// tests.
+	commands map[string]func() *cobra.Command
+}
+
+// NewCmdDash creates the dash command.
+func NewCmdDash(f *cmdutil.Factory, runF func(context.Context, *DashOptions) error) *cobra.Command {
+	opts := &DashOptions{
+		IOStreams:      f.IOStreams,
+		Client:         f.Client,
+		ProjectManager: f.ProjectManager,
+		commands: map[string]func() *cobra.Command{
+			actionStart:  func() *cobra.Command { return start.NewCmdStart(f, nil) },
+			actionStop:   func() *cobra.Command { return stop.NewCmdStop(f, nil) },
+			actionAttach: func() *cobra.Command { return attach.NewCmdAttach(f, nil) },
+			actionLogs:   func() *cobra.Command { return logs.NewCmdLogs(f, nil) },
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "dash",
+		Short: "Show a live dashboard of projects, agents, and the monitoring stack",
+		Long: `Shows a full-screen dashboard of everything clawker runs: registered
+projects, every agent container with its state, uptime, image, CPU and
+memory use, the monitoring stack, and recent container events. It refreshes
+as containers change.
+
+Keys act on the selected agent:
+
+  ↑/↓ (k/j)   select an agent
+  s           start it
+  x           stop it
+  a           attach to it; detach to return to the dashboard
+  l           show its last ` + logsTail + ` log lines
+  q, esc      quit
+
+Without a terminal, a single snapshot is printed instead.`,
+		Example: `  # Open the dashboard
+  clawker dash
+
+  # Print a snapshot, e.g. for a status report
+  clawker dash | cat`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return dashRun(cmd.Context(), opts)
+		},
+	}
+
+	return cmd
+}
+
+func dashRun(ctx context.Context, opts *DashOptions) error {
+	ios := opts.IOStreams
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+
+	// Without a terminal, one snapshot.
+	if !ios.IsStdoutTTY() {
+		b := newBoard(false)
+		collectSnapshot(ctx, client, opts.ProjectManager, b)
+		fmt.Fprint(ios.Out, b.View(ios.ColorScheme(), ios.TerminalWidth()))
+		return nil
+	}
+
+	// The board outlives each dashboard run, keeping the selection and the
+	// events seen, while actions run on the normal screen in between.
+	b := newBoard(true)
+	for {
+		result := runDashboard(ctx, ios, client, opts.ProjectManager, b)
+		if result.Err != nil {
+			return result.Err
+		}
+		if result.Action == "" {
+			return nil
+		}
+		b.notice = runAction(ctx, opts, result.Action, b.selected)
+	}
+}
+
+// runDashboard shows the board until the user quits or picks an action.
+func runDashboard(ctx context.Context, ios *iostreams.IOStreams, client *docker.Client, pm func() (project.ProjectManager, error), b *board) tui.DashboardResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events := make(chan any, 64)
+	go newCollector(client, pm, events, b.eventsSince()).run(ctx)
+
+	return tui.RunDashboard(ios, b, tui.DashboardConfig{
+		HelpText:   "↑/↓ select  s start  x stop  a attach  l logs  q quit",
+		FullScreen: true,
+	}, events)
+}
+
+// runAction runs the container command behind action on the agent named
+// name and returns the notice the dashboard shows for it.
+func runAction(ctx context.Context, opts *DashOptions, action, name string) string {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	cmd := opts.commands[action]()
+	args := []string{name}
+	if action == actionLogs {
+		args = []string{"--tail", logsTail, name}
+	}
+	cmd.SetArgs(args)
+	cmd.SetIn(ios.In)
+	cmd.SetOut(ios.Out)
+	cmd.SetErr(ios.ErrOut)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	err := cmd.ExecuteContext(ctx)
+
+	if action == actionLogs {
+		fmt.Fprint(ios.ErrOut, cs.Muted("Press Enter to return to the dashboard"))
+		_, _ = bufio.NewReader(ios.In).ReadString('\n')
+	}
+
+	switch {
+	case err != nil:
+		return fmt.Sprintf("%s %s %s: %v", cs.FailureIcon(), action, name, err)
+	case action == actionStart:
+		return fmt.Sprintf("%s Started %s", cs.SuccessIcon(), name)
+	case action == actionStop:
+		return fmt.Sprintf("%s Stopped %s", cs.SuccessIcon(), name)
+	}
+	return ""
+}
@@ -0,0 +1,359 @@
+package dash
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/shlex"
+	"github.com/moby/moby/api/types/events"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+func TestNewCmdDash(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "no args", input: ""},
+		{name: "positional arg", input: "dev", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *DashOptions
+			cmd := NewCmdDash(f, func(_ context.Context, opts *DashOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, gotOpts)
+			assert.Len(t, gotOpts.commands, 4)
+		})
+	}
+}
+
+func TestCmdDash_Properties(t *testing.T) {
+	cmd := NewCmdDash(cmdutiltest.NewFactory(t).Factory, nil)
+
+	assert.Equal(t, "dash", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+	assert.NotNil(t, cmd.RunE)
+}
+
+// statsJSON is a stats sample at 50% CPU using 50MiB of 1GiB.
+const statsJSON = `{
+	"read": "2024-01-01T00:00:01Z",
+	"cpu_stats": {
+		"cpu_usage": {"total_usage": 2000000000},
+		"system_cpu_usage": 20000000000,
+		"online_cpus": 1
+	},
+	"precpu_stats": {
+		"cpu_usage": {"total_usage": 1000000000},
+		"system_cpu_usage": 18000000000
+	},
+	"memory_stats": {"usage": 52428800, "limit": 1073741824}
+}`
+
+// seedDash switches fake to stateful mode with a running and a stopped
+// agent of myapp and a partly stopped monitoring stack.
+func seedDash(t *testing.T, fake *mocks.FakeClient) {
+	t.Helper()
+	cfg := fake.Cfg
+	state := fake.EnableState()
+	state.AddImage("node:20-slim", nil)
+	state.AddImage("grafana/grafana", nil)
+
+	add := func(name string, labels map[string]string, running bool) {
+		image := "node:20-slim"
+		if labels[cfg.LabelPurpose()] == consts.PurposeMonitoring {
+			image = "grafana/grafana"
+		}
+		id, err := state.AddContainer(whailtest.ContainerSpec{Name: name, Image: image, Labels: labels, Running: true})
+		require.NoError(t, err)
+		if !running {
+			require.NoError(t, state.Exit(id, 0))
+		}
+	}
+	agent := func(name string) map[string]string {
+		return map[string]string{
+			cfg.LabelPurpose(): consts.PurposeAgent,
+			cfg.LabelProject(): "myapp",
+			cfg.LabelAgent():   name,
+		}
+	}
+	monitoring := map[string]string{cfg.LabelPurpose(): consts.PurposeMonitoring}
+
+	add("clawker.myapp.dev", agent("dev"), true)
+	add("clawker.myapp.worker", agent("worker"), false)
+	add("grafana", monitoring, true)
+	add("jaeger", monitoring, false)
+}
+
+func testOptions(ios *iostreams.IOStreams, fake *mocks.FakeClient) *DashOptions {
+	pm := projectmocks.NewMockProjectManager()
+	pm.ListFunc = func(context.Context) ([]project.ProjectEntry, error) {
+		return []project.ProjectEntry{{Name: "myapp", Root: "/work/myapp"}}, nil
+	}
+	return &DashOptions{
+		IOStreams: ios,
+		Client: func(context.Context) (*docker.Client, error) {
+			return fake.Client, nil
+		},
+		ProjectManager: func() (project.ProjectManager, error) { return pm, nil },
+	}
+}
+
+func TestDashRun_Snapshot(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	seedDash(t, fake)
+	fake.SetupContainerStats(statsJSON)
+	fake.SetupEvents(events.Message{
+		Type:     events.ContainerEventType,
+		Action:   events.ActionDie,
+		Actor:    events.Actor{Attributes: map[string]string{"name": "clawker.myapp.worker"}},
+		TimeNano: time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local).UnixNano(),
+	})
+
+	ios, _, out, _ := iostreams.Test()
+	require.NoError(t, dashRun(context.Background(), testOptions(ios, fake)))
+
+	view := out.String()
+	assert.Contains(t, view, "2 agents, 1 running")
+	assert.Contains(t, view, "/work/myapp")
+	assert.Regexp(t, `dev\s+myapp\s+running\s+.*node:20-slim\s+50\.00%\s+50MiB / 1GiB`, view)
+	assert.Regexp(t, `worker\s+myapp\s+exited`, view)
+	assert.Contains(t, view, "1/2 running; stopped: jaeger")
+	assert.Regexp(t, `15:04:05\s+die\s+clawker\.myapp\.worker`, view)
+	assert.NotContains(t, view, "▸", "a snapshot has no selection")
+}
+
+func TestDashRun_DockerConnectionError(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	opts := &DashOptions{
+		IOStreams: ios,
+		Client: func(context.Context) (*docker.Client, error) {
+			return nil, errors.New("cannot connect to Docker daemon")
+		},
+	}
+
+	err := dashRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connecting to Docker")
+}
+
+func testBoard() *board {
+	b := newBoard(true)
+	b.ProcessEvent(stateEvent{state: dashState{Agents: []docker.Container{
+		{ID: "c2", Name: "clawker.myapp.worker", Project: "myapp", Agent: "worker", Status: "exited"},
+		{ID: "c1", Name: "clawker.myapp.dev", Project: "myapp", Agent: "dev", Status: "running"},
+		{ID: "c3", Name: "clawker.other.dev", Project: "other", Agent: "dev", Status: "running"},
+	}}})
+	return b
+}
+
+func TestBoard_HandleKey(t *testing.T) {
+	b := testBoard()
+	assert.Equal(t, "clawker.myapp.dev", b.selected, "the first agent is selected")
+
+	assert.Empty(t, b.HandleKey("down"))
+	assert.Equal(t, "clawker.myapp.worker", b.selected)
+	b.HandleKey("j")
+	b.HandleKey("j")
+	assert.Equal(t, "clawker.myapp.dev", b.selected, "down wraps")
+	b.HandleKey("up")
+	assert.Equal(t, "clawker.other.dev", b.selected, "up wraps")
+
+	for key, action := range map[string]string{"s": actionStart, "x": actionStop, "a": actionAttach, "l": actionLogs} {
+		assert.Equal(t, action, b.HandleKey(key), key)
+	}
+	assert.Empty(t, b.HandleKey("z"))
+}
+
+func TestBoard_KeepsSelection(t *testing.T) {
+	b := testBoard()
+	b.HandleKey("up")
+	require.Equal(t, "clawker.other.dev", b.selected)
+
+	b.ProcessEvent(stateEvent{state: dashState{Agents: []docker.Container{
+		{Name: "clawker.other.dev", Project: "other", Agent: "dev"},
+		{Name: "clawker.myapp.dev", Project: "myapp", Agent: "dev"},
+	}}})
+	assert.Equal(t, "clawker.other.dev", b.selected)
+
+	b.ProcessEvent(stateEvent{state: dashState{Agents: []docker.Container{
+		{Name: "clawker.myapp.dev", Project: "myapp", Agent: "dev"},
+	}}})
+	assert.Equal(t, "clawker.myapp.dev", b.selected, "a removed agent's selection moves to the first")
+
+	b.ProcessEvent(stateEvent{})
+	assert.Empty(t, b.selected)
+	assert.Empty(t, b.HandleKey("s"), "no actions without agents")
+}
+
+func TestBoard_Events(t *testing.T) {
+	b := newBoard(false)
+	for i := 1; i <= maxEvents+2; i++ {
+		b.ProcessEvent(dockerEvent{msg: events.Message{Action: events.ActionStart, TimeNano: int64(i)}})
+	}
+	// Replayed by a restarted stream.
+	b.ProcessEvent(dockerEvent{msg: events.Message{Action: events.ActionStart, TimeNano: 5}})
+
+	require.Len(t, b.events, maxEvents)
+	assert.Equal(t, int64(3), b.events[0].TimeNano)
+	assert.Equal(t, int64(maxEvents+2), b.lastEvent)
+	assert.Equal(t, "0", b.eventsSince())
+}
+
+func TestBoard_View(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	b := testBoard()
+	b.ProcessEvent(usageEvent{id: "c1", usage: usage{CPUPercent: 12.5, MemoryUsage: 1 << 20}})
+	b.notice = "Stopped clawker.myapp.worker"
+
+	view := b.View(ios.ColorScheme(), 100)
+	assert.Contains(t, view, "3 agents, 2 running")
+	assert.Regexp(t, `▸ dev\s+myapp\s+running\s+12\.50%\s+1MiB`, view)
+	assert.Regexp(t, `  worker\s+myapp\s+exited\s+-\s+-`, view)
+	assert.Contains(t, view, "No registered projects")
+	assert.Contains(t, view, "not running; start it with clawker monitor up")
+	assert.Contains(t, view, "No recent events")
+	assert.Contains(t, view, "Stopped clawker.myapp.worker")
+
+	b.ProcessEvent(usageGoneEvent{id: "c1"})
+	assert.Regexp(t, `▸ dev\s+myapp\s+running\s+-\s+-`, b.View(ios.ColorScheme(), 100))
+
+	b.ProcessEvent(stateEvent{state: dashState{Err: errors.New("daemon gone")}})
+	assert.Contains(t, b.View(ios.ColorScheme(), 100), "daemon gone")
+}
+
+// stubCommands replaces every action's command with one that records its
+// args and fails when failWith is set.
+func stubCommands(opts *DashOptions, gotArgs *[]string, failWith error) {
+	opts.commands = make(map[string]func() *cobra.Command)
+	for _, action := range []string{actionStart, actionStop, actionAttach, actionLogs} {
+		opts.commands[action] = func() *cobra.Command {
+			return &cobra.Command{
+				Use:                action,
+				DisableFlagParsing: true,
+				RunE: func(_ *cobra.Command, args []string) error {
+					*gotArgs = args
+					return failWith
+				},
+			}
+		}
+	}
+}
+
+func TestRunAction(t *testing.T) {
+	tests := []struct {
+		action     string
+		failWith   error
+		wantArgs   []string
+		wantNotice string
+	}{
+		{action: actionStart, wantArgs: []string{"clawker.myapp.dev"}, wantNotice: "Started clawker.myapp.dev"},
+		{action: actionStop, wantArgs: []string{"clawker.myapp.dev"}, wantNotice: "Stopped clawker.myapp.dev"},
+		{action: actionAttach, wantArgs: []string{"clawker.myapp.dev"}},
+		{action: actionStart, failWith: fmt.Errorf("no such image"), wantArgs: []string{"clawker.myapp.dev"}, wantNotice: "start clawker.myapp.dev: no such image"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			ios, _, _, _ := iostreams.Test()
+			opts := &DashOptions{IOStreams: ios}
+			var gotArgs []string
+			stubCommands(opts, &gotArgs, tt.failWith)
+
+			notice := runAction(context.Background(), opts, tt.action, "clawker.myapp.dev")
+			assert.Equal(t, tt.wantArgs, gotArgs)
+			if tt.wantNotice == "" {
+				assert.Empty(t, notice)
+			} else {
+				assert.Contains(t, notice, tt.wantNotice)
+			}
+		})
+	}
+}
+
+func TestRunAction_LogsWaitsForEnter(t *testing.T) {
+	ios, in, _, errOut := iostreams.Test()
+	in.WriteString("\n")
+	opts := &DashOptions{IOStreams: ios}
+	var gotArgs []string
+	stubCommands(opts, &gotArgs, nil)
+
+	assert.Empty(t, runAction(context.Background(), opts, actionLogs, "clawker.myapp.dev"))
+	assert.Equal(t, []string{"--tail", logsTail, "clawker.myapp.dev"}, gotArgs)
+	assert.Contains(t, errOut.String(), "Press Enter to return to the dashboard")
+	assert.Zero(t, in.Len(), "the Enter was read")
+}
+
+func TestCollector_FeedsDashboard(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	seedDash(t, fake)
+	fake.SetupContainerStats(statsJSON)
+	fake.SetupEvents(events.Message{Action: events.ActionStart, TimeNano: 1})
+	opts := testOptions(nil, fake)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ch := make(chan any, 16)
+	go newCollector(fake.Client, opts.ProjectManager, ch, "0").run(ctx)
+
+	var gotState, gotUsage, gotEvent bool
+	for !gotState || !gotUsage || !gotEvent {
+		select {
+		case ev := <-ch:
+			switch e := ev.(type) {
+			case stateEvent:
+				gotState = true
+				assert.Len(t, e.state.Agents, 2)
+				assert.Len(t, e.state.Monitoring, 2)
+				assert.Len(t, e.state.Projects, 1)
+			case usageEvent:
+				gotUsage = true
+				assert.InDelta(t, 50.0, e.usage.CPUPercent, 0.01)
+			case dockerEvent:
+				gotEvent = true
+				assert.Equal(t, events.ActionStart, e.msg.Action)
+			}
+		case <-ctx.Done():
+			t.Fatalf("state %v, usage %v, event %v", gotState, gotUsage, gotEvent)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+// Package bundle provides the `clawker debug bundle` command.
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/supportbundle"
+)
+
+// BundleOptions holds options for the debug bundle command.
+type BundleOptions struct {
+	IOStreams      *iostreams.IOStreams
+	Client         func(context.Context) (*docker.Client, error)
+	Config         func() (config.Config, error)
+	ProjectManager func() (project.ProjectManager, error)
+	Version        string
+
+	// Now is the bundle timestamp; nil means time.Now.
+	Now func() time.Time
+
+	Output string
+	agent  string
+}
+
+// NewCmdBundle creates the debug bundle command.
+func NewCmdBundle(f *cmdutil.Factory, runF func(context.Context, *BundleOptions) error) *cobra.Command {
+	opts := &BundleOptions{
+		IOStreams:      f.IOStreams,
+		Client:         f.Client,
+		Config:         f.Config,
+		ProjectManager: f.ProjectManager,
+		Version:        f.Version,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "bundle AGENT",
+		Short: "Write an agent's logs and state to a tar.gz for a bug report",
+		Long: `Collects everything needed to diagnose an agent into a single tar.gz:
+
+  container/inspect.json  docker inspect output for the agent container, with
+                          every environment variable value removed
+  container/logs.txt      the container's logs, with timestamps
+  clawkerd/               clawkerd and service logs from /var/log/clawker
+  host/hostproxy.log      the host proxy log
+  config/                 the effective project and settings config
+  manifest.json           what was collected, and what could not be
+
+The agent is resolved as clawker.<project>.<agent> using the project in the
+current directory. Collection is best-effort: anything that cannot be read,
+such as logs of a container that never started, is listed under "skipped"
+in the manifest instead of failing the bundle.
+
+Every file is redacted before it is written. Values of keys that look secret
+(tokens, passwords, API keys), known token formats, URL credentials and
+private keys are replaced with <redacted>. Redaction is pattern-based, so
+review the bundle before sharing it.`,
+		Example: `  # Bundle the dev agent of the current project
+  clawker debug bundle dev
+
+  # Choose where the bundle is written
+  clawker debug bundle dev --output /tmp/dev-bundle.tar.gz`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.agent = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return bundleRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Bundle file path (default: clawker-debug-<agent>-<timestamp>.tar.gz)")
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.AgentCompletions(f.Client, f.ProjectManager))
+
+	return cmd
+}
+
+func bundleRun(ctx context.Context, opts *BundleOptions) error {
+	ios := opts.IOStreams
+	now := time.Now()
+	if opts.Now != nil {
+		now = opts.Now()
+	}
+
+	var projectName string
+	if opts.ProjectManager != nil {
+		if pm, err := opts.ProjectManager(); err == nil {
+			if p, err := pm.CurrentProject(ctx); err == nil {
+				projectName = p.Name()
+			}
+		}
+	}
+	containerName, err := docker.ContainerName(projectName, opts.agent)
+	if err != nil {
+		return err
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+	c, err := client.FindContainerByName(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("failed to find container %q: %w", containerName, err)
+	}
+	if c == nil {
+		return fmt.Errorf("container %q not found", containerName)
+	}
+
+	output := opts.Output
+	if output == "" {
+		output = fmt.Sprintf("clawker-debug-%s-%s.tar.gz", opts.agent, now.Format("20060102-150405"))
+	}
+	f, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("creating bundle: %w", err)
+	}
+	defer f.Close()
+
+	w := supportbundle.NewWriter(f, now, map[string]string{
+		"clawker_version": opts.Version,
+		"os":              runtime.GOOS + "/" + runtime.GOARCH,
+		"project":         projectName,
+		"agent":           opts.agent,
+		"container":       containerName,
+		"container_id":    c.ID,
+	})
+	if err := collect(ctx, opts, client, c.ID, w); err != nil {
+		os.Remove(output)
+		return err
+	}
+	if err := w.Close(); err != nil {
+		os.Remove(output)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+
+	cs := ios.ColorScheme()
+	m := w.Manifest()
+	fmt.Fprintf(ios.ErrOut, "%s Wrote %d files to %s\n", cs.SuccessIcon(), len(m.Files), output)
+	for _, s := range m.Skipped {
+		fmt.Fprintf(ios.ErrOut, "%s Skipped %s: %s\n", cs.WarningIcon(), s.Name, s.Error)
+	}
+	fmt.Fprintln(ios.ErrOut, cs.Muted("Secrets are redacted by pattern; review the bundle before sharing it."))
+	fmt.Fprintln(ios.Out, output)
+	return nil
+}
+
+// collect adds each bundle item. A source that cannot be read is recorded
+// with Skip; only a failure to write the archive itself is returned.
+func collect(ctx context.Context, opts *BundleOptions, client *docker.Client, containerID string, w *supportbundle.Writer) error {
+	tty := false
+	info, err := client.ContainerInspect(ctx, containerID, docker.ContainerInspectOptions{})
+	if err != nil {
+		w.Skip("container/inspect.json", err)
+	} else {
+		inspect := info.Container
+		if inspect.Config != nil {
+			tty = inspect.Config.Tty
+			cfg := *inspect.Config
+			cfg.Env = redactEnv(cfg.Env)
+			inspect.Config = &cfg
+		}
+		data, err := json.MarshalIndent(inspect, "", "  ")
+		if err != nil {
+			w.Skip("container/inspect.json", err)
+		} else if err := w.Add("container/inspect.json", data); err != nil {
+			return err
+		}
+	}
+
+	if err := addContainerLogs(ctx, client, containerID, tty, w); err != nil {
+		return err
+	}
+
+	res, err := client.CopyFromContainer(ctx, containerID, docker.CopyFromContainerOptions{SourcePath: consts.CPLogsPath})
+	if err != nil {
+		w.Skip("clawkerd/", err)
+	} else {
+		err := w.AddTar("clawkerd", res.Content)
+		res.Content.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := addFile(w, "host/hostproxy.log", consts.HostProxyLogFilePath); err != nil {
+		return err
+	}
+
+	return addConfig(opts, w)
+}
+
+// redactEnv replaces every environment value with supportbundle.Redacted,
+// keeping the names. Container env carries injected secrets and forwarded
+// host variables under arbitrary names, which pattern redaction cannot
+// recognize, so no value is kept.
+func redactEnv(env []string) []string {
+	if env == nil {
+		return nil
+	}
+	out := make([]string, len(env))
+	for i, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		out[i] = name + "=" + supportbundle.Redacted
+	}
+	return out
+}
+
+// addContainerLogs adds the container's full log. Logs of a container
+// without a TTY are multiplexed; they are demultiplexed into one file.
+func addContainerLogs(ctx context.Context, client *docker.Client, containerID string, tty bool, w *supportbundle.Writer) error {
+	const name = "container/logs.txt"
+	reader, err := client.ContainerLogs(ctx, containerID, docker.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Timestamps: true,
+	})
+	if err != nil {
+		w.Skip(name, err)
+		return nil
+	}
+	defer reader.Close()
+
+	var src io.Reader = reader
+	if !tty {
+		pr, pw := io.Pipe()
+		go func() {
+			_, err := stdcopy.StdCopy(pw, pw, reader)
+			pw.CloseWithError(err)
+		}()
+		src = pr
+	}
+	if err := w.AddReader(name, src); err != nil {
+		w.Skip(name, err)
+	}
+	return nil
+}
+
+// addFile adds the host file at the path resolve returns.
+func addFile(w *supportbundle.Writer, name string, resolve func() (string, error)) error {
+	path, err := resolve()
+	if err != nil {
+		w.Skip(name, err)
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		w.Skip(name, err)
+		return nil
+	}
+	return w.Add(name, data)
+}
+
+// addConfig adds the effective project and settings config as YAML.
+func addConfig(opts *BundleOptions, w *supportbundle.Writer) error {
+	cfg, err := opts.Config()
+	if err != nil {
+		w.Skip("config/", err)
+		return nil
+	}
+	for _, item := range []struct {
+		name string
+		v    any
+	}{
+		{"config/project.yaml", cfg.Project()},
+		{"config/settings.yaml", cfg.Settings()},
+	} {
+		data, err := yaml.Marshal(item.v)
+		if err != nil {
+			w.Skip(item.name, err)
+			continue
+		}
+		if err := w.Add(item.name, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
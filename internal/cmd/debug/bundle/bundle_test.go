@@ -0,0 +1,248 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/shlex"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/supportbundle"
+)
+
+func TestNewCmdBundle(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantAgent  string
+		wantOutput string
+		wantErr    string
+	}{
+		{name: "agent", input: "dev", wantAgent: "dev"},
+		{name: "output flag", input: "dev --output /tmp/b.tar.gz", wantAgent: "dev", wantOutput: "/tmp/b.tar.gz"},
+		{name: "output shorthand", input: "dev -o b.tar.gz", wantAgent: "dev", wantOutput: "b.tar.gz"},
+		{name: "no agent", input: "", wantErr: "requires 1 argument"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOpts *BundleOptions
+			cmd := NewCmdBundle(cmdutiltest.NewFactory(t).Factory, func(_ context.Context, opts *BundleOptions) error {
+				gotOpts = opts
+				return nil
+			})
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err = cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAgent, gotOpts.agent)
+			assert.Equal(t, tt.wantOutput, gotOpts.Output)
+		})
+	}
+}
+
+// clawkerdLogs is a docker cp archive of /var/log/clawker.
+func clawkerdLogs(t *testing.T) io.ReadCloser {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	body := "clawkerd up\nbootstrap token=abc123\n"
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "clawker/clawkerd.log", Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(body))}))
+	_, err := tw.Write([]byte(body))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	return io.NopCloser(&buf)
+}
+
+func readBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	entries := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return entries
+		}
+		require.NoError(t, err)
+		b, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		entries[hdr.Name] = string(b)
+	}
+}
+
+func TestBundleRun(t *testing.T) {
+	t.Setenv(consts.EnvStateDir, t.TempDir())
+	logsDir, err := consts.LogsSubdir()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(logsDir, consts.HostProxyLogFile),
+		[]byte("proxy up\nAuthorization: Bearer s3cret\n"), 0o600))
+
+	fixture := mocks.RunningContainerFixture("", "dev")
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupFindContainer("clawker.dev", fixture)
+	fake.FakeAPI.ContainerLogsFn = func(_ context.Context, _ string, opts client.ContainerLogsOptions) (client.ContainerLogsResult, error) {
+		assert.True(t, opts.Timestamps)
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+	fake.FakeAPI.CopyFromContainerFn = func(_ context.Context, _ string, opts client.CopyFromContainerOptions) (client.CopyFromContainerResult, error) {
+		assert.Equal(t, consts.CPLogsPath, opts.SourcePath)
+		return client.CopyFromContainerResult{Content: clawkerdLogs(t)}, nil
+	}
+
+	tio, _, out, errOut := iostreams.Test()
+	output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	opts := &BundleOptions{
+		IOStreams: tio,
+		Client:    func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		Config:    func() (config.Config, error) { return configmocks.NewBlankConfig(), nil },
+		Version:   "1.2.3",
+		Now:       func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) },
+		Output:    output,
+		agent:     "dev",
+	}
+
+	require.NoError(t, bundleRun(context.Background(), opts))
+	assert.Equal(t, output+"\n", out.String())
+	assert.Contains(t, errOut.String(), "review the bundle before sharing")
+
+	entries := readBundle(t, output)
+	assert.Contains(t, entries, "container/inspect.json")
+	assert.Contains(t, entries, "container/logs.txt")
+	assert.Contains(t, entries, "config/project.yaml")
+	assert.Contains(t, entries, "config/settings.yaml")
+	assert.Equal(t, "clawkerd up\nbootstrap token=<redacted>\n", entries["clawkerd/clawkerd.log"])
+	assert.Equal(t, "proxy up\nAuthorization: Bearer <redacted>\n", entries["host/hostproxy.log"])
+
+	var m supportbundle.Manifest
+	require.NoError(t, json.Unmarshal([]byte(entries[supportbundle.ManifestFile]), &m))
+	assert.Equal(t, "1.2.3", m.Info["clawker_version"])
+	assert.Equal(t, "clawker.dev", m.Info["container"])
+	assert.Empty(t, m.Skipped)
+}
+
+func TestBundleRun_SkipsUnreadableSources(t *testing.T) {
+	t.Setenv(consts.EnvStateDir, t.TempDir())
+
+	fixture := mocks.RunningContainerFixture("", "dev")
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupFindContainer("clawker.dev", fixture)
+	fake.SetupContainerLogs("")
+	fake.FakeAPI.CopyFromContainerFn = func(context.Context, string, client.CopyFromContainerOptions) (client.CopyFromContainerResult, error) {
+		return client.CopyFromContainerResult{}, errors.New("no such path")
+	}
+
+	tio, _, _, errOut := iostreams.Test()
+	output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	opts := &BundleOptions{
+		IOStreams: tio,
+		Client:    func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		Config:    func() (config.Config, error) { return nil, errors.New("bad yaml") },
+		Output:    output,
+		agent:     "dev",
+	}
+
+	require.NoError(t, bundleRun(context.Background(), opts))
+	assert.Contains(t, errOut.String(), "Skipped clawkerd/")
+
+	var m supportbundle.Manifest
+	require.NoError(t, json.Unmarshal([]byte(readBundle(t, output)[supportbundle.ManifestFile]), &m))
+	var skipped []string
+	for _, s := range m.Skipped {
+		skipped = append(skipped, s.Name)
+	}
+	assert.ElementsMatch(t, []string{"clawkerd/", "host/hostproxy.log", "config/"}, skipped)
+}
+
+func TestBundleRun_RedactsInspectEnv(t *testing.T) {
+	t.Setenv(consts.EnvStateDir, t.TempDir())
+
+	fixture := mocks.RunningContainerFixture("", "dev")
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupFindContainer("clawker.dev", fixture)
+	inspect := fake.FakeAPI.ContainerInspectFn
+	fake.FakeAPI.ContainerInspectFn = func(ctx context.Context, id string, opts client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		res, err := inspect(ctx, id, opts)
+		if err == nil {
+			res.Container.Config.Env = []string{"DB_URL=postgres://app:hunter2@db/app", "PLAIN=hello", "EMPTY="}
+		}
+		return res, err
+	}
+	fake.SetupContainerLogs("")
+	fake.FakeAPI.CopyFromContainerFn = func(context.Context, string, client.CopyFromContainerOptions) (client.CopyFromContainerResult, error) {
+		return client.CopyFromContainerResult{}, errors.New("no such path")
+	}
+
+	tio, _, _, _ := iostreams.Test()
+	output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	opts := &BundleOptions{
+		IOStreams: tio,
+		Client:    func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		Config:    func() (config.Config, error) { return configmocks.NewBlankConfig(), nil },
+		Output:    output,
+		agent:     "dev",
+	}
+
+	require.NoError(t, bundleRun(context.Background(), opts))
+
+	raw := readBundle(t, output)["container/inspect.json"]
+	assert.NotContains(t, raw, "hunter2")
+	assert.NotContains(t, raw, "hello")
+	var inspected struct {
+		Config struct{ Env []string }
+	}
+	require.NoError(t, json.Unmarshal([]byte(raw), &inspected))
+	assert.Equal(t, []string{"DB_URL=<redacted>", "PLAIN=<redacted>", "EMPTY=<redacted>"}, inspected.Config.Env)
+}
+
+func TestBundleRun_ContainerNotFound(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList()
+
+	tio, _, _, _ := iostreams.Test()
+	output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	opts := &BundleOptions{
+		IOStreams: tio,
+		Client:    func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		Config:    func() (config.Config, error) { return configmocks.NewBlankConfig(), nil },
+		Output:    output,
+		agent:     "dev",
+	}
+
+	err := bundleRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	assert.NoFileExists(t, output)
+}
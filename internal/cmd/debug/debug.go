@@ -0,0 +1,29 @@
+// Package debug provides the `clawker debug` command group: tooling for
+// gathering diagnostics to attach to bug reports.
+package debug
+
+import (
+	"github.com/spf13/cobra"
+
+	bundlecmd "github.com/schmitthub/clawker/internal/cmd/debug/bundle"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+)
+
+// NewCmdDebug creates the debug parent command and registers its
+// subcommands.
+func NewCmdDebug(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Collect diagnostics for bug reports",
+		Long: `Commands for collecting diagnostics to attach to a clawker bug report.
+
+Run 'clawker doctor' first: it checks the environment and suggests fixes.
+Use these commands when you need to hand the details to someone else.`,
+		Example: `  # Bundle an agent's logs, config and container state
+  clawker debug bundle dev`,
+	}
+
+	cmd.AddCommand(bundlecmd.NewCmdBundle(f, nil))
+
+	return cmd
+}
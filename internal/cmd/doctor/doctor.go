@@ -0,0 +1,208 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/doctor"
+	"github.com/schmitthub/clawker/internal/hostproxy"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+)
+
+// DoctorOptions holds options for the doctor command.
+type DoctorOptions struct {
+	IOStreams      *iostreams.IOStreams
+	Config         func() (config.Config, error)
+	ProjectManager func() (project.ProjectManager, error)
+	Client         func(context.Context) (*docker.Client, error)
+	HostProxy      func() hostproxy.Service
+
+	JSON bool
+}
+
+// NewCmdDoctor creates the doctor command.
+func NewCmdDoctor(f *cmdutil.Factory, runF func(context.Context, *DoctorOptions) error) *cobra.Command {
+	opts := &DoctorOptions{
+		IOStreams:      f.IOStreams,
+		Config:         f.Config,
+		ProjectManager: f.ProjectManager,
+		Client:         f.Client,
+		HostProxy:      f.HostProxy,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the clawker environment",
+		Long: `Runs a series of checks against the local environment and reports what is
+wrong and how to fix it:
+
+  config            config files parse and match the schema
+  registry          registered project roots and worktrees still exist
+  docker            the Docker daemon is reachable
+  host-runtime      the Docker provider (Docker Desktop, Colima, Rancher Desktop,
+                    OrbStack, Podman) and whether its file sharing makes
+                    bind-mounted workspaces slow
+  monitoring-ports  monitoring stack ports are listening while the stack runs,
+                    and free while it is down
+  host-proxy        a running host proxy passes its readiness checks
+  gpg               gpg-agent is reachable when GPG forwarding is on
+  ssh               ssh-agent is reachable when SSH forwarding is on
+
+Exits non-zero when any check fails; warnings alone do not fail the run.`,
+		Example: `  # Check the environment
+  clawker doctor
+
+  # Machine-readable output
+  clawker doctor --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return doctorRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output as versioned JSON envelope")
+	cmdutil.EnableJSONOutput(cmd)
+
+	return cmd
+}
+
+func doctorRun(ctx context.Context, opts *DoctorOptions) error {
+	report := doctor.Run(ctx, buildChecks(opts))
+
+	if opts.JSON {
+		if err := opts.IOStreams.JSONPrinter().Print("doctor", report); err != nil {
+			return err
+		}
+	} else {
+		renderReport(opts.IOStreams, report)
+	}
+	if !report.OK {
+		return cmdutil.SilentError
+	}
+	return nil
+}
+
+// buildChecks assembles the checks in report order. Checks that read
+// project settings are skipped when the config does not load; the config
+// check reports why.
+func buildChecks(opts *DoctorOptions) []doctor.Check {
+	checks := []doctor.Check{
+		doctor.ConfigCheck(opts.Config),
+		doctor.RegistryCheck(func(ctx context.Context) ([]project.ProjectState, error) {
+			pm, err := opts.ProjectManager()
+			if err != nil {
+				return nil, err
+			}
+			return pm.ListProjects(ctx)
+		}),
+		doctor.DockerCheck(func(ctx context.Context) (string, error) {
+			client, err := opts.Client(ctx)
+			if err != nil {
+				return "", err
+			}
+			if err := client.HealthCheck(ctx); err != nil {
+				return "", err
+			}
+			endpoint := client.Endpoint().String()
+			if compat := client.Compat(); compat.Podman {
+				endpoint += " — " + compat.Name() + " compatibility mode"
+			}
+			return endpoint, nil
+		}),
+		doctor.HostRuntimeCheck(func(ctx context.Context) (doctor.HostRuntime, error) {
+			client, err := opts.Client(ctx)
+			if err != nil {
+				return doctor.HostRuntime{}, err
+			}
+			return hostRuntimeReport(client.HostRuntime()), nil
+		}),
+	}
+
+	cfg, err := opts.Config()
+	if err != nil {
+		for _, name := range []string{doctor.CheckMonitoringPorts, doctor.CheckHostProxy, doctor.CheckGPG, doctor.CheckSSH} {
+			checks = append(checks, doctor.Skipped(name, "config did not load"))
+		}
+		return checks
+	}
+
+	security := cfg.Project().Security
+	gc := security.GitCredentials
+	return append(checks,
+		doctor.MonitoringPortsCheck(doctor.MonitoringPorts(cfg.MonitoringConfig()), func(ctx context.Context) bool {
+			client, err := opts.Client(ctx)
+			if err != nil {
+				return false
+			}
+			return client.IsMonitoringActive(ctx)
+		}),
+		doctor.HostProxyCheck(opts.HostProxy(), security.HostProxyEnabled()),
+		doctor.GPGCheck(gc != nil && gc.GPGEnabled(), hostproxy.CheckGPGAgent),
+		doctor.SSHCheck(gc != nil && gc.GitSSHEnabled(), os.Getenv("SSH_AUTH_SOCK")),
+	)
+}
+
+// hostRuntimeReport turns the detected provider into the doctor check's
+// input, naming the file share alongside the provider when it is known.
+func hostRuntimeReport(rt docker.HostRuntime) doctor.HostRuntime {
+	advice := rt.FileSharingAdvice()
+	name := rt.Name()
+	if rt.FileSharing != docker.FileSharingUnknown {
+		name += " (" + string(rt.FileSharing) + " file sharing)"
+	}
+	return doctor.HostRuntime{
+		Name:            name,
+		SlowFileSharing: advice.Slow,
+		Cautions:        advice.Cautions,
+		Hint:            advice.Hint,
+		Remote:          rt.Remote,
+	}
+}
+
+// renderReport writes one line per check, its findings and hint indented
+// beneath, then a summary line.
+func renderReport(ios *iostreams.IOStreams, report doctor.Report) {
+	cs := ios.ColorScheme()
+
+	width := 0
+	for _, res := range report.Results {
+		width = max(width, len(res.Check))
+	}
+	for _, res := range report.Results {
+		var icon string
+		switch res.Status {
+		case doctor.StatusPass:
+			icon = cs.SuccessIcon()
+		case doctor.StatusWarn:
+			icon = cs.WarningIcon()
+		case doctor.StatusFail:
+			icon = cs.FailureIcon()
+		default:
+			icon = cs.Muted("-")
+		}
+		fmt.Fprintf(ios.Out, "%s %-*s  %s\n", icon, width, res.Check, res.Message)
+		indent := strings.Repeat(" ", width+4)
+		for _, d := range res.Details {
+			fmt.Fprintf(ios.Out, "%s- %s\n", indent, d)
+		}
+		if res.Hint != "" {
+			fmt.Fprintf(ios.Out, "%s%s\n", indent, cs.Muted("hint: "+res.Hint))
+		}
+	}
+
+	counts := report.Counts()
+	fmt.Fprintln(ios.Out)
+	fmt.Fprintf(ios.Out, "Summary: %d pass, %d warn, %d fail, %d skip\n",
+		counts[doctor.StatusPass], counts[doctor.StatusWarn], counts[doctor.StatusFail], counts[doctor.StatusSkip])
+}
@@ -0,0 +1,101 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/doctor"
+	"github.com/schmitthub/clawker/internal/hostproxy"
+	"github.com/schmitthub/clawker/internal/hostproxy/hostproxytest"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+)
+
+func TestNewCmdDoctor(t *testing.T) {
+	tf := cmdutiltest.NewFactory(t)
+
+	var gotOpts *DoctorOptions
+	cmd := NewCmdDoctor(tf.Factory, func(_ context.Context, opts *DoctorOptions) error {
+		gotOpts = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"--json"})
+	require.NoError(t, cmd.Execute())
+
+	require.NotNil(t, gotOpts)
+	assert.True(t, gotOpts.JSON)
+	assert.Same(t, tf.IOStreams, gotOpts.IOStreams)
+}
+
+// testOptions wires a loadable blank config, an empty registry, a stopped
+// host proxy, and an unreachable Docker daemon.
+func testOptions(t *testing.T, tio *iostreams.IOStreams) *DoctorOptions {
+	t.Helper()
+	t.Setenv("SSH_AUTH_SOCK", "")
+	cfg := configmocks.NewBlankConfig()
+	return &DoctorOptions{
+		IOStreams:      tio,
+		Config:         func() (config.Config, error) { return cfg, nil },
+		ProjectManager: func() (project.ProjectManager, error) { return projectmocks.NewMockProjectManager(), nil },
+		Client: func(context.Context) (*docker.Client, error) {
+			return nil, errors.New("cannot connect to the Docker daemon")
+		},
+		HostProxy: func() hostproxy.Service { return hostproxytest.NewMockManager() },
+	}
+}
+
+func TestDoctorRun_JSONReportsFailure(t *testing.T) {
+	tio, _, out, _ := iostreams.Test()
+	opts := testOptions(t, tio)
+	opts.JSON = true
+
+	err := doctorRun(context.Background(), opts)
+	assert.ErrorIs(t, err, cmdutil.SilentError, "a failed check exits non-zero")
+
+	var envelope struct {
+		Kind string        `json:"kind"`
+		Data doctor.Report `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &envelope))
+	assert.Equal(t, "doctor", envelope.Kind)
+	assert.False(t, envelope.Data.OK)
+
+	statuses := map[string]doctor.Status{}
+	for _, res := range envelope.Data.Results {
+		statuses[res.Check] = res.Status
+	}
+	assert.Equal(t, doctor.StatusPass, statuses[doctor.CheckConfig])
+	assert.Equal(t, doctor.StatusPass, statuses[doctor.CheckRegistry])
+	assert.Equal(t, doctor.StatusFail, statuses[doctor.CheckDocker])
+	assert.Equal(t, doctor.StatusSkip, statuses[doctor.CheckHostRuntime], "the docker check already reports the unreachable daemon")
+	assert.Equal(t, doctor.StatusSkip, statuses[doctor.CheckHostProxy])
+	assert.Contains(t, statuses, doctor.CheckMonitoringPorts)
+	assert.Contains(t, statuses, doctor.CheckGPG)
+	assert.Contains(t, statuses, doctor.CheckSSH)
+}
+
+func TestDoctorRun_ConfigErrorSkipsDependentChecks(t *testing.T) {
+	tio, _, out, _ := iostreams.Test()
+	opts := testOptions(t, tio)
+	opts.Config = func() (config.Config, error) { return nil, errors.New("clawker.yaml: line 3: unknown field") }
+
+	err := doctorRun(context.Background(), opts)
+	assert.ErrorIs(t, err, cmdutil.SilentError)
+
+	text := out.String()
+	assert.Contains(t, text, "[error] config")
+	assert.Contains(t, text, "unknown field")
+	assert.Contains(t, text, "config did not load")
+	assert.Contains(t, text, "Summary: 1 pass, 0 warn, 2 fail, 5 skip")
+}
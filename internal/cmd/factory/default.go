@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,15 +16,18 @@ import (
 	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
 	"github.com/schmitthub/clawker/controlplane/adminclient"
 	"github.com/schmitthub/clawker/controlplane/manager"
+	"github.com/schmitthub/clawker/internal/audit"
 	"github.com/schmitthub/clawker/internal/bundle"
 	"github.com/schmitthub/clawker/internal/bundle/componentcheck"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/git"
 	"github.com/schmitthub/clawker/internal/hostproxy"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/notify"
 	"github.com/schmitthub/clawker/internal/project"
 	"github.com/schmitthub/clawker/internal/prompter"
 	"github.com/schmitthub/clawker/internal/socketbridge"
@@ -76,6 +80,7 @@ func New(version string) *cmdutil.Factory {
 	f.ControlPlane = controlPlaneFunc(f)   // depends on Config, Logger, Client
 	f.HttpClient = httpClientFunc()        // stdlib *http.Client; tests substitute via custom RoundTripper
 	f.BundleManager = bundleManagerFunc(f) // depends on Config
+	f.Notifier = notifierFunc(f)           // depends on Config, Logger
 
 	return f
 }
@@ -105,6 +110,30 @@ func bundleManagerFunc(f *cmdutil.Factory) func() (*bundle.Manager, error) {
 	}
 }
 
+// notifierFunc returns a sync.Once-cached lazy closure that yields the desktop
+// notifier for the settings notifications: block. A config or logger failure
+// yields nil — notifications are best-effort and never fail a command.
+func notifierFunc(f *cmdutil.Factory) func() *notify.Notifier {
+	var (
+		once sync.Once
+		n    *notify.Notifier
+	)
+	return func() *notify.Notifier {
+		once.Do(func() {
+			cfg, err := f.Config()
+			if err != nil {
+				return
+			}
+			log, err := f.Logger()
+			if err != nil {
+				return
+			}
+			n = notify.New(cfg.Settings().Notifications, log)
+		})
+		return n
+	}
+}
+
 // registeredRootsFn lists every registered project root and worktree path —
 // the directories whose declarations count as bundle cache GC roots. Resolved
 // lazily per GC pass so the registry read happens only when a prune or an
@@ -229,6 +258,9 @@ func newLogger(f *cmdutil.Factory) (*logger.Logger, error) {
 			MaxQueueSize:   loggingCfg.Otel.MaxQueueSize,
 			ExportInterval: time.Duration(loggingCfg.Otel.ExportIntervalSeconds) * time.Second,
 			ServiceName:    "clawker-cli",
+			// Command and Docker API spans ride the same lane into the
+			// collector's traces pipeline.
+			Traces: true,
 		}
 	}
 
@@ -316,7 +348,15 @@ func clientFunc(f *cmdutil.Factory) func(context.Context) (*docker.Client, error
 				clientErr = fmt.Errorf("failed to get logger: %w", logErr)
 				return
 			}
-			client, clientErr = docker.NewClient(ctx, cfg, log)
+			opts := []docker.ClientOption{docker.WithDryRun(f.DryRun)}
+			// Mutating Docker calls go to the audit journal. An unusable
+			// state dir costs the audit trail, not the command.
+			if path, pathErr := consts.AuditLogPath(); pathErr == nil {
+				opts = append(opts, docker.WithAudit(audit.ActorCLI, path))
+			} else {
+				log.Warn().Err(pathErr).Msg("audit journal unavailable")
+			}
+			client, clientErr = docker.NewClient(ctx, cfg, log, opts...)
 		})
 		return client, clientErr
 	}
@@ -445,10 +485,15 @@ func projectRegistryFunc() func() (*project.Registry, error) {
 // anchor path. Empty anchor (CWD not within a registered project) disables
 // walk-up. config never reaches back to the project manager, so the
 // dependency is one-way.
+//
+// The selected --profile (f.Profile) is folded over the cached base on each
+// call rather than at load: startup hooks read config before flags parse.
 func configFunc(f *cmdutil.Factory) func() (config.Config, error) {
 	var cachedConfig config.Config
 	var configError error
-	return func() (config.Config, error) {
+	var profiled config.Config
+	var profiledName string
+	load := func() (config.Config, error) {
 		if cachedConfig != nil || configError != nil {
 			return cachedConfig, configError
 		}
@@ -466,12 +511,66 @@ func configFunc(f *cmdutil.Factory) func() (config.Config, error) {
 			configError = fmt.Errorf("resolving project root for config walk-up: %w", err)
 			return nil, configError
 		}
-		cachedConfig, configError = config.NewConfig(config.WithProjectRoot(root))
+		// Host commands declared by project files run only once approved;
+		// the approvals live in the registry, outside the workspace.
+		var trusted []string
+		if root != "" {
+			if trusted, err = reg.TrustedHostCommands(root); err != nil {
+				configError = fmt.Errorf("reading trusted host commands: %w", err)
+				return nil, configError
+			}
+		}
+		cachedConfig, configError = config.NewConfig(
+			config.WithProjectRoot(root),
+			config.WithTrustedHostCommands(trusted),
+			config.WithWriteHook(auditConfigWrite(f)),
+		)
 		return cachedConfig, configError
 	}
+	return func() (config.Config, error) {
+		cfg, err := load()
+		if err != nil || f.Profile == "" {
+			return cfg, err
+		}
+		if profiled == nil || profiledName != f.Profile {
+			p, err := config.ForProfile(cfg, f.Profile)
+			if err != nil {
+				return nil, err
+			}
+			profiled, profiledName = p, f.Profile
+		}
+		return profiled, nil
+	}
 }
 
 // prompterFunc returns a closure that creates a new Prompter.
+// auditConfigWrite records each project config or settings file write in
+// the audit journal. Only the keys are recorded — values may be secrets.
+func auditConfigWrite(f *cmdutil.Factory) func(path string, sets, deletes []string) {
+	return func(path string, sets, deletes []string) {
+		params := map[string]string{}
+		if len(sets) > 0 {
+			params["set"] = strings.Join(sets, ",")
+		}
+		if len(deletes) > 0 {
+			params["removed"] = strings.Join(deletes, ",")
+		}
+		err := audit.Append(audit.Entry{
+			Event:    audit.EventOperation,
+			Actor:    audit.ActorCLI,
+			Action:   "write",
+			Resource: "config",
+			Target:   path,
+			Params:   params,
+		})
+		if err != nil {
+			if log, logErr := f.Logger(); logErr == nil {
+				log.Warn().Err(err).Str("path", path).Msg("recording audit entry")
+			}
+		}
+	}
+}
+
 func prompterFunc(f *cmdutil.Factory) func() *prompter.Prompter {
 	return func() *prompter.Prompter {
 		return prompter.NewPrompter(f.IOStreams)
@@ -7,9 +7,11 @@ import (
 
 	"google.golang.org/grpc/connectivity"
 
+	"github.com/schmitthub/clawker/internal/audit"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/schmitthub/clawker/internal/project"
 	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+	"github.com/schmitthub/clawker/internal/testenv"
 )
 
 func TestNew(t *testing.T) {
@@ -92,3 +94,29 @@ func TestRegisteredRootsFn(t *testing.T) {
 		}
 	}
 }
+
+func TestAuditConfigWrite(t *testing.T) {
+	testenv.New(t)
+	f := New("1.0.0")
+
+	auditConfigWrite(f)("/proj/clawker.yaml", []string{"build.image", "agent.env"}, []string{"security.docker_socket"})
+
+	paths, err := audit.Paths()
+	if err != nil {
+		t.Fatalf("audit.Paths: %v", err)
+	}
+	entries, err := audit.Read(paths, time.Time{})
+	if err != nil {
+		t.Fatalf("audit.Read: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Actor != audit.ActorCLI || e.Action != "write" || e.Resource != "config" || e.Target != "/proj/clawker.yaml" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if e.Params["set"] != "build.image,agent.env" || e.Params["removed"] != "security.docker_socket" {
+		t.Errorf("unexpected params: %v", e.Params)
+	}
+}
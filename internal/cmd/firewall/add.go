@@ -7,6 +7,7 @@ import (
 
 	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/spf13/cobra"
 )
@@ -15,12 +16,14 @@ import (
 type AddOptions struct {
 	IOStreams   *iostreams.IOStreams
 	AdminClient func(context.Context) (adminv1.AdminServiceClient, error)
+	Config      func() (config.Config, error)
 	Domain      string
 	Proto       string
 	Port        string
 	Path        string
 	Action      string
 	Methods     []string
+	Save        bool
 }
 
 // NewCmdAdd creates the firewall add command.
@@ -28,11 +31,13 @@ func NewCmdAdd(f *cmdutil.Factory, runF func(context.Context, *AddOptions) error
 	opts := &AddOptions{
 		IOStreams:   f.IOStreams,
 		AdminClient: f.AdminClient,
+		Config:      f.Config,
 	}
 
 	cmd := &cobra.Command{
-		Use:   "add <domain>",
-		Short: "Add an egress rule",
+		Use:     "add <domain>",
+		Aliases: []string{"allow"},
+		Short:   "Add an egress rule",
 		Long: `Add a domain to the firewall allow list. The rule takes effect immediately
 via hot-reload — no container restart required.
 
@@ -50,7 +55,11 @@ A --path is a literal prefix by default, so --path /repos/x also matches
 /repos/x-evil. Prefix the path with ~ to match it as a regex instead, which is
 anchored end-to-end for exact matching (e.g. ~/repos/(a|b)/? matches only those
 two repos, with or without a trailing slash). Quote regex paths — the shell
-expands ~/ and treats ( | ? as special.`,
+expands ~/ and treats ( | ? as special.
+
+The rule lives in the firewall's rule store, shared by every project. Pass
+--save to also write it into the current project's clawker.yaml
+(security.firewall.rules), so it is re-applied wherever the project runs.`,
 		Example: `  # Allow HTTPS traffic to a domain
   clawker firewall add registry.npmjs.org
 
@@ -70,7 +79,10 @@ expands ~/ and treats ( | ? as special.`,
   clawker firewall add api.github.com --path /repos/ --action deny --methods POST,PUT,PATCH,DELETE
 
   # Allow only two repos exactly (regex, anchored) — blocks /repos/clawker-evil
-  clawker firewall add api.github.com --path '~/repos/(clawker|anthropic)/?' --action allow`,
+  clawker firewall add api.github.com --path '~/repos/(clawker|anthropic)/?' --action allow
+
+  # Allow a domain now and keep it in the project config
+  clawker firewall allow pypi.org --save`,
 		Args: cmdutil.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Domain = args[0]
@@ -86,6 +98,7 @@ expands ~/ and treats ( | ? as special.`,
 	cmd.Flags().StringVar(&opts.Path, "path", "", "URL path for a path-scoped rule: a literal prefix (e.g. /v1), or an RE2 regex if prefixed with ~ for exact matching (e.g. ~/repos/(a|b)/?); requires --action")
 	cmd.Flags().StringVar(&opts.Action, "action", "", "Action for the path rule: allow or deny (requires --path)")
 	cmd.Flags().StringSliceVar(&opts.Methods, "methods", nil, "HTTP methods the path rule applies to (e.g. GET,HEAD); empty = all methods. Requires --path/--action; https/http/ws/wss only")
+	cmd.Flags().BoolVar(&opts.Save, "save", false, "Also persist the rule to the current project's config")
 	cmd.MarkFlagsRequiredTogether("path", "action")
 
 	return cmd
@@ -174,5 +187,12 @@ func addRun(ctx context.Context, opts *AddOptions) error {
 		return fmt.Errorf("adding firewall rule: server returned unknown status %v", statuses[0])
 	}
 
+	if opts.Save {
+		saved := config.EgressRule{Dst: opts.Domain, Proto: opts.Proto, Port: opts.Port}
+		if opts.Path != "" {
+			saved.PathRules = []config.PathRule{{Path: opts.Path, Action: opts.Action, Methods: opts.Methods}}
+		}
+		return saveToProject(ios, opts.Config, saved)
+	}
 	return nil
 }
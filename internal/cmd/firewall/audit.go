@@ -0,0 +1,177 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// AuditOptions holds the options for the firewall audit command.
+type AuditOptions struct {
+	IOStreams      *iostreams.IOStreams
+	TUI            *tui.TUI
+	ProjectManager func() (project.ProjectManager, error)
+	AdminClient    func(context.Context) (adminv1.AdminServiceClient, error)
+	Format         *cmdutil.FormatFlags
+
+	Agent  string
+	Denied bool
+}
+
+// auditRow is the JSON/template-friendly representation of one audit entry.
+type auditRow struct {
+	Domain    string `json:"domain"`
+	Action    string `json:"action"`
+	Count     uint64 `json:"count"`
+	FirstSeen string `json:"first_seen"`
+	LastSeen  string `json:"last_seen"`
+}
+
+// NewCmdAudit creates the firewall audit command.
+func NewCmdAudit(f *cmdutil.Factory, runF func(context.Context, *AuditOptions) error) *cobra.Command {
+	opts := &AuditOptions{
+		IOStreams:      f.IOStreams,
+		TUI:            f.TUI,
+		ProjectManager: f.ProjectManager,
+		AdminClient:    f.AdminClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "audit <agent>",
+		Short: "Show the domains an agent has looked up",
+		Long: `Show the egress audit log of an agent: every domain it resolved since the
+control plane started, whether the firewall allowed or denied it, and how often.
+
+Use it to discover what an agent actually needs before tightening rules — run
+the agent through its normal work, then allow the denied domains it depends on.
+
+The log is built from the firewall's DNS query log, so it lists domains, not
+ports, and only covers traffic that went through a DNS lookup. It is kept in
+memory by the control plane and capped at 1024 domains per agent.`,
+		Example: `  # Show every domain the dev agent looked up
+  clawker firewall audit dev
+
+  # Only the lookups the firewall blocked
+  clawker firewall audit dev --denied
+
+  # Output as JSON
+  clawker firewall audit dev --json`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Agent = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return auditRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Denied, "denied", false, "Only show denied lookups")
+	opts.Format = cmdutil.AddFormatFlags(cmd)
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.AgentCompletions(f.Client, f.ProjectManager))
+
+	return cmd
+}
+
+func auditRun(ctx context.Context, opts *AuditOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	var projectName string
+	if opts.ProjectManager != nil {
+		if pm, pmErr := opts.ProjectManager(); pmErr == nil {
+			if p, pErr := pm.CurrentProject(ctx); pErr == nil {
+				projectName = p.Name()
+			}
+		}
+	}
+
+	client, err := opts.AdminClient(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to control plane: %w", err)
+	}
+
+	resp, err := callWithSpinner(ctx, ios, fmt.Sprintf("Fetching audit log for %s...", opts.Agent),
+		func(rpcCtx context.Context) (*adminv1.FirewallAuditResult, error) {
+			return client.FirewallAudit(rpcCtx, &adminv1.FirewallAuditRequest{
+				Project:   projectName,
+				AgentName: opts.Agent,
+			})
+		})
+	if err != nil {
+		return wrapRPCError(fmt.Sprintf("fetching audit log for %s", opts.Agent), err)
+	}
+
+	// Entries arrive sorted denied-first, then by count.
+	rows := make([]auditRow, 0, len(resp.GetEntries()))
+	denied := 0
+	for _, e := range resp.GetEntries() {
+		if e.GetAction() == consts.VerdictDenied {
+			denied++
+		} else if opts.Denied {
+			continue
+		}
+		rows = append(rows, auditRow{
+			Domain:    e.GetDomain(),
+			Action:    e.GetAction(),
+			Count:     e.GetCount(),
+			FirstSeen: formatAuditTime(e.GetFirstSeenUnix()),
+			LastSeen:  formatAuditTime(e.GetLastSeenUnix()),
+		})
+	}
+
+	switch {
+	case opts.Format.Quiet:
+		for _, r := range rows {
+			fmt.Fprintln(ios.Out, r.Domain)
+		}
+		return nil
+
+	case opts.Format.IsJSON():
+		return opts.Format.WriteJSON(ios, "firewall.audit", rows)
+
+	case opts.Format.IsTemplate():
+		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(rows))
+	}
+
+	if len(rows) == 0 {
+		fmt.Fprintf(ios.Out, "No lookups recorded for agent %s since %s.\n",
+			opts.Agent, formatAuditTime(resp.GetSinceUnix()))
+		return nil
+	}
+
+	tp := opts.TUI.NewTable("DOMAIN", "ACTION", "COUNT", "LAST SEEN")
+	for _, r := range rows {
+		tp.AddRow(r.Domain, r.Action, strconv.FormatUint(r.Count, 10), r.LastSeen)
+	}
+	if err := tp.Render(); err != nil {
+		return err
+	}
+
+	if n := resp.GetDropped(); n > 0 {
+		fmt.Fprintf(ios.ErrOut, "%s %d lookups of further domains were not recorded (per-agent limit reached)\n",
+			cs.WarningIcon(), n)
+	}
+	if denied > 0 {
+		fmt.Fprintf(ios.ErrOut, "\nAllow a denied domain with: clawker firewall allow <domain> --save\n")
+	}
+	return nil
+}
+
+// formatAuditTime renders a Unix timestamp from the audit log in local time.
+func formatAuditTime(unix int64) string {
+	if unix == 0 {
+		return ""
+	}
+	return time.Unix(unix, 0).Local().Format(time.DateTime)
+}
@@ -0,0 +1,89 @@
+package firewall
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	adminv1mocks "github.com/schmitthub/clawker/api/admin/v1/mocks"
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// auditMock returns an AdminClient whose FirewallAudit records the request
+// into got and answers with entries.
+func auditMock(got **adminv1.FirewallAuditRequest, entries ...*adminv1.FirewallAuditEntry) func(context.Context) (adminv1.AdminServiceClient, error) {
+	return func(_ context.Context) (adminv1.AdminServiceClient, error) {
+		return &adminv1mocks.AdminServiceClientMock{
+			FirewallAuditFunc: func(_ context.Context, req *adminv1.FirewallAuditRequest, _ ...grpc.CallOption) (*adminv1.FirewallAuditResult, error) {
+				*got = req
+				return &adminv1.FirewallAuditResult{Entries: entries, SinceUnix: 1700000000}, nil
+			},
+		}, nil
+	}
+}
+
+var testAuditEntries = []*adminv1.FirewallAuditEntry{
+	{Domain: "tracker.example.com", Action: "denied", Count: 3, FirstSeenUnix: 1700000000, LastSeenUnix: 1700000100},
+	{Domain: "api.github.com", Action: "allowed", Count: 12, FirstSeenUnix: 1700000000, LastSeenUnix: 1700000200},
+}
+
+func TestAuditCmd_Table(t *testing.T) {
+	f, out, errOut := testFactoryWithStreams(t)
+	f.TUI = tui.NewTUI(f.IOStreams)
+	var got *adminv1.FirewallAuditRequest
+	f.AdminClient = auditMock(&got, testAuditEntries...)
+
+	cmd := NewCmdAudit(f, nil)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"dev"})
+	require.NoError(t, cmd.Execute())
+
+	require.NotNil(t, got)
+	assert.Equal(t, "dev", got.GetAgentName())
+	assert.Contains(t, out.String(), "tracker.example.com")
+	assert.Contains(t, out.String(), "api.github.com")
+	assert.Contains(t, errOut.String(), "clawker firewall allow <domain> --save")
+}
+
+func TestAuditCmd_DeniedJSON(t *testing.T) {
+	f, out, _ := testFactoryWithStreams(t)
+	var got *adminv1.FirewallAuditRequest
+	f.AdminClient = auditMock(&got, testAuditEntries...)
+
+	cmd := NewCmdAudit(f, nil)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"dev", "--denied", "--format", "json"})
+	require.NoError(t, cmd.Execute())
+
+	var rows []auditRow
+	require.NoError(t, json.Unmarshal(out.Bytes(), &rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, "tracker.example.com", rows[0].Domain)
+	assert.Equal(t, uint64(3), rows[0].Count)
+}
+
+func TestAuditCmd_Empty(t *testing.T) {
+	f, out, _ := testFactoryWithStreams(t)
+	var got *adminv1.FirewallAuditRequest
+	f.AdminClient = auditMock(&got)
+
+	cmd := NewCmdAudit(f, nil)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"dev"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "No lookups recorded for agent dev")
+}
+
+func TestAuditCmd_RequiresAgent(t *testing.T) {
+	f, _, _ := testFactoryWithStreams(t)
+	cmd := NewCmdAudit(f, func(context.Context, *AuditOptions) error { return nil })
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	require.Error(t, cmd.Execute())
+}
@@ -92,6 +92,8 @@ Use --stop to cancel an active bypass immediately.`,
 	cmd.Flags().BoolVar(&opts.NonInteractive, "non-interactive", false, "Start bypass in background (use --stop to cancel)")
 	_ = cmd.MarkFlagRequired("agent")
 
+	cmd.RegisterFlagCompletionFunc("agent", cmdutil.AgentCompletions(f.Client, f.ProjectManager)) //nolint:errcheck,gosec // only errors on a programmer mistake (flag must exist); flags are defined above
+
 	return cmd
 }
 
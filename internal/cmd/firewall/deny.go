@@ -0,0 +1,125 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/spf13/cobra"
+)
+
+// DenyOptions holds the options for the firewall deny command.
+type DenyOptions struct {
+	IOStreams   *iostreams.IOStreams
+	AdminClient func(context.Context) (adminv1.AdminServiceClient, error)
+	Config      func() (config.Config, error)
+	Domain      string
+	Proto       string
+	Port        string
+	Save        bool
+}
+
+// NewCmdDeny creates the firewall deny command.
+func NewCmdDeny(f *cmdutil.Factory, runF func(context.Context, *DenyOptions) error) *cobra.Command {
+	opts := &DenyOptions{
+		IOStreams:   f.IOStreams,
+		AdminClient: f.AdminClient,
+		Config:      f.Config,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "deny <domain>",
+		Short: "Add a deny egress rule",
+		Long: `Block a domain with an explicit deny rule. The rule takes effect immediately
+via hot-reload — no container restart required.
+
+An existing allow rule for the same domain/proto/port is flipped to deny.
+Rules are re-synced from config when a container starts, so a domain the
+project config allows is allowed again then — pass --save to write the deny
+into the current project's clawker.yaml (security.firewall.rules) as well.
+Use 'clawker firewall remove' to drop the rule again. To deny only some paths
+of an allowed domain, use 'clawker firewall add --path ... --action deny'.`,
+		Example: `  # Block a domain for every agent
+  clawker firewall deny telemetry.example.com
+
+  # Block SSH to a host and keep the rule in the project config
+  clawker firewall deny git.example.com --proto ssh --save`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Domain = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return denyRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Proto, "proto", "https", "Protocol: https (default), http, ssh, tcp, or any opaque protocol name")
+	cmd.Flags().StringVar(&opts.Port, "port", "", "Destination port: a single port (443) or an inclusive range (9000-9100); default: protocol-specific")
+	cmd.Flags().BoolVar(&opts.Save, "save", false, "Also persist the rule to the current project's config")
+
+	return cmd
+}
+
+func denyRun(ctx context.Context, opts *DenyOptions) error {
+	ios := opts.IOStreams
+
+	// Same legacy alias rewrite as add.
+	if strings.EqualFold(opts.Proto, "tls") {
+		opts.Proto = "https"
+	}
+	if err := validatePortFlag(opts.Port); err != nil {
+		return err
+	}
+
+	client, err := opts.AdminClient(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to control plane: %w", err)
+	}
+
+	rule := &adminv1.EgressRule{
+		Dst:    opts.Domain,
+		Proto:  opts.Proto,
+		Port:   opts.Port,
+		Action: "deny",
+	}
+	resp, err := callWithSpinner(ctx, ios, fmt.Sprintf("Denying %s...", opts.Domain),
+		func(rpcCtx context.Context) (*adminv1.FirewallAddRulesResult, error) {
+			return client.FirewallAddRules(rpcCtx, &adminv1.FirewallAddRulesRequest{Rules: []*adminv1.EgressRule{rule}})
+		})
+	if err != nil {
+		return wrapRPCError("adding deny rule", err)
+	}
+
+	cs := ios.ColorScheme()
+	statuses := resp.GetStatuses()
+	if len(statuses) != 1 {
+		return fmt.Errorf("adding deny rule: server returned %d statuses, want 1", len(statuses))
+	}
+	switch statuses[0] {
+	case adminv1.AddRuleStatus_ADD_RULE_STATUS_ADDED:
+		fmt.Fprintf(ios.Out, "%s Denied: %s (%s)\n", cs.SuccessIcon(), opts.Domain, opts.Proto)
+		printStackRestartedNote(ios, resp.GetStackRestarted(), "rule persisted")
+	case adminv1.AddRuleStatus_ADD_RULE_STATUS_MODIFIED:
+		fmt.Fprintf(ios.Out, "%s Updated rule: %s (%s) is now denied\n", cs.SuccessIcon(), opts.Domain, opts.Proto)
+		printStackRestartedNote(ios, resp.GetStackRestarted(), "rule persisted")
+	case adminv1.AddRuleStatus_ADD_RULE_STATUS_UNCHANGED:
+		fmt.Fprintf(ios.Out, "%s Already denied: %s (%s) — no change\n", cs.InfoIcon(), opts.Domain, opts.Proto)
+	default:
+		return fmt.Errorf("adding deny rule: server returned unknown status %v", statuses[0])
+	}
+
+	if opts.Save {
+		return saveToProject(ios, opts.Config, config.EgressRule{
+			Dst:    opts.Domain,
+			Proto:  opts.Proto,
+			Port:   opts.Port,
+			Action: "deny",
+		})
+	}
+	return nil
+}
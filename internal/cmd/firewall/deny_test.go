@@ -0,0 +1,69 @@
+package firewall
+
+import (
+	"context"
+	"testing"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	adminv1mocks "github.com/schmitthub/clawker/api/admin/v1/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// addRulesMock returns an AdminClient whose FirewallAddRules records the
+// request into got and answers with status.
+func addRulesMock(got **adminv1.FirewallAddRulesRequest, status adminv1.AddRuleStatus) func(context.Context) (adminv1.AdminServiceClient, error) {
+	return func(_ context.Context) (adminv1.AdminServiceClient, error) {
+		return &adminv1mocks.AdminServiceClientMock{
+			FirewallAddRulesFunc: func(_ context.Context, req *adminv1.FirewallAddRulesRequest, _ ...grpc.CallOption) (*adminv1.FirewallAddRulesResult, error) {
+				*got = req
+				return &adminv1.FirewallAddRulesResult{Statuses: []adminv1.AddRuleStatus{status}}, nil
+			},
+		}, nil
+	}
+}
+
+func TestDenyCmd_BuildsDenyRule(t *testing.T) {
+	f, out, _ := testFactoryWithStreams(t)
+	var got *adminv1.FirewallAddRulesRequest
+	f.AdminClient = addRulesMock(&got, adminv1.AddRuleStatus_ADD_RULE_STATUS_ADDED)
+
+	cmd := NewCmdDeny(f, nil)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"git.example.com", "--proto", "ssh", "--port", "22"})
+	require.NoError(t, cmd.Execute())
+
+	require.NotNil(t, got)
+	require.Len(t, got.GetRules(), 1)
+	rule := got.GetRules()[0]
+	assert.Equal(t, "git.example.com", rule.GetDst())
+	assert.Equal(t, "ssh", rule.GetProto())
+	assert.Equal(t, "22", rule.GetPort())
+	assert.Equal(t, "deny", rule.GetAction())
+	assert.Contains(t, out.String(), "Denied: git.example.com (ssh)")
+}
+
+func TestDenyCmd_Modified_PrintsFlip(t *testing.T) {
+	f, out, _ := testFactoryWithStreams(t)
+	var got *adminv1.FirewallAddRulesRequest
+	f.AdminClient = addRulesMock(&got, adminv1.AddRuleStatus_ADD_RULE_STATUS_MODIFIED)
+
+	cmd := NewCmdDeny(f, nil)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"api.example.com"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "api.example.com (https) is now denied")
+}
+
+func TestDenyCmd_InvalidPort_NoRPC(t *testing.T) {
+	f, _, _ := testFactoryWithStreams(t)
+	var got *adminv1.FirewallAddRulesRequest
+	f.AdminClient = addRulesMock(&got, adminv1.AddRuleStatus_ADD_RULE_STATUS_ADDED)
+
+	cmd := NewCmdDeny(f, nil)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"api.example.com", "--port", "99999"})
+	require.Error(t, cmd.Execute())
+	assert.Nil(t, got, "RPC must not fire for an invalid port")
+}
@@ -49,6 +49,8 @@ Re-enable later with 'clawker firewall enable'.`,
 	cmd.Flags().StringVar(&opts.Agent, "agent", "", "Agent name to identify the container")
 	_ = cmd.MarkFlagRequired("agent")
 
+	cmd.RegisterFlagCompletionFunc("agent", cmdutil.AgentCompletions(f.Client, f.ProjectManager)) //nolint:errcheck,gosec // only errors on a programmer mistake (flag must exist); flags are defined above
+
 	return cmd
 }
 
@@ -49,6 +49,8 @@ Idempotent. Use after 'clawker firewall disable'.`,
 	cmd.Flags().StringVar(&opts.Agent, "agent", "", "Agent name to identify the container")
 	_ = cmd.MarkFlagRequired("agent")
 
+	cmd.RegisterFlagCompletionFunc("agent", cmdutil.AgentCompletions(f.Client, f.ProjectManager)) //nolint:errcheck,gosec // only errors on a programmer mistake (flag must exist); flags are defined above
+
 	return cmd
 }
 
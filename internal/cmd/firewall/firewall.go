@@ -28,6 +28,12 @@ CoreDNS (DNS-level allow/deny).`,
   # Remove a domain
   clawker firewall remove registry.npmjs.org
 
+  # Block a domain, and keep the rule in the project config
+  clawker firewall deny telemetry.example.com --save
+
+  # See which domains an agent looked up, and which were blocked
+  clawker firewall audit dev
+
   # Temporarily bypass firewall for an agent
   clawker firewall bypass 30s --agent dev`,
 	}
@@ -39,6 +45,8 @@ CoreDNS (DNS-level allow/deny).`,
 		NewCmdList(f, nil),
 		NewCmdAdd(f, nil),
 		NewCmdRemove(f, nil),
+		NewCmdDeny(f, nil),
+		NewCmdAudit(f, nil),
 		NewCmdReload(f, nil),
 		NewCmdRefresh(f, nil),
 		NewCmdDisable(f, nil),
@@ -85,7 +85,7 @@ func TestListRun_SortsByDomain(t *testing.T) {
 		},
 		{
 			name: "json",
-			args: []string{"--json"},
+			args: []string{"--format", "json"},
 			verify: func(t *testing.T, stdout string) {
 				t.Helper()
 				var rows []ruleRow
@@ -135,7 +135,7 @@ func TestListRun_SortsByDomainProtoPort(t *testing.T) {
 	f, stdout := newListCmd(t, rules, nil)
 	cmd := NewCmdList(f, nil)
 	cmd.SetContext(context.Background())
-	cmd.SetArgs([]string{"--json"})
+	cmd.SetArgs([]string{"--format", "json"})
 
 	err := cmd.Execute()
 	require.NoError(t, err)
@@ -196,7 +196,7 @@ func TestListRun_WithPaths(t *testing.T) {
 		f, stdout := newListCmd(t, rules, nil)
 		cmd := NewCmdList(f, nil)
 		cmd.SetContext(context.Background())
-		cmd.SetArgs([]string{"--json"})
+		cmd.SetArgs([]string{"--format", "json"})
 
 		require.NoError(t, cmd.Execute())
 
@@ -253,7 +253,7 @@ func TestListRun_WithMethodGatedPaths(t *testing.T) {
 		f, stdout := newListCmd(t, rules, nil)
 		cmd := NewCmdList(f, nil)
 		cmd.SetContext(context.Background())
-		cmd.SetArgs([]string{"--json"})
+		cmd.SetArgs([]string{"--format", "json"})
 
 		require.NoError(t, cmd.Execute())
 
@@ -323,7 +323,7 @@ func TestListRun_WithDenylistPaths_InfersAllowDefault(t *testing.T) {
 		f, stdout := newListCmd(t, rules, nil)
 		cmd := NewCmdList(f, nil)
 		cmd.SetContext(context.Background())
-		cmd.SetArgs([]string{"--json"})
+		cmd.SetArgs([]string{"--format", "json"})
 
 		require.NoError(t, cmd.Execute())
 
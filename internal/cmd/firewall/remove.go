@@ -9,6 +9,7 @@ import (
 
 	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/iostreams"
 )
 
@@ -16,10 +17,12 @@ import (
 type RemoveOptions struct {
 	IOStreams   *iostreams.IOStreams
 	AdminClient func(context.Context) (adminv1.AdminServiceClient, error)
+	Config      func() (config.Config, error)
 	Domain      string
 	Proto       string
 	Port        string
 	Path        string
+	Save        bool
 }
 
 // NewCmdRemove creates the firewall remove command.
@@ -27,13 +30,18 @@ func NewCmdRemove(f *cmdutil.Factory, runF func(context.Context, *RemoveOptions)
 	opts := &RemoveOptions{
 		IOStreams:   f.IOStreams,
 		AdminClient: f.AdminClient,
+		Config:      f.Config,
 	}
 
 	cmd := &cobra.Command{
 		Use:   "remove <domain>",
 		Short: "Remove an egress rule",
 		Long: `Remove a domain from the firewall allow list. The change takes effect
-immediately via hot-reload — no container restart required.`,
+immediately via hot-reload — no container restart required.
+
+Pass --save to also drop the rule (or, with --path, the path rule) from the
+current project's clawker.yaml, including a matching add_domains entry, so
+it is not re-applied the next time the project's rules are synced.`,
 		Example: `  # Remove a domain rule
   clawker firewall remove registry.npmjs.org
 
@@ -41,7 +49,10 @@ immediately via hot-reload — no container restart required.`,
   clawker firewall remove git.example.com --proto ssh --port 22
 
   # Remove a single path rule from a domain entry (entry itself stays)
-  clawker firewall remove api.example.com --path /v1`,
+  clawker firewall remove api.example.com --path /v1
+
+  # Remove a rule and drop it from the project config too
+  clawker firewall remove pypi.org --save`,
 		Args: cmdutil.RequiresMinArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Domain = args[0]
@@ -59,6 +70,7 @@ immediately via hot-reload — no container restart required.`,
 		StringVar(&opts.Port, "port", "", "Destination port: a single port (443) or an inclusive range (9000-9100)")
 	cmd.Flags().
 		StringVar(&opts.Path, "path", "", "Remove a single path rule by its stored path (exact string match); omit to remove the whole entry")
+	cmd.Flags().BoolVar(&opts.Save, "save", false, "Also remove the rule from the current project's config")
 
 	return cmd
 }
@@ -168,5 +180,8 @@ func removeRun(ctx context.Context, opts *RemoveOptions) error {
 		return fmt.Errorf("removing firewall rule: server returned unknown status %v", resp.GetStatus())
 	}
 
+	if opts.Save {
+		return removeFromProject(ios, opts.Config, opts.Domain, opts.Proto, opts.Port, opts.Path)
+	}
 	return nil
 }
@@ -0,0 +1,240 @@
+package firewall
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/storage"
+)
+
+// Project config paths --save reads and rewrites.
+const (
+	rulesPath      = "security.firewall.rules"
+	addDomainsPath = "security.firewall.add_domains"
+)
+
+// saveTarget resolves the project config file --save writes to: the
+// highest-priority discovered project layer in the walk-up. The user-level
+// clawker.yaml in the config directory is never a target, and no file is
+// created — a project must already have one.
+func saveTarget(cfg config.Config) (string, error) {
+	configDir := filepath.Clean(config.ConfigDir())
+	for _, layer := range cfg.ProjectStore().Layers() {
+		if layer.Path == "" {
+			continue // defaults / string-backed layer
+		}
+		path := filepath.Clean(layer.Path)
+		if filepath.Dir(path) == configDir {
+			continue // user-level project config, not the project's
+		}
+		return path, nil
+	}
+	return "", fmt.Errorf("no project config found in the walk-up; run inside a clawker project (see 'clawker init')")
+}
+
+// projectRules is the egress config of a single project config file.
+type projectRules struct {
+	store   *storage.Store[config.Project]
+	path    string
+	rules   []config.EgressRule
+	domains []string
+
+	rulesChanged, domainsChanged bool
+}
+
+// openProjectRules reads the egress config of the --save target through an
+// isolated single-file store, so the write carries only this file's rules —
+// never rules merged in from other layers or schema defaults.
+func openProjectRules(cfg config.Config) (*projectRules, error) {
+	path, err := saveTarget(cfg)
+	if err != nil {
+		return nil, err
+	}
+	store, err := storage.New[config.Project]("",
+		storage.WithPaths(filepath.Dir(path)),
+		storage.WithFilenames(filepath.Base(path)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("opening project config %s: %w", path, err)
+	}
+	p := &projectRules{store: store, path: path}
+	if _, err := store.Get(rulesPath, &p.rules); err != nil {
+		return nil, fmt.Errorf("reading firewall rules from %s: %w", path, err)
+	}
+	if _, err := store.Get(addDomainsPath, &p.domains); err != nil {
+		return nil, fmt.Errorf("reading firewall domains from %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// changed reports whether add or remove modified anything.
+func (p *projectRules) changed() bool {
+	return p.rulesChanged || p.domainsChanged
+}
+
+// write persists the modified lists back to the file.
+func (p *projectRules) write() error {
+	if p.rulesChanged {
+		if err := p.store.Set(rulesPath, p.rules); err != nil {
+			return fmt.Errorf("updating %s: %w", p.path, err)
+		}
+	}
+	if p.domainsChanged {
+		if err := p.store.Set(addDomainsPath, p.domains); err != nil {
+			return fmt.Errorf("updating %s: %w", p.path, err)
+		}
+	}
+	if err := p.store.WriteTo(p.path); err != nil {
+		return fmt.Errorf("saving %s: %w", p.path, err)
+	}
+	return nil
+}
+
+// find returns the index of the rule keyed like the store keys it
+// (dst:proto:port), or -1.
+func (p *projectRules) find(dst, proto, port string) int {
+	for i, r := range p.rules {
+		if strings.EqualFold(r.Dst, dst) && normalizeProto(r.Proto) == normalizeProto(proto) && r.Port == port {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasDomain reports whether dst is in the add_domains shorthand list.
+func (p *projectRules) hasDomain(dst string) bool {
+	return slices.ContainsFunc(p.domains, func(d string) bool { return strings.EqualFold(d, dst) })
+}
+
+// add merges rule into the file's rules with the same semantics the store
+// applies to FirewallAddRules: a path rule is upserted onto its entry by
+// path, otherwise the entry's action is set. A deny for a domain listed in
+// add_domains takes it out of that allow shorthand.
+func (p *projectRules) add(rule config.EgressRule) {
+	shorthand := normalizeProto(rule.Proto) == "https" && rule.Port == "" && len(rule.PathRules) == 0
+	if shorthand && isDeny(rule.Action) && p.hasDomain(rule.Dst) {
+		p.dropDomain(rule.Dst)
+	}
+	i := p.find(rule.Dst, rule.Proto, rule.Port)
+	if i < 0 {
+		if shorthand && !isDeny(rule.Action) && p.hasDomain(rule.Dst) {
+			return // already allowed by add_domains
+		}
+		p.rules = append(p.rules, rule)
+		p.rulesChanged = true
+		return
+	}
+
+	existing := &p.rules[i]
+	if len(rule.PathRules) > 0 {
+		pr := rule.PathRules[0]
+		for j, cur := range existing.PathRules {
+			if cur.Path == pr.Path {
+				if cur.Action != pr.Action || !slices.Equal(cur.Methods, pr.Methods) {
+					existing.PathRules[j] = pr
+					p.rulesChanged = true
+				}
+				return
+			}
+		}
+		existing.PathRules = append(existing.PathRules, pr)
+		p.rulesChanged = true
+		return
+	}
+	if isDeny(existing.Action) != isDeny(rule.Action) {
+		existing.Action = rule.Action
+		p.rulesChanged = true
+	}
+}
+
+// remove drops the entry keyed by dst:proto:port, or only its path rule
+// when path is set. A whole-entry removal of an https default-port rule also
+// drops dst from add_domains.
+func (p *projectRules) remove(dst, proto, port, path string) {
+	if i := p.find(dst, proto, port); i >= 0 {
+		if path == "" {
+			p.rules = slices.Delete(p.rules, i, i+1)
+			p.rulesChanged = true
+		} else {
+			before := len(p.rules[i].PathRules)
+			p.rules[i].PathRules = slices.DeleteFunc(p.rules[i].PathRules, func(pr config.PathRule) bool { return pr.Path == path })
+			p.rulesChanged = len(p.rules[i].PathRules) != before
+		}
+	}
+	if path == "" && normalizeProto(proto) == "https" && port == "" && p.hasDomain(dst) {
+		p.dropDomain(dst)
+	}
+}
+
+// dropDomain removes dst from add_domains.
+func (p *projectRules) dropDomain(dst string) {
+	p.domains = slices.DeleteFunc(p.domains, func(d string) bool { return strings.EqualFold(d, dst) })
+	p.domainsChanged = true
+}
+
+// saveToProject persists rule into the project config (--save on add/deny).
+// The live change has already been applied, so a failure here says so.
+func saveToProject(ios *iostreams.IOStreams, cfgFn func() (config.Config, error), rule config.EgressRule) error {
+	p, err := openSaveTarget(cfgFn)
+	if err != nil {
+		return err
+	}
+	p.add(rule)
+	return finishSave(ios, p, "rule already saved in")
+}
+
+// removeFromProject drops a rule from the project config (--save on remove).
+func removeFromProject(ios *iostreams.IOStreams, cfgFn func() (config.Config, error), dst, proto, port, path string) error {
+	p, err := openSaveTarget(cfgFn)
+	if err != nil {
+		return err
+	}
+	p.remove(dst, proto, port, path)
+	return finishSave(ios, p, "no matching rule saved in")
+}
+
+// openSaveTarget loads the config and opens its --save target. Errors note
+// that the live change was applied but not saved.
+func openSaveTarget(cfgFn func() (config.Config, error)) (*projectRules, error) {
+	cfg, err := cfgFn()
+	if err != nil {
+		return nil, fmt.Errorf("rule applied but not saved: loading config: %w", err)
+	}
+	p, err := openProjectRules(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("rule applied but not saved: %w", err)
+	}
+	return p, nil
+}
+
+// finishSave writes p when changed and reports the outcome.
+func finishSave(ios *iostreams.IOStreams, p *projectRules, unchangedMsg string) error {
+	cs := ios.ColorScheme()
+	if !p.changed() {
+		fmt.Fprintf(ios.ErrOut, "%s %s %s\n", cs.InfoIcon(), unchangedMsg, p.path)
+		return nil
+	}
+	if err := p.write(); err != nil {
+		return fmt.Errorf("rule applied but not saved: %w", err)
+	}
+	fmt.Fprintf(ios.Out, "%s Saved to %s\n", cs.SuccessIcon(), p.path)
+	return nil
+}
+
+// normalizeProto mirrors the store's proto normalization: empty and the
+// legacy "tls" alias mean https.
+func normalizeProto(proto string) string {
+	p := strings.ToLower(proto)
+	if p == "" || p == "tls" {
+		return "https"
+	}
+	return p
+}
+
+func isDeny(action string) bool {
+	return strings.EqualFold(action, "deny")
+}
@@ -0,0 +1,176 @@
+package firewall
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	adminv1mocks "github.com/schmitthub/clawker/api/admin/v1/mocks"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v3"
+)
+
+// newSaveEnv builds a project dir holding projectYAML as .clawker.yaml, a
+// user-level clawker.yaml carrying a rule of its own, and a config whose
+// project store discovers both. Returns the config and the project file.
+func newSaveEnv(t *testing.T, projectYAML string) (config.Config, string) {
+	t.Helper()
+	configDir := t.TempDir()
+	t.Setenv("CLAWKER_CONFIG_DIR", configDir)
+	userYAML := "security:\n  firewall:\n    add_domains: [user.example.com]\n"
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "clawker.yaml"), []byte(userYAML), 0o644))
+
+	proj := t.TempDir()
+	target := filepath.Join(proj, ".clawker.yaml")
+	require.NoError(t, os.WriteFile(target, []byte(projectYAML), 0o644))
+
+	store, err := storage.New[config.Project](storage.GenerateDefaultsYAML[config.Project](),
+		storage.WithFilenames(consts.ProjectLocalConfigFile, consts.ProjectConfigFile),
+		storage.WithDirs(proj),
+		storage.WithConfigDir(),
+	)
+	require.NoError(t, err)
+
+	mock := configmocks.NewBlankConfig()
+	mock.ProjectStoreFunc = func() *storage.Store[config.Project] { return store }
+	mock.ProjectFunc = func() *config.Project { return store.Read() }
+	return mock, target
+}
+
+// readFirewall decodes the security.firewall block of a project file.
+func readFirewall(t *testing.T, path string) config.FirewallConfig {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var p config.Project
+	require.NoError(t, yaml.Unmarshal(data, &p))
+	if p.Security.Firewall == nil {
+		return config.FirewallConfig{}
+	}
+	return *p.Security.Firewall
+}
+
+func saveFactory(t *testing.T, cfg config.Config) *cmdutil.Factory {
+	t.Helper()
+	f, _, _ := testFactoryWithStreams(t)
+	f.Config = func() (config.Config, error) { return cfg, nil }
+	f.AdminClient = func(_ context.Context) (adminv1.AdminServiceClient, error) {
+		return &adminv1mocks.AdminServiceClientMock{
+			FirewallAddRulesFunc: func(_ context.Context, _ *adminv1.FirewallAddRulesRequest, _ ...grpc.CallOption) (*adminv1.FirewallAddRulesResult, error) {
+				return &adminv1.FirewallAddRulesResult{Statuses: []adminv1.AddRuleStatus{adminv1.AddRuleStatus_ADD_RULE_STATUS_ADDED}}, nil
+			},
+			FirewallRemoveRuleFunc: func(_ context.Context, _ *adminv1.FirewallRemoveRuleRequest, _ ...grpc.CallOption) (*adminv1.FirewallRemoveRuleResult, error) {
+				return &adminv1.FirewallRemoveRuleResult{Status: adminv1.RemoveRuleStatus_REMOVE_RULE_STATUS_REMOVED}, nil
+			},
+		}, nil
+	}
+	return f
+}
+
+func TestAllowCmd_Save_AppendsToProjectConfig(t *testing.T) {
+	cfg, target := newSaveEnv(t, "build:\n  image: node:20\nsecurity:\n  firewall:\n    rules:\n      - dst: github.com\n        proto: ssh\n        port: \"22\"\n")
+	f := saveFactory(t, cfg)
+
+	cmd := NewCmdFirewall(f)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"allow", "pypi.org", "--save"})
+	require.NoError(t, cmd.Execute())
+
+	fw := readFirewall(t, target)
+	require.Len(t, fw.Rules, 2)
+	assert.Equal(t, "github.com", fw.Rules[0].Dst)
+	assert.Equal(t, config.EgressRule{Dst: "pypi.org", Proto: "https"}, fw.Rules[1])
+	assert.Empty(t, fw.AddDomains, "the user-level domain must not be copied into the project file")
+
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "image: node:20", "unrelated keys are kept")
+	assert.NotContains(t, string(data), "default_mode", "schema defaults are not backfilled")
+}
+
+func TestAddCmd_Save_PathRuleUpsertsEntry(t *testing.T) {
+	cfg, target := newSaveEnv(t, "security:\n  firewall:\n    rules:\n      - dst: api.github.com\n        path_rules:\n          - path: /repos/\n            action: allow\n")
+	f := saveFactory(t, cfg)
+
+	cmd := NewCmdAdd(f, nil)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"api.github.com", "--path", "/repos/", "--action", "deny", "--methods", "POST", "--save"})
+	require.NoError(t, cmd.Execute())
+
+	fw := readFirewall(t, target)
+	require.Len(t, fw.Rules, 1)
+	assert.Equal(t, []config.PathRule{{Path: "/repos/", Action: "deny", Methods: []string{"POST"}}}, fw.Rules[0].PathRules)
+}
+
+func TestDenyCmd_Save_ReplacesAllowShorthand(t *testing.T) {
+	cfg, target := newSaveEnv(t, "security:\n  firewall:\n    add_domains: [telemetry.example.com, pypi.org]\n")
+	f := saveFactory(t, cfg)
+
+	cmd := NewCmdDeny(f, nil)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"telemetry.example.com", "--save"})
+	require.NoError(t, cmd.Execute())
+
+	fw := readFirewall(t, target)
+	assert.Equal(t, []string{"pypi.org"}, fw.AddDomains)
+	assert.Equal(t, []config.EgressRule{{Dst: "telemetry.example.com", Proto: "https", Action: "deny"}}, fw.Rules)
+}
+
+func TestAllowCmd_Save_AlreadyInShorthand_NoWrite(t *testing.T) {
+	const projectYAML = "security:\n  firewall:\n    add_domains: [pypi.org]\n"
+	cfg, target := newSaveEnv(t, projectYAML)
+	f := saveFactory(t, cfg)
+
+	cmd := NewCmdAdd(f, nil)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"PyPI.org", "--save"})
+	require.NoError(t, cmd.Execute())
+
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, projectYAML, string(data))
+}
+
+func TestRemoveCmd_Save_DropsRuleAndShorthand(t *testing.T) {
+	cfg, target := newSaveEnv(t, "security:\n  firewall:\n    add_domains: [pypi.org]\n    rules:\n      - dst: pypi.org\n        proto: tls\n      - dst: github.com\n        proto: ssh\n")
+	f := saveFactory(t, cfg)
+
+	cmd := NewCmdRemove(f, nil)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"pypi.org", "--save"})
+	require.NoError(t, cmd.Execute())
+
+	fw := readFirewall(t, target)
+	assert.Empty(t, fw.AddDomains)
+	require.Len(t, fw.Rules, 1)
+	assert.Equal(t, "github.com", fw.Rules[0].Dst)
+}
+
+func TestSave_NoProjectConfig_Errors(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("CLAWKER_CONFIG_DIR", configDir)
+	store, err := storage.New[config.Project](storage.GenerateDefaultsYAML[config.Project](),
+		storage.WithFilenames(consts.ProjectConfigFile),
+		storage.WithDirs(t.TempDir()),
+	)
+	require.NoError(t, err)
+	cfg := configmocks.NewBlankConfig()
+	cfg.ProjectStoreFunc = func() *storage.Store[config.Project] { return store }
+	f := saveFactory(t, cfg)
+
+	cmd := NewCmdAdd(f, nil)
+	cmd.SetContext(context.Background())
+	cmd.SetArgs([]string{"pypi.org", "--save"})
+	err = cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rule applied but not saved")
+}
@@ -116,7 +116,7 @@ func renderStatus(opts *StatusOptions, row statusRow) error {
 	// Format dispatch.
 	switch {
 	case opts.Format.IsJSON():
-		return cmdutil.WriteJSON(ios.Out, row)
+		return opts.Format.WriteJSON(ios, "firewall.status", row)
 
 	case opts.Format.IsTemplate():
 		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny([]statusRow{row}))
@@ -0,0 +1,102 @@
+package hostproxy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/controlplane/adminclient"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/hostproxy"
+)
+
+// cpIdleSource is the daemon's idle reaper source, backed by the control
+// plane: agent activity comes from ListAgents (the agents' own status
+// reports) and warnings go out as NotifyAgent. The admin connection is
+// dialed on first use and redialed after a failed call, so a control plane
+// that starts after the daemon, or restarts, is picked up.
+type cpIdleSource struct {
+	cfg config.Config
+
+	mu     sync.Mutex
+	conn   *grpc.ClientConn
+	client adminv1.AdminServiceClient
+}
+
+var _ hostproxy.IdleSource = (*cpIdleSource)(nil)
+
+func (s *cpIdleSource) admin(ctx context.Context) (adminv1.AdminServiceClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client != nil {
+		return s.client, nil
+	}
+	cp := s.cfg.Settings().ControlPlane
+	client, conn, err := adminclient.Dial(ctx, cp.AdminPort, cp.HydraPublicPort)
+	if err != nil {
+		return nil, fmt.Errorf("dial control plane: %w", err)
+	}
+	s.client, s.conn = client, conn
+	return client, nil
+}
+
+// reset drops the cached connection after a failed call.
+func (s *cpIdleSource) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	s.client, s.conn = nil, nil
+}
+
+// IdleAgents lists every agent, in every project, whose clawkerd reports
+// activity. Idle time is measured on the agent's own clock — the time
+// from its last activity to its latest sample — so host/VM clock drift
+// cannot stop an agent early.
+func (s *cpIdleSource) IdleAgents(ctx context.Context) ([]hostproxy.IdleAgent, error) {
+	client, err := s.admin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.ListAgents(ctx, &adminv1.ListAgentsRequest{AllProjects: true})
+	if err != nil {
+		s.reset()
+		return nil, err
+	}
+	var agents []hostproxy.IdleAgent
+	for _, a := range resp.GetAgents() {
+		st := a.GetStatus()
+		if st.GetLastActivityUnix() == 0 {
+			continue
+		}
+		idle := time.Duration(st.GetCollectedAtUnix()-st.GetLastActivityUnix()) * time.Second
+		agents = append(agents, hostproxy.IdleAgent{
+			ContainerID: a.GetContainerId(),
+			Project:     a.GetProject(),
+			Idle:        max(idle, 0),
+		})
+	}
+	return agents, nil
+}
+
+// Warn shows message on the agent's console through its clawkerd.
+func (s *cpIdleSource) Warn(ctx context.Context, agent hostproxy.IdleAgent, message string) error {
+	client, err := s.admin(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = client.NotifyAgent(ctx, &adminv1.NotifyAgentRequest{
+		ContainerId: agent.ContainerID,
+		Project:     agent.Project,
+		Message:     message,
+	})
+	if err != nil {
+		s.reset()
+	}
+	return err
+}
@@ -4,6 +4,7 @@ package hostproxy
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -61,6 +62,12 @@ func NewCmdServe() *cobra.Command {
 					defer l.Close(context.Background())
 				}
 			}
+			defer func() {
+				if r := recover(); r != nil {
+					log.ReportPanic(os.Stderr, r)
+					panic(r)
+				}
+			}()
 
 			// Collect flag overrides — these take precedence over config values
 			// without mutating the config object.
@@ -74,6 +81,9 @@ func NewCmdServe() *cobra.Command {
 			if cmd.Flags().Changed("grace-period") {
 				opts = append(opts, hostproxy.WithGracePeriod(gracePeriod))
 			}
+			// The idle reaper reads agent activity from the control plane;
+			// it stays dormant until settings idle_timeout is set.
+			opts = append(opts, hostproxy.WithIdleSource(&cpIdleSource{cfg: cfg}))
 
 			daemon, err := hostproxy.NewDaemon(cfg, log, opts...)
 			if err != nil {
@@ -82,6 +92,8 @@ func NewCmdServe() *cobra.Command {
 			}
 
 			ctx := context.Background()
+			// SIGUSR1 toggles debug logging without a restart.
+			logger.WatchLevelSignal(ctx, log)
 			if err := daemon.Run(ctx); err != nil {
 				log.Error().Err(err).Msg("daemon error")
 				return err
@@ -112,6 +124,7 @@ func NewCmdHostProxy() *cobra.Command {
 	cmd.AddCommand(NewCmdServe())
 	cmd.AddCommand(NewCmdStatus())
 	cmd.AddCommand(NewCmdStop())
+	cmd.AddCommand(NewCmdStats())
 
 	return cmd
 }
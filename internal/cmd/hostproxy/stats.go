@@ -0,0 +1,85 @@
+package hostproxy
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/hostproxy"
+	"github.com/schmitthub/clawker/internal/iostreams"
+)
+
+// NewCmdStats creates a command to show forwarded-traffic accounting.
+func NewCmdStats() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:    "stats",
+		Short:  "Show forwarded traffic per container",
+		Hidden: true,
+		Long: `Shows bytes forwarded per container per bridge type (gpg, ssh,
+tcp-forward, callbacks) over the last 1, 5, and 15 minutes, plus how long
+each stream was paused by the host_proxy.forward_cap_kibps bandwidth cap.`,
+		Example: `  # Show forwarded traffic
+  clawker host-proxy stats
+
+  # Machine-readable output
+  clawker host-proxy stats --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.NewConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			token, err := hostproxy.LoadHostReadToken(cfg)
+			if err != nil {
+				return err
+			}
+			report, err := hostproxy.FetchTraffic(cmd.Context(), cfg.HostProxyConfig().Manager.Port, token)
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				return iostreams.NewJSONPrinter(cmd.OutOrStdout()).Print("hostproxy.stats", report)
+			}
+			return writeTrafficTable(cmd.OutOrStdout(), report)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as versioned JSON envelope")
+	cmdutil.EnableJSONOutput(cmd)
+
+	return cmd
+}
+
+// writeTrafficTable renders a traffic report as an aligned table.
+func writeTrafficTable(w io.Writer, report *hostproxy.TrafficReport) error {
+	capLabel := "unlimited"
+	if report.CapBytesPerSec > 0 {
+		capLabel = units.BytesSize(float64(report.CapBytesPerSec)) + "/s per container"
+	}
+	fmt.Fprintf(w, "Forward cap: %s\n", capLabel)
+
+	if len(report.Streams) == 0 {
+		fmt.Fprintln(w, "No forwarded traffic recorded")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "CONTAINER\tBRIDGE\tTOTAL\t1M\t5M\t15M\tTHROTTLED")
+	for _, s := range report.Streams {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Container, s.Bridge,
+			units.BytesSize(float64(s.TotalBytes)),
+			units.BytesSize(float64(s.Bytes1m)),
+			units.BytesSize(float64(s.Bytes5m)),
+			units.BytesSize(float64(s.Bytes15m)),
+			s.Throttled.Round(time.Millisecond))
+	}
+	return tw.Flush()
+}
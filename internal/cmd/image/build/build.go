@@ -8,17 +8,20 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/schmitthub/clawker/internal/auth"
 	bundlepkg "github.com/schmitthub/clawker/internal/bundle"
 	"github.com/schmitthub/clawker/internal/bundler"
+	"github.com/schmitthub/clawker/internal/cmd/image/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/notify"
 	"github.com/schmitthub/clawker/internal/project"
 	"github.com/schmitthub/clawker/internal/signals"
 	"github.com/schmitthub/clawker/internal/tui"
@@ -36,9 +39,11 @@ type BuildOptions struct {
 	ProjectRegistry func() (*project.Registry, error)
 	HttpClient      func() (*http.Client, error)
 	BundleManager   func() (*bundlepkg.Manager, error)
+	Notifier        func() *notify.Notifier
 
 	Tags      []string // -t, --tag (multiple allowed)
 	NoCache   bool     // --no-cache
+	Force     bool     // --force-rebuild
 	Pull      bool     // --pull
 	BuildArgs []string // --build-arg KEY=VALUE
 	Labels    []string // --label KEY=VALUE (user labels)
@@ -46,7 +51,10 @@ type BuildOptions struct {
 	Quiet     bool     // -q, --quiet
 	Progress  string   // --progress (output formatting)
 	Network   string   // --network
+	Platforms []string // --platform (comma-separated or repeated)
 	IIDFile   string   // --iidfile (write built image ID/digest to file)
+	Record    string   // --record (capture progress events for replay)
+	NoScan    bool     // --no-scan (skip the build.scan hooks)
 }
 
 // NewCmdBuild creates the image build command.
@@ -61,6 +69,7 @@ func NewCmdBuild(f *cmdutil.Factory, runF func(context.Context, *BuildOptions) e
 		ProjectRegistry: f.ProjectRegistry,
 		HttpClient:      f.HttpClient,
 		BundleManager:   f.BundleManager,
+		Notifier:        f.Notifier,
 	}
 
 	cmd := &cobra.Command{
@@ -73,15 +82,42 @@ adds an extra ref (tag part must name a known harness). No -t builds the
 default harness and adds the :default alias.
 
 A shared base image (clawker-<project>:base) holds the harness-agnostic
-layers and is built or reused automatically; harness images build FROM it.`,
+layers and is built or reused automatically; harness images build FROM it.
+
+Both images are labeled with a hash of their inputs — the generated
+Dockerfile, the base image, the files clawker adds, build args, and labels.
+When nothing changed, the build is skipped and the existing image is reused.
+Use --force-rebuild to build anyway, or --no-cache to also bypass Docker's
+layer cache.
+
+--platform builds the base image for each listed platform, producing one
+multi-platform image that can be pushed to a registry and shared across
+architectures. More than one platform needs BuildKit and Docker's
+containerd image store. The harness image embeds clawkerd, which this
+clawker carries for its own architecture only, so it builds for the
+listed platform matching that architecture.
+
+When the project configures build.scan, each image built is then passed to
+the SBOM and vulnerability scanner commands, with the results stored under
+the data dir. A finding at build.scan.fail_on or worse fails the command;
+the image stays built. Use --no-scan to skip scanning.`,
 		Example: `  # Build the default harness image
   clawker image build
 
   # Build a specific harness
   clawker image build -t codex
 
+  # Rebuild even though the inputs are unchanged
+  clawker image build --force-rebuild
+
   # Rebuild from scratch
-  clawker image build --no-cache`,
+  clawker image build --no-cache
+
+  # Build without running the build.scan hooks
+  clawker image build --no-scan
+
+  # Build the base image for Apple Silicon and x86 hosts
+  clawker image build --platform linux/amd64,linux/arm64`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if runF != nil {
 				return runF(cmd.Context(), opts)
@@ -94,15 +130,20 @@ layers and is built or reused automatically; harness images build FROM it.`,
 	cmd.Flags().
 		StringArrayVarP(&opts.Tags, "tag", "t", nil, "Harness to build, or an extra ref whose tag names one (format: HARNESS or name:HARNESS)")
 	cmd.Flags().BoolVar(&opts.NoCache, "no-cache", false, "Do not use cache when building the image")
+	cmd.Flags().BoolVar(&opts.Force, "force-rebuild", false, "Build even when the image's inputs are unchanged")
 	cmd.Flags().BoolVar(&opts.Pull, "pull", false, "Always attempt to pull a newer version of the base image")
 	cmd.Flags().StringArrayVar(&opts.BuildArgs, "build-arg", nil, "Set build-time variables (format: KEY=VALUE)")
 	cmd.Flags().StringArrayVar(&opts.Labels, "label", nil, "Set metadata for the image (format: KEY=VALUE)")
 	cmd.Flags().StringVar(&opts.Target, "target", "", "Set the target build stage to build")
 	cmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Suppress the build output")
+	cmd.Flags().BoolVar(&opts.NoScan, "no-scan", false, "Skip the SBOM and vulnerability scan hooks configured in build.scan")
 	cmd.Flags().StringVar(&opts.Progress, "progress", "auto", "Set type of progress output (auto, plain, tty, none)")
 	cmd.Flags().StringVar(&opts.Network, "network", "", "Set the networking mode for the RUN instructions during build")
+	cmd.Flags().
+		StringArrayVar(&opts.Platforms, "platform", nil, "Set target platforms for the build (format: OS/ARCH[,OS/ARCH...])")
 	cmd.Flags().
 		StringVar(&opts.IIDFile, "iidfile", "", "Write the built image's ID/digest to this file (docker buildx --iidfile shape)")
+	shared.AddRecordFlag(cmd, &opts.Record)
 
 	return cmd
 }
@@ -133,6 +174,11 @@ func buildRun(ctx context.Context, opts *BuildOptions) error {
 
 	suppressed := opts.Quiet || opts.Progress == "none"
 
+	targetPlatforms, err := docker.NormalizePlatforms(opts.Platforms)
+	if err != nil {
+		return cmdutil.FlagErrorf("--platform: %v", err)
+	}
+
 	// Get configuration
 	cfgGateway, err := opts.Config()
 	if err != nil {
@@ -177,8 +223,10 @@ func buildRun(ctx context.Context, opts *BuildOptions) error {
 	log.Debug().
 		Str("project", projectName).
 		Bool("no-cache", opts.NoCache).
+		Bool("force-rebuild", opts.Force).
 		Bool("pull", opts.Pull).
 		Str("target", opts.Target).
+		Strs("platforms", targetPlatforms).
 		Bool("quiet", opts.Quiet).
 		Msg("starting build")
 
@@ -190,7 +238,12 @@ func buildRun(ctx context.Context, opts *BuildOptions) error {
 
 	// Check BuildKit availability — cache mounts in Dockerfile require it
 	buildkitEnabled, bkErr := docker.BuildKitEnabled(ctx, client.APIClient)
-	if bkErr != nil {
+	if client.Compat().Podman {
+		// Podman builds with Buildah behind the classic build endpoint,
+		// which honors cache mounts itself.
+		buildkitEnabled, bkErr = false, nil
+		log.Debug().Str("daemon", client.Compat().Name()).Msg("using the classic builder on Podman")
+	} else if bkErr != nil {
 		log.Warn().Err(bkErr).Msg("BuildKit detection failed")
 		fmt.Fprintf(ios.ErrOut, "%s BuildKit detection failed — falling back to legacy builder\n", cs.WarningIcon())
 	} else if !buildkitEnabled {
@@ -201,6 +254,12 @@ func buildRun(ctx context.Context, opts *BuildOptions) error {
 		)
 	}
 
+	if len(targetPlatforms) > 1 {
+		if err := checkMultiPlatform(ctx, log, client, buildkitEnabled); err != nil {
+			return err
+		}
+	}
+
 	// Parse build args
 	buildArgs := parseBuildArgs(opts.BuildArgs)
 
@@ -281,11 +340,13 @@ func buildRun(ctx context.Context, opts *BuildOptions) error {
 		Msg("building container image")
 	buildOpts := docker.BuilderOptions{
 		NoCache:         opts.NoCache,
+		ForceRebuild:    opts.Force,
 		Labels:          userLabels,
 		Target:          opts.Target,
 		Pull:            opts.Pull,
 		SuppressOutput:  suppressed,
 		NetworkMode:     opts.Network,
+		Platforms:       targetPlatforms,
 		BuildArgs:       buildArgs,
 		Tags:            extraTags,
 		BuildKitEnabled: buildkitEnabled,
@@ -296,34 +357,20 @@ func buildRun(ctx context.Context, opts *BuildOptions) error {
 		},
 	}
 
-	// Wire progress display when output is not suppressed.
-	// The build runs in a goroutine with events streamed to a TUI display.
-	if !suppressed {
-		ch := make(chan tui.ProgressStep, 64)
-		done := make(chan struct{})
-
-		buildOpts.OnProgress = func(event whail.BuildProgressEvent) {
-			select {
-			case <-done:
-				return // display already finished, discard late events
-			case ch <- tui.ProgressStep{
-				ID:      event.StepID,
-				Name:    event.StepName,
-				Status:  progressStatus(event.Status),
-				Cached:  event.Cached,
-				Error:   event.Error,
-				LogLine: event.LogLine,
-			}:
-			}
+	started := time.Now()
+	build := shared.RecordProgress(ios, opts.Record, "image build", imageTag, func(onProgress whail.BuildProgressFunc) error {
+		buildOpts.OnProgress = onProgress
+		err := builder.Build(ctx, imageTag, buildOpts)
+		if !builder.UpToDate() {
+			notifyBuild(ctx, opts.Notifier, imageTag, time.Since(started), err)
 		}
+		return err
+	})
 
-		buildErrCh := make(chan error, 1)
-		go func() {
-			buildErrCh <- builder.Build(ctx, imageTag, buildOpts)
-			close(ch) // channel closure = done signal
-		}()
-
-		result := opts.TUI.RunProgress(opts.Progress, tui.ProgressDisplayConfig{
+	// Wire progress display when output is not suppressed.
+	// Build events stream into a StepRunner that renders them as they arrive.
+	if !suppressed {
+		runner := opts.TUI.NewStepRunner(opts.Progress, tui.ProgressDisplayConfig{
 			Title:          "Building " + projectName,
 			Subtitle:       imageTag,
 			CompletionVerb: "Built",
@@ -333,42 +380,82 @@ func buildRun(ctx context.Context, opts *BuildOptions) error {
 			CleanName:      whail.CleanStepName,
 			ParseGroup:     whail.ParseBuildStage,
 			FormatDuration: whail.FormatBuildDuration,
-		}, ch)
-		close(done) // signal OnProgress callback to stop sending
+		})
 
-		// Always drain the build goroutine before deciding which error to
-		// surface. The goroutine sends to buildErrCh before closing ch, so
-		// by the time RunProgress returns buildErrCh is ready to read.
-		// Without this drain a TUI render error would mask a real build
-		// failure (e.g. both triggered by ctx cancel).
-		buildErr := <-buildErrCh
+		// Wait for the display even when the build fails so the summary
+		// renders; a display error (e.g. an interrupted TTY) must never
+		// mask a real build failure.
+		buildErr := build(func(event whail.BuildProgressEvent) {
+			runner.Send(shared.ProgressStep(event))
+		})
+		displayErr := runner.Wait()
 
 		// The progress display has torn down; surface resolution provenance now.
 		printProvenance(ios, cs, builder.Provenance())
 
 		if buildErr != nil {
-			if result.Err != nil {
-				log.Warn().Err(result.Err).Msg("progress display error masked by build error")
+			if displayErr != nil {
+				log.Warn().Err(displayErr).Msg("progress display error masked by build error")
 			}
 			printBuildNextSteps(ios, cs, buildErr)
 			return buildErr
 		}
-		if result.Err != nil {
-			return result.Err
+		if displayErr != nil {
+			return displayErr
+		}
+		printUpToDate(ios, cs, builder, imageTag)
+		if err := scanImage(ctx, opts, cfgGateway, builder.UpToDate(), imageTag, imageDigest); err != nil {
+			return err
 		}
 		return finishBuild(log, imageTag, imageDigest, opts.IIDFile)
 	}
 
 	// Suppressed output — build synchronously without progress display.
-	buildErr := builder.Build(ctx, imageTag, buildOpts)
+	buildErr := build(nil)
 	printProvenance(ios, cs, builder.Provenance())
 	if buildErr != nil {
 		printBuildNextSteps(ios, cs, buildErr)
 		return fmt.Errorf("building %s: %w", imageTag, buildErr)
 	}
+	if !opts.Quiet {
+		printUpToDate(ios, cs, builder, imageTag)
+	}
+	if err := scanImage(ctx, opts, cfgGateway, builder.UpToDate(), imageTag, imageDigest); err != nil {
+		return err
+	}
 	return finishBuild(log, imageTag, imageDigest, opts.IIDFile)
 }
 
+// notifyBuild raises the desktop notification for a finished build:
+// build_complete on success, failure otherwise. An interrupted build
+// raises nothing — the user is already at the terminal.
+func notifyBuild(ctx context.Context, notifier func() *notify.Notifier, imageTag string, elapsed time.Duration, err error) {
+	if notifier == nil || ctx.Err() != nil {
+		return
+	}
+	note := notify.Notification{
+		Event:   notify.EventBuildComplete,
+		Title:   "clawker image build",
+		Message: "Built " + imageTag,
+		Elapsed: elapsed,
+	}
+	if err != nil {
+		note.Event = notify.EventFailure
+		note.Message = "Build of " + imageTag + " failed"
+	}
+	notifier().Notify(ctx, note)
+}
+
+// printUpToDate tells the user the build was skipped on a content-hash
+// match, and how to force it.
+func printUpToDate(ios *iostreams.IOStreams, cs *iostreams.ColorScheme, builder *docker.Builder, imageTag string) {
+	if !builder.UpToDate() {
+		return
+	}
+	fmt.Fprintf(ios.ErrOut, "%s %s is up to date — inputs unchanged (use --force-rebuild to build anyway)\n",
+		cs.SuccessIcon(), imageTag)
+}
+
 // finishBuild logs build success and, when --iidfile is set, writes the
 // resolved image digest to the named file. Returns a hard error when the
 // user requested an --iidfile but the builder returned no digest, or when
@@ -390,21 +477,24 @@ func finishBuild(log *logger.Logger, imageTag, imageDigest, iidFile string) erro
 	return nil
 }
 
-// progressStatus converts a whail build step status to a tui progress step status.
-// Explicit switch avoids iota alignment tricks between packages.
-func progressStatus(s whail.BuildStepStatus) tui.ProgressStepStatus {
-	switch s {
-	case whail.BuildStepRunning:
-		return tui.StepRunning
-	case whail.BuildStepComplete:
-		return tui.StepComplete
-	case whail.BuildStepCached:
-		return tui.StepCached
-	case whail.BuildStepError:
-		return tui.StepError
-	default:
-		return tui.StepPending
+// checkMultiPlatform fails early when the daemon cannot produce a
+// multi-platform image: the classic builder emits one platform per build,
+// and only the containerd image store can load the resulting index. An
+// image store that can't be detected is left for the build to report.
+func checkMultiPlatform(ctx context.Context, log *logger.Logger, client *docker.Client, buildkitEnabled bool) error {
+	if !buildkitEnabled {
+		return fmt.Errorf("building for more than one --platform requires BuildKit")
 	}
+	ok, err := docker.MultiPlatformImageStore(ctx, client.APIClient)
+	if err != nil {
+		log.Warn().Err(err).Msg("image store detection failed — attempting multi-platform build")
+		return nil
+	}
+	if !ok {
+		return fmt.Errorf("building for more than one --platform requires Docker's containerd image store " +
+			"(Docker Desktop: Settings > General > \"Use containerd for pulling and storing images\")")
+	}
+	return nil
 }
 
 // parseBuildArgs parses KEY=VALUE build arguments into a map.
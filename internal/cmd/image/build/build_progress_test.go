@@ -1,14 +1,17 @@
 package build
 
 import (
+	"bytes"
 	"context"
 	"net/http"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -103,6 +106,105 @@ monitoring:
 	}
 }
 
+// recordedScenarioDir holds the whailtest recordings. A session captured with
+// `clawker image build --record FILE` dropped in here replays through
+// TestBuildProgress_RecordedReplay.
+var recordedScenarioDir = filepath.Join("..", "..", "..", "..", "pkg", "whail", "whailtest", "testdata")
+
+// newRecordedBuildFactory returns a Factory whose Docker client replays events
+// through the timed BuildKit fake, with delays scaled down so long recordings
+// stay fast.
+func newRecordedBuildFactory(t *testing.T, events []whailtest.RecordedBuildEvent) (*cmdutil.Factory, *bytes.Buffer) {
+	t.Helper()
+	env := testenv.New(t)
+	t.Setenv("DOCKER_BUILDKIT", "1")
+
+	testCfg := configmocks.NewFromString(`
+version: "1"
+name: test-project
+build: { image: "node:20-slim" }
+workspace: { default_mode: "bind" }
+security: {}
+`, `
+monitoring:
+  otel_collector_port: 4318
+  otel_grpc_port: 4317
+  telemetry:
+    log_tool_details: true
+    log_user_prompts: true
+    include_account_uuid: true
+    include_session_id: true
+`)
+	fake := mocks.NewFakeClient(testCfg)
+	capture := fake.SetupBuildKitWithRecordedProgress(events)
+	capture.DelayMultiplier = 0.01
+
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithConfig(testCfg), cmdutiltest.WithFakeClient(fake))
+	tf.ProjectRegistry = func() (*project.Registry, error) {
+		return env.Registry(t), nil
+	}
+	tf.HttpClient = func() (*http.Client, error) {
+		return stubHTTPClient("2.99.99-test")
+	}
+	return tf.Factory, tf.ErrOut
+}
+
+// TestBuildProgress_RecordedReplay replays every recorded scenario, synthetic
+// or captured from a real session, through the real build command.
+func TestBuildProgress_RecordedReplay(t *testing.T) {
+	scenarios, err := whailtest.LoadRecordedScenarios(recordedScenarioDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, scenarios)
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			f, errOut := newRecordedBuildFactory(t, scenario.Events)
+
+			cmd := NewCmdBuild(f, nil)
+			cmd.SetArgs([]string{"--progress", "plain"})
+			require.NoError(t, cmd.Execute())
+
+			output := errOut.String()
+			for _, event := range scenario.FlatEvents() {
+				if event.StepName == "" || whail.IsInternalStep(event.StepName) || event.LogLine != "" {
+					continue
+				}
+				assert.Contains(t, output, whail.CleanStepName(event.StepName))
+			}
+		})
+	}
+}
+
+// TestBuildProgress_Record verifies --record captures the session's events
+// and that the file replays as a scenario.
+func TestBuildProgress_Record(t *testing.T) {
+	source, err := whailtest.LoadRecordedScenario(filepath.Join(recordedScenarioDir, "simple.json"))
+	require.NoError(t, err)
+	f, _ := newRecordedBuildFactory(t, source.Events)
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	cmd := NewCmdBuild(f, nil)
+	cmd.SetArgs([]string{"--quiet", "--record", path})
+	require.NoError(t, cmd.Execute())
+
+	recorded, err := whailtest.LoadRecordedScenario(path)
+	require.NoError(t, err)
+	assert.Equal(t, "session", recorded.Name)
+	assert.Equal(t, "image build", recorded.Command)
+
+	// Base and harness builds each replay the source events, so every source
+	// step name appears (base-image steps also appear phase-prefixed).
+	var names []string
+	for _, event := range recorded.FlatEvents() {
+		names = append(names, event.StepName)
+	}
+	for _, event := range source.FlatEvents() {
+		if event.StepName != "" {
+			assert.Contains(t, names, event.StepName)
+		}
+	}
+}
+
 // TestBuildProgress_SimplePipeline validates specific output content for the simple scenario.
 func TestBuildProgress_SimplePipeline(t *testing.T) {
 	env := testenv.New(t)
@@ -37,6 +37,7 @@ func TestCmd_Flags(t *testing.T) {
 	}{
 		{"tag flag", "tag", "t", "[]"},
 		{"no-cache flag", "no-cache", "", "false"},
+		{"force-rebuild flag", "force-rebuild", "", "false"},
 		{"pull flag", "pull", "", "false"},
 		{"build-arg flag", "build-arg", "", "[]"},
 		{"label flag", "label", "", "[]"},
@@ -44,6 +45,8 @@ func TestCmd_Flags(t *testing.T) {
 		{"quiet flag", "quiet", "q", "false"},
 		{"progress flag", "progress", "", "auto"},
 		{"network flag", "network", "", ""},
+		{"platform flag", "platform", "", "[]"},
+		{"no-scan flag", "no-scan", "", "false"},
 	}
 
 	f := &cmdutil.Factory{
@@ -306,6 +309,27 @@ func TestCmd_FlagValuePropagation(t *testing.T) {
 				require.True(t, opts.NoCache)
 			},
 		},
+		{
+			name: "force-rebuild true",
+			args: []string{"--force-rebuild"},
+			verify: func(t *testing.T, opts *BuildOptions) {
+				require.True(t, opts.Force)
+			},
+		},
+		{
+			name: "platform values",
+			args: []string{"--platform", "linux/amd64,linux/arm64", "--platform", "linux/arm/v7"},
+			verify: func(t *testing.T, opts *BuildOptions) {
+				require.Equal(t, []string{"linux/amd64,linux/arm64", "linux/arm/v7"}, opts.Platforms)
+			},
+		},
+		{
+			name: "no-scan true",
+			args: []string{"--no-scan"},
+			verify: func(t *testing.T, opts *BuildOptions) {
+				require.True(t, opts.NoScan)
+			},
+		},
 		{
 			name: "pull true",
 			args: []string{"--pull"},
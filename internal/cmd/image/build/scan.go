@@ -0,0 +1,123 @@
+package build
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/imagescan"
+)
+
+// scanImage runs the project build.scan hooks against the image just built.
+// Skipped with --no-scan, when nothing is configured, and when the build was
+// skipped as up to date — that image was scanned when it was built. A scan
+// command from a project config file must be approved with clawker project
+// trust first. A finding at build.scan.fail_on or worse fails the command;
+// the image stays tagged.
+func scanImage(ctx context.Context, opts *BuildOptions, cfgGateway config.Config, upToDate bool, imageTag, imageID string) error {
+	cfg := cfgGateway.Project().Build.Scan
+	if opts.NoScan || upToDate || !imagescan.Enabled(cfg) {
+		return nil
+	}
+	for _, hook := range []struct{ key, command string }{
+		{"build.scan.sbom", cfg.SBOM},
+		{"build.scan.command", cfg.Command},
+	} {
+		if hook.command == "" {
+			continue
+		}
+		if err := cfgGateway.CheckHostCommand(hook.key); err != nil {
+			return fmt.Errorf("scanning %s: %w (or pass --no-scan)", imageTag, err)
+		}
+	}
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	root, err := consts.ScansSubdir()
+	if err != nil {
+		return fmt.Errorf("creating scans directory: %w", err)
+	}
+	dir := filepath.Join(root, scanDirName(imageTag, imageID))
+
+	// Hook stderr is held back so it cannot tear through the spinner; it is
+	// shown only when a hook fails.
+	var stderr bytes.Buffer
+	var summary *imagescan.Summary
+	scan := func() error {
+		var scanErr error
+		summary, scanErr = imagescan.Run(ctx, imagescan.Options{
+			Config:  cfg,
+			Image:   imageTag,
+			ImageID: imageID,
+			Dir:     dir,
+			Stderr:  &stderr,
+		})
+		return scanErr
+	}
+	if opts.Quiet {
+		err = scan()
+	} else {
+		err = ios.RunWithSpinner("Scanning "+imageTag, scan)
+	}
+	if err != nil {
+		if stderr.Len() > 0 {
+			ios.ErrOut.Write(stderr.Bytes()) //nolint:errcheck // best-effort diagnostics
+		}
+		return fmt.Errorf("scanning %s: %w", imageTag, err)
+	}
+
+	if summary.ParseError != "" {
+		fmt.Fprintf(ios.ErrOut, "%s Scan report kept but not summarized: %s\n", cs.WarningIcon(), summary.ParseError)
+	}
+	if summary.Failed {
+		return fmt.Errorf("%s has %d vulnerabilities at %s severity or worse (build.scan.fail_on); results in %s",
+			imageTag, summary.AtOrAbove, summary.FailOn, dir)
+	}
+	if !opts.Quiet {
+		line := "Scanned " + imageTag
+		if summary.Report != "" && summary.ParseError == "" {
+			line += ": " + formatCounts(summary.Counts)
+		}
+		fmt.Fprintf(ios.ErrOut, "%s %s — results in %s\n", cs.SuccessIcon(), line, dir)
+	}
+	return nil
+}
+
+// scanDirName names an image's scan directory: the tag made path-safe plus
+// the short image ID, so each build of a tag keeps its own results.
+func scanDirName(imageTag, imageID string) string {
+	name := strings.NewReplacer("/", "_", ":", "_").Replace(imageTag)
+	id := strings.TrimPrefix(imageID, "sha256:")
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	if id == "" {
+		return name
+	}
+	return name + "-" + id
+}
+
+// formatCounts renders severity counts worst first ("1 critical, 0 high,
+// ..."), then any scanner-specific severities alphabetically.
+func formatCounts(counts map[string]int) string {
+	var parts []string
+	for _, sev := range slices.Backward(imagescan.Severities) {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[sev], sev))
+	}
+	var other []string
+	for sev := range counts {
+		if !slices.Contains(imagescan.Severities, sev) {
+			other = append(other, sev)
+		}
+	}
+	slices.Sort(other)
+	for _, sev := range other {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[sev], sev))
+	}
+	return strings.Join(parts, ", ")
+}
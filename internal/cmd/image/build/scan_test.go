@@ -0,0 +1,101 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/imagescan"
+	"github.com/schmitthub/clawker/internal/iostreams"
+)
+
+const testScanReport = `{"matches":[{"vulnerability":{"severity":"High"}},{"vulnerability":{"severity":"Negligible"}}]}`
+
+func TestScanImage(t *testing.T) {
+	report := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, os.WriteFile(report, []byte(testScanReport), 0o644))
+	scanCfg := config.BuildScanConfig{Command: "cat " + report}
+
+	tests := []struct {
+		name     string
+		opts     BuildOptions
+		cfg      config.BuildScanConfig
+		upToDate bool
+		trust    error // CheckHostCommand result
+		wantErr  string
+		wantOut  string
+		wantDir  bool
+	}{
+		{name: "not configured", cfg: config.BuildScanConfig{}},
+		{name: "no-scan flag", opts: BuildOptions{NoScan: true}, cfg: scanCfg},
+		{name: "up to date", cfg: scanCfg, upToDate: true},
+		{
+			name:    "scanned",
+			cfg:     scanCfg,
+			wantOut: "0 critical, 1 high, 0 medium, 0 low, 1 negligible",
+			wantDir: true,
+		},
+		{
+			name:    "threshold met",
+			cfg:     config.BuildScanConfig{Command: scanCfg.Command, FailOn: "high"},
+			wantErr: "1 vulnerabilities at high severity or worse",
+			wantDir: true,
+		},
+		{
+			name:    "unapproved project command",
+			cfg:     scanCfg,
+			trust:   fmt.Errorf("%w: build.scan.command in /proj/.clawker.yaml", config.ErrUntrustedHostCommand),
+			wantErr: "build.scan.command in /proj/.clawker.yaml",
+		},
+		{
+			name:    "scanner fails",
+			cfg:     config.BuildScanConfig{Command: "echo boom >&2; exit 1"},
+			wantErr: "scanning clawker-app:claude",
+			wantOut: "boom",
+			wantDir: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(consts.EnvDataDir, t.TempDir())
+			ios, _, _, errBuf := iostreams.Test()
+			opts := tt.opts
+			opts.IOStreams = ios
+
+			cfg := configmocks.NewBlankConfig()
+			cfg.ProjectFunc = func() *config.Project { return &config.Project{Build: config.BuildConfig{Scan: tt.cfg}} }
+			cfg.CheckHostCommandFunc = func(string) error { return tt.trust }
+
+			err := scanImage(context.Background(), &opts, cfg, tt.upToDate, "clawker-app:claude", "sha256:0123456789abcdef")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Contains(t, errBuf.String(), tt.wantOut)
+
+			root := filepath.Join(consts.DataDir(), "scans")
+			summary := filepath.Join(root, "clawker-app_claude-0123456789ab", imagescan.SummaryFile)
+			if !tt.wantDir {
+				assert.NoDirExists(t, root)
+				return
+			}
+			if tt.wantErr == "" || tt.cfg.FailOn != "" {
+				assert.FileExists(t, summary)
+			}
+		})
+	}
+}
+
+func TestScanDirName(t *testing.T) {
+	assert.Equal(t, "ghcr.io_acme_app_claude-0123456789ab", scanDirName("ghcr.io/acme/app:claude", "sha256:0123456789abcdef"))
+	assert.Equal(t, "clawker-app_claude", scanDirName("clawker-app:claude", ""))
+}
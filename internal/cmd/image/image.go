@@ -6,8 +6,12 @@ import (
 
 	"github.com/schmitthub/clawker/internal/cmd/image/build"
 	"github.com/schmitthub/clawker/internal/cmd/image/inspect"
+	"github.com/schmitthub/clawker/internal/cmd/image/layers"
 	"github.com/schmitthub/clawker/internal/cmd/image/list"
+	"github.com/schmitthub/clawker/internal/cmd/image/outdated"
 	"github.com/schmitthub/clawker/internal/cmd/image/prune"
+	"github.com/schmitthub/clawker/internal/cmd/image/pull"
+	"github.com/schmitthub/clawker/internal/cmd/image/push"
 	"github.com/schmitthub/clawker/internal/cmd/image/remove"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 )
@@ -34,6 +38,16 @@ image management commands.`,
   # Inspect an image
   clawker image inspect clawker-myapp:latest
 
+  # Find the largest layers of an image
+  clawker image layers clawker-myapp:latest --largest 5
+
+  # Check whether images were built on an outdated base
+  clawker image outdated
+
+  # Push an image to a registry, then pull it on another machine
+  clawker image push ghcr.io/acme/agent:claude
+  clawker image pull ghcr.io/acme/agent:claude
+
   # Remove unused images
   clawker image prune`,
 		// No RunE - this is a parent command
@@ -42,8 +56,12 @@ image management commands.`,
 	// Add subcommands
 	cmd.AddCommand(build.NewCmdBuild(f, nil))
 	cmd.AddCommand(inspect.NewCmdInspect(f, nil))
+	cmd.AddCommand(layers.NewCmdLayers(f, nil))
 	cmd.AddCommand(list.NewCmdList(f, nil))
+	cmd.AddCommand(outdated.NewCmdOutdated(f, nil))
 	cmd.AddCommand(prune.NewCmdPrune(f, nil))
+	cmd.AddCommand(pull.NewCmdPull(f, nil))
+	cmd.AddCommand(push.NewCmdPush(f, nil))
 	cmd.AddCommand(remove.NewCmdRemove(f, nil))
 
 	return cmd
@@ -40,8 +40,8 @@ func TestNewCmdImage_Subcommands(t *testing.T) {
 	// Get registered subcommands
 	subcommands := cmd.Commands()
 
-	// Expect 5 subcommands: build, inspect, list, prune, remove
-	require.Len(t, subcommands, 5)
+	// Expect 9 subcommands: build, inspect, layers, list, outdated, prune, pull, push, remove
+	require.Len(t, subcommands, 9)
 
 	// Get subcommand names and sort them
 	var names []string
@@ -51,6 +51,6 @@ func TestNewCmdImage_Subcommands(t *testing.T) {
 	sort.Strings(names)
 
 	// Verify expected subcommands (alphabetically sorted)
-	expected := []string{"build", "inspect", "list", "prune", "remove"}
+	expected := []string{"build", "inspect", "layers", "list", "outdated", "prune", "pull", "push", "remove"}
 	require.Equal(t, expected, names)
 }
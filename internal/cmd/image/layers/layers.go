@@ -0,0 +1,317 @@
+// Package layers provides the image layers command.
+package layers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/moby/moby/api/types/build"
+	"github.com/moby/moby/api/types/image"
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/text"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// createdByWidth is the CREATED BY column width when output is truncated.
+const createdByWidth = 60
+
+// Cache status values reported per layer.
+const (
+	cacheCached = "cached"
+	cacheNone   = "-"
+)
+
+// LayersOptions holds options for the layers command.
+type LayersOptions struct {
+	IOStreams *iostreams.IOStreams
+	TUI       *tui.TUI
+	Client    func(context.Context) (*docker.Client, error)
+
+	Format  *cmdutil.FormatFlags
+	Image   string
+	Largest int
+	Tree    bool
+	NoTrunc bool
+}
+
+// NewCmdLayers creates the image layers command.
+func NewCmdLayers(f *cmdutil.Factory, runF func(context.Context, *LayersOptions) error) *cobra.Command {
+	opts := &LayersOptions{
+		IOStreams: f.IOStreams,
+		TUI:       f.TUI,
+		Client:    f.Client,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "layers IMAGE",
+		Short: "Show the layers of an image and what they cost",
+		Long: `Shows the layers of a clawker image with their size, share of the
+image, BuildKit cache status, and the instruction that created them.
+
+Layers are numbered from the base (1) up and listed newest first. A layer is
+reported as cached when the BuildKit build cache still holds a record for its
+RUN step, so a rebuild reuses it; "-" means the step would run again (or the
+layer came from the base image). Metadata-only layers (ENV, WORKDIR, ...) add
+no size and are skipped by --largest.
+
+Use --largest to find the steps worth trimming in an oversized agent image.`,
+		Example: `  # Show every layer of an image
+  clawker image layers clawker-myapp:latest
+
+  # Show the five largest layers
+  clawker image layers clawker-myapp:latest --largest 5
+
+  # Render as a tree with the full instructions
+  clawker image layers clawker-myapp:latest --tree --no-trunc
+
+  # Output as JSON
+  clawker image layers clawker-myapp:latest --json`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Image = args[0]
+			if opts.Largest < 0 {
+				return cmdutil.FlagErrorf("--largest must be a positive number")
+			}
+			if opts.Tree && !opts.Format.IsDefault() {
+				return cmdutil.FlagErrorf("--tree and --format/--json are mutually exclusive")
+			}
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return layersRun(cmd.Context(), opts)
+		},
+	}
+
+	opts.Format = cmdutil.AddFormatFlags(cmd)
+	cmd.Flags().IntVar(&opts.Largest, "largest", 0, "Only show the N largest layers, biggest first")
+	cmd.Flags().BoolVar(&opts.Tree, "tree", false, "Render the layers as a tree under the image")
+	cmd.Flags().BoolVar(&opts.NoTrunc, "no-trunc", false, "Don't truncate the creating instructions")
+
+	return cmd
+}
+
+// layerRow is the data structure exposed to --format templates and --json output.
+type layerRow struct {
+	Index     int     `json:"index"`
+	ID        string  `json:"id"`
+	CreatedBy string  `json:"created_by"`
+	Size      int64   `json:"size"`
+	HumanSize string  `json:"human_size"`
+	Share     float64 `json:"share"`
+	Cache     string  `json:"cache"`
+	Empty     bool    `json:"empty"`
+}
+
+func layersRun(ctx context.Context, opts *LayersOptions) error {
+	ios := opts.IOStreams
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+
+	history, err := client.ImageHistory(ctx, opts.Image)
+	if err != nil {
+		return fmt.Errorf("reading layers of %s: %w", opts.Image, err)
+	}
+
+	// Cache status is best-effort: the image is still worth showing when the
+	// build cache can't be read (e.g. a daemon without BuildKit).
+	records, err := client.BuildCacheRecords(ctx)
+	if err != nil {
+		fmt.Fprintf(ios.ErrOut, "%s Build cache unavailable; cache status not shown: %v\n", ios.ColorScheme().WarningIcon(), err)
+		records = nil
+	}
+
+	rows := buildLayerRows(history.Items, records)
+	if opts.Largest > 0 {
+		rows = largestRows(rows, opts.Largest)
+	}
+
+	switch {
+	case opts.Format.Quiet:
+		for _, r := range rows {
+			fmt.Fprintln(ios.Out, r.ID)
+		}
+		return nil
+
+	case opts.Format.IsJSON():
+		return opts.Format.WriteJSON(ios, "image.layers", rows)
+
+	case opts.Format.IsTemplate():
+		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(rows))
+
+	case opts.Tree:
+		renderTree(ios.Out, ios.ColorScheme(), opts.Image, history.Items, rows, opts.NoTrunc)
+		return nil
+
+	default:
+		tp := opts.TUI.NewTable("#", "SIZE", "SHARE", "CACHE", "CREATED BY")
+		for _, r := range rows {
+			tp.AddRow(strconv.Itoa(r.Index), r.HumanSize, formatShare(r.Share), r.Cache, truncate(r.CreatedBy, opts.NoTrunc))
+		}
+		return tp.Render()
+	}
+}
+
+// buildLayerRows converts the history (newest first) into display rows,
+// numbering layers from the base and matching RUN steps to build cache
+// records.
+func buildLayerRows(items []image.HistoryResponseItem, records []build.CacheRecord) []layerRow {
+	var total int64
+	for _, it := range items {
+		total += it.Size
+	}
+
+	rows := make([]layerRow, 0, len(items))
+	for i, it := range items {
+		row := layerRow{
+			Index:     len(items) - i,
+			ID:        shortID(it.ID),
+			CreatedBy: instruction(it.CreatedBy),
+			Size:      it.Size,
+			HumanSize: text.FormatBytes(it.Size),
+			Cache:     cacheNone,
+			Empty:     it.Size == 0,
+		}
+		if total > 0 {
+			row.Share = float64(it.Size) / float64(total) * 100
+		}
+		if !row.Empty && isCached(it.CreatedBy, records) {
+			row.Cache = cacheCached
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// largestRows returns the n biggest non-empty layers, biggest first. Ties
+// keep the newest-first history order.
+func largestRows(rows []layerRow, n int) []layerRow {
+	var sized []layerRow
+	for _, r := range rows {
+		if !r.Empty {
+			sized = append(sized, r)
+		}
+	}
+	slices.SortStableFunc(sized, func(a, b layerRow) int {
+		switch {
+		case a.Size > b.Size:
+			return -1
+		case a.Size < b.Size:
+			return 1
+		}
+		return 0
+	})
+	if len(sized) > n {
+		sized = sized[:n]
+	}
+	return sized
+}
+
+// renderTree writes the image as the root with one branch per layer.
+func renderTree(w io.Writer, cs *iostreams.ColorScheme, ref string, items []image.HistoryResponseItem, rows []layerRow, noTrunc bool) {
+	var total int64
+	for _, it := range items {
+		total += it.Size
+	}
+	fmt.Fprintf(w, "%s %s\n", cs.Bold(ref), cs.Muted(fmt.Sprintf("(%s, %d layers)", text.FormatBytes(total), len(items))))
+
+	for i, r := range rows {
+		branch := "├── "
+		if i == len(rows)-1 {
+			branch = "└── "
+		}
+		cache := cs.Muted(fmt.Sprintf("%-6s", r.Cache))
+		if r.Cache == cacheCached {
+			cache = cs.Success(fmt.Sprintf("%-6s", r.Cache))
+		}
+		fmt.Fprintf(w, "%s%s %9s %6s  %s  %s\n",
+			branch,
+			cs.Muted(fmt.Sprintf("#%-3d", r.Index)),
+			r.HumanSize,
+			formatShare(r.Share),
+			cache,
+			truncate(r.CreatedBy, noTrunc),
+		)
+	}
+}
+
+// instruction normalizes a history CreatedBy into the Dockerfile instruction
+// that produced the layer: BuildKit's " # buildkit" marker and the legacy
+// builder's "/bin/sh -c #(nop) " prefix are dropped.
+func instruction(createdBy string) string {
+	s := strings.TrimSpace(createdBy)
+	s = strings.TrimSuffix(s, " # buildkit")
+	if rest, ok := strings.CutPrefix(s, "/bin/sh -c #(nop) "); ok {
+		return strings.TrimSpace(rest)
+	}
+	if strings.HasPrefix(s, "/bin/sh -c ") {
+		return "RUN " + s
+	}
+	return s
+}
+
+// runCommand extracts the shell command of a RUN layer, dropping the
+// "|N KEY=VALUE..." build-arg prefix BuildKit records. Returns "" for
+// non-RUN layers.
+func runCommand(createdBy string) string {
+	s, ok := strings.CutPrefix(instruction(createdBy), "RUN ")
+	if !ok {
+		return ""
+	}
+	if strings.HasPrefix(s, "|") {
+		fields := strings.Fields(s)
+		if n, err := strconv.Atoi(strings.TrimPrefix(fields[0], "|")); err == nil && len(fields) > n+1 {
+			s = strings.Join(fields[n+1:], " ")
+		}
+	}
+	return s
+}
+
+// isCached reports whether a build cache record describes the RUN step that
+// created the layer. BuildKit describes exec records as
+// "mount / from exec <command>".
+func isCached(createdBy string, records []build.CacheRecord) bool {
+	cmd := runCommand(createdBy)
+	if cmd == "" {
+		return false
+	}
+	for _, rec := range records {
+		if strings.HasSuffix(rec.Description, "exec "+cmd) {
+			return true
+		}
+	}
+	return false
+}
+
+// shortID shortens a layer ID to 12 characters; BuildKit-built and pulled
+// layers have no local ID and report "<missing>".
+func shortID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// truncate shortens s to the CREATED BY column width unless noTrunc is set.
+func truncate(s string, noTrunc bool) string {
+	if noTrunc || len(s) <= createdByWidth {
+		return s
+	}
+	return s[:createdByWidth-3] + "..."
+}
+
+// formatShare formats a layer's percentage of the image size.
+func formatShare(share float64) string {
+	return fmt.Sprintf("%.1f%%", share)
+}
@@ -0,0 +1,173 @@
+package layers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/moby/moby/api/types/build"
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// --- Tier 1: Flag parsing tests ---
+
+func TestNewCmdLayers(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantImage   string
+		wantLargest int
+		wantTree    bool
+		wantNoTrunc bool
+		wantErr     string
+	}{
+		{name: "image only", input: "myimage:latest", wantImage: "myimage:latest"},
+		{name: "largest", input: "myimage:latest --largest 5", wantImage: "myimage:latest", wantLargest: 5},
+		{name: "tree no-trunc", input: "myimage:latest --tree --no-trunc", wantImage: "myimage:latest", wantTree: true, wantNoTrunc: true},
+		{name: "no arguments", input: "", wantErr: "requires 1 argument"},
+		{name: "negative largest", input: "myimage:latest --largest -1", wantErr: "--largest must be a positive number"},
+		{name: "tree with json", input: "myimage:latest --tree --json", wantErr: "mutually exclusive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *LayersOptions
+			cmd := NewCmdLayers(f, func(_ context.Context, opts *LayersOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, gotOpts)
+			assert.Equal(t, tt.wantImage, gotOpts.Image)
+			assert.Equal(t, tt.wantLargest, gotOpts.Largest)
+			assert.Equal(t, tt.wantTree, gotOpts.Tree)
+			assert.Equal(t, tt.wantNoTrunc, gotOpts.NoTrunc)
+		})
+	}
+}
+
+// --- Tier 2: Run function tests ---
+
+const testImage = "clawker-myapp:latest"
+
+// testHistory is newest first, as the daemon returns it.
+var testHistory = []image.HistoryResponseItem{
+	{ID: "<missing>", CreatedBy: "WORKDIR /workspace", Size: 0, Comment: "buildkit.dockerfile.v0"},
+	{ID: "<missing>", CreatedBy: "RUN |1 NODE_VERSION=22 /bin/sh -c npm install -g pnpm # buildkit", Size: 30 * 1024 * 1024, Comment: "buildkit.dockerfile.v0"},
+	{ID: "<missing>", CreatedBy: "RUN /bin/sh -c apt-get update && apt-get install -y git # buildkit", Size: 60 * 1024 * 1024, Comment: "buildkit.dockerfile.v0"},
+	{ID: "sha256:0123456789abcdef0123", CreatedBy: "/bin/sh -c #(nop) ADD file:abc in / ", Size: 10 * 1024 * 1024},
+}
+
+func newOpts(t *testing.T, format *cmdutil.FormatFlags) (*LayersOptions, *mocks.FakeClient, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	ios, _, outBuf, errBuf := iostreams.Test()
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupImageHistory(testImage, testHistory...)
+	fake.SetupBuildCache(build.CacheRecord{
+		ID:          "rec1",
+		Type:        "regular",
+		Description: "mount / from exec /bin/sh -c apt-get update && apt-get install -y git",
+	})
+	return &LayersOptions{
+		IOStreams: ios,
+		TUI:       tui.NewTUI(ios),
+		Client:    func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		Format:    format,
+		Image:     testImage,
+	}, fake, outBuf, errBuf
+}
+
+func TestLayersRun_Table(t *testing.T) {
+	opts, _, outBuf, _ := newOpts(t, &cmdutil.FormatFlags{})
+
+	err := layersRun(context.Background(), opts)
+	require.NoError(t, err)
+
+	output := outBuf.String()
+	assert.Contains(t, output, "CREATED BY")
+	assert.Contains(t, output, "60.00MB")
+	assert.Contains(t, output, "60.0%")
+	assert.Contains(t, output, "ADD file:abc in /")
+	assert.Contains(t, output, "cached")
+}
+
+func TestLayersRun_LargestJSON(t *testing.T) {
+	format, err := cmdutil.ParseFormat("json")
+	require.NoError(t, err)
+	opts, _, outBuf, _ := newOpts(t, &cmdutil.FormatFlags{Format: format})
+	opts.Largest = 2
+
+	err = layersRun(context.Background(), opts)
+	require.NoError(t, err)
+
+	var got []layerRow
+	require.NoError(t, json.Unmarshal(outBuf.Bytes(), &got))
+	require.Len(t, got, 2)
+	assert.Equal(t, 2, got[0].Index)
+	assert.Equal(t, "RUN /bin/sh -c apt-get update && apt-get install -y git", got[0].CreatedBy)
+	assert.Equal(t, cacheCached, got[0].Cache)
+	assert.Equal(t, 3, got[1].Index)
+	assert.Equal(t, cacheNone, got[1].Cache, "build-arg prefixed RUN with no cache record")
+}
+
+func TestLayersRun_Tree(t *testing.T) {
+	opts, _, outBuf, _ := newOpts(t, &cmdutil.FormatFlags{})
+	opts.Tree = true
+
+	err := layersRun(context.Background(), opts)
+	require.NoError(t, err)
+
+	output := outBuf.String()
+	assert.Contains(t, output, testImage+" (100.00MB, 4 layers)")
+	assert.Contains(t, output, "├── #4")
+	assert.Contains(t, output, "└── #1")
+}
+
+func TestLayersRun_BuildCacheUnavailable(t *testing.T) {
+	opts, fake, outBuf, errBuf := newOpts(t, &cmdutil.FormatFlags{})
+	fake.FakeAPI.DiskUsageFn = func(_ context.Context, _ client.DiskUsageOptions) (client.DiskUsageResult, error) {
+		return client.DiskUsageResult{}, errors.New("builder not available")
+	}
+
+	err := layersRun(context.Background(), opts)
+	require.NoError(t, err, "cache status is best-effort")
+	assert.Contains(t, outBuf.String(), "ADD file:abc in /")
+	assert.NotContains(t, outBuf.String(), "cached")
+	assert.Contains(t, errBuf.String(), "Build cache unavailable")
+}
+
+func TestRunCommand(t *testing.T) {
+	assert.Equal(t, "/bin/sh -c npm install -g pnpm", runCommand("RUN |1 NODE_VERSION=22 /bin/sh -c npm install -g pnpm # buildkit"))
+	assert.Equal(t, "/bin/sh -c make", runCommand("/bin/sh -c make"))
+	assert.Empty(t, runCommand("COPY . /app # buildkit"))
+}
@@ -131,7 +131,7 @@ func listRun(ctx context.Context, opts *ListOptions) error {
 		return nil
 
 	case opts.Format.IsJSON():
-		return cmdutil.WriteJSON(ios.Out, rows)
+		return opts.Format.WriteJSON(ios, "image.list", rows)
 
 	case opts.Format.IsTemplate():
 		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(rows))
@@ -0,0 +1,214 @@
+// Package outdated provides the image outdated command.
+package outdated
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/bundler"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// Status values reported per image.
+const (
+	statusCurrent  = "current"
+	statusOutdated = "outdated"
+	statusUnknown  = "unknown"
+)
+
+// OutdatedOptions holds options for the outdated command.
+type OutdatedOptions struct {
+	IOStreams *iostreams.IOStreams
+	TUI       *tui.TUI
+	Client    func(context.Context) (*docker.Client, error)
+
+	Format *cmdutil.FormatFlags
+	Images []string
+}
+
+// NewCmdOutdated creates the image outdated command.
+func NewCmdOutdated(f *cmdutil.Factory, runF func(context.Context, *OutdatedOptions) error) *cobra.Command {
+	opts := &OutdatedOptions{
+		IOStreams: f.IOStreams,
+		TUI:       f.TUI,
+		Client:    f.Client,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "outdated [IMAGE...]",
+		Short: "Check whether images were built on an outdated base image",
+		Long: `Compares the base image each clawker image was built on with what the
+registry serves for the same tag today. An image is outdated when the base
+has been republished since the build — usually with security updates.
+
+Without arguments, every clawker image that records its base is checked.
+Images built before clawker recorded the base report "unknown"; rebuild them
+to start tracking. The registry is queried anonymously.`,
+		Example: `  # Check every clawker image
+  clawker image outdated
+
+  # Check one image
+  clawker image outdated clawker-myapp:claude
+
+  # Output as JSON
+  clawker image outdated --json`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Images = args
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return outdatedRun(cmd.Context(), opts)
+		},
+	}
+
+	opts.Format = cmdutil.AddFormatFlags(cmd)
+
+	return cmd
+}
+
+// outdatedRow is the data structure exposed to --format templates and --json output.
+type outdatedRow struct {
+	Image    string `json:"image"`
+	Base     string `json:"base"`
+	Recorded string `json:"recorded_digest"`
+	Current  string `json:"current_digest"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+func outdatedRun(ctx context.Context, opts *OutdatedOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+
+	images := opts.Images
+	if len(images) == 0 {
+		if images, err = trackedImages(ctx, client); err != nil {
+			return err
+		}
+		if len(images) == 0 {
+			fmt.Fprintln(ios.ErrOut, "No clawker images record their base image. Rebuild with 'clawker build' to start tracking it.")
+			return nil
+		}
+	}
+
+	rows := make([]outdatedRow, 0, len(images))
+	for _, ref := range images {
+		rows = append(rows, checkImage(ctx, client, ref))
+	}
+
+	switch {
+	case opts.Format.Quiet:
+		for _, r := range rows {
+			if r.Status == statusOutdated {
+				fmt.Fprintln(ios.Out, r.Image)
+			}
+		}
+		return nil
+
+	case opts.Format.IsJSON():
+		return opts.Format.WriteJSON(ios, "image.outdated", rows)
+
+	case opts.Format.IsTemplate():
+		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(rows))
+	}
+
+	tp := opts.TUI.NewTable("IMAGE", "BASE", "STATUS")
+	for _, r := range rows {
+		status := r.Status
+		switch r.Status {
+		case statusOutdated:
+			status = cs.Warning(status)
+		case statusCurrent:
+			status = cs.Success(status)
+		default:
+			status = cs.Muted(status)
+		}
+		tp.AddRow(r.Image, r.Base, status)
+	}
+	if err := tp.Render(); err != nil {
+		return err
+	}
+	printNextSteps(ios, cs, rows)
+	return nil
+}
+
+// trackedImages lists the tagged clawker images that record their base,
+// skipping the per-project :base images their harness images are built on.
+func trackedImages(ctx context.Context, client *docker.Client) ([]string, error) {
+	result, err := client.ImageList(ctx, docker.ImageListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing images: %w", err)
+	}
+	var refs []string
+	for _, img := range result.Items {
+		if img.Labels[consts.LabelBaseImage] == "" || img.Labels[consts.LabelPurpose] == consts.PurposeBaseImage {
+			continue
+		}
+		for _, tag := range img.RepoTags {
+			if tag != "<none>:<none>" {
+				refs = append(refs, tag)
+			}
+		}
+	}
+	slices.Sort(refs)
+	return refs, nil
+}
+
+// checkImage reports one image's drift. Failures become an unknown row so
+// one unreachable registry does not hide the other images' results.
+func checkImage(ctx context.Context, client *docker.Client, ref string) outdatedRow {
+	drift, err := client.ImageBaseDrift(ctx, ref)
+	if err != nil {
+		return outdatedRow{Image: ref, Base: "-", Status: statusUnknown, Error: err.Error()}
+	}
+	row := outdatedRow{
+		Image:    ref,
+		Base:     drift.BaseRef,
+		Recorded: drift.Recorded,
+		Current:  drift.Current,
+		Status:   statusCurrent,
+	}
+	if drift.Drifted {
+		row.Status = statusOutdated
+	}
+	return row
+}
+
+// printNextSteps explains the unknown and outdated rows. clawker pins its
+// base image, so a rebuild only moves an outdated image when this release
+// pins a different base than the image was built on.
+func printNextSteps(ios *iostreams.IOStreams, cs *iostreams.ColorScheme, rows []outdatedRow) {
+	_, pinned, _ := strings.Cut(bundler.SubstrateImage, "@")
+	var rebuild, upgrade bool
+	for _, r := range rows {
+		switch {
+		case r.Status == statusUnknown:
+			fmt.Fprintf(ios.ErrOut, "%s %s: %s\n", cs.WarningIcon(), r.Image, r.Error)
+		case r.Status != statusOutdated:
+		case r.Recorded != pinned:
+			rebuild = true
+		default:
+			upgrade = true
+		}
+	}
+	if rebuild {
+		fmt.Fprintf(ios.ErrOut, "%s Rebuild with 'clawker build' to move outdated images to this release's base image.\n", cs.InfoIcon())
+	}
+	if upgrade {
+		fmt.Fprintf(ios.ErrOut, "%s A newer base image is published than this clawker release pins; update clawker, then rebuild.\n", cs.InfoIcon())
+	}
+}
@@ -0,0 +1,179 @@
+package outdated
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/moby/moby/api/types/image"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/bundler"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// --- Tier 1: Flag parsing tests ---
+
+func TestNewCmdOutdated(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantImages []string
+	}{
+		{name: "no arguments", input: "", wantImages: []string{}},
+		{name: "images", input: "a:latest b:claude", wantImages: []string{"a:latest", "b:claude"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *OutdatedOptions
+			cmd := NewCmdOutdated(f, func(_ context.Context, opts *OutdatedOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			require.NoError(t, err)
+			require.NotNil(t, gotOpts)
+			assert.Equal(t, tt.wantImages, gotOpts.Images)
+		})
+	}
+}
+
+// --- Tier 2: Run function tests ---
+
+const (
+	testImage    = "clawker-myapp:claude"
+	testBaseRef  = "debian:bookworm-slim"
+	testNewer    = "sha256:9999999999999999999999999999999999999999999999999999999999999999"
+	testOlderPin = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+// pinnedDigest is the base digest this clawker release builds on.
+func pinnedDigest() string {
+	_, d, _ := strings.Cut(bundler.SubstrateImage, "@")
+	return d
+}
+
+func newOpts(t *testing.T, format *cmdutil.FormatFlags, recorded, current string) (*OutdatedOptions, *mocks.FakeClient, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	ios, _, outBuf, errBuf := iostreams.Test()
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupImageExistsWithLabels(testImage, map[string]string{consts.LabelBaseImage: testBaseRef + "@" + recorded})
+	fake.SetupRegistryDigest(testBaseRef, current)
+	return &OutdatedOptions{
+		IOStreams: ios,
+		TUI:       tui.NewTUI(ios),
+		Client:    func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		Format:    format,
+		Images:    []string{testImage},
+	}, fake, outBuf, errBuf
+}
+
+func TestOutdatedRun_Current(t *testing.T) {
+	opts, _, outBuf, errBuf := newOpts(t, &cmdutil.FormatFlags{}, pinnedDigest(), pinnedDigest())
+
+	require.NoError(t, outdatedRun(context.Background(), opts))
+
+	assert.Contains(t, outBuf.String(), testImage)
+	assert.Contains(t, outBuf.String(), statusCurrent)
+	assert.Empty(t, errBuf.String())
+}
+
+func TestOutdatedRun_NextSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		recorded string
+		wantHint string
+	}{
+		{name: "built on an older pin", recorded: testOlderPin, wantHint: "Rebuild with 'clawker build'"},
+		{name: "registry newer than the pin", recorded: pinnedDigest(), wantHint: "update clawker, then rebuild"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, _, outBuf, errBuf := newOpts(t, &cmdutil.FormatFlags{}, tt.recorded, testNewer)
+
+			require.NoError(t, outdatedRun(context.Background(), opts))
+
+			assert.Contains(t, outBuf.String(), statusOutdated)
+			assert.Contains(t, errBuf.String(), tt.wantHint)
+		})
+	}
+}
+
+func TestOutdatedRun_JSON(t *testing.T) {
+	format, err := cmdutil.ParseFormat("json")
+	require.NoError(t, err)
+	opts, _, outBuf, _ := newOpts(t, &cmdutil.FormatFlags{Format: format}, testOlderPin, testNewer)
+
+	require.NoError(t, outdatedRun(context.Background(), opts))
+
+	var got []outdatedRow
+	require.NoError(t, json.Unmarshal(outBuf.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, outdatedRow{
+		Image:    testImage,
+		Base:     testBaseRef,
+		Recorded: testOlderPin,
+		Current:  testNewer,
+		Status:   statusOutdated,
+	}, got[0])
+}
+
+func TestOutdatedRun_Unrecorded(t *testing.T) {
+	opts, fake, outBuf, errBuf := newOpts(t, &cmdutil.FormatFlags{}, "", "")
+	fake.SetupImageExists(testImage, true)
+
+	require.NoError(t, outdatedRun(context.Background(), opts))
+
+	assert.Contains(t, outBuf.String(), statusUnknown)
+	assert.Contains(t, errBuf.String(), testImage+":")
+}
+
+func TestOutdatedRun_AllTracked(t *testing.T) {
+	opts, fake, outBuf, _ := newOpts(t, &cmdutil.FormatFlags{Quiet: true}, testOlderPin, testNewer)
+	opts.Images = nil
+	base := testBaseRef + "@" + testOlderPin
+	fake.SetupImageList(
+		image.Summary{ID: "h", RepoTags: []string{testImage}, Labels: map[string]string{consts.LabelBaseImage: base}},
+		image.Summary{ID: "b", RepoTags: []string{"clawker-myapp:base"}, Labels: map[string]string{
+			consts.LabelBaseImage: base, consts.LabelPurpose: consts.PurposeBaseImage,
+		}},
+		image.Summary{ID: "old", RepoTags: []string{"clawker-old:claude"}},
+	)
+
+	require.NoError(t, outdatedRun(context.Background(), opts))
+
+	assert.Equal(t, testImage+"\n", outBuf.String())
+}
+
+func TestOutdatedRun_NothingTracked(t *testing.T) {
+	opts, fake, outBuf, errBuf := newOpts(t, &cmdutil.FormatFlags{}, testOlderPin, testNewer)
+	opts.Images = nil
+	fake.SetupImageList()
+
+	require.NoError(t, outdatedRun(context.Background(), opts))
+
+	assert.Empty(t, outBuf.String())
+	assert.Contains(t, errBuf.String(), "No clawker images record their base image")
+}
@@ -4,6 +4,9 @@ package prune
 import (
 	"context"
 	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -11,6 +14,7 @@ import (
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/prompter"
+	"github.com/schmitthub/clawker/internal/text"
 )
 
 // PruneOptions holds options for the prune command.
@@ -19,8 +23,15 @@ type PruneOptions struct {
 	Client    func(context.Context) (*docker.Client, error)
 	Prompter  func() *prompter.Prompter
 
-	Force bool
-	All   bool
+	Force      bool
+	All        bool
+	KeepLast   int
+	OlderThan  string
+	KeepTagged bool
+
+	olderThan time.Duration
+	// policy is set when a retention flag selects the policy path.
+	policy bool
 }
 
 // NewCmdPrune creates the image prune command.
@@ -39,6 +50,15 @@ func NewCmdPrune(f *cmdutil.Factory, runF func(context.Context, *PruneOptions) e
 By default, only dangling images (untagged images) are removed.
 Use --all to remove all images not used by any container.
 
+--keep-last and --older-than switch to a retention policy instead: builds
+are grouped by project and harness, the newest --keep-last of each group
+and any built within --older-than are kept, and the rest are removed.
+Tagged images are kept unless --keep-tagged=false. Images used by any
+container are never removed.
+
+With the global --dry-run nothing is removed and there is no prompt; a
+policy prune also lists the images it would remove.
+
 Use with caution as this will permanently delete images.`,
 		Example: `  # Remove unused (dangling) clawker images
   clawker image prune
@@ -46,9 +66,38 @@ Use with caution as this will permanently delete images.`,
   # Remove all unused clawker images
   clawker image prune --all
 
+  # Keep the three newest builds of each project, including tagged ones
+  clawker image prune --keep-last 3 --keep-tagged=false
+
+  # Preview removing builds older than a week
+  clawker image prune --older-than 168h --dry-run
+
   # Remove without confirmation prompt
   clawker image prune --force`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			opts.policy = flags.Changed("keep-last") || flags.Changed("older-than")
+			if !opts.policy {
+				if flags.Changed("keep-tagged") {
+					return cmdutil.FlagErrorf("--keep-tagged requires --keep-last or --older-than")
+				}
+			} else if opts.All {
+				return cmdutil.FlagErrorf("--all cannot be combined with --keep-last or --older-than")
+			}
+			if opts.KeepLast < 0 {
+				return cmdutil.FlagErrorf("invalid --keep-last %d: must not be negative", opts.KeepLast)
+			}
+			if opts.OlderThan != "" {
+				d, err := time.ParseDuration(opts.OlderThan)
+				if err != nil {
+					return cmdutil.FlagErrorf("invalid --older-than %q: %v", opts.OlderThan, err)
+				}
+				if d < 0 {
+					return cmdutil.FlagErrorf("invalid --older-than %q: must not be negative", opts.OlderThan)
+				}
+				opts.olderThan = d
+			}
 			if runF != nil {
 				return runF(cmd.Context(), opts)
 			}
@@ -58,7 +107,11 @@ Use with caution as this will permanently delete images.`,
 
 	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Do not prompt for confirmation")
 	cmd.Flags().BoolVarP(&opts.All, "all", "a", false, "Remove all unused images, not just dangling ones")
+	cmd.Flags().IntVar(&opts.KeepLast, "keep-last", 0, "Keep the newest N images of each project and harness")
+	cmd.Flags().StringVar(&opts.OlderThan, "older-than", "", "Only remove images built more than this duration ago (e.g. 168h)")
+	cmd.Flags().BoolVar(&opts.KeepTagged, "keep-tagged", true, "Keep tagged images when pruning by policy")
 
+	cmdutil.EnableDryRun(cmd)
 	return cmd
 }
 
@@ -69,12 +122,15 @@ func pruneRun(ctx context.Context, opts *PruneOptions) error {
 	// Connect to Docker
 	client, err := opts.Client(ctx)
 	if err != nil {
-		cmdutil.HandleError(ios, err)
-		return err
+		return fmt.Errorf("connecting to Docker: %w", err)
 	}
 
-	// Prompt for confirmation if not forced
-	if !opts.Force {
+	if opts.policy {
+		return policyPruneRun(ctx, opts, client)
+	}
+
+	// Prompt for confirmation if not forced; a dry run removes nothing
+	if !opts.Force && !client.DryRun() {
 		warning := "This will remove all dangling clawker-managed images."
 		if opts.All {
 			warning = "This will remove all unused clawker-managed images."
@@ -93,8 +149,7 @@ func pruneRun(ctx context.Context, opts *PruneOptions) error {
 	// dangling=!opts.All: if --all is false, only prune dangling images
 	report, err := client.ImagesPrune(ctx, !opts.All)
 	if err != nil {
-		cmdutil.HandleError(ios, err)
-		return err
+		return fmt.Errorf("pruning images: %w", err)
 	}
 
 	if len(report.Report.ImagesDeleted) == 0 {
@@ -111,27 +166,94 @@ func pruneRun(ctx context.Context, opts *PruneOptions) error {
 		}
 	}
 
-	fmt.Fprintf(ios.ErrOut, "\nTotal reclaimed space: %s\n", formatBytes(int64(report.Report.SpaceReclaimed)))
+	fmt.Fprintf(ios.ErrOut, "\nTotal reclaimed space: %s\n", text.FormatBytes(int64(report.Report.SpaceReclaimed)))
 
 	return nil
 }
 
-// formatBytes formats bytes into a human-readable string.
-func formatBytes(bytes int64) string {
-	const (
-		KB = 1024
-		MB = KB * 1024
-		GB = MB * 1024
-	)
-
-	switch {
-	case bytes >= GB:
-		return fmt.Sprintf("%.2fGB", float64(bytes)/GB)
-	case bytes >= MB:
-		return fmt.Sprintf("%.2fMB", float64(bytes)/MB)
-	case bytes >= KB:
-		return fmt.Sprintf("%.2fKB", float64(bytes)/KB)
-	default:
-		return fmt.Sprintf("%dB", bytes)
+// policyPruneRun removes the images selected by the --keep-last,
+// --older-than and --keep-tagged retention policy.
+func policyPruneRun(ctx context.Context, opts *PruneOptions, client *docker.Client) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	policy := docker.PrunePolicy{
+		KeepLast:   opts.KeepLast,
+		OlderThan:  opts.olderThan,
+		KeepTagged: opts.KeepTagged,
+		DryRun:     true,
+	}
+	preview, err := client.PruneImages(ctx, policy)
+	if err != nil {
+		return fmt.Errorf("evaluating prune policy: %w", err)
+	}
+	if len(preview.Removed) == 0 {
+		fmt.Fprintln(ios.ErrOut, "No clawker images match the prune policy.")
+		return nil
+	}
+
+	// A dry-run engine journaled the preview's removals for the report the
+	// root command prints; list them with their sizes as well.
+	if client.DryRun() {
+		tw := tabwriter.NewWriter(ios.Out, 0, 0, 2, ' ', 0)
+		for _, img := range preview.Removed {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", shortID(img.ID), imageName(img), img.Created.Format(time.DateTime), text.FormatBytes(img.Size))
+		}
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("writing output: %w", err)
+		}
+		fmt.Fprintf(ios.ErrOut, "\nWould remove %d %s, reclaiming up to %s.\n", len(preview.Removed), pluralImages(len(preview.Removed)), text.FormatBytes(preview.SpaceReclaimed))
+		return nil
+	}
+
+	if !opts.Force {
+		msg := fmt.Sprintf("%s This will remove %d clawker %s.", cs.WarningIcon(), len(preview.Removed), pluralImages(len(preview.Removed)))
+		confirmed, err := opts.Prompter().Confirm(msg, false)
+		if err != nil {
+			return fmt.Errorf("confirm prune: %w", err)
+		}
+		if !confirmed {
+			fmt.Fprintln(ios.ErrOut, "Aborted.")
+			return nil
+		}
+	}
+
+	policy.DryRun = false
+	report, pruneErr := client.PruneImages(ctx, policy)
+	for _, img := range report.Removed {
+		fmt.Fprintf(ios.ErrOut, "%s Deleted: %s\n", cs.SuccessIcon(), imageName(img))
+	}
+	for _, img := range report.Skipped {
+		fmt.Fprintf(ios.ErrOut, "%s Skipped %s: %s\n", cs.WarningIcon(), imageName(img), img.SkipReason)
+	}
+	fmt.Fprintf(ios.ErrOut, "\nTotal reclaimed space: %s\n", text.FormatBytes(report.SpaceReclaimed))
+
+	if pruneErr != nil {
+		return fmt.Errorf("pruning images: %w", pruneErr)
+	}
+	return nil
+}
+
+// imageName names an image by its tags, or its short ID when untagged.
+func imageName(img docker.PrunedImage) string {
+	if len(img.Tags) == 0 {
+		return shortID(img.ID)
+	}
+	return strings.Join(img.Tags, ", ")
+}
+
+// shortID trims an image ID to the 12 hex digits docker prints.
+func shortID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+func pluralImages(n int) string {
+	if n == 1 {
+		return "image"
 	}
+	return "images"
 }
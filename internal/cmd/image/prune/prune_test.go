@@ -1,13 +1,22 @@
 package prune
 
 import (
+	"bytes"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/shlex"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/prompter"
@@ -15,40 +24,61 @@ import (
 
 func TestNewCmd(t *testing.T) {
 	tests := []struct {
-		name     string
-		input    string
-		wantOpts PruneOptions
+		name       string
+		input      string
+		wantOpts   PruneOptions
+		wantPolicy bool
+		wantOlder  time.Duration
+		wantErr    string
 	}{
 		{
 			name:     "no flags",
 			input:    "",
-			wantOpts: PruneOptions{},
+			wantOpts: PruneOptions{KeepTagged: true},
 		},
 		{
 			name:     "force flag",
 			input:    "-f",
-			wantOpts: PruneOptions{Force: true},
+			wantOpts: PruneOptions{Force: true, KeepTagged: true},
 		},
 		{
 			name:     "force flag long",
 			input:    "--force",
-			wantOpts: PruneOptions{Force: true},
+			wantOpts: PruneOptions{Force: true, KeepTagged: true},
 		},
 		{
 			name:     "all flag",
 			input:    "-a",
-			wantOpts: PruneOptions{All: true},
+			wantOpts: PruneOptions{All: true, KeepTagged: true},
 		},
 		{
 			name:     "all flag long",
 			input:    "--all",
-			wantOpts: PruneOptions{All: true},
+			wantOpts: PruneOptions{All: true, KeepTagged: true},
 		},
 		{
 			name:     "both flags",
 			input:    "-f -a",
-			wantOpts: PruneOptions{Force: true, All: true},
+			wantOpts: PruneOptions{Force: true, All: true, KeepTagged: true},
 		},
+		{
+			name:       "keep last",
+			input:      "--keep-last 3",
+			wantOpts:   PruneOptions{KeepLast: 3, KeepTagged: true},
+			wantPolicy: true,
+		},
+		{
+			name:       "older than",
+			input:      "--older-than 168h --keep-tagged=false",
+			wantOpts:   PruneOptions{OlderThan: "168h"},
+			wantPolicy: true,
+			wantOlder:  168 * time.Hour,
+		},
+		{name: "invalid older than", input: "--older-than 7d", wantErr: `invalid --older-than "7d"`},
+		{name: "negative older than", input: "--older-than -1h", wantErr: "must not be negative"},
+		{name: "negative keep last", input: "--keep-last -1", wantErr: "must not be negative"},
+		{name: "keep tagged without policy", input: "--keep-tagged=false", wantErr: "--keep-tagged requires"},
+		{name: "all with policy", input: "--all --keep-last 2", wantErr: "--all cannot be combined"},
 	}
 
 	for _, tt := range tests {
@@ -76,9 +106,19 @@ func TestNewCmd(t *testing.T) {
 			cmd.SetErr(errOut)
 
 			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
 			require.NoError(t, err)
 			require.Equal(t, tt.wantOpts.Force, gotOpts.Force)
 			require.Equal(t, tt.wantOpts.All, gotOpts.All)
+			require.Equal(t, tt.wantOpts.KeepLast, gotOpts.KeepLast)
+			require.Equal(t, tt.wantOpts.KeepTagged, gotOpts.KeepTagged)
+			require.Equal(t, tt.wantOpts.OlderThan, gotOpts.OlderThan)
+			require.Equal(t, tt.wantPolicy, gotOpts.policy)
+			require.Equal(t, tt.wantOlder, gotOpts.olderThan)
 		})
 	}
 }
@@ -102,8 +142,138 @@ func TestCmd_Properties(t *testing.T) {
 	// Test flags exist
 	require.NotNil(t, cmd.Flags().Lookup("force"))
 	require.NotNil(t, cmd.Flags().Lookup("all"))
+	require.NotNil(t, cmd.Flags().Lookup("keep-last"))
+	require.NotNil(t, cmd.Flags().Lookup("older-than"))
+	require.NotNil(t, cmd.Flags().Lookup("keep-tagged"))
+	require.True(t, cmdutil.SupportsDryRun(cmd))
 
 	// Test shorthand flags
 	require.NotNil(t, cmd.Flags().ShorthandLookup("f"))
 	require.NotNil(t, cmd.Flags().ShorthandLookup("a"))
 }
+
+// --- Tier 2 tests (Cobra+Factory, real run function) ---
+
+// newPolicyFake seeds a fake daemon with five myapp builds, newest first
+// (b1 tagged, b4 tagged, b3 used by a container), and records the ID
+// prefixes of the images removed.
+func newPolicyFake(t *testing.T) (*mocks.FakeClient, *[]string) {
+	t.Helper()
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	cfg := fake.Cfg
+	now := time.Now()
+	build := func(id string, age time.Duration, tags ...string) image.Summary {
+		return image.Summary{
+			ID:       "sha256:" + id + "0000000000000000",
+			RepoTags: tags,
+			Size:     1024 * 1024,
+			Labels: map[string]string{
+				cfg.LabelManaged(): cfg.ManagedLabelValue(),
+				cfg.LabelProject(): "myapp",
+				cfg.LabelCreated(): now.Add(-age).Format(time.RFC3339),
+			},
+		}
+	}
+	images := []image.Summary{
+		build("b1", time.Hour, "clawker-myapp:latest"),
+		build("b2", 48*time.Hour),
+		build("b3", 72*time.Hour),
+		build("b4", 96*time.Hour, "clawker-myapp:v1"),
+		build("b5", 200*time.Hour),
+	}
+	fake.FakeAPI.ImageListFn = func(context.Context, client.ImageListOptions) (client.ImageListResult, error) {
+		return client.ImageListResult{Items: images}, nil
+	}
+	fake.FakeAPI.ContainerListFn = func(context.Context, client.ContainerListOptions) (client.ContainerListResult, error) {
+		return client.ContainerListResult{Items: []container.Summary{{ImageID: images[2].ID}}}, nil
+	}
+	var removed []string
+	fake.FakeAPI.ImageRemoveFn = func(_ context.Context, id string, _ client.ImageRemoveOptions) (client.ImageRemoveResult, error) {
+		removed = append(removed, id[len("sha256:"):len("sha256:")+2])
+		return client.ImageRemoveResult{}, nil
+	}
+	return fake, &removed
+}
+
+func runPolicyPrune(t *testing.T, fake *mocks.FakeClient, args ...string) (*cmdutiltest.Factory, error) {
+	t.Helper()
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithFakeClient(fake))
+	tf.Prompter = func() *prompter.Prompter { return prompter.NewPrompter(tf.IOStreams) }
+	cmd := NewCmdPrune(tf.Factory, nil)
+	cmd.SetArgs(args)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	return tf, cmd.Execute()
+}
+
+func TestPolicyPruneRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantRemoved []string
+		wantErrOut  []string
+	}{
+		{
+			name:        "keep last keeps tagged",
+			args:        []string{"--force", "--keep-last", "1"},
+			wantRemoved: []string{"b2", "b5"},
+			wantErrOut:  []string{"Skipped b30000000000: in use by a container", "Total reclaimed space: 2.00MB"},
+		},
+		{
+			name:        "older than without keep tagged",
+			args:        []string{"--force", "--older-than", "90h", "--keep-tagged=false"},
+			wantRemoved: []string{"b4", "b5"},
+			wantErrOut:  []string{"Deleted: clawker-myapp:v1"},
+		},
+		{
+			name:       "nothing matches",
+			args:       []string{"--force", "--keep-last", "10"},
+			wantErrOut: []string{"No clawker images match the prune policy."},
+		},
+		{
+			name:       "declined without a terminal",
+			args:       []string{"--keep-last", "1"},
+			wantErrOut: []string{"Aborted."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, removed := newPolicyFake(t)
+			tf, err := runPolicyPrune(t, fake, tt.args...)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.wantRemoved, *removed)
+			for _, want := range tt.wantErrOut {
+				assert.Contains(t, tf.ErrOut.String(), want)
+			}
+		})
+	}
+}
+
+func TestPolicyPruneRun_DryRun(t *testing.T) {
+	fake, removed := newPolicyFake(t)
+	mocks.WithDryRun()(fake)
+	tf, err := runPolicyPrune(t, fake, "--keep-last", "1")
+	require.NoError(t, err)
+	assert.Empty(t, *removed)
+	var ops []string
+	for _, op := range fake.Client.DryRunReport() {
+		ops = append(ops, op.String())
+	}
+	assert.Equal(t, []string{"remove image sha256:b20000000000000000", "remove image sha256:b50000000000000000"}, ops)
+	assert.Contains(t, tf.Out.String(), "b20000000000")
+	assert.Contains(t, tf.Out.String(), "b50000000000")
+	assert.NotContains(t, tf.Out.String(), "b3")
+	assert.Contains(t, tf.ErrOut.String(), "Would remove 2 images, reclaiming up to 2.00MB.")
+}
+
+func TestPruneRun_DryRunSkipsPrompt(t *testing.T) {
+	fake, removed := newPolicyFake(t)
+	mocks.WithDryRun()(fake)
+	tf, err := runPolicyPrune(t, fake, "--all")
+	require.NoError(t, err)
+	assert.Empty(t, *removed)
+	assert.Len(t, fake.Client.DryRunReport(), 4)
+	assert.NotContains(t, tf.ErrOut.String(), "Aborted.")
+}
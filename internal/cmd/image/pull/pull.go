@@ -0,0 +1,179 @@
+// Package pull provides the image pull command.
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/bundler"
+	"github.com/schmitthub/clawker/internal/cmd/image/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+// PullOptions holds options for the pull command.
+type PullOptions struct {
+	IOStreams      *iostreams.IOStreams
+	TUI            *tui.TUI
+	Client         func(context.Context) (*docker.Client, error)
+	Config         func() (config.Config, error)
+	ProjectManager func() (project.ProjectManager, error)
+
+	// RegistryAuth resolves registry credentials for a reference. Tests swap it.
+	RegistryAuth func(ctx context.Context, ref string) (string, error)
+
+	Image    string
+	Quiet    bool
+	Progress string
+	Record   string
+	NoTag    bool
+}
+
+// NewCmdPull creates the image pull command.
+func NewCmdPull(f *cmdutil.Factory, runF func(context.Context, *PullOptions) error) *cobra.Command {
+	opts := &PullOptions{
+		IOStreams:      f.IOStreams,
+		TUI:            f.TUI,
+		Client:         f.Client,
+		Config:         f.Config,
+		ProjectManager: f.ProjectManager,
+		RegistryAuth:   docker.RegistryAuth,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "pull IMAGE",
+		Short: "Pull a clawker-built image from a registry",
+		Long: `Pulls a clawker-built image from a registry and tags it for the current project.
+
+Only images built by clawker are accepted: a pulled image without the
+clawker managed label is removed again and the command fails. An existing
+local image at the same reference that clawker did not build is never
+replaced.
+
+Inside a project, the pulled image is also tagged as the project's image
+for the harness it was built for (clawker-<project>:<harness>, plus
+:default for the default harness), so clawker run uses it without a local
+build. Use --no-tag to skip this.
+
+Credentials come from your Docker configuration — log in with docker
+login, or configure a credential helper.`,
+		Example: `  # Pull the team's agent image built in CI
+  clawker image pull ghcr.io/acme/agent:claude
+
+  # Pull without tagging it for the current project
+  clawker image pull --no-tag ghcr.io/acme/agent:claude`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Image = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return pullRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Suppress the pull progress output")
+	cmd.Flags().StringVar(&opts.Progress, "progress", "auto", "Set type of progress output (auto, plain, tty, none)")
+	shared.AddRecordFlag(cmd, &opts.Record)
+	cmd.Flags().BoolVar(&opts.NoTag, "no-tag", false, "Do not tag the pulled image for the current project")
+
+	return cmd
+}
+
+func pullRun(ctx context.Context, opts *PullOptions) error {
+	ios := opts.IOStreams
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+	auth, err := opts.RegistryAuth(ctx, opts.Image)
+	if err != nil {
+		return fmt.Errorf("resolving registry credentials: %w", err)
+	}
+
+	pull := func(onProgress whail.BuildProgressFunc) error {
+		return client.ImagePull(ctx, opts.Image, whail.ImageTransferOptions{
+			RegistryAuth: auth,
+			OnProgress:   onProgress,
+		})
+	}
+	pull = shared.RecordProgress(ios, opts.Record, "image pull", opts.Image, pull)
+	if opts.Quiet || opts.Progress == "none" {
+		err = pull(nil)
+	} else {
+		err = shared.RunWithProgress(opts.TUI, opts.Progress, tui.ProgressDisplayConfig{
+			Title:          "Pulling",
+			Subtitle:       opts.Image,
+			CompletionVerb: "Pulled",
+			MaxVisible:     5,
+			FormatDuration: whail.FormatBuildDuration,
+		}, pull)
+	}
+	if err != nil {
+		return fmt.Errorf("pulling %s: %w", opts.Image, err)
+	}
+	fmt.Fprintln(ios.Out, opts.Image)
+
+	if opts.NoTag {
+		return nil
+	}
+	tags, err := projectTags(ctx, opts, client)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if err := client.ImageTag(ctx, opts.Image, tag); err != nil {
+			return fmt.Errorf("tagging %s: %w", tag, err)
+		}
+		fmt.Fprintln(ios.Out, tag)
+	}
+	return nil
+}
+
+// projectTags returns the local tags that make the pulled image the current
+// project's image for its harness. Outside a project, or for an image
+// without a harness label, there is nothing to tag.
+func projectTags(ctx context.Context, opts *PullOptions, client *docker.Client) ([]string, error) {
+	if opts.ProjectManager == nil {
+		return nil, nil
+	}
+	pm, err := opts.ProjectManager()
+	if err != nil {
+		return nil, nil
+	}
+	p, err := pm.CurrentProject(ctx)
+	if err != nil {
+		return nil, nil
+	}
+
+	inspect, err := client.ImageInspect(ctx, opts.Image)
+	if err != nil {
+		return nil, fmt.Errorf("inspecting %s: %w", opts.Image, err)
+	}
+	var harness string
+	if inspect.Config != nil {
+		harness = inspect.Config.Labels[consts.LabelHarness]
+	}
+	if harness == "" {
+		return nil, nil
+	}
+
+	tags := []string{docker.HarnessImageTag(p.Name(), harness)}
+	cfg, err := opts.Config()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	if def, err := bundler.ResolveHarnessName(cfg, ""); err == nil && def == harness {
+		tags = append(tags, docker.DefaultAliasImageTag(p.Name()))
+	}
+	return tags, nil
+}
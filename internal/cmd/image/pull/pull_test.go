@@ -0,0 +1,142 @@
+package pull
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+func TestNewCmdPull(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantImage string
+		wantQuiet bool
+		wantNoTag bool
+		wantErr   bool
+	}{
+		{
+			name:      "image",
+			input:     "ghcr.io/acme/agent:claude",
+			wantImage: "ghcr.io/acme/agent:claude",
+		},
+		{
+			name:      "quiet",
+			input:     "--quiet ghcr.io/acme/agent:claude",
+			wantImage: "ghcr.io/acme/agent:claude",
+			wantQuiet: true,
+		},
+		{
+			name:      "no-tag",
+			input:     "--no-tag ghcr.io/acme/agent:claude",
+			wantImage: "ghcr.io/acme/agent:claude",
+			wantNoTag: true,
+		},
+		{
+			name:    "too many arguments",
+			input:   "a:1 b:2",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *PullOptions
+			cmd := NewCmdPull(f, func(_ context.Context, opts *PullOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantImage, gotOpts.Image)
+			require.Equal(t, tt.wantQuiet, gotOpts.Quiet)
+			require.Equal(t, tt.wantNoTag, gotOpts.NoTag)
+		})
+	}
+}
+
+func newPullOpts(t *testing.T, harness string) (*PullOptions, *[]string, *bytes.Buffer) {
+	t.Helper()
+	ios, _, outBuf, _ := iostreams.Test()
+	cfg := configmocks.NewBlankConfig()
+	fake := mocks.NewFakeClient(cfg)
+	const ref = "ghcr.io/acme/agent:v1"
+	fake.SetupImageExistsWithLabels(ref, map[string]string{consts.LabelHarness: harness})
+	fake.FakeAPI.ImagePullFn = func(context.Context, string, client.ImagePullOptions) (client.ImagePullResponse, error) {
+		return whailtest.NewTransferResponse(), nil
+	}
+	var tagged []string
+	fake.FakeAPI.ImageTagFn = func(_ context.Context, opts client.ImageTagOptions) (client.ImageTagResult, error) {
+		tagged = append(tagged, opts.Target)
+		return client.ImageTagResult{}, nil
+	}
+
+	pm := projectmocks.NewMockProjectManager()
+	pm.CurrentProjectFunc = func(context.Context) (project.Project, error) {
+		return projectmocks.NewMockProject("myapp", t.TempDir()), nil
+	}
+	return &PullOptions{
+		IOStreams:      ios,
+		Client:         func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		Config:         func() (config.Config, error) { return cfg, nil },
+		ProjectManager: func() (project.ProjectManager, error) { return pm, nil },
+		RegistryAuth:   func(context.Context, string) (string, error) { return "", nil },
+		Image:          ref,
+		Quiet:          true,
+	}, &tagged, outBuf
+}
+
+func TestPullRun_TagsDefaultHarnessForProject(t *testing.T) {
+	opts, tagged, _ := newPullOpts(t, consts.DefaultHarnessName)
+
+	require.NoError(t, pullRun(context.Background(), opts))
+	require.Equal(t, []string{
+		docker.HarnessImageTag("myapp", consts.DefaultHarnessName),
+		docker.DefaultAliasImageTag("myapp"),
+	}, *tagged)
+}
+
+func TestPullRun_TagsOtherHarnessWithoutAlias(t *testing.T) {
+	opts, tagged, _ := newPullOpts(t, "codex")
+
+	require.NoError(t, pullRun(context.Background(), opts))
+	require.Equal(t, []string{docker.HarnessImageTag("myapp", "codex")}, *tagged)
+}
+
+func TestPullRun_NoTag(t *testing.T) {
+	opts, tagged, outBuf := newPullOpts(t, consts.DefaultHarnessName)
+	opts.NoTag = true
+
+	require.NoError(t, pullRun(context.Background(), opts))
+	require.Empty(t, *tagged)
+	require.Equal(t, "ghcr.io/acme/agent:v1\n", outBuf.String())
+}
@@ -0,0 +1,118 @@
+// Package push provides the image push command.
+package push
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmd/image/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+// PushOptions holds options for the push command.
+type PushOptions struct {
+	IOStreams *iostreams.IOStreams
+	TUI       *tui.TUI
+	Client    func(context.Context) (*docker.Client, error)
+
+	// RegistryAuth resolves registry credentials for a reference. Tests swap it.
+	RegistryAuth func(ctx context.Context, ref string) (string, error)
+
+	Image    string
+	Quiet    bool
+	Progress string
+	Record   string
+}
+
+// NewCmdPush creates the image push command.
+func NewCmdPush(f *cmdutil.Factory, runF func(context.Context, *PushOptions) error) *cobra.Command {
+	opts := &PushOptions{
+		IOStreams:    f.IOStreams,
+		TUI:          f.TUI,
+		Client:       f.Client,
+		RegistryAuth: docker.RegistryAuth,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "push IMAGE",
+		Short: "Push an image to a registry",
+		Long: `Pushes a clawker-built image to the registry named in its reference.
+
+Only clawker-managed images can be pushed. Give the image a registry
+reference when building it (clawker build -t REGISTRY/NAME:HARNESS), then
+push that reference. Credentials come from your Docker configuration —
+log in with docker login, or configure a credential helper.
+
+The pushed manifest digest is printed to stdout.`,
+		Example: `  # Build the default harness image under a registry reference and push it
+  clawker build -t ghcr.io/acme/agent:claude
+  clawker image push ghcr.io/acme/agent:claude
+
+  # Push without progress output
+  clawker image push -q ghcr.io/acme/agent:claude`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Image = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return pushRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Quiet, "quiet", "q", false, "Suppress the push progress output")
+	cmd.Flags().StringVar(&opts.Progress, "progress", "auto", "Set type of progress output (auto, plain, tty, none)")
+	shared.AddRecordFlag(cmd, &opts.Record)
+
+	return cmd
+}
+
+func pushRun(ctx context.Context, opts *PushOptions) error {
+	ios := opts.IOStreams
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+	auth, err := opts.RegistryAuth(ctx, opts.Image)
+	if err != nil {
+		return fmt.Errorf("resolving registry credentials: %w", err)
+	}
+
+	var digest string
+	push := func(onProgress whail.BuildProgressFunc) error {
+		digest, err = client.ImagePush(ctx, opts.Image, whail.ImageTransferOptions{
+			RegistryAuth: auth,
+			OnProgress:   onProgress,
+		})
+		return err
+	}
+	push = shared.RecordProgress(ios, opts.Record, "image push", opts.Image, push)
+	if opts.Quiet || opts.Progress == "none" {
+		err = push(nil)
+	} else {
+		err = shared.RunWithProgress(opts.TUI, opts.Progress, tui.ProgressDisplayConfig{
+			Title:          "Pushing",
+			Subtitle:       opts.Image,
+			CompletionVerb: "Pushed",
+			MaxVisible:     5,
+			FormatDuration: whail.FormatBuildDuration,
+		}, push)
+	}
+	if err != nil {
+		return fmt.Errorf("pushing %s: %w", opts.Image, err)
+	}
+
+	if digest == "" {
+		fmt.Fprintln(ios.Out, opts.Image)
+		return nil
+	}
+	fmt.Fprintf(ios.Out, "%s@%s\n", opts.Image, digest)
+	return nil
+}
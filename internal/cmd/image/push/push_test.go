@@ -0,0 +1,123 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/moby/moby/api/types/jsonstream"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+func TestNewCmdPush(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantImage    string
+		wantQuiet    bool
+		wantProgress string
+		wantErr      bool
+	}{
+		{
+			name:         "image",
+			input:        "ghcr.io/acme/agent:claude",
+			wantImage:    "ghcr.io/acme/agent:claude",
+			wantProgress: "auto",
+		},
+		{
+			name:         "quiet",
+			input:        "-q ghcr.io/acme/agent:claude",
+			wantImage:    "ghcr.io/acme/agent:claude",
+			wantQuiet:    true,
+			wantProgress: "auto",
+		},
+		{
+			name:         "progress",
+			input:        "--progress plain ghcr.io/acme/agent:claude",
+			wantImage:    "ghcr.io/acme/agent:claude",
+			wantProgress: "plain",
+		},
+		{
+			name:    "no arguments",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *PushOptions
+			cmd := NewCmdPush(f, func(_ context.Context, opts *PushOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantImage, gotOpts.Image)
+			require.Equal(t, tt.wantQuiet, gotOpts.Quiet)
+			require.Equal(t, tt.wantProgress, gotOpts.Progress)
+		})
+	}
+}
+
+func TestPushRun_PrintsDigest(t *testing.T) {
+	ios, _, outBuf, _ := iostreams.Test()
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupImageExists("ghcr.io/acme/agent:claude", true)
+	aux := json.RawMessage(`{"Tag":"claude","Digest":"sha256:abc","Size":1}`)
+	var gotAuth string
+	fake.FakeAPI.ImagePushFn = func(_ context.Context, _ string, opts client.ImagePushOptions) (client.ImagePushResponse, error) {
+		gotAuth = opts.RegistryAuth
+		return whailtest.NewTransferResponse(jsonstream.Message{Aux: &aux}), nil
+	}
+
+	err := pushRun(context.Background(), &PushOptions{
+		IOStreams:    ios,
+		Client:       func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		RegistryAuth: func(context.Context, string) (string, error) { return "creds", nil },
+		Image:        "ghcr.io/acme/agent:claude",
+		Quiet:        true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "creds", gotAuth)
+	require.Equal(t, "ghcr.io/acme/agent:claude@sha256:abc\n", outBuf.String())
+}
+
+func TestPushRun_UnmanagedImage(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupImageExists("ghcr.io/acme/agent:claude", false)
+
+	err := pushRun(context.Background(), &PushOptions{
+		IOStreams:    ios,
+		Client:       func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		RegistryAuth: func(context.Context, string) (string, error) { return "", nil },
+		Image:        "ghcr.io/acme/agent:claude",
+		Quiet:        true,
+	})
+	require.Error(t, err)
+}
@@ -66,6 +66,7 @@ Note: Only clawker-managed images can be removed with this command.`,
 	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Force removal of the image")
 	cmd.Flags().BoolVar(&opts.NoPrune, "no-prune", false, "Do not delete untagged parents")
 
+	cmdutil.EnableDryRun(cmd)
 	return cmd
 }
 
@@ -76,8 +77,7 @@ func removeRun(ctx context.Context, opts *RemoveOptions) error {
 	// Connect to Docker
 	client, err := opts.Client(ctx)
 	if err != nil {
-		cmdutil.HandleError(ios, err)
-		return err
+		return fmt.Errorf("connecting to Docker: %w", err)
 	}
 
 	removeOpts := docker.ImageRemoveOptions{
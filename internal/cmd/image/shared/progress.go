@@ -0,0 +1,51 @@
+// Package shared holds helpers shared by the image subcommands.
+package shared
+
+import (
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+// ProgressStatus converts a whail build step status to a tui progress step status.
+// Explicit switch avoids iota alignment tricks between packages.
+func ProgressStatus(s whail.BuildStepStatus) tui.ProgressStepStatus {
+	switch s {
+	case whail.BuildStepRunning:
+		return tui.StepRunning
+	case whail.BuildStepComplete:
+		return tui.StepComplete
+	case whail.BuildStepCached:
+		return tui.StepCached
+	case whail.BuildStepError:
+		return tui.StepError
+	default:
+		return tui.StepPending
+	}
+}
+
+// ProgressStep converts a whail progress event to a tui progress step.
+func ProgressStep(event whail.BuildProgressEvent) tui.ProgressStep {
+	return tui.ProgressStep{
+		ID:      event.StepID,
+		Name:    event.StepName,
+		Status:  ProgressStatus(event.Status),
+		Cached:  event.Cached,
+		Error:   event.Error,
+		LogLine: event.LogLine,
+	}
+}
+
+// RunWithProgress runs op, rendering the events it reports through a
+// tui.StepRunner, and waits for the display to finish. op's error wins over a
+// display error, so a render failure never masks the operation's own.
+func RunWithProgress(t *tui.TUI, mode string, cfg tui.ProgressDisplayConfig, op func(whail.BuildProgressFunc) error) error {
+	runner := t.NewStepRunner(mode, cfg)
+	opErr := op(func(event whail.BuildProgressEvent) {
+		runner.Send(ProgressStep(event))
+	})
+	displayErr := runner.Wait()
+	if opErr != nil {
+		return opErr
+	}
+	return displayErr
+}
@@ -0,0 +1,50 @@
+package shared
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+// AddRecordFlag registers the --record flag shared by the
+// image commands that stream engine progress events.
+func AddRecordFlag(cmd *cobra.Command, path *string) {
+	cmd.Flags().StringVar(path, "record", "",
+		"Record the engine progress events to a JSON file for replay without Docker")
+}
+
+// RecordProgress wraps op so every progress event it reports is also captured,
+// with timing, and saved to path as a whail.RecordedBuildScenario once op
+// returns. The file is written even when op fails — failed sessions are the
+// ones worth replaying. An empty path returns op unchanged.
+//
+// command names the CLI command ("image build") and subject what it acted on
+// (the image reference); both are stored in the recording.
+func RecordProgress(ios *iostreams.IOStreams, path, command, subject string, op func(whail.BuildProgressFunc) error) func(whail.BuildProgressFunc) error {
+	if path == "" {
+		return op
+	}
+	return func(onProgress whail.BuildProgressFunc) error {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		rec := whail.NewEventRecorder(name, fmt.Sprintf("clawker %s %s", command, subject), onProgress)
+		opErr := op(rec.OnProgress())
+
+		scenario := rec.Scenario()
+		scenario.Command = command
+		if err := whail.SaveRecordedScenario(path, scenario); err != nil {
+			err = fmt.Errorf("saving --record file: %w", err)
+			if opErr != nil {
+				// The operation's own failure is the one to surface.
+				fmt.Fprintf(ios.ErrOut, "%s %v\n", ios.ColorScheme().WarningIcon(), err)
+				return opErr
+			}
+			return err
+		}
+		return opErr
+	}
+}
@@ -0,0 +1,70 @@
+package shared
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+func TestRecordProgress_SavesOnFailure(t *testing.T) {
+	ios, _, _, errOut := iostreams.Test()
+	path := filepath.Join(t.TempDir(), "sessions", "failed-build.json")
+	boom := errors.New("RUN exited 1")
+
+	var forwarded []whail.BuildProgressEvent
+	op := RecordProgress(ios, path, "image build", "clawker-demo:claude", func(onProgress whail.BuildProgressFunc) error {
+		onProgress(whail.BuildProgressEvent{StepID: "s1", StepName: "RUN make", Status: whail.BuildStepRunning})
+		onProgress(whail.BuildProgressEvent{StepID: "s1", StepName: "RUN make", Status: whail.BuildStepError, Error: "exit 1"})
+		return boom
+	})
+	err := op(func(e whail.BuildProgressEvent) { forwarded = append(forwarded, e) })
+	require.ErrorIs(t, err, boom)
+	assert.Empty(t, errOut.String())
+	assert.Len(t, forwarded, 2, "events must still reach the display")
+
+	scenario, err := whail.LoadRecordedScenario(path)
+	require.NoError(t, err)
+	assert.Equal(t, "failed-build", scenario.Name)
+	assert.Equal(t, "image build", scenario.Command)
+	assert.Equal(t, "clawker image build clawker-demo:claude", scenario.Description)
+	assert.Equal(t, forwarded, scenario.FlatEvents())
+}
+
+func TestRecordProgress_SaveErrorYieldsToOpError(t *testing.T) {
+	ios, _, _, errOut := iostreams.Test()
+	// A regular file where a parent directory is needed makes the save fail.
+	blocker := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, whail.SaveRecordedScenario(blocker, &whail.RecordedBuildScenario{}))
+	path := filepath.Join(blocker, "out.json")
+
+	boom := errors.New("pull denied")
+	err := RecordProgress(ios, path, "image pull", "ghcr.io/acme/agent:claude", func(whail.BuildProgressFunc) error {
+		return boom
+	})(nil)
+	require.ErrorIs(t, err, boom)
+	assert.Contains(t, errOut.String(), "saving --record file")
+
+	err = RecordProgress(ios, path, "image pull", "ghcr.io/acme/agent:claude", func(whail.BuildProgressFunc) error {
+		return nil
+	})(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "saving --record file")
+}
+
+func TestRecordProgress_NoPathPassesThrough(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	called := false
+	op := RecordProgress(ios, "", "image push", "img", func(onProgress whail.BuildProgressFunc) error {
+		called = true
+		assert.Nil(t, onProgress)
+		return nil
+	})
+	require.NoError(t, op(nil))
+	assert.True(t, called)
+}
@@ -4,9 +4,9 @@ package init
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	projectinit "github.com/schmitthub/clawker/internal/cmd/project/init"
+	"github.com/schmitthub/clawker/internal/cmd/project/init/templates"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/spf13/cobra"
 )
@@ -40,6 +40,13 @@ See 'clawker project init --help' for full documentation.`,
   clawker init --yes --preset Go --vcs github
   clawker init --yes --preset Python --vcs gitlab --git-protocol ssh
 
+  # Start from the template gallery
+  clawker init templates list
+  clawker init --yes --template monorepo
+
+  # Scripted bootstrap answering the customize fields
+  clawker init --yes --preset Go --packages ripgrep,jq --mode snapshot --agent-name dev
+
   # Overwrite existing configuration
   clawker init --force`,
 		Args: cobra.MaximumNArgs(1),
@@ -47,17 +54,8 @@ See 'clawker project init --help' for full documentation.`,
 			if len(args) > 0 {
 				opts.Name = args[0]
 			}
-			if opts.Preset != "" && !opts.Yes {
-				return cmdutil.FlagErrorf("--preset requires --yes")
-			}
-			if (opts.VCS != "" || opts.GitProtocol != "" || opts.NoGPG) && !opts.Yes {
-				return cmdutil.FlagErrorf("--vcs, --git-protocol, and --no-gpg require --yes")
-			}
-			if opts.VCS != "" && !projectinit.IsValidVCSProvider(opts.VCS) {
-				return cmdutil.FlagErrorf("invalid --vcs value %q; valid: %s", opts.VCS, strings.Join(projectinit.VCSProviderNames(), ", "))
-			}
-			if opts.GitProtocol != "" && !projectinit.IsValidGitProtocol(opts.GitProtocol) {
-				return cmdutil.FlagErrorf("invalid --git-protocol value %q; valid: %s", opts.GitProtocol, strings.Join(projectinit.GitProtocolNames(), ", "))
+			if err := projectinit.ValidateFlags(opts); err != nil {
+				return err
 			}
 
 			cs := f.IOStreams.ColorScheme()
@@ -74,9 +72,14 @@ See 'clawker project init --help' for full documentation.`,
 	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Overwrite existing configuration files")
 	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Non-interactive mode, accept all defaults")
 	cmd.Flags().StringVar(&opts.Preset, "preset", "", "Select a language preset (requires --yes)")
+	cmd.Flags().StringVar(&opts.Template, "template", "", "Gallery template, template directory, or git URL (requires --yes)")
 	cmd.Flags().StringVar(&opts.VCS, "vcs", "", "VCS provider: github, gitlab, bitbucket (requires --yes)")
 	cmd.Flags().StringVar(&opts.GitProtocol, "git-protocol", "", "Git protocol: https, ssh (requires --yes)")
 	cmd.Flags().BoolVar(&opts.NoGPG, "no-gpg", false, "Disable GPG agent forwarding (requires --yes)")
+	cmd.Flags().StringVar(&opts.Harness, "harness", "", "Default harness to build (requires --yes)")
+	cmd.Flags().StringSliceVar(&opts.Packages, "packages", nil, "System packages, replacing the preset's (requires --yes)")
+	cmd.Flags().StringVar(&opts.Mode, "mode", "", "Default workspace mode: bind, snapshot (requires --yes)")
+	cmd.Flags().StringVar(&opts.AgentName, "agent-name", "", "Agent name for the printed next steps (requires --yes)")
 
 	cmd.RegisterFlagCompletionFunc("preset", func(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) { //nolint:errcheck // cobra registers completion internally
 		return projectinit.PresetCompletions(), cobra.ShellCompDirectiveNoFileComp
@@ -87,6 +90,14 @@ See 'clawker project init --help' for full documentation.`,
 	cmd.RegisterFlagCompletionFunc("git-protocol", func(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) { //nolint:errcheck
 		return projectinit.GitProtocolCompletions(), cobra.ShellCompDirectiveNoFileComp
 	})
+	cmd.RegisterFlagCompletionFunc("mode", func(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) { //nolint:errcheck
+		return projectinit.ModeCompletions(), cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.RegisterFlagCompletionFunc("template", func(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) { //nolint:errcheck
+		return projectinit.TemplateCompletions(), cobra.ShellCompDirectiveDefault
+	})
+
+	cmd.AddCommand(templates.NewCmdTemplates(f))
 
 	return cmd
 }
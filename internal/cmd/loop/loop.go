@@ -0,0 +1,31 @@
+// Package loop provides the `clawker loop` command group: running an agent
+// unattended in a supervised loop.
+package loop
+
+import (
+	"github.com/spf13/cobra"
+
+	runcmd "github.com/schmitthub/clawker/internal/cmd/loop/run"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+)
+
+// NewCmdLoop creates the loop parent command and registers its
+// subcommands.
+func NewCmdLoop(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "loop",
+		Short: "Run an agent in a supervised loop",
+		Long: `Commands for driving an agent unattended: the same task prompt is given
+to the agent again and again until a success command passes, an
+iteration limit is reached, or a token budget is spent.
+
+Defaults for the stop conditions come from the loop: block of
+clawker.yaml.`,
+		Example: `  # Loop the dev agent until the tests pass
+  clawker loop run dev --prompt "Make the failing tests pass" --success-command "go test ./..."`,
+	}
+
+	cmd.AddCommand(runcmd.NewCmdRun(f, nil))
+
+	return cmd
+}
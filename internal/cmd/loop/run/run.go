@@ -0,0 +1,338 @@
+// Package run provides the `clawker loop run` command.
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/loop"
+	"github.com/schmitthub/clawker/internal/notify"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/signals"
+)
+
+// RunOptions holds options for the loop run command.
+type RunOptions struct {
+	IOStreams      *iostreams.IOStreams
+	Client         func(context.Context) (*docker.Client, error)
+	Config         func() (config.Config, error)
+	ProjectManager func() (project.ProjectManager, error)
+	Notifier       func() *notify.Notifier
+
+	// Now is the run's start time; nil means time.Now.
+	Now func() time.Time
+
+	Prompt           string
+	PromptFile       string
+	MaxLoops         int
+	TokenBudget      int64
+	SuccessCommand   string
+	IterationTimeout time.Duration
+	Output           string
+
+	// set marks the stop-condition flags given on the command line; they
+	// override the loop: config.
+	set   map[string]bool
+	agent string
+}
+
+// NewCmdRun creates the loop run command.
+func NewCmdRun(f *cmdutil.Factory, runF func(context.Context, *RunOptions) error) *cobra.Command {
+	opts := &RunOptions{
+		IOStreams:      f.IOStreams,
+		Client:         f.Client,
+		Config:         f.Config,
+		ProjectManager: f.ProjectManager,
+		Notifier:       f.Notifier,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "run AGENT",
+		Short: "Drive an agent with a task prompt until a stop condition is met",
+		Long: `Runs the agent non-interactively with the same task prompt, again and
+again, in its running container. After each iteration the run stops when:
+
+  the agent command exits non-zero or times out,
+  the success command (loop.success_command) exits 0,
+  the agent has used the token budget (loop.token_budget), or
+  the iteration limit (loop.max_loops, default 10) is reached.
+
+The agent command is loop.command, run with sh -c in the container's
+working directory with the prompt in $CLAWKER_LOOP_PROMPT and the
+iteration number in $CLAWKER_LOOP_ITERATION. Without loop.command, the
+claude and codex harnesses run non-interactively with permission prompts
+skipped. Token use is read from the usage JSON the agent prints; an agent
+printing none counts as zero.
+
+Each iteration checkpoints the workspace as a git tree object, without
+touching the index, HEAD or working tree, and saves the iteration's diff.
+The run directory (default: a new directory under the clawker data
+directory) holds iteration-NNN.log, iteration-NNN.diff and report.json,
+which lists every iteration with its exit codes, token usage, checkpoint
+and changed files. Its path is printed on stdout.
+
+Interrupting the run (Ctrl+C) stops the agent and still writes the
+report. The command exits non-zero unless the success command passed.`,
+		Example: `  # Loop until the tests pass
+  clawker loop run dev --prompt "Make the failing tests pass" --success-command "go test ./..."
+
+  # Read the prompt from a file, with a budget of 2M tokens and 20 iterations
+  clawker loop run dev --prompt-file TASK.md --token-budget 2000000 --max-loops 20
+
+  # Inspect what the run changed
+  cat "$(clawker loop run dev --prompt-file TASK.md)/report.json"`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (opts.Prompt == "") == (opts.PromptFile == "") {
+				return cmdutil.FlagErrorf("exactly one of --prompt or --prompt-file is required")
+			}
+			if opts.MaxLoops < 0 || opts.TokenBudget < 0 || opts.IterationTimeout < 0 {
+				return cmdutil.FlagErrorf("--max-loops, --token-budget and --iteration-timeout cannot be negative")
+			}
+			opts.set = map[string]bool{}
+			for _, name := range []string{"max-loops", "token-budget", "success-command", "iteration-timeout"} {
+				opts.set[name] = cmd.Flags().Changed(name)
+			}
+			opts.agent = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return runRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Prompt, "prompt", "p", "", "Task prompt given to the agent on every iteration")
+	cmd.Flags().StringVar(&opts.PromptFile, "prompt-file", "", "Read the task prompt from a file (- for stdin)")
+	cmd.Flags().IntVar(&opts.MaxLoops, "max-loops", 0, "Stop after this many iterations (default: loop.max_loops, else 10)")
+	cmd.Flags().Int64Var(&opts.TokenBudget, "token-budget", 0, "Stop once the agent has used this many tokens (default: loop.token_budget, else no limit)")
+	cmd.Flags().StringVar(&opts.SuccessCommand, "success-command", "", "Shell command run in the container after each iteration; exit 0 ends the run (default: loop.success_command)")
+	cmd.Flags().DurationVar(&opts.IterationTimeout, "iteration-timeout", 0, "Kill an agent iteration that runs longer than this (default: loop.iteration_timeout, else no limit)")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Run directory (default: loops/<container>-<timestamp> in the clawker data directory)")
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.AgentCompletions(f.Client, f.ProjectManager))
+
+	return cmd
+}
+
+func runRun(ctx context.Context, opts *RunOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+
+	prompt, err := readPrompt(opts)
+	if err != nil {
+		return err
+	}
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	settings := resolveSettings(opts, cfg.Project().Loop)
+
+	var projectName string
+	if opts.ProjectManager != nil {
+		if pm, err := opts.ProjectManager(); err == nil {
+			if p, err := pm.CurrentProject(ctx); err == nil {
+				projectName = p.Name()
+			}
+		}
+	}
+	containerName, err := docker.ContainerName(projectName, opts.agent)
+	if err != nil {
+		return err
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+	c, err := client.FindContainerByName(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("failed to find container %q: %w", containerName, err)
+	}
+	if c == nil {
+		return fmt.Errorf("container %q not found", containerName)
+	}
+	if c.State != container.StateRunning {
+		return fmt.Errorf("agent %q is not running; start it with 'clawker container start %s'", opts.agent, containerName)
+	}
+
+	command := settings.Command
+	if command == "" {
+		harness := c.Labels[consts.LabelHarness]
+		var ok bool
+		if command, ok = loop.DefaultCommand(harness); !ok {
+			return fmt.Errorf("no loop.command is configured and harness %q has no default; set loop.command in clawker.yaml", harness)
+		}
+	}
+
+	dir := opts.Output
+	if dir == "" {
+		root, err := consts.LoopsSubdir()
+		if err != nil {
+			return fmt.Errorf("creating loops directory: %w", err)
+		}
+		dir = filepath.Join(root, containerName+"-"+now().Format("20060102-150405"))
+	}
+
+	ctx, cancel := signals.SetupSignalContext(ctx)
+	defer cancel()
+
+	fmt.Fprintf(ios.ErrOut, "%s Looping %s (up to %d iterations); Ctrl+C stops the run\n",
+		cs.InfoIcon(), containerName, settings.MaxLoops)
+	started := time.Now()
+	report, err := loop.Run(ctx, loop.Options{
+		Exec: func(ctx context.Context, cmd, env []string, timeout time.Duration) (string, string, int, error) {
+			return client.ContainerExecRun(ctx, c.ID, docker.ExecRunOptions{Cmd: cmd, Env: env, Timeout: timeout})
+		},
+		Dir:              dir,
+		Container:        containerName,
+		Prompt:           prompt,
+		Command:          command,
+		SuccessCommand:   settings.SuccessCommand,
+		MaxLoops:         settings.MaxLoops,
+		TokenBudget:      int64(settings.TokenBudget),
+		IterationTimeout: settings.IterationTimeout,
+		Now:              opts.Now,
+		OnIteration: func(it loop.Iteration) {
+			fmt.Fprintf(ios.ErrOut, "%s Iteration %d: %s\n", cs.InfoIcon(), it.N, describeIteration(it))
+		},
+	})
+	notifyLoop(ctx, opts.Notifier, containerName, report, time.Since(started), err)
+	if err != nil {
+		return err
+	}
+	if report.Checkpoints.Error != "" {
+		fmt.Fprintf(ios.ErrOut, "%s No checkpoints or diffs: %s\n", cs.WarningIcon(), report.Checkpoints.Error)
+	}
+
+	icon := cs.SuccessIcon()
+	if report.StopReason != loop.StopSuccess {
+		icon = cs.FailureIcon()
+	}
+	fmt.Fprintf(ios.ErrOut, "%s Stopped after %d iterations: %s (%d tokens)\n",
+		icon, len(report.Iterations), describeStop(report.StopReason), report.Tokens)
+	fmt.Fprintln(ios.Out, dir)
+	if report.StopReason != loop.StopSuccess {
+		return cmdutil.SilentError
+	}
+	return nil
+}
+
+// resolveSettings layers the stop-condition flags given on the command line
+// over the project loop: block.
+func resolveSettings(opts *RunOptions, cfg config.LoopConfig) config.LoopConfig {
+	if opts.set["max-loops"] {
+		cfg.MaxLoops = opts.MaxLoops
+	}
+	if opts.set["token-budget"] {
+		cfg.TokenBudget = int(opts.TokenBudget)
+	}
+	if opts.set["success-command"] {
+		cfg.SuccessCommand = opts.SuccessCommand
+	}
+	if opts.set["iteration-timeout"] {
+		cfg.IterationTimeout = opts.IterationTimeout
+	}
+	if cfg.MaxLoops <= 0 {
+		cfg.MaxLoops = loop.DefaultMaxLoops
+	}
+	return cfg
+}
+
+func readPrompt(opts *RunOptions) (string, error) {
+	if opts.PromptFile == "" {
+		return opts.Prompt, nil
+	}
+	var (
+		data []byte
+		err  error
+	)
+	if opts.PromptFile == "-" {
+		data, err = io.ReadAll(opts.IOStreams.In)
+	} else {
+		data, err = os.ReadFile(opts.PromptFile)
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading prompt: %w", err)
+	}
+	prompt := strings.TrimSpace(string(data))
+	if prompt == "" {
+		return "", errors.New("the prompt is empty")
+	}
+	return prompt, nil
+}
+
+func describeIteration(it loop.Iteration) string {
+	parts := []string{fmt.Sprintf("agent exit %d", it.ExitCode)}
+	if it.Error != "" {
+		parts[0] = "agent failed: " + it.Error
+	}
+	if it.SuccessExitCode != nil {
+		parts = append(parts, fmt.Sprintf("success command exit %d", *it.SuccessExitCode))
+	}
+	if it.Checkpoint != "" {
+		parts = append(parts, fmt.Sprintf("%d files changed", it.FilesChanged))
+	}
+	parts = append(parts, fmt.Sprintf("%d tokens", it.Usage.Total()))
+	return strings.Join(parts, ", ")
+}
+
+// notifyLoop raises the desktop notification for a finished run:
+// loop_complete when the success command passed, failure for any other stop
+// or error. An interrupted run raises nothing.
+func notifyLoop(ctx context.Context, notifier func() *notify.Notifier, containerName string, report *loop.Report, elapsed time.Duration, err error) {
+	if notifier == nil || ctx.Err() != nil {
+		return
+	}
+	note := notify.Notification{
+		Event:   notify.EventFailure,
+		Title:   "clawker loop " + containerName,
+		Elapsed: elapsed,
+	}
+	switch {
+	case err != nil:
+		note.Message = "Loop failed: " + err.Error()
+	case report.StopReason == loop.StopInterrupted:
+		return
+	case report.StopReason == loop.StopSuccess:
+		note.Event = notify.EventLoopComplete
+		note.Message = fmt.Sprintf("Done after %d iterations: %s", len(report.Iterations), describeStop(report.StopReason))
+	default:
+		note.Message = fmt.Sprintf("Stopped after %d iterations: %s", len(report.Iterations), describeStop(report.StopReason))
+	}
+	notifier().Notify(ctx, note)
+}
+
+func describeStop(reason loop.StopReason) string {
+	switch reason {
+	case loop.StopSuccess:
+		return "the success command passed"
+	case loop.StopMaxLoops:
+		return "iteration limit reached"
+	case loop.StopTokenBudget:
+		return "token budget spent"
+	case loop.StopAgentFailed:
+		return "the agent command failed"
+	case loop.StopInterrupted:
+		return "interrupted"
+	}
+	return string(reason)
+}
@@ -0,0 +1,252 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/shlex"
+	"github.com/moby/moby/api/pkg/stdcopy"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/loop"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+)
+
+func TestNewCmdRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    RunOptions
+		wantSet []string
+		wantErr string
+	}{
+		{name: "prompt", input: `dev -p "fix it"`, want: RunOptions{Prompt: "fix it"}},
+		{
+			name:    "stop conditions",
+			input:   `dev --prompt-file task.md --max-loops 3 --token-budget 5000 --success-command "make test" --iteration-timeout 10m`,
+			want:    RunOptions{PromptFile: "task.md", MaxLoops: 3, TokenBudget: 5000, SuccessCommand: "make test", IterationTimeout: 10 * time.Minute},
+			wantSet: []string{"max-loops", "token-budget", "success-command", "iteration-timeout"},
+		},
+		{name: "no prompt", input: "dev", wantErr: "exactly one of --prompt or --prompt-file is required"},
+		{name: "both prompts", input: "dev -p x --prompt-file y", wantErr: "exactly one of --prompt or --prompt-file is required"},
+		{name: "negative", input: "dev -p x --max-loops -1", wantErr: "cannot be negative"},
+		{name: "no agent", input: "-p x", wantErr: "requires 1 argument"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *RunOptions
+			cmd := NewCmdRun(cmdutiltest.NewFactory(t).Factory, func(_ context.Context, opts *RunOptions) error {
+				got = opts
+				return nil
+			})
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err = cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "dev", got.agent)
+			assert.Equal(t, tt.want.Prompt, got.Prompt)
+			assert.Equal(t, tt.want.PromptFile, got.PromptFile)
+			assert.Equal(t, tt.want.MaxLoops, got.MaxLoops)
+			assert.Equal(t, tt.want.TokenBudget, got.TokenBudget)
+			assert.Equal(t, tt.want.SuccessCommand, got.SuccessCommand)
+			assert.Equal(t, tt.want.IterationTimeout, got.IterationTimeout)
+			for _, name := range tt.wantSet {
+				assert.True(t, got.set[name], name)
+			}
+		})
+	}
+}
+
+func TestResolveSettings(t *testing.T) {
+	cfg := config.LoopConfig{MaxLoops: 7, TokenBudget: 100, SuccessCommand: "make test", Command: "agent"}
+
+	got := resolveSettings(&RunOptions{set: map[string]bool{}}, cfg)
+	assert.Equal(t, cfg, got, "the loop: block applies without flags")
+
+	got = resolveSettings(&RunOptions{MaxLoops: 2, SuccessCommand: "", set: map[string]bool{"max-loops": true, "success-command": true}}, cfg)
+	assert.Equal(t, 2, got.MaxLoops)
+	assert.Empty(t, got.SuccessCommand, "an explicit empty flag clears the configured command")
+	assert.Equal(t, 100, got.TokenBudget)
+
+	got = resolveSettings(&RunOptions{set: map[string]bool{}}, config.LoopConfig{})
+	assert.Equal(t, loop.DefaultMaxLoops, got.MaxLoops)
+}
+
+// execFake answers the execs of a loop run in the fake agent container:
+// the checkpoint script, git diff, the agent command ("agent") and the
+// success command ("check"), which passes on the given iteration.
+type execFake struct {
+	passOn int
+
+	mu       sync.Mutex
+	cmds     map[string][]string
+	agentRun int
+}
+
+func (e *execFake) install(fake *mocks.FakeClient) {
+	e.cmds = map[string][]string{}
+	fake.FakeAPI.ExecCreateFn = func(_ context.Context, _ string, opts client.ExecCreateOptions) (client.ExecCreateResult, error) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		id := fmt.Sprintf("exec-%d", len(e.cmds))
+		e.cmds[id] = opts.Cmd
+		return client.ExecCreateResult{ID: id}, nil
+	}
+	fake.FakeAPI.ExecAttachFn = func(_ context.Context, id string, _ client.ExecAttachOptions) (client.ExecAttachResult, error) {
+		stdout := e.respond(id)
+		clientConn, serverConn := net.Pipe()
+		go func() {
+			defer serverConn.Close()
+			if stdout != "" {
+				header := []byte{byte(stdcopy.Stdout), 0, 0, 0, 0, 0, 0, 0}
+				binary.BigEndian.PutUint32(header[4:], uint32(len(stdout)))
+				_, _ = serverConn.Write(append(header, stdout...))
+			}
+		}()
+		return client.ExecAttachResult{
+			HijackedResponse: client.NewHijackedResponse(clientConn, "application/vnd.docker.multiplexed-stream"),
+		}, nil
+	}
+	fake.FakeAPI.ExecInspectFn = func(_ context.Context, id string, _ client.ExecInspectOptions) (client.ExecInspectResult, error) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		exit := 0
+		if cmd := e.cmds[id]; cmd[0] == "sh" && cmd[2] == "check" && e.agentRun != e.passOn {
+			exit = 1
+		}
+		return client.ExecInspectResult{ExitCode: exit}, nil
+	}
+}
+
+func (e *execFake) respond(id string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	cmd := e.cmds[id]
+	switch {
+	case cmd[0] == "git":
+		return "diff --git a/main.go b/main.go\n"
+	case cmd[2] == "agent":
+		e.agentRun++
+		return `{"type":"result","usage":{"input_tokens":10,"output_tokens":5}}` + "\n"
+	case cmd[2] == "check":
+		return ""
+	}
+	return fmt.Sprintf("tree%d\n", e.agentRun)
+}
+
+func newRunTest(t *testing.T, projectYAML, stdin string) (*cmdutil.Factory, *mocks.FakeClient, *execFake, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	t.Setenv(consts.EnvDataDir, t.TempDir())
+	cfg := configmocks.NewFromString(projectYAML, "")
+	fake := mocks.NewFakeClient(cfg)
+	agent := mocks.RunningContainerFixture("app", "dev")
+	fake.SetupFindContainer("clawker.app.dev", agent)
+	fake.SetupContainerInspect("clawker.app.dev", agent)
+	ex := &execFake{passOn: 2}
+	ex.install(fake)
+
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithConfig(cfg), cmdutiltest.WithFakeClient(fake))
+	tf.In.WriteString(stdin)
+	tf.ProjectManager = func() (project.ProjectManager, error) {
+		mgr := projectmocks.NewMockProjectManager()
+		mgr.CurrentProjectFunc = func(context.Context) (project.Project, error) {
+			return projectmocks.NewMockProject("app", t.TempDir()), nil
+		}
+		return mgr, nil
+	}
+	return tf.Factory, fake, ex, tf.Out, tf.ErrOut
+}
+
+func execute(f *cmdutil.Factory, args string) error {
+	cmd := NewCmdRun(f, nil)
+	argv, _ := shlex.Split(args)
+	cmd.SetArgs(argv)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	return cmd.Execute()
+}
+
+func TestRunRun_StopsOnSuccess(t *testing.T) {
+	f, _, ex, out, errOut := newRunTest(t, "loop:\n  command: agent\n  success_command: check\n  max_loops: 5\n", "Fix the build\n")
+
+	require.NoError(t, execute(f, "dev --prompt-file -"))
+	assert.Equal(t, 2, ex.agentRun)
+
+	dir := strings.TrimSpace(out.String())
+	root, err := consts.LoopsSubdir()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(dir, filepath.Join(root, "clawker.app.dev-")), dir)
+
+	data, err := os.ReadFile(filepath.Join(dir, loop.ReportFile))
+	require.NoError(t, err)
+	var report loop.Report
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, loop.StopSuccess, report.StopReason)
+	assert.Equal(t, "Fix the build", report.Prompt)
+	assert.Equal(t, "clawker.app.dev", report.Container)
+	assert.Equal(t, int64(30), report.Tokens)
+	require.Len(t, report.Iterations, 2)
+	assert.Equal(t, "iteration-001.diff", report.Iterations[0].Diff)
+
+	assert.Contains(t, errOut.String(), "Iteration 1: agent exit 0, success command exit 1, 1 files changed, 15 tokens")
+	assert.Contains(t, errOut.String(), "Stopped after 2 iterations: the success command passed (30 tokens)")
+}
+
+func TestRunRun_FlagOverridesConfig(t *testing.T) {
+	f, _, ex, _, errOut := newRunTest(t, "loop:\n  command: agent\n  success_command: check\n  max_loops: 5\n", "")
+	dir := filepath.Join(t.TempDir(), "run")
+
+	err := execute(f, "dev -p task --max-loops 1 -o "+dir)
+	require.ErrorIs(t, err, cmdutil.SilentError, "a run that did not succeed exits non-zero")
+	assert.Equal(t, 1, ex.agentRun)
+	assert.Contains(t, errOut.String(), "iteration limit reached")
+	_, err = os.Stat(filepath.Join(dir, loop.ReportFile))
+	require.NoError(t, err)
+}
+
+func TestRunRun_NeedsCommandForUnknownHarness(t *testing.T) {
+	f, _, _, _, _ := newRunTest(t, "", "")
+
+	err := execute(f, "dev -p task")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "set loop.command")
+}
+
+func TestRunRun_AgentNotRunning(t *testing.T) {
+	f, fake, _, _, _ := newRunTest(t, "loop:\n  command: agent\n", "")
+	fake.SetupFindContainer("clawker.app.dev", mocks.ContainerFixture("app", "dev", "node:20-slim"))
+
+	err := execute(f, "dev -p task")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `agent "dev" is not running`)
+}
@@ -0,0 +1,143 @@
+// Package loglevel implements `clawker monitor loglevel` — change the log
+// level of the running control plane or an agent's clawkerd without a
+// restart.
+package loglevel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/project"
+)
+
+// controlPlaneComponents name the control plane itself; any other
+// component is an agent name in the current project.
+var controlPlaneComponents = map[string]bool{"controlplane": true, "cp": true}
+
+type LogLevelOptions struct {
+	IOStreams      *iostreams.IOStreams
+	Client         func(context.Context) (*docker.Client, error)
+	ProjectManager func() (project.ProjectManager, error)
+	AdminClient    func(context.Context) (adminv1.AdminServiceClient, error)
+
+	Component string
+	Level     string
+}
+
+func NewCmdLogLevel(f *cmdutil.Factory, runF func(context.Context, *LogLevelOptions) error) *cobra.Command {
+	opts := &LogLevelOptions{
+		IOStreams:      f.IOStreams,
+		Client:         f.Client,
+		ProjectManager: f.ProjectManager,
+		AdminClient:    f.AdminClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "loglevel COMPONENT LEVEL",
+		Short: "Change a running daemon's log level without a restart",
+		Long: `Changes the minimum log level of a running clawker daemon. The change takes
+effect immediately and lasts until the daemon restarts.
+
+COMPONENT is "controlplane" (or "cp") for the control plane, or an agent name
+in the current project for that agent's clawkerd. LEVEL is one of debug, info,
+warn, or error. Daemons start at debug.
+
+The control plane and the host proxy also toggle between debug and info on
+SIGUSR1.`,
+		Example: `  # Quiet the control plane down to warnings
+  clawker monitor loglevel controlplane warn
+
+  # Turn debug logging back on for the dev agent's clawkerd
+  clawker monitor loglevel dev debug`,
+		Args: cmdutil.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Component = args[0]
+			opts.Level = strings.ToLower(args[1])
+			if !logger.ValidLevel(opts.Level) {
+				return cmdutil.FlagErrorf("invalid level %q: must be one of %s", args[1], strings.Join(logger.Levels, ", "))
+			}
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return logLevelRun(cmd.Context(), opts)
+		},
+	}
+
+	return cmd
+}
+
+func logLevelRun(ctx context.Context, opts *LogLevelOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	req := &adminv1.SetLogLevelRequest{Level: opts.Level}
+	target := "Control plane"
+	if !controlPlaneComponents[opts.Component] {
+		containerID, projectName, err := resolveAgent(ctx, opts)
+		if err != nil {
+			return err
+		}
+		req.ContainerId = containerID
+		req.Project = projectName
+		target = fmt.Sprintf("Agent %s", opts.Component)
+	}
+
+	client, err := opts.AdminClient(ctx)
+	if err != nil {
+		return fmt.Errorf("dialing control plane: %w", err)
+	}
+	resp, err := client.SetLogLevel(ctx, req)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			return fmt.Errorf("agent %q is not connected to the control plane; is it running?", opts.Component)
+		case codes.PermissionDenied:
+			return fmt.Errorf("agent %q is not part of the current project: %s", opts.Component, status.Convert(err).Message())
+		}
+		return fmt.Errorf("SetLogLevel: %w", err)
+	}
+
+	if prev := resp.GetPreviousLevel(); prev != "" {
+		fmt.Fprintf(ios.ErrOut, "%s %s log level set to %s (was %s)\n", cs.SuccessIcon(), target, opts.Level, prev)
+	} else {
+		fmt.Fprintf(ios.ErrOut, "%s %s log level set to %s\n", cs.SuccessIcon(), target, opts.Level)
+	}
+	return nil
+}
+
+// resolveAgent maps an agent name in the current project to its container ID
+// and returns the project it resolved under, the control plane's scope
+// selector for the change.
+func resolveAgent(ctx context.Context, opts *LogLevelOptions) (string, string, error) {
+	var projectName string
+	if opts.ProjectManager != nil {
+		if pm, pmErr := opts.ProjectManager(); pmErr == nil {
+			if p, pErr := pm.CurrentProject(ctx); pErr == nil {
+				projectName = p.Name()
+			}
+		}
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("connecting to Docker: %w", err)
+	}
+	containerName, ctr, err := client.FindContainerByAgent(ctx, projectName, opts.Component)
+	if err != nil {
+		return "", "", err
+	}
+	if ctr == nil {
+		return "", "", fmt.Errorf("container %q not found", containerName)
+	}
+	return ctr.ID, projectName, nil
+}
@@ -0,0 +1,127 @@
+package loglevel
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	adminv1mocks "github.com/schmitthub/clawker/api/admin/v1/mocks"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+)
+
+func TestNewCmdLogLevel(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		wantComponent string
+		wantLevel     string
+		wantErr       bool
+	}{
+		{name: "control plane", input: "controlplane debug", wantComponent: "controlplane", wantLevel: "debug"},
+		{name: "agent", input: "dev warn", wantComponent: "dev", wantLevel: "warn"},
+		{name: "level case folded", input: "cp INFO", wantComponent: "cp", wantLevel: "info"},
+		{name: "unknown level", input: "cp trace", wantErr: true},
+		{name: "missing level", input: "cp", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *LogLevelOptions
+			cmd := NewCmdLogLevel(f, func(_ context.Context, opts *LogLevelOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantComponent, gotOpts.Component)
+			assert.Equal(t, tt.wantLevel, gotOpts.Level)
+		})
+	}
+}
+
+// testOptions wires a running myapp/dev container and an AdminService mock
+// that records the SetLogLevel request and answers with resp or err.
+func testOptions(t *testing.T, resp *adminv1.SetLogLevelResult, rpcErr error) (*LogLevelOptions, string, **adminv1.SetLogLevelRequest, *bytes.Buffer) {
+	t.Helper()
+	ios, _, _, errOut := iostreams.Test()
+
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	c := mocks.RunningContainerFixture("myapp", "dev")
+	fake.SetupFindContainer("clawker.myapp.dev", c)
+
+	pm := projectmocks.NewMockProjectManager()
+	pm.CurrentProjectFunc = func(context.Context) (project.Project, error) {
+		return projectmocks.NewMockProject("myapp", t.TempDir()), nil
+	}
+
+	var got *adminv1.SetLogLevelRequest
+	admin := &adminv1mocks.AdminServiceClientMock{
+		SetLogLevelFunc: func(_ context.Context, in *adminv1.SetLogLevelRequest, _ ...grpc.CallOption) (*adminv1.SetLogLevelResult, error) {
+			got = in
+			return resp, rpcErr
+		},
+	}
+	return &LogLevelOptions{
+		IOStreams:      ios,
+		Client:         func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		ProjectManager: func() (project.ProjectManager, error) { return pm, nil },
+		AdminClient:    func(context.Context) (adminv1.AdminServiceClient, error) { return admin, nil },
+	}, c.ID, &got, errOut
+}
+
+func TestLogLevelRun_ControlPlane(t *testing.T) {
+	opts, _, got, errOut := testOptions(t, &adminv1.SetLogLevelResult{PreviousLevel: "debug"}, nil)
+	opts.Component, opts.Level = "cp", "warn"
+
+	require.NoError(t, logLevelRun(context.Background(), opts))
+	assert.Equal(t, "warn", (*got).GetLevel())
+	assert.Empty(t, (*got).GetContainerId())
+	assert.Contains(t, errOut.String(), "Control plane log level set to warn (was debug)")
+}
+
+func TestLogLevelRun_Agent(t *testing.T) {
+	opts, id, got, errOut := testOptions(t, &adminv1.SetLogLevelResult{}, nil)
+	opts.Component, opts.Level = "dev", "debug"
+
+	require.NoError(t, logLevelRun(context.Background(), opts))
+	assert.Equal(t, id, (*got).GetContainerId())
+	assert.Equal(t, "myapp", (*got).GetProject(), "the change is scoped to the current project")
+	assert.Contains(t, errOut.String(), "Agent dev log level set to debug")
+}
+
+func TestLogLevelRun_AgentNotConnected(t *testing.T) {
+	opts, _, _, _ := testOptions(t, nil, status.Error(codes.FailedPrecondition, "not connected"))
+	opts.Component, opts.Level = "dev", "debug"
+
+	err := logLevelRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not connected")
+}
@@ -6,9 +6,14 @@ import (
 	"github.com/schmitthub/clawker/internal/cmd/monitor/down"
 	"github.com/schmitthub/clawker/internal/cmd/monitor/extensions"
 	monitorinit "github.com/schmitthub/clawker/internal/cmd/monitor/init"
+	"github.com/schmitthub/clawker/internal/cmd/monitor/loglevel"
 	"github.com/schmitthub/clawker/internal/cmd/monitor/reload"
 	"github.com/schmitthub/clawker/internal/cmd/monitor/status"
+	"github.com/schmitthub/clawker/internal/cmd/monitor/timeline"
+	"github.com/schmitthub/clawker/internal/cmd/monitor/uninstall"
 	"github.com/schmitthub/clawker/internal/cmd/monitor/up"
+	"github.com/schmitthub/clawker/internal/cmd/monitor/upgrade"
+	"github.com/schmitthub/clawker/internal/cmd/monitor/usage"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 )
 
@@ -28,7 +33,12 @@ Available commands:
   up          Start the monitoring stack
   reload      Apply this project's monitoring extensions to the running stack
   down        Stop the monitoring stack
+  upgrade     Upgrade the stack to this clawker version's pinned images and config
+  uninstall   Remove the monitoring stack and its configuration
   status      Show monitoring stack status
+  usage       Report token usage and cost per project and agent
+  timeline    Show where an agent's start-up spent its time
+  loglevel    Change a running daemon's log level without a restart
   extensions  List resolvable monitoring extensions
 
 Monitoring extensions are observability loadouts (OpenSearch index + ingest
@@ -47,7 +57,10 @@ are seeded onto the stack by 'monitor up' (or applied to a running stack by
   clawker monitor status
 
   # Stop the stack
-  clawker monitor down`,
+  clawker monitor down
+
+  # After updating clawker, move the stack to the new pinned versions
+  clawker monitor upgrade`,
 	}
 
 	// TODO: resources need clawker management labels
@@ -55,7 +68,12 @@ are seeded onto the stack by 'monitor up' (or applied to a running stack by
 	cmd.AddCommand(up.NewCmdUp(f, nil))
 	cmd.AddCommand(reload.NewCmdReload(f, nil))
 	cmd.AddCommand(down.NewCmdDown(f, nil))
+	cmd.AddCommand(upgrade.NewCmdUpgrade(f, nil))
+	cmd.AddCommand(uninstall.NewCmdUninstall(f, nil))
 	cmd.AddCommand(status.NewCmdStatus(f, nil))
+	cmd.AddCommand(usage.NewCmdUsage(f, nil))
+	cmd.AddCommand(timeline.NewCmdTimeline(f, nil))
+	cmd.AddCommand(loglevel.NewCmdLogLevel(f, nil))
 	cmd.AddCommand(extensions.NewCmdExtensions(f, nil))
 
 	return cmd
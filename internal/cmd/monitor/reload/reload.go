@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
-	"time"
 
 	"github.com/spf13/cobra"
 
@@ -19,6 +18,7 @@ import (
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
 	internalmonitor "github.com/schmitthub/clawker/internal/monitor"
+	"github.com/schmitthub/clawker/internal/tui"
 )
 
 type ReloadOptions struct {
@@ -27,6 +27,7 @@ type ReloadOptions struct {
 	Config        func() (config.Config, error)
 	Logger        func() (*logger.Logger, error)
 	BundleManager func() (*bundle.Manager, error)
+	TUI           *tui.TUI
 }
 
 func NewCmdReload(f *cmdutil.Factory, runF func(context.Context, *ReloadOptions) error) *cobra.Command {
@@ -36,6 +37,7 @@ func NewCmdReload(f *cmdutil.Factory, runF func(context.Context, *ReloadOptions)
 		Config:        f.Config,
 		Logger:        f.Logger,
 		BundleManager: f.BundleManager,
+		TUI:           f.TUI,
 	}
 
 	cmd := &cobra.Command{
@@ -99,25 +101,31 @@ func reloadRun(ctx context.Context, opts *ReloadOptions) error {
 	if err != nil {
 		return fmt.Errorf("failed to create Docker client: %w", err)
 	}
-	//nolint:exhaustruct // Name is the only required field; the embedded moby NetworkCreateOptions is optional and omitted at every EnsureNetwork call site.
-	if _, err = client.EnsureNetwork(ctx, docker.EnsureNetworkOptions{Name: networkName}); err != nil {
-		return fmt.Errorf("failed to ensure Docker network '%s': %w", networkName, err)
-	}
 
 	// Reload IS the disruptive apply: remove the collector unconditionally so
-	// the compose up recreates it against the just-rendered config.
+	// the compose up recreates it against the just-rendered config. The
+	// projection is recorded once the collector runs the new config.
 	composePath := filepath.Join(monitorDir, internalmonitor.ComposeFileName)
-	if rmErr := shared.RemoveCollector(ctx, ios, log, composePath); rmErr != nil {
-		return fmt.Errorf("failed to remove otel-collector for config reload: %w", rmErr)
+	steps := shared.NewStackSteps(opts.TUI, "Reloading monitoring stack", "Reloaded")
+	err = shared.EnsureNetwork(ctx, steps, client, networkName)
+	if err == nil {
+		if rmErr := shared.RemoveCollector(ctx, steps, log, composePath); rmErr != nil {
+			err = fmt.Errorf("failed to remove otel-collector for config reload: %w", rmErr)
+		}
 	}
-	if composeErr := shared.ComposeUp(ctx, ios, log, composePath, true); composeErr != nil {
-		return fmt.Errorf("failed to start monitoring stack: %w", composeErr)
+	if err == nil {
+		if composeErr := shared.ComposeUp(ctx, steps, log, composePath); composeErr != nil {
+			err = fmt.Errorf("failed to start monitoring stack: %w", composeErr)
+		}
 	}
-
-	// Record the seeded projection now that the bootstrap container has applied
-	// this project's OpenSearch artifacts and the collector runs the new config.
-	if saveErr := internalmonitor.SeedLedger(ctx, monitorDir, cwdUnits, time.Now()); saveErr != nil {
-		return fmt.Errorf("record seeded monitoring units: %w", saveErr)
+	if err == nil {
+		err = shared.SeedLedger(ctx, steps, monitorDir, cwdUnits)
+	}
+	if waitErr := steps.Wait(); err == nil {
+		err = waitErr
+	}
+	if err != nil {
+		return err
 	}
 
 	fmt.Fprintf(ios.ErrOut, "%s Collector reloaded with this project's monitoring extensions.\n", cs.SuccessIcon())
@@ -1,6 +1,6 @@
 // Package shared holds the stack-preparation and compose plumbing common to
 // the monitor subcommands that render and apply the monitoring stack
-// (`monitor up`, `monitor reload`).
+// (`monitor up`, `monitor reload`, `monitor upgrade`).
 package shared
 
 import (
@@ -13,9 +13,11 @@ import (
 
 	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
 	internalmonitor "github.com/schmitthub/clawker/internal/monitor"
+	"github.com/schmitthub/clawker/internal/tui"
 )
 
 // PrepareStack resolves the current projection, merges it into an in-memory
@@ -29,57 +31,90 @@ func PrepareStack(
 	cfg config.Config,
 	monitorDir string,
 ) ([]internalmonitor.ResolvedUnit, internalmonitor.StackRender, error) {
+	cwdUnits, data, err := PrepareStackData(cfg, monitorDir)
+	if err != nil {
+		return nil, internalmonitor.StackRender{}, err
+	}
+	render, err := internalmonitor.RenderStack(monitorDir, data, cwdUnits, true)
+	if err != nil {
+		return nil, internalmonitor.StackRender{}, fmt.Errorf("render monitoring stack config: %w", err)
+	}
+	return cwdUnits, render, nil
+}
+
+// PrepareStackData is PrepareStack without the render: it returns the
+// projection's units and the template data for the merged union, so a caller
+// can inspect what a render would produce (`monitor upgrade`) before writing.
+func PrepareStackData(
+	cfg config.Config,
+	monitorDir string,
+) ([]internalmonitor.ResolvedUnit, internalmonitor.MonitorTemplateData, error) {
 	cwdUnits, err := internalmonitor.ResolveUnits(cfg)
 	if err != nil {
-		return nil, internalmonitor.StackRender{}, fmt.Errorf("resolve monitoring extensions: %w", err)
+		return nil, internalmonitor.MonitorTemplateData{}, fmt.Errorf("resolve monitoring extensions: %w", err)
 	}
 
 	ledger, err := internalmonitor.LoadLedger(monitorDir)
 	if err != nil {
-		return nil, internalmonitor.StackRender{}, fmt.Errorf("load monitoring units ledger: %w", err)
+		return nil, internalmonitor.MonitorTemplateData{}, fmt.Errorf("load monitoring units ledger: %w", err)
 	}
 	// Seed collision is a hard error: a same-named loose extension with different content
 	// from another project refuses to seed rather than clobbering the stack.
 	if mergeErr := ledger.Merge(cwdUnits, time.Now()); mergeErr != nil {
-		return nil, internalmonitor.StackRender{}, fmt.Errorf("seed monitoring extensions: %w", mergeErr)
+		return nil, internalmonitor.MonitorTemplateData{}, fmt.Errorf("seed monitoring extensions: %w", mergeErr)
 	}
 	union := ledger.Union()
 	if validateErr := internalmonitor.ValidateSeededSet(union); validateErr != nil {
-		return nil, internalmonitor.StackRender{}, fmt.Errorf("validate seeded monitoring units: %w", validateErr)
+		return nil, internalmonitor.MonitorTemplateData{}, fmt.Errorf("validate seeded monitoring units: %w", validateErr)
 	}
 
 	data, err := internalmonitor.PrepareTemplateData(cfg.SettingsStore().Read(), union)
 	if err != nil {
-		return nil, internalmonitor.StackRender{}, fmt.Errorf("build monitor template data: %w", err)
-	}
-	render, err := internalmonitor.RenderStack(monitorDir, data, cwdUnits, true)
-	if err != nil {
-		return nil, internalmonitor.StackRender{}, fmt.Errorf("render monitoring stack config: %w", err)
+		return nil, internalmonitor.MonitorTemplateData{}, fmt.Errorf("build monitor template data: %w", err)
 	}
-	return cwdUnits, render, nil
+	return cwdUnits, data, nil
 }
 
 // composeCmd is the docker subcommand every stack operation goes through —
 // the CLI owns the compose lifecycle.
 const composeCmd = "compose"
 
-// ComposeUp brings the stack up with a plain `docker compose up`. It never
-// removes or recreates a service — applying a changed collector config to a
-// running stack is `monitor reload`'s job. The error is returned raw; the
-// caller adds the single contextual wrap.
+// NewStackSteps starts the step display a detached stack operation reports
+// its phases through. Compose output is captured into each step's log rather
+// than streamed, so it only surfaces when a step fails.
+func NewStackSteps(t *tui.TUI, title, verb string) *tui.StepRunner {
+	return t.NewStepRunner("auto", tui.ProgressDisplayConfig{
+		Title:          title,
+		Subtitle:       "monitoring stack",
+		CompletionVerb: verb,
+		LogLines:       5,
+	})
+}
+
+// ComposeUpArgs returns the `docker compose up` invocation for the stack. It
+// never removes or recreates a service — applying a changed collector config
+// to a running stack is `monitor reload`'s job.
+func ComposeUpArgs(composePath string, detach bool) []string {
+	upArgs := []string{composeCmd, "-f", composePath, "up", "--remove-orphans"}
+	if detach {
+		upArgs = append(upArgs, "-d")
+	}
+	return upArgs
+}
+
+// ComposeUp brings the stack up detached as a "Start services" step. The
+// error is returned raw; the caller adds the single contextual wrap.
 func ComposeUp(
 	ctx context.Context,
-	ios *iostreams.IOStreams,
+	steps *tui.StepRunner,
 	log *logger.Logger,
 	composePath string,
-	detach bool,
 ) error {
-	upArgs := []string{composeCmd, "-f", composePath, "up", "--remove-orphans"}
-	if detach {
-		upArgs = append(upArgs, "-d")
-	}
+	upArgs := ComposeUpArgs(composePath, true)
 	log.Debug().Strs("args", upArgs).Msg("running docker compose up")
-	return RunComposeCmd(ctx, ios, upArgs, "Starting monitoring stack...")
+	return steps.Run("compose-up", "Start services", func(step *tui.RunningStep) error {
+		return RunComposeStep(ctx, step, upArgs)
+	})
 }
 
 // RemoveCollector stops and removes the otel-collector service so the next
@@ -90,7 +125,7 @@ func ComposeUp(
 // wrap.
 func RemoveCollector(
 	ctx context.Context,
-	ios *iostreams.IOStreams,
+	steps *tui.StepRunner,
 	log *logger.Logger,
 	composePath string,
 ) error {
@@ -99,7 +134,31 @@ func RemoveCollector(
 		consts.MonitoringServiceOtelCollector,
 	}
 	log.Debug().Strs("args", rmArgs).Msg("removing otel-collector so up recreates it with the current config")
-	return RunComposeCmd(ctx, ios, rmArgs, "Applying updated collector config...")
+	return steps.Run("remove-collector", "Remove otel-collector", func(step *tui.RunningStep) error {
+		return RunComposeStep(ctx, step, rmArgs)
+	})
+}
+
+// EnsureNetwork creates the clawker network if needed, as a step.
+func EnsureNetwork(ctx context.Context, steps *tui.StepRunner, client *docker.Client, networkName string) error {
+	return steps.Run("network", "Ensure network "+networkName, func(*tui.RunningStep) error {
+		//nolint:exhaustruct // Name is the only required field; the embedded moby NetworkCreateOptions is optional and omitted at every EnsureNetwork call site.
+		if _, err := client.EnsureNetwork(ctx, docker.EnsureNetworkOptions{Name: networkName}); err != nil {
+			return fmt.Errorf("failed to ensure Docker network '%s': %w", networkName, err)
+		}
+		return nil
+	})
+}
+
+// SeedLedger records the projection's seeded units as a step, once the stack
+// it was rendered into is up.
+func SeedLedger(ctx context.Context, steps *tui.StepRunner, monitorDir string, units []internalmonitor.ResolvedUnit) error {
+	return steps.Run("seed", "Record monitoring extensions", func(*tui.RunningStep) error {
+		if err := internalmonitor.SeedLedger(ctx, monitorDir, units, time.Now()); err != nil {
+			return fmt.Errorf("record seeded monitoring units: %w", err)
+		}
+		return nil
+	})
 }
 
 // CollectorRunning reports whether the otel-collector service has a running
@@ -165,3 +224,17 @@ func RunComposeCmd(ctx context.Context, ios *iostreams.IOStreams, args []string,
 	//nolint:wrapcheck // raw by design: docker's stderr already streamed; caller adds the single contextual wrap
 	return err
 }
+
+// RunComposeStep runs one docker compose invocation with its output captured
+// into step's log. Errors are returned raw — the failed step shows the tail
+// of compose's output, and the caller adds the one contextual wrap.
+func RunComposeStep(ctx context.Context, step *tui.RunningStep, args []string) error {
+	out := step.LogWriter()
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	err := cmd.Run()
+	_ = out.Close()
+	//nolint:wrapcheck // raw by design: the step log carries compose's output; caller adds the single contextual wrap
+	return err
+}
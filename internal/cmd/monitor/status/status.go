@@ -6,28 +6,40 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
 
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/hostproxy"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
 	internalmonitor "github.com/schmitthub/clawker/internal/monitor"
+	"github.com/schmitthub/clawker/internal/project"
 )
 
 type StatusOptions struct {
-	IOStreams *iostreams.IOStreams
-	Config    func() (config.Config, error)
-	Logger    func() (*logger.Logger, error)
+	IOStreams      *iostreams.IOStreams
+	Config         func() (config.Config, error)
+	Logger         func() (*logger.Logger, error)
+	AdminClient    func(context.Context) (adminv1.AdminServiceClient, error)
+	ProjectManager func() (project.ProjectManager, error)
+
+	JSON bool
 }
 
 func NewCmdStatus(f *cmdutil.Factory, runF func(context.Context, *StatusOptions) error) *cobra.Command {
 	opts := &StatusOptions{
-		IOStreams: f.IOStreams,
-		Config:    f.Config,
-		Logger:    f.Logger,
+		IOStreams:      f.IOStreams,
+		Config:         f.Config,
+		Logger:         f.Logger,
+		AdminClient:    f.AdminClient,
+		ProjectManager: f.ProjectManager,
 	}
 
 	cmd := &cobra.Command{
@@ -35,9 +47,16 @@ func NewCmdStatus(f *cmdutil.Factory, runF func(context.Context, *StatusOptions)
 		Short: "Show monitoring stack status",
 		Long: `Shows the current status of the monitoring stack containers.
 
-Displays running/stopped state and service URLs when the stack is running.`,
+Displays running/stopped state and service URLs when the stack is running,
+plus the socket bridges the host proxy supervises (restarting any that die
+while their container still runs) and the in-container health each of the
+current project's agents reports to the control plane: CPU and memory of
+every process, workspace disk usage, and whether Claude Code is running.`,
 		Example: `  # Check monitoring stack status
-  clawker monitor status`,
+  clawker monitor status
+
+  # Machine-readable output
+  clawker monitor status --json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if runF != nil {
 				return runF(cmd.Context(), opts)
@@ -46,12 +65,81 @@ Displays running/stopped state and service URLs when the stack is running.`,
 		},
 	}
 
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output as versioned JSON envelope")
+	cmdutil.EnableJSONOutput(cmd)
+
 	return cmd
 }
 
+// Stack states reported by monitor status.
+const (
+	stateNotInitialized = "not_initialized"
+	stateStopped        = "stopped"
+	stateRunning        = "running"
+)
+
+// stackStatus is the monitoring stack's state — the --json document and the
+// source of the text output.
+type stackStatus struct {
+	State      string            `json:"state"`
+	Containers []serviceStatus   `json:"containers"`
+	URLs       map[string]string `json:"urls,omitempty"`
+	Network    *networkStatus    `json:"network,omitempty"`
+
+	// Bridges are the host proxy's supervised socket bridges; omitted when
+	// the host proxy is not reachable.
+	Bridges []hostproxy.BridgeStatus `json:"bridges,omitempty"`
+
+	// Agents are the current project's agents registered with the control
+	// plane; omitted when the control plane is not reachable.
+	Agents []agentHealth `json:"agents,omitempty"`
+}
+
+// agentHealth is one agent and its latest self-reported status.
+type agentHealth struct {
+	AgentName   string `json:"agent_name"`
+	ContainerID string `json:"container_id"`
+	// Status is nil until clawkerd's first report reaches the control plane.
+	Status *agentStatus `json:"status,omitempty"`
+}
+
+// agentStatus is the in-container resource usage clawkerd reported.
+type agentStatus struct {
+	ClaudeRunning       bool           `json:"claude_running"`
+	ClaudePID           int32          `json:"claude_pid,omitempty"`
+	CPUPercent          float64        `json:"cpu_percent"`
+	MemoryRSSBytes      uint64         `json:"memory_rss_bytes"`
+	WorkspacePath       string         `json:"workspace_path"`
+	WorkspaceUsedBytes  uint64         `json:"workspace_used_bytes"`
+	WorkspaceTotalBytes uint64         `json:"workspace_total_bytes"`
+	CollectedAt         time.Time      `json:"collected_at"`
+	Processes           []processUsage `json:"processes"`
+}
+
+// processUsage is one process of an agent container.
+type processUsage struct {
+	PID            int32   `json:"pid"`
+	PPID           int32   `json:"ppid"`
+	Command        string  `json:"command"`
+	CPUPercent     float64 `json:"cpu_percent"`
+	MemoryRSSBytes uint64  `json:"memory_rss_bytes"`
+}
+
+// serviceStatus is one compose container as reported by docker compose ps.
+type serviceStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Ports  string `json:"ports"`
+}
+
+// networkStatus reports whether the clawker network exists.
+type networkStatus struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
 func statusRun(ctx context.Context, opts *StatusOptions) error {
 	ios := opts.IOStreams
-	cs := ios.ColorScheme()
 
 	log, err := opts.Logger()
 	if err != nil {
@@ -62,12 +150,29 @@ func statusRun(ctx context.Context, opts *StatusOptions) error {
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
+
+	st, err := collectStatus(ctx, cfg, log)
+	if err != nil {
+		return err
+	}
+	st.Bridges = collectBridges(ctx, cfg, log)
+	st.Agents = collectAgents(ctx, opts, log)
+	if opts.JSON {
+		return ios.JSONPrinter().Print("monitor.status", st)
+	}
+	renderStatus(ios, st)
+	return nil
+}
+
+// collectStatus inspects the compose project and the clawker network.
+func collectStatus(ctx context.Context, cfg config.Config, log *logger.Logger) (stackStatus, error) {
+	st := stackStatus{State: stateNotInitialized, Containers: []serviceStatus{}}
 	networkName := cfg.ClawkerNetwork()
 
 	// Resolve monitor directory
 	monitorDir, err := cfg.MonitorSubdir()
 	if err != nil {
-		return fmt.Errorf("failed to determine monitor directory: %w", err)
+		return st, fmt.Errorf("failed to determine monitor directory: %w", err)
 	}
 
 	log.Debug().Str("monitor_dir", monitorDir).Msg("checking monitor stack status")
@@ -75,10 +180,7 @@ func statusRun(ctx context.Context, opts *StatusOptions) error {
 	// Check if compose.yaml exists
 	composePath := monitorDir + "/" + internalmonitor.ComposeFileName
 	if _, err := os.Stat(composePath); os.IsNotExist(err) {
-		fmt.Fprintf(ios.ErrOut, "Monitoring stack: %s\n", cs.Yellow("NOT INITIALIZED"))
-		fmt.Fprintln(ios.ErrOut)
-		fmt.Fprintln(ios.ErrOut, "Run 'clawker monitor init' to scaffold configuration files.")
-		return nil
+		return st, nil
 	}
 
 	// Run docker compose ps — bound to ctx so Ctrl+C doesn't leave an
@@ -91,65 +193,294 @@ func statusRun(ctx context.Context, opts *StatusOptions) error {
 		composePath,
 		"ps",
 		"--format",
-		"table {{.Name}}\t{{.Status}}\t{{.Ports}}",
+		"{{.Name}}\t{{.Status}}\t{{.Ports}}",
 	)
 	output, err := cmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to get container status: %w", err)
+		return st, fmt.Errorf("failed to get container status: %w", err)
+	}
+	st.Containers = parseServices(string(output))
+
+	st.State = stateStopped
+	for _, c := range st.Containers {
+		if strings.Contains(c.Status, "Up") {
+			st.State = stateRunning
+		}
+	}
+	if st.State != stateRunning {
+		return st, nil
+	}
+
+	// Service URLs for the services that are actually running.
+	mc := cfg.SettingsStore().Read().Monitoring
+	st.URLs = map[string]string{}
+	for _, c := range st.Containers {
+		switch {
+		case strings.Contains(c.Name, consts.MonitoringServiceOpenSearchDashboards):
+			st.URLs["opensearch_dashboards"] = fmt.Sprintf("http://localhost:%d", mc.OpenSearchDashboardsPort)
+		case strings.Contains(c.Name, consts.MonitoringServiceOpenSearchNode):
+			st.URLs["opensearch"] = fmt.Sprintf("http://localhost:%d", mc.OpenSearchPort)
+		case strings.Contains(c.Name, consts.MonitoringServicePrometheus):
+			st.URLs["prometheus"] = fmt.Sprintf("http://localhost:%d", mc.PrometheusPort)
+		}
+	}
+
+	// Check network status. Any non-success collapses to "(not found)"
+	// in the user-visible output — log the underlying err at Debug so a
+	// daemon-down / permission-denied case is recoverable from the CP log
+	// rather than indistinguishable from "no such network".
+	st.Network = &networkStatus{Name: networkName}
+	networkCmd := exec.CommandContext(ctx, "docker", "network", "inspect", networkName, "--format", "{{.Name}}")
+	if networkOutput, err := networkCmd.Output(); err == nil {
+		st.Network.Name = strings.TrimSpace(string(networkOutput))
+		st.Network.Active = true
+	} else {
+		log.Debug().Err(err).Str("network", networkName).Msg("docker network inspect failed; reporting as not found")
+	}
+
+	return st, nil
+}
+
+// collectBridges asks the host proxy for its supervised socket bridges. The
+// host proxy is independent of the monitoring stack and often not running,
+// so any failure yields nil rather than an error.
+func collectBridges(ctx context.Context, cfg config.Config, log *logger.Logger) []hostproxy.BridgeStatus {
+	ctx, cancel := context.WithTimeout(ctx, consts.HostProxyBridgeStatusTimeout)
+	defer cancel()
+	token, err := hostproxy.LoadHostReadToken(cfg)
+	if err != nil {
+		log.Debug().Err(err).Msg("host proxy read token unavailable")
+		return nil
+	}
+	report, err := hostproxy.FetchBridges(ctx, cfg.HostProxyConfig().Manager.Port, token)
+	if err != nil {
+		log.Debug().Err(err).Msg("host proxy bridge status unavailable")
+		return nil
+	}
+	return report.Bridges
+}
+
+// collectAgents asks the control plane for the current project's agents and
+// their latest self-reported status. Like the bridges, the control plane is
+// independent of the monitoring stack, so any failure yields nil.
+func collectAgents(ctx context.Context, opts *StatusOptions, log *logger.Logger) []agentHealth {
+	if opts.AdminClient == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, consts.MonitorAgentStatusTimeout)
+	defer cancel()
+	client, err := opts.AdminClient(ctx)
+	if err != nil {
+		log.Debug().Err(err).Msg("control plane unavailable; skipping agent status")
+		return nil
+	}
+	resp, err := client.ListAgents(ctx, &adminv1.ListAgentsRequest{Project: currentProject(ctx, opts)})
+	if err != nil {
+		log.Debug().Err(err).Msg("ListAgents failed; skipping agent status")
+		return nil
+	}
+	agents := make([]agentHealth, 0, len(resp.GetAgents()))
+	for _, a := range resp.GetAgents() {
+		agents = append(agents, agentHealth{
+			AgentName:   a.GetAgentName(),
+			ContainerID: a.GetContainerId(),
+			Status:      agentStatusFromProto(a.GetStatus()),
+		})
+	}
+	return agents
+}
+
+// currentProject returns the current project's name, the control plane's
+// scope selector; empty outside a project.
+func currentProject(ctx context.Context, opts *StatusOptions) string {
+	if opts.ProjectManager == nil {
+		return ""
+	}
+	pm, err := opts.ProjectManager()
+	if err != nil {
+		return ""
+	}
+	p, err := pm.CurrentProject(ctx)
+	if err != nil {
+		return ""
+	}
+	return p.Name()
+}
+
+// agentStatusFromProto converts a ListAgents status; nil stays nil.
+func agentStatusFromProto(s *adminv1.AgentStatus) *agentStatus {
+	if s == nil {
+		return nil
+	}
+	out := &agentStatus{
+		ClaudeRunning:       s.GetClaudePid() != 0,
+		ClaudePID:           s.GetClaudePid(),
+		CPUPercent:          s.GetCpuPercent(),
+		MemoryRSSBytes:      s.GetMemoryRssBytes(),
+		WorkspacePath:       s.GetWorkspacePath(),
+		WorkspaceUsedBytes:  s.GetWorkspaceUsedBytes(),
+		WorkspaceTotalBytes: s.GetWorkspaceTotalBytes(),
+		CollectedAt:         time.Unix(s.GetCollectedAtUnix(), 0).UTC(),
+		Processes:           make([]processUsage, len(s.GetProcesses())),
+	}
+	for i, p := range s.GetProcesses() {
+		out.Processes[i] = processUsage{
+			PID:            p.GetPid(),
+			PPID:           p.GetPpid(),
+			Command:        p.GetCommand(),
+			CPUPercent:     p.GetCpuPercent(),
+			MemoryRSSBytes: p.GetRssBytes(),
+		}
+	}
+	return out
+}
+
+// parseServices parses tab-separated docker compose ps rows.
+func parseServices(output string) []serviceStatus {
+	services := []serviceStatus{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 3)
+		for len(fields) < 3 {
+			fields = append(fields, "")
+		}
+		services = append(services, serviceStatus{Name: fields[0], Status: fields[1], Ports: fields[2]})
 	}
+	return services
+}
+
+// renderStatus writes the human-readable status to stderr.
+func renderStatus(ios *iostreams.IOStreams, st stackStatus) {
+	renderStack(ios, st)
+	if len(st.Bridges) > 0 {
+		fmt.Fprintln(ios.ErrOut)
+		renderBridges(ios, st.Bridges)
+	}
+	if len(st.Agents) > 0 {
+		fmt.Fprintln(ios.ErrOut)
+		renderAgents(ios, st.Agents)
+	}
+}
 
-	outputStr := strings.TrimSpace(string(output))
+// renderStack writes the monitoring stack section.
+func renderStack(ios *iostreams.IOStreams, st stackStatus) {
+	cs := ios.ColorScheme()
 
-	if outputStr == "" || !strings.Contains(outputStr, "Up") {
+	switch st.State {
+	case stateNotInitialized:
+		fmt.Fprintf(ios.ErrOut, "Monitoring stack: %s\n", cs.Yellow("NOT INITIALIZED"))
+		fmt.Fprintln(ios.ErrOut)
+		fmt.Fprintln(ios.ErrOut, "Run 'clawker monitor init' to scaffold configuration files.")
+		return
+	case stateStopped:
 		fmt.Fprintf(ios.ErrOut, "Monitoring stack: %s\n", cs.Red("STOPPED"))
 		fmt.Fprintln(ios.ErrOut)
 		fmt.Fprintln(ios.ErrOut, "Run 'clawker monitor up' to start the stack.")
-		return nil
+		return
 	}
 
 	fmt.Fprintf(ios.ErrOut, "Monitoring stack: %s\n", cs.Green("RUNNING"))
 	fmt.Fprintln(ios.ErrOut)
 	fmt.Fprintln(ios.ErrOut, "Containers:")
-	fmt.Fprintln(ios.ErrOut, outputStr)
+	tw := tabwriter.NewWriter(ios.ErrOut, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSTATUS\tPORTS")
+	for _, c := range st.Containers {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", c.Name, c.Status, c.Ports)
+	}
+	_ = tw.Flush()
 	fmt.Fprintln(ios.ErrOut)
 
-	// Check which services are actually running and print relevant URLs
-	mc := cfg.SettingsStore().Read().Monitoring
 	fmt.Fprintln(ios.ErrOut, "Service URLs:")
-	if strings.Contains(outputStr, consts.MonitoringServiceOpenSearchDashboards) {
-		fmt.Fprintf(
-			ios.ErrOut,
-			"  OpenSearch Dashboards: %s\n",
-			cs.Cyan(fmt.Sprintf("http://localhost:%d", mc.OpenSearchDashboardsPort)),
-		)
-	}
-	if strings.Contains(outputStr, consts.MonitoringServiceOpenSearchNode) {
-		fmt.Fprintf(
-			ios.ErrOut,
-			"  OpenSearch API:        %s\n",
-			cs.Cyan(fmt.Sprintf("http://localhost:%d", mc.OpenSearchPort)),
-		)
-	}
-	if strings.Contains(outputStr, consts.MonitoringServicePrometheus) {
-		fmt.Fprintf(
-			ios.ErrOut,
-			"  Prometheus:            %s\n",
-			cs.Cyan(fmt.Sprintf("http://localhost:%d", mc.PrometheusPort)),
-		)
+	if u, ok := st.URLs["opensearch_dashboards"]; ok {
+		fmt.Fprintf(ios.ErrOut, "  OpenSearch Dashboards: %s\n", cs.Cyan(u))
+	}
+	if u, ok := st.URLs["opensearch"]; ok {
+		fmt.Fprintf(ios.ErrOut, "  OpenSearch API:        %s\n", cs.Cyan(u))
+	}
+	if u, ok := st.URLs["prometheus"]; ok {
+		fmt.Fprintf(ios.ErrOut, "  Prometheus:            %s\n", cs.Cyan(u))
 	}
 
-	// Check network status. Any non-success collapses to "(not found)"
-	// in the user-visible output — log the underlying err at Debug so a
-	// daemon-down / permission-denied case is recoverable from the CP log
-	// rather than indistinguishable from "no such network".
 	fmt.Fprintln(ios.ErrOut)
-	networkCmd := exec.CommandContext(ctx, "docker", "network", "inspect", networkName, "--format", "{{.Name}}")
-	if networkOutput, err := networkCmd.Output(); err == nil {
-		fmt.Fprintf(ios.ErrOut, "Network: %s %s\n", strings.TrimSpace(string(networkOutput)), cs.Green("(active)"))
+	if st.Network.Active {
+		fmt.Fprintf(ios.ErrOut, "Network: %s %s\n", st.Network.Name, cs.Green("(active)"))
 	} else {
-		log.Debug().Err(err).Str("network", networkName).Msg("docker network inspect failed; reporting as not found")
-		fmt.Fprintf(ios.ErrOut, "Network: %s %s\n", networkName, cs.Red("(not found)"))
+		fmt.Fprintf(ios.ErrOut, "Network: %s %s\n", st.Network.Name, cs.Red("(not found)"))
 	}
+}
 
-	return nil
+// renderBridges writes the supervised socket bridge table.
+func renderBridges(ios *iostreams.IOStreams, bridges []hostproxy.BridgeStatus) {
+	cs := ios.ColorScheme()
+	fmt.Fprintln(ios.ErrOut, "Socket bridges:")
+	tw := tabwriter.NewWriter(ios.ErrOut, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "CONTAINER\tSTATE\tPID\tRESTARTS\tLAST EXIT")
+	for _, b := range bridges {
+		state := cs.Green(b.State)
+		if b.State != hostproxy.BridgeRunning {
+			state = cs.Yellow(b.State)
+		}
+		pid := "-"
+		if b.PID > 0 {
+			pid = fmt.Sprint(b.PID)
+		}
+		lastExit := "-"
+		if !b.LastExit.IsZero() {
+			lastExit = b.LastExit.Local().Format(time.TimeOnly)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", shortID(b.ContainerID), state, pid, b.Restarts, lastExit)
+	}
+	_ = tw.Flush()
+}
+
+// renderAgents writes the agent health table, then each reporting agent's
+// per-process breakdown.
+func renderAgents(ios *iostreams.IOStreams, agents []agentHealth) {
+	cs := ios.ColorScheme()
+	fmt.Fprintln(ios.ErrOut, "Agents:")
+	tw := tabwriter.NewWriter(ios.ErrOut, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "AGENT\tCONTAINER\tCLAUDE\tCPU\tMEMORY\tWORKSPACE\tREPORTED")
+	for _, a := range agents {
+		s := a.Status
+		if s == nil {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t-\t-\t-\t-\n", a.AgentName, shortID(a.ContainerID), cs.Yellow("no report"))
+			continue
+		}
+		claude := cs.Green("running")
+		if !s.ClaudeRunning {
+			claude = cs.Yellow("not running")
+		}
+		workspace := "-"
+		if s.WorkspaceTotalBytes > 0 {
+			workspace = fmt.Sprintf("%s / %s", units.BytesSize(float64(s.WorkspaceUsedBytes)), units.BytesSize(float64(s.WorkspaceTotalBytes)))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.1f%%\t%s\t%s\t%s\n",
+			a.AgentName, shortID(a.ContainerID), claude, s.CPUPercent,
+			units.BytesSize(float64(s.MemoryRSSBytes)), workspace, s.CollectedAt.Local().Format(time.TimeOnly))
+	}
+	_ = tw.Flush()
+
+	for _, a := range agents {
+		if a.Status == nil || len(a.Status.Processes) == 0 {
+			continue
+		}
+		fmt.Fprintln(ios.ErrOut)
+		fmt.Fprintf(ios.ErrOut, "Processes (%s):\n", a.AgentName)
+		tw := tabwriter.NewWriter(ios.ErrOut, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(tw, "PID\tPPID\tCOMMAND\tCPU\tMEMORY")
+		for _, p := range a.Status.Processes {
+			fmt.Fprintf(tw, "%d\t%d\t%s\t%.1f%%\t%s\n", p.PID, p.PPID, p.Command, p.CPUPercent, units.BytesSize(float64(p.MemoryRSSBytes)))
+		}
+		_ = tw.Flush()
+	}
+}
+
+// shortID truncates a container ID to the usual 12 characters.
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
 }
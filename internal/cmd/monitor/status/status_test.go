@@ -2,9 +2,17 @@ package status
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
+	"google.golang.org/grpc"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	adminv1mocks "github.com/schmitthub/clawker/api/admin/v1/mocks"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
 )
@@ -35,3 +43,98 @@ func TestNewCmdStatus(t *testing.T) {
 		t.Error("expected IOStreams to be set from factory")
 	}
 }
+
+func TestStatusRun_JSONNotInitialized(t *testing.T) {
+	tio, _, out, errOut := iostreams.Test()
+	cfg := configmocks.NewBlankConfig()
+	monitorDir := t.TempDir()
+	cfg.MonitorSubdirFunc = func() (string, error) { return monitorDir, nil }
+
+	opts := &StatusOptions{
+		IOStreams: tio,
+		Config:    func() (config.Config, error) { return cfg, nil },
+		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
+		JSON:      true,
+	}
+	if err := statusRun(context.Background(), opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `{"schema_version":1,"kind":"monitor.status","data":{"state":"not_initialized","containers":[]}}` + "\n"
+	if out.String() != want {
+		t.Errorf("stdout = %q, want %q", out.String(), want)
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("unexpected stderr: %q", errOut.String())
+	}
+}
+
+func TestParseServices(t *testing.T) {
+	out := "clawker-monitor-prometheus-1\tUp 2 hours\t0.0.0.0:9090->9090/tcp\nclawker-monitor-otel-collector-1\tExited (1)\t\n"
+	got := parseServices(out)
+	if len(got) != 2 {
+		t.Fatalf("got %d services, want 2", len(got))
+	}
+	if got[0].Name != "clawker-monitor-prometheus-1" || !strings.HasPrefix(got[0].Status, "Up") || got[0].Ports == "" {
+		t.Errorf("services[0] = %+v", got[0])
+	}
+	if got[1].Status != "Exited (1)" || got[1].Ports != "" {
+		t.Errorf("services[1] = %+v", got[1])
+	}
+	if len(parseServices("")) != 0 {
+		t.Error("empty output should parse to no services")
+	}
+}
+
+func TestCollectAgents_RendersReportedStatus(t *testing.T) {
+	tio, _, _, errOut := iostreams.Test()
+	var gotReq *adminv1.ListAgentsRequest
+	client := &adminv1mocks.AdminServiceClientMock{
+		ListAgentsFunc: func(_ context.Context, req *adminv1.ListAgentsRequest, _ ...grpc.CallOption) (*adminv1.ListAgentsResult, error) {
+			gotReq = req
+			return &adminv1.ListAgentsResult{Agents: []*adminv1.Agent{
+				{AgentName: "dev", ContainerId: "0123456789abcdef", Status: &adminv1.AgentStatus{
+					Processes:           []*adminv1.AgentProcess{{Pid: 7, Ppid: 1, Command: "claude", RssBytes: 1 << 20, CpuPercent: 25}},
+					MemoryRssBytes:      1 << 20,
+					CpuPercent:          25,
+					WorkspaceUsedBytes:  1 << 30,
+					WorkspaceTotalBytes: 4 << 30,
+					ClaudePid:           7,
+					CollectedAtUnix:     1000,
+				}},
+				{AgentName: "idle", ContainerId: "fedcba9876543210"},
+			}}, nil
+		},
+	}
+	opts := &StatusOptions{
+		IOStreams:   tio,
+		AdminClient: func(context.Context) (adminv1.AdminServiceClient, error) { return client, nil },
+	}
+
+	agents := collectAgents(context.Background(), opts, logger.Nop())
+	if gotReq == nil || gotReq.GetProject() != "" || gotReq.GetAllProjects() {
+		t.Fatalf("ListAgents request = %+v, want the (empty) current project", gotReq)
+	}
+	if len(agents) != 2 || agents[1].Status != nil {
+		t.Fatalf("agents = %+v", agents)
+	}
+	if s := agents[0].Status; s == nil || !s.ClaudeRunning || len(s.Processes) != 1 || s.Processes[0].MemoryRSSBytes != 1<<20 {
+		t.Fatalf("status = %+v", agents[0].Status)
+	}
+
+	renderAgents(tio, agents)
+	for _, want := range []string{"0123456789ab", "running", "25.0%", "1GiB / 4GiB", "no report", "Processes (dev):", "claude"} {
+		if !strings.Contains(errOut.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, errOut.String())
+		}
+	}
+}
+
+func TestCollectAgents_ControlPlaneDown(t *testing.T) {
+	opts := &StatusOptions{
+		AdminClient: func(context.Context) (adminv1.AdminServiceClient, error) { return nil, errors.New("not running") },
+	}
+	if agents := collectAgents(context.Background(), opts, logger.Nop()); agents != nil {
+		t.Errorf("agents = %+v, want nil", agents)
+	}
+}
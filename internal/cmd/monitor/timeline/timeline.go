@@ -0,0 +1,487 @@
+// Package timeline provides the monitor timeline command.
+package timeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+// OpenSearch indices the timeline reads. The control plane's zerolog records
+// (Docker events it observed, executor plan progress) land in logsIndex; agent
+// spans land in the SS4O traces index named by the collector's
+// dataset/namespace (see otel-config.yaml.tmpl).
+const (
+	logsIndex   = "clawkercp"
+	tracesIndex = "ss4o_traces-traces-clawker"
+
+	// maxLogHits bounds the control-plane records fetched for one container.
+	maxLogHits = 1000
+)
+
+// Timeline sources.
+const (
+	sourceContainer = "container"
+	sourceCP        = "cp"
+	sourceSpan      = "span"
+)
+
+// planLabels are the executor plans whose progress the control plane logs as
+// agent_<label>_* events (see controlplane/agent/dialer.go).
+var planLabels = []string{"init", "boot"}
+
+// lifecycleActions are the Docker container actions shown on the timeline.
+// Exec, attach and health events are noise for a start-up breakdown.
+var lifecycleActions = []string{
+	"create", "start", "restart", "pause", "unpause", "oom", "kill", "die", "stop", "destroy",
+}
+
+// TimelineOptions holds options for the timeline command.
+type TimelineOptions struct {
+	IOStreams      *iostreams.IOStreams
+	TUI            *tui.TUI
+	Config         func() (config.Config, error)
+	Client         func(context.Context) (*docker.Client, error)
+	ProjectManager func() (project.ProjectManager, error)
+	HttpClient     func() (*http.Client, error)
+
+	Format *cmdutil.FormatFlags
+	Agent  string
+	Spans  int
+}
+
+// NewCmdTimeline creates the monitor timeline command.
+func NewCmdTimeline(f *cmdutil.Factory, runF func(context.Context, *TimelineOptions) error) *cobra.Command {
+	opts := &TimelineOptions{
+		IOStreams:      f.IOStreams,
+		TUI:            f.TUI,
+		Config:         f.Config,
+		Client:         f.Client,
+		ProjectManager: f.ProjectManager,
+		HttpClient:     f.HttpClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "timeline AGENT",
+		Short: "Show where an agent's start-up spent its time",
+		Long: `Shows a chronological view of an agent session: container lifecycle
+events, the control plane's init and boot steps with their durations, and
+the spans the agent exported, all offset from container creation.
+
+Lifecycle and step events come from the control plane's log index and the
+spans from the traces index in the monitoring stack's OpenSearch. When the
+stack is down, only the container's created and started times are shown.
+
+The agent is resolved in the current project, like the --agent flag of
+other commands.`,
+		Example: `  # Timeline for agent dev
+  clawker monitor timeline dev
+
+  # Include up to 200 spans
+  clawker monitor timeline dev --spans 200
+
+  # Machine-readable output
+  clawker monitor timeline dev --json`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Agent = args[0]
+			if opts.Spans < 0 {
+				return cmdutil.FlagErrorf("--spans must not be negative")
+			}
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return timelineRun(cmd.Context(), opts)
+		},
+	}
+
+	opts.Format = cmdutil.AddFormatFlags(cmd)
+	cmd.Flags().IntVar(&opts.Spans, "spans", 50, "Maximum number of spans to include (0 to skip spans)")
+
+	return cmd
+}
+
+// timelineRow is the data structure exposed to --format templates and --json.
+type timelineRow struct {
+	Time       time.Time `json:"time"`
+	OffsetMS   int64     `json:"offset_ms"`
+	Source     string    `json:"source"`
+	Event      string    `json:"event"`
+	DurationMS float64   `json:"duration_ms,omitempty"`
+}
+
+func timelineRun(ctx context.Context, opts *TimelineOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	var projectName string
+	if opts.ProjectManager != nil {
+		if pm, pmErr := opts.ProjectManager(); pmErr == nil {
+			if p, pErr := pm.CurrentProject(ctx); pErr == nil {
+				projectName = p.Name()
+			}
+		}
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+	containerName, ctr, err := client.FindContainerByAgent(ctx, projectName, opts.Agent)
+	if err != nil {
+		return err
+	}
+	if ctr == nil {
+		return fmt.Errorf("container %q not found", containerName)
+	}
+	info, err := client.ContainerInspect(ctx, ctr.ID, whail.ContainerInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("inspecting %s: %w", containerName, err)
+	}
+	created, err := time.Parse(time.RFC3339Nano, info.Container.Created)
+	if err != nil {
+		return fmt.Errorf("parsing creation time of %s: %w", containerName, err)
+	}
+
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return fmt.Errorf("creating HTTP client: %w", err)
+	}
+	// Host-side access through the published OpenSearch port.
+	search := &osClient{
+		http:    httpClient,
+		baseURL: fmt.Sprintf("http://localhost:%d", cfg.MonitoringConfig().OpenSearchPort),
+	}
+
+	// Records are stamped by the collector after the event, so a small
+	// margin keeps the create event inside the window.
+	since := created.Add(-time.Second)
+
+	var rows []timelineRow
+	logs, err := search.logs(ctx, ctr.ID, since)
+	switch {
+	case errors.Is(err, errUnreachable):
+		fmt.Fprintf(ios.ErrOut, "%s %v; showing container times only\n", cs.WarningIcon(), err)
+	case err != nil:
+		return err
+	default:
+		rows = append(rows, logRows(logs, ctr.ID)...)
+		if opts.Spans > 0 {
+			spans, err := search.spans(ctx, projectName, opts.Agent, since, opts.Spans)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, spanRows(spans, projectName, opts.Agent)...)
+		}
+	}
+	if !slices.ContainsFunc(rows, func(r timelineRow) bool { return r.Source == sourceContainer }) {
+		rows = append(rows, inspectRows(created, info.Container.State)...)
+	}
+
+	slices.SortStableFunc(rows, func(a, b timelineRow) int { return a.Time.Compare(b.Time) })
+	for i := range rows {
+		rows[i].OffsetMS = rows[i].Time.Sub(created).Milliseconds()
+	}
+
+	switch {
+	case opts.Format.IsJSON():
+		return opts.Format.WriteJSON(ios, "monitor.timeline", rows)
+	case opts.Format.IsTemplate():
+		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(rows))
+	}
+
+	tp := opts.TUI.NewTable("TIME", "OFFSET", "SOURCE", "EVENT", "DURATION")
+	for _, r := range rows {
+		tp.AddRow(r.Time.Local().Format("15:04:05.000"), fmtOffset(r.OffsetMS), r.Source, r.Event, fmtDuration(r.DurationMS))
+	}
+	return tp.Render()
+}
+
+// inspectRows synthesizes the lifecycle rows the Docker daemon still
+// remembers, for when the control plane's record of them is unavailable.
+func inspectRows(created time.Time, state *container.State) []timelineRow {
+	rows := []timelineRow{{Time: created, Source: sourceContainer, Event: "create"}}
+	if state == nil {
+		return rows
+	}
+	if started, err := time.Parse(time.RFC3339Nano, state.StartedAt); err == nil && !started.Before(created) {
+		rows = append(rows, timelineRow{Time: started, Source: sourceContainer, Event: "start"})
+	}
+	if finished, err := time.Parse(time.RFC3339Nano, state.FinishedAt); err == nil && !finished.Before(created) && !state.Running {
+		rows = append(rows, timelineRow{Time: finished, Source: sourceContainer, Event: fmt.Sprintf("exited (%d)", state.ExitCode)})
+	}
+	return rows
+}
+
+// logDoc is the subset of a clawkercp record the timeline reads.
+type logDoc struct {
+	Timestamp  time.Time `json:"@timestamp"`
+	Attributes struct {
+		Event       string  `json:"event"`
+		ContainerID string  `json:"container_id"`
+		Source      string  `json:"source"`
+		Type        string  `json:"type"`
+		Action      string  `json:"action"`
+		ActorID     string  `json:"actor_id"`
+		TimeNano    int64   `json:"time_nano"`
+		Step        string  `json:"step"`
+		StepCount   int     `json:"step_count"`
+		Duration    float64 `json:"duration"`
+		Reason      string  `json:"reason"`
+	} `json:"attributes"`
+}
+
+// logRows turns control-plane records into timeline rows. The query matches
+// on analyzed fields as well as keywords, so the container ID is re-checked
+// here.
+func logRows(docs []logDoc, containerID string) []timelineRow {
+	var rows []timelineRow
+	for _, d := range docs {
+		a := d.Attributes
+		if a.Source == "docker" {
+			if a.Type != "container" || a.ActorID != containerID || !slices.Contains(lifecycleActions, a.Action) {
+				continue
+			}
+			ts := d.Timestamp
+			if a.TimeNano > 0 {
+				ts = time.Unix(0, a.TimeNano)
+			}
+			rows = append(rows, timelineRow{Time: ts, Source: sourceContainer, Event: a.Action})
+			continue
+		}
+		if a.ContainerID != containerID {
+			continue
+		}
+		if a.Event == "agent_registered" {
+			rows = append(rows, timelineRow{Time: d.Timestamp, Source: sourceCP, Event: "registered"})
+			continue
+		}
+		if r, ok := planRow(d); ok {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// planRow maps an agent_<label>_<phase> executor record to a row sourced
+// from its plan label. Step starts are dropped: the completion row carries
+// the step's duration.
+func planRow(d logDoc) (timelineRow, bool) {
+	a := d.Attributes
+	rest, ok := strings.CutPrefix(a.Event, "agent_")
+	if !ok {
+		return timelineRow{}, false
+	}
+	for _, label := range planLabels {
+		phase, ok := strings.CutPrefix(rest, label+"_")
+		if !ok {
+			continue
+		}
+		r := timelineRow{Time: d.Timestamp, Source: label}
+		switch phase {
+		case "started":
+			r.Event = fmt.Sprintf("started (%d steps)", a.StepCount)
+		case "step_completed":
+			r.Event = a.Step
+			r.DurationMS = a.Duration
+		case "completed":
+			r.Event = "completed"
+			r.DurationMS = a.Duration
+		case "failed":
+			r.Event = "failed at " + a.Step
+			if a.Reason != "" {
+				r.Event += ": " + a.Reason
+			}
+		case "panic":
+			r.Event = "panicked at " + a.Step
+		default:
+			return timelineRow{}, false
+		}
+		return r, true
+	}
+	return timelineRow{}, false
+}
+
+// spanDoc is the subset of an SS4O span document the timeline reads.
+// Resource attributes are stored flat under resource.
+type spanDoc struct {
+	Name      string            `json:"name"`
+	StartTime time.Time         `json:"startTime"`
+	EndTime   time.Time         `json:"endTime"`
+	Resource  map[string]string `json:"resource"`
+}
+
+func spanRows(docs []spanDoc, projectName, agent string) []timelineRow {
+	var rows []timelineRow
+	for _, d := range docs {
+		if d.Resource["agent"] != agent || d.Resource["project"] != projectName {
+			continue
+		}
+		r := timelineRow{Time: d.StartTime, Source: sourceSpan, Event: d.Name}
+		if d.EndTime.After(d.StartTime) {
+			r.DurationMS = float64(d.EndTime.Sub(d.StartTime).Microseconds()) / 1000
+		}
+		rows = append(rows, r)
+	}
+	return rows
+}
+
+// fmtOffset renders an offset from container creation, e.g. +1.250s.
+func fmtOffset(ms int64) string {
+	sign := "+"
+	if ms < 0 {
+		sign = "-"
+		ms = -ms
+	}
+	return fmt.Sprintf("%s%d.%03ds", sign, ms/1000, ms%1000)
+}
+
+func fmtDuration(ms float64) string {
+	if ms <= 0 {
+		return ""
+	}
+	d := time.Duration(ms * float64(time.Millisecond))
+	if d >= time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Microsecond).String()
+}
+
+// errUnreachable marks a transport failure reaching OpenSearch, as opposed to
+// an error response from it.
+var errUnreachable = errors.New("OpenSearch is not reachable (is the monitoring stack up? run 'clawker monitor up')")
+
+// osClient runs searches against the OpenSearch HTTP API.
+type osClient struct {
+	http    *http.Client
+	baseURL string
+}
+
+// anyTerm matches docs whose field equals value either as a keyword or via
+// its dynamically mapped .keyword subfield.
+func anyTerm(field, value string) map[string]any {
+	return map[string]any{"bool": map[string]any{
+		"should": []any{
+			map[string]any{"term": map[string]any{field: value}},
+			map[string]any{"term": map[string]any{field + ".keyword": value}},
+		},
+		"minimum_should_match": 1,
+	}}
+}
+
+// logs fetches the clawkercp records about containerID: executor records
+// carry it as container_id, Docker events as actor_id.
+func (c *osClient) logs(ctx context.Context, containerID string, since time.Time) ([]logDoc, error) {
+	query := map[string]any{
+		"size": maxLogHits,
+		"sort": []any{map[string]any{"@timestamp": map[string]any{"order": "asc", "unmapped_type": "date"}}},
+		"query": map[string]any{"bool": map[string]any{
+			"filter": []any{
+				map[string]any{"range": map[string]any{"@timestamp": map[string]any{"gte": since.UTC().Format(time.RFC3339Nano)}}},
+			},
+			"should": []any{
+				anyTerm("attributes.container_id", containerID),
+				anyTerm("attributes.actor_id", containerID),
+			},
+			"minimum_should_match": 1,
+		}},
+	}
+	var docs []logDoc
+	if err := c.search(ctx, logsIndex, query, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// spans fetches the earliest limit spans the agent exported since since.
+func (c *osClient) spans(ctx context.Context, projectName, agent string, since time.Time, limit int) ([]spanDoc, error) {
+	query := map[string]any{
+		"size": limit,
+		"sort": []any{map[string]any{"startTime": map[string]any{"order": "asc", "unmapped_type": "date"}}},
+		"query": map[string]any{"bool": map[string]any{
+			"filter": []any{
+				map[string]any{"range": map[string]any{"startTime": map[string]any{"gte": since.UTC().Format(time.RFC3339Nano)}}},
+				anyTerm("resource.agent", agent),
+				anyTerm("resource.project", projectName),
+			},
+		}},
+	}
+	var docs []spanDoc
+	if err := c.search(ctx, tracesIndex, query, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// searchResponse is the _search envelope; only hit sources are read.
+type searchResponse struct {
+	Hits struct {
+		Hits []struct {
+			Source json.RawMessage `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// search runs query against index and decodes each hit's _source into out,
+// which must point to a slice. A missing index yields no hits.
+func (c *osClient) search(ctx context.Context, index string, query map[string]any, out any) error {
+	body, err := json.Marshal(query)
+	if err != nil {
+		return fmt.Errorf("encoding OpenSearch query: %w", err)
+	}
+	u := fmt.Sprintf("%s/%s/_search?ignore_unavailable=true", c.baseURL, url.PathEscape(index))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building OpenSearch query: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUnreachable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("searching %s: HTTP %d: %s", index, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	var sr searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&sr); err != nil {
+		return fmt.Errorf("decoding %s search response: %w", index, err)
+	}
+	sources := make([]json.RawMessage, 0, len(sr.Hits.Hits))
+	for _, h := range sr.Hits.Hits {
+		sources = append(sources, h.Source)
+	}
+	raw, err := json.Marshal(sources)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("decoding %s documents: %w", index, err)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package timeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+)
+
+func TestNewCmdTimeline(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantAgent string
+		wantSpans int
+		wantJSON  bool
+		wantErr   bool
+	}{
+		{name: "agent", input: "dev", wantAgent: "dev", wantSpans: 50},
+		{name: "spans", input: "dev --spans 5", wantAgent: "dev", wantSpans: 5},
+		{name: "json", input: "dev --json", wantAgent: "dev", wantSpans: 50, wantJSON: true},
+		{name: "no agent", input: "", wantErr: true},
+		{name: "negative spans", input: "dev --spans -1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *TimelineOptions
+			cmd := NewCmdTimeline(f, func(_ context.Context, opts *TimelineOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAgent, gotOpts.Agent)
+			assert.Equal(t, tt.wantSpans, gotOpts.Spans)
+			assert.Equal(t, tt.wantJSON, gotOpts.Format.IsJSON())
+		})
+	}
+}
+
+// osStub serves canned _search hits keyed by index name.
+func osStub(t *testing.T, hits map[string][]string) int {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		index := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), "/_search")
+		var wrapped []string
+		for _, h := range hits[index] {
+			wrapped = append(wrapped, `{"_source":`+h+`}`)
+		}
+		fmt.Fprintf(w, `{"hits":{"hits":[%s]}}`, strings.Join(wrapped, ","))
+	}))
+	t.Cleanup(srv.Close)
+	return serverPort(t, srv)
+}
+
+func serverPort(t *testing.T, srv *httptest.Server) int {
+	t.Helper()
+	_, p, err := net.SplitHostPort(strings.TrimPrefix(srv.URL, "http://"))
+	require.NoError(t, err)
+	var port int
+	_, err = fmt.Sscan(p, &port)
+	require.NoError(t, err)
+	return port
+}
+
+// testFactory wires a running myapp/dev container created at 12:00:00 UTC
+// and an OpenSearch on port.
+func testFactory(t *testing.T, port int) (*cmdutil.Factory, string, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	cfg := configmocks.NewFromString("", fmt.Sprintf("monitoring:\n  opensearch_port: %d\n", port))
+
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	c := mocks.RunningContainerFixture("myapp", "dev")
+	fake.SetupFindContainer("clawker.myapp.dev", c)
+	fake.FakeAPI.ContainerInspectFn = func(context.Context, string, client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		return client.ContainerInspectResult{Container: container.InspectResponse{
+			ID:      c.ID,
+			Created: "2026-03-10T12:00:00Z",
+			Config:  &container.Config{Labels: c.Labels},
+			State:   &container.State{Running: true, StartedAt: "2026-03-10T12:00:00.5Z"},
+		}}, nil
+	}
+
+	pm := projectmocks.NewMockProjectManager()
+	pm.CurrentProjectFunc = func(context.Context) (project.Project, error) {
+		return projectmocks.NewMockProject("myapp", t.TempDir()), nil
+	}
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithConfig(cfg), cmdutiltest.WithFakeClient(fake))
+	tf.ProjectManager = func() (project.ProjectManager, error) { return pm, nil }
+	tf.HttpClient = func() (*http.Client, error) { return http.DefaultClient, nil }
+	return tf.Factory, c.ID, tf.Out, tf.ErrOut
+}
+
+func runTimeline(t *testing.T, f *cmdutil.Factory, args ...string) error {
+	t.Helper()
+	cmd := NewCmdTimeline(f, nil)
+	cmd.SetArgs(args)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	_, err := cmd.ExecuteC()
+	return err
+}
+
+func dockerEvent(id, action, ts string) string {
+	return fmt.Sprintf(`{"@timestamp":%q,"attributes":{"source":"docker","type":"container","action":%q,"actor_id":%q}}`, ts, action, id)
+}
+
+func cpEvent(id, event, ts, extra string) string {
+	return fmt.Sprintf(`{"@timestamp":%q,"attributes":{"event":%q,"container_id":%q%s}}`, ts, event, id, extra)
+}
+
+func TestTimelineRun_MergesSources(t *testing.T) {
+	f, id, out, _ := testFactory(t, 0)
+	port := osStub(t, map[string][]string{
+		logsIndex: {
+			dockerEvent(id, "create", "2026-03-10T12:00:00Z"),
+			dockerEvent(id, "start", "2026-03-10T12:00:00.5Z"),
+			dockerEvent(id, "exec_start: bash", "2026-03-10T12:00:01Z"),
+			dockerEvent("other", "die", "2026-03-10T12:00:01Z"),
+			cpEvent(id, "agent_registered", "2026-03-10T12:00:01Z", ""),
+			cpEvent(id, "agent_init_started", "2026-03-10T12:00:01.1Z", `,"step_count":2`),
+			cpEvent(id, "agent_init_step_started", "2026-03-10T12:00:01.1Z", `,"step":"firewall"`),
+			cpEvent(id, "agent_init_step_completed", "2026-03-10T12:00:03.1Z", `,"step":"firewall","duration":2000`),
+			cpEvent(id, "agent_init_completed", "2026-03-10T12:00:04Z", `,"duration":2900`),
+			cpEvent("other", "agent_boot_started", "2026-03-10T12:00:04Z", `,"step_count":1`),
+		},
+		tracesIndex: {
+			`{"name":"claude_code.interaction","startTime":"2026-03-10T12:00:05Z","endTime":"2026-03-10T12:00:06.25Z","resource":{"project":"myapp","agent":"dev"}}`,
+			`{"name":"claude_code.interaction","startTime":"2026-03-10T12:00:05Z","endTime":"2026-03-10T12:00:06Z","resource":{"project":"other","agent":"dev"}}`,
+		},
+	})
+	cfg := configmocks.NewFromString("", fmt.Sprintf("monitoring:\n  opensearch_port: %d\n", port))
+	f.Config = func() (config.Config, error) { return cfg, nil }
+
+	require.NoError(t, runTimeline(t, f, "dev", "--format", "json"))
+
+	var rows []timelineRow
+	require.NoError(t, json.Unmarshal(out.Bytes(), &rows))
+	type summary struct {
+		Offset   int64
+		Source   string
+		Event    string
+		Duration float64
+	}
+	var got []summary
+	for _, r := range rows {
+		got = append(got, summary{r.OffsetMS, r.Source, r.Event, r.DurationMS})
+	}
+	assert.Equal(t, []summary{
+		{0, "container", "create", 0},
+		{500, "container", "start", 0},
+		{1000, "cp", "registered", 0},
+		{1100, "init", "started (2 steps)", 0},
+		{3100, "init", "firewall", 2000},
+		{4000, "init", "completed", 2900},
+		{5000, "span", "claude_code.interaction", 1250},
+	}, got)
+}
+
+func TestTimelineRun_Table(t *testing.T) {
+	f, id, out, _ := testFactory(t, 0)
+	port := osStub(t, map[string][]string{
+		logsIndex: {
+			dockerEvent(id, "create", "2026-03-10T12:00:00Z"),
+			cpEvent(id, "agent_init_step_completed", "2026-03-10T12:00:03.1Z", `,"step":"firewall","duration":2000`),
+		},
+	})
+	cfg := configmocks.NewFromString("", fmt.Sprintf("monitoring:\n  opensearch_port: %d\n", port))
+	f.Config = func() (config.Config, error) { return cfg, nil }
+
+	require.NoError(t, runTimeline(t, f, "dev"))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "OFFSET")
+	assert.Contains(t, lines[1], "+0.000s")
+	assert.Contains(t, lines[2], "+3.100s")
+	assert.Contains(t, lines[2], "firewall")
+	assert.Contains(t, lines[2], "2s")
+}
+
+func TestTimelineRun_OpenSearchDown(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	port := serverPort(t, srv)
+	srv.Close()
+
+	f, _, out, errOut := testFactory(t, port)
+	require.NoError(t, runTimeline(t, f, "dev", "--format", "json"))
+
+	assert.Contains(t, errOut.String(), "monitor up")
+	var rows []timelineRow
+	require.NoError(t, json.Unmarshal(out.Bytes(), &rows))
+	require.Len(t, rows, 2)
+	assert.Equal(t, "create", rows[0].Event)
+	assert.Equal(t, "start", rows[1].Event)
+	assert.Equal(t, int64(500), rows[1].OffsetMS)
+}
+
+func TestTimelineRun_AgentNotFound(t *testing.T) {
+	f, _, _, _ := testFactory(t, 0)
+	f.Client = func(context.Context) (*docker.Client, error) {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		fake.SetupContainerList()
+		return fake.Client, nil
+	}
+
+	err := runTimeline(t, f, "dev")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
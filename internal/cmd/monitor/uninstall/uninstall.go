@@ -0,0 +1,152 @@
+// Package uninstall implements `clawker monitor uninstall` — tear down the
+// monitoring stack and remove its rendered config, optionally keeping the
+// data volumes.
+package uninstall
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmd/monitor/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
+	internalmonitor "github.com/schmitthub/clawker/internal/monitor"
+	"github.com/schmitthub/clawker/internal/prompter"
+)
+
+type UninstallOptions struct {
+	IOStreams *iostreams.IOStreams
+	Config    func() (config.Config, error)
+	Logger    func() (*logger.Logger, error)
+	Prompter  func() *prompter.Prompter
+
+	Volumes     bool
+	KeepVolumes bool
+}
+
+func NewCmdUninstall(f *cmdutil.Factory, runF func(context.Context, *UninstallOptions) error) *cobra.Command {
+	opts := &UninstallOptions{
+		IOStreams: f.IOStreams,
+		Config:    f.Config,
+		Logger:    f.Logger,
+		Prompter:  f.Prompter,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the monitoring stack and its configuration",
+		Long: `Stops and removes the monitoring stack's containers and deletes the rendered
+stack configuration (compose.yaml, otel-config.yaml, prometheus.yaml, and the
+opensearch-bootstrap/ tree).
+
+The data volumes hold the OpenSearch logs and traces and the Prometheus
+metrics. You are asked whether to remove them; without a terminal they are
+kept. Pass --volumes or --keep-volumes to decide up front. Kept volumes are
+picked up again by the next 'monitor up', together with the record of which
+monitoring extensions were seeded into them.`,
+		Example: `  # Uninstall, choosing interactively whether to keep the data
+  clawker monitor uninstall
+
+  # Remove everything, including collected telemetry
+  clawker monitor uninstall --volumes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return uninstallRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Volumes, "volumes", "v", false, "Remove the data volumes without asking")
+	cmd.Flags().BoolVar(&opts.KeepVolumes, "keep-volumes", false, "Keep the data volumes without asking")
+	cmd.MarkFlagsMutuallyExclusive("volumes", "keep-volumes")
+
+	return cmd
+}
+
+func uninstallRun(ctx context.Context, opts *UninstallOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	log, err := opts.Logger()
+	if err != nil {
+		return fmt.Errorf("initializing logger: %w", err)
+	}
+
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	monitorDir, err := cfg.MonitorSubdir()
+	if err != nil {
+		return fmt.Errorf("failed to determine monitor directory: %w", err)
+	}
+	log.Debug().Str("monitor_dir", monitorDir).Msg("uninstalling monitor stack")
+
+	composePath := filepath.Join(monitorDir, internalmonitor.ComposeFileName)
+	if _, err := os.Stat(composePath); os.IsNotExist(err) {
+		fmt.Fprintf(ios.ErrOut, "%s Monitoring stack not installed; nothing to uninstall.\n", cs.InfoIcon())
+		return nil
+	}
+
+	removeVolumes := opts.Volumes
+	if !opts.Volumes && !opts.KeepVolumes {
+		removeVolumes, err = opts.Prompter().Confirm(
+			fmt.Sprintf("%s Also remove the data volumes? Collected logs, traces, and metrics are deleted.", cs.WarningIcon()), false)
+		if err != nil {
+			return fmt.Errorf("confirm volume removal: %w", err)
+		}
+	}
+
+	downArgs := []string{"compose", "-f", composePath, "down", "--remove-orphans"}
+	if removeVolumes {
+		downArgs = append(downArgs, "-v")
+	}
+	log.Debug().Strs("args", downArgs).Msg("running docker compose down")
+	if err := shared.RunComposeCmd(ctx, ios, downArgs, "Removing monitoring stack..."); err != nil {
+		return fmt.Errorf("failed to remove monitoring stack: %w", err)
+	}
+
+	if err := removeRendered(monitorDir, removeVolumes); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ios.ErrOut, "%s Monitoring stack uninstalled.\n", cs.SuccessIcon())
+	if removeVolumes {
+		fmt.Fprintf(ios.ErrOut, "%s Data volumes removed.\n", cs.InfoIcon())
+	} else {
+		fmt.Fprintf(ios.ErrOut, "%s Data volumes kept; 'clawker monitor up' reinstalls the stack on top of them.\n", cs.InfoIcon())
+	}
+	return nil
+}
+
+// removeRendered deletes the generated stack config from monitorDir. The
+// units ledger describes what was seeded into the OpenSearch volume, so it
+// goes only with the volumes; the directory itself is removed once empty.
+func removeRendered(monitorDir string, withLedger bool) error {
+	paths := []string{
+		internalmonitor.ComposeFileName,
+		internalmonitor.OtelConfigFileName,
+		internalmonitor.PrometheusFileName,
+		internalmonitor.OpenSearchBootstrapDirName,
+	}
+	if withLedger {
+		paths = append(paths, internalmonitor.UnitsLedgerFile)
+	}
+	for _, p := range paths {
+		if err := os.RemoveAll(filepath.Join(monitorDir, p)); err != nil {
+			return fmt.Errorf("remove %s: %w", p, err)
+		}
+	}
+	// Best-effort: leave the directory in place if anything else lives there.
+	_ = os.Remove(monitorDir)
+	return nil
+}
@@ -0,0 +1,101 @@
+package uninstall
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/monitor"
+	"github.com/schmitthub/clawker/internal/testenv"
+)
+
+func TestNewCmdUninstall(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantVolumes bool
+		wantKeep    bool
+		wantErr     bool
+	}{
+		{name: "defaults", input: ""},
+		{name: "volumes", input: "-v", wantVolumes: true},
+		{name: "keep volumes", input: "--keep-volumes", wantKeep: true},
+		{name: "both", input: "--volumes --keep-volumes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *UninstallOptions
+			cmd := NewCmdUninstall(f, func(_ context.Context, opts *UninstallOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVolumes, gotOpts.Volumes)
+			assert.Equal(t, tt.wantKeep, gotOpts.KeepVolumes)
+		})
+	}
+}
+
+func TestUninstallRun_NotInstalled(t *testing.T) {
+	testenv.New(t)
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+	tio, _, _, errOut := iostreams.Test()
+
+	require.NoError(t, uninstallRun(context.Background(), &UninstallOptions{
+		IOStreams: tio,
+		Config:    func() (config.Config, error) { return cfg, nil },
+		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
+	}))
+	assert.Contains(t, errOut.String(), "nothing to uninstall")
+}
+
+func TestRemoveRendered(t *testing.T) {
+	for _, withLedger := range []bool{false, true} {
+		dir := t.TempDir()
+		for _, name := range []string{
+			monitor.ComposeFileName, monitor.OtelConfigFileName, monitor.PrometheusFileName, monitor.UnitsLedgerFile,
+		} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("x"), 0o644))
+		}
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, monitor.OpenSearchBootstrapDirName, "index-templates"), 0o755))
+
+		require.NoError(t, removeRendered(dir, withLedger))
+
+		_, err := os.Stat(dir)
+		if withLedger {
+			assert.True(t, os.IsNotExist(err), "empty monitor dir is removed")
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, monitor.UnitsLedgerFile, entries[0].Name())
+	}
+}
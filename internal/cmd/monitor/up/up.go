@@ -16,6 +16,7 @@ import (
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
 	internalmonitor "github.com/schmitthub/clawker/internal/monitor"
+	"github.com/schmitthub/clawker/internal/tui"
 )
 
 type UpOptions struct {
@@ -24,6 +25,7 @@ type UpOptions struct {
 	Config        func() (config.Config, error)
 	Logger        func() (*logger.Logger, error)
 	BundleManager func() (*bundle.Manager, error)
+	TUI           *tui.TUI
 
 	Detach bool
 }
@@ -35,6 +37,7 @@ func NewCmdUp(f *cmdutil.Factory, runF func(context.Context, *UpOptions) error)
 		Config:        f.Config,
 		Logger:        f.Logger,
 		BundleManager: f.BundleManager,
+		TUI:           f.TUI,
 		Detach:        true,
 	}
 
@@ -133,22 +136,13 @@ func upRun(ctx context.Context, opts *UpOptions) error {
 	if err != nil {
 		return fmt.Errorf("failed to create Docker client: %w", err)
 	}
-	//nolint:exhaustruct // Name is the only required field; the embedded moby NetworkCreateOptions is optional and omitted at every EnsureNetwork call site.
-	if _, err = client.EnsureNetwork(ctx, docker.EnsureNetworkOptions{Name: networkName}); err != nil {
-		return fmt.Errorf("failed to ensure Docker network '%s': %w", networkName, err)
-	}
-	log.Debug().Str("network", networkName).Msg("network ready")
-
-	if composeErr := shared.ComposeUp(ctx, ios, log, composePath, opts.Detach); composeErr != nil {
-		return fmt.Errorf("failed to start monitoring stack: %w", composeErr)
+	if opts.Detach {
+		err = bringUpDetached(ctx, opts, log, client, networkName, monitorDir, composePath, cwdUnits)
+	} else {
+		err = bringUpForeground(ctx, ios, log, client, networkName, monitorDir, composePath, cwdUnits)
 	}
-
-	// Record the seeded projection now that the bootstrap container has applied
-	// (or reapplied) this project's OpenSearch artifacts. SeedLedger re-reads
-	// the ledger under a file lock so a concurrent up's seeds are merged with,
-	// never overwritten by, this one's.
-	if saveErr := internalmonitor.SeedLedger(ctx, monitorDir, cwdUnits, time.Now()); saveErr != nil {
-		return fmt.Errorf("record seeded monitoring units: %w", saveErr)
+	if err != nil {
+		return err
 	}
 
 	// Reachable only on a partial bring-up (the running-stack case
@@ -173,6 +167,62 @@ func upRun(ctx context.Context, opts *UpOptions) error {
 	return nil
 }
 
+// bringUpDetached runs the detached bring-up as steps on a progress display.
+// The projection is recorded only after a successful compose up, so a failed
+// bring-up never records a seed that did not apply.
+func bringUpDetached(
+	ctx context.Context,
+	opts *UpOptions,
+	log *logger.Logger,
+	client *docker.Client,
+	networkName, monitorDir, composePath string,
+	cwdUnits []internalmonitor.ResolvedUnit,
+) error {
+	steps := shared.NewStackSteps(opts.TUI, "Starting monitoring stack", "Started")
+	err := shared.EnsureNetwork(ctx, steps, client, networkName)
+	if err == nil {
+		if composeErr := shared.ComposeUp(ctx, steps, log, composePath); composeErr != nil {
+			err = fmt.Errorf("failed to start monitoring stack: %w", composeErr)
+		}
+	}
+	if err == nil {
+		// SeedLedger re-reads the ledger under a file lock so a concurrent
+		// up's seeds are merged with, never overwritten by, this one's.
+		err = shared.SeedLedger(ctx, steps, monitorDir, cwdUnits)
+	}
+	if waitErr := steps.Wait(); err == nil {
+		err = waitErr
+	}
+	return err
+}
+
+// bringUpForeground runs compose attached, streaming the services' logs until
+// interrupted — output a step display can't host, so it keeps the spinner.
+func bringUpForeground(
+	ctx context.Context,
+	ios *iostreams.IOStreams,
+	log *logger.Logger,
+	client *docker.Client,
+	networkName, monitorDir, composePath string,
+	cwdUnits []internalmonitor.ResolvedUnit,
+) error {
+	//nolint:exhaustruct // Name is the only required field; the embedded moby NetworkCreateOptions is optional and omitted at every EnsureNetwork call site.
+	if _, err := client.EnsureNetwork(ctx, docker.EnsureNetworkOptions{Name: networkName}); err != nil {
+		return fmt.Errorf("failed to ensure Docker network '%s': %w", networkName, err)
+	}
+	log.Debug().Str("network", networkName).Msg("network ready")
+
+	upArgs := shared.ComposeUpArgs(composePath, false)
+	log.Debug().Strs("args", upArgs).Msg("running docker compose up")
+	if composeErr := shared.RunComposeCmd(ctx, ios, upArgs, "Starting monitoring stack..."); composeErr != nil {
+		return fmt.Errorf("failed to start monitoring stack: %w", composeErr)
+	}
+	if saveErr := internalmonitor.SeedLedger(ctx, monitorDir, cwdUnits, time.Now()); saveErr != nil {
+		return fmt.Errorf("record seeded monitoring units: %w", saveErr)
+	}
+	return nil
+}
+
 // printServiceURLs prints the host-facing stack URLs after a detached up.
 func printServiceURLs(ios *iostreams.IOStreams, cfg config.Config) {
 	cs := ios.ColorScheme()
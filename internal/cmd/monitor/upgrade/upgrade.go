@@ -0,0 +1,185 @@
+// Package upgrade implements `clawker monitor upgrade` — re-render the
+// installed monitoring stack from this binary's pinned templates, show what
+// changes, and apply it.
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmd/monitor/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
+	internalmonitor "github.com/schmitthub/clawker/internal/monitor"
+	"github.com/schmitthub/clawker/internal/prompter"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+type UpgradeOptions struct {
+	IOStreams *iostreams.IOStreams
+	Client    func(context.Context) (*docker.Client, error)
+	Config    func() (config.Config, error)
+	Logger    func() (*logger.Logger, error)
+	Prompter  func() *prompter.Prompter
+	TUI       *tui.TUI
+
+	DryRun bool
+	Force  bool
+}
+
+func NewCmdUpgrade(f *cmdutil.Factory, runF func(context.Context, *UpgradeOptions) error) *cobra.Command {
+	opts := &UpgradeOptions{
+		IOStreams: f.IOStreams,
+		Client:    f.Client,
+		Config:    f.Config,
+		Logger:    f.Logger,
+		Prompter:  f.Prompter,
+		TUI:       f.TUI,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade the monitoring stack to this clawker version's pinned images and config",
+		Long: `Re-renders the installed monitoring stack from the templates and image pins
+built into this clawker binary, shows a diff against the installed
+compose.yaml, otel-config.yaml, and prometheus.yaml, and applies it.
+
+Every clawker release pins the stack's images (OpenTelemetry Collector,
+OpenSearch, OpenSearch Dashboards, Prometheus) by version and digest, so after
+updating clawker this brings a running stack to the matching versions. Port
+changes in the monitoring settings are picked up the same way.
+
+Applying recreates the services whose definition changed and restarts the
+collector when its config changed. OpenSearch data and the bootstrap-applied
+index templates are kept.`,
+		Example: `  # Show what an upgrade would change
+  clawker monitor upgrade --dry-run
+
+  # Upgrade without a confirmation prompt
+  clawker monitor upgrade --force`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return upgradeRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the config diff without applying it")
+	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Do not prompt for confirmation")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "force")
+
+	return cmd
+}
+
+func upgradeRun(ctx context.Context, opts *UpgradeOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	log, err := opts.Logger()
+	if err != nil {
+		return fmt.Errorf("initializing logger: %w", err)
+	}
+
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	monitorDir, err := cfg.MonitorSubdir()
+	if err != nil {
+		return fmt.Errorf("failed to determine monitor directory: %w", err)
+	}
+	log.Debug().Str("monitor_dir", monitorDir).Msg("upgrading monitor stack")
+
+	composePath := filepath.Join(monitorDir, internalmonitor.ComposeFileName)
+	if _, err := os.Stat(composePath); os.IsNotExist(err) {
+		fmt.Fprintf(ios.ErrOut, "%s Monitoring stack not installed\n", cs.FailureIcon())
+		fmt.Fprintf(ios.ErrOut, "\nRun 'clawker monitor up' to install and start it\n")
+		return fmt.Errorf("compose.yaml not found in %s", monitorDir)
+	}
+
+	cwdUnits, data, err := shared.PrepareStackData(cfg, monitorDir)
+	if err != nil {
+		return fmt.Errorf("prepare monitoring stack: %w", err)
+	}
+	files, err := internalmonitor.RenderStackFiles(data)
+	if err != nil {
+		return fmt.Errorf("render monitoring stack config: %w", err)
+	}
+	diffs, err := internalmonitor.DiffStack(monitorDir, files)
+	if err != nil {
+		return fmt.Errorf("diff monitoring stack config: %w", err)
+	}
+	if len(diffs) == 0 {
+		fmt.Fprintf(ios.ErrOut, "%s Monitoring stack is up to date.\n", cs.SuccessIcon())
+		return nil
+	}
+
+	for _, d := range diffs {
+		fmt.Fprint(ios.Out, d.Unified)
+	}
+	if opts.DryRun {
+		return nil
+	}
+
+	if !opts.Force {
+		confirmed, err := opts.Prompter().Confirm(
+			fmt.Sprintf("%s Apply these changes and recreate the affected services?", cs.WarningIcon()), false)
+		if err != nil {
+			return fmt.Errorf("confirm upgrade: %w", err)
+		}
+		if !confirmed {
+			fmt.Fprintln(ios.ErrOut, "Aborted.")
+			return nil
+		}
+	}
+
+	render, err := internalmonitor.RenderStack(monitorDir, data, cwdUnits, true)
+	if err != nil {
+		return fmt.Errorf("render monitoring stack config: %w", err)
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Docker client: %w", err)
+	}
+	networkName := cfg.ClawkerNetwork()
+
+	// Compose recreates services whose definition changed (a new image pin,
+	// a moved port) but never notices a bind-mounted file's content changing,
+	// so a changed collector config needs the explicit removal.
+	removeCollector := render.OtelConfigChanged && shared.CollectorRunning(ctx, composePath)
+	steps := shared.NewStackSteps(opts.TUI, "Upgrading monitoring stack", "Upgraded")
+	err = shared.EnsureNetwork(ctx, steps, client, networkName)
+	if err == nil && removeCollector {
+		if rmErr := shared.RemoveCollector(ctx, steps, log, composePath); rmErr != nil {
+			err = fmt.Errorf("failed to remove otel-collector for config reload: %w", rmErr)
+		}
+	}
+	if err == nil {
+		if composeErr := shared.ComposeUp(ctx, steps, log, composePath); composeErr != nil {
+			err = fmt.Errorf("failed to start monitoring stack: %w", composeErr)
+		}
+	}
+	if err == nil {
+		err = shared.SeedLedger(ctx, steps, monitorDir, cwdUnits)
+	}
+	if waitErr := steps.Wait(); err == nil {
+		err = waitErr
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(ios.ErrOut, "%s Monitoring stack upgraded.\n", cs.SuccessIcon())
+	return nil
+}
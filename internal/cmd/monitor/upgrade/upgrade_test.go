@@ -0,0 +1,132 @@
+package upgrade
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmd/monitor/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/monitor"
+	"github.com/schmitthub/clawker/internal/testenv"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+func TestNewCmdUpgrade(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantDryRun bool
+		wantForce  bool
+		wantErr    bool
+	}{
+		{name: "defaults", input: ""},
+		{name: "dry-run", input: "--dry-run", wantDryRun: true},
+		{name: "force", input: "-f", wantForce: true},
+		{name: "dry-run and force", input: "--dry-run --force", wantErr: true},
+		{name: "arguments", input: "now", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *UpgradeOptions
+			cmd := NewCmdUpgrade(f, func(_ context.Context, opts *UpgradeOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDryRun, gotOpts.DryRun)
+			assert.Equal(t, tt.wantForce, gotOpts.Force)
+		})
+	}
+}
+
+// upgradeEnv returns upgrade options over an isolated testenv and its
+// monitor directory.
+func upgradeEnv(t *testing.T) (*UpgradeOptions, string, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	testenv.New(t)
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+	monitorDir, err := cfg.MonitorSubdir()
+	require.NoError(t, err)
+
+	tio, _, out, errOut := iostreams.Test()
+	return &UpgradeOptions{
+		IOStreams: tio,
+		TUI:       tui.NewTUI(tio),
+		Config:    func() (config.Config, error) { return cfg, nil },
+		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
+	}, monitorDir, out, errOut
+}
+
+func TestUpgradeRun_NotInstalled(t *testing.T) {
+	opts, _, _, errOut := upgradeEnv(t)
+
+	err := upgradeRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, errOut.String(), "monitor up")
+}
+
+func TestUpgradeRun_UpToDate(t *testing.T) {
+	opts, monitorDir, out, errOut := upgradeEnv(t)
+	cfg, err := opts.Config()
+	require.NoError(t, err)
+	_, _, err = shared.PrepareStack(cfg, monitorDir)
+	require.NoError(t, err)
+
+	require.NoError(t, upgradeRun(context.Background(), opts))
+	assert.Empty(t, out.String())
+	assert.Contains(t, errOut.String(), "up to date")
+}
+
+func TestUpgradeRun_DryRunShowsDiffWithoutWriting(t *testing.T) {
+	opts, monitorDir, out, _ := upgradeEnv(t)
+	cfg, err := opts.Config()
+	require.NoError(t, err)
+	_, _, err = shared.PrepareStack(cfg, monitorDir)
+	require.NoError(t, err)
+
+	// Simulate a stack installed by an older release with a different pin.
+	composePath := filepath.Join(monitorDir, monitor.ComposeFileName)
+	current, err := os.ReadFile(composePath)
+	require.NoError(t, err)
+	old := strings.Replace(string(current), monitor.PrometheusImage, "prom/prometheus:v3.0.0", 1)
+	require.NotEqual(t, string(current), old)
+	require.NoError(t, os.WriteFile(composePath, []byte(old), 0o644))
+
+	opts.DryRun = true
+	require.NoError(t, upgradeRun(context.Background(), opts))
+
+	assert.Contains(t, out.String(), "-    image: prom/prometheus:v3.0.0")
+	assert.Contains(t, out.String(), "+    image: "+monitor.PrometheusImage)
+	assert.NotContains(t, out.String(), monitor.OtelConfigFileName)
+	after, err := os.ReadFile(composePath)
+	require.NoError(t, err)
+	assert.Equal(t, old, string(after), "dry run must not touch the installed config")
+}
@@ -0,0 +1,400 @@
+// Package usage provides the monitor usage command.
+package usage
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// Prometheus series the Claude Code harness exports through the collector.
+// Token usage carries a `kind` label (the collector renames the harness's
+// `type` attribute; see otel-config.yaml.tmpl); both carry the `project` and
+// `agent` resource attributes clawker sets on every agent container.
+const (
+	tokenMetric = "claude_code_token_usage_tokens_total"
+	costMetric  = "claude_code_cost_usage_USD_total"
+)
+
+// Token kinds reported on tokenMetric.
+const (
+	kindInput         = "input"
+	kindOutput        = "output"
+	kindCacheRead     = "cacheRead"
+	kindCacheCreation = "cacheCreation"
+)
+
+// UsageOptions holds options for the usage command.
+type UsageOptions struct {
+	IOStreams  *iostreams.IOStreams
+	TUI        *tui.TUI
+	Config     func() (config.Config, error)
+	HttpClient func() (*http.Client, error)
+
+	Format  *cmdutil.FormatFlags
+	CSV     bool
+	Since   string
+	Project string
+	Agent   string
+
+	// now is the report's end time; nil means time.Now (tests pin it).
+	now func() time.Time
+}
+
+// NewCmdUsage creates the monitor usage command.
+func NewCmdUsage(f *cmdutil.Factory, runF func(context.Context, *UsageOptions) error) *cobra.Command {
+	opts := &UsageOptions{
+		IOStreams:  f.IOStreams,
+		TUI:        f.TUI,
+		Config:     f.Config,
+		HttpClient: f.HttpClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Report token usage and cost per project and agent",
+		Long: `Reports Claude Code token usage and estimated cost per project and agent,
+aggregated from the monitoring stack's Prometheus.
+
+Figures are the increase of the harness's token and cost counters over the
+--since window, so sessions that restarted during the window are counted in
+full. Cost is the harness's own USD estimate, not an invoice. Only sessions
+that ran while the monitoring stack was up are included.
+
+--since accepts a duration (90m, 24h, 7d, 2w) or an RFC3339 timestamp.
+Use --csv or --json to export the report for expense tracking.`,
+		Example: `  # Usage over the last 7 days
+  clawker monitor usage --since 7d
+
+  # One project, exported as CSV
+  clawker monitor usage --since 30d --project myapp --csv > usage.csv
+
+  # Machine-readable output
+  clawker monitor usage --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.CSV && (cmd.Flags().Changed("format") || cmd.Flags().Changed("json")) {
+				return cmdutil.FlagErrorf("--csv and --format/--json are mutually exclusive")
+			}
+			if _, err := cmdutil.ParseSince(opts.Since, time.Now()); err != nil {
+				return cmdutil.FlagErrorf("invalid --since: %v", err)
+			}
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return usageRun(cmd.Context(), opts)
+		},
+	}
+
+	opts.Format = cmdutil.AddFormatFlags(cmd)
+	cmd.Flags().BoolVar(&opts.CSV, "csv", false, "Output as CSV")
+	cmd.Flags().StringVar(&opts.Since, "since", "7d", "Report window: a duration (e.g. 24h, 7d, 2w) or an RFC3339 timestamp")
+	cmd.Flags().StringVar(&opts.Project, "project", "", "Only report this project")
+	cmd.Flags().StringVar(&opts.Agent, "agent", "", "Only report this agent")
+
+	cmd.RegisterFlagCompletionFunc("agent", cmdutil.AgentCompletions(f.Client, f.ProjectManager)) //nolint:errcheck,gosec // only errors on a programmer mistake (flag must exist); flags are defined above
+
+	return cmd
+}
+
+// usageRow is the data structure exposed to --format templates, --json, and
+// --csv output. Field tags are the export contract — a rename breaks
+// downstream expense tooling.
+type usageRow struct {
+	Project             string  `json:"project"`
+	Agent               string  `json:"agent"`
+	InputTokens         int64   `json:"input_tokens"`
+	OutputTokens        int64   `json:"output_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	TotalTokens         int64   `json:"total_tokens"`
+	CostUSD             float64 `json:"cost_usd"`
+}
+
+func usageRun(ctx context.Context, opts *UsageOptions) error {
+	ios := opts.IOStreams
+
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return fmt.Errorf("creating HTTP client: %w", err)
+	}
+
+	now := time.Now
+	if opts.now != nil {
+		now = opts.now
+	}
+	end := now()
+	window, err := cmdutil.ParseSince(opts.Since, end)
+	if err != nil {
+		return cmdutil.FlagErrorf("invalid --since: %v", err)
+	}
+
+	// Host-side access: the settings port drives both the in-cluster listener
+	// and the host publish (see config.PrometheusURL for the in-cluster form).
+	prom := &promClient{
+		http:    httpClient,
+		baseURL: fmt.Sprintf("http://localhost:%d", cfg.MonitoringConfig().PrometheusPort),
+	}
+
+	selector := labelSelector(opts.Project, opts.Agent)
+	rng := strconv.FormatInt(int64(window/time.Second), 10) + "s"
+
+	tokens, err := prom.query(ctx, fmt.Sprintf("sum by (project, agent, kind) (increase(%s%s[%s]))", tokenMetric, selector, rng), end)
+	if err != nil {
+		return err
+	}
+	costs, err := prom.query(ctx, fmt.Sprintf("sum by (project, agent) (increase(%s%s[%s]))", costMetric, selector, rng), end)
+	if err != nil {
+		return err
+	}
+
+	rows := aggregate(tokens, costs)
+
+	switch {
+	case opts.CSV:
+		return writeCSV(ios.Out, rows)
+	case opts.Format.IsJSON():
+		return opts.Format.WriteJSON(ios, "monitor.usage", rows)
+	case opts.Format.IsTemplate():
+		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(rows))
+	}
+
+	if len(rows) == 0 {
+		cs := ios.ColorScheme()
+		fmt.Fprintf(ios.ErrOut, "%s No usage recorded since %s\n", cs.InfoIcon(), end.Add(-window).Format(time.RFC3339))
+		return nil
+	}
+
+	total := usageRow{Project: "TOTAL"}
+	tp := opts.TUI.NewTable("PROJECT", "AGENT", "INPUT", "OUTPUT", "CACHE READ", "CACHE WRITE", "TOTAL", "COST (USD)")
+	for _, r := range rows {
+		tp.AddRow(r.Project, r.Agent, fmtInt(r.InputTokens), fmtInt(r.OutputTokens),
+			fmtInt(r.CacheReadTokens), fmtInt(r.CacheCreationTokens), fmtInt(r.TotalTokens), fmtCost(r.CostUSD))
+		total.InputTokens += r.InputTokens
+		total.OutputTokens += r.OutputTokens
+		total.CacheReadTokens += r.CacheReadTokens
+		total.CacheCreationTokens += r.CacheCreationTokens
+		total.TotalTokens += r.TotalTokens
+		total.CostUSD += r.CostUSD
+	}
+	if len(rows) > 1 {
+		tp.AddRow(total.Project, "", fmtInt(total.InputTokens), fmtInt(total.OutputTokens),
+			fmtInt(total.CacheReadTokens), fmtInt(total.CacheCreationTokens), fmtInt(total.TotalTokens), fmtCost(total.CostUSD))
+	}
+	return tp.Render()
+}
+
+// labelSelector renders the PromQL matcher for the project/agent filters.
+// Values are quoted with Go escaping, which PromQL string literals accept.
+func labelSelector(project, agent string) string {
+	var matchers []string
+	if project != "" {
+		matchers = append(matchers, "project="+strconv.Quote(project))
+	}
+	if agent != "" {
+		matchers = append(matchers, "agent="+strconv.Quote(agent))
+	}
+	if len(matchers) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(matchers, ",") + "}"
+}
+
+// sample is one element of a Prometheus instant-vector result.
+type sample struct {
+	Metric map[string]string
+	Value  float64
+}
+
+// aggregate folds the token and cost vectors into one row per
+// (project, agent), sorted by cost then tokens, biggest first.
+func aggregate(tokens, costs []sample) []usageRow {
+	type key struct{ project, agent string }
+	byKey := map[key]*usageRow{}
+	row := func(m map[string]string) *usageRow {
+		k := key{project: m["project"], agent: m["agent"]}
+		r, ok := byKey[k]
+		if !ok {
+			r = &usageRow{Project: k.project, Agent: k.agent}
+			byKey[k] = r
+		}
+		return r
+	}
+
+	for _, s := range tokens {
+		// increase() extrapolates across the window edges, so counts come
+		// back fractional; round to whole tokens.
+		n := int64(s.Value + 0.5)
+		r := row(s.Metric)
+		switch s.Metric["kind"] {
+		case kindInput:
+			r.InputTokens += n
+		case kindOutput:
+			r.OutputTokens += n
+		case kindCacheRead:
+			r.CacheReadTokens += n
+		case kindCacheCreation:
+			r.CacheCreationTokens += n
+		}
+		r.TotalTokens += n
+	}
+	for _, s := range costs {
+		row(s.Metric).CostUSD += s.Value
+	}
+
+	rows := make([]usageRow, 0, len(byKey))
+	for _, r := range byKey {
+		if r.TotalTokens == 0 && r.CostUSD == 0 {
+			continue
+		}
+		rows = append(rows, *r)
+	}
+	slices.SortFunc(rows, func(a, b usageRow) int {
+		switch {
+		case a.CostUSD != b.CostUSD:
+			if a.CostUSD > b.CostUSD {
+				return -1
+			}
+			return 1
+		case a.TotalTokens != b.TotalTokens:
+			if a.TotalTokens > b.TotalTokens {
+				return -1
+			}
+			return 1
+		case a.Project != b.Project:
+			return strings.Compare(a.Project, b.Project)
+		default:
+			return strings.Compare(a.Agent, b.Agent)
+		}
+	})
+	return rows
+}
+
+func writeCSV(w io.Writer, rows []usageRow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{
+		"project", "agent", "input_tokens", "output_tokens", "cache_read_tokens",
+		"cache_creation_tokens", "total_tokens", "cost_usd",
+	}); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write([]string{
+			r.Project, r.Agent,
+			strconv.FormatInt(r.InputTokens, 10),
+			strconv.FormatInt(r.OutputTokens, 10),
+			strconv.FormatInt(r.CacheReadTokens, 10),
+			strconv.FormatInt(r.CacheCreationTokens, 10),
+			strconv.FormatInt(r.TotalTokens, 10),
+			strconv.FormatFloat(r.CostUSD, 'f', 4, 64),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// fmtInt renders n with thousands separators.
+func fmtInt(n int64) string {
+	if n < 0 {
+		return "-" + fmtInt(-n)
+	}
+	s := strconv.FormatInt(n, 10)
+	var b strings.Builder
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+func fmtCost(usd float64) string {
+	return fmt.Sprintf("$%.2f", usd)
+}
+
+// promClient runs instant queries against the Prometheus HTTP API.
+type promClient struct {
+	http    *http.Client
+	baseURL string
+}
+
+// promResponse is the /api/v1/query envelope for an instant vector.
+type promResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  [2]any            `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func (c *promClient) query(ctx context.Context, promql string, at time.Time) ([]sample, error) {
+	q := url.Values{}
+	q.Set("query", promql)
+	q.Set("time", strconv.FormatInt(at.Unix(), 10))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/query?"+q.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("building Prometheus query: %w", err)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("querying Prometheus at %s (is the monitoring stack up? run 'clawker monitor up'): %w", c.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	var body promResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding Prometheus response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if body.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed: %s: %s", body.ErrorType, body.Error)
+	}
+	if body.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("prometheus query returned %q, want vector", body.Data.ResultType)
+	}
+
+	samples := make([]sample, 0, len(body.Data.Result))
+	for _, r := range body.Data.Result {
+		raw, ok := r.Value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("prometheus sample for %v has no value", r.Metric)
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("prometheus sample for %v: %w", r.Metric, err)
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		samples = append(samples, sample{Metric: r.Metric, Value: v})
+	}
+	return samples, nil
+}
@@ -0,0 +1,175 @@
+package usage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+)
+
+var testNow = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+// promStub serves canned /api/v1/query responses keyed by metric name and
+// records the queries it saw.
+func promStub(t *testing.T, bodies map[string]string) (port int, queries *[]string) {
+	t.Helper()
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("query")
+		seen = append(seen, q)
+		for metric, body := range bodies {
+			if strings.Contains(q, metric) {
+				fmt.Fprint(w, body)
+				return
+			}
+		}
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	_, p, err := net.SplitHostPort(strings.TrimPrefix(srv.URL, "http://"))
+	require.NoError(t, err)
+	_, err = fmt.Sscan(p, &port)
+	require.NoError(t, err)
+	return port, &seen
+}
+
+func vector(samples ...string) string {
+	return `{"status":"success","data":{"resultType":"vector","result":[` + strings.Join(samples, ",") + `]}}`
+}
+
+func tokenSample(project, agent, kind, value string) string {
+	return fmt.Sprintf(`{"metric":{"project":%q,"agent":%q,"kind":%q},"value":[1741608000,%q]}`, project, agent, kind, value)
+}
+
+func costSample(project, agent, value string) string {
+	return fmt.Sprintf(`{"metric":{"project":%q,"agent":%q},"value":[1741608000,%q]}`, project, agent, value)
+}
+
+func testFactory(t *testing.T, port int) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	cfg := configmocks.NewFromString("", fmt.Sprintf("monitoring:\n  prometheus_port: %d\n", port))
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithConfig(cfg))
+	tf.HttpClient = func() (*http.Client, error) { return http.DefaultClient, nil }
+	return tf.Factory, tf.Out, tf.ErrOut
+}
+
+func runUsage(t *testing.T, f *cmdutil.Factory, args ...string) error {
+	t.Helper()
+	cmd := NewCmdUsage(f, func(ctx context.Context, opts *UsageOptions) error {
+		opts.now = func() time.Time { return testNow }
+		return usageRun(ctx, opts)
+	})
+	cmd.SetArgs(args)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	return cmd.Execute()
+}
+
+func TestUsage_AggregatesPerProjectAndAgent(t *testing.T) {
+	port, queries := promStub(t, map[string]string{
+		tokenMetric: vector(
+			tokenSample("myapp", "dev", kindInput, "1200.4"),
+			tokenSample("myapp", "dev", kindOutput, "300"),
+			tokenSample("myapp", "dev", kindCacheRead, "5000"),
+			tokenSample("myapp", "dev", kindCacheCreation, "700"),
+			tokenSample("other", "ci", kindInput, "10"),
+		),
+		costMetric: vector(
+			costSample("myapp", "dev", "1.25"),
+			costSample("other", "ci", "0.01"),
+		),
+	})
+	f, out, _ := testFactory(t, port)
+
+	require.NoError(t, runUsage(t, f, "--since", "7d", "--format", "json"))
+
+	assert.JSONEq(t, `[
+		{"project":"myapp","agent":"dev","input_tokens":1200,"output_tokens":300,"cache_read_tokens":5000,"cache_creation_tokens":700,"total_tokens":7200,"cost_usd":1.25},
+		{"project":"other","agent":"ci","input_tokens":10,"output_tokens":0,"cache_read_tokens":0,"cache_creation_tokens":0,"total_tokens":10,"cost_usd":0.01}
+	]`, out.String())
+
+	require.Len(t, *queries, 2)
+	assert.Equal(t, "sum by (project, agent, kind) (increase(claude_code_token_usage_tokens_total[604800s]))", (*queries)[0])
+	assert.Equal(t, "sum by (project, agent) (increase(claude_code_cost_usage_USD_total[604800s]))", (*queries)[1])
+}
+
+func TestUsage_FiltersAndCSV(t *testing.T) {
+	port, queries := promStub(t, map[string]string{
+		tokenMetric: vector(tokenSample("my app", "dev", kindOutput, "42")),
+		costMetric:  vector(costSample("my app", "dev", "0.5")),
+	})
+	f, out, _ := testFactory(t, port)
+
+	require.NoError(t, runUsage(t, f, "--since", "24h", "--project", `my app`, "--agent", "dev", "--csv"))
+
+	assert.Equal(t, "project,agent,input_tokens,output_tokens,cache_read_tokens,cache_creation_tokens,total_tokens,cost_usd\n"+
+		"my app,dev,0,42,0,0,42,0.5000\n", out.String())
+	assert.Contains(t, (*queries)[0], `claude_code_token_usage_tokens_total{project="my app",agent="dev"}[86400s]`)
+}
+
+func TestUsage_TableWithTotal(t *testing.T) {
+	port, _ := promStub(t, map[string]string{
+		tokenMetric: vector(
+			tokenSample("a", "x", kindInput, "1000"),
+			tokenSample("b", "y", kindInput, "2000"),
+		),
+		costMetric: vector(costSample("a", "x", "3"), costSample("b", "y", "1")),
+	})
+	f, out, _ := testFactory(t, port)
+
+	require.NoError(t, runUsage(t, f))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4, out.String())
+	assert.Contains(t, lines[0], "PROJECT")
+	assert.Contains(t, lines[1], "$3.00", "highest cost first")
+	assert.Contains(t, lines[3], "TOTAL")
+	assert.Contains(t, lines[3], "3,000")
+	assert.Contains(t, lines[3], "$4.00")
+}
+
+func TestUsage_NoData(t *testing.T) {
+	port, _ := promStub(t, nil)
+	f, out, errOut := testFactory(t, port)
+
+	require.NoError(t, runUsage(t, f, "--since", "2026-03-01T00:00:00Z"))
+	assert.Empty(t, out.String())
+	assert.Contains(t, errOut.String(), "No usage recorded since 2026-03-01T00:00:00Z")
+}
+
+func TestUsage_PrometheusError(t *testing.T) {
+	port, _ := promStub(t, map[string]string{
+		tokenMetric: `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+	})
+	f, _, _ := testFactory(t, port)
+
+	err := runUsage(t, f)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad_data: parse error")
+}
+
+func TestUsage_FlagErrors(t *testing.T) {
+	f, _, _ := testFactory(t, 1)
+
+	err := runUsage(t, f, "--csv", "--json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--csv and --format/--json are mutually exclusive")
+
+	err = runUsage(t, f, "--since", "yesterday")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --since")
+}
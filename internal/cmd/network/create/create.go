@@ -74,8 +74,7 @@ func createRun(ctx context.Context, opts *CreateOptions) error {
 	// Connect to Docker
 	client, err := opts.Client(ctx)
 	if err != nil {
-		cmdutil.HandleError(opts.IOStreams, err)
-		return err
+		return fmt.Errorf("connecting to Docker: %w", err)
 	}
 
 	// Build create options
@@ -91,8 +90,7 @@ func createRun(ctx context.Context, opts *CreateOptions) error {
 	// Create the network
 	resp, err := client.NetworkCreate(ctx, opts.Name, createOpts)
 	if err != nil {
-		cmdutil.HandleError(opts.IOStreams, err)
-		return err
+		return fmt.Errorf("creating network: %w", err)
 	}
 
 	// Print the network ID
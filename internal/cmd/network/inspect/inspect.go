@@ -63,8 +63,7 @@ func inspectRun(ctx context.Context, opts *InspectOptions) error {
 	// Connect to Docker
 	client, err := opts.Client(ctx)
 	if err != nil {
-		cmdutil.HandleError(opts.IOStreams, err)
-		return err
+		return fmt.Errorf("connecting to Docker: %w", err)
 	}
 
 	var results []any
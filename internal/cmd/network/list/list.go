@@ -7,6 +7,7 @@ import (
 	"text/tabwriter"
 
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/spf13/cobra"
@@ -31,7 +32,9 @@ func NewCmdList(f *cmdutil.Factory, runF func(context.Context, *ListOptions) err
 		Use:     "list",
 		Aliases: []string{"ls"},
 		Short:   "List networks",
-		Long:    `Lists all networks created by clawker.`,
+		Long: `Lists all networks created by clawker: the shared clawker-net and
+each project's isolated clawker-<project> network. PROJECT is empty for
+clawker-net.`,
 		Example: `  # List all clawker networks
   clawker network list
 
@@ -57,15 +60,13 @@ func listRun(ctx context.Context, opts *ListOptions) error {
 	// Connect to Docker
 	client, err := opts.Client(ctx)
 	if err != nil {
-		cmdutil.HandleError(opts.IOStreams, err)
-		return err
+		return fmt.Errorf("connecting to Docker: %w", err)
 	}
 
 	// List networks
 	networks, err := client.NetworkList(ctx)
 	if err != nil {
-		cmdutil.HandleError(opts.IOStreams, err)
-		return err
+		return fmt.Errorf("listing networks: %w", err)
 	}
 
 	if len(networks.Items) == 0 {
@@ -83,12 +84,13 @@ func listRun(ctx context.Context, opts *ListOptions) error {
 
 	// Print table
 	w := tabwriter.NewWriter(opts.IOStreams.Out, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "NETWORK ID\tNAME\tDRIVER\tSCOPE")
+	fmt.Fprintln(w, "NETWORK ID\tNAME\tPROJECT\tDRIVER\tSCOPE")
 
 	for _, n := range networks.Items {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
 			truncateID(n.ID),
 			n.Name,
+			n.Labels[consts.LabelProject],
 			n.Driver,
 			n.Scope,
 		)
@@ -6,8 +6,16 @@ import (
 	"testing"
 
 	"github.com/google/shlex"
+	"github.com/moby/moby/api/types/network"
+	mobyclient "github.com/moby/moby/client"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -79,3 +87,32 @@ func TestCmdList_Properties(t *testing.T) {
 	require.NotNil(t, cmd.Flags().Lookup("quiet"))
 	require.NotNil(t, cmd.Flags().ShorthandLookup("q"))
 }
+
+func TestListRun_ProjectColumn(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	managed := func(extra map[string]string) map[string]string {
+		labels := map[string]string{fake.Cfg.LabelManaged(): fake.Cfg.ManagedLabelValue()}
+		for k, v := range extra {
+			labels[k] = v
+		}
+		return labels
+	}
+	fake.FakeAPI.NetworkListFn = func(_ context.Context, _ mobyclient.NetworkListOptions) (mobyclient.NetworkListResult, error) {
+		return mobyclient.NetworkListResult{Items: []network.Summary{
+			{Network: network.Network{ID: "aaaaaaaaaaaaaaaa", Name: consts.Network, Driver: "bridge", Scope: "local", Labels: managed(nil)}},
+			{Network: network.Network{ID: "bbbbbbbbbbbbbbbb", Name: "clawker-myapp", Driver: "bridge", Scope: "local",
+				Labels: managed(map[string]string{consts.LabelProject: "myapp"})}},
+		}}, nil
+	}
+	tio, _, out, _ := iostreams.Test()
+
+	err := listRun(context.Background(), &ListOptions{
+		IOStreams: tio,
+		Client:    func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "PROJECT")
+	assert.Regexp(t, `bbbbbbbbbbbb\s+clawker-myapp\s+myapp\s+bridge`, out.String())
+	assert.Regexp(t, `aaaaaaaaaaaa\s+clawker-net\s+bridge`, out.String())
+}
@@ -42,7 +42,10 @@ Networks with connected containers are left untouched.`,
   clawker network prune
 
   # Remove without confirmation prompt
-  clawker network prune --force`,
+  clawker network prune --force
+
+  # List the networks that would be removed, changing nothing
+  clawker network prune --dry-run --force`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if runF != nil {
 				return runF(cmd.Context(), opts)
@@ -53,6 +56,7 @@ Networks with connected containers are left untouched.`,
 
 	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Do not prompt for confirmation")
 
+	cmdutil.EnableDryRun(cmd)
 	return cmd
 }
 
@@ -63,8 +67,7 @@ func pruneRun(ctx context.Context, opts *PruneOptions) error {
 	// Connect to Docker
 	client, err := opts.Client(ctx)
 	if err != nil {
-		cmdutil.HandleError(ios, err)
-		return err
+		return fmt.Errorf("connecting to Docker: %w", err)
 	}
 
 	// Prompt for confirmation if not forced
@@ -82,8 +85,7 @@ func pruneRun(ctx context.Context, opts *PruneOptions) error {
 	// Prune all unused managed networks
 	report, err := client.NetworksPrune(ctx)
 	if err != nil {
-		cmdutil.HandleError(ios, err)
-		return err
+		return fmt.Errorf("pruning networks: %w", err)
 	}
 
 	if len(report.Report.NetworksDeleted) == 0 {
@@ -0,0 +1,291 @@
+// Package open provides the `clawker open` command.
+package open
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+)
+
+// Editors are the editors `clawker open` knows how to launch.
+var Editors = []string{"code", "cursor", "zed"}
+
+// DefaultEditor is used when neither --editor nor settings.editor.default is
+// set.
+const DefaultEditor = "code"
+
+// OpenOptions holds options for the open command.
+type OpenOptions struct {
+	IOStreams      *iostreams.IOStreams
+	Client         func(context.Context) (*docker.Client, error)
+	Config         func() (config.Config, error)
+	ProjectManager func() (project.ProjectManager, error)
+
+	// Launch starts the editor; nil runs name with args as a detached
+	// process.
+	Launch func(ctx context.Context, name string, args []string) error
+
+	Editor string
+	Export bool
+	Print  bool
+	agent  string
+}
+
+// NewCmdOpen creates the open command.
+func NewCmdOpen(f *cmdutil.Factory, runF func(context.Context, *OpenOptions) error) *cobra.Command {
+	opts := &OpenOptions{
+		IOStreams:      f.IOStreams,
+		Client:         f.Client,
+		Config:         f.Config,
+		ProjectManager: f.ProjectManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "open AGENT",
+		Short: "Open an agent's workspace in your editor",
+		Long: `Opens the workspace of an agent in an editor on the host.
+
+How it is opened depends on the agent's workspace mode:
+
+  bind       the host directory mounted into the container is opened
+             directly, in any editor.
+  snapshot   VS Code and Cursor attach to the running container with the
+             Dev Containers extension and open the workspace inside it.
+             Zed cannot attach to a container, so the workspace is exported
+             to a host directory and that copy is opened instead.
+
+--export opens an exported copy for VS Code and Cursor too, which also
+works for a stopped agent. Each export replaces the previous one under
+the clawker data directory; edits made to it are not synced back. Use
+'clawker workspace pull' to bring the agent's changes into your checkout.
+
+The editor is --editor, else editor.default in settings.yaml, else code.
+It must be on your PATH (for VS Code, run "Shell Command: Install 'code'
+command in PATH").
+
+The agent is resolved as clawker.<project>.<agent> using the project in the
+current directory.`,
+		Example: `  # Open the dev agent's workspace in the default editor
+  clawker open dev
+
+  # Open it in Zed
+  clawker open dev --editor zed
+
+  # Print the editor command instead of running it
+  clawker open dev --print`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Editor != "" && !slices.Contains(Editors, opts.Editor) {
+				return cmdutil.FlagErrorf("--editor must be one of %s; got %q", strings.Join(Editors, ", "), opts.Editor)
+			}
+			opts.agent = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return openRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Editor, "editor", "", "Editor to open: code, cursor, or zed (default: settings.editor.default, else code)")
+	cmd.Flags().BoolVar(&opts.Export, "export", false, "Open an exported copy of a snapshot workspace instead of attaching to the container")
+	cmd.Flags().BoolVar(&opts.Print, "print", false, "Print the editor command instead of running it")
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.AgentCompletions(f.Client, f.ProjectManager))
+
+	return cmd
+}
+
+func openRun(ctx context.Context, opts *OpenOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	editor, err := resolveEditor(opts.Editor, cfg)
+	if err != nil {
+		return err
+	}
+
+	var projectName string
+	if opts.ProjectManager != nil {
+		if pm, err := opts.ProjectManager(); err == nil {
+			if p, err := pm.CurrentProject(ctx); err == nil {
+				projectName = p.Name()
+			}
+		}
+	}
+	containerName, err := docker.ContainerName(projectName, opts.agent)
+	if err != nil {
+		return err
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+	c, err := client.FindContainerByName(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("failed to find container %q: %w", containerName, err)
+	}
+	if c == nil {
+		return fmt.Errorf("container %q not found", containerName)
+	}
+	ws, err := findWorkspace(c, cfg)
+	if err != nil {
+		return fmt.Errorf("container %s: %w", containerName, err)
+	}
+
+	var args []string
+	switch {
+	case ws.bind:
+		args = []string{ws.hostDir}
+	case editor != "zed" && !opts.Export:
+		if c.State != container.StateRunning {
+			return fmt.Errorf("agent %q is not running; start it first, or open an exported copy with --export", opts.agent)
+		}
+		args = []string{"--folder-uri", AttachedContainerURI(containerName, ws.dest)}
+	default:
+		dir, err := exportDir(containerName)
+		if err != nil {
+			return err
+		}
+		if !opts.Print {
+			fmt.Fprintf(ios.ErrOut, "%s Exporting %s from %s...\n", cs.InfoIcon(), ws.dest, containerName)
+			if err := client.ExportWorkspace(ctx, c.ID, ws.dest, dir); err != nil {
+				return err
+			}
+			fmt.Fprintf(ios.ErrOut, "%s Exported to %s; edits there are not synced back to the agent\n", cs.SuccessIcon(), dir)
+		}
+		args = []string{dir}
+	}
+
+	if opts.Print {
+		fmt.Fprintln(ios.Out, shellJoin(editor, args))
+		return nil
+	}
+	launch := opts.Launch
+	if launch == nil {
+		launch = launchEditor
+	}
+	if err := launch(ctx, editor, args); err != nil {
+		return err
+	}
+	fmt.Fprintf(ios.ErrOut, "%s Opened %s in %s\n", cs.SuccessIcon(), opts.agent, editor)
+	return nil
+}
+
+// resolveEditor picks the editor: the --editor flag value, else
+// settings.editor.default, else DefaultEditor. The flag is validated by the
+// command; a bad setting is reported here.
+func resolveEditor(flagValue string, cfg config.Config) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	editor := cfg.Settings().Editor.Default
+	if editor == "" {
+		return DefaultEditor, nil
+	}
+	if !slices.Contains(Editors, editor) {
+		return "", fmt.Errorf("invalid settings.editor.default %q: must be one of %s", editor, strings.Join(Editors, ", "))
+	}
+	return editor, nil
+}
+
+// workspace is where an agent's workspace lives. A bind-mode workspace is
+// the host directory itself; a snapshot-mode one exists only in the
+// container, at dest.
+type workspace struct {
+	bind    bool
+	hostDir string
+	dest    string
+}
+
+func findWorkspace(c *container.Summary, cfg config.Config) (workspace, error) {
+	hostDir := c.Labels[cfg.LabelWorkdir()]
+	volume, err := docker.VolumeName(c.Labels[cfg.LabelProject()], c.Labels[cfg.LabelAgent()], docker.VolumePurposeWorkspace)
+	if err != nil {
+		return workspace{}, err
+	}
+	for _, m := range c.Mounts {
+		switch {
+		case m.Type == mount.TypeBind && hostDir != "" && m.Source == hostDir:
+			return workspace{bind: true, hostDir: hostDir, dest: m.Destination}, nil
+		case m.Type == mount.TypeVolume && m.Name == volume:
+			return workspace{hostDir: hostDir, dest: m.Destination}, nil
+		}
+	}
+	return workspace{}, errors.New("no workspace mount found")
+}
+
+// AttachedContainerURI returns the folder URI with which VS Code (and its
+// forks) opens path inside a running container through the Dev Containers
+// extension's "attach to running container" mode.
+func AttachedContainerURI(containerName, path string) string {
+	// The authority is the hex-encoded JSON the extension itself writes;
+	// Docker reports container names with a leading slash.
+	authority, _ := json.Marshal(struct {
+		ContainerName string `json:"containerName"`
+	}{"/" + containerName})
+	return "vscode-remote://attached-container+" + hex.EncodeToString(authority) + path
+}
+
+// exportDir is the host directory a snapshot workspace is exported to.
+func exportDir(containerName string) (string, error) {
+	root, err := consts.ExportsSubdir()
+	if err != nil {
+		return "", fmt.Errorf("creating exports directory: %w", err)
+	}
+	return filepath.Join(root, containerName), nil
+}
+
+// launchEditor starts the editor without waiting for it: the editor CLIs
+// hand off to a running instance or fork a new one, and clawker's own
+// cancellation must not kill it.
+func launchEditor(_ context.Context, name string, args []string) error {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return fmt.Errorf("%s not found in PATH; install its command-line launcher or choose another --editor", name)
+		}
+		return fmt.Errorf("%s not usable: %w", name, err)
+	}
+	cmd := exec.Command(path, args...)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting %s: %w", name, err)
+	}
+	return cmd.Process.Release()
+}
+
+// shellJoin renders name and args as a command line, quoting arguments the
+// shell would split.
+func shellJoin(name string, args []string) string {
+	parts := []string{name}
+	for _, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t\n'\"\\$`*?[]{}()<>|&;#~") {
+			a = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+		}
+		parts = append(parts, a)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,179 @@
+package open
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmd/workspace/workspacetest"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/iostreams"
+)
+
+func TestNewCmdOpen(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantEditor string
+		wantExport bool
+		wantErr    string
+	}{
+		{name: "agent", input: "dev"},
+		{name: "editor flag", input: "dev --editor zed", wantEditor: "zed"},
+		{name: "export flag", input: "dev --editor cursor --export", wantEditor: "cursor", wantExport: true},
+		{name: "unknown editor", input: "dev --editor vim", wantErr: `--editor must be one of code, cursor, zed; got "vim"`},
+		{name: "no agent", input: "", wantErr: "requires 1 argument"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOpts *OpenOptions
+			cmd := NewCmdOpen(cmdutiltest.NewFactory(t).Factory, func(_ context.Context, opts *OpenOptions) error {
+				gotOpts = opts
+				return nil
+			})
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err = cmd.Execute()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "dev", gotOpts.agent)
+			assert.Equal(t, tt.wantEditor, gotOpts.Editor)
+			assert.Equal(t, tt.wantExport, gotOpts.Export)
+		})
+	}
+}
+
+type launched struct {
+	name string
+	args []string
+}
+
+// run executes `clawker open` against the fixture, with settingsYAML as the
+// user settings, and returns the editor launch it made.
+func run(t *testing.T, fx *workspacetest.Fixture, settingsYAML string, args ...string) (*launched, *bytes.Buffer, error) {
+	t.Helper()
+	ios, _, out, _ := iostreams.Test()
+	f := fx.Factory(ios)
+	cfg := configmocks.NewFromString("", settingsYAML)
+	f.Config = func() (config.Config, error) { return cfg, nil }
+
+	var got *launched
+	cmd := NewCmdOpen(f, func(ctx context.Context, opts *OpenOptions) error {
+		opts.Launch = func(_ context.Context, name string, args []string) error {
+			got = &launched{name: name, args: args}
+			return nil
+		}
+		return openRun(ctx, opts)
+	})
+	cmd.SetArgs(args)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	return got, out, cmd.Execute()
+}
+
+func TestOpenRun_BindOpensHostDirectory(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{Bind: true, Stopped: true})
+
+	got, _, err := run(t, fx, "", "dev", "--editor", "zed")
+	require.NoError(t, err)
+	assert.Equal(t, &launched{name: "zed", args: []string{fx.Host}}, got)
+}
+
+func TestOpenRun_SnapshotAttachesToContainer(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{})
+
+	got, _, err := run(t, fx, "editor:\n  default: cursor\n", "dev")
+	require.NoError(t, err)
+	require.Equal(t, "cursor", got.name, "settings.editor.default applies without --editor")
+	require.Len(t, got.args, 2)
+	assert.Equal(t, "--folder-uri", got.args[0])
+
+	uri := got.args[1]
+	rest, ok := strings.CutPrefix(uri, "vscode-remote://attached-container+")
+	require.True(t, ok, uri)
+	authority, path, _ := strings.Cut(rest, "/")
+	decoded, err := hex.DecodeString(authority)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"containerName":"/`+workspacetest.ContainerName+`"}`, string(decoded))
+	assert.Equal(t, workspacetest.Dest, "/"+path)
+}
+
+func TestOpenRun_SnapshotStoppedNeedsExport(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{Stopped: true})
+
+	_, _, err := run(t, fx, "", "dev")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not running")
+	assert.Contains(t, err.Error(), "--export")
+}
+
+func TestOpenRun_SnapshotExportsForZed(t *testing.T) {
+	t.Setenv(consts.EnvDataDir, t.TempDir())
+	fx := workspacetest.NewFixture(t, workspacetest.Options{Stopped: true})
+	require.NoError(t, os.WriteFile(fx.Workspace("agent.go"), []byte("package main // agent\n"), 0o644))
+
+	got, _, err := run(t, fx, "", "dev", "--editor", "zed")
+	require.NoError(t, err)
+	require.Equal(t, "zed", got.name)
+	require.Len(t, got.args, 1)
+
+	dir := got.args[0]
+	root, err := consts.ExportsSubdir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, workspacetest.ContainerName), dir)
+	data, err := os.ReadFile(filepath.Join(dir, "agent.go"))
+	require.NoError(t, err, "the export holds the container's copy")
+	assert.Equal(t, "package main // agent\n", string(data))
+	_, err = os.Stat(filepath.Join(dir, "pkg", "util.go"))
+	require.NoError(t, err)
+
+	// A second export replaces the first.
+	require.NoError(t, os.Remove(fx.Workspace("agent.go")))
+	_, _, err = run(t, fx, "", "dev", "--editor", "zed")
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "agent.go"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestOpenRun_Print(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{Bind: true})
+
+	got, out, err := run(t, fx, "", "dev", "--print")
+	require.NoError(t, err)
+	assert.Nil(t, got, "--print does not launch the editor")
+	assert.Equal(t, shellJoin("code", []string{fx.Host})+"\n", out.String())
+}
+
+func TestOpenRun_InvalidSetting(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{})
+
+	_, _, err := run(t, fx, "editor:\n  default: vim\n", "dev")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "settings.editor.default")
+}
+
+func TestShellJoin(t *testing.T) {
+	assert.Equal(t, "code /src/app", shellJoin("code", []string{"/src/app"}))
+	assert.Equal(t, `zed '/Users/me/My Projects/it'\''s'`, shellJoin("zed", []string{"/Users/me/My Projects/it's"}))
+}
@@ -13,13 +13,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/schmitthub/clawker/internal/cmd/plugin/shared"
-	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/iostreams"
 )
 
 func TestNewCmdInstall_DefaultScope(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	var captured *InstallOptions
 	cmd := NewCmdInstall(f, func(_ context.Context, opts *InstallOptions) error {
@@ -34,12 +33,11 @@ func TestNewCmdInstall_DefaultScope(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, captured)
 	assert.Equal(t, "user", captured.Scope)
-	assert.Equal(t, tio, captured.IOStreams)
+	assert.Equal(t, f.IOStreams, captured.IOStreams)
 }
 
 func TestNewCmdInstall_CustomScope(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	var captured *InstallOptions
 	cmd := NewCmdInstall(f, func(_ context.Context, opts *InstallOptions) error {
@@ -57,8 +55,7 @@ func TestNewCmdInstall_CustomScope(t *testing.T) {
 }
 
 func TestNewCmdInstall_ShortScope(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	var captured *InstallOptions
 	cmd := NewCmdInstall(f, func(_ context.Context, opts *InstallOptions) error {
@@ -76,8 +73,7 @@ func TestNewCmdInstall_ShortScope(t *testing.T) {
 }
 
 func TestNewCmdInstall_RejectsArgs(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	cmd := NewCmdInstall(f, func(_ context.Context, _ *InstallOptions) error { return nil })
 	cmd.SetArgs([]string{"extra-arg"})
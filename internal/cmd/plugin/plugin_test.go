@@ -7,13 +7,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/schmitthub/clawker/internal/cmd/plugin"
-	"github.com/schmitthub/clawker/internal/cmdutil"
-	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 )
 
 func TestNewCmdPlugin(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	cmd := plugin.NewCmdPlugin(f)
 
@@ -12,13 +12,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/schmitthub/clawker/internal/cmd/plugin/shared"
-	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/iostreams"
 )
 
 func TestNewCmdRemove_DefaultScope(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	var captured *RemoveOptions
 	cmd := NewCmdRemove(f, func(_ context.Context, opts *RemoveOptions) error {
@@ -33,12 +32,11 @@ func TestNewCmdRemove_DefaultScope(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, captured)
 	assert.Equal(t, "user", captured.Scope)
-	assert.Equal(t, tio, captured.IOStreams)
+	assert.Equal(t, f.IOStreams, captured.IOStreams)
 }
 
 func TestNewCmdRemove_CustomScope(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	var captured *RemoveOptions
 	cmd := NewCmdRemove(f, func(_ context.Context, opts *RemoveOptions) error {
@@ -56,8 +54,7 @@ func TestNewCmdRemove_CustomScope(t *testing.T) {
 }
 
 func TestNewCmdRemove_Aliases(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	cmd := NewCmdRemove(f, func(_ context.Context, _ *RemoveOptions) error { return nil })
 	assert.Contains(t, cmd.Aliases, "uninstall")
@@ -65,8 +62,7 @@ func TestNewCmdRemove_Aliases(t *testing.T) {
 }
 
 func TestNewCmdRemove_RejectsArgs(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	cmd := NewCmdRemove(f, func(_ context.Context, _ *RemoveOptions) error { return nil })
 	cmd.SetArgs([]string{"extra"})
@@ -9,13 +9,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/schmitthub/clawker/internal/cmd/plugin/shared"
-	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/iostreams"
 )
 
 func TestNewCmdShow_NoArgs(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	var captured *ShowOptions
 	cmd := NewCmdShow(f, func(_ context.Context, opts *ShowOptions) error {
@@ -29,12 +28,11 @@ func TestNewCmdShow_NoArgs(t *testing.T) {
 	err := cmd.Execute()
 	require.NoError(t, err)
 	require.NotNil(t, captured)
-	assert.Equal(t, tio, captured.IOStreams)
+	assert.Equal(t, f.IOStreams, captured.IOStreams)
 }
 
 func TestNewCmdShow_RejectsArgs(t *testing.T) {
-	tio, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: tio}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	cmd := NewCmdShow(f, func(_ context.Context, _ *ShowOptions) error { return nil })
 	cmd.SetArgs([]string{"extra"})
@@ -0,0 +1,180 @@
+// Package gc provides the project gc subcommand.
+package gc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/prompter"
+	"github.com/spf13/cobra"
+)
+
+// GCOptions contains the options for the project gc command.
+type GCOptions struct {
+	IOStreams      *iostreams.IOStreams
+	ProjectManager func() (project.ProjectManager, error)
+	Client         func(context.Context) (*docker.Client, error)
+	Prompter       func() *prompter.Prompter
+
+	DryRun bool
+	Yes    bool
+}
+
+// candidate is a registration whose root is gone, with whatever still
+// refers to it.
+type candidate struct {
+	name       string
+	root       string
+	containers int
+	worktrees  int
+}
+
+func (c candidate) collectable() bool { return c.containers == 0 && c.worktrees == 0 }
+
+// NewCmdGC creates the project gc command.
+func NewCmdGC(f *cmdutil.Factory, runF func(context.Context, *GCOptions) error) *cobra.Command {
+	opts := &GCOptions{
+		IOStreams:      f.IOStreams,
+		ProjectManager: f.ProjectManager,
+		Client:         f.Client,
+		Prompter:       f.Prompter,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove registry entries for projects that are gone",
+		Long: `Removes projects from the registry whose root directory no longer exists
+and that nothing refers to anymore: no clawker containers (running or
+stopped) carry the project's name, and none of its worktree directories
+remain on disk.
+
+A project whose root is missing but still has containers or worktrees is
+kept and reported. If the repository was moved rather than deleted, use
+'clawker project move' instead.
+
+Docker must be reachable: gc will not drop a project it cannot check.`,
+		Example: `  # Show what would be removed
+  clawker project gc --dry-run
+
+  # Remove without confirmation prompt
+  clawker project gc --yes`,
+		Args: cmdutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return gcRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be removed without removing it")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func gcRun(ctx context.Context, opts *GCOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	mgr, err := opts.ProjectManager()
+	if err != nil {
+		return fmt.Errorf("loading project manager: %w", err)
+	}
+	entries, err := mgr.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+
+	var candidates []candidate
+	for _, e := range entries {
+		if _, statErr := os.Stat(e.Root); !errors.Is(statErr, fs.ErrNotExist) {
+			continue
+		}
+		c := candidate{name: e.Name, root: e.Root}
+		for _, wt := range e.Worktrees {
+			if _, statErr := os.Stat(wt.Path); wt.Path != "" && statErr == nil {
+				c.worktrees++
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	if len(candidates) == 0 {
+		fmt.Fprintln(ios.ErrOut, "No dangling projects found.")
+		return nil
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker (needed to check for project containers): %w", err)
+	}
+	containers, err := client.ListContainers(ctx, true)
+	if err != nil {
+		return fmt.Errorf("listing containers: %w", err)
+	}
+	perProject := map[string]int{}
+	for _, c := range containers {
+		perProject[c.Project]++
+	}
+
+	var collect []candidate
+	for i := range candidates {
+		c := &candidates[i]
+		c.containers = perProject[c.name]
+		if c.collectable() {
+			collect = append(collect, *c)
+			fmt.Fprintf(ios.Out, "%s %s (root %s is gone)\n", cs.Muted("remove"), c.name, c.root)
+			continue
+		}
+		var refs []string
+		if c.containers > 0 {
+			refs = append(refs, fmt.Sprintf("%d container(s)", c.containers))
+		}
+		if c.worktrees > 0 {
+			refs = append(refs, fmt.Sprintf("%d worktree(s)", c.worktrees))
+		}
+		fmt.Fprintf(ios.Out, "%s %s (root %s is gone, but %s remain)\n",
+			cs.Muted("keep  "), c.name, c.root, strings.Join(refs, " and "))
+	}
+
+	if len(collect) == 0 || opts.DryRun {
+		return nil
+	}
+
+	if !opts.Yes {
+		if !ios.IsInteractive() {
+			return fmt.Errorf("--yes required in non-interactive mode")
+		}
+		confirmed, confirmErr := opts.Prompter().Confirm(
+			fmt.Sprintf("Remove %d project(s) from registry?", len(collect)), false)
+		if confirmErr != nil {
+			return confirmErr
+		}
+		if !confirmed {
+			return cmdutil.ErrAborted
+		}
+	}
+
+	var failed int
+	for _, c := range collect {
+		if err := mgr.Remove(ctx, c.root); err != nil {
+			failed++
+			fmt.Fprintf(ios.ErrOut, "%s %s: %v\n", cs.FailureIcon(), c.name, err)
+			continue
+		}
+		fmt.Fprintf(ios.Out, "%s Removed %s\n", cs.SuccessIcon(), c.name)
+	}
+	if failed > 0 {
+		fmt.Fprintf(ios.ErrOut, "\n%d of %d project(s) could not be removed\n", failed, len(collect))
+		return cmdutil.SilentError
+	}
+	return nil
+}
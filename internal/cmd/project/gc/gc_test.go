@@ -0,0 +1,118 @@
+package gc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	dockermocks "github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+)
+
+func TestNewCmdGC_Flags(t *testing.T) {
+	var got *GCOptions
+	cmd := NewCmdGC(cmdutiltest.NewFactory(t).Factory, func(_ context.Context, opts *GCOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"--dry-run", "-y"})
+	require.NoError(t, cmd.Execute())
+	assert.True(t, got.DryRun)
+	assert.True(t, got.Yes)
+}
+
+// registryFixture registers three projects: "live" (root exists), "gone"
+// (root deleted, nothing left) and "busy" (root deleted, a container left).
+func registryFixture(t *testing.T) (project.ProjectManager, *dockermocks.FakeClient) {
+	t.Helper()
+	ctx := context.Background()
+	mgr := projectmocks.NewTestProjectManager(t, nil)
+	base := t.TempDir()
+	for _, name := range []string{"live", "gone", "busy"} {
+		root := filepath.Join(base, name)
+		require.NoError(t, os.Mkdir(root, 0o755))
+		_, err := mgr.Register(ctx, name, root)
+		require.NoError(t, err)
+		if name != "live" {
+			require.NoError(t, os.Remove(root))
+		}
+	}
+	fake := dockermocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList(dockermocks.ContainerFixture("busy", "dev", "clawker-busy:latest"))
+	return mgr, fake
+}
+
+func testOptions(mgr project.ProjectManager, fake *dockermocks.FakeClient) (*GCOptions, *bytes.Buffer) {
+	ios, _, out, _ := iostreams.Test()
+	return &GCOptions{
+		IOStreams:      ios,
+		ProjectManager: func() (project.ProjectManager, error) { return mgr, nil },
+		Client:         func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+	}, out
+}
+
+func registeredNames(t *testing.T, mgr project.ProjectManager) []string {
+	t.Helper()
+	entries, err := mgr.List(context.Background())
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+func TestGCRun_RemovesOnlyUnreferenced(t *testing.T) {
+	mgr, fake := registryFixture(t)
+	opts, out := testOptions(mgr, fake)
+	opts.Yes = true
+
+	require.NoError(t, gcRun(context.Background(), opts))
+
+	assert.ElementsMatch(t, []string{"live", "busy"}, registeredNames(t, mgr))
+	assert.Contains(t, out.String(), "Removed gone")
+	assert.Contains(t, out.String(), "busy (root")
+	assert.Contains(t, out.String(), "1 container(s) remain")
+}
+
+func TestGCRun_DryRun(t *testing.T) {
+	mgr, fake := registryFixture(t)
+	opts, out := testOptions(mgr, fake)
+	opts.DryRun = true
+
+	require.NoError(t, gcRun(context.Background(), opts))
+
+	assert.ElementsMatch(t, []string{"live", "gone", "busy"}, registeredNames(t, mgr))
+	assert.Contains(t, out.String(), "remove gone")
+}
+
+func TestGCRun_DockerUnreachable(t *testing.T) {
+	mgr, _ := registryFixture(t)
+	opts, _ := testOptions(mgr, nil)
+	opts.Yes = true
+	opts.Client = func(context.Context) (*docker.Client, error) { return nil, errors.New("no daemon") }
+
+	err := gcRun(context.Background(), opts)
+	require.Error(t, err, "gc never drops a project it could not check")
+	assert.Len(t, registeredNames(t, mgr), 3)
+}
+
+func TestGCRun_RequiresYesNonInteractive(t *testing.T) {
+	mgr, fake := registryFixture(t)
+	opts, _ := testOptions(mgr, fake)
+
+	err := gcRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--yes")
+}
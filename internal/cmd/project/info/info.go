@@ -59,7 +59,10 @@ their health status.`,
 		},
 	}
 
-	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output as versioned JSON envelope")
+	cmdutil.EnableJSONOutput(cmd)
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.ProjectCompletions(f.ProjectManager))
 
 	return cmd
 }
@@ -86,7 +89,7 @@ func infoRun(ctx context.Context, opts *InfoOptions) error {
 	detail := buildDetail(state)
 
 	if opts.JSON {
-		return cmdutil.WriteJSON(ios.Out, detail)
+		return ios.JSONPrinter().Print("project.info", detail)
 	}
 
 	// Key-value display.
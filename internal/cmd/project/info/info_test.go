@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/project"
 	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
@@ -17,8 +18,7 @@ import (
 // --- Tier 1: Flag parsing tests ---
 
 func TestNewCmdInfo_RunFReceivesArgs(t *testing.T) {
-	ios, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: ios}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	called := false
 	cmd := NewCmdInfo(f, func(_ context.Context, opts *InfoOptions) error {
@@ -38,8 +38,7 @@ func TestNewCmdInfo_RunFReceivesArgs(t *testing.T) {
 }
 
 func TestNewCmdInfo_RequiresExactlyOneArg(t *testing.T) {
-	ios, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: ios}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	cmd := NewCmdInfo(f, func(_ context.Context, _ *InfoOptions) error {
 		return nil
@@ -191,6 +190,7 @@ func TestInfoRun_JSON(t *testing.T) {
 	require.NoError(t, err)
 
 	output := outBuf.String()
+	assert.Contains(t, output, `"kind":"project.info"`)
 	assert.Contains(t, output, `"name":"alpha"`)
 	assert.Contains(t, output, `"status":"missing"`)
 }
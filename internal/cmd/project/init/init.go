@@ -13,9 +13,11 @@ import (
 
 	"github.com/schmitthub/clawker/internal/auth"
 	"github.com/schmitthub/clawker/internal/bundler"
+	"github.com/schmitthub/clawker/internal/cmd/project/init/templates"
 	"github.com/schmitthub/clawker/internal/cmd/project/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/project"
@@ -86,6 +88,55 @@ const (
 	vcsSSHPort             = "22"
 )
 
+// Store paths set from the non-interactive answer flags; both are
+// customize-browser fields.
+const (
+	pathBuildPackages = "build.packages"
+	pathWorkspaceMode = "workspace.default_mode"
+)
+
+// answerSettings holds the flag-driven answers to the customize browser's
+// fields. A zero value leaves the preset's value in place.
+type answerSettings struct {
+	Harness  string   // build.harness
+	Packages []string // build.packages, replacing the preset's list
+	Mode     string   // workspace.default_mode
+}
+
+// applyAnswersToProject writes the answers into the project store by path,
+// the same way the customize browser does. Each value passes the project
+// write front door before it is staged, so the file written afterwards loads
+// cleanly.
+func applyAnswersToProject(store *storage.Store[config.Project], a answerSettings) error {
+	if a.Harness != "" {
+		if err := setValidated(store, fieldPathHarness, a.Harness); err != nil {
+			return err
+		}
+	}
+	if len(a.Packages) > 0 {
+		if err := setValidated(store, pathBuildPackages, a.Packages); err != nil {
+			return err
+		}
+	}
+	if a.Mode != "" {
+		if err := setValidated(store, pathWorkspaceMode, a.Mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setValidated stages value at path once config.ValidateProjectSet accepts it.
+func setValidated(store *storage.Store[config.Project], path string, value any) error {
+	if err := config.ValidateProjectSet(path, value); err != nil {
+		return err
+	}
+	if err := store.Set(path, value); err != nil {
+		return fmt.Errorf("setting %s: %w", path, err)
+	}
+	return nil
+}
+
 // applyVCSToProject writes the VCS provider's configuration into the project
 // store by path, the canonical Set(path, value) way — never a whole-struct
 // read-mutate-write:
@@ -167,6 +218,57 @@ func IsValidGitProtocol(s string) bool {
 	return slices.Contains(vcsProtocols(), s)
 }
 
+// ValidateFlags checks the non-interactive flags: every answer flag requires
+// --yes, and enumerated values must be valid. Shared by `project init` and the
+// top-level `init` alias.
+func ValidateFlags(opts *ProjectInitOptions) error {
+	if !opts.Yes {
+		if opts.Preset != "" {
+			return cmdutil.FlagErrorf("--preset requires --yes")
+		}
+		if opts.Template != "" {
+			return cmdutil.FlagErrorf("--template requires --yes")
+		}
+		if opts.VCS != "" || opts.GitProtocol != "" || opts.NoGPG {
+			return cmdutil.FlagErrorf("--vcs, --git-protocol, and --no-gpg require --yes")
+		}
+		if opts.Harness != "" || len(opts.Packages) > 0 || opts.Mode != "" || opts.AgentName != "" {
+			return cmdutil.FlagErrorf("--harness, --packages, --mode, and --agent-name require --yes")
+		}
+	}
+	if opts.Preset != "" && opts.Template != "" {
+		return cmdutil.FlagErrorf("--preset and --template cannot be used together")
+	}
+	if opts.VCS != "" && !IsValidVCSProvider(opts.VCS) {
+		return cmdutil.FlagErrorf(
+			"invalid --vcs value %q; valid: %s",
+			opts.VCS,
+			strings.Join(vcsProviders(), ", "),
+		)
+	}
+	if opts.GitProtocol != "" && !IsValidGitProtocol(opts.GitProtocol) {
+		return cmdutil.FlagErrorf(
+			"invalid --git-protocol value %q; valid: %s",
+			opts.GitProtocol,
+			strings.Join(vcsProtocols(), ", "),
+		)
+	}
+	if opts.Mode != "" {
+		if _, err := config.ParseMode(opts.Mode); err != nil {
+			return cmdutil.FlagErrorf("invalid --mode value %q; valid: %s, %s", opts.Mode, config.ModeBind, config.ModeSnapshot)
+		}
+	}
+	if slices.Contains(opts.Packages, "") {
+		return cmdutil.FlagErrorf("--packages entries cannot be empty")
+	}
+	if opts.AgentName != "" {
+		if err := docker.ValidateResourceName(opts.AgentName); err != nil {
+			return cmdutil.FlagErrorf("invalid --agent-name: %v", err)
+		}
+	}
+	return nil
+}
+
 // VCSProviderNames returns valid provider names for error messages.
 func VCSProviderNames() []string { return vcsProviders() }
 
@@ -181,11 +283,16 @@ type ProjectInitOptions struct {
 	Logger         func() (*logger.Logger, error)
 	ProjectManager func() (project.ProjectManager, error)
 
-	Name        string // Positional arg: project name
-	Preset      string // --preset flag: select a preset by name
-	VCS         string // --vcs flag: github|gitlab|bitbucket
-	GitProtocol string // --git-protocol flag: https|ssh
-	NoGPG       bool   // --no-gpg flag: disable GPG forwarding
+	Name        string   // Positional arg: project name
+	Preset      string   // --preset flag: select a preset by name
+	Template    string   // --template flag: gallery ID, template directory, or git URL
+	VCS         string   // --vcs flag: github|gitlab|bitbucket
+	GitProtocol string   // --git-protocol flag: https|ssh
+	NoGPG       bool     // --no-gpg flag: disable GPG forwarding
+	Harness     string   // --harness flag: build.harness
+	Packages    []string // --packages flag: build.packages, replacing the preset's
+	Mode        string   // --mode flag: workspace.default_mode
+	AgentName   string   // --agent-name flag: agent used in the next-steps commands
 	Force       bool
 	Yes         bool // Non-interactive mode
 }
@@ -217,7 +324,18 @@ If no project name is provided, you will be prompted to enter one (or accept the
 current directory name as the default).
 
 Use --yes/-y to skip all prompts (defaults to Bare preset with GitHub HTTPS).
-Combine --yes with --preset, --vcs, --git-protocol, and --no-gpg for full control.`,
+--template selects a starting config from the template gallery (see
+'clawker init templates list'), or from an organization template: a local
+directory or git URL with a clawker.yaml at its root. Custom templates are
+validated against the config schema before anything is written.
+Combine --yes with --preset, --vcs, --git-protocol, and --no-gpg for full control,
+and with --harness, --packages, and --mode to answer the customize fields the
+wizard offers. The result is the same config file the wizard writes, validated
+before it is written.
+
+The firewall switch is a global setting for every project, so init does not
+touch it; use 'clawker settings edit' to turn the firewall off.
+--agent-name fills in the agent name in the printed next steps.`,
 		Example: `  # Interactive setup with preset picker and VCS config
   clawker project init
 
@@ -228,9 +346,16 @@ Combine --yes with --preset, --vcs, --git-protocol, and --no-gpg for full contro
   clawker project init --yes --preset Go --vcs github
   clawker project init --yes --preset Python --vcs gitlab --git-protocol ssh
 
+  # Start from a gallery template or an organization template repo
+  clawker project init --yes --template monorepo
+  clawker project init --yes --template https://github.com/acme/clawker-templates.git
+
   # Non-interactive with SSH and GPG disabled
   clawker project init --yes --preset Rust --vcs github --git-protocol ssh --no-gpg
 
+  # Scripted bootstrap answering the customize fields
+  clawker project init --yes --preset Go --packages ripgrep,jq --mode snapshot --agent-name dev
+
   # Overwrite existing configuration
   clawker project init --force`,
 		Args: cobra.MaximumNArgs(1),
@@ -238,25 +363,8 @@ Combine --yes with --preset, --vcs, --git-protocol, and --no-gpg for full contro
 			if len(args) > 0 {
 				opts.Name = args[0]
 			}
-			if opts.Preset != "" && !opts.Yes {
-				return cmdutil.FlagErrorf("--preset requires --yes")
-			}
-			if (opts.VCS != "" || opts.GitProtocol != "" || opts.NoGPG) && !opts.Yes {
-				return cmdutil.FlagErrorf("--vcs, --git-protocol, and --no-gpg require --yes")
-			}
-			if opts.VCS != "" && !IsValidVCSProvider(opts.VCS) {
-				return cmdutil.FlagErrorf(
-					"invalid --vcs value %q; valid: %s",
-					opts.VCS,
-					strings.Join(vcsProviders(), ", "),
-				)
-			}
-			if opts.GitProtocol != "" && !IsValidGitProtocol(opts.GitProtocol) {
-				return cmdutil.FlagErrorf(
-					"invalid --git-protocol value %q; valid: %s",
-					opts.GitProtocol,
-					strings.Join(vcsProtocols(), ", "),
-				)
+			if err := ValidateFlags(opts); err != nil {
+				return err
 			}
 			if runF != nil {
 				return runF(cmd.Context(), opts)
@@ -268,9 +376,14 @@ Combine --yes with --preset, --vcs, --git-protocol, and --no-gpg for full contro
 	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Overwrite existing configuration files")
 	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Non-interactive mode, accept all defaults")
 	cmd.Flags().StringVar(&opts.Preset, "preset", "", "Select a language preset (requires --yes)")
+	cmd.Flags().StringVar(&opts.Template, "template", "", "Gallery template, template directory, or git URL (requires --yes)")
 	cmd.Flags().StringVar(&opts.VCS, "vcs", "", "VCS provider: github, gitlab, bitbucket (requires --yes)")
 	cmd.Flags().StringVar(&opts.GitProtocol, "git-protocol", "", "Git protocol: https, ssh (requires --yes)")
 	cmd.Flags().BoolVar(&opts.NoGPG, "no-gpg", false, "Disable GPG agent forwarding (requires --yes)")
+	cmd.Flags().StringVar(&opts.Harness, "harness", "", "Default harness to build (requires --yes)")
+	cmd.Flags().StringSliceVar(&opts.Packages, "packages", nil, "System packages, replacing the preset's (requires --yes)")
+	cmd.Flags().StringVar(&opts.Mode, "mode", "", "Default workspace mode: bind, snapshot (requires --yes)")
+	cmd.Flags().StringVar(&opts.AgentName, "agent-name", "", "Agent name for the printed next steps (requires --yes)")
 
 	cmd.RegisterFlagCompletionFunc( //nolint:errcheck,gosec // only errors on a programmer mistake (flag must exist); flags are defined above
 		"preset",
@@ -291,6 +404,21 @@ Combine --yes with --preset, --vcs, --git-protocol, and --no-gpg for full contro
 		},
 	)
 
+	cmd.RegisterFlagCompletionFunc( //nolint:errcheck,gosec // only errors on a programmer mistake (flag must exist); flags are defined above
+		"template",
+		func(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			return TemplateCompletions(), cobra.ShellCompDirectiveDefault
+		},
+	)
+	cmd.RegisterFlagCompletionFunc( //nolint:errcheck,gosec // only errors on a programmer mistake (flag must exist); flags are defined above
+		"mode",
+		func(_ *cobra.Command, _ []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			return ModeCompletions(), cobra.ShellCompDirectiveNoFileComp
+		},
+	)
+
+	cmd.AddCommand(templates.NewCmdTemplates(f))
+
 	return cmd
 }
 
@@ -341,6 +469,26 @@ func GitProtocolCompletions() []cobra.Completion {
 	}
 }
 
+// TemplateCompletions returns cobra completions for the --template flag: the
+// gallery IDs. File completion stays on for template directories.
+func TemplateCompletions() []cobra.Completion {
+	var completions []cobra.Completion
+	for _, p := range config.Presets() {
+		if p.ID != "" {
+			completions = append(completions, cobra.CompletionWithDesc(p.ID, p.Description))
+		}
+	}
+	return completions
+}
+
+// ModeCompletions returns cobra completions for the --mode flag.
+func ModeCompletions() []cobra.Completion {
+	return []cobra.Completion{
+		cobra.CompletionWithDesc(string(config.ModeBind), "Bind mount the project live"),
+		cobra.CompletionWithDesc(string(config.ModeSnapshot), "Copy the project into an isolated volume"),
+	}
+}
+
 // wizardContext captures external state needed by wizard field definitions.
 type wizardContext struct {
 	configExists   bool
@@ -575,14 +723,21 @@ func runNonInteractive(ctx context.Context, opts *ProjectInitOptions) error {
 	}
 	fmt.Fprintln(ios.ErrOut)
 
-	presetName := "Bare"
-	if opts.Preset != "" {
-		presetName = opts.Preset
-	}
-
-	preset, ok := presetByName(config.Presets(), presetName)
-	if !ok {
-		return fmt.Errorf("unknown preset %q (see --help for available presets)", presetName)
+	var preset config.Preset
+	if opts.Template != "" {
+		var err error
+		if preset, err = resolveTemplate(ctx, opts.Template); err != nil {
+			return err
+		}
+	} else {
+		presetName := "Bare"
+		if opts.Preset != "" {
+			presetName = opts.Preset
+		}
+		var ok bool
+		if preset, ok = presetByName(config.Presets(), presetName); !ok {
+			return fmt.Errorf("unknown preset %q (see --help for available presets)", presetName)
+		}
 	}
 
 	vcs := defaultVCSSettings()
@@ -596,6 +751,11 @@ func runNonInteractive(ctx context.Context, opts *ProjectInitOptions) error {
 		vcs.ForwardGPG = false
 	}
 
+	if opts.Harness != "" && !bundler.IsKnownHarness(env.cfg, opts.Harness) {
+		return fmt.Errorf("unknown harness %q; known: %s",
+			opts.Harness, strings.Join(bundler.KnownHarnessNames(env.cfg), ", "))
+	}
+
 	configPath := filepath.Join(env.wd, env.configFileName)
 
 	return performProjectSetup(ctx, performSetupInput{
@@ -606,6 +766,8 @@ func runNonInteractive(ctx context.Context, opts *ProjectInitOptions) error {
 		projectName: env.projectName,
 		preset:      preset,
 		vcs:         vcs,
+		answers:     answerSettings{Harness: opts.Harness, Packages: opts.Packages, Mode: opts.Mode},
+		agentName:   opts.AgentName,
 		configPath:  configPath,
 		wd:          env.wd,
 		force:       opts.Force,
@@ -624,6 +786,8 @@ type performSetupInput struct {
 	projectName string
 	preset      config.Preset
 	vcs         vcsSettings
+	answers     answerSettings
+	agentName   string // shown in the next steps; "" prints a placeholder
 	configPath  string
 	wd          string
 	force       bool
@@ -670,6 +834,9 @@ func performProjectSetup(ctx context.Context, in performSetupInput) error {
 	if err = applyVCSToProject(store, in.vcs); err != nil {
 		return fmt.Errorf("applying VCS config: %w", err)
 	}
+	if err = applyAnswersToProject(store, in.answers); err != nil {
+		return fmt.Errorf("applying init flags: %w", err)
+	}
 
 	if in.customize {
 		// Save destinations: the new project file, plus the user-level
@@ -781,7 +948,11 @@ func performProjectSetup(ctx context.Context, in performSetupInput) error {
 	fmt.Fprintln(in.ios.Out)
 	fmt.Fprintln(in.ios.Out, "Next Steps:")
 	fmt.Fprintf(in.ios.Out, "  1. Run 'clawker build' to build your project's container image\n")
-	fmt.Fprintf(in.ios.Out, "  2. Run 'clawker run -it --agent <agent-name> @' to start a container\n")
+	agentName := in.agentName
+	if agentName == "" {
+		agentName = "<agent-name>"
+	}
+	fmt.Fprintf(in.ios.Out, "  2. Run 'clawker run -it --agent %s @' to start a container\n", agentName)
 	fmt.Fprintln(in.ios.Out)
 	fmt.Fprintf(in.ios.Out, "To customize further, run 'clawker project edit'\n")
 	return nil
@@ -98,6 +98,16 @@ func TestNewCmdProjectInit_FlagParsing(t *testing.T) {
 		{name: "invalid vcs", args: []string{"--yes", "--vcs", "svn"}, wantErr: true},
 		{name: "invalid git-protocol", args: []string{"--yes", "--git-protocol", "ftp"}, wantErr: true},
 		{name: "all vcs flags", args: []string{"--yes", "--preset", "Go", "--vcs", "gitlab", "--git-protocol", "ssh", "--no-gpg"}, wantYes: true, wantPreset: "Go"},
+		{name: "answer flags with yes", args: []string{"--yes", "--harness", "claude", "--packages", "jq,ripgrep", "--mode", "snapshot", "--agent-name", "dev"}, wantYes: true},
+		{name: "packages without yes", args: []string{"--packages", "jq"}, wantErr: true},
+		{name: "agent-name without yes", args: []string{"--agent-name", "dev"}, wantErr: true},
+		{name: "invalid mode", args: []string{"--yes", "--mode", "copy"}, wantErr: true},
+		{name: "no firewall flag", args: []string{"--yes", "--firewall", "off"}, wantErr: true},
+		{name: "invalid agent-name", args: []string{"--yes", "--agent-name", "bad name"}, wantErr: true},
+		{name: "empty package entry", args: []string{"--yes", "--packages", "jq,,git"}, wantErr: true},
+		{name: "template with yes", args: []string{"--yes", "--template", "node"}, wantYes: true},
+		{name: "template without yes", args: []string{"--template", "node"}, wantErr: true},
+		{name: "template and preset", args: []string{"--yes", "--template", "node", "--preset", "Go"}, wantErr: true},
 	}
 
 	for _, tt := range tests {
@@ -633,6 +643,68 @@ func TestRunNonInteractive_VCSFlags(t *testing.T) {
 	assert.False(t, *snap.Security.GitCredentials.ForwardGPG)
 }
 
+func TestRunNonInteractive_AnswerFlags(t *testing.T) {
+	wd := chdirTemp(t)
+
+	tio, _, out, _ := iostreams.Test()
+	cfg := configmocks.NewIsolatedTestConfig(t)
+	mockPM := projectmocks.NewMockProjectManager()
+	mockPM.RegisterFunc = func(_ context.Context, name string, repoPath string) (project.Project, error) {
+		return projectmocks.NewMockProject(name, repoPath), nil
+	}
+
+	opts := &ProjectInitOptions{
+		IOStreams:      tio,
+		Config:         func() (config.Config, error) { return cfg, nil },
+		Logger:         func() (*logger.Logger, error) { return logger.Nop(), nil },
+		ProjectManager: func() (project.ProjectManager, error) { return mockPM, nil },
+		Yes:            true,
+		Preset:         "Rust",
+		Harness:        "claude",
+		Packages:       []string{"jq", "ripgrep"},
+		Mode:           "snapshot",
+		AgentName:      "dev",
+	}
+
+	require.NoError(t, Run(context.Background(), opts))
+
+	content, err := os.ReadFile(filepath.Join(wd, "."+cfg.ProjectConfigFileName()))
+	require.NoError(t, err)
+	reloaded, err := storage.New[config.Project](
+		string(content),
+		storage.WithDefaultsFromStruct[config.Project](),
+	)
+	require.NoError(t, err)
+
+	snap := reloaded.Read()
+	assert.Equal(t, "claude", snap.Build.Harness)
+	assert.Equal(t, []string{"jq", "ripgrep"}, snap.Build.Packages, "--packages replaces the preset's list")
+	assert.Equal(t, []string{"rust"}, snap.Build.Stacks, "the rest of the preset is kept")
+	assert.Equal(t, "snapshot", snap.Workspace.DefaultMode)
+
+	assert.True(t, cfg.SettingsStore().Read().Firewall.FirewallEnabled(), "init leaves the global firewall switch alone")
+	assert.Contains(t, out.String(), "clawker run -it --agent dev @")
+}
+
+func TestRunNonInteractive_UnknownHarness(t *testing.T) {
+	chdirTemp(t)
+
+	tio, _, _, _ := iostreams.Test()
+	cfg := configmocks.NewIsolatedTestConfig(t)
+	opts := &ProjectInitOptions{
+		IOStreams:      tio,
+		Config:         func() (config.Config, error) { return cfg, nil },
+		Logger:         func() (*logger.Logger, error) { return logger.Nop(), nil },
+		ProjectManager: func() (project.ProjectManager, error) { return projectmocks.NewMockProjectManager(), nil },
+		Yes:            true,
+		Harness:        "nope",
+	}
+
+	err := Run(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown harness "nope"`)
+}
+
 func TestPerformProjectSetup_SubdirSkipsRegistrationAndIgnore(t *testing.T) {
 	wd := chdirTemp(t)
 
@@ -0,0 +1,79 @@
+package init
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/bundle/fetch"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// resolveTemplate maps a --template value to the preset it seeds the project
+// config from, trying in order:
+//
+//   - a gallery ID (config.PresetByID): node, python, go, rust, monorepo, ...
+//   - a git clone URL, cloned at its default branch into a temporary directory
+//   - a local template directory
+//
+// A template directory holds a project config file (.clawker.yaml or
+// clawker.yaml) at its root. Its content is decoded strictly here, before
+// anything is written, so a typo in an organization template fails the init
+// instead of silently dropping the key.
+func resolveTemplate(ctx context.Context, spec string) (config.Preset, error) {
+	if p, ok := config.PresetByID(spec); ok {
+		return p, nil
+	}
+
+	dir := spec
+	if isTemplateURL(spec) {
+		tmp, err := os.MkdirTemp("", "clawker-template-*")
+		if err != nil {
+			return config.Preset{}, fmt.Errorf("creating template clone dir: %w", err)
+		}
+		defer os.RemoveAll(tmp)
+		if _, err := fetch.NewFetcher().Clone(ctx, fetch.CloneOptions{URL: spec, Dir: tmp}); err != nil {
+			return config.Preset{}, fmt.Errorf("cloning template %s: %w", spec, err)
+		}
+		dir = tmp
+	} else if info, err := os.Stat(spec); err != nil || !info.IsDir() {
+		return config.Preset{}, fmt.Errorf(
+			"unknown template %q: not a gallery template, directory, or git URL (see 'clawker init templates list')", spec)
+	}
+
+	content, err := readTemplateDir(dir)
+	if err != nil {
+		return config.Preset{}, fmt.Errorf("template %s: %w", spec, err)
+	}
+	if _, err := config.NewProjectStoreFromTemplate(content); err != nil {
+		return config.Preset{}, fmt.Errorf("template %s: %w", spec, err)
+	}
+	return config.Preset{Name: spec, YAML: content}, nil
+}
+
+// readTemplateDir returns the project config file at the root of a template
+// directory; the dotfile wins when both exist, as in a project root.
+func readTemplateDir(dir string) (string, error) {
+	for _, name := range []string{"." + consts.ProjectConfigFile, consts.ProjectConfigFile} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("no .%s or %s at the template root", consts.ProjectConfigFile, consts.ProjectConfigFile)
+}
+
+// isTemplateURL reports whether a --template value is a git clone URL (scheme
+// form or scp ssh form).
+func isTemplateURL(spec string) bool {
+	return strings.Contains(spec, "://") || strings.HasPrefix(spec, "git@")
+}
@@ -0,0 +1,112 @@
+package init
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/bundle/bundletest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+	"github.com/schmitthub/clawker/internal/storage"
+)
+
+const orgTemplate = `build:
+  stacks:
+    - go
+  packages:
+    - protobuf-compiler
+workspace:
+  default_mode: snapshot
+`
+
+func TestResolveTemplate_Gallery(t *testing.T) {
+	p, err := resolveTemplate(context.Background(), "monorepo")
+	require.NoError(t, err)
+	assert.Equal(t, "Monorepo", p.Name)
+}
+
+func TestResolveTemplate_LocalDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "clawker.yaml"), []byte(orgTemplate), 0o644))
+
+	p, err := resolveTemplate(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, orgTemplate, p.YAML)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".clawker.yaml"), []byte("build:\n  stacks: [rust]\n"), 0o644))
+	p, err = resolveTemplate(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Contains(t, p.YAML, "rust", "the dotfile shadows clawker.yaml")
+}
+
+func TestResolveTemplate_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := resolveTemplate(ctx, "cobol")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "templates list")
+
+	empty := t.TempDir()
+	_, err = resolveTemplate(ctx, empty)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no .clawker.yaml or clawker.yaml")
+
+	typo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(typo, "clawker.yaml"), []byte("workspace:\n  mode: snapshot\n"), 0o644))
+	_, err = resolveTemplate(ctx, typo)
+	require.Error(t, err, "unknown keys are rejected before anything is written")
+	assert.Contains(t, err.Error(), "mode")
+}
+
+func TestResolveTemplate_GitURL(t *testing.T) {
+	srv := bundletest.New(t)
+	repo := srv.InitRepo(t, "templates")
+	repo.Commit(t, "initial", map[string]string{"clawker.yaml": orgTemplate})
+
+	p, err := resolveTemplate(context.Background(), srv.HTTPURL("templates"))
+	require.NoError(t, err)
+	assert.Equal(t, orgTemplate, p.YAML)
+}
+
+func TestRunNonInteractive_TemplateFlag(t *testing.T) {
+	wd := chdirTemp(t)
+	tmplDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmplDir, "clawker.yaml"), []byte(orgTemplate), 0o644))
+
+	tio, _, _, _ := iostreams.Test()
+	cfg := configmocks.NewIsolatedTestConfig(t)
+	mockPM := projectmocks.NewMockProjectManager()
+	mockPM.RegisterFunc = func(_ context.Context, name string, repoPath string) (project.Project, error) {
+		return projectmocks.NewMockProject(name, repoPath), nil
+	}
+
+	opts := &ProjectInitOptions{
+		IOStreams:      tio,
+		Config:         func() (config.Config, error) { return cfg, nil },
+		Logger:         func() (*logger.Logger, error) { return logger.Nop(), nil },
+		ProjectManager: func() (project.ProjectManager, error) { return mockPM, nil },
+		Yes:            true,
+		Template:       tmplDir,
+	}
+	require.NoError(t, Run(context.Background(), opts))
+
+	content, err := os.ReadFile(filepath.Join(wd, "."+cfg.ProjectConfigFileName()))
+	require.NoError(t, err)
+	reloaded, err := storage.New[config.Project](string(content), storage.WithDefaultsFromStruct[config.Project]())
+	require.NoError(t, err)
+
+	snap := reloaded.Read()
+	assert.Equal(t, []string{"go"}, snap.Build.Stacks)
+	assert.Contains(t, snap.Build.Packages, "protobuf-compiler")
+	assert.Equal(t, "snapshot", snap.Workspace.DefaultMode)
+	assert.Contains(t, snap.Security.Firewall.AddDomains, "github.com", "VCS settings still apply on top of a template")
+}
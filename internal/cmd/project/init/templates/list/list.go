@@ -0,0 +1,112 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// ListOptions holds dependencies for the init templates list command.
+type ListOptions struct {
+	IOStreams *iostreams.IOStreams
+	TUI       *tui.TUI
+	Format    *cmdutil.FormatFlags
+}
+
+// templateEntry is one row of the listing.
+type templateEntry struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description"`
+	Stacks      []string `json:"stacks"`
+}
+
+// NewCmdList creates the `clawker init templates list` command.
+func NewCmdList(f *cmdutil.Factory, runF func(context.Context, *ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IOStreams: f.IOStreams,
+		TUI:       f.TUI,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List gallery templates",
+		Long: `Lists the templates shipped with clawker, by the ID --template takes,
+with the language stacks each one installs.`,
+		Example: `  # List templates
+  clawker init templates list
+
+  # IDs only
+  clawker init templates list -q
+
+  # Output as JSON
+  clawker init templates list --json`,
+		Args: cmdutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	opts.Format = cmdutil.AddFormatFlags(cmd)
+	cmd.Flags().Lookup("quiet").Usage = "Only display template IDs"
+
+	return cmd
+}
+
+func listRun(_ context.Context, opts *ListOptions) error {
+	entries := []templateEntry{}
+	for _, p := range config.Presets() {
+		if p.ID == "" {
+			continue
+		}
+		var proj config.Project
+		if err := yaml.Unmarshal([]byte(p.YAML), &proj); err != nil {
+			return fmt.Errorf("decoding template %s: %w", p.ID, err)
+		}
+		// Non-nil so a stackless template encodes as [] rather than null.
+		stacks := append([]string{}, proj.Build.Stacks...)
+		entries = append(entries, templateEntry{ID: p.ID, Description: p.Description, Stacks: stacks})
+	}
+
+	ios := opts.IOStreams
+	switch {
+	case opts.Format.Quiet:
+		for _, e := range entries {
+			fmt.Fprintln(ios.Out, e.ID)
+		}
+		return nil
+
+	case opts.Format.IsJSON():
+		return opts.Format.WriteJSON(ios, "init.templates.list", entries)
+
+	case opts.Format.IsTemplate():
+		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(entries))
+
+	default:
+		tp := opts.TUI.NewTable("ID", "STACKS", "DESCRIPTION")
+		cs := ios.ColorScheme()
+		for _, e := range entries {
+			stacks := strings.Join(e.Stacks, ", ")
+			if stacks == "" {
+				stacks = cs.Muted("(none)")
+			}
+			tp.AddRow(e.ID, stacks, e.Description)
+		}
+		if err := tp.Render(); err != nil {
+			return err
+		}
+		fmt.Fprintln(ios.ErrOut)
+		fmt.Fprintln(ios.ErrOut, "Organization templates: pass a directory or git URL with a clawker.yaml at its root to --template.")
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdList_RejectsArgs(t *testing.T) {
+	cmd := NewCmdList(cmdutiltest.NewFactory(t).Factory, func(context.Context, *ListOptions) error { return nil })
+	cmd.SetArgs([]string{"node"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	_, err := cmd.ExecuteC()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "accepts no arguments")
+}
+
+func newOpts(format *cmdutil.FormatFlags) (*ListOptions, *bytes.Buffer, *bytes.Buffer) {
+	ios, _, outBuf, errBuf := iostreams.Test()
+	return &ListOptions{IOStreams: ios, TUI: tui.NewTUI(ios), Format: format}, outBuf, errBuf
+}
+
+func TestListRun_Table(t *testing.T) {
+	opts, outBuf, errBuf := newOpts(&cmdutil.FormatFlags{})
+	require.NoError(t, listRun(context.Background(), opts))
+
+	output := outBuf.String()
+	assert.Contains(t, output, "STACKS")
+	assert.Contains(t, output, "node, python, go")
+	assert.NotContains(t, output, "Build from scratch")
+	assert.Contains(t, errBuf.String(), "Organization templates")
+}
+
+func TestListRun_Quiet(t *testing.T) {
+	opts, outBuf, _ := newOpts(&cmdutil.FormatFlags{Quiet: true})
+	require.NoError(t, listRun(context.Background(), opts))
+
+	for _, id := range []string{"node", "python", "go", "rust", "monorepo"} {
+		assert.Contains(t, outBuf.String(), id+"\n")
+	}
+}
+
+func TestListRun_JSON(t *testing.T) {
+	format, err := cmdutil.ParseFormat("json")
+	require.NoError(t, err)
+	opts, outBuf, _ := newOpts(&cmdutil.FormatFlags{Format: format})
+	require.NoError(t, listRun(context.Background(), opts))
+
+	var got []templateEntry
+	require.NoError(t, json.Unmarshal(outBuf.Bytes(), &got))
+	byID := map[string]templateEntry{}
+	for _, e := range got {
+		byID[e.ID] = e
+	}
+	assert.Equal(t, []string{"rust"}, byID["rust"].Stacks)
+	assert.Equal(t, []string{}, byID["bare"].Stacks)
+}
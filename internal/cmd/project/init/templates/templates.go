@@ -0,0 +1,29 @@
+package templates
+
+import (
+	"github.com/schmitthub/clawker/internal/cmd/project/init/templates/list"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdTemplates creates the `clawker init templates` parent command.
+func NewCmdTemplates(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "Inspect the init template gallery",
+		Long: `Inspect the templates 'clawker init --template' can start a project from.
+
+The gallery ships with clawker. An organization template is any local
+directory or git repository with a clawker.yaml at its root; pass its path
+or clone URL to --template.`,
+		Example: `  # List gallery templates
+  clawker init templates list
+
+  # Start a project from one
+  clawker init --yes --template python`,
+	}
+
+	cmd.AddCommand(list.NewCmdList(f, nil))
+
+	return cmd
+}
@@ -98,7 +98,7 @@ func listRun(ctx context.Context, opts *ListOptions) error {
 		return nil
 
 	case opts.Format.IsJSON():
-		return cmdutil.WriteJSON(ios.Out, rows)
+		return opts.Format.WriteJSON(ios, "project.list", rows)
 
 	case opts.Format.IsTemplate():
 		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(rows))
@@ -113,7 +113,13 @@ func listRun(ctx context.Context, opts *ListOptions) error {
 			}
 			tp.AddRow(r.Name, r.Root, strconv.Itoa(r.Worktrees), status)
 		}
-		return tp.Render()
+		if err := tp.Render(); err != nil {
+			return err
+		}
+		if missing := countMissing(rows); missing > 0 {
+			fmt.Fprintf(ios.ErrOut, "\n%d project root(s) missing. If moved: 'clawker project move NAME NEW-ROOT'. If deleted: 'clawker project gc'.\n", missing)
+		}
+		return nil
 	}
 }
 
@@ -129,3 +135,14 @@ func buildProjectRows(states []project.ProjectState) []projectRow {
 	}
 	return rows
 }
+
+// countMissing returns how many rows have a missing root directory.
+func countMissing(rows []projectRow) int {
+	n := 0
+	for _, r := range rows {
+		if r.Status == string(project.ProjectMissing) {
+			n++
+		}
+	}
+	return n
+}
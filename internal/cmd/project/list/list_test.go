@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/shlex"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/project"
 	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
@@ -43,8 +44,7 @@ func TestNewCmdList(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ios, _, _, _ := iostreams.Test()
-			f := &cmdutil.Factory{IOStreams: ios}
+			f := cmdutiltest.NewFactory(t).Factory
 
 			var gotOpts *ListOptions
 			cmd := NewCmdList(f, func(_ context.Context, opts *ListOptions) error {
@@ -81,8 +81,7 @@ func TestNewCmdList_FormatFlags(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ios, _, _, _ := iostreams.Test()
-			f := &cmdutil.Factory{IOStreams: ios}
+			f := cmdutiltest.NewFactory(t).Factory
 
 			cmd := NewCmdList(f, func(_ context.Context, _ *ListOptions) error {
 				return nil
@@ -151,7 +150,7 @@ func TestListRun_Table(t *testing.T) {
 		}, nil
 	}
 
-	ios, _, outBuf, _ := iostreams.Test()
+	ios, _, outBuf, errBuf := iostreams.Test()
 	opts := &ListOptions{
 		IOStreams:      ios,
 		TUI:            tui.NewTUI(ios),
@@ -167,6 +166,7 @@ func TestListRun_Table(t *testing.T) {
 	assert.Contains(t, output, "beta")
 	assert.Contains(t, output, "NAME")
 	assert.Contains(t, output, "ROOT")
+	assert.Contains(t, errBuf.String(), "1 project root(s) missing")
 }
 
 func TestListRun_Quiet(t *testing.T) {
@@ -0,0 +1,108 @@
+// Package move provides the project move subcommand.
+package move
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// MoveOptions contains the options for the project move command.
+type MoveOptions struct {
+	IOStreams      *iostreams.IOStreams
+	ProjectManager func() (project.ProjectManager, error)
+
+	Name    string
+	NewRoot string
+	Force   bool
+}
+
+// NewCmdMove creates the project move command.
+func NewCmdMove(f *cmdutil.Factory, runF func(context.Context, *MoveOptions) error) *cobra.Command {
+	opts := &MoveOptions{
+		IOStreams:      f.IOStreams,
+		ProjectManager: f.ProjectManager,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "move NAME NEW-ROOT",
+		Aliases: []string{"mv"},
+		Short:   "Point a project at the directory its repository moved to",
+		Long: `Updates a project's registered root after the repository was moved on disk.
+It does not move any files — move the repository first, then run this.
+
+The project keeps its name and worktrees. Worktree paths inside the old root
+are rewritten, and each worktree's git link is re-pointed at the moved
+repository (what 'git worktree repair' does).
+
+'clawker project list' shows a moved project's root as missing.`,
+		Example: `  # After mv ~/src/app ~/work/app
+  clawker project move app ~/work/app`,
+		Args: cmdutil.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Name, opts.NewRoot = args[0], args[1]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return moveRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Move the registration even though the old root still exists")
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.ProjectCompletions(f.ProjectManager))
+
+	return cmd
+}
+
+func moveRun(ctx context.Context, opts *MoveOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	newRoot, err := filepath.Abs(opts.NewRoot)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	mgr, err := opts.ProjectManager()
+	if err != nil {
+		return fmt.Errorf("loading project manager: %w", err)
+	}
+	entries, err := mgr.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+	var oldRoot string
+	for _, e := range entries {
+		if e.Name == opts.Name {
+			oldRoot = e.Root
+			break
+		}
+	}
+	if oldRoot == "" {
+		return fmt.Errorf("project %q is not registered; use 'clawker project list' to see registered projects", opts.Name)
+	}
+
+	// A root that still exists usually means the repository was copied, not
+	// moved — re-pointing would strand the original.
+	if _, statErr := os.Stat(oldRoot); !errors.Is(statErr, fs.ErrNotExist) && !opts.Force {
+		return fmt.Errorf("project root %s still exists; move the repository first, or pass --force", oldRoot)
+	}
+
+	if _, err := mgr.Relocate(ctx, oldRoot, newRoot); err != nil {
+		if errors.Is(err, project.ErrProjectExists) {
+			return fmt.Errorf("%s is already registered as another project", newRoot)
+		}
+		return fmt.Errorf("moving project: %w", err)
+	}
+	fmt.Fprintf(ios.Out, "%s Moved %s: %s → %s\n", cs.SuccessIcon(), opts.Name, oldRoot, newRoot)
+	return nil
+}
@@ -0,0 +1,75 @@
+package move
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+)
+
+func TestNewCmdMove_ArgsAndFlags(t *testing.T) {
+	var got *MoveOptions
+	cmd := NewCmdMove(cmdutiltest.NewFactory(t).Factory, func(_ context.Context, opts *MoveOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"app", "/new/root", "--force"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "app", got.Name)
+	assert.Equal(t, "/new/root", got.NewRoot)
+	assert.True(t, got.Force)
+}
+
+func testOptions(mgr project.ProjectManager, name, newRoot string) *MoveOptions {
+	ios, _, _, _ := iostreams.Test()
+	return &MoveOptions{
+		IOStreams:      ios,
+		ProjectManager: func() (project.ProjectManager, error) { return mgr, nil },
+		Name:           name,
+		NewRoot:        newRoot,
+	}
+}
+
+func TestMoveRun(t *testing.T) {
+	ctx := context.Background()
+	mgr := projectmocks.NewTestProjectManager(t, nil)
+	gone := filepath.Join(t.TempDir(), "app")
+	newRoot := t.TempDir()
+	_, err := mgr.Register(ctx, "app", gone)
+	require.NoError(t, err)
+
+	require.NoError(t, moveRun(ctx, testOptions(mgr, "app", newRoot)))
+
+	got, err := mgr.Get(ctx, newRoot)
+	require.NoError(t, err)
+	assert.Equal(t, "app", got.Name())
+}
+
+func TestMoveRun_OldRootStillExists(t *testing.T) {
+	ctx := context.Background()
+	mgr := projectmocks.NewTestProjectManager(t, nil)
+	oldRoot, newRoot := t.TempDir(), t.TempDir()
+	_, err := mgr.Register(ctx, "app", oldRoot)
+	require.NoError(t, err)
+
+	err = moveRun(ctx, testOptions(mgr, "app", newRoot))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+
+	opts := testOptions(mgr, "app", newRoot)
+	opts.Force = true
+	require.NoError(t, moveRun(ctx, opts))
+}
+
+func TestMoveRun_UnknownProject(t *testing.T) {
+	err := moveRun(context.Background(), testOptions(projectmocks.NewTestProjectManager(t, nil), "ghost", t.TempDir()))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not registered")
+}
@@ -2,11 +2,15 @@ package project
 
 import (
 	projectedit "github.com/schmitthub/clawker/internal/cmd/project/edit"
+	projectgc "github.com/schmitthub/clawker/internal/cmd/project/gc"
 	projectinfo "github.com/schmitthub/clawker/internal/cmd/project/info"
 	projectinit "github.com/schmitthub/clawker/internal/cmd/project/init"
 	projectlist "github.com/schmitthub/clawker/internal/cmd/project/list"
+	projectmove "github.com/schmitthub/clawker/internal/cmd/project/move"
 	projectregister "github.com/schmitthub/clawker/internal/cmd/project/register"
 	projectremove "github.com/schmitthub/clawker/internal/cmd/project/remove"
+	projectrename "github.com/schmitthub/clawker/internal/cmd/project/rename"
+	projecttrust "github.com/schmitthub/clawker/internal/cmd/project/trust"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/spf13/cobra"
 )
@@ -34,6 +38,16 @@ Use 'clawker project init' to set up a new project in the current directory.`,
   # Remove a project from registry
   clawker project remove my-project
 
+  # Rename a project, or re-point it after moving the repository
+  clawker project rename my-project my-service
+  clawker project move my-service ~/work/my-service
+
+  # Drop registry entries for deleted projects
+  clawker project gc --dry-run
+
+  # Approve the hooks and other host commands in the project's config
+  clawker project trust
+
   # Interactively edit project configuration
   clawker project edit`,
 	}
@@ -44,6 +58,10 @@ Use 'clawker project init' to set up a new project in the current directory.`,
 	cmd.AddCommand(projectlist.NewCmdList(f, nil))
 	cmd.AddCommand(projectinfo.NewCmdInfo(f, nil))
 	cmd.AddCommand(projectremove.NewCmdRemove(f, nil))
+	cmd.AddCommand(projectrename.NewCmdRename(f, nil))
+	cmd.AddCommand(projectmove.NewCmdMove(f, nil))
+	cmd.AddCommand(projectgc.NewCmdGC(f, nil))
+	cmd.AddCommand(projecttrust.NewCmdTrust(f, nil))
 
 	return cmd
 }
@@ -37,8 +37,9 @@ func NewCmdProjectRegister(f *cmdutil.Factory, runF func(context.Context, *Regis
 	}
 
 	cmd := &cobra.Command{
-		Use:   "register [project-name]",
-		Short: "Register an existing clawker project in the local registry",
+		Use:     "register [project-name]",
+		Aliases: []string{"add"},
+		Short:   "Register an existing clawker project in the local registry",
 		Long: `Registers the project in the current directory in the local project registry
 without modifying the configuration file.
 
@@ -57,6 +57,8 @@ Use 'clawker project list' to see registered project names.`,
 
 	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Skip confirmation prompt")
 
+	cmd.ValidArgsFunction = cmdutil.ProjectCompletions(f.ProjectManager)
+
 	return cmd
 }
 
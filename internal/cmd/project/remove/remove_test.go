@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/project"
 	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
@@ -18,8 +19,7 @@ import (
 // --- Tier 1: Flag parsing tests ---
 
 func TestNewCmdRemove_RunFReceivesArgsAndFlags(t *testing.T) {
-	ios, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: ios}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	called := false
 	cmd := NewCmdRemove(f, func(_ context.Context, opts *RemoveOptions) error {
@@ -40,8 +40,7 @@ func TestNewCmdRemove_RunFReceivesArgsAndFlags(t *testing.T) {
 }
 
 func TestNewCmdRemove_RequiresArgs(t *testing.T) {
-	ios, _, _, _ := iostreams.Test()
-	f := &cmdutil.Factory{IOStreams: ios}
+	f := cmdutiltest.NewFactory(t).Factory
 
 	cmd := NewCmdRemove(f, func(_ context.Context, _ *RemoveOptions) error {
 		return nil
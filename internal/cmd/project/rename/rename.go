@@ -0,0 +1,106 @@
+// Package rename provides the project rename subcommand.
+package rename
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// RenameOptions contains the options for the project rename command.
+type RenameOptions struct {
+	IOStreams      *iostreams.IOStreams
+	ProjectManager func() (project.ProjectManager, error)
+	Client         func(context.Context) (*docker.Client, error)
+
+	OldName string
+	NewName string
+}
+
+// NewCmdRename creates the project rename command.
+func NewCmdRename(f *cmdutil.Factory, runF func(context.Context, *RenameOptions) error) *cobra.Command {
+	opts := &RenameOptions{
+		IOStreams:      f.IOStreams,
+		ProjectManager: f.ProjectManager,
+		Client:         f.Client,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rename OLD-NAME NEW-NAME",
+		Short: "Rename a registered project",
+		Long: `Renames a project in the clawker project registry. The project root, its
+worktrees, and its configuration file are untouched.
+
+The project name is part of every container, volume, and image name clawker
+creates for it. Existing containers keep the old name; new runs use the new
+one. A name: key in the project's clawker.yaml overrides the registry name.`,
+		Example: `  # Rename a project
+  clawker project rename my-app my-service`,
+		Args: cmdutil.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.OldName, opts.NewName = args[0], args[1]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return renameRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.ProjectCompletions(f.ProjectManager))
+
+	return cmd
+}
+
+func renameRun(ctx context.Context, opts *RenameOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	if slug := cmdutil.ProjectSlugify(opts.NewName); slug != opts.NewName {
+		if slug == "" {
+			return cmdutil.FlagErrorf("invalid project name %q", opts.NewName)
+		}
+		return cmdutil.FlagErrorf("invalid project name %q (try %q)", opts.NewName, slug)
+	}
+
+	mgr, err := opts.ProjectManager()
+	if err != nil {
+		return fmt.Errorf("loading project manager: %w", err)
+	}
+	entries, err := mgr.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+
+	var root string
+	for _, e := range entries {
+		switch e.Name {
+		case opts.NewName:
+			return fmt.Errorf("project %q is already registered at %s", opts.NewName, e.Root)
+		case opts.OldName:
+			root = e.Root
+		}
+	}
+	if root == "" {
+		return fmt.Errorf("project %q is not registered; use 'clawker project list' to see registered projects", opts.OldName)
+	}
+
+	if _, err := mgr.Update(ctx, project.ProjectEntry{Name: opts.NewName, Root: root}); err != nil {
+		return fmt.Errorf("renaming project: %w", err)
+	}
+	fmt.Fprintf(ios.Out, "%s Renamed %s to %s\n", cs.SuccessIcon(), opts.OldName, opts.NewName)
+
+	// Best effort: the rename already happened, so an unreachable daemon
+	// only costs the reminder.
+	if client, err := opts.Client(ctx); err == nil {
+		if containers, err := client.ListContainersByProject(ctx, opts.OldName, true); err == nil && len(containers) > 0 {
+			fmt.Fprintf(ios.ErrOut, "%s %d container(s) still carry the name %q; recreate them to pick up %q\n",
+				cs.WarningIcon(), len(containers), opts.OldName, opts.NewName)
+		}
+	}
+	return nil
+}
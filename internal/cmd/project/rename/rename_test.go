@@ -0,0 +1,91 @@
+package rename
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	dockermocks "github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+)
+
+func TestNewCmdRename_Args(t *testing.T) {
+	var got *RenameOptions
+	cmd := NewCmdRename(cmdutiltest.NewFactory(t).Factory, func(_ context.Context, opts *RenameOptions) error {
+		got = opts
+		return nil
+	})
+	cmd.SetArgs([]string{"old", "new"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "old", got.OldName)
+	assert.Equal(t, "new", got.NewName)
+
+	cmd.SetArgs([]string{"old"})
+	assert.Error(t, cmd.Execute())
+}
+
+func testOptions(t *testing.T, mgr project.ProjectManager, client func(context.Context) (*docker.Client, error)) (*RenameOptions, *bytes.Buffer) {
+	t.Helper()
+	ios, _, _, errBuf := iostreams.Test()
+	return &RenameOptions{
+		IOStreams:      ios,
+		ProjectManager: func() (project.ProjectManager, error) { return mgr, nil },
+		Client:         client,
+	}, errBuf
+}
+
+func TestRenameRun(t *testing.T) {
+	ctx := context.Background()
+	mgr := projectmocks.NewTestProjectManager(t, nil)
+	root := t.TempDir()
+	_, err := mgr.Register(ctx, "app", root)
+	require.NoError(t, err)
+
+	fake := dockermocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList(dockermocks.ContainerFixture("app", "dev", "clawker-app:latest"))
+	opts, errBuf := testOptions(t, mgr, func(context.Context) (*docker.Client, error) { return fake.Client, nil })
+	opts.OldName, opts.NewName = "app", "service"
+
+	require.NoError(t, renameRun(ctx, opts))
+
+	got, err := mgr.Get(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, "service", got.Name())
+	assert.Contains(t, errBuf.String(), `1 container(s) still carry the name "app"`)
+}
+
+func TestRenameRun_Rejects(t *testing.T) {
+	ctx := context.Background()
+	mgr := projectmocks.NewTestProjectManager(t, nil)
+	_, err := mgr.Register(ctx, "app", t.TempDir())
+	require.NoError(t, err)
+	_, err = mgr.Register(ctx, "other", t.TempDir())
+	require.NoError(t, err)
+	noDocker := func(context.Context) (*docker.Client, error) { return nil, errors.New("no daemon") }
+
+	tests := []struct {
+		name, oldName, newName, wantErr string
+	}{
+		{"taken", "app", "other", "already registered"},
+		{"unknown", "ghost", "fresh", "not registered"},
+		{"not a slug", "app", "My App", `try "my-app"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, _ := testOptions(t, mgr, noDocker)
+			opts.OldName, opts.NewName = tt.oldName, tt.newName
+			err := renameRun(ctx, opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Package trust provides the project trust subcommand.
+package trust
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/prompter"
+)
+
+// TrustOptions contains the options for the project trust command.
+type TrustOptions struct {
+	IOStreams      *iostreams.IOStreams
+	Config         func() (config.Config, error)
+	ProjectManager func() (project.ProjectManager, error)
+	Prompter       func() *prompter.Prompter
+
+	Yes bool
+}
+
+// NewCmdTrust creates the project trust command.
+func NewCmdTrust(f *cmdutil.Factory, runF func(context.Context, *TrustOptions) error) *cobra.Command {
+	opts := &TrustOptions{
+		IOStreams:      f.IOStreams,
+		Config:         f.Config,
+		ProjectManager: f.ProjectManager,
+		Prompter:       f.Prompter,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "trust",
+		Short: "Approve the host commands in the project's config files",
+		Long: `Lists the host commands the current project's config files declare and,
+once confirmed, approves them.
+
+Host commands run on your machine, outside any container: lifecycle hooks
+(hooks:), build scan commands (build.scan.sbom and build.scan.command, also
+under profiles), and secrets, which read your host's env, files, or password
+vaults whatever their provider. A project config file can be
+edited from inside a bind-mode agent container or arrive with a cloned
+template, so clawker refuses to run these until you approve them here. Any
+later edit to an approved command needs approving again. The approval is
+kept in the project registry, outside the workspace.
+
+Commands in your user-level clawker.yaml (in the config dir) need no approval.`,
+		Example: `  # Review and approve the current project's host commands
+  clawker project trust
+
+  # Approve without a prompt
+  clawker project trust --yes`,
+		Args: cmdutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return trustRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func trustRun(ctx context.Context, opts *TrustOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	root := cfg.ProjectRoot()
+	if root == "" {
+		return fmt.Errorf("not in a registered project; run 'clawker project register' first")
+	}
+	mgr, err := opts.ProjectManager()
+	if err != nil {
+		return fmt.Errorf("loading project manager: %w", err)
+	}
+	proj, err := mgr.Get(ctx, root)
+	if err != nil {
+		return fmt.Errorf("loading project: %w", err)
+	}
+
+	commands := config.HostCommands(cfg)
+	if len(commands) == 0 {
+		fmt.Fprintln(ios.Out, "The project's config files declare no host commands")
+		return nil
+	}
+
+	fmt.Fprintf(ios.Out, "The config files of project %s declare these host commands:\n\n", proj.Name())
+	fingerprints := make([]string, len(commands))
+	for i, hc := range commands {
+		file := hc.File
+		if rel, relErr := filepath.Rel(root, file); relErr == nil && filepath.IsLocal(rel) {
+			file = rel
+		}
+		fmt.Fprintf(ios.Out, "  %s %s\n", cs.Bold(hc.Key), cs.Muted("("+file+")"))
+		fmt.Fprintf(ios.Out, "    %s\n", hc.Value)
+		fingerprints[i] = hc.Fingerprint(root)
+	}
+	fmt.Fprintln(ios.Out)
+
+	if !opts.Yes {
+		if !ios.IsInteractive() {
+			return fmt.Errorf("--yes required in non-interactive mode")
+		}
+		confirmed, confirmErr := opts.Prompter().Confirm("Allow these commands to run on this machine?", false)
+		if confirmErr != nil {
+			return confirmErr
+		}
+		if !confirmed {
+			return cmdutil.ErrAborted
+		}
+	}
+
+	if _, err := mgr.Update(ctx, project.ProjectEntry{
+		Name:                proj.Name(),
+		Root:                root,
+		TrustedHostCommands: fingerprints,
+	}); err != nil {
+		return fmt.Errorf("recording approval: %w", err)
+	}
+	fmt.Fprintf(ios.Out, "%s Trusted %d host command(s) for %s\n", cs.SuccessIcon(), len(commands), proj.Name())
+	return nil
+}
@@ -0,0 +1,110 @@
+package trust
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/testenv"
+)
+
+func TestNewCmdTrust(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantYes bool
+		wantErr bool
+	}{
+		{name: "no flags", input: ""},
+		{name: "yes", input: "--yes", wantYes: true},
+		{name: "yes shorthand", input: "-y", wantYes: true},
+		{name: "rejects args", input: "extra", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf := cmdutiltest.NewFactory(t)
+			var got *TrustOptions
+			cmd := NewCmdTrust(tf.Factory, func(_ context.Context, opts *TrustOptions) error {
+				got = opts
+				return nil
+			})
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(tf.In)
+			cmd.SetOut(tf.Out)
+			cmd.SetErr(tf.ErrOut)
+
+			err = cmd.Execute()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantYes, got.Yes)
+		})
+	}
+}
+
+// trustEnv registers a project whose .clawker.yaml holds projectYAML and
+// returns the trust options for it, the test env, and the project root.
+func trustEnv(t *testing.T, projectYAML string) (*TrustOptions, *cmdutiltest.Factory, *testenv.Env, string) {
+	t.Helper()
+	env := testenv.New(t, testenv.WithProjectManager(nil))
+	root := t.TempDir()
+	env.WriteYAML(t, testenv.ProjectConfig, root, projectYAML)
+	_, err := env.ProjectManager().Register(context.Background(), "app", root)
+	require.NoError(t, err)
+
+	t.Chdir(root)
+	cfg, err := config.NewConfig(config.WithProjectRoot(root))
+	require.NoError(t, err)
+
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithConfig(cfg))
+	return &TrustOptions{
+		IOStreams:      tf.IOStreams,
+		Config:         tf.Config,
+		ProjectManager: func() (project.ProjectManager, error) { return env.ProjectManager(), nil },
+	}, tf, env, root
+}
+
+func TestTrustRun_RecordsApproval(t *testing.T) {
+	opts, tf, env, root := trustEnv(t, "hooks:\n  pre_create: ./scripts/check.sh\n")
+	opts.Yes = true
+
+	require.NoError(t, trustRun(context.Background(), opts))
+	assert.Contains(t, tf.Out.String(), "hooks.pre_create")
+	assert.Contains(t, tf.Out.String(), "./scripts/check.sh")
+	assert.Contains(t, tf.Out.String(), "Trusted 1 host command(s) for app")
+
+	trusted, err := env.Registry(t).TrustedHostCommands(root)
+	require.NoError(t, err)
+	cfg, err := config.NewConfig(config.WithProjectRoot(root), config.WithTrustedHostCommands(trusted))
+	require.NoError(t, err)
+	assert.NoError(t, cfg.CheckHostCommand("hooks.pre_create"))
+}
+
+func TestTrustRun_NonInteractiveNeedsYes(t *testing.T) {
+	opts, _, env, root := trustEnv(t, "hooks:\n  pre_create: ./scripts/check.sh\n")
+
+	err := trustRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--yes required")
+
+	trusted, err := env.Registry(t).TrustedHostCommands(root)
+	require.NoError(t, err)
+	assert.Empty(t, trusted)
+}
+
+func TestTrustRun_NothingToTrust(t *testing.T) {
+	opts, tf, _, _ := trustEnv(t, "build:\n  image: debian\n")
+
+	require.NoError(t, trustRun(context.Background(), opts))
+	assert.Contains(t, tf.Out.String(), "declare no host commands")
+}
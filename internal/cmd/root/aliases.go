@@ -43,7 +43,7 @@ type Alias struct {
 // topLevelAliases defines all top-level shortcuts to subcommands.
 var topLevelAliases = []Alias{
 	{
-		Use:     "attach CONTAINER",
+		Use:     "attach [CONTAINER]",
 		Command: func(f *cmdutil.Factory) *cobra.Command { return containerAttach.NewCmdAttach(f, nil) },
 	},
 	{
@@ -104,7 +104,7 @@ var topLevelAliases = []Alias{
 		Command: func(f *cmdutil.Factory) *cobra.Command { return containerStats.NewCmdStats(f, nil) },
 	},
 	{
-		Use:     "stop [OPTIONS] CONTAINER [CONTAINER...]",
+		Use:     "stop [OPTIONS] [CONTAINER...]",
 		Command: func(f *cmdutil.Factory) *cobra.Command { return containerStop.NewCmdStop(f, nil) },
 	},
 	{
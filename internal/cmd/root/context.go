@@ -0,0 +1,171 @@
+package root
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/project"
+)
+
+// ContextFlagValue finds the global --context flag in args (os.Args without
+// the program name). Main needs it before the real command tree exists:
+// config is read while the tree is built (theme, user aliases), so the
+// working directory must already be the project's root by then.
+//
+// Only a --context cobra would parse as clawker's own flag counts. The
+// command is located in a probe tree built from f, and scanning stops at
+// "--", at a user alias (its expansion is parsed when it re-executes) or an
+// unknown command, and at the first argument of a command that stops flag
+// parsing there (run, create, exec), so `exec dev kubectl --context prod` leaves kubectl's flag
+// alone. Cobra's hidden completion commands are skipped so a half-typed
+// name never breaks tab completion. A trailing --context with no value
+// reports ok=false and is left for cobra to reject.
+func ContextFlagValue(f *cmdutil.Factory, args []string) (name string, ok bool) {
+	if len(args) > 0 && (args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd) {
+		return "", false
+	}
+	if !slices.ContainsFunc(args, func(arg string) bool {
+		return arg == "--context" || strings.HasPrefix(arg, "--context=")
+	}) {
+		return "", false
+	}
+
+	// The probe only needs the tree's shape; without IOStreams it installs
+	// no theme and so reports no theme errors twice.
+	probeFactory := *f
+	probeFactory.IOStreams = nil
+	probe, err := NewCmdRoot(&probeFactory, "", "")
+	if err != nil {
+		return "", false
+	}
+	target, rest, err := probe.Find(args)
+	interspersed := false
+	if err != nil || target.DisableFlagParsing {
+		// A user alias, possibly one only the named project defines: only
+		// flags before its name are the root's.
+		target, rest = probe, args
+	} else {
+		interspersed = parsesInterspersed(target.Flags())
+	}
+
+	for i := 0; i < len(rest); i++ {
+		arg := rest[i]
+		switch {
+		case arg == "--":
+			return "", false
+		case arg == "--context":
+			if i+1 < len(rest) {
+				return rest[i+1], true
+			}
+			return "", false
+		case strings.HasPrefix(arg, "--context="):
+			return strings.TrimPrefix(arg, "--context="), true
+		case len(arg) > 1 && arg[0] == '-':
+			if flagTakesValue(target, arg) {
+				i++
+			}
+		case !interspersed:
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// parsesInterspersed reports whether fs parses flags after positional
+// arguments. pflag has no getter, so it parses a probe argument list: a
+// flag set that stops at "x" leaves the "--" after it unconsumed. Only call
+// it on a throwaway command tree.
+func parsesInterspersed(fs *pflag.FlagSet) bool {
+	if err := fs.Parse([]string{"x", "--"}); err != nil {
+		return true
+	}
+	return len(fs.Args()) == 1
+}
+
+// flagTakesValue reports whether the flag argument arg of cmd consumes the
+// next argument as its value.
+func flagTakesValue(cmd *cobra.Command, arg string) bool {
+	if strings.Contains(arg, "=") {
+		return false
+	}
+	lookup := func(name string, short bool) *pflag.Flag {
+		for _, fs := range []*pflag.FlagSet{cmd.Flags(), cmd.InheritedFlags()} {
+			var fl *pflag.Flag
+			if short {
+				fl = fs.ShorthandLookup(name)
+			} else {
+				fl = fs.Lookup(name)
+			}
+			if fl != nil {
+				return fl
+			}
+		}
+		return nil
+	}
+	if name, ok := strings.CutPrefix(arg, "--"); ok {
+		fl := lookup(name, false)
+		return fl != nil && fl.NoOptDefVal == ""
+	}
+	// A shorthand group such as -it: the first shorthand that needs a value
+	// takes the rest of the group, or the next argument when it is last.
+	for j := 1; j < len(arg); j++ {
+		if fl := lookup(arg[j:j+1], true); fl != nil && fl.NoOptDefVal == "" {
+			return j == len(arg)-1
+		}
+	}
+	return false
+}
+
+// EnterProjectContext changes the process working directory to the root of
+// the registered project name and records the name on f, so every command
+// resolves that project exactly as if it had been run from its root.
+func EnterProjectContext(f *cmdutil.Factory, name string) error {
+	if name == "" {
+		return cmdutil.FlagErrorf("--context requires a project name")
+	}
+	reg, err := f.ProjectRegistry()
+	if err != nil {
+		return fmt.Errorf("--context: %w", err)
+	}
+	root, err := reg.RootByName(name)
+	if errors.Is(err, project.ErrProjectNotFound) {
+		return fmt.Errorf("--context: project %q is not registered; use 'clawker project list' to see registered projects", name)
+	}
+	if err != nil {
+		return fmt.Errorf("--context: %w", err)
+	}
+	if info, statErr := os.Stat(root); statErr != nil || !info.IsDir() {
+		return fmt.Errorf("--context: project %q root %s no longer exists; use 'clawker project move' to point it at its new location or 'clawker project remove' to unregister it", name, root)
+	}
+	if err := os.Chdir(root); err != nil {
+		return fmt.Errorf("--context: entering %s: %w", root, err)
+	}
+	// Keep PWD in step so os.Getwd and child processes see the project root.
+	os.Setenv("PWD", root) //nolint:errcheck // PWD is advisory
+	f.ProjectContext = name
+	return nil
+}
+
+// checkContextFlag rejects a --context that Main acted on but cobra did not
+// parse as a flag — one placed after the arguments of a command that stops
+// flag parsing at its first argument (run, create, exec). The process is
+// already in that project's root, so the command must not run on.
+func checkContextFlag(cmd *cobra.Command, f *cmdutil.Factory) error {
+	if cmd.DisableFlagParsing {
+		// User aliases forward their arguments verbatim and re-execute the
+		// root command, which checks the expanded flags itself.
+		return nil
+	}
+	parsed, _ := cmd.Flags().GetString("context")
+	if parsed != f.ProjectContext {
+		return cmdutil.FlagErrorf("--context must come before the command's arguments")
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package root
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/testenv"
+)
+
+func TestContextFlagValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantName string
+		wantOK   bool
+	}{
+		{name: "absent", args: []string{"ps"}},
+		{name: "separate value", args: []string{"--context", "app", "ps"}, wantName: "app", wantOK: true},
+		{name: "equals form", args: []string{"ps", "--context=app"}, wantName: "app", wantOK: true},
+		{name: "empty equals form", args: []string{"--context=", "ps"}, wantOK: true},
+		{name: "missing value", args: []string{"ps", "--context"}},
+		{name: "after separator", args: []string{"run", "--", "claude", "--context", "app"}},
+		{name: "completion request", args: []string{"__complete", "--context", "ap"}},
+		{name: "interspersed after arguments", args: []string{"container", "stop", "dev", "--context", "app"}, wantName: "app", wantOK: true},
+		{name: "exec command's own flag", args: []string{"container", "exec", "dev", "kubectl", "--context", "prod", "get", "pods"}},
+		{name: "exec flag value skipped", args: []string{"exec", "-w", "/src", "--context", "app", "dev", "ls"}, wantName: "app", wantOK: true},
+		{name: "exec shorthand group", args: []string{"exec", "-it", "dev", "kubectl", "--context", "prod"}},
+		{name: "run command's own flag", args: []string{"run", "--rm", "img", "kubectl", "--context", "prod"}},
+		{name: "before run arguments", args: []string{"run", "--context", "app", "img"}, wantName: "app", wantOK: true},
+		{name: "user alias arguments", args: []string{"k", "--context", "prod"}},
+		{name: "before user alias", args: []string{"--context", "app", "k", "get"}, wantName: "app", wantOK: true},
+		{name: "before alias only the project defines", args: []string{"--context", "app", "other", "get"}, wantName: "app", wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAliasTestFactory(t, "aliases:\n  k: exec dev kubectl\n")
+			name, ok := ContextFlagValue(f, tt.args)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestEnterProjectContext(t *testing.T) {
+	env := testenv.New(t)
+	root := filepath.Join(env.Dirs.Base, "app")
+	require.NoError(t, os.MkdirAll(root, 0o755))
+	env.WriteYAML(t, testenv.ProjectRegistry, "", "projects:\n"+
+		"  - name: app\n    root: "+root+"\n"+
+		"  - name: gone\n    root: "+filepath.Join(env.Dirs.Base, "gone")+"\n")
+
+	tests := []struct {
+		name    string
+		project string
+		wantErr string
+	}{
+		{name: "registered project", project: "app"},
+		{name: "unknown project", project: "nope", wantErr: `project "nope" is not registered`},
+		{name: "missing root", project: "gone", wantErr: `project "gone" root ` + filepath.Join(env.Dirs.Base, "gone") + " no longer exists"},
+		{name: "empty name", wantErr: "--context requires a project name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(env.Dirs.Base)
+			f := cmdutiltest.NewFactory(t).Factory
+			f.ProjectRegistry = func() (*project.Registry, error) { return env.Registry(t), nil }
+
+			err := EnterProjectContext(f, tt.project)
+			cwd, cwdErr := os.Getwd()
+			require.NoError(t, cwdErr)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Equal(t, env.Dirs.Base, cwd, "working directory must not change on error")
+				assert.Empty(t, f.ProjectContext)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, root, cwd)
+			assert.Equal(t, tt.project, f.ProjectContext)
+		})
+	}
+}
+
+func TestContextFlag_Placement(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "before the command", args: []string{"--context", "app", "version"}},
+		{name: "among the command's flags", args: []string{"version", "--context", "app"}},
+		{
+			name:    "after arguments of a non-interspersed command",
+			args:    []string{"container", "exec", "dev", "--context", "app"},
+			wantErr: "--context must come before the command's arguments",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAliasTestFactory(t, "")
+			// As Main leaves it after entering the project named on the command line.
+			f.ProjectContext = "app"
+			cmd, err := NewCmdRoot(f, "", "")
+			require.NoError(t, err)
+			cmd.SetArgs(tt.args)
+
+			err = cmd.Execute()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
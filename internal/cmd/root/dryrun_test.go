@@ -0,0 +1,49 @@
+package root
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+)
+
+func TestGlobalDryRun_ReportsSkippedOperations(t *testing.T) {
+	f := newAliasTestFactory(t, "")
+	tio, _, _, errOut := iostreams.Test()
+	f.IOStreams = tio
+
+	// Built the way clientFunc builds it under --dry-run. The fake has no
+	// VolumeRemove stub, so reaching the daemon would panic.
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig(), mocks.WithDryRun())
+	f.Client = func(context.Context) (*docker.Client, error) { return fake.Client, nil }
+
+	root, err := NewCmdRoot(f, "9.9.9-test", "2026-01-01")
+	require.NoError(t, err)
+	root.SetArgs([]string{"--dry-run", "volume", "remove", "clawker.web.dev-workspace"})
+	require.NoError(t, root.Execute())
+
+	assert.True(t, f.DryRun)
+	assert.Contains(t, errOut.String(), "Dry run: no changes were made")
+	assert.Contains(t, errOut.String(), "remove volume clawker.web.dev-workspace")
+}
+
+func TestGlobalDryRun_UnsupportedCommand(t *testing.T) {
+	_, _, err := executeOffline(t, "version", "--dry-run")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `--dry-run is not supported by "clawker version"`)
+}
+
+func TestGlobalDryRun_LocalFlagShadows(t *testing.T) {
+	// workspace sync has its own --dry-run, which shadows the global one:
+	// the root check must not reject it. Offline, the command then fails on
+	// the daemon instead.
+	_, _, err := executeOffline(t, "workspace", "sync", "--dry-run", "dev")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "--dry-run is not supported")
+}
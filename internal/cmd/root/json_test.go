@@ -0,0 +1,41 @@
+package root
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/iostreams"
+)
+
+func TestGlobalJSON_Envelope(t *testing.T) {
+	for _, args := range [][]string{
+		{"alias", "list", "--json"},
+		{"--json", "alias", "list"},
+	} {
+		t.Run(joinPath(args), func(t *testing.T) {
+			f := newAliasTestFactory(t, "aliases:\n  v: version\n")
+			tio, _, out, _ := iostreams.Test()
+			f.IOStreams = tio
+			root, err := NewCmdRoot(f, "9.9.9-test", "2026-01-01")
+			require.NoError(t, err)
+			root.SetArgs(args)
+			require.NoError(t, root.Execute())
+			assert.True(t, tio.JSONOutput())
+
+			var env iostreams.JSONEnvelope
+			require.NoError(t, json.Unmarshal(out.Bytes(), &env))
+			assert.Equal(t, iostreams.JSONSchemaVersion, env.SchemaVersion)
+			assert.Equal(t, "alias.list", env.Kind)
+			assert.NotEmpty(t, env.Data)
+		})
+	}
+}
+
+func TestGlobalJSON_UnsupportedCommand(t *testing.T) {
+	_, _, err := executeOffline(t, "version", "--json")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `--json is not supported by "clawker version"`)
+}
@@ -0,0 +1,125 @@
+package root
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// daemonCalls counts resolutions of the daemon-backed Factory nouns.
+type daemonCalls struct {
+	client, admin atomic.Int32
+}
+
+// newOfflineFactory returns a Factory whose Docker client and admin client
+// behave as if no daemon is running, counting each resolution.
+func newOfflineFactory(t *testing.T) (*cmdutil.Factory, *daemonCalls) {
+	t.Helper()
+	calls := &daemonCalls{}
+	f := newAliasTestFactory(t, "")
+	f.TUI = tui.NewTUI(f.IOStreams)
+	f.Client = func(context.Context) (*docker.Client, error) {
+		calls.client.Add(1)
+		return nil, whail.ErrDockerHealthCheckFailed(assert.AnError)
+	}
+	f.AdminClient = func(context.Context) (adminv1.AdminServiceClient, error) {
+		calls.admin.Add(1)
+		return nil, assert.AnError
+	}
+	return f, calls
+}
+
+// allCommandPaths returns the argument paths of every command under root,
+// root itself excluded.
+func allCommandPaths(root *cobra.Command) [][]string {
+	var paths [][]string
+	var walk func(c *cobra.Command, prefix []string)
+	walk = func(c *cobra.Command, prefix []string) {
+		for _, sub := range c.Commands() {
+			path := append(append([]string{}, prefix...), sub.Name())
+			paths = append(paths, path)
+			walk(sub, path)
+		}
+	}
+	walk(root, nil)
+	return paths
+}
+
+// executeOffline runs args through a fresh root tree built on an offline
+// Factory.
+func executeOffline(t *testing.T, args ...string) (*daemonCalls, string, error) {
+	t.Helper()
+	f, calls := newOfflineFactory(t)
+	tio, _, out, errOut := iostreams.Test()
+	f.IOStreams = tio
+	root, err := NewCmdRoot(f, "9.9.9-test", "2026-01-01")
+	require.NoError(t, err)
+	root.SetOut(out)
+	root.SetErr(errOut)
+	root.SetArgs(args)
+	err = root.Execute()
+	return calls, out.String(), err
+}
+
+// TestOffline_HelpNeedsNoDaemon guards the offline-first contract: help for
+// every command renders without resolving the Docker or admin client.
+func TestOffline_HelpNeedsNoDaemon(t *testing.T) {
+	f, _ := newOfflineFactory(t)
+	root, err := NewCmdRoot(f, "", "")
+	require.NoError(t, err)
+
+	for _, path := range allCommandPaths(root) {
+		t.Run(joinPath(path), func(t *testing.T) {
+			calls, out, err := executeOffline(t, append(path, "--help")...)
+			require.NoError(t, err)
+			assert.NotEmpty(t, out)
+			assert.Zero(t, calls.client.Load(), "help resolved the Docker client")
+			assert.Zero(t, calls.admin.Load(), "help resolved the admin client")
+		})
+	}
+}
+
+// TestOffline_CompletionNeedsNoDaemon checks that argument completion for
+// every command and completion-script generation succeed with no daemon.
+// Completion functions may try a client but must degrade to no suggestions.
+func TestOffline_CompletionNeedsNoDaemon(t *testing.T) {
+	f, _ := newOfflineFactory(t)
+	root, err := NewCmdRoot(f, "", "")
+	require.NoError(t, err)
+
+	for _, path := range allCommandPaths(root) {
+		t.Run(joinPath(path), func(t *testing.T) {
+			args := append([]string{cobra.ShellCompRequestCmd}, path...)
+			_, out, err := executeOffline(t, append(args, "")...)
+			require.NoError(t, err)
+			assert.Contains(t, out, ":", "completion must end with a directive line")
+		})
+	}
+
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run("completion "+shell, func(t *testing.T) {
+			calls, out, err := executeOffline(t, "completion", shell)
+			require.NoError(t, err)
+			assert.NotEmpty(t, out)
+			assert.Zero(t, calls.client.Load())
+		})
+	}
+}
+
+func joinPath(path []string) string {
+	s := path[0]
+	for _, p := range path[1:] {
+		s += " " + p
+	}
+	return s
+}
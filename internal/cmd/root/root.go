@@ -1,25 +1,35 @@
 package root
 
 import (
+	admincmd "github.com/schmitthub/clawker/internal/cmd/admin"
 	aliascmd "github.com/schmitthub/clawker/internal/cmd/alias"
+	auditcmd "github.com/schmitthub/clawker/internal/cmd/audit"
 	authcmd "github.com/schmitthub/clawker/internal/cmd/auth"
 	bridgecmd "github.com/schmitthub/clawker/internal/cmd/bridge"
 	bundlecmd "github.com/schmitthub/clawker/internal/cmd/bundle"
+	configcmd "github.com/schmitthub/clawker/internal/cmd/config"
 	"github.com/schmitthub/clawker/internal/cmd/container"
 	controlplanecmd "github.com/schmitthub/clawker/internal/cmd/controlplane"
+	dashcmd "github.com/schmitthub/clawker/internal/cmd/dash"
+	debugcmd "github.com/schmitthub/clawker/internal/cmd/debug"
+	doctorcmd "github.com/schmitthub/clawker/internal/cmd/doctor"
 	firewallcmd "github.com/schmitthub/clawker/internal/cmd/firewall"
 	harnesscmd "github.com/schmitthub/clawker/internal/cmd/harness"
 	hostproxycmd "github.com/schmitthub/clawker/internal/cmd/hostproxy"
 	"github.com/schmitthub/clawker/internal/cmd/image"
 	initcmd "github.com/schmitthub/clawker/internal/cmd/init"
+	loopcmd "github.com/schmitthub/clawker/internal/cmd/loop"
 	"github.com/schmitthub/clawker/internal/cmd/monitor"
 	"github.com/schmitthub/clawker/internal/cmd/network"
+	opencmd "github.com/schmitthub/clawker/internal/cmd/open"
 	"github.com/schmitthub/clawker/internal/cmd/plugin"
 	"github.com/schmitthub/clawker/internal/cmd/project"
+	sessioncmd "github.com/schmitthub/clawker/internal/cmd/session"
 	"github.com/schmitthub/clawker/internal/cmd/settings"
 	stackcmd "github.com/schmitthub/clawker/internal/cmd/stack"
 	versioncmd "github.com/schmitthub/clawker/internal/cmd/version"
 	"github.com/schmitthub/clawker/internal/cmd/volume"
+	"github.com/schmitthub/clawker/internal/cmd/workspace"
 	"github.com/schmitthub/clawker/internal/cmd/worktree"
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/spf13/cobra"
@@ -48,6 +58,35 @@ Workspace modes:
 			"versionInfo": versioncmd.Format(version, buildDate),
 		},
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Commands with --format flags register their own --json, which
+			// shadows this one; either way the value lands in cmd.Flags().
+			if jsonOut, _ := cmd.Flags().GetBool("json"); jsonOut {
+				if !cmdutil.SupportsJSONOutput(cmd) {
+					return cmdutil.FlagErrorf("--json is not supported by %q", cmd.CommandPath())
+				}
+				f.IOStreams.SetJSONOutput(true)
+			}
+			if noInput, _ := cmd.Flags().GetBool("no-input"); noInput {
+				f.IOStreams.SetNeverPrompt(true)
+			}
+			if err := checkContextFlag(cmd, f); err != nil {
+				return err
+			}
+			if f.DryRun && !cmdutil.SupportsDryRun(cmd) {
+				return cmdutil.FlagErrorf("--dry-run is not supported by %q", cmd.CommandPath())
+			}
+			return nil
+		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			if !f.DryRun {
+				return nil
+			}
+			// The client is memoized, so this is the engine the command used.
+			client, err := f.Client(cmd.Context())
+			if err != nil {
+				return nil
+			}
+			cmdutil.PrintDryRunReport(f.IOStreams, client.DryRunReport())
 			return nil
 		},
 		Version: f.Version,
@@ -55,11 +94,20 @@ Workspace modes:
 
 	// Global flags
 	cmd.PersistentFlags().BoolVarP(&debug, "debug", "D", false, "Enable debug logging")
+	cmd.PersistentFlags().StringVar(&f.Profile, "profile", "", "Apply a named profile from clawker.yaml (profiles.<name>)")
+	cmd.PersistentFlags().Bool("json", false, "Output as versioned JSON envelope (commands that support it)")
+	cmd.PersistentFlags().String("context", "", "Operate on a registered project by name instead of the one in the current directory")
+	cmd.RegisterFlagCompletionFunc("context", cmdutil.ProjectCompletions(f.ProjectManager)) //nolint:errcheck // cobra registers completion internally
+	cmd.PersistentFlags().Bool("no-input", false, "Never prompt; commands that would ask fail or take the safe default instead")
+	cmd.PersistentFlags().BoolVar(&f.DryRun, "dry-run", false, "Report the Docker changes a destructive command would make without making them (commands that support it)")
 
 	// Silence Cobra's default error and usage output — we handle this in Main. It's obnoxious
 	cmd.SilenceErrors = true
 	cmd.SilenceUsage = true
 
+	// Install the configured color theme before anything renders
+	applyUserTheme(f)
+
 	// Register built-in top-level aliases (shortcuts to subcommands)
 	registerBuiltinAliases(cmd, f)
 
@@ -67,11 +115,18 @@ Workspace modes:
 	cmd.AddCommand(initcmd.NewCmdInit(f, nil))
 	cmd.AddCommand(project.NewCmdProject(f))
 	cmd.AddCommand(settings.NewCmdSettings(f))
+	cmd.AddCommand(configcmd.NewCmdConfig(f))
 	cmd.AddCommand(plugin.NewCmdPlugin(f))
 	cmd.AddCommand(monitor.NewCmdMonitor(f))
+	cmd.AddCommand(doctorcmd.NewCmdDoctor(f, nil))
+	cmd.AddCommand(debugcmd.NewCmdDebug(f))
+	cmd.AddCommand(opencmd.NewCmdOpen(f, nil))
+	cmd.AddCommand(dashcmd.NewCmdDash(f, nil))
 
 	// Add management commands
+	cmd.AddCommand(admincmd.NewCmdAdmin(f))
 	cmd.AddCommand(aliascmd.NewCmdAlias(f, func(name string) bool { return builtinCommandExists(cmd, name) }))
+	cmd.AddCommand(auditcmd.NewCmdAudit(f))
 	cmd.AddCommand(authcmd.NewCmdAuth(f))
 	cmd.AddCommand(bundlecmd.NewCmdBundle(f))
 	cmd.AddCommand(harnesscmd.NewCmdHarness(f))
@@ -83,6 +138,9 @@ Workspace modes:
 	cmd.AddCommand(volume.NewCmdVolume(f))
 	cmd.AddCommand(network.NewCmdNetwork(f))
 	cmd.AddCommand(worktree.NewCmdWorktree(f))
+	cmd.AddCommand(workspace.NewCmdWorkspace(f))
+	cmd.AddCommand(loopcmd.NewCmdLoop(f))
+	cmd.AddCommand(sessioncmd.NewCmdSession(f))
 
 	// Add hidden internal commands
 	cmd.AddCommand(hostproxycmd.NewCmdHostProxy())
@@ -0,0 +1,31 @@
+package root
+
+import (
+	"fmt"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+)
+
+// applyUserTheme installs the color theme and palette overrides from
+// settings.yaml (ui.theme, ui.palette) before any command renders output.
+//
+// Like registerUserAliases it never fails root construction: a nil Config
+// closure or a config load error keeps the built-in palette. An invalid
+// theme or palette entry is reported on stderr once and also keeps the
+// built-in palette, so a typo never makes output unreadable.
+func applyUserTheme(f *cmdutil.Factory) {
+	if f.Config == nil || f.IOStreams == nil {
+		return
+	}
+	cfg, err := f.Config()
+	if err != nil {
+		rootLogger(f).Debug().Err(err).Msg("user theme skipped: config unavailable")
+		return
+	}
+
+	ui := cfg.Settings().UI
+	if err := f.IOStreams.ApplyTheme(ui.Theme, ui.Palette.Colors()); err != nil {
+		cs := f.IOStreams.ColorScheme()
+		fmt.Fprintf(f.IOStreams.ErrOut, "%s ui settings ignored: %v\n", cs.WarningIcon(), err)
+	}
+}
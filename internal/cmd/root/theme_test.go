@@ -0,0 +1,38 @@
+package root
+
+import (
+	"testing"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/stretchr/testify/assert"
+)
+
+func themeFactory(t *testing.T, settingsYAML string) *cmdutiltest.Factory {
+	return cmdutiltest.NewFactory(t, cmdutiltest.WithConfig(configmocks.NewFromString("", settingsYAML)))
+}
+
+func TestApplyUserTheme(t *testing.T) {
+	active := iostreams.ActivePalette()
+	t.Cleanup(func() { iostreams.ApplyPalette(active) })
+
+	t.Run("installs the configured theme and palette", func(t *testing.T) {
+		tf := themeFactory(t, "ui:\n  theme: high-contrast\n  palette:\n    primary: \"#123456\"\n")
+		applyUserTheme(tf.Factory)
+
+		assert.Equal(t, iostreams.ThemeHighContrast, tf.IOStreams.ColorTheme())
+		assert.Equal(t, "#123456", string(iostreams.ColorPrimary))
+		assert.Empty(t, tf.ErrOut.String())
+	})
+
+	t.Run("invalid theme warns and keeps the palette", func(t *testing.T) {
+		iostreams.ApplyPalette(active)
+		tf := themeFactory(t, "ui:\n  theme: neon\n")
+		applyUserTheme(tf.Factory)
+
+		assert.Equal(t, iostreams.ThemeDefault, tf.IOStreams.ColorTheme())
+		assert.Equal(t, active, iostreams.ActivePalette())
+		assert.Contains(t, tf.ErrOut.String(), `unknown theme "neon"`)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/iostreams"
@@ -204,7 +205,7 @@ func TestRegisterUserAliases(t *testing.T) {
 	})
 
 	t.Run("nil config closure leaves root buildable", func(t *testing.T) {
-		f := &cmdutil.Factory{}
+		f := cmdutiltest.NewFactory(t).Factory
 		root, err := NewCmdRoot(f, "", "")
 		require.NoError(t, err)
 		require.NotNil(t, root)
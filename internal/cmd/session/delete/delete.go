@@ -0,0 +1,101 @@
+// Package delete provides the session delete command.
+package delete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/session"
+)
+
+// DeleteOptions holds options for the session delete command.
+type DeleteOptions struct {
+	IOStreams      *iostreams.IOStreams
+	ProjectManager func() (project.ProjectManager, error)
+	Store          func() (*session.Store, error)
+
+	agents []string
+}
+
+// NewCmdDelete creates the session delete command.
+func NewCmdDelete(f *cmdutil.Factory, runF func(context.Context, *DeleteOptions) error) *cobra.Command {
+	opts := &DeleteOptions{
+		IOStreams:      f.IOStreams,
+		ProjectManager: f.ProjectManager,
+		Store:          session.DefaultStore,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "delete AGENT...",
+		Aliases: []string{"rm"},
+		Short:   "Delete saved agent sessions",
+		Long: `Deletes the harness sessions saved for agents of the current project.
+The agents' containers and volumes are not touched; a container recreated
+afterwards starts without its previous conversations.`,
+		Example: `  # Delete the dev agent's saved session
+  clawker session delete dev
+
+  # Delete several
+  clawker session rm dev writer`,
+		Args: cmdutil.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.agents = args
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return deleteRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.ValidArgsFunction = cmdutil.AgentCompletions(f.Client, f.ProjectManager)
+
+	return cmd
+}
+
+func deleteRun(ctx context.Context, opts *DeleteOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	var projectName string
+	if opts.ProjectManager != nil {
+		if pm, err := opts.ProjectManager(); err == nil {
+			if p, err := pm.CurrentProject(ctx); err == nil {
+				projectName = p.Name()
+			}
+		}
+	}
+	names, err := docker.ContainerNamesFromAgents(projectName, opts.agents)
+	if err != nil {
+		return err
+	}
+
+	store, err := opts.Store()
+	if err != nil {
+		return err
+	}
+
+	failed := false
+	for i, name := range names {
+		err := store.Delete(name)
+		if errors.Is(err, session.ErrNotFound) {
+			err = fmt.Errorf("no saved session for agent %q", opts.agents[i])
+		}
+		if err != nil {
+			failed = true
+			fmt.Fprintf(ios.ErrOut, "%s %v\n", cs.FailureIcon(), err)
+			continue
+		}
+		fmt.Fprintln(ios.Out, name)
+	}
+	if failed {
+		return cmdutil.SilentError
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package delete
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+	"github.com/schmitthub/clawker/internal/session"
+)
+
+func TestDeleteRun(t *testing.T) {
+	store := session.NewStore(t.TempDir())
+	_, err := store.Save(session.Meta{Container: "clawker.app.dev", Agent: "dev"}, func(dir string) error {
+		return os.WriteFile(filepath.Join(dir, "a.jsonl"), []byte("{}\n"), 0o644)
+	})
+	require.NoError(t, err)
+
+	ios, _, out, errOut := iostreams.Test()
+	opts := &DeleteOptions{
+		IOStreams: ios,
+		ProjectManager: func() (project.ProjectManager, error) {
+			mgr := projectmocks.NewMockProjectManager()
+			mgr.CurrentProjectFunc = func(context.Context) (project.Project, error) {
+				return projectmocks.NewMockProject("app", t.TempDir()), nil
+			}
+			return mgr, nil
+		},
+		Store:  func() (*session.Store, error) { return store, nil },
+		agents: []string{"dev", "writer"},
+	}
+
+	err = deleteRun(context.Background(), opts)
+	require.ErrorIs(t, err, cmdutil.SilentError, "a missing session fails the command")
+	assert.Equal(t, "clawker.app.dev\n", out.String())
+	assert.Contains(t, errOut.String(), `no saved session for agent "writer"`)
+	_, err = store.Get("clawker.app.dev")
+	assert.ErrorIs(t, err, session.ErrNotFound)
+}
@@ -0,0 +1,105 @@
+// Package list provides the session list command.
+package list
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/session"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// ListOptions holds options for the session list command.
+type ListOptions struct {
+	IOStreams *iostreams.IOStreams
+	TUI       *tui.TUI
+	Store     func() (*session.Store, error)
+	Format    *cmdutil.FormatFlags
+}
+
+// NewCmdList creates the session list command.
+func NewCmdList(f *cmdutil.Factory, runF func(context.Context, *ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IOStreams: f.IOStreams,
+		TUI:       f.TUI,
+		Store:     session.DefaultStore,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List saved agent sessions",
+		Long: `Lists the harness sessions saved on the host, across all projects. A
+session is saved when its agent container stops and restored when the
+container is recreated.`,
+		Example: `  # List saved sessions
+  clawker session list
+
+  # Container names only
+  clawker session ls -q
+
+  # Output as JSON
+  clawker session list --json`,
+		Args: cmdutil.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	opts.Format = cmdutil.AddFormatFlags(cmd)
+	cmd.Flags().Lookup("quiet").Usage = "Only display container names"
+
+	return cmd
+}
+
+func listRun(_ context.Context, opts *ListOptions) error {
+	store, err := opts.Store()
+	if err != nil {
+		return err
+	}
+	sessions, err := store.List()
+	if err != nil {
+		return err
+	}
+	if sessions == nil {
+		sessions = []session.Meta{}
+	}
+
+	ios := opts.IOStreams
+	switch {
+	case opts.Format.Quiet:
+		for _, s := range sessions {
+			fmt.Fprintln(ios.Out, s.Container)
+		}
+		return nil
+
+	case opts.Format.IsJSON():
+		return opts.Format.WriteJSON(ios, "session.list", sessions)
+
+	case opts.Format.IsTemplate():
+		return cmdutil.ExecuteTemplate(ios.Out, opts.Format.Template(), cmdutil.ToAny(sessions))
+
+	default:
+		if len(sessions) == 0 {
+			fmt.Fprintln(ios.ErrOut, "No saved sessions.")
+			return nil
+		}
+		tp := opts.TUI.NewTable("PROJECT", "AGENT", "HARNESS", "SAVED", "FILES", "SIZE")
+		for _, s := range sessions {
+			tp.AddRow(s.Project, s.Agent, s.Harness,
+				units.HumanDuration(time.Since(s.SavedAt))+" ago",
+				strconv.Itoa(s.Files), units.HumanSize(float64(s.Bytes)))
+		}
+		return tp.Render()
+	}
+}
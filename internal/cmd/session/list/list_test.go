@@ -0,0 +1,82 @@
+package list
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/session"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+func newOpts(t *testing.T, format *cmdutil.FormatFlags) (*ListOptions, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	store := session.NewStore(t.TempDir())
+	for _, agent := range []string{"dev", "writer"} {
+		_, err := store.Save(session.Meta{
+			Container: "clawker.app." + agent, Project: "app", Agent: agent, Harness: "claude",
+			SavedAt: time.Now().Add(-2 * time.Hour), Paths: []string{".claude/projects"},
+		}, func(dir string) error {
+			p := filepath.Join(dir, ".claude", "projects", "a.jsonl")
+			require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+			return os.WriteFile(p, []byte("{}\n"), 0o644)
+		})
+		require.NoError(t, err)
+	}
+	ios, _, out, errOut := iostreams.Test()
+	return &ListOptions{
+		IOStreams: ios,
+		TUI:       tui.NewTUI(ios),
+		Store:     func() (*session.Store, error) { return store, nil },
+		Format:    format,
+	}, out, errOut
+}
+
+func TestListRun_Table(t *testing.T) {
+	opts, out, _ := newOpts(t, &cmdutil.FormatFlags{})
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Contains(t, out.String(), "PROJECT")
+	assert.Contains(t, out.String(), "writer")
+	assert.Contains(t, out.String(), "2 hours ago")
+}
+
+func TestListRun_Quiet(t *testing.T) {
+	opts, out, _ := newOpts(t, &cmdutil.FormatFlags{Quiet: true})
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Equal(t, "clawker.app.dev\nclawker.app.writer\n", out.String())
+}
+
+func TestListRun_JSON(t *testing.T) {
+	format, err := cmdutil.ParseFormat("json")
+	require.NoError(t, err)
+	opts, out, _ := newOpts(t, &cmdutil.FormatFlags{Format: format})
+	require.NoError(t, listRun(context.Background(), opts))
+
+	var got []session.Meta
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	require.Len(t, got, 2)
+	assert.Equal(t, "dev", got[0].Agent)
+	assert.Equal(t, 1, got[0].Files)
+}
+
+func TestListRun_Empty(t *testing.T) {
+	ios, _, out, errOut := iostreams.Test()
+	opts := &ListOptions{
+		IOStreams: ios,
+		TUI:       tui.NewTUI(ios),
+		Store:     func() (*session.Store, error) { return session.NewStore(t.TempDir()), nil },
+		Format:    &cmdutil.FormatFlags{},
+	}
+	require.NoError(t, listRun(context.Background(), opts))
+	assert.Empty(t, out.String())
+	assert.Contains(t, errOut.String(), "No saved sessions.")
+}
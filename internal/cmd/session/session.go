@@ -0,0 +1,46 @@
+// Package session provides the `clawker session` command group: the
+// harness conversation state saved on the host across container
+// recreation.
+package session
+
+import (
+	"github.com/spf13/cobra"
+
+	deletecmd "github.com/schmitthub/clawker/internal/cmd/session/delete"
+	"github.com/schmitthub/clawker/internal/cmd/session/list"
+	"github.com/schmitthub/clawker/internal/cmd/session/show"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+)
+
+// NewCmdSession creates the session parent command and registers its
+// subcommands.
+func NewCmdSession(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "session",
+		Short: "Manage saved agent sessions",
+		Long: `Commands for the agent sessions saved on the host.
+
+When an agent container stops, the conversation state its harness declares
+(for claude, ~/.claude/projects and ~/.claude/todos; for codex,
+~/.codex/sessions) is saved under the clawker data directory. When the
+container is later recreated with fresh volumes, the saved session is
+restored so the agent can resume its conversations. Dirs bind-mounted from
+the host (harnesses.<name>.mount_projects) already persist and are not
+saved. Set harnesses.<name>.persist_sessions: false in clawker.yaml to turn
+saving and restoring off.`,
+		Example: `  # List saved sessions
+  clawker session list
+
+  # Show what was saved for the dev agent
+  clawker session show dev
+
+  # Forget the dev agent's saved session
+  clawker session delete dev`,
+	}
+
+	cmd.AddCommand(deletecmd.NewCmdDelete(f, nil))
+	cmd.AddCommand(list.NewCmdList(f, nil))
+	cmd.AddCommand(show.NewCmdShow(f, nil))
+
+	return cmd
+}
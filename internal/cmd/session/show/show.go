@@ -0,0 +1,132 @@
+// Package show provides the session show command.
+package show
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/session"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// ShowOptions holds options for the session show command.
+type ShowOptions struct {
+	IOStreams      *iostreams.IOStreams
+	TUI            *tui.TUI
+	ProjectManager func() (project.ProjectManager, error)
+	Store          func() (*session.Store, error)
+
+	agent string
+}
+
+// NewCmdShow creates the session show command.
+func NewCmdShow(f *cmdutil.Factory, runF func(context.Context, *ShowOptions) error) *cobra.Command {
+	opts := &ShowOptions{
+		IOStreams:      f.IOStreams,
+		TUI:            f.TUI,
+		ProjectManager: f.ProjectManager,
+		Store:          session.DefaultStore,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "show AGENT",
+		Short: "Show an agent's saved session",
+		Long: `Shows the harness session saved for an agent of the current project:
+when it was saved, where it is stored on the host, and the files it holds,
+newest first.`,
+		Example: `  # Show the dev agent's saved session
+  clawker session show dev`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.agent = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return showRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.AgentCompletions(f.Client, f.ProjectManager))
+
+	return cmd
+}
+
+type sessionFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func showRun(ctx context.Context, opts *ShowOptions) error {
+	var projectName string
+	if opts.ProjectManager != nil {
+		if pm, err := opts.ProjectManager(); err == nil {
+			if p, err := pm.CurrentProject(ctx); err == nil {
+				projectName = p.Name()
+			}
+		}
+	}
+	containerName, err := docker.ContainerName(projectName, opts.agent)
+	if err != nil {
+		return err
+	}
+
+	store, err := opts.Store()
+	if err != nil {
+		return err
+	}
+	meta, err := store.Get(containerName)
+	if errors.Is(err, session.ErrNotFound) {
+		return fmt.Errorf("no saved session for agent %q", opts.agent)
+	}
+	if err != nil {
+		return err
+	}
+
+	dir := store.FilesDir(containerName)
+	var files []sessionFile
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, sessionFile{path: filepath.ToSlash(rel), size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("reading saved session: %w", err)
+	}
+	slices.SortFunc(files, func(a, b sessionFile) int { return b.modTime.Compare(a.modTime) })
+
+	ios := opts.IOStreams
+	fmt.Fprintf(ios.Out, "Container: %s\n", meta.Container)
+	fmt.Fprintf(ios.Out, "Harness:   %s\n", meta.Harness)
+	fmt.Fprintf(ios.Out, "Saved:     %s (%s ago)\n", meta.SavedAt.Local().Format(time.DateTime), units.HumanDuration(time.Since(meta.SavedAt)))
+	fmt.Fprintf(ios.Out, "Files:     %d (%s)\n", meta.Files, units.HumanSize(float64(meta.Bytes)))
+	fmt.Fprintf(ios.Out, "Location:  %s\n\n", dir)
+
+	tp := opts.TUI.NewTable("FILE", "SIZE", "MODIFIED")
+	for _, f := range files {
+		tp.AddRow(f.path, units.HumanSize(float64(f.size)), f.modTime.Local().Format(time.DateTime))
+	}
+	return tp.Render()
+}
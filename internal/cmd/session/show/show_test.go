@@ -0,0 +1,53 @@
+package show
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+	"github.com/schmitthub/clawker/internal/session"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+func TestShowRun(t *testing.T) {
+	store := session.NewStore(t.TempDir())
+	_, err := store.Save(session.Meta{Container: "clawker.app.dev", Project: "app", Agent: "dev", Harness: "claude", SavedAt: time.Now()},
+		func(dir string) error {
+			p := filepath.Join(dir, ".claude", "projects", "-app", "a.jsonl")
+			require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+			return os.WriteFile(p, []byte("{}\n"), 0o644)
+		})
+	require.NoError(t, err)
+
+	ios, _, out, _ := iostreams.Test()
+	opts := &ShowOptions{
+		IOStreams: ios,
+		TUI:       tui.NewTUI(ios),
+		ProjectManager: func() (project.ProjectManager, error) {
+			mgr := projectmocks.NewMockProjectManager()
+			mgr.CurrentProjectFunc = func(context.Context) (project.Project, error) {
+				return projectmocks.NewMockProject("app", t.TempDir()), nil
+			}
+			return mgr, nil
+		},
+		Store: func() (*session.Store, error) { return store, nil },
+		agent: "dev",
+	}
+	require.NoError(t, showRun(context.Background(), opts))
+	assert.Contains(t, out.String(), "Container: clawker.app.dev")
+	assert.Contains(t, out.String(), "Files:     1 (3B)")
+	assert.Contains(t, out.String(), ".claude/projects/-app/a.jsonl")
+
+	opts.agent = "writer"
+	err = showRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no saved session for agent "writer"`)
+}
@@ -129,7 +129,7 @@ func TestStackList_JSON(t *testing.T) {
 	f, out := newFactory(t, cfg)
 
 	cmd := listcmd.NewCmdList(f, nil)
-	cmd.SetArgs([]string{"--json"})
+	cmd.SetArgs([]string{"--format", "json"})
 	require.NoError(t, cmd.Execute())
 
 	s := strings.TrimSpace(out.String())
@@ -72,8 +72,7 @@ func createRun(ctx context.Context, opts *CreateOptions) error {
 	// Connect to Docker
 	client, err := opts.Client(ctx)
 	if err != nil {
-		cmdutil.HandleError(ios, err)
-		return err
+		return fmt.Errorf("connecting to Docker: %w", err)
 	}
 
 	// Build create options
@@ -87,8 +86,7 @@ func createRun(ctx context.Context, opts *CreateOptions) error {
 	// Create the volume
 	vol, err := client.VolumeCreate(ctx, createOpts)
 	if err != nil {
-		cmdutil.HandleError(ios, err)
-		return err
+		return fmt.Errorf("creating volume: %w", err)
 	}
 
 	// Print the volume name
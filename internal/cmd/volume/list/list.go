@@ -64,15 +64,13 @@ func listRun(ctx context.Context, opts *ListOptions) error {
 	// Connect to Docker
 	client, err := opts.Client(ctx)
 	if err != nil {
-		cmdutil.HandleError(ios, err)
-		return err
+		return fmt.Errorf("connecting to Docker: %w", err)
 	}
 
 	// List volumes
 	resp, err := client.VolumeList(ctx)
 	if err != nil {
-		cmdutil.HandleError(ios, err)
-		return err
+		return fmt.Errorf("listing volumes: %w", err)
 	}
 
 	if len(resp.Items) == 0 {
@@ -10,6 +10,7 @@ import (
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/prompter"
+	"github.com/schmitthub/clawker/internal/text"
 	"github.com/spf13/cobra"
 )
 
@@ -51,7 +52,10 @@ Use with caution as this will permanently delete data.`,
   clawker volume prune --all
 
   # Remove without confirmation prompt
-  clawker volume prune --force`,
+  clawker volume prune --force
+
+  # List the volumes that would be removed, changing nothing
+  clawker volume prune --dry-run --force`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if runF != nil {
 				return runF(cmd.Context(), opts)
@@ -63,6 +67,7 @@ Use with caution as this will permanently delete data.`,
 	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Do not prompt for confirmation")
 	cmd.Flags().BoolVarP(&opts.All, "all", "a", false, "Remove all clawker-managed volumes (default: only agent volumes)")
 
+	cmdutil.EnableDryRun(cmd)
 	return cmd
 }
 
@@ -112,27 +117,7 @@ func pruneRun(ctx context.Context, opts *PruneOptions) error {
 		fmt.Fprintf(ios.ErrOut, "%s %s\n", cs.SuccessIcon(), name)
 	}
 
-	fmt.Fprintf(ios.ErrOut, "\nTotal reclaimed space: %s\n", formatBytes(int64(report.Report.SpaceReclaimed)))
+	fmt.Fprintf(ios.ErrOut, "\nTotal reclaimed space: %s\n", text.FormatBytes(int64(report.Report.SpaceReclaimed)))
 
 	return nil
 }
-
-// formatBytes formats bytes into a human-readable string.
-func formatBytes(bytes int64) string {
-	const (
-		KB = 1024
-		MB = KB * 1024
-		GB = MB * 1024
-	)
-
-	switch {
-	case bytes >= GB:
-		return fmt.Sprintf("%.2fGB", float64(bytes)/GB)
-	case bytes >= MB:
-		return fmt.Sprintf("%.2fMB", float64(bytes)/MB)
-	case bytes >= KB:
-		return fmt.Sprintf("%.2fKB", float64(bytes)/KB)
-	default:
-		return fmt.Sprintf("%dB", bytes)
-	}
-}
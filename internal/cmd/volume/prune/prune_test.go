@@ -98,25 +98,3 @@ func TestCmdPrune_Properties(t *testing.T) {
 	require.NotNil(t, cmd.Flags().ShorthandLookup("f"))
 	require.NotNil(t, cmd.Flags().ShorthandLookup("a"))
 }
-
-func TestFormatBytes(t *testing.T) {
-	tests := []struct {
-		bytes int64
-		want  string
-	}{
-		{0, "0B"},
-		{500, "500B"},
-		{1024, "1.00KB"},
-		{1536, "1.50KB"},
-		{1048576, "1.00MB"},
-		{1073741824, "1.00GB"},
-		{1610612736, "1.50GB"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.want, func(t *testing.T) {
-			got := formatBytes(tt.bytes)
-			require.Equal(t, tt.want, got)
-		})
-	}
-}
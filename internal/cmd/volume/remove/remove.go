@@ -57,6 +57,7 @@ Note: Only clawker-managed volumes can be removed with this command.`,
 
 	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Force removal of volumes")
 
+	cmdutil.EnableDryRun(cmd)
 	return cmd
 }
 
@@ -67,8 +68,7 @@ func removeRun(ctx context.Context, opts *RemoveOptions) error {
 	// Connect to Docker
 	client, err := opts.Client(ctx)
 	if err != nil {
-		cmdutil.HandleError(ios, err)
-		return err
+		return fmt.Errorf("connecting to Docker: %w", err)
 	}
 
 	var errs []error
@@ -0,0 +1,335 @@
+// Package pull provides the workspace pull command.
+package pull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/cmd/workspace/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/git"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// PullOptions holds options for the pull command.
+type PullOptions struct {
+	IOStreams       *iostreams.IOStreams
+	Client          func(context.Context) (*docker.Client, error)
+	Config          func() (config.Config, error)
+	ProjectManager  func() (project.ProjectManager, error)
+	ProjectRegistry func() (*project.Registry, error)
+
+	Agent  bool
+	DryRun bool
+	Patch  string
+	Branch string
+	Force  bool
+
+	container string
+}
+
+// NewCmdPull creates the workspace pull command.
+func NewCmdPull(f *cmdutil.Factory, runF func(context.Context, *PullOptions) error) *cobra.Command {
+	opts := &PullOptions{
+		IOStreams:       f.IOStreams,
+		Client:          f.Client,
+		Config:          f.Config,
+		ProjectManager:  f.ProjectManager,
+		ProjectRegistry: f.ProjectRegistry,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "pull [OPTIONS] CONTAINER",
+		Short: "Bring an agent's workspace changes back to the host",
+		Long: `Extract the changes an agent made to the workspace copy inside a
+snapshot-mode container and bring them back to the host directory the
+container was created from. The container may be running or stopped.
+
+Changes are measured against what the container last received: the state
+recorded by the previous "clawker workspace sync", or otherwise the snapshot
+taken on create. Paths matched by .clawkerignore and the .git directory are
+never pulled.
+
+By default the changes are written into the host working tree. A change
+conflicts when the host modified the same path since the container received
+it; pull then refuses to write anything and lists the conflicts, unless
+--force is given to let the agent's version win.
+
+Instead of writing files, --patch writes the changes as a git patch (use "-"
+for stdout) to review or apply with "git apply", and --branch commits them to
+a new branch of the host repository on top of HEAD, leaving the working tree
+untouched.
+
+When --agent is provided, the container name is resolved as clawker.<project>.<agent>
+using the project resolved from the current directory.`,
+		Example: `  # Show what the agent changed: A(dded), M(odified), D(eleted)
+  clawker workspace pull --agent dev --dry-run
+
+  # Write the agent's changes into the host working tree
+  clawker workspace pull --agent dev
+
+  # Save them as a patch instead
+  clawker workspace pull --agent dev --patch agent.diff
+
+  # Commit them to a new branch for review
+  clawker workspace pull clawker.myapp.dev --branch agent/dev`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			if opts.Force && (opts.DryRun || opts.Patch != "" || opts.Branch != "") {
+				return cmdutil.FlagErrorf("--force only applies when writing changes into the working tree")
+			}
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return pullRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Agent, "agent", false, "Treat argument as agent name (resolves to clawker.<project>.<agent>)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "List the changes without writing anything")
+	cmd.Flags().StringVar(&opts.Patch, "patch", "", "Write the changes as a git patch to `file` (\"-\" for stdout)")
+	cmd.Flags().StringVar(&opts.Branch, "branch", "", "Commit the changes to a new branch of the host repository")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Overwrite conflicting host changes")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "patch", "branch")
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.ContainerCompletions(f.Client, f.ProjectManager))
+
+	return cmd
+}
+
+func pullRun(ctx context.Context, opts *PullOptions) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+	containerName := opts.container
+
+	if opts.Agent {
+		var projectName string
+		if opts.ProjectManager != nil {
+			if pm, pmErr := opts.ProjectManager(); pmErr == nil {
+				if p, pErr := pm.CurrentProject(ctx); pErr == nil {
+					projectName = p.Name()
+				}
+			}
+		}
+		containers, err := docker.ContainerNamesFromAgents(projectName, []string{containerName})
+		if err != nil {
+			return err
+		}
+		containerName = containers[0]
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	target, err := shared.FindTarget(ctx, client, cfg, opts.ProjectRegistry, containerName)
+	if err != nil {
+		return err
+	}
+
+	result, err := client.PullWorkspace(ctx, target.ID, target.Src, target.Dest, target.Ignore, target.Created)
+	if err != nil {
+		return fmt.Errorf("reading workspace of %s: %w", target.Name, err)
+	}
+	if len(result.Changes) == 0 {
+		fmt.Fprintln(ios.ErrOut, "No changes to pull.")
+		return nil
+	}
+
+	switch {
+	case opts.DryRun:
+		for _, ch := range result.Changes {
+			line := fmt.Sprintf("%s %s", kindLetter(ch.Kind), ch.Path)
+			if ch.Conflict {
+				line += " " + cs.Yellow("(conflict)")
+			}
+			fmt.Fprintln(ios.Out, line)
+		}
+		fmt.Fprintf(ios.ErrOut, "Would pull %d changes (%d conflicting).\n", len(result.Changes), len(result.Conflicts()))
+		return nil
+
+	case opts.Patch != "":
+		return writePatch(opts, target, result)
+
+	case opts.Branch != "":
+		return commitBranch(opts, target, result)
+	}
+
+	if conflicts := result.Conflicts(); len(conflicts) > 0 && !opts.Force {
+		fmt.Fprintf(ios.ErrOut, "%s The host changed these paths too:\n", cs.FailureIcon())
+		for _, p := range conflicts {
+			fmt.Fprintf(ios.ErrOut, "  %s\n", p)
+		}
+		fmt.Fprintln(ios.ErrOut, "Use --force to overwrite them, or --patch or --branch to review the changes first.")
+		return cmdutil.SilentError
+	}
+	if err := docker.ApplyPull(target.Src, result.Changes); err != nil {
+		return fmt.Errorf("writing changes to %s: %w", target.Src, err)
+	}
+	fmt.Fprintf(ios.ErrOut, "%s Pulled %d changes into %s\n", cs.SuccessIcon(), len(result.Changes), target.Src)
+	return nil
+}
+
+// writePatch renders the changes as a git patch against the host files,
+// with paths relative to the workspace root.
+func writePatch(opts *PullOptions, target *shared.Target, result *docker.PullResult) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	var from, to []git.FileChange
+	for _, ch := range result.Changes {
+		if ch.Kind != docker.PullAdded {
+			hostFile, ok, err := readHostFile(target.Src, ch.Path)
+			if err != nil {
+				return err
+			}
+			if ok {
+				from = append(from, hostFile)
+			}
+		}
+		if change, ok := fileChange(ch, ""); ok && !change.Delete {
+			to = append(to, change)
+		}
+	}
+
+	patch, err := git.Patch(from, to)
+	if err != nil {
+		return fmt.Errorf("building patch: %w", err)
+	}
+	if opts.Patch == "-" {
+		_, err := fmt.Fprint(ios.Out, patch)
+		return err
+	}
+	if err := os.WriteFile(opts.Patch, []byte(patch), 0o644); err != nil {
+		return fmt.Errorf("writing patch: %w", err)
+	}
+	fmt.Fprintf(ios.ErrOut, "%s Wrote %d changes to %s\n", cs.SuccessIcon(), len(result.Changes), opts.Patch)
+	return nil
+}
+
+// commitBranch commits the changes to a new branch of the repository holding
+// the host workspace.
+func commitBranch(opts *PullOptions, target *shared.Target, result *docker.PullResult) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	gitMgr, err := git.NewGitManager(target.Src)
+	if err != nil {
+		return fmt.Errorf("opening host repository: %w", err)
+	}
+	prefix, err := repoRelative(gitMgr.RepoRoot(), target.Src)
+	if err != nil {
+		return err
+	}
+
+	var changes []git.FileChange
+	for _, ch := range result.Changes {
+		if change, ok := fileChange(ch, prefix); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	message := fmt.Sprintf("Pull workspace changes from %s", target.Name)
+	hash, err := gitMgr.CommitChanges(opts.Branch, message, changes)
+	if errors.Is(err, git.ErrBranchAlreadyExists) {
+		return fmt.Errorf("branch %q already exists", opts.Branch)
+	}
+	if err != nil {
+		return fmt.Errorf("committing changes: %w", err)
+	}
+	fmt.Fprintf(ios.ErrOut, "%s Committed %d changes to branch %s (%s)\n",
+		cs.SuccessIcon(), len(result.Changes), opts.Branch, hash.String()[:7])
+	return nil
+}
+
+// fileChange converts a pulled change to its git form, with prefix joined to
+// the path. Directories have no git form.
+func fileChange(ch docker.PullChange, prefix string) (git.FileChange, bool) {
+	p := ch.Path
+	if prefix != "" {
+		p = prefix + "/" + p
+	}
+	if ch.Kind == docker.PullDeleted {
+		return git.FileChange{Path: p, Delete: true}, true
+	}
+	mode := ch.Entry.Mode
+	switch {
+	case mode.IsDir():
+		return git.FileChange{}, false
+	case mode&os.ModeSymlink != 0:
+		return git.FileChange{Path: p, Mode: os.ModeSymlink, Content: []byte(ch.Entry.Link)}, true
+	}
+	return git.FileChange{Path: p, Mode: mode.Perm(), Content: ch.Content}, true
+}
+
+// readHostFile reads a host workspace file or symlink in its git form. ok is
+// false for directories and missing paths.
+func readHostFile(src, rel string) (git.FileChange, bool, error) {
+	p := filepath.Join(src, filepath.FromSlash(rel))
+	info, err := os.Lstat(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return git.FileChange{}, false, nil
+	}
+	if err != nil {
+		return git.FileChange{}, false, err
+	}
+	switch {
+	case info.IsDir():
+		return git.FileChange{}, false, nil
+	case info.Mode()&os.ModeSymlink != 0:
+		link, err := os.Readlink(p)
+		if err != nil {
+			return git.FileChange{}, false, err
+		}
+		return git.FileChange{Path: rel, Mode: os.ModeSymlink, Content: []byte(link)}, true, nil
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return git.FileChange{}, false, err
+	}
+	return git.FileChange{Path: rel, Mode: info.Mode().Perm(), Content: data}, true, nil
+}
+
+// repoRelative returns src relative to the repository root, slash-separated,
+// or "" when src is the root.
+func repoRelative(root, src string) (string, error) {
+	if r, err := filepath.EvalSymlinks(root); err == nil {
+		root = r
+	}
+	if s, err := filepath.EvalSymlinks(src); err == nil {
+		src = s
+	}
+	rel, err := filepath.Rel(root, src)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("workspace %s is outside repository %s", src, root)
+	}
+	if rel == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func kindLetter(k docker.PullKind) string {
+	switch k {
+	case docker.PullAdded:
+		return "A"
+	case docker.PullDeleted:
+		return "D"
+	default:
+		return "M"
+	}
+}
@@ -0,0 +1,276 @@
+package pull
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v6"
+	gogitconfig "github.com/go-git/go-git/v6/config"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/google/shlex"
+	"github.com/schmitthub/clawker/internal/cmd/workspace/sync"
+	"github.com/schmitthub/clawker/internal/cmd/workspace/workspacetest"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdPull(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantOpts PullOptions
+		wantErr  string
+	}{
+		{name: "container", input: "clawker.myapp.dev"},
+		{name: "agent", input: "--agent dev", wantOpts: PullOptions{Agent: true}},
+		{name: "dry run", input: "--agent dev --dry-run", wantOpts: PullOptions{Agent: true, DryRun: true}},
+		{name: "patch", input: "--agent dev --patch out.diff", wantOpts: PullOptions{Agent: true, Patch: "out.diff"}},
+		{name: "branch", input: "--agent dev --branch agent/dev", wantOpts: PullOptions{Agent: true, Branch: "agent/dev"}},
+		{name: "force", input: "--agent dev --force", wantOpts: PullOptions{Agent: true, Force: true}},
+		{name: "patch and branch", input: "--agent dev --patch - --branch b", wantErr: "[branch patch] were all set"},
+		{name: "force with dry run", input: "--agent dev --force --dry-run", wantErr: "--force only applies"},
+		{name: "no container", input: "", wantErr: "requires 1 argument"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *PullOptions
+			cmd := NewCmdPull(f, func(_ context.Context, opts *PullOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOpts.Agent, gotOpts.Agent)
+			assert.Equal(t, tt.wantOpts.DryRun, gotOpts.DryRun)
+			assert.Equal(t, tt.wantOpts.Patch, gotOpts.Patch)
+			assert.Equal(t, tt.wantOpts.Branch, gotOpts.Branch)
+			assert.Equal(t, tt.wantOpts.Force, gotOpts.Force)
+		})
+	}
+}
+
+// --- Tier 2 tests (Cobra+Factory, real run function) ---
+
+const testContainer = workspacetest.ContainerName
+
+func run(fx *workspacetest.Fixture, ios *iostreams.IOStreams, args ...string) error {
+	cmd := NewCmdPull(fx.Factory(ios), nil)
+	cmd.SetArgs(args)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	return cmd.ExecuteContext(context.Background())
+}
+
+// agentEdits makes the agent's changes in the container: main.go modified,
+// notes.md added, README.md deleted.
+func agentEdits(t *testing.T, fx *workspacetest.Fixture) {
+	t.Helper()
+	workspacetest.WriteFiles(t, fx.Workspace("."), map[string]string{
+		"main.go":  "package main\n\nfunc main() {}\n",
+		"notes.md": "agent notes\n",
+	})
+	require.NoError(t, os.Remove(fx.Workspace("README.md")))
+}
+
+func hostFile(t *testing.T, fx *workspacetest.Fixture, rel string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(fx.Host, filepath.FromSlash(rel)))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestPullRun_DryRun(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{Stopped: true})
+	agentEdits(t, fx)
+
+	ios, _, out, errOut := iostreams.Test()
+	require.NoError(t, run(fx, ios, testContainer, "--dry-run"))
+	assert.Equal(t, "D README.md\nM main.go\nA notes.md\n", out.String())
+	assert.Contains(t, errOut.String(), "Would pull 3 changes (0 conflicting).")
+	assert.Equal(t, "package main\n", hostFile(t, fx, "main.go"))
+}
+
+func TestPullRun_Apply(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{})
+	agentEdits(t, fx)
+
+	ios, _, _, errOut := iostreams.Test()
+	require.NoError(t, run(fx, ios, "--agent", "dev"))
+	assert.Contains(t, errOut.String(), "Pulled 3 changes")
+	assert.Equal(t, "package main\n\nfunc main() {}\n", hostFile(t, fx, "main.go"))
+	assert.Equal(t, "agent notes\n", hostFile(t, fx, "notes.md"))
+	assert.NoFileExists(t, filepath.Join(fx.Host, "README.md"))
+	assert.Equal(t, "package pkg\n", hostFile(t, fx, "pkg/util.go"))
+
+	// Nothing is left to pull.
+	ios, _, _, errOut = iostreams.Test()
+	require.NoError(t, run(fx, ios, testContainer))
+	assert.Contains(t, errOut.String(), "No changes to pull.")
+}
+
+func TestPullRun_Conflict(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{})
+	agentEdits(t, fx)
+	// The host edits main.go too, and pkg/util.go which the agent left alone.
+	workspacetest.WriteFiles(t, fx.Host, map[string]string{
+		"main.go":     "package main // host\n",
+		"pkg/util.go": "package pkg // host\n",
+	})
+
+	ios, _, _, errOut := iostreams.Test()
+	err := run(fx, ios, testContainer)
+	require.ErrorIs(t, err, cmdutil.SilentError)
+	assert.Contains(t, errOut.String(), "The host changed these paths too:\n  main.go\n")
+	assert.Equal(t, "package main // host\n", hostFile(t, fx, "main.go"))
+	assert.FileExists(t, filepath.Join(fx.Host, "README.md"))
+
+	ios, _, _, _ = iostreams.Test()
+	require.NoError(t, run(fx, ios, testContainer, "--force"))
+	assert.Equal(t, "package main\n\nfunc main() {}\n", hostFile(t, fx, "main.go"))
+	assert.Equal(t, "package pkg // host\n", hostFile(t, fx, "pkg/util.go"))
+}
+
+func TestPullRun_AfterSync(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{})
+
+	// The host adds a file and syncs it in; the agent then edits it.
+	workspacetest.WriteFiles(t, fx.Host, map[string]string{"docs/a.md": "a\n"})
+	ios, _, _, _ := iostreams.Test()
+	syncCmd := sync.NewCmdSync(fx.Factory(ios), nil)
+	syncCmd.SetArgs([]string{testContainer})
+	syncCmd.SetOut(&bytes.Buffer{})
+	syncCmd.SetErr(&bytes.Buffer{})
+	require.NoError(t, syncCmd.ExecuteContext(context.Background()))
+
+	workspacetest.WriteFiles(t, fx.Workspace("."), map[string]string{"docs/a.md": "a, edited\n"})
+	// A host edit newer than the container is only a conflict when it
+	// touches what the agent changed.
+	workspacetest.WriteFiles(t, fx.Host, map[string]string{"main.go": "package main // host\n"})
+
+	ios, _, out, _ := iostreams.Test()
+	require.NoError(t, run(fx, ios, testContainer, "--dry-run"))
+	assert.Equal(t, "M docs/a.md\n", out.String())
+}
+
+func TestPullRun_Patch(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{})
+	agentEdits(t, fx)
+
+	ios, _, out, _ := iostreams.Test()
+	require.NoError(t, run(fx, ios, testContainer, "--patch", "-"))
+	patch := out.String()
+	assert.Contains(t, patch, "diff --git a/main.go b/main.go")
+	assert.Contains(t, patch, "+func main() {}")
+	assert.Contains(t, patch, "diff --git a/README.md b/README.md\ndeleted file mode 100644")
+	assert.Contains(t, patch, "diff --git a/notes.md b/notes.md\nnew file mode 100644")
+
+	file := filepath.Join(t.TempDir(), "agent.diff")
+	ios, _, _, errOut := iostreams.Test()
+	require.NoError(t, run(fx, ios, testContainer, "--patch", file))
+	assert.Contains(t, errOut.String(), "Wrote 3 changes to "+file)
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, patch, string(data))
+
+	// The host working tree is untouched.
+	assert.Equal(t, "package main\n", hostFile(t, fx, "main.go"))
+}
+
+func TestPullRun_Branch(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{})
+
+	// The workspace is a repository whose HEAD holds the seeded files.
+	repo, err := gogit.PlainInit(fx.Host, false)
+	require.NoError(t, err)
+	cfg, err := repo.Config()
+	require.NoError(t, err)
+	cfg.Commit.GpgSign = gogitconfig.OptBoolFalse
+	require.NoError(t, repo.SetConfig(cfg))
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, wt.AddGlob("."))
+	_, err = wt.Commit("initial commit", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@test.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	agentEdits(t, fx)
+
+	ios, _, _, errOut := iostreams.Test()
+	require.NoError(t, run(fx, ios, testContainer, "--branch", "agent/dev"))
+	assert.Contains(t, errOut.String(), "Committed 3 changes to branch agent/dev")
+
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName("agent/dev"), true)
+	require.NoError(t, err)
+	commit, err := repo.CommitObject(ref.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, "Pull workspace changes from "+testContainer, commit.Message)
+	tree, err := commit.Tree()
+	require.NoError(t, err)
+
+	f, err := tree.File("main.go")
+	require.NoError(t, err)
+	content, err := f.Contents()
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n\nfunc main() {}\n", content)
+	_, err = tree.File("notes.md")
+	assert.NoError(t, err)
+	_, err = tree.File("README.md")
+	assert.ErrorIs(t, err, object.ErrFileNotFound)
+
+	// The working tree is untouched, and the branch cannot be reused.
+	assert.Equal(t, "package main\n", hostFile(t, fx, "main.go"))
+	ios, _, _, _ = iostreams.Test()
+	err = run(fx, ios, testContainer, "--branch", "agent/dev")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `branch "agent/dev" already exists`)
+}
+
+func TestPullRun_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    workspacetest.Options
+		args    []string
+		wantErr string
+	}{
+		{name: "not found", args: []string{"--agent", "other"}, wantErr: "not found"},
+		{name: "bind mode", opts: workspacetest.Options{Bind: true}, args: []string{testContainer}, wantErr: "bind-mode workspaces are the host directory itself"},
+		{name: "branch outside a repository", args: []string{testContainer, "--branch", "b"}, wantErr: "opening host repository"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := workspacetest.NewFixture(t, tt.opts)
+			agentEdits(t, fx)
+			ios, _, _, _ := iostreams.Test()
+
+			err := run(fx, ios, tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
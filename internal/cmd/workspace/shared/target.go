@@ -0,0 +1,118 @@
+// Package shared holds the container workspace resolution the workspace
+// subcommands have in common.
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/project"
+)
+
+// Target is a snapshot-mode container's workspace: the host directory the
+// container was created from and the path its workspace volume is mounted
+// at.
+type Target struct {
+	ID   string
+	Name string
+	// Src is the host workspace directory.
+	Src string
+	// Dest is the workspace path inside the container.
+	Dest string
+	// Ignore holds the .clawkerignore patterns that applied on create.
+	Ignore []string
+	// Running reports whether the container is running.
+	Running bool
+	// Created is when the container was created, i.e. when its snapshot was
+	// taken.
+	Created time.Time
+}
+
+// FindTarget looks up the named container and locates both ends of its
+// workspace. Bind-mode containers have no workspace volume and are refused:
+// their workspace is the host directory itself.
+func FindTarget(ctx context.Context, client *docker.Client, cfg config.Config, registry func() (*project.Registry, error), name string) (*Target, error) {
+	c, err := client.FindContainerByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find container %q: %w", name, err)
+	}
+	if c == nil {
+		return nil, fmt.Errorf("container %q not found", name)
+	}
+
+	projectName := c.Labels[cfg.LabelProject()]
+	agent := c.Labels[cfg.LabelAgent()]
+	src := c.Labels[cfg.LabelWorkdir()]
+	if agent == "" || src == "" {
+		return nil, fmt.Errorf("container %s has no recorded host workspace", name)
+	}
+
+	volume, err := docker.VolumeName(projectName, agent, docker.VolumePurposeWorkspace)
+	if err != nil {
+		return nil, err
+	}
+	var dest string
+	for _, m := range c.Mounts {
+		if m.Type == mount.TypeVolume && m.Name == volume {
+			dest = m.Destination
+			break
+		}
+	}
+	if dest == "" {
+		return nil, fmt.Errorf("container %s has no workspace snapshot volume; bind-mode workspaces are the host directory itself", name)
+	}
+
+	if info, err := os.Stat(src); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("host workspace %s is not a directory", src)
+	}
+
+	ignore, err := loadIgnorePatterns(registry, src)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Target{
+		ID:      c.ID,
+		Name:    name,
+		Src:     src,
+		Dest:    dest,
+		Ignore:  ignore,
+		Running: c.State == container.StateRunning,
+		Created: time.Unix(c.Created, 0),
+	}, nil
+}
+
+// loadIgnorePatterns reads the .clawkerignore at the registered project root
+// containing src. Outside a registered project there are no patterns, as on
+// create.
+func loadIgnorePatterns(registry func() (*project.Registry, error), src string) ([]string, error) {
+	if registry == nil {
+		return nil, nil
+	}
+	reg, err := registry()
+	if err != nil {
+		return nil, fmt.Errorf("loading project registry: %w", err)
+	}
+	root, err := reg.ResolveRoot(src)
+	if err != nil {
+		if errors.Is(err, project.ErrNotInProject) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("resolving project root: %w", err)
+	}
+	ignoreFile := filepath.Join(root, consts.IgnoreFile)
+	patterns, err := docker.LoadIgnorePatterns(ignoreFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", ignoreFile, err)
+	}
+	return patterns, nil
+}
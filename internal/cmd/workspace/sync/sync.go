@@ -0,0 +1,264 @@
+// Package sync provides the workspace sync command.
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/schmitthub/clawker/internal/cmd/workspace/shared"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/text"
+	"github.com/spf13/cobra"
+)
+
+// watchDebounce coalesces the burst of events a save or a git checkout
+// produces into one sync.
+var watchDebounce = 300 * time.Millisecond
+
+// SyncOptions holds options for the sync command.
+type SyncOptions struct {
+	IOStreams       *iostreams.IOStreams
+	Client          func(context.Context) (*docker.Client, error)
+	Config          func() (config.Config, error)
+	ProjectManager  func() (project.ProjectManager, error)
+	ProjectRegistry func() (*project.Registry, error)
+
+	Agent  bool
+	Watch  bool
+	DryRun bool
+	Full   bool
+
+	container string
+}
+
+// NewCmdSync creates the workspace sync command.
+func NewCmdSync(f *cmdutil.Factory, runF func(context.Context, *SyncOptions) error) *cobra.Command {
+	opts := &SyncOptions{
+		IOStreams:       f.IOStreams,
+		Client:          f.Client,
+		Config:          f.Config,
+		ProjectManager:  f.ProjectManager,
+		ProjectRegistry: f.ProjectRegistry,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "sync [OPTIONS] CONTAINER",
+		Short: "Push host workspace changes into a snapshot-mode container",
+		Long: `Bring the workspace copy inside a running snapshot-mode container up to
+date with the host directory it was created from.
+
+Only what changed is sent: files are compared by content hash against the
+state the previous sync recorded inside the container, new and modified
+paths are uploaded as a single archive, and paths deleted on the host are
+removed. The first sync after a container is created uploads every path and
+removes nothing. Paths matched by .clawkerignore are skipped, as on create.
+
+The host wins for every path it changed. Files changed only inside the
+container are left alone.
+
+With --watch the command keeps running and syncs again whenever the host
+workspace changes, until interrupted.
+
+Bind-mode containers mount the host directory directly and need no sync.
+
+When --agent is provided, the container name is resolved as clawker.<project>.<agent>
+using the project resolved from the current directory.`,
+		Example: `  # Push host changes into an agent's container
+  clawker workspace sync --agent dev
+
+  # Keep pushing changes as they happen
+  clawker workspace sync --agent dev --watch
+
+  # Show what would be uploaded (+) and removed (-)
+  clawker workspace sync --agent dev --dry-run
+
+  # Re-upload every path, ignoring the recorded state
+  clawker workspace sync clawker.myapp.dev --full`,
+		Args: cmdutil.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			if runF != nil {
+				return runF(cmd.Context(), opts)
+			}
+			return syncRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Agent, "agent", false, "Treat argument as agent name (resolves to clawker.<project>.<agent>)")
+	cmd.Flags().BoolVarP(&opts.Watch, "watch", "w", false, "Keep syncing as the host workspace changes")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Print the paths a sync would upload and remove without changing the container")
+	cmd.Flags().BoolVar(&opts.Full, "full", false, "Upload every path, ignoring the state recorded by the last sync")
+	cmd.MarkFlagsMutuallyExclusive("watch", "dry-run")
+
+	cmd.ValidArgsFunction = cmdutil.FirstArgCompletions(cmdutil.ContainerCompletions(f.Client, f.ProjectManager))
+
+	return cmd
+}
+
+func syncRun(ctx context.Context, opts *SyncOptions) error {
+	containerName := opts.container
+
+	if opts.Agent {
+		var projectName string
+		if opts.ProjectManager != nil {
+			if pm, pmErr := opts.ProjectManager(); pmErr == nil {
+				if p, pErr := pm.CurrentProject(ctx); pErr == nil {
+					projectName = p.Name()
+				}
+			}
+		}
+		containers, err := docker.ContainerNamesFromAgents(projectName, []string{containerName})
+		if err != nil {
+			return err
+		}
+		containerName = containers[0]
+	}
+
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("connecting to Docker: %w", err)
+	}
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	target, err := shared.FindTarget(ctx, client, cfg, opts.ProjectRegistry, containerName)
+	if err != nil {
+		return err
+	}
+	if !target.Running {
+		return fmt.Errorf("container %s is not running", containerName)
+	}
+
+	sopts := docker.SyncOptions{DryRun: opts.DryRun, Full: opts.Full}
+	result, err := client.SyncWorkspace(ctx, target.ID, target.Src, target.Dest, target.Ignore, sopts)
+	if err != nil {
+		return fmt.Errorf("syncing workspace of %s: %w", target.Name, err)
+	}
+	report(opts, result)
+
+	if !opts.Watch {
+		return nil
+	}
+	return watch(ctx, opts, client, target, result.Manifest)
+}
+
+// report prints a dry run's plan to stdout, or a sync's summary to stderr.
+func report(opts *SyncOptions, result *docker.SyncResult) {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	if opts.DryRun {
+		for _, p := range result.Upload {
+			fmt.Fprintf(ios.Out, "+ %s\n", p)
+		}
+		for _, p := range result.Delete {
+			fmt.Fprintf(ios.Out, "- %s\n", p)
+		}
+		fmt.Fprintf(ios.ErrOut, "Would upload %d paths (%s) and remove %d.\n",
+			len(result.Upload), text.FormatBytes(result.Bytes), len(result.Delete))
+		return
+	}
+
+	if result.Empty() {
+		if !opts.Watch {
+			fmt.Fprintln(ios.ErrOut, "Workspace is up to date.")
+		}
+		return
+	}
+	fmt.Fprintf(ios.ErrOut, "%s Uploaded %d paths (%s), removed %d\n",
+		cs.SuccessIcon(), len(result.Upload), text.FormatBytes(result.Bytes), len(result.Delete))
+}
+
+// watch syncs again after every burst of host changes until ctx is done.
+// Every synced directory is watched; directories created later are added
+// after the sync that uploads them.
+func watch(ctx context.Context, opts *SyncOptions, client *docker.Client, target *shared.Target, manifest *docker.SyncManifest) error {
+	ios := opts.IOStreams
+	cs := ios.ColorScheme()
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watching %s: %w", target.Src, err)
+	}
+	defer w.Close()
+
+	addDirs := func(m *docker.SyncManifest) error {
+		if err := w.Add(target.Src); err != nil {
+			return err
+		}
+		for p, e := range m.Files {
+			if !e.Mode.IsDir() {
+				continue
+			}
+			// A directory removed since the scan is picked up next sync.
+			if err := w.Add(filepath.Join(target.Src, filepath.FromSlash(p))); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := addDirs(manifest); err != nil {
+		return fmt.Errorf("watching %s: %w", target.Src, err)
+	}
+
+	fmt.Fprintf(ios.ErrOut, "Watching %s for changes (Ctrl+C to stop)\n", target.Src)
+
+	var (
+		timer   *time.Timer
+		timerCh <-chan time.Time
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			if ev.Op == fsnotify.Chmod {
+				continue
+			}
+			if timer == nil {
+				timer = time.NewTimer(watchDebounce)
+			} else {
+				timer.Reset(watchDebounce)
+			}
+			timerCh = timer.C
+		case werr, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(ios.ErrOut, "%s watch error: %v\n", cs.WarningIcon(), werr)
+		case <-timerCh:
+			timerCh = nil
+			result, err := client.SyncWorkspace(ctx, target.ID, target.Src, target.Dest, target.Ignore, docker.SyncOptions{})
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				fmt.Fprintf(ios.ErrOut, "%s sync failed: %v\n", cs.WarningIcon(), err)
+				continue
+			}
+			report(opts, result)
+			if err := addDirs(result.Manifest); err != nil {
+				fmt.Fprintf(ios.ErrOut, "%s watch error: %v\n", cs.WarningIcon(), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/shlex"
+	"github.com/schmitthub/clawker/internal/cmd/workspace/workspacetest"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdSync(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantOpts SyncOptions
+		wantErr  string
+	}{
+		{name: "container", input: "clawker.myapp.dev"},
+		{name: "agent", input: "--agent dev", wantOpts: SyncOptions{Agent: true}},
+		{name: "watch", input: "--agent dev -w", wantOpts: SyncOptions{Agent: true, Watch: true}},
+		{name: "dry run", input: "--agent dev --dry-run", wantOpts: SyncOptions{Agent: true, DryRun: true}},
+		{name: "full", input: "--agent dev --full", wantOpts: SyncOptions{Agent: true, Full: true}},
+		{name: "watch and dry run", input: "--agent dev --watch --dry-run", wantErr: "[dry-run watch] were all set"},
+		{name: "no container", input: "", wantErr: "requires 1 argument"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := cmdutiltest.NewFactory(t).Factory
+
+			var gotOpts *SyncOptions
+			cmd := NewCmdSync(f, func(_ context.Context, opts *SyncOptions) error {
+				gotOpts = opts
+				return nil
+			})
+
+			argv, err := shlex.Split(tt.input)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOpts.Agent, gotOpts.Agent)
+			assert.Equal(t, tt.wantOpts.Watch, gotOpts.Watch)
+			assert.Equal(t, tt.wantOpts.DryRun, gotOpts.DryRun)
+			assert.Equal(t, tt.wantOpts.Full, gotOpts.Full)
+		})
+	}
+}
+
+// --- Tier 2 tests (Cobra+Factory, real run function) ---
+
+const testContainer = workspacetest.ContainerName
+
+func run(ctx context.Context, fx *workspacetest.Fixture, ios *iostreams.IOStreams, args ...string) error {
+	cmd := NewCmdSync(fx.Factory(ios), nil)
+	cmd.SetArgs(args)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	return cmd.ExecuteContext(ctx)
+}
+
+// workspaceFile reads a file from the container's workspace.
+func workspaceFile(t *testing.T, fx *workspacetest.Fixture, rel string) string {
+	t.Helper()
+	data, err := os.ReadFile(fx.Workspace(rel))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func workspaceHas(fx *workspacetest.Fixture, rel string) bool {
+	_, err := os.Lstat(fx.Workspace(rel))
+	return err == nil
+}
+
+func TestSyncRun_Incremental(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{})
+	ctx := context.Background()
+
+	// First sync: everything goes up, nothing is removed.
+	ios, _, _, errOut := iostreams.Test()
+	require.NoError(t, run(ctx, fx, ios, testContainer))
+	assert.Contains(t, errOut.String(), "Uploaded 4 paths")
+	assert.Equal(t, "package main\n", workspaceFile(t, fx, "main.go"))
+	assert.Equal(t, "package pkg\n", workspaceFile(t, fx, "pkg/util.go"))
+
+	// A file created only in the container survives later syncs.
+	workspacetest.WriteFiles(t, fx.Workspace("."), map[string]string{"scratch.txt": "agent notes"})
+
+	// Edit one file, add one, delete a directory.
+	workspacetest.WriteFiles(t, fx.Host, map[string]string{
+		"main.go":   "package main\n\nfunc main() {}\n",
+		"docs/a.md": "a",
+	})
+	require.NoError(t, os.RemoveAll(filepath.Join(fx.Host, "pkg")))
+	fx.ResetExecs()
+
+	ios, _, _, errOut = iostreams.Test()
+	require.NoError(t, run(ctx, fx, ios, "--agent", "dev"))
+	assert.Contains(t, errOut.String(), "Uploaded 3 paths")
+	assert.Contains(t, errOut.String(), "removed 1")
+	assert.Equal(t, "package main\n\nfunc main() {}\n", workspaceFile(t, fx, "main.go"))
+	assert.Equal(t, "a", workspaceFile(t, fx, "docs/a.md"))
+	assert.False(t, workspaceHas(fx, "pkg"))
+	assert.True(t, workspaceHas(fx, "scratch.txt"))
+	assert.Contains(t, fx.Execs(), []string{"rm", "-rf", "--", "/workspace/pkg"})
+
+	// Nothing changed since.
+	ios, _, _, errOut = iostreams.Test()
+	require.NoError(t, run(ctx, fx, ios, testContainer))
+	assert.Contains(t, errOut.String(), "Workspace is up to date.")
+}
+
+func TestSyncRun_DryRun(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{})
+	ctx := context.Background()
+
+	ios, _, out, errOut := iostreams.Test()
+	require.NoError(t, run(ctx, fx, ios, testContainer, "--dry-run"))
+	assert.Equal(t, "+ README.md\n+ main.go\n+ pkg\n+ pkg/util.go\n", out.String())
+	assert.Contains(t, errOut.String(), "Would upload 4 paths")
+	assert.NoFileExists(t, fx.Local(docker.SyncManifestPath))
+	assert.Empty(t, fx.Execs())
+}
+
+func TestSyncRun_Full(t *testing.T) {
+	fx := workspacetest.NewFixture(t, workspacetest.Options{})
+	ctx := context.Background()
+
+	ios, _, _, _ := iostreams.Test()
+	require.NoError(t, run(ctx, fx, ios, testContainer))
+
+	ios, _, _, errOut := iostreams.Test()
+	require.NoError(t, run(ctx, fx, ios, testContainer, "--full"))
+	assert.Contains(t, errOut.String(), "Uploaded 4 paths")
+}
+
+func TestSyncRun_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    workspacetest.Options
+		args    []string
+		wantErr string
+	}{
+		{name: "not found", args: []string{"--agent", "other"}, wantErr: "not found"},
+		{name: "stopped", opts: workspacetest.Options{Stopped: true}, args: []string{testContainer}, wantErr: "is not running"},
+		{name: "bind mode", opts: workspacetest.Options{Bind: true}, args: []string{testContainer}, wantErr: "bind-mode workspaces are the host directory itself"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := workspacetest.NewFixture(t, tt.opts)
+			ios, _, _, _ := iostreams.Test()
+
+			err := run(context.Background(), fx, ios, tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSyncRun_Watch(t *testing.T) {
+	old := watchDebounce
+	watchDebounce = 20 * time.Millisecond
+	t.Cleanup(func() { watchDebounce = old })
+
+	fx := workspacetest.NewFixture(t, workspacetest.Options{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ios, _, _, _ := iostreams.Test()
+	done := make(chan error, 1)
+	go func() { done <- run(ctx, fx, ios, testContainer, "--watch") }()
+
+	// Rewrite the file until it arrives: writes before the watcher is up
+	// are not seen.
+	require.Eventually(t, func() bool {
+		workspacetest.WriteFiles(t, fx.Host, map[string]string{"pkg/new.go": "package pkg // new\n"})
+		data, err := os.ReadFile(fx.Workspace("pkg/new.go"))
+		return err == nil && string(data) == "package pkg // new\n"
+	}, 5*time.Second, 50*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not stop after cancellation")
+	}
+}
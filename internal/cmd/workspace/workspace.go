@@ -0,0 +1,38 @@
+// Package workspace provides the workspace command and its subcommands.
+package workspace
+
+import (
+	"github.com/schmitthub/clawker/internal/cmd/workspace/pull"
+	"github.com/schmitthub/clawker/internal/cmd/workspace/sync"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdWorkspace creates the workspace command.
+// This is a parent command that groups workspace-related subcommands.
+func NewCmdWorkspace(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Manage agent workspaces",
+		Long: `Manage the workspaces of clawker containers.
+
+In snapshot mode a container works on its own copy of the host directory,
+taken when the container is created. The workspace commands keep that copy
+in step with the host and bring the agent's changes back.`,
+		Example: `  # Push host changes into an agent's container
+  clawker workspace sync --agent dev
+
+  # Keep pushing changes as they happen
+  clawker workspace sync --agent dev --watch
+
+  # Bring the agent's changes back to the host
+  clawker workspace pull --agent dev`,
+		// No RunE - this is a parent command
+	}
+
+	// Add subcommands
+	cmd.AddCommand(pull.NewCmdPull(f, nil))
+	cmd.AddCommand(sync.NewCmdSync(f, nil))
+
+	return cmd
+}
@@ -0,0 +1,59 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+)
+
+func TestNewCmdWorkspace(t *testing.T) {
+	cmd := NewCmdWorkspace(cmdutiltest.NewFactory(t).Factory)
+
+	// Verify command basics
+	if cmd.Use != "workspace" {
+		t.Errorf("expected Use 'workspace', got '%s'", cmd.Use)
+	}
+
+	if cmd.Short == "" {
+		t.Error("expected Short description to be set")
+	}
+
+	if cmd.Long == "" {
+		t.Error("expected Long description to be set")
+	}
+
+	if cmd.Example == "" {
+		t.Error("expected Example to be set")
+	}
+
+	// Verify this is a parent command (no RunE)
+	if cmd.RunE != nil {
+		t.Error("expected RunE to be nil for parent command")
+	}
+}
+
+func TestNewCmdWorkspace_Subcommands(t *testing.T) {
+	cmd := NewCmdWorkspace(cmdutiltest.NewFactory(t).Factory)
+
+	subcommands := cmd.Commands()
+
+	// Check expected subcommands are registered
+	expectedSubcommands := []string{"pull", "sync"}
+	if len(subcommands) != len(expectedSubcommands) {
+		t.Errorf("expected %d subcommands, got %d", len(expectedSubcommands), len(subcommands))
+	}
+
+	// Verify each expected subcommand is present
+	for _, expected := range expectedSubcommands {
+		found := false
+		for _, sub := range subcommands {
+			if sub.Name() == expected {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected subcommand '%s' not found", expected)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+// Package workspacetest provides a fake snapshot-mode container for testing
+// the workspace commands.
+package workspacetest
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/mount"
+	"github.com/moby/moby/client"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// ContainerName is the fixture container, agent "dev" of project "myapp".
+	ContainerName = "clawker.myapp.dev"
+	// Dest is where the workspace is mounted in the fixture container.
+	Dest = "/workspace"
+)
+
+// SnapshotTime is the modification time the fixture gives the seeded files
+// on both sides, before the container was created.
+var SnapshotTime = time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+// Options tunes NewFixture.
+type Options struct {
+	// Stopped leaves the container exited.
+	Stopped bool
+	// Bind mounts the host directory instead of a workspace volume.
+	Bind bool
+	// Files seeds the host workspace and, as the create-time snapshot, the
+	// container's copy. Defaults to a small Go tree.
+	Files map[string]string
+}
+
+// Fixture is a stateful fake daemon holding one snapshot-mode container whose
+// filesystem lives in CtrRoot. Archive uploads and downloads and the
+// rm/mkdir/chown execs the sync engine issues act on that directory.
+type Fixture struct {
+	Fake    *mocks.FakeClient
+	Host    string
+	CtrRoot string
+
+	mu    sync.Mutex
+	execs [][]string
+}
+
+// NewFixture seeds the host workspace and the container's snapshot of it.
+func NewFixture(t *testing.T, o Options) *Fixture {
+	t.Helper()
+	fx := &Fixture{
+		Fake:    mocks.NewFakeClient(configmocks.NewBlankConfig()),
+		Host:    t.TempDir(),
+		CtrRoot: t.TempDir(),
+	}
+	files := o.Files
+	if files == nil {
+		files = map[string]string{
+			"main.go":     "package main\n",
+			"pkg/util.go": "package pkg\n",
+			"README.md":   "# app\n",
+		}
+	}
+	require.NoError(t, os.MkdirAll(fx.Local(Dest), 0o755))
+	WriteFiles(t, fx.Host, files)
+	Backdate(t, fx.Host, files)
+
+	cfg := fx.Fake.Cfg
+	state := fx.Fake.EnableState()
+	state.AddImage("node:20-slim", nil)
+	id, err := state.AddContainer(whailtest.ContainerSpec{
+		Name:  ContainerName,
+		Image: "node:20-slim",
+		Labels: map[string]string{
+			cfg.LabelProject(): "myapp",
+			cfg.LabelAgent():   "dev",
+			cfg.LabelWorkdir(): fx.Host,
+		},
+		Running: true,
+	})
+	require.NoError(t, err)
+	if o.Stopped {
+		require.NoError(t, state.Exit(id, 0))
+	}
+
+	// The state records no mounts; attach the workspace volume (or, for a
+	// bind-mode container, the host directory) to listed containers.
+	volume, err := docker.VolumeName("myapp", "dev", docker.VolumePurposeWorkspace)
+	require.NoError(t, err)
+	wsMount := container.MountPoint{Type: mount.TypeVolume, Name: volume, Destination: Dest}
+	if o.Bind {
+		wsMount = container.MountPoint{Type: mount.TypeBind, Source: fx.Host, Destination: Dest}
+	}
+	list := fx.Fake.FakeAPI.ContainerListFn
+	fx.Fake.FakeAPI.ContainerListFn = func(ctx context.Context, opts client.ContainerListOptions) (client.ContainerListResult, error) {
+		res, err := list(ctx, opts)
+		for i := range res.Items {
+			res.Items[i].Mounts = []container.MountPoint{wsMount}
+		}
+		return res, err
+	}
+
+	fx.Fake.FakeAPI.CopyToContainerFn = func(_ context.Context, _ string, opts client.CopyToContainerOptions) (client.CopyToContainerResult, error) {
+		return client.CopyToContainerResult{}, fx.extract(opts.DestinationPath, opts.Content)
+	}
+	fx.Fake.FakeAPI.CopyFromContainerFn = func(_ context.Context, _ string, opts client.CopyFromContainerOptions) (client.CopyFromContainerResult, error) {
+		rc, err := fx.archive(opts.SourcePath)
+		return client.CopyFromContainerResult{Content: rc}, err
+	}
+	fx.Fake.FakeAPI.ExecCreateFn = func(_ context.Context, _ string, opts client.ExecCreateOptions) (client.ExecCreateResult, error) {
+		fx.exec(opts.Cmd)
+		return client.ExecCreateResult{ID: "exec-1"}, nil
+	}
+	fx.Fake.SetupExecAttach()
+	fx.Fake.SetupExecInspect(0)
+
+	// The snapshot copy keeps host modification times, as CopyToVolume does.
+	WriteFiles(t, fx.Local(Dest), files)
+	Backdate(t, fx.Local(Dest), files)
+	return fx
+}
+
+// Factory returns a factory wired to the fixture, with the current project
+// "myapp".
+func (fx *Fixture) Factory(ios *iostreams.IOStreams) *cmdutil.Factory {
+	return &cmdutil.Factory{
+		IOStreams: ios,
+		Client: func(_ context.Context) (*docker.Client, error) {
+			return fx.Fake.Client, nil
+		},
+		Config: func() (config.Config, error) { return fx.Fake.Cfg, nil },
+		ProjectManager: func() (project.ProjectManager, error) {
+			mgr := projectmocks.NewMockProjectManager()
+			mgr.CurrentProjectFunc = func(context.Context) (project.Project, error) {
+				return projectmocks.NewMockProject("myapp", fx.Host), nil
+			}
+			return mgr, nil
+		},
+	}
+}
+
+// Local maps a container path into CtrRoot.
+func (fx *Fixture) Local(p string) string {
+	return filepath.Join(fx.CtrRoot, filepath.FromSlash(p))
+}
+
+// Workspace maps a workspace-relative path into CtrRoot.
+func (fx *Fixture) Workspace(rel string) string {
+	return fx.Local(path.Join(Dest, rel))
+}
+
+// Execs returns the commands run in the container so far.
+func (fx *Fixture) Execs() [][]string {
+	fx.mu.Lock()
+	defer fx.mu.Unlock()
+	return append([][]string(nil), fx.execs...)
+}
+
+// ResetExecs forgets the recorded commands.
+func (fx *Fixture) ResetExecs() {
+	fx.mu.Lock()
+	defer fx.mu.Unlock()
+	fx.execs = nil
+}
+
+func (fx *Fixture) exec(cmd []string) {
+	fx.mu.Lock()
+	defer fx.mu.Unlock()
+	fx.execs = append(fx.execs, cmd)
+	switch cmd[0] {
+	case "rm":
+		for _, p := range cmd[3:] {
+			_ = os.RemoveAll(fx.Local(p))
+		}
+	case "mkdir":
+		_ = os.MkdirAll(fx.Local(cmd[2]), 0o755)
+	}
+}
+
+func (fx *Fixture) extract(dest string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target := fx.Local(path.Join(dest, hdr.Name))
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			_ = os.RemoveAll(target)
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(target, data, 0o644); err != nil {
+				return err
+			}
+			if err := os.Chtimes(target, hdr.ModTime, hdr.ModTime); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// archive tars src the way the daemon does: entries are rooted at src's
+// base name.
+func (fx *Fixture) archive(src string) (io.ReadCloser, error) {
+	root := fx.Local(src)
+	if _, err := os.Lstat(root); errors.Is(err, os.ErrNotExist) {
+		return nil, cerrdefs.ErrNotFound
+	}
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(filepath.Dir(root), p)
+		if err != nil {
+			return err
+		}
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(&buf), nil
+}
+
+// WriteFiles writes files (slash-separated paths → content) under root.
+func WriteFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+}
+
+// Backdate sets the modification time of files under root to SnapshotTime.
+func Backdate(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		require.NoError(t, os.Chtimes(p, SnapshotTime, SnapshotTime))
+	}
+}
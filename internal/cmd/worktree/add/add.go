@@ -6,39 +6,68 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/schmitthub/clawker/internal/cmd/container/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/hostproxy"
 	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/project"
 	"github.com/spf13/cobra"
 )
 
+// createContainer is shared.CreateContainer, replaced in tests.
+var createContainer = shared.CreateContainer
+
 // AddOptions contains the options for the add command.
 type AddOptions struct {
-	IOStreams      *iostreams.IOStreams
-	ProjectManager func() (project.ProjectManager, error)
+	IOStreams       *iostreams.IOStreams
+	ProjectManager  func() (project.ProjectManager, error)
+	Client          func(context.Context) (*docker.Client, error)
+	Config          func() (config.Config, error)
+	ProjectRegistry func() (*project.Registry, error)
+	HostProxy       func() hostproxy.Service
+	Logger          func() (*logger.Logger, error)
+	Version         string
 
 	Branch  string
 	Base    string
 	NoTrack bool
+	Agent   string
+	Image   string
 }
 
 // NewCmdAdd creates the worktree add command.
 func NewCmdAdd(f *cmdutil.Factory, runF func(context.Context, *AddOptions) error) *cobra.Command {
 	opts := &AddOptions{
-		IOStreams:      f.IOStreams,
-		ProjectManager: f.ProjectManager,
+		IOStreams:       f.IOStreams,
+		ProjectManager:  f.ProjectManager,
+		Client:          f.Client,
+		Config:          f.Config,
+		ProjectRegistry: f.ProjectRegistry,
+		HostProxy:       f.HostProxy,
+		Logger:          f.Logger,
+		Version:         f.Version,
 	}
 
 	cmd := &cobra.Command{
-		Use:   "add BRANCH",
-		Short: "Create a worktree for a branch",
-		Long: `Creates a git worktree for the specified branch.
+		Use:     "add BRANCH",
+		Aliases: []string{"create"},
+		Short:   "Create a worktree for a branch",
+		Long: `Creates a git worktree for the specified branch and registers it with
+the current project.
 
 If the worktree already exists, the command will fail.
 If the branch exists but isn't checked out elsewhere, it's checked out in the new worktree.
 If the branch doesn't exist but a remote-tracking branch matches its name (e.g. after
 'git fetch'), it's created from the remote tip with upstream tracking configured.
-Otherwise the branch is created from the base ref (default: HEAD).`,
+Otherwise the branch is created from the base ref (default: HEAD).
+
+With --agent, an interactive agent container named clawker.<project>.<agent>
+is also created with the worktree as its workspace. It is created but not
+started; start it with 'clawker container start -ia --agent <agent>'.
+'clawker worktree remove' removes it again together with the worktree.`,
 		Example: `  # Create a worktree for a new branch
   clawker worktree add feat-42
 
@@ -49,7 +78,10 @@ Otherwise the branch is created from the base ref (default: HEAD).`,
   clawker worktree add feature/new-login
 
   # Create the branch without tracking the remote
-  clawker worktree add feature/new-login --no-track`,
+  clawker worktree add feature/new-login --no-track
+
+  # Create a worktree and an agent container bound to it
+  clawker worktree create feat-44 --agent feat44`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Branch = args[0]
@@ -62,6 +94,8 @@ Otherwise the branch is created from the base ref (default: HEAD).`,
 
 	cmd.Flags().StringVar(&opts.Base, "base", "", "Base ref to create branch from (default: HEAD)")
 	cmd.Flags().BoolVar(&opts.NoTrack, "no-track", false, "Do not set up upstream tracking when basing the branch on a remote-tracking branch")
+	cmd.Flags().StringVar(&opts.Agent, "agent", "", "Also create an agent container with this name bound to the worktree")
+	cmd.Flags().StringVar(&opts.Image, "image", "@", "Image for the --agent container (\"@\" = the built image for the project)")
 
 	return cmd
 }
@@ -80,6 +114,13 @@ func addRun(ctx context.Context, opts *AddOptions) error {
 		return err
 	}
 
+	// Reject a bad agent name before there is a worktree to leave behind.
+	if opts.Agent != "" {
+		if _, err := docker.ContainerName(proj.Name(), opts.Agent); err != nil {
+			return err
+		}
+	}
+
 	wtPath, err := proj.CreateWorktree(ctx, opts.Branch, opts.Base, opts.NoTrack)
 	if err != nil {
 		if errors.Is(err, project.ErrNotInProjectPath) || errors.Is(err, project.ErrProjectNotRegistered) {
@@ -89,5 +130,69 @@ func addRun(ctx context.Context, opts *AddOptions) error {
 	}
 
 	fmt.Fprintf(opts.IOStreams.ErrOut, "Worktree ready at %s\n", wtPath)
+
+	if opts.Agent == "" {
+		return nil
+	}
+	result, err := createAgent(ctx, opts, proj.Name())
+	if err != nil {
+		return fmt.Errorf("creating agent container for worktree %q: %w", opts.Branch, err)
+	}
+	fmt.Fprintf(opts.IOStreams.ErrOut, "Created agent container %s\n", result.ContainerName)
+	fmt.Fprintf(opts.IOStreams.ErrOut, "  Start it: clawker container start -ia --agent %s\n", opts.Agent)
 	return nil
 }
+
+// createAgent creates an interactive agent container whose workspace is the
+// worktree for opts.Branch. The worktree already exists, so the --worktree
+// resolution inside CreateContainer reuses it.
+func createAgent(ctx context.Context, opts *AddOptions, projectName string) (*shared.CreateContainerResult, error) {
+	cfg, err := opts.Config()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	cfg, err = config.ForAgent(cfg, opts.Agent)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	client, err := opts.Client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to Docker: %w", err)
+	}
+	log, err := opts.Logger()
+	if err != nil {
+		return nil, fmt.Errorf("initializing logger: %w", err)
+	}
+
+	containerOpts := shared.NewContainerOptions()
+	containerOpts.Agent = opts.Agent
+	containerOpts.Worktree = opts.Branch
+	// Worktrees are bind-only; a snapshot default_mode would be refused.
+	containerOpts.Mode = string(config.ModeBind)
+	containerOpts.Image = opts.Image
+	containerOpts.TTY = true
+	containerOpts.Stdin = true
+
+	if harnessTag, isPlaceholder := shared.ParseImagePlaceholder(containerOpts.Image); isPlaceholder {
+		ref, err := shared.ResolvePlaceholderImage(ctx, client, cfg, opts.IOStreams, projectName, harnessTag, "worktree add")
+		if err != nil {
+			return nil, fmt.Errorf("resolving image: %w", err)
+		}
+		containerOpts.Image = ref
+	}
+
+	return createContainer(ctx, &shared.CreateContainerOptions{
+		Client:          client,
+		Config:          cfg,
+		ProjectName:     projectName,
+		Options:         containerOpts,
+		Version:         opts.Version,
+		ProjectManager:  opts.ProjectManager,
+		ProjectRegistry: opts.ProjectRegistry,
+		HostProxy:       opts.HostProxy,
+		Log:             log,
+		Is256Color:      opts.IOStreams.Is256ColorSupported(),
+		IsTrueColor:     opts.IOStreams.IsTrueColorSupported(),
+		HookOut:         opts.IOStreams.ErrOut,
+	})
+}
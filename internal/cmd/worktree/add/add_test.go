@@ -5,8 +5,15 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/schmitthub/clawker/internal/cmd/container/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
 	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/project"
 	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
 	"github.com/stretchr/testify/assert"
@@ -88,3 +95,76 @@ func TestNewCmdAdd_RunFReceivesArgsAndFlags(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, called)
 }
+
+func TestNewCmdAdd_AgentFlags(t *testing.T) {
+	f := cmdutiltest.NewFactory(t).Factory
+
+	var got *AddOptions
+	cmd := NewCmdAdd(f, func(_ context.Context, opts *AddOptions) error {
+		got = opts
+		return nil
+	})
+	assert.Contains(t, cmd.Aliases, "create")
+
+	cmd.SetArgs([]string{"feat-44", "--agent", "feat44"})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "feat44", got.Agent)
+	assert.Equal(t, "@", got.Image, "--image defaults to the project image")
+}
+
+func TestAddRun_CreatesAgentContainer(t *testing.T) {
+	proj := projectmocks.NewMockProject("demo", "/repo")
+	proj.CreateWorktreeFunc = func(context.Context, string, string, bool) (string, error) {
+		return "/wt/feat-44", nil
+	}
+	pm := projectmocks.NewMockProjectManager()
+	pm.CurrentProjectFunc = func(context.Context) (project.Project, error) { return proj, nil }
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+
+	var got *shared.CreateContainerOptions
+	old := createContainer
+	createContainer = func(_ context.Context, opts *shared.CreateContainerOptions) (*shared.CreateContainerResult, error) {
+		got = opts
+		require.Len(t, proj.CreateWorktreeCalls(), 1, "the worktree exists before the container")
+		return &shared.CreateContainerResult{ContainerName: "clawker.demo.feat44"}, nil
+	}
+	t.Cleanup(func() { createContainer = old })
+
+	ios, _, _, errOut := iostreams.Test()
+	opts := &AddOptions{
+		IOStreams:      ios,
+		ProjectManager: func() (project.ProjectManager, error) { return pm, nil },
+		Client:         func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		Config:         func() (config.Config, error) { return fake.Cfg, nil },
+		Logger:         func() (*logger.Logger, error) { return logger.Nop(), nil },
+		Branch:         "feat-44",
+		Agent:          "feat44",
+		Image:          "node:20-slim",
+	}
+	require.NoError(t, addRun(context.Background(), opts))
+
+	require.NotNil(t, got)
+	assert.Equal(t, "demo", got.ProjectName)
+	assert.Equal(t, "feat44", got.Options.Agent)
+	assert.Equal(t, "feat-44", got.Options.Worktree)
+	assert.Equal(t, "node:20-slim", got.Options.Image)
+	assert.Equal(t, "bind", got.Options.Mode)
+	assert.True(t, got.Options.TTY)
+	assert.True(t, got.Options.Stdin)
+	assert.Contains(t, errOut.String(), "Created agent container clawker.demo.feat44")
+}
+
+func TestAddRun_InvalidAgentName(t *testing.T) {
+	proj := projectmocks.NewMockProject("demo", "/repo")
+	pm := projectmocks.NewMockProjectManager()
+	pm.CurrentProjectFunc = func(context.Context) (project.Project, error) { return proj, nil }
+
+	opts := &AddOptions{
+		IOStreams:      newTestIOStreams(),
+		ProjectManager: func() (project.ProjectManager, error) { return pm, nil },
+		Branch:         "feat-44",
+		Agent:          "bad name",
+	}
+	require.Error(t, addRun(context.Background(), opts))
+	assert.Empty(t, proj.CreateWorktreeCalls(), "no worktree is created for a bad agent name")
+}
@@ -8,20 +8,32 @@ import (
 
 	"github.com/spf13/cobra"
 
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	containershared "github.com/schmitthub/clawker/internal/cmd/container/shared"
 	"github.com/schmitthub/clawker/internal/cmd/worktree/shared"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/git"
 	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/socketbridge"
 )
 
 // RemoveOptions contains the options for the remove command.
 type RemoveOptions struct {
 	IOStreams      *iostreams.IOStreams
 	ProjectManager func() (project.ProjectManager, error)
+	Client         func(context.Context) (*docker.Client, error)
+	Config         func() (config.Config, error)
+	AdminClient    func(context.Context) (adminv1.AdminServiceClient, error)
+	SocketBridge   func() socketbridge.SocketBridgeManager
+	Logger         func() (*logger.Logger, error)
 
 	Force        bool
 	DeleteBranch bool
+	Volumes      bool
 	Branches     []string
 }
 
@@ -30,6 +42,11 @@ func NewCmdRemove(f *cmdutil.Factory, runF func(context.Context, *RemoveOptions)
 	opts := &RemoveOptions{
 		IOStreams:      f.IOStreams,
 		ProjectManager: f.ProjectManager,
+		Client:         f.Client,
+		Config:         f.Config,
+		AdminClient:    f.AdminClient,
+		SocketBridge:   f.SocketBridge,
+		Logger:         f.Logger,
 	}
 
 	cmd := &cobra.Command{
@@ -38,7 +55,14 @@ func NewCmdRemove(f *cmdutil.Factory, runF func(context.Context, *RemoveOptions)
 		Short:   "Remove one or more worktrees",
 		Long: `Removes worktrees by their branch name.
 
-This removes both the git worktree metadata and the filesystem directory.
+This removes the git worktree metadata, the filesystem directory, and the
+project registry entry. Agent containers whose workspace is the worktree
+(such as those created by 'clawker worktree add --agent') are removed too,
+the same way 'clawker container remove' removes them (pre_remove hook,
+firewall, socket bridge, sidecars); their volumes are kept unless --volumes
+is given. A worktree in use by a running container is refused until the
+container is stopped.
+
 The branch itself is preserved unless --delete-branch is specified.`,
 		Example: `  # Remove a worktree
   clawker worktree remove feat-42
@@ -47,7 +71,10 @@ The branch itself is preserved unless --delete-branch is specified.`,
   clawker worktree rm feat-42 feat-43
 
   # Remove worktree and delete the branch
-  clawker worktree remove --delete-branch feat-42`,
+  clawker worktree remove --delete-branch feat-42
+
+  # Also remove the volumes of the worktree's agent containers
+  clawker worktree remove --volumes feat-42`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Branches = args
@@ -65,6 +92,8 @@ The branch itself is preserved unless --delete-branch is specified.`,
 	_ = cmd.Flags().MarkHidden("force")
 	cmd.Flags().
 		BoolVar(&opts.DeleteBranch, "delete-branch", false, "Also delete the branch after removing the worktree")
+	cmd.Flags().
+		BoolVarP(&opts.Volumes, "volumes", "v", false, "Also remove the volumes of the worktree's agent containers")
 
 	return cmd
 }
@@ -83,10 +112,12 @@ func removeRun(ctx context.Context, opts *RemoveOptions) error {
 		return err
 	}
 
+	agents := findAgentContainers(ctx, opts, proj)
+
 	var removeErrors []error
 
 	for _, branch := range opts.Branches {
-		if err := removeSingleWorktree(ctx, opts, proj, branch); err != nil {
+		if err := removeSingleWorktree(ctx, opts, proj, branch, agents); err != nil {
 			removeErrors = append(removeErrors, fmt.Errorf("%s: %w", branch, err))
 		}
 	}
@@ -116,7 +147,117 @@ func removeRun(ctx context.Context, opts *RemoveOptions) error {
 	return nil
 }
 
-func removeSingleWorktree(ctx context.Context, opts *RemoveOptions, proj project.Project, branch string) error {
+// agentContainers is the project's agent containers, with the client that
+// listed them. The pre_remove hook is resolved on first use, so a worktree
+// without containers is removable even when the hook is untrusted.
+type agentContainers struct {
+	client     *docker.Client
+	containers []docker.Container
+
+	hookLoaded bool
+	hook       string
+}
+
+// findAgentContainers lists the project's agent containers. Without Docker
+// there is nothing to clean up: the worktrees are still removed, with a
+// warning.
+func findAgentContainers(ctx context.Context, opts *RemoveOptions, proj project.Project) *agentContainers {
+	if opts.Client == nil {
+		return nil
+	}
+	cs := opts.IOStreams.ColorScheme()
+	client, err := opts.Client(ctx)
+	if err == nil {
+		var containers []docker.Container
+		containers, err = client.ListContainersByProject(ctx, proj.Name(), true)
+		if err == nil {
+			return &agentContainers{client: client, containers: containers}
+		}
+	}
+	fmt.Fprintf(opts.IOStreams.ErrOut, "%s could not check for agent containers: %v\n", cs.WarningIcon(), err)
+	return nil
+}
+
+// removeAgentContainers removes the containers whose workspace is the
+// worktree at path. Nothing is removed if any of them is running.
+func removeAgentContainers(ctx context.Context, opts *RemoveOptions, agents *agentContainers, path string) error {
+	if agents == nil || path == "" {
+		return nil
+	}
+	var bound []docker.Container
+	for _, c := range agents.containers {
+		if c.Workdir == path {
+			if c.Status == "running" {
+				return fmt.Errorf("worktree is in use by running container %s; stop it first: clawker container stop %s", c.Name, c.Name)
+			}
+			bound = append(bound, c)
+		}
+	}
+	if len(bound) == 0 {
+		return nil
+	}
+	removeOpts, err := agentRemoveOptions(opts, agents)
+	if err != nil {
+		return err
+	}
+	for _, c := range bound {
+		summary, err := agents.client.FindContainerByName(ctx, c.Name)
+		if err != nil {
+			return fmt.Errorf("finding agent container %s: %w", c.Name, err)
+		}
+		if summary == nil {
+			continue
+		}
+		if err := containershared.RemoveContainer(ctx, agents.client, c.Name, *summary, removeOpts); err != nil {
+			return fmt.Errorf("removing agent container %s: %w", c.Name, err)
+		}
+		fmt.Fprintf(opts.IOStreams.ErrOut, "Removed agent container %s\n", c.Name)
+	}
+	return nil
+}
+
+// agentRemoveOptions builds the shared container-remove options, resolving
+// the trusted pre_remove hook once per run.
+func agentRemoveOptions(opts *RemoveOptions, agents *agentContainers) (containershared.RemoveContainerOptions, error) {
+	removeOpts := containershared.RemoveContainerOptions{
+		IOStreams:    opts.IOStreams,
+		AdminClient:  opts.AdminClient,
+		SocketBridge: opts.SocketBridge,
+		Volumes:      opts.Volumes,
+	}
+	if opts.Logger != nil {
+		log, err := opts.Logger()
+		if err != nil {
+			return removeOpts, fmt.Errorf("initializing logger: %w", err)
+		}
+		removeOpts.Log = log
+	}
+	if !agents.hookLoaded && opts.Config != nil {
+		cfg, err := opts.Config()
+		if err != nil {
+			return removeOpts, fmt.Errorf("loading config: %w", err)
+		}
+		hook, err := containershared.HostHookCommand(cfg, containershared.HostHookPreRemove)
+		if err != nil {
+			return removeOpts, err
+		}
+		agents.hook = hook
+	}
+	agents.hookLoaded = true
+	removeOpts.PreRemoveHook = agents.hook
+	return removeOpts, nil
+}
+
+func removeSingleWorktree(ctx context.Context, opts *RemoveOptions, proj project.Project, branch string, agents *agentContainers) error {
+	if agents != nil {
+		// An unknown branch is reported by RemoveWorktree below.
+		if state, err := proj.GetWorktree(ctx, branch); err == nil {
+			if err := removeAgentContainers(ctx, opts, agents, state.Path); err != nil {
+				return err
+			}
+		}
+	}
+
 	err := proj.RemoveWorktree(ctx, branch, opts.DeleteBranch)
 	if err == nil {
 		if opts.DeleteBranch {
@@ -5,15 +5,27 @@ import (
 	"errors"
 	"testing"
 
+	cerrdefs "github.com/containerd/errdefs"
+	mobyclient "github.com/moby/moby/client"
 	"github.com/spf13/cobra"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	adminv1mocks "github.com/schmitthub/clawker/api/admin/v1/mocks"
 	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/project"
 	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+	"github.com/schmitthub/clawker/internal/socketbridge"
+	sockebridgemocks "github.com/schmitthub/clawker/internal/socketbridge/mocks"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
 )
 
 func newTestIOStreams() *iostreams.IOStreams {
@@ -58,10 +70,11 @@ func TestNewCmdRemove_RunFReceivesArgsAndFlags(t *testing.T) {
 		assert.Equal(t, []string{"feat-a", "feat-b"}, opts.Branches)
 		assert.True(t, opts.Force)
 		assert.True(t, opts.DeleteBranch)
+		assert.True(t, opts.Volumes)
 		return nil
 	})
 
-	cmd.SetArgs([]string{"--force", "--delete-branch", "feat-a", "feat-b"})
+	cmd.SetArgs([]string{"--force", "--delete-branch", "--volumes", "feat-a", "feat-b"})
 	err := cmd.Execute()
 	require.NoError(t, err)
 	assert.True(t, called)
@@ -90,3 +103,160 @@ func TestNewCmdRemove_WiresBranchCompletion(t *testing.T) {
 	assert.Equal(t, []cobra.Completion{"feat-a"}, completions)
 	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
 }
+
+// agentFixture is a stateful fake holding a stopped container bound to the
+// feat-a worktree and a running one bound to feat-b, plus a project whose
+// worktrees live under /wt.
+func agentFixture(t *testing.T) (*mocks.FakeClient, *projectmocks.ProjectMock, *[]string) {
+	t.Helper()
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	cfg := fake.Cfg
+	state := fake.EnableState()
+	state.AddImage("node:20-slim", nil)
+	for _, c := range []struct {
+		agent, workdir string
+		running        bool
+	}{
+		{agent: "a", workdir: "/wt/feat-a"},
+		{agent: "b", workdir: "/wt/feat-b", running: true},
+		{agent: "main", workdir: "/repo"},
+	} {
+		_, err := state.AddContainer(whailtest.ContainerSpec{
+			Name:  "clawker.demo." + c.agent,
+			Image: "node:20-slim",
+			Labels: map[string]string{
+				cfg.LabelProject(): "demo",
+				cfg.LabelAgent():   c.agent,
+				cfg.LabelWorkdir(): c.workdir,
+			},
+			Running: c.running,
+		})
+		require.NoError(t, err)
+	}
+	fake.FakeAPI.VolumeListFn = func(_ context.Context, _ mobyclient.VolumeListOptions) (mobyclient.VolumeListResult, error) {
+		return mobyclient.VolumeListResult{}, nil
+	}
+	fake.FakeAPI.VolumeRemoveFn = func(_ context.Context, _ string, _ mobyclient.VolumeRemoveOptions) (mobyclient.VolumeRemoveResult, error) {
+		return mobyclient.VolumeRemoveResult{}, cerrdefs.ErrNotFound
+	}
+
+	var removed []string
+	proj := projectmocks.NewMockProject("demo", "/repo")
+	proj.GetWorktreeFunc = func(_ context.Context, branch string) (project.WorktreeState, error) {
+		return project.WorktreeState{Branch: branch, Path: "/wt/" + branch}, nil //nolint:exhaustruct // sparse fixture
+	}
+	proj.RemoveWorktreeFunc = func(_ context.Context, branch string, _ bool) error {
+		removed = append(removed, branch)
+		return nil
+	}
+	return fake, proj, &removed
+}
+
+func TestRemoveRun_RemovesAgentContainers(t *testing.T) {
+	fake, proj, removed := agentFixture(t)
+	mgr := projectmocks.NewMockProjectManager()
+	mgr.CurrentProjectFunc = func(context.Context) (project.Project, error) { return proj, nil }
+
+	ios, _, _, errOut := iostreams.Test()
+	opts := &RemoveOptions{
+		IOStreams:      ios,
+		ProjectManager: func() (project.ProjectManager, error) { return mgr, nil },
+		Client:         func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		Branches:       []string{"feat-a", "feat-b"},
+	}
+
+	err := removeRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "feat-b: worktree is in use by running container clawker.demo.b")
+	assert.Contains(t, errOut.String(), "Removed agent container clawker.demo.a")
+	assert.Equal(t, []string{"feat-a"}, *removed)
+
+	left, err := fake.Client.ListContainers(context.Background(), true)
+	require.NoError(t, err)
+	var names []string
+	for _, c := range left {
+		names = append(names, c.Name)
+	}
+	assert.ElementsMatch(t, []string{"clawker.demo.b", "clawker.demo.main"}, names)
+}
+
+func TestRemoveRun_WithoutDocker(t *testing.T) {
+	_, proj, removed := agentFixture(t)
+	mgr := projectmocks.NewMockProjectManager()
+	mgr.CurrentProjectFunc = func(context.Context) (project.Project, error) { return proj, nil }
+
+	ios, _, _, errOut := iostreams.Test()
+	opts := &RemoveOptions{
+		IOStreams:      ios,
+		ProjectManager: func() (project.ProjectManager, error) { return mgr, nil },
+		Client: func(context.Context) (*docker.Client, error) {
+			return nil, errors.New("daemon unavailable")
+		},
+		Branches: []string{"feat-b"},
+	}
+
+	require.NoError(t, removeRun(context.Background(), opts))
+	assert.Contains(t, errOut.String(), "could not check for agent containers: daemon unavailable")
+	assert.Equal(t, []string{"feat-b"}, *removed)
+}
+
+func TestRemoveRun_AgentContainerTeardown(t *testing.T) {
+	for _, volumes := range []bool{false, true} {
+		t.Run(map[bool]string{false: "keeps volumes", true: "with volumes"}[volumes], func(t *testing.T) {
+			fake, proj, _ := agentFixture(t)
+			mgr := projectmocks.NewMockProjectManager()
+			mgr.CurrentProjectFunc = func(context.Context) (project.Project, error) { return proj, nil }
+			a, err := fake.Client.FindContainerByName(context.Background(), "clawker.demo.a")
+			require.NoError(t, err)
+			require.NotNil(t, a)
+
+			bridge := sockebridgemocks.NewMockManager()
+			admin := &adminv1mocks.AdminServiceClientMock{
+				FirewallDisableFunc: func(_ context.Context, _ *adminv1.FirewallDisableRequest, _ ...grpc.CallOption) (*adminv1.FirewallDisableResult, error) {
+					return &adminv1.FirewallDisableResult{}, nil
+				},
+			}
+			opts := &RemoveOptions{
+				IOStreams:      newTestIOStreams(),
+				ProjectManager: func() (project.ProjectManager, error) { return mgr, nil },
+				Client:         func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+				Config:         func() (config.Config, error) { return configmocks.NewBlankConfig(), nil },
+				AdminClient:    func(context.Context) (adminv1.AdminServiceClient, error) { return admin, nil },
+				SocketBridge:   func() socketbridge.SocketBridgeManager { return bridge },
+				Volumes:        volumes,
+				Branches:       []string{"feat-a"},
+			}
+
+			require.NoError(t, removeRun(context.Background(), opts))
+			require.Len(t, admin.FirewallDisableCalls(), 1)
+			assert.Equal(t, a.ID, admin.FirewallDisableCalls()[0].In.GetContainerId())
+			assert.True(t, sockebridgemocks.CalledWith(bridge, "StopBridge", a.ID))
+			if volumes {
+				fake.AssertCalled(t, "VolumeList")
+			} else {
+				fake.AssertNotCalled(t, "VolumeList")
+			}
+		})
+	}
+}
+
+func TestRemoveRun_PreRemoveHookFailureKeepsWorktree(t *testing.T) {
+	fake, proj, removed := agentFixture(t)
+	mgr := projectmocks.NewMockProjectManager()
+	mgr.CurrentProjectFunc = func(context.Context) (project.Project, error) { return proj, nil }
+
+	cfg := configmocks.NewFromString("hooks:\n  pre_remove: exit 3\n", "")
+	cfg.CheckHostCommandFunc = func(string) error { return nil }
+	opts := &RemoveOptions{
+		IOStreams:      newTestIOStreams(),
+		ProjectManager: func() (project.ProjectManager, error) { return mgr, nil },
+		Client:         func(context.Context) (*docker.Client, error) { return fake.Client, nil },
+		Config:         func() (config.Config, error) { return cfg, nil },
+		Branches:       []string{"feat-a"},
+	}
+
+	err := removeRun(context.Background(), opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "removing agent container clawker.demo.a")
+	assert.Empty(t, *removed)
+}
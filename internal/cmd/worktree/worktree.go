@@ -33,6 +33,9 @@ fail, and 'git push -u' still pushes the branch but can't persist upstream track
   # Create a worktree from a specific base
   clawker worktree add feat-43 --base main
 
+  # Create a worktree and an agent container bound to it
+  clawker worktree create feat-44 --agent feat44
+
   # List all worktrees for the current project
   clawker worktree list
 
@@ -0,0 +1,94 @@
+// Package cmdutiltest builds *cmdutil.Factory values for command tests.
+//
+// Production wiring lives in internal/cmd/factory and is never imported by
+// tests; command tests used to hand-roll the same Factory literal in every
+// package. NewFactory is that literal, once: test IOStreams, a Nop logger, a
+// blank config and a Docker client backed by whailtest fakes. Every other
+// Factory field stays a plain closure, so a test overrides exactly the seam
+// it exercises by assigning to it.
+package cmdutiltest
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/schmitthub/clawker/controlplane/manager"
+	cpmocks "github.com/schmitthub/clawker/controlplane/manager/mocks"
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	"github.com/schmitthub/clawker/internal/config"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/tui"
+)
+
+// Factory is a test Factory with handles on the doubles behind it.
+type Factory struct {
+	*cmdutil.Factory
+
+	In, Out, ErrOut *bytes.Buffer
+
+	// Fake backs Client. Configure the Docker SDK responses on
+	// Fake.FakeAPI (a whailtest.FakeAPIClient) or via its Setup helpers.
+	Fake *mocks.FakeClient
+	// Cfg is the config Config returns, the same instance on every call.
+	Cfg config.Config
+}
+
+// Option customizes NewFactory.
+type Option func(*Factory)
+
+// WithConfig makes Config return cfg. Without it Config returns a blank
+// config (configmocks.NewBlankConfig).
+func WithConfig(cfg config.Config) Option {
+	return func(f *Factory) { f.Cfg = cfg }
+}
+
+// WithFakeClient backs Client with fake instead of a fresh one. Use it when
+// the test builds its fake (and fixtures) before the factory.
+func WithFakeClient(fake *mocks.FakeClient) Option {
+	return func(f *Factory) { f.Fake = fake }
+}
+
+// NewFactory returns a Factory wired with test IOStreams, a TUI on them, a
+// Nop logger, Config and a fake-backed Docker Client. Fields it does not set
+// (ProjectManager, HostProxy, ControlPlane, AdminClient, ...) stay nil, as
+// in a bare &cmdutil.Factory{}; assign them to add a seam.
+func NewFactory(t *testing.T, opts ...Option) *Factory {
+	t.Helper()
+
+	tio, in, out, errOut := iostreams.Test()
+	tf := &Factory{In: in, Out: out, ErrOut: errOut}
+	for _, opt := range opts {
+		opt(tf)
+	}
+	if tf.Cfg == nil {
+		tf.Cfg = configmocks.NewBlankConfig()
+	}
+	if tf.Fake == nil {
+		tf.Fake = mocks.NewFakeClient(tf.Cfg)
+	}
+
+	tf.Factory = &cmdutil.Factory{
+		IOStreams: tio,
+		TUI:       tui.NewTUI(tio),
+		Logger:    func() (*logger.Logger, error) { return logger.Nop(), nil },
+		Config:    func() (config.Config, error) { return tf.Cfg, nil },
+		Client:    func(context.Context) (*docker.Client, error) { return tf.Fake.Client, nil },
+	}
+	return tf
+}
+
+// RunningControlPlane returns a ControlPlane closure whose manager reports
+// the control plane as already running, for commands that bring it up
+// before starting an agent.
+func RunningControlPlane() func() manager.Manager {
+	return func() manager.Manager {
+		return &cpmocks.ManagerMock{
+			EnsureRunningFunc: func(context.Context) error { return nil },
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package cmdutiltest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/cmdutil/cmdutiltest"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+)
+
+func TestNewFactory_Defaults(t *testing.T) {
+	tf := cmdutiltest.NewFactory(t)
+
+	require.NotNil(t, tf.IOStreams)
+	require.NotNil(t, tf.TUI)
+	assert.Nil(t, tf.ProjectManager)
+	assert.Nil(t, tf.ControlPlane)
+
+	log, err := tf.Logger()
+	require.NoError(t, err)
+	assert.NotNil(t, log)
+
+	cfg, err := tf.Config()
+	require.NoError(t, err)
+	assert.Same(t, tf.Cfg, cfg)
+
+	client, err := tf.Client(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, tf.Fake.Client, client)
+
+	tf.IOStreams.Out.Write([]byte("hello"))
+	assert.Equal(t, "hello", tf.Out.String())
+}
+
+func TestNewFactory_Options(t *testing.T) {
+	cfg := configmocks.NewBlankConfig()
+	fake := mocks.NewFakeClient(cfg)
+	tf := cmdutiltest.NewFactory(t, cmdutiltest.WithConfig(cfg), cmdutiltest.WithFakeClient(fake))
+
+	got, err := tf.Config()
+	require.NoError(t, err)
+	assert.Same(t, cfg, got)
+
+	client, err := tf.Client(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, fake.Client, client)
+}
+
+func TestRunningControlPlane(t *testing.T) {
+	mgr := cmdutiltest.RunningControlPlane()()
+	assert.NoError(t, mgr.EnsureRunning(context.Background()))
+}
@@ -0,0 +1,159 @@
+package cmdutil
+
+import (
+	"context"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/project"
+)
+
+// Dynamic shell completions. Each function returns a cobra.CompletionFunc
+// that resolves candidates at tab time from the project registry or the
+// Docker engine. Values already typed as positional args are not suggested
+// again. Every failure degrades to no suggestions: completion must never
+// surface errors, so causes go to cobra's completion debug log only.
+
+// ProjectCompletions suggests the names of registered projects.
+func ProjectCompletions(pmFn func() (project.ProjectManager, error)) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return projectNames(cmd.Context(), pmFn, args), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// AgentCompletions suggests the agent names of the current project's
+// containers, in any state.
+func AgentCompletions(
+	clientFn func(context.Context) (*docker.Client, error),
+	pmFn func() (project.ProjectManager, error),
+) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return agentNames(cmd.Context(), clientFn, pmFn, args), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// ContainerCompletions suggests container arguments for commands with the
+// boolean --agent flag: agent names of the current project when --agent is
+// set, otherwise the names of every clawker-managed container.
+func ContainerCompletions(
+	clientFn func(context.Context) (*docker.Client, error),
+	pmFn func() (project.ProjectManager, error),
+) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if agent, err := cmd.Flags().GetBool("agent"); err == nil && agent {
+			return agentNames(cmd.Context(), clientFn, pmFn, args), cobra.ShellCompDirectiveNoFileComp
+		}
+		return containerNames(cmd.Context(), clientFn, args), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// FirstArgCompletions applies fn to the first positional argument only;
+// later positions fall back to the shell's default (file) completion.
+func FirstArgCompletions(fn cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		return fn(cmd, args, toComplete)
+	}
+}
+
+func projectNames(ctx context.Context, pmFn func() (project.ProjectManager, error), typed []string) []cobra.Completion {
+	if pmFn == nil {
+		return nil
+	}
+	mgr, err := pmFn()
+	if err != nil {
+		cobra.CompDebugln("clawker project completion: project manager: "+err.Error(), false)
+		return nil
+	}
+	projects, err := mgr.ListProjects(ctx)
+	if err != nil {
+		cobra.CompDebugln("clawker project completion: list projects: "+err.Error(), false)
+		return nil
+	}
+	names := make([]string, 0, len(projects))
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	return completions(names, typed)
+}
+
+func agentNames(
+	ctx context.Context,
+	clientFn func(context.Context) (*docker.Client, error),
+	pmFn func() (project.ProjectManager, error),
+	typed []string,
+) []cobra.Completion {
+	if clientFn == nil {
+		return nil
+	}
+	// No current project selects the global-scope agents.
+	var projectName string
+	if pmFn != nil {
+		if mgr, err := pmFn(); err == nil {
+			if p, err := mgr.CurrentProject(ctx); err == nil {
+				projectName = p.Name()
+			}
+		}
+	}
+	client, err := clientFn(ctx)
+	if err != nil {
+		cobra.CompDebugln("clawker agent completion: docker: "+err.Error(), false)
+		return nil
+	}
+	q := docker.Query().HasLabel(consts.LabelAgent)
+	if projectName != "" {
+		q = q.Project(projectName)
+	}
+	containers, err := client.ListContainersQuery(ctx, q, true)
+	if err != nil {
+		cobra.CompDebugln("clawker agent completion: list containers: "+err.Error(), false)
+		return nil
+	}
+	var names []string
+	for _, c := range containers {
+		// The daemon can't match an absent label, so scope is checked here.
+		if c.Project == projectName {
+			names = append(names, c.Agent)
+		}
+	}
+	return completions(names, typed)
+}
+
+func containerNames(ctx context.Context, clientFn func(context.Context) (*docker.Client, error), typed []string) []cobra.Completion {
+	if clientFn == nil {
+		return nil
+	}
+	client, err := clientFn(ctx)
+	if err != nil {
+		cobra.CompDebugln("clawker container completion: docker: "+err.Error(), false)
+		return nil
+	}
+	containers, err := client.ListContainers(ctx, true)
+	if err != nil {
+		cobra.CompDebugln("clawker container completion: list containers: "+err.Error(), false)
+		return nil
+	}
+	names := make([]string, 0, len(containers))
+	for _, c := range containers {
+		names = append(names, c.Name)
+	}
+	return completions(names, typed)
+}
+
+// completions returns the sorted, de-duplicated non-empty names not already
+// in typed.
+func completions(names, typed []string) []cobra.Completion {
+	var out []cobra.Completion
+	for _, n := range names {
+		if n != "" && !slices.Contains(typed, n) {
+			out = append(out, n)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
@@ -0,0 +1,116 @@
+package cmdutil_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schmitthub/clawker/internal/cmdutil"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/project"
+	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+)
+
+func newCompletionCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().Bool("agent", false, "")
+	cmd.SetContext(context.Background())
+	return cmd
+}
+
+func currentProject(name string) func() (project.ProjectManager, error) {
+	mgr := projectmocks.NewMockProjectManager()
+	mgr.CurrentProjectFunc = func(context.Context) (project.Project, error) {
+		return projectmocks.NewMockProject(name, "/repo"), nil
+	}
+	mgr.ListProjectsFunc = func(context.Context) ([]project.ProjectState, error) {
+		return []project.ProjectState{{Name: "web"}, {Name: "api"}}, nil
+	}
+	return func() (project.ProjectManager, error) { return mgr, nil }
+}
+
+func fakeDocker() func(context.Context) (*docker.Client, error) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList(
+		mocks.RunningContainerFixture("web", "dev"),
+		mocks.ContainerFixture("web", "review", "node:20-slim"),
+		mocks.RunningContainerFixture("api", "dev"),
+		mocks.RunningContainerFixture("", "scratch"),
+	)
+	return func(context.Context) (*docker.Client, error) { return fake.Client, nil }
+}
+
+func TestProjectCompletions(t *testing.T) {
+	fn := cmdutil.ProjectCompletions(currentProject("web"))
+
+	got, directive := fn(newCompletionCmd(), []string{"web"}, "")
+	assert.Equal(t, []cobra.Completion{"api"}, got)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func TestAgentCompletions(t *testing.T) {
+	tests := []struct {
+		name  string
+		pm    func() (project.ProjectManager, error)
+		typed []string
+		want  []cobra.Completion
+	}{
+		{name: "current project only", pm: currentProject("web"), want: []cobra.Completion{"dev", "review"}},
+		{name: "excludes typed", pm: currentProject("web"), typed: []string{"dev"}, want: []cobra.Completion{"review"}},
+		{name: "no project selects global agents", pm: nil, want: []cobra.Completion{"scratch"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := cmdutil.AgentCompletions(fakeDocker(), tt.pm)
+			got, _ := fn(newCompletionCmd(), tt.typed, "")
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestContainerCompletions(t *testing.T) {
+	fn := cmdutil.ContainerCompletions(fakeDocker(), currentProject("web"))
+
+	cmd := newCompletionCmd()
+	got, _ := fn(cmd, nil, "")
+	assert.Equal(t, []cobra.Completion{"clawker.api.dev", "clawker.scratch", "clawker.web.dev", "clawker.web.review"}, got)
+
+	cmd = newCompletionCmd()
+	assert.NoError(t, cmd.Flags().Set("agent", "true"))
+	got, _ = fn(cmd, nil, "")
+	assert.Equal(t, []cobra.Completion{"dev", "review"}, got)
+}
+
+func TestFirstArgCompletions(t *testing.T) {
+	fn := cmdutil.FirstArgCompletions(cmdutil.ProjectCompletions(currentProject("web")))
+
+	got, _ := fn(newCompletionCmd(), nil, "")
+	assert.Equal(t, []cobra.Completion{"api", "web"}, got)
+
+	got, directive := fn(newCompletionCmd(), []string{"web"}, "")
+	assert.Empty(t, got)
+	assert.Equal(t, cobra.ShellCompDirectiveDefault, directive)
+}
+
+func TestCompletions_DegradeToNoSuggestions(t *testing.T) {
+	brokenPM := func() (project.ProjectManager, error) { return nil, errors.New("boom") }
+	brokenClient := func(context.Context) (*docker.Client, error) { return nil, errors.New("no daemon") }
+
+	for name, fn := range map[string]cobra.CompletionFunc{
+		"project":   cmdutil.ProjectCompletions(brokenPM),
+		"agent":     cmdutil.AgentCompletions(brokenClient, brokenPM),
+		"container": cmdutil.ContainerCompletions(brokenClient, brokenPM),
+		"nil deps":  cmdutil.ContainerCompletions(nil, nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, directive := fn(newCompletionCmd(), nil, "")
+			assert.Empty(t, got)
+			assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+		})
+	}
+}
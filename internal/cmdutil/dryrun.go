@@ -0,0 +1,45 @@
+package cmdutil
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+// AnnotationDryRun marks a command that honors the global --dry-run flag.
+// The root command rejects --dry-run on commands without it.
+const AnnotationDryRun = "dry-run"
+
+// EnableDryRun marks cmd as honoring the global --dry-run. Only commands
+// whose every side effect goes through the Docker client, or that skip the
+// others when client.DryRun() (as container shared.RemoveContainer does),
+// may opt in: the flag turns the client into a whail dry-run engine and
+// nothing else, so a host hook or admin RPC would still run for real.
+func EnableDryRun(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[AnnotationDryRun] = "true"
+}
+
+// SupportsDryRun reports whether cmd was marked by EnableDryRun.
+func SupportsDryRun(cmd *cobra.Command) bool {
+	return cmd.Annotations[AnnotationDryRun] == "true"
+}
+
+// PrintDryRunReport writes the operations a dry-run client skipped to
+// ios.ErrOut.
+func PrintDryRunReport(ios *iostreams.IOStreams, ops []whail.DryRunOperation) {
+	cs := ios.ColorScheme()
+	if len(ops) == 0 {
+		fmt.Fprintf(ios.ErrOut, "\n%s Dry run: nothing would change.\n", cs.InfoIcon())
+		return
+	}
+	fmt.Fprintf(ios.ErrOut, "\n%s Dry run: no changes were made. Would have run:\n", cs.InfoIcon())
+	for _, op := range ops {
+		fmt.Fprintf(ios.ErrOut, "  %s\n", op)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/schmitthub/clawker/internal/hostproxy"
 	"github.com/schmitthub/clawker/internal/iostreams"
 	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/notify"
 	"github.com/schmitthub/clawker/internal/project"
 	"github.com/schmitthub/clawker/internal/prompter"
 	"github.com/schmitthub/clawker/internal/socketbridge"
@@ -34,6 +35,20 @@ type Factory struct {
 	Version   string
 	IOStreams *iostreams.IOStreams
 	TUI       *tui.TUI
+	// Profile is the project config profile selected with the global
+	// --profile flag ("" = none). The root command binds the flag to it and
+	// Config applies it on every call, so it takes effect once flags parse.
+	Profile string
+	// DryRun is set by the global --dry-run flag, which only commands marked
+	// with EnableDryRun accept. Client then journals mutating calls instead
+	// of executing them, and the root command prints the journal.
+	DryRun bool
+	// ProjectContext is the registered project named by the global --context
+	// flag ("" = none). Main changes into that project's root before the
+	// command tree is built, so every lazy noun resolves it as the current
+	// project; the root command only checks the flag was placed where cobra
+	// parses it.
+	ProjectContext string
 
 	// Lazy nouns
 	Client   func(context.Context) (*docker.Client, error)
@@ -63,4 +78,8 @@ type Factory struct {
 	// three-tier component resolution plus the cache-mutating and validation
 	// operations the `clawker bundle` verbs use. Depends on Config.
 	BundleManager func() (*bundle.Manager, error)
+	// Notifier returns the desktop notifier configured by settings
+	// notifications:. Never fails: a config or logger error yields nil,
+	// which shows nothing.
+	Notifier func() *notify.Notifier
 }
@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/schmitthub/clawker/internal/iostreams"
 )
 
 // Format mode constants for --format flag parsing.
@@ -21,6 +23,10 @@ type Format struct {
 	template string
 }
 
+// templateEscapes expands the escape sequences users type in shell-quoted
+// --format templates.
+var templateEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
 // ParseFormat parses a raw --format flag value into a Format.
 //
 // Recognized inputs:
@@ -30,6 +36,10 @@ type Format struct {
 //   - "table {{.Name}}\t{{.ID}}"     → ModeTableTemplate (prefix "table ")
 //   - "{{.Name}} {{.ID}}"            → ModeTemplate (contains "{{")
 //   - anything else                   → FlagError
+//
+// In templates the two-character sequences \t and \n become a tab and a
+// newline, as in docker, so shell-quoted formats like 'table {{.Name}}\t{{.ID}}'
+// produce aligned columns.
 func ParseFormat(raw string) (Format, error) {
 	switch {
 	case raw == "":
@@ -40,9 +50,9 @@ func ParseFormat(raw string) (Format, error) {
 		return Format{mode: ModeJSON}, nil
 	case strings.HasPrefix(raw, "table "):
 		tmpl := strings.TrimPrefix(raw, "table ")
-		return Format{mode: ModeTableTemplate, template: tmpl}, nil
+		return Format{mode: ModeTableTemplate, template: templateEscapes.Replace(tmpl)}, nil
 	case strings.Contains(raw, "{{"):
-		return Format{mode: ModeTemplate, template: raw}, nil
+		return Format{mode: ModeTemplate, template: templateEscapes.Replace(raw)}, nil
 	default:
 		return Format{}, FlagErrorf("invalid format string: %q", raw)
 	}
@@ -77,6 +87,10 @@ func (f Format) Template() string {
 type FormatFlags struct {
 	Format Format
 	Quiet  bool
+
+	// envelope is set by --json: JSON output is wrapped in a versioned
+	// iostreams.JSONEnvelope. --format json writes the bare document.
+	envelope bool
 }
 
 // IsJSON reports whether the format is JSON output.
@@ -94,8 +108,37 @@ func (ff *FormatFlags) IsTableTemplate() bool { return ff.Format.IsTableTemplate
 // Template returns the underlying Format value (for passing to ExecuteTemplate).
 func (ff *FormatFlags) Template() Format { return ff.Format }
 
+// WriteJSON writes data for JSON mode: wrapped in an envelope of the given
+// kind for --json, bare (docker-compatible) for --format json.
+func (ff *FormatFlags) WriteJSON(ios *iostreams.IOStreams, kind string, data any) error {
+	if ff.envelope {
+		return ios.JSONPrinter().Print(kind, data)
+	}
+	return WriteJSON(ios.Out, data)
+}
+
+// AnnotationJSONOutput marks a command that honors the global --json flag.
+// The root command rejects --json on commands without it.
+const AnnotationJSONOutput = "json-output"
+
+// EnableJSONOutput marks cmd as honoring --json. AddFormatFlags calls it;
+// commands that handle --json themselves call it directly.
+func EnableJSONOutput(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[AnnotationJSONOutput] = "true"
+}
+
+// SupportsJSONOutput reports whether cmd was marked by EnableJSONOutput.
+func SupportsJSONOutput(cmd *cobra.Command) bool {
+	return cmd.Annotations[AnnotationJSONOutput] == "true"
+}
+
 // AddFormatFlags registers --format, --json, and -q/--quiet flags on the
-// command and chains PreRunE validation for mutual exclusivity.
+// command and chains PreRunE validation for mutual exclusivity. The local
+// --json shadows the root's global one, so the command works the same
+// standalone (tests) and in the tree.
 //
 // The returned FormatFlags is populated during PreRunE; commands read it
 // in RunE after flag parsing is complete.
@@ -103,8 +146,9 @@ func AddFormatFlags(cmd *cobra.Command) *FormatFlags {
 	ff := &FormatFlags{}
 
 	cmd.Flags().String("format", "", `Output format: "json", "table", or a Go template`)
-	cmd.Flags().Bool("json", false, "Output as JSON (shorthand for --format json)")
+	cmd.Flags().Bool("json", false, "Output as versioned JSON envelope")
 	cmd.Flags().BoolP("quiet", "q", false, "Only display IDs")
+	EnableJSONOutput(cmd)
 
 	existingPreRunE := cmd.PreRunE
 	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
@@ -135,6 +179,7 @@ func AddFormatFlags(cmd *cobra.Command) *FormatFlags {
 		// Resolve format.
 		if jsonFlag {
 			ff.Format = Format{mode: ModeJSON}
+			ff.envelope = true
 			return nil
 		}
 
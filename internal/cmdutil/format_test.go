@@ -7,6 +7,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/iostreams"
 )
 
 func TestParseFormat(t *testing.T) {
@@ -50,6 +52,12 @@ func TestParseFormat(t *testing.T) {
 			wantMode: ModeTableTemplate,
 			wantTmpl: "{{.Name}}\t{{.ID}}",
 		},
+		{
+			name:     "escaped tab and newline expand",
+			raw:      `table {{.Name}}\t{{.ID}}\n`,
+			wantMode: ModeTableTemplate,
+			wantTmpl: "{{.Name}}\t{{.ID}}\n",
+		},
 		{
 			name:    "invalid bare word",
 			raw:     "invalid",
@@ -145,6 +153,42 @@ func TestAddFormatFlags(t *testing.T) {
 	qFlag := cmd.Flags().Lookup("quiet")
 	require.NotNil(t, qFlag)
 	assert.Equal(t, "q", qFlag.Shorthand)
+
+	assert.True(t, SupportsJSONOutput(cmd), "AddFormatFlags should mark --json support")
+}
+
+func TestFormatFlags_WriteJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "json flag wraps in envelope",
+			args: []string{"--json"},
+			want: `{"schema_version":1,"kind":"thing.list","data":[{"name":"a"}]}` + "\n",
+		},
+		{
+			name: "format json writes bare document",
+			args: []string{"--format", "json"},
+			want: `[{"name":"a"}]` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ios, _, out, _ := iostreams.Test()
+			cmd := &cobra.Command{Use: "test"}
+			ff := AddFormatFlags(cmd)
+			cmd.RunE = func(cmd *cobra.Command, args []string) error {
+				return ff.WriteJSON(ios, "thing.list", []map[string]string{{"name": "a"}})
+			}
+
+			cmd.SetArgs(tt.args)
+			require.NoError(t, cmd.Execute())
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
 }
 
 func TestAddFormatFlags_Validation(t *testing.T) {
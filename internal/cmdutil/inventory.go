@@ -146,7 +146,7 @@ func renderInventoryRows(opts *InventoryOptions, rows []inventoryRow) error {
 		}
 		return nil
 	case opts.Format.IsJSON():
-		if err := WriteJSON(ios.Out, rows); err != nil {
+		if err := opts.Format.WriteJSON(ios, "inventory."+opts.Type.Dir(), rows); err != nil {
 			return fmt.Errorf("writing json: %w", err)
 		}
 		return nil
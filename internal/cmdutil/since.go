@@ -0,0 +1,44 @@
+package cmdutil
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ParseSince resolves a --since value into a window ending at now. Durations
+// accept Go syntax plus whole-day (d) and whole-week (w) units; anything else
+// must be an RFC3339 timestamp in the past.
+func ParseSince(since string, now time.Time) (time.Duration, error) {
+	if since == "" {
+		return 0, fmt.Errorf("empty value")
+	}
+	if ts, err := time.Parse(time.RFC3339, since); err == nil {
+		if !ts.Before(now) {
+			return 0, fmt.Errorf("%q is not in the past", since)
+		}
+		return now.Sub(ts), nil
+	}
+
+	var d time.Duration
+	if unit := since[len(since)-1]; unit == 'd' || unit == 'w' {
+		n, err := strconv.Atoi(since[:len(since)-1])
+		if err != nil {
+			return 0, fmt.Errorf("%q is neither a duration nor an RFC3339 timestamp", since)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+		if unit == 'w' {
+			d *= 7
+		}
+	} else {
+		var err error
+		d, err = time.ParseDuration(since)
+		if err != nil {
+			return 0, fmt.Errorf("%q is neither a duration nor an RFC3339 timestamp", since)
+		}
+	}
+	if d < time.Second {
+		return 0, fmt.Errorf("%q is shorter than one second", since)
+	}
+	return d, nil
+}
@@ -1,6 +1,7 @@
 package cmdutil
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -53,6 +54,17 @@ func DefaultFuncMap() template.FuncMap {
 // each item, writing results to w. For table-template formats, output is
 // aligned through a tabwriter. Each item produces one line of output.
 func ExecuteTemplate(w io.Writer, f Format, items []any) error {
+	return ExecuteTemplateWithHeader(w, f, nil, items)
+}
+
+// ExecuteTemplateWithHeader is ExecuteTemplate plus a header row for
+// table-template formats: the template is first executed against header, a
+// value with the item's field names holding column titles (a
+// map[string]string or a struct of strings), the way docker's "table"
+// formats title their columns. A header the template cannot render — one
+// that indexes into a field, for instance — is skipped. Plain templates never
+// get a header.
+func ExecuteTemplateWithHeader(w io.Writer, f Format, header any, items []any) error {
 	tmpl, err := template.New("").Funcs(DefaultFuncMap()).Parse(f.Template())
 	if err != nil {
 		return fmt.Errorf("invalid template: %w", err)
@@ -64,6 +76,15 @@ func ExecuteTemplate(w io.Writer, f Format, items []any) error {
 	if f.IsTableTemplate() {
 		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 		dest = tw
+
+		if header != nil {
+			var row bytes.Buffer
+			if tmpl.Execute(&row, header) == nil {
+				if _, err := fmt.Fprintln(dest, row.String()); err != nil {
+					return fmt.Errorf("writing output: %w", err)
+				}
+			}
+		}
 	}
 
 	for _, item := range items {
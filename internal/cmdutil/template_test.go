@@ -233,3 +233,35 @@ func TestExecuteTemplate_WriteError(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "writing output")
 }
+
+func TestExecuteTemplateWithHeader(t *testing.T) {
+	header := map[string]string{"Name": "NAME", "ID": "ID"}
+	items := []any{map[string]string{"Name": "foo", "ID": "123"}}
+
+	t.Run("table template gets a header row", func(t *testing.T) {
+		f, err := ParseFormat("table {{.Name}}\t{{.ID}}")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, ExecuteTemplateWithHeader(&buf, f, header, items))
+		assert.Equal(t, "NAME  ID\nfoo   123\n", buf.String())
+	})
+
+	t.Run("plain template has none", func(t *testing.T) {
+		f, err := ParseFormat("{{.Name}}")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, ExecuteTemplateWithHeader(&buf, f, header, items))
+		assert.Equal(t, "foo\n", buf.String())
+	})
+
+	t.Run("unrenderable header is skipped", func(t *testing.T) {
+		f, err := ParseFormat("table {{index .Name 0}}")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, ExecuteTemplateWithHeader(&buf, f, struct{ Name int }{}, []any{map[string][]string{"Name": {"a"}}}))
+		assert.Equal(t, "a\n", buf.String())
+	})
+}
@@ -0,0 +1,33 @@
+package config
+
+import "regexp"
+
+// AgentsKey is the project key holding per-agent override blocks.
+const AgentsKey = "agents"
+
+// agentOverrideNameRe is the container-name charset minus '.', which would
+// split the dotted key path (agents.<name>.security...) that config get/set
+// and ForAgent address the entry by.
+var agentOverrideNameRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// AgentOverridePath returns the dotted project key of agent's override block.
+func AgentOverridePath(agent string) string {
+	return AgentsKey + "." + agent
+}
+
+// ForAgent returns cfg with the project's agents.<agent> block folded in:
+// Project() and ProjectEgressRules() reflect the base config deep-merged
+// with that entry, using the same semantics as a higher-priority config
+// file. Everything else — including ProjectStore(), which still reads and
+// writes the unmerged files — delegates to cfg. An agent entry folds over
+// an active profile (see ForProfile). With an empty agent name or no
+// matching entry, cfg is returned unchanged.
+func ForAgent(cfg Config, agent string) (Config, error) {
+	if agent == "" {
+		return cfg, nil
+	}
+	if _, ok := cfg.Project().Agents[agent]; !ok {
+		return cfg, nil
+	}
+	return withOverlay(cfg, AgentOverridePath(agent))
+}
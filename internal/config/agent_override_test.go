@@ -0,0 +1,97 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const agentOverrideYAML = `
+agent:
+  editor: vim
+  env:
+    LOG_LEVEL: info
+workspace:
+  default_mode: bind
+security:
+  firewall:
+    add_domains: [github.com]
+agents:
+  reviewer:
+    agent:
+      env:
+        REVIEW: "1"
+    workspace:
+      default_mode: snapshot
+    security:
+      firewall:
+        add_domains: [api.example.com]
+`
+
+func TestForAgent(t *testing.T) {
+	base, err := NewFromString(agentOverrideYAML, "")
+	require.NoError(t, err)
+
+	t.Run("matching entry is folded over the base", func(t *testing.T) {
+		cfg, err := ForAgent(base, "reviewer")
+		require.NoError(t, err)
+
+		p := cfg.Project()
+		assert.Equal(t, "snapshot", p.Workspace.DefaultMode)
+		assert.Equal(t, "vim", p.Agent.Editor, "unset keys inherit the base")
+		assert.Equal(t, map[string]string{"REVIEW": "1"}, p.Agent.Env, "env maps replace like a higher layer")
+		assert.Equal(t, []string{"github.com", "api.example.com"}, p.Security.Firewall.AddDomains)
+
+		var dsts []string
+		for _, r := range cfg.ProjectEgressRules() {
+			dsts = append(dsts, r.Dst)
+		}
+		assert.Equal(t, []string{"github.com", "api.example.com"}, dsts)
+
+		assert.Equal(t, "bind", base.Project().Workspace.DefaultMode, "base config is untouched")
+	})
+
+	t.Run("no matching entry returns cfg unchanged", func(t *testing.T) {
+		cfg, err := ForAgent(base, "dev")
+		require.NoError(t, err)
+		assert.Same(t, base, cfg)
+	})
+
+	t.Run("empty agent returns cfg unchanged", func(t *testing.T) {
+		cfg, err := ForAgent(base, "")
+		require.NoError(t, err)
+		assert.Same(t, base, cfg)
+	})
+}
+
+func TestAgentsNode_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "dotted agent name",
+			yaml:    "agents:\n  re.viewer:\n    workspace:\n      default_mode: snapshot\n",
+			wantErr: `invalid agent name "re.viewer"`,
+		},
+		{
+			name:    "unknown block",
+			yaml:    "agents:\n  reviewer:\n    build:\n      image: alpine\n",
+			wantErr: "agents.reviewer.build: unknown field",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFromString(tt.yaml, "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateProjectSet_agentOverride(t *testing.T) {
+	require.NoError(t, ValidateProjectSet("agents.reviewer.security.firewall.add_domains", []string{"api.example.com"}))
+	require.Error(t, ValidateProjectSet("agents.reviewer.build", map[string]any{"image": "alpine"}))
+}
@@ -7,7 +7,9 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/schmitthub/clawker/internal/build"
@@ -74,6 +76,12 @@ type Config interface {
 	// offending files.
 	BundleDeclarations() []BundleDeclaration
 
+	// CheckHostCommand returns an error wrapping ErrUntrustedHostCommand
+	// when key (hooks.<event>, build.scan.<key>, secrets.<name>) is declared
+	// by a project config file the user has not approved with clawker
+	// project trust. See HostCommands.
+	CheckHostCommand(key string) error
+
 	Domain() string
 	LabelDomain() string
 	ConfigDirEnvVar() string
@@ -147,6 +155,7 @@ type configImpl struct {
 	project     *storage.Store[Project]
 	settings    *storage.Store[Settings]
 	projectRoot string
+	trusted     map[string]bool // approved HostCommand fingerprints
 }
 
 // ProjectRoot returns the resolved project root anchor the config was loaded
@@ -162,20 +171,60 @@ type newConfigOptions struct {
 	projectYAML  string
 	settingsYAML string
 	projectRoot  string
+	writeHook    func(path string, sets, deletes []string)
+	trusted      []string
 }
 
 // NewConfig loads all clawker configuration files into a Config.
 // The project store discovers clawker.yaml via walk-up (CWD → project root)
 // and config dir. The settings store loads settings.yaml from config dir.
-// Both stores use defaults as the lowest-priority base layer.
+// Both stores use defaults as the lowest-priority base layer, and decrypt
+// ENC[age:...] values with the settings encryption.identity_file.
 func NewConfig(opts ...NewConfigOption) (Config, error) {
 	options := &newConfigOptions{}
 	for _, opt := range opts {
 		opt(options)
 	}
+	strict, err := strictInterpolation()
+	if err != nil {
+		return nil, err
+	}
+	// Settings load first: they name the age identity that decrypts
+	// encrypted values in both stores.
+	keys := &ageKeys{}
+	settingsOpts := []storage.Option{
+		storage.WithFilenames(consts.SettingsFile),
+	}
+	if options.settingsYAML != "" {
+		settingsOpts = append(settingsOpts, storage.WithDefaults(options.settingsYAML))
+	} else {
+		settingsOpts = append(settingsOpts, storage.WithDefaultsFromStruct[Settings]())
+	}
+	settingsOpts = append(settingsOpts,
+		storage.WithConfigDir(),
+		storage.WithMigrations(SettingsMigrations()...),
+		storage.WithHeader(schemaHeader(consts.SettingsSchemaFile)),
+		storage.WithLock(),
+		storage.WithCipher(&ageCipher{keys: keys}),
+		storage.WithWriteHook(options.writeHook),
+	)
+	settingsStore, err := storage.New[Settings]("", settingsOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("config: loading settings: %w", err)
+	}
+	// The identity file is itself a settings value, so settings load once
+	// without it; reload to decrypt any encrypted settings values.
+	if keys.path = settingsStore.Read().Encryption.IdentityFile; keys.path != "" {
+		if err := settingsStore.Refresh(); err != nil {
+			return nil, fmt.Errorf("config: loading settings: %w", err)
+		}
+	}
+
+	var projectStore *storage.Store[Project]
 	projectOpts := []storage.Option{
 		storage.WithFilenames(consts.ProjectLocalConfigFile, consts.ProjectConfigFile),
 		storage.WithDefaultFilename(consts.ProjectConfigFile),
+		storage.WithInterpolation(strict),
 	}
 	if options.projectYAML != "" {
 		projectOpts = append(projectOpts, storage.WithDefaults(options.projectYAML))
@@ -188,8 +237,15 @@ func NewConfig(opts ...NewConfigOption) (Config, error) {
 		storage.WithDotDefault(),
 		storage.WithMigrations(ProjectMigrations()...),
 		storage.WithHeader(schemaHeader(consts.ProjectSchemaFile)),
+		storage.WithCipher(&ageCipher{keys: keys, recipients: func() []string {
+			if projectStore == nil {
+				return nil // a write during construction (migrations)
+			}
+			return projectStore.Read().Encryption.Recipients
+		}}),
+		storage.WithWriteHook(options.writeHook),
 	)
-	projectStore, err := storage.New[Project]("", projectOpts...)
+	projectStore, err = storage.New[Project]("", projectOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("config: loading project config: %w", err)
 	}
@@ -197,31 +253,28 @@ func NewConfig(opts ...NewConfigOption) (Config, error) {
 		return nil, fmt.Errorf("config: validating project config: %w", vErr)
 	}
 
-	settingsOpts := []storage.Option{
-		storage.WithFilenames(consts.SettingsFile),
-	}
-	if options.settingsYAML != "" {
-		settingsOpts = append(settingsOpts, storage.WithDefaults(options.settingsYAML))
-	} else {
-		settingsOpts = append(settingsOpts, storage.WithDefaultsFromStruct[Settings]())
-	}
-	settingsOpts = append(settingsOpts,
-		storage.WithConfigDir(),
-		storage.WithMigrations(SettingsMigrations()...),
-		storage.WithHeader(schemaHeader(consts.SettingsSchemaFile)),
-	)
-	settingsStore, err := storage.New[Settings]("", settingsOpts...)
-	if err != nil {
-		return nil, fmt.Errorf("config: loading settings: %w", err)
-	}
-
 	return &configImpl{
 		project:     projectStore,
 		settings:    settingsStore,
 		projectRoot: options.projectRoot,
+		trusted:     trustedSet(options.trusted),
 	}, nil
 }
 
+// strictInterpolation reads EnvStrictInterpolation. Unset or empty means
+// lenient: an undefined ${VAR} expands to "".
+func strictInterpolation() (bool, error) {
+	raw := os.Getenv(consts.EnvStrictInterpolation)
+	if raw == "" {
+		return false, nil
+	}
+	strict, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("config: invalid %s %q: %w", consts.EnvStrictInterpolation, raw, err)
+	}
+	return strict, nil
+}
+
 func WithDefaultProjectYAML(yaml string) NewConfigOption {
 	return func(o *newConfigOptions) {
 		o.projectYAML = yaml
@@ -246,6 +299,32 @@ func WithProjectRoot(root string) NewConfigOption {
 	}
 }
 
+// WithWriteHook reports every write to the project and settings files: the
+// file and the dotted keys set and removed in it (see storage.WithWriteHook).
+// The CLI factory records them in the audit journal.
+func WithWriteHook(fn func(path string, sets, deletes []string)) NewConfigOption {
+	return func(o *newConfigOptions) {
+		o.writeHook = fn
+	}
+}
+
+// WithTrustedHostCommands passes the HostCommand fingerprints the user
+// approved for the project (recorded in the project registry by clawker
+// project trust). The caller reads them; config never reads the registry.
+func WithTrustedHostCommands(fingerprints []string) NewConfigOption {
+	return func(o *newConfigOptions) {
+		o.trusted = fingerprints
+	}
+}
+
+func trustedSet(fingerprints []string) map[string]bool {
+	set := make(map[string]bool, len(fingerprints))
+	for _, fp := range fingerprints {
+		set[fp] = true
+	}
+	return set
+}
+
 // NewProjectStoreFromPreset creates an isolated project store from a preset
 // YAML string. Unlike NewConfig, this does NO file discovery — no walk-up,
 // no config dir, no user-level config merging. The store contains only the
@@ -318,8 +397,13 @@ func NewFromString(projectYAML, settingsYAML string) (Config, error) {
 // selected harness's required egress floor is composed in by
 // bundler.EgressRules, which is what firewall sync paths must call.
 func (c *configImpl) ProjectEgressRules() []EgressRule {
+	return projectEgressRules(c.Project())
+}
+
+// projectEgressRules expands p's security.firewall block into egress rules.
+func projectEgressRules(p *Project) []EgressRule {
 	var rules []EgressRule
-	projectFw := c.Project().Security.Firewall
+	projectFw := p.Security.Firewall
 	if projectFw != nil {
 		rules = append(rules, projectFw.Rules...)
 		for _, d := range projectFw.AddDomains {
@@ -283,6 +283,49 @@ func TestNewConfig_projectFileOverridesDefaults(t *testing.T) {
 	assert.Equal(t, "bind", p.Workspace.DefaultMode)
 }
 
+func TestNewConfig_interpolatesProjectValues(t *testing.T) {
+	base := t.TempDir()
+	configDir := filepath.Join(base, "config")
+	t.Setenv("CLAWKER_CONFIG_DIR", configDir)
+	t.Setenv("CLAWKER_DATA_DIR", filepath.Join(base, "data"))
+	t.Setenv("CLAWKER_STATE_DIR", filepath.Join(base, "state"))
+	require.NoError(t, os.MkdirAll(configDir, 0o755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(configDir, "clawker.yaml"),
+		[]byte(`agent:
+  editor: ${TEST_CLAWKER_EDITOR:-vim}
+  visual: ${TEST_CLAWKER_UNSET_VISUAL}
+  post_init: echo ${HOME}
+`),
+		0o644,
+	))
+
+	t.Run("lenient", func(t *testing.T) {
+		t.Setenv("TEST_CLAWKER_EDITOR", "emacs")
+		cfg, err := NewConfig()
+		require.NoError(t, err)
+
+		p := cfg.Project()
+		assert.Equal(t, "emacs", p.Agent.Editor)
+		assert.Empty(t, p.Agent.Visual)
+		assert.Equal(t, "echo ${HOME}", p.Agent.PostInit, "scripts are not interpolated")
+	})
+
+	t.Run("strict rejects undefined", func(t *testing.T) {
+		t.Setenv(consts.EnvStrictInterpolation, "true")
+		_, err := NewConfig()
+		require.ErrorIs(t, err, storage.ErrUndefinedVariable)
+		assert.Contains(t, err.Error(), "TEST_CLAWKER_UNSET_VISUAL")
+	})
+
+	t.Run("invalid strict value", func(t *testing.T) {
+		t.Setenv(consts.EnvStrictInterpolation, "sometimes")
+		_, err := NewConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), consts.EnvStrictInterpolation)
+	})
+}
+
 func TestNewConfig_monitorExtensionsFileOverridesDefault(t *testing.T) {
 	cases := []struct {
 		name string
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"filippo.io/age"
+
+	"github.com/schmitthub/clawker/internal/storage"
+)
+
+// Encrypted values are stored as ENC[age:<base64 age ciphertext>], the
+// sops-style marker, so they read as ciphertext at a glance in a diff.
+const (
+	encryptedPrefix = "ENC[age:"
+	encryptedSuffix = "]"
+)
+
+// ErrNoRecipients is returned when a value must be encrypted but no age
+// recipient is configured.
+var ErrNoRecipients = errors.New("no age recipients: set encryption.identity_file in settings or encryption.recipients in clawker.yaml")
+
+// IsEncryptedValue reports whether value is an ENC[age:...] ciphertext.
+func IsEncryptedValue(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix) && strings.HasSuffix(value, encryptedSuffix)
+}
+
+// ageKeys lazily loads the age identities from the settings
+// encryption.identity_file, shared by both stores' ciphers.
+type ageKeys struct {
+	path string
+
+	once       sync.Once
+	identities []age.Identity
+	err        error
+}
+
+// load returns the identities, or none when no identity file is configured
+// or it does not exist — a checkout without the key still loads, with its
+// encrypted values left as ciphertext.
+func (k *ageKeys) load() ([]age.Identity, error) {
+	if k.path == "" {
+		return nil, nil
+	}
+	k.once.Do(func() {
+		path, err := ExpandHostPath(k.path)
+		if err != nil {
+			k.err = fmt.Errorf("encryption.identity_file: %w", err)
+			return
+		}
+		f, err := os.Open(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return
+		}
+		if err != nil {
+			k.err = fmt.Errorf("encryption.identity_file: %w", err)
+			return
+		}
+		defer f.Close()
+		if k.identities, err = age.ParseIdentities(f); err != nil {
+			k.err = fmt.Errorf("encryption.identity_file %s: %w", path, err)
+		}
+	})
+	return k.identities, k.err
+}
+
+// ageCipher is the storage.Cipher for config values. recipients returns the
+// extra recipients to encrypt to beyond the identities' own.
+type ageCipher struct {
+	keys       *ageKeys
+	recipients func() []string
+}
+
+var _ storage.Cipher = (*ageCipher)(nil)
+
+func (c *ageCipher) IsEncrypted(value string) bool { return IsEncryptedValue(value) }
+
+// Decrypt opens value with the configured identities. Without identities,
+// or when none of them is a recipient, value is returned unchanged.
+func (c *ageCipher) Decrypt(value string) (string, error) {
+	identities, err := c.keys.load()
+	if err != nil || len(identities) == 0 {
+		return value, err
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(value, encryptedPrefix), encryptedSuffix))
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	r, err := age.Decrypt(bytes.NewReader(data), identities...)
+	var noMatch *age.NoIdentityMatchError
+	if errors.As(err, &noMatch) {
+		return value, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// Encrypt seals plaintext to the identities' own recipients plus the
+// configured extra recipients.
+func (c *ageCipher) Encrypt(plaintext string) (string, error) {
+	identities, err := c.keys.load()
+	if err != nil {
+		return "", err
+	}
+	var recipients []age.Recipient
+	for _, id := range identities {
+		if x, ok := id.(*age.X25519Identity); ok {
+			recipients = append(recipients, x.Recipient())
+		}
+	}
+	if c.recipients != nil {
+		for _, s := range c.recipients() {
+			r, err := age.ParseX25519Recipient(s)
+			if err != nil {
+				return "", fmt.Errorf("encryption.recipients: %w", err)
+			}
+			recipients = append(recipients, r)
+		}
+	}
+	if len(recipients) == 0 {
+		return "", ErrNoRecipients
+	}
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.WriteString(w, plaintext); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(buf.Bytes()) + encryptedSuffix, nil
+}
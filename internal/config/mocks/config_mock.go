@@ -34,6 +34,9 @@ var _ config.Config = &ConfigMock{}
 //			CPIPLastOctetFunc: func() byte {
 //				panic("mock out the CPIPLastOctet method")
 //			},
+//			CheckHostCommandFunc: func(key string) error {
+//				panic("mock out the CheckHostCommand method")
+//			},
 //			ClawkerIgnoreNameFunc: func() string {
 //				panic("mock out the ClawkerIgnoreName method")
 //			},
@@ -239,6 +242,9 @@ type ConfigMock struct {
 	// CPIPLastOctetFunc mocks the CPIPLastOctet method.
 	CPIPLastOctetFunc func() byte
 
+	// CheckHostCommandFunc mocks the CheckHostCommand method.
+	CheckHostCommandFunc func(key string) error
+
 	// ClawkerIgnoreNameFunc mocks the ClawkerIgnoreName method.
 	ClawkerIgnoreNameFunc func() string
 
@@ -441,6 +447,11 @@ type ConfigMock struct {
 		// CPIPLastOctet holds details about calls to the CPIPLastOctet method.
 		CPIPLastOctet []struct {
 		}
+		// CheckHostCommand holds details about calls to the CheckHostCommand method.
+		CheckHostCommand []struct {
+			// Key is the key argument value.
+			Key string
+		}
 		// ClawkerIgnoreName holds details about calls to the ClawkerIgnoreName method.
 		ClawkerIgnoreName []struct {
 		}
@@ -630,6 +641,7 @@ type ConfigMock struct {
 	lockBuildSubdir             sync.RWMutex
 	lockBundleDeclarations      sync.RWMutex
 	lockCPIPLastOctet           sync.RWMutex
+	lockCheckHostCommand        sync.RWMutex
 	lockClawkerIgnoreName       sync.RWMutex
 	lockClawkerNetwork          sync.RWMutex
 	lockConfigDirEnvVar         sync.RWMutex
@@ -833,6 +845,38 @@ func (mock *ConfigMock) CPIPLastOctetCalls() []struct {
 	return calls
 }
 
+// CheckHostCommand calls CheckHostCommandFunc.
+func (mock *ConfigMock) CheckHostCommand(key string) error {
+	if mock.CheckHostCommandFunc == nil {
+		panic("ConfigMock.CheckHostCommandFunc: method is nil but Config.CheckHostCommand was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockCheckHostCommand.Lock()
+	mock.calls.CheckHostCommand = append(mock.calls.CheckHostCommand, callInfo)
+	mock.lockCheckHostCommand.Unlock()
+	return mock.CheckHostCommandFunc(key)
+}
+
+// CheckHostCommandCalls gets all the calls that were made to CheckHostCommand.
+// Check the length with:
+//
+//	len(mockedConfig.CheckHostCommandCalls())
+func (mock *ConfigMock) CheckHostCommandCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockCheckHostCommand.RLock()
+	calls = mock.calls.CheckHostCommand
+	mock.lockCheckHostCommand.RUnlock()
+	return calls
+}
+
 // ClawkerIgnoreName calls ClawkerIgnoreNameFunc.
 func (mock *ConfigMock) ClawkerIgnoreName() string {
 	if mock.ClawkerIgnoreNameFunc == nil {
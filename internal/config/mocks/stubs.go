@@ -41,6 +41,7 @@ func newMockFrom(cfg config.Config) *ConfigMock {
 
 	mock.ProjectEgressRulesFunc = cfg.ProjectEgressRules
 	mock.BundleDeclarationsFunc = cfg.BundleDeclarations
+	mock.CheckHostCommandFunc = cfg.CheckHostCommand
 
 	// Store accessors
 	mock.ProjectStoreFunc = cfg.ProjectStore
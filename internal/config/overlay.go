@@ -0,0 +1,34 @@
+package config
+
+import "fmt"
+
+// overlayConfig decorates a Config with the effective project config of one
+// or more named override blocks (a profile, an agent) folded over the base.
+type overlayConfig struct {
+	Config
+	paths   []string
+	project *Project
+}
+
+// withOverlay folds the project block at path over cfg. Decorating an
+// already-decorated config re-reads the base with every path in order, so
+// a profile and an agent override compose — the later path wins.
+func withOverlay(cfg Config, path string) (Config, error) {
+	base, paths := cfg, []string{path}
+	if oc, ok := cfg.(*overlayConfig); ok {
+		base, paths = oc.Config, append(append([]string(nil), oc.paths...), path)
+	}
+	project, err := base.ProjectStore().ReadOverlay(paths...)
+	if err != nil {
+		return nil, fmt.Errorf("applying %s override: %w", path, err)
+	}
+	return &overlayConfig{Config: base, paths: paths, project: project}, nil
+}
+
+func (c *overlayConfig) Project() *Project {
+	return c.project
+}
+
+func (c *overlayConfig) ProjectEgressRules() []EgressRule {
+	return projectEgressRules(c.project)
+}
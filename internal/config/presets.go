@@ -1,9 +1,20 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/storage"
+	"gopkg.in/yaml.v3"
+)
+
 // Preset defines a language-specific project configuration template.
 // Each preset provides a partial YAML overlay — fields not specified
 // are filled from schema defaults via WithDefaultsFromStruct[Project]().
 type Preset struct {
+	ID            string // Template gallery name (--template); empty for wizard-only entries
 	Name          string // Display name (used as select option label)
 	Description   string // Short description (used as select option secondary text)
 	YAML          string // Partial clawker.yaml content
@@ -15,46 +26,61 @@ type Preset struct {
 func Presets() []Preset {
 	return []Preset{
 		{
+			ID:          "python",
 			Name:        "Python",
 			Description: "Python development with pip and venv",
 			YAML:        pythonPreset,
 		},
 		{
+			ID:          "go",
 			Name:        "Go",
 			Description: "Go development with module support",
 			YAML:        goPreset,
 		},
 		{
+			ID:          "rust",
 			Name:        "Rust",
 			Description: "Rust development with Cargo",
 			YAML:        rustPreset,
 		},
 		{
+			ID:          "node",
 			Name:        "Node",
 			Description: "Node.js and TypeScript development",
 			YAML:        nodePreset,
 		},
 		{
+			ID:          "java",
 			Name:        "Java",
 			Description: "Java development with Maven",
 			YAML:        javaPreset,
 		},
 		{
+			ID:          "ruby",
 			Name:        "Ruby",
 			Description: "Ruby development with Bundler",
 			YAML:        rubyPreset,
 		},
 		{
+			ID:          "cpp",
 			Name:        "C/C++",
 			Description: "C/C++ development with GCC and CMake",
 			YAML:        cppPreset,
 		},
 		{
+			ID:          "dotnet",
 			Name:        "C#/.NET",
 			Description: ".NET SDK development",
 			YAML:        dotnetPreset,
 		},
 		{
+			ID:          "monorepo",
+			Name:        "Monorepo",
+			Description: "Polyglot repository with Node, Python, and Go toolchains",
+			YAML:        monorepoPreset,
+		},
+		{
+			ID:          "bare",
 			Name:        "Bare",
 			Description: "Minimal base with common tools, no language runtime",
 			YAML:        barePreset,
@@ -68,6 +94,31 @@ func Presets() []Preset {
 	}
 }
 
+// PresetByID returns the template gallery entry with the given ID
+// (case-insensitive).
+func PresetByID(id string) (Preset, bool) {
+	for _, p := range Presets() {
+		if p.ID != "" && strings.EqualFold(p.ID, id) {
+			return p, true
+		}
+	}
+	return Preset{}, false
+}
+
+// NewProjectStoreFromTemplate is NewProjectStoreFromPreset for template
+// content clawker did not ship (a local directory or git repository). It
+// first decodes the YAML strictly, so an unknown or mis-nested key is an
+// error instead of silently vanishing on the lenient store load.
+func NewProjectStoreFromTemplate(templateYAML string) (*storage.Store[Project], error) {
+	dec := yaml.NewDecoder(strings.NewReader(templateYAML))
+	dec.KnownFields(true)
+	var proj Project
+	if err := dec.Decode(&proj); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("config: validating template: %w", err)
+	}
+	return NewProjectStoreFromPreset(templateYAML)
+}
+
 const pythonPreset = `build:
   stacks:
     - python
@@ -170,6 +221,34 @@ security:
       - api.nuget.org
 `
 
+const monorepoPreset = `agent:
+  pre_run: |
+    if [ -f package.json ]; then
+      npm install || echo "warning: npm install failed; continuing"
+    fi
+build:
+  stacks:
+    - node
+    - python
+    - go
+  packages:
+    - ripgrep
+    - build-essential
+  instructions:
+    user_run:
+      - npm install -g pnpm typescript
+security:
+  firewall:
+    add_domains:
+      - pypi.org
+      - files.pythonhosted.org
+      - proxy.golang.org
+      - sum.golang.org
+      - storage.googleapis.com
+workspace:
+  default_mode: bind
+`
+
 const barePreset = `build:
   packages:
     - ripgrep
@@ -107,7 +107,7 @@ func TestPresets_FieldAssertions(t *testing.T) {
 	// longer adds language-specific domains.
 	presetsWithDomains := map[string]bool{
 		"Python": true, "Go": true, "Rust": true,
-		"Java": true, "Ruby": true, "C#/.NET": true,
+		"Java": true, "Ruby": true, "C#/.NET": true, "Monorepo": true,
 	}
 
 	for _, p := range config.Presets() {
@@ -176,3 +176,33 @@ func TestPresets_SchemaDefaultsFillGaps(t *testing.T) {
 		})
 	}
 }
+
+func TestPresetByID(t *testing.T) {
+	for _, id := range []string{"node", "python", "go", "rust", "monorepo"} {
+		p, ok := config.PresetByID(id)
+		require.True(t, ok, "gallery template %q", id)
+		assert.Equal(t, id, p.ID)
+	}
+
+	p, ok := config.PresetByID("Go")
+	require.True(t, ok, "IDs match case-insensitively")
+	assert.Equal(t, "Go", p.Name)
+
+	_, ok = config.PresetByID("")
+	assert.False(t, ok, "Build from scratch has no ID")
+	_, ok = config.PresetByID("cobol")
+	assert.False(t, ok)
+}
+
+func TestNewProjectStoreFromTemplate(t *testing.T) {
+	store, err := config.NewProjectStoreFromTemplate("build:\n  packages:\n    - jq\n")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jq"}, store.Read().Build.Packages)
+
+	_, err = config.NewProjectStoreFromTemplate("build:\n  pakages:\n    - jq\n")
+	require.Error(t, err, "a typo'd key fails instead of vanishing")
+	assert.Contains(t, err.Error(), "pakages")
+
+	_, err = config.NewProjectStoreFromTemplate("")
+	assert.NoError(t, err, "an empty template is a blank config")
+}
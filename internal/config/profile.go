@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ProfilesKey is the project key holding named config profiles.
+const ProfilesKey = "profiles"
+
+// ProfilePath returns the dotted project key of profile's block.
+func ProfilePath(profile string) string {
+	return ProfilesKey + "." + profile
+}
+
+// ProfileNames returns the project's profile names, sorted.
+func ProfileNames(cfg Config) []string {
+	return slices.Sorted(maps.Keys(cfg.Project().Profiles))
+}
+
+// ForProfile returns cfg with the project's profiles.<profile> block folded
+// in, like ForAgent: Project() and ProjectEgressRules() reflect the merged
+// config, everything else delegates to cfg. Unlike an agent entry, a
+// selected profile must exist — a typo'd --profile would otherwise run with
+// the base config unnoticed. An empty name returns cfg unchanged.
+func ForProfile(cfg Config, profile string) (Config, error) {
+	if profile == "" {
+		return cfg, nil
+	}
+	if _, ok := cfg.Project().Profiles[profile]; !ok {
+		names := ProfileNames(cfg)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("unknown profile %q: the project defines no profiles", profile)
+		}
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", profile, strings.Join(names, ", "))
+	}
+	return withOverlay(cfg, ProfilePath(profile))
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profileYAML = `
+build:
+  packages: [git]
+agent:
+  editor: vim
+workspace:
+  default_mode: bind
+security:
+  firewall:
+    add_domains: [github.com]
+agents:
+  reviewer:
+    workspace:
+      default_mode: bind
+profiles:
+  ci:
+    build:
+      packages: [jq]
+    workspace:
+      default_mode: snapshot
+    security:
+      firewall:
+        add_domains: [ci.example.com]
+    services:
+      redis:
+        command: redis-server
+  local: {}
+`
+
+func TestForProfile(t *testing.T) {
+	base, err := NewFromString(profileYAML, "")
+	require.NoError(t, err)
+
+	t.Run("selected profile is folded over the base", func(t *testing.T) {
+		cfg, err := ForProfile(base, "ci")
+		require.NoError(t, err)
+
+		p := cfg.Project()
+		assert.Equal(t, "snapshot", p.Workspace.DefaultMode)
+		assert.Equal(t, "vim", p.Agent.Editor, "unset keys inherit the base")
+		assert.Equal(t, []string{"git", "jq"}, p.Build.Packages, "merge-tagged lists add to the base like a higher layer")
+		assert.Equal(t, "redis-server", p.Services["redis"].Command)
+
+		var dsts []string
+		for _, r := range cfg.ProjectEgressRules() {
+			dsts = append(dsts, r.Dst)
+		}
+		assert.Equal(t, []string{"github.com", "ci.example.com"}, dsts)
+
+		assert.Empty(t, base.Project().Services, "base config is untouched")
+	})
+
+	t.Run("agent override folds over the profile", func(t *testing.T) {
+		cfg, err := ForProfile(base, "ci")
+		require.NoError(t, err)
+		cfg, err = ForAgent(cfg, "reviewer")
+		require.NoError(t, err)
+
+		p := cfg.Project()
+		assert.Equal(t, "bind", p.Workspace.DefaultMode, "the agent entry wins over the profile")
+		assert.Equal(t, "redis-server", p.Services["redis"].Command, "profile blocks the agent doesn't set survive")
+	})
+
+	t.Run("unknown profile is an error", func(t *testing.T) {
+		_, err := ForProfile(base, "prod")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown profile "prod" (available: ci, local)`)
+	})
+
+	t.Run("empty profile returns cfg unchanged", func(t *testing.T) {
+		cfg, err := ForProfile(base, "")
+		require.NoError(t, err)
+		assert.Same(t, base, cfg)
+	})
+}
+
+func TestForProfile_NoProfiles(t *testing.T) {
+	base, err := NewFromString("build:\n  packages: [git]\n", "")
+	require.NoError(t, err)
+
+	_, err = ForProfile(base, "ci")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the project defines no profiles")
+	assert.Empty(t, ProfileNames(base))
+}
+
+func TestProfilesNode_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "dotted profile name",
+			yaml:    "profiles:\n  c.i:\n    workspace:\n      default_mode: snapshot\n",
+			wantErr: `invalid profile name "c.i"`,
+		},
+		{
+			name:    "unknown block",
+			yaml:    "profiles:\n  ci:\n    harnesses:\n      claude: {}\n",
+			wantErr: "profiles.ci.harnesses: unknown field",
+		},
+		{
+			name:    "bad build stack",
+			yaml:    "profiles:\n  ci:\n    build:\n      stacks: [Bad_Stack]\n",
+			wantErr: "profiles.ci: build.stacks",
+		},
+		{
+			name:    "bad service restart",
+			yaml:    "profiles:\n  ci:\n    services:\n      redis:\n        command: x\n        restart: sometimes\n",
+			wantErr: "profiles.ci: services.redis.restart",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFromString(tt.yaml, "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateProjectSet_profile(t *testing.T) {
+	require.NoError(t, ValidateProjectSet("profiles.ci.workspace.default_mode", "snapshot"))
+	require.Error(t, ValidateProjectSet("profiles.ci.services.redis.restart", "sometimes"))
+	require.Error(t, ValidateProjectSet("profiles.ci.harnesses", map[string]any{"claude": map[string]any{}}))
+}
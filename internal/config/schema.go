@@ -20,7 +20,7 @@ type Project struct {
 	Security  SecurityConfig  `yaml:"security"`
 	// Harnesses holds per-harness container initialization settings; the
 	// entry matching the selected harness applies.
-	Harnesses map[string]HarnessConfig `yaml:"harnesses,omitempty" label:"Harnesses" desc:"Per-harness container initialization settings, keyed by harness name"`
+	Harnesses map[string]HarnessConfig `yaml:"harnesses,omitempty" label:"Harnesses" desc:"Per-harness container initialization settings, keyed by harness name" interpolate:"false"`
 	Aliases   map[string]string        `yaml:"aliases,omitempty"   label:"Aliases"   desc:"Command aliases expanded before execution; the value is appended to 'clawker' and supports $1..$N placeholders; merged across all config layers" merge:"union" default:"go=run --rm -it --agent $1 @,wt=run --rm -it --agent $1 --worktree $2 @,claude=run --rm -it --agent $1 @:claude --dangerously-skip-permissions,codex=run --rm -it --agent $1 @:codex --yolo"`
 	// Bundles declares the installed-bundle sources this project draws
 	// extension components (harnesses, stacks, monitoring extensions) from.
@@ -32,6 +32,154 @@ type Project struct {
 	// Monitor holds project-scoped monitoring selection (which monitoring
 	// extensions this project projects into the host monitoring stack).
 	Monitor MonitorConfig `yaml:"monitor,omitempty"`
+	// Agents holds per-agent override blocks, keyed by agent name. The entry
+	// matching --agent is folded over the base config (see ForAgent).
+	Agents map[string]AgentOverride `yaml:"agents,omitempty" label:"Agent Overrides" desc:"Per-agent settings keyed by agent name; the entry matching --agent is deep-merged over the agent, workspace, and security blocks" interpolate:"false"`
+	// Services declares long-running processes started alongside the agent
+	// inside the container, keyed by service name. The bundler bakes them
+	// into the harness image for whichever process supervisor the image
+	// ships (s6, runit, systemd user units); clawkerd supervises them
+	// itself only when none is installed.
+	Services map[string]ServiceConfig `yaml:"services,omitempty" label:"Services" desc:"Background processes started in the agent container alongside the agent, keyed by service name; run as the container user under the image's process supervisor (s6, runit, systemd) or clawkerd when none is installed" interpolate:"false"`
+	// Profiles holds named partial project configs selected with the global
+	// --profile flag. The selected entry is folded over the base config
+	// before any per-agent override (see ForProfile).
+	Profiles map[string]ProjectProfile `yaml:"profiles,omitempty" label:"Profiles" desc:"Named config profiles selected with clawker --profile NAME; the selected entry is deep-merged over the build, agent, workspace, security, and services blocks" interpolate:"false"`
+	// Hooks declares host-side commands run around a container's lifecycle
+	// (see HostHooksConfig). Unlike agent.post_init / agent.pre_run, these
+	// run on the host, not in the container.
+	Hooks HostHooksConfig `yaml:"hooks,omitempty" interpolate:"false"`
+	// Secrets declares values resolved on the host from a provider (see
+	// SecretConfig) and injected into agent containers, keyed by secret
+	// name. Tagged interpolate:"false" so `${}` in an exec command reaches
+	// the host shell; the resolved values are never written back to any
+	// config, label, or image (see internal/secrets).
+	Secrets map[string]SecretConfig `yaml:"secrets,omitempty" label:"Secrets" desc:"Secrets resolved on the host at container create/start and injected as tmpfs files under /run/secrets or as env vars, keyed by secret name; values are never stored in config, labels, images, or logs" interpolate:"false"`
+	// Sidecars declares dependency containers (a database, a local API)
+	// that container run brings up on the project network before the agent
+	// starts, keyed by sidecar name. Unlike services:, each sidecar is its
+	// own container from its own image; the agent reaches it by name.
+	Sidecars map[string]SidecarConfig `yaml:"sidecars,omitempty" label:"Sidecars" desc:"Dependency containers (databases, local services) that container run starts on the project network before the agent and removes after it exits, keyed by sidecar name; the agent reaches each one at its name or at NAME.clawker.internal" interpolate:"false"`
+	// Network selects which Docker network the project's agents and
+	// sidecars share (see ProjectNetworkConfig).
+	Network ProjectNetworkConfig `yaml:"network,omitempty"`
+	// Loop configures `clawker loop run`, which drives an agent with a task
+	// prompt until a stop condition is met (see LoopConfig).
+	Loop LoopConfig `yaml:"loop,omitempty"`
+	// Resources sets default CPU, memory and PID limits for the project's
+	// agent containers (see ResourcesConfig).
+	Resources ResourcesConfig `yaml:"resources,omitempty"`
+	// Encryption lists who ENC[age:...] values written to this project's
+	// config are encrypted to (see EncryptionConfig).
+	Encryption EncryptionConfig `yaml:"encryption,omitempty"`
+}
+
+// EncryptionConfig is the project encryption: block. Values in clawker.yaml
+// and settings.yaml may be stored as ENC[age:...] ciphertext; they are
+// decrypted at load with the identity file named by the settings
+// encryption.identity_file (see encryption.go).
+type EncryptionConfig struct {
+	Recipients []string `yaml:"recipients,omitempty" label:"Recipients" desc:"age public keys (age1...) that encrypted values in this project are also encrypted to, so teammates can decrypt them; your own identity is always included; merged across all config layers" merge:"unique-union"`
+}
+
+// ResourcesConfig is the project resources: block: limits applied to agent
+// containers at create time when the matching container run/create flag
+// (--cpus, --memory, --pids-limit) is not given. Settings
+// resources: caps them (see ResourceCapSettings).
+type ResourcesConfig struct {
+	CPUs   string `yaml:"cpus,omitempty"   label:"CPUs"   desc:"Default CPU limit for agent containers, e.g. 2 or 1.5; --cpus overrides it"`
+	Memory string `yaml:"memory,omitempty" label:"Memory" desc:"Default memory limit for agent containers, e.g. 4g or 512m; --memory overrides it"`
+	Pids   int    `yaml:"pids,omitempty"   label:"PIDs"   desc:"Default process limit for agent containers (-1 for unlimited); --pids-limit overrides it"`
+}
+
+// LoopConfig is the project loop: block. Each iteration runs Command in the
+// agent container with the task prompt in $CLAWKER_LOOP_PROMPT, then
+// SuccessCommand; the loop stops when SuccessCommand exits 0, after
+// MaxLoops iterations, or once the agent has used TokenBudget tokens.
+type LoopConfig struct {
+	MaxLoops         int           `yaml:"max_loops,omitempty"         label:"Max Loops"         desc:"Iterations after which clawker loop run stops" default:"10"`
+	TokenBudget      int           `yaml:"token_budget,omitempty"      label:"Token Budget"      desc:"Tokens (input, output and cache) the agent may use across a run; checked after each iteration, 0 for no limit"`
+	Command          string        `yaml:"command,omitempty"           label:"Agent Command"     desc:"Shell command run in the agent container for each iteration, with the prompt in $CLAWKER_LOOP_PROMPT; defaults to a non-interactive invocation of the claude or codex harness" interpolate:"false"`
+	SuccessCommand   string        `yaml:"success_command,omitempty"   label:"Success Command"   desc:"Shell command run in the agent container after each iteration; exit 0 ends the run as a success (e.g. make test)" interpolate:"false"`
+	IterationTimeout time.Duration `yaml:"iteration_timeout,omitempty" label:"Iteration Timeout" desc:"Time one agent invocation may take before it is killed and the run stops; 0 for no limit"`
+}
+
+// ProjectNetworkConfig is the project network: block. By default a
+// project's agents and sidecars join a per-project network that other
+// projects cannot reach; every agent also stays on the clawker network,
+// where the control plane and firewall live.
+type ProjectNetworkConfig struct {
+	Shared bool `yaml:"shared,omitempty" label:"Shared Network" desc:"Put this project's agents and sidecars on the shared clawker network only, instead of a per-project network isolated from other projects"`
+}
+
+// SidecarConfig is one sidecars.<name> entry: a container run from Image
+// next to the agent for the agent's lifetime.
+type SidecarConfig struct {
+	Image       string              `yaml:"image"                 label:"Image" desc:"Image the sidecar runs, e.g. postgres:16; pulled when missing"`
+	Env         map[string]string   `yaml:"env,omitempty"         label:"Env"   desc:"Environment variables for the sidecar container"`
+	Ports       []string            `yaml:"ports,omitempty"       label:"Ports" desc:"Sidecar ports to publish on the host, in --publish syntax ([ip:]host:container[/proto]); the agent reaches the sidecar without them"`
+	Healthcheck *SidecarHealthcheck `yaml:"healthcheck,omitempty"`
+}
+
+// SidecarHealthcheck replaces the sidecar image's HEALTHCHECK. The agent
+// starts once every sidecar reports healthy; a sidecar with no healthcheck
+// at all counts as ready once it is running.
+type SidecarHealthcheck struct {
+	Command     string        `yaml:"command"                label:"Health Command"      desc:"Shell command run in the sidecar (via /bin/sh -c); exit 0 means healthy"`
+	Interval    time.Duration `yaml:"interval,omitempty"     label:"Health Interval"     desc:"Time between health checks (Docker default 30s)"`
+	Timeout     time.Duration `yaml:"timeout,omitempty"      label:"Health Timeout"      desc:"Time a single check may take before it counts as failed (Docker default 30s)"`
+	Retries     int           `yaml:"retries,omitempty"      label:"Health Retries"      desc:"Consecutive failures before the sidecar is unhealthy (Docker default 3)"`
+	StartPeriod time.Duration `yaml:"start_period,omitempty" label:"Health Start Period" desc:"Grace period after start during which failures don't count"`
+}
+
+// SecretConfig is one `secrets:` entry: where the value comes from and how
+// the container receives it. A secret with neither env nor file set is
+// written to a file named after the secret.
+type SecretConfig struct {
+	Provider string `yaml:"provider"       label:"Provider" desc:"Where the value comes from: env (host env var), file (host file), op (1Password CLI), pass (password-store entry), or exec (host command output)"`
+	Ref      string `yaml:"ref"            label:"Ref"      desc:"Provider reference: env var name, file path, op:// URI, pass entry name, or shell command"`
+	Env      string `yaml:"env,omitempty"  label:"Env Var"  desc:"Inject the value as this container environment variable"`
+	File     string `yaml:"file,omitempty" label:"File"     desc:"Inject the value as this file name under /run/secrets (tmpfs, mode 0400); defaults to the secret name when env is not set"`
+}
+
+// HostHooksConfig is the project `hooks:` block: shell commands the CLI runs
+// on the host (via /bin/sh -c) at container lifecycle points. Each receives
+// a JSON document describing the container on stdin. A failing pre_* hook
+// aborts the operation; a failing post_ready hook only warns.
+type HostHooksConfig struct {
+	PreCreate string `yaml:"pre_create,omitempty" label:"Pre-Create Hook" desc:"Host command run before a container is created; receives the container name, labels, and requested ports as JSON on stdin; a non-zero exit aborts the create"`
+	PostReady string `yaml:"post_ready,omitempty" label:"Post-Ready Hook" desc:"Host command run once a started container is ready; receives the container ID, name, labels, and published ports as JSON on stdin; a non-zero exit is reported as a warning"`
+	PreRemove string `yaml:"pre_remove,omitempty" label:"Pre-Remove Hook" desc:"Host command run before clawker container remove deletes a container; receives the container ID, name, labels, and ports as JSON on stdin; a non-zero exit skips the removal"`
+}
+
+// AgentOverride is one agents.<name> entry: a partial project config whose
+// blocks are deep-merged over the base when that agent is selected, with the
+// same semantics as a higher-priority config file — scalars and env maps
+// replace, union-merged lists (firewall domains and rules) accumulate.
+type AgentOverride struct {
+	Agent     AgentConfig     `yaml:"agent,omitempty"`
+	Workspace WorkspaceConfig `yaml:"workspace,omitempty"`
+	Security  SecurityConfig  `yaml:"security,omitempty"`
+}
+
+// ProjectProfile is one profiles.<name> entry: a partial project config
+// deep-merged over the base when the profile is selected, with the same
+// semantics as a higher-priority config file.
+type ProjectProfile struct {
+	Build     BuildConfig              `yaml:"build,omitempty"`
+	Agent     AgentConfig              `yaml:"agent,omitempty"`
+	Workspace WorkspaceConfig          `yaml:"workspace,omitempty"`
+	Security  SecurityConfig           `yaml:"security,omitempty"`
+	Services  map[string]ServiceConfig `yaml:"services,omitempty"`
+}
+
+// ServiceConfig is one services.<name> entry: a shell command run as the
+// container user for the lifetime of the agent container.
+type ServiceConfig struct {
+	Command string            `yaml:"command"           label:"Command" desc:"Shell command that runs the service in the foreground (run via /bin/sh -c)"`
+	Env     map[string]string `yaml:"env,omitempty"     label:"Env"     desc:"Extra environment variables for the service, on top of the container environment"`
+	Workdir string            `yaml:"workdir,omitempty" label:"Workdir" desc:"Working directory for the service; defaults to the container user's home"`
+	Restart string            `yaml:"restart,omitempty" label:"Restart" desc:"When to restart the service after it exits: always, on-failure, or no" default:"always"`
 }
 
 // MonitorConfig is the project-scoped monitoring selection block
@@ -64,7 +212,7 @@ type BuildConfig struct {
 	// The virtual defaults layer supplies the built-in harness, so the
 	// resolved value is never empty.
 	Harness      string              `yaml:"harness,omitempty"      label:"Default Harness" desc:"Default harness when a command doesn't select one; any other harness stays available per run (clawker build -t HARNESS). Bare name or namespace.bundle.component address" default:"claude"`
-	Packages     []string            `yaml:"packages,omitempty"     label:"Packages"        desc:"System packages (apt) needed by your project that the clawker base doesn't already install"                                                                               default:"ripgrep"`
+	Packages     []string            `yaml:"packages,omitempty"     label:"Packages"        desc:"System packages (apt) needed by your project that the clawker base doesn't already install; merged across all config layers (tag the list !replace to drop inherited packages, including the default)" merge:"unique-union" default:"ripgrep"`
 	Stacks       []string            `yaml:"stacks,omitempty"       label:"Stacks"          desc:"Stack definitions your root_run/user_run steps need (e.g. node, go); installed in the shared base image before your instructions run"`
 	Instructions *DockerInstructions `yaml:"instructions,omitempty"`
 	Inject       *InjectConfig       `yaml:"inject,omitempty"`
@@ -74,17 +222,31 @@ type BuildConfig struct {
 	// bundle's own installer stacks; overlay packages get no dedupe
 	// against Packages (apt install is idempotent); overlay inject points
 	// render only in the named harness's image, never every harness image.
-	Harnesses map[string]HarnessBuildOverlay `yaml:"harnesses,omitempty" label:"Harness Build Overlay" desc:"Per-harness build additions (stacks, packages, inject), keyed by harness name"`
+	Harnesses map[string]HarnessBuildOverlay `yaml:"harnesses,omitempty" label:"Harness Build Overlay" desc:"Per-harness build additions (stacks, packages, inject), keyed by harness name" interpolate:"false"`
+	// Scan declares the post-build SBOM and vulnerability scan hooks (see
+	// BuildScanConfig).
+	Scan BuildScanConfig `yaml:"scan,omitempty" interpolate:"false"`
+}
+
+// BuildScanConfig is the build `scan:` block: host commands (/bin/sh -c)
+// `clawker image build` runs against each image it builds. Each receives
+// the image in $CLAWKER_IMAGE and $CLAWKER_IMAGE_ID; the scanner also gets
+// the SBOM path in $CLAWKER_SBOM. Their stdout is stored under the data
+// dir (see internal/imagescan).
+type BuildScanConfig struct {
+	SBOM    string `yaml:"sbom,omitempty"    label:"SBOM Command"    desc:"Host command that writes an SBOM for the built image to stdout (e.g. syft \"$CLAWKER_IMAGE\" -o spdx-json); stored as sbom.json under the data dir"`
+	Command string `yaml:"command,omitempty" label:"Scanner Command" desc:"Host command that writes a JSON vulnerability report to stdout (e.g. grype \"sbom:$CLAWKER_SBOM\" -o json); a non-zero exit fails the build"`
+	FailOn  string `yaml:"fail_on,omitempty" label:"Fail On"         desc:"Fail the build when the report has a finding at this severity or worse: low, medium, high, or critical; needs grype or trivy JSON output"`
 }
 
 // DockerInstructions represents type-safe Dockerfile instructions
 type DockerInstructions struct {
-	Copy    []CopyInstruction `yaml:"copy,omitempty"     label:"Copy"     desc:"Bake config files or credentials into the image (e.g. .npmrc, SSH config)"`
-	Env     map[string]string `yaml:"env,omitempty"      label:"Env"      desc:"Environment variables baked into the image; use agent.env for runtime-only vars"`
-	Labels  map[string]string `yaml:"labels,omitempty"   label:"Labels"   desc:"Custom Docker labels for image metadata or tooling integration"                    merge:"union"`
-	Args    []ArgDefinition   `yaml:"args,omitempty"     label:"Args"     desc:"Build-time variables resolved during docker build (ARG); not available at runtime"`
-	UserRun []string          `yaml:"user_run,omitempty" label:"User Run" desc:"Setup commands that run as the container user (e.g. npm install -g, pip install)"`
-	RootRun []string          `yaml:"root_run,omitempty" label:"Root Run" desc:"Setup commands that need root privileges (e.g. system config, additional repos)"`
+	Copy    []CopyInstruction `yaml:"copy,omitempty"     label:"Copy"     desc:"Bake config files or credentials into the image (e.g. .npmrc, SSH config)" interpolate:"false"`
+	Env     map[string]string `yaml:"env,omitempty"      label:"Env"      desc:"Environment variables baked into the image; use agent.env for runtime-only vars" interpolate:"false"`
+	Labels  map[string]string `yaml:"labels,omitempty"   label:"Labels"   desc:"Custom Docker labels for image metadata or tooling integration"                    merge:"union" interpolate:"false"`
+	Args    []ArgDefinition   `yaml:"args,omitempty"     label:"Args"     desc:"Build-time variables resolved during docker build (ARG); not available at runtime" interpolate:"false"`
+	UserRun []string          `yaml:"user_run,omitempty" label:"User Run" desc:"Setup commands that run as the container user (e.g. npm install -g, pip install)" interpolate:"false"`
+	RootRun []string          `yaml:"root_run,omitempty" label:"Root Run" desc:"Setup commands that need root privileges (e.g. system config, additional repos)" interpolate:"false"`
 }
 
 // CopyInstruction represents a COPY instruction with optional chown/chmod
@@ -101,15 +263,18 @@ type ArgDefinition struct {
 	Default string `yaml:"default,omitempty" label:"Default" desc:"Value used when not overridden by --build-arg at build time"`
 }
 
-// InjectConfig defines injection points for arbitrary Dockerfile instructions
+// InjectConfig defines injection points for arbitrary Dockerfile instructions.
+// Fragments are checked at load time: none may open a stage, replace the
+// managed entrypoint, or switch users outside before_entrypoint (see
+// validateInjectInstructions).
 type InjectConfig struct {
-	AfterFrom          []string `yaml:"after_from,omitempty"           label:"After FROM"           desc:"Add Dockerfile instructions while root with only the base image — e.g. apt sources, proxy config, or CA certs that package installation depends on"`
-	AfterPackages      []string `yaml:"after_packages,omitempty"       label:"After Packages"       desc:"Add Dockerfile instructions while root with system packages available — e.g. compile native libraries or install tools that need those packages"`
-	AfterUserSetup     []string `yaml:"after_user_setup,omitempty"     label:"After User Setup"     desc:"Add Dockerfile instructions while root with the container user (claude) created — e.g. set up directories, fix permissions, or configure services"`
-	AfterUserSwitch    []string `yaml:"after_user_switch,omitempty"    label:"After User Switch"    desc:"Add Dockerfile instructions as the container user (claude) — e.g. install dotfiles, configure your shell, or set up user-level tools"`
-	AfterClaudeInstall []string `yaml:"after_claude_install,omitempty" label:"After Claude Install" desc:"Deprecated: use user_commands"`
-	UserCommands       []string `yaml:"user_commands,omitempty"        label:"User Commands"        desc:"Add Dockerfile instructions as the container user, after the harness image's fragment blocks and config seeds — e.g. add MCP servers, install plugins, or extensions"`
-	BeforeEntrypoint   []string `yaml:"before_entrypoint,omitempty"    label:"Before Entrypoint"    desc:"Add Dockerfile instructions at the very end — e.g. final environment tweaks or cleanup that must happen after everything else"`
+	AfterFrom          []string `yaml:"after_from,omitempty"           label:"After FROM"           desc:"Add Dockerfile instructions while root with only the base image — e.g. apt sources, proxy config, or CA certs that package installation depends on" interpolate:"false"`
+	AfterPackages      []string `yaml:"after_packages,omitempty"       label:"After Packages"       desc:"Add Dockerfile instructions while root with system packages available — e.g. compile native libraries or install tools that need those packages" interpolate:"false"`
+	AfterUserSetup     []string `yaml:"after_user_setup,omitempty"     label:"After User Setup"     desc:"Add Dockerfile instructions while root with the container user (claude) created — e.g. set up directories, fix permissions, or configure services" interpolate:"false"`
+	AfterUserSwitch    []string `yaml:"after_user_switch,omitempty"    label:"After User Switch"    desc:"Add Dockerfile instructions as the container user (claude) — e.g. install dotfiles, configure your shell, or set up user-level tools" interpolate:"false"`
+	AfterClaudeInstall []string `yaml:"after_claude_install,omitempty" label:"After Claude Install" desc:"Deprecated: use user_commands" interpolate:"false"`
+	UserCommands       []string `yaml:"user_commands,omitempty"        label:"User Commands"        desc:"Add Dockerfile instructions as the container user, after the harness image's fragment blocks and config seeds — e.g. add MCP servers, install plugins, or extensions" interpolate:"false"`
+	BeforeEntrypoint   []string `yaml:"before_entrypoint,omitempty"    label:"Before Entrypoint"    desc:"Add Dockerfile instructions at the very end — e.g. final environment tweaks or cleanup that must happen after everything else" interpolate:"false"`
 }
 
 // HarnessBuildOverlay is one harness's build.harnesses.<name> entry: extra
@@ -150,13 +315,14 @@ type HarnessConfigOptions struct {
 // map keyed by harness name; the legacy `agent.claude_code` block is read
 // as the claude entry when no map entry exists.
 type HarnessConfig struct {
-	Config        HarnessConfigOptions `yaml:"config"`
-	MountProjects *bool                `yaml:"mount_projects,omitempty" label:"Mount Host State" desc:"Bind mount the harness's host state dirs (e.g. ~/.claude/projects/ for the claude harness) into the container so auto-memory and sessions are shared across container runs and instances" default:"true"`
-	EnvFile       []string             `yaml:"env_file,omitempty"       label:"Env Files"        desc:"Load extra environment variables from .env-style files when this harness is selected; layered on top of agent.env_file"`
-	FromEnv       []string             `yaml:"from_env,omitempty"       label:"Forward Env Vars" desc:"Forward specific host env vars into the container when this harness is selected; layered on top of agent.from_env"`
-	Env           map[string]string    `yaml:"env,omitempty"            label:"Env"              desc:"Set container env vars when this harness is selected; overrides agent.env on key collision"`
-	PostInit      string               `yaml:"post_init,omitempty"      label:"Post-Init Script" desc:"Shell commands run once after container creation when this harness is selected, appended after agent.post_init (e.g. install this harness's MCP servers)"`
-	PreRun        string               `yaml:"pre_run,omitempty"        label:"Pre-Run Script"   desc:"Shell commands run on every container start when this harness is selected, appended after agent.pre_run"`
+	Config          HarnessConfigOptions `yaml:"config"`
+	MountProjects   *bool                `yaml:"mount_projects,omitempty" label:"Mount Host State" desc:"Bind mount the harness's host state dirs (e.g. ~/.claude/projects/ for the claude harness) into the container so auto-memory and sessions are shared across container runs and instances" default:"true"`
+	PersistSessions *bool                `yaml:"persist_sessions,omitempty" label:"Persist Sessions" desc:"Save the harness's conversation state to the host when the agent stops and restore it when the container is recreated with fresh volumes" default:"true"`
+	EnvFile         []string             `yaml:"env_file,omitempty"       label:"Env Files"        desc:"Load extra environment variables from .env-style files when this harness is selected; layered on top of agent.env_file"`
+	FromEnv         []string             `yaml:"from_env,omitempty"       label:"Forward Env Vars" desc:"Forward specific host env vars into the container when this harness is selected; layered on top of agent.from_env"`
+	Env             map[string]string    `yaml:"env,omitempty"            label:"Env"              desc:"Set container env vars when this harness is selected; overrides agent.env on key collision"`
+	PostInit        string               `yaml:"post_init,omitempty"      label:"Post-Init Script" desc:"Shell commands run once after container creation when this harness is selected, appended after agent.post_init (e.g. install this harness's MCP servers)" interpolate:"false"`
+	PreRun          string               `yaml:"pre_run,omitempty"        label:"Pre-Run Script"   desc:"Shell commands run on every container start when this harness is selected, appended after agent.pre_run" interpolate:"false"`
 }
 
 // AgentConfig defines harness-agnostic agent runtime settings.
@@ -168,8 +334,8 @@ type AgentConfig struct {
 	Visual          string            `yaml:"visual,omitempty"            label:"Visual Editor"     desc:"Visual editor ($VISUAL) for the container"`
 	ClaudeCode      *HarnessConfig    `yaml:"claude_code,omitempty"       label:"Claude Code"       desc:"Deprecated: use the project-root harnesses map keyed by harness name instead"`
 	EnableSharedDir *bool             `yaml:"enable_shared_dir,omitempty" label:"Enable Shared Dir" desc:"Share files between host and container via ~/.clawker-share (read-only in container)"                                                                                                                                                                                                                default:"false"`
-	PostInit        string            `yaml:"post_init,omitempty"         label:"Post-Init Script"  desc:"Shell commands to run after container starts but before the harness launches (e.g. install MCP servers). Useful for seeding harness config or running setup steps that require the container environment to be up. Runs only one time after container creation in the workdir with env vars loaded."`
-	PreRun          string            `yaml:"pre_run,omitempty"           label:"Pre-Run Script"    desc:"Shell commands run on every container start, in the workdir, right before the harness CMD runs (e.g. npm install)"`
+	PostInit        string            `yaml:"post_init,omitempty"         label:"Post-Init Script"  desc:"Shell commands to run after container starts but before the harness launches (e.g. install MCP servers). Useful for seeding harness config or running setup steps that require the container environment to be up. Runs only one time after container creation in the workdir with env vars loaded." interpolate:"false"`
+	PreRun          string            `yaml:"pre_run,omitempty"           label:"Pre-Run Script"    desc:"Shell commands run on every container start, in the workdir, right before the harness CMD runs (e.g. npm install)" interpolate:"false"`
 }
 
 // MountProjectsEnabled returns whether the harness's host-state dirs should
@@ -181,6 +347,15 @@ func (c *HarnessConfig) MountProjectsEnabled() bool {
 	return *c.MountProjects
 }
 
+// PersistSessionsEnabled returns whether the harness's conversation state
+// is saved on stop and restored on recreation (default: true).
+func (c *HarnessConfig) PersistSessionsEnabled() bool {
+	if c == nil || c.PersistSessions == nil {
+		return true
+	}
+	return *c.PersistSessions
+}
+
 // ConfigStrategy returns the config strategy (default: "copy").
 func (c *HarnessConfig) ConfigStrategy() string {
 	if c == nil || c.Config.Strategy == "" {
@@ -345,6 +520,17 @@ type SecurityConfig struct {
 	CapAdd          []string              `yaml:"cap_add,omitempty"           label:"Cap Add"       desc:"Extra Linux capabilities for the agent container. Empty by default — the eBPF firewall is attached from outside, so no in-container caps are needed. Add e.g. SYS_PTRACE only if your workflow requires it."`
 	EnableHostProxy *bool                 `yaml:"enable_host_proxy,omitempty" label:"Host Proxy"    desc:"Run a proxy for browser-based auth flows and credential forwarding from the host"                                                                                                                            default:"true"`
 	GitCredentials  *GitCredentialsConfig `yaml:"git_credentials,omitempty"`
+	ReadOnlyRoot    bool                  `yaml:"read_only_root,omitempty"    label:"Read-Only Root" desc:"Run the agent with a read-only root filesystem. Clawker provisions writable mounts only for what the agent needs: tmpfs for /tmp and /var/tmp, and fresh per-agent volumes for the home directory and clawker's runtime dirs" default:"false"`
+	WritablePaths   []string              `yaml:"writable_paths,omitempty"    label:"Writable Paths" desc:"Extra absolute container paths kept writable when read_only_root is on. Each gets a fresh per-agent volume seeded from the image's content at that path"`
+	OpenURL         OpenURLConfig         `yaml:"open_url,omitempty"`
+}
+
+// OpenURLConfig is the allowlist for URLs the agent opens in the host browser
+// (host-open, xdg-open and open inside the container). Stamped onto the
+// container at create; the host proxy enforces it on top of the egress rules.
+type OpenURLConfig struct {
+	Schemes []string `yaml:"schemes,omitempty" label:"Open URL Schemes" desc:"URL schemes the agent may open in the host browser, e.g. https or vscode (empty = http and https). file, javascript, data and vbscript are never opened"`
+	Hosts   []string `yaml:"hosts,omitempty"   label:"Open URL Hosts"   desc:"Hosts http(s) URLs may point at, e.g. github.com or *.example.com (empty = any host the egress rules allow)"`
 }
 
 // HostProxyEnabled returns whether the host proxy should be enabled.
@@ -421,19 +607,148 @@ func (e *KeyNotFoundError) Error() string { return "key not found: " + e.Key }
 
 // Settings represents user-level configuration stored in ~/.config/clawker/settings.yaml.
 type Settings struct {
-	Logging      LoggingConfig        `yaml:"logging,omitempty"`
-	Monitoring   MonitoringConfig     `yaml:"monitoring,omitempty"`
-	HostProxy    HostProxyConfig      `yaml:"host_proxy,omitempty"`
-	Firewall     FirewallSettings     `yaml:"firewall,omitempty"`
-	ControlPlane ControlPlaneSettings `yaml:"control_plane,omitempty"`
-	Docker       DockerSettings       `yaml:"docker,omitempty"`
+	Logging       LoggingConfig        `yaml:"logging,omitempty"`
+	Monitoring    MonitoringConfig     `yaml:"monitoring,omitempty"`
+	HostProxy     HostProxyConfig      `yaml:"host_proxy,omitempty"`
+	Firewall      FirewallSettings     `yaml:"firewall,omitempty"`
+	ControlPlane  ControlPlaneSettings `yaml:"control_plane,omitempty"`
+	Docker        DockerSettings       `yaml:"docker,omitempty"`
+	UI            UISettings           `yaml:"ui,omitempty"`
+	Terminal      TerminalSettings     `yaml:"terminal,omitempty"`
+	Editor        EditorSettings       `yaml:"editor,omitempty"`
+	Resources     ResourceCapSettings  `yaml:"resources,omitempty"`
+	Notifications NotificationSettings `yaml:"notifications,omitempty"`
+	Encryption    EncryptionSettings   `yaml:"encryption,omitempty"`
+	Secrets       SecretsSettings      `yaml:"secrets,omitempty"`
+	IdleTimeout   time.Duration        `yaml:"idle_timeout,omitempty" label:"Idle Timeout" desc:"Stop agent containers after this long without terminal or exec activity, e.g. 2h; 0 disables. Enforced by the host proxy daemon; container run --keep-alive exempts a container"`
+}
+
+// EncryptionSettings is the settings encryption: block: the age identity
+// that decrypts ENC[age:...] config values (see EncryptionConfig).
+type EncryptionSettings struct {
+	IdentityFile string `yaml:"identity_file,omitempty" label:"Identity File" desc:"age identity file (age-keygen output) that decrypts encrypted values in clawker.yaml and settings.yaml; without it they stay encrypted. Supports ~ and $VAR" interpolate:"false"`
+}
+
+// SecretsSettings is the settings secrets: block.
+type SecretsSettings struct {
+	FileRoots []string `yaml:"file_roots,omitempty" label:"File Roots" desc:"Directories that file secrets declared in a project config file may read from, besides the project itself; secrets in your user-level clawker.yaml are not confined. Supports ~ and $VAR" interpolate:"false"`
+}
+
+// NotificationSettings is the settings notifications: block: desktop
+// notifications when a long-running operation finishes (internal/notify).
+type NotificationSettings struct {
+	Enabled     bool               `yaml:"enabled,omitempty"      label:"Desktop Notifications" desc:"Show a desktop notification when a long-running operation finishes (osascript on macOS, notify-send on Linux, a toast on Windows)" default:"false"`
+	MinDuration time.Duration      `yaml:"min_duration,omitempty" label:"Minimum Duration"      desc:"Only notify about operations that ran at least this long"                                                                        default:"30s"`
+	QuietHours  string             `yaml:"quiet_hours,omitempty"  label:"Quiet Hours"           desc:"Local time window with no notifications, as HH:MM-HH:MM, e.g. 22:00-08:00 (may span midnight)"`
+	Events      NotificationEvents `yaml:"events,omitempty"`
+}
+
+// NotificationEvents toggles notifications per event. Unset toggles are on.
+type NotificationEvents struct {
+	BuildComplete *bool `yaml:"build_complete,omitempty" label:"Build Complete" desc:"Notify when an image build finishes"                 default:"true"`
+	AgentReady    *bool `yaml:"agent_ready,omitempty"    label:"Agent Ready"    desc:"Notify when a detached agent (run -d) finishes starting" default:"true"`
+	LoopComplete  *bool `yaml:"loop_complete,omitempty"  label:"Loop Complete"  desc:"Notify when an agent loop's success command passes"  default:"true"`
+	Failure       *bool `yaml:"failure,omitempty"        label:"Failure"        desc:"Notify when a build, detached start or agent loop fails" default:"true"`
+}
+
+// On reports whether the toggle for event (a notify.Event name) is on.
+// Unknown events are off.
+func (e NotificationEvents) On(event string) bool {
+	var toggle *bool
+	switch event {
+	case "build_complete":
+		toggle = e.BuildComplete
+	case "agent_ready":
+		toggle = e.AgentReady
+	case "loop_complete":
+		toggle = e.LoopComplete
+	case "failure":
+		toggle = e.Failure
+	default:
+		return false
+	}
+	return toggle == nil || *toggle
+}
+
+// ResourceCapSettings is the settings resources: block: a hard cap on agent
+// container limits that neither CLI flags nor a project's resources: can
+// exceed. A capped limit left unset at create takes the cap, so a cap also
+// rules out unlimited containers. Empty or zero means no cap.
+type ResourceCapSettings struct {
+	MaxCPUs   string `yaml:"max_cpus,omitempty"   label:"Max CPUs"   desc:"Highest CPU limit an agent container may have, e.g. 4; containers created without a CPU limit get this one"`
+	MaxMemory string `yaml:"max_memory,omitempty" label:"Max Memory" desc:"Highest memory limit an agent container may have, e.g. 8g; containers created without a memory limit get this one"`
+	MaxPids   int    `yaml:"max_pids,omitempty"   label:"Max PIDs"   desc:"Highest process limit an agent container may have; containers created without a process limit get this one"`
+}
+
+// UISettings configures terminal output. Color is still subject to
+// NO_COLOR, CLICOLOR and CLICOLOR_FORCE — a theme only picks which colors
+// are used when color is on.
+type UISettings struct {
+	Theme   string          `yaml:"theme,omitempty"   label:"Theme"   desc:"Color theme: default (follows the terminal background), dark, light, or high-contrast" default:"default"`
+	Palette PaletteSettings `yaml:"palette,omitempty"`
+}
+
+// TerminalSettings configures interactive sessions attached to an agent
+// container (run, attach, exec).
+type TerminalSettings struct {
+	DetachKeys string `yaml:"detach_keys,omitempty" label:"Detach Keys" desc:"Key sequence that detaches from an attached container, e.g. ctrl-p,ctrl-q or ctrl-a,d" default:"ctrl-p,ctrl-q"`
+}
+
+// EditorSettings configures `clawker open`, which opens an agent's workspace
+// in a host editor.
+type EditorSettings struct {
+	Default string `yaml:"default,omitempty" label:"Default Editor" desc:"Editor used by clawker open when --editor is not given: code, cursor, or zed" default:"code"`
+}
+
+// PaletteSettings overrides individual colors of the selected theme. Values
+// are hex colors (#RGB, #RRGGBB) or ANSI color numbers (0-255); unset entries
+// keep the theme's color.
+type PaletteSettings struct {
+	Primary   string `yaml:"primary,omitempty"   label:"Primary"   desc:"Brand color for titles and emphasis"`
+	Secondary string `yaml:"secondary,omitempty" label:"Secondary" desc:"Supporting color for subtitles and panel titles"`
+	Success   string `yaml:"success,omitempty"   label:"Success"   desc:"Color for success messages and running status"`
+	Warning   string `yaml:"warning,omitempty"   label:"Warning"   desc:"Color for warnings"`
+	Error     string `yaml:"error,omitempty"     label:"Error"     desc:"Color for errors and failures"`
+	Muted     string `yaml:"muted,omitempty"     label:"Muted"     desc:"Color for dimmed and secondary text"`
+	Highlight string `yaml:"highlight,omitempty" label:"Highlight" desc:"Color for text that needs attention"`
+	Info      string `yaml:"info,omitempty"      label:"Info"      desc:"Color for informational messages"`
+	Accent    string `yaml:"accent,omitempty"    label:"Accent"    desc:"Color for accents"`
+	Border    string `yaml:"border,omitempty"    label:"Border"    desc:"Color for panel borders and dividers"`
+	Subtle    string `yaml:"subtle,omitempty"    label:"Subtle"    desc:"Color for table headers and subdued labels"`
+	Text      string `yaml:"text,omitempty"      label:"Text"      desc:"Color for emphasized foreground text"`
+}
+
+// Colors returns the set palette entries keyed by their YAML name.
+func (p PaletteSettings) Colors() map[string]string {
+	all := map[string]string{
+		"primary":   p.Primary,
+		"secondary": p.Secondary,
+		"success":   p.Success,
+		"warning":   p.Warning,
+		"error":     p.Error,
+		"muted":     p.Muted,
+		"highlight": p.Highlight,
+		"info":      p.Info,
+		"accent":    p.Accent,
+		"border":    p.Border,
+		"subtle":    p.Subtle,
+		"text":      p.Text,
+	}
+	out := make(map[string]string, len(all))
+	for k, v := range all {
+		if v != "" {
+			out[k] = v
+		}
+	}
+	return out
 }
 
 // DockerSettings configures host Docker access. Per-project Docker
 // socket exposure to agent containers lives separately under
 // SecurityConfig.DockerSocket — these knobs are unrelated.
 type DockerSettings struct {
-	Socket string `yaml:"socket,omitempty" label:"Docker Socket" desc:"Host path to the Docker daemon socket" default:"/var/run/docker.sock"`
+	Socket  string `yaml:"socket,omitempty"  label:"Docker Socket"  desc:"Host path to the Docker daemon socket" default:"/var/run/docker.sock"`
+	Context string `yaml:"context,omitempty" label:"Docker Context" desc:"Docker context to connect to (see docker context ls), including remote tcp:// and ssh:// hosts; DOCKER_HOST and DOCKER_CONTEXT override it, and empty follows the docker CLI's current context"`
 }
 
 // ControlPlaneSettings configures the control plane in settings.yaml.
@@ -477,8 +792,34 @@ func (f *FirewallSettings) FirewallEnabled() bool {
 
 // HostProxyConfig configures the host proxy.
 type HostProxyConfig struct {
-	Manager HostProxyManagerConfig `yaml:"manager,omitempty"`
-	Daemon  HostProxyDaemonConfig  `yaml:"daemon,omitempty"`
+	Manager            HostProxyManagerConfig   `yaml:"manager,omitempty"`
+	Daemon             HostProxyDaemonConfig    `yaml:"daemon,omitempty"`
+	ForwardCapKiBps    int                      `yaml:"forward_cap_kibps,omitempty" label:"Forwarded Traffic Cap (KiB/s)" desc:"Throttle a container's forwarded SSH/GPG/callback traffic above this rate (0 = unlimited)" default:"0"`
+	GitCredentialHosts []string                 `yaml:"git_credential_hosts,omitempty" label:"Git Credential Hosts" desc:"Hosts containers may fetch git HTTPS credentials for, e.g. github.com or *.example.com (empty = each container's project git remote hosts)"`
+	Clipboard          HostProxyClipboardConfig `yaml:"clipboard,omitempty"`
+}
+
+// ForwardCapBytesPerSec returns the per-container forwarded-traffic cap in
+// bytes per second (0 = unlimited; negative values are treated as 0).
+func (c HostProxyConfig) ForwardCapBytesPerSec() int64 {
+	return int64(max(c.ForwardCapKiBps, 0)) * 1024
+}
+
+// HostProxyClipboardConfig configures the clipboard bridge between containers
+// and the host clipboard (clawker-clip, pbcopy, xclip inside the container).
+type HostProxyClipboardConfig struct {
+	Enabled    bool `yaml:"enabled,omitempty"     label:"Clipboard Copy"           desc:"Let containers copy text to the host clipboard (clawker-clip, pbcopy, xclip)"              default:"false"`
+	AllowPaste bool `yaml:"allow_paste,omitempty" label:"Clipboard Paste"          desc:"Also let containers read the host clipboard (pbpaste, xclip -o); needs enabled"             default:"false"`
+	MaxKiB     int  `yaml:"max_kib,omitempty"     label:"Clipboard Size Cap (KiB)" desc:"Largest text copied to or pasted from the host clipboard (0 or less = the 1024 KiB default)" default:"1024"`
+}
+
+// MaxBytes returns the clipboard size cap in bytes, falling back to the
+// 1 MiB default when MaxKiB is not positive.
+func (c HostProxyClipboardConfig) MaxBytes() int64 {
+	if c.MaxKiB <= 0 {
+		return 1 << 20
+	}
+	return int64(c.MaxKiB) * 1024
 }
 
 // HostProxyManagerConfig configures the host proxy manager.
@@ -506,7 +847,7 @@ type LoggingConfig struct {
 
 // OtelConfig configures the OTEL zerolog bridge.
 type OtelConfig struct {
-	Enabled               *bool `yaml:"enabled,omitempty"                 label:"OTEL Logging"               desc:"Send logs to the OTEL collector for OpenSearch visibility (requires monitoring stack running)" default:"false"`
+	Enabled               *bool `yaml:"enabled,omitempty"                 label:"OTEL Logging"               desc:"Send logs and command traces (one span per Docker API call) to the OTEL collector for OpenSearch visibility (requires monitoring stack running)" default:"false"`
 	TimeoutSeconds        int   `yaml:"timeout_seconds,omitempty"         label:"OTEL Timeout (sec)"         desc:"Give up on an export batch after this long"                                                    default:"5"`
 	MaxQueueSize          int   `yaml:"max_queue_size,omitempty"          label:"OTEL Queue Size"            desc:"Buffer this many log records before dropping (increase if you see gaps)"                       default:"2048"`
 	ExportIntervalSeconds int   `yaml:"export_interval_seconds,omitempty" label:"OTEL Export Interval (sec)" desc:"How often to flush buffered logs to the collector"                                             default:"5"`
@@ -0,0 +1,32 @@
+package config
+
+// SecretsKey is the project key holding secret declarations.
+const SecretsKey = "secrets"
+
+// Secret providers (secrets.<name>.provider).
+const (
+	SecretProviderEnv  = "env"
+	SecretProviderFile = "file"
+	SecretProviderOp   = "op"
+	SecretProviderPass = "pass"
+	SecretProviderExec = "exec"
+)
+
+// SecretProviders returns the built-in secret providers in documentation
+// order.
+func SecretProviders() []string {
+	return []string{SecretProviderEnv, SecretProviderFile, SecretProviderOp, SecretProviderPass, SecretProviderExec}
+}
+
+// FileName returns the file name the secret is written to under
+// consts.SecretsDir: the configured file, or the secret name when neither
+// env nor file is set. "" means the secret is injected as an env var only.
+func (s SecretConfig) FileName(name string) string {
+	if s.File != "" {
+		return s.File
+	}
+	if s.Env == "" {
+		return name
+	}
+	return ""
+}
@@ -0,0 +1,20 @@
+package config
+
+// ServicesKey is the project key holding in-container service declarations.
+const ServicesKey = "services"
+
+// Service restart policies (services.<name>.restart).
+const (
+	ServiceRestartAlways    = "always"
+	ServiceRestartOnFailure = "on-failure"
+	ServiceRestartNo        = "no"
+)
+
+// RestartPolicy returns the service's restart policy, defaulting to
+// ServiceRestartAlways when unset.
+func (s ServiceConfig) RestartPolicy() string {
+	if s.Restart == "" {
+		return ServiceRestartAlways
+	}
+	return s.Restart
+}
@@ -0,0 +1,4 @@
+package config
+
+// SidecarsKey is the project key holding sidecar container declarations.
+const SidecarsKey = "sidecars"
@@ -4,11 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/storage"
+	"gopkg.in/yaml.v3"
 )
 
 // Known YAML field sets for the harnesses: node, the per-harness build
@@ -27,7 +29,7 @@ const fieldPath = "path"
 
 func knownHarnessConfigFields() map[string]bool {
 	return map[string]bool{
-		"config": true, "mount_projects": true, "env_file": true,
+		"config": true, "mount_projects": true, "persist_sessions": true, "env_file": true,
 		"from_env": true, "env": true, "post_init": true, "pre_run": true,
 	}
 }
@@ -42,18 +44,48 @@ func knownHarnessOverlayInjectFields() map[string]bool {
 
 func knownHarnessConfigOptionsFields() map[string]bool { return map[string]bool{"strategy": true} }
 
+func knownAgentOverrideFields() map[string]bool {
+	return map[string]bool{"agent": true, "workspace": true, "security": true}
+}
+
+func knownProfileFields() map[string]bool {
+	return map[string]bool{"build": true, "agent": true, "workspace": true, "security": true, "services": true}
+}
+
+func knownServiceFields() map[string]bool {
+	return map[string]bool{"command": true, "env": true, "workdir": true, "restart": true}
+}
+
+func knownSidecarFields() map[string]bool {
+	return map[string]bool{"image": true, "env": true, "ports": true, "healthcheck": true}
+}
+
+func knownSidecarHealthcheckFields() map[string]bool {
+	return map[string]bool{"command": true, "interval": true, "timeout": true, "retries": true, "start_period": true}
+}
+
+func knownSecretFields() map[string]bool {
+	return map[string]bool{"provider": true, "ref": true, "env": true, "file": true}
+}
+
 func knownBundleSourceFields() map[string]bool {
 	return map[string]bool{"url": true, "ref": true, "sha": true, fieldPath: true, "auto_update": true}
 }
 
 // validateProjectNodes walks every discovered clawker.yaml layer —
 // never the merged tree, so an error names the actual offending file — and
-// validates the harnesses:, build:, and bundles: nodes: every harness and
+// validates the harnesses:, build:, bundles:, agents:, services:, secrets:,
+// sidecars:, and profiles: nodes: every harness and
 // overlay name — including the build.harness selection key — must satisfy
 // the shared reference rule (consts.ValidateHarnessRef — bare or qualified,
 // reserved aliases bare-only), every stack-name reference (build.stacks,
 // build.harnesses.<name>.stacks) must satisfy consts.ValidateComponentRef,
-// and every entry's fields must be a known subset.
+// every agents: key must be a single-segment agent name, every services: entry
+// must name a command and a known restart policy, every secrets: entry must
+// name a known provider and a usable env var / file name, every sidecars:
+// entry must carry a non-empty image when set, every profiles: entry must
+// pass the same build: and services: checks, and every entry's fields must be
+// a known subset.
 func validateProjectNodes(store *storage.Store[Project]) error {
 	for _, layer := range store.Layers() {
 		label := layerLabel(layer)
@@ -66,10 +98,74 @@ func validateProjectNodes(store *storage.Store[Project]) error {
 		if err := validateBundlesNode(layer); err != nil {
 			return err
 		}
+		if err := validateAgentsNode(label, layer.Data); err != nil {
+			return err
+		}
+		if err := validateServicesNode(label, layer.Data); err != nil {
+			return err
+		}
+		if err := validateSecretsNode(label, layer.Data); err != nil {
+			return err
+		}
+		if err := validateSidecarsNode(label, layer.Data); err != nil {
+			return err
+		}
+		if err := validateProfilesNode(label, layer.Data); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// ValidateProjectSet is the write front door for a single-key project
+// mutation (clawker config set): it grafts value at the dotted path into an
+// otherwise empty document and runs the same harnesses:/build:/bundles: node
+// checks a load would. ProjectStore().Set validates only the typed decode,
+// which silently drops unknown keys — without this, a CLI write could persist
+// a file the very next load rejects.
+func ValidateProjectSet(path string, value any) error {
+	raw, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", path, err)
+	}
+	var decoded any
+	if err := yaml.Unmarshal(raw, &decoded); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+	data := map[string]any{}
+	node := data
+	segs := strings.Split(path, ".")
+	for _, seg := range segs[:len(segs)-1] {
+		child := map[string]any{}
+		node[seg] = child
+		node = child
+	}
+	node[segs[len(segs)-1]] = decoded
+	layer := storage.LayerInfo{Filename: "value", Data: data}
+	if err := validateHarnessesNode(layerLabel(layer), data); err != nil {
+		return err
+	}
+	if err := validateBuildNode(layerLabel(layer), data); err != nil {
+		return err
+	}
+	if err := validateBundlesNode(layer); err != nil {
+		return err
+	}
+	if err := validateAgentsNode(layerLabel(layer), data); err != nil {
+		return err
+	}
+	if err := validateServicesNode(layerLabel(layer), data); err != nil {
+		return err
+	}
+	if err := validateSecretsNode(layerLabel(layer), data); err != nil {
+		return err
+	}
+	if err := validateSidecarsNode(layerLabel(layer), data); err != nil {
+		return err
+	}
+	return validateProfilesNode(layerLabel(layer), data)
+}
+
 // layerLabel names a layer for error messages: its filename, or a
 // placeholder for the virtual defaults/seed layer that every storage.Store
 // carries (it has no backing file, so no filename); real file layers always
@@ -102,6 +198,244 @@ func validateHarnessesNode(label string, data map[string]any) error {
 		})
 }
 
+// validateAgentsNode checks the agents: override map: each key must be an
+// agent name addressable as a single dotted-path segment, and each entry may
+// only carry the blocks an override can fold over the base config.
+func validateAgentsNode(label string, data map[string]any) error {
+	raw, ok := data[AgentsKey]
+	if !ok {
+		return nil
+	}
+	m, isMap := nodeMapping(raw)
+	if !isMap {
+		return fmt.Errorf("%s: %s: must be a mapping of agent name to overrides", label, AgentsKey)
+	}
+	return validateEntryMap(label, AgentsKey, m, validateAgentOverrideName,
+		"must be a mapping", knownAgentOverrideFields(),
+		func(string, map[string]any) error { return nil })
+}
+
+func validateAgentOverrideName(name string) error {
+	if !agentOverrideNameRe.MatchString(name) {
+		return fmt.Errorf("invalid agent name %q: only [a-zA-Z0-9][a-zA-Z0-9_-] are allowed", name)
+	}
+	return nil
+}
+
+// validateProfilesNode checks the profiles: map: each key must be a profile
+// name addressable as a single dotted-path segment (--profile and config
+// get/set address it that way), each entry may only carry the blocks a
+// profile can fold over the base config, and its build: and services:
+// blocks get the same checks as the top-level ones.
+func validateProfilesNode(label string, data map[string]any) error {
+	raw, ok := data[ProfilesKey]
+	if !ok {
+		return nil
+	}
+	m, isMap := nodeMapping(raw)
+	if !isMap {
+		return fmt.Errorf("%s: %s: must be a mapping of profile name to config", label, ProfilesKey)
+	}
+	return validateEntryMap(label, ProfilesKey, m, validateProfileName,
+		"must be a mapping", knownProfileFields(),
+		func(keyPath string, entry map[string]any) error {
+			if err := validateBuildNode(label+": "+keyPath, entry); err != nil {
+				return err
+			}
+			return validateServicesNode(label+": "+keyPath, entry)
+		})
+}
+
+func validateProfileName(name string) error {
+	if !agentOverrideNameRe.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: only [a-zA-Z0-9][a-zA-Z0-9_-] are allowed", name)
+	}
+	return nil
+}
+
+// validateServicesNode checks the services: map. Service names become file
+// and unit names in the image, so they share the agent-name charset. Each
+// command that is set must be non-empty and restart must be a known policy.
+// Layers merge per field, so a layer may omit command when a lower one
+// supplies it; the bundler rejects an entry whose merged command is empty.
+func validateServicesNode(label string, data map[string]any) error {
+	raw, ok := data[ServicesKey]
+	if !ok {
+		return nil
+	}
+	m, isMap := nodeMapping(raw)
+	if !isMap {
+		return fmt.Errorf("%s: %s: must be a mapping of service name to config", label, ServicesKey)
+	}
+	return validateEntryMap(label, ServicesKey, m, validateServiceName,
+		"must be a mapping", knownServiceFields(),
+		func(keyPath string, entry map[string]any) error {
+			if rawCmd, hasCmd := entry["command"]; hasCmd {
+				cmd, isString := rawCmd.(string)
+				if !isString || strings.TrimSpace(cmd) == "" {
+					return fmt.Errorf("%s: %s.command: must be a non-empty string", label, keyPath)
+				}
+			}
+			if rawEnv, hasEnv := entry["env"]; hasEnv {
+				env, isMap := nodeMapping(rawEnv)
+				if !isMap {
+					return fmt.Errorf("%s: %s.env: must be a mapping of variable name to value", label, keyPath)
+				}
+				for _, name := range sortedKeys(env) {
+					if !serviceEnvNameRe.MatchString(name) {
+						return fmt.Errorf("%s: %s.env.%s: invalid environment variable name", label, keyPath, name)
+					}
+				}
+			}
+			restart, _, err := optionalStringField(label, keyPath, "restart", entry)
+			if err != nil {
+				return err
+			}
+			switch restart {
+			case "", ServiceRestartAlways, ServiceRestartOnFailure, ServiceRestartNo:
+				return nil
+			default:
+				return fmt.Errorf(
+					"%s: %s.restart: unknown policy %q (want %s, %s, or %s)",
+					label, keyPath, restart, ServiceRestartAlways, ServiceRestartOnFailure, ServiceRestartNo,
+				)
+			}
+		})
+}
+
+// serviceEnvNameRe is a POSIX shell variable name: service env is exported
+// from a generated launch script.
+var serviceEnvNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func validateServiceName(name string) error {
+	if !agentOverrideNameRe.MatchString(name) {
+		return fmt.Errorf("invalid service name %q: only [a-zA-Z0-9][a-zA-Z0-9_-] are allowed", name)
+	}
+	return nil
+}
+
+// validateSidecarsNode checks the sidecars: map. A sidecar name becomes the
+// hostname the agent reaches it at and part of its container name, so it
+// shares the agent-name charset. An image that is set must be non-empty;
+// layers merge per field, so container run rejects a merged entry with no
+// image. Port specs are parsed when the sidecar is created.
+func validateSidecarsNode(label string, data map[string]any) error {
+	raw, ok := data[SidecarsKey]
+	if !ok {
+		return nil
+	}
+	m, isMap := nodeMapping(raw)
+	if !isMap {
+		return fmt.Errorf("%s: %s: must be a mapping of sidecar name to config", label, SidecarsKey)
+	}
+	return validateEntryMap(label, SidecarsKey, m, validateSidecarName,
+		"must be a mapping", knownSidecarFields(),
+		func(keyPath string, entry map[string]any) error {
+			image, set, err := optionalStringField(label, keyPath, "image", entry)
+			if err != nil {
+				return err
+			}
+			if set && strings.TrimSpace(image) == "" {
+				return fmt.Errorf("%s: %s.image: must be a non-empty string", label, keyPath)
+			}
+			if rawEnv, hasEnv := entry["env"]; hasEnv {
+				env, isMap := nodeMapping(rawEnv)
+				if !isMap {
+					return fmt.Errorf("%s: %s.env: must be a mapping of variable name to value", label, keyPath)
+				}
+				for _, name := range sortedKeys(env) {
+					if !serviceEnvNameRe.MatchString(name) {
+						return fmt.Errorf("%s: %s.env.%s: invalid environment variable name", label, keyPath, name)
+					}
+				}
+			}
+			rawHC, hasHC := entry["healthcheck"]
+			if !hasHC {
+				return nil
+			}
+			hc, isMap := nodeMapping(rawHC)
+			if !isMap {
+				return fmt.Errorf("%s: %s.healthcheck: must be a mapping", label, keyPath)
+			}
+			if err := validateKnownFields(label, keyPath+".healthcheck", hc, knownSidecarHealthcheckFields()); err != nil {
+				return err
+			}
+			cmd, set, err := optionalStringField(label, keyPath+".healthcheck", "command", hc)
+			if err != nil {
+				return err
+			}
+			if set && strings.TrimSpace(cmd) == "" {
+				return fmt.Errorf("%s: %s.healthcheck.command: must be a non-empty string", label, keyPath)
+			}
+			return nil
+		})
+}
+
+func validateSidecarName(name string) error {
+	if !agentOverrideNameRe.MatchString(name) {
+		return fmt.Errorf("invalid sidecar name %q: only [a-zA-Z0-9][a-zA-Z0-9_-] are allowed", name)
+	}
+	return nil
+}
+
+// validateSecretsNode checks the secrets: map. Secret names share the
+// agent-name charset; provider must be a built-in one, env a POSIX variable
+// name, and file a plain file name (it is joined under consts.SecretsDir, so
+// a path separator or dot-dot would escape the tmpfs). Layers merge per
+// field, so a layer may omit provider or ref when a lower one supplies
+// them; internal/secrets rejects a merged entry missing either.
+func validateSecretsNode(label string, data map[string]any) error {
+	raw, ok := data[SecretsKey]
+	if !ok {
+		return nil
+	}
+	m, isMap := nodeMapping(raw)
+	if !isMap {
+		return fmt.Errorf("%s: %s: must be a mapping of secret name to config", label, SecretsKey)
+	}
+	return validateEntryMap(label, SecretsKey, m, validateSecretName,
+		"must be a mapping", knownSecretFields(),
+		func(keyPath string, entry map[string]any) error {
+			provider, _, err := optionalStringField(label, keyPath, "provider", entry)
+			if err != nil {
+				return err
+			}
+			if provider != "" && !slices.Contains(SecretProviders(), provider) {
+				return fmt.Errorf("%s: %s.provider: unknown provider %q (want one of %s)",
+					label, keyPath, provider, strings.Join(SecretProviders(), ", "))
+			}
+			if _, _, err := optionalStringField(label, keyPath, "ref", entry); err != nil {
+				return err
+			}
+			env, _, err := optionalStringField(label, keyPath, "env", entry)
+			if err != nil {
+				return err
+			}
+			if env != "" && !serviceEnvNameRe.MatchString(env) {
+				return fmt.Errorf("%s: %s.env: invalid environment variable name %q", label, keyPath, env)
+			}
+			file, _, err := optionalStringField(label, keyPath, "file", entry)
+			if err != nil {
+				return err
+			}
+			if file != "" && !secretFileNameRe.MatchString(file) {
+				return fmt.Errorf("%s: %s.file: %q must be a plain file name (no path separators or leading dot)",
+					label, keyPath, file)
+			}
+			return nil
+		})
+}
+
+// secretFileNameRe is a plain file name under consts.SecretsDir.
+var secretFileNameRe = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*$`)
+
+func validateSecretName(name string) error {
+	if !agentOverrideNameRe.MatchString(name) {
+		return fmt.Errorf("invalid secret name %q: only [a-zA-Z0-9][a-zA-Z0-9_-] are allowed", name)
+	}
+	return nil
+}
+
 func validateBuildNode(label string, data map[string]any) error {
 	raw, ok := data["build"]
 	if !ok {
@@ -119,6 +453,15 @@ func validateBuildNode(label string, data map[string]any) error {
 			return err
 		}
 	}
+	if injectRaw, hasInject := build["inject"]; hasInject && injectRaw != nil {
+		inject, isMap := nodeMapping(injectRaw)
+		if !isMap {
+			return fmt.Errorf("%s: build.inject: must be a mapping", label)
+		}
+		if err := validateInjectInstructions(label, "build.inject", inject); err != nil {
+			return err
+		}
+	}
 	harnessesRaw, hasHarnesses := build["harnesses"]
 	if !hasHarnesses {
 		return nil
@@ -148,6 +491,86 @@ func validateBuildHarness(label string, build map[string]any) error {
 	return nil
 }
 
+// validateInjectInstructions checks the Dockerfile fragments of an inject
+// block (build.inject or an overlay's inject). A fragment may not take over
+// what clawker manages — FROM would open a new stage that drops everything
+// clawker built before it, ENTRYPOINT/CMD would replace clawkerd as PID 1,
+// and USER would run clawker's following steps as someone else. Only
+// before_entrypoint may switch users: the template switches back to root
+// right after it. Unknown inject points are left alone here.
+func validateInjectInstructions(label, keyPath string, inject map[string]any) error {
+	for _, point := range sortedKeys(inject) {
+		if inject[point] == nil {
+			continue
+		}
+		pointPath := keyPath + "." + point
+		list, isList := inject[point].([]any)
+		if !isList {
+			return fmt.Errorf("%s: %s: must be a list of Dockerfile instructions", label, pointPath)
+		}
+		for i, item := range list {
+			fragment, isString := item.(string)
+			if !isString {
+				return fmt.Errorf("%s: %s[%d]: must be a string", label, pointPath, i)
+			}
+			for _, instruction := range fragmentInstructions(fragment) {
+				if reason, denied := deniedInjectInstruction(point, instruction); denied {
+					return fmt.Errorf("%s: %s[%d]: %s is not allowed here — %s", label, pointPath, i, instruction, reason)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// deniedInjectInstruction reports whether instruction may not appear at the
+// inject point, and why.
+func deniedInjectInstruction(point, instruction string) (string, bool) {
+	switch instruction {
+	case "FROM":
+		return "it would start a new build stage and discard clawker's", true
+	case "ENTRYPOINT", "CMD":
+		return "clawkerd is the image's managed entrypoint", true
+	case "USER":
+		if point != "before_entrypoint" {
+			return "clawker's following steps must keep running as the user this point runs as", true
+		}
+	}
+	return "", false
+}
+
+// fragmentHeredocRe matches a heredoc opener (<<EOF, <<-EOF, <<'EOF').
+var fragmentHeredocRe = regexp.MustCompile(`<<-?["']?([A-Za-z_][A-Za-z0-9_]*)["']?`)
+
+// fragmentInstructions returns the upper-cased keyword of each instruction
+// in a Dockerfile fragment, skipping comments, continuation lines and
+// heredoc bodies.
+func fragmentInstructions(fragment string) []string {
+	var keywords []string
+	continued := false
+	heredoc := ""
+	for line := range strings.SplitSeq(fragment, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if heredoc != "" {
+			if trimmed == heredoc {
+				heredoc = ""
+			}
+			continue
+		}
+		if !continued {
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			keywords = append(keywords, strings.ToUpper(strings.Fields(trimmed)[0]))
+		}
+		if m := fragmentHeredocRe.FindStringSubmatch(trimmed); m != nil {
+			heredoc = m[1]
+		}
+		continued = strings.HasSuffix(trimmed, `\`)
+	}
+	return keywords
+}
+
 // validateOverlayEntry returns the per-entry check for one
 // build.harnesses.<name> overlay: its stacks list and its inject block.
 func validateOverlayEntry(label string) func(keyPath string, overlay map[string]any) error {
@@ -165,7 +588,10 @@ func validateOverlayEntry(label string) func(keyPath string, overlay map[string]
 		if !isMap {
 			return fmt.Errorf("%s: %s.inject: must be a mapping", label, keyPath)
 		}
-		return validateKnownFields(label, keyPath+".inject", inject, knownHarnessOverlayInjectFields())
+		if err := validateKnownFields(label, keyPath+".inject", inject, knownHarnessOverlayInjectFields()); err != nil {
+			return err
+		}
+		return validateInjectInstructions(label, keyPath+".inject", inject)
 	}
 }
 
@@ -142,6 +142,10 @@ func TestKnownFieldSets_MatchSchemaTags(t *testing.T) {
 		{"harness overlay inject", reflect.TypeFor[HarnessOverlayInject](), knownHarnessOverlayInjectFields()},
 		{"harness config options", reflect.TypeFor[HarnessConfigOptions](), knownHarnessConfigOptionsFields()},
 		{"bundle source", reflect.TypeFor[BundleSource](), knownBundleSourceFields()},
+		{"agent override", reflect.TypeFor[AgentOverride](), knownAgentOverrideFields()},
+		{"service", reflect.TypeFor[ServiceConfig](), knownServiceFields()},
+		{"secret", reflect.TypeFor[SecretConfig](), knownSecretFields()},
+		{"profile", reflect.TypeFor[ProjectProfile](), knownProfileFields()},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -215,6 +216,50 @@ build:
 	require.NoError(t, err)
 }
 
+func TestValidateProjectNodes_InjectInstructions(t *testing.T) {
+	cfg, err := config.NewFromString(`
+build:
+  inject:
+    after_from:
+      - "RUN echo 'deb http://mirror.example/debian bookworm main' > /etc/apt/sources.list.d/mirror.list"
+    after_packages:
+      - |
+        RUN <<EOF
+        set -e
+        from_here=1
+        EOF
+    before_entrypoint:
+      - "USER root"
+      - "RUN make install \\\n  CMD=ignored"
+`, "")
+	require.NoError(t, err)
+	inject := cfg.Project().Build.Inject
+	require.NotNil(t, inject)
+	assert.Len(t, inject.AfterFrom, 1)
+	assert.Len(t, inject.BeforeEntrypoint, 2)
+
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "new stage", yaml: "build:\n  inject:\n    after_packages: [\"FROM alpine AS tools\"]\n", wantErr: "build.inject.after_packages[0]: FROM"},
+		{name: "entrypoint", yaml: "build:\n  inject:\n    before_entrypoint: [\"entrypoint [\\\"sh\\\"]\"]\n", wantErr: "before_entrypoint[0]: ENTRYPOINT"},
+		{name: "cmd after comment", yaml: "build:\n  inject:\n    user_commands: [\"# run it\\nCMD sh\"]\n", wantErr: "user_commands[0]: CMD"},
+		{name: "user at root point", yaml: "build:\n  inject:\n    after_from: [\"USER clawker\"]\n", wantErr: "after_from[0]: USER"},
+		{name: "user at user point", yaml: "build:\n  inject:\n    after_user_switch: [\"USER root\"]\n", wantErr: "after_user_switch[0]: USER"},
+		{name: "overlay", yaml: "build:\n  harnesses:\n    claude:\n      inject:\n        user_commands: [\"CMD sh\"]\n", wantErr: "build.harnesses.claude.inject.user_commands[0]: CMD"},
+		{name: "profile", yaml: "profiles:\n  ci:\n    build:\n      inject:\n        after_from: [\"FROM scratch\"]\n", wantErr: "build.inject.after_from[0]: FROM"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.NewFromString(tt.yaml, "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 // TestValidateProjectNodes_NullNodesAccepted covers YAML null nodes —
 // a key written with no content (a bare "build:" line, a placeholder
 // harness entry) decodes to the zero struct and must NOT be rejected as a
@@ -231,6 +276,7 @@ func TestValidateProjectNodes_NullNodesAccepted(t *testing.T) {
 		"null overlay entry":   "build:\n  harnesses:\n    claude:\n",
 		"null overlay stacks":  "build:\n  harnesses:\n    claude:\n      stacks:\n",
 		"null overlay inject":  "build:\n  harnesses:\n    claude:\n      inject:\n",
+		"null inject point":    "build:\n  inject:\n    before_entrypoint:\n",
 	} {
 		t.Run(name, func(t *testing.T) {
 			_, err := config.NewFromString(yaml, "")
@@ -273,6 +319,94 @@ harnesses:
 	})
 }
 
+func TestProjectSchema_Services(t *testing.T) {
+	cfg, err := config.NewFromString(`
+services:
+  redis:
+    command: redis-server --save ""
+    restart: on-failure
+  worker:
+    command: ./bin/worker --port ${PORT}
+    env:
+      PORT: "8080"
+    workdir: /workspace
+`, "")
+	require.NoError(t, err)
+
+	services := cfg.Project().Services
+	require.Len(t, services, 2)
+	assert.Equal(t, config.ServiceRestartOnFailure, services["redis"].RestartPolicy())
+	assert.Equal(t, config.ServiceRestartAlways, services["worker"].RestartPolicy())
+	assert.Equal(t, "./bin/worker --port ${PORT}", services["worker"].Command,
+		"service commands are shell — ${VAR} is left for the container shell")
+	assert.Equal(t, map[string]string{"PORT": "8080"}, services["worker"].Env)
+	assert.Equal(t, "/workspace", services["worker"].Workdir)
+}
+
+func TestValidateProjectNodes_Services(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "bad name", yaml: "services:\n  my.svc:\n    command: x\n", wantErr: "services.my.svc"},
+		{name: "empty command", yaml: "services:\n  svc:\n    command: \"  \"\n", wantErr: "services.svc.command"},
+		{name: "unknown restart", yaml: "services:\n  svc:\n    command: x\n    restart: sometimes\n", wantErr: "services.svc.restart"},
+		{name: "unknown field", yaml: "services:\n  svc:\n    command: x\n    user: root\n", wantErr: "services.svc.user"},
+		{name: "bad env name", yaml: "services:\n  svc:\n    command: x\n    env:\n      BAD-NAME: v\n", wantErr: "services.svc.env.BAD-NAME"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.NewFromString(tt.yaml, "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestProjectSchema_Secrets(t *testing.T) {
+	cfg, err := config.NewFromString(`
+secrets:
+  gh_token:
+    provider: env
+    ref: GITHUB_TOKEN
+    env: GH_TOKEN
+  db_password:
+    provider: exec
+    ref: cat ${HOME}/db-password
+`, "")
+	require.NoError(t, err)
+
+	secrets := cfg.Project().Secrets
+	require.Len(t, secrets, 2)
+	assert.Empty(t, secrets["gh_token"].FileName("gh_token"), "env-only secrets get no file")
+	assert.Equal(t, "db_password", secrets["db_password"].FileName("db_password"))
+	assert.Equal(t, "cat ${HOME}/db-password", secrets["db_password"].Ref,
+		"secret refs are not interpolated — ${VAR} is left for the provider")
+}
+
+func TestValidateProjectNodes_Secrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "bad name", yaml: "secrets:\n  my.secret:\n    provider: env\n", wantErr: "secrets.my.secret"},
+		{name: "unknown provider", yaml: "secrets:\n  s:\n    provider: vault\n    ref: x\n", wantErr: "secrets.s.provider"},
+		{name: "unknown field", yaml: "secrets:\n  s:\n    provider: env\n    mode: \"0444\"\n", wantErr: "secrets.s.mode"},
+		{name: "bad env name", yaml: "secrets:\n  s:\n    provider: env\n    env: BAD-NAME\n", wantErr: "secrets.s.env"},
+		{name: "hidden file", yaml: "secrets:\n  s:\n    provider: env\n    file: .npmrc\n", wantErr: "secrets.s.file"},
+		{name: "path in file", yaml: "secrets:\n  s:\n    provider: env\n    file: ../etc/passwd\n", wantErr: "secrets.s.file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.NewFromString(tt.yaml, "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 func TestNewProjectStoreFromPreset_ValidatesNodes(t *testing.T) {
 	_, err := config.NewProjectStoreFromPreset(`
 harnesses:
@@ -282,3 +416,87 @@ harnesses:
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "harnesses.Bad_Name")
 }
+
+// The config-set front door applies the load-time node checks to a single
+// grafted value, so a CLI write can't persist a file the next load rejects.
+func TestValidateProjectSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		value   any
+		wantErr string
+	}{
+		{name: "unrelated key passes", path: "build.packages", value: []string{"git"}},
+		{name: "valid harness entry passes", path: "harnesses.claude.env", value: map[string]string{"A": "b"}},
+		{name: "bad harness name", path: "harnesses.Claude_Code.env", value: map[string]string{"A": "b"}, wantErr: "harnesses.Claude_Code"},
+		{name: "unknown harness field", path: "harnesses.claude.bogus", value: "x", wantErr: "bogus"},
+		{name: "bad build.harness", path: "build.harness", value: "Not Valid", wantErr: "build.harness"},
+		{name: "bad stack name", path: "build.stacks", value: []string{"Bad_Stack"}, wantErr: "build.stacks"},
+		{name: "valid service passes", path: "services.redis.command", value: "redis-server"},
+		{name: "bad service restart", path: "services.redis.restart", value: "sometimes", wantErr: "services.redis.restart"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := config.ValidateProjectSet(tt.path, tt.value)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestProjectSchema_Sidecars(t *testing.T) {
+	cfg, err := config.NewFromString(`
+sidecars:
+  db:
+    image: postgres:16
+    env:
+      POSTGRES_PASSWORD: dev
+    ports:
+      - "127.0.0.1:5432:5432"
+    healthcheck:
+      command: pg_isready -U postgres
+      interval: 2s
+      retries: 10
+  cache:
+    image: redis:7
+`, "")
+	require.NoError(t, err)
+
+	sidecars := cfg.Project().Sidecars
+	require.Len(t, sidecars, 2)
+	db := sidecars["db"]
+	assert.Equal(t, "postgres:16", db.Image)
+	assert.Equal(t, map[string]string{"POSTGRES_PASSWORD": "dev"}, db.Env)
+	assert.Equal(t, []string{"127.0.0.1:5432:5432"}, db.Ports)
+	require.NotNil(t, db.Healthcheck)
+	assert.Equal(t, "pg_isready -U postgres", db.Healthcheck.Command)
+	assert.Equal(t, 2*time.Second, db.Healthcheck.Interval)
+	assert.Equal(t, 10, db.Healthcheck.Retries)
+	assert.Nil(t, sidecars["cache"].Healthcheck)
+}
+
+func TestValidateProjectNodes_Sidecars(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "bad name", yaml: "sidecars:\n  my.db:\n    image: postgres\n", wantErr: "sidecars.my.db"},
+		{name: "empty image", yaml: "sidecars:\n  db:\n    image: \" \"\n", wantErr: "sidecars.db.image"},
+		{name: "unknown field", yaml: "sidecars:\n  db:\n    image: postgres\n    volumes: [data]\n", wantErr: "sidecars.db.volumes"},
+		{name: "bad env name", yaml: "sidecars:\n  db:\n    image: postgres\n    env:\n      BAD-NAME: v\n", wantErr: "sidecars.db.env.BAD-NAME"},
+		{name: "unknown healthcheck field", yaml: "sidecars:\n  db:\n    image: postgres\n    healthcheck:\n      test: [CMD, true]\n", wantErr: "sidecars.db.healthcheck.test"},
+		{name: "empty healthcheck command", yaml: "sidecars:\n  db:\n    image: postgres\n    healthcheck:\n      command: \"\"\n", wantErr: "sidecars.db.healthcheck.command"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := config.NewFromString(tt.yaml, "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -57,9 +57,33 @@ const (
 // Docker/OCI label keys.
 const (
 	LabelManaged = LabelPrefix + "managed"
+	// LabelSchema records the label schema version a resource was labeled
+	// under; see LabelSchemaVersion.
+	LabelSchema  = LabelPrefix + EngineLabelSchemaLabel
 	LabelProject = LabelPrefix + "project"
 	LabelAgent   = LabelPrefix + "agent"
 	LabelHarness = LabelPrefix + "harness"
+	// LabelSidecar names the sidecars: entry a sidecar container runs, and
+	// LabelSidecarAgent the agent it serves. Sidecars deliberately carry no
+	// LabelAgent, so agent lookups never match them.
+	LabelSidecar      = LabelPrefix + "sidecar"
+	LabelSidecarAgent = LabelPrefix + "sidecar.agent"
+	// LabelKeepAlive exempts an agent container from the idle reaper; set
+	// to "true" by `container run --keep-alive`.
+	LabelKeepAlive = LabelPrefix + "keep_alive"
+	// LabelOpenURLSchemes and LabelOpenURLHosts carry the project's
+	// security.open_url allowlist (comma-separated), stamped at create and
+	// read back by the host proxy when the container asks to open a URL.
+	LabelOpenURLSchemes = LabelPrefix + "open_url.schemes"
+	LabelOpenURLHosts   = LabelPrefix + "open_url.hosts"
+	// LabelGitCredentialHosts carries the hosts of the project's git remotes
+	// (comma-separated), stamped at create; while
+	// host_proxy.git_credential_hosts is empty the host proxy fetches git
+	// credentials for the container only for these hosts.
+	LabelGitCredentialHosts = LabelPrefix + "git_credential.hosts"
+	// LabelHostProxyToken is the digest of the token the container presents
+	// to the host proxy (EnvHostProxyToken), which identifies it there.
+	LabelHostProxyToken = LabelPrefix + "host_proxy.token"
 )
 
 // Infrastructure volume-name purpose suffixes. Volume names compose as
@@ -95,6 +119,10 @@ const (
 	LabelTestName = LabelPrefix + "test.name"
 	LabelTest     = LabelPrefix + "test"
 	LabelE2ETest  = LabelPrefix + "e2e-test"
+	// LabelTestRun namespaces test resources by `go test` run so
+	// concurrent runs (parallel CI jobs, two terminals) never reap each
+	// other's resources. See test/reaper.
+	LabelTestRun = LabelPrefix + "test.run"
 	// LabelCPBinarySHA stamps the SHA-256 of the embedded clawkercp +
 	// ebpf-manager bytes onto the built CP image and running container.
 	// EnsureRunning compares the running container's label against the
@@ -106,6 +134,16 @@ const (
 	// hash to decide whether the base must be rebuilt before a harness
 	// image build. Also stamped on harness images for provenance.
 	LabelBaseContentHash = LabelPrefix + "base.content_sha256"
+	// LabelContentHash stamps the SHA-256 of a harness image's inputs
+	// (rendered harness Dockerfile, base image ID, build context files,
+	// build args, target, labels) onto the harness image. A build whose
+	// freshly computed hash matches the existing image's label is skipped.
+	LabelContentHash = LabelPrefix + "content_sha256"
+	// LabelBaseImage records the substrate image ("repo:tag@digest") the
+	// per-project base image was built FROM; harness images inherit it.
+	// whail's ImageBaseDrift reads it (EngineBaseImageLabel) to compare
+	// against the registry's current digest for the tag.
+	LabelBaseImage = LabelPrefix + EngineBaseImageLabel
 )
 
 // OCI standard label keys (not under LabelPrefix — defined by the
@@ -130,14 +168,27 @@ const (
 	PurposeMonitoring   = "monitoring"
 	PurposeFirewall     = "firewall"
 	PurposeControlPlane = "controlplane"
+	PurposeSidecar      = "sidecar"
+	// PurposeProjectNetwork marks a per-project network (clawker-<project>).
+	PurposeProjectNetwork = "project-network"
 )
 
 // Whail engine label configuration (without trailing dot — whail adds its own).
 const (
-	EngineLabelPrefix  = LabelDomain
-	EngineManagedLabel = "managed"
+	EngineLabelPrefix      = LabelDomain
+	EngineManagedLabel     = "managed"
+	EngineBaseImageLabel   = "base.image"
+	EngineLabelSchemaLabel = "label_schema"
 )
 
+// LabelSchemaVersion is the current label schema version, stamped on every
+// managed resource. Bump it whenever label keys are renamed or removed, and
+// register the step from the previous version in the docker package's
+// labelMigrations so `clawker admin migrate-labels` can upgrade existing
+// resources. Version 1 is the layout clawker used before labels were
+// versioned; a resource without the schema label counts as version 1.
+const LabelSchemaVersion = 1
+
 // Environment variable names for directory overrides.
 const (
 	EnvConfigDir   = "CLAWKER_CONFIG_DIR"
@@ -261,6 +312,9 @@ const (
 	EnvNoNotifier = "CLAWKER_NO_NOTIFIER"
 	// EnvPager overrides the pager program for paged output.
 	EnvPager = "CLAWKER_PAGER"
+	// EnvStrictInterpolation, when true, makes an undefined ${VAR} without a
+	// default in the project config a load error instead of an empty string.
+	EnvStrictInterpolation = "CLAWKER_STRICT_INTERPOLATION"
 )
 
 // File names (not paths — paths are runtime-resolved via accessor funcs below).
@@ -311,6 +365,10 @@ const (
 	buildDir           = "build"
 	bundlesDir         = "bundles"
 	worktreesDir       = "worktrees"
+	exportsDir         = "exports"
+	loopsDir           = "loops"
+	scansDir           = "scans"
+	sessionsDir        = "sessions"
 	logsDir            = "logs"
 	pidsDir            = "pids"
 	shareDir           = ".clawker-share"
@@ -427,10 +485,27 @@ const (
 	// SocketBridgeLogFile; each rotation clobbers the previous backup.
 	SocketBridgeLogBackupFile = "socketbridge.log.1"
 	BridgePIDSuffix           = ".pid"
+	BridgeSpecSuffix          = ".bridge.json"
 	ReadyFile                 = "ready"
 	GRPCSocketFile            = "grpc.sock"
 	OIDCSocketFile            = "oidc.sock"
 	AuditLogFile              = "audit.log"
+	// ControlPlaneAuditLogFile is the control plane's half of the audit
+	// journal, beside AuditLogFile. The CP appends to it from inside its
+	// container, so the CLI and the CP never append to the same file.
+	ControlPlaneAuditLogFile = "controlplane-audit.log"
+	// AttachSocketPrefix/AttachSocketSuffix frame the per-container attach
+	// hub socket an interactive session serves to --observe clients.
+	AttachSocketPrefix = "attach-"
+	AttachSocketSuffix = ".sock"
+	// GatewaySocketFile is the read-only gateway API socket; GatewayTokenFile
+	// holds the bearer token its optional localhost TCP listener requires.
+	GatewaySocketFile = "gateway.sock"
+	GatewayTokenFile  = "gateway.token"
+	// HostProxyReportKeyFile, in the bridges PID directory, holds the key
+	// socket bridge daemons sign their traffic reports to the host proxy
+	// with. No agent container mounts that directory.
+	HostProxyReportKeyFile = "hostproxy-report.key"
 )
 
 // Network.
@@ -445,6 +520,9 @@ const (
 	// port bindings and intra-container localhost dials.
 	Localhost          = "127.0.0.1"
 	DockerHostInternal = "host.docker.internal"
+	// SidecarDomain is the DNS suffix a sidecar is aliased under on its
+	// network: sidecar db answers as db.clawker.internal.
+	SidecarDomain = "clawker.internal"
 )
 
 // Container names.
@@ -559,6 +637,72 @@ const (
 	HostProxyReadyPollInterval = 1 * time.Second
 )
 
+// Host-proxy /readyz gate. Container start waits for the host proxy's
+// dependency checks (Docker, callback store) before starting a container that
+// forwards credentials, so a broken dependency fails the start instead of the
+// session. The gpg-agent is probed once, within HostProxyReadyzCheckTimeout,
+// and only warned about.
+const (
+	// HostProxyReadyzCheckTimeout bounds a single /readyz dependency check.
+	HostProxyReadyzCheckTimeout = 2 * time.Second
+	// HostProxyReadyzTimeout bounds the CLI's wait for /readyz to pass.
+	HostProxyReadyzTimeout = 10 * time.Second
+	// HostProxyReadyzPollInterval is the CLI's /readyz poll cadence.
+	HostProxyReadyzPollInterval = 250 * time.Millisecond
+)
+
+// Host-proxy forwarded-traffic accounting. Socket bridge daemons meter their
+// own streams (and enforce the bandwidth cap locally), then flush the deltas
+// to the host proxy, which aggregates them for /metrics and
+// `clawker host-proxy stats`.
+const (
+	// HostProxyTrafficReportInterval is how often a bridge daemon flushes
+	// its accounting deltas to the host proxy.
+	HostProxyTrafficReportInterval = 10 * time.Second
+	// HostProxyTrafficReportTimeout bounds a single flush (including the
+	// final flush at bridge shutdown).
+	HostProxyTrafficReportTimeout = 2 * time.Second
+)
+
+// Host-proxy socket bridge supervision. The host-proxy daemon restarts
+// bridge daemons that exit while their container still runs, backing off
+// exponentially between attempts.
+const (
+	// HostProxyBridgeCheckInterval is how often the daemon checks its
+	// supervised bridges.
+	HostProxyBridgeCheckInterval = 5 * time.Second
+	// HostProxyBridgeBackoffMin is the wait before the first restart of a
+	// bridge; it doubles with each consecutive restart.
+	HostProxyBridgeBackoffMin = 1 * time.Second
+	// HostProxyBridgeBackoffMax caps the restart backoff. A bridge that
+	// stays up this long has its backoff reset.
+	HostProxyBridgeBackoffMax = 1 * time.Minute
+	// HostProxyBridgeStatusTimeout bounds the /bridges query made by
+	// `clawker monitor status`; an unreachable host proxy just omits the
+	// bridges section.
+	HostProxyBridgeStatusTimeout = 2 * time.Second
+)
+
+// Host-proxy idle reaper. While settings idle_timeout is set, the daemon
+// stops agent containers that have shown no TTY or exec activity for that
+// long, warning each agent's console first.
+const (
+	// HostProxyIdleCheckInterval is how often the reaper reads agent
+	// activity from the control plane.
+	HostProxyIdleCheckInterval = 1 * time.Minute
+	// HostProxyIdleWarningLead is how long before the stop the agent is
+	// warned, capped at half the idle timeout.
+	HostProxyIdleWarningLead = 5 * time.Minute
+	// HostProxyIdleCallTimeout bounds one control-plane call (the activity
+	// listing or a warning) and one container stop.
+	HostProxyIdleCallTimeout = 30 * time.Second
+)
+
+// NotifySendTimeout bounds one desktop notification (osascript, notify-send
+// or the Windows toast script), so a wedged notification daemon never holds
+// up the command that raised it.
+const NotifySendTimeout = 5 * time.Second
+
 // Control plane port defaults. These are flag defaults for the CP binary
 // and test constants. Production callers should read from
 // cfg.Settings().ControlPlane.<field> which gets defaults from struct tags
@@ -612,6 +756,39 @@ const (
 	ClawkerdKeepaliveMinClientPing = 10 * time.Second
 )
 
+// clawkerd status reporting (AgentService.ReportStatus).
+const (
+	// ClawkerdStatusInterval is how often clawkerd samples the container
+	// and reports to CP. It is also the CPU-usage averaging window.
+	ClawkerdStatusInterval = 15 * time.Second
+	// ClawkerdStatusTimeout bounds one ReportStatus call; a missed report
+	// is simply retried on the next tick.
+	ClawkerdStatusTimeout = 5 * time.Second
+	// CPAgentStatusRetention is how long CP keeps an agent's last report
+	// without a newer one before dropping it — long enough to show a
+	// stalled agent's last sample, short enough that removed containers
+	// don't accumulate.
+	CPAgentStatusRetention = 10 * time.Minute
+	// MonitorAgentStatusTimeout bounds the ListAgents call made by
+	// `clawker monitor status`; an unreachable control plane just omits
+	// the agents section.
+	MonitorAgentStatusTimeout = 5 * time.Second
+)
+
+// `clawker doctor` diagnostics. Each check runs under its own timeout so one
+// hung dependency (an unresponsive Docker socket, a wedged gpg-agent) fails
+// that check instead of stalling the whole report.
+const (
+	// DoctorCheckTimeout bounds a single doctor check.
+	DoctorCheckTimeout = 10 * time.Second
+	// DoctorHostProxyTimeout bounds the doctor's wait for the host proxy's
+	// /readyz to pass — short, since a running proxy answers immediately.
+	DoctorHostProxyTimeout = 3 * time.Second
+	// DoctorPortProbeTimeout bounds the TCP dial probing whether a
+	// monitoring port is listening.
+	DoctorPortProbeTimeout = 500 * time.Millisecond
+)
+
 // Container user identity.
 const (
 	ContainerUser = "clawker"
@@ -691,6 +868,22 @@ const (
 	// tmpfs): it survives `docker stop`/`start` (restart) but is reclaimed
 	// by `docker rm`, so a freshly recreated container re-initializes.
 	AgentInitializedMarkerPath = "/var/lib/clawker/agent-initialized"
+	// ServicesDir holds the project's services: block as baked into the
+	// harness image: the services manifest, one launch script per
+	// service under bin/, the per-supervisor configs, and the supervisor
+	// marker written by the build-time install step. clawkerd reads it
+	// when the user CMD spawns; absent when no services are declared.
+	ServicesDir = "/etc/clawker/services"
+	// ServicesManifestFile and ServicesSupervisorFile are file names
+	// under ServicesDir: the JSON service list, and the name of the
+	// supervisor the install step found ("s6", "runit", "systemd", or
+	// "none" when clawkerd supervises the services itself).
+	ServicesManifestFile   = "services.json"
+	ServicesSupervisorFile = "supervisor"
+	// ServicesScanDir is the scan directory handed to s6-svscan or
+	// runsvdir. Owned by the container user: the supervisors run
+	// unprivileged and write their supervise/ state beside each service.
+	ServicesScanDir = "/var/lib/clawker/services"
 )
 
 // Exec-phase wall-clock ceilings used by the CP-driven init plan.
@@ -760,6 +953,14 @@ const (
 	BootstrapAssertionFile = "assertion.jwt"
 )
 
+// SecretsDir is the in-container tmpfs mount the CLI writes file-injected
+// secrets (project `secrets:`) into after every container start. Unlike
+// BootstrapDir it IS a tmpfs: the files are streamed in through an exec
+// into the running container, so they never reach the writable layer and
+// vanish when the container stops. The mount is 0700 and owned by the
+// container user; the files are 0400.
+const SecretsDir = "/run/secrets"
+
 // Container env vars for clawkerd bootstrap. clawkerd reads only what
 // it can authoritatively assert: container_id is server-derived from
 // the registry row keyed by container_id, and project + agent_name
@@ -807,6 +1008,10 @@ const (
 	// EnvHostProxy is the host proxy URL used for browser auth and git
 	// credential forwarding.
 	EnvHostProxy = "CLAWKER_HOST_PROXY"
+	// EnvHostProxyToken is the container's host proxy caller token, sent as
+	// the X-Clawker-Token header; the host proxy finds the container by its
+	// LabelHostProxyToken digest.
+	EnvHostProxyToken = "CLAWKER_HOST_PROXY_TOKEN"
 	// EnvGitHTTPS signals that HTTPS git credential forwarding is active;
 	// the in-container credential helper bails when unset.
 	EnvGitHTTPS = "CLAWKER_GIT_HTTPS"
@@ -1008,6 +1213,27 @@ func BundlesSubdir() (string, error) { return subdirPath(bundlesDir, DataDir) }
 // WorktreesSubdir ensures and returns the worktrees subdirectory path under DataDir.
 func WorktreesSubdir() (string, error) { return subdirPath(worktreesDir, DataDir) }
 
+// ExportsSubdir ensures and returns the workspace exports directory under
+// DataDir. `clawker open` copies snapshot-mode workspaces to
+// <ExportsSubdir>/<container-name>/ for editors that cannot attach to a
+// container.
+func ExportsSubdir() (string, error) { return subdirPath(exportsDir, DataDir) }
+
+// LoopsSubdir ensures and returns the directory `clawker loop run` writes
+// its run directories (iteration logs, diffs, report.json) to, under
+// DataDir.
+func LoopsSubdir() (string, error) { return subdirPath(loopsDir, DataDir) }
+
+// ScansSubdir ensures and returns the directory `clawker image build` writes
+// build.scan results (sbom.json, report.json, summary.json) to, one
+// directory per built image, under DataDir.
+func ScansSubdir() (string, error) { return subdirPath(scansDir, DataDir) }
+
+// SessionsSubdir ensures and returns the host session store directory —
+// one directory per container holding its saved harness conversation
+// state — under DataDir.
+func SessionsSubdir() (string, error) { return subdirPath(sessionsDir, DataDir) }
+
 // ShareSubdir ensures and returns the shared directory path under DataDir.
 func ShareSubdir() (string, error) { return subdirPath(shareDir, DataDir) }
 
@@ -1250,6 +1476,40 @@ func OIDCSocketPath() (string, error) {
 	return filepath.Join(dir, OIDCSocketFile), nil
 }
 
+// AttachSocketPath ensures the sockets subdirectory and returns the attach
+// hub socket path for a container. The ID is shortened to keep the path
+// within the Unix socket length limit.
+func AttachSocketPath(containerID string) (string, error) {
+	dir, err := SocketsDir()
+	if err != nil {
+		return "", err
+	}
+	if len(containerID) > 12 {
+		containerID = containerID[:12]
+	}
+	return filepath.Join(dir, AttachSocketPrefix+containerID+AttachSocketSuffix), nil
+}
+
+// GatewaySocketPath ensures the sockets subdirectory and returns the gateway
+// API socket path.
+func GatewaySocketPath() (string, error) {
+	dir, err := SocketsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, GatewaySocketFile), nil
+}
+
+// GatewayTokenPath ensures the sockets subdirectory and returns the gateway
+// API token file path.
+func GatewayTokenPath() (string, error) {
+	dir, err := SocketsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, GatewayTokenFile), nil
+}
+
 // ReadyFilePath ensures the state directory and returns the ready sentinel file path.
 func ReadyFilePath() (string, error) {
 	dir, err := ensureDir(StateDir())
@@ -1268,6 +1528,20 @@ func AuditLogPath() (string, error) {
 	return filepath.Join(dir, AuditLogFile), nil
 }
 
+// AuditSubdir ensures and returns <StateDir>/audit, the directory holding
+// both audit journal files.
+func AuditSubdir() (string, error) { return subdirPath(auditDir, StateDir) }
+
+// ControlPlaneAuditLogPath ensures <StateDir>/audit and returns the host path
+// of the control plane's audit journal file.
+func ControlPlaneAuditLogPath() (string, error) {
+	dir, err := AuditSubdir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ControlPlaneAuditLogFile), nil
+}
+
 // --- PID and log file paths ---
 
 // BridgePIDFilePath ensures the PID subdirectory and returns the per-container
@@ -1280,6 +1554,16 @@ func BridgePIDFilePath(containerID string) (string, error) {
 	return filepath.Join(dir, containerID+BridgePIDSuffix), nil
 }
 
+// BridgeSpecFilePath ensures the PID subdirectory and returns the path of the
+// per-container bridge spec the host proxy supervises the bridge from.
+func BridgeSpecFilePath(containerID string) (string, error) {
+	dir, err := PidsSubdir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, containerID+BridgeSpecSuffix), nil
+}
+
 // HostProxyPIDFilePath ensures the PID subdirectory and returns the host proxy
 // PID file path.
 func HostProxyPIDFilePath() (string, error) {
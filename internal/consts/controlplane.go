@@ -151,6 +151,14 @@ const (
 	// same path in the container's own filesystem.
 	CPLogsPath = "/var/log/clawker"
 
+	// CPAuditPath is the container-side audit journal directory,
+	// bind-mounted RW from the host's state/audit directory. The CP
+	// appends its entries to ControlPlaneAuditLogFile there.
+	CPAuditPath = "/var/log/clawker-audit"
+
+	// CPAuditLogPath is the container-side path of the CP's audit journal.
+	CPAuditLogPath = CPAuditPath + "/" + ControlPlaneAuditLogFile
+
 	// CPDockerSockPath is the host-side Docker socket path.
 	CPDockerSockPath = "/var/run/docker.sock"
 
@@ -12,19 +12,20 @@ import "testing"
 // edit.
 func TestPersistedValueTripwires(t *testing.T) {
 	tripwires := map[string]struct{ got, want string }{
-		"Network (existing clawker-net bridges)":                {Network, "clawker-net"},
-		"NamePrefix (resource names, XDG dir paths)":            {NamePrefix, "clawker"},
-		"LabelDomain (labels on existing resources)":            {LabelDomain, "dev.clawker"},
-		"LabelManaged (filter key on existing resources)":       {LabelManaged, "dev.clawker.managed"},
-		"LabelProject (filter key on existing resources)":       {LabelProject, "dev.clawker.project"},
-		"LabelAgent (filter key on existing resources)":         {LabelAgent, "dev.clawker.agent"},
-		"ContainerCP (CN pin baked into existing agent images)": {ContainerCP, "clawker-controlplane"},
-		"RegistryFile (existing project registries on disk)":    {RegistryFile, "registry.yaml"},
-		"ControlPlaneDBFile (existing agent trust tables)":      {ControlPlaneDBFile, "controlplane.db"},
-		"EnvConfigDir (set in user shell profiles)":             {EnvConfigDir, "CLAWKER_CONFIG_DIR"},
-		"EnvDataDir (set in user shell profiles)":               {EnvDataDir, "CLAWKER_DATA_DIR"},
-		"EnvStateDir (set in user shell profiles)":              {EnvStateDir, "CLAWKER_STATE_DIR"},
-		"EnvCacheDir (set in user shell profiles)":              {EnvCacheDir, "CLAWKER_CACHE_DIR"},
+		"Network (existing clawker-net bridges)":                  {Network, "clawker-net"},
+		"NamePrefix (resource names, XDG dir paths)":              {NamePrefix, "clawker"},
+		"LabelDomain (labels on existing resources)":              {LabelDomain, "dev.clawker"},
+		"LabelManaged (filter key on existing resources)":         {LabelManaged, "dev.clawker.managed"},
+		"LabelSchema (version read back from existing resources)": {LabelSchema, "dev.clawker.label_schema"},
+		"LabelProject (filter key on existing resources)":         {LabelProject, "dev.clawker.project"},
+		"LabelAgent (filter key on existing resources)":           {LabelAgent, "dev.clawker.agent"},
+		"ContainerCP (CN pin baked into existing agent images)":   {ContainerCP, "clawker-controlplane"},
+		"RegistryFile (existing project registries on disk)":      {RegistryFile, "registry.yaml"},
+		"ControlPlaneDBFile (existing agent trust tables)":        {ControlPlaneDBFile, "controlplane.db"},
+		"EnvConfigDir (set in user shell profiles)":               {EnvConfigDir, "CLAWKER_CONFIG_DIR"},
+		"EnvDataDir (set in user shell profiles)":                 {EnvDataDir, "CLAWKER_DATA_DIR"},
+		"EnvStateDir (set in user shell profiles)":                {EnvStateDir, "CLAWKER_STATE_DIR"},
+		"EnvCacheDir (set in user shell profiles)":                {EnvCacheDir, "CLAWKER_CACHE_DIR"},
 	}
 	for name, tw := range tripwires {
 		if tw.got != tw.want {
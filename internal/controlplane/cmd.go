@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/netip"
 	"os"
 	"os/signal"
 	"runtime/debug"
@@ -29,6 +30,7 @@ import (
 	"github.com/schmitthub/clawker/controlplane/pubsub"
 	"github.com/schmitthub/clawker/controlplane/server"
 	"github.com/schmitthub/clawker/controlplane/subprocess"
+	"github.com/schmitthub/clawker/internal/audit"
 	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
@@ -525,7 +527,7 @@ func buildEnforcement(ctx context.Context, cfg config.Config, log *logger.Logger
 ) {
 	// Docker client serves the container resolver, the firewall stack (Envoy +
 	// CoreDNS siblings over DooD), and the AgentWatcher poll loop.
-	dockerCli, err = docker.NewClient(ctx, cfg, log)
+	dockerCli, err = docker.NewClient(ctx, cfg, log, docker.WithAudit(audit.ActorControlPlane, consts.CPAuditLogPath))
 	if err != nil {
 		return nil, nil, nil, nil, nil, func() error { return nil }, fmt.Errorf("docker client: %w", err)
 	}
@@ -589,8 +591,10 @@ type grpcStackDeps struct {
 	stack             *fwhandler.Stack
 	rulesStore        *storage.Store[fwhandler.EgressRulesFile]
 	containerResolver fwhandler.ContainerResolver
+	projectNetworks   fwhandler.ProjectNetworkResolver
 	agentReg          agent.Registry
 	agentPeerLookup   *agent.MobyPeerLookup
+	agentSessions     *agent.Sessions
 	lister            *agent.ContainerLister
 	enrolledTopic     *pubsub.Topic[ebpf.EBPFContainerEnrolled]
 	caCertPool        *x509.CertPool
@@ -633,6 +637,13 @@ func buildGRPCStack(d grpcStackDeps) (
 		Queue:         actionQueue,
 		EnrolledTopic: d.enrolledTopic,
 		ListAgents:    func(ctx context.Context) ([]string, error) { return d.lister.List(ctx, agent.ListOpts{}) },
+
+		ProjectNetworks: d.projectNetworks,
+		Journal: func(e audit.Entry) {
+			if err := audit.AppendTo(consts.CPAuditLogPath, e); err != nil {
+				d.log.Warn().Err(err).Str("action", e.Action).Msg("audit journal append failed")
+			}
+		},
 	})
 	if err != nil {
 		return actionQueue, nil, nil, nil, cleanup, fmt.Errorf("firewall handler: %w", err)
@@ -641,6 +652,7 @@ func buildGRPCStack(d grpcStackDeps) (
 	grpcStack, err = server.NewGRPCStack(server.GRPCDeps{
 		Handler:        handler,
 		Registry:       d.agentReg,
+		Sessions:       d.agentSessions,
 		PeerLookup:     d.agentPeerLookup,
 		ServerCertPath: d.serverCertPath,
 		ServerKeyPath:  d.serverKeyPath,
@@ -784,25 +796,28 @@ func buildTopics(busLog *logger.Logger) (
 }
 
 // workerDeps carries the handles the long-lived observability workers
-// (pub/sub stats heartbeat, netlogger, dns_cache GC) are built against.
+// (pub/sub stats heartbeat, netlogger, dns_cache GC, DNS audit) are built
+// against.
 type workerDeps struct {
-	log           *logger.Logger
-	busLog        *logger.Logger
-	cfg           config.Config
-	ebpfMgr       *ebpf.Manager
-	dockerCli     *docker.Client
-	otelCertsSvc  *otelcerts.Service
-	handler       *fwhandler.Handler
-	agentRepo     *agent.Repository
-	dockerTopic   *pubsub.Topic[dockerevents.DockerEvent]
-	agentTopic    *pubsub.Topic[agent.AgentEvent]
-	enrolledTopic *pubsub.Topic[ebpf.EBPFContainerEnrolled]
+	log             *logger.Logger
+	busLog          *logger.Logger
+	cfg             config.Config
+	ebpfMgr         *ebpf.Manager
+	dockerCli       *docker.Client
+	otelCertsSvc    *otelcerts.Service
+	handler         *fwhandler.Handler
+	stack           *fwhandler.Stack
+	agentRepo       *agent.Repository
+	agentPeerLookup *agent.MobyPeerLookup
+	dockerTopic     *pubsub.Topic[dockerevents.DockerEvent]
+	agentTopic      *pubsub.Topic[agent.AgentEvent]
+	enrolledTopic   *pubsub.Topic[ebpf.EBPFContainerEnrolled]
 }
 
-// startWorkers launches the three long-lived observability workers on
+// startWorkers launches the four long-lived observability workers on
 // watcherCtx and returns the netlogger service + its caller-owned provider +
 // the dns_cache GC stop func — the handles the drain sequence acts on. All
-// three recover internally per the CP no-panic discipline; the heartbeat and
+// four recover internally per the CP no-panic discipline; the heartbeat and
 // GC are cancelled transitively when run() cancels watcherCtx, and the drain
 // sequence stops netlogger + GC explicitly before FlushAll.
 //
@@ -839,6 +854,22 @@ func startWorkers(watcherCtx context.Context, d workerDeps) (*netlogger.Service,
 	// dns_cache fd is torn down.
 	stopDNSGC := ebpf.NewDNSGarbageCollector(d.ebpfMgr, d.log, ebpf.DNSGCOpts{}).Start(watcherCtx)
 
+	// DNS audit — follows the CoreDNS query log and attributes each lookup
+	// to an agent by client IP, feeding the handler's egress audit log
+	// (FirewallAudit). Read-only: it never touches enforcement.
+	go fwhandler.RunDNSAudit(watcherCtx, fwhandler.DNSAuditDeps{
+		Audit:      d.handler.AuditLog(),
+		FollowLogs: d.stack.FollowCoreDNSLogs,
+		Identify: func(ctx context.Context, ip netip.Addr) (string, string, error) {
+			rc, err := d.agentPeerLookup.LookupByIP(ctx, ip)
+			if err != nil {
+				return "", "", err
+			}
+			return rc.Project.String(), rc.AgentName.String(), nil
+		},
+		Log: d.log.With("component", "dns-audit"),
+	})
+
 	return netloggerSvc, netloggerProvider, stopDNSGC
 }
 
@@ -850,6 +881,7 @@ type agentDialerDeps struct {
 	agentTopic  *pubsub.Topic[agent.AgentEvent]
 	dockerTopic *pubsub.Topic[dockerevents.DockerEvent]
 	agentReg    agent.Registry
+	sessions    *agent.Sessions
 	peerLookup  *agent.MobyPeerLookup
 	lister      *agent.ContainerLister
 	caCertPool  *x509.CertPool
@@ -891,6 +923,7 @@ func startAgentDialer(watcherCtx context.Context, d agentDialerDeps) func() {
 	if dialer == nil {
 		return agentCleanup
 	}
+	dialer.Sessions = d.sessions
 
 	// agent.Start reaps orphan registry rows against live docker and
 	// subscribes to dockerTopic for evict / session-cancel / dial.
@@ -958,6 +991,10 @@ func run(caCertPath, serverCertPath, serverKeyPath, jwkPath, logDir string) (ret
 	watcherCtx, watcherCancel := context.WithCancel(ctx)
 	defer watcherCancel()
 
+	// SIGUSR1 toggles debug logging for the CP's lifetime; the admin
+	// SetLogLevel RPC sets an explicit level.
+	logger.WatchLevelSignal(watcherCtx, log)
+
 	// Ory auth stack (Kratos, Hydra, Oathkeeper) — startup GATE 1 (see
 	// startOryStack). caCertPool/caTLS are the single CA surface reused
 	// everywhere downstream; never rebuilt.
@@ -1005,6 +1042,10 @@ func run(caCertPath, serverCertPath, serverKeyPath, jwkPath, logDir string) (ret
 		return err
 	}
 
+	// live clawkerd Session table: the dialer fills it, AdminService
+	// SetLogLevel forwards agent-targeted level changes through it.
+	agentSessions := agent.NewSessions()
+
 	// firewall handler + gRPC servers (admin + agent listeners) — see
 	// buildGRPCStack. The ActionQueue Close is drain step 1 (injected via
 	// HandlerDeps); grpcCleanup is the belt-and-braces close for non-drain
@@ -1016,8 +1057,10 @@ func run(caCertPath, serverCertPath, serverKeyPath, jwkPath, logDir string) (ret
 		stack:             stack,
 		rulesStore:        rulesStore,
 		containerResolver: containerResolver,
+		projectNetworks:   fwhandler.NewProjectNetworkResolver(dockerCli),
 		agentReg:          agentReg,
 		agentPeerLookup:   agentPeerLookup,
+		agentSessions:     agentSessions,
 		lister:            lister,
 		enrolledTopic:     enrolledTopic,
 		caCertPool:        caCertPool,
@@ -1039,6 +1082,9 @@ func run(caCertPath, serverCertPath, serverKeyPath, jwkPath, logDir string) (ret
 
 	orchestrator.SetReady()
 
+	// settings.yaml hot reload for the CP's lifetime (see startSettingsWatch).
+	startSettingsWatch(watcherCtx, cfg, log, handler)
+
 	// /healthz server (see startHealthz). Returns the server so the
 	// shutdown sequence can GracefulStop it.
 	healthServer := startHealthz(cp, log, orchestrator, serveFailed)
@@ -1053,23 +1099,25 @@ func run(caCertPath, serverCertPath, serverKeyPath, jwkPath, logDir string) (ret
 	defer feederCancel()
 
 	// long-lived observability workers (stats heartbeat, netlogger,
-	// dns_cache GC) — see startWorkers. They run on watcherCtx, so
+	// dns_cache GC, DNS audit) — see startWorkers. They run on watcherCtx, so
 	// run()'s watcherCancel stops the heartbeat and GC transitively; the drain
 	// sequence stops netlogger + GC explicitly before FlushAll. The deferred
 	// stopDNSGC is belt-and-braces (LIFO before ebpfMgr.Close) so an in-flight
 	// sweep is joined before the dns_cache fd is torn down.
 	netloggerSvc, netloggerProvider, stopDNSGC := startWorkers(watcherCtx, workerDeps{
-		log:           log,
-		busLog:        busLog,
-		cfg:           cfg,
-		ebpfMgr:       ebpfMgr,
-		dockerCli:     dockerCli,
-		otelCertsSvc:  otelCertsSvc,
-		handler:       handler,
-		agentRepo:     agentRepo,
-		dockerTopic:   dockerTopic,
-		agentTopic:    agentTopic,
-		enrolledTopic: enrolledTopic,
+		log:             log,
+		busLog:          busLog,
+		cfg:             cfg,
+		ebpfMgr:         ebpfMgr,
+		dockerCli:       dockerCli,
+		otelCertsSvc:    otelCertsSvc,
+		handler:         handler,
+		stack:           stack,
+		agentRepo:       agentRepo,
+		agentPeerLookup: agentPeerLookup,
+		dockerTopic:     dockerTopic,
+		agentTopic:      agentTopic,
+		enrolledTopic:   enrolledTopic,
 	})
 	defer stopDNSGC()
 
@@ -1138,6 +1186,7 @@ func run(caCertPath, serverCertPath, serverKeyPath, jwkPath, logDir string) (ret
 		agentTopic:  agentTopic,
 		dockerTopic: dockerTopic,
 		agentReg:    agentReg,
+		sessions:    agentSessions,
 		peerLookup:  agentPeerLookup,
 		lister:      lister,
 		caCertPool:  caCertPool,
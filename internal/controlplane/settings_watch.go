@@ -0,0 +1,80 @@
+package controlplane
+
+import (
+	"context"
+	"runtime/debug"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/storage"
+)
+
+// firewallIniter is the slice of the firewall handler the settings watcher
+// drives.
+type firewallIniter interface {
+	FirewallInit(ctx context.Context, req *adminv1.FirewallInitRequest) (*adminv1.FirewallInitResult, error)
+}
+
+// startSettingsWatch hot-reloads settings.yaml (mounted read-only from the
+// host config dir) for the lifetime of ctx and applies the changes the CP can
+// take live — see applySettingsChanges. Runs in its own recovered goroutine:
+// a watch failure or panic degrades to "changes apply on restart" and never
+// takes the CP down (§3.4).
+func startSettingsWatch(ctx context.Context, cfg config.Config, log *logger.Logger, fw firewallIniter) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error().
+					Interface("panic", r).
+					Bytes("stack", debug.Stack()).
+					Str("event", "settings_watch_panic").
+					Msg("settings watcher panicked; settings changes apply on CP restart")
+			}
+		}()
+		store := cfg.SettingsStore()
+		err := store.Watch(ctx, func(changes storage.Changes, err error) {
+			if err != nil {
+				log.Warn().Err(err).
+					Str("event", "settings_reload_failed").
+					Msg("settings reload failed; keeping previous settings")
+				return
+			}
+			applySettingsChanges(ctx, log, fw, changes, store.Read())
+		})
+		if err != nil {
+			log.Warn().Err(err).
+				Str("event", "settings_watch_unavailable").
+				Msg("settings watch unavailable; settings changes apply on CP restart")
+		}
+	}()
+}
+
+// applySettingsChanges reacts to the settings keys that changed. Turning
+// firewall.enable on brings the firewall stack up exactly like the startup
+// bringup gate (FirewallInit is idempotent). Turning it off is only reported:
+// enforcement is never torn down by a file edit — `clawker firewall down`
+// stays the explicit off switch. Port changes need a rebind and are reported
+// as restart-only; every other key is not the CP's.
+func applySettingsChanges(ctx context.Context, log *logger.Logger, fw firewallIniter, changes storage.Changes, s *config.Settings) {
+	if changes.Touches("firewall.enable") {
+		if s.Firewall.FirewallEnabled() {
+			log.Info().Str("component", "firewall-bringup").
+				Msg("settings reloaded: firewall.enable turned on; starting stack")
+			if _, err := fw.FirewallInit(ctx, &adminv1.FirewallInitRequest{}); err != nil {
+				log.Error().Err(err).
+					Str("event", "firewall_bringup_failed").
+					Str("component", "firewall-bringup").
+					Msg("firewall bringup after settings reload failed; retry with `clawker firewall up`")
+			}
+		} else {
+			log.Warn().Str("component", "firewall-bringup").
+				Msg("settings reloaded: firewall.enable turned off; the running firewall stays up until `clawker firewall down` or a CP restart")
+		}
+	}
+	if ports := append(changes.Under("control_plane"), changes.Under("monitoring.otel_infra_port")...); len(ports) > 0 {
+		log.Warn().Strs("keys", ports).
+			Str("event", "settings_restart_required").
+			Msg("settings reloaded: port changes take effect after the control plane restarts")
+	}
+}
@@ -0,0 +1,45 @@
+package controlplane
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/storage"
+)
+
+type fakeFirewallIniter struct{ calls int }
+
+func (f *fakeFirewallIniter) FirewallInit(context.Context, *adminv1.FirewallInitRequest) (*adminv1.FirewallInitResult, error) {
+	f.calls++
+	return &adminv1.FirewallInitResult{}, nil
+}
+
+func TestApplySettingsChanges(t *testing.T) {
+	on, off := true, false
+	tests := []struct {
+		name      string
+		changes   storage.Changes
+		enable    *bool
+		wantInits int
+	}{
+		{name: "firewall turned on brings the stack up", changes: storage.Changes{"firewall.enable"}, enable: &on, wantInits: 1},
+		{name: "firewall turned off never tears it down", changes: storage.Changes{"firewall.enable"}, enable: &off},
+		{name: "unrelated change is ignored", changes: storage.Changes{"logging.max_size_mb"}, enable: &on},
+		{name: "port change is restart-only", changes: storage.Changes{"control_plane.admin_port"}, enable: &on},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fw := &fakeFirewallIniter{}
+			s := &config.Settings{Firewall: config.FirewallSettings{Enable: tt.enable}}
+
+			applySettingsChanges(context.Background(), logger.Nop(), fw, tt.changes, s)
+
+			assert.Equal(t, tt.wantInits, fw.calls)
+		})
+	}
+}
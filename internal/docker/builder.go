@@ -5,6 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/containerd/platforms"
 
 	"github.com/schmitthub/clawker/internal/build"
 	"github.com/schmitthub/clawker/internal/bundler"
@@ -22,6 +27,7 @@ type Builder struct {
 	workDir     string
 	projectName string
 	provenance  []string
+	upToDate    bool
 }
 
 // Provenance returns the stack/harness resolution provenance recorded during
@@ -32,14 +38,22 @@ func (b *Builder) Provenance() []string {
 	return b.provenance
 }
 
+// UpToDate reports whether the last Build skipped the harness build because
+// the existing image's content hash matched its inputs.
+func (b *Builder) UpToDate() bool {
+	return b.upToDate
+}
+
 // BuilderOptions contains options for build operations.
 type BuilderOptions struct {
 	NoCache         bool                    // Build without Docker cache
+	ForceRebuild    bool                    // Build even when the content hashes match
 	Labels          map[string]string       // Labels to apply to the built image
 	Target          string                  // Multi-stage build target
 	Pull            bool                    // Always pull base image
 	SuppressOutput  bool                    // Suppress build output
 	NetworkMode     string                  // Network mode for build
+	Platforms       []string                // Target platforms; empty builds for the daemon's platform
 	BuildArgs       map[string]*string      // Build-time variables
 	Tags            []string                // Additional tags for the image (merged with imageTag)
 	BuildKitEnabled bool                    // Use BuildKit builder for cache mount support
@@ -67,6 +81,7 @@ func (o BuilderOptions) toBuildImageOpts(tags []string, dockerfile string, conte
 		Pull:            o.Pull,
 		SuppressOutput:  o.SuppressOutput,
 		NetworkMode:     o.NetworkMode,
+		Platforms:       o.Platforms,
 		BuildArgs:       o.BuildArgs,
 		BuildKitEnabled: o.BuildKitEnabled,
 		ContextDir:      contextDir,
@@ -109,12 +124,25 @@ func wrapExec(err error) error {
 // shared base image (clawker-<project>:base) exists and is fresh. The base
 // carries the harness-agnostic layers (packages, user setup, project
 // instructions); freshness is keyed by a content hash stamped as an image
-// label.
+// label. The harness image is keyed the same way: when the image at
+// imageTag already carries the hash of this build's inputs, the build is
+// skipped (see UpToDate) and only the extra tags are applied. NoCache and
+// ForceRebuild bypass both checks.
 func (b *Builder) Build(ctx context.Context, imageTag string, opts BuilderOptions) error {
+	b.upToDate = false
+	rebuild := opts.NoCache || opts.ForceRebuild
 	gen := bundler.NewProjectGenerator(b.client.cfg, b.workDir)
 	gen.BuildKitEnabled = opts.BuildKitEnabled
 	gen.HarnessVersion = opts.HarnessVersion
 	gen.Harness = opts.HarnessName
+	gen.Platforms = opts.Platforms
+
+	// Resolve the harness platforms before any build runs, so a platform
+	// list the harness can't serve fails without a wasted base build.
+	harnessTargets, err := harnessPlatforms(opts.Platforms)
+	if err != nil {
+		return err
+	}
 
 	// Merge image labels into build options (applied via Docker API, not in Dockerfile)
 	opts.Labels = b.mergeImageLabels(opts.Labels)
@@ -145,7 +173,7 @@ func (b *Builder) Build(ctx context.Context, imageTag string, opts BuilderOption
 	if err != nil {
 		return fmt.Errorf("failed to check base image freshness: %w", err)
 	}
-	if opts.NoCache || stale {
+	if rebuild || stale {
 		b.log.Debug().Str("image", baseTag).Str("hash", baseHash).Bool("no_cache", opts.NoCache).
 			Msg("building shared base image")
 		if buildErr := b.buildBase(ctx, gen, baseTag, baseHash, baseDockerfile, opts); buildErr != nil {
@@ -159,8 +187,7 @@ func (b *Builder) Build(ctx context.Context, imageTag string, opts BuilderOption
 	// attempt would fail against any registry. --pull applies to the base
 	// build, where the registry-backed parent lives.
 	opts.Pull = false
-
-	b.log.Debug().Str("image", imageTag).Msg("building harness image")
+	opts.Platforms = harnessTargets
 
 	dockerfile, err := gen.GenerateHarness()
 	if err != nil {
@@ -171,6 +198,33 @@ func (b *Builder) Build(ctx context.Context, imageTag string, opts BuilderOption
 	// command layer can report which layer each stack/harness resolved from.
 	b.provenance = gen.Provenance()
 
+	baseID, _, err := b.imageContentLabel(ctx, baseTag, consts.LabelBaseContentHash)
+	if err != nil {
+		return fmt.Errorf("failed to inspect base image: %w", err)
+	}
+	contentHash, err := gen.HarnessContentHash(dockerfile, bundler.HarnessBuildInputs{
+		BaseImageID: baseID,
+		BuildArgs:   opts.BuildArgs,
+		Target:      opts.Target,
+		Labels:      b.hashedLabels(opts.Labels),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hash harness image inputs: %w", err)
+	}
+	opts.Labels[consts.LabelContentHash] = contentHash
+
+	if !rebuild {
+		reused, reuseErr := b.reuseHarnessImage(ctx, imageTag, tags, contentHash, opts)
+		if reuseErr != nil {
+			return reuseErr
+		}
+		if reused {
+			return nil
+		}
+	}
+
+	b.log.Debug().Str("image", imageTag).Str("hash", contentHash).Msg("building harness image")
+
 	// BuildKit reads from the filesystem, not a tar stream.
 	// Write the generated Dockerfile + scripts to a temp dir for BuildKit to mount.
 	if opts.BuildKitEnabled {
@@ -202,6 +256,125 @@ func (b *Builder) Build(ctx context.Context, imageTag string, opts BuilderOption
 	)
 }
 
+// NormalizePlatforms parses --platform values — each entry may itself be a
+// comma-separated list — into canonical OS/ARCH[/VARIANT] strings, dropping
+// duplicates and keeping first-seen order.
+func NormalizePlatforms(values []string) ([]string, error) {
+	var out []string
+	for _, value := range values {
+		for _, raw := range strings.Split(value, ",") {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			parsed, err := platforms.Parse(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid platform %q: %w", raw, err)
+			}
+			if p := platforms.Format(platforms.Normalize(parsed)); !slices.Contains(out, p) {
+				out = append(out, p)
+			}
+		}
+	}
+	return out, nil
+}
+
+// harnessPlatforms narrows the requested platforms to those a harness image
+// can target. The harness build context carries clawkerd, which the CLI
+// embeds for its own architecture only, so the harness builds for that one;
+// the shared base image holds no clawker binaries and builds for every
+// requested platform. Nil in, nil out.
+func harnessPlatforms(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return nil, nil
+	}
+	var out []string
+	for _, p := range requested {
+		parsed, err := platforms.Parse(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid platform %q: %w", p, err)
+		}
+		if parsed.OS == "linux" && parsed.Architecture == runtime.GOARCH {
+			out = append(out, p)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf(
+			"platforms %s do not include linux/%s: the harness image needs the clawkerd binary, which this clawker embeds for linux/%s only",
+			strings.Join(requested, ","), runtime.GOARCH, runtime.GOARCH,
+		)
+	}
+	return out, nil
+}
+
+// reuseHarnessImage skips the harness build when the image at imageTag
+// carries contentHash: the remaining tags are pointed at it, OnComplete
+// fires with its ID, and a single cached progress step reports the reuse.
+// Reports false when there is no such image or its hash differs.
+func (b *Builder) reuseHarnessImage(ctx context.Context, imageTag string, tags []string, contentHash string, opts BuilderOptions) (bool, error) {
+	id, existing, err := b.imageContentLabel(ctx, imageTag, consts.LabelContentHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to check harness image freshness: %w", err)
+	}
+	if existing != contentHash {
+		return false, nil
+	}
+	for _, tag := range tags {
+		if tag == imageTag {
+			continue
+		}
+		if err := b.client.ImageTag(ctx, imageTag, tag); err != nil {
+			return false, fmt.Errorf("tagging %s: %w", tag, err)
+		}
+	}
+
+	b.log.Debug().Str("image", imageTag).Str("hash", contentHash).Msg("harness image up to date, skipping build")
+	b.upToDate = true
+	if opts.OnProgress != nil {
+		opts.OnProgress(whail.BuildProgressEvent{
+			StepID:     "content-hash",
+			StepName:   "Reuse " + imageTag + " (inputs unchanged)",
+			StepIndex:  0,
+			TotalSteps: 1,
+			Status:     whail.BuildStepComplete,
+			Cached:     true,
+		})
+	}
+	if opts.OnComplete != nil {
+		opts.OnComplete(whail.BuildResult{ImageID: id})
+	}
+	return true, nil
+}
+
+// hashedLabels returns the labels that describe a harness image's content —
+// everything but the per-build created timestamp.
+func (b *Builder) hashedLabels(labels map[string]string) map[string]string {
+	out := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if k != b.client.cfg.LabelCreated() {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// imageContentLabel returns the ID of the managed image at ref and the value
+// of its label; both are empty when no such image exists. Non-NotFound
+// inspect errors propagate.
+func (b *Builder) imageContentLabel(ctx context.Context, ref, label string) (id, value string, err error) {
+	result, err := b.client.ImageInspect(ctx, ref)
+	if err != nil {
+		if isNotFoundError(err) {
+			return "", "", nil
+		}
+		return "", "", fmt.Errorf("inspecting image %s: %w", ref, err)
+	}
+	if result.Config != nil {
+		value = result.Config.Labels[label]
+	}
+	return result.ID, value, nil
+}
+
 // baseImageStale reports whether the shared base image must be (re)built:
 // true when no managed image exists at baseTag, or when its content-hash
 // label differs from wantHash. Non-NotFound inspect errors propagate.
@@ -222,7 +395,8 @@ func (b *Builder) baseImageStale(ctx context.Context, baseTag, wantHash string)
 // buildBase builds the shared base image. Its context is the project
 // build-context directory (user copy srcs live there); the rendered
 // Dockerfile is supplied out-of-band. Base labels are clawker's own plus
-// the content hash and purpose — never user labels or the harness label,
+// the content hash, the substrate it is built FROM (inherited by harness
+// images, for `clawker image outdated`) and purpose — never user labels or the harness label,
 // which describe the runnable harness image.
 func (b *Builder) buildBase(
 	ctx context.Context,
@@ -234,6 +408,7 @@ func (b *Builder) buildBase(
 	baseOpts := opts
 	baseOpts.Labels = b.client.ImageLabels(b.projectName, build.Version)
 	baseOpts.Labels[consts.LabelBaseContentHash] = baseHash
+	baseOpts.Labels[consts.LabelBaseImage] = bundler.SubstrateImage
 	baseOpts.Labels[consts.LabelPurpose] = consts.PurposeBaseImage
 	baseOpts.Target = ""
 	baseOpts.OnComplete = nil // the caller's --iidfile wants the runnable harness image
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -193,6 +194,7 @@ type capturedBuild struct {
 	labels     map[string]string
 	dockerfile string
 	pull       bool
+	platforms  []ocispec.Platform
 }
 
 // captureImageBuilds wires ImageBuildFn to record every build call:
@@ -226,6 +228,7 @@ func captureImageBuilds(t *testing.T, fakeAPI *whailtest.FakeAPIClient) *[]captu
 			labels:     opts.Labels,
 			dockerfile: dockerfile,
 			pull:       opts.PullParent,
+			platforms:  opts.Platforms,
 		})
 		return client.ImageBuildResult{Body: io.NopCloser(strings.NewReader(""))}, nil
 	}
@@ -313,6 +316,8 @@ func TestBuild_BuildsBaseThenHarness(t *testing.T) {
 	assert.Equal(t, wantHash, base.labels[consts.LabelBaseContentHash],
 		"base image must carry the content hash label")
 	assert.Equal(t, consts.PurposeBaseImage, base.labels[consts.LabelPurpose])
+	assert.Equal(t, bundler.SubstrateImage, base.labels[consts.LabelBaseImage],
+		"base image records its substrate for image outdated")
 	assert.NotContains(t, base.labels, consts.LabelHarness,
 		"base image is harness-agnostic")
 	assert.NotContains(t, base.dockerfile, "ENTRYPOINT",
@@ -384,6 +389,83 @@ func TestBuild_NoCacheRebuildsBase(t *testing.T) {
 	require.Len(t, *builds, 2, "--no-cache must rebuild the base too")
 }
 
+// setupInspectImages makes ImageInspect return a managed image for each ref
+// in images (keyed ref → labels, ID "sha256:id-<ref>") and miss for the rest.
+// The map is read on every call, so tests can add images between builds.
+func setupInspectImages(cfg config.Config, fakeAPI *whailtest.FakeAPIClient, images map[string]map[string]string) {
+	fakeAPI.ImageInspectFn = func(_ context.Context, image string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+		extra, ok := images[image]
+		if !ok {
+			return client.ImageInspectResult{}, inspectNotFoundError{ref: image}
+		}
+		labels := map[string]string{
+			cfg.EngineLabelPrefix() + "." + cfg.EngineManagedLabel(): cfg.ManagedLabelValue(),
+		}
+		for k, v := range extra {
+			labels[k] = v
+		}
+		return client.ImageInspectResult{
+			InspectResponse: dockerimage.InspectResponse{ //nolint:exhaustruct // fixture — only ID + labels matter
+				ID: "sha256:id-" + image,
+				Config: &dockerspec.DockerOCIImageConfig{ //nolint:exhaustruct // fixture
+					ImageConfig: ocispec.ImageConfig{Labels: labels}, //nolint:exhaustruct // fixture
+				},
+			},
+		}, nil
+	}
+}
+
+// TestBuild_SkipsHarnessWhenContentHashMatches pins the harness freshness
+// gate: a build stamps the content hash; a second build with the same inputs
+// against an image carrying it builds nothing, re-points the extra tags, and
+// reports the existing image ID. Changed inputs or --force-rebuild build.
+func TestBuild_SkipsHarnessWhenContentHashMatches(t *testing.T) {
+	cfg := testHarnessCfg(t)
+	cli, fakeAPI := newTestClientWithConfig(cfg)
+	workDir := t.TempDir()
+	images := map[string]map[string]string{
+		"clawker-proj:base": {consts.LabelBaseContentHash: expectedBaseHash(t, cfg, workDir, "other")},
+	}
+	setupInspectImages(cfg, fakeAPI, images)
+	builds := captureImageBuilds(t, fakeAPI)
+	var tagged []string
+	fakeAPI.ImageTagFn = func(_ context.Context, opts client.ImageTagOptions) (client.ImageTagResult, error) {
+		tagged = append(tagged, opts.Source+"->"+opts.Target)
+		return client.ImageTagResult{}, nil
+	}
+
+	b := NewBuilder(cli, cfg.Project(), workDir, "proj")
+	newOpts := func() BuilderOptions {
+		return BuilderOptions{HarnessName: "other", SuppressOutput: true, Tags: []string{"clawker-proj:default"}}
+	}
+	require.NoError(t, b.Build(context.Background(), "clawker-proj:other", newOpts()))
+	require.Len(t, *builds, 1)
+	assert.False(t, b.UpToDate())
+	contentHash := (*builds)[0].labels[consts.LabelContentHash]
+	require.NotEmpty(t, contentHash, "harness image must carry the content hash label")
+
+	images["clawker-proj:other"] = map[string]string{consts.LabelContentHash: contentHash}
+	var gotID string
+	opts := newOpts()
+	opts.OnComplete = func(res whail.BuildResult) { gotID = res.ImageID }
+	require.NoError(t, b.Build(context.Background(), "clawker-proj:other", opts))
+	assert.Len(t, *builds, 1, "unchanged inputs must not rebuild")
+	assert.True(t, b.UpToDate())
+	assert.Equal(t, "sha256:id-clawker-proj:other", gotID)
+	assert.Equal(t, []string{"clawker-proj:other->clawker-proj:default"}, tagged)
+
+	opts = newOpts()
+	opts.BuildArgs = map[string]*string{"EXTRA": new(string)}
+	require.NoError(t, b.Build(context.Background(), "clawker-proj:other", opts))
+	assert.Len(t, *builds, 2, "a changed build arg must rebuild")
+	assert.False(t, b.UpToDate())
+
+	opts = newOpts()
+	opts.ForceRebuild = true
+	require.NoError(t, b.Build(context.Background(), "clawker-proj:other", opts))
+	assert.Len(t, *builds, 4, "--force-rebuild rebuilds base and harness")
+}
+
 // TestBuild_RelevantBuildArgRebuildsBase: a --build-arg targeting an ARG the
 // base Dockerfile declares (TZ, from the base template's `ARG TZ=UTC`) folds
 // into the base content hash, so a base image built without that arg value is
@@ -474,6 +556,67 @@ func TestBuild_HarnessBuildNeverPulls(t *testing.T) {
 	assert.False(t, (*builds)[1].pull, "harness build must never pull — its parent is local-only")
 }
 
+// TestBuild_PlatformReachesBothBuilds pins --platform plumbing on the
+// legacy path: a single native platform is sent with the base and the
+// harness build, and folds into the base content hash.
+func TestBuild_PlatformReachesBothBuilds(t *testing.T) {
+	cfg := testHarnessCfg(t)
+	cli, fakeAPI := newTestClientWithConfig(cfg)
+	workDir := t.TempDir()
+	// An existing base built for the daemon's platform must not be reused.
+	setupInspectBaseWithHash(cfg, fakeAPI, "clawker-proj:base", expectedBaseHash(t, cfg, workDir, "other"))
+	builds := captureImageBuilds(t, fakeAPI)
+
+	native := "linux/" + runtime.GOARCH
+	b := NewBuilder(cli, cfg.Project(), workDir, "proj")
+	var buildOpts BuilderOptions
+	buildOpts.HarnessName = "other"
+	buildOpts.SuppressOutput = true
+	buildOpts.Platforms = []string{native}
+	require.NoError(t, b.Build(context.Background(), "clawker-proj:other", buildOpts))
+
+	require.Len(t, *builds, 2, "a platform change must rebuild the base")
+	for _, build := range *builds {
+		require.Len(t, build.platforms, 1)
+		assert.Equal(t, runtime.GOARCH, build.platforms[0].Architecture)
+	}
+}
+
+func TestNormalizePlatforms(t *testing.T) {
+	got, err := NormalizePlatforms([]string{"linux/amd64, linux/aarch64", "linux/x86_64"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"linux/amd64", "linux/arm64"}, got)
+
+	got, err = NormalizePlatforms(nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = NormalizePlatforms([]string{"linux/amd64/v1/extra"})
+	require.ErrorContains(t, err, "invalid platform")
+}
+
+func TestHarnessPlatforms(t *testing.T) {
+	native := "linux/" + runtime.GOARCH
+	foreign := "linux/arm64"
+	if runtime.GOARCH == "arm64" {
+		foreign = "linux/amd64"
+	}
+
+	got, err := harnessPlatforms(nil)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = harnessPlatforms([]string{foreign, native})
+	require.NoError(t, err)
+	assert.Equal(t, []string{native}, got, "the harness builds only for clawkerd's architecture")
+
+	_, err = harnessPlatforms([]string{foreign})
+	require.ErrorContains(t, err, "do not include "+native)
+
+	_, err = harnessPlatforms([]string{"linux/not-an-arch/x/y"})
+	require.ErrorContains(t, err, "invalid platform")
+}
+
 // TestBuild_SelectsHarnessFromOptions proves the harness selected at the
 // command layer (BuilderOptions.HarnessName) is the one whose template
 // blocks render into the generated Dockerfile — not the configured default.
@@ -15,6 +15,14 @@ func BuildKitEnabled(ctx context.Context, p Pinger) (bool, error) {
 	return whail.BuildKitEnabled(ctx, p)
 }
 
+// InfoProvider is the subset of the Docker API needed for image store detection.
+type InfoProvider = whail.InfoProvider
+
+// MultiPlatformImageStore reports whether the daemon can store multi-platform images.
+func MultiPlatformImageStore(ctx context.Context, p InfoProvider) (bool, error) {
+	return whail.MultiPlatformImageStore(ctx, p)
+}
+
 // WireBuildKit sets up the BuildKit image builder on the given Client.
 // This encapsulates the buildkit subpackage dependency so callers don't
 // need to import pkg/whail/buildkit directly.
@@ -9,9 +9,13 @@ import (
 	"io"
 	"regexp"
 	"strings"
+	"time"
 
 	cerrdefs "github.com/containerd/errdefs"
+	"github.com/containerd/platforms"
 	"github.com/moby/moby/api/types/container"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/schmitthub/clawker/internal/audit"
 	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/logger"
@@ -26,17 +30,31 @@ type Client struct {
 	cfg config.Config  // lazily provides project and settings for image resolution
 	log *logger.Logger // structured file logger (never nil; Nop for tests)
 
+	// endpoint is the daemon NewClient connected to (zero for
+	// NewClientFromEngine).
+	endpoint Endpoint
+	// hostRuntime is the provider behind the daemon (zero for
+	// NewClientFromEngine).
+	hostRuntime HostRuntime
+
 	// ChownImage overrides the image used for CopyToVolume's chown step.
 	// When empty, defaults to "busybox:latest". Tests set this to a locally-built
 	// labeled image to avoid DockerHub pulls and ensure test-label propagation.
 	ChownImage string
 }
 
-// NewClient creates a new clawker Docker client.
-// It configures the whail.Engine with clawker's label prefix and conventions.
+// connectTimeout bounds the daemon health check in NewClient, so an
+// unresponsive daemon (a stuck Docker Desktop VM, an unreachable remote
+// DOCKER_HOST) fails with whail.ErrDockerNotAvailable instead of hanging the
+// command. A var so tests can shorten it.
+var connectTimeout = 15 * time.Second
+
 // clientOptions holds configuration for NewClient.
 type clientOptions struct {
-	labels whail.LabelConfig
+	labels     whail.LabelConfig
+	dryRun     bool
+	auditActor audit.Actor
+	auditPath  string
 }
 
 // ClientOption configures a NewClient call.
@@ -51,6 +69,29 @@ func WithLabels(labels whail.LabelConfig) ClientOption {
 	}
 }
 
+// WithDryRun puts the whail engine in dry-run mode: mutating calls are
+// journaled (Engine.DryRunReport) instead of sent to the daemon. Backs the
+// global --dry-run flag.
+func WithDryRun(dryRun bool) ClientOption {
+	return func(o *clientOptions) {
+		o.dryRun = dryRun
+	}
+}
+
+// WithAudit appends every mutating engine call to the audit journal file at
+// path, attributed to actor (see whail.EngineOptions.OnMutation). A journal
+// write failure is logged and never fails the Docker call.
+func WithAudit(actor audit.Actor, path string) ClientOption {
+	return func(o *clientOptions) {
+		o.auditActor = actor
+		o.auditPath = path
+	}
+}
+
+// NewClient creates a new clawker Docker client.
+// It configures the whail.Engine with clawker's label prefix and conventions
+// and fails with an error matching whail.ErrDockerNotAvailable when the
+// daemon does not answer within connectTimeout.
 func NewClient(ctx context.Context, cfg config.Config, log *logger.Logger, opts ...ClientOption) (*Client, error) {
 	if log == nil {
 		log = logger.Nop()
@@ -61,22 +102,81 @@ func NewClient(ctx context.Context, cfg config.Config, log *logger.Logger, opts
 		opt(&o)
 	}
 
-	engineOpts := whail.EngineOptions{
-		LabelPrefix:  cfg.EngineLabelPrefix(),
-		ManagedLabel: cfg.EngineManagedLabel(),
-		Labels:       o.labels,
+	endpoint, err := ResolveEndpoint(cfg.SettingsStore().Read().Docker.Context)
+	if err != nil {
+		return nil, whail.ErrDockerHealthCheckFailed(err)
 	}
 
-	engine, err := whail.NewWithOptions(ctx, engineOpts)
+	engineOpts := whail.EngineOptions{
+		Host:               endpoint.Host,
+		TLSCertDir:         endpoint.TLSCertDir,
+		LabelPrefix:        cfg.EngineLabelPrefix(),
+		ManagedLabel:       cfg.EngineManagedLabel(),
+		BaseImageLabel:     consts.EngineBaseImageLabel,
+		LabelSchemaLabel:   consts.EngineLabelSchemaLabel,
+		LabelSchemaVersion: consts.LabelSchemaVersion,
+		LabelMigrations:    LabelMigrations(),
+		Labels:             o.labels,
+		DryRun:             o.dryRun,
+		// Spans for every Docker API request when the logger exports them
+		// (monitoring on); nil otherwise.
+		TracerProvider: log.TracerProvider(),
+	}
+	if o.auditPath != "" {
+		engineOpts.OnMutation = auditMutation(o.auditActor, o.auditPath, log)
+	}
+
+	// ctx only feeds the health check; the engine does not retain it.
+	pingCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	engine, err := whail.NewWithOptions(pingCtx, engineOpts)
 	if err != nil {
+		if ctx.Err() == nil && errors.Is(pingCtx.Err(), context.DeadlineExceeded) {
+			return nil, whail.ErrDockerHealthCheckFailed(
+				fmt.Errorf("no response from the Docker daemon %swithin %s", endpointSuffix(endpoint), connectTimeout))
+		}
+		// Name a remote or context-selected daemon in the cause, so a
+		// stale context is not mistaken for a stopped local Docker.
+		var dockerErr *whail.DockerError
+		if endpoint.Source != defaultContextName && errors.Is(err, whail.ErrDockerNotAvailable) && errors.As(err, &dockerErr) {
+			return nil, whail.ErrDockerHealthCheckFailed(fmt.Errorf("%s: %w", endpoint, dockerErr.Err))
+		}
 		return nil, err
 	}
+	info, _ := engine.DaemonInfo()
+	hostRuntime := DetectHostRuntime(info, engine.Compat(), endpoint)
+	log.Debug().Str("endpoint", endpoint.String()).Str("runtime", hostRuntime.Name()).Msg("connected to docker daemon")
 
-	c := &Client{Engine: engine, cfg: cfg, log: log}
+	c := &Client{Engine: engine, cfg: cfg, log: log, endpoint: endpoint, hostRuntime: hostRuntime}
 	WireBuildKit(c)
 	return c, nil
 }
 
+// endpointSuffix is "at <endpoint> " for a daemon not chosen by default,
+// for splicing into a message; empty otherwise.
+func endpointSuffix(e Endpoint) string {
+	if e.Source == defaultContextName {
+		return ""
+	}
+	return "at " + e.String() + " "
+}
+
+// Endpoint returns the daemon the client connected to.
+func (c *Client) Endpoint() Endpoint {
+	return c.endpoint
+}
+
+// SetEndpoint overrides the daemon the client reports. Test doubles use it
+// to stand a fake engine in for a remote daemon; NewClient sets the real one.
+func (c *Client) SetEndpoint(ep Endpoint) {
+	c.endpoint = ep
+}
+
+// HostRuntime returns the Docker provider the client is connected to.
+func (c *Client) HostRuntime() HostRuntime {
+	return c.hostRuntime
+}
+
 // NewClientFromEngine creates a Client from an existing Engine and config.
 // Intended for testing — production code should use NewClient.
 // When log is nil, a Nop logger is used.
@@ -95,7 +195,7 @@ func (c *Client) Close() error {
 // IsMonitoringActive checks if the clawker monitoring stack is running.
 // It looks for the otel-collector container on the clawker network.
 func (c *Client) IsMonitoringActive(ctx context.Context) bool {
-	f := whail.Filters{}.Add("name", consts.MonitoringServiceOtelCollector).Add("status", "running")
+	f := whail.Query().Name(consts.MonitoringServiceOtelCollector).Running().MustFilters()
 	result, err := c.ContainerList(ctx, whail.ContainerListOptions{
 		Filters: f,
 	})
@@ -132,6 +232,15 @@ func (c *Client) ImageExists(ctx context.Context, imageRef string) (bool, error)
 	return true, nil
 }
 
+// PruneImages removes the managed images policy selects. KeepLast counts
+// builds per project and harness, and image age comes from the clawker
+// created label stamped at build time.
+func (c *Client) PruneImages(ctx context.Context, policy PrunePolicy) (PruneReport, error) {
+	policy.GroupBy = []string{c.cfg.LabelProject(), consts.LabelHarness}
+	policy.CreatedLabel = c.cfg.LabelCreated()
+	return c.ImagePruneManaged(ctx, policy)
+}
+
 // imageExistsRaw checks if an image exists locally without the managed label check.
 // Use this for external images (e.g. busybox) that are never clawker-managed.
 func (c *Client) imageExistsRaw(ctx context.Context, ref string) (bool, error) {
@@ -165,6 +274,7 @@ type BuildImageOpts struct {
 	Pull            bool                    // --pull (maps to PullParent)
 	SuppressOutput  bool                    // -q, --quiet
 	NetworkMode     string                  // --network
+	Platforms       []string                // --platform (more than one requires BuildKit)
 	BuildKitEnabled bool                    // Use BuildKit builder via whail.ImageBuildKit
 	ContextDir      string                  // Build context directory (required for BuildKit)
 	OnProgress      whail.BuildProgressFunc // Progress callback for build events
@@ -190,12 +300,25 @@ func (c *Client) BuildImage(ctx context.Context, buildContext io.Reader, opts Bu
 			Pull:           opts.Pull,
 			SuppressOutput: opts.SuppressOutput,
 			NetworkMode:    opts.NetworkMode,
+			Platforms:      opts.Platforms,
 			OnProgress:     opts.OnProgress,
 			OnComplete:     opts.OnComplete,
 		})
 	}
 
-	// Legacy SDK path
+	// Legacy SDK path. The classic builder produces one image per build, so
+	// it takes at most one platform.
+	if len(opts.Platforms) > 1 {
+		return fmt.Errorf("building for %d platforms requires BuildKit", len(opts.Platforms))
+	}
+	var buildPlatforms []ocispec.Platform
+	for _, p := range opts.Platforms {
+		parsed, err := platforms.Parse(p)
+		if err != nil {
+			return fmt.Errorf("invalid platform %q: %w", p, err)
+		}
+		buildPlatforms = append(buildPlatforms, parsed)
+	}
 	options := whail.ImageBuildOptions{
 		Tags:           opts.Tags,
 		Dockerfile:     opts.Dockerfile,
@@ -207,6 +330,7 @@ func (c *Client) BuildImage(ctx context.Context, buildContext io.Reader, opts Bu
 		PullParent:     opts.Pull,
 		SuppressOutput: opts.SuppressOutput,
 		NetworkMode:    opts.NetworkMode,
+		Platforms:      buildPlatforms,
 	}
 	resp, err := c.ImageBuild(ctx, buildContext, options)
 	if err != nil {
@@ -502,17 +626,41 @@ type Container struct {
 	Image   string
 	Workdir string
 	Status  string
+	// StatusText is the daemon's human-readable status, e.g. "Up 3 hours"
+	// or "Exited (0) 2 days ago".
+	StatusText string
+	// Health is the healthcheck status ("starting", "healthy",
+	// "unhealthy"); empty when the container has no healthcheck or is not
+	// running.
+	Health  string
 	Created int64
+	Labels  map[string]string
 }
 
 // ListContainers returns all clawker-managed containers.
 func (c *Client) ListContainers(ctx context.Context, includeAll bool) ([]Container, error) {
-	opts := whail.ContainerListOptions{
-		All:     includeAll,
-		Filters: c.ClawkerFilter(),
+	return c.ListContainersQuery(ctx, Query(), includeAll)
+}
+
+// ListContainersByProject returns containers for a specific project.
+func (c *Client) ListContainersByProject(ctx context.Context, project string, includeAll bool) ([]Container, error) {
+	return c.ListContainersQuery(ctx, Query().Project(project), includeAll)
+}
+
+// ListContainersQuery returns the clawker-managed containers matching q,
+// evaluated by the daemon. Start q from Query() so its project and agent
+// clauses resolve to clawker's label keys. A status clause selects stopped
+// containers even when includeAll is false, as it does for docker ps.
+func (c *Client) ListContainersQuery(ctx context.Context, q whail.LabelQuery, includeAll bool) ([]Container, error) {
+	filters, err := q.Filters()
+	if err != nil {
+		return nil, err
 	}
 
-	result, err := c.ContainerList(ctx, opts)
+	result, err := c.ContainerList(ctx, whail.ContainerListOptions{
+		All:     includeAll,
+		Filters: filters,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -520,19 +668,25 @@ func (c *Client) ListContainers(ctx context.Context, includeAll bool) ([]Contain
 	return c.parseContainers(result.Items), nil
 }
 
-// ListContainersByProject returns containers for a specific project.
-func (c *Client) ListContainersByProject(ctx context.Context, project string, includeAll bool) ([]Container, error) {
-	opts := whail.ContainerListOptions{
-		All:     includeAll,
-		Filters: c.ProjectFilter(project),
+// FindAgentContainer returns the container labelled with project and agent,
+// or nil when none exists. An empty project selects the global-scope agent,
+// whose container carries no project label.
+func (c *Client) FindAgentContainer(ctx context.Context, project, agent string) (*Container, error) {
+	q := Query().Agent(agent)
+	if project != "" {
+		q = q.Project(project)
 	}
-
-	result, err := c.ContainerList(ctx, opts)
+	containers, err := c.ListContainersQuery(ctx, q, true)
 	if err != nil {
 		return nil, err
 	}
-
-	return c.parseContainers(result.Items), nil
+	for i := range containers {
+		// The daemon can't match an absent label, so scope is checked here.
+		if containers[i].Project == project {
+			return &containers[i], nil
+		}
+	}
+	return nil, nil
 }
 
 // FindContainerByAgent finds a container by project and agent name.
@@ -702,17 +856,42 @@ func (cl *Client) parseContainers(containers []container.Summary) []Container {
 			image = c.Image
 		}
 
+		var health string
+		if c.Health != nil && c.Health.Status != container.NoHealthcheck {
+			health = string(c.Health.Status)
+		}
+
 		result = append(result, Container{
-			ID:      c.ID,
-			Name:    name,
-			Project: c.Labels[cl.cfg.LabelProject()],
-			Agent:   c.Labels[cl.cfg.LabelAgent()],
-			Image:   image,
-			Workdir: c.Labels[cl.cfg.LabelWorkdir()],
-			Status:  string(c.State),
-			Created: c.Created,
+			ID:         c.ID,
+			Name:       name,
+			Project:    c.Labels[cl.cfg.LabelProject()],
+			Agent:      c.Labels[cl.cfg.LabelAgent()],
+			Image:      image,
+			Workdir:    c.Labels[cl.cfg.LabelWorkdir()],
+			Status:     string(c.State),
+			StatusText: c.Status,
+			Health:     health,
+			Created:    c.Created,
+			Labels:     c.Labels,
 		})
 	}
 
 	return result
 }
+
+// auditMutation records one engine operation as an audit journal entry.
+func auditMutation(actor audit.Actor, path string, log *logger.Logger) func(whail.Operation) {
+	return func(op whail.Operation) {
+		err := audit.AppendTo(path, audit.Entry{
+			Event:    audit.EventOperation,
+			Actor:    actor,
+			Action:   op.Action,
+			Resource: op.Resource,
+			Target:   op.Target,
+			Params:   op.Params,
+		})
+		if err != nil {
+			log.Warn().Err(err).Str("operation", op.String()).Msg("recording audit entry")
+		}
+	}
+}
@@ -6,14 +6,21 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"net"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/moby/moby/api/types/container"
 	moby "github.com/moby/moby/client"
+	"github.com/schmitthub/clawker/internal/audit"
 	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/pkg/whail"
 	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -204,7 +211,11 @@ name: "testproject"
 				t.Fatalf("parseContainers() returned %d containers, want %d", len(got), len(tt.want))
 			}
 			for i := range got {
-				if got[i] != tt.want[i] {
+				if !maps.Equal(got[i].Labels, tt.in[i].Labels) {
+					t.Errorf("container[%d].Labels = %v, want %v", i, got[i].Labels, tt.in[i].Labels)
+				}
+				got[i].Labels = nil
+				if !reflect.DeepEqual(got[i], tt.want[i]) {
 					t.Errorf("container[%d] = %+v, want %+v", i, got[i], tt.want[i])
 				}
 			}
@@ -381,3 +392,106 @@ func TestBuildImage_BuildKitWithoutContextDir_FallsToLegacy(t *testing.T) {
 	}
 	whailtest.AssertCalled(t, fake, "ImageBuild")
 }
+
+func TestBuildImage_Platforms(t *testing.T) {
+	cfg := testConfig(t, `version: "1"`)
+	fake := whailtest.NewFakeAPIClient()
+	engine := clawkerEngine(cfg, fake)
+	client := &Client{Engine: engine, cfg: cfg, log: logger.Nop()}
+
+	capture := &whailtest.BuildKitCapture{}
+	engine.BuildKitImageBuilder = whailtest.FakeBuildKitBuilder(capture)
+	var legacy moby.ImageBuildOptions
+	fake.ImageBuildFn = func(_ context.Context, _ io.Reader, opts moby.ImageBuildOptions) (moby.ImageBuildResult, error) {
+		legacy = opts
+		return moby.ImageBuildResult{Body: io.NopCloser(bytes.NewReader(nil))}, nil
+	}
+	ctx := context.Background()
+	multi := []string{"linux/amd64", "linux/arm64"}
+
+	require.NoError(t, client.BuildImage(ctx, nil, BuildImageOpts{
+		BuildKitEnabled: true, ContextDir: "/tmp/build", SuppressOutput: true, Platforms: multi,
+	}))
+	assert.Equal(t, multi, capture.Opts.Platforms)
+
+	require.NoError(t, client.BuildImage(ctx, bytes.NewReader(nil), BuildImageOpts{
+		SuppressOutput: true, Platforms: []string{"linux/arm64"},
+	}))
+	require.Len(t, legacy.Platforms, 1)
+	assert.Equal(t, "arm64", legacy.Platforms[0].Architecture)
+
+	err := client.BuildImage(ctx, bytes.NewReader(nil), BuildImageOpts{SuppressOutput: true, Platforms: multi})
+	require.ErrorContains(t, err, "requires BuildKit")
+}
+
+func TestNewClient_DaemonUnavailable(t *testing.T) {
+	cfg := testConfig(t, `
+version: "1"
+name: "testproject"
+`)
+	t.Setenv("DOCKER_CERT_PATH", "")
+	t.Setenv("DOCKER_TLS_VERIFY", "")
+
+	t.Run("missing socket", func(t *testing.T) {
+		t.Setenv("DOCKER_HOST", "unix://"+filepath.Join(t.TempDir(), "docker.sock"))
+
+		_, err := NewClient(context.Background(), cfg, nil)
+		require.Error(t, err)
+		require.ErrorIs(t, err, whail.ErrDockerNotAvailable)
+	})
+
+	t.Run("unresponsive daemon times out", func(t *testing.T) {
+		sock := filepath.Join(t.TempDir(), "docker.sock")
+		ln, err := net.Listen("unix", sock)
+		require.NoError(t, err)
+		// Accept connections but never answer; closing the listener ends
+		// the loop, which then drops the held connections.
+		go func() {
+			var held []net.Conn
+			defer func() {
+				for _, c := range held {
+					c.Close()
+				}
+			}()
+			for {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				held = append(held, conn)
+			}
+		}()
+		t.Cleanup(func() { ln.Close() })
+		t.Setenv("DOCKER_HOST", "unix://"+sock)
+
+		orig := connectTimeout
+		connectTimeout = 50 * time.Millisecond
+		t.Cleanup(func() { connectTimeout = orig })
+
+		_, err = NewClient(context.Background(), cfg, nil)
+		require.ErrorIs(t, err, whail.ErrDockerNotAvailable)
+		require.ErrorContains(t, err, "no response from the Docker daemon at unix://"+sock+" (DOCKER_HOST) within 50ms")
+	})
+}
+
+func TestAuditMutation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	record := auditMutation(audit.ActorCLI, path, logger.Nop())
+
+	record(whail.Operation{Action: "create", Resource: "container", Target: "clawker.app.dev", Params: map[string]string{"image": "clawker-app:latest"}})
+	record(whail.Operation{Action: "prune", Resource: "volume"})
+
+	entries, err := audit.Read([]string{path}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, audit.EventOperation, entries[0].Event)
+	assert.Equal(t, audit.ActorCLI, entries[0].Actor)
+	assert.Equal(t, "clawker.app.dev", entries[0].Target)
+	assert.Equal(t, "clawker-app:latest", entries[0].Params["image"])
+	assert.Equal(t, "prune", entries[1].Action)
+	assert.Empty(t, entries[1].Target)
+
+	// A journal that cannot be written never fails the Docker call.
+	bad := auditMutation(audit.ActorCLI, filepath.Join(t.TempDir(), "missing", "audit.log"), logger.Nop())
+	assert.NotPanics(t, func() { bad(whail.Operation{Action: "remove", Resource: "volume", Target: "v"}) })
+}
@@ -0,0 +1,178 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultContextName is the docker CLI's built-in context: the local daemon
+// at DOCKER_HOST or the platform default socket.
+const defaultContextName = "default"
+
+// Endpoint is the Docker daemon a client connects to and where that choice
+// came from.
+type Endpoint struct {
+	// Host is the daemon address (unix://, tcp://, npipe://, ssh://).
+	// Empty means the platform default socket.
+	Host string
+	// TLSCertDir holds the context's ca.pem/cert.pem/key.pem, if any.
+	TLSCertDir string
+	// Source names what selected the endpoint: "DOCKER_HOST", a docker
+	// context, or "default".
+	Source string
+}
+
+// String describes the endpoint for diagnostics:
+// `ssh://me@build-box (docker context "remote")`.
+func (e Endpoint) String() string {
+	host := e.Host
+	if host == "" {
+		host = "default local socket"
+	}
+	if e.Source == "" || e.Source == defaultContextName {
+		return host
+	}
+	return fmt.Sprintf("%s (%s)", host, e.Source)
+}
+
+// Remote reports whether the daemon runs on another machine: a tcp://,
+// http(s):// or ssh:// host that is not a loopback address. A remote
+// daemon resolves bind-mount sources against its own filesystem, and its
+// containers cannot reach services clawker runs on this host, such as the
+// host proxy.
+func (e Endpoint) Remote() bool {
+	u, err := url.Parse(e.Host)
+	if err != nil {
+		return false
+	}
+	switch u.Scheme {
+	case "tcp", "http", "https", "ssh":
+	default:
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return false
+	}
+	return true
+}
+
+// ResolveEndpoint picks the daemon to connect to, in the docker CLI's
+// order with clawker's setting slotted in before the CLI's own selection:
+//
+//  1. DOCKER_HOST
+//  2. DOCKER_CONTEXT
+//  3. settingsContext (settings.docker.context)
+//  4. the docker CLI's current context (currentContext in
+//     $DOCKER_CONFIG/config.json, default ~/.docker/config.json)
+//  5. the platform default socket
+//
+// A named context is read from the docker CLI's context store; a context
+// that does not exist is an error rather than a silent fallback to the
+// local daemon.
+func ResolveEndpoint(settingsContext string) (Endpoint, error) {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return Endpoint{Host: host, Source: "DOCKER_HOST"}, nil
+	}
+
+	configDir, err := dockerConfigDir()
+	if err != nil {
+		return Endpoint{}, err
+	}
+
+	name, source := os.Getenv("DOCKER_CONTEXT"), "DOCKER_CONTEXT"
+	if name == "" {
+		name, source = settingsContext, "settings.docker.context"
+	}
+	if name == "" {
+		name, source = currentDockerContext(configDir), "docker context"
+	}
+	if name == "" || name == defaultContextName {
+		return Endpoint{Source: defaultContextName}, nil
+	}
+	return loadDockerContext(configDir, name, source)
+}
+
+// dockerConfigDir is the docker CLI's config directory: $DOCKER_CONFIG or
+// ~/.docker.
+func dockerConfigDir() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving docker config dir: %w", err)
+	}
+	return filepath.Join(home, ".docker"), nil
+}
+
+// currentDockerContext reads currentContext from the docker CLI config. A
+// missing or unreadable config means no selection.
+func currentDockerContext(configDir string) string {
+	data, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+	if err != nil {
+		return ""
+	}
+	var cfg struct {
+		CurrentContext string `json:"currentContext"`
+	}
+	if json.Unmarshal(data, &cfg) != nil {
+		return ""
+	}
+	return cfg.CurrentContext
+}
+
+// dockerContextMeta is the part of a context store meta.json clawker reads.
+type dockerContextMeta struct {
+	Endpoints map[string]struct {
+		Host string `json:"Host"`
+	} `json:"Endpoints"`
+}
+
+// loadDockerContext reads a named context from the docker CLI's context
+// store, where each context lives under a directory named for the SHA-256
+// of its name: contexts/meta/<digest>/meta.json for the endpoint and
+// contexts/tls/<digest>/docker/ for its TLS material.
+func loadDockerContext(configDir, name, source string) (Endpoint, error) {
+	sum := sha256.Sum256([]byte(name))
+	digest := hex.EncodeToString(sum[:])
+	label := fmt.Sprintf("docker context %q", name)
+	if source != "docker context" {
+		label = fmt.Sprintf("docker context %q from %s", name, source)
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "contexts", "meta", digest, "meta.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Endpoint{}, fmt.Errorf("%s not found; list contexts with: docker context ls", label)
+	}
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("reading %s: %w", label, err)
+	}
+	var meta dockerContextMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return Endpoint{}, fmt.Errorf("parsing %s: %w", label, err)
+	}
+	host := strings.TrimSpace(meta.Endpoints["docker"].Host)
+	if host == "" {
+		return Endpoint{}, fmt.Errorf("%s has no docker endpoint", label)
+	}
+
+	ep := Endpoint{Host: host, Source: label}
+	tlsDir := filepath.Join(configDir, "contexts", "tls", digest, "docker")
+	if _, err := os.Stat(filepath.Join(tlsDir, "ca.pem")); err == nil {
+		ep.TLSCertDir = tlsDir
+	}
+	return ep, nil
+}
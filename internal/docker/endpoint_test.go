@@ -0,0 +1,129 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeDockerContext adds a context to a docker CLI context store under dir,
+// laid out the way `docker context create` writes it.
+func writeDockerContext(t *testing.T, dir, name, host string, withTLS bool) {
+	t.Helper()
+	sum := sha256.Sum256([]byte(name))
+	digest := hex.EncodeToString(sum[:])
+	metaDir := filepath.Join(dir, "contexts", "meta", digest)
+	require.NoError(t, os.MkdirAll(metaDir, 0o755))
+	meta := `{"Name":"` + name + `","Metadata":{},"Endpoints":{"docker":{"Host":"` + host + `","SkipTLSVerify":false}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(metaDir, "meta.json"), []byte(meta), 0o644))
+	if withTLS {
+		tlsDir := filepath.Join(dir, "contexts", "tls", digest, "docker")
+		require.NoError(t, os.MkdirAll(tlsDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(tlsDir, "ca.pem"), []byte("ca"), 0o644))
+	}
+}
+
+func TestResolveEndpoint(t *testing.T) {
+	dir := t.TempDir()
+	writeDockerContext(t, dir, "remote", "ssh://me@build-box", false)
+	writeDockerContext(t, dir, "tls", "tcp://10.0.0.5:2376", true)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"currentContext":"tls"}`), 0o644))
+
+	tests := []struct {
+		name            string
+		dockerHost      string
+		dockerContext   string
+		settingsContext string
+		want            Endpoint
+	}{
+		{
+			name:       "DOCKER_HOST wins",
+			dockerHost: "tcp://192.168.1.2:2375", dockerContext: "remote", settingsContext: "remote",
+			want: Endpoint{Host: "tcp://192.168.1.2:2375", Source: "DOCKER_HOST"},
+		},
+		{
+			name:          "DOCKER_CONTEXT over the setting",
+			dockerContext: "remote", settingsContext: "tls",
+			want: Endpoint{Host: "ssh://me@build-box", Source: `docker context "remote" from DOCKER_CONTEXT`},
+		},
+		{
+			name:            "setting over the CLI's current context",
+			settingsContext: "remote",
+			want:            Endpoint{Host: "ssh://me@build-box", Source: `docker context "remote" from settings.docker.context`},
+		},
+		{
+			name: "CLI current context with TLS material",
+			want: Endpoint{
+				Host:       "tcp://10.0.0.5:2376",
+				TLSCertDir: filepath.Join(dir, "contexts", "tls", func() string { s := sha256.Sum256([]byte("tls")); return hex.EncodeToString(s[:]) }(), "docker"),
+				Source:     `docker context "tls"`,
+			},
+		},
+		{
+			name:            "default context is the local daemon",
+			settingsContext: "default",
+			want:            Endpoint{Source: "default"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DOCKER_CONFIG", dir)
+			t.Setenv("DOCKER_HOST", tt.dockerHost)
+			t.Setenv("DOCKER_CONTEXT", tt.dockerContext)
+			got, err := ResolveEndpoint(tt.settingsContext)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveEndpoint_NoCLIConfig(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	t.Setenv("DOCKER_HOST", "")
+	t.Setenv("DOCKER_CONTEXT", "")
+	got, err := ResolveEndpoint("")
+	require.NoError(t, err)
+	assert.Equal(t, Endpoint{Source: "default"}, got)
+	assert.Equal(t, "default local socket", got.String())
+}
+
+func TestResolveEndpoint_UnknownContext(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	t.Setenv("DOCKER_HOST", "")
+	t.Setenv("DOCKER_CONTEXT", "")
+	_, err := ResolveEndpoint("gone")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `docker context "gone" from settings.docker.context not found`)
+}
+
+func TestEndpointString(t *testing.T) {
+	ep := Endpoint{Host: "ssh://me@build-box", Source: `docker context "remote"`}
+	assert.Equal(t, `ssh://me@build-box (docker context "remote")`, ep.String())
+}
+
+func TestEndpointRemote(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"", false},
+		{"unix:///var/run/docker.sock", false},
+		{"npipe:////./pipe/docker_engine", false},
+		{"tcp://127.0.0.1:2375", false},
+		{"tcp://localhost:2375", false},
+		{"tcp://[::1]:2375", false},
+		{"tcp://10.0.0.5:2376", true},
+		{"ssh://me@build-box", true},
+		{"https://docker.example.com:2376", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			assert.Equal(t, tt.want, Endpoint{Host: tt.host}.Remote())
+		})
+	}
+}
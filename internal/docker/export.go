@@ -0,0 +1,103 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExportWorkspace copies the workspace at destPath out of a container
+// (running or stopped) into the host directory dir, replacing what dir held.
+// The copy is assembled next to dir and swapped in, so an interrupted export
+// leaves the previous one intact. Entries are the container's own, including
+// its .git directory; nothing is filtered, since the workspace volume never
+// received ignored paths.
+func (c *Client) ExportWorkspace(ctx context.Context, containerID, destPath, dir string) error {
+	res, err := c.CopyFromContainer(ctx, containerID, CopyFromContainerOptions{SourcePath: destPath})
+	if err != nil {
+		return fmt.Errorf("reading container workspace: %w", err)
+	}
+	defer res.Content.Close()
+
+	parent := filepath.Dir(dir)
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+	tmp, err := os.MkdirTemp(parent, filepath.Base(dir)+".tmp-")
+	if err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := extractWorkspaceArchive(res.Content, tmp); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("removing previous export: %w", err)
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return fmt.Errorf("replacing export: %w", err)
+	}
+	return nil
+}
+
+// extractWorkspaceArchive writes a CopyFromContainer archive of a workspace
+// into root. Entries are rooted at the workspace directory's own name, which
+// is stripped. Entries that would land outside root are refused.
+func extractWorkspaceArchive(r io.Reader, root string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading container workspace: %w", err)
+		}
+
+		_, rel, ok := strings.Cut(strings.TrimSuffix(hdr.Name, "/"), "/")
+		if !ok || rel == "" {
+			continue
+		}
+		if !filepath.IsLocal(rel) {
+			return fmt.Errorf("container workspace entry %q escapes the workspace", hdr.Name)
+		}
+		target := filepath.Join(root, filepath.FromSlash(rel))
+		mode := hdr.FileInfo().Mode().Perm()
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode|0o700); err != nil {
+				return fmt.Errorf("exporting %s: %w", rel, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return fmt.Errorf("exporting %s: %w", rel, err)
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+			if err != nil {
+				return fmt.Errorf("exporting %s: %w", rel, err)
+			}
+			_, err = io.Copy(f, tr)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return fmt.Errorf("exporting %s: %w", rel, err)
+			}
+			_ = os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return fmt.Errorf("exporting %s: %w", rel, err)
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return fmt.Errorf("exporting %s: %w", rel, err)
+			}
+		}
+	}
+}
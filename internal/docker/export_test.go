@@ -0,0 +1,65 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func workspaceArchive(t *testing.T, entries ...*tar.Header) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, hdr := range entries {
+		if hdr.Typeflag == tar.TypeReg {
+			hdr.Size = int64(len(hdr.Linkname))
+		}
+		body := hdr.Linkname
+		if hdr.Typeflag == tar.TypeReg {
+			hdr.Linkname = ""
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		if hdr.Typeflag == tar.TypeReg {
+			_, err := tw.Write([]byte(body))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	return &buf
+}
+
+func TestExtractWorkspaceArchive(t *testing.T) {
+	root := t.TempDir()
+	// Regular file content is carried in Linkname for brevity.
+	archive := workspaceArchive(t,
+		&tar.Header{Name: "workspace/", Typeflag: tar.TypeDir, Mode: 0o755},
+		&tar.Header{Name: "workspace/src/", Typeflag: tar.TypeDir, Mode: 0o755},
+		&tar.Header{Name: "workspace/src/main.go", Typeflag: tar.TypeReg, Mode: 0o644, Linkname: "package main\n"},
+		&tar.Header{Name: "workspace/run.sh", Typeflag: tar.TypeReg, Mode: 0o755, Linkname: "#!/bin/sh\n"},
+		&tar.Header{Name: "workspace/link", Typeflag: tar.TypeSymlink, Linkname: "src/main.go"},
+	)
+
+	require.NoError(t, extractWorkspaceArchive(archive, root))
+
+	data, err := os.ReadFile(filepath.Join(root, "src", "main.go"))
+	require.NoError(t, err)
+	require.Equal(t, "package main\n", string(data))
+	info, err := os.Stat(filepath.Join(root, "run.sh"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+	link, err := os.Readlink(filepath.Join(root, "link"))
+	require.NoError(t, err)
+	require.Equal(t, "src/main.go", link)
+}
+
+func TestExtractWorkspaceArchive_RejectsEscape(t *testing.T) {
+	archive := workspaceArchive(t,
+		&tar.Header{Name: "workspace/../../evil", Typeflag: tar.TypeReg, Mode: 0o644, Linkname: "x"},
+	)
+	err := extractWorkspaceArchive(archive, t.TempDir())
+	require.ErrorContains(t, err, "escapes the workspace")
+}
@@ -0,0 +1,331 @@
+package docker
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/moby/moby/api/types/system"
+	"gopkg.in/yaml.v3"
+
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+// HostProvider is the product serving the Docker API.
+type HostProvider string
+
+const (
+	ProviderUnknown        HostProvider = ""
+	ProviderDockerEngine   HostProvider = "docker-engine"
+	ProviderDockerDesktop  HostProvider = "docker-desktop"
+	ProviderColima         HostProvider = "colima"
+	ProviderRancherDesktop HostProvider = "rancher-desktop"
+	ProviderOrbStack       HostProvider = "orbstack"
+	ProviderPodman         HostProvider = "podman"
+)
+
+// FileSharing is how a VM-backed provider shares host directories with the
+// Docker VM, which decides bind-mount speed and semantics.
+type FileSharing string
+
+const (
+	FileSharingUnknown  FileSharing = ""
+	FileSharingVirtioFS FileSharing = "virtiofs"
+	FileSharingGRPCFUSE FileSharing = "grpcfuse"
+	FileSharingOSXFS    FileSharing = "osxfs"
+	FileSharingSSHFS    FileSharing = "sshfs"
+	FileSharing9P       FileSharing = "9p"
+)
+
+// limaHostAddress is the host's loopback as seen from a Lima VM's
+// user-mode network (host.lima.internal). Colima and Rancher Desktop on
+// macOS and Linux run their daemon in a Lima VM.
+const limaHostAddress = "192.168.5.2"
+
+// HostRuntime describes the Docker provider behind the connected daemon.
+type HostRuntime struct {
+	Provider HostProvider
+	// Profile is the Colima profile ("default" unless colima start -p).
+	Profile string
+	// Remote is set for tcp:// and ssh:// endpoints on another machine
+	// (Endpoint.Remote), so no host-side adaptation applies.
+	Remote bool
+	// FileSharing is read from the provider's own settings; unknown when
+	// the provider has no VM share or its settings are unreadable.
+	FileSharing FileSharing
+	// WSL is set for Rancher Desktop's WSL distribution on Windows, which
+	// is not Lima-based.
+	WSL bool
+}
+
+// DetectHostRuntime identifies the provider from the daemon's /info and the
+// endpoint it was reached at, and reads the provider's file-sharing setting
+// from the host. A zero info (no /info at connect) gives ProviderUnknown.
+func DetectHostRuntime(info system.Info, compat whail.Compat, endpoint Endpoint) HostRuntime {
+	home, _ := os.UserHomeDir()
+	return detectHostRuntime(info, compat, endpoint, home, runtime.GOOS)
+}
+
+func detectHostRuntime(info system.Info, compat whail.Compat, endpoint Endpoint, home, goos string) HostRuntime {
+	rt := HostRuntime{
+		Provider: detectProvider(info, compat, endpoint.Host),
+		Remote:   endpoint.Remote(),
+	}
+	switch rt.Provider {
+	case ProviderColima:
+		rt.Profile = colimaProfile(info.Name, endpoint.Host)
+		rt.FileSharing = colimaFileSharing(home, rt.Profile)
+	case ProviderRancherDesktop:
+		rt.WSL = strings.Contains(info.OperatingSystem, "WSL")
+		rt.FileSharing = rancherFileSharing(home, goos)
+	case ProviderDockerDesktop:
+		rt.FileSharing = dockerDesktopFileSharing(home, goos)
+	}
+	return rt
+}
+
+// detectProvider matches the markers each provider leaves in /info — the
+// OperatingSystem string, or the VM's host name — falling back to the
+// socket path the provider installs.
+func detectProvider(info system.Info, compat whail.Compat, host string) HostProvider {
+	switch {
+	case compat.Podman:
+		return ProviderPodman
+	case info.OperatingSystem == "OrbStack" || strings.Contains(host, "/.orbstack/"):
+		return ProviderOrbStack
+	case strings.HasPrefix(info.OperatingSystem, "Docker Desktop"):
+		return ProviderDockerDesktop
+	case strings.Contains(info.OperatingSystem, "Rancher Desktop") ||
+		info.Name == "lima-rancher-desktop" || strings.Contains(host, "/.rd/"):
+		return ProviderRancherDesktop
+	case info.Name == "colima" || strings.HasPrefix(info.Name, "colima-") || strings.Contains(host, "/.colima/"):
+		return ProviderColima
+	case strings.Contains(host, "/.docker/run/"):
+		return ProviderDockerDesktop
+	case info.OperatingSystem != "":
+		return ProviderDockerEngine
+	}
+	return ProviderUnknown
+}
+
+// colimaProfile reads the profile from the VM host name (colima,
+// colima-<profile>) or the socket path (~/.colima/<profile>/docker.sock).
+func colimaProfile(name, host string) string {
+	if p, ok := strings.CutPrefix(name, "colima-"); ok && p != "" {
+		return p
+	}
+	if _, rest, ok := strings.Cut(host, "/.colima/"); ok {
+		if p, _, ok := strings.Cut(rest, "/"); ok && p != "" && p != "default" {
+			return p
+		}
+	}
+	return "default"
+}
+
+// colimaFileSharing reads mountType from the profile's colima.yaml under
+// $COLIMA_HOME (default ~/.colima).
+func colimaFileSharing(home, profile string) FileSharing {
+	dir := os.Getenv("COLIMA_HOME")
+	if dir == "" {
+		dir = filepath.Join(home, ".colima")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, profile, "colima.yaml"))
+	if err != nil {
+		return FileSharingUnknown
+	}
+	var cfg struct {
+		MountType string `yaml:"mountType"`
+	}
+	if yaml.Unmarshal(data, &cfg) != nil {
+		return FileSharingUnknown
+	}
+	return parseFileSharing(cfg.MountType)
+}
+
+// rancherFileSharing reads the VM mount type from Rancher Desktop's
+// settings.json (virtualMachine.mount.type, formerly under experimental).
+func rancherFileSharing(home, goos string) FileSharing {
+	var path string
+	switch goos {
+	case "darwin":
+		path = filepath.Join(home, "Library", "Application Support", "rancher-desktop", "settings.json")
+	case "linux":
+		path = filepath.Join(home, ".config", "rancher-desktop", "settings.json")
+	default:
+		return FileSharingUnknown
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return FileSharingUnknown
+	}
+	type vmMount struct {
+		VirtualMachine struct {
+			Mount struct {
+				Type string `json:"type"`
+			} `json:"mount"`
+		} `json:"virtualMachine"`
+	}
+	var settings struct {
+		vmMount
+		Experimental vmMount `json:"experimental"`
+	}
+	if json.Unmarshal(data, &settings) != nil {
+		return FileSharingUnknown
+	}
+	if t := settings.VirtualMachine.Mount.Type; t != "" {
+		return parseFileSharing(t)
+	}
+	return parseFileSharing(settings.Experimental.VirtualMachine.Mount.Type)
+}
+
+// dockerDesktopFileSharing reads the file-sharing implementation from
+// Docker Desktop's macOS settings (settings-store.json, or settings.json
+// before 4.35). Keys differ in case between the two files.
+func dockerDesktopFileSharing(home, goos string) FileSharing {
+	if goos != "darwin" {
+		return FileSharingUnknown
+	}
+	dir := filepath.Join(home, "Library", "Group Containers", "group.com.docker")
+	for _, name := range []string{"settings-store.json", "settings.json"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		var settings map[string]any
+		if json.Unmarshal(data, &settings) != nil {
+			continue
+		}
+		flag := func(key string) bool {
+			for k, v := range settings {
+				if strings.EqualFold(k, key) {
+					b, _ := v.(bool)
+					return b
+				}
+			}
+			return false
+		}
+		switch {
+		case flag("useVirtualizationFrameworkVirtioFS"):
+			return FileSharingVirtioFS
+		case flag("useGrpcfuse"):
+			return FileSharingGRPCFUSE
+		default:
+			return FileSharingOSXFS
+		}
+	}
+	return FileSharingUnknown
+}
+
+func parseFileSharing(s string) FileSharing {
+	switch strings.ToLower(s) {
+	case "virtiofs":
+		return FileSharingVirtioFS
+	case "sshfs", "reverse-sshfs":
+		return FileSharingSSHFS
+	case "9p":
+		return FileSharing9P
+	}
+	return FileSharingUnknown
+}
+
+// Name describes the runtime for diagnostics: "Colima (profile dev)".
+func (r HostRuntime) Name() string {
+	var name string
+	switch r.Provider {
+	case ProviderDockerEngine:
+		name = "Docker Engine"
+	case ProviderDockerDesktop:
+		name = "Docker Desktop"
+	case ProviderColima:
+		name = "Colima"
+		if r.Profile != "" && r.Profile != "default" {
+			name += " (profile " + r.Profile + ")"
+		}
+	case ProviderRancherDesktop:
+		name = "Rancher Desktop"
+	case ProviderOrbStack:
+		name = "OrbStack"
+	case ProviderPodman:
+		name = "Podman"
+	default:
+		name = "unknown Docker provider"
+	}
+	if r.Remote {
+		name += " (remote)"
+	}
+	return name
+}
+
+// ExtraHosts returns the /etc/hosts entries a container needs to reach the
+// host proxy at host.docker.internal. Docker Desktop, OrbStack, Podman, and
+// Rancher Desktop on WSL resolve the name themselves. In a Lima VM (Colima,
+// Rancher Desktop) the daemon's host-gateway is the VM, not the machine
+// running clawker, so the name is pinned to Lima's host address; native
+// Docker Engine gets the host-gateway alias Docker Desktop provides
+// implicitly. A remote daemon's host is not this machine, so nothing is added.
+func (r HostRuntime) ExtraHosts() []string {
+	if r.Remote {
+		return nil
+	}
+	switch {
+	case r.Provider == ProviderColima, r.Provider == ProviderRancherDesktop && !r.WSL:
+		return []string{consts.DockerHostInternal + ":" + limaHostAddress}
+	case r.Provider == ProviderDockerEngine:
+		return []string{consts.DockerHostInternal + ":host-gateway"}
+	}
+	return nil
+}
+
+// FileSharingAdvice is what clawker doctor reports about bind mounts on a
+// runtime's file share.
+type FileSharingAdvice struct {
+	// Slow is set when bind-mounted workspaces will be noticeably slow.
+	Slow bool
+	// Cautions lists behaviors of the share that affect agents.
+	Cautions []string
+	// Hint is how to switch to a faster share; set only when Slow.
+	Hint string
+}
+
+// FileSharingAdvice returns the bind-mount cautions for the runtime's file
+// share. Native Linux daemons and unknown shares return the zero value.
+func (r HostRuntime) FileSharingAdvice() FileSharingAdvice {
+	if r.Remote {
+		return FileSharingAdvice{Cautions: []string{
+			"bind mounts resolve on the remote host, not this machine; use snapshot workspaces",
+		}}
+	}
+	switch r.FileSharing {
+	case FileSharingVirtioFS:
+		return FileSharingAdvice{Cautions: []string{
+			"virtiofs: ownership changes made in the container (chown) are not reflected on the host",
+		}}
+	case FileSharingGRPCFUSE, FileSharingOSXFS:
+		return FileSharingAdvice{
+			Slow: true,
+			Cautions: []string{
+				string(r.FileSharing) + ": bind-mounted workspaces are slow for large trees (dependency installs, builds, git status)",
+				string(r.FileSharing) + ": file watchers in the container may miss host edits",
+			},
+			Hint: "switch Docker Desktop to VirtioFS (Settings > General > file sharing implementation)",
+		}
+	case FileSharingSSHFS, FileSharing9P:
+		advice := FileSharingAdvice{
+			Slow: true,
+			Cautions: []string{
+				string(r.FileSharing) + ": bind-mounted workspaces are slow for large trees (dependency installs, builds, git status)",
+				string(r.FileSharing) + ": file watchers in the container do not see host edits",
+			},
+		}
+		if r.Provider == ProviderColima {
+			advice.Hint = "recreate the VM with virtiofs: colima delete && colima start --vm-type vz --mount-type virtiofs"
+		} else {
+			advice.Hint = "switch Rancher Desktop to virtiofs (Preferences > Virtual Machine > Volumes)"
+		}
+		return advice
+	}
+	return FileSharingAdvice{}
+}
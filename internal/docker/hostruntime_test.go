@@ -0,0 +1,159 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moby/moby/api/types/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+func TestDetectHostRuntime_Provider(t *testing.T) {
+	t.Setenv("COLIMA_HOME", "")
+	home := t.TempDir()
+	tests := []struct {
+		name   string
+		info   system.Info
+		compat whail.Compat
+		host   string
+		want   HostRuntime
+	}{
+		{
+			name: "docker desktop",
+			info: system.Info{OperatingSystem: "Docker Desktop", Name: "docker-desktop"},
+			want: HostRuntime{Provider: ProviderDockerDesktop},
+		},
+		{
+			name: "orbstack",
+			info: system.Info{OperatingSystem: "OrbStack", Name: "orbstack"},
+			want: HostRuntime{Provider: ProviderOrbStack},
+		},
+		{
+			name: "colima default profile",
+			info: system.Info{OperatingSystem: "Ubuntu 24.04 LTS", Name: "colima"},
+			want: HostRuntime{Provider: ProviderColima, Profile: "default"},
+		},
+		{
+			name: "colima named profile",
+			info: system.Info{OperatingSystem: "Ubuntu 24.04 LTS", Name: "colima-dev"},
+			want: HostRuntime{Provider: ProviderColima, Profile: "dev"},
+		},
+		{
+			name: "colima by socket",
+			host: "unix:///Users/me/.colima/work/docker.sock",
+			want: HostRuntime{Provider: ProviderColima, Profile: "work"},
+		},
+		{
+			name: "rancher desktop lima",
+			info: system.Info{OperatingSystem: "Alpine Linux v3.20", Name: "lima-rancher-desktop"},
+			want: HostRuntime{Provider: ProviderRancherDesktop},
+		},
+		{
+			name: "rancher desktop wsl",
+			info: system.Info{OperatingSystem: "Rancher Desktop WSL Distribution"},
+			want: HostRuntime{Provider: ProviderRancherDesktop, WSL: true},
+		},
+		{
+			name:   "podman",
+			info:   system.Info{OperatingSystem: "fedora"},
+			compat: whail.Compat{Podman: true},
+			want:   HostRuntime{Provider: ProviderPodman},
+		},
+		{
+			name: "native engine",
+			info: system.Info{OperatingSystem: "Debian GNU/Linux 12 (bookworm)"},
+			want: HostRuntime{Provider: ProviderDockerEngine},
+		},
+		{
+			name: "remote engine",
+			info: system.Info{OperatingSystem: "Ubuntu 22.04.4 LTS"},
+			host: "ssh://me@build-box",
+			want: HostRuntime{Provider: ProviderDockerEngine, Remote: true},
+		},
+		{
+			name: "no info",
+			want: HostRuntime{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectHostRuntime(tt.info, tt.compat, Endpoint{Host: tt.host}, home, "darwin")
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDetectHostRuntime_FileSharing(t *testing.T) {
+	t.Setenv("COLIMA_HOME", "")
+
+	t.Run("colima mountType", func(t *testing.T) {
+		home := t.TempDir()
+		writeFile(t, filepath.Join(home, ".colima", "default", "colima.yaml"), "cpu: 4\nmountType: sshfs\n")
+		rt := detectHostRuntime(system.Info{Name: "colima"}, whail.Compat{}, Endpoint{}, home, "darwin")
+		assert.Equal(t, FileSharingSSHFS, rt.FileSharing)
+		assert.True(t, rt.FileSharingAdvice().Slow)
+		assert.Contains(t, rt.FileSharingAdvice().Hint, "colima start")
+	})
+
+	t.Run("docker desktop settings-store", func(t *testing.T) {
+		home := t.TempDir()
+		writeFile(t, filepath.Join(home, "Library", "Group Containers", "group.com.docker", "settings-store.json"),
+			`{"UseVirtualizationFrameworkVirtioFS": false, "UseGrpcfuse": true}`)
+		rt := detectHostRuntime(system.Info{OperatingSystem: "Docker Desktop"}, whail.Compat{}, Endpoint{}, home, "darwin")
+		assert.Equal(t, FileSharingGRPCFUSE, rt.FileSharing)
+		assert.True(t, rt.FileSharingAdvice().Slow)
+	})
+
+	t.Run("docker desktop legacy settings", func(t *testing.T) {
+		home := t.TempDir()
+		writeFile(t, filepath.Join(home, "Library", "Group Containers", "group.com.docker", "settings.json"),
+			`{"useVirtualizationFrameworkVirtioFS": true}`)
+		rt := detectHostRuntime(system.Info{OperatingSystem: "Docker Desktop"}, whail.Compat{}, Endpoint{}, home, "darwin")
+		assert.Equal(t, FileSharingVirtioFS, rt.FileSharing)
+		advice := rt.FileSharingAdvice()
+		assert.False(t, advice.Slow)
+		assert.NotEmpty(t, advice.Cautions)
+	})
+
+	t.Run("rancher desktop experimental key", func(t *testing.T) {
+		home := t.TempDir()
+		writeFile(t, filepath.Join(home, ".config", "rancher-desktop", "settings.json"),
+			`{"experimental": {"virtualMachine": {"mount": {"type": "9p"}}}}`)
+		rt := detectHostRuntime(system.Info{Name: "lima-rancher-desktop"}, whail.Compat{}, Endpoint{}, home, "linux")
+		assert.Equal(t, FileSharing9P, rt.FileSharing)
+	})
+
+	t.Run("unreadable settings", func(t *testing.T) {
+		rt := detectHostRuntime(system.Info{OperatingSystem: "Docker Desktop"}, whail.Compat{}, Endpoint{}, t.TempDir(), "darwin")
+		assert.Equal(t, FileSharingUnknown, rt.FileSharing)
+		assert.Equal(t, FileSharingAdvice{}, rt.FileSharingAdvice())
+	})
+}
+
+func TestHostRuntime_ExtraHosts(t *testing.T) {
+	assert.Equal(t, []string{"host.docker.internal:192.168.5.2"}, HostRuntime{Provider: ProviderColima}.ExtraHosts())
+	assert.Equal(t, []string{"host.docker.internal:192.168.5.2"}, HostRuntime{Provider: ProviderRancherDesktop}.ExtraHosts())
+	assert.Equal(t, []string{"host.docker.internal:host-gateway"}, HostRuntime{Provider: ProviderDockerEngine}.ExtraHosts())
+	assert.Nil(t, HostRuntime{Provider: ProviderRancherDesktop, WSL: true}.ExtraHosts())
+	assert.Nil(t, HostRuntime{Provider: ProviderDockerDesktop}.ExtraHosts())
+	assert.Nil(t, HostRuntime{Provider: ProviderOrbStack}.ExtraHosts())
+	assert.Nil(t, HostRuntime{Provider: ProviderPodman}.ExtraHosts())
+	assert.Nil(t, HostRuntime{Provider: ProviderColima, Remote: true}.ExtraHosts(), "a remote daemon's host is not this machine")
+	assert.Nil(t, HostRuntime{}.ExtraHosts())
+}
+
+func TestHostRuntime_Name(t *testing.T) {
+	assert.Equal(t, "Colima (profile dev)", HostRuntime{Provider: ProviderColima, Profile: "dev"}.Name())
+	assert.Equal(t, "Colima", HostRuntime{Provider: ProviderColima, Profile: "default"}.Name())
+	assert.Equal(t, "Docker Engine (remote)", HostRuntime{Provider: ProviderDockerEngine, Remote: true}.Name())
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
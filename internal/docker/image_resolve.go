@@ -30,8 +30,7 @@ func (c *Client) findProjectImage(ctx context.Context, projectName string, wantT
 		return "", nil
 	}
 
-	f := c.ClawkerFilter().
-		Add("label", c.cfg.LabelProject()+"="+projectName)
+	f := c.ProjectFilter(projectName)
 
 	result, err := c.ImageList(ctx, ImageListOptions{
 		Filters: f,
@@ -26,6 +26,23 @@ func (c *Client) ContainerLabels(project, agent, version, image, workdir string)
 	return labels
 }
 
+// SidecarLabels returns labels for an agent's sidecar container. The owning
+// agent goes under consts.LabelSidecarAgent, not the agent label, so
+// agent lookups (FindAgentContainer, AgentFilter) never match a sidecar.
+func (c *Client) SidecarLabels(project, agent, sidecar string) map[string]string {
+	labels := map[string]string{
+		c.cfg.LabelManaged():     c.cfg.ManagedLabelValue(),
+		c.cfg.LabelPurpose():     consts.PurposeSidecar,
+		c.cfg.LabelCreated():     time.Now().Format(time.RFC3339),
+		consts.LabelSidecar:      sidecar,
+		consts.LabelSidecarAgent: agent,
+	}
+	if project != "" {
+		labels[c.cfg.LabelProject()] = project
+	}
+	return labels
+}
+
 // AgentVolumeLabels returns labels for an agent-scoped volume (history or
 // workspace). All agent volumes carry purpose=PurposeAgent; the per-volume
 // role lives in the volume name suffix, not the label.
@@ -76,22 +93,37 @@ func (c *Client) NetworkLabels() map[string]string {
 	}
 }
 
+// LabelMigrations returns the whail label migration steps for
+// consts.LabelSchemaVersion, keyed by the version each step upgrades from.
+// Version 1 is the label layout clawker used before versioning, so
+// resources without a schema label are current and there is no step yet.
+// When a key is renamed or removed, bump consts.LabelSchemaVersion and add
+// the step from the previous version here.
+func LabelMigrations() map[int]whail.LabelMigration {
+	return map[int]whail.LabelMigration{}
+}
+
+// Query starts a whail label query in clawker's label namespace, seeded with
+// the managed label, so Project/Agent/Purpose resolve to consts.LabelProject,
+// consts.LabelAgent, and consts.LabelPurpose. Keys are compile-time constants,
+// which is what lets the filter helpers below use MustFilters.
+func Query() whail.LabelQuery {
+	return whail.Query().
+		Prefix(consts.EngineLabelPrefix).
+		Label(consts.LabelManaged, consts.ManagedLabelValue)
+}
+
 // ClawkerFilter returns Docker filter for listing all clawker resources.
 func (c *Client) ClawkerFilter() whail.Filters {
-	return whail.Filters{}.Add("label", c.cfg.LabelManaged()+"="+c.cfg.ManagedLabelValue())
+	return Query().MustFilters()
 }
 
 // ProjectFilter returns Docker filter for a specific project.
 func (c *Client) ProjectFilter(project string) whail.Filters {
-	return whail.Filters{}.
-		Add("label", c.cfg.LabelManaged()+"="+c.cfg.ManagedLabelValue()).
-		Add("label", c.cfg.LabelProject()+"="+project)
+	return Query().Project(project).MustFilters()
 }
 
 // AgentFilter returns Docker filter for a specific agent within a project.
 func (c *Client) AgentFilter(project, agent string) whail.Filters {
-	return whail.Filters{}.
-		Add("label", c.cfg.LabelManaged()+"="+c.cfg.ManagedLabelValue()).
-		Add("label", c.cfg.LabelProject()+"="+project).
-		Add("label", c.cfg.LabelAgent()+"="+agent)
+	return Query().Project(project).Agent(agent).MustFilters()
 }
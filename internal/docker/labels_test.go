@@ -132,6 +132,27 @@ func TestAgentVolumeLabels(t *testing.T) {
 	})
 }
 
+func TestSidecarLabels(t *testing.T) {
+	c, cfg := testClient(t)
+
+	labels := c.SidecarLabels("myproject", "dev", "db")
+	expected := map[string]string{
+		cfg.LabelManaged():       cfg.ManagedLabelValue(),
+		cfg.LabelProject():       "myproject",
+		cfg.LabelPurpose():       consts.PurposeSidecar,
+		consts.LabelSidecar:      "db",
+		consts.LabelSidecarAgent: "dev",
+	}
+	for key, want := range expected {
+		if got := labels[key]; got != want {
+			t.Errorf("labels[%q] = %q, want %q", key, got, want)
+		}
+	}
+	if _, ok := labels[cfg.LabelAgent()]; ok {
+		t.Error("sidecar labels must not carry LabelAgent, or agent lookups would match sidecars")
+	}
+}
+
 func TestHarnessVolumeLabels(t *testing.T) {
 	c, _ := testClient(t)
 
@@ -270,3 +291,38 @@ func TestAgentFilter(t *testing.T) {
 		t.Error("AgentFilter should include agent label")
 	}
 }
+
+// TestQuery_MatchesLabelConstants pins the whail suffix conventions to
+// clawker's label constants: a drift in either would silently make every
+// project/agent list come back empty.
+func TestQuery_MatchesLabelConstants(t *testing.T) {
+	f := Query().Project("p").Agent("a").Purpose(consts.PurposeAgent).MustFilters()
+
+	labelFilters := f["label"]
+	for _, want := range []string{
+		consts.LabelManaged + "=" + consts.ManagedLabelValue,
+		consts.LabelProject + "=p",
+		consts.LabelAgent + "=a",
+		consts.LabelPurpose + "=" + consts.PurposeAgent,
+	} {
+		if _, ok := labelFilters[want]; !ok {
+			t.Errorf("query missing label filter %q (got %v)", want, labelFilters)
+		}
+	}
+}
+
+func TestProjectNetworkLabels(t *testing.T) {
+	c, cfg := testClient(t)
+
+	labels := c.ProjectNetworkLabels("myproject")
+	expected := map[string]string{
+		cfg.LabelManaged(): cfg.ManagedLabelValue(),
+		cfg.LabelProject(): "myproject",
+		cfg.LabelPurpose(): consts.PurposeProjectNetwork,
+	}
+	for key, want := range expected {
+		if got := labels[key]; got != want {
+			t.Errorf("labels[%q] = %q, want %q", key, got, want)
+		}
+	}
+}
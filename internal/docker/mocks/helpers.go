@@ -13,10 +13,13 @@ import (
 	"github.com/moby/moby/api/pkg/stdcopy"
 	"github.com/moby/moby/api/types/build"
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/events"
 	dockerimage "github.com/moby/moby/api/types/image"
 	"github.com/moby/moby/api/types/network"
+	"github.com/moby/moby/api/types/registry"
 	"github.com/moby/moby/api/types/volume"
 	"github.com/moby/moby/client"
+	godigest "github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 
 	"github.com/schmitthub/clawker/internal/config"
@@ -299,6 +302,13 @@ func (f *FakeClient) SetupNetworkCreate() {
 	}
 }
 
+// SetupNetworkConnect configures the fake to succeed on NetworkConnect.
+func (f *FakeClient) SetupNetworkConnect() {
+	f.FakeAPI.NetworkConnectFn = func(_ context.Context, _ string, _ client.NetworkConnectOptions) (client.NetworkConnectResult, error) {
+		return client.NetworkConnectResult{}, nil
+	}
+}
+
 // SetupContainerAttach configures the fake to succeed on ContainerAttach,
 // returning a HijackedResponse backed by a net.Pipe. The server side of the
 // pipe is closed immediately, simulating a container that exits right away.
@@ -454,6 +464,49 @@ func (f *FakeClient) SetupContainerInspectReapState(autoRemove, running bool) {
 	}
 }
 
+// SetupContainerHealth configures ContainerInspect to report a running,
+// managed container whose healthcheck is in the given status, with output as
+// the last probe's output — the state read by whail's ContainerWaitHealthy.
+func (f *FakeClient) SetupContainerHealth(status container.HealthStatus, output string) {
+	f.FakeAPI.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		return client.ContainerInspectResult{
+			Container: container.InspectResponse{
+				ID: id,
+				Config: &container.Config{
+					Labels: map[string]string{
+						f.Cfg.LabelManaged(): f.Cfg.ManagedLabelValue(),
+					},
+				},
+				State: &container.State{
+					Running: true,
+					Health: &container.Health{
+						Status: status,
+						Log:    []*container.HealthcheckResult{{Output: output}},
+					},
+				},
+			},
+		}, nil
+	}
+}
+
+// SetupContainerPorts wires find-by-name for the container like
+// SetupFindContainer and makes its inspect data report the summary's run
+// state and the given published ports — the data read by container port and
+// run --wait-for-port.
+func (f *FakeClient) SetupContainerPorts(name string, c container.Summary, ports network.PortMap) {
+	f.SetupFindContainer(name, c)
+	find := f.FakeAPI.ContainerInspectFn
+	f.FakeAPI.ContainerInspectFn = func(ctx context.Context, id string, opts client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		result, err := find(ctx, id, opts)
+		if err != nil {
+			return result, err
+		}
+		result.Container.State = &container.State{Status: c.State, Running: c.State == "running"}
+		result.Container.NetworkSettings = &container.NetworkSettings{Ports: ports}
+		return result, nil
+	}
+}
+
 // SetupContainerLogs configures the fake to return the given string as log
 // output. The logs are returned as a plain io.ReadCloser (suitable for
 // non-multiplexed TTY output).
@@ -473,6 +526,21 @@ func (f *FakeClient) SetupContainerTop(titles []string, processes [][]string) {
 	}
 }
 
+// SetupContainerDiff configures the fake to return the given filesystem changes.
+func (f *FakeClient) SetupContainerDiff(changes ...container.FilesystemChange) {
+	f.FakeAPI.ContainerDiffFn = func(_ context.Context, _ string, _ client.ContainerDiffOptions) (client.ContainerDiffResult, error) {
+		return client.ContainerDiffResult{Changes: changes}, nil
+	}
+}
+
+// SetupContainerCommit configures the fake to succeed on ContainerCommit,
+// returning the given image ID.
+func (f *FakeClient) SetupContainerCommit(imageID string) {
+	f.FakeAPI.ContainerCommitFn = func(_ context.Context, _ string, _ client.ContainerCommitOptions) (client.ContainerCommitResult, error) {
+		return client.ContainerCommitResult{ID: imageID}, nil
+	}
+}
+
 // SetupContainerStats configures the fake to return a single JSON stats
 // response. The body is a one-shot io.ReadCloser containing the given JSON.
 // Pass an empty string for a minimal default stats response.
@@ -487,6 +555,26 @@ func (f *FakeClient) SetupContainerStats(statsJSON string) {
 	}
 }
 
+// SetupEvents configures the fake to stream the given events, then io.EOF
+// as the daemon does once a stream bounded by Until is drained.
+func (f *FakeClient) SetupEvents(msgs ...events.Message) {
+	f.FakeAPI.EventsFn = func(ctx context.Context, _ client.EventsListOptions) client.EventsResult {
+		msgCh := make(chan events.Message)
+		errCh := make(chan error, 1)
+		go func() {
+			for _, m := range msgs {
+				select {
+				case msgCh <- m:
+				case <-ctx.Done():
+					return
+				}
+			}
+			errCh <- io.EOF
+		}()
+		return client.EventsResult{Messages: msgCh, Err: errCh}
+	}
+}
+
 // SetupCopyFromContainer configures the fake to succeed on CopyFromContainer,
 // returning an empty tar stream.
 func (f *FakeClient) SetupCopyFromContainer() {
@@ -546,6 +634,27 @@ func (f *FakeClient) SetupExecAttachWithOutput(data string) {
 	}
 }
 
+// SetupExecAttachWithStreams is SetupExecAttachWithOutput with separate
+// stdout and stderr payloads, each written as its own stdcopy frame. An
+// empty payload writes no frame.
+func (f *FakeClient) SetupExecAttachWithStreams(stdout, stderr string) {
+	f.FakeAPI.ExecAttachFn = func(_ context.Context, _ string, _ client.ExecAttachOptions) (client.ExecAttachResult, error) {
+		clientConn, serverConn := net.Pipe()
+		go func() {
+			defer serverConn.Close()
+			if stdout != "" {
+				_, _ = writeStdcopyFrame(serverConn, byte(stdcopy.Stdout), []byte(stdout))
+			}
+			if stderr != "" {
+				_, _ = writeStdcopyFrame(serverConn, byte(stdcopy.Stderr), []byte(stderr))
+			}
+		}()
+		return client.ExecAttachResult{
+			HijackedResponse: client.NewHijackedResponse(clientConn, "application/vnd.docker.multiplexed-stream"),
+		}, nil
+	}
+}
+
 // SetupExecInspect configures the fake to return an ExecInspect result with
 // the given exit code. Running is set to false (exec completed).
 func (f *FakeClient) SetupExecInspect(exitCode int) {
@@ -557,6 +666,19 @@ func (f *FakeClient) SetupExecInspect(exitCode int) {
 	}
 }
 
+// SetupRegistryDigest configures the fake registry to report digest as the
+// current digest of ref (DistributionInspect); every other ref fails.
+func (f *FakeClient) SetupRegistryDigest(ref, digest string) {
+	f.FakeAPI.DistributionInspectFn = func(_ context.Context, image string, _ client.DistributionInspectOptions) (client.DistributionInspectResult, error) {
+		if image != ref {
+			return client.DistributionInspectResult{}, notFoundError(image)
+		}
+		return client.DistributionInspectResult{DistributionInspect: registry.DistributionInspect{
+			Descriptor: ocispec.Descriptor{Digest: godigest.Digest(digest)},
+		}}, nil
+	}
+}
+
 // SetupImageList configures the fake to return the given image summaries
 // from ImageList calls.
 func (f *FakeClient) SetupImageList(summaries ...whail.ImageSummary) {
@@ -567,6 +689,44 @@ func (f *FakeClient) SetupImageList(summaries ...whail.ImageSummary) {
 	}
 }
 
+// SetupImageHistory configures the fake so ref is a managed image whose layer
+// history (newest first) is items. Every other ref reports not found.
+func (f *FakeClient) SetupImageHistory(ref string, items ...dockerimage.HistoryResponseItem) {
+	f.SetupImageExists(ref, true)
+	f.FakeAPI.ImageHistoryFn = func(_ context.Context, image string, _ ...client.ImageHistoryOption) (client.ImageHistoryResult, error) {
+		if image != ref {
+			return client.ImageHistoryResult{}, notFoundError(image)
+		}
+		return client.ImageHistoryResult{Items: items}, nil
+	}
+}
+
+// SetupBuildCache configures the fake to report the given BuildKit build
+// cache records from DiskUsage.
+func (f *FakeClient) SetupBuildCache(records ...build.CacheRecord) {
+	f.FakeAPI.DiskUsageFn = func(_ context.Context, _ client.DiskUsageOptions) (client.DiskUsageResult, error) {
+		return client.DiskUsageResult{
+			BuildCache: client.BuildCacheDiskUsage{Items: records, TotalCount: int64(len(records))},
+		}, nil
+	}
+}
+
+// EnableState switches the fake to stateful mode: containers, images and
+// networks live in one in-memory store with realistic state transitions, so
+// a create → start → inspect flow needs no hand-wired responses. Seeded
+// resources carry clawker's managed label. Seed what the test needs through
+// the returned state; Setup helpers called afterwards still override single
+// methods.
+//
+//	state := fake.EnableState()
+//	state.AddImage("clawker-myapp:latest", nil)
+func (f *FakeClient) EnableState() *whailtest.FakeState {
+	state := whailtest.NewFakeState()
+	state.Labels = map[string]string{f.Cfg.LabelManaged(): f.Cfg.ManagedLabelValue()}
+	state.Install(f.FakeAPI)
+	return state
+}
+
 // MinimalCreateOpts returns the minimum ContainerCreateOptions needed for
 // whail's ContainerCreate to succeed (requires non-nil Config for label merging).
 func MinimalCreateOpts() docker.ContainerCreateOptions {
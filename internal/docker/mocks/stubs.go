@@ -27,6 +27,7 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 
 	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/pkg/whail"
 	"github.com/schmitthub/clawker/pkg/whail/whailtest"
@@ -51,18 +52,62 @@ type FakeClient struct {
 // FakeClientOption configures a FakeClient.
 type FakeClientOption func(*FakeClient)
 
+// WithDryRun builds the Client on a dry-run engine, as the factory does
+// under the global --dry-run: mutating calls are journaled for
+// DryRunReport instead of reaching FakeAPI.
+func WithDryRun() FakeClientOption {
+	return func(f *FakeClient) {
+		f.Client = docker.NewClientFromEngine(newFakeEngine(f.FakeAPI, f.Cfg, true), f.Cfg, nil)
+	}
+}
+
+// WithEndpoint makes the Client report ep as its daemon, e.g. a remote
+// ssh:// host. Apply after WithDryRun and WithLabelSchemaVersion, which
+// rebuild the Client.
+func WithEndpoint(ep docker.Endpoint) FakeClientOption {
+	return func(f *FakeClient) {
+		f.Client.SetEndpoint(ep)
+	}
+}
+
+// WithLabelSchemaVersion builds the Client on an engine at label schema
+// version v instead of consts.LabelSchemaVersion, so tests can stand in
+// for a release that changed the label scheme: fixtures without a schema
+// label are then stale.
+func WithLabelSchemaVersion(v int) FakeClientOption {
+	return func(f *FakeClient) {
+		opts := fakeEngineOptions(f.Cfg, f.Client.DryRun())
+		opts.LabelSchemaVersion = v
+		f.Client = docker.NewClientFromEngine(whail.NewFromExisting(f.FakeAPI, opts), f.Cfg, nil)
+	}
+}
+
+// newFakeEngine wraps fakeAPI in a whail engine labeled like production.
+func newFakeEngine(fakeAPI *whailtest.FakeAPIClient, cfg config.Config, dryRun bool) *whail.Engine {
+	return whail.NewFromExisting(fakeAPI, fakeEngineOptions(cfg, dryRun))
+}
+
+// fakeEngineOptions are production's engine options over a fake API.
+func fakeEngineOptions(cfg config.Config, dryRun bool) whail.EngineOptions {
+	return whail.EngineOptions{
+		LabelPrefix:        cfg.EngineLabelPrefix(),
+		ManagedLabel:       cfg.EngineManagedLabel(),
+		BaseImageLabel:     consts.EngineBaseImageLabel,
+		LabelSchemaLabel:   consts.EngineLabelSchemaLabel,
+		LabelSchemaVersion: consts.LabelSchemaVersion,
+		LabelMigrations:    docker.LabelMigrations(),
+		Labels:             docker.TestLabelConfig(cfg),
+		DryRun:             dryRun,
+	}
+}
+
 // NewFakeClient constructs a FakeClient with production-equivalent label
 // configuration. The returned Client.Engine uses clawker's label prefix,
 // so docker-layer methods (ListContainers, FindContainerByAgent,
 // etc.) exercise real label filtering logic.
 func NewFakeClient(cfg config.Config, opts ...FakeClientOption) *FakeClient {
 	fakeAPI := whailtest.NewFakeAPIClient()
-	engine := whail.NewFromExisting(fakeAPI, whail.EngineOptions{
-		LabelPrefix:  cfg.EngineLabelPrefix(),
-		ManagedLabel: cfg.EngineManagedLabel(),
-		Labels:       docker.TestLabelConfig(cfg),
-	})
-	client := docker.NewClientFromEngine(engine, cfg, nil)
+	client := docker.NewClientFromEngine(newFakeEngine(fakeAPI, cfg, false), cfg, nil)
 
 	// Override whailtest's default ContainerInspect to return clawker labels
 	// instead of whailtest's "com.whailtest.managed" default. This prevents
@@ -9,6 +9,7 @@ import (
 
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
 )
 
 var cfg = configmocks.NewBlankConfig()
@@ -129,6 +130,45 @@ func TestFindContainerByAgent(t *testing.T) {
 	})
 }
 
+func TestFindAgentContainer(t *testing.T) {
+	ctx := context.Background()
+	fake := mocks.NewFakeClient(cfg)
+	state := fake.EnableState()
+	state.AddImage("node:20-slim", nil)
+	ids := map[string]string{}
+	for _, project := range []string{"myapp", ""} {
+		labels := map[string]string{cfg.LabelAgent(): "dev"}
+		name := "clawker.dev"
+		if project != "" {
+			labels[cfg.LabelProject()] = project
+			name = "clawker." + project + ".dev"
+		}
+		id, err := state.AddContainer(whailtest.ContainerSpec{Name: name, Image: "node:20-slim", Labels: labels})
+		if err != nil {
+			t.Fatalf("AddContainer(%q) error: %v", name, err)
+		}
+		ids[project] = id
+	}
+
+	for _, project := range []string{"myapp", ""} {
+		ctr, err := fake.Client.FindAgentContainer(ctx, project, "dev")
+		if err != nil {
+			t.Fatalf("FindAgentContainer(%q) error: %v", project, err)
+		}
+		if ctr == nil || ctr.ID != ids[project] {
+			t.Errorf("FindAgentContainer(%q) = %v, want ID %q", project, ctr, ids[project])
+		}
+	}
+
+	ctr, err := fake.Client.FindAgentContainer(ctx, "other", "dev")
+	if err != nil {
+		t.Fatalf("FindAgentContainer(other) error: %v", err)
+	}
+	if ctr != nil {
+		t.Errorf("FindAgentContainer(other) = %v, want nil", ctr)
+	}
+}
+
 func TestContainerFixture(t *testing.T) {
 	t.Run("includes clawker labels", func(t *testing.T) {
 		c := mocks.ContainerFixture("myapp", "dev", "node:20")
@@ -236,6 +276,49 @@ func TestSetupContainerStart(t *testing.T) {
 	})
 }
 
+func TestEnableState(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create start inspect share one store", func(t *testing.T) {
+		fake := mocks.NewFakeClient(cfg)
+		state := fake.EnableState()
+		state.AddImage("alpine:latest", nil)
+
+		resp, err := fake.Client.ContainerCreate(ctx, mocks.MinimalCreateOpts())
+		if err != nil {
+			t.Fatalf("ContainerCreate() error: %v", err)
+		}
+		if _, err := fake.Client.ContainerStart(ctx, mocks.MinimalStartOpts(resp.ID)); err != nil {
+			t.Fatalf("ContainerStart() error: %v", err)
+		}
+
+		info, err := fake.Client.ContainerInspect(ctx, "test-container", moby.ContainerInspectOptions{})
+		if err != nil {
+			t.Fatalf("ContainerInspect() error: %v", err)
+		}
+		if info.Container.ID != resp.ID {
+			t.Errorf("ContainerInspect().ID = %q, want %q", info.Container.ID, resp.ID)
+		}
+		if info.Container.State.Status != container.StateRunning {
+			t.Errorf("ContainerInspect().State.Status = %q, want %q", info.Container.State.Status, container.StateRunning)
+		}
+	})
+
+	t.Run("seeded image is managed", func(t *testing.T) {
+		fake := mocks.NewFakeClient(cfg)
+		state := fake.EnableState()
+		state.AddImage("clawker-myapp:latest", nil)
+
+		exists, err := fake.Client.ImageExists(ctx, "clawker-myapp:latest")
+		if err != nil {
+			t.Fatalf("ImageExists() error: %v", err)
+		}
+		if !exists {
+			t.Error("ImageExists() = false, want true")
+		}
+	})
+}
+
 func TestSetupVolumeExists(t *testing.T) {
 	ctx := context.Background()
 
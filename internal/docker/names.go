@@ -162,6 +162,34 @@ func ContainerNamesFromAgents(project string, agents []string) ([]string, error)
 	return containers, nil
 }
 
+// SidecarContainerName generates the name of an agent's sidecar container:
+// clawker.project.agent.sidecar-name. The "sidecar-" segment keeps it clear
+// of agent container names, which commonly carry hyphens.
+func SidecarContainerName(project, agent, sidecar string) (string, error) {
+	agentName, err := ContainerName(project, agent)
+	if err != nil {
+		return "", err
+	}
+	if err := ValidateResourceName(sidecar); err != nil {
+		return "", fmt.Errorf("invalid sidecar name: %w", err)
+	}
+	return fmt.Sprintf("%s.sidecar-%s", agentName, sidecar), nil
+}
+
+// ProjectNetworkName generates the name of a project's isolated network:
+// clawker-project. A project named "net" would land on the shared
+// consts.Network and is refused; such a project must use network.shared.
+func ProjectNetworkName(project string) (string, error) {
+	if err := ValidateResourceName(project); err != nil {
+		return "", fmt.Errorf("invalid project name: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s", NamePrefix, project)
+	if name == consts.Network {
+		return "", fmt.Errorf("project %q: network name %s is reserved for the shared network; set network.shared: true", project, name)
+	}
+	return name, nil
+}
+
 // ContainerNamePrefix returns prefix for filtering: clawker.project.
 func ContainerNamePrefix(project string) string {
 	if project == "" {
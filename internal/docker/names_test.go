@@ -159,6 +159,37 @@ func TestContainerNamesFromAgents(t *testing.T) {
 	}
 }
 
+func TestSidecarContainerName(t *testing.T) {
+	tests := []struct {
+		project, agent, sidecar string
+		want                    string
+		wantErr                 bool
+	}{
+		{"myproject", "dev", "db", "clawker.myproject.dev.sidecar-db", false},
+		{"", "dev", "db", "clawker.dev.sidecar-db", false},
+		{"myproject", "dev", "", "", true},
+		{"myproject", "", "db", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.project+"_"+tt.agent+"_"+tt.sidecar, func(t *testing.T) {
+			got, err := SidecarContainerName(tt.project, tt.agent, tt.sidecar)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("SidecarContainerName() = %q, nil; want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SidecarContainerName() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("SidecarContainerName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestContainerNamePrefix(t *testing.T) {
 	tests := []struct {
 		project string
@@ -386,3 +417,35 @@ func TestBaseImageTag(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectNetworkName(t *testing.T) {
+	tests := []struct {
+		project string
+		want    string
+		wantErr bool
+	}{
+		{"myproject", "clawker-myproject", false},
+		{"my.project", "clawker-my.project", false},
+		{"", "", true},
+		{"net", "", true},
+		{"bad/name", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.project, func(t *testing.T) {
+			got, err := ProjectNetworkName(tt.project)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ProjectNetworkName() = %q, nil; want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ProjectNetworkName() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ProjectNetworkName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// ProjectNetworkLabels returns labels for a project's isolated network.
+func (c *Client) ProjectNetworkLabels(project string) map[string]string {
+	return map[string]string{
+		c.cfg.LabelManaged(): c.cfg.ManagedLabelValue(),
+		c.cfg.LabelPurpose(): consts.PurposeProjectNetwork,
+		c.cfg.LabelProject(): project,
+	}
+}
+
+// ProjectNetworkOptions returns the EnsureNetwork options for a project's
+// isolated network (see ProjectNetworkName). It is a plain bridge: Docker
+// already drops traffic between separate bridge networks, which is what
+// keeps one project's containers out of another's.
+func (c *Client) ProjectNetworkOptions(name, project string) EnsureNetworkOptions {
+	return EnsureNetworkOptions{
+		Name:        name,
+		ExtraLabels: Labels{c.ProjectNetworkLabels(project)},
+	}
+}
+
+// EnsureProjectNetwork creates a project's isolated network when it is
+// missing and returns its ID.
+func (c *Client) EnsureProjectNetwork(ctx context.Context, name, project string) (string, error) {
+	return c.EnsureNetwork(ctx, c.ProjectNetworkOptions(name, project))
+}
@@ -3,6 +3,7 @@ package docker
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"os"
 	"strings"
@@ -56,6 +57,11 @@ func restoreSequence(containerInAltScreen bool) string {
 	return visualResetSequence
 }
 
+// ErrDetached is returned by Stream and StreamWithResize when the user typed
+// the detach key sequence. The container keeps running; callers treat it as a
+// clean end of the session.
+var ErrDetached = errors.New("detached from container")
+
 // PTYHandler manages the pseudo-terminal connection to a container
 type PTYHandler struct {
 	stdin   *os.File
@@ -64,6 +70,14 @@ type PTYHandler struct {
 	log     *logger.Logger
 	rawMode *term.RawMode
 
+	// detachKeys is the parsed detach key sequence watched for on stdin.
+	// Empty disables client-side detach.
+	detachKeys []byte
+
+	// outputTee, when set, receives a copy of everything the container
+	// writes to the terminal (the attach hub observers watch through).
+	outputTee io.Writer
+
 	// containerInAltScreen records whether the container's output stream left
 	// the terminal in the alternate screen buffer — an alt-screen enter with no
 	// matching leave (e.g. an in-container TUI like Claude Code killed before it
@@ -91,6 +105,29 @@ func NewPTYHandler(log *logger.Logger) *PTYHandler {
 	}
 }
 
+// SetDetachKeys sets the key sequence (as parsed by term.ParseDetachKeys) that
+// ends a stream with ErrDetached instead of forwarding it to the container.
+// Must be called before Stream or StreamWithResize.
+func (p *PTYHandler) SetDetachKeys(keys []byte) {
+	p.detachKeys = keys
+}
+
+// SetOutputTee mirrors the container's terminal output to w in addition to
+// stdout. w must not fail or block; attachhub.Hub satisfies both.
+// Must be called before Stream or StreamWithResize.
+func (p *PTYHandler) SetOutputTee(w io.Writer) {
+	p.outputTee = w
+}
+
+// output returns the writer container output is copied to.
+func (p *PTYHandler) output() io.Writer {
+	var w io.Writer = p.stdout
+	if p.outputTee != nil {
+		w = io.MultiWriter(p.stdout, p.outputTee)
+	}
+	return newAltScreenTrackingWriter(w, &p.containerInAltScreen)
+}
+
 // Setup prepares the terminal for PTY interaction
 func (p *PTYHandler) Setup() error {
 	p.mu.Lock()
@@ -151,7 +188,7 @@ func (p *PTYHandler) Stream(ctx context.Context, hijacked HijackedResponse) erro
 
 	// Copy container output to stdout
 	go func() {
-		_, err := io.Copy(newAltScreenTrackingWriter(p.stdout, &p.containerInAltScreen), hijacked.Reader)
+		_, err := io.Copy(p.output(), hijacked.Reader)
 		if err != nil && err != io.EOF && !isClosedConnectionError(err) {
 			errCh <- err
 		}
@@ -160,7 +197,13 @@ func (p *PTYHandler) Stream(ctx context.Context, hijacked HijackedResponse) erro
 
 	// Copy stdin to container input
 	go func() {
-		_, err := io.Copy(hijacked.Conn, p.stdin)
+		_, err := io.Copy(hijacked.Conn, term.NewEscapeReader(p.stdin, p.detachKeys))
+		if errors.Is(err, term.ErrDetach) {
+			// Leave the write side open: closing it would hand the
+			// container's stdin an EOF on the way out.
+			errCh <- ErrDetached
+			return
+		}
 		if err != nil && err != io.EOF && !isClosedConnectionError(err) {
 			errCh <- err
 		}
@@ -211,13 +254,16 @@ func (p *PTYHandler) StreamWithResize(
 
 		// Start monitoring for window resize events (SIGWINCH)
 		resizeHandler := signals.NewResizeHandler(resizeFunc, p.GetSize)
+		resizeHandler.SetErrorHandler(func(err error) {
+			p.log.Debug().Err(err).Msg("failed to resize container TTY")
+		})
 		resizeHandler.Start()
 		defer resizeHandler.Stop()
 	}
 
 	// Copy container output to stdout
 	go func() {
-		_, err := io.Copy(newAltScreenTrackingWriter(p.stdout, &p.containerInAltScreen), hijacked.Reader)
+		_, err := io.Copy(p.output(), hijacked.Reader)
 		if err != nil && err != io.EOF && !isClosedConnectionError(err) {
 			p.log.Debug().Err(err).Msg("error copying container output")
 			errCh <- err
@@ -227,7 +273,12 @@ func (p *PTYHandler) StreamWithResize(
 
 	// Copy stdin to container input
 	go func() {
-		_, err := io.Copy(hijacked.Conn, p.stdin)
+		_, err := io.Copy(hijacked.Conn, term.NewEscapeReader(p.stdin, p.detachKeys))
+		if errors.Is(err, term.ErrDetach) {
+			p.log.Debug().Msg("detach key sequence read, detaching")
+			errCh <- ErrDetached
+			return
+		}
 		if err != nil && err != io.EOF && !isClosedConnectionError(err) {
 			p.log.Debug().Err(err).Msg("error copying stdin to container")
 			errCh <- err
@@ -252,6 +303,39 @@ func (p *PTYHandler) StreamWithResize(
 	}
 }
 
+// Observe copies a read-only session stream (an attach hub connection) to
+// the terminal. Local keystrokes are read only to watch for the detach
+// sequence and are otherwise discarded. Returns ErrDetached on the detach
+// keys and nil when the observed session ends.
+func (p *PTYHandler) Observe(ctx context.Context, r io.Reader) error {
+	outputDone := make(chan struct{})
+	errCh := make(chan error, 2)
+
+	go func() {
+		_, err := io.Copy(newAltScreenTrackingWriter(p.stdout, &p.containerInAltScreen), r)
+		if err != nil && err != io.EOF && !isClosedConnectionError(err) {
+			errCh <- err
+		}
+		close(outputDone)
+	}()
+
+	go func() {
+		_, err := io.Copy(io.Discard, term.NewEscapeReader(p.stdin, p.detachKeys))
+		if errors.Is(err, term.ErrDetach) {
+			errCh <- ErrDetached
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	case <-outputDone:
+		return nil
+	}
+}
+
 // GetSize returns the current terminal size
 func (p *PTYHandler) GetSize() (width, height int, err error) {
 	return p.rawMode.GetSize()
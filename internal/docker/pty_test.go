@@ -2,13 +2,18 @@ package docker
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"strings"
 	"sync/atomic"
 	"testing"
 
+	"github.com/moby/moby/client"
+
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/term"
 )
@@ -176,6 +181,148 @@ func TestRestoreSequence(t *testing.T) {
 	}
 }
 
+// TestStream_DetachKeys verifies the detach sequence ends the stream with
+// ErrDetached, and that the input typed before it still reaches the container
+// while the sequence itself never does.
+func TestStream_DetachKeys(t *testing.T) {
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	defer stdinR.Close()
+	defer stdinW.Close()
+
+	conn, daemon := net.Pipe()
+	defer conn.Close()
+	defer daemon.Close()
+
+	handler := &PTYHandler{
+		stdin:   stdinR,
+		stdout:  os.Stdout,
+		stderr:  os.Stderr,
+		log:     logger.Nop(),
+		rawMode: term.NewRawMode(int(stdinR.Fd())),
+	}
+	handler.SetDetachKeys([]byte{0x10, 0x11}) // ctrl-p,ctrl-q
+
+	received := make(chan string, 1)
+	go func() {
+		buf := make([]byte, 64)
+		n, _ := daemon.Read(buf)
+		received <- string(buf[:n])
+	}()
+
+	if _, err := stdinW.Write([]byte("ls\x10\x11")); err != nil {
+		t.Fatalf("failed to write stdin: %v", err)
+	}
+
+	err = handler.Stream(context.Background(), client.NewHijackedResponse(conn, ""))
+	if !errors.Is(err, ErrDetached) {
+		t.Fatalf("Stream() error = %v, want ErrDetached", err)
+	}
+	if got := <-received; got != "ls" {
+		t.Errorf("container received %q, want %q", got, "ls")
+	}
+}
+
+func TestStream_OutputTee(t *testing.T) {
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	defer stdinR.Close()
+	defer stdinW.Close()
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	defer stdoutR.Close()
+
+	conn, daemon := net.Pipe()
+	defer conn.Close()
+
+	handler := &PTYHandler{
+		stdin:   stdinR,
+		stdout:  stdoutW,
+		stderr:  os.Stderr,
+		log:     logger.Nop(),
+		rawMode: term.NewRawMode(int(stdinR.Fd())),
+	}
+	var tee bytes.Buffer
+	handler.SetOutputTee(&tee)
+
+	go func() {
+		daemon.Write([]byte("hello"))
+		daemon.Close()
+	}()
+
+	if err := handler.Stream(context.Background(), client.NewHijackedResponse(conn, "")); err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	stdoutW.Close()
+	got, _ := io.ReadAll(stdoutR)
+	if string(got) != "hello" {
+		t.Errorf("stdout = %q, want %q", got, "hello")
+	}
+	if tee.String() != "hello" {
+		t.Errorf("tee = %q, want %q", tee.String(), "hello")
+	}
+}
+
+func TestObserve(t *testing.T) {
+	newHandler := func(t *testing.T) (*PTYHandler, *os.File, *os.File, *os.File) {
+		t.Helper()
+		stdinR, stdinW, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("failed to create pipe: %v", err)
+		}
+		stdoutR, stdoutW, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("failed to create pipe: %v", err)
+		}
+		t.Cleanup(func() {
+			stdinR.Close()
+			stdinW.Close()
+			stdoutR.Close()
+			stdoutW.Close()
+		})
+		h := &PTYHandler{
+			stdin:   stdinR,
+			stdout:  stdoutW,
+			stderr:  os.Stderr,
+			log:     logger.Nop(),
+			rawMode: term.NewRawMode(int(stdinR.Fd())),
+		}
+		h.SetDetachKeys([]byte{0x10, 0x11}) // ctrl-p,ctrl-q
+		return h, stdinW, stdoutR, stdoutW
+	}
+
+	t.Run("copies the session until it ends", func(t *testing.T) {
+		h, _, stdoutR, stdoutW := newHandler(t)
+
+		if err := h.Observe(context.Background(), strings.NewReader("screen")); err != nil {
+			t.Fatalf("Observe() error = %v", err)
+		}
+		stdoutW.Close()
+		got, _ := io.ReadAll(stdoutR)
+		if string(got) != "screen" {
+			t.Errorf("stdout = %q, want %q", got, "screen")
+		}
+	})
+
+	t.Run("detach keys end the observation", func(t *testing.T) {
+		h, stdinW, _, _ := newHandler(t)
+		session, _ := io.Pipe() // never produces output
+
+		if _, err := stdinW.Write([]byte("typed\x10\x11")); err != nil {
+			t.Fatalf("failed to write stdin: %v", err)
+		}
+		if err := h.Observe(context.Background(), session); !errors.Is(err, ErrDetached) {
+			t.Fatalf("Observe() error = %v, want ErrDetached", err)
+		}
+	})
+}
+
 func TestIsClosedConnectionError(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,368 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+// PullKind classifies a PullChange.
+type PullKind string
+
+const (
+	PullAdded    PullKind = "added"
+	PullModified PullKind = "modified"
+	PullDeleted  PullKind = "deleted"
+)
+
+// PullChange is one workspace path whose container copy the agent changed.
+type PullChange struct {
+	Path string
+	Kind PullKind
+	// Entry is the container's entry; zero for PullDeleted.
+	Entry SyncEntry
+	// Content is a regular file's content in the container.
+	Content []byte
+	// Conflict is true when the host changed the path too since the container
+	// last received it, so applying the change would discard host work.
+	Conflict bool
+}
+
+// PullResult lists the agent's changes, sorted by path.
+type PullResult struct {
+	Changes []PullChange
+	// Synced is true when changes were measured against the state the last
+	// SyncWorkspace pushed, rather than against the create-time snapshot.
+	Synced bool
+}
+
+// Conflicts returns the paths of conflicting changes.
+func (r *PullResult) Conflicts() []string {
+	var out []string
+	for _, ch := range r.Changes {
+		if ch.Conflict {
+			out = append(out, ch.Path)
+		}
+	}
+	return out
+}
+
+// PullWorkspace reads the workspace at destPath out of a container (running
+// or stopped) and reports how it differs from srcDir on the host, as the
+// agent's changes. Paths matched by ignorePatterns and the top-level .git
+// directory are never pulled: the former never went in, and the latter is
+// the container's own repository state rather than working-tree content.
+//
+// A change conflicts when the host changed the path too. When the last
+// SyncWorkspace left a manifest, both sides are measured against it.
+// Otherwise the container started from the create-time snapshot, which keeps
+// host modification times: a container file modified at or after created is
+// the agent's, and a host file modified at or after created is the host's.
+// Without a manifest, a host file newer than the snapshot that the container
+// lacks is taken to be new on the host, and a container path the host lacks
+// is reported as added, since a host-side deletion cannot be told apart.
+func (c *Client) PullWorkspace(ctx context.Context, containerID, srcDir, destPath string, ignorePatterns []string, created time.Time) (*PullResult, error) {
+	host, err := ScanWorkspace(srcDir, ignorePatterns, nil)
+	if err != nil {
+		return nil, err
+	}
+	dropGitDir(host.Files)
+
+	m, err := c.readSyncManifest(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	result := &PullResult{Synced: m != nil && m.Root == destPath}
+
+	ctr, err := c.readWorkspaceArchive(ctx, containerID, destPath, ignorePatterns, host.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	// agentChanged and hostChanged report whether each side moved away from
+	// what the container started with.
+	var agentChanged, hostChanged func(p string) bool
+	if result.Synced {
+		base := m.Files
+		dropGitDir(base)
+		differs := func(e SyncEntry, ok bool, p string) bool {
+			be, inBase := base[p]
+			return ok != inBase || (ok && !samePullContent(e, be))
+		}
+		agentChanged = func(p string) bool {
+			ce, ok := ctr[p]
+			return differs(ce.SyncEntry, ok, p)
+		}
+		hostChanged = func(p string) bool {
+			he, ok := host.Files[p]
+			return differs(he, ok, p)
+		}
+	} else {
+		since := created.UnixNano()
+		newer := func(e SyncEntry) bool { return !e.Mode.IsDir() && e.ModTime >= since }
+		hostChanged = func(p string) bool {
+			he, ok := host.Files[p]
+			return ok && newer(he)
+		}
+		agentChanged = func(p string) bool {
+			ce, inCtr := ctr[p]
+			he, inHost := host.Files[p]
+			switch {
+			case !inCtr:
+				return !newer(he)
+			case ce.Mode.IsDir():
+				return !inHost
+			default:
+				return newer(ce.SyncEntry)
+			}
+		}
+	}
+
+	paths := make(map[string]struct{}, len(host.Files)+len(ctr))
+	for p := range host.Files {
+		paths[p] = struct{}{}
+	}
+	for p := range ctr {
+		paths[p] = struct{}{}
+	}
+	if result.Synced {
+		for p := range m.Files {
+			paths[p] = struct{}{}
+		}
+	}
+
+	for p := range paths {
+		ce, inCtr := ctr[p]
+		he, inHost := host.Files[p]
+		if inCtr == inHost && (!inCtr || samePullContent(ce.SyncEntry, he)) {
+			continue // already the same on both sides
+		}
+		if !agentChanged(p) {
+			continue
+		}
+
+		ch := PullChange{Path: p, Conflict: hostChanged(p)}
+		switch {
+		case !inCtr:
+			ch.Kind = PullDeleted
+		case inHost:
+			ch.Kind = PullModified
+		default:
+			ch.Kind = PullAdded
+		}
+		if inCtr {
+			ch.Entry = ce.SyncEntry
+			ch.Content = ce.content
+		}
+		result.Changes = append(result.Changes, ch)
+	}
+
+	slices.SortFunc(result.Changes, func(a, b PullChange) int { return strings.Compare(a.Path, b.Path) })
+	return result, nil
+}
+
+// samePullContent is sameContent, except that directories are equal
+// whatever their permissions and symlinks whatever theirs, which vary by
+// platform.
+func samePullContent(a, b SyncEntry) bool {
+	switch {
+	case a.Mode.IsDir() && b.Mode.IsDir():
+		return true
+	case a.Mode&os.ModeSymlink != 0 && b.Mode&os.ModeSymlink != 0:
+		return a.Link == b.Link
+	}
+	return a.sameContent(b)
+}
+
+// dropGitDir removes the top-level .git directory from a manifest's files.
+func dropGitDir(files map[string]SyncEntry) {
+	for p := range files {
+		if p == ".git" || strings.HasPrefix(p, ".git/") {
+			delete(files, p)
+		}
+	}
+}
+
+// pulledEntry is a container workspace entry, with a regular file's content
+// when it differs from the host's.
+type pulledEntry struct {
+	SyncEntry
+	content []byte
+}
+
+// readWorkspaceArchive streams the container's workspace and records every
+// entry not matched by ignorePatterns. Content is kept only for files whose
+// hash differs from the host's entry.
+func (c *Client) readWorkspaceArchive(ctx context.Context, containerID, destPath string, ignorePatterns []string, host map[string]SyncEntry) (map[string]pulledEntry, error) {
+	res, err := c.CopyFromContainer(ctx, containerID, whail.CopyFromContainerOptions{SourcePath: destPath})
+	if err != nil {
+		return nil, fmt.Errorf("reading container workspace: %w", err)
+	}
+	defer res.Content.Close()
+
+	ignore := compileIgnorePatterns(ignorePatterns)
+	out := make(map[string]pulledEntry)
+	var skipped []string
+
+	tr := tar.NewReader(res.Content)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading container workspace: %w", err)
+		}
+
+		// Entries are rooted at the workspace directory's own name.
+		_, rel, ok := strings.Cut(strings.TrimSuffix(hdr.Name, "/"), "/")
+		if !ok || rel == "" {
+			continue
+		}
+		if rel == ".git" || strings.HasPrefix(rel, ".git/") || slices.ContainsFunc(skipped, func(s string) bool { return strings.HasPrefix(rel, s) }) {
+			continue
+		}
+		isDir := hdr.Typeflag == tar.TypeDir
+		if ignore.Match(splitIgnorePath(rel), isDir) {
+			if isDir {
+				skipped = append(skipped, rel+"/")
+			}
+			continue
+		}
+
+		info := hdr.FileInfo()
+		entry := pulledEntry{SyncEntry: SyncEntry{Mode: info.Mode()}}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+		case tar.TypeSymlink:
+			entry.Link = hdr.Linkname
+			entry.ModTime = hdr.ModTime.UnixNano()
+		case tar.TypeReg:
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s from container: %w", rel, err)
+			}
+			sum := sha256.Sum256(data)
+			entry.Size = int64(len(data))
+			entry.ModTime = hdr.ModTime.UnixNano()
+			entry.Hash = hex.EncodeToString(sum[:])
+			if h, ok := host[rel]; !ok || h.Hash != entry.Hash {
+				entry.content = data
+			}
+		default:
+			continue
+		}
+		out[rel] = entry
+	}
+}
+
+// ApplyPull writes changes into the host directory dir: deleted files go
+// first, then deleted directories deepest first (a directory still holding
+// ignored files is kept), then additions and modifications with the
+// container's permissions.
+func ApplyPull(dir string, changes []PullChange) error {
+	var dirs []string
+	for _, ch := range changes {
+		if ch.Kind != PullDeleted {
+			continue
+		}
+		p := filepath.Join(dir, filepath.FromSlash(ch.Path))
+		info, err := os.Lstat(p)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			dirs = append(dirs, p)
+			continue
+		}
+		if err := os.Remove(p); err != nil {
+			return err
+		}
+	}
+	slices.SortFunc(dirs, func(a, b string) int { return strings.Compare(b, a) })
+	for _, p := range dirs {
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) && !isDirNotEmpty(p) {
+			return err
+		}
+	}
+
+	for _, ch := range changes {
+		if ch.Kind == PullDeleted {
+			continue
+		}
+		p := filepath.Join(dir, filepath.FromSlash(ch.Path))
+		mode := ch.Entry.Mode
+		switch {
+		case mode.IsDir():
+			if info, err := os.Lstat(p); err == nil && !info.IsDir() {
+				if err := os.Remove(p); err != nil {
+					return err
+				}
+			}
+			if err := os.MkdirAll(p, mode.Perm()|0o700); err != nil {
+				return err
+			}
+		case mode&os.ModeSymlink != 0:
+			if err := os.RemoveAll(p); err != nil {
+				return err
+			}
+			if err := os.Symlink(ch.Entry.Link, p); err != nil {
+				return err
+			}
+		default:
+			if info, err := os.Lstat(p); err == nil && (info.IsDir() || info.Mode()&os.ModeSymlink != 0) {
+				if err := os.RemoveAll(p); err != nil {
+					return err
+				}
+			}
+			if err := writeFileAtomic(p, ch.Content, mode.Perm()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isDirNotEmpty reports whether p is a directory with entries left.
+func isDirNotEmpty(p string) bool {
+	entries, err := os.ReadDir(p)
+	return err == nil && len(entries) > 0
+}
+
+// writeFileAtomic replaces p with data through a temporary file in the same
+// directory, so an interrupted pull never leaves a half-written file.
+func writeFileAtomic(p string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p), "."+filepath.Base(p)+".clawker-pull-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p)
+}
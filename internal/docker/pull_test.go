@@ -0,0 +1,61 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPull(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		"main.go":         "package main\n",
+		"old/a.txt":       "a",
+		"old/sub/b.txt":   "b",
+		"kept/c.txt":      "c",
+		"kept/ignored.db": "host only",
+	})
+
+	err := ApplyPull(dir, []PullChange{
+		{Path: "kept", Kind: PullDeleted},
+		{Path: "kept/c.txt", Kind: PullDeleted},
+		{Path: "old", Kind: PullDeleted},
+		{Path: "old/a.txt", Kind: PullDeleted},
+		{Path: "old/sub", Kind: PullDeleted},
+		{Path: "old/sub/b.txt", Kind: PullDeleted},
+		{Path: "main.go", Kind: PullModified, Entry: SyncEntry{Mode: 0o644}, Content: []byte("package main // agent\n")},
+		{Path: "bin", Kind: PullAdded, Entry: SyncEntry{Mode: os.ModeDir | 0o755}},
+		{Path: "bin/run", Kind: PullAdded, Entry: SyncEntry{Mode: 0o755}, Content: []byte("#!/bin/sh\n")},
+		{Path: "link", Kind: PullAdded, Entry: SyncEntry{Mode: os.ModeSymlink | 0o777, Link: "main.go"}},
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main // agent\n", string(data))
+
+	info, err := os.Stat(filepath.Join(dir, "bin", "run"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+
+	link, err := os.Readlink(filepath.Join(dir, "link"))
+	require.NoError(t, err)
+	assert.Equal(t, "main.go", link)
+
+	assert.NoDirExists(t, filepath.Join(dir, "old"))
+	// A directory still holding files the pull never saw is kept.
+	assert.FileExists(t, filepath.Join(dir, "kept", "ignored.db"))
+	assert.NoFileExists(t, filepath.Join(dir, "kept", "c.txt"))
+
+	// No temporary files are left behind.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.ElementsMatch(t, []string{"bin", "kept", "link", "main.go"}, names)
+}
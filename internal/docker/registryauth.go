@@ -0,0 +1,181 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/moby/moby/api/pkg/authconfig"
+	"github.com/moby/moby/api/types/registry"
+)
+
+// dockerHubAuthKey is the key the Docker CLI files Docker Hub credentials
+// under, in both config.json auths and credential helper lookups.
+const dockerHubAuthKey = "https://index.docker.io/v1/"
+
+// dockerConfigFile is the subset of the Docker CLI's config.json that
+// carries registry credentials.
+type dockerConfigFile struct {
+	Auths       map[string]dockerAuthEntry `json:"auths"`
+	CredsStore  string                     `json:"credsStore"`
+	CredHelpers map[string]string          `json:"credHelpers"`
+}
+
+type dockerAuthEntry struct {
+	Auth          string `json:"auth"`
+	IdentityToken string `json:"identitytoken"`
+}
+
+// credentialHelperRunner runs `docker-credential-<helper> get` with server
+// on stdin and returns its stdout. Tests swap it.
+var credentialHelperRunner = func(ctx context.Context, helper, server string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	//nolint:gosec // helper name comes from the user's own Docker config, as with the docker CLI
+	cmd := exec.CommandContext(ctx, "docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(server)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// Helpers report a missing entry on stdout and exit non-zero.
+		msg := strings.TrimSpace(stdout.String() + " " + stderr.String())
+		return nil, fmt.Errorf("%w: %s", err, msg)
+	}
+	return stdout.Bytes(), nil
+}
+
+// RegistryAuth resolves the X-Registry-Auth header value for the registry
+// ref lives in, from the Docker CLI configuration (DOCKER_CONFIG, else
+// ~/.docker/config.json): the registry's credHelpers entry, else the
+// credsStore, else the inline auths entry — the lookup order `docker push`
+// uses. No config file or no credentials yields "", which the daemon treats
+// as an anonymous request.
+func RegistryAuth(ctx context.Context, ref string) (string, error) {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("locating docker config: %w", err)
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+	return registryAuthFromConfig(ctx, ref, filepath.Join(dir, "config.json"))
+}
+
+func registryAuthFromConfig(ctx context.Context, ref, configPath string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", fmt.Errorf("parsing image reference %q: %w", ref, err)
+	}
+	server := reference.Domain(named)
+	if server == "docker.io" {
+		server = dockerHubAuthKey
+	}
+
+	data, err := os.ReadFile(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading docker config: %w", err)
+	}
+	var cfg dockerConfigFile
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return "", fmt.Errorf("parsing docker config %s: %w", configPath, err)
+	}
+
+	var auth registry.AuthConfig
+	if helper := credentialHelperFor(cfg, server); helper != "" {
+		auth, err = helperCredentials(ctx, helper, server)
+		if err != nil {
+			return "", err
+		}
+	} else if entry, ok := authEntryFor(cfg.Auths, server); ok {
+		auth, err = decodeAuthEntry(entry)
+		if err != nil {
+			return "", fmt.Errorf("docker config auth for %s: %w", server, err)
+		}
+	}
+	if auth == (registry.AuthConfig{}) {
+		return "", nil
+	}
+	auth.ServerAddress = server
+	return authconfig.Encode(auth)
+}
+
+// credentialHelperFor returns the helper for server: its credHelpers entry,
+// else the default credsStore.
+func credentialHelperFor(cfg dockerConfigFile, server string) string {
+	if helper, ok := cfg.CredHelpers[server]; ok {
+		return helper
+	}
+	return cfg.CredsStore
+}
+
+// authEntryFor finds server's auths entry. Keys may carry a scheme or
+// path (https://ghcr.io, registry.example/v1/), as older logins wrote them.
+func authEntryFor(auths map[string]dockerAuthEntry, server string) (dockerAuthEntry, bool) {
+	if entry, ok := auths[server]; ok {
+		return entry, true
+	}
+	for key, entry := range auths {
+		if authKeyHost(key) == authKeyHost(server) {
+			return entry, true
+		}
+	}
+	return dockerAuthEntry{}, false
+}
+
+func authKeyHost(key string) string {
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+	host, _, _ := strings.Cut(key, "/")
+	return host
+}
+
+func decodeAuthEntry(entry dockerAuthEntry) (registry.AuthConfig, error) {
+	auth := registry.AuthConfig{IdentityToken: entry.IdentityToken}
+	if entry.Auth == "" {
+		return auth, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+	if err != nil {
+		return registry.AuthConfig{}, fmt.Errorf("decoding auth: %w", err)
+	}
+	user, pass, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return registry.AuthConfig{}, errors.New("auth is not username:password")
+	}
+	auth.Username, auth.Password = user, pass
+	return auth, nil
+}
+
+// helperCredentials asks a credential helper for server's credentials. A
+// helper with no entry for server yields empty credentials, not an error.
+func helperCredentials(ctx context.Context, helper, server string) (registry.AuthConfig, error) {
+	out, err := credentialHelperRunner(ctx, helper, server)
+	if err != nil {
+		if strings.Contains(err.Error(), "credentials not found") {
+			return registry.AuthConfig{}, nil
+		}
+		return registry.AuthConfig{}, fmt.Errorf("credential helper %s: %w", helper, err)
+	}
+	var creds struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return registry.AuthConfig{}, fmt.Errorf("credential helper %s: parsing output: %w", helper, err)
+	}
+	// Helpers store identity tokens under this placeholder username.
+	if creds.Username == "<token>" {
+		return registry.AuthConfig{IdentityToken: creds.Secret}, nil
+	}
+	return registry.AuthConfig{Username: creds.Username, Password: creds.Secret}, nil
+}
@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moby/moby/api/pkg/authconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDockerConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestRegistryAuth_InlineAuths(t *testing.T) {
+	// "dXNlcjpwYXNz" = base64("user:pass")
+	path := writeDockerConfig(t, `{"auths":{"https://ghcr.io":{"auth":"dXNlcjpwYXNz"}}}`)
+
+	encoded, err := registryAuthFromConfig(context.Background(), "ghcr.io/team/agent:claude", path)
+	require.NoError(t, err)
+	auth, err := authconfig.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "user", auth.Username)
+	assert.Equal(t, "pass", auth.Password)
+	assert.Equal(t, "ghcr.io", auth.ServerAddress)
+}
+
+func TestRegistryAuth_CredentialHelper(t *testing.T) {
+	orig := credentialHelperRunner
+	t.Cleanup(func() { credentialHelperRunner = orig })
+	var gotHelper, gotServer string
+	credentialHelperRunner = func(_ context.Context, helper, server string) ([]byte, error) {
+		gotHelper, gotServer = helper, server
+		return []byte(`{"Username":"<token>","Secret":"idtok"}`), nil
+	}
+	path := writeDockerConfig(t, `{"credsStore":"desktop","credHelpers":{"registry.example":"ecr-login"}}`)
+
+	encoded, err := registryAuthFromConfig(context.Background(), "registry.example/agent:v1", path)
+	require.NoError(t, err)
+	auth, err := authconfig.Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "ecr-login", gotHelper, "credHelpers entry wins over credsStore")
+	assert.Equal(t, "registry.example", gotServer)
+	assert.Equal(t, "idtok", auth.IdentityToken)
+
+	_, err = registryAuthFromConfig(context.Background(), "team/agent:v1", path)
+	require.NoError(t, err)
+	assert.Equal(t, "desktop", gotHelper)
+	assert.Equal(t, dockerHubAuthKey, gotServer, "Docker Hub refs use the index key")
+}
+
+func TestRegistryAuth_Anonymous(t *testing.T) {
+	encoded, err := registryAuthFromConfig(context.Background(), "ghcr.io/team/agent:v1", filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.Empty(t, encoded, "no config file means anonymous")
+
+	orig := credentialHelperRunner
+	t.Cleanup(func() { credentialHelperRunner = orig })
+	credentialHelperRunner = func(context.Context, string, string) ([]byte, error) {
+		return nil, errors.New("exit status 1: credentials not found in native keychain")
+	}
+	path := writeDockerConfig(t, `{"credsStore":"desktop"}`)
+	encoded, err = registryAuthFromConfig(context.Background(), "ghcr.io/team/agent:v1", path)
+	require.NoError(t, err)
+	assert.Empty(t, encoded, "a helper without an entry means anonymous")
+}
@@ -0,0 +1,68 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/moby/moby/api/pkg/stdcopy"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+// ExtractArchiveAs streams a tar archive over exec stdin into destDir of a
+// running container, extracting as uid:gid, and waits for it.
+//
+// Unlike CopyToContainer, the extraction runs inside the container's mount
+// namespace, so it lands in tmpfs mounts (the archive API writes beneath
+// them). The archive never touches the host filesystem or the image.
+func (c *Client) ExtractArchiveAs(ctx context.Context, containerID, destDir string, uid, gid int, archive io.Reader) error {
+	created, err := c.ExecCreate(ctx, containerID, whail.ExecCreateOptions{
+		User:         strconv.Itoa(uid) + ":" + strconv.Itoa(gid),
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          []string{"tar", "-xf", "-", "-C", destDir},
+	})
+	if err != nil {
+		return fmt.Errorf("tar: %w", err)
+	}
+	hijacked, err := c.ExecAttach(ctx, created.ID, whail.ExecAttachOptions{})
+	if err != nil {
+		return fmt.Errorf("tar: %w", err)
+	}
+
+	writeErr := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(hijacked.Conn, archive)
+		if cerr := hijacked.CloseWrite(); err == nil {
+			err = cerr
+		}
+		writeErr <- err
+	}()
+
+	var out bytes.Buffer
+	_, readErr := stdcopy.StdCopy(&out, &out, hijacked.Reader)
+	// Output ends when tar exits; closing unblocks a writer tar stopped
+	// reading from.
+	hijacked.Close()
+	wErr := <-writeErr
+	if readErr != nil && !errors.Is(readErr, io.EOF) {
+		return fmt.Errorf("tar: reading output: %w", readErr)
+	}
+	inspect, err := c.ExecInspect(ctx, created.ID, whail.ExecInspectOptions{})
+	if err != nil {
+		return fmt.Errorf("tar: %w", err)
+	}
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("tar exited with code %d: %s", inspect.ExitCode, strings.TrimSpace(out.String()))
+	}
+	if wErr != nil {
+		return fmt.Errorf("tar: writing archive: %w", wErr)
+	}
+	return nil
+}
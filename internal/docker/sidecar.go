@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+// ListSidecars returns the sidecar containers serving an agent, in any
+// state. An empty project selects a global-scope agent's sidecars, which
+// carry no project label.
+func (c *Client) ListSidecars(ctx context.Context, project, agent string) ([]Container, error) {
+	q := Query().Purpose(consts.PurposeSidecar).Label(consts.LabelSidecarAgent, agent)
+	if project != "" {
+		q = q.Project(project)
+	}
+	containers, err := c.ListContainersQuery(ctx, q, true)
+	if err != nil {
+		return nil, err
+	}
+	// The daemon can't match an absent label, so scope is checked here.
+	scoped := containers[:0]
+	for _, ctr := range containers {
+		if ctr.Project == project {
+			scoped = append(scoped, ctr)
+		}
+	}
+	return scoped, nil
+}
+
+// RemoveSidecars force-removes an agent's sidecar containers together with
+// their anonymous volumes: sidecar state lives only as long as the sidecar.
+// Every sidecar is attempted; the failures are joined.
+func (c *Client) RemoveSidecars(ctx context.Context, project, agent string) error {
+	sidecars, err := c.ListSidecars(ctx, project, agent)
+	if err != nil {
+		return fmt.Errorf("listing sidecars: %w", err)
+	}
+	var errs []error
+	for _, sc := range sidecars {
+		if _, err := c.ContainerRemoveWithOptions(ctx, sc.ID, ContainerRemoveOptions{
+			Force:         true,
+			RemoveVolumes: true,
+		}); err != nil && !isNotFoundError(err) {
+			errs = append(errs, fmt.Errorf("removing sidecar %s: %w", sc.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// EnsureExternalImage pulls ref when it is not present locally. It goes
+// around the managed-image jail: external images (a sidecar's postgres, the
+// chown helper) never carry clawker's managed label, so Engine.ImagePull
+// would refuse them. The pull is anonymous; pull a private image with
+// docker first.
+func (c *Client) EnsureExternalImage(ctx context.Context, ref string) error {
+	exists, err := c.imageExistsRaw(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("checking for image %s: %w", ref, err)
+	}
+	if exists {
+		return nil
+	}
+	resp, err := c.APIClient.ImagePull(ctx, ref, whail.ImagePullOptions{})
+	if err != nil {
+		return fmt.Errorf("pulling image %s: %w", ref, err)
+	}
+	defer resp.Close()
+	if _, err := io.Copy(io.Discard, resp); err != nil {
+		return fmt.Errorf("pulling image %s: %w", ref, err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package docker
+
+import "github.com/moby/moby/api/types/container"
+
+// CPUPercent derives a container's CPU usage from a stats sample as docker
+// stats does: CPU time against system time between the sample and its
+// predecessor (PreCPUStats), scaled by the online CPUs. A sample without a
+// predecessor, such as a one-shot read, yields 0.
+func CPUPercent(stats *container.StatsResponse) float64 {
+	// Calculate the change for the CPU usage of the container between readings
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+
+	// Calculate the change for the entire system between readings
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+
+	// Older daemons and cgroup v1 hosts may not report online CPUs.
+	onlineCPUs := float64(stats.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+
+	if systemDelta > 0.0 && cpuDelta > 0.0 {
+		return (cpuDelta / systemDelta) * onlineCPUs * 100.0
+	}
+	return 0.0
+}
+
+// MemoryUsageNoCache subtracts the reclaimable page cache from memory usage,
+// as docker stats does: total_inactive_file on cgroup v1, inactive_file on
+// cgroup v2.
+func MemoryUsageNoCache(mem container.MemoryStats) uint64 {
+	if v, ok := mem.Stats["total_inactive_file"]; ok && v < mem.Usage {
+		return mem.Usage - v
+	}
+	if v := mem.Stats["inactive_file"]; v < mem.Usage {
+		return mem.Usage - v
+	}
+	return mem.Usage
+}
@@ -0,0 +1,129 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCPUPercent(t *testing.T) {
+	tests := []struct {
+		name           string
+		cpuUsage       uint64
+		preCPUUsage    uint64
+		systemUsage    uint64
+		preSystemUsage uint64
+		onlineCPUs     uint32
+		expected       float64
+	}{
+		{
+			name:           "zero delta - no usage",
+			cpuUsage:       1000,
+			preCPUUsage:    1000,
+			systemUsage:    2000,
+			preSystemUsage: 2000,
+			onlineCPUs:     4,
+			expected:       0.0,
+		},
+		{
+			name:           "zero system delta",
+			cpuUsage:       2000,
+			preCPUUsage:    1000,
+			systemUsage:    2000,
+			preSystemUsage: 2000,
+			onlineCPUs:     4,
+			expected:       0.0,
+		},
+		{
+			name:           "zero cpu delta",
+			cpuUsage:       1000,
+			preCPUUsage:    1000,
+			systemUsage:    3000,
+			preSystemUsage: 2000,
+			onlineCPUs:     4,
+			expected:       0.0,
+		},
+		{
+			name:           "normal usage single core",
+			cpuUsage:       2000000000,
+			preCPUUsage:    1000000000,
+			systemUsage:    20000000000,
+			preSystemUsage: 10000000000,
+			onlineCPUs:     1,
+			expected:       10.0,
+		},
+		{
+			name:           "normal usage multi core",
+			cpuUsage:       2000000000,
+			preCPUUsage:    1000000000,
+			systemUsage:    20000000000,
+			preSystemUsage: 10000000000,
+			onlineCPUs:     4,
+			expected:       40.0,
+		},
+		{
+			name:           "100% single core",
+			cpuUsage:       2000000000,
+			preCPUUsage:    1000000000,
+			systemUsage:    2000000000,
+			preSystemUsage: 1000000000,
+			onlineCPUs:     1,
+			expected:       100.0,
+		},
+		{
+			name:           "50% of 8 cores",
+			cpuUsage:       5000000000,
+			preCPUUsage:    1000000000,
+			systemUsage:    9000000000,
+			preSystemUsage: 1000000000,
+			onlineCPUs:     8,
+			expected:       400.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := &container.StatsResponse{}
+			stats.CPUStats.CPUUsage.TotalUsage = tt.cpuUsage
+			stats.PreCPUStats.CPUUsage.TotalUsage = tt.preCPUUsage
+			stats.CPUStats.SystemUsage = tt.systemUsage
+			stats.PreCPUStats.SystemUsage = tt.preSystemUsage
+			stats.CPUStats.OnlineCPUs = tt.onlineCPUs
+
+			result := CPUPercent(stats)
+			require.InDelta(t, tt.expected, result, 0.01)
+		})
+	}
+}
+
+func TestCPUPercent_PerCPUFallback(t *testing.T) {
+	stats := &container.StatsResponse{}
+	stats.CPUStats.CPUUsage.TotalUsage = 2000000000
+	stats.PreCPUStats.CPUUsage.TotalUsage = 1000000000
+	stats.CPUStats.SystemUsage = 20000000000
+	stats.PreCPUStats.SystemUsage = 10000000000
+	stats.CPUStats.CPUUsage.PercpuUsage = []uint64{1, 2}
+
+	require.InDelta(t, 20.0, CPUPercent(stats), 0.01)
+}
+
+func TestMemoryUsageNoCache(t *testing.T) {
+	tests := []struct {
+		name string
+		mem  container.MemoryStats
+		want uint64
+	}{
+		{name: "no stats", mem: container.MemoryStats{Usage: 1000}, want: 1000},
+		{name: "cgroup v1", mem: container.MemoryStats{Usage: 1000, Stats: map[string]uint64{"total_inactive_file": 300, "inactive_file": 100}}, want: 700},
+		{name: "cgroup v2", mem: container.MemoryStats{Usage: 1000, Stats: map[string]uint64{"inactive_file": 400}}, want: 600},
+		{name: "cache larger than usage", mem: container.MemoryStats{Usage: 1000, Stats: map[string]uint64{"inactive_file": 4000}}, want: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MemoryUsageNoCache(tt.mem))
+		})
+	}
+}
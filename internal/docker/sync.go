@@ -0,0 +1,399 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+)
+
+// SyncManifestPath is where SyncWorkspace records, inside the container, the
+// workspace state it last pushed. It sits outside the workspace so the
+// agent's tree and git status never see it, and dies with the container.
+const SyncManifestPath = "/var/lib/clawker/workspace-sync.json"
+
+// syncExecBatch caps the paths handed to one rm or chown exec.
+const syncExecBatch = 256
+
+// SyncEntry is one workspace path as last seen on the host. Size and ModTime
+// are the quick check: an unchanged pair reuses Hash without re-reading the
+// file.
+type SyncEntry struct {
+	Mode    os.FileMode `json:"mode"`
+	Size    int64       `json:"size,omitempty"`
+	ModTime int64       `json:"mtime,omitempty"`
+	Hash    string      `json:"hash,omitempty"` // sha256 of a regular file's content
+	Link    string      `json:"link,omitempty"` // symlink target
+}
+
+// sameContent reports whether e and o would extract to the same entry.
+func (e SyncEntry) sameContent(o SyncEntry) bool {
+	return e.Mode == o.Mode && e.Hash == o.Hash && e.Link == o.Link
+}
+
+// SyncManifest is the set of workspace paths (slash-separated, relative to
+// the workspace root) pushed into a container, and where they were pushed.
+type SyncManifest struct {
+	Root  string               `json:"root"`
+	Files map[string]SyncEntry `json:"files"`
+}
+
+// SyncPlan lists the paths a sync writes and removes, each sorted so parents
+// precede children. Delete holds only the topmost removed paths.
+type SyncPlan struct {
+	Upload []string
+	Delete []string
+}
+
+// Empty reports whether the plan changes nothing.
+func (p SyncPlan) Empty() bool {
+	return len(p.Upload) == 0 && len(p.Delete) == 0
+}
+
+// SyncOptions tunes SyncWorkspace.
+type SyncOptions struct {
+	// DryRun computes the plan without touching the container.
+	DryRun bool
+	// Full ignores the recorded manifest and uploads every path. Nothing is
+	// deleted, since without a manifest there is no record of what to remove.
+	Full bool
+}
+
+// SyncResult describes a completed (or, with DryRun, planned) sync.
+type SyncResult struct {
+	SyncPlan
+	// Bytes is the size of the regular files uploaded.
+	Bytes int64
+	// Initial is true when the container had no usable manifest, so every
+	// path was uploaded and nothing was deleted.
+	Initial bool
+	// Manifest is the host state the container now matches.
+	Manifest *SyncManifest
+}
+
+// ScanWorkspace walks srcDir, skipping paths matched by ignorePatterns with
+// the same .gitignore semantics CopyToVolume applies, and records every
+// directory, regular file and symlink. Regular files whose size, mode and
+// modification time match their entry in prev keep prev's hash; the rest are
+// hashed. Other file types (sockets, devices, fifos) are skipped.
+func ScanWorkspace(srcDir string, ignorePatterns []string, prev *SyncManifest) (*SyncManifest, error) {
+	srcDir = filepath.Clean(srcDir)
+	ignore := compileIgnorePatterns(ignorePatterns)
+	m := &SyncManifest{Files: make(map[string]SyncEntry)}
+
+	err := filepath.Walk(srcDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if ignore.Match(splitIgnorePath(rel), info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		key := filepath.ToSlash(rel)
+		entry := SyncEntry{Mode: info.Mode()}
+		switch {
+		case info.IsDir():
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return fmt.Errorf("reading symlink %s: %w", rel, err)
+			}
+			entry.Link = link
+		case info.Mode().IsRegular():
+			entry.Size = info.Size()
+			entry.ModTime = info.ModTime().UnixNano()
+			if old, ok := prevEntry(prev, key); ok && old.Mode == entry.Mode && old.Size == entry.Size && old.ModTime == entry.ModTime && old.Hash != "" {
+				entry.Hash = old.Hash
+			} else {
+				h, err := hashFile(p)
+				if err != nil {
+					return fmt.Errorf("hashing %s: %w", rel, err)
+				}
+				entry.Hash = h
+			}
+		default:
+			return nil
+		}
+		m.Files[key] = entry
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", srcDir, err)
+	}
+	return m, nil
+}
+
+func prevEntry(prev *SyncManifest, key string) (SyncEntry, bool) {
+	if prev == nil {
+		return SyncEntry{}, false
+	}
+	e, ok := prev.Files[key]
+	return e, ok
+}
+
+func hashFile(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// DiffManifests plans the sync from prev (what the container holds) to next
+// (what the host holds). A nil prev uploads everything. Type changes need no
+// delete: extraction replaces whatever occupies a path.
+func DiffManifests(prev, next *SyncManifest) SyncPlan {
+	var plan SyncPlan
+	for p, e := range next.Files {
+		if old, ok := prevEntry(prev, p); !ok || !old.sameContent(e) {
+			plan.Upload = append(plan.Upload, p)
+		}
+	}
+	if prev != nil {
+		var gone []string
+		for p := range prev.Files {
+			if _, ok := next.Files[p]; !ok {
+				gone = append(gone, p)
+			}
+		}
+		slices.Sort(gone)
+		// Removing a directory removes its children; keep only the topmost.
+		for _, p := range gone {
+			if n := len(plan.Delete); n > 0 && strings.HasPrefix(p, plan.Delete[n-1]+"/") {
+				continue
+			}
+			plan.Delete = append(plan.Delete, p)
+		}
+	}
+	slices.Sort(plan.Upload)
+	return plan
+}
+
+// SyncWorkspace brings the workspace at destPath in a running container up to
+// date with srcDir. It compares the host tree against the manifest the last
+// sync left at SyncManifestPath, removes paths deleted on the host, uploads
+// new and changed paths as a single tar through CopyToContainer, hands them
+// to the container user, and records the new manifest.
+//
+// The host wins for every path it changed; paths changed only inside the
+// container are left alone. Without a manifest (the first sync after the
+// container was created, or opts.Full) every path is uploaded and nothing is
+// deleted.
+func (c *Client) SyncWorkspace(ctx context.Context, containerID, srcDir, destPath string, ignorePatterns []string, opts SyncOptions) (*SyncResult, error) {
+	var prev *SyncManifest
+	if !opts.Full {
+		m, err := c.readSyncManifest(ctx, containerID)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil && m.Root == destPath {
+			prev = m
+		}
+	}
+
+	next, err := ScanWorkspace(srcDir, ignorePatterns, prev)
+	if err != nil {
+		return nil, err
+	}
+	next.Root = destPath
+
+	result := &SyncResult{
+		SyncPlan: DiffManifests(prev, next),
+		Initial:  prev == nil,
+		Manifest: next,
+	}
+	for _, p := range result.Upload {
+		result.Bytes += next.Files[p].Size
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	c.log.Debug().
+		Str("container", containerID).
+		Str("src", srcDir).
+		Str("dest", destPath).
+		Int("upload", len(result.Upload)).
+		Int("delete", len(result.Delete)).
+		Msg("syncing workspace")
+
+	if err := c.execBatches(ctx, containerID, []string{"rm", "-rf", "--"}, absPaths(destPath, result.Delete)); err != nil {
+		return nil, fmt.Errorf("removing deleted paths: %w", err)
+	}
+
+	if len(result.Upload) > 0 {
+		var buf bytes.Buffer
+		if err := c.writeSyncArchive(&buf, srcDir, result.Upload); err != nil {
+			return nil, fmt.Errorf("failed to create tar archive: %w", err)
+		}
+		if _, err := c.CopyToContainer(ctx, containerID, whail.CopyToContainerOptions{
+			DestinationPath:           destPath,
+			Content:                   &buf,
+			AllowOverwriteDirWithFile: true,
+		}); err != nil {
+			return nil, fmt.Errorf("uploading changes: %w", err)
+		}
+		// CopyToContainer extracts as root whatever the headers say; see
+		// CopyToVolume.
+		owner := fmt.Sprintf("%d:%d", c.cfg.ContainerUID(), c.cfg.ContainerGID())
+		if err := c.execBatches(ctx, containerID, []string{"chown", "-h", owner, "--"}, absPaths(destPath, result.Upload)); err != nil {
+			return nil, fmt.Errorf("setting ownership: %w", err)
+		}
+	}
+
+	if err := c.writeSyncManifest(ctx, containerID, next); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// writeSyncArchive writes the listed workspace paths into a tar owned by the
+// container user.
+func (c *Client) writeSyncArchive(w io.Writer, srcDir string, paths []string) error {
+	tw := tar.NewWriter(w)
+	for _, rel := range paths {
+		p := filepath.Join(srcDir, filepath.FromSlash(rel))
+		info, err := os.Lstat(p)
+		if err != nil {
+			_ = tw.Close()
+			return fmt.Errorf("stat %s: %w", rel, err)
+		}
+		if err := writeTarEntry(tw, p, rel, info, c.cfg.ContainerUID(), c.cfg.ContainerGID()); err != nil {
+			_ = tw.Close()
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("close tar writer: %w", err)
+	}
+	return nil
+}
+
+// readSyncManifest returns the container's manifest, or nil when it has none
+// or it cannot be parsed (a later sync rewrites it).
+func (c *Client) readSyncManifest(ctx context.Context, containerID string) (*SyncManifest, error) {
+	res, err := c.CopyFromContainer(ctx, containerID, whail.CopyFromContainerOptions{SourcePath: SyncManifestPath})
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading sync manifest: %w", err)
+	}
+	defer res.Content.Close()
+
+	tr := tar.NewReader(res.Content)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading sync manifest: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		var m SyncManifest
+		if err := json.NewDecoder(tr).Decode(&m); err != nil || m.Files == nil {
+			c.log.Debug().Err(err).Str("container", containerID).Msg("ignoring unreadable sync manifest")
+			return nil, nil
+		}
+		return &m, nil
+	}
+}
+
+func (c *Client) writeSyncManifest(ctx context.Context, containerID string, m *SyncManifest) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("encoding sync manifest: %w", err)
+	}
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    path.Base(SyncManifestPath),
+		Mode:    0o600,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return fmt.Errorf("encoding sync manifest: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("encoding sync manifest: %w", err)
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("encoding sync manifest: %w", err)
+	}
+	if err := c.execRoot(ctx, containerID, []string{"mkdir", "-p", path.Dir(SyncManifestPath)}); err != nil {
+		return fmt.Errorf("writing sync manifest: %w", err)
+	}
+	if _, err := c.CopyToContainer(ctx, containerID, whail.CopyToContainerOptions{
+		DestinationPath: path.Dir(SyncManifestPath),
+		Content:         &buf,
+	}); err != nil {
+		return fmt.Errorf("writing sync manifest: %w", err)
+	}
+	return nil
+}
+
+// execBatches runs cmd followed by successive batches of args as root,
+// syncExecBatch args at a time. No args runs nothing.
+func (c *Client) execBatches(ctx context.Context, containerID string, cmd, args []string) error {
+	for batch := range slices.Chunk(args, syncExecBatch) {
+		if err := c.execRoot(ctx, containerID, slices.Concat(cmd, batch)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execRoot runs cmd as root in a running container and waits for it. A
+// non-zero exit is an error carrying the command's output.
+func (c *Client) execRoot(ctx context.Context, containerID string, cmd []string) error {
+	stdout, stderr, exitCode, err := c.ContainerExecRun(ctx, containerID, whail.ExecRunOptions{
+		User: "0",
+		Cmd:  cmd,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd[0], err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("%s exited with code %d: %s", cmd[0], exitCode, strings.TrimSpace(stdout+stderr))
+	}
+	return nil
+}
+
+func absPaths(root string, rels []string) []string {
+	out := make([]string, len(rels))
+	for i, r := range rels {
+		out[i] = path.Join(root, r)
+	}
+	return out
+}
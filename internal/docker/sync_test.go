@@ -0,0 +1,103 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+}
+
+func TestScanWorkspace(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"main.go":             "package main",
+		"pkg/util.go":         "package pkg",
+		"node_modules/x/a.js": "x",
+		".env":                "SECRET=1",
+	})
+	require.NoError(t, os.Symlink("main.go", filepath.Join(root, "link")))
+
+	m, err := ScanWorkspace(root, []string{"node_modules/", ".env"}, nil)
+	require.NoError(t, err)
+
+	keys := make([]string, 0, len(m.Files))
+	for k := range m.Files {
+		keys = append(keys, k)
+	}
+	assert.ElementsMatch(t, []string{"main.go", "pkg", "pkg/util.go", "link"}, keys)
+	assert.True(t, m.Files["pkg"].Mode.IsDir())
+	assert.Equal(t, "main.go", m.Files["link"].Link)
+	assert.Len(t, m.Files["main.go"].Hash, 64)
+	assert.Equal(t, int64(len("package main")), m.Files["main.go"].Size)
+}
+
+func TestScanWorkspace_QuickCheck(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"a.txt": "one"})
+
+	first, err := ScanWorkspace(root, nil, nil)
+	require.NoError(t, err)
+
+	// An unchanged size and mtime reuse the recorded hash without reading
+	// the file; a stale sentinel proves the file was not re-hashed.
+	prev := &SyncManifest{Files: map[string]SyncEntry{"a.txt": first.Files["a.txt"]}}
+	e := prev.Files["a.txt"]
+	e.Hash = "recorded"
+	prev.Files["a.txt"] = e
+
+	second, err := ScanWorkspace(root, nil, prev)
+	require.NoError(t, err)
+	assert.Equal(t, "recorded", second.Files["a.txt"].Hash)
+
+	// A new mtime forces a re-hash.
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(root, "a.txt"), later, later))
+	third, err := ScanWorkspace(root, nil, prev)
+	require.NoError(t, err)
+	assert.Equal(t, first.Files["a.txt"].Hash, third.Files["a.txt"].Hash)
+}
+
+func TestDiffManifests(t *testing.T) {
+	dir := SyncEntry{Mode: os.ModeDir | 0o755}
+	file := func(hash string) SyncEntry { return SyncEntry{Mode: 0o644, Hash: hash} }
+
+	prev := &SyncManifest{Files: map[string]SyncEntry{
+		"keep.go":        file("k"),
+		"edit.go":        file("old"),
+		"gone.go":        file("g"),
+		"olddir":         dir,
+		"olddir/a.go":    file("a"),
+		"olddir/sub":     dir,
+		"olddir/sub/b.g": file("b"),
+		"swap":           file("s"),
+	}}
+	next := &SyncManifest{Files: map[string]SyncEntry{
+		"keep.go":     file("k"),
+		"edit.go":     file("new"),
+		"newdir":      dir,
+		"newdir/c.go": file("c"),
+		"swap":        dir,
+	}}
+
+	plan := DiffManifests(prev, next)
+	assert.Equal(t, []string{"edit.go", "newdir", "newdir/c.go", "swap"}, plan.Upload)
+	assert.Equal(t, []string{"gone.go", "olddir"}, plan.Delete)
+
+	initial := DiffManifests(nil, next)
+	assert.Len(t, initial.Upload, len(next.Files))
+	assert.Empty(t, initial.Delete)
+
+	assert.True(t, DiffManifests(next, next).Empty())
+}
@@ -39,6 +39,7 @@ type (
 	ExecResizeOptions  = whail.ExecResizeOptions
 	ExecInspectOptions = whail.ExecInspectOptions
 	ExecInspectResult  = whail.ExecInspectResult
+	ExecRunOptions     = whail.ExecRunOptions
 
 	// Copy operation options.
 	CopyToContainerOptions   = whail.CopyToContainerOptions
@@ -50,6 +51,11 @@ type (
 	ImageBuildOptions  = whail.ImageBuildOptions
 	ImagePullOptions   = whail.ImagePullOptions
 
+	// Image prune policy and results.
+	PrunePolicy = whail.PrunePolicy
+	PruneReport = whail.PruneReport
+	PrunedImage = whail.PrunedImage
+
 	// Image result types.
 	ImageSummary    = whail.ImageSummary
 	ImageListResult = whail.ImageListResult
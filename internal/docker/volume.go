@@ -199,21 +199,9 @@ func (c *Client) CopyToVolume(ctx context.Context, volumeName, srcDir, destPath
 		},
 	}
 
-	// Pull chown image if needed (uses raw check — image may be external/unmanaged)
-	exists, err := c.imageExistsRaw(ctx, chownImg)
-	if err != nil {
-		return fmt.Errorf("checking for chown image %s: %w", chownImg, err)
-	}
-	if !exists {
-		pullResp, err := c.ImagePull(ctx, chownImg, whail.ImagePullOptions{})
-		if err != nil {
-			return fmt.Errorf("failed to pull chown image %s: %w", chownImg, err)
-		}
-		if _, err := io.Copy(io.Discard, pullResp); err != nil {
-			pullResp.Close()
-			return fmt.Errorf("failed to drain image pull response: %w", err)
-		}
-		pullResp.Close()
+	// Pull chown image if needed (raw — the image is external/unmanaged)
+	if err := c.EnsureExternalImage(ctx, chownImg); err != nil {
+		return fmt.Errorf("chown image: %w", err)
 	}
 
 	// Create temporary container via whail Engine (inherits managed labels + any
@@ -0,0 +1,52 @@
+package docs
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// CompletionShells lists the shells GenCompletions writes scripts for.
+var CompletionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// CompletionFilename returns the conventional script filename for shell:
+// <name>.bash, _<name> (zsh autoloads by function name), <name>.fish and
+// <name>.ps1.
+func CompletionFilename(name, shell string) string {
+	switch shell {
+	case "zsh":
+		return "_" + name
+	case "powershell":
+		return name + ".ps1"
+	default:
+		return name + "." + shell
+	}
+}
+
+// GenCompletions writes a completion script for each of CompletionShells to
+// dir. The scripts are cobra's: static command and flag names are embedded,
+// while dynamic values (agents, projects, containers) are resolved at tab
+// time by calling back into the binary's hidden __complete command.
+func GenCompletions(cmd *cobra.Command, dir string) error {
+	root := cmd.Root()
+	name := root.Name()
+	for _, shell := range CompletionShells {
+		path := filepath.Join(dir, CompletionFilename(name, shell))
+		var err error
+		switch shell {
+		case "bash":
+			err = root.GenBashCompletionFileV2(path, true)
+		case "zsh":
+			err = root.GenZshCompletionFile(path)
+		case "fish":
+			err = root.GenFishCompletionFile(path, true)
+		case "powershell":
+			err = root.GenPowerShellCompletionFileWithDesc(path)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to generate %s completion: %w", shell, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenCompletions(t *testing.T) {
+	rootCmd := newTestRootCmd()
+	containerCmd, _, _ := rootCmd.Find([]string{"container"})
+	require.NotNil(t, containerCmd)
+
+	dir := t.TempDir()
+	// Any command in the tree generates the root's scripts.
+	require.NoError(t, GenCompletions(containerCmd, dir))
+
+	for _, shell := range CompletionShells {
+		t.Run(shell, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(dir, CompletionFilename(rootCmd.Name(), shell)))
+			require.NoError(t, err)
+			// Dynamic values are resolved by calling back into the binary.
+			assert.Contains(t, string(data), "__complete")
+		})
+	}
+}
+
+func TestCompletionFilename(t *testing.T) {
+	assert.Equal(t, "clawker.bash", CompletionFilename("clawker", "bash"))
+	assert.Equal(t, "_clawker", CompletionFilename("clawker", "zsh"))
+	assert.Equal(t, "clawker.fish", CompletionFilename("clawker", "fish"))
+	assert.Equal(t, "clawker.ps1", CompletionFilename("clawker", "powershell"))
+}
@@ -0,0 +1,138 @@
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/internal/storage"
+)
+
+// ConfigRefFile is one config file in the flat config key reference.
+type ConfigRefFile struct {
+	File        string         `yaml:"file"`
+	Description string         `yaml:"description"`
+	Keys        []ConfigRefKey `yaml:"keys"`
+}
+
+// ConfigRefKey is a single key of a config file. Unlike ConfigField it is
+// plain text (no MDX escaping) so it serializes as-is to YAML.
+type ConfigRefKey struct {
+	Key         string `yaml:"key"`
+	Type        string `yaml:"type"`
+	Default     string `yaml:"default,omitempty"`
+	Required    bool   `yaml:"required,omitempty"`
+	Merge       string `yaml:"merge,omitempty"`
+	Interpolate bool   `yaml:"interpolate"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// ConfigReference returns every key of the project config, the settings and
+// the project registry, read from the same storage.Schema field sets the
+// stores use for defaults, merging and drift detection. Merge is only set for
+// the layered files (project config and settings), and Interpolate only for
+// clawker.yaml, the one store loaded with storage.WithInterpolation.
+func ConfigReference() []ConfigRefFile {
+	return []ConfigRefFile{
+		configRefFile(consts.ProjectConfigFile, "Project configuration, merged across the user, project and profile layers",
+			config.Project{}, true, true),
+		configRefFile(consts.SettingsFile, "User settings", config.Settings{}, true, false),
+		configRefFile(consts.RegistryFile, "Project registry, managed by clawker project commands",
+			project.ProjectRegistry{}, false, false),
+	}
+}
+
+func configRefFile(file, desc string, schema storage.Schema, layered, interpolated bool) ConfigRefFile {
+	ref := ConfigRefFile{File: file, Description: desc}
+	for _, f := range schema.Fields().All() {
+		key := ConfigRefKey{
+			Key:         f.Path(),
+			Type:        kindToType(f.Kind()),
+			Default:     f.Default(),
+			Required:    f.Required(),
+			Interpolate: interpolated && f.Interpolate(),
+			Description: f.Description(),
+		}
+		if layered {
+			key.Merge = f.MergeTag()
+			if key.Merge == "" {
+				key.Merge = "replace"
+			}
+		}
+		ref.Keys = append(ref.Keys, key)
+	}
+	return ref
+}
+
+// GenConfigReferenceYAML writes ConfigReference as a YAML document.
+func GenConfigReferenceYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(map[string][]ConfigRefFile{"files": ConfigReference()}); err != nil {
+		return fmt.Errorf("encoding config reference: %w", err)
+	}
+	return enc.Close()
+}
+
+// GenConfigReferenceMarkdown writes ConfigReference as one Markdown table
+// per config file, keyed by full dotted path.
+func GenConfigReferenceMarkdown(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString("# Config key reference\n\n")
+	buf.WriteString("Every key of every clawker config file, generated from the schema structs by\n")
+	buf.WriteString("`gen-docs --config-reference`; do not edit by hand.\n\n")
+	buf.WriteString("Keys are not overridden by environment variables one by one. Instead, values of\n")
+	buf.WriteString("keys marked `${VAR}` may reference environment variables (`${VAR}` or\n")
+	buf.WriteString("`${VAR:-default}`), which are expanded when the file is loaded. Set\n")
+	fmt.Fprintf(&buf, "`%s=1` to make an unset variable an error. Merge applies to layered\n", consts.EnvStrictInterpolation)
+	buf.WriteString("files and says how a key folds across layers.\n")
+
+	for _, file := range ConfigReference() {
+		fmt.Fprintf(&buf, "\n## %s\n\n%s.\n\n", file.File, file.Description)
+		if file.Keys[0].Merge != "" {
+			buf.WriteString("| Key | Type | Default | Merge | Env | Description |\n")
+			buf.WriteString("|-----|------|---------|-------|-----|-------------|\n")
+		} else {
+			buf.WriteString("| Key | Type | Default | Env | Description |\n")
+			buf.WriteString("|-----|------|---------|-----|-------------|\n")
+		}
+		for _, k := range file.Keys {
+			cells := []string{"`" + k.Key + "`", k.Type, codeOrDash(k.Default)}
+			if k.Merge != "" {
+				cells = append(cells, k.Merge)
+			}
+			env := "—"
+			if k.Interpolate {
+				env = "`${VAR}`"
+			}
+			desc := k.Description
+			if k.Required {
+				desc += " **(required)**"
+			}
+			cells = append(cells, env, tableCell(desc))
+			buf.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func codeOrDash(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return "`" + tableCell(s) + "`"
+}
+
+// tableCell keeps s on one Markdown table row.
+func tableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
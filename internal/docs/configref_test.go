@@ -0,0 +1,72 @@
+package docs
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+func findRefKey(t *testing.T, file ConfigRefFile, key string) ConfigRefKey {
+	t.Helper()
+	for _, k := range file.Keys {
+		if k.Key == key {
+			return k
+		}
+	}
+	t.Fatalf("%s: key %q not in reference", file.File, key)
+	return ConfigRefKey{}
+}
+
+func TestConfigReference(t *testing.T) {
+	files := ConfigReference()
+	require.Len(t, files, 3)
+	project, settings, registry := files[0], files[1], files[2]
+	assert.Equal(t, consts.ProjectConfigFile, project.File)
+	assert.Equal(t, consts.SettingsFile, settings.File)
+	assert.Equal(t, consts.RegistryFile, registry.File)
+
+	// Every schema field is listed.
+	assert.Len(t, project.Keys, config.Project{}.Fields().Len())
+	assert.Len(t, settings.Keys, config.Settings{}.Fields().Len())
+
+	packages := findRefKey(t, project, "build.packages")
+	assert.Equal(t, "string list", packages.Type)
+	assert.Equal(t, "ripgrep", packages.Default)
+	assert.Equal(t, "unique-union", packages.Merge)
+	assert.True(t, packages.Interpolate)
+
+	// Only clawker.yaml is loaded with interpolation; the registry isn't layered.
+	assert.False(t, findRefKey(t, settings, "terminal.detach_keys").Interpolate)
+	projects := findRefKey(t, registry, "projects")
+	assert.False(t, projects.Interpolate)
+	assert.Empty(t, projects.Merge)
+}
+
+func TestGenConfigReferenceMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, GenConfigReferenceMarkdown(&buf))
+	out := buf.String()
+
+	checkStringContains(t, out, "## clawker.yaml")
+	checkStringContains(t, out, "| Key | Type | Default | Merge | Env | Description |")
+	checkStringContains(t, out, "| `build.packages` | string list | `ripgrep` | unique-union | `${VAR}` |")
+	checkStringContains(t, out, "## registry.yaml")
+	checkStringContains(t, out, consts.EnvStrictInterpolation)
+}
+
+func TestGenConfigReferenceYAML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, GenConfigReferenceYAML(&buf))
+
+	var doc struct {
+		Files []ConfigRefFile `yaml:"files"`
+	}
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, ConfigReference(), doc.Files)
+}
@@ -0,0 +1,124 @@
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ManifestSchemaVersion is bumped on breaking changes to the manifest shape,
+// so consumers (docs site, editor extensions, agents) can detect them.
+const ManifestSchemaVersion = 1
+
+// CommandManifest is a machine-readable description of the whole command
+// tree: every visible command with its flags, defaults and examples.
+type CommandManifest struct {
+	SchemaVersion int               `json:"schema_version"`
+	Root          string            `json:"root"`
+	Commands      []ManifestCommand `json:"commands"`
+}
+
+// ManifestCommand describes one command. Commands are listed flat,
+// depth-first from the root; Parent and Subcommands link the tree.
+type ManifestCommand struct {
+	Path           string         `json:"path"`
+	Name           string         `json:"name"`
+	Parent         string         `json:"parent,omitempty"`
+	Aliases        []string       `json:"aliases,omitempty"`
+	Short          string         `json:"short,omitempty"`
+	Long           string         `json:"long,omitempty"`
+	Usage          string         `json:"usage,omitempty"` // Set only for runnable commands.
+	Example        string         `json:"example,omitempty"`
+	Deprecated     string         `json:"deprecated,omitempty"`
+	Subcommands    []string       `json:"subcommands,omitempty"`
+	Flags          []ManifestFlag `json:"flags,omitempty"`
+	InheritedFlags []ManifestFlag `json:"inherited_flags,omitempty"`
+}
+
+// ManifestFlag describes one flag. Default is the flag's default exactly as
+// cobra prints it ("false", "[]", ...), so consumers need not guess.
+type ManifestFlag struct {
+	Name       string `json:"name"`
+	Shorthand  string `json:"shorthand,omitempty"`
+	Type       string `json:"type"`
+	Default    string `json:"default"`
+	Usage      string `json:"usage"`
+	Required   bool   `json:"required,omitempty"`
+	Deprecated string `json:"deprecated,omitempty"`
+}
+
+// BuildCommandManifest walks cmd and its visible descendants. Hidden
+// commands and flags are left out, as in the other formats.
+func BuildCommandManifest(cmd *cobra.Command) CommandManifest {
+	m := CommandManifest{SchemaVersion: ManifestSchemaVersion, Root: cmd.CommandPath()}
+	var walk func(*cobra.Command)
+	walk = func(c *cobra.Command) {
+		m.Commands = append(m.Commands, buildManifestCommand(c))
+		for _, sub := range getNonHiddenCommands(c) {
+			walk(sub)
+		}
+	}
+	walk(cmd)
+	return m
+}
+
+// GenCommandManifest writes BuildCommandManifest(cmd) to w as indented JSON.
+func GenCommandManifest(cmd *cobra.Command, w io.Writer) error {
+	data, err := json.MarshalIndent(BuildCommandManifest(cmd), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding command manifest: %w", err)
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func buildManifestCommand(cmd *cobra.Command) ManifestCommand {
+	cmd.InitDefaultHelpFlag()
+
+	mc := ManifestCommand{
+		Path:       cmd.CommandPath(),
+		Name:       cmd.Name(),
+		Aliases:    cmd.Aliases,
+		Short:      cmd.Short,
+		Long:       cmd.Long,
+		Example:    cmd.Example,
+		Deprecated: cmd.Deprecated,
+	}
+	if cmd.HasParent() {
+		mc.Parent = cmd.Parent().CommandPath()
+	}
+	if cmd.Runnable() {
+		mc.Usage = cmd.UseLine()
+	}
+	for _, c := range getNonHiddenCommands(cmd) {
+		mc.Subcommands = append(mc.Subcommands, c.Name())
+	}
+	mc.Flags = manifestFlags(cmd.NonInheritedFlags())
+	mc.InheritedFlags = manifestFlags(cmd.InheritedFlags())
+	return mc
+}
+
+func manifestFlags(fs *pflag.FlagSet) []ManifestFlag {
+	var flags []ManifestFlag
+	fs.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden {
+			return
+		}
+		required := f.Annotations[cobra.BashCompOneRequiredFlag]
+		flags = append(flags, ManifestFlag{
+			Name:       f.Name,
+			Shorthand:  f.Shorthand,
+			Type:       f.Value.Type(),
+			Default:    f.DefValue,
+			Usage:      f.Usage,
+			Required:   len(required) > 0 && required[0] == "true",
+			Deprecated: f.Deprecated,
+		})
+	})
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
@@ -0,0 +1,79 @@
+package docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findManifestCommand(t *testing.T, m CommandManifest, path string) ManifestCommand {
+	t.Helper()
+	for _, c := range m.Commands {
+		if c.Path == path {
+			return c
+		}
+	}
+	t.Fatalf("command %q not in manifest", path)
+	return ManifestCommand{}
+}
+
+func TestBuildCommandManifest(t *testing.T) {
+	rootCmd := newTestRootCmd()
+	startCmd, _, _ := rootCmd.Find([]string{"container", "start"})
+	startCmd.Run = func(*cobra.Command, []string) {}
+	require.NoError(t, startCmd.MarkFlagRequired("agent"))
+
+	m := BuildCommandManifest(rootCmd)
+	assert.Equal(t, ManifestSchemaVersion, m.SchemaVersion)
+	assert.Equal(t, "clawker", m.Root)
+
+	var paths []string
+	for _, c := range m.Commands {
+		paths = append(paths, c.Path)
+	}
+	// Depth-first, sorted, hidden commands left out.
+	assert.Equal(t, []string{
+		"clawker",
+		"clawker container",
+		"clawker container list",
+		"clawker container start",
+		"clawker container stop",
+		"clawker volume",
+		"clawker volume list",
+		"clawker volume prune",
+	}, paths)
+
+	container := findManifestCommand(t, m, "clawker container")
+	assert.Equal(t, "clawker", container.Parent)
+	assert.Equal(t, []string{"c"}, container.Aliases)
+	assert.Equal(t, []string{"list", "start", "stop"}, container.Subcommands)
+	assert.Empty(t, container.Usage, "non-runnable commands have no usage line")
+
+	start := findManifestCommand(t, m, "clawker container start")
+	assert.Equal(t, "clawker container start [CONTAINER] [flags]", start.Usage)
+	assert.Contains(t, start.Example, "clawker container start --agent dev")
+	assert.Contains(t, start.Flags, ManifestFlag{
+		Name: "agent", Type: "string", Default: "", Usage: "Agent name shortcut", Required: true,
+	})
+	assert.Contains(t, start.InheritedFlags, ManifestFlag{
+		Name: "debug", Type: "bool", Default: "false", Usage: "Enable debug logging",
+	})
+
+	stop := findManifestCommand(t, m, "clawker container stop")
+	assert.Contains(t, stop.Flags, ManifestFlag{
+		Name: "time", Shorthand: "t", Type: "duration", Default: "0s", Usage: "Seconds to wait before killing the container",
+	})
+}
+
+func TestGenCommandManifest(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, GenCommandManifest(newTestRootCmd(), &buf))
+
+	var m CommandManifest
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	assert.Equal(t, BuildCommandManifest(newTestRootCmd()), m)
+}
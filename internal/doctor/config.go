@@ -0,0 +1,88 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/project"
+)
+
+// Check names, stable for --json consumers.
+const (
+	CheckConfig          = "config"
+	CheckRegistry        = "registry"
+	CheckDocker          = "docker"
+	CheckHostRuntime     = "host-runtime"
+	CheckMonitoringPorts = "monitoring-ports"
+	CheckHostProxy       = "host-proxy"
+	CheckGPG             = "gpg"
+	CheckSSH             = "ssh"
+)
+
+// Skipped returns a check that always skips with reason — for checks whose
+// prerequisite (usually a loadable config) is missing.
+func Skipped(name, reason string) Check {
+	return Check{Name: name, Run: func(context.Context) Result { return Skip(reason) }}
+}
+
+// ConfigCheck loads the project and settings configuration. Loading parses
+// every config file in the walk-up chain and validates it against the
+// schema, so a load error names the file and key at fault.
+func ConfigCheck(load func() (config.Config, error)) Check {
+	return Check{
+		Name: CheckConfig,
+		Run: func(context.Context) Result {
+			if _, err := load(); err != nil {
+				return Fail(err.Error(), "fix the reported file, or inspect the merged config with 'clawker config list'")
+			}
+			return Pass("config files parse and match the schema")
+		},
+	}
+}
+
+// RegistryCheck verifies every registered project root and worktree still
+// exists on disk. Stale entries are warnings: clawker keeps working, but
+// commands resolving those projects or worktrees will fail.
+func RegistryCheck(list func(ctx context.Context) ([]project.ProjectState, error)) Check {
+	return Check{
+		Name: CheckRegistry,
+		Run: func(ctx context.Context) Result {
+			projects, err := list(ctx)
+			if err != nil {
+				return Fail(fmt.Sprintf("cannot read the project registry: %v", err), "check the registry file's permissions and syntax")
+			}
+			var stale []string
+			worktrees := 0
+			for _, p := range projects {
+				switch p.Status {
+				case project.ProjectMissing:
+					stale = append(stale, fmt.Sprintf("project %s: root %s is missing", p.Name, p.Root))
+				case project.ProjectInaccessible:
+					stale = append(stale, fmt.Sprintf("project %s: root %s is inaccessible: %v", p.Name, p.Root, p.StatusErr))
+				}
+				for _, wt := range p.Worktrees {
+					worktrees++
+					if wt.Status != project.WorktreeHealthy {
+						stale = append(stale, fmt.Sprintf("project %s: worktree %s (%s) is %s", p.Name, wt.Branch, wt.Path, wt.Status))
+					}
+				}
+			}
+			if len(stale) > 0 {
+				return Warn(plural(len(stale), "stale registry entry", "stale registry entries"),
+					"re-point moved projects with 'clawker project move', drop deleted ones with 'clawker project gc', and prune worktrees with 'clawker worktree prune'",
+					stale...)
+			}
+			return Pass(fmt.Sprintf("%s and %s registered, all present",
+				plural(len(projects), "project", "projects"), plural(worktrees, "worktree", "worktrees")))
+		},
+	}
+}
+
+// plural formats n with the singular or plural noun.
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, one)
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
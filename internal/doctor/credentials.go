@@ -0,0 +1,54 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// GPGCheck verifies the host gpg-agent accepts connections on the extra
+// socket GPG forwarding bridges into containers. Container start waits for
+// the same probe, so a failure here is a start failure. enabled is the
+// project's security.git_credentials.forward_gpg; probe is
+// hostproxy.CheckGPGAgent.
+func GPGCheck(enabled bool, probe func(ctx context.Context) error) Check {
+	return Check{
+		Name: CheckGPG,
+		Run: func(ctx context.Context) Result {
+			if !enabled {
+				return Skip("GPG forwarding disabled (security.git_credentials.forward_gpg: false)")
+			}
+			if err := probe(ctx); err != nil {
+				return Fail(err.Error(),
+					"install GnuPG and run 'gpgconf --launch gpg-agent', or set security.git_credentials.forward_gpg: false")
+			}
+			return Pass("gpg-agent accepting connections")
+		},
+	}
+}
+
+// SSHCheck verifies the host ssh-agent at sock (SSH_AUTH_SOCK) accepts
+// connections. Without it git over SSH inside containers has no keys, but
+// nothing else breaks, so problems are warnings. enabled is the project's
+// security.git_credentials.forward_ssh.
+func SSHCheck(enabled bool, sock string) Check {
+	return Check{
+		Name: CheckSSH,
+		Run: func(ctx context.Context) Result {
+			if !enabled {
+				return Skip("SSH forwarding disabled (security.git_credentials.forward_ssh: false)")
+			}
+			const hint = "start ssh-agent and load keys with 'ssh-add', or set security.git_credentials.forward_ssh: false"
+			if sock == "" {
+				return Warn("SSH_AUTH_SOCK not set; containers get no SSH keys", hint)
+			}
+			var d net.Dialer
+			conn, err := d.DialContext(ctx, "unix", sock)
+			if err != nil {
+				return Warn(fmt.Sprintf("ssh-agent not accepting connections at %s: %v", sock, err), hint)
+			}
+			_ = conn.Close()
+			return Pass("ssh-agent accepting connections at " + sock)
+		},
+	}
+}
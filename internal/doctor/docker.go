@@ -0,0 +1,70 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+)
+
+// DockerCheck verifies the Docker daemon answers a ping. Every clawker
+// command that touches containers, images, or the control plane needs it.
+// ping returns a description of the daemon it reached (host and the
+// context or env var that chose it), shown on success.
+func DockerCheck(ping func(ctx context.Context) (string, error)) Check {
+	return Check{
+		Name: CheckDocker,
+		Run: func(ctx context.Context) Result {
+			endpoint, err := ping(ctx)
+			if err != nil {
+				return Fail(fmt.Sprintf("docker daemon unreachable: %v", err),
+					"start Docker (or Docker Desktop) and check DOCKER_HOST, DOCKER_CONTEXT, or settings docker.context points at it")
+			}
+			if endpoint == "" {
+				return Pass("docker daemon reachable")
+			}
+			return Pass("docker daemon reachable at " + endpoint)
+		},
+	}
+}
+
+// HostRuntime is what HostRuntimeCheck reports about the Docker provider.
+type HostRuntime struct {
+	// Name describes the provider and its file share: "Colima (sshfs)".
+	Name string
+	// SlowFileSharing is set when bind-mounted workspaces will be slow.
+	SlowFileSharing bool
+	// Cautions lists file-share behaviors that affect agents.
+	Cautions []string
+	// Hint is how to switch to a faster share.
+	Hint string
+	// Remote is set when the daemon runs on another machine (a tcp:// or
+	// ssh:// endpoint), where bind mode and the host proxy do not work.
+	Remote bool
+}
+
+// HostRuntimeCheck reports the Docker provider (Docker Desktop, Colima,
+// Rancher Desktop, OrbStack, Podman, Docker Engine) and warns when its file
+// share makes bind-mounted workspaces slow. Cautions about the share are
+// listed either way. A remote daemon is a warning of its own: bind-mode
+// workspaces are refused and the host proxy is unreachable from its
+// containers. detect fails when the daemon is unreachable, which the
+// docker check already reports, so that is a skip.
+func HostRuntimeCheck(detect func(ctx context.Context) (HostRuntime, error)) Check {
+	return Check{
+		Name: CheckHostRuntime,
+		Run: func(ctx context.Context) Result {
+			rt, err := detect(ctx)
+			if err != nil {
+				return Skip("docker daemon unreachable")
+			}
+			if rt.Remote {
+				return Warn(rt.Name+": bind mode and host proxy features are unavailable",
+					"use workspace.default_mode: snapshot (or --mode snapshot); browser auth and git credential forwarding through the host proxy will not work",
+					rt.Cautions...)
+			}
+			if rt.SlowFileSharing {
+				return Warn(rt.Name+": bind-mounted workspaces will be slow", rt.Hint, rt.Cautions...)
+			}
+			return Pass(rt.Name, rt.Cautions...)
+		},
+	}
+}
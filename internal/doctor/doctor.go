@@ -0,0 +1,114 @@
+// Package doctor runs the environment diagnostics behind `clawker doctor`.
+//
+// Each diagnostic is a Check: a name plus a Run function built by a
+// constructor that takes only the dependency it probes (a config loader, a
+// Docker ping, a port list), so checks are testable in isolation and the
+// command decides which ones apply. Run executes them in order and collects
+// one Result per check into a Report — the --json document and the source
+// of the text output.
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// Status is the outcome of one check.
+type Status string
+
+const (
+	// StatusPass means the check found nothing wrong.
+	StatusPass Status = "pass"
+	// StatusWarn means something is off but clawker still works — a
+	// degraded optional feature or a problem a later command will hit.
+	StatusWarn Status = "warn"
+	// StatusFail means clawker cannot work until the problem is fixed.
+	StatusFail Status = "fail"
+	// StatusSkip means the check did not apply (feature disabled, or a
+	// prerequisite check failed).
+	StatusSkip Status = "skip"
+)
+
+// Result is one check's outcome.
+type Result struct {
+	Check   string `json:"check"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	// Details lists the individual findings behind Message (one per
+	// project, port, ...), if any.
+	Details []string `json:"details,omitempty"`
+	// Hint is the suggested fix for a warn or fail.
+	Hint string `json:"hint,omitempty"`
+}
+
+// Check is one diagnostic. Run reports the outcome; Run does not need to
+// set Result.Check — the runner stamps Name.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) Result
+}
+
+// Report is the outcome of a doctor run.
+type Report struct {
+	// OK is false when any check failed; warnings do not clear it.
+	OK      bool     `json:"ok"`
+	Results []Result `json:"results"`
+}
+
+// Counts returns how many results have each status.
+func (r Report) Counts() map[Status]int {
+	counts := make(map[Status]int)
+	for _, res := range r.Results {
+		counts[res.Status]++
+	}
+	return counts
+}
+
+// Run executes checks in order, each under consts.DoctorCheckTimeout. A
+// check that panics is reported as failed rather than aborting the run.
+func Run(ctx context.Context, checks []Check) Report {
+	report := Report{OK: true, Results: make([]Result, 0, len(checks))}
+	for _, check := range checks {
+		res := runCheck(ctx, check)
+		if res.Status == StatusFail {
+			report.OK = false
+		}
+		report.Results = append(report.Results, res)
+	}
+	return report
+}
+
+// runCheck runs one check with its timeout and panic guard.
+func runCheck(ctx context.Context, check Check) (res Result) {
+	ctx, cancel := context.WithTimeout(ctx, consts.DoctorCheckTimeout)
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			res = Result{Status: StatusFail, Message: fmt.Sprintf("check panicked: %v", r)}
+		}
+		res.Check = check.Name
+	}()
+	return check.Run(ctx)
+}
+
+// Pass returns a passing result.
+func Pass(msg string, details ...string) Result {
+	return Result{Status: StatusPass, Message: msg, Details: details}
+}
+
+// Skip returns a skipped result.
+func Skip(msg string) Result {
+	return Result{Status: StatusSkip, Message: msg}
+}
+
+// Warn returns a warning result with a suggested fix.
+func Warn(msg, hint string, details ...string) Result {
+	return Result{Status: StatusWarn, Message: msg, Hint: hint, Details: details}
+}
+
+// Fail returns a failed result with a suggested fix.
+func Fail(msg, hint string, details ...string) Result {
+	return Result{Status: StatusFail, Message: msg, Hint: hint, Details: details}
+}
@@ -0,0 +1,203 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/hostproxy/hostproxytest"
+	"github.com/schmitthub/clawker/internal/project"
+)
+
+func TestRun_CollectsResultsAndFailsOnFail(t *testing.T) {
+	report := Run(context.Background(), []Check{
+		{Name: "a", Run: func(context.Context) Result { return Pass("fine") }},
+		{Name: "b", Run: func(context.Context) Result { return Warn("meh", "fix it") }},
+		{Name: "c", Run: func(context.Context) Result { panic("boom") }},
+		Skipped("d", "not applicable"),
+	})
+
+	assert.False(t, report.OK)
+	require.Len(t, report.Results, 4)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, []string{
+		report.Results[0].Check, report.Results[1].Check, report.Results[2].Check, report.Results[3].Check,
+	})
+	assert.Equal(t, StatusFail, report.Results[2].Status)
+	assert.Contains(t, report.Results[2].Message, "boom")
+	assert.Equal(t, map[Status]int{StatusPass: 1, StatusWarn: 1, StatusFail: 1, StatusSkip: 1}, report.Counts())
+
+	report = Run(context.Background(), []Check{
+		{Name: "a", Run: func(context.Context) Result { return Warn("meh", "fix it") }},
+	})
+	assert.True(t, report.OK, "warnings do not fail the run")
+}
+
+func TestConfigCheck(t *testing.T) {
+	res := ConfigCheck(func() (config.Config, error) { return nil, errors.New("clawker.yaml: unknown key build.nope") }).Run(context.Background())
+	assert.Equal(t, StatusFail, res.Status)
+	assert.Contains(t, res.Message, "build.nope")
+
+	res = ConfigCheck(func() (config.Config, error) { return nil, nil }).Run(context.Background())
+	assert.Equal(t, StatusPass, res.Status)
+}
+
+func TestRegistryCheck(t *testing.T) {
+	healthy := []project.ProjectState{{
+		Name: "app", Root: "/src/app", Status: project.ProjectOK,
+		Worktrees: []project.WorktreeState{{Branch: "feat", Status: project.WorktreeHealthy}},
+	}}
+	res := RegistryCheck(func(context.Context) ([]project.ProjectState, error) { return healthy, nil }).Run(context.Background())
+	assert.Equal(t, StatusPass, res.Status)
+	assert.Equal(t, "1 project and 1 worktree registered, all present", res.Message)
+
+	stale := []project.ProjectState{
+		{Name: "gone", Root: "/src/gone", Status: project.ProjectMissing},
+		{
+			Name: "app", Root: "/src/app", Status: project.ProjectOK,
+			Worktrees: []project.WorktreeState{{Branch: "old", Path: "/wt/old", Status: project.WorktreeRegistryOnly}},
+		},
+	}
+	res = RegistryCheck(func(context.Context) ([]project.ProjectState, error) { return stale, nil }).Run(context.Background())
+	assert.Equal(t, StatusWarn, res.Status)
+	assert.Equal(t, []string{
+		"project gone: root /src/gone is missing",
+		"project app: worktree old (/wt/old) is registry_only",
+	}, res.Details)
+
+	res = RegistryCheck(func(context.Context) ([]project.ProjectState, error) { return nil, errors.New("corrupt") }).Run(context.Background())
+	assert.Equal(t, StatusFail, res.Status)
+}
+
+func TestDockerCheck(t *testing.T) {
+	res := DockerCheck(func(context.Context) (string, error) { return `ssh://me@build-box (docker context "remote")`, nil }).Run(context.Background())
+	assert.Equal(t, StatusPass, res.Status)
+	assert.Contains(t, res.Message, "ssh://me@build-box")
+
+	res = DockerCheck(func(context.Context) (string, error) { return "", errors.New("connection refused") }).Run(context.Background())
+	assert.Equal(t, StatusFail, res.Status)
+	assert.Contains(t, res.Message, "connection refused")
+}
+
+func TestHostRuntimeCheck(t *testing.T) {
+	res := HostRuntimeCheck(func(context.Context) (HostRuntime, error) {
+		return HostRuntime{Name: "Docker Desktop (virtiofs file sharing)", Cautions: []string{"virtiofs: chown is not reflected"}}, nil
+	}).Run(context.Background())
+	assert.Equal(t, StatusPass, res.Status)
+	assert.Equal(t, "Docker Desktop (virtiofs file sharing)", res.Message)
+	assert.Len(t, res.Details, 1)
+
+	res = HostRuntimeCheck(func(context.Context) (HostRuntime, error) {
+		return HostRuntime{Name: "Colima (sshfs file sharing)", SlowFileSharing: true, Hint: "use virtiofs"}, nil
+	}).Run(context.Background())
+	assert.Equal(t, StatusWarn, res.Status)
+	assert.Equal(t, "use virtiofs", res.Hint)
+
+	res = HostRuntimeCheck(func(context.Context) (HostRuntime, error) {
+		return HostRuntime{Name: "Docker Engine (remote)", Remote: true}, nil
+	}).Run(context.Background())
+	assert.Equal(t, StatusWarn, res.Status)
+	assert.Contains(t, res.Message, "host proxy")
+	assert.Contains(t, res.Hint, "snapshot")
+
+	res = HostRuntimeCheck(func(context.Context) (HostRuntime, error) {
+		return HostRuntime{}, errors.New("connection refused")
+	}).Run(context.Background())
+	assert.Equal(t, StatusSkip, res.Status)
+}
+
+// listen opens a loopback listener and returns its port.
+func listen(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+// freePort returns a loopback port nothing listens on.
+func freePort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+	return port
+}
+
+func TestMonitoringPortsCheck(t *testing.T) {
+	busy := Port{Name: "prometheus", Port: listen(t)}
+	free := Port{Name: "opensearch-node", Port: freePort(t)}
+	up := func(context.Context) bool { return true }
+	down := func(context.Context) bool { return false }
+
+	tests := []struct {
+		name    string
+		ports   []Port
+		running func(context.Context) bool
+		want    Status
+		details int
+	}{
+		{"running and listening", []Port{busy}, up, StatusPass, 0},
+		{"down and free", []Port{free}, down, StatusPass, 0},
+		{"running but a port is closed", []Port{busy, free}, up, StatusWarn, 1},
+		{"down but a port is taken", []Port{busy, free}, down, StatusWarn, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := MonitoringPortsCheck(tt.ports, tt.running).Run(context.Background())
+			assert.Equal(t, tt.want, res.Status, res.Message)
+			assert.Len(t, res.Details, tt.details)
+		})
+	}
+}
+
+func TestMonitoringPorts_InfraPortOptional(t *testing.T) {
+	mc := config.MonitoringConfig{OtelGRPCPort: 4317, OtelCollectorPort: 4318, PrometheusPort: 9090, OpenSearchPort: 9200, OpenSearchDashboardsPort: 5601}
+	assert.Len(t, MonitoringPorts(mc), 5)
+
+	mc.OtelInfraPort = 4319
+	assert.Len(t, MonitoringPorts(mc), 6)
+}
+
+func TestHostProxyCheck(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, StatusSkip, HostProxyCheck(hostproxytest.NewRunningMockManager(""), false).Run(ctx).Status)
+	assert.Equal(t, StatusSkip, HostProxyCheck(hostproxytest.NewMockManager(), true).Run(ctx).Status)
+	assert.Equal(t, StatusPass, HostProxyCheck(hostproxytest.NewRunningMockManager(""), true).Run(ctx).Status)
+
+	unhealthy := hostproxytest.NewRunningMockManager("")
+	unhealthy.ReadyErr = errors.New("docker: daemon unreachable")
+	res := HostProxyCheck(unhealthy, true).Run(ctx)
+	assert.Equal(t, StatusFail, res.Status)
+	assert.Contains(t, res.Message, "daemon unreachable")
+}
+
+func TestGPGCheck(t *testing.T) {
+	ctx := context.Background()
+	probeErr := func(context.Context) error { return errors.New("gpgconf failed") }
+
+	assert.Equal(t, StatusSkip, GPGCheck(false, probeErr).Run(ctx).Status)
+	assert.Equal(t, StatusFail, GPGCheck(true, probeErr).Run(ctx).Status)
+	assert.Equal(t, StatusPass, GPGCheck(true, func(context.Context) error { return nil }).Run(ctx).Status)
+}
+
+func TestSSHCheck(t *testing.T) {
+	ctx := context.Background()
+	sock := filepath.Join(t.TempDir(), "agent.sock")
+
+	assert.Equal(t, StatusSkip, SSHCheck(false, "").Run(ctx).Status)
+	assert.Equal(t, StatusWarn, SSHCheck(true, "").Run(ctx).Status)
+	assert.Equal(t, StatusWarn, SSHCheck(true, sock).Run(ctx).Status, "nothing listening")
+
+	ln, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+	defer ln.Close()
+	assert.Equal(t, StatusPass, SSHCheck(true, sock).Run(ctx).Status)
+}
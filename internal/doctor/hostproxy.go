@@ -0,0 +1,34 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/hostproxy"
+)
+
+// HostProxyCheck verifies a running host proxy passes its /readyz
+// dependency checks. A stopped proxy is not a problem — container commands
+// start it on demand — so that case skips. enabled is the project's
+// security.enable_host_proxy.
+func HostProxyCheck(svc hostproxy.Service, enabled bool) Check {
+	return Check{
+		Name: CheckHostProxy,
+		Run: func(ctx context.Context) Result {
+			if !enabled {
+				return Skip("host proxy disabled (security.enable_host_proxy: false)")
+			}
+			if svc == nil || !svc.IsRunning() {
+				return Skip("host proxy not running; container commands start it on demand")
+			}
+			ctx, cancel := context.WithTimeout(ctx, consts.DoctorHostProxyTimeout)
+			defer cancel()
+			if err := svc.WaitReady(ctx); err != nil {
+				return Fail(fmt.Sprintf("host proxy unhealthy: %v", err),
+					"restart it with 'clawker host-proxy stop'; the next container command starts a fresh one")
+			}
+			return Pass("host proxy running and ready")
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// Port is one host port the monitoring stack publishes.
+type Port struct {
+	Name string
+	Port int
+}
+
+// MonitoringPorts returns the host ports the monitoring stack publishes on
+// loopback, per the monitoring settings. The otel infra receiver is only
+// published when its port is set.
+func MonitoringPorts(mc config.MonitoringConfig) []Port {
+	ports := []Port{
+		{Name: "otel-collector grpc", Port: mc.OtelGRPCPort},
+		{Name: "otel-collector http", Port: mc.OtelCollectorPort},
+	}
+	if mc.OtelInfraPort != 0 {
+		ports = append(ports, Port{Name: "otel-collector infra", Port: int(mc.OtelInfraPort)})
+	}
+	return append(ports,
+		Port{Name: consts.MonitoringServicePrometheus, Port: mc.PrometheusPort},
+		Port{Name: consts.MonitoringServiceOpenSearchNode, Port: mc.OpenSearchPort},
+		Port{Name: consts.MonitoringServiceOpenSearchDashboards, Port: mc.OpenSearchDashboardsPort},
+	)
+}
+
+// MonitoringPortsCheck probes each monitoring port on loopback. While the
+// stack runs every port should be listening; while it is down every port
+// should be free, since `clawker monitor up` cannot publish a port another
+// process holds. stackRunning reports whether the stack is up.
+func MonitoringPortsCheck(ports []Port, stackRunning func(ctx context.Context) bool) Check {
+	return Check{
+		Name: CheckMonitoringPorts,
+		Run: func(ctx context.Context) Result {
+			running := stackRunning(ctx)
+			var problems []string
+			for _, p := range ports {
+				listening := portListening(ctx, p.Port)
+				switch {
+				case running && !listening:
+					problems = append(problems, fmt.Sprintf("%s port %d is not listening", p.Name, p.Port))
+				case !running && listening:
+					problems = append(problems, fmt.Sprintf("%s port %d is in use by another process", p.Name, p.Port))
+				}
+			}
+			switch {
+			case len(problems) == 0 && running:
+				return Pass(fmt.Sprintf("monitoring stack running; all %d ports listening", len(ports)))
+			case len(problems) == 0:
+				return Pass(fmt.Sprintf("monitoring stack down; all %d ports free", len(ports)))
+			case running:
+				return Warn("monitoring stack running but not all of its ports are listening",
+					"check the stack with 'clawker monitor status'", problems...)
+			default:
+				return Warn("monitoring ports held by other processes; 'clawker monitor up' will fail",
+					"stop the conflicting process, or change the port under monitoring with 'clawker settings edit'", problems...)
+			}
+		},
+	}
+}
+
+// portListening reports whether something accepts TCP connections on
+// loopback port.
+func portListening(ctx context.Context, port int) bool {
+	ctx, cancel := context.WithTimeout(ctx, consts.DoctorPortProbeTimeout)
+	defer cancel()
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(consts.Localhost, strconv.Itoa(port)))
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
@@ -1,8 +1,9 @@
 // Vendored from github.com/compose-spec/compose-go/v2@v2.13.0 (dotenv
 // package, MIT — see LICENSE). Modified for clawker: the parser carries a
 // MissingFn so unresolvable interpolation references surface to the caller
-// instead of being logged (see package doc in godotenv.go), and functions are
-// restructured to satisfy this repo's linters.
+// instead of being logged (see package doc in godotenv.go), a `=value` line
+// with no key is rejected rather than stored under the empty key, and functions
+// are restructured to satisfy this repo's linters.
 
 package dotenv
 
@@ -70,6 +71,9 @@ func (p *parser) parseStatement(cutset string, out map[string]string, lookupFn L
 	if err != nil {
 		return "", err
 	}
+	if key == "" && !inherited {
+		return "", fmt.Errorf("line %d: missing variable name", p.line)
+	}
 	if strings.Contains(key, " ") {
 		return "", fmt.Errorf("line %d: key cannot contain a space", p.line)
 	}
@@ -195,6 +199,8 @@ func (p *parser) extractVarValue(src string, envMap map[string]string, lookupFn
 		return retVal, rest, err
 	}
 
+	// quoted values may span lines; errors point at the line the value opens on
+	startLine := p.line
 	value, rest, ok := p.extractQuotedValue(src, quote)
 	if !ok {
 		// return formatted error if quoted string is not terminated
@@ -203,7 +209,7 @@ func (p *parser) extractVarValue(src string, envMap map[string]string, lookupFn
 			valEndIndex = len(src)
 		}
 
-		return "", "", fmt.Errorf("line %d: unterminated quoted value %s", p.line, src[:valEndIndex])
+		return "", "", fmt.Errorf("line %d: unterminated quoted value %s", startLine, src[:valEndIndex])
 	}
 
 	if quote == prefixDoubleQuote {
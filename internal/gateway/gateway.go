@@ -0,0 +1,112 @@
+// Package gateway serves a read-only local API over clawker's agents for
+// external tools — an editor extension, a web dashboard — so they can
+// integrate without shelling out to the CLI.
+//
+// The API is JSON over HTTP plus WebSocket streams. It listens on a Unix
+// socket (protected by its 0600 mode) and optionally on a localhost TCP
+// port that requires a bearer token. Nothing it serves mutates state: every
+// route is a GET.
+//
+//	GET /v1/agents                 agent list (JSON)
+//	GET /v1/agents/{name}          one agent by container name (JSON)
+//	GET /v1/agents/{name}/logs     container logs; WebSocket or chunked text
+//	GET /v1/watch                  WebSocket; the agent list on every change
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrAgentNotFound is returned by a Source when no agent container has the
+// requested name.
+var ErrAgentNotFound = errors.New("agent not found")
+
+// Phase values describe how far an agent has come through start-up, as the
+// container's healthcheck reports it: the clawker image's healthcheck passes
+// once clawkerd has run the init steps and spawned the agent command.
+const (
+	PhaseStopped      = "stopped"
+	PhaseInitializing = "initializing"
+	PhaseReady        = "ready"
+	PhaseFailed       = "failed"
+	// PhaseRunning is a running container without a healthcheck, whose
+	// init progress cannot be observed.
+	PhaseRunning = "running"
+)
+
+// Agent is one agent container as the gateway reports it. Field tags are the
+// wire contract for API consumers.
+type Agent struct {
+	// Name is the container name (clawker.<project>.<agent>) and the key
+	// for the per-agent routes.
+	Name        string `json:"name"`
+	Agent       string `json:"agent"`
+	Project     string `json:"project,omitempty"`
+	ContainerID string `json:"container_id"`
+	Image       string `json:"image"`
+	// State is the Docker container state (running, exited, ...).
+	State string `json:"state"`
+	// Health is the healthcheck status; empty without a healthcheck.
+	Health string `json:"health,omitempty"`
+	// Phase is the init progress derived from State and Health.
+	Phase string `json:"phase"`
+	// Registered reports whether the agent is in the control plane's
+	// registry. Always false when the control plane is unreachable.
+	Registered bool `json:"registered"`
+	// LastSeen is the control plane's last contact with the agent, in Unix
+	// seconds; zero when unregistered.
+	LastSeen int64 `json:"last_seen,omitempty"`
+	// CPUPercent and MemoryRSSBytes are the agent's latest self-reported
+	// resource usage; zero until its first status report.
+	CPUPercent     float64 `json:"cpu_percent,omitempty"`
+	MemoryRSSBytes uint64  `json:"memory_rss_bytes,omitempty"`
+}
+
+// AgentList is the /v1/agents and /v1/watch payload.
+type AgentList struct {
+	Agents []Agent `json:"agents"`
+	// ControlPlane reports whether the registry fields (Registered,
+	// LastSeen, resource usage) could be read.
+	ControlPlane bool `json:"control_plane"`
+}
+
+// LogOptions selects the log output of a /logs request.
+type LogOptions struct {
+	// Follow keeps the stream open for new output.
+	Follow bool
+	// Tail is the number of lines from the end to start with; "all" or
+	// empty for the whole log.
+	Tail string
+	// Timestamps prefixes each line with its RFC3339Nano timestamp.
+	Timestamps bool
+}
+
+// Source is what the gateway reads. NewDockerSource is the implementation
+// backed by the Docker daemon and the control plane.
+type Source interface {
+	// Agents returns every agent container, running or not.
+	Agents(ctx context.Context) (AgentList, error)
+	// Logs returns the named agent's combined stdout and stderr.
+	// ErrAgentNotFound when there is no such agent.
+	Logs(ctx context.Context, name string, opts LogOptions) (io.ReadCloser, error)
+}
+
+// phase derives an agent's init progress from its container state and
+// health.
+func phase(state, health string) string {
+	if state != "running" {
+		return PhaseStopped
+	}
+	switch health {
+	case "starting":
+		return PhaseInitializing
+	case "healthy":
+		return PhaseReady
+	case "unhealthy":
+		return PhaseFailed
+	default:
+		return PhaseRunning
+	}
+}
@@ -0,0 +1,390 @@
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// defaultWatchInterval is how often /v1/watch re-reads the agent list.
+const defaultWatchInterval = 2 * time.Second
+
+// shutdownTimeout bounds the graceful shutdown of a listener's in-flight
+// plain HTTP requests once Serve's context ends.
+const shutdownTimeout = 5 * time.Second
+
+// Options configures a Server.
+type Options struct {
+	// Source is what the API reads. Required.
+	Source Source
+	// Token is the bearer token a token-protected listener requires. Required
+	// when any listener is served with requireToken.
+	Token string
+	// WatchInterval is how often /v1/watch polls the Source. Defaults to 2s.
+	WatchInterval time.Duration
+	Log           *logger.Logger
+}
+
+// Server serves the gateway API. One Server can serve several listeners.
+type Server struct {
+	source   Source
+	token    string
+	interval time.Duration
+	log      *logger.Logger
+}
+
+// New returns a Server for opts.
+func New(opts Options) (*Server, error) {
+	if opts.Source == nil {
+		return nil, errors.New("gateway: nil Source")
+	}
+	s := &Server{
+		source:   opts.Source,
+		token:    opts.Token,
+		interval: opts.WatchInterval,
+		log:      opts.Log,
+	}
+	if s.interval <= 0 {
+		s.interval = defaultWatchInterval
+	}
+	if s.log == nil {
+		s.log = logger.Nop()
+	}
+	return s, nil
+}
+
+// Handler returns the API handler. With requireToken, every request must
+// carry the server's token as "Authorization: Bearer <token>" or, for
+// browser WebSocket clients that cannot set headers, an access_token query
+// parameter.
+func (s *Server) Handler(requireToken bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/agents", s.handleAgents)
+	mux.HandleFunc("GET /v1/agents/{name}", s.handleAgent)
+	mux.HandleFunc("GET /v1/agents/{name}/logs", s.handleLogs)
+	mux.HandleFunc("GET /v1/watch", s.handleWatch)
+	if !requireToken {
+		return mux
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(r) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid token"))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return false
+	}
+	got := r.URL.Query().Get("access_token")
+	if h := r.Header.Get("Authorization"); h != "" {
+		got, _ = strings.CutPrefix(h, "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) == 1
+}
+
+// Serve serves the API on ln until ctx ends, then shuts the listener down.
+// WebSocket streams end with ctx. It returns nil after a clean shutdown.
+func (s *Server) Serve(ctx context.Context, ln net.Listener, requireToken bool) error {
+	if requireToken && s.token == "" {
+		ln.Close()
+		return errors.New("gateway: token-protected listener without a token")
+	}
+	srv := &http.Server{
+		Handler:           s.Handler(requireToken),
+		ReadHeaderTimeout: 10 * time.Second,
+		// Hijacked WebSocket connections are not tracked by Shutdown, so
+		// their handlers watch the request context, derived from ctx.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("gateway: serving %s: %w", ln.Addr(), err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("gateway: shutting down %s: %w", ln.Addr(), err)
+	}
+	return nil
+}
+
+// ListenUnix creates the API socket at path with mode 0600. A stale socket
+// left by a crashed gateway is replaced; a live one means another gateway
+// is serving and ListenUnix fails.
+//
+// The socket is bound inside a private 0700 directory and only renamed to
+// path once it is 0600, so it is never reachable with the umask's mode.
+func ListenUnix(path string) (net.Listener, error) {
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("gateway socket %s is already in use", path)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("removing stale gateway socket: %w", err)
+	}
+	// Short name: Unix socket paths are length-limited.
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".gw")
+	if err != nil {
+		return nil, fmt.Errorf("creating gateway socket directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	bound := filepath.Join(dir, "s")
+
+	ln, err := net.Listen("unix", bound)
+	if err != nil {
+		return nil, fmt.Errorf("listening on gateway socket: %w", err)
+	}
+	ul := ln.(*net.UnixListener)
+	// The bound name is gone after the rename; unixListener removes path.
+	ul.SetUnlinkOnClose(false)
+	if err := os.Chmod(bound, 0o600); err != nil {
+		ul.Close()
+		return nil, fmt.Errorf("restricting gateway socket: %w", err)
+	}
+	if err := os.Rename(bound, path); err != nil {
+		ul.Close()
+		return nil, fmt.Errorf("placing gateway socket: %w", err)
+	}
+	return &unixListener{UnixListener: ul, path: path}, nil
+}
+
+// unixListener removes the socket at path when closed, which the
+// UnixListener itself cannot do once the socket has been renamed.
+type unixListener struct {
+	*net.UnixListener
+	path string
+}
+
+func (l *unixListener) Close() error {
+	err := l.UnixListener.Close()
+	if rmErr := os.Remove(l.path); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+func (s *Server) handleAgents(w http.ResponseWriter, r *http.Request) {
+	list, err := s.source.Agents(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleAgent(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	list, err := s.source.Agents(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	for _, a := range list.Agents {
+		if a.Name == name {
+			writeJSON(w, http.StatusOK, a)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Errorf("%w: %s", ErrAgentNotFound, name))
+}
+
+// handleLogs streams an agent's logs: one text message per line over a
+// WebSocket, or a chunked text/plain body for plain HTTP clients.
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+	opts, err := logOptions(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	name := r.PathValue("name")
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	reader, err := s.source.Logs(ctx, name, opts)
+	switch {
+	case errors.Is(err, ErrAgentNotFound):
+		writeError(w, http.StatusNotFound, fmt.Errorf("%w: %s", ErrAgentNotFound, name))
+		return
+	case err != nil:
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer reader.Close()
+
+	if !isWebSocket(r) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.Copy(flushWriter{w}, reader); err != nil && ctx.Err() == nil {
+			s.log.Debug().Err(err).Str("agent", name).Msg("gateway: log stream ended")
+		}
+		return
+	}
+
+	wsHandler(func(ws *websocket.Conn) {
+		go closeOnDisconnect(ws, cancel)
+		br := bufio.NewReader(reader)
+		for {
+			line, err := br.ReadString('\n')
+			if line != "" {
+				if sendErr := websocket.Message.Send(ws, strings.ToValidUTF8(line, "�")); sendErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				if ctx.Err() == nil && !errors.Is(err, io.EOF) {
+					s.log.Debug().Err(err).Str("agent", name).Msg("gateway: log stream ended")
+				}
+				return
+			}
+		}
+	}).ServeHTTP(w, r)
+}
+
+// handleWatch pushes the agent list over a WebSocket: once on connect, then
+// whenever it changes. A Source failure is sent as {"error": "..."} and the
+// watch continues.
+func (s *Server) handleWatch(w http.ResponseWriter, r *http.Request) {
+	if !isWebSocket(r) {
+		writeError(w, http.StatusBadRequest, errors.New("/v1/watch requires a WebSocket upgrade"))
+		return
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	wsHandler(func(ws *websocket.Conn) {
+		go closeOnDisconnect(ws, cancel)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		var last []byte
+		for {
+			var payload any
+			list, err := s.source.Agents(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				payload = errorBody{Error: err.Error()}
+			} else {
+				payload = list
+			}
+			msg, err := json.Marshal(payload)
+			if err != nil {
+				s.log.Error().Err(err).Msg("gateway: encoding watch payload")
+				return
+			}
+			if !bytes.Equal(msg, last) {
+				if err := websocket.Message.Send(ws, string(msg)); err != nil {
+					return
+				}
+				last = msg
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}).ServeHTTP(w, r)
+}
+
+// wsHandler wraps h as a WebSocket handler. Origin is not checked: the
+// socket is owner-only and the TCP listener requires the token, which a
+// cross-site page cannot read.
+func wsHandler(h func(*websocket.Conn)) http.Handler {
+	return websocket.Server{
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler:   h,
+	}
+}
+
+// closeOnDisconnect reads (and discards) client frames until the connection
+// fails, then calls cancel. The streams are read-only; this is how a handler
+// learns its client went away.
+func closeOnDisconnect(ws *websocket.Conn, cancel context.CancelFunc) {
+	defer cancel()
+	var discard string
+	for {
+		if err := websocket.Message.Receive(ws, &discard); err != nil {
+			return
+		}
+	}
+}
+
+func isWebSocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// logOptions parses the follow, tail and timestamps query parameters.
+func logOptions(r *http.Request) (LogOptions, error) {
+	q := r.URL.Query()
+	opts := LogOptions{Tail: q.Get("tail")}
+	if opts.Tail != "" && opts.Tail != "all" {
+		if n, err := strconv.Atoi(opts.Tail); err != nil || n < 0 {
+			return LogOptions{}, fmt.Errorf("invalid tail %q: must be a non-negative number or \"all\"", opts.Tail)
+		}
+	}
+	for name, dst := range map[string]*bool{"follow": &opts.Follow, "timestamps": &opts.Timestamps} {
+		v := q.Get(name)
+		if v == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return LogOptions{}, fmt.Errorf("invalid %s %q: must be true or false", name, v)
+		}
+		*dst = b
+	}
+	return opts, nil
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorBody{Error: err.Error()})
+}
+
+// flushWriter flushes after every write so a followed log reaches plain
+// HTTP clients as it is produced.
+type flushWriter struct{ w http.ResponseWriter }
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err == nil {
+		err = http.NewResponseController(f.w).Flush()
+	}
+	return n, err
+}
@@ -0,0 +1,311 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// fakeSource serves a fixed agent list and log text. Agents may be swapped
+// with set to drive /v1/watch.
+type fakeSource struct {
+	mu   sync.Mutex
+	list AgentList
+	logs map[string]string
+	// gotLogOpts records the options of the last Logs call.
+	gotLogOpts LogOptions
+}
+
+func (f *fakeSource) set(list AgentList) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.list = list
+}
+
+func (f *fakeSource) Agents(context.Context) (AgentList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.list, nil
+}
+
+func (f *fakeSource) Logs(_ context.Context, name string, opts LogOptions) (io.ReadCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.gotLogOpts = opts
+	text, ok := f.logs[name]
+	if !ok {
+		return nil, ErrAgentNotFound
+	}
+	return io.NopCloser(strings.NewReader(text)), nil
+}
+
+func devAgent() Agent {
+	return Agent{
+		Name:        "clawker.app.dev",
+		Agent:       "dev",
+		Project:     "app",
+		ContainerID: "abc123",
+		Image:       "clawker-app:latest",
+		State:       "running",
+		Health:      "healthy",
+		Phase:       PhaseReady,
+		Registered:  true,
+	}
+}
+
+func newTestServer(t *testing.T, src Source, requireToken bool) *httptest.Server {
+	t.Helper()
+	s, err := New(Options{Source: src, Token: "s3cret", WatchInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	ts := httptest.NewServer(s.Handler(requireToken))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func getJSON(t *testing.T, url string, v any) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	return resp.StatusCode
+}
+
+func dialWS(t *testing.T, ts *httptest.Server, path string) *websocket.Conn {
+	t.Helper()
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+path, "", ts.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { ws.Close() })
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+	return ws
+}
+
+func TestNew_RequiresSource(t *testing.T) {
+	_, err := New(Options{})
+	require.Error(t, err)
+}
+
+func TestHandler_Agents(t *testing.T) {
+	src := &fakeSource{list: AgentList{Agents: []Agent{devAgent()}, ControlPlane: true}}
+	ts := newTestServer(t, src, false)
+
+	var list AgentList
+	require.Equal(t, http.StatusOK, getJSON(t, ts.URL+"/v1/agents", &list))
+	require.Equal(t, src.list, list)
+
+	var one Agent
+	require.Equal(t, http.StatusOK, getJSON(t, ts.URL+"/v1/agents/clawker.app.dev", &one))
+	require.Equal(t, devAgent(), one)
+
+	var body errorBody
+	require.Equal(t, http.StatusNotFound, getJSON(t, ts.URL+"/v1/agents/clawker.app.missing", &body))
+	require.Contains(t, body.Error, "agent not found")
+}
+
+func TestHandler_ReadOnly(t *testing.T) {
+	ts := newTestServer(t, &fakeSource{}, false)
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+		req, err := http.NewRequest(method, ts.URL+"/v1/agents", nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode, method)
+	}
+}
+
+func TestHandler_Token(t *testing.T) {
+	ts := newTestServer(t, &fakeSource{}, true)
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		want   int
+	}{
+		{name: "missing", path: "/v1/agents", want: http.StatusUnauthorized},
+		{name: "wrong", path: "/v1/agents", header: "Bearer nope", want: http.StatusUnauthorized},
+		{name: "bearer header", path: "/v1/agents", header: "Bearer s3cret", want: http.StatusOK},
+		{name: "query parameter", path: "/v1/agents?access_token=s3cret", want: http.StatusOK},
+		{name: "header wins over query", path: "/v1/agents?access_token=s3cret", header: "Bearer nope", want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, ts.URL+tt.path, nil)
+			require.NoError(t, err)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandler_Logs(t *testing.T) {
+	src := &fakeSource{logs: map[string]string{"clawker.app.dev": "first\nsecond\npartial"}}
+	ts := newTestServer(t, src, false)
+
+	t.Run("plain http", func(t *testing.T) {
+		resp, err := http.Get(ts.URL + "/v1/agents/clawker.app.dev/logs?tail=10&timestamps=true")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "first\nsecond\npartial", string(body))
+		require.Equal(t, LogOptions{Tail: "10", Timestamps: true}, src.gotLogOpts)
+	})
+
+	t.Run("websocket sends one message per line", func(t *testing.T) {
+		ws := dialWS(t, ts, "/v1/agents/clawker.app.dev/logs?follow=true")
+		var got []string
+		for {
+			var msg string
+			if err := websocket.Message.Receive(ws, &msg); err != nil {
+				break
+			}
+			got = append(got, msg)
+		}
+		require.Equal(t, []string{"first\n", "second\n", "partial"}, got)
+		require.True(t, src.gotLogOpts.Follow)
+	})
+
+	t.Run("unknown agent", func(t *testing.T) {
+		var body errorBody
+		require.Equal(t, http.StatusNotFound, getJSON(t, ts.URL+"/v1/agents/clawker.app.missing/logs", &body))
+	})
+
+	t.Run("invalid tail", func(t *testing.T) {
+		var body errorBody
+		require.Equal(t, http.StatusBadRequest, getJSON(t, ts.URL+"/v1/agents/clawker.app.dev/logs?tail=-1", &body))
+		require.Contains(t, body.Error, "invalid tail")
+	})
+}
+
+func TestHandler_Watch(t *testing.T) {
+	src := &fakeSource{list: AgentList{Agents: []Agent{}}}
+	ts := newTestServer(t, src, false)
+
+	ws := dialWS(t, ts, "/v1/watch")
+	var first AgentList
+	require.NoError(t, websocket.JSON.Receive(ws, &first))
+	require.Empty(t, first.Agents)
+
+	src.set(AgentList{Agents: []Agent{devAgent()}, ControlPlane: true})
+	var second AgentList
+	require.NoError(t, websocket.JSON.Receive(ws, &second))
+	require.Equal(t, []Agent{devAgent()}, second.Agents)
+
+	t.Run("requires websocket", func(t *testing.T) {
+		var body errorBody
+		require.Equal(t, http.StatusBadRequest, getJSON(t, ts.URL+"/v1/watch", &body))
+	})
+}
+
+func TestServe_UnixSocket(t *testing.T) {
+	// Unix socket paths are length-limited; t.TempDir can be too long.
+	dir, err := os.MkdirTemp("", "gw")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "gateway.sock")
+
+	ln, err := ListenUnix(path)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "the private bind directory must be removed")
+	require.Equal(t, "gateway.sock", entries[0].Name())
+
+	_, err = ListenUnix(path)
+	require.ErrorContains(t, err, "already in use")
+
+	s, err := New(Options{Source: &fakeSource{list: AgentList{Agents: []Agent{devAgent()}}}})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Serve(ctx, ln, false) }()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	resp, err := client.Get("http://gateway/v1/agents")
+	require.NoError(t, err)
+	var list AgentList
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	resp.Body.Close()
+	require.Len(t, list.Agents, 1)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after cancel")
+	}
+	_, err = os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist, "closing the listener removes the socket")
+}
+
+func TestServe_TokenListenerNeedsToken(t *testing.T) {
+	s, err := New(Options{Source: &fakeSource{}})
+	require.NoError(t, err)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.ErrorContains(t, s.Serve(context.Background(), ln, true), "without a token")
+}
+
+func TestLoadOrCreateToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sockets", "gateway.token")
+
+	token, err := LoadOrCreateToken(path)
+	require.NoError(t, err)
+	require.Len(t, token, 2*tokenBytes)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	again, err := LoadOrCreateToken(path)
+	require.NoError(t, err)
+	require.Equal(t, token, again, "the token is stable across restarts")
+
+	require.NoError(t, os.WriteFile(path, []byte("\n"), 0o600))
+	_, err = LoadOrCreateToken(path)
+	require.ErrorContains(t, err, "is empty")
+}
+
+func TestPhase(t *testing.T) {
+	tests := []struct {
+		state, health, want string
+	}{
+		{"exited", "", PhaseStopped},
+		{"created", "", PhaseStopped},
+		{"running", "starting", PhaseInitializing},
+		{"running", "healthy", PhaseReady},
+		{"running", "unhealthy", PhaseFailed},
+		{"running", "", PhaseRunning},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, phase(tt.state, tt.health), "%s/%s", tt.state, tt.health)
+	}
+}
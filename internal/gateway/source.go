@@ -0,0 +1,132 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/moby/moby/api/pkg/stdcopy"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// dockerSource reads agent containers from the Docker daemon and joins them
+// with the control plane's agent registry.
+type dockerSource struct {
+	client *docker.Client
+	admin  func(context.Context) (adminv1.AdminServiceClient, error)
+	log    *logger.Logger
+}
+
+// NewDockerSource returns a Source over client's agent containers. admin
+// dials the control plane for registry data; it may be nil, and a dial or
+// ListAgents failure only leaves the registry fields unset — container
+// state never depends on the control plane being up.
+func NewDockerSource(client *docker.Client, admin func(context.Context) (adminv1.AdminServiceClient, error), log *logger.Logger) Source {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &dockerSource{client: client, admin: admin, log: log}
+}
+
+func (s *dockerSource) Agents(ctx context.Context) (AgentList, error) {
+	containers, err := s.client.ListContainersQuery(ctx, docker.Query().Purpose(consts.PurposeAgent), true)
+	if err != nil {
+		return AgentList{}, fmt.Errorf("listing agent containers: %w", err)
+	}
+	registry, cpUp := s.registry(ctx)
+
+	list := AgentList{Agents: make([]Agent, 0, len(containers)), ControlPlane: cpUp}
+	for _, c := range containers {
+		a := Agent{
+			Name:        c.Name,
+			Agent:       c.Agent,
+			Project:     c.Project,
+			ContainerID: c.ID,
+			Image:       c.Image,
+			State:       c.Status,
+			Health:      c.Health,
+			Phase:       phase(c.Status, c.Health),
+		}
+		if r, ok := registry[c.ID]; ok {
+			a.Registered = true
+			a.LastSeen = r.GetLastSeenUnix()
+			if st := r.GetStatus(); st != nil {
+				a.CPUPercent = st.GetCpuPercent()
+				a.MemoryRSSBytes = st.GetMemoryRssBytes()
+			}
+		}
+		list.Agents = append(list.Agents, a)
+	}
+	return list, nil
+}
+
+// registry returns the control plane's registered agents by container ID,
+// and whether they could be read.
+func (s *dockerSource) registry(ctx context.Context) (map[string]*adminv1.Agent, bool) {
+	if s.admin == nil {
+		return nil, false
+	}
+	client, err := s.admin(ctx)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("gateway: control plane unavailable")
+		return nil, false
+	}
+	resp, err := client.ListAgents(ctx, &adminv1.ListAgentsRequest{AllProjects: true})
+	if err != nil {
+		s.log.Debug().Err(err).Msg("gateway: ListAgents failed")
+		return nil, false
+	}
+	byID := make(map[string]*adminv1.Agent, len(resp.GetAgents()))
+	for _, a := range resp.GetAgents() {
+		byID[a.GetContainerId()] = a
+	}
+	return byID, true
+}
+
+func (s *dockerSource) Logs(ctx context.Context, name string, opts LogOptions) (io.ReadCloser, error) {
+	containers, err := s.client.ListContainersQuery(ctx, docker.Query().Purpose(consts.PurposeAgent).Name(name), true)
+	if err != nil {
+		return nil, fmt.Errorf("finding agent container: %w", err)
+	}
+	var id string
+	for _, c := range containers {
+		// The daemon's name filter matches substrings.
+		if c.Name == name {
+			id = c.ID
+		}
+	}
+	if id == "" {
+		return nil, ErrAgentNotFound
+	}
+
+	info, err := s.client.ContainerInspect(ctx, id, docker.ContainerInspectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("inspecting container: %w", err)
+	}
+	reader, err := s.client.ContainerLogs(ctx, id, docker.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     opts.Follow,
+		Tail:       opts.Tail,
+		Timestamps: opts.Timestamps,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading logs: %w", err)
+	}
+	if info.Container.Config != nil && info.Container.Config.Tty {
+		return reader, nil
+	}
+
+	// Without a TTY the stream is multiplexed; merge it into one.
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(pw, pw, reader)
+		reader.Close()
+		pw.CloseWithError(err)
+	}()
+	return pr, nil
+}
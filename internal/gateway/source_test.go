@@ -0,0 +1,78 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	adminv1 "github.com/schmitthub/clawker/api/admin/v1"
+	adminv1mocks "github.com/schmitthub/clawker/api/admin/v1/mocks"
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+)
+
+func TestDockerSource_Agents(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	dev := mocks.RunningContainerFixture("app", "dev")
+	dev.Health = &container.HealthSummary{Status: container.Starting}
+	idle := mocks.ContainerFixture("app", "idle", "node:20-slim")
+	fake.SetupContainerList(dev, idle)
+
+	admin := &adminv1mocks.AdminServiceClientMock{
+		ListAgentsFunc: func(_ context.Context, req *adminv1.ListAgentsRequest, _ ...grpc.CallOption) (*adminv1.ListAgentsResult, error) {
+			require.True(t, req.GetAllProjects())
+			return &adminv1.ListAgentsResult{Agents: []*adminv1.Agent{{
+				AgentName:    "dev",
+				Project:      "app",
+				ContainerId:  dev.ID,
+				LastSeenUnix: 1700000000,
+				Status:       &adminv1.AgentStatus{CpuPercent: 12.5, MemoryRssBytes: 4096},
+			}}}, nil
+		},
+	}
+	src := NewDockerSource(fake.Client, func(context.Context) (adminv1.AdminServiceClient, error) { return admin, nil }, nil)
+
+	list, err := src.Agents(context.Background())
+	require.NoError(t, err)
+	require.True(t, list.ControlPlane)
+	require.Equal(t, []Agent{
+		{
+			Name: "clawker.app.dev", Agent: "dev", Project: "app", ContainerID: dev.ID, Image: "node:20-slim",
+			State: "running", Health: "starting", Phase: PhaseInitializing,
+			Registered: true, LastSeen: 1700000000, CPUPercent: 12.5, MemoryRSSBytes: 4096,
+		},
+		{
+			Name: "clawker.app.idle", Agent: "idle", Project: "app", ContainerID: idle.ID, Image: "node:20-slim",
+			State: "exited", Phase: PhaseStopped,
+		},
+	}, list.Agents)
+}
+
+func TestDockerSource_AgentsWithoutControlPlane(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList(mocks.RunningContainerFixture("app", "dev"))
+	src := NewDockerSource(fake.Client, func(context.Context) (adminv1.AdminServiceClient, error) {
+		return nil, errors.New("control plane is not running")
+	}, nil)
+
+	list, err := src.Agents(context.Background())
+	require.NoError(t, err, "container state does not depend on the control plane")
+	require.False(t, list.ControlPlane)
+	require.Len(t, list.Agents, 1)
+	require.False(t, list.Agents[0].Registered)
+	require.Equal(t, PhaseRunning, list.Agents[0].Phase)
+}
+
+func TestDockerSource_LogsUnknownAgent(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupContainerList(mocks.RunningContainerFixture("app", "dev"))
+	src := NewDockerSource(fake.Client, nil, nil)
+
+	// The daemon's name filter matches substrings; only an exact name counts.
+	_, err := src.Logs(context.Background(), "clawker.app.de", LogOptions{})
+	require.ErrorIs(t, err, ErrAgentNotFound)
+}
@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// tokenBytes is the entropy of a generated token.
+const tokenBytes = 32
+
+// LoadOrCreateToken returns the token stored at path, generating and
+// writing a new one (mode 0600) when the file does not exist. The token is
+// stable across gateway restarts so clients can keep it configured; delete
+// the file to rotate it.
+func LoadOrCreateToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("gateway token file %s is empty; delete it to generate a new token", path)
+		}
+		return token, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("reading gateway token: %w", err)
+	}
+
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating gateway token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("creating gateway token directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("writing gateway token: %w", err)
+	}
+	return token, nil
+}
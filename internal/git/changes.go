@@ -0,0 +1,257 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	gogitconfig "github.com/go-git/go-git/v6/config"
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/filemode"
+	"github.com/go-git/go-git/v6/plumbing/object"
+	gogitstorer "github.com/go-git/go-git/v6/plumbing/storer"
+	"github.com/go-git/go-git/v6/storage/memory"
+)
+
+// FileChange is the new state of one path for Patch and CommitChanges.
+type FileChange struct {
+	// Path is slash-separated and relative to the tree root.
+	Path string
+	// Mode is a regular file mode (permission bits decide executability) or
+	// os.ModeSymlink.
+	Mode os.FileMode
+	// Content is the file content, or the symlink target.
+	Content []byte
+	// Delete removes Path, and everything under it when it is a directory.
+	Delete bool
+}
+
+// Patch renders the unified git diff that turns the from files into the to
+// files. A path in only one of them is an addition or a deletion; Delete
+// entries are treated as absent. The objects live in memory; nothing touches
+// a repository.
+func Patch(from, to []FileChange) (string, error) {
+	s := memory.NewStorage()
+	fromTree, err := buildTree(s, nil, from)
+	if err != nil {
+		return "", err
+	}
+	toTree, err := buildTree(s, nil, to)
+	if err != nil {
+		return "", err
+	}
+	patch, err := fromTree.Patch(toTree)
+	if err != nil {
+		return "", fmt.Errorf("diffing trees: %w", err)
+	}
+	return patch.String(), nil
+}
+
+// CommitChanges records changes on top of HEAD as a single commit on a new
+// branch, without touching the working tree, the index, or HEAD. An unborn
+// HEAD gives a root commit. Returns ErrBranchAlreadyExists if the branch
+// exists.
+func (g *GitManager) CommitChanges(branch, message string, changes []FileChange) (plumbing.Hash, error) {
+	exists, err := g.BranchExists(branch)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if exists {
+		return plumbing.ZeroHash, ErrBranchAlreadyExists
+	}
+
+	var (
+		parents []plumbing.Hash
+		base    *object.Tree
+	)
+	head, err := g.repo.Head()
+	switch {
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+	case err != nil:
+		return plumbing.ZeroHash, fmt.Errorf("resolving HEAD: %w", err)
+	default:
+		commit, err := g.repo.CommitObject(head.Hash())
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("reading HEAD commit: %w", err)
+		}
+		if base, err = commit.Tree(); err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("reading HEAD tree: %w", err)
+		}
+		parents = []plumbing.Hash{commit.Hash}
+	}
+
+	tree, err := buildTree(g.repo.Storer, base, changes)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	sig := g.signature(time.Now())
+	commit := &object.Commit{
+		Author:       sig,
+		Committer:    sig,
+		Message:      message,
+		TreeHash:     tree.Hash,
+		ParentHashes: parents,
+	}
+	hash, err := storeObject(g.repo.Storer, plumbing.CommitObject, commit.Encode)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("writing commit: %w", err)
+	}
+
+	ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), hash)
+	if err := g.repo.Storer.SetReference(ref); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("creating branch %q: %w", branch, err)
+	}
+	return hash, nil
+}
+
+// signature is the configured user.name and user.email (repository, then
+// global and system), falling back to "clawker".
+func (g *GitManager) signature(when time.Time) object.Signature {
+	sig := object.Signature{Name: "clawker", Email: "clawker@localhost", When: when}
+	cfg, err := g.repo.ConfigScoped(gogitconfig.SystemScope)
+	if err != nil {
+		return sig
+	}
+	if cfg.User.Name != "" {
+		sig.Name = cfg.User.Name
+	}
+	if cfg.User.Email != "" {
+		sig.Email = cfg.User.Email
+	}
+	return sig
+}
+
+// buildTree writes the tree that results from applying changes to base (nil
+// for an empty tree) into s and returns it.
+func buildTree(s gogitstorer.EncodedObjectStorer, base *object.Tree, changes []FileChange) (*object.Tree, error) {
+	files := make(map[string]object.TreeEntry)
+	if base != nil {
+		w := object.NewTreeWalker(base, true, nil)
+		for {
+			name, entry, err := w.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				w.Close()
+				return nil, fmt.Errorf("reading tree: %w", err)
+			}
+			if entry.Mode != filemode.Dir {
+				files[name] = entry
+			}
+		}
+		w.Close()
+	}
+
+	deleted := make(map[string]bool)
+	for _, ch := range changes {
+		if ch.Delete {
+			deleted[ch.Path] = true
+		}
+	}
+	if len(deleted) > 0 {
+		for p := range files {
+			for dir := p; dir != "."; dir = path.Dir(dir) {
+				if deleted[dir] {
+					delete(files, p)
+					break
+				}
+			}
+		}
+	}
+
+	for _, ch := range changes {
+		if ch.Delete {
+			continue
+		}
+		mode, err := filemode.NewFromOSFileMode(ch.Mode)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ch.Path, err)
+		}
+		hash, err := storeObject(s, plumbing.BlobObject, func(o plumbing.EncodedObject) error {
+			w, err := o.Writer()
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(ch.Content); err != nil {
+				w.Close()
+				return err
+			}
+			return w.Close()
+		})
+		if err != nil {
+			return nil, fmt.Errorf("writing %s: %w", ch.Path, err)
+		}
+		files[ch.Path] = object.TreeEntry{Name: path.Base(ch.Path), Mode: mode, Hash: hash}
+	}
+
+	root := &treeNode{}
+	for p, e := range files {
+		root.insert(p, e)
+	}
+	hash, err := root.write(s, "")
+	if err != nil {
+		return nil, err
+	}
+	return object.GetTree(s, hash)
+}
+
+// treeNode is one directory of a tree being written.
+type treeNode struct {
+	entries []object.TreeEntry
+	dirs    map[string]*treeNode
+}
+
+func (n *treeNode) insert(p string, e object.TreeEntry) {
+	dir, rest, nested := strings.Cut(p, "/")
+	if !nested {
+		n.entries = append(n.entries, e)
+		return
+	}
+	if n.dirs == nil {
+		n.dirs = make(map[string]*treeNode)
+	}
+	child, ok := n.dirs[dir]
+	if !ok {
+		child = &treeNode{}
+		n.dirs[dir] = child
+	}
+	child.insert(rest, e)
+}
+
+// write stores the node's subtrees, then the node itself, and returns its
+// hash. name is the directory path, for errors.
+func (n *treeNode) write(s gogitstorer.EncodedObjectStorer, name string) (plumbing.Hash, error) {
+	entries := n.entries
+	for dir, child := range n.dirs {
+		hash, err := child.write(s, path.Join(name, dir))
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		entries = append(entries, object.TreeEntry{Name: dir, Mode: filemode.Dir, Hash: hash})
+	}
+	sort.Sort(object.TreeEntrySorter(entries))
+
+	tree := &object.Tree{Entries: entries}
+	hash, err := storeObject(s, plumbing.TreeObject, tree.Encode)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("writing tree %q: %w", name, err)
+	}
+	return hash, nil
+}
+
+// storeObject encodes an object of type t into s.
+func storeObject(s gogitstorer.EncodedObjectStorer, t plumbing.ObjectType, encode func(plumbing.EncodedObject) error) (plumbing.Hash, error) {
+	o := s.NewEncodedObject()
+	o.SetType(t)
+	if err := encode(o); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return s.SetEncodedObject(o)
+}
@@ -0,0 +1,89 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v6/plumbing"
+	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatch(t *testing.T) {
+	from := []FileChange{
+		{Path: "main.go", Mode: 0o644, Content: []byte("package main\n")},
+		{Path: "old.txt", Mode: 0o644, Content: []byte("gone\n")},
+	}
+	to := []FileChange{
+		{Path: "main.go", Mode: 0o644, Content: []byte("package main\n\nfunc main() {}\n")},
+		{Path: "pkg/new.go", Mode: 0o755, Content: []byte("package pkg\n")},
+	}
+
+	patch, err := Patch(from, to)
+	require.NoError(t, err)
+	assert.Contains(t, patch, "diff --git a/main.go b/main.go")
+	assert.Contains(t, patch, "+func main() {}")
+	assert.Contains(t, patch, "diff --git a/old.txt b/old.txt\ndeleted file mode 100644")
+	assert.Contains(t, patch, "diff --git a/pkg/new.go b/pkg/new.go\nnew file mode 100755")
+
+	empty, err := Patch(from, from)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestCommitChanges(t *testing.T) {
+	repo, dir := newTestRepoOnDisk(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "docs"), 0o755))
+	g, err := NewGitManager(dir)
+	require.NoError(t, err)
+
+	// Seed a nested file on a first branch, then build on it.
+	base, err := g.CommitChanges("seed", "seed", []FileChange{
+		{Path: "docs/a.md", Mode: 0o644, Content: []byte("a\n")},
+		{Path: "docs/b.md", Mode: 0o644, Content: []byte("b\n")},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.HEAD, base)))
+
+	hash, err := g.CommitChanges("agent/dev", "Pull changes", []FileChange{
+		{Path: "docs/a.md", Delete: true},
+		{Path: "README.md", Mode: 0o644, Content: []byte("# Changed\n")},
+		{Path: "bin/run", Mode: 0o755, Content: []byte("#!/bin/sh\n")},
+		{Path: "link", Mode: os.ModeSymlink, Content: []byte("README.md")},
+	})
+	require.NoError(t, err)
+
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName("agent/dev"), true)
+	require.NoError(t, err)
+	assert.Equal(t, hash, ref.Hash())
+
+	commit, err := repo.CommitObject(hash)
+	require.NoError(t, err)
+	assert.Equal(t, "Pull changes", commit.Message)
+	assert.Equal(t, []plumbing.Hash{base}, commit.ParentHashes)
+
+	tree, err := commit.Tree()
+	require.NoError(t, err)
+	files := map[string]string{}
+	require.NoError(t, tree.Files().ForEach(func(f *object.File) error {
+		content, err := f.Contents()
+		files[f.Name] = content
+		return err
+	}))
+	assert.Equal(t, map[string]string{
+		"README.md": "# Changed\n",
+		"bin/run":   "#!/bin/sh\n",
+		"docs/b.md": "b\n",
+		"link":      "README.md",
+	}, files)
+
+	// The working tree is untouched.
+	data, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Test Repo\n", string(data))
+
+	_, err = g.CommitChanges("agent/dev", "again", nil)
+	assert.ErrorIs(t, err, ErrBranchAlreadyExists)
+}
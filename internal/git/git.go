@@ -27,6 +27,7 @@ import (
 	gogitconfig "github.com/go-git/go-git/v6/config"
 	"github.com/go-git/go-git/v6/plumbing"
 	gogitstorer "github.com/go-git/go-git/v6/plumbing/storer"
+	"github.com/go-git/go-git/v6/plumbing/transport"
 )
 
 var (
@@ -655,6 +656,42 @@ func (g *GitManager) ResolveRemoteTrackingBranch(branch string) (remote string,
 	}
 }
 
+// RemoteHosts returns the hostnames the repository's remotes point at,
+// lowercased and deduplicated: origin's first, then the rest in remote name
+// order. SCP-like URLs (git@host:path) count; local-path and file:// remotes
+// have no host and are skipped.
+func (g *GitManager) RemoteHosts() ([]string, error) {
+	remotes, err := g.repo.Remotes()
+	if err != nil {
+		return nil, fmt.Errorf("listing remotes: %w", err)
+	}
+	sort.Slice(remotes, func(i, j int) bool {
+		a, b := remotes[i].Config().Name, remotes[j].Config().Name
+		if (a == gogit.DefaultRemoteName) != (b == gogit.DefaultRemoteName) {
+			return a == gogit.DefaultRemoteName
+		}
+		return a < b
+	})
+
+	var hosts []string
+	seen := make(map[string]bool)
+	for _, r := range remotes {
+		for _, raw := range r.Config().URLs {
+			u, err := transport.ParseURL(raw)
+			if err != nil || u.Scheme == "file" {
+				continue
+			}
+			host := strings.ToLower(u.Hostname())
+			if host == "" || seen[host] {
+				continue
+			}
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
 // SetBranchUpstream writes tracking config so localBranch tracks
 // <remote>/<remoteBranch> (branch.<localBranch>.remote / .merge), matching
 // `git branch --set-upstream-to`. remoteBranch is the branch name on the remote,
@@ -1709,3 +1709,26 @@ func TestFindBranchCheckout_DetectsLinkedWorktree(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, found, "a branch checked out nowhere must not be flagged")
 }
+
+func TestGitManager_RemoteHosts(t *testing.T) {
+	repo, repoDir := newTestRepoOnDisk(t)
+	mgr := NewGitManagerWithRepo(repo, repoDir)
+
+	hosts, err := mgr.RemoteHosts()
+	require.NoError(t, err)
+	assert.Empty(t, hosts, "a repo without remotes has no hosts")
+
+	for _, rc := range []*config.RemoteConfig{
+		{Name: "upstream", URLs: []string{"git@GitLab.example.com:team/repo.git"}},
+		{Name: "origin", URLs: []string{"https://github.com/me/repo.git", "ssh://git@github.com:22/me/repo.git"}},
+		{Name: "local", URLs: []string{"/srv/git/repo.git", "file:///srv/git/other.git"}},
+	} {
+		_, err := repo.CreateRemote(rc)
+		require.NoError(t, err)
+	}
+
+	hosts, err = mgr.RemoteHosts()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github.com", "gitlab.example.com"}, hosts,
+		"origin first, deduplicated, lowercased, local remotes skipped")
+}
@@ -2,6 +2,9 @@ package git
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	gogit "github.com/go-git/go-git/v6"
 	"github.com/go-git/go-git/v6/plumbing"
@@ -206,3 +209,47 @@ func (w *WorktreeManager) AddWithExistingBranch(path, name string, branch plumbi
 
 	return nil
 }
+
+// gitdirPrefix starts a linked worktree's .git pointer file.
+const gitdirPrefix = "gitdir: "
+
+// RepairWorktreeLink re-points a linked worktree at its repository after the
+// repository moved from oldGitDir to newGitDir — the half of
+// `git worktree repair` clawker needs when a project root is relocated. The
+// worktree's .git file is rewritten when it points into oldGitDir, and the
+// repository's worktrees/<slug>/gitdir back-pointer is rewritten to the
+// worktree's current path. Absolute pointers only: a pointer outside
+// oldGitDir is left alone. Reports whether anything was rewritten.
+func RepairWorktreeLink(worktreePath, oldGitDir, newGitDir string) (bool, error) {
+	dotGit := filepath.Join(worktreePath, ".git")
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return false, fmt.Errorf("reading worktree pointer: %w", err)
+	}
+	target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), gitdirPrefix)
+	if !ok {
+		return false, fmt.Errorf("%s is not a linked worktree pointer", dotGit)
+	}
+	rel, err := filepath.Rel(oldGitDir, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false, nil
+	}
+
+	changed := false
+	newTarget := filepath.Join(newGitDir, rel)
+	if newTarget != target {
+		if err := os.WriteFile(dotGit, []byte(gitdirPrefix+newTarget+"\n"), 0o644); err != nil {
+			return false, fmt.Errorf("rewriting worktree pointer: %w", err)
+		}
+		changed = true
+	}
+
+	backPtr := filepath.Join(newTarget, "gitdir")
+	if cur, err := os.ReadFile(backPtr); err == nil && strings.TrimSpace(string(cur)) != dotGit {
+		if err := os.WriteFile(backPtr, []byte(dotGit+"\n"), 0o644); err != nil {
+			return changed, fmt.Errorf("rewriting worktree back-pointer: %w", err)
+		}
+		changed = true
+	}
+	return changed, nil
+}
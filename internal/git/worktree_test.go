@@ -0,0 +1,44 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepairWorktreeLink(t *testing.T) {
+	base := t.TempDir()
+	oldRepo := filepath.Join(base, "old")
+	newRepo := filepath.Join(base, "new")
+	wt := filepath.Join(base, "worktrees", "feat")
+
+	meta := filepath.Join(oldRepo, ".git", "worktrees", "feat")
+	require.NoError(t, os.MkdirAll(meta, 0o755))
+	require.NoError(t, os.MkdirAll(wt, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(wt, ".git"), []byte("gitdir: "+meta+"\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(meta, "gitdir"), []byte(filepath.Join(wt, ".git")+"\n"), 0o644))
+	require.NoError(t, os.Rename(oldRepo, newRepo))
+
+	changed, err := RepairWorktreeLink(wt, filepath.Join(oldRepo, ".git"), filepath.Join(newRepo, ".git"))
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	data, err := os.ReadFile(filepath.Join(wt, ".git"))
+	require.NoError(t, err)
+	assert.Equal(t, "gitdir: "+filepath.Join(newRepo, ".git", "worktrees", "feat")+"\n", string(data))
+
+	changed, err = RepairWorktreeLink(wt, filepath.Join(oldRepo, ".git"), filepath.Join(newRepo, ".git"))
+	require.NoError(t, err)
+	assert.False(t, changed, "a repaired link points outside the old git dir")
+}
+
+func TestRepairWorktreeLink_NotAWorktree(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+
+	_, err := RepairWorktreeLink(dir, "/old/.git", "/new/.git")
+	assert.Error(t, err)
+}
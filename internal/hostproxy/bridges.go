@@ -0,0 +1,331 @@
+package hostproxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/moby/moby/client"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// Bridge states reported by /bridges.
+const (
+	BridgeRunning    = "running"
+	BridgeRestarting = "restarting"
+)
+
+// BridgeSpec describes a socket bridge daemon the host proxy keeps alive.
+// socketbridge.Manager writes one next to the bridge's PID file once the
+// bridge is up and removes it when the bridge is stopped on purpose, so a
+// spec on disk means "this bridge should be running".
+type BridgeSpec struct {
+	ContainerID string `json:"container_id"`
+	GPG         bool   `json:"gpg"`
+	PIDFile     string `json:"pid_file"`
+}
+
+// BridgeStatus is one supervised bridge as reported by GET /bridges.
+type BridgeStatus struct {
+	ContainerID string    `json:"container_id"`
+	State       string    `json:"state"`
+	PID         int       `json:"pid,omitempty"`
+	GPG         bool      `json:"gpg"`
+	Restarts    int       `json:"restarts"`
+	LastExit    time.Time `json:"last_exit,omitzero"`
+	NextRestart time.Time `json:"next_restart,omitzero"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// BridgeReport is the GET /bridges response body.
+type BridgeReport struct {
+	Bridges []BridgeStatus `json:"bridges"`
+}
+
+// WriteBridgeSpec records spec in dir (the bridges PID directory). The write
+// is atomic so the supervisor never reads a partial spec.
+func WriteBridgeSpec(dir string, spec BridgeSpec) error {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, spec.ContainerID+consts.BridgeSpecSuffix)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing bridge spec: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("writing bridge spec: %w", err)
+	}
+	return nil
+}
+
+// RemoveBridgeSpec removes containerID's spec from dir, ending its
+// supervision. A missing spec is not an error.
+func RemoveBridgeSpec(dir, containerID string) error {
+	err := os.Remove(filepath.Join(dir, containerID+consts.BridgeSpecSuffix))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// readBridgeSpecs returns every spec in dir. Unreadable or malformed specs
+// are skipped.
+func readBridgeSpecs(dir string) ([]BridgeSpec, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var specs []BridgeSpec
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), consts.BridgeSpecSuffix) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var spec BridgeSpec
+		if err := json.Unmarshal(data, &spec); err != nil || spec.ContainerID == "" {
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// BridgeSupervisor keeps socket bridge daemons alive. Each Check reads the
+// bridge specs, and a bridge whose process is gone is restarted — with
+// exponential backoff — as long as its container is still running. A bridge
+// whose container has stopped is dropped and its spec removed; its exit was
+// the normal end of its life.
+type BridgeSupervisor struct {
+	dir    string
+	log    *logger.Logger
+	docker ContainerLister
+
+	// spawn starts a bridge daemon and returns its PID; now is the clock.
+	// Both are replaced in tests.
+	spawn      func(BridgeSpec) (int, error)
+	now        func() time.Time
+	backoffMin time.Duration
+	backoffMax time.Duration
+
+	mu      sync.Mutex
+	bridges map[string]*supervisedBridge // container ID -> bridge
+}
+
+// supervisedBridge is the supervisor's record of one bridge.
+type supervisedBridge struct {
+	status   BridgeStatus
+	upSince  time.Time // when the current process was first seen running
+	failures int       // consecutive restarts; drives the backoff
+}
+
+// NewBridgeSupervisor returns a supervisor for the specs in dir. Restarted
+// daemons append their output to logPath.
+func NewBridgeSupervisor(dir, logPath string, docker ContainerLister, log *logger.Logger) *BridgeSupervisor {
+	return &BridgeSupervisor{
+		dir:        dir,
+		log:        log,
+		docker:     docker,
+		spawn:      func(spec BridgeSpec) (int, error) { return spawnBridge(spec, logPath) },
+		now:        time.Now,
+		backoffMin: consts.HostProxyBridgeBackoffMin,
+		backoffMax: consts.HostProxyBridgeBackoffMax,
+		bridges:    make(map[string]*supervisedBridge),
+	}
+}
+
+// Run checks the bridges every interval until ctx is cancelled.
+func (s *BridgeSupervisor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check reconciles the supervised bridges with the specs on disk and
+// restarts the ones that died.
+func (s *BridgeSupervisor) Check(ctx context.Context) {
+	specs, err := readBridgeSpecs(s.dir)
+	if err != nil {
+		s.log.Warn().Err(err).Str("dir", s.dir).Msg("failed to read bridge specs")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		seen[spec.ContainerID] = true
+		s.checkLocked(ctx, spec)
+	}
+	for id := range s.bridges {
+		if !seen[id] {
+			delete(s.bridges, id)
+		}
+	}
+}
+
+// checkLocked brings one bridge up to date. Must be called with s.mu held.
+func (s *BridgeSupervisor) checkLocked(ctx context.Context, spec BridgeSpec) {
+	now := s.now()
+	id := spec.ContainerID
+	b, ok := s.bridges[id]
+	if !ok {
+		b = &supervisedBridge{status: BridgeStatus{ContainerID: id}}
+		s.bridges[id] = b
+	}
+	b.status.GPG = spec.GPG
+
+	// The PID file wins, but a killed bridge can leave a stale one behind
+	// while the process restarted below hasn't written its own yet.
+	pid := 0
+	if p, err := readPIDFile(spec.PIDFile); err == nil && p > 0 && isProcessAlive(p) {
+		pid = p
+	} else if b.status.PID > 0 && isProcessAlive(b.status.PID) {
+		pid = b.status.PID
+	}
+	if pid > 0 {
+		if b.status.State != BridgeRunning {
+			b.upSince = now
+		}
+		b.status.State = BridgeRunning
+		b.status.PID = pid
+		b.status.NextRestart = time.Time{}
+		if now.Sub(b.upSince) >= s.backoffMax {
+			b.failures = 0
+		}
+		return
+	}
+
+	if b.status.State == BridgeRunning {
+		b.status.LastExit = now
+		s.log.Warn().Str("container", shortContainerID(id)).Int("pid", b.status.PID).Msg("socket bridge exited")
+	}
+	b.status.PID = 0
+
+	running, err := s.containerRunning(ctx, id)
+	if err != nil {
+		s.log.Warn().Err(err).Str("container", shortContainerID(id)).Msg("failed to check bridge container")
+		return
+	}
+	if !running {
+		s.log.Debug().Str("container", shortContainerID(id)).Msg("bridge container no longer running; ending supervision")
+		if err := RemoveBridgeSpec(s.dir, id); err != nil {
+			s.log.Warn().Err(err).Str("container", shortContainerID(id)).Msg("failed to remove bridge spec")
+		}
+		delete(s.bridges, id)
+		return
+	}
+
+	b.status.State = BridgeRestarting
+	if b.status.NextRestart.IsZero() {
+		b.status.NextRestart = now.Add(s.backoff(b.failures))
+	}
+	if now.Before(b.status.NextRestart) {
+		return
+	}
+
+	b.failures++
+	b.status.Restarts++
+	newPID, err := s.spawn(spec)
+	if err != nil {
+		b.status.LastError = err.Error()
+		b.status.NextRestart = now.Add(s.backoff(b.failures))
+		s.log.Warn().Err(err).Str("container", shortContainerID(id)).Int("restarts", b.status.Restarts).Msg("failed to restart socket bridge")
+		return
+	}
+	s.log.Info().Str("container", shortContainerID(id)).Int("pid", newPID).Int("restarts", b.status.Restarts).Msg("restarted socket bridge")
+	b.status.State = BridgeRunning
+	b.status.PID = newPID
+	b.status.NextRestart = time.Time{}
+	b.status.LastError = ""
+	b.upSince = now
+}
+
+// Status returns the supervised bridges, ordered by container ID.
+func (s *BridgeSupervisor) Status() []BridgeStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]BridgeStatus, 0, len(s.bridges))
+	for _, b := range s.bridges {
+		out = append(out, b.status)
+	}
+	slices.SortFunc(out, func(a, b BridgeStatus) int { return strings.Compare(a.ContainerID, b.ContainerID) })
+	return out
+}
+
+// backoff returns the wait before restart number failures+1.
+func (s *BridgeSupervisor) backoff(failures int) time.Duration {
+	d := s.backoffMin
+	for range failures {
+		d *= 2
+		if d >= s.backoffMax {
+			return s.backoffMax
+		}
+	}
+	return d
+}
+
+// containerRunning reports whether the container is running.
+// ContainerList returns running containers only by default.
+func (s *BridgeSupervisor) containerRunning(ctx context.Context, containerID string) (bool, error) {
+	result, err := s.docker.ContainerList(ctx, client.ContainerListOptions{
+		Filters: client.Filters{}.Add("id", containerID),
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(result.Items) > 0, nil
+}
+
+// spawnBridge starts a detached "clawker bridge serve" daemon for spec, the
+// same command socketbridge.Manager runs. The daemon is reaped by a
+// background Wait so a dead bridge never lingers as a zombie that still
+// answers the liveness check.
+func spawnBridge(spec BridgeSpec, logPath string) (int, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get executable path: %w", err)
+	}
+	args := []string{"bridge", "serve", "--container", spec.ContainerID, "--pid-file", spec.PIDFile}
+	if spec.GPG {
+		args = append(args, "--gpg")
+	}
+	cmd := exec.Command(exe, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if logFile, err := logger.OpenAppend(logPath); err == nil {
+		// The child inherits the descriptor; the parent's copy closes below.
+		defer logFile.Close()
+		cmd.Stdout = logFile
+		cmd.Stderr = logFile
+	}
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("failed to start bridge daemon: %w", err)
+	}
+	go func() { _ = cmd.Wait() }()
+	return cmd.Process.Pid, nil
+}
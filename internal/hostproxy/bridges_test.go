@@ -0,0 +1,255 @@
+package hostproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// runningLister reports a fixed set of running container IDs.
+type runningLister struct {
+	running map[string]bool
+	err     error
+}
+
+func (l *runningLister) ContainerList(_ context.Context, opts client.ContainerListOptions) (client.ContainerListResult, error) {
+	if l.err != nil {
+		return client.ContainerListResult{}, l.err
+	}
+	var items []container.Summary
+	for id := range opts.Filters["id"] {
+		if l.running[id] {
+			items = append(items, container.Summary{ID: id})
+		}
+	}
+	return client.ContainerListResult{Items: items}, nil
+}
+
+func (l *runningLister) Close() error { return nil }
+
+// testSupervisor returns a supervisor over a temp dir with a fake clock and a
+// spawn that records calls and returns pid.
+func testSupervisor(t *testing.T, lister ContainerLister, pid int, spawnErr error) (*BridgeSupervisor, *time.Time, *int) {
+	t.Helper()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	spawns := 0
+	s := NewBridgeSupervisor(t.TempDir(), filepath.Join(t.TempDir(), "bridge.log"), lister, logger.Nop())
+	s.now = func() time.Time { return now }
+	s.spawn = func(BridgeSpec) (int, error) {
+		spawns++
+		return pid, spawnErr
+	}
+	return s, &now, &spawns
+}
+
+func TestBridgeSpec_WriteReadRemove(t *testing.T) {
+	dir := t.TempDir()
+	spec := BridgeSpec{ContainerID: "abc123", GPG: true, PIDFile: filepath.Join(dir, "abc123.pid")}
+	if err := WriteBridgeSpec(dir, spec); err != nil {
+		t.Fatalf("WriteBridgeSpec: %v", err)
+	}
+	// Malformed specs are skipped.
+	if err := os.WriteFile(filepath.Join(dir, "bad.bridge.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	specs, err := readBridgeSpecs(dir)
+	if err != nil {
+		t.Fatalf("readBridgeSpecs: %v", err)
+	}
+	if len(specs) != 1 || specs[0] != spec {
+		t.Fatalf("specs = %+v, want [%+v]", specs, spec)
+	}
+
+	if err := RemoveBridgeSpec(dir, "abc123"); err != nil {
+		t.Fatalf("RemoveBridgeSpec: %v", err)
+	}
+	if err := RemoveBridgeSpec(dir, "abc123"); err != nil {
+		t.Errorf("second RemoveBridgeSpec: %v", err)
+	}
+	if specs, _ := readBridgeSpecs(dir); len(specs) != 0 {
+		t.Errorf("specs after remove = %+v", specs)
+	}
+}
+
+func TestBridgeSupervisor_AliveBridgeIsRunning(t *testing.T) {
+	s, _, spawns := testSupervisor(t, &runningLister{}, 0, nil)
+	pidFile := filepath.Join(s.dir, "c1.pid")
+	if err := os.WriteFile(pidFile, []byte(strconv.Itoa(os.Getpid())), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteBridgeSpec(s.dir, BridgeSpec{ContainerID: "c1", PIDFile: pidFile}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Check(context.Background())
+
+	got := s.Status()
+	if len(got) != 1 || got[0].State != BridgeRunning || got[0].PID != os.Getpid() {
+		t.Fatalf("status = %+v, want running with our PID", got)
+	}
+	if *spawns != 0 {
+		t.Errorf("spawns = %d, want 0", *spawns)
+	}
+}
+
+func TestServerBridges(t *testing.T) {
+	sup, _, _ := testSupervisor(t, &runningLister{}, 0, nil)
+	for _, id := range []string{"abc123", "other"} {
+		pidFile := filepath.Join(sup.dir, id+".pid")
+		if err := os.WriteFile(pidFile, []byte(strconv.Itoa(os.Getpid())), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteBridgeSpec(sup.dir, BridgeSpec{ContainerID: id, PIDFile: pidFile}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sup.Check(context.Background())
+
+	key := []byte("test-report-key")
+	s := &Server{log: logger.Nop(), callerLookup: testAgent} // testCallerToken is container abc123
+	s.SetReportKey(key)
+	s.SetBridgeSupervisor(sup)
+
+	get := func(header, value string) (int, []string) {
+		req := httptest.NewRequest(http.MethodGet, "/bridges", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		s.handleBridges(w, req)
+		var report BridgeReport
+		_ = json.NewDecoder(w.Body).Decode(&report)
+		var ids []string
+		for _, b := range report.Bridges {
+			ids = append(ids, b.ContainerID)
+		}
+		slices.Sort(ids)
+		return w.Code, ids
+	}
+
+	if code, ids := get(HeaderHostToken, HostReadToken(key)); code != http.StatusOK || !slices.Equal(ids, []string{"abc123", "other"}) {
+		t.Errorf("host reader: status %d, bridges %v; want 200 and every bridge", code, ids)
+	}
+	if code, ids := get(HeaderCallerToken, testCallerToken); code != http.StatusOK || !slices.Equal(ids, []string{"abc123"}) {
+		t.Errorf("agent: status %d, bridges %v; want 200 and only its own", code, ids)
+	}
+	if code, _ := get("", ""); code != http.StatusForbidden {
+		t.Errorf("no token: status %d, want 403", code)
+	}
+	if code, _ := get(HeaderCallerToken, "forged"); code != http.StatusForbidden {
+		t.Errorf("forged token: status %d, want 403", code)
+	}
+}
+
+func TestBridgeSupervisor_RestartsWithBackoff(t *testing.T) {
+	lister := &runningLister{running: map[string]bool{"c1": true}}
+	s, now, spawns := testSupervisor(t, lister, os.Getpid(), nil)
+	if err := WriteBridgeSpec(s.dir, BridgeSpec{ContainerID: "c1", PIDFile: filepath.Join(s.dir, "c1.pid")}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// Dead bridge: the first check schedules a restart after the minimum backoff.
+	s.Check(ctx)
+	got := s.Status()
+	if len(got) != 1 || got[0].State != BridgeRestarting {
+		t.Fatalf("status = %+v, want restarting", got)
+	}
+	if want := now.Add(s.backoffMin); !got[0].NextRestart.Equal(want) {
+		t.Errorf("NextRestart = %v, want %v", got[0].NextRestart, want)
+	}
+	if *spawns != 0 {
+		t.Fatalf("spawned before backoff elapsed")
+	}
+
+	*now = now.Add(s.backoffMin)
+	s.Check(ctx)
+	got = s.Status()
+	if *spawns != 1 {
+		t.Fatalf("spawns = %d, want 1", *spawns)
+	}
+	if got[0].State != BridgeRunning || got[0].Restarts != 1 || got[0].PID != os.Getpid() {
+		t.Errorf("status = %+v, want running after 1 restart", got[0])
+	}
+}
+
+func TestBridgeSupervisor_SpawnFailureBacksOff(t *testing.T) {
+	lister := &runningLister{running: map[string]bool{"c1": true}}
+	s, now, spawns := testSupervisor(t, lister, 0, errors.New("boom"))
+	if err := WriteBridgeSpec(s.dir, BridgeSpec{ContainerID: "c1", PIDFile: filepath.Join(s.dir, "c1.pid")}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	s.Check(ctx)
+	*now = now.Add(s.backoffMin)
+	s.Check(ctx)
+	got := s.Status()
+	if *spawns != 1 || got[0].LastError != "boom" || got[0].State != BridgeRestarting {
+		t.Fatalf("spawns = %d, status = %+v", *spawns, got[0])
+	}
+	if want := now.Add(2 * s.backoffMin); !got[0].NextRestart.Equal(want) {
+		t.Errorf("NextRestart = %v, want %v", got[0].NextRestart, want)
+	}
+	s.Check(ctx)
+	if *spawns != 1 {
+		t.Errorf("spawns = %d, want no retry before backoff", *spawns)
+	}
+}
+
+func TestBridgeSupervisor_StoppedContainerEndsSupervision(t *testing.T) {
+	s, _, spawns := testSupervisor(t, &runningLister{}, 0, nil)
+	if err := WriteBridgeSpec(s.dir, BridgeSpec{ContainerID: "c1", PIDFile: filepath.Join(s.dir, "c1.pid")}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Check(context.Background())
+
+	if got := s.Status(); len(got) != 0 {
+		t.Errorf("status = %+v, want empty", got)
+	}
+	if specs, _ := readBridgeSpecs(s.dir); len(specs) != 0 {
+		t.Errorf("spec not removed: %+v", specs)
+	}
+	if *spawns != 0 {
+		t.Errorf("spawns = %d, want 0", *spawns)
+	}
+}
+
+func TestBridgeSupervisor_DockerErrorKeepsBridge(t *testing.T) {
+	s, _, spawns := testSupervisor(t, &runningLister{err: errors.New("daemon down")}, 0, nil)
+	if err := WriteBridgeSpec(s.dir, BridgeSpec{ContainerID: "c1", PIDFile: filepath.Join(s.dir, "c1.pid")}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Check(context.Background())
+
+	if specs, _ := readBridgeSpecs(s.dir); len(specs) != 1 {
+		t.Errorf("spec removed on docker error")
+	}
+	if *spawns != 0 {
+		t.Errorf("spawns = %d, want 0", *spawns)
+	}
+}
+
+func TestBridgeSupervisor_Backoff(t *testing.T) {
+	s := &BridgeSupervisor{backoffMin: time.Second, backoffMax: 10 * time.Second}
+	for failures, want := range []time.Duration{1, 2, 4, 8, 10, 10} {
+		if got := s.backoff(failures); got != want*time.Second {
+			t.Errorf("backoff(%d) = %v, want %v", failures, got, want*time.Second)
+		}
+	}
+}
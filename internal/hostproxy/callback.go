@@ -27,6 +27,9 @@ const (
 	metadataPath     = "path"
 	metadataReceived = "received"
 	metadataData     = "data"
+	// metadataContainer is set by the server, not the channel: the
+	// registering container's ID, used to account relayed callback bytes.
+	metadataContainer = "container"
 )
 
 // CallbackData contains the captured OAuth callback request data.
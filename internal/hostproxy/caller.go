@@ -0,0 +1,133 @@
+package hostproxy
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// HeaderCallerToken carries the calling agent container's host proxy token,
+// which the container holds as consts.EnvHostProxyToken.
+const HeaderCallerToken = "X-Clawker-Token"
+
+// errUnidentifiedCaller is returned when a request carries no caller token,
+// or one no running agent container holds.
+var errUnidentifiedCaller = errors.New("cannot identify the calling container; recreate it with this clawker version")
+
+// NewCallerToken returns a random token identifying one agent container to
+// the host proxy, and its digest. The container's environment holds the
+// token; the container carries the digest as consts.LabelHostProxyToken, so
+// listing containers does not reveal a usable token. A request's claim to
+// be a container is only as good as this token: nothing a caller sends
+// about itself (container ID, hostname) is trusted.
+func NewCallerToken() (token, digest string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("generating host proxy token: %w", err)
+	}
+	token = hex.EncodeToString(buf)
+	return token, CallerTokenDigest(token), nil
+}
+
+// CallerTokenDigest returns the label value for token.
+func CallerTokenDigest(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Caller is the running agent container holding the caller token a request
+// carries.
+type Caller struct {
+	ContainerID string
+	Project     string
+}
+
+// CallerFunc resolves the running agent container holding token. It fails
+// when no container holds it.
+type CallerFunc func(ctx context.Context, token string) (Caller, error)
+
+// SetCallerLookup sets how endpoints that act for one agent container (the
+// clipboard bridge) identify it. Without one every such request is refused.
+func (s *Server) SetCallerLookup(fn CallerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callerLookup = fn
+}
+
+// callerFor returns the agent container holding token, or
+// errUnidentifiedCaller.
+func (s *Server) callerFor(ctx context.Context, token string) (Caller, error) {
+	s.mu.RLock()
+	fn := s.callerLookup
+	s.mu.RUnlock()
+	if fn == nil || token == "" {
+		return Caller{}, errUnidentifiedCaller
+	}
+	caller, err := fn(ctx, token)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("caller lookup failed")
+		return Caller{}, errUnidentifiedCaller
+	}
+	return caller, nil
+}
+
+// caller is the daemon's CallerFunc.
+func (d *Daemon) caller(ctx context.Context, token string) (Caller, error) {
+	c, err := d.callerContainer(ctx, token)
+	if err != nil {
+		return Caller{}, err
+	}
+	return Caller{ContainerID: c.ID, Project: c.Labels[consts.LabelProject]}, nil
+}
+
+// callerLabels returns the labels of the running agent container labelled
+// with token's digest.
+func (d *Daemon) callerLabels(ctx context.Context, token string) (map[string]string, error) {
+	c, err := d.callerContainer(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return c.Labels, nil
+}
+
+// callerContainer returns the running agent container labelled with token's
+// digest.
+func (d *Daemon) callerContainer(ctx context.Context, token string) (container.Summary, error) {
+	f := client.Filters{}.
+		Add("label", consts.LabelHostProxyToken+"="+CallerTokenDigest(token)).
+		Add("label", d.cfg.LabelManaged()+"="+d.cfg.ManagedLabelValue()).
+		Add("label", d.cfg.LabelPurpose()+"="+d.cfg.PurposeAgent())
+	result, err := d.docker.ContainerList(ctx, client.ContainerListOptions{Filters: f})
+	if err != nil {
+		return container.Summary{}, err
+	}
+	if len(result.Items) != 1 {
+		return container.Summary{}, fmt.Errorf("%d agent containers hold the caller token", len(result.Items))
+	}
+	return result.Items[0], nil
+}
+
+// projectContainers is the daemon's ProjectContainersFunc: the clawker
+// containers labelled with project, running or not.
+func (d *Daemon) projectContainers(ctx context.Context, project string) ([]string, error) {
+	f := client.Filters{}.
+		Add("label", consts.LabelProject+"="+project).
+		Add("label", d.cfg.LabelManaged()+"="+d.cfg.ManagedLabelValue())
+	result, err := d.docker.ContainerList(ctx, client.ContainerListOptions{All: true, Filters: f})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(result.Items))
+	for _, c := range result.Items {
+		ids = append(ids, c.ID)
+	}
+	return ids, nil
+}
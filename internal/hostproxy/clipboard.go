@@ -0,0 +1,192 @@
+package hostproxy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"unicode/utf8"
+
+	"github.com/schmitthub/clawker/internal/config"
+)
+
+// clipboardResponse is the JSON response body for POST /clipboard, and for
+// GET /clipboard when it fails (a successful GET returns the text itself).
+type clipboardResponse struct {
+	Success bool   `json:"success"`
+	Bytes   int    `json:"bytes,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// SetClipboard sets the clipboard bridge policy: whether containers may copy
+// to the host clipboard, whether they may read it, and the size cap.
+func (s *Server) SetClipboard(c config.HostProxyClipboardConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clipboard = c
+}
+
+func (s *Server) clipboardPolicy() config.HostProxyClipboardConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clipboard
+}
+
+// clipboardCaller identifies the agent container behind a /clipboard
+// request, writing the refusal and reporting false when no running agent
+// container holds its caller token. The port is reachable from every
+// container on the host, and only clawker's own agents may use the bridge.
+func (s *Server) clipboardCaller(w http.ResponseWriter, r *http.Request) (Caller, bool) {
+	caller, err := s.callerFor(r.Context(), r.Header.Get(HeaderCallerToken))
+	if err != nil {
+		s.log.Warn().Str("remote", r.RemoteAddr).Msg("clipboard request from an unidentified caller refused")
+		s.writeJSON(w, http.StatusForbidden, clipboardResponse{Error: err.Error()})
+		return Caller{}, false
+	}
+	return caller, true
+}
+
+// handleClipboardCopy handles POST /clipboard: the request body, UTF-8 text
+// of at most host_proxy.clipboard.max_kib, replaces the host clipboard.
+func (s *Server) handleClipboardCopy(w http.ResponseWriter, r *http.Request) {
+	policy := s.clipboardPolicy()
+	if !policy.Enabled {
+		s.writeJSON(w, http.StatusForbidden, clipboardResponse{
+			Error: "clipboard bridge is disabled (set host_proxy.clipboard.enabled in settings.yaml)",
+		})
+		return
+	}
+	caller, ok := s.clipboardCaller(w, r)
+	if !ok {
+		return
+	}
+
+	limit := policy.MaxBytes()
+	text, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			s.writeJSON(w, http.StatusRequestEntityTooLarge, clipboardResponse{
+				Error: fmt.Sprintf("clipboard text exceeds %d KiB (host_proxy.clipboard.max_kib)", limit/1024),
+			})
+			return
+		}
+		s.writeJSON(w, http.StatusBadRequest, clipboardResponse{Error: "failed to read request body"})
+		return
+	}
+	if !utf8.Valid(text) {
+		s.writeJSON(w, http.StatusBadRequest, clipboardResponse{Error: "clipboard text must be UTF-8"})
+		return
+	}
+
+	if err := s.clipboardWriteFunc(text); err != nil {
+		s.log.Warn().Err(err).Msg("failed to write host clipboard")
+		s.writeJSON(w, http.StatusInternalServerError, clipboardResponse{
+			Error: "failed to write host clipboard",
+		})
+		return
+	}
+	// The text itself is never logged: clipboards carry secrets.
+	s.log.Info().Str("container", caller.ContainerID).Int("bytes", len(text)).Msg("copied to host clipboard")
+	s.writeJSON(w, http.StatusOK, clipboardResponse{Success: true, Bytes: len(text)})
+}
+
+// handleClipboardPaste handles GET /clipboard: it returns the host clipboard
+// as text/plain when host_proxy.clipboard.allow_paste is set.
+func (s *Server) handleClipboardPaste(w http.ResponseWriter, r *http.Request) {
+	policy := s.clipboardPolicy()
+	if !policy.Enabled || !policy.AllowPaste {
+		s.writeJSON(w, http.StatusForbidden, clipboardResponse{
+			Error: "clipboard paste is disabled (set host_proxy.clipboard.enabled and allow_paste in settings.yaml)",
+		})
+		return
+	}
+	caller, ok := s.clipboardCaller(w, r)
+	if !ok {
+		return
+	}
+
+	text, err := s.clipboardReadFunc()
+	if err != nil {
+		s.log.Warn().Err(err).Msg("failed to read host clipboard")
+		s.writeJSON(w, http.StatusInternalServerError, clipboardResponse{
+			Error: "failed to read host clipboard",
+		})
+		return
+	}
+	if limit := policy.MaxBytes(); int64(len(text)) > limit {
+		s.writeJSON(w, http.StatusRequestEntityTooLarge, clipboardResponse{
+			Error: fmt.Sprintf("host clipboard exceeds %d KiB (host_proxy.clipboard.max_kib)", limit/1024),
+		})
+		return
+	}
+	s.log.Info().Str("container", caller.ContainerID).Int("bytes", len(text)).Msg("pasted host clipboard")
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(text)
+}
+
+// clipboardCommands returns the host commands that write and read the
+// clipboard. On Linux a Wayland session prefers wl-clipboard, then xclip,
+// then xsel.
+func clipboardCommands() (copyCmd, pasteCmd []string, err error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"pbcopy"}, []string{"pbpaste"}, nil
+	case "windows":
+		return []string{"powershell", "-NoProfile", "-Command",
+				"[Console]::InputEncoding=[Text.Encoding]::UTF8; Set-Clipboard -Value ([Console]::In.ReadToEnd())"},
+			[]string{"powershell", "-NoProfile", "-Command",
+				"[Console]::OutputEncoding=[Text.Encoding]::UTF8; Get-Clipboard -Raw"},
+			nil
+	case "linux":
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			if _, err := exec.LookPath("wl-copy"); err == nil {
+				return []string{"wl-copy"}, []string{"wl-paste", "--no-newline"}, nil
+			}
+		}
+		if _, err := exec.LookPath("xclip"); err == nil {
+			return []string{"xclip", "-selection", "clipboard"}, []string{"xclip", "-selection", "clipboard", "-o"}, nil
+		}
+		if _, err := exec.LookPath("xsel"); err == nil {
+			return []string{"xsel", "--clipboard", "--input"}, []string{"xsel", "--clipboard", "--output"}, nil
+		}
+		return nil, nil, errors.New("no clipboard tool found (install wl-clipboard, xclip or xsel)")
+	default:
+		return nil, nil, fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+}
+
+// writeClipboard replaces the host clipboard with text.
+func writeClipboard(text []byte) error {
+	copyCmd, _, err := clipboardCommands()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(copyCmd[0], copyCmd[1:]...)
+	cmd.Stdin = bytes.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", copyCmd[0], err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// readClipboard returns the host clipboard's text.
+func readClipboard() ([]byte, error) {
+	_, pasteCmd, err := clipboardCommands()
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command(pasteCmd[0], pasteCmd[1:]...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", pasteCmd[0], err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return out, nil
+}
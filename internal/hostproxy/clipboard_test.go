@@ -0,0 +1,190 @@
+package hostproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// testAgent resolves testCallerToken to an agent container and refuses any
+// other token.
+func testAgent(_ context.Context, token string) (Caller, error) {
+	if token != testCallerToken {
+		return Caller{}, errors.New("no container holds the token")
+	}
+	return Caller{ContainerID: "abc123", Project: "app"}, nil
+}
+
+// clipboardRequest builds a /clipboard request carrying token as the caller
+// token ("" = none).
+func clipboardRequest(method, body, token string) *http.Request {
+	req := httptest.NewRequest(method, "/clipboard", strings.NewReader(body))
+	if token != "" {
+		req.Header.Set(HeaderCallerToken, token)
+	}
+	return req
+}
+
+func TestServerClipboardCopy(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     config.HostProxyClipboardConfig
+		body       string
+		token      string
+		wantStatus int
+		wantCopied bool
+	}{
+		{
+			name:       "disabled by default",
+			body:       "hello",
+			token:      testCallerToken,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "refuses a request without a caller token",
+			policy:     config.HostProxyClipboardConfig{Enabled: true, MaxKiB: 1},
+			body:       "hello",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "refuses a token no agent container holds",
+			policy:     config.HostProxyClipboardConfig{Enabled: true, MaxKiB: 1},
+			body:       "hello",
+			token:      "forged",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "copies text",
+			policy:     config.HostProxyClipboardConfig{Enabled: true, MaxKiB: 1},
+			body:       "hello\n",
+			token:      testCallerToken,
+			wantStatus: http.StatusOK,
+			wantCopied: true,
+		},
+		{
+			name:       "over the size cap",
+			policy:     config.HostProxyClipboardConfig{Enabled: true, MaxKiB: 1},
+			body:       strings.Repeat("x", 1025),
+			token:      testCallerToken,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "rejects non UTF-8",
+			policy:     config.HostProxyClipboardConfig{Enabled: true, MaxKiB: 1},
+			body:       "\xff\xfe",
+			token:      testCallerToken,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var copied []byte
+			s := &Server{
+				log:                logger.Nop(),
+				clipboardWriteFunc: func(text []byte) error { copied = text; return nil },
+				callerLookup:       testAgent,
+			}
+			s.SetClipboard(tt.policy)
+
+			w := httptest.NewRecorder()
+			s.handleClipboardCopy(w, clipboardRequest(http.MethodPost, tt.body, tt.token))
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			var result clipboardResponse
+			if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if result.Success != tt.wantCopied {
+				t.Errorf("success = %v, want %v (error %q)", result.Success, tt.wantCopied, result.Error)
+			}
+			if tt.wantCopied && string(copied) != tt.body {
+				t.Errorf("clipboard = %q, want %q", copied, tt.body)
+			}
+			if !tt.wantCopied && copied != nil {
+				t.Errorf("clipboard written on a rejected copy: %q", copied)
+			}
+		})
+	}
+}
+
+func TestServerClipboardPaste(t *testing.T) {
+	read := func() ([]byte, error) { return []byte("from the host\n"), nil }
+
+	tests := []struct {
+		name       string
+		policy     config.HostProxyClipboardConfig
+		token      string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "copy-only bridge refuses paste",
+			policy:     config.HostProxyClipboardConfig{Enabled: true, MaxKiB: 1},
+			token:      testCallerToken,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "allow_paste without enabled refuses paste",
+			policy:     config.HostProxyClipboardConfig{AllowPaste: true, MaxKiB: 1},
+			token:      testCallerToken,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "refuses a request without a caller token",
+			policy:     config.HostProxyClipboardConfig{Enabled: true, AllowPaste: true, MaxKiB: 1},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "returns the clipboard",
+			policy:     config.HostProxyClipboardConfig{Enabled: true, AllowPaste: true, MaxKiB: 1},
+			token:      testCallerToken,
+			wantStatus: http.StatusOK,
+			wantBody:   "from the host\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{log: logger.Nop(), clipboardReadFunc: read, callerLookup: testAgent}
+			s.SetClipboard(tt.policy)
+
+			w := httptest.NewRecorder()
+			s.handleClipboardPaste(w, clipboardRequest(http.MethodGet, "", tt.token))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestServerClipboardPaste_OverCap(t *testing.T) {
+	s := &Server{
+		log:               logger.Nop(),
+		clipboardReadFunc: func() ([]byte, error) { return bytes.Repeat([]byte("x"), 2048), nil },
+		callerLookup:      testAgent,
+	}
+	s.SetClipboard(config.HostProxyClipboardConfig{Enabled: true, AllowPaste: true, MaxKiB: 1})
+
+	w := httptest.NewRecorder()
+	s.handleClipboardPaste(w, clipboardRequest(http.MethodGet, "", testCallerToken))
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/storage"
 )
 
 // ContainerLister is the minimal interface needed for container watcher functionality.
@@ -24,7 +26,7 @@ import (
 //
 // Note: This package imports github.com/moby/moby/client directly rather than going
 // through pkg/whail. This is intentional because the daemon runs as a standalone
-// subprocess that only needs to list containers - it doesn't need whail's jail
+// subprocess that only needs to list (and, for the idle reaper, stop) containers - it doesn't need whail's jail
 // semantics or label enforcement. The interface pattern still allows for testing.
 // egressHealthTimeout bounds the HTTP request used to probe Envoy's health
 // listener during the daemon's startup readiness gate.
@@ -38,14 +40,22 @@ type ContainerLister interface {
 // Daemon manages the host proxy server as a background process.
 // It polls Docker for clawker containers and auto-exits when none are running.
 type Daemon struct {
-	cfg                config.Config
-	log                *logger.Logger
-	server             *Server
-	docker             ContainerLister
-	pidFile            string
+	cfg         config.Config
+	log         *logger.Logger
+	server      *Server
+	docker      ContainerLister
+	pidFile     string
+	gracePeriod time.Duration
+	bridges     *BridgeSupervisor // nil = no bridge supervision
+	idle        IdleSource        // nil = no idle reaper
+	stopper     ContainerStopper
+
+	// tuningMu guards the container-watcher knobs settings.yaml can change
+	// while the daemon runs (see applySettings).
+	tuningMu           sync.Mutex
 	pollInterval       time.Duration
-	gracePeriod        time.Duration
 	maxConsecutiveErrs int
+	idleAfter          time.Duration // settings idle_timeout; 0 = never reap
 
 	// Staged startup-readiness gate: probes and per-stage wait budgets, all
 	// populated by NewDaemon (probes → the real implementations, budgets → the
@@ -126,6 +136,19 @@ func NewDaemon(cfg config.Config, log *logger.Logger, opts ...DaemonOption) (*Da
 		rulesFilePath = filepath.Join(dataDir, cfg.EgressRulesFileName())
 	}
 
+	bridgesDir, err := cfg.BridgesSubdir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve bridges directory: %w", err)
+	}
+	logsDir, err := cfg.LogsSubdir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve logs directory: %w", err)
+	}
+	reportKey, err := LoadOrCreateReportKey(filepath.Join(bridgesDir, consts.HostProxyReportKeyFile))
+	if err != nil {
+		return nil, err
+	}
+
 	dockerClient, err := client.New(client.FromEnv)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create docker client: %w", err)
@@ -136,10 +159,13 @@ func NewDaemon(cfg config.Config, log *logger.Logger, opts ...DaemonOption) (*Da
 		log:                log,
 		server:             NewServer(daemonCfg.Port, log, rulesFilePath),
 		docker:             dockerClient,
+		stopper:            dockerClient,
 		pidFile:            pidFile,
 		pollInterval:       daemonCfg.PollInterval,
 		gracePeriod:        daemonCfg.GracePeriod,
 		maxConsecutiveErrs: daemonCfg.MaxConsecutiveErrs,
+		idleAfter:          cfg.Settings().IdleTimeout,
+		bridges:            NewBridgeSupervisor(bridgesDir, filepath.Join(logsDir, consts.SocketBridgeLogFile), dockerClient, log),
 
 		// Readiness-gate probes default to the real implementations; the
 		// per-stage budgets to the firewall-derived consts. Tests override these
@@ -156,6 +182,21 @@ func NewDaemon(cfg config.Config, log *logger.Logger, opts ...DaemonOption) (*Da
 		opt(d)
 	}
 
+	// Applied after the options: WithDaemonPort replaces the server. The cap
+	// is reported on /metrics; enforcement happens where bytes flow (the
+	// socket bridge daemons read the same setting).
+	d.server.Traffic().SetCap(cfg.HostProxyConfig().ForwardCapBytesPerSec())
+	d.server.SetGitCredentialHosts(cfg.HostProxyConfig().GitCredentialHosts)
+	d.server.SetReportKey(reportKey)
+	d.server.SetClipboard(cfg.HostProxyConfig().Clipboard)
+	d.server.SetOpenURLPolicy(d.openURLPolicy)
+	d.server.SetCallerLookup(d.caller)
+	d.server.SetProjectContainers(d.projectContainers)
+	d.server.SetProjectGitCredentialHosts(d.projectGitCredentialHosts)
+	d.server.SetBridgeSupervisor(d.bridges)
+	d.server.AddReadinessCheck(dockerReadyCheck(dockerClient))
+	d.server.AddReadinessCheck(gpgAgentReadyCheck())
+
 	return d, nil
 }
 
@@ -199,6 +240,13 @@ func (d *Daemon) Run(ctx context.Context) error {
 	// enforcement already fails closed.
 	watcherDone := make(chan struct{})
 	readyErrCh := make(chan error, 1)
+	go d.watchSettings(runCtx)
+	if d.bridges != nil {
+		go d.bridges.Run(runCtx, consts.HostProxyBridgeCheckInterval)
+	}
+	if d.idle != nil {
+		go d.reapIdle(runCtx, consts.HostProxyIdleCheckInterval)
+	}
 	go func() {
 		if err := d.ensureEgressRulesReady(runCtx); err != nil {
 			// A cancelled context means we're already shutting down (signal or
@@ -243,7 +291,9 @@ func (d *Daemon) Run(ctx context.Context) error {
 }
 
 // watchContainers polls Docker for clawker containers and exits when none are found.
-// It also exits if Docker API errors exceed the configured threshold.
+// It also exits if Docker API errors exceed the configured threshold. The
+// poll interval and threshold are re-read after every tick, so a settings.yaml
+// change applies from the next poll on.
 func (d *Daemon) watchContainers(ctx context.Context) {
 	// Initial grace period before first check
 	select {
@@ -252,7 +302,8 @@ func (d *Daemon) watchContainers(ctx context.Context) {
 	case <-time.After(d.gracePeriod):
 	}
 
-	ticker := time.NewTicker(d.pollInterval)
+	interval, _ := d.tuning()
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	consecutiveErrs := 0
@@ -262,12 +313,17 @@ func (d *Daemon) watchContainers(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			next, maxErrs := d.tuning()
+			if next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
 			count, err := d.countClawkerContainers(ctx)
 			if err != nil {
 				consecutiveErrs++
 				d.log.Warn().Err(err).Int("consecutive_errors", consecutiveErrs).Msg("failed to count containers")
-				if consecutiveErrs >= d.maxConsecutiveErrs {
-					d.log.Error().Int("threshold", d.maxConsecutiveErrs).Msg("too many consecutive Docker API errors, initiating shutdown")
+				if consecutiveErrs >= maxErrs {
+					d.log.Error().Int("threshold", maxErrs).Msg("too many consecutive Docker API errors, initiating shutdown")
 					return
 				}
 				continue
@@ -283,6 +339,87 @@ func (d *Daemon) watchContainers(ctx context.Context) {
 	}
 }
 
+func (d *Daemon) tuning() (time.Duration, int) {
+	d.tuningMu.Lock()
+	defer d.tuningMu.Unlock()
+	return d.pollInterval, d.maxConsecutiveErrs
+}
+
+// watchSettings hot-reloads settings.yaml for the daemon's lifetime (see
+// applySettings). A watch that cannot start is logged and the daemon keeps
+// its startup settings.
+func (d *Daemon) watchSettings(ctx context.Context) {
+	if d.cfg == nil {
+		return
+	}
+	store := d.cfg.SettingsStore()
+	err := store.Watch(ctx, func(changes storage.Changes, err error) {
+		if err != nil {
+			d.log.Warn().Err(err).Msg("settings reload failed; keeping previous settings")
+			return
+		}
+		settings := store.Read()
+		d.applySettings(changes, settings.HostProxy)
+		d.applyIdleTimeout(changes, settings.IdleTimeout)
+	})
+	if err != nil {
+		d.log.Warn().Err(err).Msg("settings watch unavailable; changes apply on restart")
+	}
+}
+
+// applySettings applies the host_proxy keys in changes from hp: the forward
+// cap, the git credential host allowlist, the clipboard bridge and the
+// container-watcher knobs take effect live. Ports need a rebind and are only
+// reported; every other key is not the daemon's.
+func (d *Daemon) applySettings(changes storage.Changes, hp config.HostProxyConfig) {
+	if changes.Touches("host_proxy.forward_cap_kibps") {
+		d.server.Traffic().SetCap(hp.ForwardCapBytesPerSec())
+		d.log.Info().Int("forward_cap_kibps", hp.ForwardCapKiBps).Msg("settings reloaded: forward cap updated")
+	}
+	if changes.Touches("host_proxy.git_credential_hosts") {
+		d.server.SetGitCredentialHosts(hp.GitCredentialHosts)
+		d.log.Info().Strs("git_credential_hosts", hp.GitCredentialHosts).Msg("settings reloaded: git credential hosts updated")
+	}
+	if changes.Touches("host_proxy.clipboard") {
+		d.server.SetClipboard(hp.Clipboard)
+		d.log.Info().Bool("enabled", hp.Clipboard.Enabled).Bool("allow_paste", hp.Clipboard.AllowPaste).
+			Int("max_kib", hp.Clipboard.MaxKiB).Msg("settings reloaded: clipboard bridge updated")
+	}
+	if changes.Touches("host_proxy.daemon.poll_interval", "host_proxy.daemon.max_consecutive_errs") {
+		daemonCfg := hp.Daemon
+		if daemonCfg.PollInterval <= 0 || daemonCfg.MaxConsecutiveErrs <= 0 {
+			d.log.Warn().Dur("poll_interval", daemonCfg.PollInterval).Int("max_consecutive_errs", daemonCfg.MaxConsecutiveErrs).
+				Msg("settings reload: invalid container watcher settings ignored")
+		} else {
+			d.tuningMu.Lock()
+			d.pollInterval = daemonCfg.PollInterval
+			d.maxConsecutiveErrs = daemonCfg.MaxConsecutiveErrs
+			d.tuningMu.Unlock()
+			d.log.Info().Dur("poll_interval", daemonCfg.PollInterval).Int("max_consecutive_errs", daemonCfg.MaxConsecutiveErrs).
+				Msg("settings reloaded: container watcher updated")
+		}
+	}
+	if ports := append(changes.Under("host_proxy.daemon.port"), changes.Under("host_proxy.manager.port")...); len(ports) > 0 {
+		d.log.Warn().Strs("keys", ports).Msg("settings reload: port changes take effect after the host proxy restarts")
+	}
+}
+
+// applyIdleTimeout applies a changed idle_timeout to the idle reaper from
+// its next sweep on. A negative value is ignored.
+func (d *Daemon) applyIdleTimeout(changes storage.Changes, timeout time.Duration) {
+	if !changes.Touches("idle_timeout") {
+		return
+	}
+	if timeout < 0 {
+		d.log.Warn().Dur("idle_timeout", timeout).Msg("settings reload: negative idle_timeout ignored")
+		return
+	}
+	d.tuningMu.Lock()
+	d.idleAfter = timeout
+	d.tuningMu.Unlock()
+	d.log.Info().Dur("idle_timeout", timeout).Msg("settings reloaded: idle timeout updated")
+}
+
 // countClawkerContainers returns the number of running agent containers.
 // Filters directly on purpose=agent — every managed container has an
 // explicit purpose label ("agent", "monitoring", "firewall").
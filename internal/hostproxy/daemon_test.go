@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/moby/moby/client"
+	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/storage"
 )
 
 // mockContainerLister implements ContainerLister for testing.
@@ -382,3 +384,62 @@ func TestEnsureEgressRulesReady_ContextCancel(t *testing.T) {
 		t.Fatalf("expected context.Canceled, got %v", err)
 	}
 }
+
+func TestDaemon_ApplySettings(t *testing.T) {
+	d := &Daemon{
+		log:                logger.Nop(),
+		server:             NewServer(0, logger.Nop(), ""),
+		pollInterval:       30 * time.Second,
+		maxConsecutiveErrs: 10,
+	}
+
+	hp := config.HostProxyConfig{
+		ForwardCapKiBps:    64,
+		Daemon:             config.HostProxyDaemonConfig{Port: 19999, PollInterval: 5 * time.Second, MaxConsecutiveErrs: 3},
+		GitCredentialHosts: []string{"github.com"},
+		Clipboard:          config.HostProxyClipboardConfig{Enabled: true},
+	}
+
+	// Only changed keys apply: an unrelated change leaves everything alone.
+	d.applySettings(storage.Changes{"logging.max_size_mb"}, hp)
+	if interval, maxErrs := d.tuning(); interval != 30*time.Second || maxErrs != 10 {
+		t.Errorf("unrelated change altered tuning: interval=%v maxErrs=%d", interval, maxErrs)
+	}
+	if got := d.server.Traffic().Cap(); got != 0 {
+		t.Errorf("unrelated change set forward cap to %d", got)
+	}
+	if len(d.server.credentialHosts) != 0 {
+		t.Error("unrelated change applied the git credential host allowlist")
+	}
+	if d.server.clipboardPolicy().Enabled {
+		t.Error("unrelated change enabled the clipboard bridge")
+	}
+
+	d.applySettings(storage.Changes{
+		"host_proxy.daemon.poll_interval",
+		"host_proxy.daemon.port",
+		"host_proxy.forward_cap_kibps",
+		"host_proxy.git_credential_hosts",
+		"host_proxy.clipboard.enabled",
+	}, hp)
+	if interval, maxErrs := d.tuning(); interval != 5*time.Second || maxErrs != 3 {
+		t.Errorf("tuning = (%v, %d), want (5s, 3)", interval, maxErrs)
+	}
+	if got := d.server.Traffic().Cap(); got != 64*1024 {
+		t.Errorf("forward cap = %d, want %d", got, 64*1024)
+	}
+	if d.server.gitCredentialHostAllowed(context.Background(), "", "gitlab.com") == nil ||
+		d.server.gitCredentialHostAllowed(context.Background(), "", "github.com") != nil {
+		t.Error("git credential host allowlist not applied")
+	}
+	if !d.server.clipboardPolicy().Enabled {
+		t.Error("clipboard bridge not enabled")
+	}
+
+	// Invalid watcher settings are ignored rather than applied.
+	hp.Daemon.PollInterval = 0
+	d.applySettings(storage.Changes{"host_proxy.daemon.poll_interval"}, hp)
+	if interval, _ := d.tuning(); interval != 5*time.Second {
+		t.Errorf("invalid poll interval applied: %v", interval)
+	}
+}
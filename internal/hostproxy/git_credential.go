@@ -3,7 +3,10 @@ package hostproxy
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
 	"os/exec"
 	"strings"
@@ -11,7 +14,7 @@ import (
 	"github.com/schmitthub/clawker/internal/logger"
 )
 
-// gitCredentialRequest is the JSON request body for POST /git/credential.
+// gitCredentialRequest is the JSON request body for POST /git/credentials.
 type gitCredentialRequest struct {
 	Action   string `json:"action"`   // "get", "store", or "erase"
 	Protocol string `json:"protocol"` // "https" typically
@@ -21,7 +24,7 @@ type gitCredentialRequest struct {
 	Password string `json:"password,omitempty"` // Only for store/erase
 }
 
-// gitCredentialResponse is the JSON response body for POST /git/credential.
+// gitCredentialResponse is the JSON response body for POST /git/credentials.
 type gitCredentialResponse struct {
 	Success  bool   `json:"success"`
 	Protocol string `json:"protocol,omitempty"`
@@ -31,9 +34,10 @@ type gitCredentialResponse struct {
 	Error    string `json:"error,omitempty"`
 }
 
-// handleGitCredential handles POST /git/credential requests.
-// It acts as a bridge between the container's git-credential-clawker helper
-// and the host's git credential system.
+// handleGitCredential handles POST /git/credentials (and the legacy
+// /git/credential) requests. It acts as a bridge between the container's
+// git-credential-clawker helper and the host's git credential system, for
+// hosts that pass gitCredentialHostAllowed.
 func (s *Server) handleGitCredential(w http.ResponseWriter, r *http.Request) {
 	// Limit request body size
 	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
@@ -91,6 +95,19 @@ func (s *Server) handleGitCredential(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if err := s.gitCredentialHostAllowed(r.Context(), r.Header.Get(HeaderCallerToken), req.Host); err != nil {
+		s.log.Warn().
+			Err(err).
+			Str("action", req.Action).
+			Str("host", req.Host).
+			Msg("rejected git credential request")
+		s.writeJSON(w, http.StatusForbidden, gitCredentialResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
 	// Log request (without password)
 	s.log.Debug().
 		Str("action", req.Action).
@@ -165,6 +182,84 @@ func (s *Server) handleGitCredential(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GitCredentialHostsFunc resolves the git remote hosts of the project whose
+// running agent container holds the caller token a /git/credentials request
+// carries (see NewCallerToken). It fails when no container holds it.
+type GitCredentialHostsFunc func(ctx context.Context, token string) ([]string, error)
+
+// SetProjectGitCredentialHosts sets how /git/credentials finds the calling
+// container's project git hosts, the allowlist used while
+// host_proxy.git_credential_hosts is empty. Without one, requests are
+// refused unless the settings list allows the host.
+func (s *Server) SetProjectGitCredentialHosts(fn GitCredentialHostsFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projectGitHosts = fn
+}
+
+// gitCredentialHostAllowed reports why the caller holding token may not
+// fetch credentials for host, or nil. A non-empty
+// host_proxy.git_credential_hosts decides alone. Otherwise only the hosts of
+// the calling container's own project git remotes are allowed, so an
+// unidentified caller, or one whose project has no remote, gets nothing:
+// the daemon serves every container, and the host's credential helpers hold
+// tokens for far more than the project at hand.
+func (s *Server) gitCredentialHostAllowed(ctx context.Context, token, host string) error {
+	s.mu.RLock()
+	hosts := s.credentialHosts
+	fn := s.projectGitHosts
+	s.mu.RUnlock()
+	if len(hosts) > 0 {
+		if !matchCredentialHost(hosts, host) {
+			return fmt.Errorf("host %q is not in host_proxy.git_credential_hosts", host)
+		}
+		return nil
+	}
+
+	if fn == nil || token == "" {
+		return errUnidentifiedCaller
+	}
+	projectHosts, err := fn(ctx, token)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("git credential caller lookup failed")
+		return errUnidentifiedCaller
+	}
+	if !matchCredentialHost(projectHosts, host) {
+		return fmt.Errorf("host %q is not a git remote host of this project; add it to host_proxy.git_credential_hosts", host)
+	}
+	return nil
+}
+
+// matchCredentialHost reports whether host (a git credential "host" value,
+// optionally with a :port) matches one of patterns. A pattern is a hostname,
+// which matches on any port, a host:port, which matches only that port, or
+// "*.domain", which matches any subdomain of domain but not domain itself.
+// Matching is case-insensitive.
+func matchCredentialHost(patterns []string, host string) bool {
+	host = strings.ToLower(host)
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSpace(p))
+		candidate := hostname
+		if _, _, err := net.SplitHostPort(p); err == nil {
+			candidate = host
+		}
+		if suffix, ok := strings.CutPrefix(p, "*."); ok {
+			if strings.HasSuffix(candidate, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if candidate == p {
+			return true
+		}
+	}
+	return false
+}
+
 // containsCredentialInjectionChars returns true if s contains characters that
 // could inject additional key=value pairs into the git credential protocol.
 func containsCredentialInjectionChars(s string) bool {
@@ -2,7 +2,9 @@ package hostproxy
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -133,6 +135,106 @@ func TestHandleGitCredential(t *testing.T) {
 	}
 }
 
+func TestHandleGitCredentialHostAllowlist(t *testing.T) {
+	s := &Server{log: logger.Nop()}
+	s.SetGitCredentialHosts([]string{"github.com", "*.example.com"})
+
+	body := `{"action":"get","protocol":"https","host":"evil.test"}`
+	req := httptest.NewRequest(http.MethodPost, "/git/credentials", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	s.handleGitCredential(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", resp.StatusCode)
+	}
+	var result gitCredentialResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if want := `host "evil.test" is not in host_proxy.git_credential_hosts`; result.Error != want {
+		t.Errorf("expected error %q, got %q", want, result.Error)
+	}
+
+	if err := s.gitCredentialHostAllowed(context.Background(), "", "git.example.com"); err != nil {
+		t.Errorf("expected git.example.com to be allowed: %v", err)
+	}
+}
+
+func TestHandleGitCredentialProjectHosts(t *testing.T) {
+	s := &Server{log: logger.Nop()}
+	ctx := context.Background()
+
+	// No settings list and no way to identify the caller: refuse.
+	if err := s.gitCredentialHostAllowed(ctx, "tok", "github.com"); !errors.Is(err, errUnidentifiedCaller) {
+		t.Errorf("unwired lookup: err = %v, want errUnidentifiedCaller", err)
+	}
+
+	s.SetProjectGitCredentialHosts(func(_ context.Context, token string) ([]string, error) {
+		if token != "tok" {
+			return nil, errors.New("no container holds the token")
+		}
+		return []string{"github.com"}, nil
+	})
+	if err := s.gitCredentialHostAllowed(ctx, "tok", "github.com"); err != nil {
+		t.Errorf("project host refused: %v", err)
+	}
+	if err := s.gitCredentialHostAllowed(ctx, "tok", "gitlab.com"); err == nil {
+		t.Error("expected a host outside the project's remotes to be refused")
+	}
+	for _, token := range []string{"", "forged"} {
+		if err := s.gitCredentialHostAllowed(ctx, token, "github.com"); !errors.Is(err, errUnidentifiedCaller) {
+			t.Errorf("token %q: err = %v, want errUnidentifiedCaller", token, err)
+		}
+	}
+
+	// A settings list replaces the project default.
+	s.SetGitCredentialHosts([]string{"gitlab.com"})
+	if err := s.gitCredentialHostAllowed(ctx, "", "gitlab.com"); err != nil {
+		t.Errorf("settings host refused: %v", err)
+	}
+	if err := s.gitCredentialHostAllowed(ctx, "tok", "github.com"); err == nil {
+		t.Error("expected the settings list to override the project hosts")
+	}
+
+	body := `{"action":"get","protocol":"https","host":"evil.test"}`
+	req := httptest.NewRequest(http.MethodPost, "/git/credentials", bytes.NewBufferString(body))
+	req.Header.Set(HeaderCallerToken, "tok")
+	w := httptest.NewRecorder()
+	s.SetGitCredentialHosts(nil)
+	s.handleGitCredential(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", w.Code)
+	}
+}
+
+func TestMatchCredentialHost(t *testing.T) {
+	patterns := []string{"GitHub.com", "*.example.com", "git.corp.test:8443"}
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"github.com", true},
+		{"GITHUB.COM", true},
+		{"github.com:443", true},
+		{"gist.github.com", false},
+		{"git.example.com", true},
+		{"a.b.example.com:8080", true},
+		{"example.com", false},
+		{"notexample.com", false},
+		{"git.corp.test:8443", true},
+		{"git.corp.test", false},
+		{"git.corp.test:9000", false},
+		{"gitlab.com", false},
+	}
+	for _, tt := range tests {
+		if got := matchCredentialHost(patterns, tt.host); got != tt.want {
+			t.Errorf("matchCredentialHost(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
 func TestHandleGitCredentialBodySizeLimit(t *testing.T) {
 	s := &Server{log: logger.Nop()}
 
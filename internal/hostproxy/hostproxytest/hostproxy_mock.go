@@ -70,6 +70,7 @@ func NewMockHostProxy(t *testing.T) *MockHostProxy {
 	mux.HandleFunc("/cb/", m.handleOAuthCallback)
 
 	// Git credential forwarding
+	mux.HandleFunc("/git/credentials", m.handleGitCredential)
 	mux.HandleFunc("/git/credential", m.handleGitCredential)
 
 	m.Server = httptest.NewServer(mux)
@@ -303,7 +304,7 @@ func (m *MockHostProxy) handleOAuthCallback(w http.ResponseWriter, r *http.Reque
 	w.Write([]byte("Callback received. You can close this window."))
 }
 
-// handleGitCredential handles /git/credential requests.
+// handleGitCredential handles /git/credentials (and legacy /git/credential) requests.
 func (m *MockHostProxy) handleGitCredential(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -338,7 +339,7 @@ func (m *MockHostProxy) handleGitCredential(w http.ResponseWriter, r *http.Reque
 
 	m.t.Logf("MockHostProxy: git credential %s for %s://%s", req.Action, req.Protocol, req.Host)
 
-	// For "get" operations, return mock credentials in format expected by git-credential-clawker.sh
+	// For "get" operations, return mock credentials in format expected by clawker-credential-helper
 	if req.Action == "get" {
 		resp := struct {
 			Success  bool   `json:"success"`
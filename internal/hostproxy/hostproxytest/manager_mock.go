@@ -1,6 +1,10 @@
 package hostproxytest
 
-import "github.com/schmitthub/clawker/internal/hostproxy"
+import (
+	"context"
+
+	"github.com/schmitthub/clawker/internal/hostproxy"
+)
 
 // Compile-time interface check.
 var _ hostproxy.Service = (*MockManager)(nil)
@@ -11,6 +15,9 @@ type MockManager struct {
 	EnsureErr error  // Error returned by EnsureRunning
 	Running   bool   // Value returned by IsRunning
 	URL       string // Value returned by ProxyURL
+	ReadyErr  error  // Error returned by WaitReady
+
+	ReadyChecks []string // On-demand checks passed to the last WaitReady
 }
 
 // NewMockManager returns a MockManager that starts not running.
@@ -38,3 +45,7 @@ func (m *MockManager) EnsureRunning() error {
 }
 func (m *MockManager) IsRunning() bool  { return m.Running }
 func (m *MockManager) ProxyURL() string { return m.URL }
+func (m *MockManager) WaitReady(_ context.Context, checks ...string) error {
+	m.ReadyChecks = checks
+	return m.ReadyErr
+}
@@ -0,0 +1,152 @@
+package hostproxy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/moby/moby/client"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// IdleAgent is one agent's activity as the idle reaper sees it.
+type IdleAgent struct {
+	ContainerID string
+	Project     string
+	// Idle is how long the agent has gone without TTY or exec activity,
+	// as of its latest status report.
+	Idle time.Duration
+}
+
+// IdleSource reports agent activity and delivers idle warnings. This
+// package does not import the control plane; `clawker host-proxy serve`
+// wires an implementation backed by the CP admin client.
+type IdleSource interface {
+	// IdleAgents lists every agent that has reported activity.
+	IdleAgents(ctx context.Context) ([]IdleAgent, error)
+	// Warn shows message on the agent's console.
+	Warn(ctx context.Context, agent IdleAgent, message string) error
+}
+
+// ContainerStopper is the Docker call the idle reaper stops agents with.
+type ContainerStopper interface {
+	ContainerStop(ctx context.Context, containerID string, options client.ContainerStopOptions) (client.ContainerStopResult, error)
+}
+
+// WithIdleSource enables the idle reaper with src as its activity source.
+// Without it the daemon never stops idle agents, whatever idle_timeout says.
+func WithIdleSource(src IdleSource) DaemonOption {
+	return func(d *Daemon) {
+		d.idle = src
+	}
+}
+
+// idleTimeout returns the live settings idle_timeout.
+func (d *Daemon) idleTimeout() time.Duration {
+	d.tuningMu.Lock()
+	defer d.tuningMu.Unlock()
+	return d.idleAfter
+}
+
+// reapIdle runs sweepIdle every HostProxyIdleCheckInterval until ctx is
+// done. A sweep with idle_timeout unset is a no-op, so a settings change
+// turns the reaper on or off without a restart.
+func (d *Daemon) reapIdle(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	warned := make(map[string]bool)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.sweepIdle(ctx, warned)
+		}
+	}
+}
+
+// sweepIdle stops every running agent idle for idle_timeout or longer and
+// warns the ones within the warning lead of it. warned holds the agents
+// already warned in their current idle stretch; an agent that becomes
+// active again is dropped from it so its next stretch warns again.
+// Agents labeled keep-alive, and agents not running, are left alone.
+func (d *Daemon) sweepIdle(ctx context.Context, warned map[string]bool) {
+	timeout := d.idleTimeout()
+	if timeout <= 0 || d.idle == nil {
+		clear(warned)
+		return
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, consts.HostProxyIdleCallTimeout)
+	defer cancel()
+	running, err := d.runningAgents(listCtx)
+	if err != nil {
+		d.log.Warn().Err(err).Msg("idle reaper: failed to list agent containers")
+		return
+	}
+	agents, err := d.idle.IdleAgents(listCtx)
+	if err != nil {
+		d.log.Debug().Err(err).Msg("idle reaper: agent activity unavailable")
+		return
+	}
+
+	lead := min(consts.HostProxyIdleWarningLead, timeout/2)
+	for _, a := range agents {
+		keepAlive, ok := running[a.ContainerID]
+		if !ok || keepAlive {
+			delete(warned, a.ContainerID)
+			continue
+		}
+		switch {
+		case a.Idle >= timeout:
+			d.stopIdle(ctx, a, timeout)
+			delete(warned, a.ContainerID)
+		case a.Idle >= timeout-lead:
+			if warned[a.ContainerID] {
+				continue
+			}
+			msg := fmt.Sprintf("clawker: no activity for %s; this agent stops in %s (idle_timeout). Type anything to keep it running.",
+				a.Idle.Round(time.Minute), (timeout - a.Idle).Round(time.Minute))
+			warnCtx, warnCancel := context.WithTimeout(ctx, consts.HostProxyIdleCallTimeout)
+			if err := d.idle.Warn(warnCtx, a, msg); err != nil {
+				d.log.Warn().Err(err).Str("container_id", a.ContainerID).Msg("idle reaper: failed to warn agent")
+			}
+			warnCancel()
+			// Warned once per stretch even when delivery failed: the stop
+			// goes ahead on schedule either way.
+			warned[a.ContainerID] = true
+		default:
+			delete(warned, a.ContainerID)
+		}
+	}
+}
+
+// stopIdle stops one idle agent container.
+func (d *Daemon) stopIdle(ctx context.Context, a IdleAgent, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, consts.HostProxyIdleCallTimeout)
+	defer cancel()
+	if _, err := d.stopper.ContainerStop(ctx, a.ContainerID, client.ContainerStopOptions{}); err != nil {
+		d.log.Warn().Err(err).Str("container_id", a.ContainerID).Msg("idle reaper: failed to stop idle agent")
+		return
+	}
+	d.log.Info().Str("container_id", a.ContainerID).Str("project", a.Project).
+		Dur("idle", a.Idle).Dur("idle_timeout", timeout).Msg("idle reaper: stopped idle agent")
+}
+
+// runningAgents maps each running agent container's ID to whether it
+// carries the keep-alive label.
+func (d *Daemon) runningAgents(ctx context.Context) (map[string]bool, error) {
+	f := client.Filters{}.
+		Add("label", d.cfg.LabelManaged()+"="+d.cfg.ManagedLabelValue()).
+		Add("label", d.cfg.LabelPurpose()+"="+d.cfg.PurposeAgent())
+	result, err := d.docker.ContainerList(ctx, client.ContainerListOptions{Filters: f})
+	if err != nil {
+		return nil, err
+	}
+	running := make(map[string]bool, len(result.Items))
+	for _, c := range result.Items {
+		running[c.ID] = c.Labels[consts.LabelKeepAlive] == "true"
+	}
+	return running, nil
+}
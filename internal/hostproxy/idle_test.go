@@ -0,0 +1,134 @@
+package hostproxy
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/logger"
+	"github.com/schmitthub/clawker/internal/storage"
+)
+
+// fakeAgentLister lists a fixed set of running agent containers.
+type fakeAgentLister struct {
+	items []container.Summary
+}
+
+func (f *fakeAgentLister) ContainerList(context.Context, client.ContainerListOptions) (client.ContainerListResult, error) {
+	return client.ContainerListResult{Items: f.items}, nil
+}
+
+func (f *fakeAgentLister) Close() error { return nil }
+
+// fakeIdleSource reports fixed agent activity and records warnings.
+type fakeIdleSource struct {
+	agents []IdleAgent
+	warned map[string]string
+}
+
+func (f *fakeIdleSource) IdleAgents(context.Context) ([]IdleAgent, error) { return f.agents, nil }
+
+func (f *fakeIdleSource) Warn(_ context.Context, a IdleAgent, message string) error {
+	f.warned[a.ContainerID] = message
+	return nil
+}
+
+// fakeStopper records the containers it was asked to stop.
+type fakeStopper struct {
+	stopped []string
+}
+
+func (f *fakeStopper) ContainerStop(_ context.Context, id string, _ client.ContainerStopOptions) (client.ContainerStopResult, error) {
+	f.stopped = append(f.stopped, id)
+	return client.ContainerStopResult{}, nil
+}
+
+func TestSweepIdle(t *testing.T) {
+	lister := &fakeAgentLister{items: []container.Summary{
+		{ID: "busy"},
+		{ID: "drowsy"},
+		{ID: "asleep"},
+		{ID: "pinned", Labels: map[string]string{consts.LabelKeepAlive: "true"}},
+	}}
+	src := &fakeIdleSource{warned: map[string]string{}, agents: []IdleAgent{
+		{ContainerID: "busy", Idle: time.Minute},
+		{ContainerID: "drowsy", Idle: 57 * time.Minute},
+		{ContainerID: "asleep", Idle: 2 * time.Hour},
+		{ContainerID: "pinned", Idle: 2 * time.Hour},
+		{ContainerID: "gone", Idle: 2 * time.Hour},
+	}}
+	stopper := &fakeStopper{}
+	d := &Daemon{
+		cfg:       configmocks.NewBlankConfig(),
+		log:       logger.Nop(),
+		docker:    lister,
+		stopper:   stopper,
+		idle:      src,
+		idleAfter: time.Hour,
+	}
+	warned := map[string]bool{}
+
+	d.sweepIdle(context.Background(), warned)
+
+	if len(stopper.stopped) != 1 || stopper.stopped[0] != "asleep" {
+		t.Errorf("stopped = %v, want [asleep]: keep-alive and non-running agents are exempt", stopper.stopped)
+	}
+	if len(src.warned) != 1 || !strings.Contains(src.warned["drowsy"], "stops in 3m") {
+		t.Errorf("warned = %v, want only drowsy, 3m ahead of the stop", src.warned)
+	}
+
+	// A second sweep in the same idle stretch does not warn again; activity
+	// ends the stretch, so the next one warns anew.
+	clear(src.warned)
+	d.sweepIdle(context.Background(), warned)
+	if len(src.warned) != 0 {
+		t.Errorf("re-warned within one idle stretch: %v", src.warned)
+	}
+	src.agents[1].Idle = 0
+	d.sweepIdle(context.Background(), warned)
+	src.agents[1].Idle = 56 * time.Minute
+	d.sweepIdle(context.Background(), warned)
+	if _, ok := src.warned["drowsy"]; !ok {
+		t.Error("a new idle stretch was not warned")
+	}
+}
+
+func TestSweepIdle_DisabledWithoutTimeout(t *testing.T) {
+	stopper := &fakeStopper{}
+	d := &Daemon{
+		cfg:     configmocks.NewBlankConfig(),
+		log:     logger.Nop(),
+		docker:  &fakeAgentLister{items: []container.Summary{{ID: "asleep"}}},
+		stopper: stopper,
+		idle:    &fakeIdleSource{agents: []IdleAgent{{ContainerID: "asleep", Idle: 48 * time.Hour}}},
+	}
+
+	d.sweepIdle(context.Background(), map[string]bool{})
+
+	if len(stopper.stopped) != 0 {
+		t.Errorf("stopped %v with idle_timeout unset", stopper.stopped)
+	}
+}
+
+func TestApplyIdleTimeout(t *testing.T) {
+	d := &Daemon{log: logger.Nop()}
+
+	d.applyIdleTimeout(storage.Changes{"host_proxy.forward_cap_kibps"}, time.Hour)
+	if got := d.idleTimeout(); got != 0 {
+		t.Errorf("unrelated change set idle timeout to %v", got)
+	}
+	d.applyIdleTimeout(storage.Changes{"idle_timeout"}, time.Hour)
+	if got := d.idleTimeout(); got != time.Hour {
+		t.Errorf("idle timeout = %v, want 1h", got)
+	}
+	d.applyIdleTimeout(storage.Changes{"idle_timeout"}, -time.Minute)
+	if got := d.idleTimeout(); got != time.Hour {
+		t.Errorf("negative idle timeout applied: %v", got)
+	}
+}
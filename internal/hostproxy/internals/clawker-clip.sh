@@ -0,0 +1,79 @@
+#!/bin/sh
+# clawker-clip - Copy to (or paste from) the host clipboard via the clawker
+# host proxy.
+#
+#   some-command | clawker-clip     copy stdin to the host clipboard
+#   clawker-clip --paste            print the host clipboard
+#
+# Also installed as pbcopy, pbpaste and xclip, so tools that shell out to a
+# clipboard command reach the host's clipboard. The bridge is off unless
+# host_proxy.clipboard.enabled is set in the host's settings.yaml; pasting
+# additionally needs host_proxy.clipboard.allow_paste.
+
+usage() {
+    echo "Usage: clawker-clip [--paste]" >&2
+    echo "Copies stdin to the host clipboard, or prints it with --paste." >&2
+}
+
+mode=copy
+case "$(basename "$0")" in
+    pbpaste)
+        mode=paste
+        ;;
+    xclip)
+        # xclip copies by default and prints the selection with -o/-out.
+        # Other options (-selection, -i, -t, ...) are accepted and ignored:
+        # there is only the one host clipboard.
+        for arg in "$@"; do
+            case "$arg" in
+                -o|-out) mode=paste ;;
+            esac
+        done
+        ;;
+    pbcopy)
+        ;;
+    *)
+        case "$1" in
+            "") ;;
+            -p|--paste) mode=paste ;;
+            -h|--help) usage; exit 0 ;;
+            *) usage; exit 2 ;;
+        esac
+        ;;
+esac
+
+if [ -z "$CLAWKER_HOST_PROXY" ]; then
+    echo "Error: CLAWKER_HOST_PROXY not set" >&2
+    echo "This command requires the clawker host proxy to be running." >&2
+    exit 1
+fi
+
+# Print the error from a host proxy JSON response, or the raw response when
+# it is not JSON. An empty response means the proxy was not reached.
+fail() {
+    if [ -z "$1" ]; then
+        echo "clawker-clip: host proxy unreachable at $CLAWKER_HOST_PROXY" >&2
+        exit 1
+    fi
+    msg=$(printf '%s' "$1" | jq -r '.error // empty' 2>/dev/null)
+    echo "clawker-clip: ${msg:-$1}" >&2
+    exit 1
+}
+
+if [ "$mode" = paste ]; then
+    # Buffer to a file so an error body never reaches stdout and the
+    # clipboard's trailing newlines survive.
+    tmp=$(mktemp) || exit 1
+    trap 'rm -f "$tmp"' EXIT
+    if ! curl -s --fail-with-body -o "$tmp" \
+        -H "X-Clawker-Token: $CLAWKER_HOST_PROXY_TOKEN" "$CLAWKER_HOST_PROXY/clipboard"; then
+        fail "$(cat "$tmp")"
+    fi
+    cat "$tmp"
+    exit 0
+fi
+
+response=$(curl -s --fail-with-body -X POST "$CLAWKER_HOST_PROXY/clipboard" \
+    -H "Content-Type: text/plain; charset=utf-8" \
+    -H "X-Clawker-Token: $CLAWKER_HOST_PROXY_TOKEN" \
+    --data-binary @-) || fail "$response"
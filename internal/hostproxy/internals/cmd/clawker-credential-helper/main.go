@@ -0,0 +1,189 @@
+// TRIPWIRE: stdlib only — compiled standalone in Docker (//go:embed, no go.mod
+// in the build stage). NEVER import clawker-module packages (e.g. internal/consts);
+// it breaks the image build. Inline literals here are intentional, exempt from
+// the no-hardcoded-strings policy. See internal/hostproxy/internals/CLAUDE.md.
+//
+// clawker-credential-helper is the in-container git credential helper for HTTPS
+// remotes. Git runs it (as git-credential-clawker) with the credential protocol
+// on stdin; it forwards the request to the host proxy's /git/credentials
+// endpoint, which answers from the host's own credential helpers, and prints
+// the result back to git.
+//
+// Credentials only ever live in this process's memory: nothing is written to
+// the container's disk, and "store"/"erase" are relayed to the host instead of
+// being kept locally.
+//
+// Usage:
+//
+//	clawker-credential-helper <get|store|erase>
+//
+// Environment variables:
+//
+//	CLAWKER_HOST_PROXY: Host proxy URL (required)
+//	CLAWKER_HOST_PROXY_TOKEN: Caller token identifying this container to the
+//	  host proxy (sent as X-Clawker-Token)
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds a round trip to the host proxy. The host's credential
+// helper may have to unlock a keychain, so this is generous.
+const requestTimeout = 60 * time.Second
+
+// credentialRequest matches gitCredentialRequest in the host proxy.
+type credentialRequest struct {
+	Action   string `json:"action"`
+	Protocol string `json:"protocol"`
+	Host     string `json:"host"`
+	Path     string `json:"path,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// credentialResponse matches gitCredentialResponse in the host proxy.
+type credentialResponse struct {
+	Success  bool   `json:"success"`
+	Protocol string `json:"protocol,omitempty"`
+	Host     string `json:"host,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: clawker-credential-helper <get|store|erase>")
+		os.Exit(1)
+	}
+	if err := run(os.Args[1], os.Getenv("CLAWKER_HOST_PROXY"), os.Getenv("CLAWKER_HOST_PROXY_TOKEN"), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "clawker-credential-helper: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run handles one git credential helper invocation. token identifies the
+// container to the host proxy, which only answers for its project's hosts.
+func run(action, proxyURL, token string, in io.Reader, out io.Writer) error {
+	switch action {
+	case "get", "store", "erase":
+	default:
+		// Git may grow new actions; helpers are expected to ignore them.
+		return nil
+	}
+	if proxyURL == "" {
+		return errors.New("CLAWKER_HOST_PROXY not set")
+	}
+
+	req, err := parseCredential(in)
+	if err != nil {
+		return fmt.Errorf("reading credential request: %w", err)
+	}
+	req.Action = action
+
+	client := &http.Client{Timeout: requestTimeout}
+	resp, err := forward(client, proxyURL, token, req)
+	if err != nil {
+		return err
+	}
+	if action == "get" {
+		return writeCredential(out, resp)
+	}
+	return nil
+}
+
+// parseCredential reads git credential protocol input: key=value lines ending
+// with a blank line or EOF. Unknown keys are ignored.
+func parseCredential(r io.Reader) (credentialRequest, error) {
+	var req credentialRequest
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "protocol":
+			req.Protocol = value
+		case "host":
+			req.Host = value
+		case "path":
+			req.Path = value
+		case "username":
+			req.Username = value
+		case "password":
+			req.Password = value
+		}
+	}
+	return req, scanner.Err()
+}
+
+// forward POSTs the request to the host proxy and decodes its answer.
+func forward(client *http.Client, proxyURL, token string, req credentialRequest) (credentialResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return credentialResponse{}, err
+	}
+	url := strings.TrimRight(proxyURL, "/") + "/git/credentials"
+	httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return credentialResponse{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-Clawker-Token", token)
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return credentialResponse{}, fmt.Errorf("failed to contact host proxy: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp credentialResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return credentialResponse{}, fmt.Errorf("invalid response from host proxy (status %d)", httpResp.StatusCode)
+	}
+	if httpResp.StatusCode >= 400 || !resp.Success {
+		if resp.Error == "" {
+			resp.Error = fmt.Sprintf("request failed with status %d", httpResp.StatusCode)
+		}
+		return credentialResponse{}, errors.New(resp.Error)
+	}
+	return resp, nil
+}
+
+// writeCredential prints the non-empty fields of resp in git credential
+// protocol format.
+func writeCredential(w io.Writer, resp credentialResponse) error {
+	var sb strings.Builder
+	for _, kv := range [][2]string{
+		{"protocol", resp.Protocol},
+		{"host", resp.Host},
+		{"username", resp.Username},
+		{"password", resp.Password},
+	} {
+		if kv[1] == "" {
+			continue
+		}
+		// The host proxy rejects these on the way in; never let one through
+		// on the way out either, where it would inject extra attributes.
+		if strings.ContainsAny(kv[1], "\n\r\x00") {
+			return fmt.Errorf("host proxy returned an invalid %s", kv[0])
+		}
+		sb.WriteString(kv[0] + "=" + kv[1] + "\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseCredential(t *testing.T) {
+	in := "protocol=https\nhost=github.com\npath=org/repo.git\nusername=bob\nwwwauth[]=Basic\n\nhost=ignored.example\n"
+	req, err := parseCredential(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("parseCredential: %v", err)
+	}
+	want := credentialRequest{Protocol: "https", Host: "github.com", Path: "org/repo.git", Username: "bob"}
+	if req != want {
+		t.Fatalf("parseCredential = %+v, want %+v", req, want)
+	}
+}
+
+func TestRunGet(t *testing.T) {
+	var got credentialRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/git/credentials" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if tok := r.Header.Get("X-Clawker-Token"); tok != "tok" {
+			t.Errorf("caller token = %q, want %q", tok, "tok")
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_ = json.NewEncoder(w).Encode(credentialResponse{
+			Success: true, Protocol: "https", Host: "github.com", Username: "bob", Password: "s3cret",
+		})
+	}))
+	defer srv.Close()
+
+	var out strings.Builder
+	if err := run("get", srv.URL+"/", "tok", strings.NewReader("protocol=https\nhost=github.com\n\n"), &out); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if got.Action != "get" || got.Host != "github.com" {
+		t.Fatalf("forwarded %+v", got)
+	}
+	if want := "protocol=https\nhost=github.com\nusername=bob\npassword=s3cret\n"; out.String() != want {
+		t.Fatalf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestRunDenied(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(credentialResponse{Error: `host "evil.example" is not allowed`})
+	}))
+	defer srv.Close()
+
+	var out strings.Builder
+	err := run("get", srv.URL, "tok", strings.NewReader("protocol=https\nhost=evil.example\n\n"), &out)
+	if err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Fatalf("run err = %v, want host denial", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("denied request printed %q", out.String())
+	}
+}
+
+func TestRunStoreRelaysToHost(t *testing.T) {
+	var got credentialRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_ = json.NewEncoder(w).Encode(credentialResponse{Success: true})
+	}))
+	defer srv.Close()
+
+	var out strings.Builder
+	in := "protocol=https\nhost=github.com\nusername=bob\npassword=s3cret\n\n"
+	if err := run("store", srv.URL, "tok", strings.NewReader(in), &out); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if got.Action != "store" || got.Password != "s3cret" {
+		t.Fatalf("forwarded %+v", got)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("store printed %q", out.String())
+	}
+}
+
+func TestRunIgnoresUnknownAction(t *testing.T) {
+	if err := run("capability", "", "tok", strings.NewReader(""), &strings.Builder{}); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+}
+
+func TestWriteCredentialRejectsInjection(t *testing.T) {
+	err := writeCredential(&strings.Builder{}, credentialResponse{Password: "x\nhost=evil"})
+	if err == nil {
+		t.Fatal("expected an error for a password containing a newline")
+	}
+}
@@ -12,11 +12,12 @@ import _ "embed"
 //go:embed host-open.sh
 var HostOpenScript string
 
-// GitCredentialScript is a git credential helper that forwards credential
-// requests to the host proxy for HTTPS git authentication.
+// ClipboardScript is the clawker-clip shell script, also installed as
+// pbcopy, pbpaste and xclip. It copies stdin to (or prints) the host
+// clipboard via the host proxy's /clipboard endpoints.
 //
-//go:embed git-credential-clawker.sh
-var GitCredentialScript string
+//go:embed clawker-clip.sh
+var ClipboardScript string
 
 // CallbackForwarderSource is the Go source for the callback-forwarder binary.
 // It polls the host proxy for captured OAuth callbacks and forwards them
@@ -33,3 +34,11 @@ var CallbackForwarderSource string
 //
 //go:embed cmd/clawker-socket-server/main.go
 var SocketForwarderSource string
+
+// CredentialHelperSource is the Go source for the clawker-credential-helper
+// binary, the git credential helper that forwards HTTPS credential requests
+// to the host proxy. Installed as git-credential-clawker.
+// Compiled during Docker image build via multi-stage Dockerfile.
+//
+//go:embed cmd/clawker-credential-helper/main.go
+var CredentialHelperSource string
@@ -1,7 +1,8 @@
 #!/bin/sh
 # host-open - Open URLs via clawker host proxy
-# This script is used as the BROWSER environment variable so CLI tools
-# (like Claude Code) can open URLs on the host machine.
+# This script is used as the BROWSER environment variable, and installed as
+# xdg-open and open, so CLI tools (like Claude Code) open URLs on the host
+# machine.
 #
 # For OAuth flows, it automatically:
 # 1. Detects localhost callback URLs in the request
@@ -11,7 +12,7 @@
 
 URL="$1"
 if [ -z "$URL" ]; then
-    echo "Usage: host-open <url>" >&2
+    echo "Usage: $(basename "$0") <url>" >&2
     exit 1
 fi
 
@@ -98,7 +99,7 @@ register_callback() {
 
     local response=$(curl -sf -X POST "$CLAWKER_HOST_PROXY/callback/register" \
         -H "Content-Type: application/json" \
-        -d "{\"port\": $port, \"path\": \"$path\", \"timeout_seconds\": 300}" 2>&1)
+        -d "{\"port\": $port, \"path\": \"$path\", \"timeout_seconds\": 300, \"container\": \"$(hostname)\"}" 2>&1)
 
     if [ $? -ne 0 ]; then
         echo ""
@@ -180,31 +181,38 @@ main() {
     return $?
 }
 
-# Open URL via host proxy
+# Open URL via host proxy. The container's host proxy token identifies it,
+# so the host proxy applies its project's security.open_url allowlist; a
+# request without one is refused.
 open_url() {
     local url="$1"
-
-    # Escape the URL for JSON (handle quotes and backslashes)
-    local escaped_url=$(printf '%s' "$url" | sed 's/\\/\\\\/g; s/"/\\"/g')
-
-    # Send request to host proxy to open the URL
-    local response=$(curl -sf -X POST "$CLAWKER_HOST_PROXY/open/url" \
-        -H "Content-Type: application/json" \
-        -d "{\"url\": \"$escaped_url\"}" 2>&1)
-
-    if [ $? -ne 0 ]; then
-        echo "Failed to open URL via host proxy" >&2
-        echo "Response: $response" >&2
-        return 1
+    local body
+    body=$(jq -nc --arg url "$url" '{url: $url}')
+
+    local tmp
+    tmp=$(mktemp) || return 1
+    local status
+    status=$(curl -s -o "$tmp" -w '%{http_code}' -X POST "$CLAWKER_HOST_PROXY/open-url" \
+        -H "Content-Type: application/json" -H "X-Clawker-Token: $CLAWKER_HOST_PROXY_TOKEN" -d "$body")
+    if [ "$status" = "404" ]; then
+        # Host proxy from before /open-url.
+        status=$(curl -s -o "$tmp" -w '%{http_code}' -X POST "$CLAWKER_HOST_PROXY/open/url" \
+            -H "Content-Type: application/json" -H "X-Clawker-Token: $CLAWKER_HOST_PROXY_TOKEN" -d "$body")
     fi
 
-    # Check if the response indicates success
-    if echo "$response" | grep -q '"success":true'; then
+    local response
+    response=$(cat "$tmp")
+    rm -f "$tmp"
+    if [ "$status" = "200" ] && echo "$response" | grep -q '"success":true'; then
         return 0
-    else
-        echo "Failed to open URL: $response" >&2
-        return 1
     fi
+    local error
+    error=$(printf '%s' "$response" | jq -r '.error // empty' 2>/dev/null)
+    if [ -z "$error" ] && [ "$status" = "000" ]; then
+        error="host proxy unreachable at $CLAWKER_HOST_PROXY"
+    fi
+    echo "Failed to open URL: ${error:-$response}" >&2
+    return 1
 }
 
 main
@@ -3,6 +3,7 @@ package hostproxy
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -34,6 +35,9 @@ type Service interface {
 	IsRunning() bool
 	// ProxyURL returns the URL containers should use to reach the host proxy.
 	ProxyURL() string
+	// WaitReady waits until /readyz passes, running the named on-demand
+	// checks (e.g. ReadyCheckGPGAgent) on top of the default ones.
+	WaitReady(ctx context.Context, checks ...string) error
 }
 
 // validatePort checks that a port number is in the valid TCP range.
@@ -132,6 +136,67 @@ func (m *Manager) ProxyURL() string {
 	return u.String()
 }
 
+// WaitReady polls the daemon's /readyz until it passes or ctx ends, in which
+// case the last failing checks are returned. A daemon predating /readyz
+// (404) counts as ready; it is replaced on its next restart.
+func (m *Manager) WaitReady(ctx context.Context, checks ...string) error {
+	q := url.Values{"check": checks}
+	readyURL := fmt.Sprintf(schemeHTTP+"://"+consts.Localhost+":%d/readyz?%s", m.port, q.Encode())
+	ticker := time.NewTicker(consts.HostProxyReadyzPollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		report, err := m.readyz(ctx, readyURL)
+		switch {
+		case errors.Is(err, errReadyzUnsupported):
+			m.log.Debug().Msg("host proxy daemon has no /readyz; skipping readiness gate")
+			return nil
+		case err != nil:
+			lastErr = err
+		case report.Ready:
+			return nil
+		default:
+			lastErr = fmt.Errorf("host proxy not ready: %s", report.Failed())
+		}
+
+		select {
+		case <-ctx.Done():
+			return lastErr
+		case <-ticker.C:
+		}
+	}
+}
+
+// errReadyzUnsupported reports a daemon without the /readyz endpoint.
+var errReadyzUnsupported = errors.New("host proxy has no /readyz endpoint")
+
+// readyz fetches one readiness report.
+func (m *Manager) readyz(ctx context.Context, readyURL string) (*ReadinessReport, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, readyURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build readiness request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("host proxy unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusServiceUnavailable:
+	case http.StatusNotFound:
+		return nil, errReadyzUnsupported
+	default:
+		return nil, fmt.Errorf("host proxy readiness returned %s", resp.Status)
+	}
+	var report ReadinessReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("decoding readiness report: %w", err)
+	}
+	return &report, nil
+}
+
 // isDaemonRunning checks if the daemon is running via PID file and health check.
 func (m *Manager) isDaemonRunning() bool {
 	if m.cfg == nil {
@@ -0,0 +1,125 @@
+package hostproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// neverOpenSchemes are refused whatever a project allows: they reach the
+// host's filesystem or run script instead of loading a page.
+var neverOpenSchemes = []string{"file", "javascript", "data", "vbscript"}
+
+// OpenURLPolicy is the URL allowlist of the container asking to open a URL,
+// from its project's security.open_url.
+type OpenURLPolicy struct {
+	Schemes []string // empty = http and https
+	Hosts   []string // hosts http(s) URLs may point at; empty = any
+}
+
+// OpenURLPolicyFunc resolves the policy of the running agent container
+// holding the caller token an /open-url request carries (see
+// NewCallerToken). It fails when no container holds it.
+type OpenURLPolicyFunc func(ctx context.Context, token string) (OpenURLPolicy, error)
+
+// OpenURLPolicyFromLabels reads the policy stamped on a container by
+// consts.LabelOpenURLSchemes and consts.LabelOpenURLHosts.
+func OpenURLPolicyFromLabels(labels map[string]string) OpenURLPolicy {
+	return OpenURLPolicy{
+		Schemes: splitLabelList(labels[consts.LabelOpenURLSchemes]),
+		Hosts:   splitLabelList(labels[consts.LabelOpenURLHosts]),
+	}
+}
+
+func splitLabelList(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// SetOpenURLPolicy sets how /open-url finds the calling container's policy.
+// Without one every request is refused.
+func (s *Server) SetOpenURLPolicy(fn OpenURLPolicyFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.openURLPolicy = fn
+}
+
+// openURLPolicyFor returns the policy of the container holding token.
+// Unidentified callers are refused with errUnidentifiedCaller: the policy
+// narrows what a project's agent may open, so a caller that could pick its
+// identity could pick the loosest policy on the host.
+func (s *Server) openURLPolicyFor(ctx context.Context, token string) (OpenURLPolicy, error) {
+	s.mu.RLock()
+	fn := s.openURLPolicy
+	s.mu.RUnlock()
+	if fn == nil || token == "" {
+		return OpenURLPolicy{}, errUnidentifiedCaller
+	}
+	policy, err := fn(ctx, token)
+	if err != nil {
+		s.log.Debug().Err(err).Msg("open-url caller lookup failed")
+		return OpenURLPolicy{}, errUnidentifiedCaller
+	}
+	return policy, nil
+}
+
+// checkScheme reports why u's scheme may not be opened, or nil.
+func (p OpenURLPolicy) checkScheme(u *url.URL) error {
+	scheme := strings.ToLower(u.Scheme)
+	if len(p.Schemes) == 0 {
+		if !isWebScheme(scheme) {
+			return errors.New("only http and https URLs are allowed")
+		}
+		return nil
+	}
+	allowed := slices.ContainsFunc(p.Schemes, func(s string) bool {
+		return strings.EqualFold(strings.TrimSuffix(s, ":"), scheme)
+	})
+	if !allowed || slices.Contains(neverOpenSchemes, scheme) {
+		return fmt.Errorf("URL scheme %q is not allowed for this project (security.open_url.schemes)", scheme)
+	}
+	return nil
+}
+
+// checkHost reports why u's host may not be opened, or nil. Hosts apply to
+// http(s) URLs only; other allowed schemes go to the host's handler as-is.
+func (p OpenURLPolicy) checkHost(u *url.URL) error {
+	if !isWebScheme(u.Scheme) || len(p.Hosts) == 0 || matchCredentialHost(p.Hosts, u.Host) {
+		return nil
+	}
+	return fmt.Errorf("URL host %q is not allowed for this project (security.open_url.hosts)", u.Hostname())
+}
+
+func isWebScheme(scheme string) bool {
+	return strings.EqualFold(scheme, "http") || strings.EqualFold(scheme, "https")
+}
+
+// openURLPolicy is the daemon's OpenURLPolicyFunc: it reads the policy off
+// the labels of the running agent container labelled with token's digest.
+func (d *Daemon) openURLPolicy(ctx context.Context, token string) (OpenURLPolicy, error) {
+	labels, err := d.callerLabels(ctx, token)
+	if err != nil {
+		return OpenURLPolicy{}, err
+	}
+	return OpenURLPolicyFromLabels(labels), nil
+}
+
+// projectGitCredentialHosts is the daemon's GitCredentialHostsFunc: it reads
+// consts.LabelGitCredentialHosts off the calling container.
+func (d *Daemon) projectGitCredentialHosts(ctx context.Context, token string) ([]string, error) {
+	labels, err := d.callerLabels(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return splitLabelList(labels[consts.LabelGitCredentialHosts]), nil
+}
@@ -0,0 +1,179 @@
+package hostproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// testCallerToken is the caller token defaultCaller recognizes.
+const testCallerToken = "test-token"
+
+// defaultCaller resolves testCallerToken to the default policy and refuses
+// any other token.
+func defaultCaller(_ context.Context, token string) (OpenURLPolicy, error) {
+	if token != testCallerToken {
+		return OpenURLPolicy{}, errors.New("no such container")
+	}
+	return OpenURLPolicy{}, nil
+}
+
+func TestServerOpenURL_Policy(t *testing.T) {
+	policy := OpenURLPolicy{
+		Schemes: []string{"https", "vscode", "file"},
+		Hosts:   []string{"github.com", "*.example.test"},
+	}
+	tests := []struct {
+		name        string
+		token       string
+		url         string
+		wantStatus  int
+		wantBrowser bool
+	}{
+		{name: "allowed host", token: "abc123", url: "https://github.com/org/repo", wantStatus: http.StatusOK, wantBrowser: true},
+		{name: "allowed subdomain", token: "abc123", url: "https://docs.example.test/", wantStatus: http.StatusOK, wantBrowser: true},
+		{name: "host outside the allowlist", token: "abc123", url: "https://evil.test/", wantStatus: http.StatusForbidden},
+		{name: "scheme outside the allowlist", token: "abc123", url: "http://github.com/", wantStatus: http.StatusBadRequest},
+		{name: "extra scheme skips hosts", token: "abc123", url: "vscode://file/home/user/x.go", wantStatus: http.StatusOK, wantBrowser: true},
+		{name: "file is never opened", token: "abc123", url: "file:///etc/passwd", wantStatus: http.StatusBadRequest},
+		{name: "no token is refused", url: "http://anywhere.test/", wantStatus: http.StatusForbidden},
+		{name: "unknown token is refused", token: "gone", url: "http://anywhere.test/", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			browserCalled := false
+			s := &Server{
+				log:         logger.Nop(),
+				browserFunc: func(string) error { browserCalled = true; return nil },
+			}
+			s.SetOpenURLPolicy(func(_ context.Context, token string) (OpenURLPolicy, error) {
+				if token != "abc123" {
+					return OpenURLPolicy{}, errors.New("no such container")
+				}
+				return policy, nil
+			})
+
+			body, _ := json.Marshal(openURLRequest{URL: tt.url})
+			req := httptest.NewRequest(http.MethodPost, "/open-url", bytes.NewReader(body))
+			if tt.token != "" {
+				req.Header.Set(HeaderCallerToken, tt.token)
+			}
+			w := httptest.NewRecorder()
+			s.handleOpenURL(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if browserCalled != tt.wantBrowser {
+				t.Errorf("browserCalled = %v, want %v", browserCalled, tt.wantBrowser)
+			}
+		})
+	}
+}
+
+func TestServerOpenURL_NoResolverRefuses(t *testing.T) {
+	s := &Server{
+		log:         logger.Nop(),
+		browserFunc: func(string) error { t.Fatal("browser should not be called"); return nil },
+	}
+	req := httptest.NewRequest(http.MethodPost, "/open-url", bytes.NewBufferString(`{"url":"https://github.com/"}`))
+	req.Header.Set(HeaderCallerToken, testCallerToken)
+	w := httptest.NewRecorder()
+	s.handleOpenURL(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403 (%s)", w.Code, w.Body.String())
+	}
+}
+
+func TestDaemonOpenURLPolicy(t *testing.T) {
+	token, digest, err := NewCallerToken()
+	if err != nil {
+		t.Fatalf("NewCallerToken: %v", err)
+	}
+	if token == digest || digest != CallerTokenDigest(token) {
+		t.Fatalf("digest %q does not match token", digest)
+	}
+	lister := &filteringAgentLister{items: []container.Summary{{
+		ID: "abc123",
+		Labels: map[string]string{
+			consts.LabelHostProxyToken: digest,
+			consts.LabelOpenURLSchemes: "https, vscode",
+			consts.LabelOpenURLHosts:   "github.com",
+		},
+	}}}
+	d := &Daemon{cfg: configmocks.NewBlankConfig(), log: logger.Nop(), docker: lister}
+
+	got, err := d.openURLPolicy(context.Background(), token)
+	if err != nil {
+		t.Fatalf("openURLPolicy: %v", err)
+	}
+	if len(got.Schemes) != 2 || got.Schemes[1] != "vscode" || len(got.Hosts) != 1 || got.Hosts[0] != "github.com" {
+		t.Errorf("policy = %+v, want schemes [https vscode], hosts [github.com]", got)
+	}
+
+	if _, err := d.openURLPolicy(context.Background(), "abc123"); err == nil {
+		t.Error("expected an error for a container ID instead of its token")
+	}
+}
+
+func TestDaemonCaller(t *testing.T) {
+	token, digest, err := NewCallerToken()
+	if err != nil {
+		t.Fatalf("NewCallerToken: %v", err)
+	}
+	lister := &filteringAgentLister{items: []container.Summary{{
+		ID:     "abc123",
+		Labels: map[string]string{consts.LabelHostProxyToken: digest, consts.LabelProject: "app"},
+	}}}
+	d := &Daemon{cfg: configmocks.NewBlankConfig(), log: logger.Nop(), docker: lister}
+
+	got, err := d.caller(context.Background(), token)
+	if err != nil {
+		t.Fatalf("caller: %v", err)
+	}
+	if got != (Caller{ContainerID: "abc123", Project: "app"}) {
+		t.Errorf("caller = %+v, want abc123 in app", got)
+	}
+	if _, err := d.caller(context.Background(), "forged"); err == nil {
+		t.Error("expected an error for a token no container holds")
+	}
+}
+
+// filteringAgentLister applies the LabelHostProxyToken label filter, as the
+// Docker daemon would.
+type filteringAgentLister struct {
+	items []container.Summary
+}
+
+func (f *filteringAgentLister) ContainerList(_ context.Context, opts client.ContainerListOptions) (client.ContainerListResult, error) {
+	var out []container.Summary
+	for _, c := range f.items {
+		match := true
+		for kv := range opts.Filters["label"] {
+			k, v, _ := strings.Cut(kv, "=")
+			if k == consts.LabelHostProxyToken && c.Labels[k] != v {
+				match = false
+			}
+		}
+		if match {
+			out = append(out, c)
+		}
+	}
+	return client.ContainerListResult{Items: out}, nil
+}
+
+func (f *filteringAgentLister) Close() error { return nil }
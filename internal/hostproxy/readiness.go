@@ -0,0 +1,187 @@
+package hostproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/moby/moby/client"
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// Readiness check names reported by /readyz and accepted by its ?check= query.
+const (
+	ReadyCheckDocker    = "docker"
+	ReadyCheckCallbacks = "callbacks"
+	ReadyCheckGPGAgent  = "gpg-agent"
+)
+
+// ReadinessCheck is one dependency probed by GET /readyz.
+type ReadinessCheck struct {
+	Name string
+	// OnDemand checks run only when the caller names them with ?check=, for
+	// dependencies of features only some projects use (GPG forwarding).
+	OnDemand bool
+	Run      func(ctx context.Context) error
+}
+
+// CheckResult is the outcome of one readiness check.
+type CheckResult struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// ReadinessReport is the GET /readyz response body. The status code is 200
+// when Ready and 503 otherwise.
+type ReadinessReport struct {
+	Ready  bool          `json:"ready"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// Failed returns the names and errors of the failed checks, for messages.
+func (r *ReadinessReport) Failed() string {
+	var parts []string
+	for _, c := range r.Checks {
+		if !c.OK {
+			parts = append(parts, c.Name+": "+c.Error)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// AddReadinessCheck registers a check with /readyz. A check with the name of
+// an existing one replaces it.
+func (s *Server) AddReadinessCheck(check ReadinessCheck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readiness = slices.DeleteFunc(s.readiness, func(c ReadinessCheck) bool { return c.Name == check.Name })
+	s.readiness = append(s.readiness, check)
+}
+
+// handleReadyz handles GET /readyz. Every check not marked OnDemand runs, plus
+// the OnDemand ones named by ?check=. A requested name no check answers to
+// fails the report, so a caller never mistakes an unsupported check for a
+// passing one.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	requested := r.URL.Query()["check"]
+
+	s.mu.RLock()
+	checks := slices.Clone(s.readiness)
+	s.mu.RUnlock()
+
+	report := ReadinessReport{Ready: true, Checks: []CheckResult{}}
+	for _, check := range checks {
+		if check.OnDemand && !slices.Contains(requested, check.Name) {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), consts.HostProxyReadyzCheckTimeout)
+		err := check.Run(ctx)
+		cancel()
+		result := CheckResult{Name: check.Name, OK: err == nil}
+		if err != nil {
+			result.Error = err.Error()
+			report.Ready = false
+		}
+		report.Checks = append(report.Checks, result)
+	}
+	for _, name := range requested {
+		if !slices.ContainsFunc(checks, func(c ReadinessCheck) bool { return c.Name == name }) {
+			report.Ready = false
+			report.Checks = append(report.Checks, CheckResult{Name: name, Error: "unknown check"})
+		}
+	}
+
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	s.writeJSON(w, status, report)
+}
+
+// checkCallbacks verifies the callback session store agrees with the dynamic
+// listeners: every live callback session owns a listener on its port, and
+// every listener belongs to a live session. A mismatch means OAuth callbacks
+// would be captured by the wrong session or lost.
+func (s *Server) checkCallbacks(context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for port, dl := range s.dynamicListeners {
+		if s.portToSession[port] != dl.sessionID {
+			return fmt.Errorf("listener on port %d not mapped to its session", port)
+		}
+		session := s.sessionStore.Get(dl.sessionID)
+		if session == nil {
+			// Expired sessions are reaped asynchronously; their listener
+			// closes on delete.
+			continue
+		}
+		if session.Type != CallbackSessionType {
+			return fmt.Errorf("listener on port %d owned by a %s session", port, session.Type)
+		}
+		if p, ok := session.GetMetadata(metadataPort); !ok || p != port {
+			return fmt.Errorf("callback session for port %d has port metadata %v", port, p)
+		}
+	}
+	for port, id := range s.portToSession {
+		if _, ok := s.dynamicListeners[port]; !ok {
+			return fmt.Errorf("session %s mapped to port %d without a listener", id, port)
+		}
+	}
+	return nil
+}
+
+// dockerReadyCheck returns a check that the Docker daemon answers.
+func dockerReadyCheck(docker ContainerLister) ReadinessCheck {
+	return ReadinessCheck{
+		Name: ReadyCheckDocker,
+		Run: func(ctx context.Context) error {
+			if _, err := docker.ContainerList(ctx, client.ContainerListOptions{Limit: 1}); err != nil {
+				return fmt.Errorf("docker daemon unreachable: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// gpgAgentReadyCheck returns a check that the host gpg-agent accepts
+// connections on the extra socket the socket bridge forwards.
+func gpgAgentReadyCheck() ReadinessCheck {
+	return ReadinessCheck{
+		Name:     ReadyCheckGPGAgent,
+		OnDemand: true,
+		Run:      CheckGPGAgent,
+	}
+}
+
+// CheckGPGAgent dials the gpg-agent extra socket the socket bridge forwards.
+// Shared with `clawker doctor`.
+func CheckGPGAgent(ctx context.Context) error {
+	output, err := exec.CommandContext(ctx, "gpgconf", "--list-dir", "agent-extra-socket").Output()
+	if err != nil {
+		return fmt.Errorf("gpgconf failed: %w", err)
+	}
+	path := strings.TrimSpace(string(output))
+	if path == "" {
+		return errors.New("gpgconf returned empty socket path")
+	}
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("gpg-agent extra socket not found at %s — is gpg-agent running? try: gpgconf --launch gpg-agent", path)
+		}
+		return fmt.Errorf("cannot access gpg-agent extra socket at %s: %w", path, err)
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", path)
+	if err != nil {
+		return fmt.Errorf("gpg-agent not accepting connections at %s: %w", path, err)
+	}
+	return conn.Close()
+}
@@ -0,0 +1,80 @@
+package hostproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+func readyz(t *testing.T, s *Server, target string) (int, ReadinessReport) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	s.handleReadyz(w, httptest.NewRequest(http.MethodGet, target, nil))
+	var report ReadinessReport
+	if err := json.NewDecoder(w.Result().Body).Decode(&report); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return w.Code, report
+}
+
+func TestServerReadyz_DefaultChecksPass(t *testing.T) {
+	s := NewServer(0, logger.Nop(), "")
+	defer s.sessionStore.Stop()
+	s.AddReadinessCheck(ReadinessCheck{Name: "ok", Run: func(context.Context) error { return nil }})
+	s.AddReadinessCheck(ReadinessCheck{Name: "later", OnDemand: true, Run: func(context.Context) error {
+		t.Error("on-demand check ran without being requested")
+		return nil
+	}})
+
+	code, report := readyz(t, s, "/readyz")
+	if code != http.StatusOK || !report.Ready {
+		t.Fatalf("code = %d, report = %+v, want ready", code, report)
+	}
+	var names []string
+	for _, c := range report.Checks {
+		names = append(names, c.Name)
+	}
+	if got := strings.Join(names, ","); got != ReadyCheckCallbacks+",ok" {
+		t.Errorf("checks = %s", got)
+	}
+}
+
+func TestServerReadyz_FailingAndRequestedChecks(t *testing.T) {
+	s := NewServer(0, logger.Nop(), "")
+	defer s.sessionStore.Stop()
+	s.AddReadinessCheck(ReadinessCheck{Name: "gpg", OnDemand: true, Run: func(context.Context) error {
+		return errors.New("no agent")
+	}})
+
+	code, report := readyz(t, s, "/readyz?check=gpg")
+	if code != http.StatusServiceUnavailable || report.Ready {
+		t.Fatalf("code = %d, report = %+v, want not ready", code, report)
+	}
+	if got := report.Failed(); got != "gpg: no agent" {
+		t.Errorf("Failed() = %q", got)
+	}
+
+	code, report = readyz(t, s, "/readyz?check=bogus")
+	if code != http.StatusServiceUnavailable || report.Failed() != "bogus: unknown check" {
+		t.Errorf("code = %d, failed = %q, want unknown check", code, report.Failed())
+	}
+}
+
+func TestServerCheckCallbacks(t *testing.T) {
+	s := NewServer(0, logger.Nop(), "")
+	defer s.sessionStore.Stop()
+	if err := s.checkCallbacks(context.Background()); err != nil {
+		t.Fatalf("empty store: %v", err)
+	}
+
+	s.portToSession[4242] = "orphan"
+	if err := s.checkCallbacks(context.Background()); err == nil {
+		t.Error("expected error for session mapped without a listener")
+	}
+}
@@ -0,0 +1,230 @@
+package hostproxy
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// Headers a socket bridge daemon sends with POST /traffic/report.
+const (
+	// HeaderBridgeContainer names the container the reporting bridge serves.
+	HeaderBridgeContainer = "X-Clawker-Bridge"
+	// HeaderBridgeToken carries BridgeReportToken for that container.
+	HeaderBridgeToken = "X-Clawker-Bridge-Token"
+)
+
+// HeaderHostToken carries HostReadToken: host-side clawker commands send it
+// to read /metrics, /traffic and /bridges for every container.
+const HeaderHostToken = "X-Clawker-Host-Token"
+
+// hostReadSubject is signed for HostReadToken. A container ID never holds a
+// colon, so no bridge's report token equals it.
+const hostReadSubject = "host:read"
+
+// reportKeyBytes is the entropy of a generated report key.
+const reportKeyBytes = 32
+
+// errUnauthorizedReport is returned when a traffic report carries no valid
+// bridge token.
+var errUnauthorizedReport = errors.New("traffic report is not from a clawker socket bridge")
+
+// LoadOrCreateReportKey returns the traffic report key stored at path,
+// generating and writing a new one (mode 0600) when the file does not
+// exist. The file lives in the host's bridges directory, which no agent
+// container mounts, so agents cannot sign traffic reports. The host proxy
+// and the first bridge daemon may race to create it; the loser reads the
+// winner's key.
+func LoadOrCreateReportKey(path string) ([]byte, error) {
+	for {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			key := strings.TrimSpace(string(data))
+			if key == "" {
+				return nil, fmt.Errorf("traffic report key file %s is empty; delete it to generate a new key", path)
+			}
+			return []byte(key), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("reading traffic report key: %w", err)
+		}
+
+		buf := make([]byte, reportKeyBytes)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("generating traffic report key: %w", err)
+		}
+		key := hex.EncodeToString(buf)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return nil, fmt.Errorf("creating traffic report key directory: %w", err)
+		}
+		// Write the key aside and link it into place: the link fails if the
+		// file already exists, and a reader never sees it half-written.
+		f, err := os.CreateTemp(filepath.Dir(path), ".report-key-*")
+		if err != nil {
+			return nil, fmt.Errorf("writing traffic report key: %w", err)
+		}
+		_, werr := f.WriteString(key + "\n")
+		if cerr := f.Close(); werr == nil {
+			werr = cerr
+		}
+		if werr == nil {
+			werr = os.Link(f.Name(), path)
+		}
+		_ = os.Remove(f.Name())
+		if errors.Is(werr, os.ErrExist) {
+			continue
+		}
+		if werr != nil {
+			return nil, fmt.Errorf("writing traffic report key: %w", werr)
+		}
+		return []byte(key), nil
+	}
+}
+
+// BridgeReportToken returns the token the bridge for containerID presents
+// with its traffic reports: an HMAC of the container ID under the report
+// key. It binds a report to one bridge, so a token cannot be replayed to
+// report traffic for another container.
+func BridgeReportToken(key []byte, containerID string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(containerID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// BridgeIdentity is how a socket bridge daemon identifies itself on
+// POST /traffic/report.
+type BridgeIdentity struct {
+	ContainerID string
+	Token       string // BridgeReportToken(key, ContainerID)
+}
+
+// NewBridgeIdentity returns the identity of the bridge for containerID.
+func NewBridgeIdentity(key []byte, containerID string) BridgeIdentity {
+	return BridgeIdentity{ContainerID: containerID, Token: BridgeReportToken(key, containerID)}
+}
+
+// SetReportKey sets the key traffic reports are checked against. Without
+// one every report is refused.
+func (s *Server) SetReportKey(key []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reportKey = append([]byte(nil), key...)
+}
+
+// verifyBridgeReport reports whether containerID and token identify a
+// socket bridge, or errUnauthorizedReport.
+func (s *Server) verifyBridgeReport(containerID, token string) error {
+	s.mu.RLock()
+	key := s.reportKey
+	s.mu.RUnlock()
+	if len(key) == 0 || containerID == "" || token == "" {
+		return errUnauthorizedReport
+	}
+	if !hmac.Equal([]byte(token), []byte(BridgeReportToken(key, containerID))) {
+		return errUnauthorizedReport
+	}
+	return nil
+}
+
+// HostReadToken returns the token that lets a host-side reader see the
+// traffic and socket bridges of every container. Only processes that can read the report key,
+// which no agent container mounts, can present it.
+func HostReadToken(key []byte) string {
+	return BridgeReportToken(key, hostReadSubject)
+}
+
+// LoadHostReadToken returns HostReadToken for the host proxy cfg configures,
+// creating the report key if the host proxy has not yet done so.
+func LoadHostReadToken(cfg config.Config) (string, error) {
+	dir, err := cfg.BridgesSubdir()
+	if err != nil {
+		return "", fmt.Errorf("resolving bridges directory: %w", err)
+	}
+	key, err := LoadOrCreateReportKey(filepath.Join(dir, consts.HostProxyReportKeyFile))
+	if err != nil {
+		return "", err
+	}
+	return HostReadToken(key), nil
+}
+
+// isHostReader reports whether token is HostReadToken under the report key.
+func (s *Server) isHostReader(token string) bool {
+	s.mu.RLock()
+	key := s.reportKey
+	s.mu.RUnlock()
+	if len(key) == 0 || token == "" {
+		return false
+	}
+	return hmac.Equal([]byte(token), []byte(HostReadToken(key)))
+}
+
+// ProjectContainersFunc returns the IDs of the containers in project.
+type ProjectContainersFunc func(ctx context.Context, project string) ([]string, error)
+
+// SetProjectContainers sets how the traffic endpoints find the containers of
+// the caller's project. Without one a caller sees only its own container.
+func (s *Server) SetProjectContainers(fn ProjectContainersFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projectContainers = fn
+}
+
+// containerScope is the containers a traffic or bridge status reader may
+// see; nil means all.
+type containerScope []string
+
+// includes reports whether id, a full or short (12+ character) container
+// ID, names a container in scope.
+func (sc containerScope) includes(id string) bool {
+	if sc == nil {
+		return true
+	}
+	for _, c := range sc {
+		if c == id || (len(id) >= shortContainerIDLen && strings.HasPrefix(c, id)) {
+			return true
+		}
+	}
+	return false
+}
+
+// shortContainerIDLen is the length of Docker's short container IDs.
+const shortContainerIDLen = 12
+
+// readScope returns what the reader of r may see: everything for a host-side
+// reader presenting HeaderHostToken, otherwise the containers of the
+// calling agent's project, identified by its caller token. The port is
+// reachable from every container, so an unidentified reader sees nothing
+// and gets errUnidentifiedCaller.
+func (s *Server) readScope(r *http.Request) (containerScope, error) {
+	if s.isHostReader(r.Header.Get(HeaderHostToken)) {
+		return nil, nil
+	}
+	caller, err := s.callerFor(r.Context(), r.Header.Get(HeaderCallerToken))
+	if err != nil {
+		return nil, err
+	}
+	scope := containerScope{caller.ContainerID}
+	s.mu.RLock()
+	fn := s.projectContainers
+	s.mu.RUnlock()
+	if fn == nil || caller.Project == "" {
+		return scope, nil
+	}
+	ids, err := fn(r.Context(), caller.Project)
+	if err != nil {
+		s.log.Debug().Err(err).Str("project", caller.Project).Msg("project container lookup failed")
+		return scope, nil
+	}
+	return append(scope, ids...), nil
+}
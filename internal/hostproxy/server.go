@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/logger"
 )
@@ -36,33 +37,49 @@ type dynamicListener struct {
 // Server is an HTTP server that handles requests from containers to perform
 // host-side actions.
 type Server struct {
-	port             int
-	log              *logger.Logger
-	rulesFilePath    string             // egress rules file path; empty = skip check (firewall disabled)
-	browserFunc      func(string) error // opens URL in host browser; defaults to openBrowser
-	listeners        []net.Listener     // IPv4 and optionally IPv6 listeners
-	servers          []*http.Server     // One server per listener
-	mu               sync.RWMutex
-	running          bool
-	sessionStore     *SessionStore
-	callbackChannel  *CallbackChannel
-	dynamicListeners map[int]*dynamicListener // port -> listener
-	portToSession    map[int]string           // port -> sessionID for lookups
+	port               int
+	log                *logger.Logger
+	rulesFilePath      string                 // egress rules file path; empty = skip check (firewall disabled)
+	browserFunc        func(string) error     // opens URL in host browser; defaults to openBrowser
+	clipboardWriteFunc func([]byte) error     // replaces the host clipboard; defaults to writeClipboard
+	clipboardReadFunc  func() ([]byte, error) // reads the host clipboard; defaults to readClipboard
+	listeners          []net.Listener         // IPv4 and optionally IPv6 listeners
+	servers            []*http.Server         // One server per listener
+	mu                 sync.RWMutex
+	running            bool
+	sessionStore       *SessionStore
+	callbackChannel    *CallbackChannel
+	dynamicListeners   map[int]*dynamicListener        // port -> listener
+	portToSession      map[int]string                  // port -> sessionID for lookups
+	traffic            *TrafficMeter                   // forwarded-traffic accounting, fed locally and by bridge reports
+	reportKey          []byte                          // verifies bridge traffic reports; nil = refuse all (guarded by mu)
+	credentialHosts    []string                        // git credential host allowlist; empty = the caller's project hosts (guarded by mu)
+	projectGitHosts    GitCredentialHostsFunc          // resolves a caller's project git hosts; nil = unidentifiable callers (guarded by mu)
+	bridges            *BridgeSupervisor               // socket bridge supervision behind /bridges; nil = none (guarded by mu)
+	readiness          []ReadinessCheck                // dependency checks behind /readyz (guarded by mu)
+	openURLPolicy      OpenURLPolicyFunc               // resolves a caller's open_url allowlist; nil = default policy (guarded by mu)
+	callerLookup       CallerFunc                      // resolves the agent container holding a caller token; nil = unidentifiable callers (guarded by mu)
+	projectContainers  ProjectContainersFunc           // lists a project's containers for the traffic endpoints; nil = the caller's only (guarded by mu)
+	clipboard          config.HostProxyClipboardConfig // clipboard bridge policy; zero = disabled (guarded by mu)
 }
 
 // NewServer creates a new host proxy server on the specified port.
 func NewServer(port int, log *logger.Logger, rulesFilePath string) *Server {
 	sessionStore := NewSessionStore()
 	s := &Server{
-		port:             port,
-		log:              log,
-		rulesFilePath:    rulesFilePath,
-		browserFunc:      openBrowser,
-		sessionStore:     sessionStore,
-		callbackChannel:  NewCallbackChannel(sessionStore, log),
-		dynamicListeners: make(map[int]*dynamicListener),
-		portToSession:    make(map[int]string),
-	}
+		port:               port,
+		log:                log,
+		rulesFilePath:      rulesFilePath,
+		browserFunc:        openBrowser,
+		clipboardWriteFunc: writeClipboard,
+		clipboardReadFunc:  readClipboard,
+		sessionStore:       sessionStore,
+		callbackChannel:    NewCallbackChannel(sessionStore, log),
+		dynamicListeners:   make(map[int]*dynamicListener),
+		portToSession:      make(map[int]string),
+		traffic:            NewTrafficMeter(0),
+	}
+	s.readiness = []ReadinessCheck{{Name: ReadyCheckCallbacks, Run: s.checkCallbacks}}
 
 	// Set up cleanup callback for when sessions are deleted
 	sessionStore.SetOnDelete(func(session *Session) {
@@ -96,10 +113,17 @@ func (s *Server) Start() error {
 	}
 
 	mux := http.NewServeMux()
+	// URL opening. /open/url is the route used by images built before the
+	// container allowlist (their host-open sends no container).
+	mux.HandleFunc("POST /open-url", s.handleOpenURL)
 	mux.HandleFunc("POST /open/url", s.handleOpenURL)
 	mux.HandleFunc("GET /health", s.handleHealth)
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /readyz", s.handleReadyz)
 
-	// Git credential forwarding endpoint
+	// Git credential forwarding endpoint. /git/credential is the route used
+	// by images built before the clawker-credential-helper binary.
+	mux.HandleFunc("POST /git/credentials", s.handleGitCredential)
 	mux.HandleFunc("POST /git/credential", s.handleGitCredential)
 
 	// Callback channel endpoints for OAuth flow
@@ -108,6 +132,18 @@ func (s *Server) Start() error {
 	mux.HandleFunc("DELETE /callback/{session}", s.handleCallbackDelete)
 	mux.HandleFunc("GET /cb/{session}/{path...}", s.handleCallbackCapture)
 
+	// Forwarded-traffic accounting endpoints
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	mux.HandleFunc("GET /traffic", s.handleTraffic)
+	mux.HandleFunc("POST /traffic/report", s.handleTrafficReport)
+
+	// Clipboard bridge (opt-in via host_proxy.clipboard)
+	mux.HandleFunc("POST /clipboard", s.handleClipboardCopy)
+	mux.HandleFunc("GET /clipboard", s.handleClipboardPaste)
+
+	// Socket bridge supervision status
+	mux.HandleFunc("GET /bridges", s.handleBridges)
+
 	// Bind to localhost only for security - both IPv4 and IPv6
 	// This is necessary because Docker Desktop's host.docker.internal can
 	// resolve to either IPv4 or IPv6 depending on the system configuration.
@@ -361,19 +397,45 @@ func (s *Server) Port() int {
 	return s.port
 }
 
-// openURLRequest is the JSON request body for the /open/url endpoint.
+// Traffic returns the server's forwarded-traffic meter.
+func (s *Server) Traffic() *TrafficMeter {
+	return s.traffic
+}
+
+// SetGitCredentialHosts sets the hosts containers may fetch git credentials
+// for (see matchCredentialHost). An empty list limits each container to its
+// own project's git remote hosts (see SetProjectGitCredentialHosts).
+func (s *Server) SetGitCredentialHosts(hosts []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.credentialHosts = append([]string(nil), hosts...)
+}
+
+// SetBridgeSupervisor sets the supervisor whose bridges /bridges reports.
+func (s *Server) SetBridgeSupervisor(sup *BridgeSupervisor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bridges = sup
+}
+
+// openURLRequest is the JSON request body for the /open-url endpoint.
+// The caller is identified by its HeaderCallerToken, not by anything in
+// the body.
 type openURLRequest struct {
 	URL string `json:"url"`
 }
 
-// openURLResponse is the JSON response body for the /open/url endpoint.
+// openURLResponse is the JSON response body for the /open-url endpoint.
 type openURLResponse struct {
 	Success bool   `json:"success"`
 	URL     string `json:"url,omitempty"`
 	Error   string `json:"error,omitempty"`
 }
 
-// handleOpenURL handles POST /open/url requests to open a URL in the host browser.
+// handleOpenURL handles POST /open-url (and the legacy /open/url) requests to
+// open a URL in the host browser. The caller must present its container's
+// HeaderCallerToken, and the URL must pass that container's OpenURLPolicy
+// and, for http(s) URLs, the egress rules.
 func (s *Server) handleOpenURL(w http.ResponseWriter, r *http.Request) {
 	// Limit request body size to prevent DoS
 	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
@@ -404,16 +466,37 @@ func (s *Server) handleOpenURL(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
-	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+	policy, err := s.openURLPolicyFor(r.Context(), r.Header.Get(HeaderCallerToken))
+	if err != nil {
+		s.log.Warn().Err(err).Str("url", req.URL).Msg("open-url from an unidentified caller refused")
+		s.writeJSON(w, http.StatusForbidden, openURLResponse{
+			Success: false,
+			URL:     req.URL,
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err := policy.checkScheme(parsedURL); err != nil {
 		s.writeJSON(w, http.StatusBadRequest, openURLResponse{
 			Success: false,
-			Error:   "only http and https URLs are allowed",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err := policy.checkHost(parsedURL); err != nil {
+		s.log.Warn().Err(err).Str("url", req.URL).Msg("blocked by open_url allowlist")
+		s.writeJSON(w, http.StatusForbidden, openURLResponse{
+			Success: false,
+			URL:     req.URL,
+			Error:   err.Error(),
 		})
 		return
 	}
 
-	// Enforce egress rules if configured (firewall enabled).
-	if s.rulesFilePath != "" {
+	// Enforce egress rules if configured (firewall enabled). Only http(s)
+	// URLs reach the network from the browser; a project's extra schemes go
+	// to local handlers and have no egress rule to match.
+	if s.rulesFilePath != "" && isWebScheme(parsedURL.Scheme) {
 		if err := CheckURLAgainstEgressRules(req.URL, s.rulesFilePath); err != nil {
 			if errors.Is(err, errEgressRulesInvalid) {
 				// Present-but-corrupt rules file (e.g. tampered after the daemon
@@ -466,7 +549,8 @@ type healthResponse struct {
 	Service string `json:"service"`
 }
 
-// handleHealth handles GET /health requests for health checking.
+// handleHealth handles GET /health and GET /healthz, the liveness check: it
+// answers whenever the server is up, whatever its dependencies' state.
 func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
 	s.writeJSON(w, http.StatusOK, healthResponse{
 		Status:  "ok",
@@ -490,6 +574,7 @@ type callbackRegisterRequest struct {
 	Port           int    `json:"port"`
 	Path           string `json:"path,omitempty"`
 	TimeoutSeconds int    `json:"timeout_seconds,omitempty"`
+	Container      string `json:"container,omitempty"` // caller's container ID (its hostname); keys traffic accounting
 }
 
 // callbackRegisterResponse is the JSON response body for POST /callback/register.
@@ -544,6 +629,9 @@ func (s *Server) handleCallbackRegister(w http.ResponseWriter, r *http.Request)
 		})
 		return
 	}
+	if req.Container != "" {
+		session.SetMetadata(metadataContainer, req.Container)
+	}
 
 	// Start a dynamic listener on the callback port
 	// This allows the host to capture OAuth callbacks on the same port
@@ -608,10 +696,34 @@ func (s *Server) handleCallbackGetData(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.writeJSON(w, http.StatusOK, callbackDataResponse{
+	body, err := json.Marshal(callbackDataResponse{
 		Received: true,
 		Callback: data,
 	})
+	if err != nil {
+		s.log.Error().Err(err).Str("session_id", sessionID).Msg("failed to encode callback data")
+		s.writeJSON(w, http.StatusInternalServerError, callbackDataResponse{
+			Received: false,
+			Error:    "failed to encode callback data",
+		})
+		return
+	}
+	body = append(body, '\n')
+	if session := s.sessionStore.Get(sessionID); session != nil {
+		if container, ok := session.GetMetadata(metadataContainer); ok {
+			if id, ok := container.(string); ok {
+				// Callback payloads are tiny and already captured; account
+				// them but never delay the container's poll.
+				s.traffic.Add([]TrafficSample{{Container: id, Bridge: BridgeCallback, Bytes: int64(len(body))}})
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		s.log.Debug().Err(err).Msg("failed to write callback data response")
+	}
 }
 
 // callbackDeleteResponse is the JSON response body for DELETE /callback/{session}.
@@ -692,3 +804,131 @@ func (s *Server) writeCallbackErrorPage(w http.ResponseWriter, message string) {
 		s.log.Debug().Err(err).Msg("failed to write callback error page")
 	}
 }
+
+// --- Forwarded-traffic accounting handlers ---
+
+// handleMetrics handles GET /metrics, serving traffic accounting in the
+// Prometheus text exposition format, scoped by readScope.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	streams, ok := s.scopedTraffic(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if err := WriteTrafficMetrics(w, s.traffic.Cap(), streams); err != nil {
+		s.log.Debug().Err(err).Msg("failed to write metrics response")
+	}
+}
+
+// handleTraffic handles GET /traffic, the JSON snapshot behind
+// `clawker host-proxy stats`, scoped by readScope.
+func (s *Server) handleTraffic(w http.ResponseWriter, r *http.Request) {
+	streams, ok := s.scopedTraffic(w, r)
+	if !ok {
+		return
+	}
+	s.writeJSON(w, http.StatusOK, TrafficReport{
+		CapBytesPerSec: s.traffic.Cap(),
+		Streams:        streams,
+	})
+}
+
+// scopedTraffic returns the traffic snapshot the reader of r may see. For
+// an unidentified reader it writes the refusal and reports false.
+func (s *Server) scopedTraffic(w http.ResponseWriter, r *http.Request) ([]TrafficStats, bool) {
+	scope, err := s.readScope(r)
+	if err != nil {
+		s.log.Warn().Str("remote", r.RemoteAddr).Str("path", r.URL.Path).Msg("traffic read from an unidentified caller refused")
+		s.writeJSON(w, http.StatusForbidden, readRefusedResponse{Error: err.Error()})
+		return nil, false
+	}
+	streams := s.traffic.Snapshot()
+	if scope == nil {
+		return streams, true
+	}
+	visible := make([]TrafficStats, 0, len(streams))
+	for _, st := range streams {
+		if scope.includes(st.Container) {
+			visible = append(visible, st)
+		}
+	}
+	return visible, true
+}
+
+// handleTrafficReport handles POST /traffic/report. Socket bridge daemons
+// meter their own streams and flush the deltas here for aggregation. The
+// port is reachable from containers, so a report must carry its bridge's
+// HeaderBridgeContainer and HeaderBridgeToken, and may only hold samples for
+// that bridge's container.
+func (s *Server) handleTrafficReport(w http.ResponseWriter, r *http.Request) {
+	containerID := r.Header.Get(HeaderBridgeContainer)
+	if err := s.verifyBridgeReport(containerID, r.Header.Get(HeaderBridgeToken)); err != nil {
+		s.log.Warn().Str("remote", r.RemoteAddr).Msg("rejected unauthenticated traffic report")
+		s.writeJSON(w, http.StatusUnauthorized, trafficReportResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+
+	var req trafficReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeJSON(w, http.StatusBadRequest, trafficReportResponse{
+			Success: false,
+			Error:   "invalid JSON request body",
+		})
+		return
+	}
+
+	for _, sample := range req.Samples {
+		if sample.Container == "" || !ValidBridgeType(sample.Bridge) || sample.Bytes < 0 || sample.Throttled < 0 {
+			s.writeJSON(w, http.StatusBadRequest, trafficReportResponse{
+				Success: false,
+				Error:   fmt.Sprintf("invalid sample for container %q bridge %q", sample.Container, sample.Bridge),
+			})
+			return
+		}
+	}
+
+	for _, sample := range req.Samples {
+		if sample.Container != containerID {
+			s.writeJSON(w, http.StatusForbidden, trafficReportResponse{
+				Success: false,
+				Error:   fmt.Sprintf("bridge for container %q cannot report traffic for container %q", containerID, sample.Container),
+			})
+			return
+		}
+	}
+
+	s.traffic.Add(req.Samples)
+	s.writeJSON(w, http.StatusOK, trafficReportResponse{Success: true})
+}
+
+// --- Socket bridge supervision handlers ---
+
+// handleBridges handles GET /bridges, the supervised socket bridges behind
+// `clawker monitor status`, scoped by readScope.
+func (s *Server) handleBridges(w http.ResponseWriter, r *http.Request) {
+	scope, err := s.readScope(r)
+	if err != nil {
+		s.log.Warn().Str("remote", r.RemoteAddr).Msg("bridge status read from an unidentified caller refused")
+		s.writeJSON(w, http.StatusForbidden, readRefusedResponse{Error: err.Error()})
+		return
+	}
+	s.mu.RLock()
+	sup := s.bridges
+	s.mu.RUnlock()
+
+	report := BridgeReport{Bridges: []BridgeStatus{}}
+	if sup != nil {
+		for _, b := range sup.Status() {
+			if scope.includes(b.ContainerID) {
+				report.Bridges = append(report.Bridges, b)
+			}
+		}
+	}
+	s.writeJSON(w, http.StatusOK, report)
+}
@@ -10,7 +10,9 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -109,9 +111,10 @@ func TestServerOpenURLEndpoint(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &Server{log: logger.Nop()}
+			s := &Server{log: logger.Nop(), openURLPolicy: defaultCaller}
 			req := httptest.NewRequest(http.MethodPost, "/open/url", bytes.NewBufferString(tt.body))
 			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(HeaderCallerToken, testCallerToken)
 			w := httptest.NewRecorder()
 
 			s.handleOpenURL(w, req)
@@ -199,6 +202,7 @@ func TestServerOpenURL_EgressBlocking(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			browserCalled := false
 			s := &Server{
+				openURLPolicy: defaultCaller,
 				log:           logger.Nop(),
 				rulesFilePath: rulesFile,
 				browserFunc:   func(_ string) error { browserCalled = true; return nil },
@@ -206,6 +210,7 @@ func TestServerOpenURL_EgressBlocking(t *testing.T) {
 
 			req := httptest.NewRequest(http.MethodPost, "/open/url", bytes.NewBufferString(tt.body))
 			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(HeaderCallerToken, testCallerToken)
 			w := httptest.NewRecorder()
 
 			s.handleOpenURL(w, req)
@@ -238,13 +243,15 @@ func TestServerOpenURL_EgressBlocking(t *testing.T) {
 func TestServerOpenURL_NoRulesFile_SkipsCheck(t *testing.T) {
 	browserCalled := false
 	s := &Server{
-		log:         logger.Nop(),
-		browserFunc: func(_ string) error { browserCalled = true; return nil },
+		openURLPolicy: defaultCaller,
+		log:           logger.Nop(),
+		browserFunc:   func(_ string) error { browserCalled = true; return nil },
 	}
 
 	req := httptest.NewRequest(http.MethodPost, "/open/url",
 		bytes.NewBufferString(`{"url":"https://any-domain.test/path"}`))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderCallerToken, testCallerToken)
 	w := httptest.NewRecorder()
 
 	s.handleOpenURL(w, req)
@@ -260,6 +267,7 @@ func TestServerOpenURL_NoRulesFile_SkipsCheck(t *testing.T) {
 
 func TestServerOpenURL_MissingRulesFile_FailsClosed(t *testing.T) {
 	s := &Server{
+		openURLPolicy: defaultCaller,
 		log:           logger.Nop(),
 		rulesFilePath: "/nonexistent/egress-rules.yaml",
 		browserFunc:   func(_ string) error { t.Fatal("browser should not be called"); return nil },
@@ -268,6 +276,7 @@ func TestServerOpenURL_MissingRulesFile_FailsClosed(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, "/open/url",
 		bytes.NewBufferString(`{"url":"https://github.test/foo"}`))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderCallerToken, testCallerToken)
 	w := httptest.NewRecorder()
 
 	s.handleOpenURL(w, req)
@@ -304,6 +313,7 @@ func TestServerOpenURL_InvalidRulesFile_FailsClosedLoud(t *testing.T) {
 	}
 
 	s := &Server{
+		openURLPolicy: defaultCaller,
 		log:           logger.Nop(),
 		rulesFilePath: f,
 		browserFunc:   func(_ string) error { t.Fatal("browser should not be called"); return nil },
@@ -312,6 +322,7 @@ func TestServerOpenURL_InvalidRulesFile_FailsClosedLoud(t *testing.T) {
 	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/open/url",
 		bytes.NewBufferString(`{"url":"https://github.test/"}`))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderCallerToken, testCallerToken)
 	w := httptest.NewRecorder()
 
 	s.handleOpenURL(w, req)
@@ -348,7 +359,7 @@ func TestServerIsRunning(t *testing.T) {
 }
 
 func TestServerOpenURLBodySizeLimit(t *testing.T) {
-	s := &Server{log: logger.Nop()}
+	s := &Server{log: logger.Nop(), openURLPolicy: defaultCaller}
 
 	// Create a body larger than maxRequestBodySize (1MB)
 	largeBody := make([]byte, maxRequestBodySize+1)
@@ -358,6 +369,7 @@ func TestServerOpenURLBodySizeLimit(t *testing.T) {
 
 	req := httptest.NewRequest(http.MethodPost, "/open/url", bytes.NewReader(largeBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderCallerToken, testCallerToken)
 	w := httptest.NewRecorder()
 
 	s.handleOpenURL(w, req)
@@ -878,3 +890,289 @@ func TestServer_DynamicListenerCaptureIPv6(t *testing.T) {
 		t.Errorf("expected query to contain 'state=RANDOM_STATE_V6', got %q", data.Query)
 	}
 }
+
+func TestServerTrafficReport(t *testing.T) {
+	key := []byte("report-key")
+	bridge := NewBridgeIdentity(key, "0123456789abcdef")
+	tests := []struct {
+		name       string
+		body       string
+		bridge     BridgeIdentity
+		wantStatus int
+	}{
+		{
+			name:       "valid samples",
+			body:       `{"samples":[{"container":"0123456789abcdef","bridge":"ssh","bytes":128}]}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown bridge type",
+			body:       `{"samples":[{"container":"0123456789abcdef","bridge":"smtp","bytes":1}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing container",
+			body:       `{"samples":[{"bridge":"gpg","bytes":1}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "negative bytes",
+			body:       `{"samples":[{"container":"0123456789abcdef","bridge":"gpg","bytes":-1}]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid JSON",
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unsigned",
+			body:       `{"samples":[{"container":"0123456789abcdef","bridge":"ssh","bytes":128}]}`,
+			bridge:     BridgeIdentity{ContainerID: bridge.ContainerID},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "token for another container",
+			body:       `{"samples":[{"container":"fedcba9876543210","bridge":"ssh","bytes":128}]}`,
+			bridge:     BridgeIdentity{ContainerID: "fedcba9876543210", Token: bridge.Token},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "sample for another container",
+			body:       `{"samples":[{"container":"fedcba9876543210","bridge":"ssh","bytes":128}]}`,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer(18374, logger.Nop(), "")
+			defer s.Stop(context.Background())
+			s.SetReportKey(key)
+
+			id := bridge
+			if tt.bridge != (BridgeIdentity{}) {
+				id = tt.bridge
+			}
+			req := httptest.NewRequest(http.MethodPost, "/traffic/report", bytes.NewBufferString(tt.body))
+			req.Header.Set(HeaderBridgeContainer, id.ContainerID)
+			req.Header.Set(HeaderBridgeToken, id.Token)
+			w := httptest.NewRecorder()
+			s.handleTrafficReport(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			stats := s.Traffic().Snapshot()
+			if tt.wantStatus != http.StatusOK {
+				if len(stats) != 0 {
+					t.Errorf("rejected report must not be accounted, got %+v", stats)
+				}
+				return
+			}
+			if len(stats) != 1 || stats[0].Container != "0123456789ab" || stats[0].TotalBytes != 128 {
+				t.Errorf("unexpected stats: %+v", stats)
+			}
+		})
+	}
+}
+
+func TestServerTrafficReport_NoKeyRefusesAll(t *testing.T) {
+	s := NewServer(18374, logger.Nop(), "")
+	defer s.Stop(context.Background())
+
+	id := NewBridgeIdentity([]byte("some-key"), "0123456789abcdef")
+	req := httptest.NewRequest(http.MethodPost, "/traffic/report",
+		bytes.NewBufferString(`{"samples":[{"container":"0123456789abcdef","bridge":"ssh","bytes":1}]}`))
+	req.Header.Set(HeaderBridgeContainer, id.ContainerID)
+	req.Header.Set(HeaderBridgeToken, id.Token)
+	w := httptest.NewRecorder()
+	s.handleTrafficReport(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401 without a report key, got %d", w.Code)
+	}
+}
+
+func TestLoadOrCreateReportKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pids", "hostproxy-report.key")
+	key, err := LoadOrCreateReportKey(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateReportKey: %v", err)
+	}
+	if len(key) != 2*reportKeyBytes {
+		t.Errorf("key length = %d, want %d", len(key), 2*reportKeyBytes)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat key file: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("key file mode = %o, want 600", perm)
+	}
+	again, err := LoadOrCreateReportKey(path)
+	if err != nil || string(again) != string(key) {
+		t.Errorf("second load = %q, %v; want the stored key", again, err)
+	}
+
+	// Racing creators agree on one key.
+	racePath := filepath.Join(t.TempDir(), "race.key")
+	keys := make(chan string, 8)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			k, err := LoadOrCreateReportKey(racePath)
+			if err != nil {
+				t.Errorf("concurrent LoadOrCreateReportKey: %v", err)
+			}
+			keys <- string(k)
+		}()
+	}
+	wg.Wait()
+	close(keys)
+	first := <-keys
+	for k := range keys {
+		if k != first {
+			t.Fatalf("racing creators got different keys %q and %q", first, k)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadOrCreateReportKey(path); err == nil {
+		t.Error("expected an empty key file to be an error")
+	}
+}
+
+func TestServerTrafficAndMetrics(t *testing.T) {
+	s := NewServer(18374, logger.Nop(), "")
+	defer s.Stop(context.Background())
+	key := []byte("test-report-key")
+	s.SetReportKey(key)
+	s.SetCallerLookup(testAgent) // testCallerToken is container abc123 of project app
+	s.SetProjectContainers(func(_ context.Context, project string) ([]string, error) {
+		if project != "app" {
+			t.Errorf("project = %q, want app", project)
+		}
+		return []string{"abc123", "def456"}, nil
+	})
+	s.Traffic().SetCap(4096)
+	s.Traffic().Add([]TrafficSample{
+		{Container: "abc123", Bridge: BridgeGPG, Bytes: 10},
+		{Container: "def456", Bridge: BridgeSSH, Bytes: 20},
+		{Container: "other", Bridge: BridgeGPG, Bytes: 30},
+	})
+
+	get := func(handler http.HandlerFunc, path, header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+	containers := func(w *httptest.ResponseRecorder) []string {
+		t.Helper()
+		var report TrafficReport
+		if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if report.CapBytesPerSec != 4096 {
+			t.Errorf("cap = %d, want 4096", report.CapBytesPerSec)
+		}
+		var ids []string
+		for _, st := range report.Streams {
+			ids = append(ids, st.Container)
+		}
+		slices.Sort(ids)
+		return ids
+	}
+
+	w := get(s.handleTraffic, "/traffic", HeaderHostToken, HostReadToken(key))
+	if got := containers(w); !slices.Equal(got, []string{"abc123", "def456", "other"}) {
+		t.Errorf("host reader sees %v, want every container", got)
+	}
+
+	w = get(s.handleTraffic, "/traffic", HeaderCallerToken, testCallerToken)
+	if got := containers(w); !slices.Equal(got, []string{"abc123", "def456"}) {
+		t.Errorf("agent sees %v, want its project's containers", got)
+	}
+
+	for _, tc := range []struct{ name, header, value string }{
+		{name: "no token"},
+		{name: "forged caller token", header: HeaderCallerToken, value: "forged"},
+		{name: "forged host token", header: HeaderHostToken, value: HostReadToken([]byte("other-key"))},
+	} {
+		for _, ep := range []struct {
+			path    string
+			handler http.HandlerFunc
+		}{{"/traffic", s.handleTraffic}, {"/metrics", s.handleMetrics}} {
+			if w := get(ep.handler, ep.path, tc.header, tc.value); w.Code != http.StatusForbidden {
+				t.Errorf("%s with %s: status = %d, want 403", ep.path, tc.name, w.Code)
+			}
+		}
+	}
+
+	w = get(s.handleMetrics, "/metrics", HeaderCallerToken, testCallerToken)
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("expected text/plain content type, got %q", ct)
+	}
+	if !strings.Contains(w.Body.String(), `clawker_hostproxy_forwarded_bytes_total{container="abc123",bridge="gpg"} 10`) {
+		t.Errorf("metrics missing gpg counter:\n%s", w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), `container="other"`) {
+		t.Errorf("metrics leak another project's container:\n%s", w.Body.String())
+	}
+}
+
+func TestContainerScope(t *testing.T) {
+	scope := containerScope{"abc123def4567890"}
+	for id, want := range map[string]bool{
+		"abc123def4567890": true,
+		"abc123def456":     true,  // short ID
+		"abc123":           false, // too short to be an ID
+		"fff123def456":     false,
+	} {
+		if got := scope.includes(id); got != want {
+			t.Errorf("includes(%q) = %v, want %v", id, got, want)
+		}
+	}
+	if !containerScope(nil).includes("anything") {
+		t.Error("a nil scope must include every container")
+	}
+}
+
+func TestServerCallbackGetData_AccountsTraffic(t *testing.T) {
+	s := NewServer(18374, logger.Nop(), "")
+	defer s.Stop(context.Background())
+
+	session, err := s.callbackChannel.Register(8080, "/callback", 5*time.Minute)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	session.SetMetadata(metadataContainer, "feedfacecafe")
+
+	captureReq := httptest.NewRequest(http.MethodGet, "/cb/"+session.ID+"/callback?code=ABC", nil)
+	captureReq.SetPathValue("session", session.ID)
+	captureReq.SetPathValue("path", "callback")
+	s.handleCallbackCapture(httptest.NewRecorder(), captureReq)
+
+	req := httptest.NewRequest(http.MethodGet, "/callback/"+session.ID+"/data", nil)
+	req.SetPathValue("session", session.ID)
+	w := httptest.NewRecorder()
+	s.handleCallbackGetData(w, req)
+
+	stats := s.Traffic().Snapshot()
+	if len(stats) != 1 {
+		t.Fatalf("expected 1 traffic row, got %+v", stats)
+	}
+	if stats[0].Container != "feedfacecafe" || stats[0].Bridge != BridgeCallback {
+		t.Errorf("unexpected row: %+v", stats[0])
+	}
+	if stats[0].TotalBytes != int64(w.Body.Len()) {
+		t.Errorf("TotalBytes = %d, want response size %d", stats[0].TotalBytes, w.Body.Len())
+	}
+}
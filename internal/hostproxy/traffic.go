@@ -0,0 +1,341 @@
+package hostproxy
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BridgeType names a forwarded-traffic channel for bandwidth accounting.
+type BridgeType string
+
+const (
+	// BridgeGPG is GPG agent traffic carried by the socket bridge.
+	BridgeGPG BridgeType = "gpg"
+	// BridgeSSH is SSH agent traffic carried by the socket bridge.
+	BridgeSSH BridgeType = "ssh"
+	// BridgeTCPForward is raw TCP port-forward traffic.
+	BridgeTCPForward BridgeType = "tcp-forward"
+	// BridgeCallback is OAuth callback data relayed back into a container.
+	BridgeCallback BridgeType = "callbacks"
+)
+
+// BridgeTypes lists every accounted bridge type in display order.
+func BridgeTypes() []BridgeType {
+	return []BridgeType{BridgeGPG, BridgeSSH, BridgeTCPForward, BridgeCallback}
+}
+
+// Rolling-window geometry: fixed-width buckets in a ring long enough to
+// cover the widest reported window.
+const (
+	trafficBucketWidth = 10 * time.Second
+	trafficBucketCount = int64(15 * time.Minute / trafficBucketWidth)
+)
+
+// containerShortIDLen is the Docker short-ID length. Accounting keys on it so
+// the socket bridge (full IDs) and in-container callers (which only know their
+// hostname, the short ID) land on the same row.
+const containerShortIDLen = 12
+
+// Prometheus metric names served on GET /metrics.
+const (
+	metricForwardedBytesTotal  = "clawker_hostproxy_forwarded_bytes_total"
+	metricForwardedBytesWindow = "clawker_hostproxy_forwarded_bytes_window"
+	metricThrottledSeconds     = "clawker_hostproxy_throttled_seconds_total"
+	metricCapBytesPerSecond    = "clawker_hostproxy_forward_cap_bytes_per_second"
+)
+
+// TrafficStats is one container/bridge row of a traffic snapshot.
+type TrafficStats struct {
+	Container  string        `json:"container"`
+	Bridge     BridgeType    `json:"bridge"`
+	TotalBytes int64         `json:"total_bytes"`
+	Bytes1m    int64         `json:"bytes_1m"`
+	Bytes5m    int64         `json:"bytes_5m"`
+	Bytes15m   int64         `json:"bytes_15m"`
+	Throttled  time.Duration `json:"throttled_ns"`
+}
+
+// TrafficSample is an accounting delta reported by an out-of-process bridge.
+type TrafficSample struct {
+	Container string     `json:"container"`
+	Bridge    BridgeType `json:"bridge"`
+	Bytes     int64      `json:"bytes"`
+	Throttled int64      `json:"throttled_ns,omitempty"`
+}
+
+type trafficKey struct {
+	container string
+	bridge    BridgeType
+}
+
+// trafficCounter holds a lifetime total plus a ring of per-bucket byte counts.
+// epochs records which bucket index each slot currently holds, so stale slots
+// are recognized (and reset) lazily instead of by a sweeper goroutine.
+type trafficCounter struct {
+	total     int64
+	throttled time.Duration
+	bytes     [trafficBucketCount]int64
+	epochs    [trafficBucketCount]int64
+}
+
+func (c *trafficCounter) add(now time.Time, n int64) {
+	c.total += n
+	epoch := now.UnixNano() / int64(trafficBucketWidth)
+	slot := epoch % trafficBucketCount
+	if c.epochs[slot] != epoch {
+		c.epochs[slot] = epoch
+		c.bytes[slot] = 0
+	}
+	c.bytes[slot] += n
+}
+
+func (c *trafficCounter) sum(now time.Time, window time.Duration) int64 {
+	epoch := now.UnixNano() / int64(trafficBucketWidth)
+	var total int64
+	for i := range int64(window / trafficBucketWidth) {
+		e := epoch - i
+		slot := e % trafficBucketCount
+		if c.epochs[slot] == e {
+			total += c.bytes[slot]
+		}
+	}
+	return total
+}
+
+// tokenBucket enforces a per-container byte rate with a one-second burst.
+// Tokens may go negative: the deficit is the debt the caller must sleep off.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) take(now time.Time, rate int64, n int64) time.Duration {
+	limit := float64(rate)
+	if b.last.IsZero() {
+		b.tokens = limit
+	} else {
+		b.tokens = min(limit, b.tokens+now.Sub(b.last).Seconds()*limit)
+	}
+	b.last = now
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / limit * float64(time.Second))
+}
+
+// TrafficMeter accounts forwarded bytes per container per bridge type over
+// rolling windows, and optionally caps each container's combined forwarded
+// rate. It is safe for concurrent use.
+//
+// The meter never sleeps itself: Record returns how long the caller should
+// pause so the owning stream (not the whole process) absorbs the throttle.
+type TrafficMeter struct {
+	mu         sync.Mutex
+	capBPS     int64 // bytes/sec per container; 0 = unlimited
+	now        func() time.Time
+	counters   map[trafficKey]*trafficCounter
+	buckets    map[string]*tokenBucket
+	unreported map[trafficKey]*TrafficSample
+}
+
+// NewTrafficMeter creates a meter capping each container at capBytesPerSec
+// (0 = unlimited).
+func NewTrafficMeter(capBytesPerSec int64) *TrafficMeter {
+	return &TrafficMeter{
+		capBPS:     max(capBytesPerSec, 0),
+		now:        time.Now,
+		counters:   make(map[trafficKey]*trafficCounter),
+		buckets:    make(map[string]*tokenBucket),
+		unreported: make(map[trafficKey]*TrafficSample),
+	}
+}
+
+// Cap returns the per-container cap in bytes per second (0 = unlimited).
+func (m *TrafficMeter) Cap() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.capBPS
+}
+
+// SetCap changes the per-container cap. Existing token buckets are dropped so
+// a lowered cap does not inherit a burst sized for the old one.
+func (m *TrafficMeter) SetCap(capBytesPerSec int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.capBPS = max(capBytesPerSec, 0)
+	m.buckets = make(map[string]*tokenBucket)
+}
+
+// Record accounts n bytes forwarded for containerID over bridge and returns
+// the delay the caller should wait before forwarding more, or zero when the
+// container is under its cap. The delay is also tallied as throttled time.
+func (m *TrafficMeter) Record(containerID string, bridge BridgeType, n int) time.Duration {
+	if n <= 0 {
+		return 0
+	}
+	key := trafficKey{container: shortContainerID(containerID), bridge: bridge}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	m.counterLocked(key).add(now, int64(n))
+
+	var delay time.Duration
+	if m.capBPS > 0 {
+		b, ok := m.buckets[key.container]
+		if !ok {
+			b = &tokenBucket{}
+			m.buckets[key.container] = b
+		}
+		delay = b.take(now, m.capBPS, int64(n))
+		m.counters[key].throttled += delay
+	}
+
+	pending, ok := m.unreported[key]
+	if !ok {
+		pending = &TrafficSample{Container: key.container, Bridge: bridge}
+		m.unreported[key] = pending
+	}
+	pending.Bytes += int64(n)
+	pending.Throttled += int64(delay)
+	return delay
+}
+
+// Add folds samples reported by another process into the meter. Samples are
+// accounted at the time they arrive and never throttled here — the reporting
+// process already enforced the cap on its own streams.
+func (m *TrafficMeter) Add(samples []TrafficSample) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	for _, s := range samples {
+		if s.Bytes <= 0 && s.Throttled <= 0 {
+			continue
+		}
+		c := m.counterLocked(trafficKey{container: shortContainerID(s.Container), bridge: s.Bridge})
+		if s.Bytes > 0 {
+			c.add(now, s.Bytes)
+		}
+		if s.Throttled > 0 {
+			c.throttled += time.Duration(s.Throttled)
+		}
+	}
+}
+
+// TakeUnreported returns the bytes recorded since the previous call and
+// resets the pending deltas. Bridges flush these to the host proxy.
+func (m *TrafficMeter) TakeUnreported() []TrafficSample {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.unreported) == 0 {
+		return nil
+	}
+	samples := make([]TrafficSample, 0, len(m.unreported))
+	for _, s := range m.unreported {
+		samples = append(samples, *s)
+	}
+	m.unreported = make(map[trafficKey]*TrafficSample)
+	sortSamples(samples)
+	return samples
+}
+
+// Snapshot returns one row per container/bridge pair seen so far, sorted by
+// container then bridge type.
+func (m *TrafficMeter) Snapshot() []TrafficStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	stats := make([]TrafficStats, 0, len(m.counters))
+	for key, c := range m.counters {
+		stats = append(stats, TrafficStats{
+			Container:  key.container,
+			Bridge:     key.bridge,
+			TotalBytes: c.total,
+			Bytes1m:    c.sum(now, time.Minute),
+			Bytes5m:    c.sum(now, 5*time.Minute),
+			Bytes15m:   c.sum(now, 15*time.Minute),
+			Throttled:  c.throttled,
+		})
+	}
+	slices.SortFunc(stats, func(a, b TrafficStats) int {
+		if c := strings.Compare(a.Container, b.Container); c != 0 {
+			return c
+		}
+		return bridgeOrder(a.Bridge) - bridgeOrder(b.Bridge)
+	})
+	return stats
+}
+
+func (m *TrafficMeter) counterLocked(key trafficKey) *trafficCounter {
+	c, ok := m.counters[key]
+	if !ok {
+		c = &trafficCounter{}
+		m.counters[key] = c
+	}
+	return c
+}
+
+// WriteTrafficMetrics renders a snapshot in the Prometheus text exposition
+// format.
+func WriteTrafficMetrics(w io.Writer, capBytesPerSec int64, stats []TrafficStats) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# HELP %s Per-container forwarded traffic cap (0 = unlimited).\n", metricCapBytesPerSecond)
+	fmt.Fprintf(&b, "# TYPE %s gauge\n", metricCapBytesPerSecond)
+	fmt.Fprintf(&b, "%s %d\n", metricCapBytesPerSecond, capBytesPerSec)
+
+	fmt.Fprintf(&b, "# HELP %s Bytes forwarded per container and bridge type.\n", metricForwardedBytesTotal)
+	fmt.Fprintf(&b, "# TYPE %s counter\n", metricForwardedBytesTotal)
+	for _, s := range stats {
+		fmt.Fprintf(&b, "%s{container=%q,bridge=%q} %d\n", metricForwardedBytesTotal, s.Container, s.Bridge, s.TotalBytes)
+	}
+
+	fmt.Fprintf(&b, "# HELP %s Bytes forwarded over a trailing window.\n", metricForwardedBytesWindow)
+	fmt.Fprintf(&b, "# TYPE %s gauge\n", metricForwardedBytesWindow)
+	for _, s := range stats {
+		for _, win := range []struct {
+			label string
+			bytes int64
+		}{{"1m", s.Bytes1m}, {"5m", s.Bytes5m}, {"15m", s.Bytes15m}} {
+			fmt.Fprintf(&b, "%s{container=%q,bridge=%q,window=%q} %d\n", metricForwardedBytesWindow, s.Container, s.Bridge, win.label, win.bytes)
+		}
+	}
+
+	fmt.Fprintf(&b, "# HELP %s Time forwarded streams spent paused by the bandwidth cap.\n", metricThrottledSeconds)
+	fmt.Fprintf(&b, "# TYPE %s counter\n", metricThrottledSeconds)
+	for _, s := range stats {
+		fmt.Fprintf(&b, "%s{container=%q,bridge=%q} %g\n", metricThrottledSeconds, s.Container, s.Bridge, s.Throttled.Seconds())
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ValidBridgeType reports whether t is one of the accounted bridge types.
+func ValidBridgeType(t BridgeType) bool {
+	return slices.Contains(BridgeTypes(), t)
+}
+
+func bridgeOrder(t BridgeType) int {
+	return slices.Index(BridgeTypes(), t)
+}
+
+func sortSamples(samples []TrafficSample) {
+	slices.SortFunc(samples, func(a, b TrafficSample) int {
+		if c := strings.Compare(a.Container, b.Container); c != 0 {
+			return c
+		}
+		return bridgeOrder(a.Bridge) - bridgeOrder(b.Bridge)
+	})
+}
+
+func shortContainerID(id string) string {
+	if len(id) > containerShortIDLen {
+		return id[:containerShortIDLen]
+	}
+	return id
+}
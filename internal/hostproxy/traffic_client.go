@@ -0,0 +1,157 @@
+package hostproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// TrafficReport is the GET /traffic response body.
+type TrafficReport struct {
+	CapBytesPerSec int64          `json:"cap_bytes_per_sec"`
+	Streams        []TrafficStats `json:"streams"`
+}
+
+// trafficReportRequest is the POST /traffic/report request body.
+type trafficReportRequest struct {
+	Samples []TrafficSample `json:"samples"`
+}
+
+// trafficReportResponse is the POST /traffic/report response body.
+type trafficReportResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// readRefusedResponse is the body of a refused GET /metrics, /traffic or
+// /bridges.
+type readRefusedResponse struct {
+	Error string `json:"error"`
+}
+
+// localURL is the host-side base URL of the host proxy on port.
+func localURL(port int) string {
+	return fmt.Sprintf(schemeHTTP+"://"+consts.Localhost+":%d", port)
+}
+
+// FetchTraffic retrieves the aggregated traffic snapshot of every container
+// from the host proxy listening on port, presenting hostToken (see
+// LoadHostReadToken).
+func FetchTraffic(ctx context.Context, port int, hostToken string) (*TrafficReport, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, localURL(port)+"/traffic", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(HeaderHostToken, hostToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("host proxy unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var r readRefusedResponse
+		if decodeErr := json.NewDecoder(resp.Body).Decode(&r); decodeErr == nil && r.Error != "" {
+			return nil, fmt.Errorf("host proxy refused traffic read: %s", r.Error)
+		}
+		return nil, fmt.Errorf("host proxy returned %s", resp.Status)
+	}
+	var report TrafficReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("decoding traffic report: %w", err)
+	}
+	return &report, nil
+}
+
+// FetchBridges retrieves every supervised socket bridge from the host proxy
+// listening on port, presenting hostToken (see LoadHostReadToken).
+func FetchBridges(ctx context.Context, port int, hostToken string) (*BridgeReport, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, localURL(port)+"/bridges", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(HeaderHostToken, hostToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("host proxy unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var r readRefusedResponse
+		if decodeErr := json.NewDecoder(resp.Body).Decode(&r); decodeErr == nil && r.Error != "" {
+			return nil, fmt.Errorf("host proxy refused bridge status read: %s", r.Error)
+		}
+		return nil, fmt.Errorf("host proxy returned %s", resp.Status)
+	}
+	var report BridgeReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("decoding bridge report: %w", err)
+	}
+	return &report, nil
+}
+
+// ReportTraffic posts accounting deltas to the host proxy listening on port,
+// signed as bridge.
+func ReportTraffic(ctx context.Context, port int, bridge BridgeIdentity, samples []TrafficSample) error {
+	body, err := json.Marshal(trafficReportRequest{Samples: samples})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, localURL(port)+"/traffic/report", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderBridgeContainer, bridge.ContainerID)
+	req.Header.Set(HeaderBridgeToken, bridge.Token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("host proxy unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var r trafficReportResponse
+		if decodeErr := json.NewDecoder(resp.Body).Decode(&r); decodeErr == nil && r.Error != "" {
+			return fmt.Errorf("host proxy rejected traffic report: %s", r.Error)
+		}
+		return fmt.Errorf("host proxy returned %s", resp.Status)
+	}
+	return nil
+}
+
+// RunTrafficReporter flushes meter's pending deltas to the host proxy on port,
+// signed as bridge, every interval until ctx is cancelled, then makes one
+// final flush. Flush
+// failures are logged at debug and the deltas dropped: accounting is
+// best-effort while the host proxy is down, and the cap is enforced locally
+// regardless.
+func RunTrafficReporter(ctx context.Context, meter *TrafficMeter, port int, bridge BridgeIdentity, interval time.Duration, log *logger.Logger) {
+	flush := func(parent context.Context) {
+		samples := meter.TakeUnreported()
+		if len(samples) == 0 {
+			return
+		}
+		flushCtx, cancel := context.WithTimeout(parent, consts.HostProxyTrafficReportTimeout)
+		defer cancel()
+		if err := ReportTraffic(flushCtx, port, bridge, samples); err != nil {
+			log.Debug().Err(err).Int("samples", len(samples)).Msg("dropping traffic report")
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flush(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C:
+			flush(ctx)
+		}
+	}
+}
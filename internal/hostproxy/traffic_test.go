@@ -0,0 +1,162 @@
+package hostproxy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestMeter returns a meter whose clock is driven by the returned advance
+// function.
+func newTestMeter(capBytesPerSec int64) (*TrafficMeter, func(time.Duration)) {
+	m := NewTrafficMeter(capBytesPerSec)
+	now := time.Unix(1_700_000_000, 0)
+	m.now = func() time.Time { return now }
+	return m, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestTrafficMeter_RollingWindows(t *testing.T) {
+	m, advance := newTestMeter(0)
+
+	m.Record("container-a", BridgeSSH, 100)
+	advance(2 * time.Minute)
+	m.Record("container-a", BridgeSSH, 50)
+	advance(10 * time.Minute)
+	m.Record("container-a", BridgeSSH, 10)
+
+	stats := m.Snapshot()
+	if len(stats) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(stats))
+	}
+	got := stats[0]
+	if got.TotalBytes != 160 {
+		t.Errorf("TotalBytes = %d, want 160", got.TotalBytes)
+	}
+	if got.Bytes1m != 10 {
+		t.Errorf("Bytes1m = %d, want 10", got.Bytes1m)
+	}
+	if got.Bytes5m != 10 {
+		t.Errorf("Bytes5m = %d, want 10", got.Bytes5m)
+	}
+	if got.Bytes15m != 160 {
+		t.Errorf("Bytes15m = %d, want 160", got.Bytes15m)
+	}
+
+	// Past the widest window only the lifetime total survives.
+	advance(20 * time.Minute)
+	got = m.Snapshot()[0]
+	if got.Bytes15m != 0 || got.TotalBytes != 160 {
+		t.Errorf("after 20m: Bytes15m = %d, TotalBytes = %d; want 0, 160", got.Bytes15m, got.TotalBytes)
+	}
+}
+
+func TestTrafficMeter_KeysOnShortContainerID(t *testing.T) {
+	m, _ := newTestMeter(0)
+
+	m.Record("0123456789abcdef0123", BridgeGPG, 5)
+	m.Add([]TrafficSample{{Container: "0123456789ab", Bridge: BridgeGPG, Bytes: 7}})
+	m.Record("0123456789abcdef0123", BridgeSSH, 1)
+
+	stats := m.Snapshot()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(stats))
+	}
+	if stats[0].Container != "0123456789ab" || stats[0].Bridge != BridgeGPG || stats[0].TotalBytes != 12 {
+		t.Errorf("unexpected gpg row: %+v", stats[0])
+	}
+	if stats[1].Bridge != BridgeSSH {
+		t.Errorf("rows not in bridge order: %+v", stats)
+	}
+}
+
+func TestTrafficMeter_CapThrottles(t *testing.T) {
+	m, advance := newTestMeter(1000)
+
+	// The first second's worth is burst.
+	if d := m.Record("c", BridgeSSH, 1000); d != 0 {
+		t.Errorf("burst delay = %v, want 0", d)
+	}
+	// Going 500 bytes over a 1000 B/s cap owes half a second.
+	if d := m.Record("c", BridgeGPG, 500); d != 500*time.Millisecond {
+		t.Errorf("delay = %v, want 500ms", d)
+	}
+	// The cap is per container, not per bridge or globally.
+	if d := m.Record("other", BridgeSSH, 1000); d != 0 {
+		t.Errorf("other container delay = %v, want 0", d)
+	}
+	// After the debt is paid off the bucket refills.
+	advance(2 * time.Second)
+	if d := m.Record("c", BridgeSSH, 100); d != 0 {
+		t.Errorf("refilled delay = %v, want 0", d)
+	}
+
+	var throttled time.Duration
+	for _, s := range m.Snapshot() {
+		throttled += s.Throttled
+	}
+	if throttled != 500*time.Millisecond {
+		t.Errorf("total throttled = %v, want 500ms", throttled)
+	}
+}
+
+func TestTrafficMeter_Unlimited(t *testing.T) {
+	m, _ := newTestMeter(0)
+	if d := m.Record("c", BridgeSSH, 1<<30); d != 0 {
+		t.Errorf("unlimited delay = %v, want 0", d)
+	}
+	if d := m.Record("c", BridgeSSH, 0); d != 0 {
+		t.Errorf("zero-byte delay = %v, want 0", d)
+	}
+}
+
+func TestTrafficMeter_TakeUnreported(t *testing.T) {
+	m, _ := newTestMeter(0)
+
+	if got := m.TakeUnreported(); got != nil {
+		t.Fatalf("expected nil before any traffic, got %+v", got)
+	}
+
+	m.Record("c", BridgeSSH, 3)
+	m.Record("c", BridgeSSH, 4)
+	m.Record("c", BridgeGPG, 1)
+	// Reported samples from elsewhere are not re-reported.
+	m.Add([]TrafficSample{{Container: "c", Bridge: BridgeCallback, Bytes: 9}})
+
+	got := m.TakeUnreported()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 samples, got %+v", got)
+	}
+	if got[0].Bridge != BridgeGPG || got[0].Bytes != 1 || got[1].Bridge != BridgeSSH || got[1].Bytes != 7 {
+		t.Errorf("unexpected samples: %+v", got)
+	}
+	if again := m.TakeUnreported(); again != nil {
+		t.Errorf("expected deltas reset, got %+v", again)
+	}
+}
+
+func TestWriteTrafficMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteTrafficMetrics(&buf, 2048, []TrafficStats{{
+		Container:  "abc",
+		Bridge:     BridgeSSH,
+		TotalBytes: 42,
+		Bytes1m:    2,
+		Throttled:  1500 * time.Millisecond,
+	}})
+	if err != nil {
+		t.Fatalf("WriteTrafficMetrics: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"clawker_hostproxy_forward_cap_bytes_per_second 2048",
+		"# TYPE clawker_hostproxy_forwarded_bytes_total counter",
+		`clawker_hostproxy_forwarded_bytes_total{container="abc",bridge="ssh"} 42`,
+		`clawker_hostproxy_forwarded_bytes_window{container="abc",bridge="ssh",window="1m"} 2`,
+		`clawker_hostproxy_throttled_seconds_total{container="abc",bridge="ssh"} 1.5`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Package imagescan runs the post-build SBOM and vulnerability scan hooks of
+// the project build.scan: block against a freshly built image and stores the
+// results under the data dir. Both hooks are host commands (/bin/sh -c); the
+// scanner's JSON report is read for severity counts (grype and trivy formats)
+// so fail_on can fail the build.
+package imagescan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/schmitthub/clawker/internal/config"
+)
+
+// Result file names inside a scan directory.
+const (
+	SBOMFile    = "sbom.json"
+	ReportFile  = "report.json"
+	SummaryFile = "summary.json"
+)
+
+// Severities from least to most severe. Scanner severities outside this list
+// (negligible, unknown) count under their own names but never meet fail_on.
+var Severities = []string{"low", "medium", "high", "critical"}
+
+// Options describes one scan.
+type Options struct {
+	Config  config.BuildScanConfig
+	Image   string // the built tag, exported as CLAWKER_IMAGE
+	ImageID string // the built image ID, exported as CLAWKER_IMAGE_ID
+	// Dir receives sbom.json, report.json and summary.json; created if
+	// missing.
+	Dir string
+	// Stderr receives the hooks' stderr (nil discards it).
+	Stderr io.Writer
+}
+
+// Summary is the outcome of a scan, also written as summary.json.
+type Summary struct {
+	Image     string         `json:"image"`
+	ImageID   string         `json:"image_id,omitempty"`
+	ScannedAt time.Time      `json:"scanned_at"`
+	SBOM      string         `json:"sbom,omitempty"`
+	Report    string         `json:"report,omitempty"`
+	Counts    map[string]int `json:"counts,omitempty"`
+	FailOn    string         `json:"fail_on,omitempty"`
+	// AtOrAbove counts the findings at fail_on or worse; Failed is true
+	// when it is non-zero.
+	AtOrAbove int  `json:"at_or_above,omitempty"`
+	Failed    bool `json:"failed"`
+	// ParseError says why the report's severities could not be read.
+	ParseError string `json:"parse_error,omitempty"`
+}
+
+// Enabled reports whether cfg configures anything to run.
+func Enabled(cfg config.BuildScanConfig) bool {
+	return cfg.SBOM != "" || cfg.Command != ""
+}
+
+// Run generates the SBOM (when configured), then runs the scanner (when
+// configured) with CLAWKER_SBOM pointing at it, and writes summary.json. A
+// hook exiting non-zero is an error — leave the scanner's own fail flags off
+// and let fail_on decide. With fail_on set, a report whose severities cannot
+// be read is an error too; without it, the report is kept and the parse
+// error only recorded.
+func Run(ctx context.Context, opts Options) (*Summary, error) {
+	failRank, err := severityRank(opts.Config.FailOn)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating scan directory: %w", err)
+	}
+
+	s := &Summary{
+		Image:     opts.Image,
+		ImageID:   opts.ImageID,
+		ScannedAt: time.Now().UTC(),
+		FailOn:    strings.ToLower(opts.Config.FailOn),
+	}
+	env := []string{"CLAWKER_IMAGE=" + opts.Image, "CLAWKER_IMAGE_ID=" + opts.ImageID}
+
+	if opts.Config.SBOM != "" {
+		s.SBOM = filepath.Join(opts.Dir, SBOMFile)
+		if err := runHook(ctx, opts.Config.SBOM, env, s.SBOM, opts.Stderr); err != nil {
+			return nil, fmt.Errorf("sbom: %w", err)
+		}
+		env = append(env, "CLAWKER_SBOM="+s.SBOM)
+	}
+
+	if opts.Config.Command != "" {
+		s.Report = filepath.Join(opts.Dir, ReportFile)
+		if err := runHook(ctx, opts.Config.Command, env, s.Report, opts.Stderr); err != nil {
+			return nil, fmt.Errorf("vulnerability scan: %w", err)
+		}
+		report, err := os.ReadFile(s.Report)
+		if err != nil {
+			return nil, fmt.Errorf("reading scan report: %w", err)
+		}
+		counts, err := ParseSeverities(report)
+		if err != nil {
+			if failRank > 0 {
+				return nil, fmt.Errorf("vulnerability scan: %w; fail_on needs grype or trivy JSON output", err)
+			}
+			s.ParseError = err.Error()
+		}
+		s.Counts = counts
+		for sev, n := range counts {
+			if r, _ := severityRank(sev); failRank > 0 && r >= failRank {
+				s.AtOrAbove += n
+			}
+		}
+		s.Failed = s.AtOrAbove > 0
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding scan summary: %w", err)
+	}
+	//nolint:gosec // scan results are not secret
+	if err := os.WriteFile(filepath.Join(opts.Dir, SummaryFile), append(data, '\n'), 0o644); err != nil {
+		return nil, fmt.Errorf("writing scan summary: %w", err)
+	}
+	return s, nil
+}
+
+// runHook runs command via /bin/sh -c with env added, writing its stdout to
+// outPath.
+func runHook(ctx context.Context, command string, env []string, outPath string, stderr io.Writer) error {
+	out, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if stderr == nil {
+		stderr = io.Discard
+	}
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Stdout = out
+	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(), env...)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%q: %w", command, err)
+	}
+	return out.Close()
+}
+
+// severityRank ranks a severity name: 1 for low up to 4 for critical, 0 for
+// "" (no threshold) and for severities below low. An unknown name is an
+// error only where a threshold is expected, so callers counting findings
+// ignore it.
+func severityRank(sev string) (int, error) {
+	if sev == "" {
+		return 0, nil
+	}
+	if i := slices.Index(Severities, strings.ToLower(sev)); i >= 0 {
+		return i + 1, nil
+	}
+	return 0, fmt.Errorf("build.scan.fail_on %q: want one of %s", sev, strings.Join(Severities, ", "))
+}
+
+// scanReport covers the two supported report shapes: grype's
+// matches[].vulnerability.severity and trivy's
+// Results[].Vulnerabilities[].Severity.
+type scanReport struct {
+	Matches *[]struct {
+		Vulnerability struct {
+			Severity string `json:"severity"`
+		} `json:"vulnerability"`
+	} `json:"matches"`
+	Results *[]struct {
+		Vulnerabilities []struct {
+			Severity string `json:"Severity"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// ParseSeverities counts the findings in a grype or trivy JSON report by
+// lower-cased severity.
+func ParseSeverities(report []byte) (map[string]int, error) {
+	var r scanReport
+	if err := json.NewDecoder(bytes.NewReader(report)).Decode(&r); err != nil {
+		return nil, fmt.Errorf("reading scan report: %w", err)
+	}
+	counts := map[string]int{}
+	switch {
+	case r.Matches != nil:
+		for _, m := range *r.Matches {
+			counts[strings.ToLower(m.Vulnerability.Severity)]++
+		}
+	case r.Results != nil:
+		for _, res := range *r.Results {
+			for _, v := range res.Vulnerabilities {
+				counts[strings.ToLower(v.Severity)]++
+			}
+		}
+	default:
+		return nil, errors.New("unrecognized scan report: want grype (-o json) or trivy (--format json) output")
+	}
+	return counts, nil
+}
@@ -0,0 +1,135 @@
+package imagescan
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+)
+
+const grypeReport = `{"matches":[
+ {"vulnerability":{"id":"CVE-1","severity":"High"}},
+ {"vulnerability":{"id":"CVE-2","severity":"Medium"}},
+ {"vulnerability":{"id":"CVE-3","severity":"Negligible"}}]}`
+
+const trivyReport = `{"Results":[
+ {"Target":"debian","Vulnerabilities":[{"Severity":"CRITICAL"},{"Severity":"LOW"}]},
+ {"Target":"node"}]}`
+
+func TestParseSeverities(t *testing.T) {
+	counts, err := ParseSeverities([]byte(grypeReport))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"high": 1, "medium": 1, "negligible": 1}, counts)
+
+	counts, err = ParseSeverities([]byte(trivyReport))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"critical": 1, "low": 1}, counts)
+
+	counts, err = ParseSeverities([]byte(`{"matches":[]}`))
+	require.NoError(t, err)
+	assert.Empty(t, counts, "a clean grype report is still recognized")
+
+	_, err = ParseSeverities([]byte(`{"runs":[]}`))
+	assert.ErrorContains(t, err, "unrecognized scan report")
+}
+
+// writeReport stages report as a file a scanner hook can cat.
+func writeReport(t *testing.T, report string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	require.NoError(t, os.WriteFile(path, []byte(report), 0o644))
+	return path
+}
+
+func TestRun(t *testing.T) {
+	fixture := writeReport(t, grypeReport)
+	tests := []struct {
+		name          string
+		failOn        string
+		wantAtOrAbove int
+		wantFailed    bool
+	}{
+		{name: "no threshold", failOn: ""},
+		{name: "below the findings", failOn: "critical"},
+		{name: "at the findings", failOn: "high", wantAtOrAbove: 1, wantFailed: true},
+		{name: "threshold is case-insensitive", failOn: "MEDIUM", wantAtOrAbove: 2, wantFailed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "scan")
+			s, err := Run(context.Background(), Options{
+				Config: config.BuildScanConfig{
+					SBOM: `printf '{"image":"%s"}' "$CLAWKER_IMAGE"`,
+					// The scanner sees the SBOM the first hook wrote.
+					Command: `test -s "$CLAWKER_SBOM" && cat ` + fixture,
+					FailOn:  tt.failOn,
+				},
+				Image:   "clawker-app:claude",
+				ImageID: "sha256:abc",
+				Dir:     dir,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAtOrAbove, s.AtOrAbove)
+			assert.Equal(t, tt.wantFailed, s.Failed)
+			assert.Equal(t, map[string]int{"high": 1, "medium": 1, "negligible": 1}, s.Counts)
+
+			sbom, err := os.ReadFile(filepath.Join(dir, SBOMFile))
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"image":"clawker-app:claude"}`, string(sbom))
+
+			var onDisk Summary
+			data, err := os.ReadFile(filepath.Join(dir, SummaryFile))
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(data, &onDisk))
+			assert.Equal(t, s.Failed, onDisk.Failed)
+			assert.Equal(t, filepath.Join(dir, ReportFile), onDisk.Report)
+		})
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.BuildScanConfig
+		wantErr string
+	}{
+		{
+			name:    "unknown threshold",
+			cfg:     config.BuildScanConfig{Command: "true", FailOn: "severe"},
+			wantErr: "build.scan.fail_on",
+		},
+		{
+			name:    "scanner exits non-zero",
+			cfg:     config.BuildScanConfig{Command: "exit 3"},
+			wantErr: "vulnerability scan",
+		},
+		{
+			name:    "unreadable report with a threshold",
+			cfg:     config.BuildScanConfig{Command: `echo '{}'`, FailOn: "high"},
+			wantErr: "fail_on needs grype or trivy JSON",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Run(context.Background(), Options{Config: tt.cfg, Image: "img", Dir: t.TempDir()})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRun_UnreadableReportWithoutThreshold(t *testing.T) {
+	s, err := Run(context.Background(), Options{
+		Config: config.BuildScanConfig{Command: `echo '{}'`},
+		Image:  "img",
+		Dir:    t.TempDir(),
+	})
+	require.NoError(t, err)
+	assert.False(t, s.Failed)
+	assert.Contains(t, s.ParseError, "unrecognized scan report")
+}
@@ -68,6 +68,9 @@ type IOStreams struct {
 	// terminalTheme is the detected terminal theme: "light", "dark", or "none"
 	terminalTheme string
 
+	// colorTheme is the palette name installed by ApplyTheme ("" until then).
+	colorTheme string
+
 	// Spinner state
 	progressIndicatorEnabled bool
 	activeSpinner            *spinnerRunner
@@ -80,6 +83,9 @@ type IOStreams struct {
 
 	// neverPrompt disables all interactive prompts (e.g., for CI)
 	neverPrompt bool
+
+	// jsonOutput selects machine-readable output (global --json)
+	jsonOutput bool
 }
 
 // System creates an IOStreams wired to the real system terminal.
@@ -328,6 +334,31 @@ func (s *IOStreams) ColorScheme() *ColorScheme {
 	return NewColorScheme(s.ColorEnabled(), s.TerminalTheme())
 }
 
+// ApplyTheme resolves the named color theme against this IOStreams'
+// detected terminal background, layers the palette overrides on top, and
+// installs the result for all styled output (see ApplyPalette). An invalid
+// theme or override leaves the current palette in place.
+func (s *IOStreams) ApplyTheme(theme string, overrides map[string]string) error {
+	p, err := ResolvePalette(theme, s.TerminalTheme(), overrides)
+	if err != nil {
+		return err
+	}
+	ApplyPalette(p)
+	if theme == "" {
+		theme = ThemeDefault
+	}
+	s.colorTheme = theme
+	return nil
+}
+
+// ColorTheme returns the theme installed by ApplyTheme, or ThemeDefault.
+func (s *IOStreams) ColorTheme() string {
+	if s.colorTheme == "" {
+		return ThemeDefault
+	}
+	return s.colorTheme
+}
+
 // StartProgressIndicator starts a spinner on stderr.
 //
 // Deprecated: Use StartSpinner instead.
@@ -0,0 +1,60 @@
+package iostreams
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONSchemaVersion is the schema_version stamped on every JSON envelope.
+// Bump it only when an existing kind's data changes incompatibly (a field
+// renamed, removed, or retyped); new fields and new kinds are additive.
+const JSONSchemaVersion = 1
+
+// JSONEnvelope is the top-level document machine-readable (--json) output
+// is wrapped in. Kind names the shape of Data ("container.list",
+// "monitor.status", ...), so a consumer can dispatch on it and reject a
+// schema_version it doesn't know.
+type JSONEnvelope struct {
+	SchemaVersion int    `json:"schema_version"`
+	Kind          string `json:"kind"`
+	Data          any    `json:"data"`
+}
+
+// JSONPrinter writes JSON envelopes, one compact document per line.
+type JSONPrinter struct {
+	w io.Writer
+}
+
+// NewJSONPrinter returns a JSONPrinter writing to w.
+func NewJSONPrinter(w io.Writer) *JSONPrinter {
+	return &JSONPrinter{w: w}
+}
+
+// JSONPrinter returns a JSONPrinter writing to Out.
+func (s *IOStreams) JSONPrinter() *JSONPrinter {
+	return NewJSONPrinter(s.Out)
+}
+
+// Print writes data wrapped in an envelope of the given kind. HTML
+// escaping is disabled so values like `<none>:<none>` are written literally.
+func (p *JSONPrinter) Print(kind string, data any) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(JSONEnvelope{
+		SchemaVersion: JSONSchemaVersion,
+		Kind:          kind,
+		Data:          data,
+	})
+}
+
+// SetJSONOutput switches the streams to machine-readable output. The root
+// command sets it from the global --json flag; commands that print with
+// JSONPrinter check JSONOutput.
+func (s *IOStreams) SetJSONOutput(enabled bool) {
+	s.jsonOutput = enabled
+}
+
+// JSONOutput reports whether machine-readable output was requested.
+func (s *IOStreams) JSONOutput() bool {
+	return s.jsonOutput
+}
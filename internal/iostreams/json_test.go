@@ -0,0 +1,33 @@
+package iostreams_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPrinter_Envelope(t *testing.T) {
+	ios, _, out, _ := iostreams.Test()
+
+	rows := []map[string]string{{"name": "dev", "image": "<none>:<none>"}}
+	require.NoError(t, ios.JSONPrinter().Print("container.list", rows))
+
+	assert.Equal(t,
+		`{"schema_version":1,"kind":"container.list","data":[{"image":"<none>:<none>","name":"dev"}]}`+"\n",
+		out.String())
+
+	var env iostreams.JSONEnvelope
+	require.NoError(t, json.Unmarshal(out.Bytes(), &env))
+	assert.Equal(t, iostreams.JSONSchemaVersion, env.SchemaVersion)
+	assert.Equal(t, "container.list", env.Kind)
+}
+
+func TestJSONOutput_Toggle(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	assert.False(t, ios.JSONOutput())
+	ios.SetJSONOutput(true)
+	assert.True(t, ios.JSONOutput())
+}
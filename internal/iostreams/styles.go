@@ -21,10 +21,13 @@ var (
 	ColorJet         = lipgloss.Color("#1A1A1A") // Near-black
 	ColorGunmetal    = lipgloss.Color("#2A2A2A") // Dark charcoal
 	ColorSilver      = lipgloss.Color("#A0A0A0") // Muted silver (nearest: X11 DarkGray)
+	ColorWhite       = lipgloss.Color("#FFFFFF") // Exact X11/CSS: White
 )
 
 // ─── Semantic Theme ───────────────────────────────────────────────
-// Intent-based aliases. Swap the RHS to change the entire color theme.
+// Intent-based aliases. These hold the active palette: ApplyPalette
+// reassigns them and rebuilds every style below. The initial values are
+// the dark palette (see theme.go).
 var (
 	ColorPrimary   = ColorBurntOrange // Brand primary
 	ColorSecondary = ColorDeepSkyBlue // Brand secondary
@@ -41,168 +44,264 @@ var (
 	ColorBg        = ColorJet
 	ColorBgAlt     = ColorGunmetal
 	ColorSubtle    = ColorSilver
+	ColorText      = ColorWhite // Emphasized foreground text
 )
 
 // Text styles — common text formatting.
 var (
-	TitleStyle     = lipgloss.NewStyle().Bold(true).Foreground(ColorPrimary)
-	SubtitleStyle  = lipgloss.NewStyle().Foreground(ColorSecondary)
-	ErrorStyle     = lipgloss.NewStyle().Foreground(ColorError)
-	SuccessStyle   = lipgloss.NewStyle().Foreground(ColorSuccess)
-	WarningStyle   = lipgloss.NewStyle().Foreground(ColorWarning)
-	MutedStyle     = lipgloss.NewStyle().Foreground(ColorMuted)
-	HighlightStyle = lipgloss.NewStyle().Foreground(ColorHighlight)
-	AccentStyle    = lipgloss.NewStyle().Foreground(ColorAccent)
-	DisabledStyle  = lipgloss.NewStyle().Foreground(ColorDisabled)
+	TitleStyle     lipgloss.Style
+	SubtitleStyle  lipgloss.Style
+	ErrorStyle     lipgloss.Style
+	SuccessStyle   lipgloss.Style
+	WarningStyle   lipgloss.Style
+	MutedStyle     lipgloss.Style
+	HighlightStyle lipgloss.Style
+	AccentStyle    lipgloss.Style
+	DisabledStyle  lipgloss.Style
 )
 
 // Concrete color styles — pure foreground color, no decorations.
 // Used by ColorScheme concrete color methods (Red, Blue, etc.).
 var (
-	BlueStyle = lipgloss.NewStyle().Foreground(ColorDeepSkyBlue)
-	CyanStyle = lipgloss.NewStyle().Foreground(ColorInfo)
+	BlueStyle lipgloss.Style
+	CyanStyle lipgloss.Style
 )
 
 // Border styles.
 var (
-	BorderStyle       = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
-	BorderActiveStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(ColorPrimary)
-	BorderMutedStyle  = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(ColorMuted)
+	BorderStyle       lipgloss.Style
+	BorderActiveStyle lipgloss.Style
+	BorderMutedStyle  lipgloss.Style
 )
 
 // Header styles.
 var (
+	HeaderStyle         lipgloss.Style
+	HeaderTitleStyle    lipgloss.Style
+	HeaderSubtitleStyle lipgloss.Style
+)
+
+// Panel styles.
+var (
+	PanelStyle       lipgloss.Style
+	PanelActiveStyle lipgloss.Style
+	PanelTitleStyle  lipgloss.Style
+)
+
+// List styles.
+var (
+	ListItemStyle         lipgloss.Style
+	ListItemSelectedStyle lipgloss.Style
+	ListItemDimStyle      lipgloss.Style
+)
+
+// Help bar styles.
+var (
+	HelpKeyStyle       lipgloss.Style
+	HelpDescStyle      lipgloss.Style
+	HelpSeparatorStyle lipgloss.Style
+)
+
+// Label-value pair styles.
+var (
+	LabelStyle lipgloss.Style
+	ValueStyle lipgloss.Style
+	CountStyle lipgloss.Style
+)
+
+// Status indicator styles.
+var (
+	StatusRunningStyle lipgloss.Style
+	StatusStoppedStyle lipgloss.Style
+	StatusErrorStyle   lipgloss.Style
+	StatusWarningStyle lipgloss.Style
+	StatusInfoStyle    lipgloss.Style
+)
+
+// Badge styles.
+var (
+	BadgeStyle        lipgloss.Style
+	BadgeSuccessStyle lipgloss.Style
+	BadgeWarningStyle lipgloss.Style
+	BadgeErrorStyle   lipgloss.Style
+	BadgeMutedStyle   lipgloss.Style
+)
+
+// Table styles.
+var (
+	// TableHeaderStyle for table column headers: subtle silver foreground, no bold.
+	// Headers are rendered as uppercase by the table renderer for visual distinction.
+	TableHeaderStyle lipgloss.Style
+
+	// TablePrimaryColumnStyle for the first column: primary brand color for emphasis.
+	TablePrimaryColumnStyle lipgloss.Style
+)
+
+// Miscellaneous element styles.
+var (
+	// DividerStyle for horizontal rules.
+	DividerStyle lipgloss.Style
+
+	// EmptyStateStyle for empty state messages.
+	EmptyStateStyle lipgloss.Style
+
+	// StatusBarStyle for status bar backgrounds.
+	StatusBarStyle lipgloss.Style
+
+	// TagStyle for bordered tag elements.
+	TagStyle lipgloss.Style
+)
+
+func init() {
+	buildStyles()
+}
+
+// buildStyles (re)derives every package style from the semantic colors.
+// Called at init and by ApplyPalette.
+func buildStyles() {
+	TitleStyle = lipgloss.NewStyle().Bold(true).Foreground(ColorPrimary)
+	SubtitleStyle = lipgloss.NewStyle().Foreground(ColorSecondary)
+	ErrorStyle = lipgloss.NewStyle().Foreground(ColorError)
+	SuccessStyle = lipgloss.NewStyle().Foreground(ColorSuccess)
+	WarningStyle = lipgloss.NewStyle().Foreground(ColorWarning)
+	MutedStyle = lipgloss.NewStyle().Foreground(ColorMuted)
+	HighlightStyle = lipgloss.NewStyle().Foreground(ColorHighlight)
+	AccentStyle = lipgloss.NewStyle().Foreground(ColorAccent)
+	DisabledStyle = lipgloss.NewStyle().Foreground(ColorDisabled)
+
+	BlueStyle = lipgloss.NewStyle().Foreground(ColorSecondary)
+	CyanStyle = lipgloss.NewStyle().Foreground(ColorInfo)
+
+	BorderStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
+	BorderActiveStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(ColorPrimary)
+	BorderMutedStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(ColorMuted)
+
 	HeaderStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorPrimary).
-			Padding(0, 1)
+		Bold(true).
+		Foreground(ColorPrimary).
+		Padding(0, 1)
 
 	HeaderTitleStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color("#FFFFFF"))
+		Bold(true).
+		Foreground(ColorText)
 
 	HeaderSubtitleStyle = lipgloss.NewStyle().
-				Foreground(ColorSecondary).
-				Italic(true)
-)
+		Foreground(ColorSecondary).
+		Italic(true)
 
-// Panel styles.
-var (
 	PanelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorBorder).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBorder).
+		Padding(0, 1)
 
 	PanelActiveStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(ColorPrimary).
-				Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Padding(0, 1)
 
 	PanelTitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(ColorSecondary).
-			Padding(0, 1)
-)
+		Bold(true).
+		Foreground(ColorSecondary).
+		Padding(0, 1)
 
-// List styles.
-var (
 	ListItemStyle = lipgloss.NewStyle().
-			Padding(0, 1)
+		Padding(0, 1)
 
 	ListItemSelectedStyle = lipgloss.NewStyle().
-				Foreground(ColorSelected).
-				Bold(true).
-				Padding(0, 1)
+		Foreground(ColorSelected).
+		Bold(true).
+		Padding(0, 1)
 
 	ListItemDimStyle = lipgloss.NewStyle().
-				Foreground(ColorMuted).
-				Padding(0, 1)
-)
+		Foreground(ColorMuted).
+		Padding(0, 1)
 
-// Help bar styles.
-var (
 	HelpKeyStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary).
-			Bold(true)
+		Foreground(ColorPrimary).
+		Bold(true)
 
 	HelpDescStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted)
+		Foreground(ColorMuted)
 
 	HelpSeparatorStyle = lipgloss.NewStyle().
-				Foreground(ColorBorder)
-)
+		Foreground(ColorBorder)
 
-// Label-value pair styles.
-var (
 	LabelStyle = lipgloss.NewStyle().
-			Foreground(ColorMuted).
-			Width(12)
+		Foreground(ColorMuted).
+		Width(12)
 
 	ValueStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF"))
+		Foreground(ColorText)
 
 	CountStyle = lipgloss.NewStyle().
-			Foreground(ColorPrimary).
-			Bold(true)
-)
+		Foreground(ColorPrimary).
+		Bold(true)
 
-// Status indicator styles.
-var (
 	StatusRunningStyle = lipgloss.NewStyle().
-				Foreground(ColorSuccess).
-				Bold(true)
+		Foreground(ColorSuccess).
+		Bold(true)
 
 	StatusStoppedStyle = lipgloss.NewStyle().
-				Foreground(ColorMuted)
+		Foreground(ColorMuted)
 
 	StatusErrorStyle = lipgloss.NewStyle().
-				Foreground(ColorError).
-				Bold(true)
+		Foreground(ColorError).
+		Bold(true)
 
 	StatusWarningStyle = lipgloss.NewStyle().
-				Foreground(ColorWarning)
+		Foreground(ColorWarning)
 
 	StatusInfoStyle = lipgloss.NewStyle().
-			Foreground(ColorInfo)
-)
+		Foreground(ColorInfo)
 
-// Badge styles.
-var (
+	// Badge text sits on a saturated background, so it stays fixed
+	// regardless of the palette's foreground color.
 	BadgeStyle = lipgloss.NewStyle().
-			Padding(0, 1).
-			Background(ColorPrimary).
-			Foreground(lipgloss.Color("#FFFFFF"))
+		Padding(0, 1).
+		Background(ColorPrimary).
+		Foreground(ColorWhite)
 
 	BadgeSuccessStyle = lipgloss.NewStyle().
-				Padding(0, 1).
-				Background(ColorSuccess).
-				Foreground(lipgloss.Color("#FFFFFF"))
+		Padding(0, 1).
+		Background(ColorSuccess).
+		Foreground(ColorWhite)
 
 	BadgeWarningStyle = lipgloss.NewStyle().
-				Padding(0, 1).
-				Background(ColorWarning).
-				Foreground(lipgloss.Color("#000000"))
+		Padding(0, 1).
+		Background(ColorWarning).
+		Foreground(lipgloss.Color("#000000"))
 
 	BadgeErrorStyle = lipgloss.NewStyle().
-			Padding(0, 1).
-			Background(ColorError).
-			Foreground(lipgloss.Color("#FFFFFF"))
+		Padding(0, 1).
+		Background(ColorError).
+		Foreground(ColorWhite)
 
 	BadgeMutedStyle = lipgloss.NewStyle().
-			Padding(0, 1).
-			Background(ColorMuted).
-			Foreground(lipgloss.Color("#FFFFFF"))
-)
+		Padding(0, 1).
+		Background(ColorMuted).
+		Foreground(ColorWhite)
 
-// Table styles.
-var (
-	// TableHeaderStyle for table column headers: subtle silver foreground, no bold.
-	// Headers are rendered as uppercase by the table renderer for visual distinction.
 	TableHeaderStyle = lipgloss.NewStyle().Foreground(ColorSubtle)
-
-	// TablePrimaryColumnStyle for the first column: primary brand color for emphasis.
 	TablePrimaryColumnStyle = lipgloss.NewStyle().Foreground(ColorPrimary)
-)
+
+	DividerStyle = lipgloss.NewStyle().
+		Foreground(ColorBorder)
+
+	EmptyStateStyle = lipgloss.NewStyle().
+		Foreground(ColorMuted).
+		Italic(true).
+		Align(lipgloss.Center)
+
+	StatusBarStyle = lipgloss.NewStyle().
+		Background(ColorBgAlt).
+		Foreground(ColorText).
+		Padding(0, 1)
+
+	TagStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Padding(0, 1)
+}
 
 // RenderFixedWidth renders text at a fixed width using lipgloss.
 // Used by tui.TablePrinter to set column widths without importing lipgloss directly.
@@ -210,28 +309,6 @@ func RenderFixedWidth(s string, width int) string {
 	return lipgloss.NewStyle().Width(width).Render(s)
 }
 
-// DividerStyle for horizontal rules.
-var DividerStyle = lipgloss.NewStyle().
-	Foreground(ColorBorder)
-
-// EmptyStateStyle for empty state messages.
-var EmptyStateStyle = lipgloss.NewStyle().
-	Foreground(ColorMuted).
-	Italic(true).
-	Align(lipgloss.Center)
-
-// StatusBarStyle for status bar backgrounds.
-var StatusBarStyle = lipgloss.NewStyle().
-	Background(ColorBgAlt).
-	Foreground(lipgloss.Color("#FFFFFF")).
-	Padding(0, 1)
-
-// TagStyle for bordered tag elements.
-var TagStyle = lipgloss.NewStyle().
-	Border(lipgloss.RoundedBorder()).
-	BorderForeground(ColorPrimary).
-	Padding(0, 1)
-
 // StatusStyle returns a style appropriate for running/stopped status.
 func StatusStyle(running bool) lipgloss.Style {
 	if running {
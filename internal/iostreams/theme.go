@@ -0,0 +1,265 @@
+package iostreams
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Built-in theme names.
+const (
+	// ThemeDefault picks ThemeLight or ThemeDark from the detected terminal
+	// background.
+	ThemeDefault      = "default"
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+)
+
+// Palette color keys, as used for user overrides (settings.yaml ui.palette).
+const (
+	PalettePrimary   = "primary"
+	PaletteSecondary = "secondary"
+	PaletteSuccess   = "success"
+	PaletteWarning   = "warning"
+	PaletteError     = "error"
+	PaletteMuted     = "muted"
+	PaletteHighlight = "highlight"
+	PaletteInfo      = "info"
+	PaletteAccent    = "accent"
+	PaletteBorder    = "border"
+	PaletteSubtle    = "subtle"
+	PaletteText      = "text"
+)
+
+// Palette is a complete set of semantic colors. ApplyPalette installs one as
+// the package-wide theme.
+type Palette struct {
+	Primary   lipgloss.Color
+	Secondary lipgloss.Color
+	Success   lipgloss.Color
+	Warning   lipgloss.Color
+	Error     lipgloss.Color
+	Muted     lipgloss.Color
+	Highlight lipgloss.Color
+	Info      lipgloss.Color
+	Disabled  lipgloss.Color
+	Selected  lipgloss.Color
+	Border    lipgloss.Color
+	Accent    lipgloss.Color
+	Bg        lipgloss.Color
+	BgAlt     lipgloss.Color
+	Subtle    lipgloss.Color
+	Text      lipgloss.Color
+}
+
+// darkPalette is the original clawker palette, tuned for dark backgrounds.
+var darkPalette = Palette{
+	Primary:   ColorBurntOrange,
+	Secondary: ColorDeepSkyBlue,
+	Success:   ColorEmerald,
+	Warning:   ColorAmber,
+	Error:     ColorHotPink,
+	Muted:     ColorDimGray,
+	Highlight: ColorOrchid,
+	Info:      ColorSkyBlue,
+	Disabled:  ColorCharcoal,
+	Selected:  ColorGold,
+	Border:    ColorOnyx,
+	Accent:    ColorSalmon,
+	Bg:        ColorJet,
+	BgAlt:     ColorGunmetal,
+	Subtle:    ColorSilver,
+	Text:      ColorWhite,
+}
+
+// lightPalette uses deeper tones that stay legible on white backgrounds.
+var lightPalette = Palette{
+	Primary:   lipgloss.Color("#C2410C"),
+	Secondary: lipgloss.Color("#0369A1"),
+	Success:   lipgloss.Color("#047857"),
+	Warning:   lipgloss.Color("#B45309"),
+	Error:     lipgloss.Color("#BE123C"),
+	Muted:     lipgloss.Color("#6B7280"),
+	Highlight: lipgloss.Color("#7E22CE"),
+	Info:      lipgloss.Color("#0E7490"),
+	Disabled:  lipgloss.Color("#9CA3AF"),
+	Selected:  lipgloss.Color("#A16207"),
+	Border:    lipgloss.Color("#D1D5DB"),
+	Accent:    lipgloss.Color("#DC2626"),
+	Bg:        lipgloss.Color("#FFFFFF"),
+	BgAlt:     lipgloss.Color("#F3F4F6"),
+	Subtle:    lipgloss.Color("#4B5563"),
+	Text:      lipgloss.Color("#111827"),
+}
+
+// highContrastPalette sticks to fully saturated colors and avoids grays
+// that wash out, for low-vision users and low-quality displays.
+var highContrastPalette = Palette{
+	Primary:   lipgloss.Color("#FFAF00"),
+	Secondary: lipgloss.Color("#00FFFF"),
+	Success:   lipgloss.Color("#00FF00"),
+	Warning:   lipgloss.Color("#FFFF00"),
+	Error:     lipgloss.Color("#FF0000"),
+	Muted:     lipgloss.Color("#D0D0D0"),
+	Highlight: lipgloss.Color("#FF00FF"),
+	Info:      lipgloss.Color("#00FFFF"),
+	Disabled:  lipgloss.Color("#A0A0A0"),
+	Selected:  lipgloss.Color("#FFFF00"),
+	Border:    lipgloss.Color("#FFFFFF"),
+	Accent:    lipgloss.Color("#FF8700"),
+	Bg:        lipgloss.Color("#000000"),
+	BgAlt:     lipgloss.Color("#000000"),
+	Subtle:    lipgloss.Color("#E0E0E0"),
+	Text:      lipgloss.Color("#FFFFFF"),
+}
+
+// Themes returns the built-in theme names in display order.
+func Themes() []string {
+	return []string{ThemeDefault, ThemeDark, ThemeLight, ThemeHighContrast}
+}
+
+// PaletteKeys returns the color keys accepted by ResolvePalette overrides.
+func PaletteKeys() []string {
+	return []string{
+		PalettePrimary, PaletteSecondary, PaletteSuccess, PaletteWarning,
+		PaletteError, PaletteMuted, PaletteHighlight, PaletteInfo,
+		PaletteAccent, PaletteBorder, PaletteSubtle, PaletteText,
+	}
+}
+
+// hexColorRe matches #RGB and #RRGGBB color values.
+var hexColorRe = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ResolvePalette returns the palette for theme with overrides applied on top.
+// An empty theme means ThemeDefault, which follows terminalTheme ("light"
+// selects the light palette, anything else the dark one). Override keys are
+// the Palette* constants; values are hex colors ("#RGB", "#RRGGBB") or ANSI
+// color numbers ("0"–"255"). Empty values are ignored.
+func ResolvePalette(theme, terminalTheme string, overrides map[string]string) (Palette, error) {
+	var p Palette
+	switch theme {
+	case "", ThemeDefault:
+		if terminalTheme == ThemeLight {
+			p = lightPalette
+		} else {
+			p = darkPalette
+		}
+	case ThemeDark:
+		p = darkPalette
+	case ThemeLight:
+		p = lightPalette
+	case ThemeHighContrast:
+		p = highContrastPalette
+	default:
+		return Palette{}, fmt.Errorf("unknown theme %q (valid: %s)", theme, strings.Join(Themes(), ", "))
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(overrides)) {
+		value := strings.TrimSpace(overrides[key])
+		if value == "" {
+			continue
+		}
+		slot := p.slot(key)
+		if slot == nil {
+			return Palette{}, fmt.Errorf("unknown palette color %q (valid: %s)", key, strings.Join(PaletteKeys(), ", "))
+		}
+		if err := validateColor(value); err != nil {
+			return Palette{}, fmt.Errorf("palette %s: %w", key, err)
+		}
+		*slot = lipgloss.Color(value)
+	}
+	return p, nil
+}
+
+// slot returns the field addressed by a user-facing palette key, or nil.
+func (p *Palette) slot(key string) *lipgloss.Color {
+	switch key {
+	case PalettePrimary:
+		return &p.Primary
+	case PaletteSecondary:
+		return &p.Secondary
+	case PaletteSuccess:
+		return &p.Success
+	case PaletteWarning:
+		return &p.Warning
+	case PaletteError:
+		return &p.Error
+	case PaletteMuted:
+		return &p.Muted
+	case PaletteHighlight:
+		return &p.Highlight
+	case PaletteInfo:
+		return &p.Info
+	case PaletteAccent:
+		return &p.Accent
+	case PaletteBorder:
+		return &p.Border
+	case PaletteSubtle:
+		return &p.Subtle
+	case PaletteText:
+		return &p.Text
+	}
+	return nil
+}
+
+func validateColor(value string) error {
+	if hexColorRe.MatchString(value) {
+		return nil
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 255 {
+		return nil
+	}
+	return fmt.Errorf("invalid color %q: want #RGB, #RRGGBB, or an ANSI color number 0-255", value)
+}
+
+// ActivePalette returns the palette currently backing the package styles.
+func ActivePalette() Palette {
+	return Palette{
+		Primary:   ColorPrimary,
+		Secondary: ColorSecondary,
+		Success:   ColorSuccess,
+		Warning:   ColorWarning,
+		Error:     ColorError,
+		Muted:     ColorMuted,
+		Highlight: ColorHighlight,
+		Info:      ColorInfo,
+		Disabled:  ColorDisabled,
+		Selected:  ColorSelected,
+		Border:    ColorBorder,
+		Accent:    ColorAccent,
+		Bg:        ColorBg,
+		BgAlt:     ColorBgAlt,
+		Subtle:    ColorSubtle,
+		Text:      ColorText,
+	}
+}
+
+// ApplyPalette installs p as the package-wide theme: it reassigns the
+// semantic Color* variables and rebuilds every exported style. Styles are
+// package globals, so call it once at startup, before any output is
+// rendered and before goroutines that render are started.
+func ApplyPalette(p Palette) {
+	ColorPrimary = p.Primary
+	ColorSecondary = p.Secondary
+	ColorSuccess = p.Success
+	ColorWarning = p.Warning
+	ColorError = p.Error
+	ColorMuted = p.Muted
+	ColorHighlight = p.Highlight
+	ColorInfo = p.Info
+	ColorDisabled = p.Disabled
+	ColorSelected = p.Selected
+	ColorBorder = p.Border
+	ColorAccent = p.Accent
+	ColorBg = p.Bg
+	ColorBgAlt = p.BgAlt
+	ColorSubtle = p.Subtle
+	ColorText = p.Text
+	buildStyles()
+}
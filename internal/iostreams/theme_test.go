@@ -0,0 +1,104 @@
+package iostreams
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restorePalette puts the palette active at call time back after the test.
+func restorePalette(t *testing.T) {
+	t.Helper()
+	active := ActivePalette()
+	t.Cleanup(func() { ApplyPalette(active) })
+}
+
+func TestResolvePalette(t *testing.T) {
+	tests := []struct {
+		name          string
+		theme         string
+		terminalTheme string
+		want          Palette
+	}{
+		{name: "empty is default", theme: "", terminalTheme: "dark", want: darkPalette},
+		{name: "default on dark terminal", theme: ThemeDefault, terminalTheme: "dark", want: darkPalette},
+		{name: "default on light terminal", theme: ThemeDefault, terminalTheme: "light", want: lightPalette},
+		{name: "default without terminal", theme: ThemeDefault, terminalTheme: "none", want: darkPalette},
+		{name: "explicit dark on light terminal", theme: ThemeDark, terminalTheme: "light", want: darkPalette},
+		{name: "light", theme: ThemeLight, terminalTheme: "dark", want: lightPalette},
+		{name: "high contrast", theme: ThemeHighContrast, terminalTheme: "dark", want: highContrastPalette},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolvePalette(tt.theme, tt.terminalTheme, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolvePalette_Overrides(t *testing.T) {
+	got, err := ResolvePalette(ThemeLight, "", map[string]string{
+		PalettePrimary: "#123456",
+		PaletteError:   "196",
+		PaletteMuted:   "",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, lipgloss.Color("#123456"), got.Primary)
+	assert.Equal(t, lipgloss.Color("196"), got.Error)
+	assert.Equal(t, lightPalette.Muted, got.Muted, "empty override keeps the theme color")
+	assert.Equal(t, lightPalette.Success, got.Success)
+}
+
+func TestResolvePalette_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		theme     string
+		overrides map[string]string
+		wantErr   string
+	}{
+		{name: "unknown theme", theme: "neon", wantErr: `unknown theme "neon"`},
+		{name: "unknown color key", overrides: map[string]string{"brand": "#fff"}, wantErr: `unknown palette color "brand"`},
+		{name: "named color", overrides: map[string]string{PalettePrimary: "orange"}, wantErr: `palette primary: invalid color "orange"`},
+		{name: "short hex", overrides: map[string]string{PalettePrimary: "#12"}, wantErr: "invalid color"},
+		{name: "ansi out of range", overrides: map[string]string{PalettePrimary: "256"}, wantErr: "invalid color"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ResolvePalette(tt.theme, "", tt.overrides)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestApplyPalette_RebuildsStyles(t *testing.T) {
+	restorePalette(t)
+
+	ApplyPalette(highContrastPalette)
+
+	assert.Equal(t, highContrastPalette, ActivePalette())
+	assert.Equal(t, highContrastPalette.Primary, TitleStyle.GetForeground())
+	assert.Equal(t, highContrastPalette.Error, ErrorStyle.GetForeground())
+	assert.Equal(t, highContrastPalette.Secondary, BlueStyle.GetForeground())
+	assert.Equal(t, highContrastPalette.Text, ValueStyle.GetForeground())
+	assert.Equal(t, highContrastPalette.Border, PanelStyle.GetBorderTopForeground())
+}
+
+func TestIOStreams_ApplyTheme(t *testing.T) {
+	restorePalette(t)
+	ios, _, _, _ := Test()
+	assert.Equal(t, ThemeDefault, ios.ColorTheme())
+
+	require.NoError(t, ios.ApplyTheme(ThemeLight, map[string]string{PaletteAccent: "#ABCDEF"}))
+	assert.Equal(t, ThemeLight, ios.ColorTheme())
+	assert.Equal(t, lightPalette.Primary, ColorPrimary)
+	assert.Equal(t, lipgloss.Color("#ABCDEF"), ColorAccent)
+
+	err := ios.ApplyTheme("neon", nil)
+	require.Error(t, err)
+	assert.Equal(t, ThemeLight, ios.ColorTheme(), "a failed apply keeps the current theme")
+	assert.Equal(t, lightPalette.Primary, ColorPrimary)
+}
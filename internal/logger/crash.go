@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// defaultCrashRingSize is how many recent records New keeps in memory for
+// crash dumps when Options.CrashRingSize is zero.
+const defaultCrashRingSize = 500
+
+// ErrNoCrashRing is returned by DumpCrash on a logger built without a
+// crash ring (Nop, NewWriter, or New with a negative CrashRingSize).
+var ErrNoCrashRing = errors.New("logger: no crash ring")
+
+// crashRing keeps the most recent records at every level — debug included,
+// whatever SetLevel filters out of the file — so a crash dump carries the
+// context leading up to it. A fatal record dumps the ring as it is written,
+// since zerolog exits the process right after.
+type crashRing struct {
+	dir string
+
+	mu   sync.Mutex
+	recs [][]byte
+	next int
+	full bool
+}
+
+func newCrashRing(size int, dir string) *crashRing {
+	return &crashRing{dir: dir, recs: make([][]byte, size)}
+}
+
+func (r *crashRing) Write(p []byte) (int, error) {
+	return r.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter. It never fails, so a full
+// disk or a broken sink elsewhere can't surface through the ring.
+func (r *crashRing) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	rec := make([]byte, len(p))
+	copy(rec, p)
+	r.mu.Lock()
+	r.recs[r.next] = rec
+	r.next = (r.next + 1) % len(r.recs)
+	if r.next == 0 {
+		r.full = true
+	}
+	r.mu.Unlock()
+
+	if level == zerolog.FatalLevel {
+		if path, err := r.dump("fatal log record", debug.Stack()); err == nil {
+			fmt.Fprintf(os.Stderr, "crash report written to %s\n", path)
+		}
+	}
+	return len(p), nil
+}
+
+// snapshot returns the buffered records, oldest first.
+func (r *crashRing) snapshot() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([][]byte(nil), r.recs[:r.next]...)
+	}
+	out := make([][]byte, 0, len(r.recs))
+	out = append(out, r.recs[r.next:]...)
+	return append(out, r.recs[:r.next]...)
+}
+
+// dump writes reason, stack, and the buffered records to
+// crash-<timestamp>.log in the ring's directory and returns its path.
+func (r *crashRing) dump(reason string, stack []byte) (string, error) {
+	now := time.Now().UTC()
+	path := filepath.Join(r.dir, "crash-"+now.Format("20060102T150405.000Z")+".log")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("logger: create crash dump: %w", err)
+	}
+	recs := r.snapshot()
+	writeErr := writeCrash(f, now, reason, stack, recs)
+	if closeErr := f.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		return "", fmt.Errorf("logger: write crash dump: %w", writeErr)
+	}
+	return path, nil
+}
+
+func writeCrash(w io.Writer, now time.Time, reason string, stack []byte, recs [][]byte) error {
+	if _, err := fmt.Fprintf(w, "time: %s\nreason: %s\n\n%s\n", now.Format(time.RFC3339Nano), reason, stack); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "--- last %d log records, oldest first ---\n", len(recs)); err != nil {
+		return err
+	}
+	for _, rec := range recs {
+		if _, err := w.Write(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DumpCrash writes a crash report for the panic value v — the stack, then
+// the recent records from the crash ring — to crash-<timestamp>.log next
+// to the log file, and returns its path.
+func (l *Logger) DumpCrash(v any, stack []byte) (string, error) {
+	if l.ring == nil {
+		return "", ErrNoCrashRing
+	}
+	return l.ring.dump(fmt.Sprintf("panic: %v", v), stack)
+}
+
+// ReportPanic is the top-level crash hook: it writes a crash dump for the
+// panic value v and tells w where it went. Call it from a deferred recover
+// at the process entry point and re-panic afterwards, so the exit status
+// and Go's own trace are unchanged:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			log.ReportPanic(os.Stderr, r)
+//			panic(r)
+//		}
+//	}()
+func (l *Logger) ReportPanic(w io.Writer, v any) {
+	path, err := l.DumpCrash(v, debug.Stack())
+	switch {
+	case errors.Is(err, ErrNoCrashRing):
+	case err != nil:
+		fmt.Fprintf(w, "failed to write crash report: %v\n", err)
+	default:
+		fmt.Fprintf(w, "crash report written to %s\n", path)
+	}
+}
@@ -0,0 +1,153 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func readCrashDump(t *testing.T, dir string) string {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, "crash-*.log"))
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("want 1 crash dump in %s, got %d", dir, len(matches))
+	}
+	content, err := os.ReadFile(matches[0])
+	if err != nil {
+		t.Fatalf("read crash dump: %v", err)
+	}
+	return string(content)
+}
+
+func TestDumpCrash_KeepsFilteredDebugRecords(t *testing.T) {
+	resetLevel(t)
+	dir := t.TempDir()
+	l, err := New(Options{LogsDir: dir})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer l.Close(context.Background())
+
+	if err := SetLevel("info"); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+	l.With("component", "test").Debug().Msg("context-before-crash")
+	l.Info().Msg("info-line")
+
+	path, err := l.DumpCrash("boom", []byte("goroutine 1 [running]:"))
+	if err != nil {
+		t.Fatalf("DumpCrash: %v", err)
+	}
+	if filepath.Dir(path) != dir {
+		t.Errorf("crash dump at %s, want it in %s", path, dir)
+	}
+
+	dump := readCrashDump(t, dir)
+	for _, want := range []string{"panic: boom", "goroutine 1 [running]:", "context-before-crash", "info-line"} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("crash dump missing %q:\n%s", want, dump)
+		}
+	}
+	file, err := os.ReadFile(l.LogFilePath())
+	if err != nil {
+		t.Fatalf("read log file: %v", err)
+	}
+	if strings.Contains(string(file), "context-before-crash") {
+		t.Error("debug record reached the log file at level info")
+	}
+}
+
+func TestCrashRing_KeepsNewestInOrder(t *testing.T) {
+	r := newCrashRing(3, t.TempDir())
+	for _, rec := range []string{"a\n", "b\n", "c\n", "d\n", "e\n"} {
+		if _, err := r.Write([]byte(rec)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	var got []string
+	for _, rec := range r.snapshot() {
+		got = append(got, string(rec))
+	}
+	if strings.Join(got, "") != "c\nd\ne\n" {
+		t.Errorf("snapshot = %q, want c, d, e", got)
+	}
+}
+
+func TestCrashRing_FatalDumps(t *testing.T) {
+	dir := t.TempDir()
+	l, err := New(Options{LogsDir: dir})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer l.Close(context.Background())
+
+	prevExit := zerolog.FatalExitFunc
+	zerolog.FatalExitFunc = func() {}
+	t.Cleanup(func() { zerolog.FatalExitFunc = prevExit })
+
+	l.Debug().Msg("leading-up")
+	l.Fatal().Msg("cannot continue")
+
+	dump := readCrashDump(t, dir)
+	if !strings.Contains(dump, "leading-up") || !strings.Contains(dump, "cannot continue") {
+		t.Errorf("fatal crash dump missing records:\n%s", dump)
+	}
+}
+
+func TestReportPanic(t *testing.T) {
+	dir := t.TempDir()
+	l, err := New(Options{LogsDir: dir})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer l.Close(context.Background())
+
+	var out bytes.Buffer
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				l.ReportPanic(&out, r)
+			}
+		}()
+		panic("kaboom")
+	}()
+
+	if !strings.Contains(out.String(), "crash report written to "+dir) {
+		t.Errorf("ReportPanic output = %q", out.String())
+	}
+	dump := readCrashDump(t, dir)
+	if !strings.Contains(dump, "panic: kaboom") || !strings.Contains(dump, "TestReportPanic") {
+		t.Errorf("crash dump missing panic value or stack:\n%s", dump)
+	}
+}
+
+func TestDumpCrash_NoRing(t *testing.T) {
+	if _, err := Nop().DumpCrash("x", nil); !errors.Is(err, ErrNoCrashRing) {
+		t.Errorf("Nop DumpCrash err = %v, want ErrNoCrashRing", err)
+	}
+
+	dir := t.TempDir()
+	l, err := New(Options{LogsDir: dir, CrashRingSize: -1})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer l.Close(context.Background())
+	if _, err := l.DumpCrash("x", nil); !errors.Is(err, ErrNoCrashRing) {
+		t.Errorf("disabled ring DumpCrash err = %v, want ErrNoCrashRing", err)
+	}
+
+	var out bytes.Buffer
+	l.ReportPanic(&out, "x")
+	if out.Len() != 0 {
+		t.Errorf("ReportPanic without a ring wrote %q", out.String())
+	}
+}
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/rs/zerolog"
+)
+
+// Levels lists the level names SetLevel accepts, most to least verbose.
+var Levels = []string{"debug", "info", "warn", "error"}
+
+// minLevel is the process-wide minimum level the sinks write. Records
+// below it are still built and reach the crash ring; only the file,
+// stdout, and OTEL sinks drop them.
+var minLevel atomic.Int32 // zerolog.DebugLevel == 0
+
+// SetLevel sets the minimum level every Logger in the process writes to
+// its sinks. Loggers are built at debug, so this is the only filter; it
+// starts at debug and changes only through SetLevel. The crash ring keeps
+// every record regardless. Safe for concurrent use.
+func SetLevel(level string) error {
+	lvl, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	minLevel.Store(int32(lvl))
+	return nil
+}
+
+// Level returns the name of the process-wide minimum level.
+func Level() string {
+	return zerolog.Level(minLevel.Load()).String()
+}
+
+// ValidLevel reports whether SetLevel accepts level.
+func ValidLevel(level string) bool {
+	_, err := parseLevel(level)
+	return err == nil
+}
+
+func parseLevel(level string) (zerolog.Level, error) {
+	switch level {
+	case "debug":
+		return zerolog.DebugLevel, nil
+	case "info":
+		return zerolog.InfoLevel, nil
+	case "warn":
+		return zerolog.WarnLevel, nil
+	case "error":
+		return zerolog.ErrorLevel, nil
+	}
+	return zerolog.NoLevel, fmt.Errorf("logger: unknown level %q (want debug, info, warn, or error)", level)
+}
+
+// levelFilter drops records below the process-wide minimum level before
+// they reach w. Level-less records (zerolog's Log) always pass.
+type levelFilter struct {
+	w zerolog.LevelWriter
+}
+
+func (f levelFilter) Write(p []byte) (int, error) {
+	return f.w.Write(p)
+}
+
+func (f levelFilter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level != zerolog.NoLevel && level < zerolog.Level(minLevel.Load()) {
+		return len(p), nil
+	}
+	return f.w.WriteLevel(level, p)
+}
+
+// WatchLevelSignal toggles the process-wide level between debug and info on
+// every SIGUSR1 until ctx is done, logging each change to log. Any level
+// other than debug toggles to debug. For daemons that own their signals;
+// clawkerd forwards SIGUSR1 to its child and takes level changes over the
+// Session instead.
+func WatchLevelSignal(ctx context.Context, log *Logger) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1)
+	go func() {
+		defer signal.Stop(sigCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigCh:
+				prev := Level()
+				next := "debug"
+				if prev == "debug" {
+					next = "info"
+				}
+				_ = SetLevel(next)
+				log.Info().
+					Str("event", "log_level_changed").
+					Str("previous_level", prev).
+					Str("level", next).
+					Str("trigger", "SIGUSR1").
+					Msg("log level changed")
+			}
+		}
+	}()
+}
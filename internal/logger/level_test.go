@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func resetLevel(t *testing.T) {
+	t.Helper()
+	prev := Level()
+	t.Cleanup(func() { _ = SetLevel(prev) })
+}
+
+func TestSetLevel_FiltersEveryLogger(t *testing.T) {
+	resetLevel(t)
+	var buf bytes.Buffer
+	l := NewWriter(&buf).With("component", "test")
+
+	if got := Level(); got != "debug" {
+		t.Fatalf("default Level() = %q, want debug", got)
+	}
+	if err := SetLevel("warn"); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+	if got := Level(); got != "warn" {
+		t.Fatalf("Level() = %q, want warn", got)
+	}
+
+	l.Debug().Msg("debug-line")
+	l.Info().Msg("info-line")
+	l.Warn().Msg("warn-line")
+
+	out := buf.String()
+	if strings.Contains(out, "debug-line") || strings.Contains(out, "info-line") {
+		t.Errorf("records below warn were written: %s", out)
+	}
+	if !strings.Contains(out, "warn-line") {
+		t.Errorf("warn record missing: %s", out)
+	}
+}
+
+func TestSetLevel_Unknown(t *testing.T) {
+	resetLevel(t)
+	if err := SetLevel("trace"); err == nil {
+		t.Fatal("expected error for unsupported level")
+	}
+	if ValidLevel("verbose") {
+		t.Error("ValidLevel(verbose) = true")
+	}
+	for _, lvl := range Levels {
+		if !ValidLevel(lvl) {
+			t.Errorf("ValidLevel(%q) = false", lvl)
+		}
+	}
+	if got := Level(); got != "debug" {
+		t.Errorf("Level() = %q after failed SetLevel, want debug", got)
+	}
+}
+
+func TestWatchLevelSignal_Toggles(t *testing.T) {
+	resetLevel(t)
+	var buf bytes.Buffer
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	WatchLevelSignal(ctx, NewWriter(&buf))
+
+	for _, want := range []string{"info", "debug"} {
+		if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+			t.Fatalf("send SIGUSR1: %v", err)
+		}
+		deadline := time.Now().Add(2 * time.Second)
+		for Level() != want && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		if got := Level(); got != want {
+			t.Fatalf("Level() = %q after SIGUSR1, want %q", got, want)
+		}
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
 	"google.golang.org/grpc/credentials"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -38,6 +39,8 @@ type Logger struct {
 	zl       zerolog.Logger
 	fw       *lumberjack.Logger
 	provider *sdklog.LoggerProvider
+	tracer   *sdktrace.TracerProvider // nil unless OtelOptions.Traces
+	ring     *crashRing               // nil unless built by New with a crash ring
 
 	// base is the field-less root logger (sinks + timestamp, no With
 	// fields). With rebuilds zl from base each call so a repeated key
@@ -103,6 +106,12 @@ type Options struct {
 	// Otel configures the OTEL zerolog bridge. Nil disables OTEL export.
 	Otel *OtelOptions
 
+	// CrashRingSize is how many recent records (at every level, whatever
+	// SetLevel filters out of the sinks) are kept in memory for DumpCrash
+	// and fatal records. Zero means the default of 500; negative disables
+	// the ring.
+	CrashRingSize int
+
 	// EchoStdout mirrors every record to os.Stdout in addition to the
 	// file (and OTEL bridge if configured). Intended for containerized
 	// daemons whose structured logs should also surface in
@@ -130,6 +139,13 @@ type OtelOptions struct {
 	// (host CLI, set in internal/cmd/factory/default.go).
 	ServiceName string
 
+	// Traces also builds an OTLP/gRPC span exporter on the same endpoint,
+	// credentials, and resource, exposed through Logger.TracerProvider.
+	// Only for collectors whose receiver runs a traces pipeline — the
+	// untrusted otlp receiver the host CLI targets does; otlp/infra does
+	// not.
+	Traces bool
+
 	// mTLS configuration. Two mutually-exclusive shapes:
 	//
 	//   - File-path triple (CACertFile + ClientCertFile + ClientKeyFile).
@@ -179,6 +195,13 @@ func (o *Options) maxAgeDays() int {
 	return o.MaxAgeDays
 }
 
+func (o *Options) crashRingSize() int {
+	if o.CrashRingSize == 0 {
+		return defaultCrashRingSize
+	}
+	return o.CrashRingSize
+}
+
 func (o *Options) maxBackups() int {
 	if o.MaxBackups <= 0 {
 		return defaultLogMaxBackups
@@ -200,7 +223,7 @@ func Nop() *Logger {
 // for example, writing to os.Stderr when the log directory is unavailable.
 // Also useful in tests that capture output via a *bytes.Buffer.
 func NewWriter(w io.Writer) *Logger {
-	zl := zerolog.New(w).
+	zl := zerolog.New(levelFilter{w: levelWriter(w)}).
 		Level(zerolog.DebugLevel).
 		With().
 		Timestamp().
@@ -300,13 +323,30 @@ func New(opts Options) (*Logger, error) {
 			l.provider = provider
 			sinks = append(sinks, newOtelLogWriter(provider.Logger("clawker")))
 		}
+		if opts.Otel.Traces {
+			tracer, err := newOtelTracerProvider(opts.Otel)
+			if err != nil {
+				fallbackZL.Warn().Err(err).Msg("OTEL tracing unavailable, continuing without spans")
+				fmt.Fprintf(os.Stderr, "warning: OTEL tracing unavailable, continuing without spans: %v\n", err)
+			} else {
+				l.tracer = tracer
+			}
+		}
 	}
 
-	var writer io.Writer
+	var sink io.Writer
 	if len(sinks) == 1 {
-		writer = sinks[0]
+		sink = sinks[0]
 	} else {
-		writer = zerolog.MultiLevelWriter(sinks...)
+		sink = zerolog.MultiLevelWriter(sinks...)
+	}
+
+	// The sinks sit behind the SetLevel filter; the crash ring sits beside
+	// it and sees every record.
+	var writer io.Writer = levelFilter{w: levelWriter(sink)}
+	if size := opts.crashRingSize(); size > 0 {
+		l.ring = newCrashRing(size, opts.LogsDir)
+		writer = zerolog.MultiLevelWriter(writer, l.ring)
 	}
 
 	zl := zerolog.New(writer).
@@ -343,6 +383,8 @@ func (l *Logger) With(keyvals ...any) *Logger {
 		zl:       ctx.Logger(),
 		fw:       l.fw,
 		provider: l.provider,
+		tracer:   l.tracer,
+		ring:     l.ring,
 		base:     l.base,
 		fields:   fields,
 	}
@@ -377,7 +419,8 @@ func (l *Logger) LogFilePath() string {
 	return ""
 }
 
-// Close flushes pending OTEL batches and closes the file writer.
+// Close flushes pending OTEL batches (logs and, with Traces, spans) and
+// closes the file writer.
 // Safe to call multiple times. Safe to call on a Nop logger.
 func (l *Logger) Close(ctx context.Context) error {
 	l.mu.Lock()
@@ -388,7 +431,7 @@ func (l *Logger) Close(ctx context.Context) error {
 	}
 	l.closed = true
 
-	var provErr, fwErr error
+	var provErr, tracerErr, fwErr error
 
 	if l.provider != nil {
 		// ctx is the flush deadline. Shutdown honors it: a canceled or expired
@@ -403,13 +446,20 @@ func (l *Logger) Close(ctx context.Context) error {
 		}
 	}
 
+	if l.tracer != nil {
+		// Same deadline semantics as the log provider above.
+		if err := l.tracer.Shutdown(ctx); err != nil {
+			tracerErr = fmt.Errorf("logger: shutdown OTEL tracer provider: %w", err)
+		}
+	}
+
 	if l.fw != nil {
 		if err := l.fw.Close(); err != nil {
 			fwErr = fmt.Errorf("logger: close file writer: %w", err)
 		}
 	}
 
-	return errors.Join(provErr, fwErr)
+	return errors.Join(provErr, tracerErr, fwErr)
 }
 
 // absorbingWriter forwards writes to an inner writer but always
@@ -439,31 +489,14 @@ func newOtelProvider(cfg *OtelOptions, fileLogger zerolog.Logger) (*sdklog.Logge
 	exporterOpts := []otlploggrpc.Option{
 		otlploggrpc.WithEndpoint(cfg.Endpoint),
 	}
-
-	hasPathTriple := cfg.ClientCertFile != "" || cfg.ClientKeyFile != "" || cfg.CACertFile != ""
+	creds, insecure, err := otelCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
 	switch {
-	case cfg.TLSConfig != nil && hasPathTriple:
-		// Path triple and TLSConfig together are a wiring bug — the
-		// caller has two trust anchors and we'd silently pick one. Fail
-		// loud so the operator can resolve the conflict.
-		return nil, fmt.Errorf("OTEL mTLS: TLSConfig and file-path triple are mutually exclusive")
-	case cfg.TLSConfig != nil:
-		// In-process tls.Config — typically minted by
-		// internal/controlplane/otelcerts.Service.LoadTLSConfig with a
-		// GetClientCertificate hook that re-mints per handshake.
-		exporterOpts = append(exporterOpts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(cfg.TLSConfig)))
-	case hasPathTriple:
-		// All three required when any are set — partial config is a
-		// configuration bug rather than a soft fallback.
-		if cfg.ClientCertFile == "" || cfg.ClientKeyFile == "" || cfg.CACertFile == "" {
-			return nil, fmt.Errorf("OTEL mTLS: ClientCertFile, ClientKeyFile, and CACertFile must all be set")
-		}
-		tlsCfg, err := buildOtelMTLSConfig(cfg)
-		if err != nil {
-			return nil, fmt.Errorf("OTEL mTLS config: %w", err)
-		}
-		exporterOpts = append(exporterOpts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
-	case cfg.Insecure:
+	case creds != nil:
+		exporterOpts = append(exporterOpts, otlploggrpc.WithTLSCredentials(creds))
+	case insecure:
 		exporterOpts = append(exporterOpts, otlploggrpc.WithInsecure())
 	}
 
@@ -499,6 +532,41 @@ func newOtelProvider(cfg *OtelOptions, fileLogger zerolog.Logger) (*sdklog.Logge
 	return sdklog.NewLoggerProvider(providerOpts...), nil
 }
 
+// otelCredentials picks the exporter transport security from cfg: the
+// in-process TLSConfig or the file-path mTLS triple as TLS credentials,
+// insecure for a plaintext collector, or neither — the exporter's default
+// TLS against the system roots. Shared by the log and trace exporters so
+// both signals reach the collector over the same trust boundary.
+func otelCredentials(cfg *OtelOptions) (creds credentials.TransportCredentials, insecure bool, err error) {
+	hasPathTriple := cfg.ClientCertFile != "" || cfg.ClientKeyFile != "" || cfg.CACertFile != ""
+	switch {
+	case cfg.TLSConfig != nil && hasPathTriple:
+		// Path triple and TLSConfig together are a wiring bug — the
+		// caller has two trust anchors and we'd silently pick one. Fail
+		// loud so the operator can resolve the conflict.
+		return nil, false, fmt.Errorf("OTEL mTLS: TLSConfig and file-path triple are mutually exclusive")
+	case cfg.TLSConfig != nil:
+		// In-process tls.Config — typically minted by
+		// internal/controlplane/otelcerts.Service.LoadTLSConfig with a
+		// GetClientCertificate hook that re-mints per handshake.
+		return credentials.NewTLS(cfg.TLSConfig), false, nil
+	case hasPathTriple:
+		// All three required when any are set — partial config is a
+		// configuration bug rather than a soft fallback.
+		if cfg.ClientCertFile == "" || cfg.ClientKeyFile == "" || cfg.CACertFile == "" {
+			return nil, false, fmt.Errorf("OTEL mTLS: ClientCertFile, ClientKeyFile, and CACertFile must all be set")
+		}
+		tlsCfg, err := buildOtelMTLSConfig(cfg)
+		if err != nil {
+			return nil, false, fmt.Errorf("OTEL mTLS config: %w", err)
+		}
+		return credentials.NewTLS(tlsCfg), false, nil
+	case cfg.Insecure:
+		return nil, true, nil
+	}
+	return nil, false, nil
+}
+
 // buildOtelMTLSConfig loads the client keypair and trust roots for the
 // OTLP exporter's mTLS handshake from the file-path triple in OtelOptions.
 // The client cert is presented during the handshake; the receiver gates
@@ -523,3 +591,11 @@ func buildOtelMTLSConfig(cfg *OtelOptions) (*tls.Config, error) {
 		MinVersion:   tls.VersionTLS12,
 	}, nil
 }
+
+// levelWriter adapts w to zerolog.LevelWriter.
+func levelWriter(w io.Writer) zerolog.LevelWriter {
+	if lw, ok := w.(zerolog.LevelWriter); ok {
+		return lw
+	}
+	return zerolog.LevelWriterAdapter{Writer: w}
+}
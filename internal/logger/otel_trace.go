@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerProvider returns the span provider built from OtelOptions.Traces,
+// or nil when the logger exports no spans (no OTEL, Traces off, exporter
+// setup failed, or a Nop/NewWriter logger). Callers treat nil as "tracing
+// off" — pass it straight to whail.EngineOptions.TracerProvider.
+func (l *Logger) TracerProvider() trace.TracerProvider {
+	if l.tracer == nil {
+		return nil
+	}
+	return l.tracer
+}
+
+// FlushTraces exports the spans batched so far, bounded by ctx. Short-lived
+// processes call it once their root span has ended: spans otherwise ride
+// the batch export interval, and Close's shutdown may run on an
+// already-canceled context. No-op when the logger exports no spans.
+func (l *Logger) FlushTraces(ctx context.Context) error {
+	if l.tracer == nil {
+		return nil
+	}
+	if err := l.tracer.ForceFlush(ctx); err != nil {
+		return fmt.Errorf("logger: flush OTEL spans: %w", err)
+	}
+	return nil
+}
+
+// newOtelTracerProvider creates an OTLP/gRPC span exporter and batch span
+// processor on the log exporter's endpoint, credentials, and service.name,
+// so spans land in the same collector lane as the process's logs.
+func newOtelTracerProvider(cfg *OtelOptions) (*sdktrace.TracerProvider, error) {
+	exporterOpts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.Endpoint),
+	}
+	creds, insecure, err := otelCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case creds != nil:
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithTLSCredentials(creds))
+	case insecure:
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	if cfg.Timeout > 0 {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithTimeout(cfg.Timeout))
+	}
+
+	// otlptracegrpc.New does not dial; an unreachable collector surfaces
+	// as export errors on the OTEL error handler, not here.
+	exporter, err := otlptracegrpc.New(context.Background(), exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP trace exporter: %w", err)
+	}
+
+	var batchOpts []sdktrace.BatchSpanProcessorOption
+	if cfg.MaxQueueSize > 0 {
+		batchOpts = append(batchOpts, sdktrace.WithMaxQueueSize(cfg.MaxQueueSize))
+	}
+	if cfg.ExportInterval > 0 {
+		batchOpts = append(batchOpts, sdktrace.WithBatchTimeout(cfg.ExportInterval))
+	}
+
+	providerOpts := []sdktrace.TracerProviderOption{sdktrace.WithBatcher(exporter, batchOpts...)}
+	if cfg.ServiceName != "" {
+		res, err := sdkresource.Merge(sdkresource.Default(), sdkresource.NewSchemaless(
+			semconv.ServiceName(cfg.ServiceName),
+		))
+		if err != nil {
+			return nil, fmt.Errorf("build OTEL resource: %w", err)
+		}
+		providerOpts = append(providerOpts, sdktrace.WithResource(res))
+	}
+	return sdktrace.NewTracerProvider(providerOpts...), nil
+}
@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+func TestTracerProvider_OffUnlessTraces(t *testing.T) {
+	if tp := Nop().TracerProvider(); tp != nil {
+		t.Errorf("Nop logger must export no spans, got %T", tp)
+	}
+
+	l, err := New(Options{
+		LogsDir: t.TempDir(),
+		Otel:    &OtelOptions{Endpoint: consts.Localhost + ":19876", Insecure: true},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer l.Close(context.Background())
+	if tp := l.TracerProvider(); tp != nil {
+		t.Errorf("OTEL logs without Traces must export no spans, got %T", tp)
+	}
+	if err := l.FlushTraces(context.Background()); err != nil {
+		t.Errorf("FlushTraces without a tracer must be a no-op, got %v", err)
+	}
+}
+
+// TestFlushTraces_BoundedByContext pins the CLI exit path: the command span
+// is flushed under a short deadline, so an unreachable collector costs at
+// most that deadline rather than the exporter's retry backoff.
+func TestFlushTraces_BoundedByContext(t *testing.T) {
+	l, err := New(Options{
+		LogsDir: t.TempDir(),
+		Otel: &OtelOptions{
+			Endpoint: consts.Localhost + ":19876",
+			Insecure: true,
+			Traces:   true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_ = l.Close(ctx)
+	}()
+
+	tp := l.TracerProvider()
+	if tp == nil {
+		t.Fatal("Traces must wire a tracer provider")
+	}
+	// With carries the tracer along with the other sinks.
+	if l.With("component", "test").TracerProvider() == nil {
+		t.Error("With must keep the tracer provider")
+	}
+
+	_, span := tp.Tracer("test").Start(context.Background(), "command")
+	span.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_ = l.FlushTraces(ctx)
+	if elapsed := time.Since(start); elapsed >= 2*time.Second {
+		t.Errorf("FlushTraces blocked %v; the context deadline must bound it", elapsed)
+	}
+}
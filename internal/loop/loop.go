@@ -0,0 +1,370 @@
+// Package loop runs an agent in a supervised loop for `clawker loop run`.
+// Each iteration invokes the agent with the same task prompt, checkpoints
+// the workspace, and evaluates the stop conditions: a success command
+// exiting 0, an iteration limit, or a token budget. Everything a run
+// produces — per-iteration logs and diffs and a report.json — is written
+// to one directory.
+//
+// The runner reaches the container only through an ExecFunc, so it has no
+// Docker dependency of its own.
+package loop
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxLoops applies when Options.MaxLoops is not positive.
+const DefaultMaxLoops = 10
+
+// Environment variables set for the agent command and the success command.
+const (
+	EnvPrompt    = "CLAWKER_LOOP_PROMPT"
+	EnvIteration = "CLAWKER_LOOP_ITERATION"
+)
+
+// ReportFile is the run report's name in the run directory.
+const ReportFile = "report.json"
+
+// defaultCommands are the agent commands used when loop.command is unset,
+// keyed by harness name. Each runs the harness non-interactively with the
+// prompt and prints usage as JSON for ParseUsage. The container is the
+// sandbox, so permission prompts are skipped as in the shipped aliases.
+var defaultCommands = map[string]string{
+	"claude": `claude -p "$` + EnvPrompt + `" --output-format json --dangerously-skip-permissions`,
+	"codex":  `codex exec --json --dangerously-bypass-approvals-and-sandbox "$` + EnvPrompt + `"`,
+}
+
+// DefaultCommand returns the agent command for a harness, and false when
+// the harness has none and loop.command must be set.
+func DefaultCommand(harness string) (string, bool) {
+	cmd, ok := defaultCommands[harness]
+	return cmd, ok
+}
+
+// StopReason says why a run ended.
+type StopReason string
+
+const (
+	// StopSuccess: the success command exited 0.
+	StopSuccess StopReason = "success"
+	// StopMaxLoops: the iteration limit was reached.
+	StopMaxLoops StopReason = "max_loops"
+	// StopTokenBudget: the agent's token use reached the budget.
+	StopTokenBudget StopReason = "token_budget"
+	// StopAgentFailed: the agent command exited non-zero, timed out, or
+	// could not be run.
+	StopAgentFailed StopReason = "agent_failed"
+	// StopInterrupted: the run's context was cancelled.
+	StopInterrupted StopReason = "interrupted"
+)
+
+// ExecFunc runs cmd in the agent container with env added to its
+// environment and waits for it, killing it after timeout when timeout is
+// positive. A non-zero exit is not an error. It has the semantics of
+// whail's ContainerExecRun.
+type ExecFunc func(ctx context.Context, cmd, env []string, timeout time.Duration) (stdout, stderr string, exitCode int, err error)
+
+// Options configures Run.
+type Options struct {
+	// Exec runs commands in the agent container; required.
+	Exec ExecFunc
+	// Dir is the run directory; it is created if missing.
+	Dir string
+
+	// Container names the agent container in the report.
+	Container string
+	// Prompt is the task prompt given to every iteration.
+	Prompt string
+	// Command is the agent's shell command; required.
+	Command string
+	// SuccessCommand, when set, is run after each iteration that the agent
+	// completed; exit 0 ends the run.
+	SuccessCommand string
+	// MaxLoops bounds the iterations; see DefaultMaxLoops.
+	MaxLoops int
+	// TokenBudget stops the run once the agent has used this many tokens,
+	// as reported in its output (see ParseUsage); 0 means no limit. It is
+	// checked after each iteration, so a run can overshoot by one.
+	TokenBudget int64
+	// IterationTimeout bounds one agent invocation; 0 means no limit.
+	IterationTimeout time.Duration
+
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+	// OnIteration, when set, is called after each iteration.
+	OnIteration func(Iteration)
+}
+
+// Report is the run report, written to ReportFile.
+type Report struct {
+	Container      string       `json:"container,omitempty"`
+	Prompt         string       `json:"prompt"`
+	Command        string       `json:"command"`
+	SuccessCommand string       `json:"success_command,omitempty"`
+	MaxLoops       int          `json:"max_loops"`
+	TokenBudget    int64        `json:"token_budget,omitempty"`
+	StartedAt      time.Time    `json:"started_at"`
+	FinishedAt     time.Time    `json:"finished_at"`
+	StopReason     StopReason   `json:"stop_reason"`
+	Tokens         int64        `json:"tokens"`
+	Iterations     []Iteration  `json:"iterations"`
+	Checkpoints    *Checkpoints `json:"checkpoints"`
+}
+
+// Checkpoints describes the workspace checkpoints of a run. Each is a git
+// tree object written to the workspace repository without touching its
+// index, HEAD or working tree; `git diff BASE TREE` or
+// `git restore --source=TREE .` in the workspace use them.
+type Checkpoints struct {
+	// Base is the workspace before the first iteration.
+	Base string `json:"base,omitempty"`
+	// Error says why the workspace could not be checkpointed (no git
+	// repository, no git in the image); the run continues without diffs.
+	Error string `json:"error,omitempty"`
+}
+
+// Iteration is one agent invocation and its outcome.
+type Iteration struct {
+	N          int       `json:"n"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMS int64     `json:"duration_ms"`
+	ExitCode   int       `json:"exit_code"`
+	// Error is set when the agent command could not be run or timed out.
+	Error string `json:"error,omitempty"`
+	Usage Usage  `json:"usage"`
+	// SuccessExitCode is the success command's exit code; nil when it is
+	// not configured or was not run.
+	SuccessExitCode *int `json:"success_exit_code,omitempty"`
+	// Checkpoint is the workspace tree after the iteration.
+	Checkpoint string `json:"checkpoint,omitempty"`
+	// Diff is the iteration's patch against the previous checkpoint,
+	// relative to the run directory; empty when nothing changed.
+	Diff         string `json:"diff,omitempty"`
+	FilesChanged int    `json:"files_changed"`
+	// Log holds the agent's and the success command's output, relative to
+	// the run directory.
+	Log string `json:"log"`
+}
+
+// Run drives the loop until a stop condition is met and writes the report.
+// The report is returned and written even when the run is interrupted;
+// err reports only invalid options and failures to write the run
+// directory.
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Exec == nil {
+		return nil, errors.New("loop: no exec function")
+	}
+	if strings.TrimSpace(opts.Command) == "" {
+		return nil, errors.New("loop: no agent command")
+	}
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+	maxLoops := opts.MaxLoops
+	if maxLoops <= 0 {
+		maxLoops = DefaultMaxLoops
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating run directory: %w", err)
+	}
+
+	report := &Report{
+		Container:      opts.Container,
+		Prompt:         opts.Prompt,
+		Command:        opts.Command,
+		SuccessCommand: opts.SuccessCommand,
+		MaxLoops:       maxLoops,
+		TokenBudget:    opts.TokenBudget,
+		StartedAt:      now(),
+		Iterations:     []Iteration{},
+		Checkpoints:    &Checkpoints{},
+	}
+
+	prev, err := checkpoint(ctx, opts.Exec)
+	if err != nil {
+		report.Checkpoints.Error = err.Error()
+	}
+	report.Checkpoints.Base = prev
+
+	for n := 1; ; n++ {
+		if ctx.Err() != nil {
+			report.StopReason = StopInterrupted
+			break
+		}
+		it, reason, err := runIteration(ctx, opts, n, now, prev)
+		if err != nil {
+			return report, err
+		}
+		if it.Checkpoint != "" {
+			prev = it.Checkpoint
+		}
+		report.Iterations = append(report.Iterations, it)
+		report.Tokens += it.Usage.Total()
+		if opts.OnIteration != nil {
+			opts.OnIteration(it)
+		}
+
+		switch {
+		case reason != "":
+			report.StopReason = reason
+		case opts.TokenBudget > 0 && report.Tokens >= opts.TokenBudget:
+			report.StopReason = StopTokenBudget
+		case n >= maxLoops:
+			report.StopReason = StopMaxLoops
+		}
+		if report.StopReason != "" {
+			break
+		}
+	}
+
+	report.FinishedAt = now()
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return report, fmt.Errorf("encoding run report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(opts.Dir, ReportFile), append(data, '\n'), 0o644); err != nil {
+		return report, fmt.Errorf("writing run report: %w", err)
+	}
+	return report, nil
+}
+
+// runIteration runs iteration n: the agent, the checkpoint against prev
+// (skipped when prev is empty) and the success command. The returned stop
+// reason is empty when the loop may continue on this iteration's account.
+func runIteration(ctx context.Context, opts Options, n int, now func() time.Time, prev string) (Iteration, StopReason, error) {
+	it := Iteration{
+		N:         n,
+		StartedAt: now(),
+		Log:       fmt.Sprintf("iteration-%03d.log", n),
+	}
+	env := []string{EnvPrompt + "=" + opts.Prompt, EnvIteration + "=" + strconv.Itoa(n)}
+
+	var log strings.Builder
+	fmt.Fprintf(&log, "$ %s\n", opts.Command)
+	stdout, stderr, exitCode, err := opts.Exec(ctx, []string{"sh", "-c", opts.Command}, env, opts.IterationTimeout)
+	it.DurationMS = now().Sub(it.StartedAt).Milliseconds()
+	it.ExitCode = exitCode
+	it.Usage = ParseUsage(stdout)
+	writeOutput(&log, stdout, stderr)
+
+	var reason StopReason
+	switch {
+	case ctx.Err() != nil:
+		reason = StopInterrupted
+	case err != nil:
+		it.Error = err.Error()
+		fmt.Fprintf(&log, "[%s]\n", it.Error)
+		reason = StopAgentFailed
+	case exitCode != 0:
+		reason = StopAgentFailed
+	}
+
+	if prev != "" && ctx.Err() == nil {
+		if err := diffCheckpoint(ctx, opts, &it, prev); err != nil {
+			fmt.Fprintf(&log, "[checkpoint failed: %v]\n", err)
+		}
+	}
+
+	if reason == "" && opts.SuccessCommand != "" {
+		fmt.Fprintf(&log, "\n$ %s\n", opts.SuccessCommand)
+		stdout, stderr, exitCode, err := opts.Exec(ctx, []string{"sh", "-c", opts.SuccessCommand}, env, 0)
+		writeOutput(&log, stdout, stderr)
+		switch {
+		case ctx.Err() != nil:
+			reason = StopInterrupted
+		case err != nil:
+			fmt.Fprintf(&log, "[%v]\n", err)
+		default:
+			it.SuccessExitCode = &exitCode
+			if exitCode == 0 {
+				reason = StopSuccess
+			}
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(opts.Dir, it.Log), []byte(log.String()), 0o644); err != nil {
+		return it, reason, fmt.Errorf("writing iteration log: %w", err)
+	}
+	return it, reason, nil
+}
+
+// diffCheckpoint checkpoints the workspace after an iteration and writes
+// the patch from prev, if any, to the run directory.
+func diffCheckpoint(ctx context.Context, opts Options, it *Iteration, prev string) error {
+	tree, err := checkpoint(ctx, opts.Exec)
+	if err != nil {
+		return err
+	}
+	it.Checkpoint = tree
+	if tree == prev {
+		return nil
+	}
+	patch, err := git(ctx, opts.Exec, "diff", "--binary", prev, tree)
+	if err != nil {
+		return err
+	}
+	it.FilesChanged = strings.Count("\n"+patch, "\ndiff --git ")
+	it.Diff = fmt.Sprintf("iteration-%03d.diff", it.N)
+	if err := os.WriteFile(filepath.Join(opts.Dir, it.Diff), []byte(patch), 0o644); err != nil {
+		return fmt.Errorf("writing iteration diff: %w", err)
+	}
+	return nil
+}
+
+// checkpointScript writes the workspace, as `git add -A` sees it, to a tree
+// object through a throwaway index, leaving the real index and HEAD alone.
+const checkpointScript = `set -e
+GIT_INDEX_FILE=$(mktemp)
+export GIT_INDEX_FILE
+trap 'rm -f "$GIT_INDEX_FILE"' EXIT
+rm -f "$GIT_INDEX_FILE"
+if git rev-parse -q --verify HEAD >/dev/null; then git read-tree HEAD; fi
+git add -A
+git write-tree`
+
+// checkpoint records the workspace as a git tree object and returns its
+// hash.
+func checkpoint(ctx context.Context, exec ExecFunc) (string, error) {
+	stdout, stderr, exitCode, err := exec(ctx, []string{"sh", "-c", checkpointScript}, nil, 0)
+	if err != nil {
+		return "", fmt.Errorf("checkpointing workspace: %w", err)
+	}
+	if exitCode != 0 {
+		return "", fmt.Errorf("checkpointing workspace: git exited with code %d: %s", exitCode, strings.TrimSpace(stderr))
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+func git(ctx context.Context, exec ExecFunc, args ...string) (string, error) {
+	stdout, stderr, exitCode, err := exec(ctx, append([]string{"git"}, args...), nil, 0)
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	if exitCode != 0 {
+		return "", fmt.Errorf("git %s exited with code %d: %s", args[0], exitCode, strings.TrimSpace(stderr))
+	}
+	return stdout, nil
+}
+
+func writeOutput(b *strings.Builder, stdout, stderr string) {
+	b.WriteString(stdout)
+	if stdout != "" && !strings.HasSuffix(stdout, "\n") {
+		b.WriteByte('\n')
+	}
+	if stderr != "" {
+		b.WriteString("--- stderr ---\n")
+		b.WriteString(stderr)
+		if !strings.HasSuffix(stderr, "\n") {
+			b.WriteByte('\n')
+		}
+	}
+}
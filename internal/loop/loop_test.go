@@ -0,0 +1,219 @@
+package loop
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeContainer answers the runner's execs: the agent command, the success
+// command, the checkpoint script and git diff. Each agent run changes the
+// workspace, so every checkpoint is a new tree.
+type fakeContainer struct {
+	agentOut     string
+	agentExit    func(n int) int
+	successExit  func(n int) int
+	noGit        bool
+	onAgent      func(n int)
+	agentRuns    int
+	successRuns  int
+	lastEnv      []string
+	agentTimeout time.Duration
+}
+
+func (f *fakeContainer) exec(_ context.Context, cmd, env []string, timeout time.Duration) (string, string, int, error) {
+	switch {
+	case slices.Equal(cmd, []string{"sh", "-c", checkpointScript}):
+		if f.noGit {
+			return "", "fatal: not a git repository", 128, nil
+		}
+		tree := fmt.Sprintf("tree%d", f.agentRuns)
+		return tree + "\n", "", 0, nil
+	case cmd[0] == "git" && cmd[1] == "diff":
+		return fmt.Sprintf("diff --git a/f b/f\n+%s..%s\n", cmd[3], cmd[4]), "", 0, nil
+	case slices.Equal(cmd, []string{"sh", "-c", "agent"}):
+		f.agentRuns++
+		f.lastEnv = env
+		f.agentTimeout = timeout
+		if f.onAgent != nil {
+			f.onAgent(f.agentRuns)
+		}
+		exit := 0
+		if f.agentExit != nil {
+			exit = f.agentExit(f.agentRuns)
+		}
+		return f.agentOut, "", exit, nil
+	case slices.Equal(cmd, []string{"sh", "-c", "check"}):
+		f.successRuns++
+		exit := 1
+		if f.successExit != nil {
+			exit = f.successExit(f.agentRuns)
+		}
+		return "", "tests failed\n", exit, nil
+	}
+	return "", "", -1, fmt.Errorf("unexpected command %q", cmd)
+}
+
+func testOptions(t *testing.T, f *fakeContainer) Options {
+	t.Helper()
+	return Options{
+		Exec:           f.exec,
+		Dir:            filepath.Join(t.TempDir(), "run"),
+		Prompt:         "fix the tests",
+		Command:        "agent",
+		SuccessCommand: "check",
+		MaxLoops:       5,
+	}
+}
+
+func TestRun_StopsOnSuccess(t *testing.T) {
+	f := &fakeContainer{
+		agentOut:    `{"type":"result","usage":{"input_tokens":100,"output_tokens":20}}`,
+		successExit: func(n int) int { return map[bool]int{true: 0, false: 1}[n == 2] },
+	}
+	opts := testOptions(t, f)
+	opts.IterationTimeout = time.Minute
+	var seen []int
+	opts.OnIteration = func(it Iteration) { seen = append(seen, it.N) }
+
+	report, err := Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, StopSuccess, report.StopReason)
+	assert.Equal(t, []int{1, 2}, seen)
+	assert.Equal(t, int64(240), report.Tokens)
+	assert.Equal(t, "tree0", report.Checkpoints.Base)
+	assert.Contains(t, f.lastEnv, EnvPrompt+"=fix the tests")
+	assert.Contains(t, f.lastEnv, EnvIteration+"=2")
+	assert.Equal(t, time.Minute, f.agentTimeout)
+
+	require.Len(t, report.Iterations, 2)
+	first := report.Iterations[0]
+	assert.Equal(t, "tree1", first.Checkpoint)
+	assert.Equal(t, 1, first.FilesChanged)
+	assert.Equal(t, 1, *first.SuccessExitCode)
+	patch, err := os.ReadFile(filepath.Join(opts.Dir, first.Diff))
+	require.NoError(t, err)
+	assert.Contains(t, string(patch), "tree0..tree1", "each diff is against the previous checkpoint")
+	patch, err = os.ReadFile(filepath.Join(opts.Dir, report.Iterations[1].Diff))
+	require.NoError(t, err)
+	assert.Contains(t, string(patch), "tree1..tree2")
+
+	log, err := os.ReadFile(filepath.Join(opts.Dir, first.Log))
+	require.NoError(t, err)
+	assert.Contains(t, string(log), "$ agent\n")
+	assert.Contains(t, string(log), "$ check\n--- stderr ---\ntests failed\n")
+
+	data, err := os.ReadFile(filepath.Join(opts.Dir, ReportFile))
+	require.NoError(t, err)
+	var written Report
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, StopSuccess, written.StopReason)
+	assert.Len(t, written.Iterations, 2)
+}
+
+func TestRun_StopConditions(t *testing.T) {
+	tests := []struct {
+		name        string
+		fake        *fakeContainer
+		tokenBudget int64
+		wantReason  StopReason
+		wantIters   int
+	}{
+		{
+			name:       "max loops",
+			fake:       &fakeContainer{},
+			wantReason: StopMaxLoops,
+			wantIters:  5,
+		},
+		{
+			name:        "token budget",
+			fake:        &fakeContainer{agentOut: `{"usage":{"input_tokens":400,"output_tokens":100}}`},
+			tokenBudget: 1200,
+			wantReason:  StopTokenBudget,
+			wantIters:   3,
+		},
+		{
+			name:       "agent failure",
+			fake:       &fakeContainer{agentExit: func(n int) int { return map[bool]int{true: 2, false: 0}[n == 2] }},
+			wantReason: StopAgentFailed,
+			wantIters:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := testOptions(t, tt.fake)
+			opts.TokenBudget = tt.tokenBudget
+			report, err := Run(context.Background(), opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReason, report.StopReason)
+			assert.Len(t, report.Iterations, tt.wantIters)
+		})
+	}
+}
+
+func TestRun_AgentFailureSkipsSuccessCommand(t *testing.T) {
+	f := &fakeContainer{agentExit: func(int) int { return 1 }}
+	report, err := Run(context.Background(), testOptions(t, f))
+	require.NoError(t, err)
+	assert.Equal(t, StopAgentFailed, report.StopReason)
+	assert.Zero(t, f.successRuns)
+	assert.Nil(t, report.Iterations[0].SuccessExitCode)
+}
+
+func TestRun_WithoutGitKeepsLooping(t *testing.T) {
+	f := &fakeContainer{noGit: true}
+	opts := testOptions(t, f)
+	opts.MaxLoops = 2
+	report, err := Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, StopMaxLoops, report.StopReason)
+	assert.Contains(t, report.Checkpoints.Error, "not a git repository")
+	for _, it := range report.Iterations {
+		assert.Empty(t, it.Checkpoint)
+		assert.Empty(t, it.Diff)
+	}
+}
+
+func TestRun_InterruptWritesReport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := &fakeContainer{onAgent: func(n int) {
+		if n == 2 {
+			cancel()
+		}
+	}}
+	opts := testOptions(t, f)
+	report, err := Run(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, StopInterrupted, report.StopReason)
+	assert.Len(t, report.Iterations, 2)
+	assert.Equal(t, 1, f.successRuns, "the interrupted iteration does not run the success command")
+	_, err = os.Stat(filepath.Join(opts.Dir, ReportFile))
+	require.NoError(t, err)
+}
+
+func TestRun_RequiresCommand(t *testing.T) {
+	opts := testOptions(t, &fakeContainer{})
+	opts.Command = " "
+	_, err := Run(context.Background(), opts)
+	require.Error(t, err)
+	_, err = os.Stat(opts.Dir)
+	assert.True(t, errors.Is(err, os.ErrNotExist), "nothing is written for invalid options")
+}
+
+func TestDefaultCommand(t *testing.T) {
+	cmd, ok := DefaultCommand("claude")
+	require.True(t, ok)
+	assert.Contains(t, cmd, `"$`+EnvPrompt+`"`)
+	_, ok = DefaultCommand("aider")
+	assert.False(t, ok)
+}
@@ -0,0 +1,50 @@
+package loop
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+)
+
+// Usage is the token use an agent reported for one iteration.
+type Usage struct {
+	InputTokens              int64 `json:"input_tokens"`
+	OutputTokens             int64 `json:"output_tokens"`
+	CacheCreationInputTokens int64 `json:"cache_creation_input_tokens,omitempty"`
+	CacheReadInputTokens     int64 `json:"cache_read_input_tokens,omitempty"`
+}
+
+// Total is the tokens counted against a budget. Claude reports cache
+// tokens separately from input tokens, so they are added; codex's
+// cached_input_tokens are part of input_tokens and are not.
+func (u Usage) Total() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+}
+
+// ParseUsage sums the token usage an agent printed as JSON: every line of
+// output holding a JSON object with a top-level "usage" object counts.
+// That matches `claude -p --output-format json` (one result object) and
+// `codex exec --json` (one turn.completed event per turn). Output without
+// usage, such as plain text, reports zero.
+func ParseUsage(output string) Usage {
+	var total Usage
+	sc := bufio.NewScanner(strings.NewReader(output))
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var msg struct {
+			Usage *Usage `json:"usage"`
+		}
+		if json.Unmarshal([]byte(line), &msg) != nil || msg.Usage == nil {
+			continue
+		}
+		total.InputTokens += msg.Usage.InputTokens
+		total.OutputTokens += msg.Usage.OutputTokens
+		total.CacheCreationInputTokens += msg.Usage.CacheCreationInputTokens
+		total.CacheReadInputTokens += msg.Usage.CacheReadInputTokens
+	}
+	return total
+}
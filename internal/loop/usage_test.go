@@ -0,0 +1,44 @@
+package loop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUsage(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   Usage
+		total  int64
+	}{
+		{
+			name:   "claude result",
+			output: `{"type":"result","result":"done","usage":{"input_tokens":12,"output_tokens":340,"cache_creation_input_tokens":1000,"cache_read_input_tokens":5000}}`,
+			want:   Usage{InputTokens: 12, OutputTokens: 340, CacheCreationInputTokens: 1000, CacheReadInputTokens: 5000},
+			total:  6352,
+		},
+		{
+			name: "codex events",
+			output: `{"type":"thread.started","thread_id":"t1"}
+{"type":"turn.completed","usage":{"input_tokens":900,"cached_input_tokens":800,"output_tokens":50}}
+progress text
+{"type":"turn.completed","usage":{"input_tokens":100,"cached_input_tokens":0,"output_tokens":25}}`,
+			want:  Usage{InputTokens: 1000, OutputTokens: 75},
+			total: 1075,
+		},
+		{
+			name:   "nested usage does not count",
+			output: `{"type":"assistant","message":{"usage":{"input_tokens":5,"output_tokens":5}}}`,
+		},
+		{name: "plain text", output: "All tests pass.\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseUsage(tt.output)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.total, got.Total())
+		})
+	}
+}
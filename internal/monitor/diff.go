@@ -0,0 +1,51 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// FileDiff is the pending change to one installed stack config file.
+type FileDiff struct {
+	// Name is the file name relative to the monitor directory.
+	Name string
+	// Added reports that the file is not installed yet.
+	Added bool
+	// Unified is the unified diff from the installed to the rendered content.
+	Unified string
+}
+
+// DiffStack compares freshly rendered stack files against the ones installed
+// in monitorDir and returns a diff for each file that would change, in render
+// order. Image pins live in compose.yaml, so a version bump shows up as a
+// change to its image lines.
+func DiffStack(monitorDir string, files []RenderedFile) ([]FileDiff, error) {
+	var diffs []FileDiff
+	for _, f := range files {
+		existing, err := os.ReadFile(filepath.Join(monitorDir, f.Name))
+		added := errors.Is(err, fs.ErrNotExist)
+		if err != nil && !added {
+			return nil, fmt.Errorf("read installed %s: %w", f.Name, err)
+		}
+		if !added && string(existing) == string(f.Content) {
+			continue
+		}
+		unified, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(existing)),
+			B:        difflib.SplitLines(string(f.Content)),
+			FromFile: "installed/" + f.Name,
+			ToFile:   "rendered/" + f.Name,
+			Context:  3,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("diff %s: %w", f.Name, err)
+		}
+		diffs = append(diffs, FileDiff{Name: f.Name, Added: added, Unified: unified})
+	}
+	return diffs, nil
+}
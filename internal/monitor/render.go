@@ -79,36 +79,28 @@ func RenderStack(
 	bootstrapUnits []ResolvedUnit,
 	force bool,
 ) (StackRender, error) {
-	files := []struct {
-		name string
-		tmpl string
-	}{
-		{ComposeFileName, ComposeTemplate},
-		{OtelConfigFileName, OtelConfigTemplate},
-		{PrometheusFileName, PrometheusTemplate},
+	files, err := RenderStackFiles(data)
+	if err != nil {
+		return StackRender{}, err
 	}
 
 	result := StackRender{Written: nil, Skipped: nil, OtelConfigChanged: false}
 	for _, f := range files {
-		rendered, renderErr := RenderTemplate(f.name, f.tmpl, data)
-		if renderErr != nil {
-			return StackRender{}, fmt.Errorf("render %s: %w", f.name, renderErr)
-		}
-		filePath := filepath.Join(monitorDir, f.name)
+		filePath := filepath.Join(monitorDir, f.Name)
 		existing, readErr := os.ReadFile(filePath)
 		exists := readErr == nil
-		if f.name == OtelConfigFileName {
-			result.OtelConfigChanged = exists && !bytes.Equal(existing, []byte(rendered))
+		if f.Name == OtelConfigFileName {
+			result.OtelConfigChanged = exists && !bytes.Equal(existing, f.Content)
 		}
 		if exists && !force {
-			result.Skipped = append(result.Skipped, f.name)
+			result.Skipped = append(result.Skipped, f.Name)
 			continue
 		}
 		//nolint:gosec // generated, non-secret monitoring config; conventional world-readable perms
-		if writeErr := os.WriteFile(filePath, []byte(rendered), 0o644); writeErr != nil {
-			return StackRender{}, fmt.Errorf("write %s: %w", f.name, writeErr)
+		if writeErr := os.WriteFile(filePath, f.Content, 0o644); writeErr != nil {
+			return StackRender{}, fmt.Errorf("write %s: %w", f.Name, writeErr)
 		}
-		result.Written = append(result.Written, f.name)
+		result.Written = append(result.Written, f.Name)
 	}
 
 	bootstrapDir := filepath.Join(monitorDir, OpenSearchBootstrapDirName)
@@ -117,3 +109,33 @@ func RenderStack(
 	}
 	return result, nil
 }
+
+// RenderedFile is one top-level stack config file rendered in memory.
+type RenderedFile struct {
+	Name    string
+	Content []byte
+}
+
+// RenderStackFiles renders compose.yaml, otel-config.yaml, and prometheus.yaml
+// without touching disk. [RenderStack] writes its result; `monitor upgrade`
+// diffs it against the installed files first.
+func RenderStackFiles(data MonitorTemplateData) ([]RenderedFile, error) {
+	files := []struct {
+		name string
+		tmpl string
+	}{
+		{ComposeFileName, ComposeTemplate},
+		{OtelConfigFileName, OtelConfigTemplate},
+		{PrometheusFileName, PrometheusTemplate},
+	}
+
+	rendered := make([]RenderedFile, 0, len(files))
+	for _, f := range files {
+		content, err := RenderTemplate(f.name, f.tmpl, data)
+		if err != nil {
+			return nil, fmt.Errorf("render %s: %w", f.name, err)
+		}
+		rendered = append(rendered, RenderedFile{Name: f.name, Content: []byte(content)})
+	}
+	return rendered, nil
+}
@@ -88,3 +88,52 @@ func TestRenderStack_SkipIfExists(t *testing.T) {
 		r.Skipped,
 	)
 }
+
+// TestDiffStack reports only the files a re-render would change: nothing after
+// an identical render, every file on a fresh directory, and a unified diff of
+// the changed lines when settings move.
+func TestDiffStack(t *testing.T) {
+	dir := t.TempDir()
+
+	data, err := monitor.NewMonitorTemplateData(
+		configmocks.NewFromString("", renderSettings).SettingsStore().Read(), nil)
+	require.NoError(t, err)
+	files, err := monitor.RenderStackFiles(data)
+	require.NoError(t, err)
+
+	fresh, err := monitor.DiffStack(dir, files)
+	require.NoError(t, err)
+	require.Len(t, fresh, len(files))
+	for _, d := range fresh {
+		assert.True(t, d.Added, d.Name)
+	}
+
+	_, err = monitor.RenderStack(dir, data, nil, true)
+	require.NoError(t, err)
+	same, err := monitor.DiffStack(dir, files)
+	require.NoError(t, err)
+	assert.Empty(t, same)
+
+	moved, err := monitor.NewMonitorTemplateData(
+		configmocks.NewFromString("", `
+monitoring:
+  otel_collector_port: 4318
+  otel_grpc_port: 4317
+  otel_infra_port: 4319
+  prometheus_port: 9191
+  prometheus_metrics_port: 8889
+  opensearch_port: 9200
+  opensearch_dashboards_port: 5601
+  opensearch_heap_mb: 512
+`).SettingsStore().Read(), nil)
+	require.NoError(t, err)
+	movedFiles, err := monitor.RenderStackFiles(moved)
+	require.NoError(t, err)
+	diffs, err := monitor.DiffStack(dir, movedFiles)
+	require.NoError(t, err)
+	require.NotEmpty(t, diffs)
+	assert.Equal(t, monitor.ComposeFileName, diffs[0].Name)
+	assert.False(t, diffs[0].Added)
+	assert.Contains(t, diffs[0].Unified, "+++ rendered/compose.yaml")
+	assert.Contains(t, diffs[0].Unified, "9191")
+}
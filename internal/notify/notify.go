@@ -0,0 +1,131 @@
+// Package notify shows desktop notifications when long-running clawker
+// operations finish: osascript on macOS, notify-send on Linux and a toast on
+// Windows. Delivery is best-effort — a missing tool or a failed command is
+// logged, never returned to the caller.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// Event names an operation outcome that can raise a notification. Each has a
+// toggle under settings notifications.events.
+type Event string
+
+const (
+	// EventBuildComplete is a finished image build.
+	EventBuildComplete Event = "build_complete"
+	// EventAgentReady is a detached agent (run -d) that finished starting.
+	EventAgentReady Event = "agent_ready"
+	// EventLoopComplete is an agent loop that met its stop condition.
+	EventLoopComplete Event = "loop_complete"
+	// EventFailure is any of the above operations failing.
+	EventFailure Event = "failure"
+)
+
+// Notification is one desktop notification.
+type Notification struct {
+	Event   Event
+	Title   string
+	Message string
+	// Elapsed is how long the operation ran. Operations shorter than
+	// notifications.min_duration raise nothing: the user is still watching.
+	Elapsed time.Duration
+}
+
+// Notifier shows notifications according to the settings notifications:
+// block. A nil *Notifier is valid and shows nothing.
+type Notifier struct {
+	settings config.NotificationSettings
+	log      *logger.Logger
+	now      func() time.Time
+	send     func(ctx context.Context, title, message string) error
+}
+
+// New returns a Notifier for settings that sends through the platform's
+// notification tool.
+func New(settings config.NotificationSettings, log *logger.Logger) *Notifier {
+	if log == nil {
+		log = logger.Nop()
+	}
+	return &Notifier{settings: settings, log: log, now: time.Now, send: send}
+}
+
+// Notify shows note unless notifications are off, its event is toggled off,
+// it finished faster than min_duration, or it is quiet hours. It waits at
+// most consts.NotifySendTimeout for the platform tool.
+func (n *Notifier) Notify(ctx context.Context, note Notification) {
+	if n == nil {
+		return
+	}
+	if reason := n.suppressed(note); reason != "" {
+		n.log.Debug().Str("event", string(note.Event)).Str("reason", reason).Msg("notification suppressed")
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), consts.NotifySendTimeout)
+	defer cancel()
+	if err := n.send(ctx, note.Title, note.Message); err != nil {
+		n.log.Debug().Err(err).Str("event", string(note.Event)).Msg("desktop notification failed")
+	}
+}
+
+// suppressed returns why note is not shown, or "" when it is.
+func (n *Notifier) suppressed(note Notification) string {
+	s := n.settings
+	switch {
+	case !s.Enabled:
+		return "disabled"
+	case !s.Events.On(string(note.Event)):
+		return "event disabled"
+	case note.Elapsed < s.MinDuration:
+		return "below min_duration"
+	}
+	if s.QuietHours == "" {
+		return ""
+	}
+	start, end, err := ParseQuietHours(s.QuietHours)
+	if err != nil {
+		n.log.Warn().Err(err).Msg("ignoring invalid notifications.quiet_hours")
+		return ""
+	}
+	if inWindow(n.now(), start, end) {
+		return "quiet hours"
+	}
+	return ""
+}
+
+// ParseQuietHours parses a quiet_hours window, "HH:MM-HH:MM" in local time,
+// into its bounds as offsets from midnight. The window may span midnight
+// (22:00-08:00); equal bounds are an empty window.
+func ParseQuietHours(s string) (start, end time.Duration, err error) {
+	var sh, sm, eh, em int
+	if _, err := fmt.Sscanf(s, "%d:%d-%d:%d", &sh, &sm, &eh, &em); err != nil {
+		return 0, 0, fmt.Errorf("quiet hours %q: want HH:MM-HH:MM", s)
+	}
+	for _, v := range [][2]int{{sh, sm}, {eh, em}} {
+		if v[0] < 0 || v[0] > 23 || v[1] < 0 || v[1] > 59 {
+			return 0, 0, fmt.Errorf("quiet hours %q: %02d:%02d is not a time of day", s, v[0], v[1])
+		}
+	}
+	return clock(sh, sm), clock(eh, em), nil
+}
+
+func clock(h, m int) time.Duration {
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute
+}
+
+// inWindow reports whether t's local time of day falls in [start, end),
+// wrapping past midnight when end is before start.
+func inWindow(t time.Time, start, end time.Duration) bool {
+	tod := clock(t.Hour(), t.Minute())
+	if start <= end {
+		return tod >= start && tod < end
+	}
+	return tod >= start || tod < end
+}
@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+func newTestNotifier(settings config.NotificationSettings, now time.Time) (*Notifier, *[]string) {
+	var sent []string
+	n := New(settings, logger.Nop())
+	n.now = func() time.Time { return now }
+	n.send = func(_ context.Context, title, message string) error {
+		sent = append(sent, title+": "+message)
+		return nil
+	}
+	return n, &sent
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	off := false
+	noon := time.Date(2026, 10, 17, 12, 0, 0, 0, time.Local)
+	night := time.Date(2026, 10, 17, 23, 30, 0, 0, time.Local)
+	on := config.NotificationSettings{Enabled: true, MinDuration: 30 * time.Second}
+	build := Notification{Event: EventBuildComplete, Title: "clawker", Message: "built", Elapsed: time.Minute}
+
+	tests := []struct {
+		name     string
+		settings config.NotificationSettings
+		now      time.Time
+		note     Notification
+		wantSent bool
+	}{
+		{name: "shown", settings: on, now: noon, note: build, wantSent: true},
+		{name: "disabled", settings: config.NotificationSettings{}, now: noon, note: build},
+		{name: "event toggled off", settings: config.NotificationSettings{
+			Enabled: true, Events: config.NotificationEvents{BuildComplete: &off},
+		}, now: noon, note: build},
+		{name: "other events stay on", settings: config.NotificationSettings{
+			Enabled: true, Events: config.NotificationEvents{BuildComplete: &off},
+		}, now: noon, note: Notification{Event: EventFailure, Elapsed: time.Minute}, wantSent: true},
+		{name: "faster than min_duration", settings: on, now: noon, note: Notification{Event: EventBuildComplete, Elapsed: time.Second}},
+		{name: "quiet hours across midnight", settings: config.NotificationSettings{
+			Enabled: true, QuietHours: "22:00-08:00",
+		}, now: night, note: build},
+		{name: "outside quiet hours", settings: config.NotificationSettings{
+			Enabled: true, QuietHours: "22:00-08:00",
+		}, now: noon, note: build, wantSent: true},
+		{name: "invalid quiet hours are ignored", settings: config.NotificationSettings{
+			Enabled: true, QuietHours: "late",
+		}, now: night, note: build, wantSent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, sent := newTestNotifier(tt.settings, tt.now)
+			n.Notify(context.Background(), tt.note)
+			assert.Equal(t, tt.wantSent, len(*sent) == 1, "sent = %v", *sent)
+		})
+	}
+}
+
+func TestNotifier_NilIsSilent(t *testing.T) {
+	var n *Notifier
+	n.Notify(context.Background(), Notification{Event: EventFailure})
+}
+
+func TestParseQuietHours(t *testing.T) {
+	start, end, err := ParseQuietHours("22:30-07:05")
+	require.NoError(t, err)
+	assert.Equal(t, 22*time.Hour+30*time.Minute, start)
+	assert.Equal(t, 7*time.Hour+5*time.Minute, end)
+
+	for _, bad := range []string{"", "10pm-7am", "24:00-08:00", "22:00-08:60"} {
+		_, _, err := ParseQuietHours(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestAppleScriptString(t *testing.T) {
+	assert.Equal(t, `"say \"hi\" \\ bye"`, appleScriptString(`say "hi" \ bye`))
+}
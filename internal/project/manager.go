@@ -48,6 +48,10 @@ type ProjectManager interface {
 	List(ctx context.Context) ([]ProjectEntry, error)
 	ListProjects(ctx context.Context) ([]ProjectState, error)
 	Remove(ctx context.Context, root string) error
+	// Relocate moves a registration to the directory a repository was moved
+	// to, keeping its name and worktrees, and re-points the worktrees' git
+	// links at the moved repository. newRoot must exist.
+	Relocate(ctx context.Context, oldRoot, newRoot string) (Project, error)
 	Get(ctx context.Context, root string) (Project, error)
 	ResolvePath(ctx context.Context, cwd string) (Project, error)
 	CurrentProject(ctx context.Context) (Project, error)
@@ -241,6 +245,33 @@ func (s *projectManager) Remove(_ context.Context, root string) error {
 	return nil
 }
 
+// Relocate moves a project registration from oldRoot to newRoot. Worktree
+// git links are repaired best-effort: a worktree that can't be re-pointed is
+// logged and left for `clawker worktree prune` to report.
+func (s *projectManager) Relocate(_ context.Context, oldRoot, newRoot string) (Project, error) {
+	info, err := os.Stat(newRoot)
+	if err != nil {
+		return nil, fmt.Errorf("new root: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("new root is not a directory: %s", newRoot)
+	}
+
+	entry, err := s.reg.relocate(oldRoot, newRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	oldGitDir := filepath.Join(oldRoot, ".git")
+	newGitDir := filepath.Join(entry.Root, ".git")
+	for branch, wt := range entry.Worktrees {
+		if _, err := git.RepairWorktreeLink(wt.Path, oldGitDir, newGitDir); err != nil {
+			s.log.Warn().Err(err).Str("branch", branch).Str("path", wt.Path).Msg("worktree link not repaired")
+		}
+	}
+	return &projectHandle{manager: s, record: projectRecordFromEntry(entry)}, nil
+}
+
 // Get loads a registered project by root path.
 func (s *projectManager) Get(_ context.Context, root string) (Project, error) {
 	entry, ok, err := s.reg.projectByRoot(root)
@@ -37,6 +37,9 @@ var _ project.ProjectManager = &ProjectManagerMock{}
 //			RegisterFunc: func(ctx context.Context, name string, repoPath string) (project.Project, error) {
 //				panic("mock out the Register method")
 //			},
+//			RelocateFunc: func(ctx context.Context, oldRoot string, newRoot string) (project.Project, error) {
+//				panic("mock out the Relocate method")
+//			},
 //			RemoveFunc: func(ctx context.Context, root string) error {
 //				panic("mock out the Remove method")
 //			},
@@ -71,6 +74,9 @@ type ProjectManagerMock struct {
 	// RegisterFunc mocks the Register method.
 	RegisterFunc func(ctx context.Context, name string, repoPath string) (project.Project, error)
 
+	// RelocateFunc mocks the Relocate method.
+	RelocateFunc func(ctx context.Context, oldRoot string, newRoot string) (project.Project, error)
+
 	// RemoveFunc mocks the Remove method.
 	RemoveFunc func(ctx context.Context, root string) error
 
@@ -118,6 +124,15 @@ type ProjectManagerMock struct {
 			// RepoPath is the repoPath argument value.
 			RepoPath string
 		}
+		// Relocate holds details about calls to the Relocate method.
+		Relocate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OldRoot is the oldRoot argument value.
+			OldRoot string
+			// NewRoot is the newRoot argument value.
+			NewRoot string
+		}
 		// Remove holds details about calls to the Remove method.
 		Remove []struct {
 			// Ctx is the ctx argument value.
@@ -146,6 +161,7 @@ type ProjectManagerMock struct {
 	lockListProjects   sync.RWMutex
 	lockListWorktrees  sync.RWMutex
 	lockRegister       sync.RWMutex
+	lockRelocate       sync.RWMutex
 	lockRemove         sync.RWMutex
 	lockResolvePath    sync.RWMutex
 	lockUpdate         sync.RWMutex
@@ -355,6 +371,46 @@ func (mock *ProjectManagerMock) RegisterCalls() []struct {
 	return calls
 }
 
+// Relocate calls RelocateFunc.
+func (mock *ProjectManagerMock) Relocate(ctx context.Context, oldRoot string, newRoot string) (project.Project, error) {
+	if mock.RelocateFunc == nil {
+		panic("ProjectManagerMock.RelocateFunc: method is nil but ProjectManager.Relocate was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		OldRoot string
+		NewRoot string
+	}{
+		Ctx:     ctx,
+		OldRoot: oldRoot,
+		NewRoot: newRoot,
+	}
+	mock.lockRelocate.Lock()
+	mock.calls.Relocate = append(mock.calls.Relocate, callInfo)
+	mock.lockRelocate.Unlock()
+	return mock.RelocateFunc(ctx, oldRoot, newRoot)
+}
+
+// RelocateCalls gets all the calls that were made to Relocate.
+// Check the length with:
+//
+//	len(mockedProjectManager.RelocateCalls())
+func (mock *ProjectManagerMock) RelocateCalls() []struct {
+	Ctx     context.Context
+	OldRoot string
+	NewRoot string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		OldRoot string
+		NewRoot string
+	}
+	mock.lockRelocate.RLock()
+	calls = mock.calls.Relocate
+	mock.lockRelocate.RUnlock()
+	return calls
+}
+
 // Remove calls RemoveFunc.
 func (mock *ProjectManagerMock) Remove(ctx context.Context, root string) error {
 	if mock.RemoveFunc == nil {
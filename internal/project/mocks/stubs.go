@@ -25,6 +25,9 @@ func NewMockProjectManager() *ProjectManagerMock {
 		RemoveFunc: func(ctx context.Context, root string) error {
 			return nil
 		},
+		RelocateFunc: func(ctx context.Context, oldRoot, newRoot string) (project.Project, error) {
+			return nil, nil
+		},
 		GetFunc: func(ctx context.Context, root string) (project.Project, error) {
 			return nil, project.ErrProjectNotFound
 		},
@@ -17,6 +17,7 @@ import (
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/project"
 	projectmocks "github.com/schmitthub/clawker/internal/project/mocks"
+	"github.com/schmitthub/clawker/internal/testenv"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -796,6 +797,24 @@ func TestUpdate(t *testing.T) {
 		assert.Equal(t, "new-name", got.Name())
 	})
 
+	t.Run("keeps trusted host commands unless replaced", func(t *testing.T) {
+		env := testenv.New(t, testenv.WithProjectManager(nil))
+		mgr := env.ProjectManager()
+		ctx := context.Background()
+		root := t.TempDir()
+
+		_, err := mgr.Register(ctx, "app", root)
+		require.NoError(t, err)
+		_, err = mgr.Update(ctx, project.ProjectEntry{Name: "app", Root: root, TrustedHostCommands: []string{"abc"}})
+		require.NoError(t, err)
+		_, err = mgr.Update(ctx, project.ProjectEntry{Name: "renamed", Root: root})
+		require.NoError(t, err)
+
+		trusted, err := env.Registry(t).TrustedHostCommands(root)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"abc"}, trusted, "an update that sets no approvals keeps the recorded ones")
+	})
+
 	t.Run("error for unregistered project", func(t *testing.T) {
 		mgr := projectmocks.NewTestProjectManager(t, nil)
 		ctx := context.Background()
@@ -808,6 +827,61 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
+func TestRelocate(t *testing.T) {
+	t.Run("moves the root and keeps the name", func(t *testing.T) {
+		mgr := projectmocks.NewTestProjectManager(t, nil)
+		ctx := context.Background()
+		oldRoot := filepath.Join(t.TempDir(), "app")
+		newRoot := t.TempDir()
+
+		_, err := mgr.Register(ctx, "my-app", oldRoot)
+		require.NoError(t, err)
+
+		moved, err := mgr.Relocate(ctx, oldRoot, newRoot)
+		require.NoError(t, err)
+		assert.Equal(t, "my-app", moved.Name())
+
+		_, err = mgr.Get(ctx, oldRoot)
+		assert.ErrorIs(t, err, project.ErrProjectNotFound)
+		got, err := mgr.Get(ctx, newRoot)
+		require.NoError(t, err)
+		assert.Equal(t, "my-app", got.Name())
+	})
+
+	t.Run("rejects a root registered to another project", func(t *testing.T) {
+		mgr := projectmocks.NewTestProjectManager(t, nil)
+		ctx := context.Background()
+		oldRoot, taken := t.TempDir(), t.TempDir()
+
+		_, err := mgr.Register(ctx, "a", oldRoot)
+		require.NoError(t, err)
+		_, err = mgr.Register(ctx, "b", taken)
+		require.NoError(t, err)
+
+		_, err = mgr.Relocate(ctx, oldRoot, taken)
+		assert.ErrorIs(t, err, project.ErrProjectExists)
+	})
+
+	t.Run("new root must exist", func(t *testing.T) {
+		mgr := projectmocks.NewTestProjectManager(t, nil)
+		ctx := context.Background()
+		oldRoot := t.TempDir()
+
+		_, err := mgr.Register(ctx, "a", oldRoot)
+		require.NoError(t, err)
+
+		_, err = mgr.Relocate(ctx, oldRoot, filepath.Join(t.TempDir(), "nope"))
+		assert.Error(t, err)
+	})
+
+	t.Run("error for unregistered project", func(t *testing.T) {
+		mgr := projectmocks.NewTestProjectManager(t, nil)
+
+		_, err := mgr.Relocate(context.Background(), "/nonexistent", t.TempDir())
+		assert.ErrorIs(t, err, project.ErrProjectNotFound)
+	})
+}
+
 func TestResolvePath(t *testing.T) {
 	t.Run("resolves registered root", func(t *testing.T) {
 		mgr := projectmocks.NewTestProjectManager(t, nil)
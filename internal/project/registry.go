@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"maps"
 	"path/filepath"
+	"slices"
 
 	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/storage"
@@ -77,12 +78,13 @@ func (r *Registry) projects() []ProjectEntry {
 }
 
 // list returns all project entries in undefined order. Each entry's Worktrees
-// map is cloned so callers never alias live store state.
+// map and TrustedHostCommands slice are cloned so callers never alias live store state.
 func (r *Registry) list() []ProjectEntry {
 	entries := r.projects()
 	result := make([]ProjectEntry, len(entries))
 	for i, entry := range entries {
 		entry.Worktrees = maps.Clone(entry.Worktrees)
+		entry.TrustedHostCommands = slices.Clone(entry.TrustedHostCommands)
 		result[i] = entry
 	}
 	return result
@@ -249,6 +251,9 @@ func (r *Registry) update(entry ProjectEntry) (ProjectEntry, error) {
 	if entry.Worktrees == nil {
 		entry.Worktrees = maps.Clone(existing.Worktrees)
 	}
+	if entry.TrustedHostCommands == nil {
+		entry.TrustedHostCommands = slices.Clone(existing.TrustedHostCommands)
+	}
 
 	entries := r.list()
 	entries[index] = entry
@@ -261,3 +266,49 @@ func (r *Registry) update(entry ProjectEntry) (ProjectEntry, error) {
 
 	return entry, nil
 }
+
+// relocate moves a registered project from oldRoot to newRoot, keeping its
+// name and worktrees. Worktree paths under the old root move with it.
+func (r *Registry) relocate(oldRoot, newRoot string) (ProjectEntry, error) {
+	if r == nil || r.store == nil {
+		return ProjectEntry{}, fmt.Errorf("registry not initialized")
+	}
+
+	absNew, err := filepath.Abs(newRoot)
+	if err != nil {
+		return ProjectEntry{}, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	index, existing, ok, err := r.findByResolvedRoot(oldRoot)
+	if err != nil {
+		return ProjectEntry{}, err
+	}
+	if !ok {
+		return ProjectEntry{}, ErrProjectNotFound
+	}
+	if _, _, taken, err := r.findByResolvedRoot(absNew); err != nil {
+		return ProjectEntry{}, err
+	} else if taken {
+		return ProjectEntry{}, ErrProjectExists
+	}
+
+	// list() clones each entry's Worktrees map, so rewriting the indexed
+	// entry never touches live store state.
+	entries := r.list()
+	entry := entries[index]
+	for branch, wt := range entry.Worktrees {
+		if rel, relErr := filepath.Rel(existing.Root, wt.Path); relErr == nil && filepath.IsLocal(rel) {
+			wt.Path = filepath.Join(absNew, rel)
+			entry.Worktrees[branch] = wt
+		}
+	}
+	entry.Root = absNew
+	entries[index] = entry
+	if err := r.setProjects(entries); err != nil {
+		return ProjectEntry{}, err
+	}
+	if err := r.save(); err != nil {
+		return ProjectEntry{}, err
+	}
+	return entry, nil
+}
@@ -7,6 +7,10 @@ type ProjectEntry struct {
 	Name      string                   `yaml:"name" label:"Name" desc:"Project slug identifier"`
 	Root      string                   `yaml:"root" label:"Root" desc:"Filesystem path to project root"`
 	Worktrees map[string]WorktreeEntry `yaml:"worktrees,omitempty" label:"Worktrees" desc:"Active worktrees for this project"`
+	// TrustedHostCommands are the fingerprints of the project-file host
+	// commands approved by clawker project trust (see config.HostCommand).
+	// Kept here, outside the workspace, so an agent cannot approve its own.
+	TrustedHostCommands []string `yaml:"trusted_host_commands,omitempty" label:"Trusted Host Commands" desc:"Fingerprints of the project-file hooks, scan commands, and secrets approved with clawker project trust"`
 }
 
 // WorktreeEntry represents a worktree within a project.
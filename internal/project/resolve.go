@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -116,3 +117,30 @@ func (r *Registry) CurrentRoot() (string, error) {
 	}
 	return r.ResolveRoot(cwd)
 }
+
+// RootByName returns the recorded root of the registered project named name,
+// as stored — the root is not checked against the filesystem, so callers
+// that chdir into it report a stale entry themselves. Returns
+// ErrProjectNotFound when no project has that name.
+func (r *Registry) RootByName(name string) (string, error) {
+	if r == nil || r.store == nil {
+		return "", fmt.Errorf("project: registry not initialized")
+	}
+	for _, p := range r.projects() {
+		if p.Name == name {
+			return p.Root, nil
+		}
+	}
+	return "", ErrProjectNotFound
+}
+
+// TrustedHostCommands returns the host command fingerprints approved for the
+// project at root (see clawker project trust), or nil when none are or the
+// project is not registered. The CLI factory hands them to config.
+func (r *Registry) TrustedHostCommands(root string) ([]string, error) {
+	entry, ok, err := r.projectByRoot(root)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return slices.Clone(entry.TrustedHostCommands), nil
+}
@@ -242,3 +242,22 @@ func TestRegistry_CurrentRoot_ConfigWalkUpSeam(t *testing.T) {
 		require.NotNil(t, cfg)
 	})
 }
+
+func TestRegistry_RootByName(t *testing.T) {
+	env := testenv.New(t)
+	root := mkdirAll(t, env, "proj")
+	stale := filepath.Join(env.Dirs.Base, "deleted")
+	writeRegistry(t, env, root, stale)
+	reg := env.Registry(t)
+
+	got, err := reg.RootByName("p0")
+	require.NoError(t, err)
+	assert.Equal(t, root, got)
+
+	got, err = reg.RootByName("p1")
+	require.NoError(t, err)
+	assert.Equal(t, stale, got, "a root missing from disk is still returned as recorded")
+
+	_, err = reg.RootByName("nope")
+	assert.ErrorIs(t, err, project.ErrProjectNotFound)
+}
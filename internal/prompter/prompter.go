@@ -51,9 +51,9 @@ type PromptConfig struct {
 
 // String prompts the user for a string value.
 // Returns the default if the user enters nothing.
-// In non-interactive mode, returns the default without prompting.
+// When prompts are off (no TTY, or --no-input), returns the default without prompting.
 func (p *Prompter) String(cfg PromptConfig) (string, error) {
-	if !p.ios.IsInteractive() {
+	if !p.ios.CanPrompt() {
 		if cfg.Required && cfg.Default == "" {
 			return "", fmt.Errorf("required input missing in non-interactive mode")
 		}
@@ -96,9 +96,9 @@ func (p *Prompter) String(cfg PromptConfig) (string, error) {
 }
 
 // Confirm prompts the user for a yes/no confirmation.
-// In non-interactive mode, returns the default without prompting.
+// When prompts are off (no TTY, or --no-input), returns the default without prompting.
 func (p *Prompter) Confirm(message string, defaultYes bool) (bool, error) {
-	if !p.ios.IsInteractive() {
+	if !p.ios.CanPrompt() {
 		return defaultYes, nil
 	}
 
@@ -135,7 +135,7 @@ type SelectOption struct {
 
 // Select prompts the user to select from a list of options.
 // Returns the index of the selected option.
-// In non-interactive mode, returns the defaultIdx without prompting.
+// When prompts are off (no TTY, or --no-input), returns the defaultIdx without prompting.
 func (p *Prompter) Select(message string, options []SelectOption, defaultIdx int) (int, error) {
 	if len(options) == 0 {
 		return -1, fmt.Errorf("no options provided")
@@ -145,7 +145,7 @@ func (p *Prompter) Select(message string, options []SelectOption, defaultIdx int
 		defaultIdx = 0
 	}
 
-	if !p.ios.IsInteractive() {
+	if !p.ios.CanPrompt() {
 		return defaultIdx, nil
 	}
 
@@ -415,3 +415,23 @@ func TestPrompter_Select(t *testing.T) {
 		})
 	}
 }
+
+func TestPrompter_NeverPrompt(t *testing.T) {
+	ios, in, _, errOut := iostreams.Test()
+	ios.SetStdinTTY(true)
+	ios.SetStdoutTTY(true)
+	ios.SetNeverPrompt(true)
+	in.Write([]byte("y\n"))
+
+	p := NewPrompter(ios)
+	got, err := p.Confirm("Proceed?", false)
+	if err != nil {
+		t.Fatalf("Confirm() unexpected error: %v", err)
+	}
+	if got {
+		t.Error("Confirm() = true, want the default (false) without prompting")
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("Confirm() wrote a prompt: %q", errOut.String())
+	}
+}
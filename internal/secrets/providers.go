@@ -0,0 +1,151 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/schmitthub/clawker/internal/config"
+)
+
+// CommandRunner runs a host command in dir and returns its stdout. A
+// non-zero exit is an error carrying the command's stderr.
+type CommandRunner func(ctx context.Context, dir, name string, args ...string) ([]byte, error)
+
+// builtinProviders returns the providers every Resolver starts with.
+func builtinProviders(opts Options) map[string]Provider {
+	lookup := opts.LookupEnv
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	run := opts.Run
+	if run == nil {
+		run = runCommand
+	}
+	dir := opts.ProjectDir
+
+	return map[string]Provider{
+		// env: a host env var, verbatim.
+		config.SecretProviderEnv: ProviderFunc(func(_ context.Context, ref string) ([]byte, error) {
+			v, ok := lookup(ref)
+			if !ok {
+				return nil, fmt.Errorf("host env var %s is not set", ref)
+			}
+			return []byte(v), nil
+		}),
+
+		// file: a host file, verbatim. ~ expands to the home dir; relative
+		// paths resolve against the project dir.
+		config.SecretProviderFile: ProviderFunc(func(_ context.Context, ref string) ([]byte, error) {
+			path, err := hostPath(ref, dir)
+			if err != nil {
+				return nil, err
+			}
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", ref, err)
+			}
+			return b, nil
+		}),
+
+		// op: a 1Password secret reference (op://vault/item/field), read
+		// through the 1Password CLI.
+		config.SecretProviderOp: ProviderFunc(func(ctx context.Context, ref string) ([]byte, error) {
+			return run(ctx, dir, "op", "read", "--no-newline", ref)
+		}),
+
+		// pass: a password-store entry. By pass convention the first line
+		// is the secret; the rest is metadata.
+		config.SecretProviderPass: ProviderFunc(func(ctx context.Context, ref string) ([]byte, error) {
+			out, err := run(ctx, dir, "pass", "show", ref)
+			if err != nil {
+				return nil, err
+			}
+			first, _, _ := bytes.Cut(out, []byte("\n"))
+			return first, nil
+		}),
+
+		// exec: the stdout of a host shell command, less one trailing
+		// newline.
+		config.SecretProviderExec: ProviderFunc(func(ctx context.Context, ref string) ([]byte, error) {
+			out, err := run(ctx, dir, "/bin/sh", "-c", ref)
+			if err != nil {
+				return nil, err
+			}
+			out = bytes.TrimSuffix(out, []byte("\n"))
+			return bytes.TrimSuffix(out, []byte("\r")), nil
+		}),
+	}
+}
+
+// runCommand is the default CommandRunner. stdin is not attached, so a
+// provider CLI that needs to prompt fails instead of hanging.
+func runCommand(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, fmt.Errorf("%s not found in PATH", name)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// readConfined reads a `file` ref that must lie inside one of roots. The
+// file is opened with os.OpenInRoot, so a symlink leading out of the root is
+// refused too.
+func readConfined(ref, dir string, roots []string) ([]byte, error) {
+	path, err := hostPath(ref, dir)
+	if err != nil {
+		return nil, err
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, path)
+		if err != nil || !filepath.IsLocal(rel) {
+			continue
+		}
+		f, err := os.OpenInRoot(root, rel)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", ref, err)
+		}
+		defer f.Close()
+		b, err := io.ReadAll(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", ref, err)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("%s is outside the allowed directories", ref)
+}
+
+// hostPath expands a leading ~ and anchors a relative path at dir.
+func hostPath(ref, dir string) (string, error) {
+	if ref == "~" || strings.HasPrefix(ref, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("expanding ~ in %q: %w", ref, err)
+		}
+		ref = filepath.Join(home, strings.TrimPrefix(ref, "~"))
+	}
+	if filepath.IsAbs(ref) || dir == "" {
+		return ref, nil
+	}
+	return filepath.Join(dir, ref), nil
+}
@@ -0,0 +1,229 @@
+// Package secrets resolves the project `secrets:` block on the host and
+// shapes the values for injection into agent containers.
+//
+// A value only ever lives in process memory: Secret redacts itself under
+// fmt and zerolog, errors name the secret and provider but never carry the
+// value, and injection goes through the container env or a tmpfs (see
+// consts.SecretsDir) — never a label, an image layer, or a host file.
+package secrets
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/schmitthub/clawker/internal/config"
+)
+
+// Secret is one resolved secret and where the container receives it.
+//
+// String and GoString redact, so a Secret (or a slice of them) cannot leak
+// through fmt verbs or an interface-typed log field. Read Value directly.
+type Secret struct {
+	// Name is the secrets: key.
+	Name string
+	// Env is the container env var the value is injected as; "" when the
+	// secret is not injected into the environment.
+	Env string
+	// File is the file name under consts.SecretsDir; "" when the secret is
+	// not injected as a file.
+	File  string
+	Value []byte
+}
+
+// String redacts the value.
+func (s Secret) String() string { return "Secret{" + s.Name + ": <redacted>}" }
+
+// GoString redacts the value under %#v.
+func (s Secret) GoString() string { return s.String() }
+
+// Provider resolves a provider-specific reference to a secret value.
+type Provider interface {
+	Resolve(ctx context.Context, ref string) ([]byte, error)
+}
+
+// ProviderFunc adapts a function to Provider.
+type ProviderFunc func(ctx context.Context, ref string) ([]byte, error)
+
+// Resolve calls f.
+func (f ProviderFunc) Resolve(ctx context.Context, ref string) ([]byte, error) { return f(ctx, ref) }
+
+// ErrUnknownProvider is returned for a secret naming a provider that is not
+// registered.
+var ErrUnknownProvider = errors.New("unknown secret provider")
+
+// Options configures NewResolver. Zero values select the host defaults.
+type Options struct {
+	// ProjectDir anchors relative `file` refs and is the working directory
+	// of `exec` commands.
+	ProjectDir string
+	// LookupEnv reads host env vars for the `env` provider. Defaults to
+	// os.LookupEnv.
+	LookupEnv func(string) (string, bool)
+	// Run executes a host command and returns its stdout. Defaults to
+	// os/exec; tests inject a fake.
+	Run CommandRunner
+	// Check, when set, vets each secret before its provider runs; an error
+	// fails the secret. The container commands use it to refuse exec and
+	// file secrets from an unapproved project config file.
+	Check func(name string, spec config.SecretConfig) error
+	// ConfineFile, when set, returns the directories a secret's `file` ref
+	// must stay inside, or nil to leave the secret unconfined. A confined
+	// file is opened beneath its root, so neither the ref nor a symlink
+	// along it can reach outside.
+	ConfineFile func(name string) []string
+}
+
+// Resolver resolves secrets through its registered providers.
+type Resolver struct {
+	providers map[string]Provider
+	dir       string
+	check     func(name string, spec config.SecretConfig) error
+	confine   func(name string) []string
+}
+
+// NewResolver returns a Resolver with the built-in providers (env, file,
+// op, pass, exec) registered.
+func NewResolver(opts Options) *Resolver {
+	r := &Resolver{
+		providers: make(map[string]Provider),
+		dir:       opts.ProjectDir,
+		check:     opts.Check,
+		confine:   opts.ConfineFile,
+	}
+	for name, p := range builtinProviders(opts) {
+		r.Register(name, p)
+	}
+	return r
+}
+
+// Register adds or replaces the provider for name.
+func (r *Resolver) Register(name string, p Provider) {
+	r.providers[name] = p
+}
+
+// Resolve resolves one secret. The merged entry must name a provider and a
+// ref; a value injected as an env var must not contain a NUL byte.
+func (r *Resolver) Resolve(ctx context.Context, name string, spec config.SecretConfig) (Secret, error) {
+	if spec.Provider == "" || spec.Ref == "" {
+		return Secret{}, fmt.Errorf("secret %q: provider and ref are required", name)
+	}
+	p, ok := r.providers[spec.Provider]
+	if !ok {
+		return Secret{}, fmt.Errorf("secret %q: %w %q", name, ErrUnknownProvider, spec.Provider)
+	}
+	if r.check != nil {
+		if err := r.check(name, spec); err != nil {
+			return Secret{}, fmt.Errorf("secret %q: %w", name, err)
+		}
+	}
+	var value []byte
+	var err error
+	if roots := r.confinedRoots(name, spec); roots != nil {
+		value, err = readConfined(spec.Ref, r.dir, roots)
+	} else {
+		value, err = p.Resolve(ctx, spec.Ref)
+	}
+	if err != nil {
+		return Secret{}, fmt.Errorf("secret %q (%s): %w", name, spec.Provider, err)
+	}
+	if spec.Env != "" && bytes.IndexByte(value, 0) >= 0 {
+		return Secret{}, fmt.Errorf("secret %q: value contains a NUL byte and cannot be injected as env var %s", name, spec.Env)
+	}
+	return Secret{Name: name, Env: spec.Env, File: spec.FileName(name), Value: value}, nil
+}
+
+// confinedRoots returns the roots a file secret is confined to, or nil.
+func (r *Resolver) confinedRoots(name string, spec config.SecretConfig) []string {
+	if r.confine == nil || spec.Provider != config.SecretProviderFile {
+		return nil
+	}
+	return r.confine(name)
+}
+
+// ResolveAll resolves every spec in name order, stopping at the first
+// failure.
+func (r *Resolver) ResolveAll(ctx context.Context, specs map[string]config.SecretConfig) ([]Secret, error) {
+	out := make([]Secret, 0, len(specs))
+	for _, name := range slices.Sorted(maps.Keys(specs)) {
+		s, err := r.Resolve(ctx, name, specs[name])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// EnvSpecs returns the specs injected as env vars.
+func EnvSpecs(specs map[string]config.SecretConfig) map[string]config.SecretConfig {
+	return filterSpecs(specs, func(name string, s config.SecretConfig) bool { return s.Env != "" })
+}
+
+// FileSpecs returns the specs injected as files.
+func FileSpecs(specs map[string]config.SecretConfig) map[string]config.SecretConfig {
+	return filterSpecs(specs, func(name string, s config.SecretConfig) bool { return s.FileName(name) != "" })
+}
+
+func filterSpecs(specs map[string]config.SecretConfig, keep func(string, config.SecretConfig) bool) map[string]config.SecretConfig {
+	out := make(map[string]config.SecretConfig)
+	for name, s := range specs {
+		if keep(name, s) {
+			out[name] = s
+		}
+	}
+	return out
+}
+
+// EnvVars returns the KEY=value entries for the secrets injected as env
+// vars.
+func EnvVars(secrets []Secret) []string {
+	var env []string
+	for _, s := range secrets {
+		if s.Env != "" {
+			env = append(env, s.Env+"="+string(s.Value))
+		}
+	}
+	return env
+}
+
+// FilesTar archives the secrets injected as files — flat, 0400, owned by
+// uid:gid — for extraction into consts.SecretsDir. Two secrets targeting
+// the same file name are an error.
+func FilesTar(secrets []Secret, uid, gid int) (*bytes.Buffer, error) {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	now := time.Now()
+	seen := make(map[string]string)
+	for _, s := range secrets {
+		if s.File == "" {
+			continue
+		}
+		if other, dup := seen[s.File]; dup {
+			return nil, fmt.Errorf("secrets %q and %q both write file %s", other, s.Name, s.File)
+		}
+		seen[s.File] = s.Name
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    s.File,
+			Mode:    0o400,
+			Size:    int64(len(s.Value)),
+			Uid:     uid,
+			Gid:     gid,
+			ModTime: now,
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(s.Value); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
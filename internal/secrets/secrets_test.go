@@ -0,0 +1,197 @@
+package secrets
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/config"
+)
+
+// fakeRun records the command it was handed and answers with out/err.
+func fakeRun(got *[]string, out string, err error) CommandRunner {
+	return func(_ context.Context, _ string, name string, args ...string) ([]byte, error) {
+		*got = append([]string{name}, args...)
+		return []byte(out), err
+	}
+}
+
+func TestProviders(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key.pem"), []byte("PEM\n"), 0o600))
+
+	tests := []struct {
+		name    string
+		spec    config.SecretConfig
+		out     string
+		want    string
+		wantCmd []string
+	}{
+		{name: "env", spec: config.SecretConfig{Provider: "env", Ref: "HOST_TOKEN"}, want: "from-env"},
+		{name: "file keeps bytes", spec: config.SecretConfig{Provider: "file", Ref: "key.pem"}, want: "PEM\n"},
+		{
+			name: "op", spec: config.SecretConfig{Provider: "op", Ref: "op://vault/item/field"},
+			out: "s3cr3t", want: "s3cr3t",
+			wantCmd: []string{"op", "read", "--no-newline", "op://vault/item/field"},
+		},
+		{
+			name: "pass takes first line", spec: config.SecretConfig{Provider: "pass", Ref: "work/api"},
+			out: "s3cr3t\nuser: me\n", want: "s3cr3t",
+			wantCmd: []string{"pass", "show", "work/api"},
+		},
+		{
+			name: "exec trims one newline", spec: config.SecretConfig{Provider: "exec", Ref: "vault read -field=v x"},
+			out: "s3cr3t\n", want: "s3cr3t",
+			wantCmd: []string{"/bin/sh", "-c", "vault read -field=v x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotCmd []string
+			r := NewResolver(Options{
+				ProjectDir: dir,
+				LookupEnv:  func(k string) (string, bool) { return "from-env", k == "HOST_TOKEN" },
+				Run:        fakeRun(&gotCmd, tt.out, nil),
+			})
+			s, err := r.Resolve(context.Background(), "tok", tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(s.Value))
+			assert.Equal(t, tt.wantCmd, gotCmd)
+		})
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	var cmd []string
+	r := NewResolver(Options{
+		LookupEnv: func(string) (string, bool) { return "", false },
+		Run:       fakeRun(&cmd, "", errors.New("op: not signed in")),
+	})
+	ctx := context.Background()
+
+	_, err := r.Resolve(ctx, "a", config.SecretConfig{Provider: "env", Ref: "MISSING"})
+	assert.ErrorContains(t, err, `secret "a" (env): host env var MISSING is not set`)
+
+	_, err = r.Resolve(ctx, "b", config.SecretConfig{Provider: "op", Ref: "op://x/y/z"})
+	assert.ErrorContains(t, err, "not signed in")
+
+	_, err = r.Resolve(ctx, "c", config.SecretConfig{Provider: "vault", Ref: "x"})
+	assert.ErrorIs(t, err, ErrUnknownProvider)
+
+	_, err = r.Resolve(ctx, "d", config.SecretConfig{Provider: "env"})
+	assert.ErrorContains(t, err, "provider and ref are required")
+}
+
+func TestResolve_EnvRejectsNUL(t *testing.T) {
+	r := NewResolver(Options{LookupEnv: func(string) (string, bool) { return "a\x00b", true }})
+	_, err := r.Resolve(context.Background(), "t", config.SecretConfig{Provider: "env", Ref: "X", Env: "T"})
+	assert.ErrorContains(t, err, "NUL")
+}
+
+func TestResolve_Check(t *testing.T) {
+	var cmd []string
+	r := NewResolver(Options{
+		Run: fakeRun(&cmd, "s3cr3t", nil),
+		Check: func(name string, spec config.SecretConfig) error {
+			if spec.Provider == "exec" {
+				return errors.New("not approved")
+			}
+			return nil
+		},
+	})
+	_, err := r.Resolve(context.Background(), "tok", config.SecretConfig{Provider: "exec", Ref: "cat ~/.ssh/id_ed25519"})
+	assert.ErrorContains(t, err, `secret "tok": not approved`)
+	assert.Nil(t, cmd, "a refused secret never reaches its provider")
+}
+
+func TestResolve_ConfineFile(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "token"), []byte("in"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "id_rsa"), []byte("out"), 0o600))
+	require.NoError(t, os.Symlink(filepath.Join(outside, "id_rsa"), filepath.Join(root, "link")))
+
+	r := NewResolver(Options{
+		ProjectDir: root,
+		ConfineFile: func(name string) []string {
+			if name == "trusted" {
+				return nil
+			}
+			return []string{root}
+		},
+	})
+	ctx := context.Background()
+
+	s, err := r.Resolve(ctx, "t", config.SecretConfig{Provider: "file", Ref: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "in", string(s.Value))
+
+	_, err = r.Resolve(ctx, "t", config.SecretConfig{Provider: "file", Ref: filepath.Join(outside, "id_rsa")})
+	assert.ErrorContains(t, err, "outside the allowed directories")
+
+	_, err = r.Resolve(ctx, "t", config.SecretConfig{Provider: "file", Ref: "../" + filepath.Base(outside) + "/id_rsa"})
+	assert.ErrorContains(t, err, "outside the allowed directories")
+
+	_, err = r.Resolve(ctx, "t", config.SecretConfig{Provider: "file", Ref: "link"})
+	assert.Error(t, err, "a symlink out of the root is refused")
+
+	s, err = r.Resolve(ctx, "trusted", config.SecretConfig{Provider: "file", Ref: filepath.Join(outside, "id_rsa")})
+	require.NoError(t, err)
+	assert.Equal(t, "out", string(s.Value), "an unconfined secret reads anywhere")
+}
+
+func TestRegister(t *testing.T) {
+	r := NewResolver(Options{})
+	r.Register("vault", ProviderFunc(func(_ context.Context, ref string) ([]byte, error) {
+		return []byte("v:" + ref), nil
+	}))
+	s, err := r.Resolve(context.Background(), "t", config.SecretConfig{Provider: "vault", Ref: "kv/x"})
+	require.NoError(t, err)
+	assert.Equal(t, "v:kv/x", string(s.Value))
+}
+
+func TestSecret_Redacts(t *testing.T) {
+	s := Secret{Name: "token", Env: "API_TOKEN", Value: []byte("s3cr3t")}
+	for _, out := range []string{fmt.Sprint(s), fmt.Sprintf("%v", []Secret{s}), fmt.Sprintf("%#v", s)} {
+		assert.NotContains(t, out, "s3cr3t")
+	}
+}
+
+func TestSpecFilters(t *testing.T) {
+	specs := map[string]config.SecretConfig{
+		"env_only": {Provider: "env", Ref: "A", Env: "A"},
+		"both":     {Provider: "env", Ref: "B", Env: "B", File: "b"},
+		"file":     {Provider: "env", Ref: "C"},
+	}
+	assert.Len(t, EnvSpecs(specs), 2)
+	assert.Len(t, FileSpecs(specs), 2)
+	assert.NotContains(t, FileSpecs(specs), "env_only")
+}
+
+func TestFilesTar(t *testing.T) {
+	buf, err := FilesTar([]Secret{
+		{Name: "env_only", Env: "A", Value: []byte("a")},
+		{Name: "key", File: "key", Value: []byte("k")},
+	}, 1001, 1001)
+	require.NoError(t, err)
+
+	tr := tar.NewReader(buf)
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "key", hdr.Name)
+	assert.Equal(t, int64(0o400), hdr.Mode)
+	assert.Equal(t, 1001, hdr.Uid)
+	_, err = tr.Next()
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = FilesTar([]Secret{{Name: "a", File: "f"}, {Name: "b", File: "f"}}, 0, 0)
+	assert.ErrorContains(t, err, "both write file f")
+}
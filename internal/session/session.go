@@ -0,0 +1,210 @@
+// Package session is the host-side store for agent conversation state.
+//
+// Harness bundles declare the container dirs holding their conversation
+// state (harness.yaml sessions:). When an agent container stops, those dirs
+// are saved here, one entry per container name, so a recreated container
+// for the same project and agent can have them restored into its fresh
+// volumes. The store knows nothing about Docker: callers fill an entry's
+// files directory and the store swaps it in whole.
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// MetaFile is the entry's metadata file; the saved dirs live beside it
+// under files/, laid out relative to the container home.
+const (
+	MetaFile = "session.json"
+	filesDir = "files"
+)
+
+// ErrNotFound is returned when no session is saved for a container.
+var ErrNotFound = errors.New("no saved session")
+
+// Meta describes one saved session.
+type Meta struct {
+	Container string    `json:"container"`
+	Project   string    `json:"project,omitempty"`
+	Agent     string    `json:"agent"`
+	Harness   string    `json:"harness"`
+	SavedAt   time.Time `json:"saved_at"`
+	// Paths are the container-home-relative dirs that were saved.
+	Paths []string `json:"paths"`
+	Files int      `json:"files"`
+	Bytes int64    `json:"bytes"`
+}
+
+// Store is a directory of saved sessions keyed by container name.
+type Store struct {
+	root string
+}
+
+// NewStore returns a store rooted at dir.
+func NewStore(dir string) *Store {
+	return &Store{root: dir}
+}
+
+// DefaultStore returns the store under the clawker data directory.
+func DefaultStore() (*Store, error) {
+	dir, err := consts.SessionsSubdir()
+	if err != nil {
+		return nil, fmt.Errorf("creating sessions directory: %w", err)
+	}
+	return NewStore(dir), nil
+}
+
+// FilesDir returns the directory holding a container's saved files, laid
+// out relative to the container home (e.g. files/.claude/projects).
+func (s *Store) FilesDir(container string) string {
+	return filepath.Join(s.root, container, filesDir)
+}
+
+// Save replaces the container's saved session. fill writes the session
+// files into the directory it is given; the entry is assembled beside the
+// store entry and swapped in, so a failed save keeps the previous one. A
+// save that produced no files leaves the previous session untouched and
+// returns nil.
+func (s *Store) Save(meta Meta, fill func(dir string) error) (*Meta, error) {
+	if err := validName(meta.Container); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(s.root, 0o700); err != nil {
+		return nil, fmt.Errorf("creating sessions directory: %w", err)
+	}
+	tmp, err := os.MkdirTemp(s.root, meta.Container+".tmp-")
+	if err != nil {
+		return nil, fmt.Errorf("saving session: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	files := filepath.Join(tmp, filesDir)
+	if err := os.MkdirAll(files, 0o700); err != nil {
+		return nil, fmt.Errorf("saving session: %w", err)
+	}
+	if err := fill(files); err != nil {
+		return nil, err
+	}
+	meta.Files, meta.Bytes, err = countFiles(files)
+	if err != nil {
+		return nil, fmt.Errorf("saving session: %w", err)
+	}
+	if meta.Files == 0 {
+		return nil, nil
+	}
+	if err := writeMeta(filepath.Join(tmp, MetaFile), meta); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(s.root, meta.Container)
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("replacing saved session: %w", err)
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return nil, fmt.Errorf("replacing saved session: %w", err)
+	}
+	return &meta, nil
+}
+
+// Get returns the container's saved session, or ErrNotFound.
+func (s *Store) Get(container string) (*Meta, error) {
+	if err := validName(container); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(s.root, container, MetaFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading saved session: %w", err)
+	}
+	var meta Meta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("reading saved session %s: %w", container, err)
+	}
+	return &meta, nil
+}
+
+// List returns every saved session, sorted by container name. Entries
+// with unreadable metadata are skipped.
+func (s *Store) List() ([]Meta, error) {
+	entries, err := os.ReadDir(s.root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	var out []Meta
+	for _, e := range entries {
+		if !e.IsDir() || strings.Contains(e.Name(), ".tmp-") {
+			continue
+		}
+		meta, err := s.Get(e.Name())
+		if err != nil {
+			continue
+		}
+		out = append(out, *meta)
+	}
+	slices.SortFunc(out, func(a, b Meta) int { return strings.Compare(a.Container, b.Container) })
+	return out, nil
+}
+
+// Delete removes the container's saved session, or returns ErrNotFound.
+func (s *Store) Delete(container string) error {
+	if _, err := s.Get(container); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(s.root, container)); err != nil {
+		return fmt.Errorf("deleting saved session: %w", err)
+	}
+	return nil
+}
+
+func validName(container string) error {
+	if container == "" || !filepath.IsLocal(container) || strings.ContainsAny(container, `/\`) {
+		return fmt.Errorf("invalid session name %q", container)
+	}
+	return nil
+}
+
+func writeMeta(path string, meta Meta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding session metadata: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("writing session metadata: %w", err)
+	}
+	return nil
+}
+
+func countFiles(dir string) (int, int64, error) {
+	var (
+		n    int
+		size int64
+	)
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		n++
+		size += info.Size()
+		return nil
+	})
+	return n, size, err
+}
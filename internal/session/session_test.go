@@ -0,0 +1,88 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestStore_SaveGetListDelete(t *testing.T) {
+	s := NewStore(t.TempDir())
+	saved := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	meta, err := s.Save(Meta{Container: "clawker.app.dev", Project: "app", Agent: "dev", Harness: "claude", SavedAt: saved, Paths: []string{".claude/projects"}},
+		func(dir string) error {
+			writeFile(t, filepath.Join(dir, ".claude/projects/-app/a.jsonl"), "hello")
+			writeFile(t, filepath.Join(dir, ".claude/projects/-app/b.jsonl"), "hi")
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, 2, meta.Files)
+	assert.Equal(t, int64(7), meta.Bytes)
+
+	got, err := s.Get("clawker.app.dev")
+	require.NoError(t, err)
+	assert.Equal(t, *meta, *got)
+	data, err := os.ReadFile(filepath.Join(s.FilesDir("clawker.app.dev"), ".claude/projects/-app/a.jsonl"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	_, err = s.Save(Meta{Container: "clawker.app.api", Agent: "api"}, func(dir string) error {
+		writeFile(t, filepath.Join(dir, ".codex/sessions/x"), "x")
+		return nil
+	})
+	require.NoError(t, err)
+	list, err := s.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "clawker.app.api", list[0].Container)
+
+	require.NoError(t, s.Delete("clawker.app.api"))
+	assert.ErrorIs(t, s.Delete("clawker.app.api"), ErrNotFound)
+	_, err = s.Get("clawker.app.api")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore_SaveKeepsPreviousOnFailureOrEmpty(t *testing.T) {
+	s := NewStore(t.TempDir())
+	fill := func(dir string) error {
+		writeFile(t, filepath.Join(dir, ".claude/projects/a"), "a")
+		return nil
+	}
+	_, err := s.Save(Meta{Container: "clawker.app.dev"}, fill)
+	require.NoError(t, err)
+
+	_, err = s.Save(Meta{Container: "clawker.app.dev"}, func(string) error { return errors.New("copy failed") })
+	require.EqualError(t, err, "copy failed")
+
+	meta, err := s.Save(Meta{Container: "clawker.app.dev"}, func(string) error { return nil })
+	require.NoError(t, err)
+	assert.Nil(t, meta, "an empty capture saves nothing")
+
+	got, err := s.Get("clawker.app.dev")
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Files)
+	list, err := s.List()
+	require.NoError(t, err)
+	assert.Len(t, list, 1, "no temporary entries are left behind")
+}
+
+func TestStore_RejectsPathNames(t *testing.T) {
+	s := NewStore(t.TempDir())
+	for _, name := range []string{"", "..", "a/b", "../x"} {
+		_, err := s.Get(name)
+		assert.Error(t, err, name)
+		assert.NotErrorIs(t, err, ErrNotFound, name)
+	}
+}
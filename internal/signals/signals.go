@@ -1,6 +1,7 @@
 // Package signals provides OS signal utilities for graceful shutdown and
 // terminal resize propagation. This is a leaf package — stdlib only, no
-// internal imports, no logging.
+// internal imports, no logging; callers that want resize failures logged
+// register a handler with SetErrorHandler.
 package signals
 
 import (
@@ -9,6 +10,7 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 )
 
 // SetupSignalContext creates a context that's canceled on SIGINT/SIGTERM.
@@ -30,6 +32,10 @@ func SetupSignalContext(parent context.Context) (context.Context, context.Cancel
 	return ctx, cancel
 }
 
+// resizeDebounce is how long the handler waits for a burst of SIGWINCH
+// (a window being dragged) to settle before resizing the remote TTY once.
+const resizeDebounce = 50 * time.Millisecond
+
 // ResizeHandler manages terminal resize signals (SIGWINCH).
 // It takes closures for resize and size-query operations so the caller
 // decides *what* to resize and *how* to measure — keeping this package
@@ -38,23 +44,43 @@ type ResizeHandler struct {
 	sigChan    chan os.Signal
 	resizeFunc func(height, width uint) error // called with (height, width) — note: swapped from getSize's (width, height)
 	getSize    func() (width, height int, err error)
+	debounce   time.Duration
+	after      func(time.Duration) <-chan time.Time // debounce clock; time.After outside tests
 	done       chan struct{}
 	stopOnce   sync.Once
+
+	// mu guards the last size sent, so a signal that leaves the size
+	// unchanged costs no API call, and the error handler.
+	mu                    sync.Mutex
+	lastWidth, lastHeight int
+	onError               func(error)
 }
 
 // NewResizeHandler creates a new resize handler.
 //
-//   - resizeFunc is called with (height, width) whenever SIGWINCH arrives.
+//   - resizeFunc is called with (height, width) once a burst of SIGWINCH
+//     settles, when the size differs from the last one sent.
 //   - getSize returns the current terminal dimensions (width, height).
 func NewResizeHandler(resizeFunc func(height, width uint) error, getSize func() (width, height int, err error)) *ResizeHandler {
 	return &ResizeHandler{
 		sigChan:    make(chan os.Signal, 1),
 		resizeFunc: resizeFunc,
 		getSize:    getSize,
+		debounce:   resizeDebounce,
+		after:      time.After,
 		done:       make(chan struct{}),
 	}
 }
 
+// SetErrorHandler registers fn to receive resizeFunc failures. Without
+// one, failures are dropped — resize is best-effort. A failed resize is
+// not remembered as sent, so the next signal retries it.
+func (h *ResizeHandler) SetErrorHandler(fn func(error)) {
+	h.mu.Lock()
+	h.onError = fn
+	h.mu.Unlock()
+}
+
 // Start begins listening for resize signals.
 func (h *ResizeHandler) Start() {
 	signal.Notify(h.sigChan, syscall.SIGWINCH)
@@ -70,25 +96,33 @@ func (h *ResizeHandler) Stop() {
 	})
 }
 
-// handle processes resize signals.
+// handle processes resize signals. Each signal restarts the debounce
+// timer, abandoning the previous one; the resize runs when the latest
+// fires.
 func (h *ResizeHandler) handle() {
 	defer func() {
 		// Recover from panics (e.g. send on closed channel) to avoid
 		// crashing the host process — resize is best-effort.
 		recover() //nolint:revive // intentionally discarding recovered value
 	}()
+	var fire <-chan time.Time
 	for {
 		select {
 		case <-h.done:
 			return
 		case <-h.sigChan:
-			h.doResize()
+			fire = h.after(h.debounce)
+		case <-fire:
+			fire = nil
+			h.doResize(false)
 		}
 	}
 }
 
-// doResize performs the actual resize operation.
-func (h *ResizeHandler) doResize() {
+// doResize performs the actual resize operation. Unless force is set, it
+// is skipped when the size matches the last one sent. The size is only
+// recorded as sent once resizeFunc succeeds.
+func (h *ResizeHandler) doResize(force bool) {
 	if h.getSize == nil || h.resizeFunc == nil {
 		return
 	}
@@ -98,10 +132,30 @@ func (h *ResizeHandler) doResize() {
 		return
 	}
 
-	_ = h.resizeFunc(uint(height), uint(width))
+	h.mu.Lock()
+	unchanged := width == h.lastWidth && height == h.lastHeight
+	h.mu.Unlock()
+	if !force && unchanged {
+		return
+	}
+
+	if err := h.resizeFunc(uint(height), uint(width)); err != nil {
+		h.mu.Lock()
+		onError := h.onError
+		h.mu.Unlock()
+		if onError != nil {
+			onError(err)
+		}
+		return
+	}
+
+	h.mu.Lock()
+	h.lastWidth, h.lastHeight = width, height
+	h.mu.Unlock()
 }
 
-// TriggerResize manually triggers a resize operation.
+// TriggerResize manually triggers a resize operation, even when the size
+// is unchanged.
 func (h *ResizeHandler) TriggerResize() {
-	h.doResize()
+	h.doResize(true)
 }
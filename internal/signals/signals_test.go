@@ -3,7 +3,9 @@ package signals
 import (
 	"context"
 	"errors"
+	"os"
 	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -113,3 +115,196 @@ func TestResizeHandler_DoubleStop(t *testing.T) {
 	rh.Stop()
 	rh.Stop() // second Stop must not panic
 }
+
+// resizeRecorder captures resizeFunc calls for the lifecycle tests.
+type resizeRecorder struct {
+	calls  atomic.Int32
+	height atomic.Uint32
+	width  atomic.Uint32
+}
+
+func (r *resizeRecorder) resize(h, w uint) error {
+	r.height.Store(uint32(h))
+	r.width.Store(uint32(w))
+	r.calls.Add(1)
+	return nil
+}
+
+func (r *resizeRecorder) waitCalls(t *testing.T, want int32) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for r.calls.Load() < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d resize calls, got %d", want, r.calls.Load())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestResizeHandler_SIGWINCHLifecycle(t *testing.T) {
+	var rec resizeRecorder
+	var width, height atomic.Int32
+	width.Store(80)
+	height.Store(24)
+
+	rh := NewResizeHandler(rec.resize, func() (int, int, error) {
+		return int(width.Load()), int(height.Load()), nil
+	})
+	rh.debounce = 10 * time.Millisecond
+	rh.Start()
+	defer rh.Stop()
+
+	// Session start: the caller sizes the remote TTY up front.
+	rh.TriggerResize()
+	rec.waitCalls(t, 1)
+
+	// Mid-session the local terminal grows and the kernel signals us.
+	width.Store(132)
+	height.Store(50)
+	if err := syscall.Kill(os.Getpid(), syscall.SIGWINCH); err != nil {
+		t.Fatalf("kill: %v", err)
+	}
+	rec.waitCalls(t, 2)
+
+	if rec.height.Load() != 50 || rec.width.Load() != 132 {
+		t.Errorf("expected (height=50, width=132), got (height=%d, width=%d)", rec.height.Load(), rec.width.Load())
+	}
+}
+
+// fakeClock stands in for time.After so debounce tests fire timers
+// explicitly instead of sleeping. Each call hands the test its channel.
+type fakeClock struct {
+	timers chan chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{timers: make(chan chan time.Time, 64)}
+}
+
+func (c *fakeClock) after(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.timers <- ch
+	return ch
+}
+
+// next returns the timer started by the signal just delivered.
+func (c *fakeClock) next(t *testing.T) chan time.Time {
+	t.Helper()
+	select {
+	case ch := <-c.timers:
+		return ch
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler did not start a debounce timer")
+		return nil
+	}
+}
+
+func TestResizeHandler_DebouncesBursts(t *testing.T) {
+	var rec resizeRecorder
+	var width atomic.Int32
+	width.Store(80)
+
+	clock := newFakeClock()
+	rh := NewResizeHandler(rec.resize, func() (int, int, error) {
+		return int(width.Load()), 24, nil
+	})
+	rh.after = clock.after
+	rh.Start()
+	defer rh.Stop()
+
+	// A window drag: many signals, each with a new size and each
+	// restarting the debounce timer.
+	var timers []chan time.Time
+	for i := range 10 {
+		width.Store(int32(81 + i))
+		rh.sigChan <- syscall.SIGWINCH
+		timers = append(timers, clock.next(t))
+	}
+
+	// Superseded timers firing late must not resize.
+	for _, timer := range timers[:len(timers)-1] {
+		timer <- time.Time{}
+	}
+	timers[len(timers)-1] <- time.Time{}
+	rec.waitCalls(t, 1)
+
+	// A follow-up signal at the same size proves the handler has drained
+	// every fired timer without another resize.
+	rh.sigChan <- syscall.SIGWINCH
+	clock.next(t)
+	rh.Stop()
+
+	if got := rec.calls.Load(); got != 1 {
+		t.Errorf("expected burst coalesced into 1 resize, got %d", got)
+	}
+	if rec.width.Load() != 90 {
+		t.Errorf("expected final width 90, got %d", rec.width.Load())
+	}
+}
+
+func TestResizeHandler_RetriesFailedResize(t *testing.T) {
+	var calls atomic.Int32
+	var failing atomic.Bool
+	failing.Store(true)
+	resizeErr := errors.New("exec not running")
+
+	clock := newFakeClock()
+	rh := NewResizeHandler(func(h, w uint) error {
+		calls.Add(1)
+		if failing.Load() {
+			return resizeErr
+		}
+		return nil
+	}, func() (int, int, error) { return 132, 50, nil })
+	rh.after = clock.after
+	errs := make(chan error, 4)
+	rh.SetErrorHandler(func(err error) { errs <- err })
+	rh.Start()
+	defer rh.Stop()
+
+	rh.sigChan <- syscall.SIGWINCH
+	clock.next(t) <- time.Time{}
+	select {
+	case err := <-errs:
+		if !errors.Is(err, resizeErr) {
+			t.Errorf("expected resize error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("resize failure was not reported")
+	}
+
+	// The failed size was not recorded as sent, so the same size is
+	// retried on the next signal.
+	failing.Store(false)
+	rh.sigChan <- syscall.SIGWINCH
+	clock.next(t) <- time.Time{}
+	deadline := time.Now().Add(2 * time.Second)
+	for calls.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected failed resize to be retried, got %d calls", calls.Load())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestResizeHandler_SkipsUnchangedSize(t *testing.T) {
+	var rec resizeRecorder
+
+	rh := NewResizeHandler(rec.resize, func() (int, int, error) { return 80, 24, nil })
+	rh.debounce = time.Millisecond
+	rh.Start()
+	defer rh.Stop()
+
+	rh.TriggerResize()
+	rec.waitCalls(t, 1)
+
+	rh.sigChan <- syscall.SIGWINCH
+	time.Sleep(50 * time.Millisecond)
+	if got := rec.calls.Load(); got != 1 {
+		t.Errorf("expected unchanged size to be skipped, got %d calls", got)
+	}
+
+	// TriggerResize always resizes, even at the same size.
+	rh.TriggerResize()
+	rec.waitCalls(t, 2)
+}
@@ -17,8 +17,10 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/hostproxy"
 	"github.com/schmitthub/clawker/internal/logger"
 )
 
@@ -70,9 +72,14 @@ type Bridge struct {
 	// If nil, warnings are suppressed.
 	Warnings io.Writer
 
-	streams  map[uint32]net.Conn
-	streamMu sync.RWMutex
-	writeMu  sync.Mutex
+	streams     map[uint32]net.Conn
+	streamKinds map[uint32]hostproxy.BridgeType // accounting bucket per stream; guarded by streamMu
+	streamMu    sync.RWMutex
+	writeMu     sync.Mutex
+
+	// traffic meters forwarded bytes and enforces the per-container
+	// bandwidth cap. Nil disables accounting.
+	traffic *hostproxy.TrafficMeter
 
 	done      chan struct{}
 	closeOnce sync.Once // Prevents double-close panic on done channel
@@ -88,6 +95,7 @@ func NewBridge(containerID string, gpgEnabled bool, log *logger.Logger) *Bridge
 		gpgEnabled:  gpgEnabled,
 		log:         log,
 		streams:     make(map[uint32]net.Conn),
+		streamKinds: make(map[uint32]hostproxy.BridgeType),
 		done:        make(chan struct{}),
 		errCh:       make(chan error, 1),
 	}
@@ -99,6 +107,12 @@ func (b *Bridge) SetGPGPubkey(pubkey []byte) {
 	b.gpgPubkey = pubkey
 }
 
+// SetTrafficMeter enables forwarded-traffic accounting (and the meter's
+// bandwidth cap) for this bridge's streams. Must be called before Start.
+func (b *Bridge) SetTrafficMeter(m *hostproxy.TrafficMeter) {
+	b.traffic = m
+}
+
 // Start launches the socket-forwarder in the container and begins forwarding.
 func (b *Bridge) Start(ctx context.Context) error {
 	// Get GPG pubkey if GPG forwarding is enabled
@@ -301,6 +315,7 @@ func (b *Bridge) handleOpen(msg Message) {
 
 	b.streamMu.Lock()
 	b.streams[streamID] = conn
+	b.streamKinds[streamID] = bridgeTypeForSocket(socketType)
 	b.streamMu.Unlock()
 
 	// Start reading from the host socket
@@ -333,6 +348,7 @@ func (b *Bridge) readFromHostSocket(streamID uint32, conn net.Conn) {
 			b.closeStream(streamID)
 			return
 		}
+		b.account(streamID, n)
 
 		if err := b.sendMessage(Message{
 			Type:     MsgData,
@@ -354,6 +370,7 @@ func (b *Bridge) handleData(msg Message) {
 		return
 	}
 
+	b.account(msg.StreamID, len(msg.Payload))
 	if _, err := conn.Write(msg.Payload); err != nil {
 		b.closeStream(msg.StreamID)
 	}
@@ -368,6 +385,7 @@ func (b *Bridge) closeStream(streamID uint32) {
 	conn, ok := b.streams[streamID]
 	if ok {
 		delete(b.streams, streamID)
+		delete(b.streamKinds, streamID)
 	}
 	b.streamMu.Unlock()
 
@@ -377,6 +395,39 @@ func (b *Bridge) closeStream(streamID uint32) {
 	}
 }
 
+// account records n forwarded bytes on streamID and, when the container is
+// over its bandwidth cap, pauses the calling goroutine for the meter's delay.
+// The pause ends early if the bridge stops.
+func (b *Bridge) account(streamID uint32, n int) {
+	if b.traffic == nil {
+		return
+	}
+	b.streamMu.RLock()
+	kind, ok := b.streamKinds[streamID]
+	b.streamMu.RUnlock()
+	if !ok {
+		return
+	}
+	delay := b.traffic.Record(b.containerID, kind, n)
+	if delay <= 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-b.done:
+	}
+}
+
+// bridgeTypeForSocket maps a forwarded socket type to its accounting bucket.
+func bridgeTypeForSocket(socketType string) hostproxy.BridgeType {
+	if socketType == consts.SocketTypeGPGAgent {
+		return hostproxy.BridgeGPG
+	}
+	return hostproxy.BridgeSSH
+}
+
 func (b *Bridge) sendMessage(msg Message) error {
 	b.writeMu.Lock()
 	defer b.writeMu.Unlock()
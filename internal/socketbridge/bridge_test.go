@@ -4,12 +4,15 @@ import (
 	"bufio"
 	"bytes"
 	"io"
+	"net"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/hostproxy"
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/socketbridge"
 	sockebridgemocks "github.com/schmitthub/clawker/internal/socketbridge/mocks"
@@ -83,3 +86,36 @@ func TestSendMessage_ReducedAllocations(t *testing.T) {
 	assert.Equal(t, uint32(42), got.StreamID)
 	assert.Equal(t, []byte("hello"), got.Payload)
 }
+
+func TestBridge_HandleData_RecordsTraffic(t *testing.T) {
+	b := socketbridge.NewBridge("0123456789abcdef", false, logger.Nop())
+	meter := hostproxy.NewTrafficMeter(0)
+	b.SetTrafficMeter(meter)
+
+	hostSide, agentSide := net.Pipe()
+	defer hostSide.Close()
+	defer agentSide.Close()
+	b.AddStreamForTest(7, hostSide, consts.SocketTypeSSHAgent)
+
+	var buf bytes.Buffer
+	sockebridgemocks.WriteTestMessage(&buf, socketbridge.Message{Type: socketbridge.MsgData, StreamID: 7, Payload: []byte("ssh-request")})
+	b.SetBridgeIOForTest(io.NopCloser(&buf), sockebridgemocks.NopWriteCloser{})
+	b.InitErrChForTest()
+
+	got := make(chan []byte, 1)
+	go func() {
+		p := make([]byte, 64)
+		n, _ := agentSide.Read(p)
+		got <- p[:n]
+	}()
+
+	b.StartReadLoopForTest()
+	assert.Equal(t, []byte("ssh-request"), <-got)
+	b.WaitReadLoopForTest()
+
+	stats := meter.Snapshot()
+	require.Len(t, stats, 1)
+	assert.Equal(t, "0123456789ab", stats[0].Container)
+	assert.Equal(t, hostproxy.BridgeSSH, stats[0].Bridge)
+	assert.Equal(t, int64(len("ssh-request")), stats[0].TotalBytes)
+}
@@ -3,6 +3,7 @@ package socketbridge
 import (
 	"bufio"
 	"io"
+	"net"
 	"time"
 )
 
@@ -37,6 +38,15 @@ func (b *Bridge) SendMessageForTest(msg Message) error {
 	return b.sendMessage(msg)
 }
 
+// AddStreamForTest registers an open stream as if the container had sent
+// OPEN for socketType.
+func (b *Bridge) AddStreamForTest(streamID uint32, conn net.Conn, socketType string) {
+	b.streamMu.Lock()
+	defer b.streamMu.Unlock()
+	b.streams[streamID] = conn
+	b.streamKinds[streamID] = bridgeTypeForSocket(socketType)
+}
+
 // ReadMessageForTest exposes the package-level readMessage function.
 var ReadMessageForTest = func(r *bufio.Reader) (Message, error) {
 	return readMessage(r)
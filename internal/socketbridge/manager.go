@@ -15,6 +15,7 @@ import (
 
 	"github.com/schmitthub/clawker/internal/config"
 	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/hostproxy"
 	"github.com/schmitthub/clawker/internal/logger"
 )
 
@@ -39,6 +40,11 @@ type SocketBridgeManager interface {
 // It spawns detached "clawker bridge serve" subprocesses that forward
 // GPG and SSH agent sockets into running containers.
 //
+// Every running bridge also has a hostproxy.BridgeSpec next to its PID file;
+// the host proxy daemon restarts bridges that die while their container
+// still runs. StopBridge and StopAll remove the spec first so a deliberate
+// stop is not undone.
+//
 // Manager implements SocketBridgeManager.
 type Manager struct {
 	cfg     config.Config
@@ -84,6 +90,7 @@ func (m *Manager) EnsureBridge(containerID string, gpgEnabled bool) error {
 	if bp, ok := m.bridges[containerID]; ok {
 		if isProcessAlive(bp.pid) {
 			m.log.Debug().Str("container", ShortID(containerID)).Int("pid", bp.pid).Msg("bridge already running")
+			m.writeSpec(containerID, gpgEnabled, bp.pidFile)
 			return nil
 		}
 		// Process died — clean up stale entry
@@ -99,11 +106,16 @@ func (m *Manager) EnsureBridge(containerID string, gpgEnabled bool) error {
 	if pid := readPIDFile(pidFile); pid > 0 && isProcessAlive(pid) {
 		m.log.Debug().Str("container", ShortID(containerID)).Int("pid", pid).Msg("found existing bridge via PID file")
 		m.bridges[containerID] = &bridgeProcess{pid: pid, pidFile: pidFile}
+		m.writeSpec(containerID, gpgEnabled, pidFile)
 		return nil
 	}
 
 	// Spawn a new bridge daemon
-	return m.startBridge(containerID, gpgEnabled, pidFile)
+	if err := m.startBridge(containerID, gpgEnabled, pidFile); err != nil {
+		return err
+	}
+	m.writeSpec(containerID, gpgEnabled, pidFile)
+	return nil
 }
 
 // StopBridge stops the bridge daemon for the given container.
@@ -116,6 +128,9 @@ func (m *Manager) StopBridge(containerID string) error {
 		return fmt.Errorf("failed to get bridge PID file path: %w", err)
 	}
 
+	// End host proxy supervision before killing, or it restarts the bridge.
+	m.removeSpec(containerID)
+
 	// Check in-memory tracking first
 	if bp, ok := m.bridges[containerID]; ok {
 		m.cleanupBridgeLocked(containerID, bp)
@@ -137,6 +152,7 @@ func (m *Manager) StopAll() error {
 
 	// Stop in-memory tracked bridges
 	for id, bp := range m.bridges {
+		m.removeSpec(id)
 		m.cleanupBridgeLocked(id, bp)
 	}
 
@@ -151,6 +167,13 @@ func (m *Manager) StopAll() error {
 		return nil // Non-fatal: directory may not exist
 	}
 
+	// Specs first, so the host proxy doesn't restart what is killed below.
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), consts.BridgeSpecSuffix) {
+			os.Remove(filepath.Join(bridgesDir, entry.Name()))
+		}
+	}
+
 	for _, entry := range entries {
 		if !strings.HasSuffix(entry.Name(), ".pid") {
 			continue
@@ -252,6 +275,26 @@ func (m *Manager) startBridge(containerID string, gpgEnabled bool, pidFile strin
 	return nil
 }
 
+// writeSpec records the running bridge for host proxy supervision. Failure
+// only costs the auto-restart, so it is logged rather than returned.
+func (m *Manager) writeSpec(containerID string, gpgEnabled bool, pidFile string) {
+	spec := hostproxy.BridgeSpec{ContainerID: containerID, GPG: gpgEnabled, PIDFile: pidFile}
+	if err := hostproxy.WriteBridgeSpec(filepath.Dir(pidFile), spec); err != nil {
+		m.log.Debug().Err(err).Str("container", ShortID(containerID)).Msg("failed to write bridge spec")
+	}
+}
+
+// removeSpec ends host proxy supervision of the container's bridge.
+func (m *Manager) removeSpec(containerID string) {
+	bridgesDir, err := m.cfg.BridgesSubdir()
+	if err != nil {
+		return
+	}
+	if err := hostproxy.RemoveBridgeSpec(bridgesDir, containerID); err != nil {
+		m.log.Debug().Err(err).Str("container", ShortID(containerID)).Msg("failed to remove bridge spec")
+	}
+}
+
 // cleanupBridgeLocked removes the bridge from tracking and kills the process.
 // Must be called with m.mu held.
 func (m *Manager) cleanupBridgeLocked(containerID string, bp *bridgeProcess) {
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Cipher encrypts and decrypts individual string values. The store holds
+// only ciphertext: values are decrypted on the decoded snapshot, and a write
+// re-encrypts a value whose destination still holds ciphertext (WithCipher).
+type Cipher interface {
+	// IsEncrypted reports whether value is ciphertext this cipher produced.
+	IsEncrypted(value string) bool
+	// Decrypt returns the plaintext of an encrypted value. A value the
+	// available keys cannot open is returned unchanged with a nil error, so
+	// a checkout without the key still loads.
+	Decrypt(value string) (string, error)
+	// Encrypt returns plaintext as ciphertext.
+	Encrypt(plaintext string) (string, error)
+}
+
+// decryptNode replaces every encrypted string scalar under node with its
+// plaintext, in place. Callers pass a clone — the store's tree keeps the
+// ciphertext. path is node's dotted location.
+func decryptNode(node *yaml.Node, path string, c Cipher) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := decryptNode(child, path, c); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childPath := node.Content[i].Value
+			if path != "" {
+				childPath = path + "." + childPath
+			}
+			if err := decryptNode(node.Content[i+1], childPath, c); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if node.ShortTag() != "!!str" || !c.IsEncrypted(node.Value) {
+			return nil
+		}
+		plain, err := c.Decrypt(node.Value)
+		if err != nil {
+			return fmt.Errorf("decrypting %s: %w", path, err)
+		}
+		if plain == node.Value {
+			return nil
+		}
+		node.Value = plain
+		// Re-resolve the plaintext like an interpolated plain scalar, so an
+		// encrypted port still lands in an int field.
+		node.Tag = ""
+		node.Style = 0
+	}
+	return nil
+}
+
+// sealForDest returns val ready to graft over dest's current value at segs:
+// a plaintext string replacing ciphertext is encrypted, so a value stored
+// encrypted stays encrypted on every write. Anything else is returned as is.
+func sealForDest(dest *yaml.Node, segs []string, val *yaml.Node, c Cipher) (*yaml.Node, error) {
+	if c == nil || val.Kind != yaml.ScalarNode || c.IsEncrypted(val.Value) {
+		return val, nil
+	}
+	cur, ok := nodeValueAt(dest, segs)
+	if !ok || cur.Kind != yaml.ScalarNode || !c.IsEncrypted(cur.Value) {
+		return val, nil
+	}
+	sealed, err := c.Encrypt(val.Value)
+	if err != nil {
+		return nil, fmt.Errorf("encrypting %s: %w", strings.Join(segs, "."), err)
+	}
+	out := *val
+	out.Value = sealed
+	out.Tag = "!!str"
+	out.Style = 0
+	return &out, nil
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rot13Cipher marks ciphertext with an "ENC[" prefix. The key "open" gates
+// decryption, mimicking a checkout without the identity file.
+type rot13Cipher struct {
+	canOpen bool
+	fail    bool
+}
+
+func rot13(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return 'a' + (r-'a'+13)%26
+		case r >= 'A' && r <= 'Z':
+			return 'A' + (r-'A'+13)%26
+		}
+		return r
+	}, s)
+}
+
+func (c rot13Cipher) IsEncrypted(v string) bool {
+	return strings.HasPrefix(v, "ENC[") && strings.HasSuffix(v, "]")
+}
+
+func (c rot13Cipher) Decrypt(v string) (string, error) {
+	if c.fail {
+		return "", errors.New("corrupt ciphertext")
+	}
+	if !c.canOpen {
+		return v, nil
+	}
+	return rot13(strings.TrimSuffix(strings.TrimPrefix(v, "ENC["), "]")), nil
+}
+
+func (c rot13Cipher) Encrypt(p string) (string, error) { return "ENC[" + rot13(p) + "]", nil }
+
+func TestCipher_DecryptsSnapshot(t *testing.T) {
+	store, err := New[testInterpCfg](`
+image: ENC[nycvar:3.20]
+port: ENC[8080]
+env:
+  TOKEN: ENC[f3perg]
+  PLAIN: visible
+`, WithCipher(rot13Cipher{canOpen: true}))
+	require.NoError(t, err)
+
+	got := store.Read()
+	assert.Equal(t, "alpine:3.20", got.Image)
+	assert.Equal(t, 8080, got.Port, "decrypted plain scalar re-resolves")
+	assert.Equal(t, "s3cret", got.Env["TOKEN"])
+	assert.Equal(t, "visible", got.Env["PLAIN"])
+
+	var raw string
+	_, err = store.Get("env.TOKEN", &raw)
+	require.NoError(t, err)
+	assert.Equal(t, "ENC[f3perg]", raw, "Get reads the ciphertext tree")
+}
+
+func TestCipher_WithoutKey(t *testing.T) {
+	store, err := New[testInterpCfg](`image: ENC[nycvar]`, WithCipher(rot13Cipher{}))
+	require.NoError(t, err)
+	assert.Equal(t, "ENC[nycvar]", store.Read().Image, "unopenable values load verbatim")
+
+	_, err = New[testInterpCfg](`image: ENC[nycvar]`, WithCipher(rot13Cipher{fail: true}))
+	require.ErrorContains(t, err, "decrypting image")
+}
+
+func TestCipher_WriteKeepsEncrypted(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte("image: plain\nenv:\n  TOKEN: ENC[byq]\n"), 0o644))
+
+	store, err := New[testInterpCfg]("", WithFilenames("config.yaml"), WithPaths(dir), WithCipher(rot13Cipher{canOpen: true}))
+	require.NoError(t, err)
+	require.Equal(t, "old", store.Read().Env["TOKEN"])
+
+	require.NoError(t, store.Set("env.TOKEN", "new"))
+	require.NoError(t, store.Set("image", "alpine"))
+	require.NoError(t, store.Write())
+
+	data, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "TOKEN: ENC[arj]", "replacing ciphertext re-encrypts")
+	assert.Contains(t, string(data), "image: alpine", "plaintext fields stay plaintext")
+	assert.NotContains(t, string(data), "new")
+	assert.Equal(t, "new", store.Read().Env["TOKEN"])
+}
@@ -38,3 +38,19 @@ var ErrNonMappingRoot = errors.New("expected a mapping at the document root")
 // Config files are single-document; silently using only the first document
 // would drop the rest, so the file is rejected loudly instead.
 var ErrMultiDocument = errors.New("expected a single YAML document")
+
+// ErrUndefinedVariable reports a ${VAR} reference, with no default, to a
+// variable that is not set in the environment while strict interpolation is
+// enabled (WithInterpolation(true)).
+var ErrUndefinedVariable = errors.New("undefined variable")
+
+// ErrLocked reports a write that could not take its file lock (WithLock)
+// because another process kept holding it past the lock timeout — typically a
+// second clawker invocation in the middle of its own write. Nothing was
+// written; retrying once the other command finishes is safe.
+var ErrLocked = errors.New("another clawker instance holds the lock")
+
+// ErrUnknownMergeStrategy reports a schema field whose `merge` struct tag is not
+// one of the supported strategies (replace, append, unique-union/union). It is
+// a programming error in the schema type, surfaced at store construction.
+var ErrUnknownMergeStrategy = errors.New("unknown merge strategy")
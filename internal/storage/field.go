@@ -69,7 +69,8 @@ type Field interface {
 	Description() string // Help text (from `desc` tag).
 	Default() string     // Default value hint (from `default` tag), may be empty.
 	Required() bool      // Whether this field must have a value (from `required:"true"` tag).
-	MergeTag() string    // Merge strategy hint (from `merge` tag): "union", "overwrite", or "".
+	MergeTag() string    // Merge strategy (from `merge` tag): "replace", "append", "unique-union" ("union"), or "" (replace).
+	Interpolate() bool   // Whether ${VAR} expansion applies (false with `interpolate:"false"`).
 }
 
 // FieldSet is an ordered, indexed collection of [Field] values.
@@ -98,6 +99,9 @@ type field struct {
 	def      string
 	required bool
 	mergeTag string
+	// noInterpolate opts the field out of ${VAR} expansion; zero value
+	// (expand) is the default so NewField fields interpolate.
+	noInterpolate bool
 }
 
 func (f *field) Path() string        { return f.path }
@@ -107,6 +111,7 @@ func (f *field) Description() string { return f.desc }
 func (f *field) Default() string     { return f.def }
 func (f *field) Required() bool      { return f.required }
 func (f *field) MergeTag() string    { return f.mergeTag }
+func (f *field) Interpolate() bool   { return !f.noInterpolate }
 
 // fieldSet is the unexported concrete implementation of [FieldSet].
 type fieldSet struct {
@@ -274,6 +279,7 @@ func normalizeStruct(rt reflect.Type, prefix string, fields *[]Field, kindFunc K
 		def := sf.Tag.Get("default")
 		req := sf.Tag.Get("required") == "true"
 		merge := sf.Tag.Get("merge")
+		noInterp := sf.Tag.Get("interpolate") == "false"
 
 		ft := sf.Type
 
@@ -281,7 +287,7 @@ func normalizeStruct(rt reflect.Type, prefix string, fields *[]Field, kindFunc K
 		if ft.Kind() == reflect.Pointer {
 			elem := ft.Elem()
 			if elem.Kind() == reflect.Bool {
-				*fields = append(*fields, &field{path: path, kind: KindBool, label: label, desc: desc, def: def, required: req, mergeTag: merge})
+				*fields = append(*fields, &field{path: path, kind: KindBool, label: label, desc: desc, def: def, required: req, mergeTag: merge, noInterpolate: noInterp})
 				continue
 			}
 			if elem.Kind() == reflect.Struct {
@@ -294,39 +300,40 @@ func normalizeStruct(rt reflect.Type, prefix string, fields *[]Field, kindFunc K
 		// Non-pointer types.
 		switch {
 		case ft == reflect.TypeFor[time.Duration]():
-			*fields = append(*fields, &field{path: path, kind: KindDuration, label: label, desc: desc, def: def, required: req, mergeTag: merge})
+			*fields = append(*fields, &field{path: path, kind: KindDuration, label: label, desc: desc, def: def, required: req, mergeTag: merge, noInterpolate: noInterp})
 
 		case ft == reflect.TypeFor[time.Time]():
 			// time.Time is a struct, but it serializes as an RFC3339Nano scalar via
 			// yaml.v3 — treat it as an opaque leaf, never recurse into its
 			// unexported fields (which would flatten it to an empty map).
-			*fields = append(*fields, &field{path: path, kind: KindTime, label: label, desc: desc, def: def, required: req, mergeTag: merge})
+			*fields = append(*fields, &field{path: path, kind: KindTime, label: label, desc: desc, def: def, required: req, mergeTag: merge, noInterpolate: noInterp})
 
 		case ft.Kind() == reflect.String:
-			*fields = append(*fields, &field{path: path, kind: KindText, label: label, desc: desc, def: def, required: req, mergeTag: merge})
+			*fields = append(*fields, &field{path: path, kind: KindText, label: label, desc: desc, def: def, required: req, mergeTag: merge, noInterpolate: noInterp})
 
 		case ft.Kind() == reflect.Bool:
-			*fields = append(*fields, &field{path: path, kind: KindBool, label: label, desc: desc, def: def, required: req, mergeTag: merge})
+			*fields = append(*fields, &field{path: path, kind: KindBool, label: label, desc: desc, def: def, required: req, mergeTag: merge, noInterpolate: noInterp})
 
 		case ft.Kind() == reflect.Int, ft.Kind() == reflect.Int64:
-			*fields = append(*fields, &field{path: path, kind: KindInt, label: label, desc: desc, def: def, required: req, mergeTag: merge})
+			*fields = append(*fields, &field{path: path, kind: KindInt, label: label, desc: desc, def: def, required: req, mergeTag: merge, noInterpolate: noInterp})
 
 		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.String:
-			*fields = append(*fields, &field{path: path, kind: KindStringSlice, label: label, desc: desc, def: def, required: req, mergeTag: merge})
+			*fields = append(*fields, &field{path: path, kind: KindStringSlice, label: label, desc: desc, def: def, required: req, mergeTag: merge, noInterpolate: noInterp})
 
 		case ft.Kind() == reflect.Struct:
 			normalizeStruct(ft, path, fields, kindFunc)
 			continue // Struct itself is not a leaf field.
 
 		case ft.Kind() == reflect.Map && ft.Key().Kind() == reflect.String && ft.Elem().Kind() == reflect.String:
-			*fields = append(*fields, &field{path: path, kind: KindMap, label: label, desc: desc, def: def, required: req, mergeTag: merge})
+			*fields = append(*fields, &field{path: path, kind: KindMap, label: label, desc: desc, def: def, required: req, mergeTag: merge, noInterpolate: noInterp})
 
 		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Struct:
-			*fields = append(*fields, &field{path: path, kind: KindStructSlice, label: label, desc: desc, def: def, required: req, mergeTag: merge})
+			*fields = append(*fields, &field{path: path, kind: KindStructSlice, label: label, desc: desc, def: def, required: req, mergeTag: merge, noInterpolate: noInterp})
 
 		case ft.Kind() == reflect.Map && ft.Key().Kind() == reflect.String && ft.Elem().Kind() == reflect.Struct:
 			*fields = append(*fields, &field{
 				path: path, kind: KindStructMap, label: label, desc: desc, def: def, required: req, mergeTag: merge,
+				noInterpolate: noInterp,
 			})
 
 		default:
@@ -336,7 +343,7 @@ func normalizeStruct(rt reflect.Type, prefix string, fields *[]Field, kindFunc K
 					if kind <= KindLast {
 						panic(fmt.Sprintf("storage.NormalizeFields: KindFunc returned storage-defined kind %s for type %s at path %q; consumer kinds must be > KindLast", kind, ft, path))
 					}
-					*fields = append(*fields, &field{path: path, kind: kind, label: label, desc: desc, def: def, required: req, mergeTag: merge})
+					*fields = append(*fields, &field{path: path, kind: kind, label: label, desc: desc, def: def, required: req, mergeTag: merge, noInterpolate: noInterp})
 					break
 				}
 			}
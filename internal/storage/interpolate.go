@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/schmitthub/clawker/internal/dotenv/template"
+)
+
+// bracedPattern is the template engine's default pattern minus the bare $VAR
+// form: only ${VAR} (with the :-, -, :?, ?, :+, + operators) and the $$
+// escape are recognized. Config values routinely carry shell-flavoured text
+// such as "$HOME" that must pass through untouched.
+var bracedPattern = regexp.MustCompile(
+	`\$(?i:(?P<escaped>\$)|{(?:(?P<braced>[_a-z][_a-z0-9]*(?::?[-+?](.*))?)}|(?P<invalid>)))`,
+)
+
+// interpolationDisabled reports whether path, or the schema leaf it sits
+// under, is tagged `interpolate:"false"`. Paths below a leaf (struct-slice
+// items, opaque map entries) inherit the leaf's setting.
+func (r tagRegistry) interpolationDisabled(path string) bool {
+	for p := path; p != ""; {
+		if meta, ok := r[p]; ok {
+			return meta.noInterpolate
+		}
+		i := strings.LastIndexByte(p, '.')
+		if i < 0 {
+			break
+		}
+		p = p[:i]
+	}
+	return false
+}
+
+// interpolateNode expands ${VAR} references in every string scalar under node
+// in place. Callers pass a clone — the store's tree must keep the verbatim
+// text so writes stay portable. path is node's dotted location.
+func interpolateNode(node *yaml.Node, path string, tags tagRegistry, strict bool) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := interpolateNode(child, path, tags, strict); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childPath := node.Content[i].Value
+			if path != "" {
+				childPath = path + "." + childPath
+			}
+			if tags.interpolationDisabled(childPath) {
+				continue
+			}
+			if err := interpolateNode(node.Content[i+1], childPath, tags, strict); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if node.ShortTag() != "!!str" || !strings.Contains(node.Value, "$") {
+			return nil
+		}
+		expanded, err := expandValue(node.Value, strict)
+		if err != nil {
+			return fmt.Errorf("interpolating %s: %w", path, err)
+		}
+		node.Value = expanded
+		if node.Style == 0 {
+			// Plain scalar: let the decoder re-resolve the expanded text so
+			// "${PORT}" can land in an int field. Quoted scalars stay strings.
+			node.Tag = ""
+		}
+	}
+	return nil
+}
+
+// expandValue substitutes ${VAR} references in value from the process
+// environment. Unset variables collapse to "" unless strict is set.
+func expandValue(value string, strict bool) (string, error) {
+	var missing []string
+	out, err := template.SubstituteWithOptions(value, os.LookupEnv,
+		template.WithPattern(bracedPattern),
+		template.WithMissingHandler(func(name string) { missing = append(missing, name) }),
+	)
+	if err != nil {
+		return "", err
+	}
+	if strict && len(missing) > 0 {
+		return "", fmt.Errorf("%w %s", ErrUndefinedVariable, strings.Join(missing, ", "))
+	}
+	return out, nil
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testInterpCfg struct {
+	Image   string            `yaml:"image"`
+	Port    int               `yaml:"port"`
+	Env     map[string]string `yaml:"env"`
+	Script  string            `yaml:"script" interpolate:"false"`
+	Steps   []testInterpStep  `yaml:"steps"  interpolate:"false"`
+	Aliases []string          `yaml:"aliases"`
+}
+
+type testInterpStep struct {
+	Run string `yaml:"run"`
+}
+
+func (t testInterpCfg) Fields() FieldSet { return NormalizeFields(t) }
+
+func TestInterpolation_ExpandsSnapshot(t *testing.T) {
+	t.Setenv("TEST_BASE_IMAGE", "alpine:3.20")
+	t.Setenv("TEST_PORT", "8080")
+	t.Setenv("TEST_TOKEN", "s3cret")
+
+	store, err := New[testInterpCfg](`
+image: ${TEST_BASE_IMAGE:-node:20-slim}
+port: ${TEST_PORT}
+env:
+  TOKEN: ${TEST_TOKEN}
+  QUOTED: "${TEST_PORT}"
+  LITERAL: $$HOME and $HOME
+script: echo ${TEST_TOKEN}
+steps:
+  - run: echo ${TEST_TOKEN}
+aliases:
+  - run --agent $1 @
+`, WithInterpolation(false))
+	require.NoError(t, err)
+
+	got := store.Read()
+	assert.Equal(t, "alpine:3.20", got.Image)
+	assert.Equal(t, 8080, got.Port, "plain scalar re-resolves after expansion")
+	assert.Equal(t, "s3cret", got.Env["TOKEN"])
+	assert.Equal(t, "8080", got.Env["QUOTED"])
+	assert.Equal(t, "$HOME and $HOME", got.Env["LITERAL"], "$$ escapes; bare $VAR is untouched")
+	assert.Equal(t, "echo ${TEST_TOKEN}", got.Script, "opted-out field stays verbatim")
+	assert.Equal(t, "echo ${TEST_TOKEN}", got.Steps[0].Run, "items under an opted-out leaf inherit it")
+	assert.Equal(t, []string{"run --agent $1 @"}, got.Aliases)
+
+	var raw string
+	_, err = store.Get("image", &raw)
+	require.NoError(t, err)
+	assert.Equal(t, "${TEST_BASE_IMAGE:-node:20-slim}", raw, "Get reads the verbatim tree")
+}
+
+func TestInterpolation_DefaultWhenUnset(t *testing.T) {
+	store, err := New[testInterpCfg](`image: ${TEST_UNSET_IMAGE_VAR:-node:20-slim}`, WithInterpolation(true))
+	require.NoError(t, err)
+	assert.Equal(t, "node:20-slim", store.Read().Image)
+}
+
+func TestInterpolation_Undefined(t *testing.T) {
+	const seed = `image: ${TEST_UNSET_IMAGE_VAR}`
+
+	t.Run("lenient expands to empty", func(t *testing.T) {
+		store, err := New[testInterpCfg](seed, WithInterpolation(false))
+		require.NoError(t, err)
+		assert.Empty(t, store.Read().Image)
+	})
+
+	t.Run("strict fails the load", func(t *testing.T) {
+		_, err := New[testInterpCfg](seed, WithInterpolation(true))
+		require.ErrorIs(t, err, ErrUndefinedVariable)
+		assert.Contains(t, err.Error(), "TEST_UNSET_IMAGE_VAR")
+		assert.Contains(t, err.Error(), "image")
+	})
+}
+
+func TestInterpolation_DisabledByDefault(t *testing.T) {
+	t.Setenv("TEST_BASE_IMAGE", "alpine:3.20")
+
+	store, err := New[testInterpCfg](`image: ${TEST_BASE_IMAGE}`)
+	require.NoError(t, err)
+	assert.Equal(t, "${TEST_BASE_IMAGE}", store.Read().Image)
+}
+
+func TestInterpolation_WritePreservesVerbatim(t *testing.T) {
+	t.Setenv("TEST_BASE_IMAGE", "alpine:3.20")
+
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte("image: ${TEST_BASE_IMAGE}\nport: 1\n"), 0o644))
+
+	store, err := New[testInterpCfg]("", WithFilenames("config.yaml"), WithPaths(dir), WithInterpolation(true))
+	require.NoError(t, err)
+	require.Equal(t, "alpine:3.20", store.Read().Image)
+
+	require.NoError(t, store.Set("port", 2))
+	require.NoError(t, store.Write())
+
+	data, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "image: ${TEST_BASE_IMAGE}")
+	assert.Contains(t, string(data), "port: 2")
+	assert.Equal(t, "alpine:3.20", store.Read().Image, "snapshot stays expanded after Set")
+}
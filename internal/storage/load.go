@@ -51,3 +51,26 @@ func decodeNode[T Schema](node *yaml.Node) (*T, error) {
 	}
 	return &result, nil
 }
+
+// decode deserializes node into T like decodeNode, first expanding ${VAR}
+// references (WithInterpolation) and then decrypting encrypted values
+// (WithCipher) on a clone. node itself is never modified, so the tree keeps
+// the verbatim text. Ciphertext carries no "$", so interpolation never
+// touches it, and decrypted plaintext is not interpolated.
+func (s *Store[T]) decode(node *yaml.Node) (*T, error) {
+	if node != nil && (s.opts.Interpolate || s.opts.Cipher != nil) {
+		expanded := cloneNode(node)
+		if s.opts.Interpolate {
+			if err := interpolateNode(expanded, "", s.tags, s.opts.StrictInterpolation); err != nil {
+				return nil, fmt.Errorf("storage: %w", err)
+			}
+		}
+		if s.opts.Cipher != nil {
+			if err := decryptNode(expanded, "", s.opts.Cipher); err != nil {
+				return nil, fmt.Errorf("storage: %w", err)
+			}
+		}
+		node = expanded
+	}
+	return decodeNode[T](node)
+}
@@ -1,12 +1,35 @@
 package storage
 
 import (
+	"fmt"
+
 	"gopkg.in/yaml.v3"
 )
 
-// mergeUnion is the merge-tag value selecting additive/deduplicated merge for a
-// slice or map field (as opposed to last-wins).
-const mergeUnion = "union"
+// Merge-tag values selecting how a slice or map field folds across layers.
+// Untagged fields replace (last-wins).
+const (
+	// mergeReplace makes the highest-priority layer's value win wholesale.
+	mergeReplace = "replace"
+	// mergeAppend concatenates sequences lowest→highest priority, keeping
+	// duplicates.
+	mergeAppend = "append"
+	// mergeUniqueUnion concatenates sequences lowest→highest priority, dropping
+	// duplicates (by decoded value); an item a higher layer re-lists moves to
+	// that layer's position.
+	mergeUniqueUnion = "unique-union"
+	// mergeUnion is the original deduplicating merge: an item keeps the
+	// position of its first (lowest-priority) occurrence. On a map field
+	// (either spelling) entries merge key-by-key.
+	mergeUnion = "union"
+	// mergeOverwrite is the original spelling of mergeReplace.
+	mergeOverwrite = "overwrite"
+)
+
+// replaceSeqTag is the YAML tag a layer puts on a sequence to replace the
+// lower layers' items instead of merging with them, whatever the field's
+// merge strategy (packages: !replace [git]). The merged tree drops the tag.
+const replaceSeqTag = "!replace"
 
 // provenance maps field paths to the index of the layer that provided the
 // winning value. E.g. "build.image" → 2 means layer[2] won that field.
@@ -16,8 +39,15 @@ type provenance map[string]int
 // Merge strategy and field kind are recorded together so that
 // mergeNodes and Write can make schema-aware decisions from a single registry.
 type fieldMeta struct {
-	mergeTag string    // "union", "overwrite", or "" (empty = last-wins)
-	kind     FieldKind // Go type classification (KindMap, KindStringSlice, etc.)
+	mergeTag      string    // one of the merge* strategy values, or "" (empty = last-wins)
+	kind          FieldKind // Go type classification (KindMap, KindStringSlice, etc.)
+	noInterpolate bool      // `interpolate:"false"` — ${VAR} left verbatim (see interpolate.go)
+}
+
+// unionMap reports whether a map field merges key-by-key rather than being
+// replaced wholesale by the highest-priority layer.
+func (m fieldMeta) unionMap() bool {
+	return m.kind == KindMap && (m.mergeTag == mergeUnion || m.mergeTag == mergeUniqueUnion)
 }
 
 // tagRegistry maps dotted field paths to their schema metadata.
@@ -34,13 +64,28 @@ func buildTagRegistry[T Schema]() tagRegistry {
 	reg := make(tagRegistry, fields.Len())
 	for _, f := range fields.All() {
 		reg[f.Path()] = fieldMeta{
-			mergeTag: f.MergeTag(),
-			kind:     f.Kind(),
+			mergeTag:      f.MergeTag(),
+			kind:          f.Kind(),
+			noInterpolate: !f.Interpolate(),
 		}
 	}
 	return reg
 }
 
+// validateMergeTags rejects a schema field whose `merge` tag names no known
+// strategy. A typo would otherwise silently degrade to last-wins.
+func validateMergeTags[T Schema]() error {
+	var zero T
+	for _, f := range zero.Fields().All() {
+		switch f.MergeTag() {
+		case "", mergeReplace, mergeOverwrite, mergeAppend, mergeUniqueUnion, mergeUnion:
+		default:
+			return fmt.Errorf("storage: field %s: %w %q", f.Path(), ErrUnknownMergeStrategy, f.MergeTag())
+		}
+	}
+	return nil
+}
+
 // merge folds N layer node trees in priority order into a single merged node
 // tree, tracking provenance. Layers are ordered from highest priority (index 0,
 // closest to CWD) to lowest (last index). Processed lowest→highest so the
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 )
@@ -228,8 +229,10 @@ func nodeValueAt(m *yaml.Node, segments []string) (*yaml.Node, bool) {
 // nodeGraftValue sets value at the dotted segments in target, creating
 // intermediate mapping nodes as needed. value is cloned and comment-stripped
 // first, then mappingPut preserves any comment the destination already had on
-// that field. This is the single graft primitive shared by Set (into the merged
-// tree) and Write (into a layer node).
+// that field. A sequence replacing one tagged !replace keeps the tag, so
+// rewriting a layer's list does not turn it back into a merging one. This is
+// the single graft primitive shared by Set (into the merged tree) and Write
+// (into a layer node).
 func nodeGraftValue(target *yaml.Node, segments []string, value *yaml.Node) {
 	graft := cloneNode(value)
 	stripComments(graft)
@@ -237,6 +240,9 @@ func nodeGraftValue(target *yaml.Node, segments []string, value *yaml.Node) {
 	cur := target
 	for i, seg := range segments {
 		if i == len(segments)-1 {
+			if old, ok := mappingValue(cur, seg); ok && old.Tag == replaceSeqTag && graft.Kind == yaml.SequenceNode {
+				graft.Tag = replaceSeqTag
+			}
 			mappingPut(cur, seg, graft)
 			return
 		}
@@ -311,7 +317,7 @@ func decodedEqual(a, b *yaml.Node) bool {
 // mergeNodes folds src (the higher-priority layer) into dst, mutating dst and
 // recording provenance per dotted path. Merge semantics: opaque (non-union) maps
 // replace wholesale, union maps merge per-entry, struct nesting recurses,
-// sequences union or replace, scalars last-win. Because callers fold
+// sequences union, append, or replace, scalars last-win. Because callers fold
 // lowest→highest priority, src wins on conflict and its value node (with its
 // comments) lands in the merged tree — so the top layer's comments are the ones
 // preserved through a union merge.
@@ -350,10 +356,26 @@ func mergeEntry(
 	case yaml.MappingNode:
 		mergeMappingEntry(dst, key, srcVal, dstVal, exists, path, prov, layerIdx, tags)
 	case yaml.SequenceNode:
-		if meta, ok := tags[path]; ok && meta.mergeTag == mergeUnion && exists && dstVal.Kind == yaml.SequenceNode {
-			mappingPut(dst, key, unionSeqNodes(dstVal, srcVal))
+		if srcVal.Tag == replaceSeqTag {
+			// An explicit !replace drops whatever lower layers contributed.
+			seq := cloneNode(srcVal)
+			seq.Tag = "!!seq"
+			mappingPut(dst, key, seq)
 			return
 		}
+		if meta, ok := tags[path]; ok && exists && dstVal.Kind == yaml.SequenceNode {
+			switch meta.mergeTag {
+			case mergeUnion:
+				mappingPut(dst, key, unionSeqNodes(dstVal, srcVal))
+				return
+			case mergeUniqueUnion:
+				mappingPut(dst, key, uniqueUnionSeqNodes(dstVal, srcVal))
+				return
+			case mergeAppend:
+				mappingPut(dst, key, appendSeqNodes(dstVal, srcVal))
+				return
+			}
+		}
 		mappingPut(dst, key, cloneNode(srcVal))
 	case yaml.ScalarNode, yaml.AliasNode, yaml.DocumentNode:
 		mappingPut(dst, key, cloneNode(srcVal)) // last wins
@@ -363,9 +385,9 @@ func mergeEntry(
 }
 
 // mergeMappingEntry handles a mapping-valued key: a non-union opaque map
-// (KindMap without merge:"union") replaces wholesale; everything else (union
-// maps and struct nesting) recurses when the destination is also a mapping,
-// otherwise replaces.
+// (KindMap without merge:"union"/"unique-union") replaces wholesale; everything
+// else (union maps and struct nesting) recurses when the destination is also a
+// mapping, otherwise replaces.
 func mergeMappingEntry(
 	dst *yaml.Node,
 	key string,
@@ -377,7 +399,7 @@ func mergeMappingEntry(
 	tags tagRegistry,
 ) {
 	meta, isField := tags[path]
-	opaqueReplace := isField && meta.kind == KindMap && meta.mergeTag != mergeUnion
+	opaqueReplace := isField && meta.kind == KindMap && !meta.unionMap()
 	if !opaqueReplace && exists && isMapping(dstVal) {
 		mergeNodes(dstVal, srcVal, prov, layerIdx, path, tags)
 		return
@@ -412,6 +434,38 @@ func unionSeqNodes(dst, src *yaml.Node) *yaml.Node {
 	return result
 }
 
+// uniqueUnionSeqNodes is unionSeqNodes, except that an item both layers list
+// takes the position src gives it: a layer that re-lists inherited items
+// controls their order, so [ripgrep] under [git, ripgrep] merges to
+// [git, ripgrep].
+func uniqueUnionSeqNodes(dst, src *yaml.Node) *yaml.Node {
+	inherited := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, item := range dst.Content {
+		if !slices.ContainsFunc(src.Content, func(n *yaml.Node) bool { return decodedEqual(n, item) }) {
+			inherited.Content = append(inherited.Content, item)
+		}
+	}
+	return unionSeqNodes(inherited, src)
+}
+
+// appendSeqNodes concatenates two sequence nodes without deduplication:
+// lower-priority (dst) items first, then higher-priority (src) items. Like
+// unionSeqNodes, the result keeps src's sequence-level comments.
+func appendSeqNodes(dst, src *yaml.Node) *yaml.Node {
+	result := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	result.HeadComment = src.HeadComment
+	result.LineComment = src.LineComment
+	result.FootComment = src.FootComment
+
+	for _, item := range dst.Content {
+		result.Content = append(result.Content, cloneNode(item))
+	}
+	for _, item := range src.Content {
+		result.Content = append(result.Content, cloneNode(item))
+	}
+	return result
+}
+
 // buildVirtualNode constructs the virtual (lowest-priority) layer node from the
 // defaults YAML and the raw seed string: defaults at the bottom, raw merged on
 // top. Returns an empty mapping when both are empty; the caller skips appending
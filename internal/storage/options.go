@@ -51,6 +51,19 @@ type Options struct {
 	// Header is stamped as a comment block at the top of the file on every
 	// write; empty disables it (WithHeader).
 	Header string
+	// Interpolate expands ${VAR} references in string values against the
+	// process environment when the typed snapshot is decoded
+	// (WithInterpolation).
+	Interpolate bool
+	// StrictInterpolation makes an undefined ${VAR} with no default a load
+	// error instead of an empty string (WithInterpolation).
+	StrictInterpolation bool
+	// Cipher decrypts encrypted string values when the typed snapshot is
+	// decoded and re-encrypts values written over ciphertext (WithCipher).
+	Cipher Cipher
+	// OnWrite is called after each file a write updates, with the dotted
+	// field paths set and removed in it (WithWriteHook).
+	OnWrite func(path string, sets, deletes []string)
 
 	migrations []any // []Migration[T] (type-erased; asserted to func(*Store[T]) (bool, error) in migrateLayer)
 }
@@ -184,8 +197,41 @@ func WithMigrations[T Schema](fns ...Migration[T]) Option {
 	}
 }
 
-// WithLock enables flock-based advisory locking for Write operations.
-// Use for stores that need cross-process mutual exclusion (e.g. a store
+// WithInterpolation enables ${VAR} / ${VAR:-default} expansion of string
+// values against the process environment. Expansion happens only on the
+// decoded snapshot (Read); the node tree — and therefore Get, Layers, and
+// every Write — keeps the verbatim ${VAR} text, so files stay portable across
+// machines. Bare $VAR is not expanded, $$ escapes a literal $, and fields
+// tagged `interpolate:"false"` are left untouched. With strict set, a
+// reference to an undefined variable without a default fails the decode.
+func WithInterpolation(strict bool) Option {
+	return func(o *Options) {
+		o.Interpolate = true
+		o.StrictInterpolation = strict
+	}
+}
+
+// WithCipher enables encrypted values. Like interpolation, decryption happens
+// only on the decoded snapshot (Read): the node tree — Get, Layers, and every
+// Write — keeps the ciphertext. A Write that replaces a value its destination
+// file holds encrypted encrypts the new value, so a key stored encrypted is
+// never persisted in plaintext.
+func WithCipher(c Cipher) Option {
+	return func(o *Options) {
+		o.Cipher = c
+	}
+}
+
+// WithWriteHook calls fn after each file a Write, WriteTo, or WriteFieldTo
+// updates, with the dotted field paths set and removed in that file.
+// Migration rewrites are not reported. Values are deliberately not passed:
+// a hook that records writes (the audit journal) must not copy secrets out
+// of the file.
+func WithWriteHook(fn func(path string, sets, deletes []string)) Option {
+	return func(o *Options) {
+		o.OnWrite = fn
+	}
+}
 
 // writeFilename returns the filename used when creating a file at a location
 // with no existing layer: DefaultFilename, falling back to the first
@@ -200,7 +246,10 @@ func (o *Options) writeFilename() string {
 	return ""
 }
 
-// written by concurrent CLI invocations).
+// WithLock enables flock-based advisory locking for Write operations.
+// Use for stores that need cross-process mutual exclusion (e.g. a store
+// written by concurrent CLI invocations). Lock acquisition retries with
+// backoff; a lock held past the timeout fails the write with ErrLocked.
 func WithLock() Option {
 	return func(o *Options) {
 		o.Lock = true
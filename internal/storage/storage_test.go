@@ -17,6 +17,7 @@ import (
 
 	treepkg "github.com/a8m/tree"
 	"github.com/a8m/tree/ostree"
+	"github.com/gofrs/flock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
@@ -2903,6 +2904,115 @@ func TestStore_Merge_UnionWithImplicitYAMLFieldName(t *testing.T) {
 		"merge union should still apply when yaml tag uses implicit field name")
 }
 
+type testListStrategyCfg struct {
+	Appended []string          `yaml:"appended" merge:"append"`
+	Unique   []string          `yaml:"unique"   merge:"unique-union"`
+	Replaced []string          `yaml:"replaced" merge:"replace"`
+	Env      map[string]string `yaml:"env"      merge:"unique-union"`
+}
+
+func (t testListStrategyCfg) Fields() FieldSet { return NormalizeFields(t) }
+
+type testBadMergeTagCfg struct {
+	Items []string `yaml:"items" merge:"unoin"`
+}
+
+func (t testBadMergeTagCfg) Fields() FieldSet { return NormalizeFields(t) }
+
+func TestStore_Merge_ListStrategies(t *testing.T) {
+	tags := buildTagRegistry[testListStrategyCfg]()
+
+	base := mustNode(t, map[string]any{
+		"appended": []any{"a", "b"},
+		"unique":   []any{"a", "b"},
+		"replaced": []any{"a", "b"},
+		"env":      map[string]any{"A": "1", "B": "1"},
+	})
+	top := mustNode(t, map[string]any{
+		"appended": []any{"b", "c"},
+		"unique":   []any{"b", "c"},
+		"replaced": []any{"b", "c"},
+		"env":      map[string]any{"B": "2"},
+	})
+
+	result, _ := merge([]layer{
+		{path: "top.yaml", filename: "top.yaml", node: top},
+		{path: "", filename: "", node: base, virtual: true},
+	}, tags)
+	cfg, err := decodeNode[testListStrategyCfg](result)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a", "b", "b", "c"}, cfg.Appended, "append keeps duplicates")
+	assert.Equal(t, []string{"a", "b", "c"}, cfg.Unique, "unique-union drops duplicates")
+	assert.Equal(t, []string{"b", "c"}, cfg.Replaced, "replace is last-wins")
+	assert.Equal(t, map[string]string{"A": "1", "B": "2"}, cfg.Env, "unique-union map merges per key")
+}
+
+func TestStore_Merge_UniqueUnionOrderAndReplaceTag(t *testing.T) {
+	tags := buildTagRegistry[testListStrategyCfg]()
+	layerNode := func(data string) *yaml.Node {
+		n, err := rootMapping([]byte(data))
+		require.NoError(t, err)
+		return n
+	}
+
+	base := layerNode("unique: [ripgrep, jq]\nappended: [a, b]\n")
+	tests := []struct {
+		name         string
+		top          string
+		wantUnique   []string
+		wantAppended []string
+	}{
+		{name: "re-listed items take the top layer's order", top: "unique: [git, ripgrep]\n", wantUnique: []string{"jq", "git", "ripgrep"}, wantAppended: []string{"a", "b"}},
+		{name: "replace tag drops inherited items", top: "unique: !replace [git]\nappended: !replace [c]\n", wantUnique: []string{"git"}, wantAppended: []string{"c"}},
+		{name: "empty replace clears the list", top: "unique: !replace []\n", wantUnique: []string{}, wantAppended: []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := merge([]layer{
+				{path: "top.yaml", filename: "top.yaml", node: layerNode(tt.top)},
+				{path: "", filename: "", node: base, virtual: true},
+			}, tags)
+			cfg, err := decodeNode[testListStrategyCfg](result)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUnique, cfg.Unique)
+			assert.Equal(t, tt.wantAppended, cfg.Appended)
+
+			unique, ok := mappingValue(result, "unique")
+			require.True(t, ok)
+			assert.Equal(t, "!!seq", unique.Tag, "the merged tree drops the replace tag")
+		})
+	}
+}
+
+func TestStore_Write_KeepsReplaceTag(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("unique: !replace [git]\n"), 0o644))
+
+	store, err := New[testListStrategyCfg]("unique: [ripgrep]\n",
+		WithFilenames("config.yaml"),
+		WithPaths(dir),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git"}, store.Read().Unique)
+
+	require.NoError(t, store.Set("unique", []string{"git", "jq"}))
+	require.NoError(t, store.Write())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "!replace")
+	assert.Equal(t, []string{"git", "jq"}, store.Read().Unique)
+}
+
+func TestNew_RejectsUnknownMergeStrategy(t *testing.T) {
+	_, err := New[testBadMergeTagCfg]("items: [a]\n")
+	require.ErrorIs(t, err, ErrUnknownMergeStrategy)
+	assert.Contains(t, err.Error(), `items`)
+	assert.Contains(t, err.Error(), `"unoin"`)
+}
+
 // testPortRule mirrors a real-world opaque struct-slice element whose Port is a
 // Go string but is written on disk as a bare yaml int (e.g. `port: 22`).
 type testPortRule struct {
@@ -3427,6 +3537,25 @@ func TestWriteTo_MergesIntoExistingExternalFile(t *testing.T) {
 	assert.Contains(t, got, "# precious comment", "existing comment lost")
 }
 
+// New keys are grafted in sorted path order, so the same Sets produce the
+// same file on every run.
+func TestWriteTo_NewKeysInDeterministicOrder(t *testing.T) {
+	for i := range 10 {
+		dir := t.TempDir()
+		s, err := New[hardSchema]("")
+		require.NoError(t, err)
+		require.NoError(t, s.Set("name", "alice"))
+		require.NoError(t, s.Set("mode", "snapshot"))
+		require.NoError(t, s.Set("count", 3))
+
+		path := filepath.Join(dir, "out.yaml")
+		require.NoError(t, s.WriteTo(path))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "count: 3\nmode: snapshot\nname: alice\n", string(data), "run %d", i)
+	}
+}
+
 // Two stores on the same file (two processes): each sets a disjoint field and
 // writes. The second write must not revert the first's update — the write path
 // re-reads the file under the lock instead of trusting its stale in-memory node.
@@ -3449,6 +3578,45 @@ func TestWrite_CrossStoreNoLostUpdate(t *testing.T) {
 	assert.Contains(t, got, "mode: TWO-UPDATED", "s2's own write missing")
 }
 
+// A writer blocked on another process's lock retries with backoff and writes
+// once the lock is released; a lock held past the timeout fails the write with
+// ErrLocked and leaves the file untouched.
+func TestWrite_LockContention(t *testing.T) {
+	origTimeout := lockTimeout
+	t.Cleanup(func() { lockTimeout = origTimeout })
+	lockTimeout = 300 * time.Millisecond
+
+	dir := t.TempDir()
+	file := writeHardFile(t, dir, "cfg.yaml", "name: one\n")
+	s := newHardStore(t, dir, WithLock())
+
+	other := flock.New(file + ".lock")
+	locked, err := other.TryLock()
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	t.Run("held past the timeout", func(t *testing.T) {
+		require.NoError(t, s.Set("name", "blocked"))
+		err := s.Write()
+		require.ErrorIs(t, err, ErrLocked)
+		assert.Contains(t, err.Error(), "another clawker instance holds the lock")
+
+		data, rerr := os.ReadFile(file)
+		require.NoError(t, rerr)
+		assert.Equal(t, "name: one\n", string(data))
+	})
+
+	t.Run("released while waiting", func(t *testing.T) {
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			_ = other.Unlock()
+		}()
+		require.NoError(t, s.Set("name", "after-release"))
+		require.NoError(t, s.Write())
+		assert.Contains(t, mustReadFile(t, file), "name: after-release")
+	})
+}
+
 // Refresh must surface a layer that no longer parses — silently dropping it
 // would revert every field it owned to defaults (the exact failure rootMapping
 // rejects loudly at construction).
@@ -3700,3 +3868,93 @@ func makeOptions(opts ...Option) Options {
 	}
 	return o
 }
+
+type testOverlayEntry struct {
+	Build    testBuild `yaml:"build"`
+	Packages []string  `yaml:"packages"`
+}
+
+type testOverlayCfg struct {
+	Build     testBuild                   `yaml:"build"`
+	Packages  []string                    `yaml:"packages"  merge:"union"`
+	Overrides map[string]testOverlayEntry `yaml:"overrides"`
+}
+
+func (t testOverlayCfg) Fields() FieldSet { return NormalizeFields(t) }
+
+func TestStore_ReadOverlay(t *testing.T) {
+	store, err := New[testOverlayCfg](`
+build:
+  image: node:20
+  target: dev
+packages: [git]
+overrides:
+  reviewer:
+    build:
+      image: node:22
+    packages: [ripgrep]
+  ci:
+    build:
+      target: ci
+    packages: [jq]
+`)
+	require.NoError(t, err)
+
+	t.Run("folds the entry over the root", func(t *testing.T) {
+		got, err := store.ReadOverlay("overrides.reviewer")
+		require.NoError(t, err)
+		assert.Equal(t, "node:22", got.Build.Image)
+		assert.Equal(t, "dev", got.Build.Target, "unset keys inherit the base")
+		assert.Equal(t, []string{"git", "ripgrep"}, got.Packages, "union fields accumulate")
+	})
+
+	t.Run("store is untouched", func(t *testing.T) {
+		_, err := store.ReadOverlay("overrides.reviewer")
+		require.NoError(t, err)
+		assert.Equal(t, "node:20", store.Read().Build.Image)
+		assert.Equal(t, []string{"git"}, store.Read().Packages)
+	})
+
+	t.Run("paths fold in order", func(t *testing.T) {
+		got, err := store.ReadOverlay("overrides.ci", "overrides.missing", "overrides.reviewer")
+		require.NoError(t, err)
+		assert.Equal(t, "node:22", got.Build.Image)
+		assert.Equal(t, "ci", got.Build.Target)
+		assert.Equal(t, []string{"git", "jq", "ripgrep"}, got.Packages)
+	})
+
+	t.Run("absent path returns the snapshot", func(t *testing.T) {
+		got, err := store.ReadOverlay("overrides.missing")
+		require.NoError(t, err)
+		assert.Same(t, store.Read(), got)
+	})
+
+	t.Run("non-mapping is an error", func(t *testing.T) {
+		_, err := store.ReadOverlay("build.image")
+		require.Error(t, err)
+	})
+}
+
+func TestWriteHook_ReportsWrittenPaths(t *testing.T) {
+	dir := t.TempDir()
+	file := writeHardFile(t, dir, "cfg.yaml", "name: alice\nmode: bind\n")
+
+	type write struct {
+		path          string
+		sets, deletes []string
+	}
+	var got []write
+	s := newHardStore(t, dir, WithWriteHook(func(path string, sets, deletes []string) {
+		got = append(got, write{path, sets, deletes})
+	}))
+	require.NoError(t, s.Set("name", "bob"))
+	_, err := s.Remove("mode")
+	require.NoError(t, err)
+	require.NoError(t, s.Write())
+
+	assert.Equal(t, []write{{file, []string{"name"}, []string{"mode"}}}, got)
+
+	// Nothing dirty, nothing written, nothing reported.
+	require.NoError(t, s.Write())
+	assert.Len(t, got, 1)
+}
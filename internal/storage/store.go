@@ -115,6 +115,9 @@ func New[T Schema](seed string, opts ...Option) (*Store[T], error) {
 	}
 
 	// Build the virtual layer node: defaults (safety net) + seed string on top.
+	if err := validateMergeTags[T](); err != nil {
+		return nil, err
+	}
 	tags := buildTagRegistry[T]()
 	virtual, err := buildVirtualNode(o.Defaults, seed, tags)
 	if err != nil {
@@ -150,7 +153,7 @@ func New[T Schema](seed string, opts ...Option) (*Store[T], error) {
 	}
 
 	// Final strict decode — migrations have fixed any legacy shapes by now.
-	value, err := decodeNode[T](s.tree)
+	value, err := s.decode(s.tree)
 	if err != nil {
 		return nil, fmt.Errorf("storage: deserializing merged tree: %w", err)
 	}
@@ -468,6 +471,43 @@ func (s *Store[T]) Has(path string) bool {
 	return ok
 }
 
+// ReadOverlay returns a snapshot of the merged tree with the mapping at each
+// dotted path folded over the root as one more, highest-priority layer — the
+// same merge semantics as file layers (union-tagged fields accumulate, opaque
+// maps and scalars are replaced), with keys read relative to the document
+// root. Paths fold in order, so a later path wins over an earlier one. It is
+// how a schema carries named override blocks (e.g. per-agent settings, config
+// profiles) without a second file. The store itself is not modified; absent
+// paths are skipped, and with none present the regular snapshot is returned.
+// A non-mapping value at a path is an error, as is a fold that no longer
+// decodes into T.
+func (s *Store[T]) ReadOverlay(paths ...string) (*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var merged *yaml.Node
+	for _, path := range paths {
+		overlay, ok := nodeValueAt(s.tree, strings.Split(path, "."))
+		if !ok {
+			continue
+		}
+		if !isMapping(overlay) {
+			return nil, fmt.Errorf("storage: overlay %q is not a mapping", path)
+		}
+		if merged == nil {
+			merged = cloneNode(s.tree)
+		}
+		mergeNodes(merged, cloneNode(overlay), provenance{}, 0, "", s.tags)
+	}
+	if merged == nil {
+		return s.value.Load(), nil
+	}
+	v, err := s.decode(merged)
+	if err != nil {
+		return nil, fmt.Errorf("storage: overlay %q: %w", strings.Join(paths, ","), err)
+	}
+	return v, nil
+}
+
 // Layers returns information about the discovered file layers.
 // Layers are ordered from highest priority (index 0) to lowest.
 func (s *Store[T]) Layers() []LayerInfo {
@@ -576,7 +616,7 @@ func (s *Store[T]) Set(path string, value any) error {
 	// while the dirty path persists, so the next Write poisons the file on disk.
 	candidate := cloneNode(s.tree)
 	nodeGraftValue(candidate, segs, valNode)
-	decoded, derr := decodeNode[T](candidate)
+	decoded, derr := s.decode(candidate)
 	if derr != nil {
 		return fmt.Errorf("storage: Set %q: %w: %w", path, ErrSchemaDecode, derr)
 	}
@@ -613,7 +653,7 @@ func (s *Store[T]) Remove(path string) (bool, error) {
 	if !nodeDeletePath(candidate, segs) {
 		return false, nil
 	}
-	decoded, derr := decodeNode[T](candidate)
+	decoded, derr := s.decode(candidate)
 	if derr != nil {
 		return false, fmt.Errorf("storage: Remove %q: result no longer decodes into schema: %w", path, derr)
 	}
@@ -630,7 +670,7 @@ func (s *Store[T]) Remove(path string) (bool, error) {
 // Set/Remove path does NOT use this: it validates the decode and surfaces a
 // failure. Caller must hold s.mu (and have s.migrating set).
 func (s *Store[T]) refreshSnapshot() {
-	if value, err := decodeNode[T](s.tree); err == nil {
+	if value, err := s.decode(s.tree); err == nil {
 		s.value.Store(value)
 	}
 }
@@ -701,7 +741,7 @@ func isOpaqueField(tags tagRegistry, path string) bool {
 	if !ok {
 		return false
 	}
-	if meta.kind == KindMap && meta.mergeTag == mergeUnion {
+	if meta.unionMap() {
 		return false // union maps recurse per-entry
 	}
 	return meta.kind == KindMap || meta.kind == KindStructMap || meta.kind == KindStructSlice
@@ -834,7 +874,7 @@ func (s *Store[T]) restage(sets map[string]*yaml.Node, deletes []string) error {
 	for _, path := range deletes {
 		nodeDeletePath(candidate, strings.Split(path, "."))
 	}
-	decoded, err := decodeNode[T](candidate)
+	decoded, err := s.decode(candidate)
 	if err != nil {
 		return fmt.Errorf("storage: re-staging pending mutations after partial flush: %w", err)
 	}
@@ -901,8 +941,8 @@ type fileOps struct {
 
 // groupDirtyByDest groups the dirty field paths by destination file. A
 // non-empty target directs every path at that file; otherwise each path routes
-// to its provenance layer, falling back to defaultWritePath. Caller must hold
-// s.mu.
+// to its provenance layer, falling back to defaultWritePath. Paths are sorted
+// per file. Caller must hold s.mu.
 func (s *Store[T]) groupDirtyByDest(target string) (map[string]*fileOps, error) {
 	grouped := make(map[string]*fileOps)
 	for path, op := range s.dirtyPaths {
@@ -928,6 +968,12 @@ func (s *Store[T]) groupDirtyByDest(target string) (map[string]*fileOps, error)
 			grouped[dest].deletes = append(grouped[dest].deletes, path)
 		}
 	}
+	// Map order is random; sorted paths graft new keys into a file in the
+	// same order on every write.
+	for _, ops := range grouped {
+		slices.Sort(ops.sets)
+		slices.Sort(ops.deletes)
+	}
 	return grouped, nil
 }
 
@@ -943,12 +989,18 @@ func (s *Store[T]) groupDirtyByDest(target string) (map[string]*fileOps, error)
 // grafted values are sourced from the merged tree and comment-stripped, and the
 // destination's existing field comments are carried forward.
 func (s *Store[T]) writeLayerFile(dest string, sets, deletes []string) error {
+	var err error
 	if s.opts.Lock {
-		return withLock(dest, func() error {
+		err = withLock(dest, func() error {
 			return s.writeLayerFileLocked(dest, sets, deletes)
 		})
+	} else {
+		err = s.writeLayerFileLocked(dest, sets, deletes)
+	}
+	if err == nil && s.opts.OnWrite != nil {
+		s.opts.OnWrite(dest, sets, deletes)
 	}
-	return s.writeLayerFileLocked(dest, sets, deletes)
+	return err
 }
 
 // loadDestNode returns dest's current on-disk mapping node, or a fresh empty
@@ -976,6 +1028,10 @@ func (s *Store[T]) writeLayerFileLocked(dest string, sets, deletes []string) err
 	for _, p := range sets {
 		segs := strings.Split(p, ".")
 		if val, ok := nodeValueAt(s.tree, segs); ok {
+			val, err := sealForDest(node, segs, val, s.opts.Cipher)
+			if err != nil {
+				return fmt.Errorf("storage: %w", err)
+			}
 			nodeGraftValue(node, segs, val)
 		} else {
 			// Value no longer present in the merged tree (cleared) — drop it.
@@ -1187,7 +1243,7 @@ func (s *Store[T]) Txn(fn func(*Tx[T]) error) error {
 // from the current layer stack. Caller must hold s.mu.
 func (s *Store[T]) remerge() error {
 	tree, prov := merge(s.layers, s.tags)
-	value, err := decodeNode[T](tree)
+	value, err := s.decode(tree)
 	if err != nil {
 		return fmt.Errorf("storage: remerge: %w", err)
 	}
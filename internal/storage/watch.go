@@ -0,0 +1,209 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce coalesces the burst of events a single save produces
+// (editors write a temp file, rename it over the original, then chmod it)
+// into one reload.
+const watchDebounce = 200 * time.Millisecond
+
+// Changes lists the dotted leaf paths whose merged value differs between two
+// loads of a store, sorted. A path that appeared or disappeared counts as
+// changed. Sequences are compared whole and reported at their own path.
+type Changes []string
+
+// Touches reports whether any changed path is one of prefixes or lies under
+// one of them ("host_proxy.daemon" is touched by
+// "host_proxy.daemon.poll_interval").
+func (c Changes) Touches(prefixes ...string) bool {
+	for _, path := range c {
+		for _, p := range prefixes {
+			if path == p || strings.HasPrefix(path, p+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Under returns the changed paths that Touches prefix.
+func (c Changes) Under(prefix string) Changes {
+	var out Changes
+	for _, path := range c {
+		if path == prefix || strings.HasPrefix(path, prefix+".") {
+			out = append(out, path)
+		}
+	}
+	return out
+}
+
+// Watch reloads the store whenever one of its files changes on disk and
+// reports what changed. It blocks until ctx is done, then returns nil.
+//
+// Watched are the explicit directories (WithPaths, WithConfigDir, ...) and
+// the directory of every file layer discovered when Watch starts; any create,
+// write, rename, or removal of a candidate filename there triggers a Refresh
+// after a short debounce. onChange is called from Watch's goroutine with the
+// changed leaf paths when the merged tree differs, or with the Refresh error
+// when the files no longer load — the store keeps serving the last good
+// snapshot in that case, so a half-saved edit never tears down a caller.
+// A save that changes nothing effective (comments, formatting) is not
+// reported.
+//
+// Refresh discards pending mutations, so a store that is being mutated
+// in-process should not also be watched.
+func (s *Store[T]) Watch(ctx context.Context, onChange func(Changes, error)) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("storage: Watch: %w", err)
+	}
+	defer w.Close()
+
+	dirs := s.watchDirs()
+	for _, dir := range dirs {
+		if err := w.Add(dir); err != nil {
+			return fmt.Errorf("storage: Watch %s: %w", dir, err)
+		}
+	}
+	names := s.watchNames()
+
+	var (
+		timer   *time.Timer
+		timerCh <-chan time.Time
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			if !names[filepath.Base(ev.Name)] || ev.Op == fsnotify.Chmod {
+				continue
+			}
+			if timer == nil {
+				timer = time.NewTimer(watchDebounce)
+			} else {
+				timer.Reset(watchDebounce)
+			}
+			timerCh = timer.C
+		case werr, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			onChange(nil, fmt.Errorf("storage: Watch: %w", werr))
+		case <-timerCh:
+			timerCh = nil
+			changes, rerr := s.reloadChanges()
+			if rerr != nil || len(changes) > 0 {
+				onChange(changes, rerr)
+			}
+		}
+	}
+}
+
+// reloadChanges refreshes the store from disk and diffs the merged tree. On
+// a failed Refresh the store is left untouched.
+func (s *Store[T]) reloadChanges() (Changes, error) {
+	s.mu.Lock()
+	before := flattenLeaves(nodeToMap(s.tree))
+	s.mu.Unlock()
+
+	if err := s.Refresh(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	after := flattenLeaves(nodeToMap(s.tree))
+	s.mu.Unlock()
+
+	var changes Changes
+	for path, v := range after {
+		if old, ok := before[path]; !ok || !reflect.DeepEqual(old, v) {
+			changes = append(changes, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changes = append(changes, path)
+		}
+	}
+	slices.Sort(changes)
+	return changes, nil
+}
+
+// watchDirs returns the existing directories Watch observes, deduplicated.
+func (s *Store[T]) watchDirs() []string {
+	s.mu.Lock()
+	candidates := slices.Clone(s.opts.Paths)
+	for _, l := range s.layers {
+		if !l.virtual && l.path != "" {
+			candidates = append(candidates, filepath.Dir(l.path))
+		}
+	}
+	s.mu.Unlock()
+
+	var dirs []string
+	for _, dir := range candidates {
+		dir = filepath.Clean(dir)
+		if slices.Contains(dirs, dir) {
+			continue
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// watchNames returns every base name discovery could pick up for the
+// store's filenames: both YAML extensions, bare and dot-prefixed.
+func (s *Store[T]) watchNames() map[string]bool {
+	names := map[string]bool{}
+	for _, fname := range s.opts.Filenames {
+		for _, n := range yamlExtensions(fname) {
+			names[n] = true
+		}
+		for _, n := range yamlExtensions("." + fname) {
+			names[n] = true
+		}
+	}
+	return names
+}
+
+// flattenLeaves maps every non-mapping value in m to its dotted path.
+func flattenLeaves(m map[string]any) map[string]any {
+	out := map[string]any{}
+	var walk func(prefix string, v any)
+	walk = func(prefix string, v any) {
+		if sub, ok := v.(map[string]any); ok && len(sub) > 0 {
+			for k, child := range sub {
+				walk(prefix+"."+k, child)
+			}
+			return
+		}
+		out[prefix] = v
+	}
+	for k, v := range m {
+		walk(k, v)
+	}
+	return out
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watchResult struct {
+	changes Changes
+	err     error
+}
+
+// startWatch runs store.Watch in the background and returns the channel its
+// callbacks land on. The watch stops when the test ends.
+func startWatch(t *testing.T, store *Store[testConfig]) <-chan watchResult {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	results := make(chan watchResult, 8)
+	done := make(chan error, 1)
+	go func() {
+		done <- store.Watch(ctx, func(c Changes, err error) { results <- watchResult{c, err} })
+	}()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+	// Let the watcher register its directories before the test writes.
+	time.Sleep(50 * time.Millisecond)
+	return results
+}
+
+func nextResult(t *testing.T, results <-chan watchResult) watchResult {
+	t.Helper()
+	select {
+	case r := <-results:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a watch callback")
+		return watchResult{}
+	}
+}
+
+func TestStore_Watch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("name: app\nbuild:\n  image: node:20\n"), 0o644))
+
+	store, err := New[testConfig]("", WithFilenames("config.yaml"), WithPaths(dir))
+	require.NoError(t, err)
+	results := startWatch(t, store)
+
+	t.Run("reports changed leaf paths and reloads", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("name: app\nbuild:\n  image: node:22\n  target: dev\n"), 0o644))
+
+		r := nextResult(t, results)
+		require.NoError(t, r.err)
+		assert.Equal(t, Changes{"build.image", "build.target"}, r.changes)
+		assert.Equal(t, "node:22", store.Read().Build.Image)
+	})
+
+	t.Run("comment-only save is not reported", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("# tweak\nname: app\nbuild:\n  image: node:22\n  target: dev\n"), 0o644))
+		// Follow with a real change: the first callback must be for it.
+		time.Sleep(2 * watchDebounce)
+		require.NoError(t, os.WriteFile(path, []byte("name: web\nbuild:\n  image: node:22\n  target: dev\n"), 0o644))
+
+		r := nextResult(t, results)
+		require.NoError(t, r.err)
+		assert.Equal(t, Changes{"name"}, r.changes)
+	})
+
+	t.Run("invalid file keeps the last good snapshot", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("name: [unterminated\n"), 0o644))
+
+		r := nextResult(t, results)
+		require.Error(t, r.err)
+		assert.Equal(t, "web", store.Read().Name)
+	})
+}
+
+func TestStore_Watch_FileCreated(t *testing.T) {
+	dir := t.TempDir()
+	store, err := New[testConfig]("", WithFilenames("config.yaml"), WithPaths(dir), WithDefaults("name: default\n"))
+	require.NoError(t, err)
+	results := startWatch(t, store)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("name: created\n"), 0o644))
+
+	r := nextResult(t, results)
+	require.NoError(t, r.err)
+	assert.Equal(t, Changes{"name"}, r.changes)
+	assert.Equal(t, "created", store.Read().Name)
+}
+
+func TestChanges_Touches(t *testing.T) {
+	c := Changes{"host_proxy.daemon.poll_interval", "logging.max_size_mb"}
+
+	assert.True(t, c.Touches("host_proxy.daemon"))
+	assert.True(t, c.Touches("firewall", "logging.max_size_mb"))
+	assert.False(t, c.Touches("host_proxy.daemon.port"))
+	assert.False(t, c.Touches("host_proxy.dae"), "prefixes match whole segments")
+	assert.Equal(t, Changes{"logging.max_size_mb"}, c.Under("logging"))
+}
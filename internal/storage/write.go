@@ -2,7 +2,6 @@ package storage
 
 import (
 	"bytes"
-	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -272,24 +271,53 @@ func atomicWrite(path string, data []byte, perm os.FileMode) error {
 	return nil
 }
 
+// Lock acquisition tuning for withLock. The first retries come quickly so a
+// short write by another process barely delays this one; the delay then
+// doubles up to lockMaxBackoff until lockTimeout gives up.
+var (
+	lockTimeout     = 10 * time.Second
+	lockBackoff     = 10 * time.Millisecond
+	lockMaxBackoff  = 500 * time.Millisecond
+	errLockTimedOut = errors.New("lock wait timed out")
+)
+
 // withLock acquires an advisory file lock on path+".lock" before running fn.
-// Provides cross-process mutual exclusion for file writes.
+// Provides cross-process mutual exclusion for file writes. A lock still held
+// by another process after lockTimeout yields an error wrapping ErrLocked.
 func withLock(path string, fn func() error) error {
 	fl := flock.New(path + ".lock")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	locked, err := fl.TryLockContext(ctx, 100*time.Millisecond)
-	if err != nil {
+	if err := tryLockWithBackoff(fl); err != nil {
+		if errors.Is(err, errLockTimedOut) {
+			return fmt.Errorf("storage: writing %s: %w (waited %s)", path, ErrLocked, lockTimeout)
+		}
 		return fmt.Errorf("storage: acquiring file lock for %s: %w", path, err)
 	}
-	if !locked {
-		return fmt.Errorf("storage: timed out acquiring file lock for %s", path)
-	}
 	// Unlock error is unactionable in deferred cleanup: the flock is released by
 	// the OS on process exit regardless, and the write outcome is already decided.
 	defer func() { _ = fl.Unlock() }()
 
 	return fn()
 }
+
+// tryLockWithBackoff polls fl with exponential backoff until it is acquired,
+// fails, or lockTimeout elapses (errLockTimedOut).
+func tryLockWithBackoff(fl *flock.Flock) error {
+	deadline := time.Now().Add(lockTimeout)
+	delay := lockBackoff
+	for {
+		locked, err := fl.TryLock()
+		if err != nil {
+			return err
+		}
+		if locked {
+			return nil
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return errLockTimedOut
+		}
+		time.Sleep(min(delay, remaining))
+		delay = min(delay*2, lockMaxBackoff)
+	}
+}
@@ -0,0 +1,140 @@
+// Package supportbundle writes the tar.gz archive `clawker debug bundle`
+// produces for bug reports. Every file added is passed through Redact, and
+// the archive ends with a manifest.json listing what was collected and what
+// could not be.
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// ManifestFile is the archive entry describing the bundle.
+const ManifestFile = "manifest.json"
+
+// Manifest is the bundle's table of contents.
+type Manifest struct {
+	CreatedAt time.Time         `json:"created_at"`
+	Info      map[string]string `json:"info,omitempty"`
+	Files     []string          `json:"files"`
+	Skipped   []Skipped         `json:"skipped,omitempty"`
+}
+
+// Skipped records an item that could not be collected. Collection is
+// best-effort, so a missing log is noted rather than failing the bundle.
+type Skipped struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// Writer builds a support bundle. It is not safe for concurrent use.
+type Writer struct {
+	gz       *gzip.Writer
+	tw       *tar.Writer
+	manifest Manifest
+}
+
+// NewWriter starts a bundle on w. info is recorded in the manifest as-is
+// (clawker version, agent, container ID, ...).
+func NewWriter(w io.Writer, now time.Time, info map[string]string) *Writer {
+	gz := gzip.NewWriter(w)
+	return &Writer{
+		gz: gz,
+		tw: tar.NewWriter(gz),
+		manifest: Manifest{
+			CreatedAt: now.UTC(),
+			Info:      info,
+			Files:     []string{},
+		},
+	}
+}
+
+// Add writes data, redacted, as the archive entry name.
+func (w *Writer) Add(name string, data []byte) error {
+	return w.write(name, Redact(data))
+}
+
+// AddReader reads r to EOF and adds it as name.
+func (w *Writer) AddReader(name string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+	return w.Add(name, data)
+}
+
+// AddTar copies the regular files of the tar stream r (a docker cp archive)
+// into the bundle under dir, redacting each. The archive's own top-level
+// directory is dropped, so /var/log/clawker/x.log lands at dir/x.log.
+func (w *Writer) AddTar(dir string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		rel := hdr.Name
+		if i := strings.IndexByte(rel, '/'); i >= 0 {
+			rel = rel[i+1:]
+		}
+		if err := w.AddReader(path.Join(dir, rel), tr); err != nil {
+			return err
+		}
+	}
+}
+
+// Skip records that name could not be collected.
+func (w *Writer) Skip(name string, err error) {
+	w.manifest.Skipped = append(w.manifest.Skipped, Skipped{Name: name, Error: err.Error()})
+}
+
+// Manifest returns the manifest as built so far.
+func (w *Writer) Manifest() Manifest { return w.manifest }
+
+// Close writes the manifest and finishes the archive. It does not close the
+// underlying writer.
+func (w *Writer) Close() error {
+	data, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+	if err := w.write(ManifestFile, append(data, '\n')); err != nil {
+		return err
+	}
+	if err := w.tw.Close(); err != nil {
+		return fmt.Errorf("finishing archive: %w", err)
+	}
+	return w.gz.Close()
+}
+
+func (w *Writer) write(name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    int64(len(data)),
+		ModTime: w.manifest.CreatedAt,
+	}
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	if _, err := w.tw.Write(data); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	if name != ManifestFile {
+		w.manifest.Files = append(w.manifest.Files, name)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package supportbundle
+
+import "regexp"
+
+// Redacted replaces every secret value Redact finds.
+const Redacted = "<redacted>"
+
+// redactRule rewrites one shape of secret. repl may reference groups in re.
+type redactRule struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+// redactRules run in order. Whole-token shapes come first so a known token
+// is caught even where no key names it; the key-based rules then cover any
+// value assigned to a key that sounds secret, in env, YAML, JSON and logfmt.
+var redactRules = []redactRule{
+	{
+		re:   regexp.MustCompile(`-----BEGIN ([A-Z ]*)PRIVATE KEY-----[\s\S]*?-----END ([A-Z ]*)PRIVATE KEY-----`),
+		repl: "-----BEGIN ${1}PRIVATE KEY-----" + Redacted + "-----END ${2}PRIVATE KEY-----",
+	},
+	{re: regexp.MustCompile(`sk-ant-[A-Za-z0-9_\-]{8,}`), repl: Redacted},
+	{re: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})`), repl: Redacted},
+	{re: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9\-]{10,}`), repl: Redacted},
+	{re: regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`), repl: Redacted},
+	{re: regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]{8,}\.[A-Za-z0-9_\-]{8,}\.[A-Za-z0-9_\-]{8,}`), repl: Redacted},
+	// user:password@ in URLs.
+	{re: regexp.MustCompile(`(://[^/\s:@]+:)[^/\s@]+@`), repl: "${1}" + Redacted + "@"},
+	// Authorization headers keep their scheme.
+	{
+		re:   regexp.MustCompile(`(?i)((?:proxy-)?authorization["']?\s*[:=]\s*["']?)((?:bearer|basic|token)\s+)?[^\s"',]+`),
+		repl: "${1}${2}" + Redacted,
+	},
+	{
+		re:   regexp.MustCompile(`(?i)([A-Za-z0-9_.\-]*(?:token|secret|password|passwd|api[_\-]?key|credential|private[_\-]?key)[A-Za-z0-9_.\-]*["']?\s*[:=]\s*["']?)[^\s"',}\]]+`),
+		repl: "${1}" + Redacted,
+	},
+}
+
+// Redact returns data with secrets replaced by Redacted. Redaction is
+// pattern-based: it errs toward over-redacting, and a secret in a shape it
+// does not know can still slip through.
+func Redact(data []byte) []byte {
+	for _, r := range redactRules {
+		data = r.re.ReplaceAll(data, []byte(r.repl))
+	}
+	return data
+}
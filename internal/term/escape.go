@@ -0,0 +1,120 @@
+package term
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultDetachKeys is the key sequence that detaches from an attached
+// container when none is configured — the same default the Docker CLI uses.
+const DefaultDetachKeys = "ctrl-p,ctrl-q"
+
+// ErrDetach is returned by a reader from NewEscapeReader once the full detach
+// key sequence has been read.
+var ErrDetach = errors.New("read detach key sequence")
+
+// ParseDetachKeys converts a detach key spec into the bytes it produces, using
+// Docker's format: a comma-separated list where each entry is a single
+// character or ctrl-<value>, with <value> one of a-z, @, [, \, ], ^ or _.
+func ParseDetachKeys(keys string) ([]byte, error) {
+	if keys == "" {
+		return nil, errors.New("detach keys must not be empty")
+	}
+	var codes []byte
+	for _, key := range strings.Split(keys, ",") {
+		if len(key) == 1 {
+			codes = append(codes, key[0])
+			continue
+		}
+		ctrl, ok := strings.CutPrefix(strings.ToLower(key), "ctrl-")
+		if !ok || len(ctrl) != 1 {
+			return nil, fmt.Errorf("invalid detach key %q: must be a single character or ctrl-<value>", key)
+		}
+		switch c := ctrl[0]; {
+		case c >= 'a' && c <= 'z':
+			codes = append(codes, c-'a'+1)
+		case c == '@' || (c >= '[' && c <= '_'):
+			codes = append(codes, c-'@')
+		default:
+			return nil, fmt.Errorf("invalid detach key %q: ctrl- must be followed by a-z, @, [, \\, ], ^ or _", key)
+		}
+	}
+	return codes, nil
+}
+
+// escapeReader passes bytes through from r while watching for keys. Bytes that
+// could start the sequence are held back until it is either completed — the
+// read fails with ErrDetach and the held bytes are dropped — or broken, in
+// which case they are released ahead of the byte that broke it.
+type escapeReader struct {
+	r        io.Reader
+	keys     []byte
+	matched  int    // length of the keys prefix currently held back
+	pending  []byte // bytes ready to be returned on the next Read
+	detached bool   // the sequence was read; fail once pending is drained
+}
+
+// NewEscapeReader wraps r so reading keys from it returns ErrDetach instead of
+// passing them through. An empty keys disables detection.
+func NewEscapeReader(r io.Reader, keys []byte) io.Reader {
+	if len(keys) == 0 {
+		return r
+	}
+	return &escapeReader{r: r, keys: keys}
+}
+
+func (e *escapeReader) Read(p []byte) (int, error) {
+	for len(e.pending) == 0 {
+		if e.detached {
+			return 0, ErrDetach
+		}
+		buf := make([]byte, len(p))
+		n, err := e.r.Read(buf)
+		for _, b := range buf[:n] {
+			if b == e.keys[e.matched] {
+				e.matched++
+				if e.matched == len(e.keys) {
+					// Anything read past the sequence is dropped; what came
+					// before it is delivered first.
+					e.detached = true
+					break
+				}
+				continue
+			}
+			// Sequence broken: release the held prefix, then re-check b
+			// as a fresh start.
+			e.pending = append(e.pending, e.keys[:e.matched]...)
+			e.matched = 0
+			if b == e.keys[0] {
+				e.matched = 1
+				continue
+			}
+			e.pending = append(e.pending, b)
+		}
+		if e.detached {
+			continue
+		}
+		if err != nil {
+			// The stream ended mid-sequence: the held prefix was input.
+			e.pending = append(e.pending, e.keys[:e.matched]...)
+			e.matched = 0
+			if len(e.pending) > 0 {
+				return e.drain(p), nil
+			}
+			return 0, err
+		}
+		if n == 0 {
+			return 0, nil
+		}
+	}
+	return e.drain(p), nil
+}
+
+// drain moves as much of pending into p as fits.
+func (e *escapeReader) drain(p []byte) int {
+	n := copy(p, e.pending)
+	e.pending = e.pending[n:]
+	return n
+}
@@ -0,0 +1,76 @@
+package term
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDetachKeys(t *testing.T) {
+	tests := []struct {
+		keys    string
+		want    []byte
+		wantErr bool
+	}{
+		{keys: "ctrl-p,ctrl-q", want: []byte{0x10, 0x11}},
+		{keys: "ctrl-@,ctrl-[,ctrl-_", want: []byte{0x00, 0x1b, 0x1f}},
+		{keys: "CTRL-A,x", want: []byte{0x01, 'x'}},
+		{keys: "", wantErr: true},
+		{keys: "ctrl-", wantErr: true},
+		{keys: "ctrl-pq", wantErr: true},
+		{keys: "ctrl-1", wantErr: true},
+		{keys: "alt-p", wantErr: true},
+		{keys: "ctrl-p,,ctrl-q", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.keys, func(t *testing.T) {
+			got, err := ParseDetachKeys(tt.keys)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEscapeReader(t *testing.T) {
+	keys := []byte{0x10, 0x11} // ctrl-p,ctrl-q
+
+	tests := []struct {
+		name       string
+		input      []byte
+		want       []byte
+		wantDetach bool
+	}{
+		{name: "plain input passes through", input: []byte("ls -la\r"), want: []byte("ls -la\r")},
+		{name: "sequence detaches", input: []byte("ab\x10\x11cd"), want: []byte("ab"), wantDetach: true},
+		{name: "broken sequence is released", input: []byte("a\x10b\x11"), want: []byte("a\x10b\x11")},
+		{name: "repeated prefix restarts the match", input: []byte("\x10\x10\x11"), want: []byte("\x10"), wantDetach: true},
+		{name: "held prefix released at EOF", input: []byte("a\x10"), want: []byte("a\x10")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// One byte per Read exercises sequences split across reads.
+			for _, r := range []io.Reader{bytes.NewReader(tt.input), iotest.OneByteReader(bytes.NewReader(tt.input))} {
+				got, err := io.ReadAll(NewEscapeReader(r, keys))
+				if tt.wantDetach {
+					assert.ErrorIs(t, err, ErrDetach)
+				} else {
+					assert.NoError(t, err)
+				}
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewEscapeReader_NoKeys(t *testing.T) {
+	r := bytes.NewReader([]byte("\x10\x11"))
+	assert.Same(t, r, NewEscapeReader(r, nil))
+}
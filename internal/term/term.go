@@ -28,7 +28,7 @@ func FromEnv() Term {
 	var termWidthPercentage int
 
 	stdoutIsTTY = IsTerminal(os.Stdout)
-	isColorEnabled = IsColorForced() || (!IsColorDisabled() && stdoutIsTTY)
+	isColorEnabled = ColorEnabled(stdoutIsTTY)
 
 	isVirtualTerminal := false
 	if stdoutIsTTY {
@@ -70,6 +70,25 @@ func (t *Term) IsTrueColorSupported() bool {
 	return t.hasTrueColor
 }
 
+// ColorEnabled reports whether color output should be produced for a stream
+// whose TTY state is isTTY. Precedence, highest first:
+//
+//   - NO_COLOR set to any non-empty value disables color (https://no-color.org)
+//   - CLICOLOR_FORCE set to a non-zero value enables color, even off a TTY
+//   - CLICOLOR=0 disables color
+//   - otherwise color follows isTTY
+func ColorEnabled(isTTY bool) bool {
+	switch {
+	case os.Getenv("NO_COLOR") != "":
+		return false
+	case IsColorForced():
+		return true
+	case IsColorDisabled():
+		return false
+	}
+	return isTTY
+}
+
 // IsColorDisabled returns true if environment variables NO_COLOR or CLICOLOR prohibit usage of color codes
 // in terminal output.
 func IsColorDisabled() bool {
@@ -90,3 +90,30 @@ func TestFromEnv_FileDescriptors(t *testing.T) {
 	assert.Equal(t, os.Stdout, tm.out, "out should be os.Stdout")
 	assert.Equal(t, os.Stderr, tm.errOut, "errOut should be os.Stderr")
 }
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		isTTY   bool
+		enabled bool
+	}{
+		{name: "tty", isTTY: true, enabled: true},
+		{name: "not a tty", isTTY: false, enabled: false},
+		{name: "NO_COLOR on tty", env: map[string]string{"NO_COLOR": "1"}, isTTY: true, enabled: false},
+		{name: "empty NO_COLOR is ignored", env: map[string]string{"NO_COLOR": ""}, isTTY: true, enabled: true},
+		{name: "CLICOLOR=0 on tty", env: map[string]string{"CLICOLOR": "0"}, isTTY: true, enabled: false},
+		{name: "CLICOLOR_FORCE off tty", env: map[string]string{"CLICOLOR_FORCE": "1"}, isTTY: false, enabled: true},
+		{name: "CLICOLOR_FORCE=0 is not forcing", env: map[string]string{"CLICOLOR_FORCE": "0"}, isTTY: false, enabled: false},
+		{name: "CLICOLOR_FORCE beats CLICOLOR=0", env: map[string]string{"CLICOLOR": "0", "CLICOLOR_FORCE": "1"}, isTTY: false, enabled: true},
+		{name: "NO_COLOR beats CLICOLOR_FORCE", env: map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}, isTTY: true, enabled: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"NO_COLOR", "CLICOLOR", "CLICOLOR_FORCE"} {
+				t.Setenv(k, tt.env[k])
+			}
+			assert.Equal(t, tt.enabled, ColorEnabled(tt.isTTY))
+		})
+	}
+}
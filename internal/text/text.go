@@ -4,6 +4,7 @@
 package text
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 	"unicode/utf8"
@@ -222,6 +223,30 @@ func LineCount(s string) int {
 	return strings.Count(s, "\n") + 1
 }
 
+// FormatBytes formats a byte count with binary multiples and two decimals:
+// "512B", "1.50KB", "2.00GB".
+func FormatBytes(bytes int64) string {
+	const (
+		KB = 1024
+		MB = KB * 1024
+		GB = MB * 1024
+		TB = GB * 1024
+	)
+
+	switch {
+	case bytes >= TB:
+		return fmt.Sprintf("%.2fTB", float64(bytes)/TB)
+	case bytes >= GB:
+		return fmt.Sprintf("%.2fGB", float64(bytes)/GB)
+	case bytes >= MB:
+		return fmt.Sprintf("%.2fMB", float64(bytes)/MB)
+	case bytes >= KB:
+		return fmt.Sprintf("%.2fKB", float64(bytes)/KB)
+	default:
+		return fmt.Sprintf("%dB", bytes)
+	}
+}
+
 // Slugify normalizes input text into a lowercase dash-separated slug.
 // The result contains only [a-z0-9-], collapses repeated dashes,
 // trims leading/trailing dashes, and is capped at 64 chars.
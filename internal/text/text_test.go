@@ -315,6 +315,28 @@ func TestLineCount(t *testing.T) {
 	}
 }
 
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{0, "0B"},
+		{500, "500B"},
+		{1024, "1.00KB"},
+		{1536, "1.50KB"},
+		{1048576, "1.00MB"},
+		{1073741824, "1.00GB"},
+		{1610612736, "1.50GB"},
+		{1099511627776, "1.00TB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatBytes(tt.bytes))
+		})
+	}
+}
+
 func TestSlugify(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -22,16 +22,28 @@ type DashboardRenderer interface {
 	View(cs *iostreams.ColorScheme, width int) string
 }
 
+// DashboardKeyHandler is optionally implemented by a DashboardRenderer to
+// handle keys beyond the framework's q/Esc/Ctrl+C.
+type DashboardKeyHandler interface {
+	// HandleKey receives the key's string form (e.g. "up", "s"). A non-empty
+	// action ends the dashboard with DashboardResult.Action set to it.
+	HandleKey(key string) (action string)
+}
+
 // DashboardConfig configures the generic dashboard.
 type DashboardConfig struct {
 	HelpText string // e.g., "q detach  ctrl+c stop"
+	// FullScreen runs the dashboard on the alternate screen buffer, leaving
+	// the terminal's scrollback untouched when it exits.
+	FullScreen bool
 }
 
 // DashboardResult is returned when the dashboard exits.
 type DashboardResult struct {
-	Err         error // display error only
-	Detached    bool  // user pressed q/Esc
-	Interrupted bool  // user pressed Ctrl+C
+	Err         error  // display error only
+	Detached    bool   // user pressed q/Esc
+	Interrupted bool   // user pressed Ctrl+C
+	Action      string // returned by the renderer's HandleKey
 }
 
 // ---------------------------------------------------------------------------
@@ -67,6 +79,7 @@ type dashboardModel struct {
 	finished    bool
 	detached    bool
 	interrupted bool
+	action      string
 	width       int
 
 	// High-water mark for stable frame height (pointer for View value receiver)
@@ -103,6 +116,13 @@ func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.finished = true
 			return m, tea.Quit
 		}
+		if h, ok := m.renderer.(DashboardKeyHandler); ok {
+			if action := h.HandleKey(msg.String()); action != "" {
+				m.action = action
+				m.finished = true
+				return m, tea.Quit
+			}
+		}
 		return m, nil
 
 	case tea.WindowSizeMsg:
@@ -163,10 +183,11 @@ func (m dashboardModel) View() string {
 
 // RunDashboard runs a generic channel-driven dashboard.
 // Events are read from ch and dispatched to renderer.ProcessEvent().
-// Returns when the channel is closed or the user presses q/Esc/Ctrl+C.
+// Returns when the channel is closed, the user presses q/Esc/Ctrl+C, or a
+// DashboardKeyHandler renderer returns an action.
 func RunDashboard(ios *iostreams.IOStreams, renderer DashboardRenderer, cfg DashboardConfig, ch <-chan any) DashboardResult {
 	model := newDashboardModel(ios, renderer, cfg, ch)
-	finalModel, err := RunProgram(ios, model)
+	finalModel, err := RunProgram(ios, model, WithAltScreen(cfg.FullScreen))
 	if err != nil {
 		return DashboardResult{Err: err}
 	}
@@ -182,6 +203,9 @@ func RunDashboard(ios *iostreams.IOStreams, renderer DashboardRenderer, cfg Dash
 	if m.interrupted {
 		return DashboardResult{Interrupted: true}
 	}
+	if m.action != "" {
+		return DashboardResult{Action: m.action}
+	}
 
 	return DashboardResult{}
 }
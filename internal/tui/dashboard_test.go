@@ -114,6 +114,46 @@ func TestDashboard_Update_OtherKeys(t *testing.T) {
 	assert.Nil(t, cmd)
 }
 
+// keyRenderer is a testRenderer that also handles keys: "x" is an action,
+// anything else is recorded and ignored.
+type keyRenderer struct {
+	testRenderer
+	keys []string
+}
+
+func (r *keyRenderer) HandleKey(key string) string {
+	r.keys = append(r.keys, key)
+	if key == "x" {
+		return "stop"
+	}
+	return ""
+}
+
+func TestDashboard_Update_KeyHandler(t *testing.T) {
+	ch := make(chan any, 1)
+	defer close(ch)
+
+	ios, _, _, _ := iostreams.Test()
+	renderer := &keyRenderer{}
+	m := newDashboardModel(ios, renderer, DashboardConfig{}, ch)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model := updated.(dashboardModel)
+	assert.False(t, model.finished)
+	assert.Nil(t, cmd)
+
+	updated, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	model = updated.(dashboardModel)
+	assert.True(t, model.finished)
+	assert.Equal(t, "stop", model.action)
+	require.NotNil(t, cmd)
+	assert.Equal(t, []string{"down", "x"}, renderer.keys)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	assert.True(t, updated.(dashboardModel).detached, "q still detaches before the handler sees it")
+	assert.Equal(t, []string{"down", "x"}, renderer.keys)
+}
+
 func TestDashboard_Update_Event(t *testing.T) {
 	ch := make(chan any, 1)
 	defer close(ch)
@@ -0,0 +1,250 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/schmitthub/clawker/internal/text"
+)
+
+// PickerItem is one choice in a fuzzy picker: a label plus detail columns
+// (e.g. status, uptime, image) aligned across items. The filter matches the
+// label and the columns.
+type PickerItem struct {
+	Label   string
+	Columns []string
+}
+
+// PickerConfig configures RunPicker.
+type PickerConfig struct {
+	Title string // e.g., "Select an agent to stop"
+	// MaxVisible caps the rows shown at once (default 10).
+	MaxVisible int
+}
+
+// PickerResult is returned when the picker exits.
+type PickerResult struct {
+	Err      error // display error only
+	Index    int   // index into the items passed to RunPicker; -1 when canceled
+	Canceled bool  // user pressed Esc or Ctrl+C
+}
+
+// FuzzyMatch reports whether every rune of pattern occurs in s in order,
+// ignoring case, and scores the match: consecutive runs and matches at word
+// starts score higher. An empty pattern matches everything with score 0.
+func FuzzyMatch(pattern, s string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	if len(p) == 0 {
+		return 0, true
+	}
+	score, pi, prevMatch := 0, 0, -2
+	prev := ' '
+	for i, r := range []rune(strings.ToLower(s)) {
+		if pi < len(p) && r == p[pi] {
+			score++
+			if prevMatch == i-1 {
+				score += 2
+			}
+			if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+				score += 3
+			}
+			prevMatch = i
+			pi++
+		}
+		prev = r
+	}
+	return score, pi == len(p)
+}
+
+// ---------------------------------------------------------------------------
+// BubbleTea model
+// ---------------------------------------------------------------------------
+
+type pickerModel struct {
+	cs    *iostreams.ColorScheme
+	cfg   PickerConfig
+	items []PickerItem
+	// widths holds each column's width across all items (label first), so
+	// rows stay aligned while filtering.
+	widths []int
+
+	query    string
+	matches  []int // item indices matching query, best first
+	cursor   int   // position in matches
+	offset   int   // first visible position in matches
+	chosen   int
+	canceled bool
+
+	// High-water mark for stable frame height (pointer for View value receiver)
+	highWater *int
+}
+
+func newPickerModel(cs *iostreams.ColorScheme, cfg PickerConfig, items []PickerItem) pickerModel {
+	if cfg.MaxVisible <= 0 {
+		cfg.MaxVisible = 10
+	}
+	m := pickerModel{
+		cs:        cs,
+		cfg:       cfg,
+		items:     items,
+		chosen:    -1,
+		highWater: new(int),
+	}
+	for _, it := range items {
+		cells := append([]string{it.Label}, it.Columns...)
+		for i, c := range cells {
+			if i == len(m.widths) {
+				m.widths = append(m.widths, 0)
+			}
+			m.widths[i] = max(m.widths[i], text.CountVisibleWidth(c))
+		}
+	}
+	m.filter()
+	return m
+}
+
+// filter recomputes matches for the current query, best score first and
+// input order among equals, and resets the cursor.
+func (m *pickerModel) filter() {
+	type scored struct{ idx, score int }
+	var hits []scored
+	for i, it := range m.items {
+		score, ok := FuzzyMatch(m.query, it.Label)
+		if !ok {
+			// Columns match too, ranked below any label match.
+			if _, ok = FuzzyMatch(m.query, strings.Join(it.Columns, " ")); !ok {
+				continue
+			}
+			score = -1
+		}
+		hits = append(hits, scored{i, score})
+	}
+	for i := 1; i < len(hits); i++ {
+		for j := i; j > 0 && hits[j].score > hits[j-1].score; j-- {
+			hits[j], hits[j-1] = hits[j-1], hits[j]
+		}
+	}
+	m.matches = m.matches[:0]
+	for _, h := range hits {
+		m.matches = append(m.matches, h.idx)
+	}
+	m.cursor, m.offset = 0, 0
+}
+
+func (m *pickerModel) move(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.cursor = (m.cursor + delta + len(m.matches)) % len(m.matches)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.cfg.MaxVisible {
+		m.offset = m.cursor - m.cfg.MaxVisible + 1
+	}
+}
+
+func (m pickerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch key.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		m.canceled = true
+		return m, tea.Quit
+	case tea.KeyEnter:
+		if len(m.matches) == 0 {
+			return m, nil
+		}
+		m.chosen = m.matches[m.cursor]
+		return m, tea.Quit
+	case tea.KeyUp, tea.KeyCtrlP, tea.KeyShiftTab:
+		m.move(-1)
+	case tea.KeyDown, tea.KeyCtrlN, tea.KeyTab:
+		m.move(1)
+	case tea.KeyBackspace:
+		if r := []rune(m.query); len(r) > 0 {
+			m.query = string(r[:len(r)-1])
+			m.filter()
+		}
+	case tea.KeyCtrlU:
+		m.query = ""
+		m.filter()
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(key.Runes)
+		m.filter()
+	}
+	return m, nil
+}
+
+func (m pickerModel) View() string {
+	cs := m.cs
+	var buf strings.Builder
+	lines := 0
+	writeLine := func(s string) {
+		buf.WriteString(s)
+		buf.WriteByte('\n')
+		lines++
+	}
+
+	if m.cfg.Title != "" {
+		writeLine(cs.Bold(m.cfg.Title))
+	}
+	writeLine(cs.Primary("> ") + m.query + cs.Muted("█"))
+
+	if len(m.matches) == 0 {
+		writeLine(cs.Muted("  No matches"))
+	}
+	end := min(m.offset+m.cfg.MaxVisible, len(m.matches))
+	for pos := m.offset; pos < end; pos++ {
+		it := m.items[m.matches[pos]]
+		cells := append([]string{it.Label}, it.Columns...)
+		for i := range cells {
+			if i < len(cells)-1 {
+				cells[i] = text.PadRight(cells[i], m.widths[i])
+			}
+		}
+		row := strings.Join(cells, "  ")
+		if pos == m.cursor {
+			writeLine(cs.Primary("▸ ") + cs.Bold(row))
+		} else {
+			writeLine("  " + row)
+		}
+	}
+	writeLine(cs.Muted("  ↑/↓ move  enter select  esc cancel  type to filter"))
+
+	// Pad to high-water mark
+	*m.highWater = max(*m.highWater, lines)
+	for range *m.highWater - lines {
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+// ---------------------------------------------------------------------------
+// Entry point
+// ---------------------------------------------------------------------------
+
+// RunPicker shows items in an inline fuzzy picker and returns the one the
+// user selects. Typing filters the items; the caller decides beforehand
+// whether prompting is allowed (IOStreams.CanPrompt).
+func RunPicker(ios *iostreams.IOStreams, cfg PickerConfig, items []PickerItem) PickerResult {
+	model := newPickerModel(ios.ColorScheme(), cfg, items)
+	finalModel, err := RunProgram(ios, model)
+	if err != nil {
+		return PickerResult{Err: err, Index: -1}
+	}
+	m, ok := finalModel.(pickerModel)
+	if !ok || m.canceled {
+		return PickerResult{Index: -1, Canceled: true}
+	}
+	return PickerResult{Index: m.chosen}
+}
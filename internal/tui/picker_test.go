@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/internal/iostreams"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		wantOK     bool
+	}{
+		{"", "anything", true},
+		{"dev", "dev", true},
+		{"DV", "dev", true},
+		{"cmd", "clawker.myapp.dev", true},
+		{"vd", "dev", false},
+		{"devx", "dev", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.s, func(t *testing.T) {
+			_, ok := FuzzyMatch(tt.pattern, tt.s)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestFuzzyMatch_Scoring(t *testing.T) {
+	contiguous, _ := FuzzyMatch("web", "web")
+	scattered, _ := FuzzyMatch("web", "wide-label")
+	assert.Greater(t, contiguous, scattered, "consecutive runes score higher")
+
+	wordStart, _ := FuzzyMatch("a", "x-a")
+	midWord, _ := FuzzyMatch("a", "xa")
+	assert.Greater(t, wordStart, midWord, "word starts score higher")
+}
+
+func testPickerModel() pickerModel {
+	ios, _, _, _ := iostreams.Test()
+	return newPickerModel(ios.ColorScheme(), PickerConfig{Title: "Pick"}, []PickerItem{
+		{Label: "dev", Columns: []string{"running", "Up 2 hours", "node:20"}},
+		{Label: "web", Columns: []string{"running", "Up 5 minutes", "node:20"}},
+		{Label: "worker", Columns: []string{"running", "Up 1 day", "python:3"}},
+	})
+}
+
+func pickerKeys(m pickerModel, keys ...tea.KeyMsg) pickerModel {
+	for _, k := range keys {
+		next, _ := m.Update(k)
+		m = next.(pickerModel)
+	}
+	return m
+}
+
+func typed(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestPickerModel_Filter(t *testing.T) {
+	m := pickerKeys(testPickerModel(), typed("w"))
+	assert.Equal(t, []int{1, 2}, m.matches)
+
+	m = pickerKeys(m, typed("k"))
+	assert.Equal(t, []int{2}, m.matches)
+
+	m = pickerKeys(m, tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Equal(t, "w", m.query)
+	assert.Equal(t, []int{1, 2}, m.matches)
+
+	m = pickerKeys(m, tea.KeyMsg{Type: tea.KeyCtrlU})
+	assert.Equal(t, []int{0, 1, 2}, m.matches)
+}
+
+func TestPickerModel_FilterColumns(t *testing.T) {
+	m := pickerKeys(testPickerModel(), typed("python"))
+	assert.Equal(t, []int{2}, m.matches, "detail columns are searched too")
+}
+
+func TestPickerModel_Select(t *testing.T) {
+	m := pickerKeys(testPickerModel(),
+		tea.KeyMsg{Type: tea.KeyDown},
+		tea.KeyMsg{Type: tea.KeyDown},
+	)
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, 2, next.(pickerModel).chosen)
+}
+
+func TestPickerModel_SelectFiltered(t *testing.T) {
+	m := pickerKeys(testPickerModel(), typed("wor"), tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, 2, m.chosen)
+}
+
+func TestPickerModel_Wraps(t *testing.T) {
+	m := pickerKeys(testPickerModel(), tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, 2, m.cursor)
+
+	m = pickerKeys(m, tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 0, m.cursor)
+}
+
+func TestPickerModel_Cancel(t *testing.T) {
+	m := pickerKeys(testPickerModel(), tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, m.canceled)
+	assert.Equal(t, -1, m.chosen)
+}
+
+func TestPickerModel_NoMatches(t *testing.T) {
+	m := pickerKeys(testPickerModel(), typed("zzz"))
+	assert.Empty(t, m.matches)
+	assert.Contains(t, m.View(), "No matches")
+
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd, "enter with no matches does nothing")
+	assert.Equal(t, -1, next.(pickerModel).chosen)
+}
+
+func TestPickerModel_View(t *testing.T) {
+	view := testPickerModel().View()
+	assert.Contains(t, view, "Pick")
+	assert.Contains(t, view, "▸ dev     running  Up 2 hours    node:20")
+	assert.Contains(t, view, "  worker  running  Up 1 day      python:3")
+}
@@ -36,6 +36,7 @@ type ProgressStep struct {
 	LogLine string
 	Cached  bool
 	Error   string
+	Group   string // explicit group/stage; overrides ParseGroup when set
 }
 
 // ProgressDisplayConfig configures the progress display.
@@ -120,6 +121,14 @@ func (cfg *ProgressDisplayConfig) parseGroup(name string) string {
 	return cfg.ParseGroup(name)
 }
 
+// stepGroup resolves a step's group: the explicit Group wins over ParseGroup.
+func (cfg *ProgressDisplayConfig) stepGroup(step ProgressStep) string {
+	if step.Group != "" {
+		return step.Group
+	}
+	return cfg.parseGroup(step.Name)
+}
+
 func (cfg *ProgressDisplayConfig) formatDuration(d time.Duration) string {
 	if cfg.FormatDuration == nil {
 		return defaultFormatDuration(d)
@@ -170,18 +179,22 @@ func defaultFormatDuration(d time.Duration) string {
 // The mode parameter can be "auto", "plain", or "tty".
 // Channel closure signals completion — the caller closes ch when done.
 func RunProgress(ios *iostreams.IOStreams, mode string, cfg ProgressDisplayConfig, ch <-chan ProgressStep) ProgressResult {
-	ttyMode := ios.IsStderrTTY()
+	if progressTTYMode(ios, mode) {
+		return runProgressTTY(ios, cfg, ch)
+	}
+	return runProgressPlain(ios, cfg, ch)
+}
+
+// progressTTYMode resolves a progress mode ("auto", "plain", "tty") against
+// the terminal: auto renders the TTY display only when stderr is a terminal.
+func progressTTYMode(ios *iostreams.IOStreams, mode string) bool {
 	switch mode {
 	case "tty":
-		ttyMode = true
+		return true
 	case "plain":
-		ttyMode = false
-	}
-
-	if ttyMode {
-		return runProgressTTY(ios, cfg, ch)
+		return false
 	}
-	return runProgressPlain(ios, cfg, ch)
+	return ios.IsStderrTTY()
 }
 
 // ---------------------------------------------------------------------------
@@ -501,13 +514,13 @@ func (m *progressModel) processEvent(step ProgressStep) {
 			cached:    step.Cached,
 			errMsg:    step.Error,
 			startTime: time.Now(),
-			group:     m.cfg.parseGroup(step.Name),
+			group:     m.cfg.stepGroup(step),
 		})
 	} else {
 		s := m.steps[idx]
 		if step.Name != "" {
 			s.name = step.Name
-			s.group = m.cfg.parseGroup(step.Name)
+			s.group = m.cfg.stepGroup(step)
 		}
 		s.status = step.Status
 		s.cached = step.Cached
@@ -915,78 +928,112 @@ func handleHookResult(hr HookResult) ProgressResult {
 // ---------------------------------------------------------------------------
 
 func runProgressPlain(ios *iostreams.IOStreams, cfg ProgressDisplayConfig, eventCh <-chan ProgressStep) ProgressResult {
-	cs := ios.ColorScheme()
-	width := ios.TerminalWidth() - 20
-	if width < 40 {
-		width = 40
+	p := newPlainProgress(ios, cfg)
+	for step := range eventCh {
+		p.handle(step)
+	}
+	return p.finish()
+}
+
+// plainProgress is the plain-mode renderer: one line per status change,
+// written synchronously as each event is handled. runProgressPlain drives it
+// from a channel; StepRunner drives it directly so plain output interleaves
+// in order with whatever else the caller writes.
+type plainProgress struct {
+	ios          *iostreams.IOStreams
+	cs           *iostreams.ColorScheme
+	cfg          ProgressDisplayConfig
+	width        int
+	startTime    time.Time
+	steps        map[string]*progressStep
+	orderedSteps []*progressStep
+}
+
+// newPlainProgress prints the header and returns a renderer ready for events.
+func newPlainProgress(ios *iostreams.IOStreams, cfg ProgressDisplayConfig) *plainProgress {
+	p := &plainProgress{
+		ios:       ios,
+		cs:        ios.ColorScheme(),
+		cfg:       cfg,
+		width:     ios.TerminalWidth() - 20,
+		startTime: time.Now(),
+		steps:     make(map[string]*progressStep),
+	}
+	if p.width < 40 {
+		p.width = 40
 	}
-	startTime := time.Now()
 
 	// Header.
-	fmt.Fprintf(ios.ErrOut, "%s %s (%s)\n", cs.Primary("━━"), cfg.Title, cfg.Subtitle)
-
-	steps := make(map[string]*progressStep)
-	var orderedSteps []*progressStep
+	if cfg.Subtitle == "" {
+		fmt.Fprintf(ios.ErrOut, "%s %s\n", p.cs.Primary("━━"), cfg.Title)
+	} else {
+		fmt.Fprintf(ios.ErrOut, "%s %s (%s)\n", p.cs.Primary("━━"), cfg.Title, cfg.Subtitle)
+	}
+	return p
+}
 
-	for step := range eventCh {
-		if step.ID == "" {
-			continue
-		}
+func (p *plainProgress) handle(step ProgressStep) {
+	if step.ID == "" {
+		return
+	}
 
-		// Buffer log lines for known steps (needed for error context display).
-		if step.LogLine != "" {
-			if s, exists := steps[step.ID]; exists {
-				if s.logBuf == nil {
-					s.logBuf = newRingBuffer(cfg.logLines())
-				}
-				s.logBuf.Push(step.LogLine)
-			}
-			// Log-only events (no Name, no Status change) stop here.
-			if step.Name == "" {
-				continue
+	// Buffer log lines for known steps (needed for error context display).
+	if step.LogLine != "" {
+		if s, exists := p.steps[step.ID]; exists {
+			if s.logBuf == nil {
+				s.logBuf = newRingBuffer(p.cfg.logLines())
 			}
+			s.logBuf.Push(step.LogLine)
 		}
+		// Log-only events (no Name, no Status change) stop here.
+		if step.Name == "" {
+			return
+		}
+	}
 
-		s, exists := steps[step.ID]
-		if !exists {
-			s = &progressStep{
-				id:        step.ID,
-				name:      step.Name,
-				status:    step.Status,
-				cached:    step.Cached,
-				errMsg:    step.Error,
-				startTime: time.Now(),
-			}
-			steps[step.ID] = s
-			orderedSteps = append(orderedSteps, s)
-			if step.Status != StepPending {
-				renderPlainProgressStepLine(ios, cs, &cfg, s, width)
-			}
-		} else {
-			prevStatus := s.status
-			if step.Name != "" {
-				s.name = step.Name
-			}
-			s.status = step.Status
-			s.cached = step.Cached
-			if step.Error != "" {
-				s.errMsg = step.Error
-			}
-			if step.Status == StepComplete || step.Status == StepCached || step.Status == StepError {
-				s.endTime = time.Now()
-			}
-			if step.Status != prevStatus {
-				renderPlainProgressStepLine(ios, cs, &cfg, s, width)
-			}
+	s, exists := p.steps[step.ID]
+	if !exists {
+		s = &progressStep{
+			id:        step.ID,
+			name:      step.Name,
+			status:    step.Status,
+			cached:    step.Cached,
+			errMsg:    step.Error,
+			startTime: time.Now(),
+			group:     step.Group, // explicit groups only: parsed stages are already in the name
 		}
+		p.steps[step.ID] = s
+		p.orderedSteps = append(p.orderedSteps, s)
+		if step.Status != StepPending {
+			renderPlainProgressStepLine(p.ios, p.cs, &p.cfg, s, p.width)
+		}
+		return
 	}
 
-	// Fire lifecycle hook.
-	if result := handleHookResult(cfg.fireHook("progress", "before_complete")); result.Err != nil {
+	prevStatus := s.status
+	if step.Name != "" {
+		s.name = step.Name
+	}
+	s.status = step.Status
+	s.cached = step.Cached
+	if step.Error != "" {
+		s.errMsg = step.Error
+	}
+	if step.Status == StepComplete || step.Status == StepCached || step.Status == StepError {
+		s.endTime = time.Now()
+	}
+	if step.Status != prevStatus {
+		renderPlainProgressStepLine(p.ios, p.cs, &p.cfg, s, p.width)
+	}
+}
+
+// finish fires the before_complete hook and prints the summary.
+func (p *plainProgress) finish() ProgressResult {
+	if result := handleHookResult(p.cfg.fireHook("progress", "before_complete")); result.Err != nil {
 		return result
 	}
 
-	renderProgressSummary(ios, &cfg, orderedSteps, startTime)
+	renderProgressSummary(p.ios, &p.cfg, p.orderedSteps, p.startTime)
 	return ProgressResult{}
 }
 
@@ -995,6 +1042,9 @@ func renderPlainProgressStepLine(ios *iostreams.IOStreams, cs *iostreams.ColorSc
 		return
 	}
 	name := cfg.cleanName(step.name)
+	if step.group != "" && step.group != name {
+		name = step.group + " › " + name
+	}
 	if width > 0 {
 		runes := []rune(name)
 		if len(runes) > width {
@@ -1010,7 +1060,11 @@ func renderPlainProgressStepLine(ios *iostreams.IOStreams, cs *iostreams.ColorSc
 	case StepCached:
 		fmt.Fprintf(ios.ErrOut, "%s   %s (cached)\n", cs.Success("[ok]"), name)
 	case StepError:
-		fmt.Fprintf(ios.ErrOut, "%s %s: %s\n", cs.Error("[fail]"), name, step.errMsg)
+		if step.errMsg == "" {
+			fmt.Fprintf(ios.ErrOut, "%s %s\n", cs.Error("[fail]"), name)
+		} else {
+			fmt.Fprintf(ios.ErrOut, "%s %s: %s\n", cs.Error("[fail]"), name, step.errMsg)
+		}
 		// Show buffered log lines below the error for diagnostic context.
 		if step.logBuf != nil {
 			for _, line := range step.logBuf.Lines() {
@@ -1083,7 +1137,10 @@ func renderProgressSummary(ios *iostreams.IOStreams, cfg *ProgressDisplayConfig,
 		}
 	}
 
-	summary := fmt.Sprintf("%s %s", cfg.completionVerb(), cfg.Subtitle)
+	summary := cfg.completionVerb()
+	if cfg.Subtitle != "" {
+		summary += " " + cfg.Subtitle
+	}
 	if cachedCount > 0 {
 		summary += fmt.Sprintf(" (%d/%d cached)", cachedCount, visibleCount)
 	}
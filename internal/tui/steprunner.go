@@ -0,0 +1,345 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/schmitthub/clawker/internal/iostreams"
+)
+
+// errStepRunnerStopped aborts the before_complete hook so a stopped runner's
+// TTY display skips the summary. Never returned to callers.
+var errStepRunnerStopped = errors.New("step runner stopped")
+
+// StepRunner drives a progress display imperatively: instead of assembling a
+// ProgressStep channel, the caller starts steps and reports on them as work
+// happens. It is the shared front-end over RunProgress for image builds
+// and monitor stack bring-ups.
+//
+//	r := t.NewStepRunner(mode, cfg)
+//	err := r.Run("network", "Ensure network", func(s *RunningStep) error { ... })
+//	if waitErr := r.Wait(); err == nil {
+//		err = waitErr
+//	}
+//
+// Steps are top-level lines; Group starts substeps nested under a stage
+// heading. Log lines feed the per-step ring buffer, shown while the step runs
+// (TTY) and under it when it fails (both modes). Producers that already emit
+// ProgressStep events (the whail build pipeline) use Send directly.
+//
+// TTY mode renders on a background goroutine. Plain mode renders on the
+// calling goroutine, so its lines interleave in order with anything else the
+// caller writes to the same stream.
+//
+// Resume makes a runner resumable: Run reports a resumed step ID as cached
+// without calling its function, so an operation retried after a failure shows
+// what already finished without redoing it. Completed returns the IDs to
+// persist for that retry.
+//
+// Every method is safe for concurrent use. Exactly one of Wait or Stop must
+// be called on every path; events after either are discarded.
+type StepRunner struct {
+	mu      sync.Mutex
+	plain   *plainProgress    // plain mode renderer; nil in TTY mode
+	ch      chan ProgressStep // TTY mode event channel
+	done    chan struct{}     // closed once the display has finished
+	result  ProgressResult
+	closed  bool
+	stopped atomic.Bool
+
+	resumed   map[string]bool
+	completed []string
+}
+
+// NewStepRunner starts a progress display and returns the runner feeding it.
+// mode is "auto", "plain", or "tty", as for RunProgress.
+func NewStepRunner(ios *iostreams.IOStreams, mode string, cfg ProgressDisplayConfig) *StepRunner {
+	r := &StepRunner{
+		done:    make(chan struct{}),
+		resumed: make(map[string]bool),
+	}
+	hook := cfg.OnLifecycle
+	cfg.OnLifecycle = func(component, event string) HookResult {
+		if r.stopped.Load() {
+			return HookResult{Err: errStepRunnerStopped}
+		}
+		if hook == nil {
+			return HookResult{Continue: true}
+		}
+		return hook(component, event)
+	}
+
+	if !progressTTYMode(ios, mode) {
+		r.plain = newPlainProgress(ios, cfg)
+		return r
+	}
+	r.ch = make(chan ProgressStep, 64)
+	go func() {
+		r.result = runProgressTTY(ios, cfg, r.ch)
+		close(r.done)
+	}()
+	return r
+}
+
+// Resume marks step IDs as finished by an earlier attempt. Run and
+// StepGroup.Run report them cached instead of running them.
+func (r *StepRunner) Resume(ids ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range ids {
+		r.resumed[id] = true
+	}
+}
+
+// Completed returns the IDs of steps that finished (done, cached, or resumed),
+// in completion order.
+func (r *StepRunner) Completed() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.completed...)
+}
+
+// Send forwards a raw event to the display. Events sent after Wait or Stop
+// are discarded, as are events the TTY display can no longer take (the user
+// interrupted it).
+func (r *StepRunner) Send(step ProgressStep) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sendLocked(step)
+}
+
+func (r *StepRunner) sendLocked(step ProgressStep) {
+	if r.closed {
+		return
+	}
+	if r.plain != nil {
+		r.plain.handle(step)
+		return
+	}
+	select {
+	case <-r.done:
+	case r.ch <- step:
+	}
+}
+
+// Start reports a top-level step as running.
+func (r *StepRunner) Start(id, name string) *RunningStep {
+	return r.start("", id, name)
+}
+
+// Run starts a top-level step, calls fn, and settles the step from its
+// result: done on nil, failed on error (which is returned). fn may settle the
+// step itself, e.g. with Cached. A resumed step is reported cached and fn is
+// not called.
+func (r *StepRunner) Run(id, name string, fn func(*RunningStep) error) error {
+	return r.run("", id, name, fn)
+}
+
+// Group returns a handle for starting substeps under the named stage. Step
+// IDs are shared across the whole runner, not scoped to the group.
+func (r *StepRunner) Group(name string) *StepGroup {
+	return &StepGroup{r: r, name: name}
+}
+
+// Wait ends the display and prints the summary. It returns the display's own
+// error (an interrupt or aborting lifecycle hook), never a step's.
+func (r *StepRunner) Wait() error {
+	return r.finish(false)
+}
+
+// Stop ends the display without a summary, for callers abandoning the
+// operation or handing the terminal to something else.
+func (r *StepRunner) Stop() {
+	_ = r.finish(true)
+}
+
+func (r *StepRunner) finish(stop bool) error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		<-r.done
+		return r.displayErr()
+	}
+	r.closed = true
+	if stop {
+		r.stopped.Store(true)
+	}
+	if r.plain != nil {
+		if !stop {
+			r.result = r.plain.finish()
+		}
+		close(r.done)
+		r.mu.Unlock()
+		return r.displayErr()
+	}
+	close(r.ch)
+	r.mu.Unlock()
+
+	<-r.done
+	return r.displayErr()
+}
+
+func (r *StepRunner) displayErr() error {
+	if errors.Is(r.result.Err, errStepRunnerStopped) {
+		return nil
+	}
+	return r.result.Err
+}
+
+func (r *StepRunner) start(group, id, name string) *RunningStep {
+	s := &RunningStep{r: r, id: id, name: name, group: group}
+	r.Send(ProgressStep{ID: id, Name: name, Group: group, Status: StepRunning})
+	return s
+}
+
+func (r *StepRunner) run(group, id, name string, fn func(*RunningStep) error) error {
+	r.mu.Lock()
+	if r.resumed[id] {
+		r.sendLocked(ProgressStep{ID: id, Name: name, Group: group, Status: StepCached, Cached: true})
+		r.completed = append(r.completed, id)
+		r.mu.Unlock()
+		return nil
+	}
+	r.mu.Unlock()
+
+	s := r.start(group, id, name)
+	if err := fn(s); err != nil {
+		s.Fail(err)
+		return err
+	}
+	s.Done()
+	return nil
+}
+
+// StepGroup starts substeps nested under a stage heading.
+type StepGroup struct {
+	r    *StepRunner
+	name string
+}
+
+// Start reports a substep as running.
+func (g *StepGroup) Start(id, name string) *RunningStep {
+	return g.r.start(g.name, id, name)
+}
+
+// Run is StepRunner.Run for a substep.
+func (g *StepGroup) Run(id, name string, fn func(*RunningStep) error) error {
+	return g.r.run(g.name, id, name, fn)
+}
+
+// RunningStep is a started step. The first of Done, Cached, or Fail settles
+// it; later status calls and log lines are ignored.
+type RunningStep struct {
+	r       *StepRunner
+	id      string
+	name    string
+	group   string
+	settled bool // guarded by r.mu
+}
+
+// Log appends a line to the step's log buffer.
+func (s *RunningStep) Log(line string) {
+	s.r.mu.Lock()
+	defer s.r.mu.Unlock()
+	if s.settled {
+		return
+	}
+	s.r.sendLocked(ProgressStep{ID: s.id, LogLine: line})
+}
+
+// Logf is Log with formatting.
+func (s *RunningStep) Logf(format string, args ...any) {
+	s.Log(fmt.Sprintf(format, args...))
+}
+
+// SetName retitles the step. The next status line shows the new name, so a
+// step can start as "Pulling image..." and complete as "Image pulled".
+func (s *RunningStep) SetName(name string) {
+	s.r.mu.Lock()
+	defer s.r.mu.Unlock()
+	s.name = name
+}
+
+// Done settles the step as completed.
+func (s *RunningStep) Done() {
+	s.settle(ProgressStep{Status: StepComplete})
+}
+
+// Cached settles the step as skipped because its result already existed.
+func (s *RunningStep) Cached() {
+	s.settle(ProgressStep{Status: StepCached, Cached: true})
+}
+
+// Fail settles the step as failed. A nil err marks the failure without a
+// message.
+func (s *RunningStep) Fail(err error) {
+	step := ProgressStep{Status: StepError}
+	if err != nil {
+		step.Error = err.Error()
+	}
+	s.settle(step)
+}
+
+func (s *RunningStep) settle(step ProgressStep) {
+	s.r.mu.Lock()
+	defer s.r.mu.Unlock()
+	if s.settled {
+		return
+	}
+	s.settled = true
+	step.ID, step.Name, step.Group = s.id, s.name, s.group
+	s.r.sendLocked(step)
+	if step.Status != StepError {
+		s.r.completed = append(s.r.completed, s.id)
+	}
+}
+
+// LogWriter returns a writer that splits what is written to it into lines
+// and logs each one, for piping a subprocess's output into the step. Close
+// flushes a trailing partial line.
+func (s *RunningStep) LogWriter() io.WriteCloser {
+	return &stepLogWriter{step: s}
+}
+
+type stepLogWriter struct {
+	mu   sync.Mutex
+	step *RunningStep
+	buf  bytes.Buffer
+}
+
+func (w *stepLogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err != nil {
+			// Partial line: keep it for the next write.
+			w.buf.WriteString(line)
+			return len(p), nil
+		}
+		w.log(line)
+	}
+}
+
+func (w *stepLogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.buf.Len() > 0 {
+		w.log(w.buf.String())
+		w.buf.Reset()
+	}
+	return nil
+}
+
+func (w *stepLogWriter) log(line string) {
+	line = strings.TrimRight(line, "\r\n")
+	if line != "" {
+		w.step.Log(line)
+	}
+}
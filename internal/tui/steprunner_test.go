@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/schmitthub/clawker/internal/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepRunner_PlainRunInOrder(t *testing.T) {
+	tio, _, _, errOut := iostreams.Test()
+	r := NewStepRunner(tio, "plain", ProgressDisplayConfig{Title: "Starting stack", CompletionVerb: "Started"})
+
+	require.NoError(t, r.Run("network", "Ensure network", func(*RunningStep) error { return nil }))
+	fmt.Fprintln(tio.ErrOut, "caller-line")
+	require.NoError(t, r.Run("up", "Start services", func(*RunningStep) error { return nil }))
+	require.NoError(t, r.Wait())
+
+	out := errOut.String()
+	assert.Contains(t, out, "━━ Starting stack\n")
+	iNet := strings.Index(out, "[ok]   Ensure network")
+	iCaller := strings.Index(out, "caller-line")
+	iUp := strings.Index(out, "[run]  Start services")
+	require.True(t, iNet >= 0 && iCaller >= 0 && iUp >= 0, "missing lines:\n%s", out)
+	assert.Less(t, iNet, iCaller, "plain mode must render synchronously")
+	assert.Less(t, iCaller, iUp)
+	assert.Contains(t, out, "Started")
+	assert.Equal(t, []string{"network", "up"}, r.Completed())
+}
+
+func TestStepRunner_FailShowsLogs(t *testing.T) {
+	tio, _, _, errOut := iostreams.Test()
+	r := NewStepRunner(tio, "plain", ProgressDisplayConfig{Title: "Starting stack"})
+
+	boom := errors.New("exit status 1")
+	err := r.Run("up", "Start services", func(s *RunningStep) error {
+		w := s.LogWriter()
+		fmt.Fprint(w, "pulling prometheus\nport 9090 already ")
+		fmt.Fprint(w, "allocated")
+		require.NoError(t, w.Close())
+		return boom
+	})
+	require.ErrorIs(t, err, boom)
+	require.NoError(t, r.Wait())
+
+	out := errOut.String()
+	assert.Contains(t, out, "[fail] Start services: exit status 1")
+	assert.Contains(t, out, "pulling prometheus")
+	assert.Contains(t, out, "port 9090 already allocated")
+	assert.Contains(t, out, "Starting stack failed")
+	assert.Empty(t, r.Completed())
+}
+
+func TestStepRunner_ResumeSkipsCompleted(t *testing.T) {
+	tio, _, _, errOut := iostreams.Test()
+	r := NewStepRunner(tio, "plain", ProgressDisplayConfig{Title: "Init", Subtitle: "agent"})
+	r.Resume("config")
+
+	called := false
+	require.NoError(t, r.Run("config", "Seed config", func(*RunningStep) error {
+		called = true
+		return nil
+	}))
+	require.NoError(t, r.Run("git", "Configure git", func(s *RunningStep) error {
+		s.Cached()
+		return nil
+	}))
+	require.NoError(t, r.Wait())
+
+	assert.False(t, called, "resumed step must not run")
+	out := errOut.String()
+	assert.Contains(t, out, "Seed config (cached)")
+	assert.Contains(t, out, "Configure git (cached)")
+	assert.Contains(t, out, "(2/2 cached)")
+	assert.Equal(t, []string{"config", "git"}, r.Completed())
+}
+
+func TestStepRunner_GroupAndRename(t *testing.T) {
+	tio, _, _, errOut := iostreams.Test()
+	r := NewStepRunner(tio, "plain", ProgressDisplayConfig{Title: "Init"})
+
+	s := r.Group("Agent init").Start("git", "Configuring git...")
+	s.SetName("Git configured")
+	s.Done()
+	s.Fail(errors.New("ignored after settle"))
+	require.NoError(t, r.Wait())
+
+	out := errOut.String()
+	assert.Contains(t, out, "[run]  Agent init › Configuring git...")
+	assert.Contains(t, out, "[ok]   Agent init › Git configured")
+	assert.NotContains(t, out, "ignored after settle")
+}
+
+func TestStepRunner_StopSkipsSummaryAndMutes(t *testing.T) {
+	tio, _, _, errOut := iostreams.Test()
+	r := NewStepRunner(tio, "plain", ProgressDisplayConfig{Title: "Init", CompletionVerb: "Initialized"})
+
+	s := r.Start("config", "Seed config")
+	r.Stop()
+	before := errOut.String()
+
+	s.Done()
+	r.Start("late", "Late step")
+	r.Send(ProgressStep{ID: "raw", Name: "Raw", Status: StepRunning})
+	assert.NoError(t, r.Wait(), "Wait after Stop is a no-op")
+
+	assert.Equal(t, before, errOut.String(), "events after Stop leaked")
+	assert.NotContains(t, before, "Initialized")
+}
+
+// TestStepRunner_ConsumerScenarios drives the runner the way each consumer
+// does: build streams raw pipeline events through Send and monitor
+// bring-ups capture compose output as step logs.
+func TestStepRunner_ConsumerScenarios(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   ProgressDisplayConfig
+		drive func(r *StepRunner)
+		want  []string
+	}{
+		{
+			name: "build",
+			cfg:  ProgressDisplayConfig{Title: "Building demo", Subtitle: "demo:latest", CompletionVerb: "Built"},
+			drive: func(r *StepRunner) {
+				r.Send(ProgressStep{ID: "s1", Name: "FROM alpine", Status: StepCached, Cached: true})
+				r.Send(ProgressStep{ID: "s2", Name: "RUN apk add git", Status: StepRunning})
+				r.Send(ProgressStep{ID: "s2", LogLine: "fetching index"})
+				r.Send(ProgressStep{ID: "s2", Name: "RUN apk add git", Status: StepComplete})
+			},
+			want: []string{"FROM alpine (cached)", "[ok]   RUN apk add git", "Built demo:latest (1/2 cached)"},
+		},
+		{
+			name: "monitor",
+			cfg:  ProgressDisplayConfig{Title: "Starting monitoring stack", CompletionVerb: "Started"},
+			drive: func(r *StepRunner) {
+				_ = r.Run("network", "Ensure network clawker-net", func(*RunningStep) error { return nil })
+				_ = r.Run("compose-up", "Start services", func(s *RunningStep) error {
+					fmt.Fprintln(s.LogWriter(), "Container prometheus Started")
+					return nil
+				})
+			},
+			want: []string{"[ok]   Ensure network clawker-net", "[ok]   Start services", "Started"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tio, _, _, errOut := iostreams.Test()
+			r := NewStepRunner(tio, "plain", tt.cfg)
+			tt.drive(r)
+			require.NoError(t, r.Wait())
+			for _, want := range tt.want {
+				assert.Contains(t, errOut.String(), want)
+			}
+		})
+	}
+}
@@ -39,11 +39,26 @@ func (t *TUI) RunProgress(mode string, cfg ProgressDisplayConfig, ch <-chan Prog
 	return RunProgress(t.ios, mode, cfg, ch)
 }
 
+// NewStepRunner starts a StepRunner on the TUI's streams. Registered hooks are
+// injected into cfg.OnLifecycle if the caller has not already set one.
+func (t *TUI) NewStepRunner(mode string, cfg ProgressDisplayConfig) *StepRunner {
+	if len(t.hooks) > 0 && cfg.OnLifecycle == nil {
+		cfg.OnLifecycle = t.composedHook()
+	}
+	return NewStepRunner(t.ios, mode, cfg)
+}
+
 // IOStreams returns the underlying IOStreams for callers that need direct access.
 func (t *TUI) IOStreams() *iostreams.IOStreams {
 	return t.ios
 }
 
+// RunPicker shows an inline fuzzy picker over items on the TUI's streams,
+// delegating to the package-level RunPicker.
+func (t *TUI) RunPicker(cfg PickerConfig, items []PickerItem) PickerResult {
+	return RunPicker(t.ios, cfg, items)
+}
+
 // RunWizard runs a multi-step wizard using the given steps.
 // Each step wraps a pre-constructed WizardPage — the wizard is a generic
 // page sequencer that delegates rendering and input to each page model.
@@ -0,0 +1,201 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/moby/moby/api/types/mount"
+
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+// readOnlyTmpfsOptions are the mount options for the scratch tmpfs mounts
+// provisioned under a read-only root: world-writable with the sticky bit,
+// matching a stock /tmp.
+const readOnlyTmpfsOptions = "rw,nosuid,nodev,mode=1777"
+
+// readOnlyTmpfsPaths are scratch dirs backed by tmpfs under a read-only root.
+// Nothing in them is expected to outlive the process that wrote it.
+var readOnlyTmpfsPaths = []string{"/tmp", "/var/tmp"}
+
+// readOnlyRuntimePaths are the clawker runtime dirs that must stay writable
+// under a read-only root: /run/clawker receives the bootstrap material via
+// CopyToContainer between create and start (a tmpfs would shadow it — see
+// consts.BootstrapDir) and holds the ready marker; /var/lib/clawker holds the
+// init marker and the services scan dir; /var/log/clawker holds clawkerd's
+// log. Each gets a volume so the image's pre-created dirs and their ownership
+// are copied up.
+var readOnlyRuntimePaths = []string{"/run/clawker", "/var/lib/clawker", "/var/log/clawker"}
+
+// readOnlyVolumePurposePrefix prefixes the volume purpose of every volume
+// provisioned for a read-only root, keeping them out of the namespace of the
+// persistent agent volumes.
+const readOnlyVolumePurposePrefix = "rootfs-"
+
+// ReadOnlyRootConfig holds configuration for read-only root filesystem setup.
+type ReadOnlyRootConfig struct {
+	// Log is the logger instance for diagnostic file logging.
+	Log *logger.Logger
+	// ProjectName is the resolved project name for volume naming.
+	ProjectName string
+	// AgentName is the agent name for volume naming.
+	AgentName string
+	// HomeDir is the container user's home directory, resolved by the
+	// caller from the image config.
+	HomeDir string
+	// WritablePaths are the extra absolute paths from security.writable_paths.
+	WritablePaths []string
+	// Targets are the container paths already covered by a mount (workspace,
+	// config volumes, user -v/--mount/--tmpfs). They are writable or
+	// deliberately read-only already and are never provisioned again.
+	Targets []string
+	// Plan returns the mounts without resetting or creating any volume.
+	Plan bool
+}
+
+// ReadOnlyRootResult holds the writable surface provisioned for a read-only
+// root filesystem.
+type ReadOnlyRootResult struct {
+	// Mounts are the volume mounts to add to the container's HostConfig.
+	Mounts []mount.Mount
+	// Tmpfs maps scratch paths to their tmpfs mount options.
+	Tmpfs map[string]string
+	// CreatedVolumes are the volumes created during setup. Used for cleanup
+	// on init failure.
+	CreatedVolumes []string
+}
+
+// SetupReadOnlyRoot provisions the writable paths an agent needs when its
+// root filesystem is mounted read-only: tmpfs for the scratch dirs, and a
+// per-agent volume for the home directory, clawker's runtime dirs, and each
+// configured writable path.
+//
+// The volumes stand in for the container's writable layer, so they are
+// ephemeral: an existing one is removed and recreated, and Docker seeds the
+// fresh volume from the image's content at its target on first mount. A
+// volume still held by another container fails the setup instead of being
+// shared.
+func SetupReadOnlyRoot(ctx context.Context, client *docker.Client, cfg ReadOnlyRootConfig) (*ReadOnlyRootResult, error) {
+	for _, p := range cfg.WritablePaths {
+		if !path.IsAbs(p) {
+			return nil, fmt.Errorf("security.writable_paths entry must be an absolute path, got %q", p)
+		}
+		if path.Clean(p) == "/" {
+			return nil, fmt.Errorf("security.writable_paths cannot include the root directory")
+		}
+	}
+
+	covered := make(map[string]bool, len(cfg.Targets))
+	for _, t := range cfg.Targets {
+		covered[path.Clean(t)] = true
+	}
+
+	result := &ReadOnlyRootResult{Tmpfs: make(map[string]string)}
+
+	// Volumes created before a later failure are removed here — the caller
+	// only tracks the volumes of a successful setup.
+	succeeded := false
+	defer func() {
+		if succeeded {
+			return
+		}
+		for _, name := range result.CreatedVolumes {
+			if _, err := client.VolumeRemove(context.Background(), name, true); err != nil {
+				cfg.Log.Warn().Str("volume", name).Err(err).Msg("failed to clean up writable volume after setup failure")
+			}
+		}
+	}()
+
+	for _, p := range readOnlyTmpfsPaths {
+		if covered[p] {
+			continue
+		}
+		covered[p] = true
+		result.Tmpfs[p] = readOnlyTmpfsOptions
+	}
+
+	volumePaths := append([]string{cfg.HomeDir}, readOnlyRuntimePaths...)
+	volumePaths = append(volumePaths, cfg.WritablePaths...)
+
+	byName := make(map[string]string)
+	labels := client.AgentVolumeLabels(cfg.ProjectName, cfg.AgentName)
+
+	for _, p := range volumePaths {
+		p = path.Clean(p)
+		if !path.IsAbs(p) || p == "/" {
+			// An image without a usable HOME leaves nothing to provision.
+			continue
+		}
+		if covered[p] {
+			cfg.Log.Debug().Str("path", p).Msg("read-only root: path already mounted, skipping")
+			continue
+		}
+		covered[p] = true
+
+		name, err := docker.VolumeName(cfg.ProjectName, cfg.AgentName, readOnlyVolumePurpose(p))
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := byName[name]; ok {
+			return nil, fmt.Errorf("writable paths %q and %q map to the same volume %s", other, p, name)
+		}
+		byName[name] = p
+
+		if !cfg.Plan {
+			if err := resetVolume(ctx, client, name); err != nil {
+				return nil, err
+			}
+			created, err := client.EnsureVolume(ctx, name, labels)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create writable volume for %s: %w", p, err)
+			}
+			if created {
+				result.CreatedVolumes = append(result.CreatedVolumes, name)
+			}
+		}
+
+		result.Mounts = append(result.Mounts, mount.Mount{
+			Type:   mount.TypeVolume,
+			Source: name,
+			Target: p,
+		})
+		cfg.Log.Debug().Str("path", p).Str("volume", name).Msg("read-only root: provisioned writable volume")
+	}
+
+	succeeded = true
+	return result, nil
+}
+
+// readOnlyVolumePurpose returns the volume purpose for the read-only root
+// volume backing containerPath, e.g. "rootfs-var-lib-clawker".
+func readOnlyVolumePurpose(containerPath string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(path.Clean(containerPath)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('-')
+		}
+	}
+	return readOnlyVolumePurposePrefix + strings.Trim(b.String(), "-")
+}
+
+// resetVolume removes the named volume if it exists, so the next create
+// starts from the image's content rather than a previous container's writes.
+func resetVolume(ctx context.Context, client *docker.Client, name string) error {
+	exists, err := client.VolumeExists(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to check volume existence: %w", err)
+	}
+	if !exists {
+		return nil
+	}
+	if _, err := client.VolumeRemove(ctx, name, false); err != nil {
+		return fmt.Errorf("failed to reset writable volume %s (is another container using it?): %w", name, err)
+	}
+	return nil
+}
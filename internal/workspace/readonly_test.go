@@ -0,0 +1,187 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/moby/moby/api/types/mount"
+	mobyclient "github.com/moby/moby/client"
+
+	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/docker/mocks"
+	"github.com/schmitthub/clawker/internal/logger"
+)
+
+func TestReadOnlyVolumePurpose(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/home/clawker", "rootfs-home-clawker"},
+		{"/var/lib/clawker/", "rootfs-var-lib-clawker"},
+		{"/opt/My_Tools", "rootfs-opt-my-tools"},
+	}
+	for _, tt := range tests {
+		if got := readOnlyVolumePurpose(tt.path); got != tt.want {
+			t.Errorf("readOnlyVolumePurpose(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+// TestSetupReadOnlyRoot_ProvisionsCuratedPaths pins the writable surface of a
+// read-only root: tmpfs for the scratch dirs, a fresh per-agent volume for the
+// home dir, clawker's runtime dirs, and each configured writable path — and
+// nothing for paths another mount already covers.
+func TestSetupReadOnlyRoot_ProvisionsCuratedPaths(t *testing.T) {
+	mockCfg := configmocks.NewBlankConfig()
+	fake := mocks.NewFakeClient(mockCfg)
+	fake.SetupVolumeExists("", false)
+
+	var createdLabels map[string]string
+	fake.FakeAPI.VolumeCreateFn = func(_ context.Context, opts mobyclient.VolumeCreateOptions) (mobyclient.VolumeCreateResult, error) {
+		createdLabels = opts.Labels
+		return mobyclient.VolumeCreateResult{}, nil
+	}
+
+	result, err := SetupReadOnlyRoot(t.Context(), fake.Client, ReadOnlyRootConfig{
+		Log:           logger.Nop(),
+		ProjectName:   "myproj",
+		AgentName:     "dev",
+		HomeDir:       "/home/clawker",
+		WritablePaths: []string{"/opt/cache", "/var/log/clawker"},
+		Targets:       []string{"/var/tmp", "/workspace"},
+	})
+	if err != nil {
+		t.Fatalf("SetupReadOnlyRoot() error = %v", err)
+	}
+
+	if len(result.Tmpfs) != 1 || result.Tmpfs["/tmp"] != readOnlyTmpfsOptions {
+		t.Errorf("Tmpfs = %v, want only /tmp (the user already mounts /var/tmp)", result.Tmpfs)
+	}
+
+	want := map[string]string{
+		"/home/clawker":    "clawker.myproj.dev-rootfs-home-clawker",
+		"/run/clawker":     "clawker.myproj.dev-rootfs-run-clawker",
+		"/var/lib/clawker": "clawker.myproj.dev-rootfs-var-lib-clawker",
+		"/var/log/clawker": "clawker.myproj.dev-rootfs-var-log-clawker",
+		"/opt/cache":       "clawker.myproj.dev-rootfs-opt-cache",
+	}
+	if len(result.Mounts) != len(want) {
+		t.Fatalf("len(Mounts) = %d, want %d: %+v", len(result.Mounts), len(want), result.Mounts)
+	}
+	for target, name := range want {
+		m := findMountByTarget(result.Mounts, target)
+		if m == nil {
+			t.Errorf("no mount for %s", target)
+			continue
+		}
+		if m.Type != mount.TypeVolume || m.Source != name {
+			t.Errorf("mount %s = %s %s, want volume %s", target, m.Type, m.Source, name)
+		}
+	}
+	if len(result.CreatedVolumes) != len(want) {
+		t.Errorf("CreatedVolumes = %v, want all %d volumes tracked", result.CreatedVolumes, len(want))
+	}
+
+	if got := createdLabels[mockCfg.LabelAgent()]; got != "dev" {
+		t.Errorf("LabelAgent = %q, want %q — cleanup on rm -v keys on the agent labels", got, "dev")
+	}
+}
+
+// TestSetupReadOnlyRoot_ResetsExistingVolume verifies a volume left behind by
+// a previous container is removed and recreated, so the agent never starts
+// on another container's writes.
+func TestSetupReadOnlyRoot_ResetsExistingVolume(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupVolumeExists("clawker.myproj.dev-rootfs-home-clawker", true)
+	fake.SetupVolumeCreate()
+
+	var removed []string
+	fake.FakeAPI.VolumeRemoveFn = func(_ context.Context, id string, opts mobyclient.VolumeRemoveOptions) (mobyclient.VolumeRemoveResult, error) {
+		if opts.Force {
+			t.Errorf("VolumeRemove(%s) forced — a volume in use must fail the reset, not be yanked", id)
+		}
+		removed = append(removed, id)
+		return mobyclient.VolumeRemoveResult{}, nil
+	}
+
+	_, err := SetupReadOnlyRoot(t.Context(), fake.Client, ReadOnlyRootConfig{
+		Log:         logger.Nop(),
+		ProjectName: "myproj",
+		AgentName:   "dev",
+		HomeDir:     "/home/clawker",
+	})
+	if err != nil {
+		t.Fatalf("SetupReadOnlyRoot() error = %v", err)
+	}
+	if len(removed) != 1 || removed[0] != "clawker.myproj.dev-rootfs-home-clawker" {
+		t.Errorf("removed = %v, want only the existing home volume", removed)
+	}
+}
+
+// TestSetupReadOnlyRoot_FailureCleansUp verifies volumes created before a
+// failed reset are removed rather than orphaned.
+func TestSetupReadOnlyRoot_FailureCleansUp(t *testing.T) {
+	fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+	fake.SetupVolumeCreate()
+
+	// Volumes exist once created; the /var/lib/clawker one is held by
+	// another container.
+	existing := map[string]bool{"clawker.myproj.dev-rootfs-var-lib-clawker": true}
+	fake.SetupVolumeExists("", true)
+	inspectManaged := fake.FakeAPI.VolumeInspectFn
+	fake.SetupVolumeExists("", false)
+	inspectMissing := fake.FakeAPI.VolumeInspectFn
+	fake.FakeAPI.VolumeInspectFn = func(ctx context.Context, id string, opts mobyclient.VolumeInspectOptions) (mobyclient.VolumeInspectResult, error) {
+		if existing[id] {
+			return inspectManaged(ctx, id, opts)
+		}
+		return inspectMissing(ctx, id, opts)
+	}
+	createFn := fake.FakeAPI.VolumeCreateFn
+	fake.FakeAPI.VolumeCreateFn = func(ctx context.Context, opts mobyclient.VolumeCreateOptions) (mobyclient.VolumeCreateResult, error) {
+		existing[opts.Name] = true
+		return createFn(ctx, opts)
+	}
+
+	var removed []string
+	fake.FakeAPI.VolumeRemoveFn = func(_ context.Context, id string, _ mobyclient.VolumeRemoveOptions) (mobyclient.VolumeRemoveResult, error) {
+		if id == "clawker.myproj.dev-rootfs-var-lib-clawker" {
+			return mobyclient.VolumeRemoveResult{}, errors.New("volume is in use")
+		}
+		removed = append(removed, id)
+		return mobyclient.VolumeRemoveResult{}, nil
+	}
+
+	_, err := SetupReadOnlyRoot(t.Context(), fake.Client, ReadOnlyRootConfig{
+		Log:         logger.Nop(),
+		ProjectName: "myproj",
+		AgentName:   "dev",
+		HomeDir:     "/home/clawker",
+	})
+	if err == nil || !strings.Contains(err.Error(), "volume is in use") {
+		t.Fatalf("SetupReadOnlyRoot() error = %v, want the reset failure", err)
+	}
+	want := []string{"clawker.myproj.dev-rootfs-home-clawker", "clawker.myproj.dev-rootfs-run-clawker"}
+	if strings.Join(removed, ",") != strings.Join(want, ",") {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+}
+
+func TestSetupReadOnlyRoot_RejectsInvalidWritablePaths(t *testing.T) {
+	for _, p := range []string{"opt/cache", "/"} {
+		fake := mocks.NewFakeClient(configmocks.NewBlankConfig())
+		_, err := SetupReadOnlyRoot(t.Context(), fake.Client, ReadOnlyRootConfig{
+			Log:           logger.Nop(),
+			ProjectName:   "myproj",
+			AgentName:     "dev",
+			WritablePaths: []string{p},
+		})
+		if err == nil {
+			t.Errorf("SetupReadOnlyRoot(writable_paths=[%q]) error = nil, want rejection", p)
+		}
+		fake.AssertNotCalled(t, "VolumeCreate")
+	}
+}
@@ -24,6 +24,28 @@ var ErrWorktreeSnapshot = errors.New(
 	"worktrees are not supported in snapshot mode (snapshot already isolates the workspace from the host); set workspace.default_mode: bind or pass --mode bind",
 )
 
+// ErrRemoteBind is returned when bind mode is requested against a remote
+// Docker daemon (docker.Endpoint.Remote). The daemon resolves a bind
+// source against its own filesystem, so the container would get whatever
+// happens to live at that path on the daemon's machine — usually an empty
+// directory — instead of the project. Snapshot mode copies the workspace
+// into a volume over the API and works against any daemon.
+var ErrRemoteBind = errors.New(
+	"bind mode is not supported with a remote Docker daemon (it would mount the daemon machine's filesystem, not this one); set workspace.default_mode: snapshot or pass --mode snapshot",
+)
+
+// CheckRemoteBind returns ErrRemoteBind, naming the daemon, when mode is
+// bind and client talks to a remote daemon. A nil client passes.
+func CheckRemoteBind(client *docker.Client, mode config.Mode) error {
+	if mode != config.ModeBind || client == nil {
+		return nil
+	}
+	if ep := client.Endpoint(); ep.Remote() {
+		return fmt.Errorf("%w (daemon: %s)", ErrRemoteBind, ep)
+	}
+	return nil
+}
+
 // ResolveMode applies workspace-mode precedence: an explicit override (CLI
 // --mode flag) wins, otherwise the project's configured default mode. An empty
 // resulting value resolves to ModeBind (config.ParseMode's default); only an
@@ -82,6 +104,11 @@ type SetupMountsConfig struct {
 	// HarnessConfig is the per-harness initialization config resolved for
 	// the selected harness (nil = defaults). Gates the host-state binds.
 	HarnessConfig *config.HarnessConfig
+	// Plan resolves the mounts without creating anything: the strategy is
+	// not prepared and the config volumes are not ensured, so the result's
+	// ConfigVolumeResult and WorkspaceVolumeName stay empty. Backs
+	// `container run --plan`.
+	Plan bool
 }
 
 // SetupMountsResult holds the results from setting up workspace mounts.
@@ -137,6 +164,10 @@ func SetupMounts(ctx context.Context, client *docker.Client, cfg SetupMountsConf
 	if cfg.ProjectRootDir != "" && mode == config.ModeSnapshot {
 		return nil, ErrWorktreeSnapshot
 	}
+	if err := CheckRemoteBind(client, mode); err != nil {
+		return nil, err
+	}
+	remote := client != nil && client.Endpoint().Remote()
 
 	// Load ignore patterns (no ignore file when no project is registered)
 	var ignorePatterns []string
@@ -167,14 +198,16 @@ func SetupMounts(ctx context.Context, client *docker.Client, cfg SetupMountsConf
 		Msg("using workspace strategy")
 
 	// Prepare workspace resources (important for snapshot mode)
-	if err := strategy.Prepare(ctx, client); err != nil {
-		return nil, fmt.Errorf("failed to prepare workspace: %w", err)
-	}
-
-	// Track workspace volume name for cleanup on init failure (snapshot mode only)
 	var wsVolumeName string
-	if ss, ok := strategy.(*SnapshotStrategy); ok && ss.WasCreated() {
-		wsVolumeName = ss.VolumeName()
+	if !cfg.Plan {
+		if err := strategy.Prepare(ctx, client); err != nil {
+			return nil, fmt.Errorf("failed to prepare workspace: %w", err)
+		}
+
+		// Track workspace volume name for cleanup on init failure (snapshot mode only)
+		if ss, ok := strategy.(*SnapshotStrategy); ok && ss.WasCreated() {
+			wsVolumeName = ss.VolumeName()
+		}
 	}
 
 	// Get workspace mount
@@ -197,10 +230,13 @@ func SetupMounts(ctx context.Context, client *docker.Client, cfg SetupMountsConf
 	}
 
 	// Ensure harness + history volumes (returns creation state for init orchestration)
-	configResult, err := EnsureConfigVolumes(
-		ctx, client, cfg.ProjectName, cfg.AgentName, cfg.HarnessName, cfg.HarnessVolumes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create config volumes: %w", err)
+	var configResult ConfigVolumeResult
+	if !cfg.Plan {
+		configResult, err = EnsureConfigVolumes(
+			ctx, client, cfg.ProjectName, cfg.AgentName, cfg.HarnessName, cfg.HarnessVolumes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create config volumes: %w", err)
+		}
 	}
 	configMounts, err := GetConfigVolumeMounts(cfg.ProjectName, cfg.AgentName, cfg.HarnessName, cfg.HarnessVolumes)
 	if err != nil {
@@ -218,7 +254,11 @@ func SetupMounts(ctx context.Context, client *docker.Client, cfg SetupMountsConf
 	//
 	// Container UID is host-derived (see consts.ContainerUID() /
 	// consts.HostUID()) so the bind mounts are writable by construction.
-	if cfg.HarnessConfig.MountProjectsEnabled() {
+	// A remote daemon would bind its own machine's paths, so the binds are
+	// skipped there.
+	if remote && cfg.HarnessConfig.MountProjectsEnabled() {
+		cfg.Log.Warn().Msg("skip host-state binds: remote docker daemon cannot see host paths")
+	} else if cfg.HarnessConfig.MountProjectsEnabled() {
 		for _, hm := range cfg.Harness.Mounts {
 			src, ok, resolveErr := containerfs.ResolveHostMountSource(hm.Src)
 			if resolveErr != nil {
@@ -241,8 +281,11 @@ func SetupMounts(ctx context.Context, client *docker.Client, cfg SetupMountsConf
 		}
 	}
 
-	// Ensure and mount shared directory (if enabled)
-	if project.Agent.SharedDirEnabled() {
+	// Ensure and mount shared directory (if enabled; a host bind, so not
+	// against a remote daemon)
+	if remote && project.Agent.SharedDirEnabled() {
+		cfg.Log.Warn().Msg("skip shared dir bind: remote docker daemon cannot see host paths")
+	} else if project.Agent.SharedDirEnabled() {
 		sharePath, err := cfg.Cfg.ShareSubdir()
 		if err != nil {
 			return nil, fmt.Errorf("failed to ensure share directory: %w", err)
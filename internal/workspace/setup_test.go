@@ -11,6 +11,7 @@ import (
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
 	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/docker/mocks"
 	"github.com/schmitthub/clawker/internal/logger"
 )
@@ -332,6 +333,45 @@ func TestSetupMounts_RejectsWorktreeInSnapshotMode(t *testing.T) {
 	}
 }
 
+func TestSetupMounts_RemoteDaemon(t *testing.T) {
+	remote := docker.Endpoint{Host: "ssh://me@build-box", Source: "DOCKER_HOST"}
+
+	t.Run("bind mode is refused", func(t *testing.T) {
+		cfg, _, _ := setupMountsForBindBranch(t, "")
+		fake := mocks.NewFakeClient(cfg.Cfg, mocks.WithEndpoint(remote))
+		_, err := SetupMounts(t.Context(), fake.Client, cfg)
+		if !errors.Is(err, ErrRemoteBind) {
+			t.Fatalf("SetupMounts() error = %v, want ErrRemoteBind", err)
+		}
+		if !strings.Contains(err.Error(), "ssh://me@build-box") {
+			t.Errorf("error %q does not name the daemon", err)
+		}
+	})
+
+	t.Run("snapshot mode skips host-state binds", func(t *testing.T) {
+		cfg, hostDir, _ := setupMountsForBindBranch(t,
+			`agent:
+  claude_code:
+    mount_projects: true`)
+		if err := os.Mkdir(filepath.Join(hostDir, "projects"), 0o700); err != nil {
+			t.Fatalf("mkdir projects: %v", err)
+		}
+		fake := mocks.NewFakeClient(cfg.Cfg, mocks.WithEndpoint(remote))
+		cfg.ModeOverride = string(config.ModeSnapshot)
+		cfg.Plan = true
+
+		res, err := SetupMounts(t.Context(), fake.Client, cfg)
+		if err != nil {
+			t.Fatalf("SetupMounts() error = %v", err)
+		}
+		for _, m := range res.Mounts {
+			if m.Type == mount.TypeBind {
+				t.Errorf("unexpected host bind against a remote daemon: %+v", m)
+			}
+		}
+	})
+}
+
 func TestResolveMode(t *testing.T) {
 	tests := []struct {
 		name        string
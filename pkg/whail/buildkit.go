@@ -40,3 +40,28 @@ func BuildKitEnabled(ctx context.Context, p Pinger) (bool, error) {
 	// 3. Default: enabled (only disabled for Windows/WCOW)
 	return ping.OSType != "windows", nil
 }
+
+// InfoProvider is the subset of the Docker API needed for image store detection.
+type InfoProvider interface {
+	Info(ctx context.Context, options client.InfoOptions) (client.SystemInfoResult, error)
+}
+
+// containerdSnapshotterDriverType is the DriverStatus "driver-type" the
+// daemon reports when images live in the containerd image store.
+const containerdSnapshotterDriverType = "io.containerd.snapshotter.v1"
+
+// MultiPlatformImageStore reports whether the daemon keeps images in the
+// containerd image store. Only that store can hold an image index with more
+// than one platform; the classic store rejects a multi-platform export.
+func MultiPlatformImageStore(ctx context.Context, p InfoProvider) (bool, error) {
+	res, err := p.Info(ctx, client.InfoOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to query Docker daemon info: %w", err)
+	}
+	for _, kv := range res.Info.DriverStatus {
+		if kv[0] == "driver-type" && kv[1] == containerdSnapshotterDriverType {
+			return true, nil
+		}
+	}
+	return false, nil
+}
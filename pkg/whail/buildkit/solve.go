@@ -65,6 +65,11 @@ func toSolveOpt(opts whail.ImageBuildKitOptions) (bkclient.SolveOpt, error) {
 		attrs["force-network-mode"] = opts.NetworkMode
 	}
 
+	// Target platforms — the dockerfile frontend fans out one build per entry.
+	if len(opts.Platforms) > 0 {
+		attrs["platform"] = strings.Join(opts.Platforms, ",")
+	}
+
 	// Local mounts: context and dockerfile directory
 	contextDir, err := filepath.Abs(opts.ContextDir)
 	if err != nil {
@@ -146,6 +146,19 @@ func TestToSolveOpt_Target(t *testing.T) {
 	assert.Equal(t, "builder", solveOpt.FrontendAttrs["target"])
 }
 
+func TestToSolveOpt_Platforms(t *testing.T) {
+	dir := t.TempDir()
+	opts := whail.ImageBuildKitOptions{
+		ContextDir: dir,
+		Platforms:  []string{"linux/amd64", "linux/arm64"},
+	}
+
+	solveOpt, err := toSolveOpt(opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, "linux/amd64,linux/arm64", solveOpt.FrontendAttrs["platform"])
+}
+
 func TestToSolveOpt_Pull(t *testing.T) {
 	dir := t.TempDir()
 	opts := whail.ImageBuildKitOptions{
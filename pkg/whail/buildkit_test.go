@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/moby/moby/api/types/build"
+	"github.com/moby/moby/api/types/system"
 	"github.com/moby/moby/client"
 	"github.com/schmitthub/clawker/pkg/whail"
 	"github.com/stretchr/testify/require"
@@ -93,3 +94,32 @@ func TestBuildKitEnabled_PingError(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "failed to ping Docker daemon")
 }
+
+// fakeInfo implements whail.InfoProvider for testing.
+type fakeInfo struct {
+	result client.SystemInfoResult
+	err    error
+}
+
+func (f *fakeInfo) Info(_ context.Context, _ client.InfoOptions) (client.SystemInfoResult, error) {
+	return f.result, f.err
+}
+
+func TestMultiPlatformImageStore(t *testing.T) {
+	containerd := &fakeInfo{result: client.SystemInfoResult{Info: system.Info{
+		DriverStatus: [][2]string{{"driver-type", "io.containerd.snapshotter.v1"}},
+	}}}
+	ok, err := whail.MultiPlatformImageStore(context.Background(), containerd)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	classic := &fakeInfo{result: client.SystemInfoResult{Info: system.Info{
+		DriverStatus: [][2]string{{"Backing Filesystem", "extfs"}},
+	}}}
+	ok, err = whail.MultiPlatformImageStore(context.Background(), classic)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = whail.MultiPlatformImageStore(context.Background(), &fakeInfo{err: fmt.Errorf("boom")})
+	require.ErrorContains(t, err, "failed to query Docker daemon info")
+}
@@ -0,0 +1,77 @@
+package whail
+
+import (
+	"context"
+
+	"github.com/moby/moby/api/types/checkpoint"
+	"github.com/moby/moby/api/types/system"
+	"github.com/moby/moby/client"
+)
+
+// CheckpointSupport reports whether the daemon can checkpoint and restore
+// containers. Docker implements checkpoints with CRIU behind its
+// experimental flag, on Linux daemons only; CRIU needs root, so rootless
+// daemons cannot, and Podman's Docker-compatible API has no checkpoint
+// endpoints. A nil result means the daemon was not ruled out — whether CRIU
+// is installed on the daemon host is only known when a checkpoint is taken.
+// Without /info from connect time nothing is ruled out.
+func (e *Engine) CheckpointSupport() error {
+	return checkpointSupport(e.compat, e.info)
+}
+
+func checkpointSupport(compat Compat, info *system.Info) error {
+	if compat.Podman {
+		return ErrPodmanUnsupported("Checkpoint/restore", nil)
+	}
+	if compat.Rootless {
+		return ErrCheckpointUnsupported("the daemon runs rootless; CRIU needs a rootful daemon")
+	}
+	if info == nil {
+		return nil
+	}
+	if info.OSType != "" && info.OSType != "linux" {
+		return ErrCheckpointUnsupported("the daemon runs " + info.OSType + " containers; CRIU is Linux-only")
+	}
+	if !info.ExperimentalBuild {
+		return ErrCheckpointUnsupported("the daemon's experimental features are off")
+	}
+	return nil
+}
+
+// CheckpointCreate checkpoints a managed container's processes with CRIU.
+// Unless opts.Exit is false the container stops once the checkpoint is
+// written. Fails with CheckpointSupport's error on a daemon that cannot
+// checkpoint.
+func (e *Engine) CheckpointCreate(ctx context.Context, containerID string, opts client.CheckpointCreateOptions) error {
+	if err := e.CheckpointSupport(); err != nil {
+		return err
+	}
+	isManaged, err := e.IsContainerManaged(ctx, containerID)
+	if err != nil {
+		return ErrCheckpointFailed(containerID, err)
+	}
+	if !isManaged {
+		return ErrContainerNotFound(containerID)
+	}
+	if _, err := e.APIClient.CheckpointCreate(ctx, containerID, opts); err != nil {
+		return ErrCheckpointFailed(containerID, err)
+	}
+	return nil
+}
+
+// CheckpointList lists a managed container's checkpoints. Restore one by
+// passing its name as ContainerStartOptions.CheckpointID.
+func (e *Engine) CheckpointList(ctx context.Context, containerID string, opts client.CheckpointListOptions) ([]checkpoint.Summary, error) {
+	isManaged, err := e.IsContainerManaged(ctx, containerID)
+	if err != nil {
+		return nil, ErrCheckpointListFailed(containerID, err)
+	}
+	if !isManaged {
+		return nil, ErrContainerNotFound(containerID)
+	}
+	result, err := e.APIClient.CheckpointList(ctx, containerID, opts)
+	if err != nil {
+		return nil, ErrCheckpointListFailed(containerID, err)
+	}
+	return result.Items, nil
+}
@@ -0,0 +1,36 @@
+package whail
+
+import (
+	"testing"
+
+	"github.com/moby/moby/api/types/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointSupport(t *testing.T) {
+	tests := []struct {
+		name    string
+		compat  Compat
+		info    *system.Info
+		wantErr string
+	}{
+		{name: "no info", info: nil},
+		{name: "experimental linux", info: &system.Info{OSType: "linux", ExperimentalBuild: true}},
+		{name: "experimental off", info: &system.Info{OSType: "linux"}, wantErr: "experimental features are off"},
+		{name: "windows", info: &system.Info{OSType: "windows", ExperimentalBuild: true}, wantErr: "CRIU is Linux-only"},
+		{name: "rootless", compat: Compat{Rootless: true}, info: &system.Info{OSType: "linux", ExperimentalBuild: true}, wantErr: "rootless"},
+		{name: "podman", compat: Compat{Podman: true}, wantErr: "not supported by Podman"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkpointSupport(tt.compat, tt.info)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
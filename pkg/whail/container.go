@@ -3,6 +3,7 @@ package whail
 import (
 	"context"
 	"strings"
+	"time"
 
 	cerrdefs "github.com/containerd/errdefs"
 	"github.com/moby/moby/api/types/container"
@@ -33,7 +34,8 @@ type ContainerCreateOptions struct {
 
 	// EnsureNetwork, if non-nil, ensures the named network exists (creating it
 	// if necessary) and adds the container to it. The network is added in addition
-	// to any networks already specified in NetworkingConfig.
+	// to any networks already specified in NetworkingConfig; an entry there for
+	// the same network supplies the endpoint settings (e.g. aliases).
 	EnsureNetwork *EnsureNetworkOptions
 }
 
@@ -48,12 +50,26 @@ type ContainerStartOptions struct {
 	// the container to it before starting. This is useful for connecting
 	// existing containers to networks that may have been removed.
 	EnsureNetwork *EnsureNetworkOptions
+
+	// WaitHealthy makes ContainerStart return only once the started container
+	// reports healthy (see ContainerWaitHealthy). HealthTimeout bounds the
+	// wait when positive; otherwise only ctx does.
+	WaitHealthy   bool
+	HealthTimeout time.Duration
 }
 
 // ContainerCreate creates a container with managed labels automatically applied.
 // If EnsureNetwork is specified, the network is created (if needed) and the container is connected to it.
 // Does not mutate the caller's config - creates an internal copy.
 func (e *Engine) ContainerCreate(ctx context.Context, opts ContainerCreateOptions) (client.ContainerCreateResult, error) {
+	createOp := Operation{Action: "create", Resource: "container", Target: opts.Name}
+	if opts.Config != nil && opts.Config.Image != "" {
+		createOp.Params = map[string]string{"image": opts.Config.Image}
+	}
+	if e.skipDryRunOp(createOp) {
+		return client.ContainerCreateResult{ID: dryRunIDPrefix + opts.Name}, nil
+	}
+
 	// Copy the config to avoid mutating caller's struct.
 	var configCopy *container.Config
 	if opts.Config != nil {
@@ -83,9 +99,14 @@ func (e *Engine) ContainerCreate(ctx context.Context, opts ContainerCreateOption
 			}
 			networkingConfig = &nc
 		}
-		networkingConfig.EndpointsConfig[opts.EnsureNetwork.Name] = &network.EndpointSettings{
-			NetworkID: networkID,
+		// Keep caller settings for the ensured network (aliases, priority);
+		// only the ID comes from EnsureNetwork.
+		ep := network.EndpointSettings{}
+		if existing := networkingConfig.EndpointsConfig[opts.EnsureNetwork.Name]; existing != nil {
+			ep = *existing
 		}
+		ep.NetworkID = networkID
+		networkingConfig.EndpointsConfig[opts.EnsureNetwork.Name] = &ep
 	}
 
 	// Merge labels into the copy: base managed + config + extra + user-provided
@@ -94,14 +115,24 @@ func (e *Engine) ContainerCreate(ctx context.Context, opts ContainerCreateOption
 			e.containerLabels(opts.ExtraLabels...),
 			opts.Config.Labels,
 		)
-		// Ensure managed label cannot be overridden by extra or user labels.
-		configCopy.Labels[e.managedLabelKey] = e.managedLabelValue
+		// Ensure the managed and label-schema labels cannot be overridden by extra or user labels.
+		e.enforceManagedLabels(configCopy.Labels)
+	}
+
+	// Podman differs from Docker Engine in userns and host-name defaults;
+	// see Compat.adjustHostConfig.
+	if e.compat.usesKeepID(opts.HostConfig) && configCopy != nil {
+		user, err := e.keepIDUser(ctx, configCopy)
+		if err != nil {
+			return client.ContainerCreateResult{}, ErrContainerCreateFailed(err)
+		}
+		configCopy.User = user
 	}
 
 	sdkOpts := client.ContainerCreateOptions{
 		Name:             opts.Name,
 		Config:           configCopy,
-		HostConfig:       opts.HostConfig,
+		HostConfig:       e.compat.adjustHostConfig(opts.HostConfig),
 		NetworkingConfig: networkingConfig,
 		Platform:         opts.Platform,
 	}
@@ -115,12 +146,17 @@ func (e *Engine) ContainerCreate(ctx context.Context, opts ContainerCreateOption
 // ContainerStart starts a container with managed label verification.
 // If EnsureNetwork is specified, the network is created (if needed) and the container
 // is connected to it before starting. This is useful for reconnecting existing
-// containers to networks that may have been removed.
+// containers to networks that may have been removed. With WaitHealthy it then
+// waits for the container to report healthy; the container is left running
+// when the wait fails.
 func (e *Engine) ContainerStart(ctx context.Context, opts ContainerStartOptions) (client.ContainerStartResult, error) {
 	containerID := opts.ContainerID
 	if containerID == "" {
 		return client.ContainerStartResult{}, ErrContainerStartFailed("", nil)
 	}
+	if isDryRunID(containerID) && e.skipDryRun("start", "container", containerID) {
+		return client.ContainerStartResult{}, nil
+	}
 
 	isManaged, err := e.IsContainerManaged(ctx, containerID)
 	if err != nil {
@@ -129,6 +165,9 @@ func (e *Engine) ContainerStart(ctx context.Context, opts ContainerStartOptions)
 	if !isManaged {
 		return client.ContainerStartResult{}, ErrContainerNotFound(containerID)
 	}
+	if e.skipDryRun("start", "container", containerID) {
+		return client.ContainerStartResult{}, nil
+	}
 
 	// Handle EnsureNetwork if specified - creates network if needed and connects container
 	if opts.EnsureNetwork != nil {
@@ -169,6 +208,11 @@ func (e *Engine) ContainerStart(ctx context.Context, opts ContainerStartOptions)
 	if err != nil {
 		return client.ContainerStartResult{}, ErrContainerStartFailed(containerID, err)
 	}
+	if opts.WaitHealthy {
+		if err := e.ContainerWaitHealthy(ctx, containerID, opts.HealthTimeout); err != nil {
+			return result, err
+		}
+	}
 	return result, nil
 }
 
@@ -198,6 +242,9 @@ func (e *Engine) ContainerStop(ctx context.Context, containerID string, timeout
 	if !isManaged {
 		return client.ContainerStopResult{}, ErrContainerNotManaged(containerID)
 	}
+	if e.skipDryRun("stop", "container", containerID) {
+		return client.ContainerStopResult{}, nil
+	}
 	stopOptions := client.ContainerStopOptions{}
 	if timeout != nil {
 		stopOptions.Timeout = timeout
@@ -211,6 +258,13 @@ func (e *Engine) ContainerStop(ctx context.Context, containerID string, timeout
 
 // ContainerRemove overrides to only remove managed containers.
 func (e *Engine) ContainerRemove(ctx context.Context, containerID string, force bool) (client.ContainerRemoveResult, error) {
+	return e.ContainerRemoveWithOptions(ctx, containerID, client.ContainerRemoveOptions{Force: force})
+}
+
+// ContainerRemoveWithOptions removes a managed container with the SDK's
+// remove options, e.g. RemoveVolumes to drop its anonymous volumes as
+// docker rm -v does. Named volumes are never removed by the daemon.
+func (e *Engine) ContainerRemoveWithOptions(ctx context.Context, containerID string, options client.ContainerRemoveOptions) (client.ContainerRemoveResult, error) {
 	isManaged, err := e.IsContainerManaged(ctx, containerID)
 	if err != nil {
 		return client.ContainerRemoveResult{}, ErrContainerRemoveFailed(containerID, err)
@@ -218,10 +272,10 @@ func (e *Engine) ContainerRemove(ctx context.Context, containerID string, force
 	if !isManaged {
 		return client.ContainerRemoveResult{}, ErrContainerNotManaged(containerID)
 	}
-	result, err := e.APIClient.ContainerRemove(ctx, containerID, client.ContainerRemoveOptions{
-		Force:         force,
-		RemoveVolumes: false,
-	})
+	if e.skipDryRun("remove", "container", containerID) {
+		return client.ContainerRemoveResult{}, nil
+	}
+	result, err := e.APIClient.ContainerRemove(ctx, containerID, options)
 	if err != nil {
 		return client.ContainerRemoveResult{}, ErrContainerRemoveFailed(containerID, err)
 	}
@@ -260,9 +314,9 @@ func (e *Engine) ContainerListRunning(ctx context.Context) ([]container.Summary,
 // ContainerListByLabels lists containers matching additional label filters.
 // The managed label filter is automatically injected.
 func (e *Engine) ContainerListByLabels(ctx context.Context, labels map[string]string, all bool) ([]container.Summary, error) {
-	f := e.newManagedFilter()
-	for k, v := range labels {
-		f = f.Add("label", k+"="+v)
+	f, err := e.Query().Labels(labels).Filters()
+	if err != nil {
+		return nil, ErrContainerListFailed(err)
 	}
 	result, err := e.APIClient.ContainerList(ctx, client.ContainerListOptions{
 		All:     all,
@@ -388,6 +442,12 @@ func (e *Engine) ExecCreate(ctx context.Context, containerID string, opts client
 	if !isManaged {
 		return client.ExecCreateResult{}, ErrContainerNotManaged(containerID)
 	}
+	// Exec is not skipped by a dry run, so it is reported either way.
+	execOp := Operation{Action: "exec", Resource: "container", Target: containerID, Params: map[string]string{"cmd": strings.Join(opts.Cmd, " ")}}
+	if opts.User != "" {
+		execOp.Params["user"] = opts.User
+	}
+	e.notifyMutation(execOp)
 	resp, err := e.APIClient.ExecCreate(ctx, containerID, opts)
 	if err != nil {
 		return client.ExecCreateResult{}, ErrExecCreateFailed(containerID, err)
@@ -446,6 +506,9 @@ func (e *Engine) ContainerKill(ctx context.Context, containerID, signal string)
 	if !isManaged {
 		return client.ContainerKillResult{}, ErrContainerNotFound(containerID)
 	}
+	if e.skipDryRun("kill", "container", containerID) {
+		return client.ContainerKillResult{}, nil
+	}
 	if signal == "" {
 		signal = "SIGKILL"
 	}
@@ -501,6 +564,9 @@ func (e *Engine) ContainerRestart(ctx context.Context, containerID string, timeo
 	if !isManaged {
 		return client.ContainerRestartResult{}, ErrContainerNotFound(containerID)
 	}
+	if e.skipDryRun("restart", "container", containerID) {
+		return client.ContainerRestartResult{}, nil
+	}
 	restartOpts := client.ContainerRestartOptions{}
 	if timeout != nil {
 		restartOpts.Timeout = timeout
@@ -606,3 +672,56 @@ func (e *Engine) ContainerUpdate(ctx context.Context, containerID string, resour
 	}
 	return resp, nil
 }
+
+// ContainerDiff returns the filesystem changes in a container's writable layer
+// relative to its image. Volume and bind mount contents are not part of that
+// layer and never appear in the result.
+// Only diffs managed containers.
+func (e *Engine) ContainerDiff(ctx context.Context, containerID string) (client.ContainerDiffResult, error) {
+	isManaged, err := e.IsContainerManaged(ctx, containerID)
+	if err != nil {
+		return client.ContainerDiffResult{}, ErrContainerDiffFailed(containerID, err)
+	}
+	if !isManaged {
+		return client.ContainerDiffResult{}, ErrContainerNotFound(containerID)
+	}
+	result, err := e.APIClient.ContainerDiff(ctx, containerID, client.ContainerDiffOptions{})
+	if err != nil {
+		return client.ContainerDiffResult{}, ErrContainerDiffFailed(containerID, err)
+	}
+	return result, nil
+}
+
+// ContainerCommit creates an image from a container's writable layer.
+// The resulting image carries the managed label and configured image labels,
+// followed by any labels in options.Config and extraLabels. The daemon merges
+// the remaining container config (Cmd, Env, WorkingDir, ...) into the image.
+// The managed label cannot be overridden.
+// Only commits managed containers.
+func (e *Engine) ContainerCommit(ctx context.Context, containerID string, options client.ContainerCommitOptions, extraLabels ...map[string]string) (client.ContainerCommitResult, error) {
+	isManaged, err := e.IsContainerManaged(ctx, containerID)
+	if err != nil {
+		return client.ContainerCommitResult{}, ErrContainerCommitFailed(containerID, err)
+	}
+	if !isManaged {
+		return client.ContainerCommitResult{}, ErrContainerNotFound(containerID)
+	}
+
+	// Copy options and config to avoid mutating caller's structs
+	optsCopy := options
+	cfg := container.Config{}
+	if options.Config != nil {
+		cfg = *options.Config
+	}
+	all := append([]map[string]string{e.imageLabels(), cfg.Labels}, extraLabels...)
+	cfg.Labels = MergeLabels(all...)
+	// Ensure the managed and label-schema labels cannot be overridden by caller labels.
+	e.enforceManagedLabels(cfg.Labels)
+	optsCopy.Config = &cfg
+
+	result, err := e.APIClient.ContainerCommit(ctx, containerID, optsCopy)
+	if err != nil {
+		return client.ContainerCommitResult{}, ErrContainerCommitFailed(containerID, err)
+	}
+	return result, nil
+}
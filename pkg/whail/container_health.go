@@ -0,0 +1,122 @@
+package whail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+)
+
+// healthPollInterval is how often a health wait re-inspects the container.
+// A package var so tests can shorten it.
+var healthPollInterval = 500 * time.Millisecond
+
+// ErrContainerUnhealthy is a sentinel error indicating a container did not
+// become healthy: its healthcheck failed, it exited, or the wait timed out.
+// Use errors.Is(err, ErrContainerUnhealthy) to detect this condition, and
+// errors.As with *ContainerHealthError for the details.
+var ErrContainerUnhealthy = errors.New("container did not become healthy")
+
+// ContainerHealthError reports why a health wait failed.
+type ContainerHealthError struct {
+	ContainerID string
+	// Status is the last health status seen: "unhealthy", "starting" (the
+	// wait timed out or was cancelled), or "exited" when the container
+	// stopped first.
+	Status string
+	// ExitCode is the container's exit code when Status is "exited".
+	ExitCode int
+	// Output is the output of the last healthcheck probe, if any.
+	Output string
+	// Err is the context error when the wait timed out or was cancelled.
+	Err error
+}
+
+func (e *ContainerHealthError) Error() string {
+	var msg string
+	switch e.Status {
+	case "exited":
+		msg = fmt.Sprintf("container %s exited with code %d before becoming healthy", e.ContainerID, e.ExitCode)
+	case string(container.Unhealthy):
+		msg = fmt.Sprintf("container %s reported unhealthy", e.ContainerID)
+	default:
+		msg = fmt.Sprintf("container %s did not become healthy (status %s)", e.ContainerID, e.Status)
+		if e.Err != nil {
+			msg += ": " + e.Err.Error()
+		}
+	}
+	if e.Output != "" {
+		msg += "; last probe: " + e.Output
+	}
+	return msg
+}
+
+func (e *ContainerHealthError) Unwrap() error { return e.Err }
+
+// Is matches ErrContainerUnhealthy.
+func (e *ContainerHealthError) Is(target error) bool {
+	return target == ErrContainerUnhealthy
+}
+
+// ContainerWaitHealthy waits until containerID's healthcheck reports healthy.
+// A container without a healthcheck counts as healthy once it is running.
+// The wait is bounded by ctx and, when positive, by timeout. Failures are
+// returned as *ContainerHealthError. ContainerStart runs it for
+// ContainerStartOptions.WaitHealthy; call it directly when something must
+// happen between the start and the wait.
+func (e *Engine) ContainerWaitHealthy(ctx context.Context, containerID string, timeout time.Duration) error {
+	if isDryRunID(containerID) {
+		return nil
+	}
+	isManaged, err := e.IsContainerManaged(ctx, containerID)
+	if err != nil {
+		return ErrContainerInspectFailed(containerID, err)
+	}
+	if !isManaged {
+		return ErrContainerNotFound(containerID)
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(healthPollInterval)
+	defer ticker.Stop()
+	status := string(container.Starting)
+	var output string
+	for {
+		info, err := e.APIClient.ContainerInspect(ctx, containerID, client.ContainerInspectOptions{})
+		if err != nil {
+			if ctx.Err() != nil {
+				return &ContainerHealthError{ContainerID: containerID, Status: status, Output: output, Err: ctx.Err()}
+			}
+			return ErrContainerInspectFailed(containerID, err)
+		}
+		if state := info.Container.State; state != nil {
+			if h := state.Health; h != nil {
+				status = string(h.Status)
+				if n := len(h.Log); n > 0 && h.Log[n-1] != nil {
+					output = strings.TrimSpace(h.Log[n-1].Output)
+				}
+			}
+			switch {
+			case !state.Running:
+				return &ContainerHealthError{ContainerID: containerID, Status: "exited", ExitCode: state.ExitCode, Output: output}
+			case state.Health == nil || state.Health.Status == container.NoHealthcheck || state.Health.Status == container.Healthy:
+				return nil
+			case state.Health.Status == container.Unhealthy:
+				return &ContainerHealthError{ContainerID: containerID, Status: status, Output: output}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return &ContainerHealthError{ContainerID: containerID, Status: status, Output: output, Err: ctx.Err()}
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package whail_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+// newHealthFake wires a FakeAPIClient whose managed container "c1" starts
+// and then inspects with the given state.
+func newHealthFake(state *container.State) *whailtest.FakeAPIClient {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		res := whailtest.ManagedContainerInspect(id)
+		res.Container.State = state
+		return res, nil
+	}
+	fake.ContainerStartFn = func(_ context.Context, _ string, _ client.ContainerStartOptions) (client.ContainerStartResult, error) {
+		return client.ContainerStartResult{}, nil
+	}
+	return fake
+}
+
+func TestContainerStart_WaitHealthy(t *testing.T) {
+	probe := func(out string) []*container.HealthcheckResult {
+		return []*container.HealthcheckResult{{ExitCode: 1, Output: out + "\n"}}
+	}
+
+	tests := []struct {
+		name       string
+		state      *container.State
+		wantStatus string
+		wantOutput string
+		wantCtxErr bool
+	}{
+		{
+			name:  "healthy",
+			state: &container.State{Running: true, Health: &container.Health{Status: container.Healthy}},
+		},
+		{
+			name:  "no healthcheck counts once running",
+			state: &container.State{Running: true},
+		},
+		{
+			name:       "unhealthy",
+			state:      &container.State{Running: true, Health: &container.Health{Status: container.Unhealthy, Log: probe("ready marker missing")}},
+			wantStatus: "unhealthy",
+			wantOutput: "ready marker missing",
+		},
+		{
+			name:       "exited first",
+			state:      &container.State{ExitCode: 3, Health: &container.Health{Status: container.Starting}},
+			wantStatus: "exited",
+		},
+		{
+			name:       "timeout while starting",
+			state:      &container.State{Running: true, Health: &container.Health{Status: container.Starting, Log: probe("still booting")}},
+			wantStatus: "starting",
+			wantOutput: "still booting",
+			wantCtxErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eng := whail.NewFromExisting(newHealthFake(tt.state), whailtest.TestEngineOptions())
+
+			_, err := eng.ContainerStart(context.Background(), whail.ContainerStartOptions{
+				ContainerID:   "c1",
+				WaitHealthy:   true,
+				HealthTimeout: 20 * time.Millisecond,
+			})
+			if tt.wantStatus == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, whail.ErrContainerUnhealthy)
+			var hErr *whail.ContainerHealthError
+			require.True(t, errors.As(err, &hErr))
+			assert.Equal(t, "c1", hErr.ContainerID)
+			assert.Equal(t, tt.wantStatus, hErr.Status)
+			assert.Equal(t, tt.wantOutput, hErr.Output)
+			if tt.wantStatus == "exited" {
+				assert.Equal(t, 3, hErr.ExitCode)
+			}
+			assert.Equal(t, tt.wantCtxErr, errors.Is(err, context.DeadlineExceeded))
+		})
+	}
+}
+
+func TestContainerStart_NoWaitByDefault(t *testing.T) {
+	fake := newHealthFake(&container.State{Running: true, Health: &container.Health{Status: container.Unhealthy}})
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	_, err := eng.ContainerStart(context.Background(), whail.ContainerStartOptions{ContainerID: "c1"})
+	require.NoError(t, err)
+}
+
+func TestContainerWaitHealthy_RejectsUnmanaged(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		return whailtest.UnmanagedContainerInspect(id), nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	err := eng.ContainerWaitHealthy(context.Background(), "c1", time.Second)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, whail.ErrContainerUnhealthy)
+}
@@ -0,0 +1,167 @@
+package whail
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+	"github.com/moby/moby/client"
+)
+
+// replaceSuffix names the replacement container while both generations run.
+// It is renamed to the service name once the old container is gone.
+const replaceSuffix = "-next"
+
+// ContainerReplaceResult identifies both generations of a replaced container.
+type ContainerReplaceResult struct {
+	OldID string
+	NewID string
+}
+
+// ContainerReplace swaps the managed container called name for a new one built
+// from newSpec without a window in which the service is unreachable:
+//
+//  1. The replacement is created as "<name>-next" on the same networks, but
+//     without the service's network aliases, and started.
+//  2. ContainerReplace waits until the replacement's healthcheck reports
+//     healthy (or, with no healthcheck, until it is running). The wait is
+//     bounded by ctx.
+//  3. The replacement is re-attached to each network with the service aliases
+//     — the old aliases plus name itself — so both generations answer for a
+//     moment and the alias never resolves to nothing.
+//  4. The old container is stopped with its own stop timeout, so in-flight
+//     connections drain on SIGTERM, then removed, and the replacement is
+//     renamed to name.
+//
+// newSpec.Name is ignored. When newSpec.NetworkingConfig is nil the old
+// container's networks are reused; an endpoint in newSpec without aliases
+// inherits the old container's aliases on that network. If the replacement
+// fails before the alias switch completes it is removed and the old container
+// is left untouched. A failure while retiring the old container is returned
+// together with a populated result — the replacement is already serving.
+func (e *Engine) ContainerReplace(ctx context.Context, name string, newSpec ContainerCreateOptions) (ContainerReplaceResult, error) {
+	old, err := e.FindContainerByName(ctx, name)
+	if err != nil {
+		return ContainerReplaceResult{}, ErrContainerReplaceFailed(name, err)
+	}
+	oldInfo, err := e.ContainerInspect(ctx, old.ID, client.ContainerInspectOptions{})
+	if err != nil {
+		return ContainerReplaceResult{}, ErrContainerReplaceFailed(name, err)
+	}
+
+	endpoints := serviceEndpoints(name, oldInfo.Container, newSpec.NetworkingConfig)
+
+	spec := newSpec
+	spec.Name = name + replaceSuffix
+	spec.NetworkingConfig = &network.NetworkingConfig{
+		EndpointsConfig: make(map[string]*network.EndpointSettings, len(endpoints)),
+	}
+	for netName, ep := range endpoints {
+		staged := *ep
+		staged.Aliases = nil
+		spec.NetworkingConfig.EndpointsConfig[netName] = &staged
+	}
+	if oldHC := oldInfo.Container.HostConfig; oldHC != nil && (spec.HostConfig == nil || spec.HostConfig.NetworkMode == "") {
+		// Without a network mode the daemon would also attach the default
+		// bridge; inherit the old container's primary network instead.
+		hc := container.HostConfig{}
+		if spec.HostConfig != nil {
+			hc = *spec.HostConfig
+		}
+		hc.NetworkMode = oldHC.NetworkMode
+		spec.HostConfig = &hc
+	}
+
+	created, err := e.ContainerCreate(ctx, spec)
+	if err != nil {
+		return ContainerReplaceResult{}, ErrContainerReplaceFailed(name, err)
+	}
+
+	// rollback removes the replacement and leaves the old container serving.
+	// Cleanup runs even if ctx was cancelled (e.g. the health wait timed out).
+	rollback := func(cause error) (ContainerReplaceResult, error) {
+		if _, rmErr := e.ContainerRemove(context.WithoutCancel(ctx), created.ID, true); rmErr != nil {
+			cause = errors.Join(cause, rmErr)
+		}
+		return ContainerReplaceResult{}, ErrContainerReplaceFailed(name, cause)
+	}
+
+	if _, err := e.ContainerStart(ctx, ContainerStartOptions{ContainerID: created.ID}); err != nil {
+		return rollback(err)
+	}
+	if err := e.ContainerWaitHealthy(ctx, created.ID, 0); err != nil {
+		return rollback(err)
+	}
+
+	// Docker cannot change an endpoint's aliases in place, so re-attach the
+	// replacement with them. The old container keeps its own endpoint, so the
+	// alias stays resolvable throughout.
+	for _, netName := range slices.Sorted(maps.Keys(endpoints)) {
+		ep := endpoints[netName]
+		if len(ep.Aliases) == 0 {
+			continue
+		}
+		if _, err := e.NetworkDisconnect(ctx, netName, created.ID, false); err != nil {
+			return rollback(err)
+		}
+		if _, err := e.NetworkConnect(ctx, netName, created.ID, ep); err != nil {
+			return rollback(err)
+		}
+	}
+
+	result := ContainerReplaceResult{OldID: old.ID, NewID: created.ID}
+	if _, err := e.ContainerStop(ctx, old.ID, nil); err != nil {
+		return result, ErrContainerReplaceFailed(name, err)
+	}
+	if _, err := e.ContainerRemove(ctx, old.ID, false); err != nil {
+		return result, ErrContainerReplaceFailed(name, err)
+	}
+	if _, err := e.ContainerRename(ctx, created.ID, name); err != nil {
+		return result, ErrContainerReplaceFailed(name, err)
+	}
+	return result, nil
+}
+
+// serviceEndpoints returns the endpoint, per network, that the replacement
+// should end up with. Aliases are only attached on user-defined networks —
+// the daemon rejects them on the default bridge.
+func serviceEndpoints(name string, old container.InspectResponse, spec *network.NetworkingConfig) map[string]*network.EndpointSettings {
+	var oldNetworks map[string]*network.EndpointSettings
+	if old.NetworkSettings != nil {
+		oldNetworks = old.NetworkSettings.Networks
+	}
+
+	out := make(map[string]*network.EndpointSettings)
+	if spec != nil && len(spec.EndpointsConfig) > 0 {
+		for netName, ep := range spec.EndpointsConfig {
+			settings := network.EndpointSettings{}
+			if ep != nil {
+				settings = *ep
+			}
+			out[netName] = &settings
+		}
+	} else {
+		for netName := range oldNetworks {
+			out[netName] = &network.EndpointSettings{}
+		}
+	}
+
+	for netName, ep := range out {
+		if !container.NetworkMode(netName).IsUserDefined() {
+			ep.Aliases = nil
+			continue
+		}
+		aliases := slices.Clone(ep.Aliases)
+		if len(aliases) == 0 && oldNetworks[netName] != nil {
+			aliases = slices.Clone(oldNetworks[netName].Aliases)
+		}
+		if !slices.Contains(aliases, name) {
+			aliases = append(aliases, name)
+		}
+		ep.Aliases = aliases
+	}
+	return out
+}
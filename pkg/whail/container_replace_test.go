@@ -0,0 +1,157 @@
+package whail_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+const (
+	replaceOldID   = "old-id"
+	replaceNewID   = "new-id"
+	replaceNetwork = "clawker-net"
+)
+
+// newReplaceFake wires a FakeAPIClient with a running "proxy" service on
+// replaceNetwork (alias "db") and a replacement whose health is newHealth.
+func newReplaceFake(newHealth container.HealthStatus) (*whailtest.FakeAPIClient, *client.ContainerCreateOptions) {
+	fake := whailtest.NewFakeAPIClient()
+	created := &client.ContainerCreateOptions{}
+
+	fake.ContainerListFn = func(_ context.Context, _ client.ContainerListOptions) (client.ContainerListResult, error) {
+		return client.ContainerListResult{Items: []container.Summary{{ID: replaceOldID, Names: []string{"/proxy"}}}}, nil
+	}
+	fake.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		res := whailtest.ManagedContainerInspect(id)
+		switch id {
+		case replaceOldID:
+			res.Container.HostConfig = &container.HostConfig{NetworkMode: replaceNetwork}
+			res.Container.NetworkSettings = &container.NetworkSettings{
+				Networks: map[string]*network.EndpointSettings{replaceNetwork: {Aliases: []string{"db"}}},
+			}
+		case replaceNewID:
+			res.Container.State = &container.State{Running: true, Health: &container.Health{Status: newHealth}}
+		}
+		return res, nil
+	}
+	fake.ContainerCreateFn = func(_ context.Context, opts client.ContainerCreateOptions) (client.ContainerCreateResult, error) {
+		*created = opts
+		return client.ContainerCreateResult{ID: replaceNewID}, nil
+	}
+	fake.ContainerStartFn = func(_ context.Context, _ string, _ client.ContainerStartOptions) (client.ContainerStartResult, error) {
+		return client.ContainerStartResult{}, nil
+	}
+	fake.ContainerStopFn = func(_ context.Context, _ string, _ client.ContainerStopOptions) (client.ContainerStopResult, error) {
+		return client.ContainerStopResult{}, nil
+	}
+	fake.ContainerRemoveFn = func(_ context.Context, _ string, _ client.ContainerRemoveOptions) (client.ContainerRemoveResult, error) {
+		return client.ContainerRemoveResult{}, nil
+	}
+	fake.ContainerRenameFn = func(_ context.Context, _ string, _ client.ContainerRenameOptions) (client.ContainerRenameResult, error) {
+		return client.ContainerRenameResult{}, nil
+	}
+	fake.NetworkDisconnectFn = func(_ context.Context, _ string, _ client.NetworkDisconnectOptions) (client.NetworkDisconnectResult, error) {
+		return client.NetworkDisconnectResult{}, nil
+	}
+	fake.NetworkConnectFn = func(_ context.Context, _ string, _ client.NetworkConnectOptions) (client.NetworkConnectResult, error) {
+		return client.NetworkConnectResult{}, nil
+	}
+	return fake, created
+}
+
+func TestContainerReplace_SwapsAfterHealthy(t *testing.T) {
+	fake, created := newReplaceFake(container.Healthy)
+	var connected client.NetworkConnectOptions
+	fake.NetworkConnectFn = func(_ context.Context, _ string, opts client.NetworkConnectOptions) (client.NetworkConnectResult, error) {
+		connected = opts
+		return client.NetworkConnectResult{}, nil
+	}
+	var stopped, removed, renamedTo string
+	fake.ContainerStopFn = func(_ context.Context, id string, _ client.ContainerStopOptions) (client.ContainerStopResult, error) {
+		stopped = id
+		return client.ContainerStopResult{}, nil
+	}
+	fake.ContainerRemoveFn = func(_ context.Context, id string, _ client.ContainerRemoveOptions) (client.ContainerRemoveResult, error) {
+		removed = id
+		return client.ContainerRemoveResult{}, nil
+	}
+	fake.ContainerRenameFn = func(_ context.Context, id string, opts client.ContainerRenameOptions) (client.ContainerRenameResult, error) {
+		renamedTo = id + "->" + opts.NewName
+		return client.ContainerRenameResult{}, nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	res, err := eng.ContainerReplace(context.Background(), "proxy", whail.ContainerCreateOptions{
+		Config: &container.Config{Image: "proxy:2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, whail.ContainerReplaceResult{OldID: replaceOldID, NewID: replaceNewID}, res)
+
+	// The replacement is staged beside the old one, on its network, without aliases.
+	assert.Equal(t, "proxy-next", created.Name)
+	assert.Equal(t, container.NetworkMode(replaceNetwork), created.HostConfig.NetworkMode)
+	require.Contains(t, created.NetworkingConfig.EndpointsConfig, replaceNetwork)
+	assert.Empty(t, created.NetworkingConfig.EndpointsConfig[replaceNetwork].Aliases)
+
+	// Once healthy it takes over the old aliases plus the service name.
+	assert.Equal(t, replaceNewID, connected.Container)
+	assert.Equal(t, []string{"db", "proxy"}, connected.EndpointConfig.Aliases)
+
+	assert.Equal(t, replaceOldID, stopped)
+	assert.Equal(t, replaceOldID, removed)
+	assert.Equal(t, replaceNewID+"->proxy", renamedTo)
+
+	// Aliases move before the old container is retired.
+	connectAt := slices.Index(fake.Calls, "NetworkConnect")
+	stopAt := slices.Index(fake.Calls, "ContainerStop")
+	require.NotEqual(t, -1, connectAt)
+	assert.Less(t, connectAt, stopAt)
+}
+
+func TestContainerReplace_UnhealthyRollsBack(t *testing.T) {
+	fake, _ := newReplaceFake(container.Unhealthy)
+	var removed []string
+	fake.ContainerRemoveFn = func(_ context.Context, id string, _ client.ContainerRemoveOptions) (client.ContainerRemoveResult, error) {
+		removed = append(removed, id)
+		return client.ContainerRemoveResult{}, nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	_, err := eng.ContainerReplace(context.Background(), "proxy", whail.ContainerCreateOptions{
+		Config: &container.Config{Image: "proxy:2"},
+	})
+	require.Error(t, err)
+	var dErr *whail.DockerError
+	require.True(t, errors.As(err, &dErr))
+	assert.Equal(t, "replace", dErr.Op)
+	assert.Contains(t, err.Error(), "unhealthy")
+
+	assert.Equal(t, []string{replaceNewID}, removed, "only the replacement is removed")
+	whailtest.AssertNotCalled(t, fake, "NetworkConnect")
+	whailtest.AssertNotCalled(t, fake, "ContainerStop")
+	whailtest.AssertNotCalled(t, fake, "ContainerRename")
+}
+
+func TestContainerReplace_HealthWaitBoundedByContext(t *testing.T) {
+	fake, _ := newReplaceFake(container.Starting)
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := eng.ContainerReplace(ctx, "proxy", whail.ContainerCreateOptions{
+		Config: &container.Config{Image: "proxy:2"},
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	whailtest.AssertCalled(t, fake, "ContainerRemove")
+	whailtest.AssertNotCalled(t, fake, "ContainerStop")
+}
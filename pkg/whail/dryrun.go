@@ -0,0 +1,165 @@
+package whail
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/client"
+)
+
+// Operation is one mutating call on a managed resource: journaled by a
+// dry-run engine, otherwise reported to EngineOptions.OnMutation.
+type Operation struct {
+	Action   string `json:"action"`   // create, start, stop, kill, restart, remove, prune, exec, relabel
+	Resource string `json:"resource"` // container, volume, network, image
+	Target   string `json:"target"`   // name or ID as the caller passed it; empty for prunes
+	// Params carries request details worth recording (the image of a
+	// created container, the command of an exec). Nil for most calls.
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// String renders the operation as "remove volume foo".
+func (op Operation) String() string {
+	if op.Target == "" {
+		return op.Action + " " + op.Resource
+	}
+	return op.Action + " " + op.Resource + " " + op.Target
+}
+
+// DryRunOperation is one mutating call a dry-run engine skipped.
+type DryRunOperation = Operation
+
+// dryRunIDPrefix marks IDs synthesized for resources a dry run pretended to
+// create, so a later start on the same ID is journaled instead of failing
+// the managed-label check against a container that does not exist.
+const dryRunIDPrefix = "dry-run-"
+
+// dryRunJournal collects the operations of a dry-run engine. Safe for
+// concurrent use.
+type dryRunJournal struct {
+	mu  sync.Mutex
+	ops []DryRunOperation
+}
+
+// DryRun reports whether the engine was created with EngineOptions.DryRun.
+func (e *Engine) DryRun() bool {
+	return e.options.DryRun
+}
+
+// DryRunReport returns the operations a dry-run engine skipped, in call
+// order. It is nil when the engine is not in dry-run mode.
+func (e *Engine) DryRunReport() []DryRunOperation {
+	if e.journal == nil {
+		return nil
+	}
+	e.journal.mu.Lock()
+	defer e.journal.mu.Unlock()
+	return append([]DryRunOperation(nil), e.journal.ops...)
+}
+
+// skipDryRun journals the operation and reports true when the engine is in
+// dry-run mode; the caller then returns a synthesized result instead of
+// calling the daemon. Otherwise the operation goes to
+// EngineOptions.OnMutation and the caller proceeds. Called after the
+// managed-label checks, so a dry run still fails on targets the real call
+// would reject.
+func (e *Engine) skipDryRun(action, resource, target string) bool {
+	return e.skipDryRunOp(Operation{Action: action, Resource: resource, Target: target})
+}
+
+// skipDryRunOp is skipDryRun for an operation that carries Params.
+func (e *Engine) skipDryRunOp(op Operation) bool {
+	if e.journal == nil {
+		e.notifyMutation(op)
+		return false
+	}
+	e.journal.mu.Lock()
+	defer e.journal.mu.Unlock()
+	e.journal.ops = append(e.journal.ops, op)
+	return true
+}
+
+// notifyMutation reports an operation about to reach the daemon to
+// EngineOptions.OnMutation, when set.
+func (e *Engine) notifyMutation(op Operation) {
+	if e.options.OnMutation != nil {
+		e.options.OnMutation(op)
+	}
+}
+
+func isDryRunID(id string) bool {
+	return strings.HasPrefix(id, dryRunIDPrefix)
+}
+
+// dryRunVolumesPrune lists the volumes VolumesPrune would remove: unused
+// (dangling) managed volumes matching the filters and, unless all is set,
+// anonymous ones only.
+func (e *Engine) dryRunVolumesPrune(ctx context.Context, all bool, f client.Filters) (client.VolumePruneResult, error) {
+	list, err := e.APIClient.VolumeList(ctx, client.VolumeListOptions{Filters: f.Add("dangling", "true")})
+	if err != nil {
+		return client.VolumePruneResult{}, ErrVolumesPruneFailed(err)
+	}
+	var result client.VolumePruneResult
+	for _, v := range list.Items {
+		if _, anonymous := v.Labels["com.docker.volume.anonymous"]; !all && !anonymous {
+			continue
+		}
+		e.skipDryRun("remove", "volume", v.Name)
+		result.Report.VolumesDeleted = append(result.Report.VolumesDeleted, v.Name)
+	}
+	return result, nil
+}
+
+// dryRunNetworksPrune lists the managed networks NetworksPrune would
+// remove: those no container is attached to.
+func (e *Engine) dryRunNetworksPrune(ctx context.Context) (client.NetworkPruneResult, error) {
+	list, err := e.APIClient.NetworkList(ctx, client.NetworkListOptions{Filters: e.newManagedFilter()})
+	if err != nil {
+		return client.NetworkPruneResult{}, ErrNetworksPruneFailed(err)
+	}
+	var result client.NetworkPruneResult
+	for _, n := range list.Items {
+		info, err := e.APIClient.NetworkInspect(ctx, n.ID, client.NetworkInspectOptions{})
+		if err != nil {
+			return client.NetworkPruneResult{}, ErrNetworksPruneFailed(err)
+		}
+		if len(info.Network.Containers) > 0 {
+			continue
+		}
+		e.skipDryRun("remove", "network", n.Name)
+		result.Report.NetworksDeleted = append(result.Report.NetworksDeleted, n.Name)
+	}
+	return result, nil
+}
+
+// dryRunImagesPrune lists the managed images ImagesPrune would remove:
+// those no container uses, and with dangling set, untagged ones only.
+func (e *Engine) dryRunImagesPrune(ctx context.Context, dangling bool) (client.ImagePruneResult, error) {
+	images, err := e.ImageList(ctx, client.ImageListOptions{})
+	if err != nil {
+		return client.ImagePruneResult{}, ErrImagesPruneFailed(err)
+	}
+	containers, err := e.APIClient.ContainerList(ctx, client.ContainerListOptions{All: true})
+	if err != nil {
+		return client.ImagePruneResult{}, ErrImagesPruneFailed(err)
+	}
+	inUse := make(map[string]bool, len(containers.Items))
+	for _, c := range containers.Items {
+		inUse[c.ImageID] = true
+	}
+
+	var result client.ImagePruneResult
+	for _, img := range images.Items {
+		if inUse[img.ID] || (dangling && len(imageTags(img.RepoTags)) > 0) {
+			continue
+		}
+		e.skipDryRun("remove", "image", img.ID)
+		result.Report.ImagesDeleted = append(result.Report.ImagesDeleted, image.DeleteResponse{Deleted: img.ID})
+		if img.Size > 0 {
+			result.Report.SpaceReclaimed += uint64(img.Size)
+		}
+	}
+	return result, nil
+}
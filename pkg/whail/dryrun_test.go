@@ -0,0 +1,170 @@
+package whail_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/api/types/network"
+	"github.com/moby/moby/api/types/volume"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+func dryRunEngine(fake *whailtest.FakeAPIClient) *whail.Engine {
+	opts := whailtest.TestEngineOptions()
+	opts.DryRun = true
+	return whail.NewFromExisting(fake, opts)
+}
+
+// The fake panics on any call without a stub, so these tests also prove the
+// mutating SDK calls are never reached.
+func TestDryRun_JournalsMutatingCalls(t *testing.T) {
+	ctx := context.Background()
+	eng := dryRunEngine(whailtest.NewFakeAPIClient())
+	require.True(t, eng.DryRun())
+
+	created, err := eng.ContainerCreate(ctx, whail.ContainerCreateOptions{Name: "web", Config: &container.Config{Image: "alpine"}})
+	require.NoError(t, err)
+	_, err = eng.ContainerStart(ctx, whail.ContainerStartOptions{ContainerID: created.ID})
+	require.NoError(t, err)
+	_, err = eng.ContainerStop(ctx, "c1", nil)
+	require.NoError(t, err)
+	_, err = eng.ContainerRemove(ctx, "c1", true)
+	require.NoError(t, err)
+	_, err = eng.VolumeRemove(ctx, "vol", false)
+	require.NoError(t, err)
+	_, err = eng.NetworkRemove(ctx, "net")
+	require.NoError(t, err)
+	removed, err := eng.ImageRemove(ctx, "img", client.ImageRemoveOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "img", removed.Items[0].Deleted)
+
+	var got []string
+	for _, op := range eng.DryRunReport() {
+		got = append(got, op.String())
+	}
+	assert.Equal(t, []string{
+		"create container web",
+		"start container " + created.ID,
+		"stop container c1",
+		"remove container c1",
+		"remove volume vol",
+		"remove network net",
+		"remove image img",
+	}, got)
+}
+
+func TestDryRun_ManagedChecksStillApply(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		return whailtest.UnmanagedContainerInspect(id), nil
+	}
+	eng := dryRunEngine(fake)
+
+	_, err := eng.ContainerRemove(context.Background(), "foreign", true)
+	require.Error(t, err)
+	assert.Empty(t, eng.DryRunReport())
+}
+
+func TestDryRun_PrunesReportCandidates(t *testing.T) {
+	ctx := context.Background()
+	fake := whailtest.NewFakeAPIClient()
+	fake.VolumeListFn = func(context.Context, client.VolumeListOptions) (client.VolumeListResult, error) {
+		return client.VolumeListResult{Items: []volume.Volume{{Name: "named"}, {Name: "anon", Labels: map[string]string{"com.docker.volume.anonymous": ""}}}}, nil
+	}
+	fake.NetworkListFn = func(context.Context, client.NetworkListOptions) (client.NetworkListResult, error) {
+		return client.NetworkListResult{Items: []network.Summary{{Network: network.Network{Name: "idle", ID: "idle"}}, {Network: network.Network{Name: "busy", ID: "busy"}}}}, nil
+	}
+	fake.NetworkInspectFn = func(_ context.Context, name string, _ client.NetworkInspectOptions) (client.NetworkInspectResult, error) {
+		res := whailtest.ManagedNetworkInspect(name)
+		if name == "busy" {
+			res.Network.Containers = map[string]network.EndpointResource{"c1": {}}
+		}
+		return res, nil
+	}
+	eng := dryRunEngine(fake)
+
+	vols, err := eng.VolumesPrune(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"anon"}, vols.Report.VolumesDeleted)
+
+	vols, err = eng.VolumesPrune(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"named", "anon"}, vols.Report.VolumesDeleted)
+
+	nets, err := eng.NetworksPrune(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"idle"}, nets.Report.NetworksDeleted)
+
+	assert.Len(t, eng.DryRunReport(), 4)
+}
+
+func TestDryRun_ImagePruneManaged(t *testing.T) {
+	fake, removed := newPruneFake([]image.Summary{
+		pruneImage("old", "alpha", 0),
+		pruneImage("used", "alpha", 0),
+	}, "used")
+	eng := dryRunEngine(fake)
+
+	report, err := eng.ImagePruneManaged(context.Background(), whail.PrunePolicy{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old"}, reportIDs(report.Removed))
+	assert.Empty(t, *removed)
+	assert.Equal(t, []whail.DryRunOperation{{Action: "remove", Resource: "image", Target: "old"}}, eng.DryRunReport())
+}
+
+func TestDryRun_Disabled(t *testing.T) {
+	eng := whail.NewFromExisting(whailtest.NewFakeAPIClient(), whailtest.TestEngineOptions())
+	assert.False(t, eng.DryRun())
+	assert.Nil(t, eng.DryRunReport())
+}
+
+func TestOnMutation_ReportsCallsThatReachTheDaemon(t *testing.T) {
+	ctx := context.Background()
+	fake := whailtest.NewFakeAPIClient()
+	fake.VolumeRemoveFn = func(context.Context, string, client.VolumeRemoveOptions) (client.VolumeRemoveResult, error) {
+		return client.VolumeRemoveResult{}, nil
+	}
+	fake.VolumePruneFn = func(context.Context, client.VolumePruneOptions) (client.VolumePruneResult, error) {
+		return client.VolumePruneResult{}, nil
+	}
+	fake.ExecCreateFn = func(context.Context, string, client.ExecCreateOptions) (client.ExecCreateResult, error) {
+		return client.ExecCreateResult{ID: "e1"}, nil
+	}
+	var ops []whail.Operation
+	opts := whailtest.TestEngineOptions()
+	opts.OnMutation = func(op whail.Operation) { ops = append(ops, op) }
+	eng := whail.NewFromExisting(fake, opts)
+
+	_, err := eng.VolumeRemove(ctx, "vol", false)
+	require.NoError(t, err)
+	_, err = eng.VolumesPrune(ctx, true)
+	require.NoError(t, err)
+	_, err = eng.ExecCreate(ctx, "c1", client.ExecCreateOptions{Cmd: []string{"ls", "-la"}, User: "root"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []whail.Operation{
+		{Action: "remove", Resource: "volume", Target: "vol"},
+		{Action: "prune", Resource: "volume", Params: map[string]string{"all": "true"}},
+		{Action: "exec", Resource: "container", Target: "c1", Params: map[string]string{"cmd": "ls -la", "user": "root"}},
+	}, ops)
+}
+
+func TestOnMutation_NotCalledForSkippedCalls(t *testing.T) {
+	var ops []whail.Operation
+	opts := whailtest.TestEngineOptions()
+	opts.DryRun = true
+	opts.OnMutation = func(op whail.Operation) { ops = append(ops, op) }
+	eng := whail.NewFromExisting(whailtest.NewFakeAPIClient(), opts)
+
+	_, err := eng.VolumeRemove(context.Background(), "vol", false)
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+	assert.Len(t, eng.DryRunReport(), 1)
+}
@@ -3,8 +3,12 @@ package whail
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/moby/moby/api/types/system"
 	"github.com/moby/moby/client"
+	"github.com/moby/moby/client/pkg/versions"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // EngineOptions configures the behavior of the Engine.
@@ -19,13 +23,87 @@ type EngineOptions struct {
 	// the full key is "com.myapp.managed=true".
 	ManagedLabel string
 
+	// BaseImageLabel is the label key suffix under which images record the
+	// base image they were built FROM, as "repo:tag@digest". Read by
+	// ImageBaseDrift. Default: "base.image".
+	BaseImageLabel string
+
+	// LabelSchemaLabel is the label key suffix under which every created
+	// resource records the label schema version it was labeled under.
+	// Default: "label_schema".
+	LabelSchemaLabel string
+
+	// LabelSchemaVersion is the version of the caller's label scheme. When
+	// non-zero it is stamped on every created resource, and a managed
+	// resource recording an older version is reported by
+	// StaleLabelSchemas. Versions start at FirstLabelSchema, which is also
+	// what a resource recording no version counts as, so turning
+	// versioning on does not make every existing resource stale. Bump it
+	// whenever the scheme changes. Zero disables versioning.
+	LabelSchemaVersion int
+
+	// LabelMigrations upgrades labels recorded under an older schema,
+	// keyed by the version they upgrade from: LabelMigrations[1] turns
+	// version 1 labels into version 2 labels. MigrateLabels runs them in
+	// order; a missing step only needs the version stamp.
+	LabelMigrations map[int]LabelMigration
+
 	// Labels configures labels for different resource types.
 	Labels LabelConfig
+
+	// DryRun makes the mutating calls (container create/start/stop/kill/
+	// restart/remove, volume/network create/remove, image remove, and the
+	// prunes) skip the daemon: each returns a synthesized result and is
+	// journaled for DryRunReport. Managed-label checks and reads still run,
+	// so a dry run fails where the real call would.
+	DryRun bool
+
+	// OnMutation, when set, is called with each mutating call just before
+	// it is sent to the daemon: the calls DryRun journals, the prunes, and
+	// exec creation. It sees the request, not the outcome, so a call the
+	// daemon then rejects is still reported. Not called for calls a dry run
+	// skips. Runs synchronously on the caller's goroutine; keep it quick.
+	OnMutation func(Operation)
+
+	// Host is the daemon address: unix://, tcp://, npipe://, or
+	// ssh://[user@]host[:port][/socket] (tunneled through the remote
+	// `docker system dial-stdio`, so the remote machine needs the docker
+	// CLI). Empty uses DOCKER_HOST, then the platform default socket.
+	Host string
+
+	// TLSCertDir holds ca.pem, cert.pem, and key.pem for a tcp:// Host
+	// (e.g. a docker context's TLS material). Empty falls back to
+	// DOCKER_CERT_PATH and DOCKER_TLS_VERIFY.
+	TLSCertDir string
+
+	// MinAPIVersion is the oldest daemon API version the caller supports.
+	// NewWithOptions fails with ErrDockerAPIVersionUnsupported when the
+	// daemon reports an older one. Default: the moby client's minimum.
+	MinAPIVersion string
+
+	// TracerProvider, when set, receives one span per Docker API request
+	// (see tracingClientOpts), parented to the span in the call's context.
+	// Nil leaves the client on the global OTEL provider, a no-op unless the
+	// process installed one. Only NewWithOptions applies it — a client
+	// passed to NewFromExisting is already built.
+	TracerProvider trace.TracerProvider
+
+	// Compat presets the daemon runtime instead of detecting it. Nil makes
+	// NewWithOptions query /info (see DetectCompat); NewFromExisting, which
+	// never detects, treats nil as Docker Engine.
+	Compat *Compat
 }
 
 // DefaultManagedLabel is the default label suffix for marking managed resources.
 const DefaultManagedLabel = "managed"
 
+// DefaultBaseImageLabel is the default label suffix recording an image's base.
+const DefaultBaseImageLabel = "base.image"
+
+// DefaultLabelSchemaLabel is the default label suffix recording the label
+// schema version.
+const DefaultLabelSchemaLabel = "label_schema"
+
 // Engine wraps the Docker client with automatic label-based resource isolation.
 // All list operations automatically inject filters to only return resources
 // managed by this engine (identified by the configured label prefix).
@@ -43,6 +121,13 @@ type Engine struct {
 	// Precomputed values for efficiency
 	managedLabelKey   string // e.g., "com.myapp.managed"
 	managedLabelValue string // always "true"
+	baseImageLabelKey string // e.g., "com.myapp.base.image"
+	labelSchemaKey    string // e.g., "com.myapp.label_schema"
+
+	journal *dryRunJournal // non-nil only with EngineOptions.DryRun
+
+	compat Compat       // daemon runtime; adjusts requests for Podman
+	info   *system.Info // daemon /info from NewWithOptions; nil otherwise
 }
 
 // New creates a new Engine with default options.
@@ -52,17 +137,32 @@ func New(ctx context.Context) (*Engine, error) {
 }
 
 // NewWithOptions creates a new Engine with the given options.
-// It connects to the Docker daemon and verifies the connection.
+// It connects to the Docker daemon and verifies the connection and the
+// daemon's API version.
 func NewWithOptions(ctx context.Context, opts EngineOptions) (*Engine, error) {
 	// Apply defaults
 	if opts.ManagedLabel == "" {
 		opts.ManagedLabel = DefaultManagedLabel
 	}
+	if opts.BaseImageLabel == "" {
+		opts.BaseImageLabel = DefaultBaseImageLabel
+	}
+	if opts.LabelSchemaLabel == "" {
+		opts.LabelSchemaLabel = DefaultLabelSchemaLabel
+	}
+	if opts.MinAPIVersion == "" {
+		opts.MinAPIVersion = client.MinAPIVersion
+	}
 
 	// Create the underlying Docker client (moby/moby/client; version pinned in go.mod).
 	// client.New is lazy — it only configures the client, not connecting to
-	// the daemon. Connection errors surface at HealthCheck (Ping) below.
-	realClient, err := client.New(client.FromEnv)
+	// the daemon. Connection errors surface at the Ping below.
+	clientOpts, err := hostClientOpts(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker client: %w", err)
+	}
+	clientOpts = append(clientOpts, tracingClientOpts(opts.TracerProvider)...)
+	realClient, err := client.New(clientOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create docker client: %w", err)
 	}
@@ -77,14 +177,32 @@ func NewWithOptions(ctx context.Context, opts EngineOptions) (*Engine, error) {
 		options:           opts,
 		managedLabelKey:   opts.LabelPrefix + "." + opts.ManagedLabel,
 		managedLabelValue: "true",
+		baseImageLabelKey: opts.LabelPrefix + "." + opts.BaseImageLabel,
+		labelSchemaKey:    opts.LabelPrefix + "." + opts.LabelSchemaLabel,
 		// logger:    logger,
 	}
+	if opts.DryRun {
+		e.journal = &dryRunJournal{}
+	}
 
-	// Verify connectivity
-
-	if err := e.HealthCheck(ctx); err != nil {
+	// Verify connectivity and that the daemon speaks a new enough API.
+	ping, err := e.Ping(ctx, client.PingOptions{})
+	if err != nil {
 		return nil, ErrDockerHealthCheckFailed(err)
 	}
+	if ping.APIVersion != "" && versions.LessThan(ping.APIVersion, opts.MinAPIVersion) {
+		return nil, ErrDockerAPIVersionUnsupported(ping.APIVersion, opts.MinAPIVersion)
+	}
+	// A failed /info leaves the engine on Docker Engine behavior: the
+	// daemon answered the ping, so the failure is not a connection error.
+	// The /info answer is kept for DaemonInfo.
+	if res, err := e.APIClient.Info(ctx, client.InfoOptions{}); err == nil {
+		e.info = &res.Info
+		e.compat = compatFromInfo(res.Info)
+	}
+	if opts.Compat != nil {
+		e.compat = *opts.Compat
+	}
 	// logger.Printf("[Engine] Connected to Docker daemon")
 
 	return e, nil
@@ -99,13 +217,37 @@ func NewFromExisting(c client.APIClient, opts ...EngineOptions) *Engine {
 	if o.ManagedLabel == "" {
 		o.ManagedLabel = DefaultManagedLabel
 	}
+	if o.BaseImageLabel == "" {
+		o.BaseImageLabel = DefaultBaseImageLabel
+	}
+	if o.LabelSchemaLabel == "" {
+		o.LabelSchemaLabel = DefaultLabelSchemaLabel
+	}
 
-	return &Engine{
+	e := &Engine{
 		APIClient:         c,
 		options:           o,
 		managedLabelKey:   o.LabelPrefix + "." + o.ManagedLabel,
 		managedLabelValue: "true",
+		baseImageLabelKey: o.LabelPrefix + "." + o.BaseImageLabel,
+		labelSchemaKey:    o.LabelPrefix + "." + o.LabelSchemaLabel,
+	}
+	if o.DryRun {
+		e.journal = &dryRunJournal{}
+	}
+	if o.Compat != nil {
+		e.compat = *o.Compat
 	}
+	return e
+}
+
+// DaemonInfo returns the daemon's /info as NewWithOptions read it at
+// connect time. ok is false for NewFromExisting or when the call failed.
+func (e *Engine) DaemonInfo() (info system.Info, ok bool) {
+	if e.info == nil {
+		return system.Info{}, false
+	}
+	return *e.info, true
 }
 
 // HealthCheck verifies the Docker daemon is reachable.
@@ -124,6 +266,12 @@ func (e *Engine) ManagedLabelKey() string {
 	return e.managedLabelKey
 }
 
+// BaseImageLabelKey returns the full base-image label key
+// (e.g., "com.myapp.base.image"). Builders stamp it as "repo:tag@digest".
+func (e *Engine) BaseImageLabelKey() string {
+	return e.baseImageLabelKey
+}
+
 // ManagedLabelValue returns the managed label value (always "true").
 func (e *Engine) ManagedLabelValue() string {
 	return e.managedLabelValue
@@ -148,10 +296,20 @@ func (e *Engine) newManagedFilter() client.Filters {
 	return client.Filters{}.Add("label", e.managedLabelKey+"="+e.managedLabelValue)
 }
 
-// managedLabels returns the base labels that mark a resource as managed.
+// managedLabels returns the base labels that mark a resource as managed,
+// including the label schema version when versioning is on.
 func (e *Engine) managedLabels() map[string]string {
-	return map[string]string{
-		e.managedLabelKey: e.managedLabelValue,
+	labels := map[string]string{}
+	e.enforceManagedLabels(labels)
+	return labels
+}
+
+// enforceManagedLabels sets the managed label and the label schema version
+// on labels, overriding whatever the caller put there.
+func (e *Engine) enforceManagedLabels(labels map[string]string) {
+	labels[e.managedLabelKey] = e.managedLabelValue
+	if e.options.LabelSchemaVersion > 0 {
+		labels[e.labelSchemaKey] = strconv.Itoa(e.options.LabelSchemaVersion)
 	}
 }
 
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/moby/moby/client"
 )
 
 // ErrDockerNotAvailable is a sentinel error indicating the Docker daemon
@@ -52,6 +54,14 @@ func (e *DockerError) Is(target error) bool {
 	}
 }
 
+// IsDockerUnavailable reports whether err means the Docker daemon could not be
+// reached: a connect-phase DockerError (errors.Is ErrDockerNotAvailable), or a
+// moby connection failure anywhere in the chain — the daemon going away after
+// the health check surfaces from an ordinary operation, not from connect.
+func IsDockerUnavailable(err error) bool {
+	return errors.Is(err, ErrDockerNotAvailable) || client.IsErrConnectionFailed(err)
+}
+
 // FormatUserError formats the error for display to users with next steps.
 func (e *DockerError) FormatUserError() string {
 	var sb strings.Builder
@@ -91,6 +101,35 @@ func ErrDockerHealthCheckFailed(err error) *DockerError {
 	}
 }
 
+// ErrDockerAPIVersionUnsupported returns an error for a daemon whose API is
+// older than the engine's MinAPIVersion. Typically a remote host running an
+// old Docker Engine. It does not match ErrDockerNotAvailable: the daemon
+// answered, it is just too old.
+func ErrDockerAPIVersionUnsupported(daemonVersion, minVersion string) *DockerError {
+	return &DockerError{
+		Op:      "version",
+		Message: fmt.Sprintf("Docker daemon API version %s is too old; version %s or newer is required", daemonVersion, minVersion),
+		NextSteps: []string{
+			"Upgrade Docker Engine on the daemon host",
+			"Check which daemon you are connected to: docker version",
+		},
+	}
+}
+
+// ErrPodmanUnsupported returns an error for a feature the Podman
+// Docker-compatible API does not provide.
+func ErrPodmanUnsupported(feature string, err error) *DockerError {
+	return &DockerError{
+		Op:      "podman",
+		Err:     err,
+		Message: fmt.Sprintf("%s is not supported by Podman", feature),
+		NextSteps: []string{
+			"Run against Docker Engine or Docker Desktop to use this feature",
+			"Check which daemon you are connected to: docker version",
+		},
+	}
+}
+
 // ErrImageNotFound returns an error for when an image cannot be found.
 func ErrImageNotFound(image string, err error) *DockerError {
 	return &DockerError{
@@ -432,6 +471,46 @@ func ErrContainerUnpauseFailed(name string, err error) *DockerError {
 	}
 }
 
+// ErrCheckpointUnsupported returns an error for a daemon that cannot
+// checkpoint containers.
+func ErrCheckpointUnsupported(reason string) *DockerError {
+	return &DockerError{
+		Op:      "checkpoint",
+		Message: "Checkpoint/restore is not available: " + reason,
+		NextSteps: []string{
+			"Use a rootful Linux Docker Engine with experimental features on (\"experimental\": true in daemon.json)",
+			"Install CRIU on the daemon host: https://criu.org/Installation",
+		},
+	}
+}
+
+// ErrCheckpointFailed returns an error for when checkpointing a container fails.
+func ErrCheckpointFailed(name string, err error) *DockerError {
+	return &DockerError{
+		Op:      "checkpoint",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to checkpoint container '%s'", name),
+		NextSteps: []string{
+			"Check that the container is running: docker ps",
+			"Check that CRIU is installed on the daemon host: criu check",
+			"CRIU cannot capture open TCP connections; retry while the agent is idle",
+		},
+	}
+}
+
+// ErrCheckpointListFailed returns an error for when listing a container's
+// checkpoints fails.
+func ErrCheckpointListFailed(name string, err error) *DockerError {
+	return &DockerError{
+		Op:      "checkpoint",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to list checkpoints of container '%s'", name),
+		NextSteps: []string{
+			"Pass the directory the checkpoint was written to with --checkpoint-dir",
+		},
+	}
+}
+
 // ErrContainerRenameFailed returns an error for when renaming a container fails.
 func ErrContainerRenameFailed(name string, err error) *DockerError {
 	return &DockerError{
@@ -483,6 +562,31 @@ func ErrContainerUpdateFailed(name string, err error) *DockerError {
 	}
 }
 
+// ErrContainerDiffFailed returns an error for when listing container filesystem changes fails.
+func ErrContainerDiffFailed(name string, err error) *DockerError {
+	return &DockerError{
+		Op:      "diff",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to get filesystem changes for container '%s'", name),
+		NextSteps: []string{
+			"Check if the container exists: docker ps -a",
+		},
+	}
+}
+
+// ErrContainerCommitFailed returns an error for when committing a container to an image fails.
+func ErrContainerCommitFailed(name string, err error) *DockerError {
+	return &DockerError{
+		Op:      "commit",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to commit container '%s'", name),
+		NextSteps: []string{
+			"Check if the container exists: docker ps -a",
+			"Verify the image reference is valid (e.g. myimage:tag)",
+		},
+	}
+}
+
 // ErrCopyToContainerFailed returns an error for when copying to a container fails.
 func ErrCopyToContainerFailed(name string, err error) *DockerError {
 	return &DockerError{
@@ -601,6 +705,34 @@ func ErrExecStartFailed(execID string, err error) *DockerError {
 	}
 }
 
+// ErrExecInspectFailed returns an error for when inspecting an exec instance fails.
+func ErrExecInspectFailed(execID string, err error) *DockerError {
+	return &DockerError{
+		Op:      "exec_inspect",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to inspect exec instance '%s'", execID),
+		NextSteps: []string{
+			"Check if the exec instance is still valid",
+			"Verify the container is still running",
+		},
+	}
+}
+
+// ErrExecRunCanceled returns an error for when a ContainerExecRun command is
+// cancelled or times out before exiting. err is the context's error, so
+// errors.Is(err, context.DeadlineExceeded) detects a timeout.
+func ErrExecRunCanceled(containerID, command string, err error) *DockerError {
+	return &DockerError{
+		Op:      "exec_run",
+		Err:     err,
+		Message: fmt.Sprintf("Command '%s' in container '%s' did not finish", command, containerID),
+		NextSteps: []string{
+			"Raise the timeout if the command needs more time",
+			"Check whether the command is waiting on input or a lock",
+		},
+	}
+}
+
 // ErrVolumesPruneFailed returns an error for when pruning volumes fails.
 func ErrVolumesPruneFailed(err error) *DockerError {
 	return &DockerError{
@@ -653,6 +785,112 @@ func ErrImageListFailed(err error) *DockerError {
 	}
 }
 
+// ErrImageHistoryFailed returns an error for when reading an image's layer history fails.
+func ErrImageHistoryFailed(image string, err error) *DockerError {
+	return &DockerError{
+		Op:      "image_history",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to read history of image '%s'", image),
+		NextSteps: []string{
+			"Verify Docker daemon is running: docker info",
+			"Check the image exists: docker image inspect " + image,
+		},
+	}
+}
+
+// ErrImageTagFailed returns an error for when tagging an image fails.
+func ErrImageTagFailed(source, target string, err error) *DockerError {
+	return &DockerError{
+		Op:      "image_tag",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to tag image '%s' as '%s'", source, target),
+		NextSteps: []string{
+			"Verify Docker daemon is running: docker info",
+			"Check the target is a valid reference: " + target,
+		},
+	}
+}
+
+// ErrImageNotManaged returns an error for an image that lacks the managed
+// label — refused before a pull would replace it, or removed after a pull
+// brought it in.
+func ErrImageNotManaged(image string) *DockerError {
+	return &DockerError{
+		Op:      "managed_check",
+		Err:     nil,
+		Message: fmt.Sprintf("Image '%s' is not managed by this tool", image),
+		NextSteps: []string{
+			"Pull images that were built and pushed by this tool",
+			"Inspect the image's labels: docker image inspect " + image,
+		},
+	}
+}
+
+// ErrImagePushFailed returns an error for when pushing an image fails.
+func ErrImagePushFailed(image string, err error) *DockerError {
+	return &DockerError{
+		Op:      "image_push",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to push image '%s'", image),
+		NextSteps: []string{
+			"Check the reference names a registry you can push to",
+			"Log in to the registry: docker login",
+		},
+	}
+}
+
+// ErrImagePullFailed returns an error for when pulling an image fails.
+func ErrImagePullFailed(image string, err error) *DockerError {
+	return &DockerError{
+		Op:      "image_pull",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to pull image '%s'", image),
+		NextSteps: []string{
+			"Check the image name and tag are correct",
+			"Log in to the registry if it is private: docker login",
+		},
+	}
+}
+
+// ErrBaseImageUnrecorded returns an error for when an image carries no
+// "repo:tag@digest" base-image label to compare against its registry.
+func ErrBaseImageUnrecorded(image string) *DockerError {
+	return &DockerError{
+		Op:      "image_base_drift",
+		Message: fmt.Sprintf("Image '%s' does not record the base image it was built on", image),
+		NextSteps: []string{
+			"Rebuild the image so the base image is recorded",
+		},
+	}
+}
+
+// ErrBaseDigestLookupFailed returns an error for when the registry cannot
+// report the current digest of a base image.
+func ErrBaseDigestLookupFailed(ref string, err error) *DockerError {
+	return &DockerError{
+		Op:      "distribution_inspect",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to look up the current digest of '%s'", ref),
+		NextSteps: []string{
+			"Verify you have network access to the registry",
+			"Check the registry directly: docker buildx imagetools inspect " + ref,
+		},
+	}
+}
+
+// ErrBuildCacheUsageFailed returns an error for when reading the build cache fails.
+func ErrBuildCacheUsageFailed(err error) *DockerError {
+	return &DockerError{
+		Op:      "build_cache_usage",
+		Err:     err,
+		Message: "Failed to read the build cache",
+		NextSteps: []string{
+			"Verify Docker daemon is running: docker info",
+			"Check the build cache directly: docker buildx du",
+		},
+	}
+}
+
 // ErrImageRemoveFailed returns an error for when image removal fails.
 func ErrImageRemoveFailed(image string, err error) *DockerError {
 	return &DockerError{
@@ -732,3 +970,45 @@ func ErrNetworkDisconnectFailed(networkID, containerID string, err error) *Docke
 		},
 	}
 }
+
+// ErrContainerReplaceFailed returns an error for when a blue/green container replacement fails.
+func ErrContainerReplaceFailed(name string, err error) *DockerError {
+	return &DockerError{
+		Op:      "replace",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to replace container '%s'", name),
+		NextSteps: []string{
+			"Check the replacement's logs: docker logs " + name + replaceSuffix,
+			"Check the replacement's healthcheck: docker inspect --format '{{json .State.Health}}' " + name + replaceSuffix,
+			"Remove a leftover replacement before retrying: docker rm -f " + name + replaceSuffix,
+		},
+	}
+}
+
+// ErrContainerRelabelFailed returns an error for when recreating a container
+// under the current label schema fails.
+func ErrContainerRelabelFailed(name string, err error) *DockerError {
+	return &DockerError{
+		Op:      "relabel",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to relabel container '%s'", name),
+		NextSteps: []string{
+			"Stop the container before relabeling it: docker stop " + name,
+			"If the old container was left parked, restore its name: docker rename " + name + relabelSuffix + " " + name,
+		},
+	}
+}
+
+// ErrImageRelabelFailed returns an error for when rebuilding an image under
+// the current label schema fails.
+func ErrImageRelabelFailed(image string, err error) *DockerError {
+	return &DockerError{
+		Op:      "relabel",
+		Err:     err,
+		Message: fmt.Sprintf("Failed to relabel image '%s'", image),
+		NextSteps: []string{
+			"Verify Docker daemon is running: docker info",
+			"Rebuild the image instead, or remove it if it is no longer needed",
+		},
+	}
+}
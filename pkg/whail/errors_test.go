@@ -1,10 +1,14 @@
 package whail
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/moby/moby/client"
 )
 
 func TestDockerError_Error(t *testing.T) {
@@ -129,6 +133,39 @@ func TestErrDockerNotAvailable_SurvivesDoubleWrapping(t *testing.T) {
 	}
 }
 
+func TestIsDockerUnavailable(t *testing.T) {
+	// A real moby connection failure: nothing listens on this socket.
+	c, err := client.New(client.WithHost("unix://" + filepath.Join(t.TempDir(), "docker.sock")))
+	if err != nil {
+		t.Fatalf("client.New: %v", err)
+	}
+	_, connErr := c.Ping(context.Background(), client.PingOptions{})
+	if connErr == nil {
+		t.Fatal("Ping succeeded against a missing socket")
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"health check failure", ErrDockerHealthCheckFailed(errors.New("connection refused")), true},
+		{"wrapped health check failure", fmt.Errorf("connecting to Docker: %w", ErrDockerHealthCheckFailed(connErr)), true},
+		{"connection lost mid-operation", ErrContainerListFailed(connErr), true},
+		{"raw connection failure", fmt.Errorf("listing: %w", connErr), true},
+		{"other docker error", ErrContainerNotFound("dev"), false},
+		{"plain error", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDockerUnavailable(tt.err); got != tt.want {
+				t.Errorf("IsDockerUnavailable() = %v, want %v (err: %v)", got, tt.want, tt.err)
+			}
+		})
+	}
+}
+
 func TestErrImageNotFound(t *testing.T) {
 	err := ErrImageNotFound("myimage:latest", nil)
 
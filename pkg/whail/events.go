@@ -0,0 +1,17 @@
+package whail
+
+import (
+	"context"
+
+	"github.com/moby/moby/client"
+)
+
+// Events streams daemon events for managed resources. The managed label
+// filter is injected, so events of unmanaged containers, images, volumes
+// and networks never arrive; daemon-scoped events, which carry no labels,
+// are filtered out too. As with the moby client, cancel ctx to end the
+// stream; with opts.Until set, io.EOF arrives on Err once it is drained.
+func (e *Engine) Events(ctx context.Context, opts client.EventsListOptions) client.EventsResult {
+	opts.Filters = e.injectManagedFilter(opts.Filters)
+	return e.APIClient.Events(ctx, opts)
+}
@@ -0,0 +1,31 @@
+package whail_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/moby/api/types/events"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+func TestEvents_InjectsManagedFilter(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	var got client.EventsListOptions
+	fake.EventsFn = func(_ context.Context, opts client.EventsListOptions) client.EventsResult {
+		got = opts
+		return client.EventsResult{}
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	filters := make(client.Filters).Add("type", string(events.ContainerEventType))
+	eng.Events(context.Background(), client.EventsListOptions{Since: "10m", Filters: filters})
+
+	assert.Equal(t, "10m", got.Since)
+	assert.True(t, got.Filters["type"][string(events.ContainerEventType)])
+	assert.True(t, got.Filters["label"][testManagedKey+"=true"])
+	assert.NotContains(t, filters, "label", "the caller's filters are not mutated")
+}
@@ -0,0 +1,125 @@
+package whail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/moby/moby/api/pkg/stdcopy"
+)
+
+// execRunMarkerEnv tags every process a ContainerExecRun starts. Docker has
+// no API to kill an exec, so a cancelled run finds its processes — children
+// inherit the variable — by this marker and kills them.
+const execRunMarkerEnv = "WHAIL_EXEC_RUN"
+
+// execKillTimeout bounds the best-effort kill of a cancelled run.
+const execKillTimeout = 10 * time.Second
+
+// ExecRunOptions configures ContainerExecRun.
+type ExecRunOptions struct {
+	Cmd  []string // command and arguments; required
+	User string   // user[:group] to run as; empty uses the container's user
+	Env  []string // extra KEY=VALUE variables
+
+	// Timeout bounds the run. Zero means no limit beyond ctx.
+	Timeout time.Duration
+}
+
+// ContainerExecRun runs a command in a managed, running container and waits
+// for it, returning its demultiplexed stdout and stderr and its exit code.
+// A non-zero exit is not an error; err reports only failures to run the
+// command at all.
+//
+// When ctx is cancelled or Timeout elapses, the command and its children
+// are killed (best effort: the kill is itself an exec of sh, so it needs a
+// shell in the image) and err wraps ctx's error. The output captured up to
+// that point is returned and exitCode is -1.
+func (e *Engine) ContainerExecRun(ctx context.Context, containerID string, opts ExecRunOptions) (stdout, stderr string, exitCode int, err error) {
+	if len(opts.Cmd) == 0 {
+		return "", "", -1, ErrExecCreateFailed(containerID, errors.New("no command"))
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	marker := newExecRunMarker()
+	created, err := e.ExecCreate(ctx, containerID, ExecCreateOptions{
+		User:         opts.User,
+		Env:          append(slices.Clone(opts.Env), execRunMarkerEnv+"="+marker),
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          opts.Cmd,
+	})
+	if err != nil {
+		return "", "", -1, err
+	}
+	hijacked, err := e.APIClient.ExecAttach(ctx, created.ID, ExecAttachOptions{})
+	if err != nil {
+		return "", "", -1, ErrExecAttachFailed(created.ID, err)
+	}
+	defer hijacked.Close()
+
+	var outBuf, errBuf bytes.Buffer
+	copied := make(chan error, 1)
+	go func() {
+		_, err := stdcopy.StdCopy(&outBuf, &errBuf, hijacked.Reader)
+		copied <- err
+	}()
+
+	select {
+	case err = <-copied:
+	case <-ctx.Done():
+		// Closing the connection unblocks the copy but leaves the command
+		// running in the container; kill it explicitly.
+		hijacked.Close()
+		<-copied
+		e.killExecRun(containerID, opts.User, marker)
+		return outBuf.String(), errBuf.String(), -1, ErrExecRunCanceled(containerID, opts.Cmd[0], ctx.Err())
+	}
+	if err != nil {
+		return outBuf.String(), errBuf.String(), -1, ErrExecAttachFailed(created.ID, err)
+	}
+
+	inspect, err := e.APIClient.ExecInspect(ctx, created.ID, ExecInspectOptions{})
+	if err != nil {
+		return outBuf.String(), errBuf.String(), -1, ErrExecInspectFailed(created.ID, err)
+	}
+	return outBuf.String(), errBuf.String(), inspect.ExitCode, nil
+}
+
+// killExecRun SIGKILLs every process in the container carrying marker. It
+// runs as the same user, which can read those processes' environments and
+// signal them. Failures are ignored: the run has already failed with the
+// cancellation, and a missing shell only means the command outlives it.
+func (e *Engine) killExecRun(containerID, user, marker string) {
+	ctx, cancel := context.WithTimeout(context.Background(), execKillTimeout)
+	defer cancel()
+
+	script := fmt.Sprintf(`for d in /proc/[0-9]*; do
+  case "$(tr '\0' '\n' < "$d/environ" 2>/dev/null)" in
+    *%s=%s*) kill -KILL "${d#/proc/}" 2>/dev/null ;;
+  esac
+done`, execRunMarkerEnv, marker)
+	created, err := e.APIClient.ExecCreate(ctx, containerID, ExecCreateOptions{
+		User: user,
+		Cmd:  []string{"sh", "-c", script},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = e.APIClient.ExecStart(ctx, created.ID, ExecStartOptions{Detach: true})
+}
+
+func newExecRunMarker() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,155 @@
+package whail_test
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+// stdcopy stream IDs as framed by the daemon.
+const (
+	streamStdout = 1
+	streamStderr = 2
+)
+
+// execAttachResult returns a multiplexed hijacked connection. write runs on
+// the server side; the connection closes when it returns.
+func execAttachResult(write func(conn net.Conn)) client.ExecAttachResult {
+	clientConn, serverConn := net.Pipe()
+	go func() {
+		defer serverConn.Close()
+		write(serverConn)
+	}()
+	return client.ExecAttachResult{
+		HijackedResponse: client.NewHijackedResponse(clientConn, "application/vnd.docker.multiplexed-stream"),
+	}
+}
+
+func writeFrame(conn net.Conn, stream byte, data string) {
+	header := make([]byte, 8)
+	header[0] = stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(data)))
+	_, _ = conn.Write(append(header, data...))
+}
+
+func newExecFake(t *testing.T) (*whailtest.FakeAPIClient, *[]client.ExecCreateOptions) {
+	t.Helper()
+	fake := whailtest.NewFakeAPIClient()
+	fake.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		return whailtest.ManagedContainerInspect(id), nil
+	}
+	var (
+		mu      sync.Mutex
+		creates []client.ExecCreateOptions
+	)
+	fake.ExecCreateFn = func(_ context.Context, _ string, opts client.ExecCreateOptions) (client.ExecCreateResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		creates = append(creates, opts)
+		return client.ExecCreateResult{ID: fmt.Sprintf("exec-%d", len(creates))}, nil
+	}
+	return fake, &creates
+}
+
+func TestContainerExecRun(t *testing.T) {
+	fake, creates := newExecFake(t)
+	fake.ExecAttachFn = func(context.Context, string, client.ExecAttachOptions) (client.ExecAttachResult, error) {
+		return execAttachResult(func(conn net.Conn) {
+			writeFrame(conn, streamStdout, "hello\n")
+			writeFrame(conn, streamStderr, "warning\n")
+			writeFrame(conn, streamStdout, "world\n")
+		}), nil
+	}
+	fake.ExecInspectFn = func(_ context.Context, id string, _ client.ExecInspectOptions) (client.ExecInspectResult, error) {
+		assert.Equal(t, "exec-1", id)
+		return client.ExecInspectResult{ExitCode: 3}, nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	stdout, stderr, exitCode, err := eng.ContainerExecRun(context.Background(), "c1", whail.ExecRunOptions{
+		Cmd:  []string{"sh", "-c", "echo"},
+		User: "1000:1000",
+		Env:  []string{"FOO=bar"},
+	})
+	require.NoError(t, err, "a non-zero exit is not an error")
+	assert.Equal(t, "hello\nworld\n", stdout)
+	assert.Equal(t, "warning\n", stderr)
+	assert.Equal(t, 3, exitCode)
+
+	require.Len(t, *creates, 1)
+	got := (*creates)[0]
+	assert.Equal(t, "1000:1000", got.User)
+	assert.Equal(t, []string{"sh", "-c", "echo"}, got.Cmd)
+	assert.True(t, got.AttachStdout)
+	assert.True(t, got.AttachStderr)
+	assert.Equal(t, "FOO=bar", got.Env[0])
+	assert.True(t, strings.HasPrefix(got.Env[1], "WHAIL_EXEC_RUN="), "run marker must be set: %v", got.Env)
+}
+
+func TestContainerExecRun_TimeoutKills(t *testing.T) {
+	fake, creates := newExecFake(t)
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	fake.ExecAttachFn = func(context.Context, string, client.ExecAttachOptions) (client.ExecAttachResult, error) {
+		return execAttachResult(func(conn net.Conn) {
+			writeFrame(conn, streamStdout, "partial\n")
+			<-release // the command never exits on its own
+		}), nil
+	}
+	started := make(chan client.ExecStartOptions, 1)
+	fake.ExecStartFn = func(_ context.Context, _ string, opts client.ExecStartOptions) (client.ExecStartResult, error) {
+		started <- opts
+		return client.ExecStartResult{}, nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	stdout, _, exitCode, err := eng.ContainerExecRun(context.Background(), "c1", whail.ExecRunOptions{
+		Cmd:     []string{"sleep", "infinity"},
+		User:    "agent",
+		Timeout: 50 * time.Millisecond,
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "partial\n", stdout)
+	assert.Equal(t, -1, exitCode)
+
+	require.Len(t, *creates, 2, "a second exec kills the run")
+	marker := (*creates)[0].Env[0]
+	kill := (*creates)[1]
+	assert.Equal(t, "agent", kill.User)
+	require.Len(t, kill.Cmd, 3)
+	assert.Equal(t, []string{"sh", "-c"}, kill.Cmd[:2])
+	assert.Contains(t, kill.Cmd[2], marker)
+	assert.True(t, (<-started).Detach)
+	whailtest.AssertNotCalled(t, fake, "ExecInspect")
+}
+
+func TestContainerExecRun_RejectsUnmanaged(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		return whailtest.UnmanagedContainerInspect(id), nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	_, _, exitCode, err := eng.ContainerExecRun(context.Background(), "c1", whail.ExecRunOptions{Cmd: []string{"id"}})
+	require.Error(t, err)
+	assert.Equal(t, -1, exitCode)
+	whailtest.AssertNotCalled(t, fake, "ExecCreate")
+}
+
+func TestContainerExecRun_NoCommand(t *testing.T) {
+	eng := whail.NewFromExisting(whailtest.NewFakeAPIClient(), whailtest.TestEngineOptions())
+	_, _, _, err := eng.ContainerExecRun(context.Background(), "c1", whail.ExecRunOptions{})
+	assert.ErrorContains(t, err, "no command")
+}
@@ -0,0 +1,200 @@
+package whail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moby/moby/client"
+)
+
+// hostClientOpts returns the moby client options that point the client at
+// opts.Host, or at DOCKER_HOST when Host is empty. TLS material comes from
+// opts.TLSCertDir, else DOCKER_CERT_PATH and DOCKER_TLS_VERIFY. An ssh:// host tunnels the API through
+// `ssh ... docker system dial-stdio` on the remote machine, the same
+// transport the docker CLI uses for ssh contexts.
+func hostClientOpts(opts EngineOptions) ([]client.Opt, error) {
+	if opts.Host == "" {
+		return []client.Opt{client.FromEnv}, nil
+	}
+	base := []client.Opt{client.WithAPIVersionFromEnv()}
+	if strings.HasPrefix(opts.Host, "ssh://") {
+		args, err := sshArgs(opts.Host)
+		if err != nil {
+			return nil, err
+		}
+		dial := func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return newCommandConn(ctx, "ssh", args...)
+		}
+		// The host is a placeholder: every connection goes through dial.
+		return append(base, client.WithHost("http://docker.example.com"), client.WithDialContext(dial)), nil
+	}
+	if opts.TLSCertDir == "" {
+		// Replaces the HTTP client, so it must precede WithHost.
+		return append(base, client.WithTLSClientConfigFromEnv(), client.WithHost(opts.Host)), nil
+	}
+	return append(base, client.WithHost(opts.Host), client.WithTLSClientConfig(
+		filepath.Join(opts.TLSCertDir, "ca.pem"),
+		filepath.Join(opts.TLSCertDir, "cert.pem"),
+		filepath.Join(opts.TLSCertDir, "key.pem"),
+	)), nil
+}
+
+// sshArgs parses an ssh://[user@]host[:port][/socket] URL into the `ssh`
+// arguments for one API connection. A path names the remote daemon socket;
+// without one the remote docker CLI picks its default.
+func sshArgs(host string) ([]string, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ssh docker host %q: %w", host, err)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid ssh docker host %q: no host name", host)
+	}
+	if _, hasPassword := u.User.Password(); hasPassword {
+		return nil, fmt.Errorf("invalid ssh docker host %q: passwords are not supported; use an ssh key or agent", host)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("invalid ssh docker host %q: query and fragment are not supported", host)
+	}
+
+	args := []string{"-o", "ConnectTimeout=30"}
+	if user := u.User.Username(); user != "" {
+		args = append(args, "-l", user)
+	}
+	if port := u.Port(); port != "" {
+		args = append(args, "-p", port)
+	}
+	args = append(args, "--", u.Hostname(), "docker")
+	if u.Path != "" && u.Path != "/" {
+		args = append(args, "--host", "unix://"+u.Path)
+	}
+	return append(args, "system", "dial-stdio"), nil
+}
+
+// commandConn is a net.Conn over a child process's stdin and stdout. The
+// process outlives the dial context — the HTTP transport pools the
+// connection past the request that opened it — and is killed on Close.
+type commandConn struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	stderr *lockedBuffer
+
+	closeOnce sync.Once
+	waitOnce  sync.Once
+}
+
+func newCommandConn(ctx context.Context, name string, args ...string) (*commandConn, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(name, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr := &lockedBuffer{}
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting %s: %w", name, err)
+	}
+	return &commandConn{cmd: cmd, stdin: stdin, stdout: stdout, stderr: stderr}, nil
+}
+
+// Read returns the process's stdout. When the process exits before the
+// caller is done, the error carries its stderr — for ssh that is the
+// "connection refused" or "docker: command not found" the user needs.
+func (c *commandConn) Read(p []byte) (int, error) {
+	n, err := c.stdout.Read(p)
+	if err == io.EOF {
+		// Reap the process so its stderr is fully collected.
+		c.wait()
+		if msg := c.stderrText(); msg != "" {
+			return n, fmt.Errorf("%s exited: %s", c.cmd.Path, msg)
+		}
+	}
+	return n, err
+}
+
+func (c *commandConn) Write(p []byte) (int, error) {
+	n, err := c.stdin.Write(p)
+	if err != nil {
+		if msg := c.stderrText(); msg != "" {
+			return n, fmt.Errorf("%s exited: %s", c.cmd.Path, msg)
+		}
+	}
+	return n, err
+}
+
+func (c *commandConn) stderrText() string {
+	return strings.TrimSpace(c.stderr.String())
+}
+
+// CloseWrite half-closes the connection by closing the process's stdin, so
+// an attached stream can signal EOF and still read the remaining output.
+func (c *commandConn) CloseWrite() error {
+	return c.stdin.Close()
+}
+
+// Close ends the connection and reaps the process.
+func (c *commandConn) Close() error {
+	c.closeOnce.Do(func() {
+		_ = c.stdin.Close()
+		if c.cmd.Process != nil {
+			_ = c.cmd.Process.Kill()
+		}
+		c.wait()
+	})
+	return nil
+}
+
+// wait reaps the process once; Read at EOF and Close may both get here.
+func (c *commandConn) wait() {
+	c.waitOnce.Do(func() { _ = c.cmd.Wait() })
+}
+
+func (c *commandConn) LocalAddr() net.Addr  { return dummyAddr{} }
+func (c *commandConn) RemoteAddr() net.Addr { return dummyAddr{} }
+
+// Deadlines are not supported on pipes; the HTTP transport's own timeouts
+// and request contexts bound each call.
+func (c *commandConn) SetDeadline(time.Time) error      { return nil }
+func (c *commandConn) SetReadDeadline(time.Time) error  { return nil }
+func (c *commandConn) SetWriteDeadline(time.Time) error { return nil }
+
+type dummyAddr struct{}
+
+func (dummyAddr) Network() string { return "command" }
+func (dummyAddr) String() string  { return "command" }
+
+// lockedBuffer collects the process's stderr; it is read while the process
+// may still be writing.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -3,7 +3,10 @@ package whail
 import (
 	"context"
 	"io"
+	"strconv"
 
+	"github.com/moby/moby/api/types/build"
+	"github.com/moby/moby/api/types/image"
 	"github.com/moby/moby/client"
 )
 
@@ -16,8 +19,8 @@ func (e *Engine) ImageBuild(ctx context.Context, buildContext io.Reader, options
 		e.imageLabels(),
 		options.Labels,
 	)
-	// Ensure managed label cannot be overridden by caller labels.
-	optsCopy.Labels[e.managedLabelKey] = e.managedLabelValue
+	// Ensure the managed and label-schema labels cannot be overridden by caller labels.
+	e.enforceManagedLabels(optsCopy.Labels)
 
 	resp, err := e.APIClient.ImageBuild(ctx, buildContext, optsCopy)
 	if err != nil {
@@ -35,6 +38,11 @@ func (e *Engine) ImageBuildKit(ctx context.Context, opts ImageBuildKitOptions) e
 	if e.BuildKitImageBuilder == nil {
 		return ErrBuildKitNotConfigured()
 	}
+	// Podman builds through Buildah behind the classic /build endpoint and
+	// serves no BuildKit session.
+	if e.compat.Podman {
+		return ErrPodmanUnsupported("BuildKit", nil)
+	}
 
 	// Copy options to avoid mutating caller's struct
 	optsCopy := opts
@@ -42,8 +50,8 @@ func (e *Engine) ImageBuildKit(ctx context.Context, opts ImageBuildKitOptions) e
 		e.imageLabels(),
 		opts.Labels,
 	)
-	// Ensure managed label cannot be overridden by caller labels.
-	optsCopy.Labels[e.managedLabelKey] = e.managedLabelValue
+	// Ensure the managed and label-schema labels cannot be overridden by caller labels.
+	e.enforceManagedLabels(optsCopy.Labels)
 
 	return e.BuildKitImageBuilder(ctx, optsCopy)
 }
@@ -54,6 +62,9 @@ func (e *Engine) ImageRemove(ctx context.Context, imageID string, options client
 	if err != nil || !isManaged {
 		return client.ImageRemoveResult{}, ErrImageNotFound(imageID, err)
 	}
+	if e.skipDryRun("remove", "image", imageID) {
+		return client.ImageRemoveResult{Items: []image.DeleteResponse{{Deleted: imageID}}}, nil
+	}
 	result, err := e.APIClient.ImageRemove(ctx, imageID, options)
 	if err != nil {
 		return client.ImageRemoveResult{}, ErrImageRemoveFailed(imageID, err)
@@ -85,6 +96,42 @@ func (e *Engine) ImageInspect(ctx context.Context, imageRef string) (client.Imag
 	return result, nil
 }
 
+// ImageHistory returns the layer history of a managed image, newest layer first.
+func (e *Engine) ImageHistory(ctx context.Context, imageRef string) (client.ImageHistoryResult, error) {
+	isManaged, err := e.isManagedImage(ctx, imageRef)
+	if err != nil || !isManaged {
+		return client.ImageHistoryResult{}, ErrImageNotFound(imageRef, err)
+	}
+	result, err := e.APIClient.ImageHistory(ctx, imageRef)
+	if err != nil {
+		return client.ImageHistoryResult{}, ErrImageHistoryFailed(imageRef, err)
+	}
+	return result, nil
+}
+
+// ImageTag adds target as a reference to the managed image source.
+func (e *Engine) ImageTag(ctx context.Context, source, target string) error {
+	isManaged, err := e.isManagedImage(ctx, source)
+	if err != nil || !isManaged {
+		return ErrImageNotFound(source, err)
+	}
+	if _, err := e.APIClient.ImageTag(ctx, client.ImageTagOptions{Source: source, Target: target}); err != nil {
+		return ErrImageTagFailed(source, target, err)
+	}
+	return nil
+}
+
+// BuildCacheRecords returns the daemon's BuildKit build cache records.
+// The build cache carries no labels, so the records are daemon-wide; they are
+// read-only metadata used to tell which image layers are still cached.
+func (e *Engine) BuildCacheRecords(ctx context.Context) ([]build.CacheRecord, error) {
+	result, err := e.APIClient.DiskUsage(ctx, client.DiskUsageOptions{BuildCache: true, Verbose: true})
+	if err != nil {
+		return nil, ErrBuildCacheUsageFailed(err)
+	}
+	return result.BuildCache.Items, nil
+}
+
 // isManagedImage checks if an image has the managed label.
 func (e *Engine) isManagedImage(ctx context.Context, imageRef string) (bool, error) {
 	result, err := e.APIClient.ImageInspect(ctx, imageRef)
@@ -100,6 +147,10 @@ func (e *Engine) isManagedImage(ctx context.Context, imageRef string) (bool, err
 // The dangling parameter controls whether to only remove dangling images (untagged)
 // or all unused images.
 func (e *Engine) ImagesPrune(ctx context.Context, dangling bool) (client.ImagePruneResult, error) {
+	if e.DryRun() {
+		return e.dryRunImagesPrune(ctx, dangling)
+	}
+	e.notifyMutation(Operation{Action: "prune", Resource: "image", Params: map[string]string{"dangling": strconv.FormatBool(dangling)}})
 	f := e.newManagedFilter()
 	// dangling=true means only remove images without tags
 	// dangling=false means remove all unused images
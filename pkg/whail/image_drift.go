@@ -0,0 +1,57 @@
+package whail
+
+import (
+	"context"
+	"strings"
+
+	"github.com/moby/moby/client"
+)
+
+// BaseDrift compares the base image a managed image was built on with what
+// its registry serves for the same reference today.
+type BaseDrift struct {
+	// Image is the inspected image reference.
+	Image string
+	// BaseRef is the base image's repository and tag, without digest
+	// (e.g. "debian:bookworm-slim").
+	BaseRef string
+	// Recorded is the base image digest stamped at build time.
+	Recorded string
+	// Current is the digest the registry serves for BaseRef now.
+	Current string
+	// Drifted is true when Current differs from Recorded: a rebuild on the
+	// current base would pick up whatever changed upstream.
+	Drifted bool
+}
+
+// ImageBaseDrift reads the base image reference recorded on the managed image
+// imageTag (the base-image label, "repo:tag@digest") and asks the registry
+// for that tag's current digest. The registry call is anonymous, so a private
+// base fails with ErrBaseDigestLookupFailed.
+func (e *Engine) ImageBaseDrift(ctx context.Context, imageTag string) (BaseDrift, error) {
+	result, err := e.ImageInspect(ctx, imageTag)
+	if err != nil {
+		return BaseDrift{}, err
+	}
+	var recorded string
+	if result.Config != nil {
+		recorded = result.Config.Labels[e.baseImageLabelKey]
+	}
+	ref, digest, ok := strings.Cut(recorded, "@")
+	if !ok || ref == "" || digest == "" {
+		return BaseDrift{}, ErrBaseImageUnrecorded(imageTag)
+	}
+
+	dist, err := e.APIClient.DistributionInspect(ctx, ref, client.DistributionInspectOptions{})
+	if err != nil {
+		return BaseDrift{}, ErrBaseDigestLookupFailed(ref, err)
+	}
+	current := dist.Descriptor.Digest.String()
+	return BaseDrift{
+		Image:    imageTag,
+		BaseRef:  ref,
+		Recorded: digest,
+		Current:  current,
+		Drifted:  current != digest,
+	}, nil
+}
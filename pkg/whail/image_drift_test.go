@@ -0,0 +1,108 @@
+package whail_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/moby/moby/api/types/registry"
+	"github.com/moby/moby/client"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+const (
+	driftRecorded = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	driftCurrent  = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+// newDriftFake serves a managed image recording base and a registry that
+// reports current for every reference.
+func newDriftFake(base, current string) (*whailtest.FakeAPIClient, *string) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ImageInspectFn = func(_ context.Context, ref string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+		res := whailtest.ManagedImageInspect(ref)
+		if base != "" {
+			res.Config.Labels[whailtest.TestLabelPrefix+"."+whail.DefaultBaseImageLabel] = base
+		}
+		return res, nil
+	}
+	var asked string
+	fake.DistributionInspectFn = func(_ context.Context, ref string, _ client.DistributionInspectOptions) (client.DistributionInspectResult, error) {
+		asked = ref
+		return client.DistributionInspectResult{DistributionInspect: registry.DistributionInspect{
+			Descriptor: ocispec.Descriptor{Digest: digest.Digest(current)},
+		}}, nil
+	}
+	return fake, &asked
+}
+
+func TestImageBaseDrift(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		drifted bool
+	}{
+		{name: "registry matches the recorded digest", current: driftRecorded},
+		{name: "registry moved on", current: driftCurrent, drifted: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, asked := newDriftFake("debian:bookworm-slim@"+driftRecorded, tt.current)
+			eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+			drift, err := eng.ImageBaseDrift(context.Background(), "app:latest")
+			require.NoError(t, err)
+			assert.Equal(t, "debian:bookworm-slim", *asked, "the registry is asked for the tag, not the pinned digest")
+			assert.Equal(t, whail.BaseDrift{
+				Image:    "app:latest",
+				BaseRef:  "debian:bookworm-slim",
+				Recorded: driftRecorded,
+				Current:  tt.current,
+				Drifted:  tt.drifted,
+			}, drift)
+		})
+	}
+}
+
+func TestImageBaseDrift_Unrecorded(t *testing.T) {
+	for _, base := range []string{"", "debian:bookworm-slim"} {
+		fake, _ := newDriftFake(base, driftCurrent)
+		eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+		_, err := eng.ImageBaseDrift(context.Background(), "app:latest")
+		var derr *whail.DockerError
+		require.ErrorAs(t, err, &derr, "base label %q", base)
+		assert.Equal(t, "image_base_drift", derr.Op)
+		assert.NotContains(t, fake.Calls, "DistributionInspect")
+	}
+}
+
+func TestImageBaseDrift_RegistryError(t *testing.T) {
+	fake, _ := newDriftFake("debian:bookworm-slim@"+driftRecorded, driftCurrent)
+	fake.DistributionInspectFn = func(context.Context, string, client.DistributionInspectOptions) (client.DistributionInspectResult, error) {
+		return client.DistributionInspectResult{}, errors.New("unauthorized")
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	_, err := eng.ImageBaseDrift(context.Background(), "app:latest")
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "unauthorized")
+}
+
+func TestImageBaseDrift_Unmanaged(t *testing.T) {
+	fake, _ := newDriftFake("debian:bookworm-slim@"+driftRecorded, driftCurrent)
+	fake.ImageInspectFn = func(_ context.Context, ref string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+		return whailtest.UnmanagedImageInspect(ref), nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	_, err := eng.ImageBaseDrift(context.Background(), "debian:bookworm-slim")
+	require.Error(t, err)
+	assert.NotContains(t, fake.Calls, "DistributionInspect")
+}
@@ -0,0 +1,176 @@
+package whail
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/client"
+)
+
+// PrunePolicy selects which managed images ImagePruneManaged removes. An
+// image is removed only when every rule allows it; the zero policy removes
+// every managed image no container uses.
+type PrunePolicy struct {
+	// KeepLast keeps the newest KeepLast images of each group.
+	KeepLast int
+
+	// OlderThan keeps images built less than OlderThan ago. Zero disables
+	// the age rule.
+	OlderThan time.Duration
+
+	// KeepTagged keeps every image that still has a tag, so only images a
+	// rebuild left untagged are candidates.
+	KeepTagged bool
+
+	// GroupBy lists the label keys that partition images for KeepLast:
+	// images agreeing on every key form one group (e.g. one project's
+	// builds of one harness). Empty puts every image in a single group.
+	GroupBy []string
+
+	// CreatedLabel names a label holding the image's RFC 3339 build time.
+	// Images without it, or with an unparsable value, fall back to the
+	// daemon's creation time.
+	CreatedLabel string
+
+	// DryRun evaluates the policy without removing anything; the report
+	// lists what would be removed.
+	DryRun bool
+}
+
+// PrunedImage is one image ImagePruneManaged considered for removal.
+type PrunedImage struct {
+	ID      string
+	Tags    []string
+	Size    int64
+	Created time.Time
+
+	// SkipReason says why a candidate was not removed. Empty for removed
+	// images.
+	SkipReason string
+}
+
+// PruneReport is the outcome of ImagePruneManaged.
+type PruneReport struct {
+	Removed []PrunedImage
+	Skipped []PrunedImage
+
+	// SpaceReclaimed sums the sizes of the removed images. Layers shared
+	// with kept images are not actually freed, so it is an upper bound.
+	SpaceReclaimed int64
+}
+
+// ImagePruneManaged removes the managed images policy selects. An image any
+// container references (running or not, managed or not) is never removed.
+// A removal the daemon refuses as a conflict — typically a base image with
+// child images — is reported in Skipped rather than failing the prune. Any
+// other removal failure stops the prune; the report covers the images
+// handled before it.
+func (e *Engine) ImagePruneManaged(ctx context.Context, policy PrunePolicy) (PruneReport, error) {
+	var report PruneReport
+	policy.DryRun = policy.DryRun || e.DryRun()
+
+	images, err := e.ImageList(ctx, client.ImageListOptions{})
+	if err != nil {
+		return report, err
+	}
+	// Deliberately unfiltered: an unmanaged container can still run a
+	// managed image, and removing it out from under that container would
+	// be a surprise.
+	containers, err := e.APIClient.ContainerList(ctx, client.ContainerListOptions{All: true})
+	if err != nil {
+		return report, ErrContainerListFailed(err)
+	}
+	inUse := make(map[string]bool, len(containers.Items))
+	for _, c := range containers.Items {
+		inUse[c.ImageID] = true
+	}
+
+	groups := make(map[string][]PrunedImage)
+	for _, img := range images.Items {
+		key := pruneGroupKey(img.Labels, policy.GroupBy)
+		groups[key] = append(groups[key], PrunedImage{
+			ID:      img.ID,
+			Tags:    imageTags(img.RepoTags),
+			Size:    img.Size,
+			Created: imageCreated(img.Created, img.Labels, policy.CreatedLabel),
+		})
+	}
+
+	now := time.Now()
+	var candidates []PrunedImage
+	for _, key := range slices.Sorted(maps.Keys(groups)) {
+		group := groups[key]
+		slices.SortFunc(group, func(a, b PrunedImage) int {
+			// Newest first; ID breaks ties so the order is deterministic.
+			return cmp.Or(b.Created.Compare(a.Created), strings.Compare(a.ID, b.ID))
+		})
+		for i, img := range group {
+			switch {
+			case i < policy.KeepLast:
+			case policy.OlderThan > 0 && now.Sub(img.Created) < policy.OlderThan:
+			case policy.KeepTagged && len(img.Tags) > 0:
+			case inUse[img.ID]:
+				img.SkipReason = "in use by a container"
+				report.Skipped = append(report.Skipped, img)
+			default:
+				candidates = append(candidates, img)
+			}
+		}
+	}
+
+	for _, img := range candidates {
+		if !policy.DryRun {
+			// Force removes every tag of a multi-tagged image; containers
+			// were ruled out above.
+			_, err := e.APIClient.ImageRemove(ctx, img.ID, client.ImageRemoveOptions{Force: true, PruneChildren: true})
+			if cerrdefs.IsConflict(err) {
+				img.SkipReason = err.Error()
+				report.Skipped = append(report.Skipped, img)
+				continue
+			}
+			if err != nil {
+				return report, ErrImageRemoveFailed(img.ID, err)
+			}
+		}
+		e.skipDryRun("remove", "image", img.ID)
+		report.Removed = append(report.Removed, img)
+		report.SpaceReclaimed += img.Size
+	}
+	return report, nil
+}
+
+// pruneGroupKey joins the values of keys in labels into a group key.
+func pruneGroupKey(labels map[string]string, keys []string) string {
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = labels[k]
+	}
+	return strings.Join(values, "\x00")
+}
+
+// imageCreated returns the build time from createdLabel, falling back to
+// the daemon's creation time (Unix seconds).
+func imageCreated(daemonCreated int64, labels map[string]string, createdLabel string) time.Time {
+	if createdLabel != "" {
+		if t, err := time.Parse(time.RFC3339, labels[createdLabel]); err == nil {
+			return t
+		}
+	}
+	return time.Unix(daemonCreated, 0)
+}
+
+// imageTags drops the placeholder the daemon reports for untagged images.
+func imageTags(repoTags []string) []string {
+	var tags []string
+	for _, t := range repoTags {
+		if t != "<none>:<none>" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,183 @@
+package whail_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+const (
+	pruneProjectLabel = "test.project"
+	pruneCreatedLabel = "test.created"
+)
+
+// pruneImage builds an image summary built age ago for project.
+func pruneImage(id, project string, age time.Duration, tags ...string) image.Summary {
+	return image.Summary{
+		ID:       id,
+		RepoTags: tags,
+		Size:     100,
+		Created:  time.Now().Add(-age).Unix(),
+		Labels:   map[string]string{pruneProjectLabel: project},
+	}
+}
+
+func newPruneFake(images []image.Summary, usedImages ...string) (*whailtest.FakeAPIClient, *[]string) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ImageListFn = func(context.Context, client.ImageListOptions) (client.ImageListResult, error) {
+		return client.ImageListResult{Items: images}, nil
+	}
+	fake.ContainerListFn = func(context.Context, client.ContainerListOptions) (client.ContainerListResult, error) {
+		var items []container.Summary
+		for _, id := range usedImages {
+			items = append(items, container.Summary{ImageID: id})
+		}
+		return client.ContainerListResult{Items: items}, nil
+	}
+	var removed []string
+	fake.ImageRemoveFn = func(_ context.Context, id string, _ client.ImageRemoveOptions) (client.ImageRemoveResult, error) {
+		removed = append(removed, id)
+		return client.ImageRemoveResult{}, nil
+	}
+	return fake, &removed
+}
+
+func reportIDs(images []whail.PrunedImage) []string {
+	ids := make([]string, 0, len(images))
+	for _, img := range images {
+		ids = append(ids, img.ID)
+	}
+	return ids
+}
+
+func TestImagePruneManaged_Policy(t *testing.T) {
+	day := 24 * time.Hour
+	images := []image.Summary{
+		pruneImage("a-new", "alpha", 1*day, "clawker-alpha:claude"),
+		pruneImage("a-mid", "alpha", 5*day),
+		pruneImage("a-old", "alpha", 30*day),
+		pruneImage("b-new", "beta", 2*day, "clawker-beta:claude"),
+		pruneImage("b-old", "beta", 40*day),
+	}
+
+	tests := []struct {
+		name   string
+		policy whail.PrunePolicy
+		want   []string
+	}{
+		{
+			name:   "keep last per group",
+			policy: whail.PrunePolicy{KeepLast: 1, GroupBy: []string{pruneProjectLabel}},
+			want:   []string{"a-mid", "a-old", "b-old"},
+		},
+		{
+			name:   "keep last without groups counts every image",
+			policy: whail.PrunePolicy{KeepLast: 3},
+			want:   []string{"a-old", "b-old"},
+		},
+		{
+			name:   "older than",
+			policy: whail.PrunePolicy{OlderThan: 10 * day},
+			want:   []string{"a-old", "b-old"},
+		},
+		{
+			name:   "keep tagged",
+			policy: whail.PrunePolicy{KeepTagged: true},
+			want:   []string{"a-mid", "a-old", "b-old"},
+		},
+		{
+			name: "rules combine",
+			policy: whail.PrunePolicy{
+				KeepLast:   2,
+				OlderThan:  10 * day,
+				KeepTagged: true,
+				GroupBy:    []string{pruneProjectLabel},
+			},
+			want: []string{"a-old"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, removed := newPruneFake(images)
+			eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+			report, err := eng.ImagePruneManaged(context.Background(), tt.policy)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, reportIDs(report.Removed))
+			assert.ElementsMatch(t, tt.want, *removed)
+			assert.Equal(t, int64(100*len(tt.want)), report.SpaceReclaimed)
+		})
+	}
+}
+
+func TestImagePruneManaged_CreatedLabelWins(t *testing.T) {
+	// The daemon reports the image as brand new (e.g. re-pulled), but the
+	// build label says it is a month old.
+	img := pruneImage("relabeled", "alpha", 0)
+	img.Labels[pruneCreatedLabel] = time.Now().Add(-30 * 24 * time.Hour).Format(time.RFC3339)
+	fake, _ := newPruneFake([]image.Summary{img})
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	report, err := eng.ImagePruneManaged(context.Background(), whail.PrunePolicy{
+		OlderThan:    7 * 24 * time.Hour,
+		CreatedLabel: pruneCreatedLabel,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"relabeled"}, reportIDs(report.Removed))
+}
+
+func TestImagePruneManaged_SkipsInUseAndConflicts(t *testing.T) {
+	images := []image.Summary{
+		pruneImage("used", "alpha", time.Hour),
+		pruneImage("parent", "alpha", time.Hour),
+		pruneImage("free", "alpha", time.Hour),
+	}
+	fake, _ := newPruneFake(images, "used")
+	fake.ImageRemoveFn = func(_ context.Context, id string, _ client.ImageRemoveOptions) (client.ImageRemoveResult, error) {
+		if id == "parent" {
+			return client.ImageRemoveResult{}, cerrdefs.ErrConflict.WithMessage("image has dependent child images")
+		}
+		return client.ImageRemoveResult{}, nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	report, err := eng.ImagePruneManaged(context.Background(), whail.PrunePolicy{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"free"}, reportIDs(report.Removed))
+	assert.ElementsMatch(t, []string{"used", "parent"}, reportIDs(report.Skipped))
+	for _, img := range report.Skipped {
+		assert.NotEmpty(t, img.SkipReason, img.ID)
+	}
+}
+
+func TestImagePruneManaged_DryRun(t *testing.T) {
+	fake, removed := newPruneFake([]image.Summary{pruneImage("old", "alpha", time.Hour)})
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	report, err := eng.ImagePruneManaged(context.Background(), whail.PrunePolicy{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old"}, reportIDs(report.Removed))
+	assert.Empty(t, *removed)
+}
+
+func TestImagePruneManaged_RemoveError(t *testing.T) {
+	fake, _ := newPruneFake([]image.Summary{pruneImage("old", "alpha", time.Hour)})
+	fake.ImageRemoveFn = func(context.Context, string, client.ImageRemoveOptions) (client.ImageRemoveResult, error) {
+		return client.ImageRemoveResult{}, errors.New("daemon gone")
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	_, err := eng.ImagePruneManaged(context.Background(), whail.PrunePolicy{})
+	assert.ErrorContains(t, err, "daemon gone")
+}
@@ -0,0 +1,133 @@
+package whail
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"strings"
+
+	"github.com/moby/moby/api/types/jsonstream"
+	"github.com/moby/moby/client"
+)
+
+// ImageTransferOptions configures an ImagePush or ImagePull.
+type ImageTransferOptions struct {
+	// RegistryAuth is the base64url-encoded X-Registry-Auth header value.
+	// Empty transfers anonymously.
+	RegistryAuth string
+
+	// OnProgress receives one event per layer status update when non-nil.
+	// Events reuse the build progress shape: StepID is the layer ID.
+	OnProgress BuildProgressFunc
+}
+
+// ImagePush pushes the managed image at ref to its registry and returns the
+// digest of the pushed manifest (empty if the daemon did not report one).
+func (e *Engine) ImagePush(ctx context.Context, ref string, opts ImageTransferOptions) (string, error) {
+	isManaged, err := e.isManagedImage(ctx, ref)
+	if err != nil || !isManaged {
+		return "", ErrImageNotFound(ref, err)
+	}
+	resp, err := e.APIClient.ImagePush(ctx, ref, client.ImagePushOptions{RegistryAuth: opts.RegistryAuth})
+	if err != nil {
+		return "", ErrImagePushFailed(ref, err)
+	}
+	digest, err := drainTransfer(resp.JSONMessages(ctx), opts.OnProgress)
+	if err != nil {
+		return "", ErrImagePushFailed(ref, err)
+	}
+	return digest, nil
+}
+
+// ImagePull pulls ref and admits it only if it carries the managed label.
+// A pulled image without the label is removed again and reported as not
+// managed; an unmanaged image already at ref is refused before pulling, so
+// the pull never replaces an image this engine doesn't own.
+func (e *Engine) ImagePull(ctx context.Context, ref string, opts ImageTransferOptions) error {
+	if existing, err := e.APIClient.ImageInspect(ctx, ref); err == nil {
+		var labels map[string]string
+		if existing.Config != nil {
+			labels = existing.Config.Labels
+		}
+		if !e.isManagedLabelPresent(labels) {
+			return ErrImageNotManaged(ref)
+		}
+	}
+	resp, err := e.APIClient.ImagePull(ctx, ref, client.ImagePullOptions{RegistryAuth: opts.RegistryAuth})
+	if err != nil {
+		return ErrImagePullFailed(ref, err)
+	}
+	if _, err := drainTransfer(resp.JSONMessages(ctx), opts.OnProgress); err != nil {
+		return ErrImagePullFailed(ref, err)
+	}
+
+	isManaged, err := e.isManagedImage(ctx, ref)
+	if err != nil {
+		return ErrImagePullFailed(ref, err)
+	}
+	if !isManaged {
+		// Best effort: the image is already refused; a failed removal only
+		// leaves an unmanaged image that no engine method will touch.
+		_, _ = e.APIClient.ImageRemove(ctx, ref, client.ImageRemoveOptions{PruneChildren: true})
+		return ErrImageNotManaged(ref)
+	}
+	return nil
+}
+
+// pushAux is the aux payload of a push stream's final message.
+type pushAux struct {
+	Digest string `json:"Digest"`
+}
+
+// drainTransfer consumes a push/pull message stream, forwarding layer
+// events to onProgress. It returns the first in-band error and the digest
+// from a push's aux message.
+func drainTransfer(messages iter.Seq2[jsonstream.Message, error], onProgress BuildProgressFunc) (string, error) {
+	var digest string
+	for msg, err := range messages {
+		if err != nil {
+			return "", err
+		}
+		if msg.Error != nil {
+			return "", msg.Error
+		}
+		if msg.Aux != nil {
+			var aux pushAux
+			if json.Unmarshal(*msg.Aux, &aux) == nil && aux.Digest != "" {
+				digest = aux.Digest
+			}
+		}
+		if onProgress != nil {
+			if event, ok := transferEvent(msg); ok {
+				onProgress(event)
+			}
+		}
+	}
+	return digest, nil
+}
+
+// transferEvent maps a push/pull message to a per-layer progress event.
+// Messages that aren't about a layer — the "Pulling from" header, digest
+// and summary lines — report false.
+func transferEvent(msg jsonstream.Message) (BuildProgressEvent, bool) {
+	if msg.ID == "" || msg.Status == "" || strings.HasPrefix(msg.Status, "Pulling from") {
+		return BuildProgressEvent{}, false
+	}
+	event := BuildProgressEvent{
+		StepID:     msg.ID,
+		StepName:   "layer " + msg.ID,
+		StepIndex:  -1,
+		TotalSteps: -1,
+		Status:     BuildStepRunning,
+	}
+	switch {
+	case msg.Status == "Pushed", msg.Status == "Pull complete":
+		event.Status = BuildStepComplete
+	case msg.Status == "Layer already exists", msg.Status == "Already exists",
+		strings.HasPrefix(msg.Status, "Mounted from"):
+		// Already at the destination — nothing was transferred.
+		event.Status = BuildStepComplete
+		event.Cached = true
+	}
+	return event, true
+}
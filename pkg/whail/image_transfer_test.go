@@ -0,0 +1,119 @@
+package whail_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/jsonstream"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+func TestImagePush(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ImageInspectFn = func(_ context.Context, ref string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+		return whailtest.ManagedImageInspect(ref), nil
+	}
+	aux := json.RawMessage(`{"Tag":"v1","Digest":"sha256:abc","Size":1}`)
+	var gotAuth string
+	fake.ImagePushFn = func(_ context.Context, _ string, opts client.ImagePushOptions) (client.ImagePushResponse, error) {
+		gotAuth = opts.RegistryAuth
+		return whailtest.NewTransferResponse(
+			jsonstream.Message{ID: "l1", Status: "Preparing"},
+			jsonstream.Message{ID: "l1", Status: "Pushed"},
+			jsonstream.Message{ID: "l2", Status: "Layer already exists"},
+			jsonstream.Message{Status: "v1: digest: sha256:abc size: 1"},
+			jsonstream.Message{Aux: &aux},
+		), nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	var events []whail.BuildProgressEvent
+	digest, err := eng.ImagePush(context.Background(), "reg.example/img:v1", whail.ImageTransferOptions{
+		RegistryAuth: "token",
+		OnProgress:   func(e whail.BuildProgressEvent) { events = append(events, e) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:abc", digest)
+	assert.Equal(t, "token", gotAuth)
+
+	require.Len(t, events, 3, "only per-layer messages become events")
+	assert.Equal(t, whail.BuildStepRunning, events[0].Status)
+	assert.Equal(t, whail.BuildStepComplete, events[1].Status)
+	assert.True(t, events[2].Cached)
+}
+
+func TestImagePush_StreamError(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ImageInspectFn = func(_ context.Context, ref string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+		return whailtest.ManagedImageInspect(ref), nil
+	}
+	fake.ImagePushFn = func(context.Context, string, client.ImagePushOptions) (client.ImagePushResponse, error) {
+		return whailtest.NewTransferResponse(
+			jsonstream.Message{Error: &jsonstream.Error{Message: "denied: requested access to the resource is denied"}},
+		), nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	_, err := eng.ImagePush(context.Background(), "reg.example/img:v1", whail.ImageTransferOptions{})
+	require.ErrorContains(t, err, "denied")
+}
+
+func TestImagePull(t *testing.T) {
+	pulled := false
+	fake := whailtest.NewFakeAPIClient()
+	fake.ImageInspectFn = func(_ context.Context, ref string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+		if !pulled {
+			return client.ImageInspectResult{}, cerrdefs.ErrNotFound
+		}
+		return whailtest.ManagedImageInspect(ref), nil
+	}
+	fake.ImagePullFn = func(context.Context, string, client.ImagePullOptions) (client.ImagePullResponse, error) {
+		pulled = true
+		return whailtest.NewTransferResponse(
+			jsonstream.Message{ID: "v1", Status: "Pulling from team/img"},
+			jsonstream.Message{ID: "l1", Status: "Pull complete"},
+		), nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	var events []whail.BuildProgressEvent
+	err := eng.ImagePull(context.Background(), "reg.example/img:v1", whail.ImageTransferOptions{
+		OnProgress: func(e whail.BuildProgressEvent) { events = append(events, e) },
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1, "the Pulling from header is not a layer")
+	assert.Equal(t, "l1", events[0].StepID)
+}
+
+func TestImagePull_RemovesUnmanagedResult(t *testing.T) {
+	pulled := false
+	fake := whailtest.NewFakeAPIClient()
+	fake.ImageInspectFn = func(_ context.Context, ref string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+		if !pulled {
+			return client.ImageInspectResult{}, cerrdefs.ErrNotFound
+		}
+		return whailtest.UnmanagedImageInspect(ref), nil
+	}
+	fake.ImagePullFn = func(context.Context, string, client.ImagePullOptions) (client.ImagePullResponse, error) {
+		pulled = true
+		return whailtest.NewTransferResponse(), nil
+	}
+	var removed string
+	fake.ImageRemoveFn = func(_ context.Context, ref string, _ client.ImageRemoveOptions) (client.ImageRemoveResult, error) {
+		removed = ref
+		return client.ImageRemoveResult{}, nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	err := eng.ImagePull(context.Background(), "docker.io/library/alpine:3", whail.ImageTransferOptions{})
+	require.True(t, errors.Is(err, whail.ErrNotManaged), "got %v", err)
+	assert.Equal(t, "docker.io/library/alpine:3", removed)
+}
@@ -56,7 +56,7 @@ func TestJail_RejectsUnmanaged(t *testing.T) {
 		// the method was called exactly once (the check), not zero times.
 		inspectSelf bool
 	}{
-		// ── Container methods (21) ──────────────────────────────────────
+		// ── Container methods (25) ──────────────────────────────────────
 
 		{
 			name:      "ContainerStop",
@@ -201,6 +201,23 @@ func TestJail_RejectsUnmanaged(t *testing.T) {
 			},
 			dangerous: "ContainerStart",
 		},
+		{
+			name:  "CheckpointCreate",
+			setup: unmanagedContainer,
+			call: func(e *whail.Engine) error {
+				return e.CheckpointCreate(context.Background(), "c1", client.CheckpointCreateOptions{CheckpointID: "cp"})
+			},
+			dangerous: "CheckpointCreate",
+		},
+		{
+			name:  "CheckpointList",
+			setup: unmanagedContainer,
+			call: func(e *whail.Engine) error {
+				_, err := e.CheckpointList(context.Background(), "c1", client.CheckpointListOptions{})
+				return err
+			},
+			dangerous: "CheckpointList",
+		},
 		{
 			name:  "ExecCreate",
 			setup: unmanagedContainer,
@@ -237,6 +254,24 @@ func TestJail_RejectsUnmanaged(t *testing.T) {
 			},
 			dangerous: "ContainerStatPath",
 		},
+		{
+			name:  "ContainerDiff",
+			setup: unmanagedContainer,
+			call: func(e *whail.Engine) error {
+				_, err := e.ContainerDiff(context.Background(), "c1")
+				return err
+			},
+			dangerous: "ContainerDiff",
+		},
+		{
+			name:  "ContainerCommit",
+			setup: unmanagedContainer,
+			call: func(e *whail.Engine) error {
+				_, err := e.ContainerCommit(context.Background(), "c1", client.ContainerCommitOptions{})
+				return err
+			},
+			dangerous: "ContainerCommit",
+		},
 
 		// ── Volume methods (2) ──────────────────────────────────────────
 
@@ -281,6 +316,25 @@ func TestJail_RejectsUnmanaged(t *testing.T) {
 			},
 			dangerous: "NetworkConnect",
 		},
+		{
+			name:  "NetworkConnectWithAlias",
+			setup: unmanagedNetwork,
+			call: func(e *whail.Engine) error {
+				_, err := e.NetworkConnectWithAlias(context.Background(), "n1", "c1", "db")
+				return err
+			},
+			dangerous: "NetworkConnect",
+		},
+		{
+			name:  "NetworkInspectManaged",
+			setup: unmanagedNetwork,
+			call: func(e *whail.Engine) error {
+				_, err := e.NetworkInspectManaged(context.Background(), "n1", client.NetworkInspectOptions{})
+				return err
+			},
+			dangerous:   "NetworkInspect",
+			inspectSelf: true,
+		},
 		{
 			name:  "NetworkDisconnect",
 			setup: unmanagedNetwork,
@@ -291,7 +345,7 @@ func TestJail_RejectsUnmanaged(t *testing.T) {
 			dangerous: "NetworkDisconnect",
 		},
 
-		// ── Image methods (2) ───────────────────────────────────────────
+		// ── Image methods (6) ───────────────────────────────────────────
 
 		{
 			name:  "ImageRemove",
@@ -309,6 +363,35 @@ func TestJail_RejectsUnmanaged(t *testing.T) {
 			dangerous:   "ImageInspect",
 			inspectSelf: true,
 		},
+		{
+			name:      "ImageHistory",
+			setup:     unmanagedImage,
+			call:      func(e *whail.Engine) error { _, err := e.ImageHistory(context.Background(), "img1"); return err },
+			dangerous: "ImageHistory",
+		},
+		{
+			name:      "ImageTag",
+			setup:     unmanagedImage,
+			call:      func(e *whail.Engine) error { return e.ImageTag(context.Background(), "img1", "img1:extra") },
+			dangerous: "ImageTag",
+		},
+		{
+			name:  "ImagePush",
+			setup: unmanagedImage,
+			call: func(e *whail.Engine) error {
+				_, err := e.ImagePush(context.Background(), "img1", whail.ImageTransferOptions{})
+				return err
+			},
+			dangerous: "ImagePush",
+		},
+		{
+			name:  "ImagePull",
+			setup: unmanagedImage,
+			call: func(e *whail.Engine) error {
+				return e.ImagePull(context.Background(), "img1", whail.ImageTransferOptions{})
+			},
+			dangerous: "ImagePull",
+		},
 	}
 
 	for _, tc := range tests {
@@ -424,6 +507,31 @@ func TestJail_InjectsLabels(t *testing.T) {
 			t.Errorf("expected managed label %s=true, got labels: %v", managedKey, capturedLabels)
 		}
 	})
+
+	t.Run("ContainerCommit", func(t *testing.T) {
+		fake := whailtest.NewFakeAPIClient()
+		fake.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+			return whailtest.ManagedContainerInspect(id), nil
+		}
+		var capturedLabels map[string]string
+		fake.ContainerCommitFn = func(_ context.Context, _ string, opts client.ContainerCommitOptions) (client.ContainerCommitResult, error) {
+			capturedLabels = opts.Config.Labels
+			return client.ContainerCommitResult{}, nil
+		}
+		eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+		_, err := eng.ContainerCommit(context.Background(), "c1", client.ContainerCommitOptions{Reference: "img:snap"},
+			map[string]string{"extra": "value"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if capturedLabels[managedKey] != "true" {
+			t.Errorf("expected managed label %s=true, got labels: %v", managedKey, capturedLabels)
+		}
+		if capturedLabels["extra"] != "value" {
+			t.Errorf("expected extra label extra=value, got labels: %v", capturedLabels)
+		}
+	})
 }
 
 // TestJail_InjectsFilter verifies that every INJECT_FILTER-category method adds
@@ -703,4 +811,30 @@ func TestJail_LabelOverridePrevention(t *testing.T) {
 				managedKey, managedKey, capturedLabels[managedKey])
 		}
 	})
+
+	t.Run("ContainerCommit", func(t *testing.T) {
+		fake := whailtest.NewFakeAPIClient()
+		fake.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+			return whailtest.ManagedContainerInspect(id), nil
+		}
+		var capturedLabels map[string]string
+		fake.ContainerCommitFn = func(_ context.Context, _ string, opts client.ContainerCommitOptions) (client.ContainerCommitResult, error) {
+			capturedLabels = opts.Config.Labels
+			return client.ContainerCommitResult{}, nil
+		}
+		eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+		callerConfig := &container.Config{Labels: maliciousLabels}
+		_, err := eng.ContainerCommit(context.Background(), "c1", client.ContainerCommitOptions{Config: callerConfig}, maliciousLabels)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if capturedLabels[managedKey] != "true" {
+			t.Errorf("managed label was overridden: expected %s=true, got %s=%s",
+				managedKey, managedKey, capturedLabels[managedKey])
+		}
+		if callerConfig.Labels[managedKey] != "false" {
+			t.Errorf("caller's config was mutated: %v", callerConfig.Labels)
+		}
+	})
 }
@@ -0,0 +1,352 @@
+package whail
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/moby/moby/api/types/build"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/jsonstream"
+	"github.com/moby/moby/api/types/network"
+	"github.com/moby/moby/client"
+)
+
+// LabelMigration upgrades a resource's labels by one schema version, in
+// place: rename, add, or delete keys. It never sees the managed or
+// label-schema labels' final values — the engine stamps those afterwards.
+type LabelMigration func(labels map[string]string)
+
+// composeProjectLabel marks containers Docker Compose created. Compose
+// recreates them from its own file, so they are never relabeled here.
+const composeProjectLabel = "com.docker.compose.project"
+
+// relabelSuffix parks the old container while its relabeled replacement
+// takes the name.
+const relabelSuffix = "-relabel-old"
+
+// Resource kinds reported by StaleLabelSchemas.
+const (
+	StaleContainer = "container"
+	StaleImage     = "image"
+)
+
+// StaleResource is a managed container or image labeled under an older
+// label schema than EngineOptions.LabelSchemaVersion.
+type StaleResource struct {
+	// Kind is StaleContainer or StaleImage.
+	Kind string
+	// ID is the container or image ID.
+	ID string
+	// Name is the container name, or the image's first tag (empty for an
+	// untagged image).
+	Name string
+	// Schema is the recorded label schema version (FirstLabelSchema when
+	// none is recorded).
+	Schema int
+	// Running is true for a container that is running, paused, or
+	// restarting. ContainerRelabel refuses those.
+	Running bool
+	// ImageID is the image a container was created from.
+	ImageID string
+}
+
+// LabelSchemaKey returns the full label-schema label key
+// (e.g., "com.myapp.label_schema").
+func (e *Engine) LabelSchemaKey() string {
+	return e.labelSchemaKey
+}
+
+// LabelSchemaVersion returns the label schema version the engine stamps;
+// 0 when versioning is off.
+func (e *Engine) LabelSchemaVersion() int {
+	return e.options.LabelSchemaVersion
+}
+
+// FirstLabelSchema is the version of a label scheme as it stood before it
+// was versioned. A resource that records no version carries those labels.
+const FirstLabelSchema = 1
+
+// RecordedLabelSchema returns the label schema version recorded in labels.
+// A missing, unparsable, or out-of-range value is FirstLabelSchema: the
+// resource predates versioning, so its labels are the first scheme's.
+func (e *Engine) RecordedLabelSchema(labels map[string]string) int {
+	v, err := strconv.Atoi(labels[e.labelSchemaKey])
+	if err != nil || v < FirstLabelSchema {
+		return FirstLabelSchema
+	}
+	return v
+}
+
+// MigrateLabels returns a copy of labels upgraded from their recorded schema
+// version to the current one: each EngineOptions.LabelMigrations step from
+// the recorded version up runs in order, then the managed and label-schema
+// labels are stamped.
+func (e *Engine) MigrateLabels(labels map[string]string) map[string]string {
+	out := maps.Clone(labels)
+	if out == nil {
+		out = map[string]string{}
+	}
+	for v := e.RecordedLabelSchema(labels); v < e.options.LabelSchemaVersion; v++ {
+		if migrate := e.options.LabelMigrations[v]; migrate != nil {
+			migrate(out)
+		}
+	}
+	e.enforceManagedLabels(out)
+	return out
+}
+
+// StaleLabelSchemas lists the managed containers and images labeled under an
+// older label schema than the current one, containers first. Containers
+// created by Docker Compose are left out. It returns nil when versioning is
+// off.
+func (e *Engine) StaleLabelSchemas(ctx context.Context) ([]StaleResource, error) {
+	current := e.options.LabelSchemaVersion
+	if current == 0 {
+		return nil, nil
+	}
+
+	containers, err := e.ContainerListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var stale []StaleResource
+	for _, c := range containers {
+		if _, compose := c.Labels[composeProjectLabel]; compose {
+			continue
+		}
+		schema := e.RecordedLabelSchema(c.Labels)
+		if schema >= current {
+			continue
+		}
+		var name string
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		stale = append(stale, StaleResource{
+			Kind:    StaleContainer,
+			ID:      c.ID,
+			Name:    name,
+			Schema:  schema,
+			Running: c.State == container.StateRunning || c.State == container.StatePaused || c.State == container.StateRestarting,
+			ImageID: c.ImageID,
+		})
+	}
+
+	images, err := e.ImageList(ctx, client.ImageListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, img := range images.Items {
+		schema := e.RecordedLabelSchema(img.Labels)
+		if schema >= current {
+			continue
+		}
+		var name string
+		if len(img.RepoTags) > 0 {
+			name = img.RepoTags[0]
+		}
+		stale = append(stale, StaleResource{Kind: StaleImage, ID: img.ID, Name: name, Schema: schema})
+	}
+	return stale, nil
+}
+
+// ImageRelabel rebuilds the managed image imageRef with its labels upgraded
+// by MigrateLabels and moves all of its tags to the result, returning the new
+// image ID. The rebuild is a single FROM on the old image, so it adds no
+// layer and keeps the image config; only labels change. An image inherits
+// its parent's labels, so a key a migration drops is set to "" rather than
+// removed. The old image stays until nothing uses it. Untagged images are
+// refused — the result would be dangling.
+func (e *Engine) ImageRelabel(ctx context.Context, imageRef string) (string, error) {
+	info, err := e.ImageInspect(ctx, imageRef)
+	if err != nil {
+		return "", err
+	}
+	if len(info.RepoTags) == 0 {
+		return "", ErrImageRelabelFailed(imageRef, errors.New("image has no tags"))
+	}
+	if e.skipDryRun("relabel", "image", imageRef) {
+		return info.ID, nil
+	}
+
+	var old map[string]string
+	if info.Config != nil {
+		old = info.Config.Labels
+	}
+	labels := e.MigrateLabels(old)
+	for k := range old {
+		if _, kept := labels[k]; !kept {
+			labels[k] = ""
+		}
+	}
+
+	buildContext, err := dockerfileContext("FROM " + info.ID + "\n")
+	if err != nil {
+		return "", ErrImageRelabelFailed(imageRef, err)
+	}
+	resp, err := e.ImageBuild(ctx, buildContext, client.ImageBuildOptions{
+		Version:     build.BuilderV1,
+		Dockerfile:  "Dockerfile",
+		Tags:        info.RepoTags,
+		Labels:      labels,
+		Remove:      true,
+		ForceRemove: true,
+	})
+	if err != nil {
+		return "", ErrImageRelabelFailed(imageRef, err)
+	}
+	defer resp.Body.Close()
+	id, err := drainBuild(resp.Body)
+	if err != nil {
+		return "", ErrImageRelabelFailed(imageRef, err)
+	}
+	if id == "" {
+		res, err := e.APIClient.ImageInspect(ctx, info.RepoTags[0])
+		if err != nil {
+			return "", ErrImageRelabelFailed(imageRef, err)
+		}
+		id = res.ID
+	}
+	return id, nil
+}
+
+// ContainerRelabel recreates the stopped managed container containerID with
+// its labels upgraded by MigrateLabels and returns the new container's ID.
+// The replacement keeps the old name, config, host config, and networks;
+// image, when non-empty, replaces the image it runs (e.g. the ID
+// ImageRelabel returned for it), otherwise it runs the exact image the old
+// container did. As with any recreate, the old container's writable layer
+// and anonymous volumes are not carried over — named volumes and bind
+// mounts are. A running container is refused.
+func (e *Engine) ContainerRelabel(ctx context.Context, containerID, image string) (string, error) {
+	res, err := e.ContainerInspect(ctx, containerID, client.ContainerInspectOptions{})
+	if err != nil {
+		return "", err
+	}
+	old := res.Container
+	name := strings.TrimPrefix(old.Name, "/")
+	if old.State != nil && (old.State.Running || old.State.Paused || old.State.Restarting) {
+		return "", ErrContainerRelabelFailed(name, errors.New("container is running"))
+	}
+	if old.Config == nil {
+		return "", ErrContainerRelabelFailed(name, errors.New("container has no config"))
+	}
+	if image == "" {
+		image = old.Image
+	}
+	if e.skipDryRun("relabel", "container", name) {
+		return dryRunIDPrefix + name, nil
+	}
+
+	cfg := *old.Config
+	cfg.Image = image
+	cfg.Labels = e.MigrateLabels(old.Config.Labels)
+	spec := ContainerCreateOptions{
+		Name:             name,
+		Config:           &cfg,
+		HostConfig:       old.HostConfig,
+		NetworkingConfig: relabelEndpoints(old),
+	}
+
+	// Park the old container under another name so the replacement can take
+	// its own; it is renamed back if the create fails.
+	if _, err := e.ContainerRename(ctx, old.ID, name+relabelSuffix); err != nil {
+		return "", ErrContainerRelabelFailed(name, err)
+	}
+	created, err := e.ContainerCreate(ctx, spec)
+	if err != nil {
+		if _, rnErr := e.ContainerRename(context.WithoutCancel(ctx), old.ID, name); rnErr != nil {
+			err = errors.Join(err, rnErr)
+		}
+		return "", ErrContainerRelabelFailed(name, err)
+	}
+	if _, err := e.ContainerRemove(ctx, old.ID, false); err != nil {
+		return created.ID, ErrContainerRelabelFailed(name, err)
+	}
+	return created.ID, nil
+}
+
+// relabelEndpoints carries a container's network endpoints over to its
+// replacement: the user-set configuration (aliases, static IPs, links,
+// driver options, gateway priority), never the operational addresses. The
+// alias the daemon derives from the old container ID is dropped.
+func relabelEndpoints(old container.InspectResponse) *network.NetworkingConfig {
+	if old.NetworkSettings == nil || len(old.NetworkSettings.Networks) == 0 {
+		return nil
+	}
+	var shortID string
+	if len(old.ID) >= 12 {
+		shortID = old.ID[:12]
+	}
+	endpoints := make(map[string]*network.EndpointSettings, len(old.NetworkSettings.Networks))
+	for netName, ep := range old.NetworkSettings.Networks {
+		settings := &network.EndpointSettings{}
+		if ep != nil {
+			settings.IPAMConfig = ep.IPAMConfig
+			settings.Links = ep.Links
+			settings.DriverOpts = ep.DriverOpts
+			settings.GwPriority = ep.GwPriority
+			for _, alias := range ep.Aliases {
+				if alias != shortID {
+					settings.Aliases = append(settings.Aliases, alias)
+				}
+			}
+		}
+		endpoints[netName] = settings
+	}
+	return &network.NetworkingConfig{EndpointsConfig: endpoints}
+}
+
+// dockerfileContext returns a build context holding only a Dockerfile.
+func dockerfileContext(dockerfile string) (io.Reader, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: "Dockerfile", Mode: 0o644, Size: int64(len(dockerfile))}); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write([]byte(dockerfile)); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// buildAux is the aux payload carrying a classic build's image ID.
+type buildAux struct {
+	ID string `json:"ID"`
+}
+
+// drainBuild consumes a classic build's message stream and returns the
+// built image ID from its aux message (empty if the daemon sent none) or
+// the first in-band error.
+func drainBuild(body io.Reader) (string, error) {
+	var id string
+	dec := json.NewDecoder(body)
+	for {
+		var msg jsonstream.Message
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return id, nil
+			}
+			return "", err
+		}
+		if msg.Error != nil {
+			return "", msg.Error
+		}
+		if msg.Aux != nil {
+			var aux buildAux
+			if json.Unmarshal(*msg.Aux, &aux) == nil && aux.ID != "" {
+				id = aux.ID
+			}
+		}
+	}
+}
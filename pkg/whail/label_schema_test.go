@@ -0,0 +1,342 @@
+package whail_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/moby/moby/api/types/build"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/api/types/network"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+const (
+	testSchemaKey  = whailtest.TestLabelPrefix + "." + whail.DefaultLabelSchemaLabel
+	testManagedKey = whailtest.TestLabelPrefix + "." + whailtest.TestManagedLabel
+)
+
+// schemaEngineOptions versions the test label scheme at 3. Step 1 renames
+// "app.owner" to "app.user"; step 2 drops "app.legacy".
+func schemaEngineOptions() whail.EngineOptions {
+	opts := whailtest.TestEngineOptions()
+	opts.LabelSchemaVersion = 3
+	opts.LabelMigrations = map[int]whail.LabelMigration{
+		1: func(labels map[string]string) {
+			if v, ok := labels["app.owner"]; ok {
+				labels["app.user"] = v
+				delete(labels, "app.owner")
+			}
+		},
+		2: func(labels map[string]string) { delete(labels, "app.legacy") },
+	}
+	return opts
+}
+
+func TestLabelSchema_StampedOnCreate(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	var labels map[string]string
+	fake.ContainerCreateFn = func(_ context.Context, opts client.ContainerCreateOptions) (client.ContainerCreateResult, error) {
+		labels = opts.Config.Labels
+		return client.ContainerCreateResult{ID: "c1"}, nil
+	}
+	eng := whail.NewFromExisting(fake, schemaEngineOptions())
+
+	_, err := eng.ContainerCreate(context.Background(), whail.ContainerCreateOptions{
+		Name:   "web",
+		Config: &container.Config{Image: "alpine", Labels: map[string]string{testSchemaKey: "1"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "3", labels[testSchemaKey], "the engine's version wins over a caller-supplied one")
+}
+
+func TestLabelSchema_NotStampedWhenUnversioned(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	var labels map[string]string
+	fake.ContainerCreateFn = func(_ context.Context, opts client.ContainerCreateOptions) (client.ContainerCreateResult, error) {
+		labels = opts.Config.Labels
+		return client.ContainerCreateResult{ID: "c1"}, nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	_, err := eng.ContainerCreate(context.Background(), whail.ContainerCreateOptions{Name: "web", Config: &container.Config{Image: "alpine"}})
+	require.NoError(t, err)
+	assert.NotContains(t, labels, testSchemaKey)
+}
+
+func TestMigrateLabels(t *testing.T) {
+	eng := whail.NewFromExisting(whailtest.NewFakeAPIClient(), schemaEngineOptions())
+
+	tests := []struct {
+		name string
+		in   map[string]string
+		want map[string]string
+	}{
+		{
+			name: "unversioned is the first schema and runs every step",
+			in:   map[string]string{"app.owner": "ana", "app.legacy": "x"},
+			want: map[string]string{"app.user": "ana", testManagedKey: "true", testSchemaKey: "3"},
+		},
+		{
+			name: "version 2 runs the last step only",
+			in:   map[string]string{"app.owner": "ana", "app.legacy": "x", testSchemaKey: "2"},
+			want: map[string]string{"app.owner": "ana", testManagedKey: "true", testSchemaKey: "3"},
+		},
+		{
+			name: "unparsable version counts as the first schema",
+			in:   map[string]string{"app.owner": "ana", testSchemaKey: "v1"},
+			want: map[string]string{"app.user": "ana", testManagedKey: "true", testSchemaKey: "3"},
+		},
+		{
+			name: "version 0 counts as the first schema",
+			in:   map[string]string{"app.owner": "ana", testSchemaKey: "0"},
+			want: map[string]string{"app.user": "ana", testManagedKey: "true", testSchemaKey: "3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(map[string]string, len(tt.in))
+			for k, v := range tt.in {
+				in[k] = v
+			}
+			assert.Equal(t, tt.want, eng.MigrateLabels(in))
+			assert.Equal(t, tt.in, in, "the input is not modified")
+		})
+	}
+}
+
+func TestStaleLabelSchemas(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ContainerListFn = func(_ context.Context, _ client.ContainerListOptions) (client.ContainerListResult, error) {
+		return client.ContainerListResult{Items: []container.Summary{
+			{ID: "c-current", Names: []string{"/current"}, Labels: map[string]string{testSchemaKey: "3"}},
+			{ID: "c-old", Names: []string{"/old"}, State: container.StateRunning, ImageID: "sha256:img",
+				Labels: map[string]string{testSchemaKey: "2"}},
+			{ID: "c-none", Names: []string{"/none"}, State: container.StateExited, Labels: map[string]string{}},
+			{ID: "c-compose", Names: []string{"/monitor-grafana-1"}, Labels: map[string]string{"com.docker.compose.project": "monitor"}},
+		}}, nil
+	}
+	fake.ImageListFn = func(_ context.Context, _ client.ImageListOptions) (client.ImageListResult, error) {
+		return client.ImageListResult{Items: []image.Summary{
+			{ID: "sha256:new", RepoTags: []string{"app:latest"}, Labels: map[string]string{testSchemaKey: "3"}},
+			{ID: "sha256:img", RepoTags: []string{"app:old"}, Labels: map[string]string{}},
+		}}, nil
+	}
+	eng := whail.NewFromExisting(fake, schemaEngineOptions())
+
+	stale, err := eng.StaleLabelSchemas(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []whail.StaleResource{
+		{Kind: whail.StaleContainer, ID: "c-old", Name: "old", Schema: 2, Running: true, ImageID: "sha256:img"},
+		{Kind: whail.StaleContainer, ID: "c-none", Name: "none", Schema: 1},
+		{Kind: whail.StaleImage, ID: "sha256:img", Name: "app:old", Schema: 1},
+	}, stale)
+}
+
+func TestStaleLabelSchemas_FirstVersionUnlabeledIsCurrent(t *testing.T) {
+	// Turning versioning on at the first schema leaves resources created
+	// before it alone: their labels already are that schema.
+	fake := whailtest.NewFakeAPIClient()
+	fake.ContainerListFn = func(_ context.Context, _ client.ContainerListOptions) (client.ContainerListResult, error) {
+		return client.ContainerListResult{Items: []container.Summary{
+			{ID: "c-none", Names: []string{"/none"}, Labels: map[string]string{}},
+		}}, nil
+	}
+	fake.ImageListFn = func(_ context.Context, _ client.ImageListOptions) (client.ImageListResult, error) {
+		return client.ImageListResult{Items: []image.Summary{
+			{ID: "sha256:img", RepoTags: []string{"app:old"}, Labels: map[string]string{}},
+		}}, nil
+	}
+	opts := whailtest.TestEngineOptions()
+	opts.LabelSchemaVersion = whail.FirstLabelSchema
+	eng := whail.NewFromExisting(fake, opts)
+
+	stale, err := eng.StaleLabelSchemas(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, stale)
+}
+
+func TestStaleLabelSchemas_Unversioned(t *testing.T) {
+	// No list stubs: an unversioned engine never asks the daemon.
+	eng := whail.NewFromExisting(whailtest.NewFakeAPIClient(), whailtest.TestEngineOptions())
+	stale, err := eng.StaleLabelSchemas(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, stale)
+}
+
+// relabelFake serves a stopped, unversioned container "web" on network
+// "appnet" and records the create request.
+func relabelFake(running bool) (*whailtest.FakeAPIClient, *client.ContainerCreateOptions) {
+	fake := whailtest.NewFakeAPIClient()
+	const id = "0123456789abcdef"
+	fake.ContainerInspectFn = func(_ context.Context, _ string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+		return client.ContainerInspectResult{Container: container.InspectResponse{
+			ID:    id,
+			Name:  "/web",
+			Image: "sha256:old",
+			State: &container.State{Running: running},
+			Config: &container.Config{
+				Image:  "app:latest",
+				Env:    []string{"A=1"},
+				Labels: map[string]string{testManagedKey: "true", "app.owner": "ana"},
+			},
+			HostConfig: &container.HostConfig{NetworkMode: "appnet"},
+			NetworkSettings: &container.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+				"appnet": {Aliases: []string{"web", id[:12]}, NetworkID: "n1", EndpointID: "e1"},
+			}},
+		}}, nil
+	}
+	created := &client.ContainerCreateOptions{}
+	fake.ContainerRenameFn = func(_ context.Context, _ string, _ client.ContainerRenameOptions) (client.ContainerRenameResult, error) {
+		return client.ContainerRenameResult{}, nil
+	}
+	fake.ContainerCreateFn = func(_ context.Context, opts client.ContainerCreateOptions) (client.ContainerCreateResult, error) {
+		*created = opts
+		return client.ContainerCreateResult{ID: "new-id"}, nil
+	}
+	fake.ContainerRemoveFn = func(_ context.Context, _ string, _ client.ContainerRemoveOptions) (client.ContainerRemoveResult, error) {
+		return client.ContainerRemoveResult{}, nil
+	}
+	return fake, created
+}
+
+func TestContainerRelabel(t *testing.T) {
+	fake, created := relabelFake(false)
+	eng := whail.NewFromExisting(fake, schemaEngineOptions())
+
+	newID, err := eng.ContainerRelabel(context.Background(), "web", "sha256:relabeled")
+	require.NoError(t, err)
+	assert.Equal(t, "new-id", newID)
+
+	var mutating []string
+	for _, call := range fake.Calls {
+		if call != "ContainerInspect" {
+			mutating = append(mutating, call)
+		}
+	}
+	assert.Equal(t, []string{"ContainerRename", "ContainerCreate", "ContainerRemove"}, mutating)
+
+	assert.Equal(t, "web", created.Name)
+	assert.Equal(t, "sha256:relabeled", created.Config.Image)
+	assert.Equal(t, []string{"A=1"}, created.Config.Env)
+	assert.Equal(t, "ana", created.Config.Labels["app.user"])
+	assert.Equal(t, "3", created.Config.Labels[testSchemaKey])
+	ep := created.NetworkingConfig.EndpointsConfig["appnet"]
+	require.NotNil(t, ep)
+	assert.Equal(t, []string{"web"}, ep.Aliases, "the ID-derived alias is not carried over")
+	assert.Empty(t, ep.EndpointID)
+}
+
+func TestContainerRelabel_KeepsImageByDefault(t *testing.T) {
+	fake, created := relabelFake(false)
+	eng := whail.NewFromExisting(fake, schemaEngineOptions())
+
+	_, err := eng.ContainerRelabel(context.Background(), "web", "")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:old", created.Config.Image)
+}
+
+func TestContainerRelabel_RefusesRunning(t *testing.T) {
+	fake, _ := relabelFake(true)
+	eng := whail.NewFromExisting(fake, schemaEngineOptions())
+
+	_, err := eng.ContainerRelabel(context.Background(), "web", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "container is running")
+	assert.NotContains(t, fake.Calls, "ContainerRename")
+}
+
+func TestImageRelabel(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ImageInspectFn = func(_ context.Context, ref string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+		res := whailtest.ManagedImageInspect(ref)
+		res.ID = "sha256:old"
+		res.RepoTags = []string{"app:latest", "app:dev"}
+		res.Config.Labels["app.owner"] = "ana"
+		res.Config.Labels["app.legacy"] = "x"
+		return res, nil
+	}
+	var opts client.ImageBuildOptions
+	var dockerfile string
+	fake.ImageBuildFn = func(_ context.Context, buildContext io.Reader, o client.ImageBuildOptions) (client.ImageBuildResult, error) {
+		opts = o
+		raw, _ := io.ReadAll(buildContext)
+		dockerfile = string(raw)
+		body := `{"stream":"Step 1/1 : FROM sha256:old"}` + "\n" + `{"aux":{"ID":"sha256:new"}}` + "\n"
+		return client.ImageBuildResult{Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+	eng := whail.NewFromExisting(fake, schemaEngineOptions())
+
+	newID, err := eng.ImageRelabel(context.Background(), "app:latest")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:new", newID)
+	assert.Contains(t, dockerfile, "FROM sha256:old\n")
+	assert.Equal(t, build.BuilderV1, opts.Version)
+	assert.Equal(t, []string{"app:latest", "app:dev"}, opts.Tags)
+	assert.Equal(t, "ana", opts.Labels["app.user"])
+	assert.Equal(t, "", opts.Labels["app.owner"], "a dropped key is blanked over the inherited one")
+	assert.Equal(t, "", opts.Labels["app.legacy"])
+	assert.Equal(t, "3", opts.Labels[testSchemaKey])
+}
+
+func TestImageRelabel_BuildError(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ImageInspectFn = func(_ context.Context, ref string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+		res := whailtest.ManagedImageInspect(ref)
+		res.RepoTags = []string{"app:latest"}
+		return res, nil
+	}
+	fake.ImageBuildFn = func(_ context.Context, _ io.Reader, _ client.ImageBuildOptions) (client.ImageBuildResult, error) {
+		body := `{"errorDetail":{"message":"no space left"},"error":"no space left"}` + "\n"
+		return client.ImageBuildResult{Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+	eng := whail.NewFromExisting(fake, schemaEngineOptions())
+
+	_, err := eng.ImageRelabel(context.Background(), "app:latest")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no space left")
+}
+
+func TestImageRelabel_RefusesUntagged(t *testing.T) {
+	eng := whail.NewFromExisting(whailtest.NewFakeAPIClient(), schemaEngineOptions())
+	_, err := eng.ImageRelabel(context.Background(), "sha256:dangling")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "image has no tags")
+}
+
+// The fake has no build, rename, create, or remove stubs, so a dry run that
+// reached the daemon would panic.
+func TestRelabel_DryRun(t *testing.T) {
+	fake, _ := relabelFake(false)
+	fake.ContainerRenameFn = nil
+	fake.ContainerCreateFn = nil
+	fake.ContainerRemoveFn = nil
+	fake.ImageInspectFn = func(_ context.Context, ref string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+		res := whailtest.ManagedImageInspect(ref)
+		res.ID = "sha256:old"
+		res.RepoTags = []string{"app:latest"}
+		return res, nil
+	}
+	opts := schemaEngineOptions()
+	opts.DryRun = true
+	eng := whail.NewFromExisting(fake, opts)
+
+	id, err := eng.ImageRelabel(context.Background(), "app:latest")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:old", id)
+	_, err = eng.ContainerRelabel(context.Background(), "web", "")
+	require.NoError(t, err)
+
+	var got []string
+	for _, op := range eng.DryRunReport() {
+		got = append(got, op.String())
+	}
+	assert.Equal(t, []string{"relabel image app:latest", "relabel container web"}, got)
+}
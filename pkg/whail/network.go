@@ -29,14 +29,20 @@ func (e *Engine) NetworkCreate(ctx context.Context, name string, options client.
 	} else {
 		options.Labels = MergeLabels(options.Labels, labels)
 	}
-	// Ensure managed label cannot be overridden by extra labels.
-	options.Labels[e.managedLabelKey] = e.managedLabelValue
+	// Ensure the managed and label-schema labels cannot be overridden by extra labels.
+	e.enforceManagedLabels(options.Labels)
 
 	// Set default driver if not specified
 	if options.Driver == "" {
 		options.Driver = "bridge"
 	}
 
+	if err := e.compat.checkNetworkCreate(name, options); err != nil {
+		return client.NetworkCreateResult{}, err
+	}
+	if e.skipDryRun("create", "network", name) {
+		return client.NetworkCreateResult{ID: dryRunIDPrefix + name}, nil
+	}
 	resp, err := e.APIClient.NetworkCreate(ctx, name, options)
 	if err != nil {
 		return client.NetworkCreateResult{}, ErrNetworkCreateFailed(name, err)
@@ -50,6 +56,9 @@ func (e *Engine) NetworkRemove(ctx context.Context, name string) (client.Network
 	if err != nil || !isManaged {
 		return client.NetworkRemoveResult{}, ErrNetworkNotFound(name, err)
 	}
+	if e.skipDryRun("remove", "network", name) {
+		return client.NetworkRemoveResult{}, nil
+	}
 	result, err := e.APIClient.NetworkRemove(ctx, name, client.NetworkRemoveOptions{})
 	if err != nil {
 		return client.NetworkRemoveResult{}, ErrNetworkRemoveFailed(name, err)
@@ -81,11 +90,13 @@ func (e *Engine) NetworkExists(ctx context.Context, name string) (bool, error) {
 // NetworkList lists networks matching the filter.
 // The managed label filter is automatically injected.
 func (e *Engine) NetworkList(ctx context.Context, extraFilters ...map[string]string) (client.NetworkListResult, error) {
-	f := e.newManagedFilter()
+	q := e.Query()
 	for _, labels := range extraFilters {
-		for k, v := range labels {
-			f = f.Add("label", k+"="+v)
-		}
+		q = q.Labels(labels)
+	}
+	f, err := q.Filters()
+	if err != nil {
+		return client.NetworkListResult{}, ErrNetworkListFailed(err)
 	}
 	result, err := e.APIClient.NetworkList(ctx, client.NetworkListOptions{Filters: f})
 	if err != nil {
@@ -141,6 +152,10 @@ func (e *Engine) IsNetworkManaged(ctx context.Context, name string) (bool, error
 // The managed label filter is automatically injected to ensure only
 // managed networks are affected.
 func (e *Engine) NetworksPrune(ctx context.Context) (client.NetworkPruneResult, error) {
+	if e.DryRun() {
+		return e.dryRunNetworksPrune(ctx)
+	}
+	e.notifyMutation(Operation{Action: "prune", Resource: "network"})
 	f := e.newManagedFilter()
 	result, err := e.APIClient.NetworkPrune(ctx, client.NetworkPruneOptions{Filters: f})
 	if err != nil {
@@ -192,3 +207,63 @@ func (e *Engine) NetworkDisconnect(ctx context.Context, network, containerID str
 	}
 	return result, nil
 }
+
+// NetworkConnectWithAlias connects a container to a network under the given
+// DNS aliases, so other containers on the network resolve it by a stable
+// name through Docker's embedded DNS. Unlike NetworkConnect, both sides are
+// checked: the network and the container must be managed.
+func (e *Engine) NetworkConnectWithAlias(ctx context.Context, netName, containerID string, aliases ...string) (client.NetworkConnectResult, error) {
+	isManaged, err := e.IsNetworkManaged(ctx, netName)
+	if err != nil {
+		return client.NetworkConnectResult{}, ErrNetworkConnectFailed(netName, containerID, err)
+	}
+	if !isManaged {
+		return client.NetworkConnectResult{}, ErrNetworkNotFound(netName, nil)
+	}
+	isManaged, err = e.IsContainerManaged(ctx, containerID)
+	if err != nil {
+		return client.NetworkConnectResult{}, ErrNetworkConnectFailed(netName, containerID, err)
+	}
+	if !isManaged {
+		return client.NetworkConnectResult{}, ErrContainerNotFound(containerID)
+	}
+
+	opts := client.NetworkConnectOptions{
+		Container:      containerID,
+		EndpointConfig: &network.EndpointSettings{Aliases: aliases},
+	}
+	result, err := e.APIClient.NetworkConnect(ctx, netName, opts)
+	if err != nil {
+		return client.NetworkConnectResult{}, ErrNetworkConnectFailed(netName, containerID, err)
+	}
+	return result, nil
+}
+
+// NetworkInspectManaged inspects a managed network and drops the endpoints
+// of unmanaged containers from the result, so callers resolving names on
+// the network only see containers this engine owns.
+func (e *Engine) NetworkInspectManaged(ctx context.Context, name string, options client.NetworkInspectOptions) (client.NetworkInspectResult, error) {
+	result, err := e.NetworkInspect(ctx, name, options)
+	if err != nil {
+		return client.NetworkInspectResult{}, err
+	}
+	if len(result.Network.Containers) == 0 {
+		return result, nil
+	}
+	managed, err := e.ContainerList(ctx, client.ContainerListOptions{All: true})
+	if err != nil {
+		return client.NetworkInspectResult{}, err
+	}
+	ids := make(map[string]bool, len(managed.Items))
+	for _, c := range managed.Items {
+		ids[c.ID] = true
+	}
+	containers := make(map[string]network.EndpointResource, len(result.Network.Containers))
+	for id, ep := range result.Network.Containers {
+		if ids[id] {
+			containers[id] = ep
+		}
+	}
+	result.Network.Containers = containers
+	return result, nil
+}
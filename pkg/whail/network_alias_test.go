@@ -0,0 +1,101 @@
+package whail_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+func TestNetworkConnectWithAlias(t *testing.T) {
+	t.Run("forwards aliases", func(t *testing.T) {
+		fake := whailtest.NewFakeAPIClient()
+		fake.NetworkInspectFn = func(_ context.Context, name string, _ client.NetworkInspectOptions) (client.NetworkInspectResult, error) {
+			return whailtest.ManagedNetworkInspect(name), nil
+		}
+		fake.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+			return whailtest.ManagedContainerInspect(id), nil
+		}
+		var got client.NetworkConnectOptions
+		fake.NetworkConnectFn = func(_ context.Context, _ string, opts client.NetworkConnectOptions) (client.NetworkConnectResult, error) {
+			got = opts
+			return client.NetworkConnectResult{}, nil
+		}
+		eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+		_, err := eng.NetworkConnectWithAlias(context.Background(), "n1", "c1", "db", "db.example.internal")
+		require.NoError(t, err)
+		assert.Equal(t, "c1", got.Container)
+		assert.Equal(t, []string{"db", "db.example.internal"}, got.EndpointConfig.Aliases)
+	})
+
+	t.Run("rejects unmanaged container", func(t *testing.T) {
+		fake := whailtest.NewFakeAPIClient()
+		fake.NetworkInspectFn = func(_ context.Context, name string, _ client.NetworkInspectOptions) (client.NetworkInspectResult, error) {
+			return whailtest.ManagedNetworkInspect(name), nil
+		}
+		fake.ContainerInspectFn = func(_ context.Context, id string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+			return whailtest.UnmanagedContainerInspect(id), nil
+		}
+		eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+		_, err := eng.NetworkConnectWithAlias(context.Background(), "n1", "c1", "db")
+		var dockerErr *whail.DockerError
+		require.True(t, errors.As(err, &dockerErr), "want *DockerError, got %v", err)
+		whailtest.AssertNotCalled(t, fake, "NetworkConnect")
+	})
+}
+
+func TestNetworkInspectManaged(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.NetworkInspectFn = func(_ context.Context, name string, _ client.NetworkInspectOptions) (client.NetworkInspectResult, error) {
+		res := whailtest.ManagedNetworkInspect(name)
+		res.Network.Containers = map[string]network.EndpointResource{
+			"managed1":   {Name: "db"},
+			"unmanaged1": {Name: "other"},
+		}
+		return res, nil
+	}
+	fake.ContainerListFn = func(_ context.Context, _ client.ContainerListOptions) (client.ContainerListResult, error) {
+		return client.ContainerListResult{Items: []container.Summary{{ID: "managed1"}}}, nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	res, err := eng.NetworkInspectManaged(context.Background(), "n1", client.NetworkInspectOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]network.EndpointResource{"managed1": {Name: "db"}}, res.Network.Containers)
+}
+
+func TestContainerCreate_EnsureNetworkKeepsAliases(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.NetworkInspectFn = func(_ context.Context, name string, _ client.NetworkInspectOptions) (client.NetworkInspectResult, error) {
+		return whailtest.ManagedNetworkInspect(name), nil
+	}
+	var got client.ContainerCreateOptions
+	fake.ContainerCreateFn = func(_ context.Context, opts client.ContainerCreateOptions) (client.ContainerCreateResult, error) {
+		got = opts
+		return client.ContainerCreateResult{ID: "c1"}, nil
+	}
+	eng := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+
+	_, err := eng.ContainerCreate(context.Background(), whail.ContainerCreateOptions{
+		Config: &container.Config{Image: "postgres"},
+		NetworkingConfig: &network.NetworkingConfig{EndpointsConfig: map[string]*network.EndpointSettings{
+			"n1": {Aliases: []string{"db"}},
+		}},
+		EnsureNetwork: &whail.EnsureNetworkOptions{Name: "n1"},
+	})
+	require.NoError(t, err)
+	ep := got.NetworkingConfig.EndpointsConfig["n1"]
+	require.NotNil(t, ep)
+	assert.Equal(t, "net-n1", ep.NetworkID)
+	assert.Equal(t, []string{"db"}, ep.Aliases)
+}
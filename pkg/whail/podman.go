@@ -0,0 +1,150 @@
+package whail
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/system"
+	"github.com/moby/moby/client"
+)
+
+// Compat describes how the connected daemon departs from Docker Engine.
+// The zero value is Docker Engine.
+type Compat struct {
+	// Podman is set when the daemon is Podman's Docker-compatible API
+	// service (`podman system service`, Podman Desktop, podman machine).
+	Podman bool
+	// Rootless is set when the daemon runs without root. Rootless Docker
+	// reports it too; only rootless Podman changes what the engine does.
+	Rootless bool
+	// Version is the daemon's ServerVersion (Podman's own version on Podman).
+	Version string
+}
+
+// Name names the daemon for diagnostics: "Podman 5.2.1 (rootless)".
+func (c Compat) Name() string {
+	name := "Docker Engine"
+	if c.Podman {
+		name = "Podman"
+	}
+	if c.Version != "" {
+		name += " " + c.Version
+	}
+	if c.Rootless {
+		name += " (rootless)"
+	}
+	return name
+}
+
+// podmanStorageDir is the tail of containers/storage's graph root
+// (/var/lib/containers/storage, ~/.local/share/containers/storage), which
+// Podman reports as DockerRootDir. Docker Engine reports its own data root
+// (/var/lib/docker by default).
+const podmanStorageDir = "containers/storage"
+
+// DetectCompat queries the daemon's /info and reports which runtime serves
+// the API. Podman's compat service answers /info with its containers/storage
+// graph root as DockerRootDir, which no Docker Engine data root uses.
+func DetectCompat(ctx context.Context, p InfoProvider) (Compat, error) {
+	res, err := p.Info(ctx, client.InfoOptions{})
+	if err != nil {
+		return Compat{}, fmt.Errorf("failed to query Docker daemon info: %w", err)
+	}
+	return compatFromInfo(res.Info), nil
+}
+
+func compatFromInfo(info system.Info) Compat {
+	return Compat{
+		Podman:   strings.Contains(info.DockerRootDir, podmanStorageDir),
+		Rootless: slices.Contains(info.SecurityOptions, "name=rootless"),
+		Version:  info.ServerVersion,
+	}
+}
+
+// Compat returns the detected daemon runtime: set by NewWithOptions, or
+// EngineOptions.Compat for NewFromExisting (the zero value — Docker Engine —
+// when unset).
+func (e *Engine) Compat() Compat {
+	return e.compat
+}
+
+// podmanKeepID is the userns mode that maps the invoking host user to the
+// same UID inside the container instead of to root.
+const podmanKeepID = "keep-id"
+
+// hostDockerInternalGateway is the Docker Engine idiom for reaching the host
+// from a container on Linux: --add-host host.docker.internal:host-gateway.
+const hostDockerInternalGateway = "host.docker.internal:host-gateway"
+
+// adjustHostConfig returns the host config to send to a Podman daemon. The
+// caller's struct is never mutated; Docker Engine gets it unchanged.
+//
+//   - Rootless Podman maps the host user to root in the container, so files
+//     a container user with the host UID writes to a bind mount land on the
+//     host as a subordinate UID. An unset userns mode becomes keep-id, which
+//     maps the host user to its own UID (see keepIDUser for the run user).
+//   - Podman writes host.docker.internal (and host.containers.internal)
+//     into /etc/hosts itself, so the Docker Engine host-gateway entry for
+//     it is dropped rather than passed to a daemon that may not know the
+//     host-gateway keyword.
+func (c Compat) adjustHostConfig(hc *container.HostConfig) *container.HostConfig {
+	if !c.Podman || hc == nil {
+		return hc
+	}
+	adjusted := *hc
+	if c.usesKeepID(hc) {
+		adjusted.UsernsMode = podmanKeepID
+	}
+	if slices.Contains(adjusted.ExtraHosts, hostDockerInternalGateway) {
+		adjusted.ExtraHosts = slices.DeleteFunc(slices.Clone(adjusted.ExtraHosts), func(h string) bool {
+			return h == hostDockerInternalGateway
+		})
+	}
+	return &adjusted
+}
+
+// usesKeepID reports whether adjustHostConfig switches hc to keep-id.
+func (c Compat) usesKeepID(hc *container.HostConfig) bool {
+	return c.Podman && c.Rootless && hc != nil && hc.UsernsMode == ""
+}
+
+// keepIDUser returns the user to pin on a keep-id container. Podman runs a
+// keep-id container as the host user unless a user is given, while Docker
+// runs it as the image's USER; pinning the image's user (root when it sets
+// none) keeps Docker's behavior. An explicit cfg.User is kept.
+func (e *Engine) keepIDUser(ctx context.Context, cfg *container.Config) (string, error) {
+	if cfg.User != "" {
+		return cfg.User, nil
+	}
+	res, err := e.APIClient.ImageInspect(ctx, cfg.Image)
+	if err != nil {
+		return "", err
+	}
+	if res.Config != nil && res.Config.User != "" {
+		return res.Config.User, nil
+	}
+	return "0", nil
+}
+
+// checkNetworkCreate rejects network options Podman has no equivalent for:
+// swarm-scoped, overlay, ingress, and attachable networks belong to Docker
+// swarm mode, which Podman does not implement.
+func (c Compat) checkNetworkCreate(name string, opts client.NetworkCreateOptions) error {
+	if !c.Podman {
+		return nil
+	}
+	switch {
+	case opts.Driver == "overlay":
+		return ErrPodmanUnsupported("overlay network "+name, nil)
+	case opts.Scope == "swarm":
+		return ErrPodmanUnsupported("swarm-scoped network "+name, nil)
+	case opts.Ingress:
+		return ErrPodmanUnsupported("ingress network "+name, nil)
+	case opts.Attachable:
+		return ErrPodmanUnsupported("attachable network "+name, nil)
+	}
+	return nil
+}
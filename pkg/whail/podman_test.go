@@ -0,0 +1,154 @@
+package whail_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/system"
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+)
+
+func TestDetectCompat(t *testing.T) {
+	tests := []struct {
+		name string
+		info system.Info
+		want whail.Compat
+	}{
+		{
+			name: "docker engine",
+			info: system.Info{DockerRootDir: "/var/lib/docker", ServerVersion: "28.1.1"},
+			want: whail.Compat{Version: "28.1.1"},
+		},
+		{
+			name: "rootless docker",
+			info: system.Info{DockerRootDir: "/home/me/.local/share/docker", SecurityOptions: []string{"name=seccomp,profile=builtin", "name=rootless"}},
+			want: whail.Compat{Rootless: true},
+		},
+		{
+			name: "rootful podman",
+			info: system.Info{DockerRootDir: "/var/lib/containers/storage", ServerVersion: "5.2.1"},
+			want: whail.Compat{Podman: true, Version: "5.2.1"},
+		},
+		{
+			name: "rootless podman",
+			info: system.Info{DockerRootDir: "/home/me/.local/share/containers/storage", ServerVersion: "5.2.1", SecurityOptions: []string{"name=rootless"}},
+			want: whail.Compat{Podman: true, Rootless: true, Version: "5.2.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := whailtest.NewFakeAPIClient()
+			fake.InfoFn = func(context.Context, client.InfoOptions) (client.SystemInfoResult, error) {
+				return client.SystemInfoResult{Info: tt.info}, nil
+			}
+			got, err := whail.DetectCompat(context.Background(), fake)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.Equal(t, "Podman 5.2.1 (rootless)", whail.Compat{Podman: true, Rootless: true, Version: "5.2.1"}.Name())
+}
+
+func podmanEngine(fake *whailtest.FakeAPIClient, compat whail.Compat) *whail.Engine {
+	opts := whailtest.TestEngineOptions()
+	opts.Compat = &compat
+	return whail.NewFromExisting(fake, opts)
+}
+
+func TestContainerCreate_RootlessPodman(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ImageInspectFn = func(_ context.Context, ref string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+		return whailtest.ManagedImageInspect(ref), nil
+	}
+	var created client.ContainerCreateOptions
+	fake.ContainerCreateFn = func(_ context.Context, opts client.ContainerCreateOptions) (client.ContainerCreateResult, error) {
+		created = opts
+		return client.ContainerCreateResult{ID: "c1"}, nil
+	}
+	eng := podmanEngine(fake, whail.Compat{Podman: true, Rootless: true})
+
+	hostCfg := &container.HostConfig{ExtraHosts: []string{"host.docker.internal:host-gateway", "db:10.0.0.2"}}
+	_, err := eng.ContainerCreate(context.Background(), whail.ContainerCreateOptions{
+		Name:       "agent",
+		Config:     &container.Config{Image: "img:latest"},
+		HostConfig: hostCfg,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, container.UsernsMode("keep-id"), created.HostConfig.UsernsMode)
+	assert.Equal(t, "0", created.Config.User, "the image's user (root when unset) is pinned so keep-id does not change it")
+	assert.Equal(t, []string{"db:10.0.0.2"}, created.HostConfig.ExtraHosts, "Podman provides host.docker.internal itself")
+	assert.Empty(t, hostCfg.UsernsMode, "the caller's host config is not mutated")
+	assert.Len(t, hostCfg.ExtraHosts, 2)
+}
+
+func TestContainerCreate_PodmanKeepsExplicitSettings(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	var created client.ContainerCreateOptions
+	fake.ContainerCreateFn = func(_ context.Context, opts client.ContainerCreateOptions) (client.ContainerCreateResult, error) {
+		created = opts
+		return client.ContainerCreateResult{ID: "c1"}, nil
+	}
+	eng := podmanEngine(fake, whail.Compat{Podman: true, Rootless: true})
+
+	_, err := eng.ContainerCreate(context.Background(), whail.ContainerCreateOptions{
+		Name:       "agent",
+		Config:     &container.Config{Image: "img:latest", User: "1000"},
+		HostConfig: &container.HostConfig{UsernsMode: "host"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, container.UsernsMode("host"), created.HostConfig.UsernsMode)
+	assert.Equal(t, "1000", created.Config.User)
+	assert.NotContains(t, fake.Calls, "ImageInspect")
+}
+
+func TestContainerCreate_DockerUnchanged(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	var created client.ContainerCreateOptions
+	fake.ContainerCreateFn = func(_ context.Context, opts client.ContainerCreateOptions) (client.ContainerCreateResult, error) {
+		created = opts
+		return client.ContainerCreateResult{ID: "c1"}, nil
+	}
+	eng := podmanEngine(fake, whail.Compat{Rootless: true})
+
+	hostCfg := &container.HostConfig{ExtraHosts: []string{"host.docker.internal:host-gateway"}}
+	_, err := eng.ContainerCreate(context.Background(), whail.ContainerCreateOptions{
+		Config:     &container.Config{Image: "img:latest"},
+		HostConfig: hostCfg,
+	})
+	require.NoError(t, err)
+	assert.Same(t, hostCfg, created.HostConfig)
+	assert.Empty(t, created.Config.User)
+}
+
+func TestPodmanUnsupportedFeatures(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	eng := podmanEngine(fake, whail.Compat{Podman: true})
+
+	for _, opts := range []client.NetworkCreateOptions{
+		{Driver: "overlay"},
+		{Scope: "swarm"},
+		{Attachable: true},
+	} {
+		_, err := eng.NetworkCreate(context.Background(), "net", opts)
+		var dockerErr *whail.DockerError
+		require.True(t, errors.As(err, &dockerErr), "%+v", opts)
+		assert.Equal(t, "podman", dockerErr.Op)
+	}
+	err := eng.CheckpointCreate(context.Background(), "c1", client.CheckpointCreateOptions{CheckpointID: "cp"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Checkpoint/restore is not supported by Podman")
+	assert.Empty(t, fake.Calls, "unsupported requests never reach the daemon")
+
+	eng.BuildKitImageBuilder = func(context.Context, whail.ImageBuildKitOptions) error { return nil }
+	err = eng.ImageBuildKit(context.Background(), whail.ImageBuildKitOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BuildKit is not supported by Podman")
+}
@@ -0,0 +1,203 @@
+package whail
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/moby/moby/client"
+)
+
+// Conventional label suffixes resolved against a query's prefix by
+// LabelQuery.Project, Agent, and Purpose (e.g. "com.myapp" + "project").
+const (
+	LabelSuffixProject = "project"
+	LabelSuffixAgent   = "agent"
+	LabelSuffixPurpose = "purpose"
+)
+
+// containerStates are the values the daemon accepts for the "status" filter.
+var containerStates = []string{"created", "restarting", "running", "removing", "paused", "exited", "dead"}
+
+// queryClause is one compiled daemon filter term (e.g. "label" → "k=v").
+type queryClause struct {
+	field string
+	value string
+}
+
+// LabelQuery is a typed builder for daemon list filters:
+//
+//	f, err := whail.Query().Prefix("com.myapp").Project("foo").Agent("bar").Running().Filters()
+//
+// compiles to label=com.myapp.project=foo, label=com.myapp.agent=bar,
+// status=running. Clauses are ANDed, as the daemon does for distinct label
+// terms. LabelQuery is a value type and every method returns a new query, so
+// a shared base can be extended in several directions without aliasing.
+//
+// Invalid input (an empty label key, a key containing "=", an unknown
+// container state) does not panic mid-chain; the first such error is kept
+// and returned by Filters.
+type LabelQuery struct {
+	prefix  string
+	clauses []queryClause
+	err     error
+}
+
+// Query starts an empty, unprefixed query.
+func Query() LabelQuery {
+	return LabelQuery{}
+}
+
+// Query starts a query scoped to this engine: it carries the engine's label
+// prefix (so Project/Agent/Purpose resolve to its keys) and the managed label.
+// List operations inject the managed label anyway; seeding it here makes the
+// compiled filters self-describing.
+func (e *Engine) Query() LabelQuery {
+	return Query().Prefix(e.options.LabelPrefix).Label(e.managedLabelKey, e.managedLabelValue)
+}
+
+// Prefix sets the label namespace used by Project, Agent, and Purpose. A
+// trailing "." is tolerated. Clauses already added are unaffected.
+func (q LabelQuery) Prefix(prefix string) LabelQuery {
+	q.prefix = strings.TrimSuffix(prefix, ".")
+	return q
+}
+
+// Label matches resources whose label key equals value exactly. An empty
+// value matches a label that is present with an empty value — use HasLabel
+// to match on presence alone. Values may contain "="; the daemon splits on
+// the first one, which always falls after the key.
+func (q LabelQuery) Label(key, value string) LabelQuery {
+	if err := validateLabelKey(key); err != nil {
+		return q.fail(err)
+	}
+	return q.with("label", key+"="+value)
+}
+
+// HasLabel matches resources carrying label key with any value.
+func (q LabelQuery) HasLabel(key string) LabelQuery {
+	if err := validateLabelKey(key); err != nil {
+		return q.fail(err)
+	}
+	return q.with("label", key)
+}
+
+// Labels adds a Label clause per entry, in key order so the compiled query
+// is deterministic.
+func (q LabelQuery) Labels(labels map[string]string) LabelQuery {
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		q = q.Label(k, labels[k])
+	}
+	return q
+}
+
+// Project matches {prefix}.project=name.
+func (q LabelQuery) Project(name string) LabelQuery {
+	return q.Label(q.key(LabelSuffixProject), name)
+}
+
+// Agent matches {prefix}.agent=name.
+func (q LabelQuery) Agent(name string) LabelQuery {
+	return q.Label(q.key(LabelSuffixAgent), name)
+}
+
+// Purpose matches {prefix}.purpose=purpose.
+func (q LabelQuery) Purpose(purpose string) LabelQuery {
+	return q.Label(q.key(LabelSuffixPurpose), purpose)
+}
+
+// Name matches resources whose name contains name (the daemon's "name"
+// filter is a substring/regexp match, not an exact one).
+func (q LabelQuery) Name(name string) LabelQuery {
+	if name == "" {
+		return q.fail(fmt.Errorf("name filter: empty name"))
+	}
+	return q.with("name", name)
+}
+
+// Status matches containers in any of the given states. Repeated status
+// values are ORed by the daemon.
+func (q LabelQuery) Status(states ...string) LabelQuery {
+	for _, s := range states {
+		if !slices.Contains(containerStates, s) {
+			return q.fail(fmt.Errorf("status filter: unknown container state %q (want one of %s)",
+				s, strings.Join(containerStates, ", ")))
+		}
+		q = q.with("status", s)
+	}
+	return q
+}
+
+// Running matches running containers.
+func (q LabelQuery) Running() LabelQuery {
+	return q.Status("running")
+}
+
+// Filters compiles the query into daemon filters, or returns the first error
+// recorded while building it.
+func (q LabelQuery) Filters() (client.Filters, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	f := client.Filters{}
+	for _, c := range q.clauses {
+		f = f.Add(c.field, c.value)
+	}
+	return f, nil
+}
+
+// MustFilters is Filters for queries whose keys are compile-time constants;
+// it panics on a build error.
+func (q LabelQuery) MustFilters() client.Filters {
+	f, err := q.Filters()
+	if err != nil {
+		panic(fmt.Sprintf("whail: invalid query: %v", err))
+	}
+	return f
+}
+
+// String renders the compiled clauses as field=value terms in insertion
+// order, for logs and error messages.
+func (q LabelQuery) String() string {
+	terms := make([]string, len(q.clauses))
+	for i, c := range q.clauses {
+		terms[i] = c.field + "=" + c.value
+	}
+	return strings.Join(terms, " ")
+}
+
+// key resolves a conventional label suffix against the query prefix.
+func (q LabelQuery) key(suffix string) string {
+	if q.prefix == "" {
+		return suffix
+	}
+	return q.prefix + "." + suffix
+}
+
+// with appends a clause without sharing the backing array with q, so sibling
+// queries derived from the same base never overwrite each other.
+func (q LabelQuery) with(field, value string) LabelQuery {
+	q.clauses = append(slices.Clip(q.clauses), queryClause{field: field, value: value})
+	return q
+}
+
+func (q LabelQuery) fail(err error) LabelQuery {
+	if q.err == nil {
+		q.err = err
+	}
+	return q
+}
+
+// validateLabelKey rejects keys the daemon's "key=value" filter syntax cannot
+// express: an empty key, or one containing "=" (the daemon splits on the
+// first "=", so the remainder would be read as the value).
+func validateLabelKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("label filter: empty key")
+	}
+	if strings.Contains(key, "=") {
+		return fmt.Errorf("label filter: key %q contains '='", key)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package whail
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/moby/moby/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// filterTerms flattens client.Filters into field → sorted values for
+// comparisons that don't depend on map iteration order.
+func filterTerms(f client.Filters) map[string][]string {
+	out := map[string][]string{}
+	for field, values := range f {
+		for v := range values {
+			out[field] = append(out[field], v)
+		}
+	}
+	for _, vals := range out {
+		slices.Sort(vals)
+	}
+	return out
+}
+
+func TestQuery_Compile(t *testing.T) {
+	tests := []struct {
+		name  string
+		query LabelQuery
+		want  map[string][]string
+	}{
+		{
+			name:  "empty query",
+			query: Query(),
+			want:  map[string][]string{},
+		},
+		{
+			name:  "project agent running with prefix",
+			query: Query().Prefix("com.myapp").Project("foo").Agent("bar").Running(),
+			want: map[string][]string{
+				"label":  {"com.myapp.agent=bar", "com.myapp.project=foo"},
+				"status": {"running"},
+			},
+		},
+		{
+			name:  "trailing dot on prefix is tolerated",
+			query: Query().Prefix("com.myapp.").Purpose("agent"),
+			want:  map[string][]string{"label": {"com.myapp.purpose=agent"}},
+		},
+		{
+			name:  "unprefixed conventions use the bare suffix",
+			query: Query().Project("foo"),
+			want:  map[string][]string{"label": {"project=foo"}},
+		},
+		{
+			name:  "value containing equals is kept whole",
+			query: Query().Label("k", "a=b=c"),
+			want:  map[string][]string{"label": {"k=a=b=c"}},
+		},
+		{
+			name:  "empty value matches empty label, not presence",
+			query: Query().Label("k", ""),
+			want:  map[string][]string{"label": {"k="}},
+		},
+		{
+			name:  "presence-only match has no equals",
+			query: Query().HasLabel("k"),
+			want:  map[string][]string{"label": {"k"}},
+		},
+		{
+			name:  "value whitespace and quotes are passed through verbatim",
+			query: Query().Label("k", ` "quoted" value `),
+			want:  map[string][]string{"label": {`k= "quoted" value `}},
+		},
+		{
+			name:  "duplicate clauses collapse",
+			query: Query().Label("k", "v").Label("k", "v"),
+			want:  map[string][]string{"label": {"k=v"}},
+		},
+		{
+			name:  "multiple states",
+			query: Query().Status("exited", "dead"),
+			want:  map[string][]string{"status": {"dead", "exited"}},
+		},
+		{
+			name:  "name filter",
+			query: Query().Name("otel-collector").Running(),
+			want: map[string][]string{
+				"name":   {"otel-collector"},
+				"status": {"running"},
+			},
+		},
+		{
+			name:  "labels map",
+			query: Query().Labels(map[string]string{"b": "2", "a": "1"}),
+			want:  map[string][]string{"label": {"a=1", "b=2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := tt.query.Filters()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, filterTerms(f))
+		})
+	}
+}
+
+func TestQuery_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   LabelQuery
+		wantErr string
+	}{
+		{
+			name:    "empty key",
+			query:   Query().Label("", "v"),
+			wantErr: "label filter: empty key",
+		},
+		{
+			name:    "key containing equals",
+			query:   Query().Label("a=b", "v"),
+			wantErr: `label filter: key "a=b" contains '='`,
+		},
+		{
+			name:    "presence check with equals in key",
+			query:   Query().HasLabel("a=b"),
+			wantErr: `label filter: key "a=b" contains '='`,
+		},
+		{
+			name:    "unknown state",
+			query:   Query().Status("up"),
+			wantErr: `status filter: unknown container state "up"`,
+		},
+		{
+			name:    "empty name",
+			query:   Query().Name(""),
+			wantErr: "name filter: empty name",
+		},
+		{
+			name:    "first error wins and later clauses do not clear it",
+			query:   Query().Label("", "v").Status("up").Project("ok"),
+			wantErr: "label filter: empty key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := tt.query.Filters()
+			require.Error(t, err)
+			assert.Nil(t, f)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Panics(t, func() { tt.query.MustFilters() })
+		})
+	}
+}
+
+func TestQuery_Immutable(t *testing.T) {
+	// Build a base with spare capacity, then branch it twice: neither branch
+	// may observe the other's clause.
+	base := Query().Prefix("com.myapp").Label("a", "1").Label("b", "2").Label("c", "3")
+	left := base.Project("left")
+	right := base.Project("right")
+
+	assert.Equal(t, "label=a=1 label=b=2 label=c=3", base.String())
+	assert.Equal(t, "label=a=1 label=b=2 label=c=3 label=com.myapp.project=left", left.String())
+	assert.Equal(t, "label=a=1 label=b=2 label=c=3 label=com.myapp.project=right", right.String())
+
+	// An error on one branch does not leak into the base.
+	_ = base.Label("", "x")
+	_, err := base.Filters()
+	assert.NoError(t, err)
+}
+
+func TestEngine_Query(t *testing.T) {
+	e := NewFromExisting(nil, EngineOptions{LabelPrefix: "com.myapp"})
+
+	f, err := e.Query().Project("foo").Filters()
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"label": {"com.myapp.managed=true", "com.myapp.project=foo"},
+	}, filterTerms(f))
+}
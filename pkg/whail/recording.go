@@ -0,0 +1,135 @@
+package whail
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RecordedBuildEvent pairs a build progress event with its timing delay.
+// Delay represents the wall-clock time since the previous event (or since
+// recording started for the first event).
+type RecordedBuildEvent struct {
+	// DelayMs is the delay in milliseconds since the previous event.
+	// Stored as int64 for clean JSON (avoids float precision issues).
+	DelayMs int64              `json:"delay_ms"`
+	Event   BuildProgressEvent `json:"event"`
+}
+
+// Delay returns the delay as a time.Duration.
+func (e RecordedBuildEvent) Delay() time.Duration {
+	return time.Duration(e.DelayMs) * time.Millisecond
+}
+
+// RecordedBuildScenario is a named sequence of timed build events that can be
+// serialized to/from JSON for deterministic replay without Docker. Command
+// names the CLI command a real-world recording came from ("image build");
+// synthetic scenarios leave it empty.
+type RecordedBuildScenario struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Command     string               `json:"command,omitempty"`
+	Events      []RecordedBuildEvent `json:"events"`
+}
+
+// FlatEvents extracts just the BuildProgressEvents without timing information.
+func (s *RecordedBuildScenario) FlatEvents() []BuildProgressEvent {
+	events := make([]BuildProgressEvent, len(s.Events))
+	for i, re := range s.Events {
+		events[i] = re.Event
+	}
+	return events
+}
+
+// LoadRecordedScenario reads a RecordedBuildScenario from a JSON file.
+func LoadRecordedScenario(path string) (*RecordedBuildScenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read recorded scenario %s: %w", path, err)
+	}
+	var scenario RecordedBuildScenario
+	if err := json.Unmarshal(data, &scenario); err != nil {
+		return nil, fmt.Errorf("parse recorded scenario %s: %w", path, err)
+	}
+	return &scenario, nil
+}
+
+// SaveRecordedScenario writes a RecordedBuildScenario to a JSON file.
+// Creates parent directories as needed.
+func SaveRecordedScenario(path string, scenario *RecordedBuildScenario) error {
+	data, err := json.MarshalIndent(scenario, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal recorded scenario: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write recorded scenario %s: %w", path, err)
+	}
+	return nil
+}
+
+// EventRecorder wraps a BuildProgressFunc callback, capturing each event with
+// wall-clock timing. The CLI's --record flag uses it to capture real Docker
+// sessions as scenarios that replay without Docker.
+type EventRecorder struct {
+	name  string
+	desc  string
+	inner BuildProgressFunc
+
+	mu      sync.Mutex
+	events  []RecordedBuildEvent
+	lastAt  time.Time
+	started bool
+}
+
+// NewEventRecorder creates a recorder that forwards events to inner (which may be nil).
+func NewEventRecorder(name, desc string, inner BuildProgressFunc) *EventRecorder {
+	return &EventRecorder{
+		name:  name,
+		desc:  desc,
+		inner: inner,
+	}
+}
+
+// OnProgress returns a BuildProgressFunc suitable for use as a callback.
+// Each invocation records the event and its delay since the previous event.
+func (r *EventRecorder) OnProgress() BuildProgressFunc {
+	return func(event BuildProgressEvent) {
+		r.mu.Lock()
+		now := time.Now()
+		var delayMs int64
+		if r.started {
+			delayMs = max(now.Sub(r.lastAt).Milliseconds(), 0)
+		}
+		r.lastAt = now
+		r.started = true
+		r.events = append(r.events, RecordedBuildEvent{
+			DelayMs: delayMs,
+			Event:   event,
+		})
+		r.mu.Unlock()
+
+		if r.inner != nil {
+			r.inner(event)
+		}
+	}
+}
+
+// Scenario returns the captured events as a RecordedBuildScenario.
+// Call after the build completes.
+func (r *EventRecorder) Scenario() *RecordedBuildScenario {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := make([]RecordedBuildEvent, len(r.events))
+	copy(events, r.events)
+	return &RecordedBuildScenario{
+		Name:        r.name,
+		Description: r.desc,
+		Events:      events,
+	}
+}
@@ -0,0 +1,75 @@
+package whail
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/moby/moby/client"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingClientOpts returns the moby client options that send one span per
+// Docker API request to tp. The client's otelhttp transport does the
+// instrumentation — each span carries the request method, full URL (and so
+// the container/image/volume ID), status code, duration, and error status —
+// and joins whatever trace the request context carries, so spans nest under
+// the caller's. A nil tp adds nothing and the client keeps the global
+// (no-op unless installed) provider.
+func tracingClientOpts(tp trace.TracerProvider) []client.Opt {
+	if tp == nil {
+		return nil
+	}
+	return []client.Opt{
+		client.WithTraceProvider(tp),
+		client.WithTraceOptions(otelhttp.WithSpanNameFormatter(spanName)),
+	}
+}
+
+// collectionOps are the second path segments that name an operation on the
+// resource collection rather than a resource ID (/containers/json,
+// /images/create, /volumes/prune, /system/df).
+var collectionOps = map[string]bool{
+	"json": true, "create": true, "prune": true, "load": true,
+	"get": true, "search": true, "df": true,
+}
+
+// resourceOps are the trailing path segments that name an operation on one
+// resource (/containers/{id}/stop). Anything else after the ID is part of a
+// multi-segment ID — image references contain slashes.
+var resourceOps = map[string]bool{
+	"archive": true, "attach": true, "changes": true, "checkpoints": true,
+	"commit": true, "connect": true, "disconnect": true, "exec": true,
+	"export": true, "get": true, "history": true, "json": true, "kill": true,
+	"logs": true, "pause": true, "push": true, "rename": true,
+	"resize": true, "restart": true, "start": true, "stats": true,
+	"stop": true, "tag": true, "top": true, "unpause": true, "update": true,
+	"wait": true,
+}
+
+// spanName names a Docker API span by method and path template:
+// "POST /containers/{id}/stop" for POST /v1.51/containers/3f2a.../stop. The
+// API version and resource IDs are dropped so span names stay low
+// cardinality — the collector derives RED metrics per span name — while
+// the ID remains on the span's URL attribute.
+func spanName(_ string, req *http.Request) string {
+	path := strings.Trim(req.URL.Path, "/")
+	if strings.HasPrefix(path, "v1.") {
+		if i := strings.IndexByte(path, '/'); i >= 0 {
+			path = path[i+1:]
+		} else {
+			path = ""
+		}
+	}
+	segs := strings.Split(path, "/")
+	switch {
+	case len(segs) == 1:
+		// /_ping, /info, /build, /events
+	case len(segs) == 2 && collectionOps[segs[1]]:
+	case resourceOps[segs[len(segs)-1]] && len(segs) > 2:
+		segs = []string{segs[0], "{id}", segs[len(segs)-1]}
+	default:
+		segs = []string{segs[0], "{id}"}
+	}
+	return req.Method + " /" + strings.Join(segs, "/")
+}
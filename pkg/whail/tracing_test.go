@@ -0,0 +1,67 @@
+package whail
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpanName(t *testing.T) {
+	tests := []struct {
+		method, path, want string
+	}{
+		{"HEAD", "/_ping", "HEAD /_ping"},
+		{"GET", "/v1.51/info", "GET /info"},
+		{"GET", "/v1.51/containers/json", "GET /containers/json"},
+		{"POST", "/v1.51/containers/create", "POST /containers/create"},
+		{"POST", "/v1.51/containers/3f2a9c/stop", "POST /containers/{id}/stop"},
+		{"DELETE", "/v1.51/containers/3f2a9c", "DELETE /containers/{id}"},
+		{"GET", "/v1.51/images/docker.io/library/alpine:3.20/json", "GET /images/{id}/json"},
+		{"DELETE", "/v1.51/images/library/alpine", "DELETE /images/{id}"},
+		{"POST", "/v1.51/exec/9bc1/start", "POST /exec/{id}/start"},
+		{"GET", "/v1.51/volumes/clawker.app.dev-claude.config", "GET /volumes/{id}"},
+		{"GET", "/v1.51/system/df", "GET /system/df"},
+		{"POST", "/build", "POST /build"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			assert.Equal(t, tt.want, spanName("", req))
+		})
+	}
+}
+
+func TestNewWithOptions_TracerProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Api-Version", "1.51")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	t.Setenv("DOCKER_HOST", "tcp://"+strings.TrimPrefix(srv.URL, "http://"))
+
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "command")
+	e, err := NewWithOptions(ctx, EngineOptions{LabelPrefix: "com.test", TracerProvider: tp})
+	require.NoError(t, err)
+	defer e.Close()
+	parent.End()
+
+	var ping sdktrace.ReadOnlySpan
+	for _, s := range rec.Ended() {
+		if strings.HasSuffix(s.Name(), " /_ping") {
+			ping = s
+		}
+	}
+	require.NotNil(t, ping, "health check ping should emit a span")
+	assert.Equal(t, parent.SpanContext().TraceID(), ping.SpanContext().TraceID())
+	assert.Equal(t, parent.SpanContext().SpanID(), ping.Parent().SpanID())
+}
@@ -115,6 +115,12 @@ type ImageBuildKitOptions struct {
 	// NetworkMode sets the network mode for RUN instructions.
 	NetworkMode string
 
+	// Platforms are the target platforms (e.g., "linux/amd64", "linux/arm64").
+	// Empty builds for the daemon's platform. More than one produces a
+	// multi-platform image, which the daemon can only load when it uses the
+	// containerd image store (see MultiPlatformImageStore).
+	Platforms []string
+
 	// OnProgress receives build progress events when non-nil.
 	// Called from the progress-draining goroutine — must be safe for concurrent use.
 	OnProgress BuildProgressFunc
@@ -2,8 +2,10 @@ package whail
 
 import (
 	"context"
+	"strconv"
 
 	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/volume"
 	"github.com/moby/moby/client"
 )
 
@@ -18,9 +20,12 @@ func (e *Engine) VolumeCreate(ctx context.Context, options client.VolumeCreateOp
 	} else {
 		options.Labels = MergeLabels(options.Labels, labels)
 	}
-	// Ensure managed label cannot be overridden by extra labels.
-	options.Labels[e.managedLabelKey] = e.managedLabelValue
+	// Ensure the managed and label-schema labels cannot be overridden by extra labels.
+	e.enforceManagedLabels(options.Labels)
 
+	if e.skipDryRun("create", "volume", options.Name) {
+		return client.VolumeCreateResult{Volume: volume.Volume{Name: options.Name, Driver: options.Driver, Labels: options.Labels}}, nil
+	}
 	result, err := e.APIClient.VolumeCreate(ctx, options)
 	if err != nil {
 		return client.VolumeCreateResult{}, ErrVolumeCreateFailed(options.Name, err)
@@ -38,6 +43,9 @@ func (e *Engine) VolumeRemove(ctx context.Context, volumeID string, force bool)
 	if !isManaged {
 		return client.VolumeRemoveResult{}, ErrVolumeNotFound(volumeID, nil)
 	}
+	if e.skipDryRun("remove", "volume", volumeID) {
+		return client.VolumeRemoveResult{}, nil
+	}
 	result, err := e.APIClient.VolumeRemove(ctx, volumeID, client.VolumeRemoveOptions{Force: force})
 	if err != nil {
 		return client.VolumeRemoveResult{}, ErrVolumeRemoveFailed(volumeID, err)
@@ -73,11 +81,13 @@ func (e *Engine) VolumeExists(ctx context.Context, volumeID string) (bool, error
 // VolumeList lists volumes matching the filter.
 // The managed label filter is automatically injected.
 func (e *Engine) VolumeList(ctx context.Context, extraFilters ...map[string]string) (client.VolumeListResult, error) {
-	f := e.newManagedFilter()
+	q := e.Query()
 	for _, labels := range extraFilters {
-		for k, v := range labels {
-			f = f.Add("label", k+"="+v)
-		}
+		q = q.Labels(labels)
+	}
+	f, err := q.Filters()
+	if err != nil {
+		return client.VolumeListResult{}, ErrVolumeListFailed(err)
 	}
 	result, err := e.APIClient.VolumeList(ctx, client.VolumeListOptions{Filters: f})
 	if err != nil {
@@ -112,12 +122,18 @@ func (e *Engine) IsVolumeManaged(ctx context.Context, name string) (bool, error)
 // If all is true, prunes all unused volumes including named ones.
 // If all is false, only prunes anonymous volumes (Docker's default behavior).
 func (e *Engine) VolumesPrune(ctx context.Context, all bool, extraFilters ...map[string]string) (client.VolumePruneResult, error) {
-	f := e.newManagedFilter()
+	q := e.Query()
 	for _, labels := range extraFilters {
-		for k, v := range labels {
-			f = f.Add("label", k+"="+v)
-		}
+		q = q.Labels(labels)
+	}
+	f, err := q.Filters()
+	if err != nil {
+		return client.VolumePruneResult{}, ErrVolumesPruneFailed(err)
+	}
+	if e.DryRun() {
+		return e.dryRunVolumesPrune(ctx, all, f)
 	}
+	e.notifyMutation(Operation{Action: "prune", Resource: "volume", Params: map[string]string{"all": strconv.FormatBool(all)}})
 	result, err := e.APIClient.VolumePrune(ctx, client.VolumePruneOptions{All: all, Filters: f})
 	if err != nil {
 		return client.VolumePruneResult{}, ErrVolumesPruneFailed(err)
@@ -19,4 +19,8 @@
 //
 //	// Assert calls were made
 //	whailtest.AssertCalled(t, fake, "ContainerStop")
+//
+// For flows spanning several calls (create → start → inspect), FakeState
+// backs the container, image and network methods with one in-memory store
+// instead of per-call stubs; see NewFakeState.
 package whailtest
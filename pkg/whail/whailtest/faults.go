@@ -0,0 +1,123 @@
+package whailtest
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// AnyMethod applies a fault to every method of the fake; a fault set for a
+// specific method takes precedence.
+const AnyMethod = "*"
+
+// ErrInjectedFault is the error WithErrorRate returns when it is given nil.
+var ErrInjectedFault = errors.New("whailtest: injected fault")
+
+// faultSeed is the default seed of the fault RNG, so a test sees the same
+// failure sequence on every run. SetFaultSeed changes it.
+const faultSeed = 1
+
+// fault is the latency and failure injected into one method.
+type fault struct {
+	latency time.Duration
+	rate    float64
+	err     error
+}
+
+// WithLatency delays every call to method (or AnyMethod) by d before the Fn
+// runs. The delay honors the call's context: a deadline or cancel ends it
+// early and the call returns ctx.Err() without reaching the Fn — the same
+// outcome as a slow daemon behind a client timeout. Returns f for chaining.
+func (f *FakeAPIClient) WithLatency(method string, d time.Duration) *FakeAPIClient {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	flt := f.faultLocked(method)
+	flt.latency = d
+	return f
+}
+
+// WithErrorRate makes calls to method (or AnyMethod) fail with err with
+// probability p (0 never, 1 always) instead of running the Fn; a nil err
+// becomes ErrInjectedFault. Failing calls are still recorded in Calls. The
+// draws come from a seeded RNG, so a given test fails on the same calls
+// every run. Returns f for chaining.
+func (f *FakeAPIClient) WithErrorRate(method string, p float64, err error) *FakeAPIClient {
+	if err == nil {
+		err = ErrInjectedFault
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	flt := f.faultLocked(method)
+	flt.rate = p
+	flt.err = err
+	return f
+}
+
+// SetFaultSeed reseeds the RNG WithErrorRate draws from.
+func (f *FakeAPIClient) SetFaultSeed(seed uint64) {
+	f.mu.Lock()
+	f.rng = rand.New(rand.NewPCG(seed, seed))
+	f.mu.Unlock()
+}
+
+// ClearFaults removes all injected latency and errors.
+func (f *FakeAPIClient) ClearFaults() {
+	f.mu.Lock()
+	f.faults = nil
+	f.mu.Unlock()
+}
+
+// faultLocked returns method's fault, creating it. f.mu must be held.
+func (f *FakeAPIClient) faultLocked(method string) *fault {
+	if f.faults == nil {
+		f.faults = make(map[string]*fault)
+	}
+	flt, ok := f.faults[method]
+	if !ok {
+		flt = &fault{}
+		f.faults[method] = flt
+	}
+	return flt
+}
+
+// inject applies method's fault, falling back to AnyMethod's: it sleeps the
+// latency (returning ctx.Err() if the context ends first) and then returns
+// the injected error when the draw fails. A nil result lets the call through.
+func (f *FakeAPIClient) inject(ctx context.Context, method string) error {
+	f.mu.Lock()
+	flt, ok := f.faults[method]
+	if !ok {
+		flt, ok = f.faults[AnyMethod]
+	}
+	if !ok {
+		f.mu.Unlock()
+		return nil
+	}
+	latency, err := flt.latency, flt.err
+	fail := flt.rate > 0 && (flt.rate >= 1 || f.randLocked() < flt.rate)
+	f.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if fail {
+		return err
+	}
+	return nil
+}
+
+// randLocked draws from the fault RNG, seeding it on first use. f.mu must
+// be held.
+func (f *FakeAPIClient) randLocked() float64 {
+	if f.rng == nil {
+		f.rng = rand.New(rand.NewPCG(faultSeed, faultSeed))
+	}
+	return f.rng.Float64()
+}
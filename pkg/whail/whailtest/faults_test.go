@@ -0,0 +1,126 @@
+package whailtest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/client"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pingFake returns a fake whose Ping succeeds and counts the calls that reach it.
+func pingFake() (*whailtest.FakeAPIClient, *int) {
+	fake := whailtest.NewFakeAPIClient()
+	reached := 0
+	fake.PingFn = func(context.Context, client.PingOptions) (client.PingResult, error) {
+		reached++
+		return client.PingResult{}, nil
+	}
+	return fake, &reached
+}
+
+func TestWithLatency_DelaysCall(t *testing.T) {
+	fake, reached := pingFake()
+	fake.WithLatency("Ping", 20*time.Millisecond)
+
+	start := time.Now()
+	_, err := fake.Ping(context.Background(), client.PingOptions{})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	assert.Equal(t, 1, *reached)
+}
+
+func TestWithLatency_HonorsDeadline(t *testing.T) {
+	fake, reached := pingFake()
+	fake.WithLatency("Ping", time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := fake.Ping(ctx, client.PingOptions{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, *reached, "a timed-out call never reaches the Fn")
+	assert.Equal(t, []string{"Ping"}, fake.Calls)
+}
+
+func TestWithErrorRate(t *testing.T) {
+	t.Run("always", func(t *testing.T) {
+		fake, reached := pingFake()
+		fake.WithErrorRate("Ping", 1, nil)
+		for range 5 {
+			_, err := fake.Ping(context.Background(), client.PingOptions{})
+			require.ErrorIs(t, err, whailtest.ErrInjectedFault)
+		}
+		assert.Zero(t, *reached)
+		assert.Len(t, fake.Calls, 5, "failing calls are recorded")
+	})
+
+	t.Run("never", func(t *testing.T) {
+		fake, reached := pingFake()
+		fake.WithErrorRate("Ping", 0, nil)
+		for range 5 {
+			_, err := fake.Ping(context.Background(), client.PingOptions{})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 5, *reached)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		fake, _ := pingFake()
+		boom := errors.New("boom")
+		fake.WithErrorRate("Ping", 1, boom)
+		_, err := fake.Ping(context.Background(), client.PingOptions{})
+		assert.ErrorIs(t, err, boom)
+	})
+
+	t.Run("deterministic per seed", func(t *testing.T) {
+		run := func(seed uint64) []bool {
+			fake, _ := pingFake()
+			fake.SetFaultSeed(seed)
+			fake.WithErrorRate("Ping", 0.5, nil)
+			var failed []bool
+			for range 32 {
+				_, err := fake.Ping(context.Background(), client.PingOptions{})
+				failed = append(failed, err != nil)
+			}
+			return failed
+		}
+		first := run(7)
+		assert.Equal(t, first, run(7))
+		assert.Contains(t, first, true)
+		assert.Contains(t, first, false)
+	})
+}
+
+func TestFaults_AnyMethodAndPrecedence(t *testing.T) {
+	fake, reached := pingFake()
+	fake.InfoFn = func(context.Context, client.InfoOptions) (client.SystemInfoResult, error) {
+		return client.SystemInfoResult{}, nil
+	}
+	fake.WithErrorRate(whailtest.AnyMethod, 1, nil).WithErrorRate("Ping", 0, nil)
+
+	_, err := fake.Info(context.Background(), client.InfoOptions{})
+	require.ErrorIs(t, err, whailtest.ErrInjectedFault, "AnyMethod applies to methods without their own fault")
+	_, err = fake.Ping(context.Background(), client.PingOptions{})
+	require.NoError(t, err, "a method's own fault takes precedence")
+	assert.Equal(t, 1, *reached)
+
+	fake.ClearFaults()
+	_, err = fake.Info(context.Background(), client.InfoOptions{})
+	assert.NoError(t, err)
+}
+
+func TestWithErrorRate_ContainerWait(t *testing.T) {
+	fake := whailtest.NewFakeAPIClient()
+	fake.ContainerWaitFn = func(context.Context, string, client.ContainerWaitOptions) client.ContainerWaitResult {
+		t.Fatal("ContainerWaitFn reached")
+		return client.ContainerWaitResult{}
+	}
+	fake.WithErrorRate("ContainerWait", 1, nil)
+
+	res := fake.ContainerWait(context.Background(), "c1", client.ContainerWaitOptions{})
+	assert.ErrorIs(t, <-res.Error, whailtest.ErrInjectedFault)
+}
@@ -1,7 +1,11 @@
 package whailtest
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"iter"
 	"slices"
 	"testing"
 	"time"
@@ -9,6 +13,7 @@ import (
 	dockerspec "github.com/moby/docker-image-spec/specs-go/v1"
 	"github.com/moby/moby/api/types/container"
 	dockerimage "github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/api/types/jsonstream"
 	"github.com/moby/moby/api/types/network"
 	"github.com/moby/moby/api/types/volume"
 	"github.com/moby/moby/client"
@@ -320,3 +325,44 @@ func FakeTimedBuildKitBuilder(capture *BuildKitCapture) func(context.Context, wh
 		return capture.Err
 	}
 }
+
+// --- Push/pull test helpers ---
+
+// TransferResponse is a canned push/pull response: it satisfies both
+// client.ImagePushResponse and client.ImagePullResponse and replays the
+// given messages as the daemon's JSON stream.
+type TransferResponse struct {
+	io.ReadCloser
+	messages []jsonstream.Message
+}
+
+// NewTransferResponse returns a TransferResponse replaying messages.
+func NewTransferResponse(messages ...jsonstream.Message) *TransferResponse {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, m := range messages {
+		_ = enc.Encode(m)
+	}
+	return &TransferResponse{ReadCloser: io.NopCloser(&buf), messages: messages}
+}
+
+// JSONMessages yields the canned messages.
+func (r *TransferResponse) JSONMessages(_ context.Context) iter.Seq2[jsonstream.Message, error] {
+	return func(yield func(jsonstream.Message, error) bool) {
+		for _, m := range r.messages {
+			if !yield(m, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Wait returns the first in-band error, like the real response.
+func (r *TransferResponse) Wait(_ context.Context) error {
+	for _, m := range r.messages {
+		if m.Error != nil {
+			return m.Error
+		}
+	}
+	return nil
+}
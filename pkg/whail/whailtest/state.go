@@ -0,0 +1,1128 @@
+package whailtest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	dockerspec "github.com/moby/docker-image-spec/specs-go/v1"
+	"github.com/moby/moby/api/types/container"
+	dockerimage "github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/api/types/network"
+	"github.com/moby/moby/client"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// FakeState is an in-memory model of a Docker daemon's containers, images and
+// networks for stateful tests. Installed on a FakeAPIClient it backs the
+// container, image and network methods with one shared store and the daemon's
+// state transitions (created → running ⇄ paused → exited → removed), so a
+// create → start → inspect flow needs no hand-wired responses:
+//
+//	fake := whailtest.NewFakeAPIClient()
+//	state := whailtest.NewFakeState()
+//	state.Install(fake)
+//	state.AddImage("alpine:latest", nil)
+//
+//	engine := whail.NewFromExisting(fake, whailtest.TestEngineOptions())
+//	// engine.ContainerCreate / ContainerStart / ContainerInspect now agree.
+//
+// Calls are still recorded on the fake, and any Fn set after Install replaces
+// the state's handler for that one method. Methods the state does not back
+// (exec, attach, logs, copy, volumes, builds, …) keep the fake's defaults.
+//
+// Transitions follow the daemon: starting a running container is a no-op,
+// pausing a stopped one or removing a running one without Force is a conflict,
+// and lookups that miss return errors satisfying errdefs.IsNotFound. Stop
+// exits 0 and SIGKILL exits 137; a container's own exit is simulated with
+// Exit. AutoRemove containers are removed when they exit.
+type FakeState struct {
+	// Labels are merged into every image, network and container seeded with
+	// AddImage, AddNetwork and AddContainer, so seeded resources pass whail's
+	// managed checks. Defaults to the whailtest managed label. Resources
+	// created through the API carry whatever labels the caller sends.
+	Labels map[string]string
+
+	mu         sync.Mutex
+	seq        int
+	containers []*container.InspectResponse // creation order
+	images     []*dockerimage.InspectResponse
+	networks   []*network.Inspect
+	waiters    []*stateWaiter
+}
+
+// Snapshot is a deep copy of a FakeState's store, in creation order. Take one
+// to assert on the whole daemon state at once, or to Restore a seeded baseline
+// between subtests.
+type Snapshot struct {
+	Containers []container.InspectResponse
+	Images     []dockerimage.InspectResponse
+	Networks   []network.Inspect
+}
+
+// ContainerSpec seeds a container with AddContainer.
+type ContainerSpec struct {
+	// Name is the container name, without the leading slash.
+	Name string
+	// Image is the reference of an image already in the state.
+	Image string
+	// Labels are merged over FakeState.Labels.
+	Labels map[string]string
+	// Running starts the container after creating it.
+	Running bool
+}
+
+// stateWaiter is a pending ContainerWait.
+type stateWaiter struct {
+	id   string
+	cond container.WaitCondition
+	ch   chan container.WaitResponse
+}
+
+// builtinNetworks are the networks every daemon has. Containers may use them
+// as their network mode without seeding; they are not listed or inspectable.
+var builtinNetworks = []string{"bridge", "host", "none", "default"}
+
+// stateEpoch is the creation time of the first resource; each later resource
+// is one second newer, so ordering by creation time is deterministic.
+var stateEpoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// NewFakeState returns an empty FakeState that labels seeded resources with
+// the whailtest managed label.
+func NewFakeState() *FakeState {
+	return &FakeState{Labels: map[string]string{testManagedLabelKey: "true"}}
+}
+
+// Install points f's container, image and network Fn fields at the state.
+func (s *FakeState) Install(f *FakeAPIClient) {
+	f.ContainerCreateFn = s.containerCreate
+	f.ContainerStartFn = s.containerStart
+	f.ContainerStopFn = s.containerStop
+	f.ContainerKillFn = s.containerKill
+	f.ContainerRestartFn = s.containerRestart
+	f.ContainerPauseFn = s.containerPause
+	f.ContainerUnpauseFn = s.containerUnpause
+	f.ContainerRemoveFn = s.containerRemove
+	f.ContainerRenameFn = s.containerRename
+	f.ContainerInspectFn = s.containerInspect
+	f.ContainerListFn = s.containerList
+	f.ContainerWaitFn = s.containerWait
+
+	f.ImageInspectFn = s.imageInspect
+	f.ImageListFn = s.imageList
+	f.ImageRemoveFn = s.imageRemove
+	f.ImageTagFn = s.imageTag
+
+	f.NetworkCreateFn = s.networkCreate
+	f.NetworkInspectFn = s.networkInspect
+	f.NetworkListFn = s.networkList
+	f.NetworkRemoveFn = s.networkRemove
+	f.NetworkConnectFn = s.networkConnect
+	f.NetworkDisconnectFn = s.networkDisconnect
+}
+
+// --- Seeding ---
+
+// AddImage adds an image tagged ref (":latest" is implied when ref has no
+// tag) and returns its ID. labels are merged over s.Labels.
+func (s *FakeState) AddImage(ref string, labels map[string]string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	img := &dockerimage.InspectResponse{
+		ID:       "sha256:" + s.newID("image"),
+		RepoTags: []string{normalizeRef(ref)},
+		Created:  s.now().Format(time.RFC3339Nano),
+		Config: &dockerspec.DockerOCIImageConfig{
+			ImageConfig: ocispec.ImageConfig{Labels: mergeLabels(s.Labels, labels)},
+		},
+		Os:           "linux",
+		Architecture: "amd64",
+	}
+	s.untag(img.RepoTags[0])
+	s.images = append(s.images, img)
+	return img.ID
+}
+
+// AddNetwork adds a bridge network and returns its ID. labels are merged over
+// s.Labels.
+func (s *FakeState) AddNetwork(name string, labels map[string]string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := &network.Inspect{Network: network.Network{
+		Name:    name,
+		ID:      s.newID("network"),
+		Created: s.now(),
+		Scope:   "local",
+		Driver:  "bridge",
+		Labels:  mergeLabels(s.Labels, labels),
+	}}
+	s.networks = append(s.networks, n)
+	return n.ID
+}
+
+// AddContainer adds a container from spec and returns its ID. It fails like
+// ContainerCreate would: a missing image or a name already in use.
+func (s *FakeState) AddContainer(spec ContainerSpec) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.create(spec.Name, &container.Config{
+		Image:  spec.Image,
+		Labels: mergeLabels(s.Labels, spec.Labels),
+	}, &container.HostConfig{}, nil)
+	if err != nil {
+		return "", err
+	}
+	if spec.Running {
+		s.start(c)
+	}
+	return c.ID, nil
+}
+
+// Exit simulates the process in a running or paused container exiting on its
+// own with code: waiters are released and an AutoRemove container is removed.
+func (s *FakeState) Exit(ref string, code int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.findContainer(ref)
+	if err != nil {
+		return err
+	}
+	if !c.State.Running {
+		return conflictErrorf("container %s is not running", c.ID)
+	}
+	s.exit(c, code)
+	return nil
+}
+
+// Snapshot returns a deep copy of the store.
+func (s *FakeState) Snapshot() Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var snap Snapshot
+	for _, c := range s.containers {
+		snap.Containers = append(snap.Containers, clone(*c))
+	}
+	for _, img := range s.images {
+		snap.Images = append(snap.Images, clone(*img))
+	}
+	for _, n := range s.networks {
+		snap.Networks = append(snap.Networks, s.inspectNetwork(n))
+	}
+	return snap
+}
+
+// Restore replaces the store with a copy of snap. Pending ContainerWait calls
+// are not part of a snapshot and keep waiting.
+func (s *FakeState) Restore(snap Snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.containers, s.images, s.networks = nil, nil, nil
+	for _, c := range snap.Containers {
+		c := clone(c)
+		s.containers = append(s.containers, &c)
+	}
+	for _, img := range snap.Images {
+		img := clone(img)
+		s.images = append(s.images, &img)
+	}
+	for _, n := range snap.Networks {
+		n := clone(n)
+		n.Containers = nil // derived from the containers on read
+		s.networks = append(s.networks, &n)
+	}
+}
+
+// --- Containers ---
+
+func (s *FakeState) containerCreate(_ context.Context, opts client.ContainerCreateOptions) (client.ContainerCreateResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cfg := &container.Config{}
+	if opts.Config != nil {
+		cfg = ptr(clone(*opts.Config))
+	}
+	if opts.Image != "" {
+		cfg.Image = opts.Image
+	}
+	hostCfg := &container.HostConfig{}
+	if opts.HostConfig != nil {
+		hostCfg = ptr(clone(*opts.HostConfig))
+	}
+	c, err := s.create(opts.Name, cfg, hostCfg, opts.NetworkingConfig)
+	if err != nil {
+		return client.ContainerCreateResult{}, err
+	}
+	return client.ContainerCreateResult{ID: c.ID}, nil
+}
+
+func (s *FakeState) containerStart(_ context.Context, ref string, _ client.ContainerStartOptions) (client.ContainerStartResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.findContainer(ref)
+	if err != nil {
+		return client.ContainerStartResult{}, err
+	}
+	if c.State.Paused {
+		return client.ContainerStartResult{}, conflictErrorf("cannot start a paused container, try unpause instead")
+	}
+	if !c.State.Running {
+		s.start(c)
+	}
+	return client.ContainerStartResult{}, nil
+}
+
+func (s *FakeState) containerStop(_ context.Context, ref string, _ client.ContainerStopOptions) (client.ContainerStopResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.findContainer(ref)
+	if err != nil {
+		return client.ContainerStopResult{}, err
+	}
+	if c.State.Running {
+		s.exit(c, 0)
+	}
+	return client.ContainerStopResult{}, nil
+}
+
+func (s *FakeState) containerKill(_ context.Context, ref string, opts client.ContainerKillOptions) (client.ContainerKillResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.findContainer(ref)
+	if err != nil {
+		return client.ContainerKillResult{}, err
+	}
+	if !c.State.Running {
+		return client.ContainerKillResult{}, conflictErrorf("cannot kill container: %s: container %s is not running", ref, c.ID)
+	}
+	if sig, ok := terminatingSignal(opts.Signal); ok {
+		s.exit(c, 128+sig)
+	}
+	return client.ContainerKillResult{}, nil
+}
+
+func (s *FakeState) containerRestart(_ context.Context, ref string, _ client.ContainerRestartOptions) (client.ContainerRestartResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.findContainer(ref)
+	if err != nil {
+		return client.ContainerRestartResult{}, err
+	}
+	if c.State.Running {
+		autoRemove := c.HostConfig.AutoRemove
+		c.HostConfig.AutoRemove = false // a restart never removes
+		s.exit(c, 0)
+		c.HostConfig.AutoRemove = autoRemove
+	}
+	s.start(c)
+	return client.ContainerRestartResult{}, nil
+}
+
+func (s *FakeState) containerPause(_ context.Context, ref string, _ client.ContainerPauseOptions) (client.ContainerPauseResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.findContainer(ref)
+	if err != nil {
+		return client.ContainerPauseResult{}, err
+	}
+	switch {
+	case !c.State.Running:
+		return client.ContainerPauseResult{}, conflictErrorf("container %s is not running", c.ID)
+	case c.State.Paused:
+		return client.ContainerPauseResult{}, conflictErrorf("container %s is already paused", c.ID)
+	}
+	c.State.Paused = true
+	c.State.Status = container.StatePaused
+	return client.ContainerPauseResult{}, nil
+}
+
+func (s *FakeState) containerUnpause(_ context.Context, ref string, _ client.ContainerUnpauseOptions) (client.ContainerUnpauseResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.findContainer(ref)
+	if err != nil {
+		return client.ContainerUnpauseResult{}, err
+	}
+	if !c.State.Paused {
+		return client.ContainerUnpauseResult{}, conflictErrorf("container %s is not paused", c.ID)
+	}
+	c.State.Paused = false
+	c.State.Status = container.StateRunning
+	return client.ContainerUnpauseResult{}, nil
+}
+
+func (s *FakeState) containerRemove(_ context.Context, ref string, opts client.ContainerRemoveOptions) (client.ContainerRemoveResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.findContainer(ref)
+	if err != nil {
+		return client.ContainerRemoveResult{}, err
+	}
+	if c.State.Running {
+		if !opts.Force {
+			return client.ContainerRemoveResult{}, conflictErrorf(
+				"cannot remove container %q: container is running: stop the container before removing or force remove", c.Name)
+		}
+		s.exit(c, 137)
+		if s.containerIndex(c.ID) < 0 {
+			return client.ContainerRemoveResult{}, nil // AutoRemove already took it
+		}
+	}
+	s.remove(c)
+	return client.ContainerRemoveResult{}, nil
+}
+
+func (s *FakeState) containerRename(_ context.Context, ref string, opts client.ContainerRenameOptions) (client.ContainerRenameResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.findContainer(ref)
+	if err != nil {
+		return client.ContainerRenameResult{}, err
+	}
+	name := "/" + strings.TrimPrefix(opts.NewName, "/")
+	if other := s.containerByName(name); other != nil && other != c {
+		return client.ContainerRenameResult{}, conflictErrorf(
+			"Conflict. The container name %q is already in use by container %q. You have to remove (or rename) that container to be able to reuse that name.", name, other.ID)
+	}
+	c.Name = name
+	return client.ContainerRenameResult{}, nil
+}
+
+func (s *FakeState) containerInspect(_ context.Context, ref string, _ client.ContainerInspectOptions) (client.ContainerInspectResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, err := s.findContainer(ref)
+	if err != nil {
+		return client.ContainerInspectResult{}, err
+	}
+	return client.ContainerInspectResult{Container: clone(*c)}, nil
+}
+
+func (s *FakeState) containerList(_ context.Context, opts client.ContainerListOptions) (client.ContainerListResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := checkFilterTerms(opts.Filters, "label", "name", "id", "status"); err != nil {
+		return client.ContainerListResult{}, err
+	}
+	var items []container.Summary
+	// Newest first, like the daemon.
+	for _, c := range slices.Backward(s.containers) {
+		if !opts.All && !c.State.Running && len(opts.Filters["status"]) == 0 {
+			continue
+		}
+		if !matchLabels(opts.Filters["label"], c.Config.Labels) ||
+			!matchAny(opts.Filters["name"], func(v string) bool { return matchName(v, c.Name) }) ||
+			!matchAny(opts.Filters["id"], func(v string) bool { return strings.HasPrefix(c.ID, v) }) ||
+			!matchAny(opts.Filters["status"], func(v string) bool { return v == string(c.State.Status) }) {
+			continue
+		}
+		items = append(items, summarizeContainer(c))
+		if opts.Limit > 0 && len(items) == opts.Limit {
+			break
+		}
+	}
+	return client.ContainerListResult{Items: items}, nil
+}
+
+func (s *FakeState) containerWait(ctx context.Context, ref string, opts client.ContainerWaitOptions) client.ContainerWaitResult {
+	resultCh := make(chan container.WaitResponse, 1)
+	errCh := make(chan error, 1)
+	result := client.ContainerWaitResult{Result: resultCh, Error: errCh}
+
+	s.mu.Lock()
+	c, err := s.findContainer(ref)
+	if err != nil {
+		s.mu.Unlock()
+		errCh <- err
+		return result
+	}
+	cond := opts.Condition
+	if cond == "" {
+		cond = container.WaitConditionNotRunning
+	}
+	if cond == container.WaitConditionNotRunning && !c.State.Running {
+		s.mu.Unlock()
+		resultCh <- container.WaitResponse{StatusCode: int64(c.State.ExitCode)}
+		return result
+	}
+	w := &stateWaiter{id: c.ID, cond: cond, ch: make(chan container.WaitResponse, 1)}
+	s.waiters = append(s.waiters, w)
+	s.mu.Unlock()
+
+	go func() {
+		select {
+		case resp := <-w.ch:
+			resultCh <- resp
+		case <-ctx.Done():
+			s.mu.Lock()
+			s.waiters = slices.DeleteFunc(s.waiters, func(o *stateWaiter) bool { return o == w })
+			s.mu.Unlock()
+			errCh <- ctx.Err()
+		}
+	}()
+	return result
+}
+
+// create adds a container in the created state. Callers hold s.mu.
+func (s *FakeState) create(name string, cfg *container.Config, hostCfg *container.HostConfig, netCfg *network.NetworkingConfig) (*container.InspectResponse, error) {
+	img, err := s.findImage(cfg.Image)
+	if err != nil {
+		return nil, err
+	}
+	id := s.newID("container")
+	if name == "" {
+		name = fmt.Sprintf("fake_%d", s.seq)
+	}
+	name = "/" + strings.TrimPrefix(name, "/")
+	if other := s.containerByName(name); other != nil {
+		return nil, conflictErrorf(
+			"Conflict. The container name %q is already in use by container %q. You have to remove (or rename) that container to be able to reuse that name.", name, other.ID)
+	}
+
+	networks := map[string]*network.EndpointSettings{}
+	attach := func(netName string, ep *network.EndpointSettings) error {
+		if slices.Contains(builtinNetworks, netName) {
+			return nil
+		}
+		n, err := s.findNetwork(netName)
+		if err != nil {
+			return err
+		}
+		networks[n.Name] = s.endpoint(n, ep)
+		return nil
+	}
+	if mode := string(hostCfg.NetworkMode); mode != "" {
+		if err := attach(mode, nil); err != nil {
+			return nil, err
+		}
+	}
+	if netCfg != nil {
+		for netName, ep := range netCfg.EndpointsConfig {
+			if err := attach(netName, ep); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Containers inherit their image's labels, as with the daemon.
+	cfg.Labels = mergeLabels(imageLabels(img), cfg.Labels)
+	args := cfg.Cmd
+	c := &container.InspectResponse{
+		ID:              id,
+		Created:         s.now().Format(time.RFC3339Nano),
+		Name:            name,
+		Image:           img.ID,
+		Config:          cfg,
+		HostConfig:      hostCfg,
+		State:           &container.State{Status: container.StateCreated},
+		NetworkSettings: &container.NetworkSettings{Networks: networks},
+	}
+	if len(cfg.Entrypoint) > 0 {
+		c.Path, c.Args = cfg.Entrypoint[0], append(slices.Clone(cfg.Entrypoint[1:]), args...)
+	} else if len(args) > 0 {
+		c.Path, c.Args = args[0], slices.Clone(args[1:])
+	}
+	s.containers = append(s.containers, c)
+	return c, nil
+}
+
+// start moves c to running. Callers hold s.mu.
+func (s *FakeState) start(c *container.InspectResponse) {
+	s.seq++
+	c.State.Status = container.StateRunning
+	c.State.Running = true
+	c.State.Paused = false
+	c.State.Pid = 1000 + s.seq
+	c.State.ExitCode = 0
+	c.State.StartedAt = s.now().Format(time.RFC3339Nano)
+	c.State.FinishedAt = ""
+}
+
+// exit moves a running c to exited with code, releases its waiters, and
+// removes it when AutoRemove is set. Callers hold s.mu.
+func (s *FakeState) exit(c *container.InspectResponse, code int) {
+	c.State.Status = container.StateExited
+	c.State.Running = false
+	c.State.Paused = false
+	c.State.Pid = 0
+	c.State.ExitCode = code
+	c.State.FinishedAt = s.now().Format(time.RFC3339Nano)
+	s.release(c.ID, code, container.WaitConditionNotRunning, container.WaitConditionNextExit)
+	if c.HostConfig.AutoRemove {
+		s.remove(c)
+	}
+}
+
+// remove deletes c and releases its removal waiters. Callers hold s.mu.
+func (s *FakeState) remove(c *container.InspectResponse) {
+	if i := s.containerIndex(c.ID); i >= 0 {
+		s.containers = slices.Delete(s.containers, i, i+1)
+	}
+	s.release(c.ID, c.State.ExitCode, container.WaitConditionRemoved)
+}
+
+// release answers the waiters on id for any of conds. Callers hold s.mu.
+func (s *FakeState) release(id string, code int, conds ...container.WaitCondition) {
+	s.waiters = slices.DeleteFunc(s.waiters, func(w *stateWaiter) bool {
+		if w.id != id || !slices.Contains(conds, w.cond) {
+			return false
+		}
+		w.ch <- container.WaitResponse{StatusCode: int64(code)}
+		return true
+	})
+}
+
+// findContainer resolves ref the way the daemon does: full ID, name, then
+// unique ID prefix. Callers hold s.mu.
+func (s *FakeState) findContainer(ref string) (*container.InspectResponse, error) {
+	if i := s.containerIndex(ref); i >= 0 {
+		return s.containers[i], nil
+	}
+	if c := s.containerByName("/" + strings.TrimPrefix(ref, "/")); c != nil {
+		return c, nil
+	}
+	var match *container.InspectResponse
+	for _, c := range s.containers {
+		if ref != "" && strings.HasPrefix(c.ID, ref) {
+			if match != nil {
+				return nil, invalidErrorf("multiple IDs found with provided prefix: %s", ref)
+			}
+			match = c
+		}
+	}
+	if match == nil {
+		return nil, notFoundErrorf("No such container: %s", ref)
+	}
+	return match, nil
+}
+
+func (s *FakeState) containerIndex(id string) int {
+	return slices.IndexFunc(s.containers, func(c *container.InspectResponse) bool { return c.ID == id })
+}
+
+func (s *FakeState) containerByName(name string) *container.InspectResponse {
+	for _, c := range s.containers {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func summarizeContainer(c *container.InspectResponse) container.Summary {
+	c2 := clone(*c)
+	sum := container.Summary{
+		ID:              c2.ID,
+		Names:           []string{c2.Name},
+		Image:           c2.Config.Image,
+		ImageID:         c2.Image,
+		Command:         strings.TrimSpace(c2.Path + " " + strings.Join(c2.Args, " ")),
+		Labels:          c2.Config.Labels,
+		State:           c2.State.Status,
+		Status:          containerStatus(c2.State),
+		NetworkSettings: &container.NetworkSettingsSummary{Networks: c2.NetworkSettings.Networks},
+	}
+	if created, err := time.Parse(time.RFC3339Nano, c2.Created); err == nil {
+		sum.Created = created.Unix()
+	}
+	sum.HostConfig.NetworkMode = string(c2.HostConfig.NetworkMode)
+	return sum
+}
+
+// containerStatus renders the human-readable status column of docker ps.
+func containerStatus(st *container.State) string {
+	switch st.Status {
+	case container.StateRunning:
+		return "Up"
+	case container.StatePaused:
+		return "Up (Paused)"
+	case container.StateExited:
+		return fmt.Sprintf("Exited (%d)", st.ExitCode)
+	default:
+		return "Created"
+	}
+}
+
+// terminatingSignal reports the number of sig when it ends the container's
+// process; an empty signal is SIGKILL, as for the daemon.
+func terminatingSignal(sig string) (int, bool) {
+	switch strings.TrimPrefix(strings.ToUpper(sig), "SIG") {
+	case "", "KILL", "9":
+		return 9, true
+	case "TERM", "15":
+		return 15, true
+	case "INT", "2":
+		return 2, true
+	case "QUIT", "3":
+		return 3, true
+	case "HUP", "1":
+		return 1, true
+	}
+	return 0, false
+}
+
+// --- Images ---
+
+func (s *FakeState) imageInspect(_ context.Context, ref string, _ ...client.ImageInspectOption) (client.ImageInspectResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	img, err := s.findImage(ref)
+	if err != nil {
+		return client.ImageInspectResult{}, err
+	}
+	return client.ImageInspectResult{InspectResponse: clone(*img)}, nil
+}
+
+func (s *FakeState) imageList(_ context.Context, opts client.ImageListOptions) (client.ImageListResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := checkFilterTerms(opts.Filters, "label", "reference", "dangling"); err != nil {
+		return client.ImageListResult{}, err
+	}
+	var items []dockerimage.Summary
+	for _, img := range slices.Backward(s.images) {
+		dangling := len(img.RepoTags) == 0
+		if !matchLabels(opts.Filters["label"], imageLabels(img)) ||
+			!matchAny(opts.Filters["reference"], func(v string) bool { return matchReference(v, img.RepoTags) }) ||
+			!matchAny(opts.Filters["dangling"], func(v string) bool { return v == fmt.Sprint(dangling) }) {
+			continue
+		}
+		img := clone(*img)
+		sum := dockerimage.Summary{
+			ID:       img.ID,
+			RepoTags: img.RepoTags,
+			Labels:   imageLabels(&img),
+			Size:     img.Size,
+		}
+		if created, err := time.Parse(time.RFC3339Nano, img.Created); err == nil {
+			sum.Created = created.Unix()
+		}
+		for _, c := range s.containers {
+			if c.Image == img.ID {
+				sum.Containers++
+			}
+		}
+		items = append(items, sum)
+	}
+	return client.ImageListResult{Items: items}, nil
+}
+
+func (s *FakeState) imageRemove(_ context.Context, ref string, opts client.ImageRemoveOptions) (client.ImageRemoveResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	img, err := s.findImage(ref)
+	if err != nil {
+		return client.ImageRemoveResult{}, err
+	}
+	// Removing one of several tags only untags.
+	if tag := normalizeRef(ref); len(img.RepoTags) > 1 && slices.Contains(img.RepoTags, tag) {
+		img.RepoTags = slices.DeleteFunc(img.RepoTags, func(t string) bool { return t == tag })
+		return client.ImageRemoveResult{Items: []dockerimage.DeleteResponse{{Untagged: tag}}}, nil
+	}
+	for _, c := range s.containers {
+		if c.Image != img.ID {
+			continue
+		}
+		if c.State.Running {
+			return client.ImageRemoveResult{}, conflictErrorf(
+				"conflict: unable to delete %s (cannot be forced) - image is being used by running container %s", ref, c.ID)
+		}
+		if !opts.Force {
+			return client.ImageRemoveResult{}, conflictErrorf(
+				"conflict: unable to delete %s (must be forced) - image is being used by stopped container %s", ref, c.ID)
+		}
+	}
+	var items []dockerimage.DeleteResponse
+	for _, tag := range img.RepoTags {
+		items = append(items, dockerimage.DeleteResponse{Untagged: tag})
+	}
+	items = append(items, dockerimage.DeleteResponse{Deleted: img.ID})
+	s.images = slices.DeleteFunc(s.images, func(o *dockerimage.InspectResponse) bool { return o == img })
+	return client.ImageRemoveResult{Items: items}, nil
+}
+
+func (s *FakeState) imageTag(_ context.Context, opts client.ImageTagOptions) (client.ImageTagResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	img, err := s.findImage(opts.Source)
+	if err != nil {
+		return client.ImageTagResult{}, err
+	}
+	target := normalizeRef(opts.Target)
+	s.untag(target)
+	img.RepoTags = append(img.RepoTags, target)
+	return client.ImageTagResult{}, nil
+}
+
+// untag moves tag off whichever image holds it. Callers hold s.mu.
+func (s *FakeState) untag(tag string) {
+	for _, img := range s.images {
+		img.RepoTags = slices.DeleteFunc(img.RepoTags, func(t string) bool { return t == tag })
+	}
+}
+
+// findImage resolves ref as a tag, a full ID, or a unique ID prefix, with or
+// without the sha256: prefix. Callers hold s.mu.
+func (s *FakeState) findImage(ref string) (*dockerimage.InspectResponse, error) {
+	tag := normalizeRef(ref)
+	for _, img := range s.images {
+		if img.ID == ref || slices.Contains(img.RepoTags, tag) {
+			return img, nil
+		}
+	}
+	hex := strings.TrimPrefix(ref, "sha256:")
+	var match *dockerimage.InspectResponse
+	for _, img := range s.images {
+		if hex != "" && strings.HasPrefix(strings.TrimPrefix(img.ID, "sha256:"), hex) {
+			if match != nil {
+				return nil, invalidErrorf("multiple IDs found with provided prefix: %s", ref)
+			}
+			match = img
+		}
+	}
+	if match == nil {
+		return nil, notFoundErrorf("No such image: %s", ref)
+	}
+	return match, nil
+}
+
+func imageLabels(img *dockerimage.InspectResponse) map[string]string {
+	if img.Config == nil {
+		return nil
+	}
+	return img.Config.Labels
+}
+
+// normalizeRef appends ":latest" to a reference without a tag or digest.
+func normalizeRef(ref string) string {
+	if strings.Contains(ref, "@") || strings.Contains(path.Base(ref), ":") {
+		return ref
+	}
+	return ref + ":latest"
+}
+
+// matchReference reports whether pattern, a reference filter value, matches
+// one of tags: with a tag it is a glob over repo:tag, without one a glob over
+// the repository.
+func matchReference(pattern string, tags []string) bool {
+	for _, tag := range tags {
+		target := tag
+		if !strings.Contains(path.Base(pattern), ":") {
+			target = tag[:strings.LastIndex(tag, ":")]
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// --- Networks ---
+
+func (s *FakeState) networkCreate(_ context.Context, name string, opts client.NetworkCreateOptions) (client.NetworkCreateResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if slices.Contains(builtinNetworks, name) || slices.ContainsFunc(s.networks, func(n *network.Inspect) bool { return n.Name == name }) {
+		return client.NetworkCreateResult{}, conflictErrorf("network with name %s already exists", name)
+	}
+	driver := opts.Driver
+	if driver == "" {
+		driver = "bridge"
+	}
+	scope := opts.Scope
+	if scope == "" {
+		scope = "local"
+	}
+	n := &network.Inspect{Network: network.Network{
+		Name:       name,
+		ID:         s.newID("network"),
+		Created:    s.now(),
+		Scope:      scope,
+		Driver:     driver,
+		Internal:   opts.Internal,
+		Attachable: opts.Attachable,
+		Options:    clone(opts.Options),
+		Labels:     clone(opts.Labels),
+	}}
+	if opts.IPAM != nil {
+		n.IPAM = clone(*opts.IPAM)
+	}
+	if opts.EnableIPv4 != nil {
+		n.EnableIPv4 = *opts.EnableIPv4
+	} else {
+		n.EnableIPv4 = true
+	}
+	if opts.EnableIPv6 != nil {
+		n.EnableIPv6 = *opts.EnableIPv6
+	}
+	s.networks = append(s.networks, n)
+	return client.NetworkCreateResult{ID: n.ID}, nil
+}
+
+func (s *FakeState) networkInspect(_ context.Context, ref string, _ client.NetworkInspectOptions) (client.NetworkInspectResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, err := s.findNetwork(ref)
+	if err != nil {
+		return client.NetworkInspectResult{}, err
+	}
+	return client.NetworkInspectResult{Network: s.inspectNetwork(n)}, nil
+}
+
+func (s *FakeState) networkList(_ context.Context, opts client.NetworkListOptions) (client.NetworkListResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := checkFilterTerms(opts.Filters, "label", "name", "id", "driver"); err != nil {
+		return client.NetworkListResult{}, err
+	}
+	var items []network.Summary
+	for _, n := range s.networks {
+		if !matchLabels(opts.Filters["label"], n.Labels) ||
+			!matchAny(opts.Filters["name"], func(v string) bool { return strings.Contains(n.Name, v) }) ||
+			!matchAny(opts.Filters["id"], func(v string) bool { return strings.HasPrefix(n.ID, v) }) ||
+			!matchAny(opts.Filters["driver"], func(v string) bool { return v == n.Driver }) {
+			continue
+		}
+		items = append(items, network.Summary{Network: clone(n.Network)})
+	}
+	return client.NetworkListResult{Items: items}, nil
+}
+
+func (s *FakeState) networkRemove(_ context.Context, ref string, _ client.NetworkRemoveOptions) (client.NetworkRemoveResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, err := s.findNetwork(ref)
+	if err != nil {
+		return client.NetworkRemoveResult{}, err
+	}
+	if len(s.endpoints(n)) > 0 {
+		return client.NetworkRemoveResult{}, conflictErrorf("error while removing network: network %s has active endpoints", n.Name)
+	}
+	s.networks = slices.DeleteFunc(s.networks, func(o *network.Inspect) bool { return o == n })
+	return client.NetworkRemoveResult{}, nil
+}
+
+func (s *FakeState) networkConnect(_ context.Context, ref string, opts client.NetworkConnectOptions) (client.NetworkConnectResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, err := s.findNetwork(ref)
+	if err != nil {
+		return client.NetworkConnectResult{}, err
+	}
+	c, err := s.findContainer(opts.Container)
+	if err != nil {
+		return client.NetworkConnectResult{}, err
+	}
+	if _, ok := c.NetworkSettings.Networks[n.Name]; ok {
+		return client.NetworkConnectResult{}, forbiddenErrorf("endpoint with name %s already exists in network %s", strings.TrimPrefix(c.Name, "/"), n.Name)
+	}
+	c.NetworkSettings.Networks[n.Name] = s.endpoint(n, opts.EndpointConfig)
+	return client.NetworkConnectResult{}, nil
+}
+
+func (s *FakeState) networkDisconnect(_ context.Context, ref string, opts client.NetworkDisconnectOptions) (client.NetworkDisconnectResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, err := s.findNetwork(ref)
+	if err != nil {
+		return client.NetworkDisconnectResult{}, err
+	}
+	c, err := s.findContainer(opts.Container)
+	if err != nil {
+		return client.NetworkDisconnectResult{}, err
+	}
+	if _, ok := c.NetworkSettings.Networks[n.Name]; !ok {
+		return client.NetworkDisconnectResult{}, invalidErrorf("container %s is not connected to network %s", c.ID, n.Name)
+	}
+	delete(c.NetworkSettings.Networks, n.Name)
+	return client.NetworkDisconnectResult{}, nil
+}
+
+// endpoint returns the endpoint settings for attaching to n, copied from ep.
+// Callers hold s.mu.
+func (s *FakeState) endpoint(n *network.Inspect, ep *network.EndpointSettings) *network.EndpointSettings {
+	out := &network.EndpointSettings{}
+	if ep != nil {
+		out = ptr(clone(*ep))
+	}
+	out.NetworkID = n.ID
+	out.EndpointID = s.newID("endpoint")
+	return out
+}
+
+// endpoints derives n's attached containers from the container store, so the
+// two views never disagree. Callers hold s.mu.
+func (s *FakeState) endpoints(n *network.Inspect) map[string]network.EndpointResource {
+	out := map[string]network.EndpointResource{}
+	for _, c := range s.containers {
+		if ep, ok := c.NetworkSettings.Networks[n.Name]; ok {
+			out[c.ID] = network.EndpointResource{Name: strings.TrimPrefix(c.Name, "/"), EndpointID: ep.EndpointID}
+		}
+	}
+	return out
+}
+
+// inspectNetwork returns a copy of n with its attached containers. Callers
+// hold s.mu.
+func (s *FakeState) inspectNetwork(n *network.Inspect) network.Inspect {
+	out := clone(*n)
+	out.Containers = s.endpoints(n)
+	return out
+}
+
+// findNetwork resolves ref as a full ID, name, or unique ID prefix. Callers
+// hold s.mu.
+func (s *FakeState) findNetwork(ref string) (*network.Inspect, error) {
+	for _, n := range s.networks {
+		if n.ID == ref || n.Name == ref {
+			return n, nil
+		}
+	}
+	var match *network.Inspect
+	for _, n := range s.networks {
+		if ref != "" && strings.HasPrefix(n.ID, ref) {
+			if match != nil {
+				return nil, invalidErrorf("network %s is ambiguous", ref)
+			}
+			match = n
+		}
+	}
+	if match == nil {
+		return nil, notFoundErrorf("network %s not found", ref)
+	}
+	return match, nil
+}
+
+// --- Shared helpers ---
+
+// newID returns a deterministic 64-hex-digit ID. Callers hold s.mu.
+func (s *FakeState) newID(kind string) string {
+	s.seq++
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s-%d", kind, s.seq))
+	return hex.EncodeToString(sum[:])
+}
+
+// now returns the state's clock: stateEpoch plus one second per ID issued.
+// Callers hold s.mu.
+func (s *FakeState) now() time.Time {
+	return stateEpoch.Add(time.Duration(s.seq) * time.Second)
+}
+
+// checkFilterTerms rejects filter terms the state does not implement, so a
+// test never passes on a filter that was silently ignored.
+func checkFilterTerms(f client.Filters, supported ...string) error {
+	for term := range f {
+		if !slices.Contains(supported, term) {
+			return invalidErrorf("invalid filter '%s' (FakeState supports %s)", term, strings.Join(supported, ", "))
+		}
+	}
+	return nil
+}
+
+// matchLabels reports whether labels satisfy every "key" or "key=value" in
+// values — label filter values are ANDed, unlike other terms.
+func matchLabels(values map[string]bool, labels map[string]string) bool {
+	for v := range values {
+		key, want, hasValue := strings.Cut(v, "=")
+		got, ok := labels[key]
+		if !ok || (hasValue && got != want) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchAny reports whether values is empty or match accepts one of them.
+func matchAny(values map[string]bool, match func(string) bool) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for v := range values {
+		if match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchName applies the daemon's name filter: an unanchored regexp over the
+// name, tried with and without its leading slash.
+func matchName(pattern, name string) bool {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return strings.Contains(name, pattern)
+	}
+	return re.MatchString(name) || re.MatchString(strings.TrimPrefix(name, "/"))
+}
+
+func mergeLabels(base, extra map[string]string) map[string]string {
+	out := make(map[string]string, len(base)+len(extra))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range extra {
+		out[k] = v
+	}
+	return out
+}
+
+// clone deep-copies v through JSON. The API types are JSON documents, so this
+// keeps stored state and returned values from aliasing each other.
+func clone[T any](v T) T {
+	var out T
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("whailtest: clone %T: %v", v, err))
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		panic(fmt.Sprintf("whailtest: clone %T: %v", v, err))
+	}
+	return out
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// stateError is an error satisfying the errdefs class checks whail and its
+// callers use (errdefs.IsNotFound, IsConflict, IsInvalidArgument).
+type stateError struct{ msg string }
+
+func (e stateError) Error() string { return e.msg }
+
+type notFoundStateError struct{ stateError }
+
+func (notFoundStateError) NotFound() {}
+
+type conflictStateError struct{ stateError }
+
+func (conflictStateError) Conflict() {}
+
+type forbiddenStateError struct{ stateError }
+
+func (forbiddenStateError) Forbidden() {}
+
+type invalidStateError struct{ stateError }
+
+func (invalidStateError) InvalidParameter() {}
+
+func notFoundErrorf(format string, args ...any) error {
+	return notFoundStateError{stateError{msg: fmt.Sprintf(format, args...)}}
+}
+
+func conflictErrorf(format string, args ...any) error {
+	return conflictStateError{stateError{msg: fmt.Sprintf(format, args...)}}
+}
+
+func forbiddenErrorf(format string, args ...any) error {
+	return forbiddenStateError{stateError{msg: fmt.Sprintf(format, args...)}}
+}
+
+func invalidErrorf(format string, args ...any) error {
+	return invalidStateError{stateError{msg: fmt.Sprintf(format, args...)}}
+}
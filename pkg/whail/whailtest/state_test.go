@@ -0,0 +1,333 @@
+package whailtest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/network"
+	"github.com/moby/moby/client"
+	"github.com/schmitthub/clawker/pkg/whail"
+	"github.com/schmitthub/clawker/pkg/whail/whailtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStatefulEngine returns an engine backed by a fresh FakeState seeded with
+// one image, alpine:latest.
+func newStatefulEngine(t *testing.T) (*whail.Engine, *whailtest.FakeAPIClient, *whailtest.FakeState) {
+	t.Helper()
+	fake := whailtest.NewFakeAPIClient()
+	state := whailtest.NewFakeState()
+	state.Install(fake)
+	state.AddImage("alpine", nil)
+	return whail.NewFromExisting(fake, whailtest.TestEngineOptions()), fake, state
+}
+
+func createContainer(t *testing.T, engine *whail.Engine, name string) string {
+	t.Helper()
+	resp, err := engine.ContainerCreate(context.Background(), whail.ContainerCreateOptions{
+		Name:   name,
+		Config: &container.Config{Image: "alpine", Cmd: []string{"sleep", "300"}},
+	})
+	require.NoError(t, err)
+	return resp.ID
+}
+
+func TestFakeState_CreateStartInspect(t *testing.T) {
+	engine, fake, _ := newStatefulEngine(t)
+	ctx := context.Background()
+
+	id := createContainer(t, engine, "agent")
+
+	info, err := engine.ContainerInspect(ctx, "agent", client.ContainerInspectOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, id, info.Container.ID)
+	assert.Equal(t, "/agent", info.Container.Name)
+	assert.Equal(t, container.StateCreated, info.Container.State.Status)
+	assert.Equal(t, "sleep", info.Container.Path)
+	assert.Equal(t, []string{"300"}, info.Container.Args)
+
+	_, err = engine.ContainerStart(ctx, whail.ContainerStartOptions{ContainerID: id})
+	require.NoError(t, err)
+
+	info, err = engine.ContainerInspect(ctx, id[:12], client.ContainerInspectOptions{})
+	require.NoError(t, err)
+	assert.True(t, info.Container.State.Running)
+	assert.NotZero(t, info.Container.State.Pid)
+
+	running, err := engine.ContainerListRunning(ctx)
+	require.NoError(t, err)
+	require.Len(t, running, 1)
+	assert.Equal(t, []string{"/agent"}, running[0].Names)
+	assert.Equal(t, "Up", running[0].Status)
+
+	// Calls are still recorded on the fake.
+	whailtest.AssertCalled(t, fake, "ContainerCreate")
+	whailtest.AssertCalled(t, fake, "ContainerStart")
+}
+
+func TestFakeState_ContainerTransitions(t *testing.T) {
+	engine, _, _ := newStatefulEngine(t)
+	ctx := context.Background()
+	id := createContainer(t, engine, "agent")
+
+	status := func() container.ContainerState {
+		t.Helper()
+		info, err := engine.ContainerInspect(ctx, id, client.ContainerInspectOptions{})
+		require.NoError(t, err)
+		return info.Container.State.Status
+	}
+
+	_, err := engine.ContainerPause(ctx, id)
+	assert.True(t, cerrdefs.IsConflict(err), "pausing a created container conflicts: %v", err)
+
+	_, err = engine.ContainerStart(ctx, whail.ContainerStartOptions{ContainerID: id})
+	require.NoError(t, err)
+	_, err = engine.ContainerPause(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, container.StatePaused, status())
+
+	_, err = engine.ContainerStart(ctx, whail.ContainerStartOptions{ContainerID: id})
+	assert.True(t, cerrdefs.IsConflict(err), "starting a paused container conflicts: %v", err)
+
+	_, err = engine.ContainerUnpause(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, container.StateRunning, status())
+
+	_, err = engine.ContainerRemove(ctx, id, false)
+	assert.True(t, cerrdefs.IsConflict(err), "removing a running container conflicts: %v", err)
+
+	_, err = engine.ContainerKill(ctx, id, "SIGKILL")
+	require.NoError(t, err)
+	info, err := engine.ContainerInspect(ctx, id, client.ContainerInspectOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, container.StateExited, info.Container.State.Status)
+	assert.Equal(t, 137, info.Container.State.ExitCode)
+
+	_, err = engine.ContainerRemove(ctx, id, false)
+	require.NoError(t, err)
+	_, err = engine.ContainerInspect(ctx, id, client.ContainerInspectOptions{})
+	assert.Error(t, err)
+}
+
+func TestFakeState_CreateErrors(t *testing.T) {
+	engine, _, _ := newStatefulEngine(t)
+	ctx := context.Background()
+	createContainer(t, engine, "agent")
+
+	_, err := engine.ContainerCreate(ctx, whail.ContainerCreateOptions{
+		Name:   "agent",
+		Config: &container.Config{Image: "alpine"},
+	})
+	assert.True(t, cerrdefs.IsConflict(err), "duplicate name conflicts: %v", err)
+
+	_, err = engine.ContainerCreate(ctx, whail.ContainerCreateOptions{
+		Name:   "other",
+		Config: &container.Config{Image: "missing:1.0"},
+	})
+	assert.True(t, cerrdefs.IsNotFound(err), "missing image is not found: %v", err)
+	assert.ErrorContains(t, err, "No such image: missing:1.0")
+}
+
+func TestFakeState_ContainerListFilters(t *testing.T) {
+	engine, fake, state := newStatefulEngine(t)
+	ctx := context.Background()
+
+	_, err := state.AddContainer(whailtest.ContainerSpec{Name: "web", Image: "alpine", Running: true, Labels: map[string]string{"role": "web"}})
+	require.NoError(t, err)
+	_, err = state.AddContainer(whailtest.ContainerSpec{Name: "db", Image: "alpine", Labels: map[string]string{"role": "db"}})
+	require.NoError(t, err)
+	_, err = fake.ContainerCreate(ctx, client.ContainerCreateOptions{
+		Name:   "foreign",
+		Config: &container.Config{Image: "alpine"},
+	})
+	require.NoError(t, err)
+
+	all, err := engine.ContainerListAll(ctx)
+	require.NoError(t, err)
+	var names []string
+	for _, c := range all {
+		names = append(names, c.Names[0])
+	}
+	// Newest first. "foreign" was created without labels but inherits the
+	// managed label from its image, as on a real daemon.
+	assert.Equal(t, []string{"/foreign", "/db", "/web"}, names)
+
+	byRole, err := engine.ContainerListByLabels(ctx, map[string]string{"role": "db"}, true)
+	require.NoError(t, err)
+	require.Len(t, byRole, 1)
+	assert.Equal(t, "Created", byRole[0].Status)
+
+	found, err := engine.FindContainerByName(ctx, "web")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/web"}, found.Names)
+
+	_, err = fake.ContainerList(ctx, client.ContainerListOptions{Filters: client.Filters{}.Add("ancestor", "alpine")})
+	assert.True(t, cerrdefs.IsInvalidArgument(err), "unsupported filters are rejected: %v", err)
+}
+
+func TestFakeState_Wait(t *testing.T) {
+	engine, _, state := newStatefulEngine(t)
+	ctx := context.Background()
+	id := createContainer(t, engine, "agent")
+	_, err := engine.ContainerStart(ctx, whail.ContainerStartOptions{ContainerID: id})
+	require.NoError(t, err)
+
+	wait := engine.ContainerWait(ctx, id, container.WaitConditionNotRunning)
+	select {
+	case <-wait.Result:
+		t.Fatal("wait returned while the container is running")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	require.NoError(t, state.Exit("agent", 3))
+	select {
+	case resp := <-wait.Result:
+		assert.EqualValues(t, 3, resp.StatusCode)
+	case err := <-wait.Error:
+		t.Fatalf("wait failed: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("wait did not return after exit")
+	}
+
+	// Already stopped: not-running returns immediately.
+	wait = engine.ContainerWait(ctx, id, container.WaitConditionNotRunning)
+	select {
+	case resp := <-wait.Result:
+		assert.EqualValues(t, 3, resp.StatusCode)
+	case <-time.After(time.Second):
+		t.Fatal("wait on a stopped container did not return")
+	}
+}
+
+func TestFakeState_AutoRemove(t *testing.T) {
+	engine, _, state := newStatefulEngine(t)
+	ctx := context.Background()
+	resp, err := engine.ContainerCreate(ctx, whail.ContainerCreateOptions{
+		Name:       "oneshot",
+		Config:     &container.Config{Image: "alpine"},
+		HostConfig: &container.HostConfig{AutoRemove: true},
+	})
+	require.NoError(t, err)
+	_, err = engine.ContainerStart(ctx, whail.ContainerStartOptions{ContainerID: resp.ID})
+	require.NoError(t, err)
+
+	removed := engine.ContainerWait(ctx, resp.ID, container.WaitConditionRemoved)
+	_, err = engine.ContainerStop(ctx, resp.ID, nil)
+	require.NoError(t, err)
+
+	select {
+	case <-removed.Result:
+	case err := <-removed.Error:
+		t.Fatalf("wait failed: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("removed wait did not return")
+	}
+	assert.Empty(t, state.Snapshot().Containers)
+}
+
+func TestFakeState_Images(t *testing.T) {
+	engine, _, state := newStatefulEngine(t)
+	ctx := context.Background()
+
+	_, err := engine.APIClient.ImageTag(ctx, client.ImageTagOptions{Source: "alpine", Target: "agent:v1"})
+	require.NoError(t, err)
+
+	list, err := engine.ImageList(ctx, client.ImageListOptions{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.ElementsMatch(t, []string{"alpine:latest", "agent:v1"}, list.Items[0].RepoTags)
+
+	// Removing one of two tags only untags.
+	removed, err := engine.ImageRemove(ctx, "agent:v1", client.ImageRemoveOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "agent:v1", removed.Items[0].Untagged)
+
+	id := createContainer(t, engine, "agent")
+	_, err = engine.ImageRemove(ctx, "alpine", client.ImageRemoveOptions{})
+	assert.True(t, cerrdefs.IsConflict(err), "image in use conflicts: %v", err)
+
+	_, err = engine.ContainerRemove(ctx, id, false)
+	require.NoError(t, err)
+	_, err = engine.ImageRemove(ctx, "alpine", client.ImageRemoveOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, state.Snapshot().Images)
+}
+
+func TestFakeState_Networks(t *testing.T) {
+	engine, _, _ := newStatefulEngine(t)
+	ctx := context.Background()
+
+	resp, err := engine.ContainerCreate(ctx, whail.ContainerCreateOptions{
+		Name:          "agent",
+		Config:        &container.Config{Image: "alpine"},
+		EnsureNetwork: &whail.EnsureNetworkOptions{Name: "agents"},
+	})
+	require.NoError(t, err)
+
+	net, err := engine.NetworkInspect(ctx, "agents", client.NetworkInspectOptions{})
+	require.NoError(t, err)
+	require.Contains(t, net.Network.Containers, resp.ID)
+	assert.Equal(t, "agent", net.Network.Containers[resp.ID].Name)
+
+	_, err = engine.NetworkRemove(ctx, "agents")
+	assert.Error(t, err, "network with endpoints cannot be removed")
+
+	// Starting with EnsureNetwork sees the existing endpoint and skips connect.
+	_, err = engine.ContainerStart(ctx, whail.ContainerStartOptions{
+		ContainerID:   resp.ID,
+		EnsureNetwork: &whail.EnsureNetworkOptions{Name: "agents"},
+	})
+	require.NoError(t, err)
+
+	_, err = engine.NetworkConnect(ctx, "agents", resp.ID, &network.EndpointSettings{})
+	assert.True(t, cerrdefs.IsPermissionDenied(err), "already connected is forbidden: %v", err)
+
+	_, err = engine.ContainerRemove(ctx, resp.ID, true)
+	require.NoError(t, err)
+	_, err = engine.NetworkRemove(ctx, "agents")
+	require.NoError(t, err)
+}
+
+func TestFakeState_SnapshotRestore(t *testing.T) {
+	engine, _, state := newStatefulEngine(t)
+	ctx := context.Background()
+	id := createContainer(t, engine, "agent")
+	baseline := state.Snapshot()
+
+	_, err := engine.ContainerStart(ctx, whail.ContainerStartOptions{ContainerID: id})
+	require.NoError(t, err)
+	createContainer(t, engine, "second")
+
+	// Mutating a snapshot does not reach the store.
+	snap := state.Snapshot()
+	require.Len(t, snap.Containers, 2)
+	snap.Containers[0].State.Status = container.StateDead
+	assert.Equal(t, container.StateRunning, state.Snapshot().Containers[0].State.Status)
+
+	state.Restore(baseline)
+	assert.Equal(t, baseline, state.Snapshot())
+	info, err := engine.ContainerInspect(ctx, id, client.ContainerInspectOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, container.StateCreated, info.Container.State.Status)
+}
+
+func TestFakeState_FnOverride(t *testing.T) {
+	engine, fake, _ := newStatefulEngine(t)
+	ctx := context.Background()
+	id := createContainer(t, engine, "agent")
+
+	// A Fn set after Install replaces the state for that method only.
+	fake.ContainerStartFn = func(context.Context, string, client.ContainerStartOptions) (client.ContainerStartResult, error) {
+		return client.ContainerStartResult{}, cerrdefs.ErrUnavailable
+	}
+	_, err := engine.ContainerStart(ctx, whail.ContainerStartOptions{ContainerID: id})
+	assert.Error(t, err)
+
+	info, err := engine.ContainerInspect(ctx, id, client.ContainerInspectOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, container.StateCreated, info.Container.State.Status)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"sync"
 
 	"github.com/moby/moby/client"
@@ -28,6 +29,11 @@ type FakeAPIClient struct {
 	// Calls records the method names invoked on this fake, in order.
 	Calls []string
 
+	// faults holds injected latency and errors by method (see faults.go);
+	// rng draws WithErrorRate failures. Both are guarded by mu.
+	faults map[string]*fault
+	rng    *rand.Rand
+
 	// --- Container methods ---
 	ContainerCreateFn   func(ctx context.Context, opts client.ContainerCreateOptions) (client.ContainerCreateResult, error)
 	ContainerStartFn    func(ctx context.Context, container string, opts client.ContainerStartOptions) (client.ContainerStartResult, error)
@@ -48,6 +54,12 @@ type FakeAPIClient struct {
 	ContainerStatsFn    func(ctx context.Context, container string, opts client.ContainerStatsOptions) (client.ContainerStatsResult, error)
 	ContainerUpdateFn   func(ctx context.Context, container string, opts client.ContainerUpdateOptions) (client.ContainerUpdateResult, error)
 	ContainerStatPathFn func(ctx context.Context, container string, opts client.ContainerStatPathOptions) (client.ContainerStatPathResult, error)
+	ContainerDiffFn     func(ctx context.Context, container string, opts client.ContainerDiffOptions) (client.ContainerDiffResult, error)
+	ContainerCommitFn   func(ctx context.Context, container string, opts client.ContainerCommitOptions) (client.ContainerCommitResult, error)
+
+	// --- Checkpoint methods ---
+	CheckpointCreateFn func(ctx context.Context, container string, opts client.CheckpointCreateOptions) (client.CheckpointCreateResult, error)
+	CheckpointListFn   func(ctx context.Context, container string, opts client.CheckpointListOptions) (client.CheckpointListResult, error)
 
 	// --- Exec methods ---
 	ExecCreateFn  func(ctx context.Context, container string, opts client.ExecCreateOptions) (client.ExecCreateResult, error)
@@ -82,11 +94,19 @@ type FakeAPIClient struct {
 	ImageInspectFn func(ctx context.Context, image string, opts ...client.ImageInspectOption) (client.ImageInspectResult, error)
 	ImagePruneFn   func(ctx context.Context, opts client.ImagePruneOptions) (client.ImagePruneResult, error)
 	ImageTagFn     func(ctx context.Context, opts client.ImageTagOptions) (client.ImageTagResult, error)
+	ImageHistoryFn func(ctx context.Context, image string, opts ...client.ImageHistoryOption) (client.ImageHistoryResult, error)
+	ImagePushFn    func(ctx context.Context, image string, opts client.ImagePushOptions) (client.ImagePushResponse, error)
+	ImagePullFn    func(ctx context.Context, ref string, opts client.ImagePullOptions) (client.ImagePullResponse, error)
+
+	// --- Distribution methods ---
+	DistributionInspectFn func(ctx context.Context, ref string, opts client.DistributionInspectOptions) (client.DistributionInspectResult, error)
 
 	// --- System methods ---
-	PingFn  func(ctx context.Context, options client.PingOptions) (client.PingResult, error)
-	InfoFn  func(ctx context.Context, options client.InfoOptions) (client.SystemInfoResult, error)
-	CloseFn func() error
+	PingFn      func(ctx context.Context, options client.PingOptions) (client.PingResult, error)
+	InfoFn      func(ctx context.Context, options client.InfoOptions) (client.SystemInfoResult, error)
+	DiskUsageFn func(ctx context.Context, options client.DiskUsageOptions) (client.DiskUsageResult, error)
+	EventsFn    func(ctx context.Context, options client.EventsListOptions) client.EventsResult
+	CloseFn     func() error
 }
 
 // record appends a method name to the call log (thread-safe).
@@ -115,6 +135,9 @@ func (f *FakeAPIClient) ContainerCreate(ctx context.Context, opts client.Contain
 		notImplemented("ContainerCreate")
 	}
 	f.record("ContainerCreate")
+	if err := f.inject(ctx, "ContainerCreate"); err != nil {
+		return client.ContainerCreateResult{}, err
+	}
 	return f.ContainerCreateFn(ctx, opts)
 }
 
@@ -123,6 +146,9 @@ func (f *FakeAPIClient) ContainerStart(ctx context.Context, container string, op
 		notImplemented("ContainerStart")
 	}
 	f.record("ContainerStart")
+	if err := f.inject(ctx, "ContainerStart"); err != nil {
+		return client.ContainerStartResult{}, err
+	}
 	return f.ContainerStartFn(ctx, container, opts)
 }
 
@@ -131,6 +157,9 @@ func (f *FakeAPIClient) ContainerStop(ctx context.Context, container string, opt
 		notImplemented("ContainerStop")
 	}
 	f.record("ContainerStop")
+	if err := f.inject(ctx, "ContainerStop"); err != nil {
+		return client.ContainerStopResult{}, err
+	}
 	return f.ContainerStopFn(ctx, container, opts)
 }
 
@@ -139,6 +168,9 @@ func (f *FakeAPIClient) ContainerRemove(ctx context.Context, container string, o
 		notImplemented("ContainerRemove")
 	}
 	f.record("ContainerRemove")
+	if err := f.inject(ctx, "ContainerRemove"); err != nil {
+		return client.ContainerRemoveResult{}, err
+	}
 	return f.ContainerRemoveFn(ctx, container, opts)
 }
 
@@ -147,6 +179,9 @@ func (f *FakeAPIClient) ContainerList(ctx context.Context, opts client.Container
 		notImplemented("ContainerList")
 	}
 	f.record("ContainerList")
+	if err := f.inject(ctx, "ContainerList"); err != nil {
+		return client.ContainerListResult{}, err
+	}
 	return f.ContainerListFn(ctx, opts)
 }
 
@@ -155,6 +190,9 @@ func (f *FakeAPIClient) ContainerInspect(ctx context.Context, container string,
 		notImplemented("ContainerInspect")
 	}
 	f.record("ContainerInspect")
+	if err := f.inject(ctx, "ContainerInspect"); err != nil {
+		return client.ContainerInspectResult{}, err
+	}
 	return f.ContainerInspectFn(ctx, container, opts)
 }
 
@@ -163,6 +201,9 @@ func (f *FakeAPIClient) ContainerAttach(ctx context.Context, container string, o
 		notImplemented("ContainerAttach")
 	}
 	f.record("ContainerAttach")
+	if err := f.inject(ctx, "ContainerAttach"); err != nil {
+		return client.ContainerAttachResult{}, err
+	}
 	return f.ContainerAttachFn(ctx, container, opts)
 }
 
@@ -171,6 +212,11 @@ func (f *FakeAPIClient) ContainerWait(ctx context.Context, container string, opt
 		notImplemented("ContainerWait")
 	}
 	f.record("ContainerWait")
+	if err := f.inject(ctx, "ContainerWait"); err != nil {
+		errCh := make(chan error, 1)
+		errCh <- err
+		return client.ContainerWaitResult{Error: errCh}
+	}
 	return f.ContainerWaitFn(ctx, container, opts)
 }
 
@@ -179,6 +225,9 @@ func (f *FakeAPIClient) ContainerLogs(ctx context.Context, container string, opt
 		notImplemented("ContainerLogs")
 	}
 	f.record("ContainerLogs")
+	if err := f.inject(ctx, "ContainerLogs"); err != nil {
+		return nil, err
+	}
 	return f.ContainerLogsFn(ctx, container, opts)
 }
 
@@ -187,6 +236,9 @@ func (f *FakeAPIClient) ContainerResize(ctx context.Context, container string, o
 		notImplemented("ContainerResize")
 	}
 	f.record("ContainerResize")
+	if err := f.inject(ctx, "ContainerResize"); err != nil {
+		return client.ContainerResizeResult{}, err
+	}
 	return f.ContainerResizeFn(ctx, container, opts)
 }
 
@@ -195,6 +247,9 @@ func (f *FakeAPIClient) ContainerKill(ctx context.Context, container string, opt
 		notImplemented("ContainerKill")
 	}
 	f.record("ContainerKill")
+	if err := f.inject(ctx, "ContainerKill"); err != nil {
+		return client.ContainerKillResult{}, err
+	}
 	return f.ContainerKillFn(ctx, container, opts)
 }
 
@@ -203,6 +258,9 @@ func (f *FakeAPIClient) ContainerPause(ctx context.Context, container string, op
 		notImplemented("ContainerPause")
 	}
 	f.record("ContainerPause")
+	if err := f.inject(ctx, "ContainerPause"); err != nil {
+		return client.ContainerPauseResult{}, err
+	}
 	return f.ContainerPauseFn(ctx, container, opts)
 }
 
@@ -211,6 +269,9 @@ func (f *FakeAPIClient) ContainerUnpause(ctx context.Context, container string,
 		notImplemented("ContainerUnpause")
 	}
 	f.record("ContainerUnpause")
+	if err := f.inject(ctx, "ContainerUnpause"); err != nil {
+		return client.ContainerUnpauseResult{}, err
+	}
 	return f.ContainerUnpauseFn(ctx, container, opts)
 }
 
@@ -219,6 +280,9 @@ func (f *FakeAPIClient) ContainerRestart(ctx context.Context, container string,
 		notImplemented("ContainerRestart")
 	}
 	f.record("ContainerRestart")
+	if err := f.inject(ctx, "ContainerRestart"); err != nil {
+		return client.ContainerRestartResult{}, err
+	}
 	return f.ContainerRestartFn(ctx, container, opts)
 }
 
@@ -227,6 +291,9 @@ func (f *FakeAPIClient) ContainerRename(ctx context.Context, container string, o
 		notImplemented("ContainerRename")
 	}
 	f.record("ContainerRename")
+	if err := f.inject(ctx, "ContainerRename"); err != nil {
+		return client.ContainerRenameResult{}, err
+	}
 	return f.ContainerRenameFn(ctx, container, opts)
 }
 
@@ -235,6 +302,9 @@ func (f *FakeAPIClient) ContainerTop(ctx context.Context, container string, opts
 		notImplemented("ContainerTop")
 	}
 	f.record("ContainerTop")
+	if err := f.inject(ctx, "ContainerTop"); err != nil {
+		return client.ContainerTopResult{}, err
+	}
 	return f.ContainerTopFn(ctx, container, opts)
 }
 
@@ -243,6 +313,9 @@ func (f *FakeAPIClient) ContainerStats(ctx context.Context, container string, op
 		notImplemented("ContainerStats")
 	}
 	f.record("ContainerStats")
+	if err := f.inject(ctx, "ContainerStats"); err != nil {
+		return client.ContainerStatsResult{}, err
+	}
 	return f.ContainerStatsFn(ctx, container, opts)
 }
 
@@ -251,6 +324,9 @@ func (f *FakeAPIClient) ContainerUpdate(ctx context.Context, container string, o
 		notImplemented("ContainerUpdate")
 	}
 	f.record("ContainerUpdate")
+	if err := f.inject(ctx, "ContainerUpdate"); err != nil {
+		return client.ContainerUpdateResult{}, err
+	}
 	return f.ContainerUpdateFn(ctx, container, opts)
 }
 
@@ -259,9 +335,58 @@ func (f *FakeAPIClient) ContainerStatPath(ctx context.Context, container string,
 		notImplemented("ContainerStatPath")
 	}
 	f.record("ContainerStatPath")
+	if err := f.inject(ctx, "ContainerStatPath"); err != nil {
+		return client.ContainerStatPathResult{}, err
+	}
 	return f.ContainerStatPathFn(ctx, container, opts)
 }
 
+func (f *FakeAPIClient) ContainerDiff(ctx context.Context, container string, opts client.ContainerDiffOptions) (client.ContainerDiffResult, error) {
+	if f.ContainerDiffFn == nil {
+		notImplemented("ContainerDiff")
+	}
+	f.record("ContainerDiff")
+	if err := f.inject(ctx, "ContainerDiff"); err != nil {
+		return client.ContainerDiffResult{}, err
+	}
+	return f.ContainerDiffFn(ctx, container, opts)
+}
+
+func (f *FakeAPIClient) ContainerCommit(ctx context.Context, container string, opts client.ContainerCommitOptions) (client.ContainerCommitResult, error) {
+	if f.ContainerCommitFn == nil {
+		notImplemented("ContainerCommit")
+	}
+	f.record("ContainerCommit")
+	if err := f.inject(ctx, "ContainerCommit"); err != nil {
+		return client.ContainerCommitResult{}, err
+	}
+	return f.ContainerCommitFn(ctx, container, opts)
+}
+
+// --- Checkpoint method implementations ---
+
+func (f *FakeAPIClient) CheckpointCreate(ctx context.Context, container string, opts client.CheckpointCreateOptions) (client.CheckpointCreateResult, error) {
+	if f.CheckpointCreateFn == nil {
+		notImplemented("CheckpointCreate")
+	}
+	f.record("CheckpointCreate")
+	if err := f.inject(ctx, "CheckpointCreate"); err != nil {
+		return client.CheckpointCreateResult{}, err
+	}
+	return f.CheckpointCreateFn(ctx, container, opts)
+}
+
+func (f *FakeAPIClient) CheckpointList(ctx context.Context, container string, opts client.CheckpointListOptions) (client.CheckpointListResult, error) {
+	if f.CheckpointListFn == nil {
+		notImplemented("CheckpointList")
+	}
+	f.record("CheckpointList")
+	if err := f.inject(ctx, "CheckpointList"); err != nil {
+		return client.CheckpointListResult{}, err
+	}
+	return f.CheckpointListFn(ctx, container, opts)
+}
+
 // --- Exec method implementations ---
 
 func (f *FakeAPIClient) ExecCreate(ctx context.Context, container string, opts client.ExecCreateOptions) (client.ExecCreateResult, error) {
@@ -269,6 +394,9 @@ func (f *FakeAPIClient) ExecCreate(ctx context.Context, container string, opts c
 		notImplemented("ExecCreate")
 	}
 	f.record("ExecCreate")
+	if err := f.inject(ctx, "ExecCreate"); err != nil {
+		return client.ExecCreateResult{}, err
+	}
 	return f.ExecCreateFn(ctx, container, opts)
 }
 
@@ -277,6 +405,9 @@ func (f *FakeAPIClient) ExecStart(ctx context.Context, execID string, opts clien
 		notImplemented("ExecStart")
 	}
 	f.record("ExecStart")
+	if err := f.inject(ctx, "ExecStart"); err != nil {
+		return client.ExecStartResult{}, err
+	}
 	return f.ExecStartFn(ctx, execID, opts)
 }
 
@@ -285,6 +416,9 @@ func (f *FakeAPIClient) ExecAttach(ctx context.Context, execID string, opts clie
 		notImplemented("ExecAttach")
 	}
 	f.record("ExecAttach")
+	if err := f.inject(ctx, "ExecAttach"); err != nil {
+		return client.ExecAttachResult{}, err
+	}
 	return f.ExecAttachFn(ctx, execID, opts)
 }
 
@@ -293,6 +427,9 @@ func (f *FakeAPIClient) ExecInspect(ctx context.Context, execID string, opts cli
 		notImplemented("ExecInspect")
 	}
 	f.record("ExecInspect")
+	if err := f.inject(ctx, "ExecInspect"); err != nil {
+		return client.ExecInspectResult{}, err
+	}
 	return f.ExecInspectFn(ctx, execID, opts)
 }
 
@@ -303,6 +440,9 @@ func (f *FakeAPIClient) CopyToContainer(ctx context.Context, container string, o
 		notImplemented("CopyToContainer")
 	}
 	f.record("CopyToContainer")
+	if err := f.inject(ctx, "CopyToContainer"); err != nil {
+		return client.CopyToContainerResult{}, err
+	}
 	return f.CopyToContainerFn(ctx, container, opts)
 }
 
@@ -311,6 +451,9 @@ func (f *FakeAPIClient) CopyFromContainer(ctx context.Context, container string,
 		notImplemented("CopyFromContainer")
 	}
 	f.record("CopyFromContainer")
+	if err := f.inject(ctx, "CopyFromContainer"); err != nil {
+		return client.CopyFromContainerResult{}, err
+	}
 	return f.CopyFromContainerFn(ctx, container, opts)
 }
 
@@ -321,6 +464,9 @@ func (f *FakeAPIClient) VolumeCreate(ctx context.Context, opts client.VolumeCrea
 		notImplemented("VolumeCreate")
 	}
 	f.record("VolumeCreate")
+	if err := f.inject(ctx, "VolumeCreate"); err != nil {
+		return client.VolumeCreateResult{}, err
+	}
 	return f.VolumeCreateFn(ctx, opts)
 }
 
@@ -329,6 +475,9 @@ func (f *FakeAPIClient) VolumeRemove(ctx context.Context, volumeID string, opts
 		notImplemented("VolumeRemove")
 	}
 	f.record("VolumeRemove")
+	if err := f.inject(ctx, "VolumeRemove"); err != nil {
+		return client.VolumeRemoveResult{}, err
+	}
 	return f.VolumeRemoveFn(ctx, volumeID, opts)
 }
 
@@ -337,6 +486,9 @@ func (f *FakeAPIClient) VolumeInspect(ctx context.Context, volumeID string, opts
 		notImplemented("VolumeInspect")
 	}
 	f.record("VolumeInspect")
+	if err := f.inject(ctx, "VolumeInspect"); err != nil {
+		return client.VolumeInspectResult{}, err
+	}
 	return f.VolumeInspectFn(ctx, volumeID, opts)
 }
 
@@ -345,6 +497,9 @@ func (f *FakeAPIClient) VolumeList(ctx context.Context, opts client.VolumeListOp
 		notImplemented("VolumeList")
 	}
 	f.record("VolumeList")
+	if err := f.inject(ctx, "VolumeList"); err != nil {
+		return client.VolumeListResult{}, err
+	}
 	return f.VolumeListFn(ctx, opts)
 }
 
@@ -353,6 +508,9 @@ func (f *FakeAPIClient) VolumePrune(ctx context.Context, opts client.VolumePrune
 		notImplemented("VolumePrune")
 	}
 	f.record("VolumePrune")
+	if err := f.inject(ctx, "VolumePrune"); err != nil {
+		return client.VolumePruneResult{}, err
+	}
 	return f.VolumePruneFn(ctx, opts)
 }
 
@@ -363,6 +521,9 @@ func (f *FakeAPIClient) NetworkCreate(ctx context.Context, name string, opts cli
 		notImplemented("NetworkCreate")
 	}
 	f.record("NetworkCreate")
+	if err := f.inject(ctx, "NetworkCreate"); err != nil {
+		return client.NetworkCreateResult{}, err
+	}
 	return f.NetworkCreateFn(ctx, name, opts)
 }
 
@@ -371,6 +532,9 @@ func (f *FakeAPIClient) NetworkRemove(ctx context.Context, network string, opts
 		notImplemented("NetworkRemove")
 	}
 	f.record("NetworkRemove")
+	if err := f.inject(ctx, "NetworkRemove"); err != nil {
+		return client.NetworkRemoveResult{}, err
+	}
 	return f.NetworkRemoveFn(ctx, network, opts)
 }
 
@@ -379,6 +543,9 @@ func (f *FakeAPIClient) NetworkInspect(ctx context.Context, network string, opts
 		notImplemented("NetworkInspect")
 	}
 	f.record("NetworkInspect")
+	if err := f.inject(ctx, "NetworkInspect"); err != nil {
+		return client.NetworkInspectResult{}, err
+	}
 	return f.NetworkInspectFn(ctx, network, opts)
 }
 
@@ -387,6 +554,9 @@ func (f *FakeAPIClient) NetworkList(ctx context.Context, opts client.NetworkList
 		notImplemented("NetworkList")
 	}
 	f.record("NetworkList")
+	if err := f.inject(ctx, "NetworkList"); err != nil {
+		return client.NetworkListResult{}, err
+	}
 	return f.NetworkListFn(ctx, opts)
 }
 
@@ -395,6 +565,9 @@ func (f *FakeAPIClient) NetworkPrune(ctx context.Context, opts client.NetworkPru
 		notImplemented("NetworkPrune")
 	}
 	f.record("NetworkPrune")
+	if err := f.inject(ctx, "NetworkPrune"); err != nil {
+		return client.NetworkPruneResult{}, err
+	}
 	return f.NetworkPruneFn(ctx, opts)
 }
 
@@ -403,6 +576,9 @@ func (f *FakeAPIClient) NetworkConnect(ctx context.Context, network string, opts
 		notImplemented("NetworkConnect")
 	}
 	f.record("NetworkConnect")
+	if err := f.inject(ctx, "NetworkConnect"); err != nil {
+		return client.NetworkConnectResult{}, err
+	}
 	return f.NetworkConnectFn(ctx, network, opts)
 }
 
@@ -411,6 +587,9 @@ func (f *FakeAPIClient) NetworkDisconnect(ctx context.Context, network string, o
 		notImplemented("NetworkDisconnect")
 	}
 	f.record("NetworkDisconnect")
+	if err := f.inject(ctx, "NetworkDisconnect"); err != nil {
+		return client.NetworkDisconnectResult{}, err
+	}
 	return f.NetworkDisconnectFn(ctx, network, opts)
 }
 
@@ -421,6 +600,9 @@ func (f *FakeAPIClient) ImageBuild(ctx context.Context, buildContext io.Reader,
 		notImplemented("ImageBuild")
 	}
 	f.record("ImageBuild")
+	if err := f.inject(ctx, "ImageBuild"); err != nil {
+		return client.ImageBuildResult{}, err
+	}
 	return f.ImageBuildFn(ctx, buildContext, opts)
 }
 
@@ -429,6 +611,9 @@ func (f *FakeAPIClient) ImageRemove(ctx context.Context, image string, opts clie
 		notImplemented("ImageRemove")
 	}
 	f.record("ImageRemove")
+	if err := f.inject(ctx, "ImageRemove"); err != nil {
+		return client.ImageRemoveResult{}, err
+	}
 	return f.ImageRemoveFn(ctx, image, opts)
 }
 
@@ -437,6 +622,9 @@ func (f *FakeAPIClient) ImageList(ctx context.Context, opts client.ImageListOpti
 		notImplemented("ImageList")
 	}
 	f.record("ImageList")
+	if err := f.inject(ctx, "ImageList"); err != nil {
+		return client.ImageListResult{}, err
+	}
 	return f.ImageListFn(ctx, opts)
 }
 
@@ -445,14 +633,42 @@ func (f *FakeAPIClient) ImageInspect(ctx context.Context, image string, opts ...
 		notImplemented("ImageInspect")
 	}
 	f.record("ImageInspect")
+	if err := f.inject(ctx, "ImageInspect"); err != nil {
+		return client.ImageInspectResult{}, err
+	}
 	return f.ImageInspectFn(ctx, image, opts...)
 }
 
+func (f *FakeAPIClient) DistributionInspect(ctx context.Context, ref string, opts client.DistributionInspectOptions) (client.DistributionInspectResult, error) {
+	if f.DistributionInspectFn == nil {
+		notImplemented("DistributionInspect")
+	}
+	f.record("DistributionInspect")
+	if err := f.inject(ctx, "DistributionInspect"); err != nil {
+		return client.DistributionInspectResult{}, err
+	}
+	return f.DistributionInspectFn(ctx, ref, opts)
+}
+
+func (f *FakeAPIClient) ImageHistory(ctx context.Context, image string, opts ...client.ImageHistoryOption) (client.ImageHistoryResult, error) {
+	if f.ImageHistoryFn == nil {
+		notImplemented("ImageHistory")
+	}
+	f.record("ImageHistory")
+	if err := f.inject(ctx, "ImageHistory"); err != nil {
+		return client.ImageHistoryResult{}, err
+	}
+	return f.ImageHistoryFn(ctx, image, opts...)
+}
+
 func (f *FakeAPIClient) ImagePrune(ctx context.Context, opts client.ImagePruneOptions) (client.ImagePruneResult, error) {
 	if f.ImagePruneFn == nil {
 		notImplemented("ImagePrune")
 	}
 	f.record("ImagePrune")
+	if err := f.inject(ctx, "ImagePrune"); err != nil {
+		return client.ImagePruneResult{}, err
+	}
 	return f.ImagePruneFn(ctx, opts)
 }
 
@@ -461,9 +677,34 @@ func (f *FakeAPIClient) ImageTag(ctx context.Context, opts client.ImageTagOption
 		notImplemented("ImageTag")
 	}
 	f.record("ImageTag")
+	if err := f.inject(ctx, "ImageTag"); err != nil {
+		return client.ImageTagResult{}, err
+	}
 	return f.ImageTagFn(ctx, opts)
 }
 
+func (f *FakeAPIClient) ImagePush(ctx context.Context, image string, opts client.ImagePushOptions) (client.ImagePushResponse, error) {
+	if f.ImagePushFn == nil {
+		notImplemented("ImagePush")
+	}
+	f.record("ImagePush")
+	if err := f.inject(ctx, "ImagePush"); err != nil {
+		return nil, err
+	}
+	return f.ImagePushFn(ctx, image, opts)
+}
+
+func (f *FakeAPIClient) ImagePull(ctx context.Context, ref string, opts client.ImagePullOptions) (client.ImagePullResponse, error) {
+	if f.ImagePullFn == nil {
+		notImplemented("ImagePull")
+	}
+	f.record("ImagePull")
+	if err := f.inject(ctx, "ImagePull"); err != nil {
+		return nil, err
+	}
+	return f.ImagePullFn(ctx, ref, opts)
+}
+
 // --- System method implementations ---
 
 func (f *FakeAPIClient) Ping(ctx context.Context, options client.PingOptions) (client.PingResult, error) {
@@ -471,6 +712,9 @@ func (f *FakeAPIClient) Ping(ctx context.Context, options client.PingOptions) (c
 		notImplemented("Ping")
 	}
 	f.record("Ping")
+	if err := f.inject(ctx, "Ping"); err != nil {
+		return client.PingResult{}, err
+	}
 	return f.PingFn(ctx, options)
 }
 
@@ -479,9 +723,36 @@ func (f *FakeAPIClient) Info(ctx context.Context, options client.InfoOptions) (c
 		notImplemented("Info")
 	}
 	f.record("Info")
+	if err := f.inject(ctx, "Info"); err != nil {
+		return client.SystemInfoResult{}, err
+	}
 	return f.InfoFn(ctx, options)
 }
 
+func (f *FakeAPIClient) DiskUsage(ctx context.Context, options client.DiskUsageOptions) (client.DiskUsageResult, error) {
+	if f.DiskUsageFn == nil {
+		notImplemented("DiskUsage")
+	}
+	f.record("DiskUsage")
+	if err := f.inject(ctx, "DiskUsage"); err != nil {
+		return client.DiskUsageResult{}, err
+	}
+	return f.DiskUsageFn(ctx, options)
+}
+
+func (f *FakeAPIClient) Events(ctx context.Context, options client.EventsListOptions) client.EventsResult {
+	if f.EventsFn == nil {
+		notImplemented("Events")
+	}
+	f.record("Events")
+	if err := f.inject(ctx, "Events"); err != nil {
+		errCh := make(chan error, 1)
+		errCh <- err
+		return client.EventsResult{Err: errCh}
+	}
+	return f.EventsFn(ctx, options)
+}
+
 // Close implements the APIClient Close method.
 // Defaults to a no-op if CloseFn is not set, since the embedded nil *client.Client
 // would panic on Close and most tests don't care about Close behavior.
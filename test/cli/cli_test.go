@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"os"
+	"testing"
+
+	"github.com/schmitthub/clawker/test/cli/golden"
+)
+
+// TestMain removes the binary golden.Binary built for this process.
+func TestMain(m *testing.M) {
+	code := m.Run()
+	golden.RemoveBinary()
+	os.Exit(code)
+}
+
+func TestVersion(t *testing.T) {
+	s := golden.New(t)
+	s.Assert("version", s.Run("version"))
+}
+
+func TestUnknownCommand(t *testing.T) {
+	s := golden.New(t)
+	s.Assert("unknown-command", s.Run("bogus"))
+}
+
+func TestUnsupportedJSONFlag(t *testing.T) {
+	s := golden.New(t)
+	s.Assert("unsupported-json", s.Run("version", "--json"))
+}
+
+func TestProjectInit(t *testing.T) {
+	s := golden.New(t)
+	s.Assert("project-init",
+		s.Run("project", "init", "--yes"),
+		s.Run("project", "list"),
+		s.Run("project", "list", "--json"),
+	)
+	golden.AssertString(t, "project-init.clawker.yaml", s.ReadFile(".clawker.yaml"))
+}
+
+func TestDockerUnavailable(t *testing.T) {
+	s := golden.New(t)
+	s.Assert("docker-unavailable", s.Run("container", "ls"))
+}
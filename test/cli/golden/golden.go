@@ -0,0 +1,293 @@
+// Package golden runs the built clawker binary against a scratch project and
+// compares its user-facing output to golden files.
+//
+// Every run gets its own HOME, XDG directories and project directory, an
+// unreachable DOCKER_HOST, and no color, so output depends only on the
+// arguments and the scratch files. Dynamic fields (scratch paths, container
+// and image IDs, commit SHAs, timestamps, durations) are normalized before
+// comparison. Regenerate the golden files with:
+//
+//	go test ./test/cli/... -update
+package golden
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// Version and Revision are stamped into the test binary so version strings
+// and schema URLs are stable across commits.
+const (
+	Version  = "0.0.0-golden"
+	Revision = "golden"
+)
+
+// runTimeout bounds one CLI invocation; a command that waits on a daemon
+// the scratch environment does not have fails instead of hanging the suite.
+const runTimeout = 30 * time.Second
+
+var (
+	binaryOnce sync.Once
+	binaryPath string
+	binaryErr  error
+)
+
+// Binary builds the clawker binary once per test process into a temp
+// directory and returns its path. Remove the directory with RemoveBinary
+// from TestMain.
+func Binary(t *testing.T) string {
+	t.Helper()
+	binaryOnce.Do(func() {
+		_, thisFile, _, _ := runtime.Caller(0)
+		repoRoot := filepath.Join(filepath.Dir(thisFile), "..", "..", "..")
+
+		dir, err := os.MkdirTemp("", "clawker-golden-")
+		if err != nil {
+			binaryErr = err
+			return
+		}
+		binaryPath = filepath.Join(dir, "clawker")
+		ldflags := "-X 'github.com/schmitthub/clawker/internal/build.Version=" + Version + "'" +
+			" -X 'github.com/schmitthub/clawker/internal/build.Revision=" + Revision + "'"
+		cmd := exec.CommandContext(context.Background(), "go", "build", "-ldflags", ldflags, "-o", binaryPath, "./cmd/clawker")
+		cmd.Dir = repoRoot
+		if out, err := cmd.CombinedOutput(); err != nil {
+			binaryErr = fmt.Errorf("%s (%w)", out, err)
+		}
+	})
+	if binaryErr != nil {
+		t.Fatalf("golden: building clawker binary: %v", binaryErr)
+	}
+	return binaryPath
+}
+
+// RemoveBinary deletes the binary Binary built, if any.
+func RemoveBinary() {
+	if binaryPath != "" {
+		_ = os.RemoveAll(filepath.Dir(binaryPath))
+	}
+}
+
+// Scratch is an isolated environment for running the binary.
+type Scratch struct {
+	t *testing.T
+	// Base holds everything the scratch run can write.
+	Base string
+	// Home is $HOME; the XDG directories live under it.
+	Home string
+	// ProjectDir is the working directory of every run.
+	ProjectDir string
+
+	binary string
+	env    []string
+}
+
+// Result is the outcome of one run.
+type Result struct {
+	Args     []string
+	ExitCode int
+	Stdout   string
+	Stderr   string
+}
+
+// New builds the binary (once) and creates a scratch environment with an
+// empty project directory named "project".
+func New(t *testing.T) *Scratch {
+	t.Helper()
+	bin := Binary(t)
+
+	// Resolve symlinks (macOS /var → /private/var) so the paths the binary
+	// prints match the ones normalized away.
+	base, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("golden: resolving temp dir: %v", err)
+	}
+	s := &Scratch{
+		t:          t,
+		Base:       base,
+		Home:       filepath.Join(base, "home"),
+		ProjectDir: filepath.Join(base, "project"),
+		binary:     bin,
+	}
+	for _, dir := range []string{s.Home, s.ProjectDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("golden: creating %s: %v", dir, err)
+		}
+	}
+	s.env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + s.Home,
+		"XDG_CONFIG_HOME=" + filepath.Join(s.Home, ".config"),
+		"XDG_DATA_HOME=" + filepath.Join(s.Home, ".local", "share"),
+		"XDG_STATE_HOME=" + filepath.Join(s.Home, ".local", "state"),
+		"XDG_CACHE_HOME=" + filepath.Join(s.Home, ".cache"),
+		"DOCKER_HOST=unix://" + filepath.Join(base, "docker.sock"),
+		"NO_COLOR=1",
+		"TERM=dumb",
+		consts.EnvNoNotifier + "=1",
+	}
+	return s
+}
+
+// Setenv adds or replaces an environment variable for later runs.
+func (s *Scratch) Setenv(key, value string) {
+	prefix := key + "="
+	for i, kv := range s.env {
+		if strings.HasPrefix(kv, prefix) {
+			s.env[i] = prefix + value
+			return
+		}
+	}
+	s.env = append(s.env, prefix+value)
+}
+
+// WriteFile writes a file relative to the project directory.
+func (s *Scratch) WriteFile(name, content string) {
+	s.t.Helper()
+	path := filepath.Join(s.ProjectDir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		s.t.Fatalf("golden: creating dir for %s: %v", name, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		s.t.Fatalf("golden: writing %s: %v", name, err)
+	}
+}
+
+// ReadFile reads a file relative to the project directory.
+func (s *Scratch) ReadFile(name string) string {
+	s.t.Helper()
+	data, err := os.ReadFile(filepath.Join(s.ProjectDir, name))
+	if err != nil {
+		s.t.Fatalf("golden: reading %s: %v", name, err)
+	}
+	return string(data)
+}
+
+// Run executes the binary in the project directory with stdin closed. A
+// non-zero exit is a Result, not a test failure; failing to start the
+// binary or hitting runTimeout is.
+func (s *Scratch) Run(args ...string) *Result {
+	s.t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.binary, args...)
+	cmd.Dir = s.ProjectDir
+	cmd.Env = s.env
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		s.t.Fatalf("golden: clawker %s: no exit within %s\nstdout:\n%s\nstderr:\n%s",
+			strings.Join(args, " "), runTimeout, stdout.String(), stderr.String())
+	}
+
+	res := &Result{Args: args, Stdout: stdout.String(), Stderr: stderr.String()}
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		s.t.Fatalf("golden: running clawker %s: %v", strings.Join(args, " "), err)
+	}
+	return res
+}
+
+// Dynamic fields, most specific first, then column padding. Scratch paths
+// are replaced before these run.
+var normalizers = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), "<TIME>"},
+	{regexp.MustCompile(`\b[0-9a-f]{64}\b`), "<ID>"},
+	{regexp.MustCompile(`\b[0-9a-f]{40}\b`), "<SHA>"},
+	{regexp.MustCompile(`\b[0-9a-f]{12}\b`), "<ID>"},
+	{regexp.MustCompile(`\b\d+(\.\d+)?(ns|µs|ms|s)\b`), "<DURATION>"},
+	// Column padding depends on the widest cell, which for path columns
+	// depends on the temp dir; keep the column break, drop the width.
+	{regexp.MustCompile(`(\S) {2,}`), "$1  "},
+}
+
+// Normalize replaces the scratch paths and dynamic fields in out.
+func (s *Scratch) Normalize(out string) string {
+	out = strings.ReplaceAll(out, s.ProjectDir, "$PROJECT")
+	out = strings.ReplaceAll(out, s.Home, "$HOME")
+	out = strings.ReplaceAll(out, s.Base, "$SCRATCH")
+	for _, n := range normalizers {
+		out = n.re.ReplaceAllString(out, n.repl)
+	}
+	return out
+}
+
+// Format renders results as the golden file body: per run, the command,
+// exit code, and normalized stdout and stderr.
+func (s *Scratch) Format(results ...*Result) string {
+	var b strings.Builder
+	for i, r := range results {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "$ clawker %s\n", strings.Join(r.Args, " "))
+		fmt.Fprintf(&b, "-- exit: %d --\n", r.ExitCode)
+		b.WriteString("-- stdout --\n")
+		b.WriteString(ensureNewline(s.Normalize(r.Stdout)))
+		b.WriteString("-- stderr --\n")
+		b.WriteString(ensureNewline(s.Normalize(r.Stderr)))
+	}
+	return b.String()
+}
+
+// Assert compares the formatted results with testdata/<name>.golden,
+// rewriting the file instead when -update is set.
+func (s *Scratch) Assert(name string, results ...*Result) {
+	s.t.Helper()
+	AssertString(s.t, name, s.Format(results...))
+}
+
+// AssertString compares got with testdata/<name>.golden, rewriting the file
+// instead when -update is set.
+func AssertString(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("golden: creating testdata: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("golden: writing %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden: reading %s: %v (run with -update to create it)", path, err)
+	}
+	if string(want) != got {
+		t.Errorf("golden: output differs from %s (run with -update to accept)\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+func ensureNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
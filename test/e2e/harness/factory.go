@@ -24,6 +24,7 @@ import (
 	"github.com/schmitthub/clawker/internal/cmdutil"
 	"github.com/schmitthub/clawker/internal/config"
 	configmocks "github.com/schmitthub/clawker/internal/config/mocks"
+	"github.com/schmitthub/clawker/internal/consts"
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/docker/mocks"
 	"github.com/schmitthub/clawker/internal/git"
@@ -36,6 +37,7 @@ import (
 	"github.com/schmitthub/clawker/internal/socketbridge"
 	"github.com/schmitthub/clawker/internal/state"
 	"github.com/schmitthub/clawker/internal/tui"
+	"github.com/schmitthub/clawker/test/reaper"
 )
 
 // harnessAdminKeepalive mirrors the production adminClientKeepalive in
@@ -169,8 +171,9 @@ func NewFactory(t *testing.T, opts *FactoryOptions) (*cmdutil.Factory, *bytes.Bu
 					clientErr = cErr
 					return
 				}
-				client, clientErr = opts.Client(ctx, c, logger.Nop(),
-					docker.WithLabels(docker.TestLabelConfig(c, t.Name())))
+				labels := docker.TestLabelConfig(c, t.Name())
+				labels.Default[consts.LabelTestRun] = reaper.RunID()
+				client, clientErr = opts.Client(ctx, c, logger.Nop(), docker.WithLabels(labels))
 			} else {
 				c, _ := resolveConfig()
 				fake := mocks.NewFakeClient(c)
@@ -22,6 +22,7 @@ import (
 	"github.com/schmitthub/clawker/internal/docker"
 	"github.com/schmitthub/clawker/internal/logger"
 	"github.com/schmitthub/clawker/internal/testenv"
+	"github.com/schmitthub/clawker/test/reaper"
 )
 
 // EnsureNoControlPlane stops and removes any pre-existing CP container so the
@@ -210,13 +211,13 @@ func cleanupTestEnvironment(t *testing.T, h *Harness) {
 	cpLabel := fmt.Sprintf("%s=%s", cfg.LabelPurpose(), consts.PurposeControlPlane)
 	testLabel := fmt.Sprintf("%s.test.name=%s", cfg.LabelDomain(), t.Name())
 
-	if ids, err := dockerListByLabel(ctx, "container", firewallLabel); err == nil {
+	if ids, err := reaper.ListByLabels(ctx, "container", firewallLabel); err == nil {
 		report.FirewallContainers = len(ids)
 	}
-	if ids, err := dockerListByLabel(ctx, "container", cpLabel); err == nil {
+	if ids, err := reaper.ListByLabels(ctx, "container", cpLabel); err == nil {
 		report.CPContainers = len(ids)
 	}
-	if ids, err := dockerListByLabel(ctx, "container", testLabel); err == nil {
+	if ids, err := reaper.ListByLabels(ctx, "container", testLabel); err == nil {
 		report.AgentContainers = len(ids)
 	}
 	h.Cleanup = report
@@ -226,7 +227,7 @@ func cleanupTestEnvironment(t *testing.T, h *Harness) {
 	h.Run("host-proxy", "stop")
 
 	// 2. Remove shared firewall infrastructure containers (not test-labeled).
-	if ids, err := dockerListByLabel(ctx, "container", firewallLabel); err != nil {
+	if ids, err := reaper.ListByLabels(ctx, "container", firewallLabel); err != nil {
 		t.Logf("cleanup: docker ps firewall label=%s: %v (firewall containers may leak)", firewallLabel, err)
 	} else if len(ids) > 0 {
 		//nolint:gosec // label is derived from config accessors, not user input
@@ -237,7 +238,7 @@ func cleanupTestEnvironment(t *testing.T, h *Harness) {
 	}
 
 	// 3. Remove control plane container if left running (not test-labeled).
-	if ids, err := dockerListByLabel(ctx, "container", cpLabel); err != nil {
+	if ids, err := reaper.ListByLabels(ctx, "container", cpLabel); err != nil {
 		t.Logf("cleanup: docker ps control plane label=%s: %v (control plane containers may leak)", cpLabel, err)
 	} else if len(ids) > 0 {
 		//nolint:gosec // label is derived from config accessors, not user input
@@ -247,38 +248,10 @@ func cleanupTestEnvironment(t *testing.T, h *Harness) {
 		}
 	}
 
-	// 4. Remove test-labeled containers, volumes, networks.
-	label := testLabel
-
-	if ids, err := dockerListByLabel(ctx, "container", label); err != nil {
-		t.Logf("cleanup: docker ps label=%s: %v (containers may leak)", label, err)
-	} else if len(ids) > 0 {
-		//nolint:gosec // label is derived from t.Name(), not user input
-		args := append([]string{"rm", "-f"}, ids...)
-		if out, err := exec.CommandContext(ctx, "docker", args...).CombinedOutput(); err != nil {
-			t.Logf("cleanup: docker rm: %s (%v)", strings.TrimSpace(string(out)), err)
-		}
-	}
-
-	if ids, err := dockerListByLabel(ctx, "volume", label); err != nil {
-		t.Logf("cleanup: docker volume ls label=%s: %v (volumes may leak)", label, err)
-	} else if len(ids) > 0 {
-		//nolint:gosec // label is derived from t.Name(), not user input
-		args := append([]string{"volume", "rm", "-f"}, ids...)
-		if out, err := exec.CommandContext(ctx, "docker", args...).CombinedOutput(); err != nil {
-			t.Logf("cleanup: docker volume rm: %s (%v)", strings.TrimSpace(string(out)), err)
-		}
-	}
-
-	if ids, err := dockerListByLabel(ctx, "network", label); err != nil {
-		t.Logf("cleanup: docker network ls label=%s: %v (networks may leak)", label, err)
-	} else if len(ids) > 0 {
-		for _, id := range ids {
-			//nolint:gosec // id comes from docker ls output
-			if out, err := exec.CommandContext(ctx, "docker", "network", "rm", id).CombinedOutput(); err != nil {
-				t.Logf("cleanup: docker network rm %s: %s (%v)", id, strings.TrimSpace(string(out)), err)
-			}
-		}
+	// 4. Remove this test's containers, volumes, networks. Matching the run
+	// label too keeps a concurrent run of the same test untouched.
+	if _, err := reaper.ReapLabels(ctx, testLabel, reaper.RunLabel()); err != nil {
+		t.Logf("cleanup: %v (test resources may leak)", err)
 	}
 }
 
@@ -300,43 +273,6 @@ func resolveCleanupConfig(t *testing.T, h *Harness) config.Config {
 	return configmocks.NewBlankConfig()
 }
 
-// dockerListByLabel returns IDs of Docker resources matching a label filter.
-// Returns an error when `docker` fails to execute so callers can surface the
-// underlying reason (daemon down, permission denied) instead of silently
-// assuming "nothing to clean", which would leak resources into the next test.
-func dockerListByLabel(ctx context.Context, resourceType, label string) ([]string, error) {
-	var cmd *exec.Cmd
-	switch resourceType {
-	case "container":
-		cmd = exec.CommandContext(ctx, "docker", "ps", "-aq", "--filter", "label="+label)
-	case "volume":
-		cmd = exec.CommandContext(ctx, "docker", "volume", "ls", "-q", "--filter", "label="+label)
-	case "network":
-		cmd = exec.CommandContext(ctx, "docker", "network", "ls", "-q", "--filter", "label="+label)
-	default:
-		return nil, fmt.Errorf("dockerListByLabel: unsupported resource type %q", resourceType)
-	}
-
-	out, err := cmd.Output()
-	if err != nil {
-		// Include stderr from docker on ExitError so the failure mode is
-		// visible in test logs (e.g. "Cannot connect to the Docker daemon").
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
-			return nil, fmt.Errorf("docker %s: %w: %s", resourceType, err, strings.TrimSpace(string(exitErr.Stderr)))
-		}
-		return nil, fmt.Errorf("docker %s: %w", resourceType, err)
-	}
-	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
-	var ids []string
-	for _, line := range lines {
-		if id := strings.TrimSpace(line); id != "" {
-			ids = append(ids, id)
-		}
-	}
-	return ids, nil
-}
-
 // RequireServicesWereRunning fails the test if the cleanup report shows
 // that the specified services were not running. Callers specify which
 // services they expect — tests using fakes don't call this at all.
@@ -0,0 +1,43 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/schmitthub/clawker/test/reaper"
+)
+
+// TestMain reaps this run's resources once the tests finish or the run is
+// interrupted. Per-test t.Cleanup handles the normal path; this catches
+// what an aborted test left behind. Only this run's label is reaped, so
+// concurrent runs are unaffected.
+func TestMain(m *testing.M) {
+	reap := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if report, err := reaper.Reap(ctx); err == nil && report.Total() > 0 {
+			fmt.Fprintf(os.Stderr, "reaper: removed %d leaked resources (%s)\n", report.Total(), reaper.RunLabel())
+		}
+	}
+
+	// Catch SIGINT/SIGTERM so Ctrl+C still cleans up.
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sig
+		reap()
+		os.Exit(1)
+	}()
+
+	code := m.Run()
+
+	signal.Stop(sig)
+	reap()
+
+	os.Exit(code)
+}
@@ -0,0 +1,122 @@
+package e2e
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/schmitthub/clawker/internal/config"
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/internal/docker"
+	"github.com/schmitthub/clawker/internal/project"
+	"github.com/schmitthub/clawker/test/e2e/harness"
+)
+
+// TestExecTTYResize drives `container exec -it` from a real pseudo-terminal
+// and resizes it mid-session: the SIGWINCH must reach the exec's TTY, which
+// the shell inside sees through `stty size`. The in-process harness has no
+// terminal, so the exec runs the built binary as a subprocess on the pty.
+func TestExecTTYResize(t *testing.T) {
+	h := &harness.Harness{
+		T: t,
+		Opts: &harness.FactoryOptions{
+			Config:         config.NewConfig,
+			Client:         docker.NewClient,
+			ProjectManager: project.NewProjectManager,
+		},
+	}
+	h.NewIsolatedFS(nil)
+
+	initRes := h.Run("project", "init", "--yes")
+	require.NoError(t, initRes.Err, "init failed\nstdout: %s\nstderr: %s",
+		initRes.Stdout, initRes.Stderr)
+	buildRes := h.Run("build")
+	require.NoError(t, buildRes.Err, "build failed\nstdout: %s\nstderr: %s",
+		buildRes.Stdout, buildRes.Stderr)
+	runRes := h.Run("container", "run", "--detach", "--agent", "dev", "@")
+	require.NoError(t, runRes.Err, "run failed\nstdout: %s\nstderr: %s",
+		runRes.Stdout, runRes.Stderr)
+
+	master, slave := openPTY(t, 24, 80)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	t.Cleanup(cancel)
+	cmd := exec.CommandContext(ctx, os.Getenv(consts.EnvExecutable),
+		"container", "exec", "-it", "--agent", "dev",
+		"sh", "-c", "while :; do stty size; sleep 0.2; done")
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = slave, slave, slave
+	// Session leader with the pty as its controlling terminal, so the
+	// kernel delivers SIGWINCH to it when the pty is resized.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true, Ctty: 0}
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+	_ = slave.Close()
+
+	sizes := make(chan string, 64)
+	go func() {
+		defer close(sizes)
+		scanner := bufio.NewScanner(master)
+		for scanner.Scan() {
+			sizes <- strings.TrimSpace(scanner.Text())
+		}
+	}()
+
+	waitForSize(t, sizes, "24 80")
+	require.NoError(t, unix.IoctlSetWinsize(int(master.Fd()), unix.TIOCSWINSZ,
+		&unix.Winsize{Row: 50, Col: 132}))
+	waitForSize(t, sizes, "50 132")
+}
+
+// openPTY allocates a pseudo-terminal pair sized rows x cols. Both ends
+// are closed when the test finishes.
+func openPTY(t *testing.T, rows, cols uint16) (master, slave *os.File) {
+	t.Helper()
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	require.NoError(t, err, "opening /dev/ptmx")
+	t.Cleanup(func() { _ = master.Close() })
+
+	fd := int(master.Fd())
+	require.NoError(t, unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0), "unlocking pty")
+	n, err := unix.IoctlGetInt(fd, unix.TIOCGPTN)
+	require.NoError(t, err, "reading pty number")
+	require.NoError(t, unix.IoctlSetWinsize(fd, unix.TIOCSWINSZ,
+		&unix.Winsize{Row: rows, Col: cols}))
+
+	slave, err = os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|unix.O_NOCTTY, 0)
+	require.NoError(t, err, "opening pty slave")
+	t.Cleanup(func() { _ = slave.Close() })
+	return master, slave
+}
+
+// waitForSize reads `stty size` lines until want appears.
+func waitForSize(t *testing.T, sizes <-chan string, want string) {
+	t.Helper()
+	deadline := time.After(30 * time.Second)
+	var seen []string
+	for {
+		select {
+		case got, ok := <-sizes:
+			if !ok {
+				t.Fatalf("exec session ended before stty reported %q; output: %q", want, seen)
+			}
+			if got == want {
+				return
+			}
+			seen = append(seen, got)
+		case <-deadline:
+			t.Fatalf("stty never reported %q; output: %q", want, seen)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// reaper removes Docker resources left behind by clawker test runs. CI
+// cleanup jobs run it after the test step, whatever its outcome:
+//
+//	CLAWKER_TEST_RUN=$GITHUB_RUN_ID go test ./test/e2e/...
+//	CLAWKER_TEST_RUN=$GITHUB_RUN_ID go run ./test/reaper/cmd/reaper
+//
+// --all reaps every test-labeled resource regardless of run; use it only
+// where no other run can be in flight.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/schmitthub/clawker/internal/consts"
+	"github.com/schmitthub/clawker/test/reaper"
+)
+
+func main() {
+	if err := run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := pflag.NewFlagSet("reaper", pflag.ContinueOnError)
+
+	var (
+		flagRun     string
+		flagAll     bool
+		flagTimeout time.Duration
+	)
+
+	flags.StringVar(&flagRun, "run", os.Getenv(reaper.RunIDEnv), "Test run ID to reap (default $"+reaper.RunIDEnv+")")
+	flags.BoolVar(&flagAll, "all", false, "Reap every test-labeled resource, from any run")
+	flags.DurationVar(&flagTimeout, "timeout", time.Minute, "Give up after this long")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s:\n\n%s", filepath.Base(args[0]), flags.FlagUsages())
+	}
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	var label string
+	switch {
+	case flagAll && flagRun != "":
+		return errors.New("--run and --all are mutually exclusive")
+	case flagAll:
+		label = reaper.LabelFilter(consts.LabelTest, consts.ManagedLabelValue)
+	case flagRun != "":
+		label = reaper.LabelFilter(consts.LabelTestRun, flagRun)
+	default:
+		return fmt.Errorf("--run (or $%s) or --all is required", reaper.RunIDEnv)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), flagTimeout)
+	defer cancel()
+
+	report, err := reaper.ReapLabels(ctx, label)
+	fmt.Printf("reaped %d containers, %d volumes, %d networks (%s)\n",
+		report.Containers, report.Volumes, report.Networks, label)
+	return err
+}
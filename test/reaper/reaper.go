@@ -0,0 +1,170 @@
+// Package reaper removes Docker resources left behind by test runs.
+//
+// Every resource the e2e harness creates carries a per-run namespace label,
+// dev.clawker.test.run=<uuid>, alongside the per-test dev.clawker.test.name
+// label. A run that finishes normally removes its resources through
+// t.Cleanup; one that is interrupted (Ctrl+C, a CI timeout, a panicking
+// test binary) leaks them. Reap removes everything carrying the current
+// run's label, and cmd/reaper does the same from outside the test binary
+// for CI cleanup jobs.
+//
+// Reaping is by label only, so concurrent runs never touch each other's
+// resources. The package shells out to the docker CLI, like the harness.
+package reaper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/schmitthub/clawker/internal/consts"
+)
+
+// RunIDEnv overrides the generated run ID. CI sets it before `go test` so a
+// later cleanup job can reap the same run: go run ./test/reaper/cmd/reaper.
+const RunIDEnv = "CLAWKER_TEST_RUN"
+
+var (
+	runIDOnce sync.Once
+	runID     string
+)
+
+// RunID returns this process's test run ID: $CLAWKER_TEST_RUN when set,
+// otherwise a random UUID generated on first use.
+func RunID() string {
+	runIDOnce.Do(func() {
+		runID = os.Getenv(RunIDEnv)
+		if runID == "" {
+			runID = uuid.NewString()
+		}
+	})
+	return runID
+}
+
+// RunLabel returns the label filter matching this run's resources.
+func RunLabel() string {
+	return LabelFilter(consts.LabelTestRun, RunID())
+}
+
+// LabelFilter formats a key=value docker label filter.
+func LabelFilter(key, value string) string {
+	return key + "=" + value
+}
+
+// Report counts the resources a reap removed.
+type Report struct {
+	Containers int
+	Volumes    int
+	Networks   int
+}
+
+// Total returns the number of resources removed.
+func (r Report) Total() int {
+	return r.Containers + r.Volumes + r.Networks
+}
+
+// Reap removes every container, volume, and network carrying this run's
+// label.
+func Reap(ctx context.Context) (Report, error) {
+	return ReapLabels(ctx, RunLabel())
+}
+
+// ReapLabels removes every container, volume, and network matching all of
+// labels (docker ANDs repeated label filters). Containers go first so the
+// volumes and networks they hold are free to remove. Removal keeps going
+// past failures; the returned error joins all of them.
+func ReapLabels(ctx context.Context, labels ...string) (Report, error) {
+	var (
+		report Report
+		errs   []error
+	)
+
+	if ids, err := ListByLabels(ctx, "container", labels...); err != nil {
+		errs = append(errs, err)
+	} else if len(ids) > 0 {
+		if err := docker(ctx, append([]string{"rm", "-f"}, ids...)...); err != nil {
+			errs = append(errs, err)
+		} else {
+			report.Containers = len(ids)
+		}
+	}
+
+	if ids, err := ListByLabels(ctx, "volume", labels...); err != nil {
+		errs = append(errs, err)
+	} else if len(ids) > 0 {
+		if err := docker(ctx, append([]string{"volume", "rm", "-f"}, ids...)...); err != nil {
+			errs = append(errs, err)
+		} else {
+			report.Volumes = len(ids)
+		}
+	}
+
+	// Networks one at a time: one still in use must not block the rest.
+	if ids, err := ListByLabels(ctx, "network", labels...); err != nil {
+		errs = append(errs, err)
+	} else {
+		for _, id := range ids {
+			if err := docker(ctx, "network", "rm", id); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			report.Networks++
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// ListByLabels returns IDs of Docker resources matching all of labels.
+// Returns an error when `docker` fails to execute so callers can surface the
+// underlying reason (daemon down, permission denied) instead of silently
+// assuming "nothing to clean", which would leak resources into the next test.
+func ListByLabels(ctx context.Context, resourceType string, labels ...string) ([]string, error) {
+	var args []string
+	switch resourceType {
+	case "container":
+		args = []string{"ps", "-aq"}
+	case "volume":
+		args = []string{"volume", "ls", "-q"}
+	case "network":
+		args = []string{"network", "ls", "-q"}
+	default:
+		return nil, fmt.Errorf("ListByLabels: unsupported resource type %q", resourceType)
+	}
+	for _, label := range labels {
+		args = append(args, "--filter", "label="+label)
+	}
+
+	//nolint:gosec // args are fixed subcommands plus label filters
+	out, err := exec.CommandContext(ctx, "docker", args...).Output()
+	if err != nil {
+		// Include stderr from docker on ExitError so the failure mode is
+		// visible in test logs (e.g. "Cannot connect to the Docker daemon").
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("docker %s: %w: %s", resourceType, err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("docker %s: %w", resourceType, err)
+	}
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if id := strings.TrimSpace(line); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func docker(ctx context.Context, args ...string) error {
+	//nolint:gosec // args are fixed subcommands plus IDs from docker ls output
+	if out, err := exec.CommandContext(ctx, "docker", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("docker %s: %s (%w)", strings.Join(args[:min(2, len(args))], " "), strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package reaper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunLabel(t *testing.T) {
+	id := RunID()
+	assert.NotEmpty(t, id)
+	assert.Equal(t, id, RunID(), "run ID must be stable for the process")
+	assert.Equal(t, "dev.clawker.test.run="+id, RunLabel())
+}
+
+func TestListByLabels_UnsupportedType(t *testing.T) {
+	_, err := ListByLabels(t.Context(), "image", RunLabel())
+	assert.ErrorContains(t, err, `unsupported resource type "image"`)
+}